@@ -33,7 +33,10 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/apiserver"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/logcontrol"
 	"github.com/hyperledger/firefly/internal/namespace"
+	"github.com/hyperledger/firefly/internal/secrets"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -89,7 +92,10 @@ func resetConfig() {
 
 func reloadConfig() error {
 	resetConfig()
-	return config.ReadConfig(configSuffix, cfgFile)
+	if err := config.ReadConfig(configSuffix, cfgFile); err != nil {
+		return err
+	}
+	return secrets.ResolveConfig(context.Background())
 }
 func getRootManager() namespace.Manager {
 	if _utManager != nil {
@@ -126,6 +132,7 @@ func run() error {
 	}
 
 	config.SetupLogging(rootCtx)
+	logcontrol.Install()
 	log.L(rootCtx).Infof("Hyperledger FireFly")
 	log.L(rootCtx).Infof("© Copyright 2023 Kaleido, Inc.")
 	_, ok := debug.ReadBuildInfo()
@@ -207,9 +214,14 @@ func startFirefly(ctx context.Context, cancelCtx context.CancelFunc, mgr namespa
 		if debugServer != nil {
 			_ = debugServer.Close()
 		}
+		tracing.Shutdown(context.Background())
 		close(ffDone)
 	}()
 
+	if err = tracing.Init(ctx); err != nil {
+		errChan <- err
+		return
+	}
 	if err = mgr.Init(ctx, cancelCtx, resetChan, reloadConfig); err != nil {
 		errChan <- err
 		return
@@ -0,0 +1,21 @@
+BEGIN;
+CREATE TABLE operationfees (
+  seq            SERIAL          PRIMARY KEY,
+  namespace      VARCHAR(64)     NOT NULL,
+  operation_id   UUID            NOT NULL,
+  tx_id          UUID,
+  optype         VARCHAR(64)     NOT NULL,
+  opstatus       VARCHAR(64)     NOT NULL,
+  plugin         VARCHAR(64)     NOT NULL,
+  signing_key    VARCHAR(1024),
+  blockchain_id  VARCHAR(1024),
+  gas_used       VARCHAR(65),
+  gas_price      VARCHAR(65),
+  fee            VARCHAR(65),
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX operationfees_operation ON operationfees(namespace,operation_id);
+CREATE INDEX operationfees_key_created ON operationfees(namespace,signing_key,created);
+
+COMMIT;
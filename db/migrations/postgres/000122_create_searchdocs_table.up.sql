@@ -0,0 +1,14 @@
+BEGIN;
+CREATE TABLE searchdocs (
+  seq            SERIAL          PRIMARY KEY,
+  namespace      VARCHAR(64)     NOT NULL,
+  message_id     UUID            NOT NULL,
+  content        TEXT            NOT NULL,
+  created        BIGINT          NOT NULL,
+  tsv            TSVECTOR        GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED
+);
+
+CREATE UNIQUE INDEX searchdocs_message ON searchdocs(namespace, message_id);
+CREATE INDEX searchdocs_tsv ON searchdocs USING GIN(tsv);
+
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE operations ADD COLUMN progress TEXT;
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+CREATE TABLE batchacks (
+  seq            SERIAL          PRIMARY KEY,
+  namespace      VARCHAR(64)     NOT NULL,
+  batch_id       UUID            NOT NULL,
+  node_id        UUID            NOT NULL,
+  ack_state      VARCHAR(64)     NOT NULL,
+  created        BIGINT          NOT NULL,
+  updated        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX batchacks_batch_node ON batchacks(namespace,batch_id,node_id);
+
+COMMIT;
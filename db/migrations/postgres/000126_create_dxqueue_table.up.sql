@@ -0,0 +1,12 @@
+BEGIN;
+CREATE TABLE dxqueue (
+  seq            SERIAL          PRIMARY KEY,
+  namespace      VARCHAR(64)     NOT NULL,
+  operation_id   UUID            NOT NULL,
+  priority       INTEGER         NOT NULL,
+  created        BIGINT          NOT NULL
+);
+
+CREATE INDEX dxqueue_priority ON dxqueue(namespace,priority,seq);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+CREATE TABLE disclosures (
+  seq                 SERIAL          PRIMARY KEY,
+  id                  UUID            NOT NULL,
+  namespace           VARCHAR(64)     NOT NULL,
+  message_id          UUID            NOT NULL,
+  message_hash        CHAR(64)        NOT NULL,
+  circuit             VARCHAR(1024)   NOT NULL,
+  public_inputs       TEXT,
+  proof               TEXT            NOT NULL,
+  author              VARCHAR(1024),
+  status              VARCHAR(64)     NOT NULL,
+  definition_message  UUID,
+  created             BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX disclosures_id ON disclosures(namespace, id);
+CREATE INDEX disclosures_message ON disclosures(namespace, message_id);
+
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE messages DROP COLUMN trace;
+ALTER TABLE batches DROP COLUMN trace;
+COMMIT;
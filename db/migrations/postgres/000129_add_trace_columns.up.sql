@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE messages ADD COLUMN trace BOOLEAN DEFAULT false;
+ALTER TABLE batches ADD COLUMN trace BOOLEAN DEFAULT false;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE tokenpool ADD COLUMN first_event VARCHAR(64);
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+CREATE TABLE confirmationqueue (
+  seq            SERIAL          PRIMARY KEY,
+  namespace      VARCHAR(64)     NOT NULL,
+  block_number   BIGINT          NOT NULL,
+  block_hash     VARCHAR(128),
+  entry_type     VARCHAR(64)     NOT NULL,
+  payload        TEXT,
+  created        BIGINT          NOT NULL
+);
+
+CREATE INDEX confirmationqueue_seq ON confirmationqueue(namespace,seq);
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+CREATE TABLE blockchainreorgs (
+  seq            SERIAL          PRIMARY KEY,
+  id             UUID            NOT NULL,
+  namespace      VARCHAR(64)     NOT NULL,
+  source         VARCHAR(256)    NOT NULL,
+  block_number   BIGINT          NOT NULL,
+  block_hash     VARCHAR(128),
+  reverted       TEXT,
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX blockchainreorgs_id ON blockchainreorgs(namespace, id);
+
+COMMIT;
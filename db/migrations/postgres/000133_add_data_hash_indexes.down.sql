@@ -0,0 +1,4 @@
+BEGIN;
+DROP INDEX messages_data_hash;
+DROP INDEX data_namespace_blob_hash;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+CREATE INDEX messages_data_hash ON messages_data(namespace, data_hash);
+CREATE INDEX data_namespace_blob_hash ON data(namespace, blob_hash);
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+DROP INDEX groups_display_name;
+ALTER TABLE groups DROP COLUMN display_name;
+ALTER TABLE groups DROP COLUMN description;
+ALTER TABLE groups DROP COLUMN updated;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+ALTER TABLE groups ADD COLUMN display_name VARCHAR(256) DEFAULT '';
+ALTER TABLE groups ADD COLUMN description TEXT DEFAULT '';
+ALTER TABLE groups ADD COLUMN updated BIGINT;
+CREATE INDEX groups_display_name ON groups(namespace_local, display_name);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE messages ADD COLUMN context_qualifier VARCHAR(64) DEFAULT '';
+COMMIT;
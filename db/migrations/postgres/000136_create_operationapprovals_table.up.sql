@@ -0,0 +1,21 @@
+BEGIN;
+CREATE TABLE operationapprovals (
+  seq            SERIAL          PRIMARY KEY,
+  id             UUID            NOT NULL,
+  namespace      VARCHAR(64)     NOT NULL,
+  operation_id   UUID            NOT NULL,
+  tx_id          UUID,
+  optype         VARCHAR(64)     NOT NULL,
+  status         VARCHAR(64)     NOT NULL,
+  required       INTEGER         NOT NULL,
+  approvers      TEXT,
+  created        BIGINT          NOT NULL,
+  updated        BIGINT,
+  expires        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX operationapprovals_id ON operationapprovals(namespace, id);
+CREATE UNIQUE INDEX operationapprovals_operation ON operationapprovals(namespace, operation_id);
+CREATE INDEX operationapprovals_status ON operationapprovals(namespace, status, expires);
+
+COMMIT;
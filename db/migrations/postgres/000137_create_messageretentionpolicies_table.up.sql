@@ -0,0 +1,18 @@
+BEGIN;
+CREATE TABLE messageretentionpolicies (
+  seq            SERIAL          PRIMARY KEY,
+  id             UUID            NOT NULL,
+  namespace      VARCHAR(64)     NOT NULL,
+  name           VARCHAR(64)     NOT NULL,
+  topic          VARCHAR(64),
+  tag            VARCHAR(64),
+  max_age        VARCHAR(64)     NOT NULL,
+  checkpoint     BIGINT          NOT NULL,
+  created        BIGINT          NOT NULL,
+  updated        BIGINT
+);
+
+CREATE UNIQUE INDEX messageretentionpolicies_id ON messageretentionpolicies(namespace, id);
+CREATE UNIQUE INDEX messageretentionpolicies_name ON messageretentionpolicies(namespace, name);
+
+COMMIT;
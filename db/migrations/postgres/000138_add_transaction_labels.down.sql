@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE messages DROP COLUMN tx_labels;
+ALTER TABLE transactions DROP COLUMN labels;
+COMMIT;
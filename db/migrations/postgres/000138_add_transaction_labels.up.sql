@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE transactions ADD COLUMN labels VARCHAR(1024) DEFAULT '';
+ALTER TABLE messages ADD COLUMN tx_labels VARCHAR(1024) DEFAULT '';
+COMMIT;
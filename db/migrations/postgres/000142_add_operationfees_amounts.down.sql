@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE operationfees DROP COLUMN gas_used_amount;
+ALTER TABLE operationfees DROP COLUMN fee_amount;
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+ALTER TABLE operationfees ADD COLUMN gas_used_amount NUMERIC(78);
+ALTER TABLE operationfees ADD COLUMN fee_amount NUMERIC(78);
+
+-- The existing columns hold zero padded hex, which is converted 32 bits at a time
+UPDATE operationfees SET
+  gas_used_amount = (SELECT SUM(('x' || substr(lpad(gas_used, 64, '0'), 57 - 8 * k, 8))::bit(32)::bigint::numeric * power(2::numeric, 32 * k)) FROM generate_series(0, 7) AS k)
+  WHERE gas_used IS NOT NULL AND gas_used NOT LIKE '-%';
+UPDATE operationfees SET
+  fee_amount = (SELECT SUM(('x' || substr(lpad(fee, 64, '0'), 57 - 8 * k, 8))::bit(32)::bigint::numeric * power(2::numeric, 32 * k)) FROM generate_series(0, 7) AS k)
+  WHERE fee IS NOT NULL AND fee NOT LIKE '-%';
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+CREATE TABLE snapshotverifications (
+  seq            SERIAL          PRIMARY KEY,
+  namespace      VARCHAR(64)     NOT NULL,
+  state          TEXT            NOT NULL,
+  updated        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX snapshotverifications_namespace ON snapshotverifications(namespace);
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+CREATE TABLE namespacequotas (
+  seq            SERIAL          PRIMARY KEY,
+  namespace      VARCHAR(64)     NOT NULL,
+  limits         TEXT            NOT NULL,
+  updated        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX namespacequotas_namespace ON namespacequotas(namespace);
+
+COMMIT;
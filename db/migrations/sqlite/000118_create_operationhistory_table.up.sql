@@ -0,0 +1,17 @@
+CREATE TABLE operationhistory (
+  seq            INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id             UUID            NOT NULL,
+  namespace      VARCHAR(64)     NOT NULL,
+  operation_id   UUID            NOT NULL,
+  tx_id          UUID,
+  opstatus       VARCHAR(64)     NOT NULL,
+  error          VARCHAR         NOT NULL,
+  plugin         VARCHAR(64)     NOT NULL,
+  blockchain_id  VARCHAR(1024),
+  receipt        TEXT,
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX operationhistory_id ON operationhistory(id);
+CREATE INDEX operationhistory_operation ON operationhistory(namespace,operation_id);
+CREATE INDEX operationhistory_created ON operationhistory(created);
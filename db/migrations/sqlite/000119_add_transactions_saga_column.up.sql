@@ -0,0 +1 @@
+ALTER TABLE transactions ADD COLUMN saga TEXT;
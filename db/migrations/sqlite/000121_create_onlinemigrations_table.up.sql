@@ -0,0 +1,10 @@
+CREATE TABLE onlinemigrations (
+  name           VARCHAR(256)    NOT NULL,
+  tbl            VARCHAR(64)     NOT NULL,
+  status         VARCHAR(64)     NOT NULL,
+  backfill_seq   BIGINT          NOT NULL,
+  backfill_rows  BIGINT          NOT NULL,
+  updated        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX onlinemigrations_name ON onlinemigrations(name);
@@ -0,0 +1,9 @@
+CREATE TABLE searchdocs (
+  seq            INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace      VARCHAR(64)     NOT NULL,
+  message_id     UUID            NOT NULL,
+  content        TEXT            NOT NULL,
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX searchdocs_message ON searchdocs(namespace, message_id);
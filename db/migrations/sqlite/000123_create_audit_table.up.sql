@@ -0,0 +1,20 @@
+CREATE TABLE auditlog (
+  seq             INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id              UUID            NOT NULL,
+  namespace       VARCHAR(64)     NOT NULL,
+  username        VARCHAR(256),
+  source          VARCHAR(256),
+  method          VARCHAR(16)     NOT NULL,
+  path            TEXT            NOT NULL,
+  route           VARCHAR(64)     NOT NULL,
+  idempotency_key VARCHAR(256),
+  status          INTEGER         NOT NULL,
+  latency         BIGINT          NOT NULL,
+  created         BIGINT          NOT NULL,
+  prev_hash       CHAR(64),
+  hash            CHAR(64)        NOT NULL
+);
+
+CREATE UNIQUE INDEX auditlog_id ON auditlog(id);
+CREATE INDEX auditlog_namespace ON auditlog(namespace, seq);
+
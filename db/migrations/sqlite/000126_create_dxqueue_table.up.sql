@@ -0,0 +1,9 @@
+CREATE TABLE dxqueue (
+  seq            INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace      VARCHAR(64)     NOT NULL,
+  operation_id   UUID            NOT NULL,
+  priority       INTEGER         NOT NULL,
+  created        BIGINT          NOT NULL
+);
+
+CREATE INDEX dxqueue_priority ON dxqueue(namespace,priority,seq);
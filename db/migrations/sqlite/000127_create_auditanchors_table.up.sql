@@ -0,0 +1,16 @@
+CREATE TABLE auditanchors (
+  seq             INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id              UUID            NOT NULL,
+  namespace       VARCHAR(64)     NOT NULL,
+  first_event     BIGINT          NOT NULL,
+  last_event      BIGINT          NOT NULL,
+  leaves          BIGINT          NOT NULL,
+  root            CHAR(64)        NOT NULL,
+  tx_type         VARCHAR(64),
+  tx_id           UUID,
+  created         BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX auditanchors_id ON auditanchors(id);
+CREATE INDEX auditanchors_namespace ON auditanchors(namespace, last_event);
+
@@ -0,0 +1,2 @@
+ALTER TABLE messages DROP COLUMN trace;
+ALTER TABLE batches DROP COLUMN trace;
@@ -0,0 +1,2 @@
+ALTER TABLE messages ADD COLUMN trace BOOLEAN DEFAULT false;
+ALTER TABLE batches ADD COLUMN trace BOOLEAN DEFAULT false;
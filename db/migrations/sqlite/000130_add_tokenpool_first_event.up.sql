@@ -0,0 +1 @@
+ALTER TABLE tokenpool ADD COLUMN first_event VARCHAR(64);
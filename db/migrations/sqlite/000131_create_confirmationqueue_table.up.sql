@@ -0,0 +1,11 @@
+CREATE TABLE confirmationqueue (
+  seq            INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace      VARCHAR(64)     NOT NULL,
+  block_number   BIGINT          NOT NULL,
+  block_hash     VARCHAR(128),
+  entry_type     VARCHAR(64)     NOT NULL,
+  payload        TEXT,
+  created        BIGINT          NOT NULL
+);
+
+CREATE INDEX confirmationqueue_seq ON confirmationqueue(namespace,seq);
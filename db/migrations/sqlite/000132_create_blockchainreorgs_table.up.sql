@@ -0,0 +1,12 @@
+CREATE TABLE blockchainreorgs (
+  seq            INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id             UUID            NOT NULL,
+  namespace      VARCHAR(64)     NOT NULL,
+  source         VARCHAR(256)    NOT NULL,
+  block_number   BIGINT          NOT NULL,
+  block_hash     VARCHAR(128),
+  reverted       TEXT,
+  created        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX blockchainreorgs_id ON blockchainreorgs(namespace, id);
@@ -0,0 +1,2 @@
+DROP INDEX messages_data_hash;
+DROP INDEX data_namespace_blob_hash;
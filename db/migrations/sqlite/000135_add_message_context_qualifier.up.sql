@@ -0,0 +1 @@
+ALTER TABLE messages ADD COLUMN context_qualifier VARCHAR(64) DEFAULT '';
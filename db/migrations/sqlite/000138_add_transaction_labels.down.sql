@@ -0,0 +1,2 @@
+ALTER TABLE messages DROP COLUMN tx_labels;
+ALTER TABLE transactions DROP COLUMN labels;
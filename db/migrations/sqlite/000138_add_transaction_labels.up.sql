@@ -0,0 +1,2 @@
+ALTER TABLE transactions ADD COLUMN labels VARCHAR(1024) DEFAULT '';
+ALTER TABLE messages ADD COLUMN tx_labels VARCHAR(1024) DEFAULT '';
@@ -0,0 +1,4 @@
+DROP INDEX operations_correlation_id;
+DROP INDEX transactions_correlation_id;
+ALTER TABLE operations DROP COLUMN correlation_id;
+ALTER TABLE transactions DROP COLUMN correlation_id;
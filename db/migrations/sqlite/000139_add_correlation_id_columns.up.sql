@@ -0,0 +1,4 @@
+ALTER TABLE transactions ADD COLUMN correlation_id VARCHAR(64) DEFAULT '';
+ALTER TABLE operations ADD COLUMN correlation_id VARCHAR(64) DEFAULT '';
+CREATE INDEX transactions_correlation_id ON transactions(namespace, correlation_id);
+CREATE INDEX operations_correlation_id ON operations(namespace, correlation_id);
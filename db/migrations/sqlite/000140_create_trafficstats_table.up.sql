@@ -0,0 +1,13 @@
+CREATE TABLE trafficstats (
+  seq            INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace      VARCHAR(64)     NOT NULL,
+  bucket         BIGINT          NOT NULL,
+  counterparty   VARCHAR(256)    NOT NULL,
+  direction      VARCHAR(16)     NOT NULL,
+  messages       BIGINT          NOT NULL,
+  message_bytes  BIGINT          NOT NULL,
+  transfers      BIGINT          NOT NULL,
+  updated        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX trafficstats_bucket ON trafficstats(namespace, bucket, counterparty, direction);
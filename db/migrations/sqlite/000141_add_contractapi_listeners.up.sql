@@ -0,0 +1 @@
+ALTER TABLE contractapis ADD COLUMN listeners TEXT;
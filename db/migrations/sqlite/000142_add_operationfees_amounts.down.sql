@@ -0,0 +1,2 @@
+ALTER TABLE operationfees DROP COLUMN gas_used_amount;
+ALTER TABLE operationfees DROP COLUMN fee_amount;
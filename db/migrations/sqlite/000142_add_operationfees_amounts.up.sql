@@ -0,0 +1,4 @@
+-- SQLite has no way to convert the existing hex columns, so fees recorded before this
+-- migration are counted in fee summaries, but not included in their totals
+ALTER TABLE operationfees ADD COLUMN gas_used_amount NUMERIC;
+ALTER TABLE operationfees ADD COLUMN fee_amount NUMERIC;
@@ -0,0 +1,8 @@
+CREATE TABLE snapshotverifications (
+  seq            INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace      VARCHAR(64)     NOT NULL,
+  state          TEXT            NOT NULL,
+  updated        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX snapshotverifications_namespace ON snapshotverifications(namespace);
@@ -0,0 +1,8 @@
+CREATE TABLE namespacequotas (
+  seq            INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace      VARCHAR(64)     NOT NULL,
+  limits         TEXT            NOT NULL,
+  updated        BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX namespacequotas_namespace ON namespacequotas(namespace);
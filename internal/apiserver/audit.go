@@ -0,0 +1,128 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Every API call that can change state is recorded in the audit log of its namespace, once the response
+// has been written - including calls that fail, such as those rejected by authorization.
+
+type auditContextKey struct{}
+
+// auditEntry is filled in by the route handler, once the namespace of the call is known
+type auditEntry struct {
+	or     orchestrator.Orchestrator
+	record core.AuditRecord
+}
+
+// noteAudit records the namespace, route and idempotency key of the call, if it is being audited
+func noteAudit(r *ffapi.APIRequest, route *ffapi.Route, or orchestrator.Orchestrator) {
+	entry, ok := r.Req.Context().Value(auditContextKey{}).(*auditEntry)
+	if !ok || or == nil {
+		return
+	}
+	entry.or = or
+	entry.record.Route = route.Name
+	entry.record.IdempotencyKey = inputIdempotencyKey(r.Input)
+}
+
+func inputIdempotencyKey(input interface{}) core.IdempotencyKey {
+	v := reflect.ValueOf(input)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	if f := v.FieldByName("IdempotencyKey"); f.IsValid() && f.Kind() == reflect.String {
+		return core.IdempotencyKey(f.String())
+	}
+	return ""
+}
+
+// statusResponseWriter captures the status code of the response
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// withAudit records the outcome of calls to routes that can change state, when auditing is enabled
+func withAudit(route *ffapi.Route, handler http.HandlerFunc) http.HandlerFunc {
+	if !config.GetBool(coreconfig.AuditEnabled) || route.Method == http.MethodGet || route.Method == http.MethodHead {
+		return handler
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		entry := &auditEntry{}
+		user, _, _ := req.BasicAuth()
+		entry.record.User = user
+		entry.record.Source = req.RemoteAddr
+		entry.record.Method = req.Method
+		entry.record.Path = req.URL.Path
+
+		sw := &statusResponseWriter{ResponseWriter: w}
+		handler(sw, req.WithContext(context.WithValue(req.Context(), auditContextKey{}, entry)))
+
+		if entry.or == nil {
+			return // not a namespaced call
+		}
+		entry.record.Status = sw.status
+		entry.record.Latency = time.Since(start).Milliseconds()
+		entry.record.Created = fftypes.Now()
+		// The record must be written even if the client has gone away
+		ctx := context.WithoutCancel(req.Context())
+		if err := entry.or.AuditAPIRequest(ctx, &entry.record); err != nil {
+			log.L(ctx).Errorf("Failed to write audit record for %s %s: %s", req.Method, req.URL.Path, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/broadcastmocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditBroadcast(t *testing.T) {
+	config.Set(coreconfig.AuditEnabled, true)
+	defer config.Set(coreconfig.AuditEnabled, false)
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeMessagesPerDay, int64(1)).Return(nil)
+	o.On("MultiParty").Return(&multipartymocks.Manager{})
+	mbm := &broadcastmocks.Manager{}
+	o.On("Broadcast").Return(mbm)
+	mbm.On("BroadcastMessage", mock.Anything, mock.AnythingOfType("*core.MessageInOut"), false).
+		Return(&core.Message{}, nil)
+	o.On("AuditAPIRequest", mock.Anything, mock.MatchedBy(func(record *core.AuditRecord) bool {
+		return record.User == "alice" &&
+			record.Method == "POST" &&
+			record.Path == "/api/v1/namespaces/ns1/messages/broadcast" &&
+			record.Route == "postNewMessageBroadcast" &&
+			record.IdempotencyKey == "key1" &&
+			record.Status == 202 &&
+			record.Created != nil
+	})).Return(nil)
+
+	input := core.MessageInOut{Message: core.Message{IdempotencyKey: "key1"}}
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(&input)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/messages/broadcast", &buf)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.SetBasicAuth("alice", "secret")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 202, res.Result().StatusCode)
+	o.AssertExpectations(t)
+}
+
+func TestAuditAuthorizeFail(t *testing.T) {
+	config.Set(coreconfig.AuditEnabled, true)
+	defer config.Set(coreconfig.AuditEnabled, false)
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	o.On("AuditAPIRequest", mock.Anything, mock.MatchedBy(func(record *core.AuditRecord) bool {
+		return record.Route == "postNewMessageBroadcast" && record.Status == 500
+	})).Return(fmt.Errorf("pop"))
+
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/messages/broadcast", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 500, res.Result().StatusCode)
+	o.AssertExpectations(t)
+}
+
+func TestAuditSkipsReads(t *testing.T) {
+	config.Set(coreconfig.AuditEnabled, true)
+	defer config.Set(coreconfig.AuditEnabled, false)
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetStatus", mock.Anything).Return(&core.NamespaceStatus{}, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/status", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	o.AssertNotCalled(t, "AuditAPIRequest", mock.Anything, mock.Anything)
+}
+
+func TestInputIdempotencyKey(t *testing.T) {
+	assert.Equal(t, core.IdempotencyKey("key1"), inputIdempotencyKey(&core.ContractCallRequest{IdempotencyKey: "key1"}))
+	assert.Equal(t, core.IdempotencyKey(""), inputIdempotencyKey((*core.MessageInOut)(nil)))
+	assert.Equal(t, core.IdempotencyKey(""), inputIdempotencyKey(nil))
+	assert.Equal(t, core.IdempotencyKey(""), inputIdempotencyKey(map[string]interface{}{}))
+	assert.Equal(t, core.IdempotencyKey(""), inputIdempotencyKey(&core.Data{}))
+}
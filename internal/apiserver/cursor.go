@@ -0,0 +1,218 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// List APIs are iterated newest first, by sequence where the collection has one, or otherwise by
+// creation time with the ID breaking ties. A cursor records the position of the last item of a page
+// in that order, so the next page starts strictly after it - regardless of any items inserted in
+// the meantime, and without the database having to count past the skipped rows.
+
+type pageCursor struct {
+	Field string `json:"f"`
+	Value string `json:"v"`
+	ID    string `json:"i,omitempty"`
+}
+
+type cursorOrder struct {
+	field   string
+	idField string // the ID field that breaks ties between items with the same value, if any
+	idJSON  string // the name of the ID field in the JSON of the items
+}
+
+func cursorOrderFor(qf ffapi.QueryFactory) *cursorOrder {
+	fields, ok := qf.(*ffapi.QueryFields)
+	if !ok {
+		return nil
+	}
+	if _, ok := (*fields)["sequence"]; ok {
+		return &cursorOrder{field: "sequence"}
+	}
+	if _, ok := (*fields)["created"]; ok {
+		order := &cursorOrder{field: "created"}
+		if _, ok := (*fields)["id"]; ok {
+			order.idField, order.idJSON = "id", "id"
+		} else if _, ok := (*fields)["localid"]; ok {
+			order.idField, order.idJSON = "localid", "localId"
+		}
+		return order
+	}
+	return nil
+}
+
+func encodeCursor(c *pageCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(ctx context.Context, token string) (*pageCursor, error) {
+	var c pageCursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidCursor)
+	}
+	return &c, nil
+}
+
+// after returns the condition that matches the items after the cursor
+func (o *cursorOrder) after(ctx context.Context, fb ffapi.FilterBuilder, c *pageCursor) (ffapi.Filter, error) {
+	var value interface{}
+	var err error
+	if c.Field != o.field {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidCursor)
+	}
+	if o.field == "sequence" {
+		value, err = strconv.ParseInt(c.Value, 10, 64)
+	} else {
+		value, err = fftypes.ParseTimeString(c.Value)
+	}
+	if err == nil && o.idField != "" {
+		_, err = fftypes.ParseUUID(ctx, c.ID)
+	}
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidCursor)
+	}
+	if o.idField == "" {
+		return fb.Lt(o.field, value), nil
+	}
+	return fb.Or(fb.Lt(o.field, value), fb.And(fb.Eq(o.field, value), fb.Lt(o.idField, c.ID))), nil
+}
+
+func (o *cursorOrder) sort(filter ffapi.Filter) {
+	filter.Sort("-" + o.field)
+	if o.idField != "" {
+		filter.Sort("-" + o.idField)
+	}
+}
+
+// applyCursor orders the filter of a list API in cursor order, and restricts it to the items after the
+// cursor passed by the caller. Cursor pagination is opted into by passing the cursor parameter, which is
+// empty for the first page, so the default order of list APIs is unchanged for callers that do not use it.
+// Streamed exports are always read in cursor order, so the order is returned for them without a cursor,
+// unless the caller chose their own sort order.
+func applyCursor(ctx context.Context, route *ffapi.Route, r *ffapi.APIRequest, stream bool) (*cursorOrder, error) {
+	if route.FilterFactory == nil || r.Filter == nil || r.Req.Method != http.MethodGet {
+		return nil, nil
+	}
+	fi, err := r.Filter.Finalize()
+	if err != nil {
+		return nil, err
+	}
+	order := cursorOrderFor(route.FilterFactory)
+	query := r.Req.URL.Query()
+	if !query.Has("cursor") {
+		if !stream || order == nil || len(fi.Sort) > 0 {
+			return nil, nil
+		}
+		return order, nil
+	}
+
+	if order == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgCursorNotSupported)
+	}
+	if len(fi.Sort) > 0 || fi.Skip > 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgCursorWithSortOrSkip)
+	}
+	if token := query.Get("cursor"); token != "" {
+		c, err := decodeCursor(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		after, err := order.after(ctx, route.FilterFactory.NewFilter(ctx), c)
+		if err != nil {
+			return nil, err
+		}
+		r.Filter.Condition(after)
+	}
+	order.sort(r.Filter)
+	return order, nil
+}
+
+// setNextCursor returns the cursor for the next page in a response header, when the page is full
+func (o *cursorOrder) setNextCursor(r *ffapi.APIRequest, output interface{}) {
+	fi, err := r.Filter.Finalize()
+	if err != nil {
+		return
+	}
+	if withCount, ok := output.(*ffapi.FilterResultsWithCount); ok {
+		output = withCount.Items
+	}
+	items := reflect.ValueOf(output)
+	if items.Kind() != reflect.Slice || items.Len() == 0 || uint64(items.Len()) < fi.Limit {
+		return
+	}
+	if c := o.cursorAfter(items.Index(items.Len() - 1).Interface()); c != nil {
+		r.ResponseHeaders.Set(core.HTTPHeadersNextCursor, encodeCursor(c))
+	}
+}
+
+func (o *cursorOrder) cursorAfter(item interface{}) *pageCursor {
+	// The sequence is not part of the JSON of all types, so it is read from the Go structure if available
+	if v := reflect.Indirect(reflect.ValueOf(item)); o.field == "sequence" && v.Kind() == reflect.Struct {
+		if seq := v.FieldByName("Sequence"); seq.IsValid() && seq.Kind() == reflect.Int64 {
+			return &pageCursor{Field: o.field, Value: strconv.FormatInt(seq.Int(), 10)}
+		}
+	}
+
+	var values map[string]interface{}
+	b, _ := json.Marshal(item)
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil
+	}
+	if header, ok := values["header"].(map[string]interface{}); ok {
+		values = header // messages hold their ID and creation time in the header
+	}
+	c := &pageCursor{Field: o.field, Value: jsonString(values[o.field])}
+	if o.idField != "" {
+		c.ID = jsonString(values[o.idJSON])
+	}
+	if c.Value == "" || (o.idField != "" && c.ID == "") {
+		return nil
+	}
+	return c
+}
+
+func jsonString(v interface{}) string {
+	switch vt := v.(type) {
+	case string:
+		return vt
+	case json.Number:
+		return vt.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func finalizeFilter(filter ffapi.AndFilter) *ffapi.FilterInfo {
+	fi, _ := filter.Finalize()
+	return fi
+}
+
+func TestCursorFirstPageBySequence(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages?limit=2&cursor=", nil)
+	res := httptest.NewRecorder()
+
+	o.On("GetMessages", mock.Anything, mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi := finalizeFilter(filter)
+		return len(fi.Sort) == 1 && fi.Sort[0].Field == "sequence" && fi.Sort[0].Descending
+	})).Return([]*core.Message{{Sequence: 10}, {Sequence: 9}}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, encodeCursor(&pageCursor{Field: "sequence", Value: "9"}), res.Result().Header.Get(core.HTTPHeadersNextCursor))
+}
+
+func TestCursorNotRequested(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages?limit=2", nil)
+	res := httptest.NewRecorder()
+
+	o.On("GetMessages", mock.Anything, mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		return len(finalizeFilter(filter).Sort) == 0
+	})).Return([]*core.Message{{Sequence: 10}, {Sequence: 9}}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Empty(t, res.Result().Header.Get(core.HTTPHeadersNextCursor))
+}
+
+func TestCursorNextPageBySequence(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	cursor := encodeCursor(&pageCursor{Field: "sequence", Value: "9"})
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/events?limit=2&cursor="+cursor, nil)
+	res := httptest.NewRecorder()
+
+	o.On("GetEvents", mock.Anything, mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi := finalizeFilter(filter)
+		return strings.Contains(fi.String(), "sequence < 9") && fi.Sort[0].Field == "sequence" && fi.Skip == 0
+	})).Return([]*core.Event{{Sequence: 8}}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Empty(t, res.Result().Header.Get(core.HTTPHeadersNextCursor))
+}
+
+func TestCursorByCreatedWithCount(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	created := fftypes.Now()
+	lastID := fftypes.NewUUID()
+	cursor := encodeCursor(&pageCursor{Field: "created", Value: created.String(), ID: lastID.String()})
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/transactions?limit=1&count=true&cursor="+cursor, nil)
+	res := httptest.NewRecorder()
+
+	nextID := fftypes.NewUUID()
+	total := int64(5)
+	o.On("GetTransactions", mock.Anything, mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi := finalizeFilter(filter)
+		filterStr := fi.String()
+		return strings.Contains(filterStr, "created <") &&
+			strings.Contains(filterStr, "created ==") &&
+			strings.Contains(filterStr, "id < '"+lastID.String()+"'") &&
+			len(fi.Sort) == 2 && fi.Sort[0].Field == "created" && fi.Sort[1].Field == "id" && fi.Sort[1].Descending
+	})).Return([]*core.Transaction{{ID: nextID, Created: created}}, &ffapi.FilterResult{TotalCount: &total}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, encodeCursor(&pageCursor{Field: "created", Value: created.String(), ID: nextID.String()}), res.Result().Header.Get(core.HTTPHeadersNextCursor))
+}
+
+func TestCursorUserSortNoCursor(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/transactions?limit=1&sort=type", nil)
+	res := httptest.NewRecorder()
+
+	o.On("GetTransactions", mock.Anything, mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi := finalizeFilter(filter)
+		return len(fi.Sort) == 1 && fi.Sort[0].Field == "type"
+	})).Return([]*core.Transaction{{ID: fftypes.NewUUID(), Created: fftypes.Now()}}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Empty(t, res.Result().Header.Get(core.HTTPHeadersNextCursor))
+}
+
+func TestCursorNotSupported(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/tokens/balances?cursor=abc", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Result().StatusCode)
+	assert.Regexp(t, "FF10537", res.Body.String())
+}
+
+func TestCursorWithSortOrSkip(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	cursor := encodeCursor(&pageCursor{Field: "sequence", Value: "9"})
+	for _, qs := range []string{"sort=type", "skip=10"} {
+		req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/events?"+qs+"&cursor="+cursor, nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, 400, res.Result().StatusCode)
+		assert.Regexp(t, "FF10536", res.Body.String())
+	}
+}
+
+func TestCursorInvalid(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	for _, cursor := range []string{
+		"!!!",
+		encodeCursor(&pageCursor{Field: "sequence", Value: "9"}),
+		encodeCursor(&pageCursor{Field: "created", Value: "not a time", ID: fftypes.NewUUID().String()}),
+		encodeCursor(&pageCursor{Field: "created", Value: fftypes.Now().String(), ID: "bad"}),
+	} {
+		req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/transactions?cursor="+cursor, nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, 400, res.Result().StatusCode)
+		assert.Regexp(t, "FF10535", res.Body.String())
+	}
+}
+
+func TestCursorAfterMissingValues(t *testing.T) {
+	order := &cursorOrder{field: "created", idField: "id", idJSON: "id"}
+	assert.Nil(t, order.cursorAfter(&core.Transaction{ID: fftypes.NewUUID()}))
+	assert.Nil(t, order.cursorAfter(map[string]interface{}{"created": 12345}))
+	assert.Nil(t, order.cursorAfter("not an object"))
+
+	msgID := fftypes.NewUUID()
+	created := fftypes.Now()
+	c := order.cursorAfter(&core.Message{Header: core.MessageHeader{ID: msgID, Created: created}})
+	assert.Equal(t, &pageCursor{Field: "created", Value: created.String(), ID: msgID.String()}, c)
+
+	order = &cursorOrder{field: "sequence"}
+	assert.Equal(t, &pageCursor{Field: "sequence", Value: "12"}, order.cursorAfter(map[string]interface{}{"sequence": 12}))
+}
+
+func TestCursorOrderForTokenTransfers(t *testing.T) {
+	assert.Equal(t, &cursorOrder{field: "created", idField: "localid", idJSON: "localId"}, cursorOrderFor(database.TokenTransferQueryFactory))
+}
+
+func TestCursorOrderForNonQueryFields(t *testing.T) {
+	assert.Nil(t, cursorOrderFor(nil))
+	order := &cursorOrder{field: "sequence"}
+	_, err := order.after(context.Background(), database.EventQueryFactory.NewFilter(context.Background()), &pageCursor{Field: "sequence", Value: "x"})
+	assert.Regexp(t, "FF10535", err)
+}
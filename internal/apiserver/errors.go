@@ -0,0 +1,186 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Error responses from the API are extended with structured fields, so clients can handle errors without
+// matching the text of the message. The code is the prefix of the message, and the params are recovered by
+// matching the message against the template of its code in the error catalog. The correlation ID is the
+// request ID the request is logged under, which is returned on every response.
+
+var errorCodeExtractor = regexp.MustCompile(`^([A-Z]{2}\d{5}):`)
+
+var templateVerbMatcher = regexp.MustCompile(`%[-+# 0]*\d*(?:\.\d+)?[a-zA-Z%]`)
+
+type errorCatalog struct {
+	codes    []*core.ErrorCode
+	byCode   map[string]*core.ErrorCode
+	matchers map[string][]*regexp.Regexp
+}
+
+var coreErrorCatalog = newErrorCatalog(coremsgs.ErrorTemplates())
+
+func newErrorCatalog(templates []*coremsgs.ErrorTemplate) *errorCatalog {
+	ec := &errorCatalog{
+		codes:    make([]*core.ErrorCode, len(templates)),
+		byCode:   make(map[string]*core.ErrorCode, len(templates)),
+		matchers: make(map[string][]*regexp.Regexp, len(templates)),
+	}
+	for i, t := range templates {
+		code := &core.ErrorCode{
+			Code:      t.Code,
+			Template:  t.Template,
+			Status:    t.Status,
+			Retryable: core.StatusRetryable(t.Status),
+		}
+		ec.codes[i] = code
+		ec.byCode[t.Code] = code
+		ec.matchers[t.Code] = templateMatchers(t.Code, t.Template)
+	}
+	return ec
+}
+
+// templateMatchers builds a regular expression that captures each param of a message built from the template.
+// A second expression allows for the message of a wrapped error being appended after the template.
+func templateMatchers(code, template string) []*regexp.Regexp {
+	parts := templateVerbMatcher.Split(template, -1)
+	verbs := templateVerbMatcher.FindAllString(template, -1)
+	buff := new(strings.Builder)
+	buff.WriteString(`(?s)^`)
+	buff.WriteString(regexp.QuoteMeta(code + ": "))
+	for i, part := range parts {
+		buff.WriteString(regexp.QuoteMeta(part))
+		if i < len(verbs) {
+			if verbs[i] == "%%" {
+				buff.WriteString("%")
+			} else {
+				buff.WriteString("(.*?)")
+			}
+		}
+	}
+	expr := buff.String()
+	return []*regexp.Regexp{
+		regexp.MustCompile(expr + `$`),
+		regexp.MustCompile(expr + `: .*$`),
+	}
+}
+
+func (ec *errorCatalog) getErrorCodes() []*core.ErrorCode {
+	return ec.codes
+}
+
+func (ec *errorCatalog) getErrorCode(code string) *core.ErrorCode {
+	return ec.byCode[strings.ToUpper(code)]
+}
+
+func (ec *errorCatalog) params(code, message string) []string {
+	for _, matcher := range ec.matchers[code] {
+		if match := matcher.FindStringSubmatch(message); match != nil {
+			return match[1:]
+		}
+	}
+	return nil
+}
+
+func (ec *errorCatalog) apiError(message string, status int, correlationID string) *core.APIError {
+	apiErr := &core.APIError{
+		Error:         message,
+		Retryable:     core.StatusRetryable(status),
+		CorrelationID: correlationID,
+	}
+	if match := errorCodeExtractor.FindStringSubmatch(message); match != nil {
+		apiErr.Code = match[1]
+		apiErr.Params = ec.params(apiErr.Code, message)
+	}
+	return apiErr
+}
+
+// structuredErrorWriter holds back the body of an error response, so the error can be re-written
+// with its structured fields once the handler is complete
+type structuredErrorWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *structuredErrorWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+	if status < http.StatusBadRequest {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *structuredErrorWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.status >= http.StatusBadRequest {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *structuredErrorWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok && w.status < http.StatusBadRequest {
+		flusher.Flush()
+	}
+}
+
+func (w *structuredErrorWriter) complete(ec *errorCatalog, correlationID string) {
+	if w.status < http.StatusBadRequest {
+		return
+	}
+	body := w.body.Bytes()
+	var restErr fftypes.RESTError
+	if err := json.Unmarshal(body, &restErr); err == nil && restErr.Error != "" {
+		b, _ := json.Marshal(ec.apiError(restErr.Error, w.status, correlationID))
+		body = append(b, '\n')
+		w.Header().Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+func withStructuredErrors(handler http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		// Ensure the request is logged under the same ID we return to the client
+		correlationID := req.Header.Get(ffapi.FFRequestIDHeader)
+		if correlationID == "" {
+			correlationID = fftypes.ShortID()
+			req.Header.Set(ffapi.FFRequestIDHeader, correlationID)
+		}
+		res.Header().Set(ffapi.FFRequestIDHeader, correlationID)
+		w := &structuredErrorWriter{ResponseWriter: res}
+		handler(w, req)
+		w.complete(coreErrorCatalog, correlationID)
+	}
+}
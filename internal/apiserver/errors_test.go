@@ -0,0 +1,162 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestErrorCatalog() *errorCatalog {
+	return newErrorCatalog([]*coremsgs.ErrorTemplate{
+		{Code: "FF99001", Template: "Group '%s' not found in namespace %s", Status: 404},
+		{Code: "FF99002", Template: "Database query failed", Status: 500},
+		{Code: "FF99003", Template: "Progress %d%% after %.2fms", Status: 400},
+	})
+}
+
+func TestErrorCatalogParams(t *testing.T) {
+	ec := newTestErrorCatalog()
+
+	apiErr := ec.apiError("FF99001: Group 'abc' not found in namespace ns1", 404, "req1")
+	assert.Equal(t, "FF99001", apiErr.Code)
+	assert.Equal(t, []string{"abc", "ns1"}, apiErr.Params)
+	assert.False(t, apiErr.Retryable)
+	assert.Equal(t, "req1", apiErr.CorrelationID)
+
+	apiErr = ec.apiError("FF99002: Database query failed: pop", 500, "req1")
+	assert.Equal(t, "FF99002", apiErr.Code)
+	assert.Empty(t, apiErr.Params)
+	assert.True(t, apiErr.Retryable)
+
+	apiErr = ec.apiError("FF99003: Progress 50% after 1.25ms", 400, "req1")
+	assert.Equal(t, []string{"50", "1.25"}, apiErr.Params)
+
+	apiErr = ec.apiError("FF99001: Something else entirely", 404, "req1")
+	assert.Equal(t, "FF99001", apiErr.Code)
+	assert.Nil(t, apiErr.Params)
+
+	apiErr = ec.apiError("not an FF error", 500, "req1")
+	assert.Empty(t, apiErr.Code)
+	assert.Nil(t, apiErr.Params)
+}
+
+func TestErrorCatalogLookup(t *testing.T) {
+	ec := newTestErrorCatalog()
+	assert.Len(t, ec.getErrorCodes(), 3)
+	code := ec.getErrorCode("ff99001")
+	assert.Equal(t, 404, code.Status)
+	assert.False(t, code.Retryable)
+	assert.True(t, ec.getErrorCode("FF99002").Retryable)
+	assert.Nil(t, ec.getErrorCode("FF99999"))
+}
+
+func TestCoreErrorCatalog(t *testing.T) {
+	code := coreErrorCatalog.getErrorCode("FF10109")
+	assert.Equal(t, "Not found", code.Template)
+	assert.Equal(t, 404, code.Status)
+	assert.Len(t, coreErrorCatalog.getErrorCodes(), len(coremsgs.ErrorTemplates()))
+}
+
+func TestStructuredErrorWriterError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &structuredErrorWriter{ResponseWriter: rec}
+	w.Header().Set("Content-Length", "100")
+	w.WriteHeader(404)
+	w.WriteHeader(500) // ignored
+	w.Write([]byte(`{"error":"FF99001: Group 'abc' not found in namespace ns1"}`))
+	w.Flush()
+	assert.False(t, rec.Flushed)
+	w.complete(newTestErrorCatalog(), "req1")
+
+	assert.Equal(t, 404, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Length"))
+	var apiErr core.APIError
+	err := json.Unmarshal(rec.Body.Bytes(), &apiErr)
+	assert.NoError(t, err)
+	assert.Equal(t, "FF99001", apiErr.Code)
+	assert.Equal(t, []string{"abc", "ns1"}, apiErr.Params)
+	assert.Equal(t, "req1", apiErr.CorrelationID)
+}
+
+func TestStructuredErrorWriterNonJSONError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &structuredErrorWriter{ResponseWriter: rec}
+	w.WriteHeader(500)
+	w.Write([]byte(`not json`))
+	w.complete(newTestErrorCatalog(), "req1")
+
+	assert.Equal(t, 500, rec.Code)
+	assert.Equal(t, "not json", rec.Body.String())
+}
+
+func TestStructuredErrorWriterSuccess(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &structuredErrorWriter{ResponseWriter: rec}
+	w.Write([]byte(`{}`))
+	w.Flush()
+	w.complete(newTestErrorCatalog(), "req1")
+
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "{}", rec.Body.String())
+	assert.True(t, rec.Flushed)
+}
+
+func TestStructuredErrorResponse(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetMessageByID", mock.Anything, "abc").Return(nil, fmt.Errorf("pop"))
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/abc", nil)
+	req.Header.Set(ffapi.FFRequestIDHeader, "myreq")
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 500, res.Result().StatusCode)
+	assert.Equal(t, "myreq", res.Result().Header.Get(ffapi.FFRequestIDHeader))
+	var apiErr core.APIError
+	err := json.NewDecoder(res.Body).Decode(&apiErr)
+	assert.NoError(t, err)
+	assert.Equal(t, "pop", apiErr.Error)
+	assert.True(t, apiErr.Retryable)
+	assert.Equal(t, "myreq", apiErr.CorrelationID)
+}
+
+func TestStructuredErrorResponseGeneratesCorrelationID(t *testing.T) {
+	_, r := newTestAPIServer()
+	req := httptest.NewRequest("GET", "/api/v1/errors/FF99999", nil)
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 404, res.Result().StatusCode)
+	var apiErr core.APIError
+	err := json.NewDecoder(res.Body).Decode(&apiErr)
+	assert.NoError(t, err)
+	assert.Equal(t, "FF10109", apiErr.Code)
+	assert.False(t, apiErr.Retryable)
+	assert.NotEmpty(t, apiErr.CorrelationID)
+	assert.Equal(t, apiErr.CorrelationID, res.Result().Header.Get(ffapi.FFRequestIDHeader))
+}
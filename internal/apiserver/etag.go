@@ -0,0 +1,112 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+)
+
+// Routes support conditional requests by setting an ETag header on their response. Where the resource
+// has an immutable hash that is used, otherwise the tag is the hash of the JSON of the response.
+// When the tag matches the If-None-Match header of the request, a 304 is returned without the body.
+
+func setETag(r *ffapi.APIRequest, hash string) {
+	r.ResponseHeaders.Set("ETag", `"`+hash+`"`)
+}
+
+// withJSONETag sets the tag of a JSON response from the hash of its content
+func withJSONETag(r *ffapi.APIRequest, output interface{}, err error) (interface{}, error) {
+	if err != nil || output == nil {
+		return output, err
+	}
+	if v := reflect.ValueOf(output); v.Kind() == reflect.Ptr && v.IsNil() {
+		return output, err
+	}
+	b, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(b)
+	setETag(r, hex.EncodeToString(hash[:]))
+	return output, nil
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionalResponseWriter replaces a successful response with a 304, when the entity tag
+// set by the route matches the If-None-Match header of the request
+type conditionalResponseWriter struct {
+	http.ResponseWriter
+	ifNoneMatch   string
+	headerWritten bool
+	notModified   bool
+}
+
+func (w *conditionalResponseWriter) WriteHeader(status int) {
+	if w.headerWritten {
+		return
+	}
+	w.headerWritten = true
+	if etag := w.Header().Get("ETag"); status == http.StatusOK && etag != "" && etagMatches(w.ifNoneMatch, etag) {
+		w.notModified = true
+		w.Header().Del("Content-Type")
+		w.Header().Del("Content-Length")
+		status = http.StatusNotModified
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *conditionalResponseWriter) Write(b []byte) (int, error) {
+	if !w.headerWritten {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.notModified {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *conditionalResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok && !w.notModified {
+		flusher.Flush()
+	}
+}
+
+func withConditionalRequests(handler http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" && (req.Method == http.MethodGet || req.Method == http.MethodHead) {
+			res = &conditionalResponseWriter{ResponseWriter: res, ifNoneMatch: ifNoneMatch}
+		}
+		handler(res, req)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetMessageByIDConditional(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetMessageByID", mock.Anything, "abcd12345").
+		Return(&core.Message{Hash: fftypes.NewRandB32()}, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/abcd12345", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 200, res.Result().StatusCode)
+	etag := res.Result().Header.Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{64}"$`, etag)
+
+	req = httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/abcd12345", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 304, res.Result().StatusCode)
+	assert.Empty(t, res.Body.String())
+	assert.Empty(t, res.Result().Header.Get("Content-Type"))
+
+	req = httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/abcd12345", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.NotEmpty(t, res.Body.String())
+}
+
+func TestGetMessageByIDConditionalNotFound(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetMessageByID", mock.Anything, "abcd12345").Return(nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/abcd12345", nil)
+	req.Header.Set("If-None-Match", "*")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 404, res.Result().StatusCode)
+	assert.Empty(t, res.Result().Header.Get("ETag"))
+}
+
+func TestGetDataValueConditional(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	hash := fftypes.NewRandB32()
+	o.On("GetDataByID", mock.Anything, "abcd12345").
+		Return(&core.Data{Hash: hash, Value: fftypes.JSONAnyPtr(`{"some":"data"}`)}, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/data/abcd12345/value", nil)
+	req.Header.Set("If-None-Match", `W/"`+hash.String()+`"`)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 304, res.Result().StatusCode)
+	assert.Equal(t, `"`+hash.String()+`"`, res.Result().Header.Get("ETag"))
+	assert.Empty(t, res.Body.String())
+}
+
+func TestWithJSONETagMarshalFail(t *testing.T) {
+	r := &ffapi.APIRequest{ResponseHeaders: http.Header{}}
+	_, err := withJSONETag(r, map[string]interface{}{"bad": make(chan int)}, nil)
+	assert.Error(t, err)
+	assert.Empty(t, r.ResponseHeaders.Get("ETag"))
+}
+
+func TestConditionalResponseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := &conditionalResponseWriter{ResponseWriter: rec, ifNoneMatch: `"abc"`}
+	w.Header().Set("ETag", `"abc"`)
+	n, err := w.Write([]byte("body"))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	w.WriteHeader(500) // ignored, as the header has been written
+	w.Flush()
+	assert.Equal(t, 304, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.False(t, rec.Flushed)
+
+	rec = httptest.NewRecorder()
+	w = &conditionalResponseWriter{ResponseWriter: rec, ifNoneMatch: `"abc"`}
+	_, err = w.Write([]byte("body"))
+	assert.NoError(t, err)
+	w.Flush()
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "body", rec.Body.String())
+	assert.True(t, rec.Flushed)
+}
@@ -0,0 +1,118 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+)
+
+// fieldSelection is the set of JSON fields requested with the "fields" query parameter of a GET, such as
+// "fields=header.id,header.tag,state". Each field maps to the selection of its own fields, or nil when the
+// whole value of the field is returned.
+type fieldSelection map[string]fieldSelection
+
+func parseFieldSelection(req *http.Request) fieldSelection {
+	if req.Method != http.MethodGet {
+		return nil
+	}
+	var fs fieldSelection
+	for _, param := range req.URL.Query()["fields"] {
+		for _, path := range strings.Split(param, ",") {
+			if path = strings.TrimSpace(path); path == "" {
+				continue
+			}
+			if fs == nil {
+				fs = fieldSelection{}
+			}
+			fs.add(strings.Split(path, "."))
+		}
+	}
+	return fs
+}
+
+func (fs fieldSelection) add(path []string) {
+	sub, exists := fs[path[0]]
+	switch {
+	case len(path) == 1:
+		fs[path[0]] = nil // the whole field, which includes any sub-fields already selected
+	case exists && sub == nil:
+		// the whole field is already selected
+	default:
+		if sub == nil {
+			sub = fieldSelection{}
+			fs[path[0]] = sub
+		}
+		sub.add(path[1:])
+	}
+}
+
+// apply returns the response with only the selected fields of each item of a list, or of a single object
+func (fs fieldSelection) apply(output interface{}) (interface{}, error) {
+	switch ot := output.(type) {
+	case nil, io.Reader:
+		return output, nil
+	case *ffapi.FilterResultsWithCount:
+		items, err := fs.apply(ot.Items)
+		if err != nil {
+			return nil, err
+		}
+		ot.Items = items
+		return ot, nil
+	}
+	b, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return fs.selectFrom(value), nil
+}
+
+func (fs fieldSelection) selectFrom(value interface{}) interface{} {
+	switch vt := value.(type) {
+	case map[string]interface{}:
+		selected := make(map[string]interface{}, len(fs))
+		for name, sub := range fs {
+			if v, ok := vt[name]; ok {
+				if sub == nil {
+					selected[name] = v
+				} else {
+					selected[name] = sub.selectFrom(v)
+				}
+			}
+		}
+		return selected
+	case []interface{}:
+		items := make([]interface{}, len(vt))
+		for i, item := range vt {
+			items[i] = fs.selectFrom(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetMessagesFields(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	msgID := fftypes.MustParseUUID("4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e")
+	o.On("GetMessages", mock.Anything, mock.Anything).Return([]*core.Message{
+		{Header: core.MessageHeader{ID: msgID, Tag: "t1", Topics: fftypes.FFStringArray{"topic1"}}, State: core.MessageStateConfirmed},
+	}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages?fields=header.id,+header.tag,state&fields=missing", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.JSONEq(t, `[{"header": {"id": "4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e", "tag": "t1"}, "state": "confirmed"}]`, res.Body.String())
+}
+
+func TestGetMessagesFieldsWithCount(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	total := int64(10)
+	o.On("GetMessages", mock.Anything, mock.Anything).Return([]*core.Message{
+		{Header: core.MessageHeader{Tag: "t1"}, State: core.MessageStateConfirmed},
+	}, &ffapi.FilterResult{TotalCount: &total}, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages?count=true&fields=state", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.JSONEq(t, `{"count": 1, "total": 10, "items": [{"state": "confirmed"}]}`, res.Body.String())
+}
+
+func TestGetMessageByIDFields(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetMessageByID", mock.Anything, "abcd12345").Return(&core.Message{
+		Header: core.MessageHeader{Tag: "t1"},
+		Data:   core.DataRefs{{ID: fftypes.MustParseUUID("4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e"), Hash: fftypes.NewRandB32()}, {}},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages/abcd12345?fields=data.id,header", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	var result map[string]interface{}
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &result))
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e"}, map[string]interface{}{}}, result["data"])
+	assert.Equal(t, "t1", result["header"].(map[string]interface{})["tag"])
+	assert.Len(t, result, 2)
+}
+
+func TestStreamMessagesNDJSONFields(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetMessages", mock.Anything, mock.Anything).Return([]*core.Message{
+		{Sequence: 2, Header: core.MessageHeader{Tag: "t2"}},
+		{Sequence: 1, Header: core.MessageHeader{Tag: "t1"}},
+	}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages?fields=header.tag", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, "{\"header\":{\"tag\":\"t2\"}}\n{\"header\":{\"tag\":\"t1\"}}\n", res.Body.String())
+}
+
+func TestParseFieldSelection(t *testing.T) {
+	assert.Nil(t, parseFieldSelection(httptest.NewRequest("GET", "/?fields=,", nil)))
+	assert.Nil(t, parseFieldSelection(httptest.NewRequest("POST", "/?fields=id", nil)))
+
+	fs := parseFieldSelection(httptest.NewRequest("GET", "/?fields=header.id,header,data.id&fields=data.hash,tx.id,tx.type,tx", nil))
+	assert.Equal(t, fieldSelection{
+		"header": nil,
+		"data":   fieldSelection{"id": nil, "hash": nil},
+		"tx":     nil,
+	}, fs)
+}
+
+func TestFieldSelectionApply(t *testing.T) {
+	fs := fieldSelection{"id": nil}
+
+	reader := strings.NewReader("")
+	output, err := fs.apply(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, reader, output)
+
+	output, err = fs.apply(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, output)
+
+	output, err = fs.apply("scalar")
+	assert.NoError(t, err)
+	assert.Equal(t, "scalar", output)
+
+	_, err = fs.apply(map[string]interface{}{"id": make(chan int)})
+	assert.Error(t, err)
+
+	_, err = fs.apply(&ffapi.FilterResultsWithCount{Items: []interface{}{make(chan int)}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,138 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// streamPageHandler returns one page of the items of a list route, for the given filter
+type streamPageHandler func(r *ffapi.APIRequest, cr *coreRequest, filter ffapi.AndFilter) (items interface{}, err error)
+
+func acceptsNDJSON(req *http.Request) bool {
+	for _, accept := range strings.Split(req.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept)); err == nil && mediaType == ndjsonContentType {
+			return true
+		}
+	}
+	return false
+}
+
+// ndjsonResponseWriter sets the content type of a successful streamed response, and flushes each
+// write to the client so rows are delivered as they are read
+type ndjsonResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *ndjsonResponseWriter) WriteHeader(status int) {
+	if status == http.StatusOK {
+		w.Header().Set("Content-Type", ndjsonContentType)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *ndjsonResponseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
+
+func withNDJSON(handler http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		if acceptsNDJSON(req) {
+			res = &ndjsonResponseWriter{ResponseWriter: res}
+		}
+		handler(res, req)
+	}
+}
+
+// streamNDJSON returns a reader of every item matching the filter of the request, one JSON object per line.
+// The items are read a page at a time using cursors, so the iteration is stable while items are inserted.
+// Pages are only read as the client consumes the stream, so a slow client does not cause rows to be
+// buffered in memory.
+func streamNDJSON(r *ffapi.APIRequest, cr *coreRequest, route *ffapi.Route, order *cursorOrder, fields fieldSelection, page streamPageHandler) (io.ReadCloser, error) {
+	if order == nil {
+		return nil, i18n.NewError(cr.ctx, coremsgs.MsgStreamWithSort)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeNDJSON(r, cr, route, order, fields, page, pw))
+	}()
+	return pr, nil
+}
+
+func writeNDJSON(r *ffapi.APIRequest, cr *coreRequest, route *ffapi.Route, order *cursorOrder, fields fieldSelection, page streamPageHandler, w io.Writer) error {
+	pageSize := config.GetInt(coreconfig.APIMaxFilterLimit)
+	enc := json.NewEncoder(w)
+	var next *pageCursor
+	for {
+		fb := route.FilterFactory.NewFilter(cr.ctx)
+		filter := fb.And(r.Filter)
+		if next != nil {
+			after, err := order.after(cr.ctx, fb, next)
+			if err != nil {
+				return err
+			}
+			filter.Condition(after)
+		}
+		order.sort(filter)
+		filter.Limit(uint64(pageSize))
+
+		output, err := page(r, cr, filter)
+		if err != nil {
+			log.L(cr.ctx).Errorf("NDJSON export of %s failed: %s", route.Path, err)
+			return err
+		}
+		items := reflect.ValueOf(output)
+		if items.Kind() != reflect.Slice {
+			return nil
+		}
+		for i := 0; i < items.Len(); i++ {
+			item := items.Index(i).Interface()
+			if fields != nil {
+				if item, err = fields.apply(item); err != nil {
+					return err
+				}
+			}
+			if err := enc.Encode(item); err != nil {
+				return err // the client has gone away
+			}
+		}
+		if items.Len() < pageSize {
+			return nil
+		}
+		if next = order.cursorAfter(items.Index(items.Len() - 1).Interface()); next == nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/assetmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func afterSequence(seq string) interface{} {
+	return mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, _ := filter.Finalize()
+		if !strings.Contains(fi.String(), "confirmed") || fi.Limit != 2 || len(fi.Sort) == 0 || fi.Sort[0].Field != "sequence" {
+			return false
+		}
+		if seq == "" {
+			return !strings.Contains(fi.String(), "sequence <")
+		}
+		return strings.Contains(fi.String(), "sequence < "+seq)
+	})
+}
+
+func TestStreamMessagesNDJSON(t *testing.T) {
+	o, r := newTestAPIServer()
+	config.Set(coreconfig.APIMaxFilterLimit, 2)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages?state=confirmed", nil)
+	req.Header.Set("Accept", "application/json, application/x-ndjson")
+	res := httptest.NewRecorder()
+
+	o.On("GetMessages", mock.Anything, afterSequence("")).Return([]*core.Message{
+		{Sequence: 10, Header: core.MessageHeader{Tag: "t10"}},
+		{Sequence: 9, Header: core.MessageHeader{Tag: "t9"}},
+	}, nil, nil)
+	o.On("GetMessages", mock.Anything, afterSequence("9")).Return([]*core.Message{
+		{Sequence: 8, Header: core.MessageHeader{Tag: "t8"}},
+	}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, "application/x-ndjson", res.Result().Header.Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(res.Body.String()), "\n")
+	assert.Len(t, lines, 3)
+	for i, tag := range []string{"t10", "t9", "t8"} {
+		assert.Contains(t, lines[i], `"tag":"`+tag+`"`)
+	}
+	o.AssertExpectations(t)
+}
+
+func TestStreamMessagesWithDataNDJSON(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages?fetchdata", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	res := httptest.NewRecorder()
+
+	o.On("GetMessagesWithData", mock.Anything, mock.Anything).Return([]*core.MessageInOut{{}}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, 1, strings.Count(res.Body.String(), "\n"))
+}
+
+func TestStreamEventsNDJSONFailMidStream(t *testing.T) {
+	o, r := newTestAPIServer()
+	config.Set(coreconfig.APIMaxFilterLimit, 1)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/events", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	res := httptest.NewRecorder()
+
+	o.On("GetEvents", mock.Anything, mock.Anything).Return([]*core.Event{{Sequence: 5}}, nil, nil).Once()
+	o.On("GetEvents", mock.Anything, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, 1, strings.Count(res.Body.String(), "\n"))
+}
+
+func TestStreamEventsWithReferencesNDJSON(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/events?fetchreferences", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	res := httptest.NewRecorder()
+
+	o.On("GetEventsWithReferences", mock.Anything, mock.Anything).Return([]*core.EnrichedEvent{}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Empty(t, res.Body.String())
+}
+
+func TestStreamTokenTransfersNDJSON(t *testing.T) {
+	o, r := newTestAPIServer()
+	config.Set(coreconfig.APIMaxFilterLimit, 1)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mam := &assetmocks.Manager{}
+	o.On("Assets").Return(mam)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/tokens/transfers?fromOrTo=0x1", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	res := httptest.NewRecorder()
+
+	localID := fftypes.NewUUID()
+	created := fftypes.Now()
+	mam.On("GetTokenTransfers", mock.Anything, mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, _ := filter.Finalize()
+		return strings.Contains(fi.String(), "from == '0x1'") && !strings.Contains(fi.String(), "localid <")
+	})).Return([]*core.TokenTransfer{{LocalID: localID, Created: created}}, nil, nil)
+	mam.On("GetTokenTransfers", mock.Anything, mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, _ := filter.Finalize()
+		return strings.Contains(fi.String(), "localid < '"+localID.String()+"'")
+	})).Return([]*core.TokenTransfer{}, nil, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, 1, strings.Count(res.Body.String(), "\n"))
+	mam.AssertExpectations(t)
+}
+
+func TestStreamNDJSONWithSort(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages?sort=tag", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Result().StatusCode)
+	assert.Equal(t, "application/json", res.Result().Header.Get("Content-Type"))
+	assert.Regexp(t, "FF10538", res.Body.String())
+}
+
+type errorWriter struct{}
+
+func (w *errorWriter) Write(b []byte) (int, error) {
+	return 0, fmt.Errorf("closed")
+}
+
+func TestWriteNDJSONClientGone(t *testing.T) {
+	coreconfig.Reset()
+	cr := &coreRequest{ctx: context.Background()}
+	r := &ffapi.APIRequest{Filter: getMsgs.FilterFactory.NewFilter(cr.ctx).And()}
+	err := writeNDJSON(r, cr, getMsgs, &cursorOrder{field: "sequence"}, nil, func(r *ffapi.APIRequest, cr *coreRequest, filter ffapi.AndFilter) (interface{}, error) {
+		return []*core.Message{{}}, nil
+	}, &errorWriter{})
+	assert.Regexp(t, "closed", err)
+}
+
+func TestWriteNDJSONNotSlice(t *testing.T) {
+	coreconfig.Reset()
+	cr := &coreRequest{ctx: context.Background()}
+	r := &ffapi.APIRequest{Filter: getMsgs.FilterFactory.NewFilter(cr.ctx).And()}
+	err := writeNDJSON(r, cr, getMsgs, &cursorOrder{field: "sequence"}, nil, func(r *ffapi.APIRequest, cr *coreRequest, filter ffapi.AndFilter) (interface{}, error) {
+		return nil, nil
+	}, &errorWriter{})
+	assert.NoError(t, err)
+}
+
+func TestAcceptsNDJSON(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	assert.False(t, acceptsNDJSON(req))
+	req.Header.Set("Accept", "application/json;q=0.9, application/x-ndjson;q=1.0")
+	assert.True(t, acceptsNDJSON(req))
+	req.Header.Set("Accept", "application/json")
+	assert.False(t, acceptsNDJSON(req))
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/policy"
+)
+
+// isMutating returns true for the methods that change state, which the policy of the namespace is checked for
+func isMutating(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+}
+
+// summarizePayload flattens the scalar fields of a JSON input, to one level of nesting, so policies can
+// make decisions on fields like "header.tag" without the full data (which can be large) being passed
+func summarizePayload(r *ffapi.APIRequest) *policy.PayloadSummary {
+	summary := &policy.PayloadSummary{
+		ContentType: r.Req.Header.Get("Content-Type"),
+		Size:        r.Req.ContentLength,
+	}
+	if r.Input == nil {
+		return summary
+	}
+	var input map[string]interface{}
+	if b, err := json.Marshal(r.Input); err != nil || json.Unmarshal(b, &input) != nil {
+		return summary
+	}
+	fields := make(map[string]interface{})
+	for k, v := range input {
+		switch vt := v.(type) {
+		case map[string]interface{}:
+			for nk, nv := range vt {
+				if isScalar(nv) {
+					fields[k+"."+nk] = nv
+				}
+			}
+		default:
+			if isScalar(vt) {
+				fields[k] = vt
+			}
+		}
+	}
+	if len(fields) > 0 {
+		summary.Fields = fields
+	}
+	return summary
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, float64, bool:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkPolicy asks the policy decision point of the namespace whether a mutating call is allowed.
+// Only the principal authenticated by the auth plugin is passed as the identity, never anything
+// the caller asserts about itself, so it is empty when the namespace has no auth plugin.
+func checkPolicy(r *ffapi.APIRequest, route *ffapi.Route, or orchestrator.Orchestrator) error {
+	if !isMutating(r.Req.Method) {
+		return nil
+	}
+	return or.CheckPolicy(r.Req.Context(), &policy.Request{
+		Identity: core.GetAuthPrincipal(r.Req.Context()),
+		Method:   r.Req.Method,
+		Route:    route.Name,
+		Path:     r.Req.URL.Path,
+		Payload:  summarizePayload(r),
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPolicyDenied(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.ExpectedCalls = nil // replace the default of allowing all requests
+	o.On("CheckRateLimit", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		core.SetAuthPrincipal(args[0].(context.Context), "alice")
+	})
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeMessagesPerDay, int64(1)).Return(nil)
+	o.On("MultiParty").Return(&multipartymocks.Manager{})
+	o.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(req *policy.Request) bool {
+		return req.Identity == "alice" &&
+			req.Route == "postNewMessageBroadcast" &&
+			req.Path == "/api/v1/namespaces/ns1/messages/broadcast" &&
+			req.Payload.Fields["header.tag"] == "trade"
+	})).Return(i18n.NewError(context.Background(), coremsgs.MsgPolicyDenied, "tag not permitted"))
+
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/messages/broadcast", bytes.NewBufferString(`{"header":{"tag":"trade"}}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 403, res.Result().StatusCode)
+	o.AssertExpectations(t)
+}
+
+func TestPolicyNotCheckedForReads(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.ExpectedCalls = nil // replace the default of allowing all requests
+	o.On("CheckRateLimit", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetMessages", mock.Anything, mock.Anything).Return([]*core.Message{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	o.AssertNotCalled(t, "CheckPolicy", mock.Anything, mock.Anything)
+}
+
+func TestSummarizePayload(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = 100
+	summary := summarizePayload(&ffapi.APIRequest{
+		Req: req,
+		Input: &core.MessageInOut{
+			Message: core.Message{
+				Header: core.MessageHeader{Tag: "trade", Topics: fftypes.FFStringArray{"t1"}},
+			},
+			InlineData: core.InlineData{{Value: fftypes.JSONAnyPtr(`{"amount":10}`)}},
+		},
+	})
+	assert.Equal(t, "application/json", summary.ContentType)
+	assert.Equal(t, int64(100), summary.Size)
+	assert.Equal(t, "trade", summary.Fields["header.tag"])
+	assert.NotContains(t, summary.Fields, "header.topics")
+	assert.NotContains(t, summary.Fields, "data")
+}
+
+func TestSummarizePayloadNoInput(t *testing.T) {
+	req := httptest.NewRequest("POST", "/", nil)
+	summary := summarizePayload(&ffapi.APIRequest{Req: req})
+	assert.Nil(t, summary.Fields)
+
+	summary = summarizePayload(&ffapi.APIRequest{Req: req, Input: map[string]interface{}{"bad": make(chan int)}})
+	assert.Nil(t, summary.Fields)
+
+	summary = summarizePayload(&ffapi.APIRequest{Req: req, Input: &[]string{"a"}})
+	assert.Nil(t, summary.Fields)
+}
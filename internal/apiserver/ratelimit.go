@@ -0,0 +1,71 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// rateLimitGroup returns the group of routes that share a rate limit with the route
+func rateLimitGroup(route *ffapi.Route) core.RateLimitGroup {
+	switch strings.SplitN(route.Path, "/", 2)[0] {
+	case "messages":
+		return core.RateLimitGroupMessages
+	case "contracts", "apis":
+		return core.RateLimitGroupContracts
+	case "tokens":
+		return core.RateLimitGroupTokens
+	default:
+		return core.RateLimitGroupOther
+	}
+}
+
+// requestIdentity returns the identity a request is rate limited as. This is the principal the auth plugin of the
+// namespace authenticated the caller as, where there is one, and otherwise the address of the client.
+// Credentials in the request are never read directly, as without an auth plugin nothing has verified them.
+func requestIdentity(req *http.Request) string {
+	if principal := core.GetAuthPrincipal(req.Context()); principal != "" {
+		return "user:" + principal
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	return "addr:" + host
+}
+
+// checkRateLimit uses one request of the allowance of the caller, and returns the state of their allowance
+// in the RateLimit headers of the response
+func checkRateLimit(r *ffapi.APIRequest, route *ffapi.Route, or orchestrator.Orchestrator) error {
+	status, err := or.CheckRateLimit(r.Req.Context(), rateLimitGroup(route), requestIdentity(r.Req))
+	if status != nil {
+		r.ResponseHeaders.Set("RateLimit-Limit", strconv.Itoa(status.Limit))
+		r.ResponseHeaders.Set("RateLimit-Remaining", strconv.Itoa(status.Remaining))
+		r.ResponseHeaders.Set("RateLimit-Reset", strconv.FormatInt(status.Reset, 10))
+		if err != nil {
+			r.ResponseHeaders.Set("Retry-After", strconv.FormatInt(status.RetryAfter, 10))
+		}
+	}
+	return err
+}
@@ -0,0 +1,114 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimitHeaders(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.ExpectedCalls = nil // replace the default of no rate limit
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		core.SetAuthPrincipal(args[0].(context.Context), "alice")
+	})
+	o.On("CheckRateLimit", mock.Anything, core.RateLimitGroupMessages, "user:alice").
+		Return(&core.RateLimitStatus{Limit: 10, Remaining: 9, Reset: 1}, nil)
+	o.On("GetMessages", mock.Anything, mock.Anything).Return([]*core.Message{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages", nil)
+	req.SetBasicAuth("mallory", "unchecked") // only the principal from the auth plugin is used
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, "10", res.Result().Header.Get("RateLimit-Limit"))
+	assert.Equal(t, "9", res.Result().Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", res.Result().Header.Get("RateLimit-Reset"))
+	assert.Empty(t, res.Result().Header.Get("Retry-After"))
+}
+
+func TestRateLimitExceeded(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.ExpectedCalls = nil // replace the default of no rate limit
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckRateLimit", mock.Anything, core.RateLimitGroupTokens, "addr:192.0.2.1").
+		Return(&core.RateLimitStatus{Limit: 10, Remaining: 0, Reset: 20, RetryAfter: 2},
+			i18n.NewError(context.Background(), coremsgs.MsgRateLimitExceeded, "tokens", "mynamespace", 2))
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/tokens/pools", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 429, res.Result().StatusCode)
+	assert.Equal(t, "0", res.Result().Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", res.Result().Header.Get("Retry-After"))
+}
+
+func TestRateLimitFormUpload(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.ExpectedCalls = nil // replace the default of no rate limit
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckRateLimit", mock.Anything, core.RateLimitGroupOther, "addr:192.0.2.1").
+		Return(&core.RateLimitStatus{Limit: 10, Remaining: 0, Reset: 20, RetryAfter: 2},
+			i18n.NewError(context.Background(), coremsgs.MsgRateLimitExceeded, "other", "mynamespace", 2))
+
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	writer, err := w.CreateFormFile("file", "filename.ext")
+	assert.NoError(t, err)
+	writer.Write([]byte(`some data`))
+	w.Close()
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/mynamespace/data", &b)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 429, res.Result().StatusCode)
+	assert.Equal(t, "2", res.Result().Header.Get("Retry-After"))
+}
+
+func TestRateLimitGroup(t *testing.T) {
+	assert.Equal(t, core.RateLimitGroupMessages, rateLimitGroup(postNewMessageBroadcast))
+	assert.Equal(t, core.RateLimitGroupContracts, rateLimitGroup(postContractInvoke))
+	assert.Equal(t, core.RateLimitGroupContracts, rateLimitGroup(postContractAPIInvoke))
+	assert.Equal(t, core.RateLimitGroupTokens, rateLimitGroup(postTokenTransfer))
+	assert.Equal(t, core.RateLimitGroupOther, rateLimitGroup(getBatches))
+}
+
+func TestRequestIdentity(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	assert.Equal(t, "addr:192.0.2.1", requestIdentity(req))
+
+	req.RemoteAddr = "no-port"
+	assert.Equal(t, "addr:no-port", requestIdentity(req))
+
+	// Credentials that have not been verified by an auth plugin are ignored
+	req.SetBasicAuth("alice", "pass")
+	assert.Equal(t, "addr:no-port", requestIdentity(req))
+
+	req = req.WithContext(core.WithAuthPrincipal(req.Context()))
+	core.SetAuthPrincipal(req.Context(), "bob")
+	assert.Equal(t, "user:bob", requestIdentity(req))
+}
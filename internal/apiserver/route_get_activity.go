@@ -0,0 +1,61 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func activityCategories(r *ffapi.APIRequest) []string {
+	var categories []string
+	for _, c := range strings.Split(r.QP["category"], ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+var getActivity = &ffapi.Route{
+	Name:       "getActivity",
+	Path:       "activity",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "category", Example: "message,tokentransfer", Description: coremsgs.APIActivityCategoryParam},
+	},
+	FilterFactory:   database.EventQueryFactory,
+	Description:     coremsgs.APIEndpointsGetActivity,
+	JSONInputValue:  nil,
+	JSONOutputValue: func() interface{} { return []*core.ActivityItem{} },
+	JSONOutputCodes: []int{http.StatusOK},
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			return r.FilterResult(cr.or.GetActivity(cr.ctx, activityCategories(r), r.Filter))
+		},
+		StreamPage: func(r *ffapi.APIRequest, cr *coreRequest, filter ffapi.AndFilter) (items interface{}, err error) {
+			items, _, err = cr.or.GetActivity(cr.ctx, activityCategories(r), filter)
+			return items, err
+		},
+	},
+}
@@ -39,7 +39,7 @@ var getBatchByID = &ffapi.Route{
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.GetBatchByID(cr.ctx, r.PP["batchid"])
-			return output, err
+			return withJSONETag(r, output, err)
 		},
 	},
 }
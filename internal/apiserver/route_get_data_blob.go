@@ -48,6 +48,7 @@ var getDataBlob = &ffapi.Route{
 			blob, reader, err := cr.or.Data().DownloadBlob(cr.ctx, r.PP["dataid"])
 			if err == nil {
 				r.ResponseHeaders.Set(core.HTTPHeadersBlobHashSHA256, blob.Hash.String())
+				setETag(r, blob.Hash.String())
 				if blob.Size > 0 {
 					r.ResponseHeaders.Set(core.HTTPHeadersBlobSize, strconv.FormatInt(blob.Size, 10))
 				}
@@ -39,7 +39,7 @@ var getDataByID = &ffapi.Route{
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.GetDataByID(cr.ctx, r.PP["dataid"])
-			return output, err
+			return withJSONETag(r, output, err)
 		},
 	},
 }
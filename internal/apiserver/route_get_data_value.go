@@ -43,6 +43,9 @@ var getDataValue = &ffapi.Route{
 			if err != nil {
 				return nil, err
 			}
+			if d.Hash != nil {
+				setETag(r, d.Hash.String())
+			}
 			return d.Value, err
 		},
 	},
@@ -0,0 +1,50 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var getErrorCodeByCode = &ffapi.Route{
+	Name:   "getErrorCodeByCode",
+	Path:   "errors/{code}",
+	Method: http.MethodGet,
+	PathParams: []*ffapi.PathParam{
+		{Name: "code", Example: "FF10109", Description: coremsgs.APIParamsErrorCode},
+	},
+	QueryParams:     nil,
+	FilterFactory:   nil,
+	Description:     coremsgs.APIEndpointsGetErrorCodeByCode,
+	JSONInputValue:  nil,
+	JSONOutputValue: func() interface{} { return &core.ErrorCode{} },
+	JSONOutputCodes: []int{http.StatusOK},
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			code := coreErrorCatalog.getErrorCode(r.PP["code"])
+			if code == nil {
+				return nil, i18n.NewError(cr.ctx, coremsgs.Msg404NotFound)
+			}
+			return code, nil
+		},
+	},
+}
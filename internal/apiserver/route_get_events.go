@@ -47,5 +47,13 @@ var getEvents = &ffapi.Route{
 			}
 			return r.FilterResult(cr.or.GetEvents(cr.ctx, r.Filter))
 		},
+		StreamPage: func(r *ffapi.APIRequest, cr *coreRequest, filter ffapi.AndFilter) (items interface{}, err error) {
+			if strings.EqualFold(r.QP["fetchreferences"], "true") || strings.EqualFold(r.QP["fetchreference"], "true") {
+				items, _, err = cr.or.GetEventsWithReferences(cr.ctx, filter)
+				return items, err
+			}
+			items, _, err = cr.or.GetEvents(cr.ctx, filter)
+			return items, err
+		},
 	},
 }
@@ -42,9 +42,11 @@ var getMsgByID = &ffapi.Route{
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			if strings.EqualFold(r.QP["data"], "true") || strings.EqualFold(r.QP["fetchdata"], "true") {
-				return cr.or.GetMessageByIDWithData(cr.ctx, r.PP["msgid"])
+				output, err = cr.or.GetMessageByIDWithData(cr.ctx, r.PP["msgid"])
+				return withJSONETag(r, output, err)
 			}
-			return cr.or.GetMessageByID(cr.ctx, r.PP["msgid"])
+			output, err = cr.or.GetMessageByID(cr.ctx, r.PP["msgid"])
+			return withJSONETag(r, output, err)
 		},
 	},
 }
@@ -46,5 +46,13 @@ var getMsgs = &ffapi.Route{
 			}
 			return r.FilterResult(cr.or.GetMessages(cr.ctx, r.Filter))
 		},
+		StreamPage: func(r *ffapi.APIRequest, cr *coreRequest, filter ffapi.AndFilter) (items interface{}, err error) {
+			if strings.EqualFold(r.QP["fetchdata"], "true") {
+				items, _, err = cr.or.GetMessagesWithData(cr.ctx, filter)
+				return items, err
+			}
+			items, _, err = cr.or.GetMessages(cr.ctx, filter)
+			return items, err
+		},
 	},
 }
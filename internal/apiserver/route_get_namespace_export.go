@@ -0,0 +1,55 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var getNamespaceExport = &ffapi.Route{
+	Name:       "getNamespaceExport",
+	Path:       "export",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "messages", Description: coremsgs.APIExportMsgsQueryParam, IsBool: true},
+	},
+	Description:     coremsgs.APIEndpointsGetNamespaceExport,
+	JSONInputValue:  nil,
+	JSONOutputValue: func() interface{} { return &core.NamespaceArchive{} },
+	JSONOutputCodes: []int{http.StatusOK},
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			// The archive is streamed as it is read from the database, so it is never held in memory
+			options := &core.NamespaceExportOptions{
+				IncludeMessages: strings.EqualFold(r.QP["messages"], "true"),
+			}
+			r.ResponseHeaders.Set("Content-Type", "application/json")
+			pr, pw := io.Pipe()
+			go func() {
+				pw.CloseWithError(cr.or.Archive().Export(cr.ctx, options, pw))
+			}()
+			return pr, nil
+		},
+	},
+}
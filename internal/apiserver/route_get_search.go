@@ -0,0 +1,58 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var getSearch = &ffapi.Route{
+	Name:       "getSearch",
+	Path:       "search",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "q", Description: coremsgs.APISearchQueryParam, IsBool: false},
+		{Name: "limit", Description: coremsgs.APISearchLimitParam, IsBool: false},
+	},
+	Description:     coremsgs.APIEndpointsGetSearch,
+	JSONInputValue:  nil,
+	JSONOutputValue: func() interface{} { return []*core.SearchResult{} },
+	JSONOutputCodes: []int{http.StatusOK},
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			limit := config.GetInt(coreconfig.APIDefaultFilterLimit)
+			if r.QP["limit"] != "" {
+				if limit, err = strconv.Atoi(r.QP["limit"]); err != nil || limit < 1 {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgInvalidSearchLimit, r.QP["limit"])
+				}
+			}
+			if maxLimit := config.GetInt(coreconfig.APIMaxFilterLimit); limit > maxLimit {
+				limit = maxLimit
+			}
+			return cr.or.SearchMessages(cr.ctx, r.QP["q"], limit)
+		},
+	},
+}
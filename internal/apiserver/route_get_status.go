@@ -37,7 +37,7 @@ var getStatus = &ffapi.Route{
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.GetStatus(cr.ctx)
-			return output, err
+			return withJSONETag(r, output, err)
 		},
 	},
 }
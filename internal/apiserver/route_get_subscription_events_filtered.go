@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/events/poll"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 )
@@ -47,6 +48,12 @@ var getSubscriptionEventsFiltered = &ffapi.Route{
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			subscription, _ := cr.or.GetSubscriptionByID(cr.ctx, r.PP["subid"])
+			if subscription != nil && subscription.Transport == poll.PollTransport &&
+				r.QP["startsequence"] == "" && r.QP["endsequence"] == "" {
+				// The next page of events after the offset the consumer last acknowledged
+				return r.FilterResult(cr.or.PollSubscriptionEvents(cr.ctx, subscription, r.Filter))
+			}
+
 			var startSeq int
 			var endSeq int
 
@@ -83,3 +83,19 @@ func TestGetSubscriptionEventsFilteredNoSequenceIDsProvided(t *testing.T) {
 	r.ServeHTTP(res, req)
 	assert.Equal(t, 200, res.Result().StatusCode)
 }
+
+func TestGetSubscriptionEventsFilteredPoll(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/subscriptions/abcd12345/events?limit=10", nil)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+	o.On("GetSubscriptionByID", mock.Anything, "abcd12345").
+		Return(&core.Subscription{Transport: "poll"}, nil)
+	o.On("PollSubscriptionEvents", mock.Anything, mock.Anything, mock.Anything).
+		Return([]*core.EnrichedEvent{}, nil, nil)
+
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 200, res.Result().StatusCode)
+	o.AssertNotCalled(t, "GetSubscriptionEventsHistorical", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
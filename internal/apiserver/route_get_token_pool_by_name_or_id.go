@@ -39,7 +39,7 @@ var getTokenPoolByNameOrID = &ffapi.Route{
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.Assets().GetTokenPoolByNameOrID(cr.ctx, r.PP["nameOrId"])
-			return output, err
+			return withJSONETag(r, output, err)
 		},
 	},
 }
@@ -40,15 +40,22 @@ var getTokenTransfers = &ffapi.Route{
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
-			filter := r.Filter
-			if fromOrTo, ok := r.QP["fromOrTo"]; ok {
-				fb := database.TokenTransferQueryFactory.NewFilter(cr.ctx)
-				filter = filter.Condition(
-					fb.Or().
-						Condition(fb.Eq("from", fromOrTo)).
-						Condition(fb.Eq("to", fromOrTo)))
-			}
-			return r.FilterResult(cr.or.Assets().GetTokenTransfers(cr.ctx, filter))
+			return r.FilterResult(cr.or.Assets().GetTokenTransfers(cr.ctx, withFromOrTo(r, cr, r.Filter)))
+		},
+		StreamPage: func(r *ffapi.APIRequest, cr *coreRequest, filter ffapi.AndFilter) (items interface{}, err error) {
+			items, _, err = cr.or.Assets().GetTokenTransfers(cr.ctx, withFromOrTo(r, cr, filter))
+			return items, err
 		},
 	},
 }
+
+func withFromOrTo(r *ffapi.APIRequest, cr *coreRequest, filter ffapi.AndFilter) ffapi.AndFilter {
+	if fromOrTo, ok := r.QP["fromOrTo"]; ok {
+		fb := database.TokenTransferQueryFactory.NewFilter(cr.ctx)
+		filter = filter.Condition(
+			fb.Or().
+				Condition(fb.Eq("from", fromOrTo)).
+				Condition(fb.Eq("to", fromOrTo)))
+	}
+	return filter
+}
@@ -0,0 +1,58 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var getTrafficMatrix = &ffapi.Route{
+	Name:       "getTrafficMatrix",
+	Path:       "traffic",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "startTime", Description: coremsgs.APITrafficStartTimeParam, IsBool: false},
+		{Name: "endTime", Description: coremsgs.APITrafficEndTimeParam, IsBool: false},
+	},
+	Description:     coremsgs.APIEndpointsGetTrafficMatrix,
+	JSONInputValue:  nil,
+	JSONOutputValue: func() interface{} { return &core.TrafficMatrix{} },
+	JSONOutputCodes: []int{http.StatusOK},
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			var startTime, endTime *fftypes.FFTime
+			if r.QP["startTime"] != "" {
+				if startTime, err = fftypes.ParseTimeString(r.QP["startTime"]); err != nil {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgInvalidTimeParam, "startTime")
+				}
+			}
+			if r.QP["endTime"] != "" {
+				if endTime, err = fftypes.ParseTimeString(r.QP["endTime"]); err != nil {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgInvalidTimeParam, "endTime")
+				}
+			}
+			return cr.or.GetTrafficMatrix(cr.ctx, startTime, endTime)
+		},
+	},
+}
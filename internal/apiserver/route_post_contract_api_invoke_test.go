@@ -31,6 +31,7 @@ import (
 func TestPostContractAPIInvoke(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mcm := &contractmocks.Manager{}
 	o.On("Contracts").Return(mcm)
 	input := core.Datatype{}
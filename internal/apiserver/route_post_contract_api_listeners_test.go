@@ -31,6 +31,7 @@ import (
 func TestPostContractAPIListen(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeContractListeners, int64(1)).Return(nil)
 	mcm := &contractmocks.Manager{}
 	o.On("Contracts").Return(mcm)
 	input := core.Datatype{}
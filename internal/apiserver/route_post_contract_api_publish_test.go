@@ -31,6 +31,7 @@ import (
 func TestPostContractAPIPublish(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mds := &definitionsmocks.Sender{}
 	o.On("DefinitionSender").Return(mds)
 	input := core.DefinitionPublish{NetworkName: "banana-net"}
@@ -59,6 +59,10 @@ var postData = &ffapi.Route{
 			if !cr.or.Data().BlobsEnabled() {
 				return nil, i18n.NewError(r.Req.Context(), coremsgs.MsgActionNotSupported)
 			}
+			// The length of the multipart request includes the form fields, so is an upper bound on the size of the blob
+			if err := cr.or.CheckQuota(cr.ctx, core.QuotaTypeBlobBytes, r.Req.ContentLength); err != nil {
+				return nil, err
+			}
 
 			data := &core.DataRefOrValue{}
 			validator := r.FP["validator"]
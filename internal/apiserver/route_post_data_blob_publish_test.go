@@ -33,6 +33,7 @@ import (
 func TestPostDataBlobPublish(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mdm := &datamocks.Manager{}
 	o.On("Data").Return(mdm)
 	mbm := &broadcastmocks.Manager{}
@@ -79,6 +79,7 @@ func TestPostDataBinary(t *testing.T) {
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
 	mdm := &datamocks.Manager{}
 	mdm.On("BlobsEnabled").Return(true)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeBlobBytes, mock.Anything).Return(nil)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
 	o.On("Data").Return(mdm)
 
@@ -107,6 +108,7 @@ func TestPostDataBinaryObjAutoMeta(t *testing.T) {
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
 	mdm := &datamocks.Manager{}
 	mdm.On("BlobsEnabled").Return(true)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeBlobBytes, mock.Anything).Return(nil)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
 	o.On("Data").Return(mdm)
 
@@ -156,6 +158,7 @@ func TestPostDataBinaryStringMetadata(t *testing.T) {
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
 	mdm := &datamocks.Manager{}
 	mdm.On("BlobsEnabled").Return(true)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeBlobBytes, mock.Anything).Return(nil)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
 	o.On("Data").Return(mdm)
 
@@ -192,6 +195,7 @@ func TestPostDataTrailingMetadata(t *testing.T) {
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
 	mdm := &datamocks.Manager{}
 	mdm.On("BlobsEnabled").Return(true)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeBlobBytes, mock.Anything).Return(nil)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
 	o.On("Data").Return(mdm)
 
@@ -226,6 +230,7 @@ func TestPostDataBinaryMissing(t *testing.T) {
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
 	mdm := &datamocks.Manager{}
 	mdm.On("BlobsEnabled").Return(true)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeBlobBytes, mock.Anything).Return(nil)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
 	o.On("Data").Return(mdm)
 
@@ -253,6 +258,7 @@ func TestPostDataBadForm(t *testing.T) {
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
 	mdm := &datamocks.Manager{}
 	mdm.On("BlobsEnabled").Return(true)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeBlobBytes, mock.Anything).Return(nil)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
 	o.On("Data").Return(mdm)
 
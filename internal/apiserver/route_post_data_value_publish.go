@@ -38,6 +38,7 @@ var postDataValuePublish = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.Data{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		Submission: true,
 		EnabledIf: func(or orchestrator.Orchestrator) bool {
 			return or.Broadcast() != nil
 		},
@@ -0,0 +1,96 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/datamocks"
+	"github.com/hyperledger/firefly/mocks/definitionsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestDatatypeInferRequest(query string) *http.Request {
+	input := core.DatatypeInference{
+		Name:    "widget",
+		Version: "1.0.0",
+		Samples: []*fftypes.JSONAny{fftypes.JSONAnyPtr(`{"id": 1}`)},
+	}
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(&input)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/datatypes/infer"+query, &buf)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return req
+}
+
+func TestPostDatatypeInferPreview(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	res := httptest.NewRecorder()
+
+	mdm.On("InferDatatype", mock.Anything, mock.AnythingOfType("*core.DatatypeInference")).
+		Return(&core.Datatype{Name: "widget", Version: "1.0.0"}, nil)
+	r.ServeHTTP(res, newTestDatatypeInferRequest(""))
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	mdm.AssertExpectations(t)
+}
+
+func TestPostDatatypeInferPublish(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	mds := &definitionsmocks.Sender{}
+	o.On("DefinitionSender").Return(mds)
+	res := httptest.NewRecorder()
+
+	dt := &core.Datatype{Name: "widget", Version: "1.0.0"}
+	mdm.On("InferDatatype", mock.Anything, mock.AnythingOfType("*core.DatatypeInference")).Return(dt, nil)
+	mds.On("DefineDatatype", mock.Anything, dt, false).Return(nil)
+	r.ServeHTTP(res, newTestDatatypeInferRequest("?publish=true"))
+
+	assert.Equal(t, 202, res.Result().StatusCode)
+	mdm.AssertExpectations(t)
+	mds.AssertExpectations(t)
+}
+
+func TestPostDatatypeInferFail(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	res := httptest.NewRecorder()
+
+	mdm.On("InferDatatype", mock.Anything, mock.AnythingOfType("*core.DatatypeInference")).Return(nil, fmt.Errorf("pop"))
+	r.ServeHTTP(res, newTestDatatypeInferRequest("?publish=true"))
+
+	assert.Equal(t, 500, res.Result().StatusCode)
+}
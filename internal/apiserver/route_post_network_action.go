@@ -36,6 +36,8 @@ var postNetworkAction = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.NetworkAction{} },
 	JSONOutputCodes: []int{http.StatusAccepted},
 	Extensions: &coreExtensions{
+		Permission: core.APIPermissionAdmin,
+		Submission: true,
 		EnabledIf: func(or orchestrator.Orchestrator) bool {
 			return or.MultiParty() != nil
 		},
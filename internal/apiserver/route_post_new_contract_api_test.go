@@ -32,6 +32,7 @@ import (
 func TestPostNewContractAPI(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mds := &definitionsmocks.Sender{}
 	o.On("Contracts").Return(&contractmocks.Manager{})
 	o.On("DefinitionSender").Return(mds)
@@ -51,6 +52,7 @@ func TestPostNewContractAPI(t *testing.T) {
 func TestPostNewContractAPISync(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mds := &definitionsmocks.Sender{}
 	o.On("Contracts").Return(&contractmocks.Manager{})
 	o.On("DefinitionSender").Return(mds)
@@ -32,6 +32,7 @@ import (
 func TestPostNewDatatypes(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mds := &definitionsmocks.Sender{}
 	o.On("DefinitionSender").Return(mds)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
@@ -51,6 +52,7 @@ func TestPostNewDatatypes(t *testing.T) {
 func TestPostNewDatatypesSync(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mds := &definitionsmocks.Sender{}
 	o.On("DefinitionSender").Return(mds)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
@@ -32,6 +32,8 @@ import (
 func TestPostNewMessageBroadcast(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeMessagesPerDay, int64(1)).Return(nil)
 	mmp := &multipartymocks.Manager{}
 	o.On("MultiParty").Return(mmp)
 	mbm := &broadcastmocks.Manager{}
@@ -53,6 +55,8 @@ func TestPostNewMessageBroadcast(t *testing.T) {
 func TestPostNewMessageBroadcastSync(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeMessagesPerDay, int64(1)).Return(nil)
 	mmp := &multipartymocks.Manager{}
 	o.On("MultiParty").Return(mmp)
 	mbm := &broadcastmocks.Manager{}
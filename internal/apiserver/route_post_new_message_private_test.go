@@ -32,6 +32,8 @@ import (
 func TestPostNewMessagePrivate(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeMessagesPerDay, int64(1)).Return(nil)
 	mmp := &multipartymocks.Manager{}
 	o.On("MultiParty").Return(mmp)
 	mpm := &privatemessagingmocks.Manager{}
@@ -53,6 +55,8 @@ func TestPostNewMessagePrivate(t *testing.T) {
 func TestPostNewMessagePrivateSync(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeMessagesPerDay, int64(1)).Return(nil)
 	mmp := &multipartymocks.Manager{}
 	o.On("MultiParty").Return(mmp)
 	mpm := &privatemessagingmocks.Manager{}
@@ -36,9 +36,11 @@ var postNewMessageRequestReply = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.MessageInOut{} },
 	JSONOutputCodes: []int{http.StatusOK}, // Sync operation
 	Extensions: &coreExtensions{
+		Submission: true,
 		EnabledIf: func(or orchestrator.Orchestrator) bool {
 			return or.MultiParty() != nil
 		},
+		Quota: core.QuotaTypeMessagesPerDay,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.RequestReply(cr.ctx, r.Input.(*core.MessageInOut))
 			return output, err
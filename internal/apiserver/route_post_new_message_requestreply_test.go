@@ -32,6 +32,8 @@ import (
 func TestPostNewMessageRequestReply(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeMessagesPerDay, int64(1)).Return(nil)
 	o.On("PrivateMessaging").Return(&privatemessagingmocks.Manager{})
 	o.On("RequestReply", mock.Anything, mock.Anything).Return(&core.MessageInOut{}, nil)
 	mmp := &multipartymocks.Manager{}
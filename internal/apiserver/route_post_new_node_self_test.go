@@ -32,6 +32,7 @@ import (
 func TestPostNewNodeSelf(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mnm := &networkmapmocks.Manager{}
 	o.On("NetworkMap").Return(mnm)
 	o.On("MultiParty").Return(&multipartymocks.Manager{})
@@ -18,10 +18,13 @@ package apiserver
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -30,6 +33,7 @@ import (
 func TestPostNewSubscription(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeSubscriptions, int64(1)).Return(nil)
 	input := core.Subscription{}
 	var buf bytes.Buffer
 	json.NewEncoder(&buf).Encode(&input)
@@ -43,3 +47,20 @@ func TestPostNewSubscription(t *testing.T) {
 
 	assert.Equal(t, 201, res.Result().StatusCode)
 }
+
+func TestPostNewSubscriptionQuotaExceeded(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckQuota", mock.Anything, core.QuotaTypeSubscriptions, int64(1)).Return(i18n.NewError(context.Background(), coremsgs.MsgNamespaceQuotaExceeded, "ns1", core.QuotaTypeSubscriptions, 10))
+	input := core.Subscription{}
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(&input)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/subscriptions", &buf)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 429, res.Result().StatusCode)
+	o.AssertNotCalled(t, "CreateSubscription", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,57 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var postOpApprovalApprove = &ffapi.Route{
+	Name:   "postOpApprovalApprove",
+	Path:   "operationapprovals/{approvalid}/approve",
+	Method: http.MethodPost,
+	PathParams: []*ffapi.PathParam{
+		{Name: "approvalid", Description: coremsgs.OperationApprovalID},
+	},
+	QueryParams:     []*ffapi.QueryParam{},
+	Description:     coremsgs.APIEndpointsPostOpApprovalApprove,
+	JSONInputValue:  func() interface{} { return &core.EmptyInput{} },
+	JSONOutputValue: func() interface{} { return &core.OperationApproval{} },
+	JSONOutputCodes: []int{http.StatusAccepted},
+	Extensions: &coreExtensions{
+		Submission: true,
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			approvalID, err := fftypes.ParseUUID(cr.ctx, r.PP["approvalid"])
+			if err != nil {
+				return nil, err
+			}
+			return cr.or.Operations().ApproveOperation(cr.ctx, approvalID, approverIdentity(r.Req))
+		},
+	},
+}
+
+// approverIdentity is the principal the auth plugin of the namespace authenticated the caller as. Without an
+// auth plugin there is no authenticated principal, and approvals are refused, as approvals must come from
+// distinct users and nothing else in the request can be trusted to identify them.
+func approverIdentity(req *http.Request) string {
+	return core.GetAuthPrincipal(req.Context())
+}
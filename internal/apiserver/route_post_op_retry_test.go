@@ -32,6 +32,7 @@ import (
 func TestPostOpRetry(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mom := &operationmocks.Manager{}
 	o.On("Operations").Return(mom)
 	input := core.EmptyInput{}
@@ -52,6 +53,7 @@ func TestPostOpRetry(t *testing.T) {
 func TestPostOpRetryBadID(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	input := core.EmptyInput{}
 	var buf bytes.Buffer
 	json.NewEncoder(&buf).Encode(&input)
@@ -32,6 +32,7 @@ import (
 func TestPostTokenApproval(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mam := &assetmocks.Manager{}
 	o.On("Assets").Return(mam)
 	input := fftypes.JSONObject{}
@@ -52,6 +53,7 @@ func TestPostTokenApproval(t *testing.T) {
 func TestPostTokenApprovalUnapprove(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mam := &assetmocks.Manager{}
 	o.On("Assets").Return(mam)
 	input := fftypes.JSONObject{"approved": false}
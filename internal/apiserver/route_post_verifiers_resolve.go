@@ -36,6 +36,7 @@ var postVerifiersResolve = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.VerifierRef{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		Permission: core.APIPermissionRead,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return cr.or.Identity().ResolveInputVerifierRef(cr.ctx, r.Input.(*core.VerifierRef),
 				blockchain.ResolveKeyIntentLookup, /* This is special - as we are not actually submitting a signing request */
@@ -32,6 +32,7 @@ import (
 func TestPutContractAPI(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mds := &definitionsmocks.Sender{}
 	o.On("DefinitionSender").Return(mds)
 	o.On("Contracts").Return(&contractmocks.Manager{})
@@ -51,6 +52,7 @@ func TestPutContractAPI(t *testing.T) {
 func TestPutContractAPISync(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("CheckWritable", mock.Anything).Return(nil)
 	mds := &definitionsmocks.Sender{}
 	o.On("DefinitionSender").Return(mds)
 	o.On("Contracts").Return(&contractmocks.Manager{})
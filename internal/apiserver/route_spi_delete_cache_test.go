@@ -0,0 +1,55 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSPIDeleteCache(t *testing.T) {
+	coreconfig.Reset()
+	mgr, _, as := newTestServer()
+	r := as.createAdminMuxRouter(mgr)
+	req := httptest.NewRequest("DELETE", "/spi/v1/caches/ns1:cache.batch", nil)
+	res := httptest.NewRecorder()
+
+	mgr.On("InvalidateCache", mock.Anything, "ns1:cache.batch").Return(nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 204, res.Result().StatusCode)
+}
+
+func TestSPIDeleteCacheNotFound(t *testing.T) {
+	coreconfig.Reset()
+	mgr, _, as := newTestServer()
+	r := as.createAdminMuxRouter(mgr)
+	req := httptest.NewRequest("DELETE", "/spi/v1/caches/ns1:cache.unknown", nil)
+	res := httptest.NewRecorder()
+
+	mgr.On("InvalidateCache", mock.Anything, "ns1:cache.unknown").
+		Return(i18n.NewError(req.Context(), coremsgs.MsgCacheNotFound, "ns1:cache.unknown"))
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 404, res.Result().StatusCode)
+}
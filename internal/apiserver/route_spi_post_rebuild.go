@@ -0,0 +1,51 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var spiPostRebuild = &ffapi.Route{
+	Name:   "spiPostRebuild",
+	Path:   "namespaces/{ns}/rebuild/{target}",
+	Method: http.MethodPost,
+	PathParams: []*ffapi.PathParam{
+		{Name: "target", Description: coremsgs.APIParamsRebuildTarget},
+	},
+	QueryParams:     nil,
+	Description:     coremsgs.APIEndpointsAdminPostRebuild,
+	JSONInputValue:  func() interface{} { return &core.EmptyInput{} },
+	JSONOutputValue: func() interface{} { return &core.RebuildStatus{} },
+	JSONOutputCodes: []int{http.StatusAccepted},
+	Tag:             routeTagNonDefaultNamespace,
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			target, err := fftypes.FFEnumParseString(cr.ctx, "rebuildtarget", r.PP["target"])
+			if err != nil {
+				return nil, i18n.NewError(cr.ctx, coremsgs.MsgRebuildUnknownTarget, r.PP["target"])
+			}
+			return cr.or.RebuildDerivedState(cr.ctx, target)
+		},
+	},
+}
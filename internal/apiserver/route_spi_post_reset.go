@@ -18,24 +18,32 @@ package apiserver
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
 )
 
 var spiPostReset = &ffapi.Route{
-	Name:            "spiPostReset",
-	Path:            "reset",
-	Method:          http.MethodPost,
-	PathParams:      nil,
-	QueryParams:     nil,
-	FilterFactory:   nil,
+	Name:          "spiPostReset",
+	Path:          "reset",
+	Method:        http.MethodPost,
+	PathParams:    nil,
+	FilterFactory: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "dryRun", Example: "true", Description: coremsgs.APIParamsConfigReloadDryRun, IsBool: true},
+	},
 	Description:     coremsgs.APIEndpointsAdminPostReset,
 	JSONInputValue:  nil,
-	JSONOutputValue: nil,
-	JSONOutputCodes: []int{http.StatusNoContent},
+	JSONOutputValue: func() interface{} { return &core.ConfigReloadPreview{} },
+	JSONOutputCodes: []int{http.StatusNoContent, http.StatusOK},
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			if strings.EqualFold(r.QP["dryRun"], "true") {
+				r.SuccessStatus = http.StatusOK
+				return cr.mgr.PreviewConfigReload(cr.ctx)
+			}
 			return nil, cr.mgr.Reset(cr.ctx)
 		},
 	},
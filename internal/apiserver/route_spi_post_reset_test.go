@@ -18,9 +18,11 @@ package apiserver
 
 import (
 	"bytes"
+	"encoding/json"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -37,3 +39,25 @@ func TestAdminPostResetConfig(t *testing.T) {
 
 	assert.Equal(t, 204, res.Result().StatusCode)
 }
+
+func TestAdminPostResetConfigDryRun(t *testing.T) {
+	mgr, _, as := newTestServer()
+	r := as.createAdminMuxRouter(mgr)
+	req := httptest.NewRequest("POST", "/spi/v1/reset?dryRun=true", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	mgr.On("PreviewConfigReload", mock.Anything).Return(&core.ConfigReloadPreview{
+		Namespaces: []*core.ConfigReloadChange{
+			{Name: "ns1", Action: core.ConfigReloadActionRestarted, Changes: []string{"plugin:erc20"}},
+		},
+	}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	var preview core.ConfigReloadPreview
+	err := json.NewDecoder(res.Body).Decode(&preview)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1", preview.Namespaces[0].Name)
+	mgr.AssertNotCalled(t, "Reset", mock.Anything)
+}
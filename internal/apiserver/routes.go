@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/namespace"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
 )
 
 type coreRequest struct {
@@ -37,6 +38,10 @@ type coreExtensions struct {
 	EnabledIf             func(or orchestrator.Orchestrator) bool
 	CoreJSONHandler       func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error)
 	CoreFormUploadHandler func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error)
+	Quota                 core.QuotaType     // if set, the namespace quota of this type is checked before calling the JSON handler
+	Submission            bool               // if set, the route submits to the network, so is rejected on a read-only namespace
+	StreamPage            streamPageHandler  // if set, the route streams every matching item as NDJSON when the client accepts application/x-ndjson
+	Permission            core.APIPermission // if set, overrides the permission auth plugins require for the route - by default read for GET, and send otherwise
 }
 
 const (
@@ -48,6 +53,8 @@ const (
 var nsRoutes = []*ffapi.Route{}
 var routes = append(
 	globalRoutes([]*ffapi.Route{
+		getErrorCodeByCode,
+		getErrorCodes,
 		getNamespace,
 		getNamespaces,
 		getWebSockets,
@@ -57,10 +64,17 @@ var routes = append(
 		deleteContractInterface,
 		deleteContractListener,
 		deleteData,
+		deleteIdempotencyKey,
+		deleteMsgRetentionPolicy,
 		deleteSubscription,
 		deleteTokenPool,
+		getActivity,
+		getBatchAcks,
 		getBatchByID,
 		getBatches,
+		getBatchVerify,
+		getBlobHashBatches,
+		getBlobHashMsgs,
 		getBlockchainEventByID,
 		getBlockchainEvents,
 		getChartHistogram,
@@ -78,23 +92,43 @@ var routes = append(
 		getDataSubPaths,
 		getDataValue,
 		getDataByID,
+		getDataHashBatches,
+		getDataHashMsgs,
 		getDataMsgs,
 		getDatatypeByName,
 		getDatatypes,
+		getDisclosureByID,
+		getDisclosures,
 		getEventByID,
 		getEvents,
+		getFeeSummary,
+		getFees,
 		getGroupByHash,
+		getGroupsByName,
+		getGroupLatency,
+		getGroupMemberStatus,
 		getGroups,
+		getIdempotencyKey,
+		getIdempotencyKeys,
 		getIdentities,
 		getIdentityByDID,
 		getIdentityByID,
 		getIdentityDID,
 		getIdentityVerifiers,
+		getLedgerBalances,
+		getLedgerTransactions,
+		getMsgApproval,
+		getMsgBatchVerify,
 		getMsgByID,
 		getMsgData,
 		getMsgEvents,
+		getMsgRetentionPolicies,
+		getMsgRetentionPolicyByID,
 		getMsgs,
 		getMsgTxn,
+		getNamespaceExport,
+		getNamespaceSnapshot,
+		getNetworkActions,
 		getNetworkDIDDocByDID,
 		getNetworkIdentities,
 		getNetworkIdentityByDID,
@@ -103,15 +137,28 @@ var routes = append(
 		getNetworkOrg,
 		getNetworkOrgs,
 		getNextPins,
+		getOpApprovalByID,
+		getOpApprovals,
 		getOpByID,
+		getOpHistory,
+		getOpOutputSchemas,
 		getOps,
 		getPins,
+		getRetentionEstimate,
+		getSearch,
 		getStatus,
 		getStatusMultiparty,
+		getStatusBootstrap,
+		getStatusHealth,
+		getStatusSLOs,
+		getStatusListenerReconciliation,
+		getStatusCatchUp,
+		getStatusSnapshot,
 		getStatusBatchManager,
 		getSubscriptionByID,
 		getSubscriptions,
 		getSubscriptionEventsFiltered,
+		getSubscriptionOffset,
 		getTokenAccountPools,
 		getTokenAccounts,
 		getTokenApprovals,
@@ -121,6 +168,7 @@ var routes = append(
 		getTokenPools,
 		getTokenTransferByID,
 		getTokenTransfers,
+		getTrafficMatrix,
 		getTxnBlockchainEvents,
 		getTxnByID,
 		getTxnOps,
@@ -139,11 +187,21 @@ var routes = append(
 		postContractInterfacePublish,
 		postContractDeploy,
 		postContractInvoke,
+		postContractInvokeSequence,
 		postContractQuery,
 		postData,
 		postDataBlobPublish,
 		postDataValuePublish,
+		postDatatypeInfer,
+		postGraphQL,
+		postGroupMetadata,
+		postMsgApprove,
+		postMsgDisclosure,
+		postMsgRelease,
+		postNamespaceSnapshot,
 		postNetworkAction,
+		postNetworkMigration,
+		postNetworkMigrationAck,
 		postNewContractAPI,
 		postNewContractInterface,
 		postNewContractListener,
@@ -152,11 +210,15 @@ var routes = append(
 		postNewMessageBroadcast,
 		postNewMessagePrivate,
 		postNewMessageRequestReply,
+		postNewMsgRetentionPolicy,
 		postNewSubscription,
+		postSubscriptionEventsAck,
 		postNewOrganization,
 		postNewOrganizationSelf,
 		postNodesSelf,
+		postOpApprovalApprove,
 		postOpRetry,
+		postOpsRetry,
 		postPinsRewind,
 		postTokenApproval,
 		postTokenBurn,
@@ -165,7 +227,9 @@ var routes = append(
 		postTokenPoolPublish,
 		postTokenTransfer,
 		putContractAPI,
+		putMsgRetentionPolicy,
 		putSubscription,
+		putSubscriptionOffset,
 		postVerifiersResolve,
 	})...,
 )
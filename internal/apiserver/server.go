@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gorilla/mux"
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
@@ -38,6 +39,9 @@ import (
 	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/namespace"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/internal/tracing"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -214,19 +218,18 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 	// We also pass the Orchestrator context through
 	ce := route.Extensions.(*coreExtensions)
 	route.JSONHandler = func(r *ffapi.APIRequest) (output interface{}, err error) {
+		if r.Req.Method == http.MethodGet {
+			// Reads made by the API can be served by a database read replica, where one is configured
+			r.Req = r.Req.WithContext(database.WithReplicaReads(r.Req.Context()))
+		}
 		or, err := getOrchestrator(r.Req.Context(), mgr, route.Tag, r)
 		if err != nil {
 			return nil, err
 		}
+		noteAudit(r, route, or)
 
-		// Authorize the request
-		authReq := &fftypes.AuthReq{
-			Method: r.Req.Method,
-			URL:    r.Req.URL,
-			Header: r.Req.Header,
-		}
 		if or != nil {
-			if err := or.Authorize(r.Req.Context(), authReq); err != nil {
+			if err := authorizeRequest(r, route, or, fixedBaseURL != ""); err != nil {
 				return nil, err
 			}
 		}
@@ -235,6 +238,24 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 			return nil, i18n.NewError(r.Req.Context(), coremsgs.MsgActionNotSupported)
 		}
 
+		if ce.Submission && or != nil {
+			if err := or.CheckWritable(r.Req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		if ce.Quota != "" && or != nil {
+			if err := or.CheckQuota(r.Req.Context(), ce.Quota, 1); err != nil {
+				return nil, err
+			}
+		}
+
+		if or != nil {
+			if err := checkPolicy(r, route, or); err != nil {
+				return nil, err
+			}
+		}
+
 		apiBaseURL := fixedBaseURL // for SPI
 		if apiBaseURL == "" {
 			apiBaseURL = as.getBaseURL(r.Req)
@@ -245,7 +266,26 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 			ctx:        r.Req.Context(),
 			apiBaseURL: apiBaseURL,
 		}
-		return ce.CoreJSONHandler(r, cr)
+		stream := ce.StreamPage != nil && acceptsNDJSON(r.Req)
+		order, err := applyCursor(cr.ctx, route, r, stream)
+		if err != nil {
+			return nil, err
+		}
+		fields := parseFieldSelection(r.Req)
+		if stream {
+			return streamNDJSON(r, cr, route, order, fields, ce.StreamPage)
+		}
+		output, err = ce.CoreJSONHandler(r, cr)
+		if err != nil {
+			return nil, err
+		}
+		if order != nil {
+			order.setNextCursor(r, output)
+		}
+		if fields != nil {
+			return fields.apply(output)
+		}
+		return output, nil
 	}
 	if ce.CoreFormUploadHandler != nil {
 		route.FormUploadHandler = func(r *ffapi.APIRequest) (output interface{}, err error) {
@@ -253,9 +293,20 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 			if err != nil {
 				return nil, err
 			}
+			noteAudit(r, route, or)
+			if or != nil {
+				if err := authorizeRequest(r, route, or, fixedBaseURL != ""); err != nil {
+					return nil, err
+				}
+			}
 			if ce.EnabledIf != nil && !ce.EnabledIf(or) {
 				return nil, i18n.NewError(r.Req.Context(), coremsgs.MsgActionNotSupported)
 			}
+			if or != nil {
+				if err := checkPolicy(r, route, or); err != nil {
+					return nil, err
+				}
+			}
 
 			apiBaseURL := fixedBaseURL // for SPI
 			if apiBaseURL == "" {
@@ -270,7 +321,44 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 			return ce.CoreFormUploadHandler(r, cr)
 		}
 	}
-	return hf.RouteHandler(route)
+	handler := hf.RouteHandler(route)
+	if ce.StreamPage != nil {
+		handler = withNDJSON(handler)
+	}
+	return withAudit(route, withStructuredErrors(withConditionalRequests(handler)))
+}
+
+// authorizeRequest authorizes the request with the auth plugin of the namespace, which records the principal it
+// authenticates the caller as on the context of the request, and then uses one request of the caller's rate limit
+func authorizeRequest(r *ffapi.APIRequest, route *ffapi.Route, or orchestrator.Orchestrator, spi bool) error {
+	r.Req = r.Req.WithContext(core.WithAuthPrincipal(r.Req.Context()))
+	authReq := &fftypes.AuthReq{
+		Method: r.Req.Method,
+		URL:    r.Req.URL,
+		Header: r.Req.Header,
+	}
+	authCtx := core.WithAPIPermission(r.Req.Context(), routePermission(route, route.Extensions.(*coreExtensions), spi))
+	if err := or.Authorize(authCtx, authReq); err != nil {
+		return err
+	}
+	if spi {
+		return nil
+	}
+	return checkRateLimit(r, route, or)
+}
+
+// routePermission returns the permission role-based auth plugins check the caller has in the namespace
+func routePermission(route *ffapi.Route, ce *coreExtensions, spi bool) core.APIPermission {
+	switch {
+	case spi:
+		return core.APIPermissionSPI
+	case ce.Permission != "":
+		return ce.Permission
+	case route.Method == http.MethodGet || route.Method == http.MethodHead:
+		return core.APIPermissionRead
+	default:
+		return core.APIPermissionSend
+	}
 }
 
 func (as *apiServer) handlerFactory() *ffapi.HandlerFactory {
@@ -299,9 +387,27 @@ func (as *apiServer) nsOpenAPIHandlerFactory(req *http.Request, publicURL string
 	}
 }
 
-func (as *apiServer) namespacedSwaggerHandler(hf *ffapi.HandlerFactory, r *mux.Router, publicURL, relativePath string, format ffapi.OpenAPIFormat) {
+// The operation routes of a namespaced swagger are annotated with the output schemas the plugins
+// of that namespace have declared for each operation type, when the namespace is available.
+func (as *apiServer) operationOutputSchemaCustomizer(schemas []*core.OperationOutputSchema) func(ctx context.Context, sg *ffapi.SwaggerGen, route *ffapi.Route, op *openapi3.Operation) {
+	return func(ctx context.Context, sg *ffapi.SwaggerGen, route *ffapi.Route, op *openapi3.Operation) {
+		switch route.Name {
+		case getOpByID.Name, getOps.Name, getTxnOps.Name:
+			if op.Extensions == nil {
+				op.Extensions = map[string]interface{}{}
+			}
+			op.Extensions["x-firefly-operation-output-schemas"] = schemas
+		}
+	}
+}
+
+func (as *apiServer) namespacedSwaggerHandler(hf *ffapi.HandlerFactory, r *mux.Router, mgr namespace.Manager, publicURL, relativePath string, format ffapi.OpenAPIFormat) {
 	r.HandleFunc(`/api/v1/namespaces/{ns}`+relativePath, hf.APIWrapper(func(res http.ResponseWriter, req *http.Request) (status int, err error) {
-		return as.nsOpenAPIHandlerFactory(req, publicURL).OpenAPIHandler("", ffapi.OpenAPIFormatJSON, nsRoutes)(res, req)
+		oaf := as.nsOpenAPIHandlerFactory(req, publicURL)
+		if or, nsErr := mgr.Orchestrator(req.Context(), mux.Vars(req)["ns"], false); nsErr == nil && or.Operations() != nil {
+			oaf.BaseSwaggerGenOptions.RouteCustomizations = as.operationOutputSchemaCustomizer(or.Operations().GetOutputSchemas())
+		}
+		return oaf.OpenAPIHandler("", ffapi.OpenAPIFormatJSON, nsRoutes)(res, req)
 	}))
 }
 
@@ -359,6 +465,8 @@ func (as *apiServer) createMuxRouter(ctx context.Context, mgr namespace.Manager)
 	if as.deprecatedMetricsEnabled || as.monitoringEnabled {
 		r.Use(metrics.GetRestServerInstrumentation().Middleware)
 	}
+	r.Use(tracing.Middleware)
+	r.Use(tracing.CorrelationMiddleware)
 
 	for _, route := range routes {
 		if ce, ok := route.Extensions.(*coreExtensions); ok {
@@ -383,10 +491,10 @@ func (as *apiServer) createMuxRouter(ctx context.Context, mgr namespace.Manager)
 	r.HandleFunc(`/api/openapi.yaml`, hf.APIWrapper(oaf.OpenAPIHandler(`/api/v1`, ffapi.OpenAPIFormatYAML, routes)))
 	r.HandleFunc(`/api`, hf.APIWrapper(oaf.SwaggerUIHandler(`/api/openapi.yaml`)))
 	// Namespace relative APIs
-	as.namespacedSwaggerHandler(hf, r, as.apiPublicURL, `/api/swagger.json`, ffapi.OpenAPIFormatJSON)
-	as.namespacedSwaggerHandler(hf, r, as.apiPublicURL, `/api/openapi.json`, ffapi.OpenAPIFormatJSON)
-	as.namespacedSwaggerHandler(hf, r, as.apiPublicURL, `/api/swagger.yaml`, ffapi.OpenAPIFormatYAML)
-	as.namespacedSwaggerHandler(hf, r, as.apiPublicURL, `/api/openapi.yaml`, ffapi.OpenAPIFormatYAML)
+	as.namespacedSwaggerHandler(hf, r, mgr, as.apiPublicURL, `/api/swagger.json`, ffapi.OpenAPIFormatJSON)
+	as.namespacedSwaggerHandler(hf, r, mgr, as.apiPublicURL, `/api/openapi.json`, ffapi.OpenAPIFormatJSON)
+	as.namespacedSwaggerHandler(hf, r, mgr, as.apiPublicURL, `/api/swagger.yaml`, ffapi.OpenAPIFormatYAML)
+	as.namespacedSwaggerHandler(hf, r, mgr, as.apiPublicURL, `/api/openapi.yaml`, ffapi.OpenAPIFormatYAML)
 	as.namespacedSwaggerUI(hf, r, as.apiPublicURL, `/api`)
 	// Dynamic swagger for namespaced contract APIs
 	as.namespacedContractSwaggerGenerator(hf, r, mgr, as.apiPublicURL, `/api/swagger.json`, ffapi.OpenAPIFormatJSON)
@@ -447,6 +555,8 @@ func (as *apiServer) createAdminMuxRouter(mgr namespace.Manager) *mux.Router {
 	if as.deprecatedMetricsEnabled || as.monitoringEnabled {
 		r.Use(metrics.GetAdminServerInstrumentation().Middleware)
 	}
+	r.Use(tracing.Middleware)
+	r.Use(tracing.CorrelationMiddleware)
 	hf := as.handlerFactory()
 
 	publicURL := as.getPublicURL(spiConfig, "spi")
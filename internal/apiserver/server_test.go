@@ -42,10 +42,12 @@ import (
 	"github.com/hyperledger/firefly/mocks/apiservermocks"
 	"github.com/hyperledger/firefly/mocks/contractmocks"
 	"github.com/hyperledger/firefly/mocks/namespacemocks"
+	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/orchestratormocks"
 	"github.com/hyperledger/firefly/mocks/spieventsmocks"
 	"github.com/hyperledger/firefly/mocks/websocketsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -59,6 +61,8 @@ func newTestServer() (*namespacemocks.Manager, *orchestratormocks.Orchestrator,
 	mgr.On("Orchestrator", mock.Anything, "default", false).Return(o, nil).Maybe()
 	mgr.On("Orchestrator", mock.Anything, "mynamespace", false).Return(o, nil).Maybe()
 	mgr.On("Orchestrator", mock.Anything, "ns1", false).Return(o, nil).Maybe()
+	o.On("CheckRateLimit", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	o.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil).Maybe()
 	config.Set(coreconfig.APIMaxFilterLimit, 100)
 	as := NewAPIServer().(*apiServer)
 	return mgr, o, as
@@ -217,6 +221,28 @@ func TestUnauthorized(t *testing.T) {
 	assert.Regexp(t, "FF00169", resJSON["error"])
 }
 
+func TestAuthorizeRoutePermission(t *testing.T) {
+	mgr, o, as := newTestServer()
+	o.On("Authorize", mock.MatchedBy(func(ctx context.Context) bool {
+		return core.GetAPIPermission(ctx, "GET") == core.APIPermissionSPI
+	}), mock.Anything).Return(i18n.NewError(context.Background(), i18n.MsgUnauthorized))
+	handler := as.routeHandler(as.handlerFactory(), mgr, "http://localhost:5101/spi/v1", getBatches)
+
+	req := httptest.NewRequest("GET", "http://localhost:12345/test", nil)
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+	assert.Equal(t, 401, res.Result().StatusCode)
+	o.AssertExpectations(t)
+}
+
+func TestRoutePermission(t *testing.T) {
+	assert.Equal(t, core.APIPermissionRead, routePermission(getBatches, getBatches.Extensions.(*coreExtensions), false))
+	assert.Equal(t, core.APIPermissionSPI, routePermission(getBatches, getBatches.Extensions.(*coreExtensions), true))
+	assert.Equal(t, core.APIPermissionSend, routePermission(postData, postData.Extensions.(*coreExtensions), false))
+	assert.Equal(t, core.APIPermissionRead, routePermission(postGraphQL, postGraphQL.Extensions.(*coreExtensions), false))
+	assert.Equal(t, core.APIPermissionAdmin, routePermission(postNetworkAction, postNetworkAction.Extensions.(*coreExtensions), false))
+}
+
 func TestSwaggerJSON(t *testing.T) {
 	o, r := newTestAPIServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
@@ -232,7 +258,9 @@ func TestSwaggerJSON(t *testing.T) {
 }
 
 func TestNamespacedSwaggerJSON(t *testing.T) {
-	o, r := newTestAPIServer()
+	mgr, o, as := newTestServer()
+	mgr.On("Orchestrator", mock.Anything, "test", false).Return(nil, fmt.Errorf("pop"))
+	r := as.createMuxRouter(context.Background(), mgr)
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
 	s := httptest.NewServer(r)
 	defer s.Close()
@@ -245,6 +273,34 @@ func TestNamespacedSwaggerJSON(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNamespacedSwaggerJSONOperationOutputSchemas(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mom := &operationmocks.Manager{}
+	o.On("Operations").Return(mom)
+	mom.On("GetOutputSchemas").Return([]*core.OperationOutputSchema{
+		{
+			Plugin: "ethereum",
+			Type:   core.OpTypeBlockchainInvoke,
+			Schema: fftypes.JSONAnyPtr(`{"type":"object"}`),
+		},
+	})
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	res, err := http.Get(fmt.Sprintf("http://%s/api/v1/namespaces/ns1/api/swagger.json", s.Listener.Addr()))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+	b, _ := io.ReadAll(res.Body)
+	var doc openapi3.T
+	err = json.Unmarshal(b, &doc)
+	assert.NoError(t, err)
+	ext := doc.Paths.Find("/operations").Get.Extensions["x-firefly-operation-output-schemas"]
+	assert.NotNil(t, ext)
+	assert.Contains(t, string(b), `"blockchain_invoke"`)
+	mom.AssertExpectations(t)
+}
+
 func TestNamespacedSwaggerUI(t *testing.T) {
 	mgr, o, as := newTestServer()
 	r := as.createMuxRouter(context.Background(), mgr)
@@ -582,3 +638,16 @@ func TestMonitoringServerRoutes(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 200, res.StatusCode)
 }
+
+func TestGetRequestsAllowReplicaReads(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetBatches", mock.MatchedBy(func(ctx context.Context) bool {
+		return database.ReplicaReadsAllowed(ctx)
+	}), mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/batches", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 200, res.Result().StatusCode)
+}
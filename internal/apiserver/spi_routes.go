@@ -25,14 +25,47 @@ import (
 // The Service Provider Interface (SPI) allows external microservices (such as the FireFly Transaction Manager)
 // to act as augmented components to the core.
 var spiRoutes = append(globalRoutes([]*ffapi.Route{
+	spiDeleteCache,
+	spiDeleteCacheKey,
+	spiDeleteLogCapture,
+	spiGetCaches,
+	spiGetDiagnostics,
+	spiGetLogCapture,
+	spiGetLogCaptureBundle,
+	spiGetLogLevels,
 	spiGetNamespaceByName,
 	spiGetNamespaces,
 	spiGetOpByID,
 	spiPatchOpByID,
+	spiPostLogCapture,
+	spiPostNamespaceClone,
 	spiPostReset,
+	spiPutLogLevels,
+	spiPutNamespaceConfig,
 }),
 	namespacedSPIRoutes([]*ffapi.Route{
+		spiDeleteLoadTest,
+		spiGetAuditAnchorProof,
+		spiGetAuditAnchorVerify,
+		spiGetAuditAnchors,
+		spiGetAuditRecords,
+		spiGetAuditVerify,
+		spiGetDefinitionRecording,
+		spiGetFaults,
+		spiGetLoadTest,
+		spiGetOnlineMigrations,
 		spiGetOps,
+		spiGetQuotas,
+		spiGetRebuildStatus,
+		spiPostCredentialIssue,
+		spiPostDefinitionReplay,
+		spiPostLoadTest,
+		spiPostNamespaceImport,
+		spiPostOnlineMigrationRun,
+		spiPostRebuild,
+		spiPostSignTypedData,
+		spiPutFaults,
+		spiPutQuotas,
 	})...,
 )
 
@@ -0,0 +1,153 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Clone copies the selected local definitions of this namespace into the target namespace, within a
+// single database group. Every record is given a new ID, and copied definitions are local to the target
+// namespace (unpublished) regardless of whether they were published to the network in this namespace.
+func (am *archiveManager) Clone(ctx context.Context, target string, options *core.NamespaceClone) (map[string]int, error) {
+	copied := make(map[string]int)
+	err := am.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
+		if options.Datatypes {
+			if copied["datatypes"], err = am.cloneDatatypes(ctx, target); err != nil {
+				return err
+			}
+		}
+		if options.ContractAPIs {
+			interfaces, err := am.cloneFFIs(ctx, target)
+			if err != nil {
+				return err
+			}
+			copied["interfaces"] = len(interfaces)
+			if copied["apis"], err = am.cloneContractAPIs(ctx, target, interfaces); err != nil {
+				return err
+			}
+		}
+		if options.Subscriptions {
+			if copied["subscriptions"], err = am.cloneSubscriptions(ctx, target); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Cloned definitions of namespace '%s' into '%s': %v", am.namespace, target, copied)
+	return copied, nil
+}
+
+func (am *archiveManager) cloneDatatypes(ctx context.Context, target string) (int, error) {
+	datatypes, err := getAll(ctx, database.DatatypeQueryFactory, am.pageSize, pageByID(func(datatype *core.Datatype) *fftypes.UUID { return datatype.ID }), func(filter ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error) {
+		return am.database.GetDatatypes(ctx, am.namespace, filter)
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, datatype := range datatypes {
+		datatype.ID = fftypes.NewUUID()
+		datatype.Namespace = target
+		datatype.Message = nil
+		datatype.Created = fftypes.Now()
+		if err := am.database.UpsertDatatype(ctx, datatype, false); err != nil {
+			return 0, err
+		}
+	}
+	return len(datatypes), nil
+}
+
+// cloneFFIs copies all interfaces, returning a map from the original interface ID to the new one
+func (am *archiveManager) cloneFFIs(ctx context.Context, target string) (map[fftypes.UUID]*fftypes.UUID, error) {
+	ffis, err := am.exportFFIs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	interfaces := make(map[fftypes.UUID]*fftypes.UUID, len(ffis))
+	for _, ffi := range ffis {
+		newID := fftypes.NewUUID()
+		interfaces[*ffi.ID] = newID
+		ffi.ID = newID
+		ffi.Message = nil
+		ffi.NetworkName = ""
+		ffi.Published = false
+		for _, method := range ffi.Methods {
+			method.ID = fftypes.NewUUID()
+		}
+		for _, event := range ffi.Events {
+			event.ID = fftypes.NewUUID()
+		}
+		for _, errorDef := range ffi.Errors {
+			errorDef.ID = fftypes.NewUUID()
+		}
+		if err := am.upsertFFI(ctx, target, ffi); err != nil {
+			return nil, err
+		}
+	}
+	return interfaces, nil
+}
+
+func (am *archiveManager) cloneContractAPIs(ctx context.Context, target string, interfaces map[fftypes.UUID]*fftypes.UUID) (int, error) {
+	apis, err := getAll(ctx, database.ContractAPIQueryFactory, am.pageSize, pageByID(func(api *core.ContractAPI) *fftypes.UUID { return api.ID }), func(filter ffapi.AndFilter) ([]*core.ContractAPI, *ffapi.FilterResult, error) {
+		return am.database.GetContractAPIs(ctx, am.namespace, filter)
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, api := range apis {
+		api.ID = fftypes.NewUUID()
+		api.Namespace = target
+		api.Message = nil
+		api.NetworkName = ""
+		api.Published = false
+		if api.Interface != nil && api.Interface.ID != nil {
+			api.Interface = &fftypes.FFIReference{ID: interfaces[*api.Interface.ID]}
+		}
+		if err := am.database.UpsertContractAPI(ctx, api, database.UpsertOptimizationNew); err != nil {
+			return 0, err
+		}
+	}
+	return len(apis), nil
+}
+
+func (am *archiveManager) cloneSubscriptions(ctx context.Context, target string) (int, error) {
+	subs, err := getAll(ctx, database.SubscriptionQueryFactory, am.pageSize, pageByID(func(sub *core.Subscription) *fftypes.UUID { return sub.ID }), func(filter ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error) {
+		return am.database.GetSubscriptions(ctx, am.namespace, filter)
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, sub := range subs {
+		sub.ID = fftypes.NewUUID()
+		sub.Namespace = target
+		sub.Created = fftypes.Now()
+		sub.Updated = nil
+		if err := am.database.UpsertSubscription(ctx, sub, false); err != nil {
+			return 0, err
+		}
+	}
+	return len(subs), nil
+}
@@ -0,0 +1,193 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloneAll(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	ctx := context.Background()
+
+	dtID := fftypes.NewUUID()
+	ffiID := fftypes.NewUUID()
+	apiID := fftypes.NewUUID()
+	subID := fftypes.NewUUID()
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{{ID: dtID, Namespace: "ns1", Message: fftypes.NewUUID()}}, nil, nil)
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{{ID: ffiID, Namespace: "ns1", NetworkName: "net1", Published: true}}, nil, nil)
+	mdi.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIMethod{{ID: fftypes.NewUUID(), Interface: ffiID}}, nil, nil)
+	mdi.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIEvent{{ID: fftypes.NewUUID(), Interface: ffiID}}, nil, nil)
+	mdi.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIError{{ID: fftypes.NewUUID(), Interface: ffiID}}, nil, nil)
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractAPI{{ID: apiID, Interface: &fftypes.FFIReference{ID: ffiID}, Published: true}}, nil, nil)
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{{SubscriptionRef: core.SubscriptionRef{ID: subID, Namespace: "ns1", Name: "sub1"}}}, nil, nil)
+
+	var newFFIID *fftypes.UUID
+	mdi.On("UpsertDatatype", mock.Anything, mock.MatchedBy(func(dt *core.Datatype) bool {
+		return !dt.ID.Equals(dtID) && dt.Namespace == "ns2" && dt.Message == nil
+	}), false).Return(nil)
+	mdi.On("UpsertFFI", mock.Anything, mock.MatchedBy(func(ffi *fftypes.FFI) bool {
+		newFFIID = ffi.ID
+		return !ffi.ID.Equals(ffiID) && ffi.Namespace == "ns2" && ffi.NetworkName == "" && !ffi.Published
+	}), database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertFFIMethod", mock.Anything, mock.MatchedBy(func(method *fftypes.FFIMethod) bool {
+		return method.Interface.Equals(newFFIID) && method.Namespace == "ns2"
+	})).Return(nil)
+	mdi.On("UpsertFFIEvent", mock.Anything, mock.MatchedBy(func(event *fftypes.FFIEvent) bool {
+		return event.Interface.Equals(newFFIID) && event.Namespace == "ns2"
+	})).Return(nil)
+	mdi.On("UpsertFFIError", mock.Anything, mock.MatchedBy(func(errorDef *fftypes.FFIError) bool {
+		return errorDef.Interface.Equals(newFFIID) && errorDef.Namespace == "ns2"
+	})).Return(nil)
+	mdi.On("UpsertContractAPI", mock.Anything, mock.MatchedBy(func(api *core.ContractAPI) bool {
+		return !api.ID.Equals(apiID) && api.Namespace == "ns2" && api.Interface.ID.Equals(newFFIID) && !api.Published
+	}), database.UpsertOptimizationNew).Return(nil)
+	mdi.On("UpsertSubscription", mock.Anything, mock.MatchedBy(func(sub *core.Subscription) bool {
+		return !sub.ID.Equals(subID) && sub.Namespace == "ns2" && sub.Name == "sub1"
+	}), false).Return(nil)
+
+	copied, err := am.Clone(ctx, "ns2", &core.NamespaceClone{
+		Name:          "ns2",
+		Datatypes:     true,
+		ContractAPIs:  true,
+		Subscriptions: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"datatypes":     1,
+		"interfaces":    1,
+		"apis":          1,
+		"subscriptions": 1,
+	}, copied)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneNothingSelected(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	copied, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{Name: "ns2"})
+	assert.NoError(t, err)
+	assert.Empty(t, copied)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneDatatypesFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{{}}, nil, nil)
+	mdi.On("UpsertDatatype", mock.Anything, mock.Anything, false).Return(fmt.Errorf("pop"))
+
+	_, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{Datatypes: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneGetDatatypesFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{Datatypes: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneFFIsFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{ContractAPIs: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneUpsertFFIFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{{ID: fftypes.NewUUID()}}, nil, nil)
+	mdi.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIMethod{}, nil, nil)
+	mdi.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIEvent{}, nil, nil)
+	mdi.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIError{}, nil, nil)
+	mdi.On("UpsertFFI", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(fmt.Errorf("pop"))
+
+	_, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{ContractAPIs: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneContractAPIsFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractAPI{{}}, nil, nil)
+	mdi.On("UpsertContractAPI", mock.Anything, mock.Anything, database.UpsertOptimizationNew).Return(fmt.Errorf("pop"))
+
+	_, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{ContractAPIs: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneGetContractAPIsFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{ContractAPIs: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneSubscriptionsFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{{}}, nil, nil)
+	mdi.On("UpsertSubscription", mock.Anything, mock.Anything, false).Return(fmt.Errorf("pop"))
+
+	_, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{Subscriptions: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCloneGetSubscriptionsFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.Clone(context.Background(), "ns2", &core.NamespaceClone{Subscriptions: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
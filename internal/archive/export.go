@@ -0,0 +1,293 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// pageKey is the unique field a collection is paged on, in ascending order, and how to read it from a record
+type pageKey[T any] struct {
+	field string
+	value func(record T) interface{}
+}
+
+func pageBySequence[T any](sequence func(record T) int64) pageKey[T] {
+	return pageKey[T]{field: "sequence", value: func(record T) interface{} { return sequence(record) }}
+}
+
+func pageByID[T any](id func(record T) *fftypes.UUID) pageKey[T] {
+	return pageKey[T]{field: "id", value: func(record T) interface{} { return id(record).String() }}
+}
+
+func pageByHash[T any](hash func(record T) *fftypes.Bytes32) pageKey[T] {
+	return pageKey[T]{field: "hash", value: func(record T) interface{} { return hash(record).String() }}
+}
+
+// forEachPage pages through a collection in order of its key, until a page returns fewer records than the
+// page size. Each page starts after the key of the last record, so records inserted or deleted while paging
+// cannot cause records to be skipped or returned twice.
+func forEachPage[T any](ctx context.Context, qf ffapi.QueryFactory, pageSize uint64, key pageKey[T], getPage func(filter ffapi.AndFilter) ([]T, *ffapi.FilterResult, error), fn func(records []T) error) error {
+	var last interface{}
+	for {
+		fb := qf.NewFilterLimit(ctx, pageSize)
+		filter := fb.And()
+		if last != nil {
+			filter.Condition(fb.Gt(key.field, last))
+		}
+		filter.Sort(key.field)
+		records, _, err := getPage(filter)
+		if err != nil {
+			return err
+		}
+		if len(records) > 0 {
+			if err := fn(records); err != nil {
+				return err
+			}
+			last = key.value(records[len(records)-1])
+		}
+		if uint64(len(records)) < pageSize {
+			return nil
+		}
+	}
+}
+
+// getAll reads every record of a collection into memory, so should only be used for collections that
+// are small, such as definitions
+func getAll[T any](ctx context.Context, qf ffapi.QueryFactory, pageSize uint64, key pageKey[T], getPage func(filter ffapi.AndFilter) ([]T, *ffapi.FilterResult, error)) ([]T, error) {
+	results := []T{}
+	err := forEachPage(ctx, qf, pageSize, key, getPage, func(records []T) error {
+		results = append(results, records...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// archiveWriter writes the JSON of a core.NamespaceArchive a field at a time, so a collection can be written
+// a page at a time. The first write error is kept, and all later writes are skipped.
+type archiveWriter struct {
+	w      io.Writer
+	err    error
+	fields int
+	items  int
+}
+
+func (aw *archiveWriter) write(s string) {
+	if aw.err == nil {
+		_, aw.err = io.WriteString(aw.w, s)
+	}
+}
+
+func (aw *archiveWriter) value(v interface{}) {
+	if aw.err == nil {
+		var b []byte
+		if b, aw.err = json.Marshal(v); aw.err == nil {
+			_, aw.err = aw.w.Write(b)
+		}
+	}
+}
+
+func (aw *archiveWriter) field(name string, v interface{}) {
+	if aw.fields == 0 {
+		aw.write("{")
+	} else {
+		aw.write(",")
+	}
+	aw.fields++
+	aw.value(name)
+	aw.write(":")
+	if v != nil {
+		aw.value(v)
+	}
+}
+
+func (aw *archiveWriter) startArray(name string) {
+	aw.field(name, nil)
+	aw.write("[")
+	aw.items = 0
+}
+
+func (aw *archiveWriter) item(v interface{}) {
+	if aw.items > 0 {
+		aw.write(",")
+	}
+	aw.items++
+	aw.value(v)
+}
+
+func (aw *archiveWriter) endArray() {
+	aw.write("]")
+}
+
+func (aw *archiveWriter) close() error {
+	aw.write("}")
+	return aw.err
+}
+
+// exportCollection writes every record of a collection to the archive as a JSON array, a page at a time
+func exportCollection[T any](ctx context.Context, am *archiveManager, aw *archiveWriter, counts map[string]int, name string, qf ffapi.QueryFactory, key pageKey[T], getPage func(filter ffapi.AndFilter) ([]T, *ffapi.FilterResult, error)) error {
+	aw.startArray(name)
+	err := forEachPage(ctx, qf, am.pageSize, key, getPage, func(records []T) error {
+		for _, record := range records {
+			aw.item(record)
+		}
+		counts[name] += len(records)
+		return aw.err
+	})
+	if err != nil {
+		return err
+	}
+	aw.endArray()
+	return aw.err
+}
+
+// Export writes the namespace to the writer as the JSON of a core.NamespaceArchive. Each collection is read
+// and written a page at a time, within a single database group, so the namespace is never held in memory.
+// If an error is returned the output is incomplete, and must be discarded.
+func (am *archiveManager) Export(ctx context.Context, options *core.NamespaceExportOptions, w io.Writer) error {
+	aw := &archiveWriter{w: w}
+	aw.field("version", core.NamespaceArchiveVersion)
+	aw.field("namespace", am.namespace)
+	aw.field("created", fftypes.Now())
+	counts := make(map[string]int)
+	err := am.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		if err := exportCollection(ctx, am, aw, counts, "identities", database.IdentityQueryFactory,
+			pageByID(func(identity *core.Identity) *fftypes.UUID { return identity.ID }),
+			func(filter ffapi.AndFilter) ([]*core.Identity, *ffapi.FilterResult, error) {
+				return am.database.GetIdentities(ctx, am.namespace, filter)
+			}); err != nil {
+			return err
+		}
+		if err := exportCollection(ctx, am, aw, counts, "verifiers", database.VerifierQueryFactory,
+			pageByHash(func(verifier *core.Verifier) *fftypes.Bytes32 { return verifier.Hash }),
+			func(filter ffapi.AndFilter) ([]*core.Verifier, *ffapi.FilterResult, error) {
+				return am.database.GetVerifiers(ctx, am.namespace, filter)
+			}); err != nil {
+			return err
+		}
+		if err := exportCollection(ctx, am, aw, counts, "groups", database.GroupQueryFactory,
+			pageByHash(func(group *core.Group) *fftypes.Bytes32 { return group.Hash }),
+			func(filter ffapi.AndFilter) ([]*core.Group, *ffapi.FilterResult, error) {
+				return am.database.GetGroups(ctx, am.namespace, filter)
+			}); err != nil {
+			return err
+		}
+		if err := exportCollection(ctx, am, aw, counts, "datatypes", database.DatatypeQueryFactory,
+			pageByID(func(datatype *core.Datatype) *fftypes.UUID { return datatype.ID }),
+			func(filter ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error) {
+				return am.database.GetDatatypes(ctx, am.namespace, filter)
+			}); err != nil {
+			return err
+		}
+		if err := exportCollection(ctx, am, aw, counts, "interfaces", database.FFIQueryFactory, ffiPageKey,
+			func(filter ffapi.AndFilter) ([]*fftypes.FFI, *ffapi.FilterResult, error) {
+				return am.getFFIPage(ctx, filter)
+			}); err != nil {
+			return err
+		}
+		if err := exportCollection(ctx, am, aw, counts, "apis", database.ContractAPIQueryFactory,
+			pageByID(func(api *core.ContractAPI) *fftypes.UUID { return api.ID }),
+			func(filter ffapi.AndFilter) ([]*core.ContractAPI, *ffapi.FilterResult, error) {
+				return am.database.GetContractAPIs(ctx, am.namespace, filter)
+			}); err != nil {
+			return err
+		}
+		if err := exportCollection(ctx, am, aw, counts, "tokenPools", database.TokenPoolQueryFactory,
+			pageBySequence(func(pool *core.TokenPool) int64 { return pool.Sequence }),
+			func(filter ffapi.AndFilter) ([]*core.TokenPool, *ffapi.FilterResult, error) {
+				return am.database.GetTokenPools(ctx, am.namespace, filter)
+			}); err != nil {
+			return err
+		}
+		if options != nil && options.IncludeMessages {
+			if err := exportCollection(ctx, am, aw, counts, "messages", database.MessageQueryFactory,
+				pageBySequence(func(msg *core.Message) int64 { return msg.Sequence }),
+				func(filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+					return am.database.GetMessages(ctx, am.namespace, filter)
+				}); err != nil {
+				return err
+			}
+			if err := exportCollection(ctx, am, aw, counts, "data", database.DataQueryFactory,
+				pageByID(func(data *core.Data) *fftypes.UUID { return data.ID }),
+				func(filter ffapi.AndFilter) ([]*core.Data, *ffapi.FilterResult, error) {
+					return am.database.GetData(ctx, am.namespace, filter)
+				}); err != nil {
+				return err
+			}
+		}
+		if options != nil && options.IncludeBatches {
+			if err := exportCollection(ctx, am, aw, counts, "batches", database.BatchQueryFactory,
+				pageBySequence(func(batch *core.BatchPersisted) int64 { return batch.Sequence }),
+				func(filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+					return am.database.GetBatches(ctx, am.namespace, filter.Condition(filter.Builder().Neq("confirmed", nil)))
+				}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := aw.close(); err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Exported namespace '%s': %v", am.namespace, counts)
+	return nil
+}
+
+var ffiPageKey = pageByID(func(ffi *fftypes.FFI) *fftypes.UUID { return ffi.ID })
+
+// getFFIPage reads a page of interfaces, with all of their methods, events and errors
+func (am *archiveManager) getFFIPage(ctx context.Context, filter ffapi.AndFilter) ([]*fftypes.FFI, *ffapi.FilterResult, error) {
+	ffis, res, err := am.database.GetFFIs(ctx, am.namespace, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, ffi := range ffis {
+		fb := database.FFIMethodQueryFactory.NewFilter(ctx)
+		if ffi.Methods, _, err = am.database.GetFFIMethods(ctx, am.namespace, fb.Eq("interface", ffi.ID)); err != nil {
+			return nil, nil, err
+		}
+		fb = database.FFIEventQueryFactory.NewFilter(ctx)
+		if ffi.Events, _, err = am.database.GetFFIEvents(ctx, am.namespace, fb.Eq("interface", ffi.ID)); err != nil {
+			return nil, nil, err
+		}
+		fb = database.FFIErrorQueryFactory.NewFilter(ctx)
+		if ffi.Errors, _, err = am.database.GetFFIErrors(ctx, am.namespace, fb.Eq("interface", ffi.ID)); err != nil {
+			return nil, nil, err
+		}
+	}
+	return ffis, res, nil
+}
+
+func (am *archiveManager) exportFFIs(ctx context.Context) ([]*fftypes.FFI, error) {
+	return getAll(ctx, database.FFIQueryFactory, am.pageSize, ffiPageKey, func(filter ffapi.AndFilter) ([]*fftypes.FFI, *ffapi.FilterResult, error) {
+		return am.getFFIPage(ctx, filter)
+	})
+}
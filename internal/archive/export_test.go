@@ -0,0 +1,188 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func exportArchive(t *testing.T, am *archiveManager, options *core.NamespaceExportOptions) (*core.NamespaceArchive, error) {
+	var buff bytes.Buffer
+	if err := am.Export(context.Background(), options, &buff); err != nil {
+		return nil, err
+	}
+	var archive *core.NamespaceArchive
+	assert.NoError(t, json.Unmarshal(buff.Bytes(), &archive))
+	return archive, nil
+}
+
+type errWriter struct{}
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	return 0, fmt.Errorf("pop")
+}
+
+func TestExportDefinitionsOnly(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	am.pageSize = 1
+
+	ffiID := fftypes.NewUUID()
+	identity1 := &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}}
+	identity2 := &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}}
+	pagedAfter := func(id *fftypes.UUID) interface{} {
+		return mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+			return strings.Contains(filter.String(), id.String())
+		})
+	}
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{identity1}, nil, nil).Once()
+	mdi.On("GetIdentities", mock.Anything, "ns1", pagedAfter(identity1.ID)).Return([]*core.Identity{identity2}, nil, nil).Once()
+	mdi.On("GetIdentities", mock.Anything, "ns1", pagedAfter(identity2.ID)).Return([]*core.Identity{}, nil, nil).Once()
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{}, nil, nil)
+	mdi.On("GetGroups", mock.Anything, "ns1", mock.Anything).Return([]*core.Group{}, nil, nil)
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{{ID: ffiID}}, nil, nil).Once()
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{}, nil, nil).Once()
+	mdi.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIMethod{{Name: "set"}}, nil, nil)
+	mdi.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIEvent{}, nil, nil)
+	mdi.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFIError{}, nil, nil)
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{}, nil, nil)
+
+	archive, err := exportArchive(t, am, &core.NamespaceExportOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, core.NamespaceArchiveVersion, archive.Version)
+	assert.Equal(t, "ns1", archive.Namespace)
+	assert.Len(t, archive.Identities, 2)
+	assert.Equal(t, identity1.ID, archive.Identities[0].ID)
+	assert.Equal(t, identity2.ID, archive.Identities[1].ID)
+	assert.Empty(t, archive.Verifiers)
+	assert.Len(t, archive.FFIs, 1)
+	assert.Equal(t, "set", archive.FFIs[0].Methods[0].Name)
+	assert.Nil(t, archive.Messages)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportWithMessages(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{}, nil, nil)
+	mdi.On("GetGroups", mock.Anything, "ns1", mock.Anything).Return([]*core.Group{}, nil, nil)
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{}, nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{{}}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{{}}, nil, nil)
+
+	archive, err := exportArchive(t, am, &core.NamespaceExportOptions{IncludeMessages: true})
+	assert.NoError(t, err)
+	assert.Len(t, archive.Messages, 1)
+	assert.Len(t, archive.Data, 1)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportWithBatches(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{}, nil, nil)
+	mdi.On("GetGroups", mock.Anything, "ns1", mock.Anything).Return([]*core.Group{}, nil, nil)
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		return strings.Contains(filter.String(), "sort=sequence")
+	})).Return([]*core.BatchPersisted{{}}, nil, nil)
+
+	archive, err := exportArchive(t, am, &core.NamespaceExportOptions{IncludeBatches: true})
+	assert.NoError(t, err)
+	assert.Len(t, archive.Batches, 1)
+	assert.Nil(t, archive.Messages)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportBatchesFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{}, nil, nil)
+	mdi.On("GetGroups", mock.Anything, "ns1", mock.Anything).Return([]*core.Group{}, nil, nil)
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := exportArchive(t, am, &core.NamespaceExportOptions{IncludeBatches: true})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := exportArchive(t, am, nil)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportFFIChildrenFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{}, nil, nil)
+	mdi.On("GetGroups", mock.Anything, "ns1", mock.Anything).Return([]*core.Group{}, nil, nil)
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{{ID: fftypes.NewUUID()}}, nil, nil)
+	mdi.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := exportArchive(t, am, nil)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportWriteFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{{}}, nil, nil)
+
+	err := am.Export(context.Background(), nil, &errWriter{})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
@@ -0,0 +1,253 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Import writes all records from an archive into this namespace, within a single database group.
+// Records are re-homed into the local namespace, while network identifiers (such as the network
+// namespace of messages and groups) are retained so hashes continue to match across the network.
+// Blob payloads referenced by data records are not part of the archive.
+// The records are written directly, without the definition processing that keeps identities and definitions
+// consistent with the rest of the network, so an archive can only be imported into an empty namespace.
+func (am *archiveManager) Import(ctx context.Context, archive *core.NamespaceArchive) (*core.NamespaceImportResult, error) {
+	return am.importArchive(ctx, archive, true)
+}
+
+// importArchive upserts the records of the archive by ID, so importing the same archive more than once is safe
+// when the namespace is not required to be empty
+func (am *archiveManager) importArchive(ctx context.Context, archive *core.NamespaceArchive, requireEmpty bool) (*core.NamespaceImportResult, error) {
+	if archive == nil || archive.Version != core.NamespaceArchiveVersion {
+		version := 0
+		if archive != nil {
+			version = archive.Version
+		}
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceArchiveVersion, version, core.NamespaceArchiveVersion)
+	}
+
+	result := &core.NamespaceImportResult{
+		Namespace: am.namespace,
+		Imported:  make(map[string]int),
+	}
+	err := am.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		if requireEmpty {
+			if err := am.checkEmpty(ctx); err != nil {
+				return err
+			}
+		}
+
+		for _, identity := range archive.Identities {
+			identity.Namespace = am.namespace
+			if err := am.database.UpsertIdentity(ctx, identity, database.UpsertOptimizationSkip); err != nil {
+				return err
+			}
+		}
+		result.Imported["identities"] = len(archive.Identities)
+
+		for _, verifier := range archive.Verifiers {
+			verifier.Namespace = am.namespace
+			if err := am.database.UpsertVerifier(ctx, verifier.Seal(), database.UpsertOptimizationSkip); err != nil {
+				return err
+			}
+		}
+		result.Imported["verifiers"] = len(archive.Verifiers)
+
+		for _, group := range archive.Groups {
+			group.LocalNamespace = am.namespace
+			if err := am.database.UpsertGroup(ctx, group, database.UpsertOptimizationSkip); err != nil {
+				return err
+			}
+		}
+		result.Imported["groups"] = len(archive.Groups)
+
+		for _, datatype := range archive.Datatypes {
+			datatype.Namespace = am.namespace
+			if err := am.database.UpsertDatatype(ctx, datatype, true); err != nil {
+				return err
+			}
+		}
+		result.Imported["datatypes"] = len(archive.Datatypes)
+
+		for _, ffi := range archive.FFIs {
+			if err := am.upsertFFI(ctx, am.namespace, ffi); err != nil {
+				return err
+			}
+		}
+		result.Imported["interfaces"] = len(archive.FFIs)
+
+		for _, api := range archive.ContractAPIs {
+			api.Namespace = am.namespace
+			if err := am.database.UpsertContractAPI(ctx, api, database.UpsertOptimizationSkip); err != nil {
+				return err
+			}
+		}
+		result.Imported["apis"] = len(archive.ContractAPIs)
+
+		for _, pool := range archive.TokenPools {
+			pool.Namespace = am.namespace
+			if err := am.database.UpsertTokenPool(ctx, pool, database.UpsertOptimizationSkip); err != nil {
+				return err
+			}
+		}
+		result.Imported["tokenPools"] = len(archive.TokenPools)
+
+		for _, data := range archive.Data {
+			data.Namespace = am.namespace
+			if err := am.database.UpsertData(ctx, data, database.UpsertOptimizationSkip); err != nil {
+				return err
+			}
+		}
+		result.Imported["data"] = len(archive.Data)
+
+		for _, msg := range archive.Messages {
+			msg.LocalNamespace = am.namespace
+			if err := am.database.UpsertMessage(ctx, msg, database.UpsertOptimizationSkip); err != nil {
+				return err
+			}
+		}
+		result.Imported["messages"] = len(archive.Messages)
+
+		for _, batch := range archive.Batches {
+			batch.Namespace = am.namespace
+			if _, err := am.database.InsertOrGetBatch(ctx, batch); err != nil {
+				return err
+			}
+		}
+		result.Imported["batches"] = len(archive.Batches)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Imported archive of namespace '%s' into '%s': %v", archive.Namespace, am.namespace, result.Imported)
+	return result, nil
+}
+
+// hasRecords checks whether a collection contains at least one record
+func hasRecords[T any](ctx context.Context, qf ffapi.QueryFactory, getPage func(filter ffapi.AndFilter) ([]T, *ffapi.FilterResult, error)) (bool, error) {
+	records, _, err := getPage(qf.NewFilterLimit(ctx, 1).And())
+	return len(records) > 0, err
+}
+
+// checkEmpty returns an error naming the first collection of the archive that already has records in the namespace
+func (am *archiveManager) checkEmpty(ctx context.Context) error {
+	collections := []struct {
+		name       string
+		hasRecords func() (bool, error)
+	}{
+		{"identities", func() (bool, error) {
+			return hasRecords(ctx, database.IdentityQueryFactory, func(filter ffapi.AndFilter) ([]*core.Identity, *ffapi.FilterResult, error) {
+				return am.database.GetIdentities(ctx, am.namespace, filter)
+			})
+		}},
+		{"verifiers", func() (bool, error) {
+			return hasRecords(ctx, database.VerifierQueryFactory, func(filter ffapi.AndFilter) ([]*core.Verifier, *ffapi.FilterResult, error) {
+				return am.database.GetVerifiers(ctx, am.namespace, filter)
+			})
+		}},
+		{"groups", func() (bool, error) {
+			return hasRecords(ctx, database.GroupQueryFactory, func(filter ffapi.AndFilter) ([]*core.Group, *ffapi.FilterResult, error) {
+				return am.database.GetGroups(ctx, am.namespace, filter)
+			})
+		}},
+		{"datatypes", func() (bool, error) {
+			return hasRecords(ctx, database.DatatypeQueryFactory, func(filter ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error) {
+				return am.database.GetDatatypes(ctx, am.namespace, filter)
+			})
+		}},
+		{"interfaces", func() (bool, error) {
+			return hasRecords(ctx, database.FFIQueryFactory, func(filter ffapi.AndFilter) ([]*fftypes.FFI, *ffapi.FilterResult, error) {
+				return am.database.GetFFIs(ctx, am.namespace, filter)
+			})
+		}},
+		{"apis", func() (bool, error) {
+			return hasRecords(ctx, database.ContractAPIQueryFactory, func(filter ffapi.AndFilter) ([]*core.ContractAPI, *ffapi.FilterResult, error) {
+				return am.database.GetContractAPIs(ctx, am.namespace, filter)
+			})
+		}},
+		{"tokenPools", func() (bool, error) {
+			return hasRecords(ctx, database.TokenPoolQueryFactory, func(filter ffapi.AndFilter) ([]*core.TokenPool, *ffapi.FilterResult, error) {
+				return am.database.GetTokenPools(ctx, am.namespace, filter)
+			})
+		}},
+		{"messages", func() (bool, error) {
+			return hasRecords(ctx, database.MessageQueryFactory, func(filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+				return am.database.GetMessages(ctx, am.namespace, filter)
+			})
+		}},
+		{"data", func() (bool, error) {
+			return hasRecords(ctx, database.DataQueryFactory, func(filter ffapi.AndFilter) ([]*core.Data, *ffapi.FilterResult, error) {
+				return am.database.GetData(ctx, am.namespace, filter)
+			})
+		}},
+		{"batches", func() (bool, error) {
+			return hasRecords(ctx, database.BatchQueryFactory, func(filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+				return am.database.GetBatches(ctx, am.namespace, filter)
+			})
+		}},
+	}
+	for _, collection := range collections {
+		found, err := collection.hasRecords()
+		if err != nil {
+			return err
+		}
+		if found {
+			return i18n.NewError(ctx, coremsgs.MsgNamespaceImportNotEmpty, am.namespace, collection.name)
+		}
+	}
+	return nil
+}
+
+// upsertFFI writes an interface and all of its methods, events and errors into the given namespace
+func (am *archiveManager) upsertFFI(ctx context.Context, namespace string, ffi *fftypes.FFI) error {
+	ffi.Namespace = namespace
+	if err := am.database.UpsertFFI(ctx, ffi, database.UpsertOptimizationSkip); err != nil {
+		return err
+	}
+	for _, method := range ffi.Methods {
+		method.Namespace = namespace
+		method.Interface = ffi.ID
+		if err := am.database.UpsertFFIMethod(ctx, method); err != nil {
+			return err
+		}
+	}
+	for _, event := range ffi.Events {
+		event.Namespace = namespace
+		event.Interface = ffi.ID
+		if err := am.database.UpsertFFIEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	for _, errorDef := range ffi.Errors {
+		errorDef.Namespace = namespace
+		errorDef.Interface = ffi.ID
+		if err := am.database.UpsertFFIError(ctx, errorDef); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockEmptyNamespace(mdi *databasemocks.Plugin) {
+	mockEmptyExport(mdi)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+}
+
+func TestImportAll(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mockEmptyNamespace(mdi)
+	ctx := context.Background()
+
+	ffiID := fftypes.NewUUID()
+	archive := &core.NamespaceArchive{
+		Version:      core.NamespaceArchiveVersion,
+		Namespace:    "ns0",
+		Identities:   []*core.Identity{{IdentityBase: core.IdentityBase{Namespace: "ns0"}}},
+		Verifiers:    []*core.Verifier{{Namespace: "ns0", VerifierRef: core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}}},
+		Groups:       []*core.Group{{LocalNamespace: "ns0"}},
+		Datatypes:    []*core.Datatype{{Namespace: "ns0"}},
+		FFIs:         []*fftypes.FFI{{ID: ffiID, Namespace: "ns0", Methods: []*fftypes.FFIMethod{{}}, Events: []*fftypes.FFIEvent{{}}, Errors: []*fftypes.FFIError{{}}}},
+		ContractAPIs: []*core.ContractAPI{{Namespace: "ns0"}},
+		TokenPools:   []*core.TokenPool{{Namespace: "ns0"}},
+		Messages:     []*core.Message{{LocalNamespace: "ns0"}},
+		Data:         core.DataArray{{Namespace: "ns0"}},
+		Batches:      []*core.BatchPersisted{{BatchHeader: core.BatchHeader{Namespace: "ns0"}}},
+	}
+
+	mdi.On("UpsertIdentity", mock.Anything, archive.Identities[0], database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertVerifier", mock.Anything, archive.Verifiers[0], database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertGroup", mock.Anything, archive.Groups[0], database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertDatatype", mock.Anything, archive.Datatypes[0], true).Return(nil)
+	mdi.On("UpsertFFI", mock.Anything, archive.FFIs[0], database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertFFIMethod", mock.Anything, archive.FFIs[0].Methods[0]).Return(nil)
+	mdi.On("UpsertFFIEvent", mock.Anything, archive.FFIs[0].Events[0]).Return(nil)
+	mdi.On("UpsertFFIError", mock.Anything, archive.FFIs[0].Errors[0]).Return(nil)
+	mdi.On("UpsertContractAPI", mock.Anything, archive.ContractAPIs[0], database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertTokenPool", mock.Anything, archive.TokenPools[0], database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertData", mock.Anything, archive.Data[0], database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertMessage", mock.Anything, archive.Messages[0], database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, archive.Batches[0]).Return(nil, nil)
+
+	result, err := am.Import(ctx, archive)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1", result.Namespace)
+	assert.Equal(t, 1, result.Imported["interfaces"])
+	assert.Equal(t, 1, result.Imported["messages"])
+
+	// Records are re-homed to the local namespace
+	assert.Equal(t, "ns1", archive.Identities[0].Namespace)
+	assert.Equal(t, "ns1", archive.Verifiers[0].Namespace)
+	assert.Equal(t, archive.Verifiers[0].Hash, (&core.Verifier{Namespace: "ns1", VerifierRef: archive.Verifiers[0].VerifierRef}).Seal().Hash)
+	assert.Equal(t, "ns1", archive.Groups[0].LocalNamespace)
+	assert.Equal(t, "ns1", archive.FFIs[0].Methods[0].Namespace)
+	assert.Equal(t, ffiID, archive.FFIs[0].Events[0].Interface)
+	assert.Equal(t, "ns1", archive.Messages[0].LocalNamespace)
+	assert.Equal(t, "ns1", archive.Data[0].Namespace)
+	assert.Equal(t, "ns1", archive.Batches[0].Namespace)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestImportBadVersion(t *testing.T) {
+	am, _ := newTestArchiveManager(t)
+
+	_, err := am.Import(context.Background(), &core.NamespaceArchive{Version: 99})
+	assert.Regexp(t, "FF10493.*99", err)
+
+	_, err = am.Import(context.Background(), nil)
+	assert.Regexp(t, "FF10493", err)
+}
+
+func TestImportFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mockEmptyNamespace(mdi)
+
+	mdi.On("UpsertIdentity", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(fmt.Errorf("pop"))
+
+	_, err := am.Import(context.Background(), &core.NamespaceArchive{
+		Version:    core.NamespaceArchiveVersion,
+		Identities: []*core.Identity{{}},
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestImportFFIMethodFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mockEmptyNamespace(mdi)
+
+	mdi.On("UpsertFFI", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertFFIMethod", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := am.Import(context.Background(), &core.NamespaceArchive{
+		Version: core.NamespaceArchiveVersion,
+		FFIs:    []*fftypes.FFI{{Methods: []*fftypes.FFIMethod{{}}}},
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestImportBatchFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mockEmptyNamespace(mdi)
+
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.Import(context.Background(), &core.NamespaceArchive{
+		Version: core.NamespaceArchiveVersion,
+		Batches: []*core.BatchPersisted{{}},
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestImportNotEmpty(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{{}}, nil, nil)
+
+	_, err := am.Import(context.Background(), &core.NamespaceArchive{
+		Version:    core.NamespaceArchiveVersion,
+		Identities: []*core.Identity{{}},
+	})
+	assert.Regexp(t, "FF10677.*ns1.*verifiers", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestImportCheckEmptyFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.Import(context.Background(), &core.NamespaceArchive{
+		Version: core.NamespaceArchiveVersion,
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
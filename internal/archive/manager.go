@@ -0,0 +1,72 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Manager exports the state of a namespace to a portable archive, and imports an archive into a namespace.
+// This allows a namespace to be migrated between environments, or recovered, without a copy of the database.
+// It also clones local definitions from a template namespace into a newly provisioned namespace.
+// Signed snapshots of the confirmed state allow a new node of an existing org to bootstrap from another
+// node of the same org, with the pinned batches verified against the blockchain after the import.
+type Manager interface {
+	Start()
+	Export(ctx context.Context, options *core.NamespaceExportOptions, w io.Writer) error
+	Import(ctx context.Context, archive *core.NamespaceArchive) (*core.NamespaceImportResult, error)
+	Clone(ctx context.Context, target string, options *core.NamespaceClone) (map[string]int, error)
+	ExportSnapshot(ctx context.Context, options *core.NamespaceExportOptions) (*core.NamespaceSnapshot, error)
+	ImportSnapshot(ctx context.Context, snapshot *core.NamespaceSnapshot) (*core.NamespaceImportResult, error)
+	GetSnapshotVerification(ctx context.Context) (*core.SnapshotVerification, error)
+}
+
+type archiveManager struct {
+	ctx            context.Context
+	namespace      string
+	database       database.Plugin
+	identity       identity.Manager
+	pageSize       uint64
+	verifyInterval time.Duration
+	snapshotMux    sync.Mutex
+	snapshot       *snapshotVerifier
+}
+
+func NewArchiveManager(ctx context.Context, ns string, di database.Plugin, im identity.Manager) (Manager, error) {
+	if di == nil || im == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "ArchiveManager")
+	}
+	return &archiveManager{
+		ctx:            ctx,
+		namespace:      ns,
+		database:       di,
+		identity:       im,
+		pageSize:       100,
+		verifyInterval: config.GetDuration(coreconfig.SnapshotVerifyInterval),
+	}, nil
+}
@@ -0,0 +1,374 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// snapshotVerifier checks the pinned batches of an imported snapshot against the pins received from the
+// blockchain, as the node catches up with the network. The batches are trusted on import, because the
+// snapshot is signed by the org, but the hash of each batch is recomputed from the messages and data in the
+// snapshot, and a mismatch with the pinned hash is reported in the verification status. The state is
+// persisted, so verification resumes after a restart.
+type snapshotVerifier struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mux     sync.Mutex
+	status  core.SnapshotVerification
+	pending map[fftypes.UUID]*fftypes.Bytes32
+	done    chan struct{}
+}
+
+// ExportSnapshot exports the confirmed state of the namespace, including batches, and signs the hash of the
+// archive with the payload signing key of the org
+func (am *archiveManager) ExportSnapshot(ctx context.Context, options *core.NamespaceExportOptions) (*core.NamespaceSnapshot, error) {
+	exportOptions := core.NamespaceExportOptions{IncludeBatches: true}
+	if options != nil {
+		exportOptions.IncludeMessages = options.IncludeMessages
+	}
+	// The hash is calculated over the whole archive, so it is built in memory rather than streamed
+	var buff bytes.Buffer
+	if err := am.Export(ctx, &exportOptions, &buff); err != nil {
+		return nil, err
+	}
+	var archive *core.NamespaceArchive
+	if err := json.Unmarshal(buff.Bytes(), &archive); err != nil {
+		return nil, err
+	}
+	hash := archive.Hash()
+	signature, err := am.identity.SignPayload(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if signature == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSnapshotSigningNotEnabled)
+	}
+	return &core.NamespaceSnapshot{
+		Hash:      hash,
+		Signature: signature,
+		Archive:   archive,
+	}, nil
+}
+
+// ImportSnapshot checks a snapshot was signed by this org, imports it, then starts verifying the pinned batches
+// of the snapshot in the background
+func (am *archiveManager) ImportSnapshot(ctx context.Context, snapshot *core.NamespaceSnapshot) (*core.NamespaceImportResult, error) {
+	if snapshot == nil {
+		snapshot = &core.NamespaceSnapshot{}
+	}
+	hash := snapshot.Archive.Hash()
+	if !hash.Equals(snapshot.Hash) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSnapshotHashMismatch, hash, snapshot.Hash)
+	}
+	if err := am.identity.VerifyOrgPayloadSignature(ctx, hash, snapshot.Signature); err != nil {
+		return nil, err
+	}
+	// A later snapshot of the same org can replace an earlier one, so the namespace is not required to be empty
+	result, err := am.importArchive(ctx, snapshot.Archive, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := am.startSnapshotVerifier(ctx, hash, snapshot.Archive); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Start resumes verification of the last snapshot imported into the namespace, if it did not complete
+// before the node was stopped
+func (am *archiveManager) Start() {
+	state, err := am.database.GetSnapshotVerification(am.ctx, am.namespace)
+	if err != nil {
+		log.L(am.ctx).Errorf("Failed to load snapshot verification state: %s", err)
+		return
+	}
+	if state == nil {
+		return
+	}
+	sv := newSnapshotVerifier(am.ctx, state)
+	am.snapshotMux.Lock()
+	am.snapshot = sv
+	am.snapshotMux.Unlock()
+
+	if state.Completed == nil {
+		log.L(am.ctx).Infof("Resuming snapshot verification: batches=%d pending=%d", sv.status.Batches, sv.status.Pending)
+		go am.snapshotVerifyLoop(sv)
+	} else {
+		sv.cancel()
+		close(sv.done)
+	}
+}
+
+func (am *archiveManager) GetSnapshotVerification(ctx context.Context) (*core.SnapshotVerification, error) {
+	am.snapshotMux.Lock()
+	sv := am.snapshot
+	am.snapshotMux.Unlock()
+	if sv == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSnapshotNotImported)
+	}
+	sv.mux.Lock()
+	defer sv.mux.Unlock()
+	status := sv.status
+	status.Mismatched = append([]*fftypes.UUID{}, sv.status.Mismatched...)
+	return &status, nil
+}
+
+func newSnapshotVerifier(ctx context.Context, state *core.SnapshotVerificationState) *snapshotVerifier {
+	sv := &snapshotVerifier{
+		status:  state.SnapshotVerification,
+		pending: make(map[fftypes.UUID]*fftypes.Bytes32, len(state.Expected)),
+		done:    make(chan struct{}),
+	}
+	if sv.status.Mismatched == nil {
+		sv.status.Mismatched = []*fftypes.UUID{}
+	}
+	for id, hash := range state.Expected {
+		if batchID, err := fftypes.ParseUUID(ctx, id); err == nil {
+			sv.pending[*batchID] = hash
+		}
+	}
+	sv.status.Pending = len(sv.pending)
+	sv.ctx, sv.cancel = context.WithCancel(ctx)
+	return sv
+}
+
+func (am *archiveManager) startSnapshotVerifier(ctx context.Context, hash *fftypes.Bytes32, archive *core.NamespaceArchive) error {
+	messages := make(map[fftypes.UUID]*core.Message, len(archive.Messages))
+	for _, msg := range archive.Messages {
+		if msg.Header.ID != nil {
+			messages[*msg.Header.ID] = msg
+		}
+	}
+	data := make(map[fftypes.UUID]*core.Data, len(archive.Data))
+	for _, d := range archive.Data {
+		if d.ID != nil {
+			data[*d.ID] = d
+		}
+	}
+
+	state := &core.SnapshotVerificationState{
+		SnapshotVerification: core.SnapshotVerification{
+			Hash:       hash,
+			Imported:   fftypes.Now(),
+			Mismatched: []*fftypes.UUID{},
+		},
+		Expected: make(map[string]*fftypes.Bytes32),
+	}
+	for _, batch := range archive.Batches {
+		if batch.ID == nil || !core.IsPinned(batch.TX.Type) {
+			continue
+		}
+		state.Batches++
+		expected := batchContentHash(ctx, batch, messages, data)
+		if expected == nil {
+			log.L(ctx).Errorf("Batch %s in the imported snapshot does not match its manifest", batch.ID)
+			state.Mismatched = append(state.Mismatched, batch.ID)
+			continue
+		}
+		state.Expected[batch.ID.String()] = expected
+	}
+	sv := newSnapshotVerifier(am.ctx, state)
+
+	// Only the most recently imported snapshot is verified
+	am.snapshotMux.Lock()
+	defer am.snapshotMux.Unlock()
+	if err := am.persistSnapshotVerification(ctx, sv); err != nil {
+		sv.cancel()
+		return err
+	}
+	if am.snapshot != nil {
+		am.snapshot.cancel()
+	}
+	am.snapshot = sv
+
+	go am.snapshotVerifyLoop(sv)
+	return nil
+}
+
+// batchContentHash rebuilds the manifest of a batch from the messages and data in the snapshot, in the same
+// way as verifying a batch pin, and returns the hash the pin is expected to have. Entries not included in the
+// snapshot keep the hash from the manifest. Nil is returned if the batch does not match its own manifest, or
+// a message in the snapshot does not match its data.
+func batchContentHash(ctx context.Context, batch *core.BatchPersisted, messages map[fftypes.UUID]*core.Message, data map[fftypes.UUID]*core.Data) *fftypes.Bytes32 {
+	var manifest core.BatchManifest
+	if err := batch.Manifest.Unmarshal(ctx, &manifest); err != nil {
+		return nil
+	}
+	if !fftypes.HashString(manifest.String()).Equals(batch.Hash) {
+		return nil
+	}
+
+	rebuilt := manifest
+	rebuilt.Messages = make([]*core.MessageManifestEntry, len(manifest.Messages))
+	for i, entry := range manifest.Messages {
+		if entry == nil || entry.ID == nil {
+			return nil
+		}
+		rebuilt.Messages[i] = entry
+		if msg, ok := messages[*entry.ID]; ok {
+			if !msg.Header.DataHash.Equals(msg.Data.Hash()) {
+				return nil
+			}
+			rebuilt.Messages[i] = &core.MessageManifestEntry{
+				MessageRef: core.MessageRef{
+					ID:   msg.Header.ID,
+					Hash: msg.Header.Hash(),
+				},
+				Topics: len(msg.Header.Topics),
+			}
+		}
+	}
+
+	rebuilt.Data = make(core.DataRefs, len(manifest.Data))
+	for i, entry := range manifest.Data {
+		if entry == nil || entry.ID == nil {
+			return nil
+		}
+		rebuilt.Data[i] = entry
+		if d, ok := data[*entry.ID]; ok {
+			hash, err := d.CalcHash(ctx)
+			if err != nil {
+				return nil
+			}
+			rebuilt.Data[i] = &core.DataRef{
+				ID:   d.ID,
+				Hash: hash,
+			}
+		}
+	}
+
+	return fftypes.HashString(rebuilt.String())
+}
+
+// persistSnapshotVerification stores the current state of a verifier, including the expected hash of each
+// batch still waiting for its pin. The caller must hold snapshotMux, so the state of a verifier replaced by a
+// later import cannot overwrite the state of the new one.
+func (am *archiveManager) persistSnapshotVerification(ctx context.Context, sv *snapshotVerifier) error {
+	sv.mux.Lock()
+	state := &core.SnapshotVerificationState{
+		SnapshotVerification: sv.status,
+		Expected:             make(map[string]*fftypes.Bytes32, len(sv.pending)),
+	}
+	state.Mismatched = append([]*fftypes.UUID{}, sv.status.Mismatched...)
+	for id, hash := range sv.pending {
+		state.Expected[id.String()] = hash
+	}
+	sv.mux.Unlock()
+	return am.database.UpsertSnapshotVerification(ctx, am.namespace, state)
+}
+
+func (am *archiveManager) snapshotVerifyLoop(sv *snapshotVerifier) {
+	defer close(sv.done)
+	defer sv.cancel()
+	for {
+		complete, err := am.checkSnapshotPins(sv)
+		if err != nil {
+			log.L(sv.ctx).Errorf("Snapshot verification failed: %s", err)
+		}
+		if complete {
+			log.L(sv.ctx).Infof("Snapshot verification complete: batches=%d verified=%d mismatched=%d", sv.status.Batches, sv.status.Verified, len(sv.status.Mismatched))
+			return
+		}
+		select {
+		case <-time.After(am.verifyInterval):
+		case <-sv.ctx.Done():
+			log.L(sv.ctx).Debugf("Snapshot verifier exiting")
+			return
+		}
+	}
+}
+
+// checkSnapshotPins looks up the pins received so far for the pending batches, in pages, and compares the
+// batch hash of each pin with the hash recomputed from the snapshot. The state is persisted if any pins
+// were checked.
+func (am *archiveManager) checkSnapshotPins(sv *snapshotVerifier) (complete bool, err error) {
+	sv.mux.Lock()
+	ids := make([]driver.Value, 0, len(sv.pending))
+	for id := range sv.pending {
+		ids = append(ids, id.String())
+	}
+	sv.mux.Unlock()
+
+	changed := false
+	for start := 0; start < len(ids); start += int(am.pageSize) {
+		end := start + int(am.pageSize)
+		if end > len(ids) {
+			end = len(ids)
+		}
+		fb := database.PinQueryFactory.NewFilter(sv.ctx)
+		pins, _, err := am.database.GetPins(sv.ctx, am.namespace, fb.In("batch", ids[start:end]))
+		if err != nil {
+			return false, err
+		}
+		for _, pin := range pins {
+			if sv.checkPin(sv.ctx, pin) {
+				changed = true
+			}
+		}
+	}
+
+	sv.mux.Lock()
+	if len(sv.pending) == 0 {
+		sv.status.Completed = fftypes.Now()
+		complete, changed = true, true
+	}
+	sv.mux.Unlock()
+	if changed {
+		am.snapshotMux.Lock()
+		defer am.snapshotMux.Unlock()
+		if am.snapshot == sv {
+			if err := am.persistSnapshotVerification(sv.ctx, sv); err != nil {
+				return complete, err
+			}
+		}
+	}
+	return complete, nil
+}
+
+func (sv *snapshotVerifier) checkPin(ctx context.Context, pin *core.Pin) bool {
+	if pin.Batch == nil {
+		return false
+	}
+	sv.mux.Lock()
+	defer sv.mux.Unlock()
+	batchHash, ok := sv.pending[*pin.Batch]
+	if !ok {
+		return false
+	}
+	delete(sv.pending, *pin.Batch)
+	sv.status.Pending = len(sv.pending)
+	if batchHash.Equals(pin.BatchHash) {
+		sv.status.Verified++
+	} else {
+		log.L(ctx).Errorf("Batch %s in the imported snapshot has content hash %s, but the pin received from the blockchain has batch hash %s", pin.Batch, batchHash, pin.BatchHash)
+		sv.status.Mismatched = append(sv.status.Mismatched, pin.Batch)
+	}
+	return true
+}
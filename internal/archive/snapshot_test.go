@@ -0,0 +1,416 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockEmptyExport(mdi *databasemocks.Plugin) {
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{}, nil, nil)
+	mdi.On("GetGroups", mock.Anything, "ns1", mock.Anything).Return([]*core.Group{}, nil, nil)
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	mdi.On("GetFFIs", mock.Anything, "ns1", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	mdi.On("GetContractAPIs", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{}, nil, nil)
+}
+
+func newTestSnapshot(batches ...*core.BatchPersisted) *core.NamespaceSnapshot {
+	return newTestSnapshotArchive(&core.NamespaceArchive{
+		Version:   core.NamespaceArchiveVersion,
+		Namespace: "ns0",
+		Batches:   batches,
+	})
+}
+
+func newTestSnapshotArchive(archive *core.NamespaceArchive) *core.NamespaceSnapshot {
+	return &core.NamespaceSnapshot{
+		Hash:      archive.Hash(),
+		Signature: &core.PayloadSignature{Algorithm: "ECDSA-SHA256"},
+		Archive:   archive,
+	}
+}
+
+// newTestSnapshotBatch builds a batch containing one message and its data, with a manifest and hash that
+// match the content
+func newTestSnapshotBatch(t *testing.T, txType core.TransactionType) (*core.BatchPersisted, *core.Message, *core.Data) {
+	ctx := context.Background()
+	data := &core.Data{Value: fftypes.JSONAnyPtr(`"value"`)}
+	assert.NoError(t, data.Seal(ctx, nil))
+	msg := &core.Message{
+		Header: core.MessageHeader{TxType: txType},
+		Data:   core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+	}
+	assert.NoError(t, msg.Seal(ctx))
+	payload := &core.BatchPayload{
+		TX:       core.TransactionRef{Type: txType, ID: fftypes.NewUUID()},
+		Messages: []*core.Message{msg},
+		Data:     core.DataArray{data},
+	}
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()},
+		TX:          payload.TX,
+	}
+	manifest := payload.Manifest(batch.ID)
+	batch.Manifest = fftypes.JSONAnyPtr(manifest.String())
+	batch.Hash = fftypes.HashString(manifest.String())
+	return batch, msg, data
+}
+
+func TestExportSnapshot(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	ctx := context.Background()
+
+	mockEmptyExport(mdi)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{{}}, nil, nil)
+	signature := &core.PayloadSignature{Algorithm: "ECDSA-SHA256"}
+	mim.On("SignPayload", ctx, mock.Anything).Return(signature, nil)
+
+	snapshot, err := am.ExportSnapshot(ctx, &core.NamespaceExportOptions{IncludeMessages: true})
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Archive.Batches, 1)
+	assert.Equal(t, snapshot.Archive.Hash(), snapshot.Hash)
+	assert.Equal(t, signature, snapshot.Signature)
+	mim.AssertCalled(t, "SignPayload", ctx, snapshot.Hash)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestExportSnapshotSigningNotEnabled(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mim := am.identity.(*identitymanagermocks.Manager)
+
+	mockEmptyExport(mdi)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, nil)
+
+	_, err := am.ExportSnapshot(context.Background(), nil)
+	assert.Regexp(t, "FF10600", err)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestExportSnapshotSignFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mim := am.identity.(*identitymanagermocks.Manager)
+
+	mockEmptyExport(mdi)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.ExportSnapshot(context.Background(), nil)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestExportSnapshotExportFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.ExportSnapshot(context.Background(), nil)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestImportSnapshotAndVerify(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	am.verifyInterval = 1 * time.Millisecond
+	mim := am.identity.(*identitymanagermocks.Manager)
+	ctx := context.Background()
+
+	verified, verifiedMsg, verifiedData := newTestSnapshotBatch(t, core.TransactionTypeBatchPin)
+	mismatched, mismatchedMsg, mismatchedData := newTestSnapshotBatch(t, core.TransactionTypeContractInvokePin)
+	tampered, tamperedMsg, tamperedData := newTestSnapshotBatch(t, core.TransactionTypeBatchPin)
+	badManifest, _, _ := newTestSnapshotBatch(t, core.TransactionTypeBatchPin)
+	unpinned, _, _ := newTestSnapshotBatch(t, core.TransactionTypeUnpinned)
+
+	// Change a message after the batch was built, keeping the declared batch hash
+	tamperedMsg.Header.Tag = "tampered"
+	assert.NoError(t, tamperedMsg.Seal(ctx))
+	badManifest.Hash = fftypes.NewRandB32()
+
+	snapshot := newTestSnapshotArchive(&core.NamespaceArchive{
+		Version:   core.NamespaceArchiveVersion,
+		Namespace: "ns0",
+		Messages:  []*core.Message{verifiedMsg, mismatchedMsg, tamperedMsg},
+		Data:      core.DataArray{verifiedData, mismatchedData, tamperedData},
+		Batches:   []*core.BatchPersisted{verified, mismatched, tampered, badManifest, unpinned},
+	})
+
+	mim.On("VerifyOrgPayloadSignature", ctx, snapshot.Hash, snapshot.Signature).Return(nil)
+	mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("UpsertMessage", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	var persisted *core.SnapshotVerificationState
+	mdi.On("UpsertSnapshotVerification", mock.Anything, "ns1", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		persisted = args[2].(*core.SnapshotVerificationState)
+	})
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: verified.ID, BatchHash: verified.Hash},
+		{Batch: verified.ID, BatchHash: verified.Hash},
+	}, nil, nil).Once()
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: mismatched.ID, BatchHash: fftypes.NewRandB32()},
+		{Batch: tampered.ID, BatchHash: tampered.Hash},
+		{},
+	}, nil, nil).Once()
+
+	result, err := am.ImportSnapshot(ctx, snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, result.Imported["batches"])
+
+	<-am.snapshot.done
+	status, err := am.GetSnapshotVerification(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.Hash, status.Hash)
+	assert.NotNil(t, status.Completed)
+	assert.Equal(t, 4, status.Batches)
+	assert.Equal(t, 1, status.Verified)
+	assert.Equal(t, 0, status.Pending)
+	assert.ElementsMatch(t, []*fftypes.UUID{badManifest.ID, mismatched.ID, tampered.ID}, status.Mismatched)
+
+	assert.NotNil(t, persisted.Completed)
+	assert.Empty(t, persisted.Expected)
+	assert.Len(t, persisted.Mismatched, 3)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestImportSnapshotReplacesVerifier(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	am.verifyInterval = 1 * time.Minute
+	mim := am.identity.(*identitymanagermocks.Manager)
+	ctx := context.Background()
+
+	batch, _, _ := newTestSnapshotBatch(t, core.TransactionTypeBatchPin)
+	mim.On("VerifyOrgPayloadSignature", ctx, mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	mdi.On("UpsertSnapshotVerification", mock.Anything, "ns1", mock.Anything).Return(nil)
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+
+	_, err := am.ImportSnapshot(ctx, newTestSnapshot(batch))
+	assert.NoError(t, err)
+	first := am.snapshot
+
+	_, err = am.ImportSnapshot(ctx, newTestSnapshot(batch))
+	assert.NoError(t, err)
+	<-first.done
+
+	status, err := am.GetSnapshotVerification(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, status.Completed)
+	assert.Equal(t, 1, status.Pending)
+
+	am.snapshot.cancel()
+	<-am.snapshot.done
+}
+
+func TestImportSnapshotPersistFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mim := am.identity.(*identitymanagermocks.Manager)
+
+	batch, _, _ := newTestSnapshotBatch(t, core.TransactionTypeBatchPin)
+	mim.On("VerifyOrgPayloadSignature", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	mdi.On("UpsertSnapshotVerification", mock.Anything, "ns1", mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := am.ImportSnapshot(context.Background(), newTestSnapshot(batch))
+	assert.EqualError(t, err, "pop")
+	assert.Nil(t, am.snapshot)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestStartResumesSnapshotVerification(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	am.verifyInterval = 1 * time.Millisecond
+	ctx := context.Background()
+
+	batchID := fftypes.NewUUID()
+	expected := fftypes.NewRandB32()
+	mdi.On("GetSnapshotVerification", mock.Anything, "ns1").Return(&core.SnapshotVerificationState{
+		SnapshotVerification: core.SnapshotVerification{
+			Hash:     fftypes.NewRandB32(),
+			Imported: fftypes.Now(),
+			Batches:  2,
+			Verified: 1,
+			Pending:  1,
+		},
+		Expected: map[string]*fftypes.Bytes32{
+			batchID.String(): expected,
+			"!uuid":          expected,
+		},
+	}, nil)
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: batchID, BatchHash: expected},
+	}, nil, nil)
+	mdi.On("UpsertSnapshotVerification", mock.Anything, "ns1", mock.Anything).Return(fmt.Errorf("pop")).Once()
+
+	am.Start()
+	<-am.snapshot.done
+
+	status, err := am.GetSnapshotVerification(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, status.Completed)
+	assert.Equal(t, 2, status.Verified)
+	assert.Equal(t, 0, status.Pending)
+	assert.Empty(t, status.Mismatched)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestStartSnapshotVerificationCompleted(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	ctx := context.Background()
+
+	mdi.On("GetSnapshotVerification", mock.Anything, "ns1").Return(&core.SnapshotVerificationState{
+		SnapshotVerification: core.SnapshotVerification{
+			Hash:      fftypes.NewRandB32(),
+			Imported:  fftypes.Now(),
+			Completed: fftypes.Now(),
+			Batches:   1,
+			Verified:  1,
+		},
+	}, nil)
+
+	am.Start()
+	<-am.snapshot.done
+
+	status, err := am.GetSnapshotVerification(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, status.Completed)
+	assert.Equal(t, 1, status.Verified)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestStartNoSnapshotVerification(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+
+	mdi.On("GetSnapshotVerification", mock.Anything, "ns1").Return(nil, nil).Once()
+	am.Start()
+	assert.Nil(t, am.snapshot)
+
+	mdi.On("GetSnapshotVerification", mock.Anything, "ns1").Return(nil, fmt.Errorf("pop")).Once()
+	am.Start()
+	assert.Nil(t, am.snapshot)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBatchContentHashInvalid(t *testing.T) {
+	ctx := context.Background()
+	noContent := map[fftypes.UUID]*core.Message{}
+	noData := map[fftypes.UUID]*core.Data{}
+
+	batch, msg, data := newTestSnapshotBatch(t, core.TransactionTypeBatchPin)
+	assert.Equal(t, batch.Hash, batchContentHash(ctx, batch, noContent, noData))
+	assert.Equal(t, batch.Hash, batchContentHash(ctx, batch, map[fftypes.UUID]*core.Message{*msg.Header.ID: msg}, map[fftypes.UUID]*core.Data{*data.ID: data}))
+
+	// Message that does not match its data
+	badMsg := *msg
+	badMsg.Data = core.DataRefs{}
+	assert.Nil(t, batchContentHash(ctx, batch, map[fftypes.UUID]*core.Message{*msg.Header.ID: &badMsg}, noData))
+
+	// Data that cannot be hashed
+	assert.Nil(t, batchContentHash(ctx, batch, noContent, map[fftypes.UUID]*core.Data{*data.ID: {ID: data.ID}}))
+
+	// Manifest that cannot be parsed
+	batch.Manifest = fftypes.JSONAnyPtr("!json")
+	assert.Nil(t, batchContentHash(ctx, batch, noContent, noData))
+
+	// Manifest with missing entries
+	for _, manifest := range []*core.BatchManifest{
+		{Messages: []*core.MessageManifestEntry{{}}},
+		{Data: core.DataRefs{{}}},
+	} {
+		batch.Manifest = fftypes.JSONAnyPtr(manifest.String())
+		batch.Hash = fftypes.HashString(manifest.String())
+		assert.Nil(t, batchContentHash(ctx, batch, noContent, noData))
+	}
+}
+
+func TestImportSnapshotHashMismatch(t *testing.T) {
+	am, _ := newTestArchiveManager(t)
+
+	snapshot := newTestSnapshot()
+	snapshot.Hash = fftypes.NewRandB32()
+	_, err := am.ImportSnapshot(context.Background(), snapshot)
+	assert.Regexp(t, "FF10601", err)
+
+	_, err = am.ImportSnapshot(context.Background(), nil)
+	assert.Regexp(t, "FF10601", err)
+}
+
+func TestImportSnapshotBadSignature(t *testing.T) {
+	am, _ := newTestArchiveManager(t)
+	mim := am.identity.(*identitymanagermocks.Manager)
+
+	mim.On("VerifyOrgPayloadSignature", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := am.ImportSnapshot(context.Background(), newTestSnapshot())
+	assert.EqualError(t, err, "pop")
+	assert.Nil(t, am.snapshot)
+
+	mim.AssertExpectations(t)
+}
+
+func TestImportSnapshotImportFail(t *testing.T) {
+	am, mdi := newTestArchiveManager(t)
+	mim := am.identity.(*identitymanagermocks.Manager)
+
+	mim.On("VerifyOrgPayloadSignature", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.ImportSnapshot(context.Background(), newTestSnapshot(&core.BatchPersisted{}))
+	assert.EqualError(t, err, "pop")
+	assert.Nil(t, am.snapshot)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestGetSnapshotVerificationNotImported(t *testing.T) {
+	am, _ := newTestArchiveManager(t)
+
+	_, err := am.GetSnapshotVerification(context.Background())
+	assert.Regexp(t, "FF10603", err)
+}
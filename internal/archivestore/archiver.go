@@ -0,0 +1,179 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const (
+	// FormatCSV writes each batch of rows as a CSV file, with a header row of column names
+	FormatCSV = "csv"
+)
+
+// Archiver exports rows to an archive store ahead of them being pruned by the retention policy.
+// Each batch of rows is written as a file, alongside a manifest recording the sequence range and a
+// SHA-256 checksum of the file. The highest archived sequence of each collection is stored as an offset,
+// so archiving resumes where it left off after a restart.
+type Archiver interface {
+	Collections() []database.CollectionName
+	Archive(ctx context.Context, collection database.CollectionName, before *fftypes.FFTime) (archivedTo int64, err error)
+}
+
+// Manifest is written alongside each archive file
+type Manifest struct {
+	Namespace     string          `json:"namespace"`
+	Collection    string          `json:"collection"`
+	Format        string          `json:"format"`
+	File          string          `json:"file"`
+	Rows          int             `json:"rows"`
+	FirstSequence int64           `json:"firstSequence"`
+	LastSequence  int64           `json:"lastSequence"`
+	SHA256        string          `json:"sha256"`
+	Columns       []string        `json:"columns"`
+	Created       *fftypes.FFTime `json:"created"`
+}
+
+type archiver struct {
+	namespace   string
+	database    database.Plugin
+	store       Store
+	format      string
+	batchSize   int
+	collections []database.CollectionName
+}
+
+// NewArchiver returns nil if archiving is not enabled
+func NewArchiver(ctx context.Context, ns string, di database.Plugin) (Archiver, error) {
+	if !config.GetBool(coreconfig.RetentionArchiveEnabled) {
+		return nil, nil
+	}
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "Archiver")
+	}
+	format := config.GetString(coreconfig.RetentionArchiveFormat)
+	if format != FormatCSV {
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedArchiveFormat, format)
+	}
+	store, err := NewStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a := &archiver{
+		namespace: ns,
+		database:  di,
+		store:     store,
+		format:    format,
+		batchSize: config.GetInt(coreconfig.RetentionArchiveBatchSize),
+	}
+	for _, c := range config.GetStringSlice(coreconfig.RetentionArchiveCollections) {
+		a.collections = append(a.collections, database.CollectionName(c))
+	}
+	return a, nil
+}
+
+func (a *archiver) Collections() []database.CollectionName {
+	return a.collections
+}
+
+func (a *archiver) offsetName(collection database.CollectionName) string {
+	return fmt.Sprintf("%s:%s", a.namespace, collection)
+}
+
+// Archive writes all rows of the collection created before the given time, that have not already been
+// archived, and returns the highest sequence that has been archived
+func (a *archiver) Archive(ctx context.Context, collection database.CollectionName, before *fftypes.FFTime) (archivedTo int64, err error) {
+	offset, err := a.database.GetOffset(ctx, core.OffsetTypeArchive, a.offsetName(collection))
+	if err != nil {
+		return -1, err
+	}
+	if offset == nil {
+		offset = &core.Offset{Type: core.OffsetTypeArchive, Name: a.offsetName(collection)}
+	}
+	for {
+		rows, err := a.database.GetArchiveRows(ctx, a.namespace, collection, offset.Current, before, a.batchSize)
+		if err != nil {
+			return -1, err
+		}
+		if len(rows.Rows) == 0 {
+			return offset.Current, nil
+		}
+		if err := a.writeBatch(ctx, collection, rows); err != nil {
+			return -1, err
+		}
+		offset.Current = rows.Sequences[len(rows.Sequences)-1]
+		if err := a.database.UpsertOffset(ctx, offset, true); err != nil {
+			return -1, err
+		}
+		if len(rows.Rows) < a.batchSize {
+			return offset.Current, nil
+		}
+	}
+}
+
+func (a *archiver) writeBatch(ctx context.Context, collection database.CollectionName, rows *database.ArchiveRows) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(rows.Columns)
+	_ = w.WriteAll(rows.Rows)
+	if err := w.Error(); err != nil {
+		return err
+	}
+	content := buf.Bytes()
+	hash := sha256.Sum256(content)
+
+	first, last := rows.Sequences[0], rows.Sequences[len(rows.Sequences)-1]
+	base := fmt.Sprintf("%s/%s/%020d-%020d", a.namespace, collection, first, last)
+	manifest := &Manifest{
+		Namespace:     a.namespace,
+		Collection:    string(collection),
+		Format:        a.format,
+		File:          base + "." + a.format,
+		Rows:          len(rows.Rows),
+		FirstSequence: first,
+		LastSequence:  last,
+		SHA256:        hex.EncodeToString(hash[:]),
+		Columns:       rows.Columns,
+		Created:       fftypes.Now(),
+	}
+	manifestBytes, _ := json.MarshalIndent(manifest, "", "  ")
+
+	// The manifest is written last, so its presence confirms the file is complete
+	if err := a.store.Put(ctx, manifest.File, content); err != nil {
+		return err
+	}
+	if err := a.store.Put(ctx, base+".manifest.json", manifestBytes); err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Archived %d %s rows (sequence %d-%d) to %s", manifest.Rows, collection, first, last, manifest.File)
+	return nil
+}
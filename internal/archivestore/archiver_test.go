@@ -0,0 +1,221 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testStore struct {
+	files map[string][]byte
+	err   error
+}
+
+func (ts *testStore) Put(ctx context.Context, path string, content []byte) error {
+	if ts.err != nil {
+		return ts.err
+	}
+	ts.files[path] = content
+	return nil
+}
+
+func newTestArchiver(t *testing.T) (*archiver, *databasemocks.Plugin, *testStore, func()) {
+	coreconfig.Reset()
+	InitConfig()
+	dir := t.TempDir()
+	config.Set(coreconfig.RetentionArchiveEnabled, true)
+	config.Set(coreconfig.RetentionArchiveStorePath, dir)
+	config.Set(coreconfig.RetentionArchiveBatchSize, 2)
+	mdi := &databasemocks.Plugin{}
+	a, err := NewArchiver(context.Background(), "ns1", mdi)
+	assert.NoError(t, err)
+	ts := &testStore{files: map[string][]byte{}}
+	a.(*archiver).store = ts
+	return a.(*archiver), mdi, ts, func() {
+		mdi.AssertExpectations(t)
+	}
+}
+
+func TestNewArchiverDisabled(t *testing.T) {
+	coreconfig.Reset()
+	a, err := NewArchiver(context.Background(), "ns1", &databasemocks.Plugin{})
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+}
+
+func TestNewArchiverMissingDeps(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionArchiveEnabled, true)
+	_, err := NewArchiver(context.Background(), "ns1", nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestNewArchiverBadFormat(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionArchiveEnabled, true)
+	config.Set(coreconfig.RetentionArchiveFormat, "parquet")
+	_, err := NewArchiver(context.Background(), "ns1", &databasemocks.Plugin{})
+	assert.Regexp(t, "FF10511.*parquet", err)
+}
+
+func TestNewArchiverBadStore(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionArchiveEnabled, true)
+	config.Set(coreconfig.RetentionArchiveStoreType, "wrong")
+	_, err := NewArchiver(context.Background(), "ns1", &databasemocks.Plugin{})
+	assert.Regexp(t, "FF10512.*wrong", err)
+}
+
+func TestArchiveBatches(t *testing.T) {
+	a, mdi, ts, done := newTestArchiver(t)
+	defer done()
+	assert.Equal(t, []database.CollectionName{"messages", "events", "tokentransfers"}, a.Collections())
+
+	before := fftypes.Now()
+	columns := []string{"seq", "id"}
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeArchive, "ns1:events").Return(&core.Offset{Current: 10}, nil)
+	mdi.On("GetArchiveRows", mock.Anything, "ns1", database.CollectionName("events"), int64(10), before, 2).Return(&database.ArchiveRows{
+		Columns:   columns,
+		Rows:      [][]string{{"11", "a"}, {"12", "b,c"}},
+		Sequences: []int64{11, 12},
+	}, nil)
+	mdi.On("GetArchiveRows", mock.Anything, "ns1", database.CollectionName("events"), int64(12), before, 2).Return(&database.ArchiveRows{
+		Columns:   columns,
+		Rows:      [][]string{{"13", "d"}},
+		Sequences: []int64{13},
+	}, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Type == core.OffsetTypeArchive && o.Name == "ns1:events"
+	}), true).Return(nil).Twice()
+
+	archivedTo, err := a.Archive(context.Background(), "events", before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13), archivedTo)
+
+	assert.Len(t, ts.files, 4)
+	assert.Equal(t, "seq,id\n11,a\n12,\"b,c\"\n", string(ts.files["ns1/events/00000000000000000011-00000000000000000012.csv"]))
+	var manifest Manifest
+	err = json.Unmarshal(ts.files["ns1/events/00000000000000000011-00000000000000000012.manifest.json"], &manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1/events/00000000000000000011-00000000000000000012.csv", manifest.File)
+	assert.Equal(t, 2, manifest.Rows)
+	assert.Equal(t, int64(11), manifest.FirstSequence)
+	assert.Equal(t, int64(12), manifest.LastSequence)
+	assert.Equal(t, columns, manifest.Columns)
+	assert.Len(t, manifest.SHA256, 64)
+}
+
+func TestArchiveNothingNew(t *testing.T) {
+	a, mdi, _, done := newTestArchiver(t)
+	defer done()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeArchive, "ns1:messages").Return(nil, nil)
+	mdi.On("GetArchiveRows", mock.Anything, "ns1", database.CollectionName("messages"), int64(0), mock.Anything, 2).Return(&database.ArchiveRows{}, nil)
+
+	archivedTo, err := a.Archive(context.Background(), "messages", fftypes.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), archivedTo)
+}
+
+func TestArchiveGetOffsetFail(t *testing.T) {
+	a, mdi, _, done := newTestArchiver(t)
+	defer done()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeArchive, "ns1:messages").Return(nil, fmt.Errorf("pop"))
+
+	_, err := a.Archive(context.Background(), "messages", fftypes.Now())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestArchiveGetRowsFail(t *testing.T) {
+	a, mdi, _, done := newTestArchiver(t)
+	defer done()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeArchive, "ns1:messages").Return(nil, nil)
+	mdi.On("GetArchiveRows", mock.Anything, "ns1", database.CollectionName("messages"), int64(0), mock.Anything, 2).Return(nil, fmt.Errorf("pop"))
+
+	_, err := a.Archive(context.Background(), "messages", fftypes.Now())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestArchiveStoreFail(t *testing.T) {
+	a, mdi, ts, done := newTestArchiver(t)
+	defer done()
+	ts.err = fmt.Errorf("pop")
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeArchive, "ns1:messages").Return(nil, nil)
+	mdi.On("GetArchiveRows", mock.Anything, "ns1", database.CollectionName("messages"), int64(0), mock.Anything, 2).Return(&database.ArchiveRows{
+		Columns:   []string{"seq"},
+		Rows:      [][]string{{"1"}},
+		Sequences: []int64{1},
+	}, nil)
+
+	_, err := a.Archive(context.Background(), "messages", fftypes.Now())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestArchiveUpsertOffsetFail(t *testing.T) {
+	a, mdi, _, done := newTestArchiver(t)
+	defer done()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeArchive, "ns1:messages").Return(nil, nil)
+	mdi.On("GetArchiveRows", mock.Anything, "ns1", database.CollectionName("messages"), int64(0), mock.Anything, 2).Return(&database.ArchiveRows{
+		Columns:   []string{"seq"},
+		Rows:      [][]string{{"1"}},
+		Sequences: []int64{1},
+	}, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(fmt.Errorf("pop"))
+
+	_, err := a.Archive(context.Background(), "messages", fftypes.Now())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestArchiveToFilesystem(t *testing.T) {
+	a, mdi, _, done := newTestArchiver(t)
+	defer done()
+	store, err := NewStore(context.Background())
+	assert.NoError(t, err)
+	a.store = store
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeArchive, "ns1:messages").Return(nil, nil)
+	mdi.On("GetArchiveRows", mock.Anything, "ns1", database.CollectionName("messages"), int64(0), mock.Anything, 2).Return(&database.ArchiveRows{
+		Columns:   []string{"seq"},
+		Rows:      [][]string{{"1"}},
+		Sequences: []int64{1},
+	}, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+	_, err = a.Archive(context.Background(), "messages", fftypes.Now())
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(config.GetString(coreconfig.RetentionArchiveStorePath), "ns1", "messages", "00000000000000000001-00000000000000000001.csv"))
+	assert.NoError(t, err)
+	assert.Equal(t, "seq\n1\n", string(content))
+}
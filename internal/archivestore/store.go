@@ -0,0 +1,98 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+const (
+	// StoreTypeFilesystem writes archive files under a local (or mounted) directory
+	StoreTypeFilesystem = "filesystem"
+	// StoreTypeHTTP uploads archive files with an HTTP PUT, for example to an object store gateway
+	StoreTypeHTTP = "http"
+)
+
+var httpConfig = config.RootSection("retention.archive.store.http")
+
+func InitConfig() {
+	ffresty.InitConfig(httpConfig)
+}
+
+// Store is the destination archive files are written to
+type Store interface {
+	Put(ctx context.Context, path string, content []byte) error
+}
+
+func NewStore(ctx context.Context) (Store, error) {
+	storeType := config.GetString(coreconfig.RetentionArchiveStoreType)
+	switch storeType {
+	case StoreTypeFilesystem:
+		return &fsStore{dir: config.GetString(coreconfig.RetentionArchiveStorePath)}, nil
+	case StoreTypeHTTP:
+		client, err := ffresty.New(ctx, httpConfig)
+		if err != nil {
+			return nil, err
+		}
+		return &httpStore{client: client}, nil
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedArchiveStore, storeType)
+	}
+}
+
+type fsStore struct {
+	dir string
+}
+
+func (fs *fsStore) Put(ctx context.Context, path string, content []byte) error {
+	target := filepath.Join(fs.dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgArchiveStoreErr, err)
+	}
+	// Write to a temporary file and rename, so a partially written file is never visible
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgArchiveStoreErr, err)
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgArchiveStoreErr, err)
+	}
+	return nil
+}
+
+type httpStore struct {
+	client *resty.Client
+}
+
+func (hs *httpStore) Put(ctx context.Context, path string, content []byte) error {
+	res, err := hs.client.R().SetContext(ctx).
+		SetBody(content).
+		Put("/" + path)
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgArchiveStoreErr)
+	}
+	return nil
+}
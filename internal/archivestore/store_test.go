@@ -0,0 +1,79 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archivestore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesystemStoreMkdirFail(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "file"), []byte{}, 0600)
+	assert.NoError(t, err)
+	fs := &fsStore{dir: filepath.Join(dir, "file")}
+	err = fs.Put(context.Background(), "ns1/events/1.csv", []byte("data"))
+	assert.Regexp(t, "FF10513", err)
+}
+
+func TestFilesystemStoreRenameFail(t *testing.T) {
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "ns1", "1.csv"), 0755)
+	assert.NoError(t, err)
+	fs := &fsStore{dir: dir}
+	err = fs.Put(context.Background(), "ns1/1.csv", []byte("data"))
+	assert.Regexp(t, "FF10513", err)
+}
+
+func TestHTTPStore(t *testing.T) {
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		if r.URL.Path == "/ns1/events/fail.csv" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		assert.Equal(t, "/ns1/events/1.csv", r.URL.Path)
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	coreconfig.Reset()
+	InitConfig()
+	config.Set(coreconfig.RetentionArchiveStoreType, StoreTypeHTTP)
+	httpConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	store, err := NewStore(context.Background())
+	assert.NoError(t, err)
+
+	err = store.Put(context.Background(), "ns1/events/1.csv", []byte("data"))
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(uploaded))
+
+	err = store.Put(context.Background(), "ns1/events/fail.csv", []byte("data"))
+	assert.Regexp(t, "FF10513", err)
+}
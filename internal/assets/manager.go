@@ -72,8 +72,8 @@ type Manager interface {
 	PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error)
 	RunOperation(ctx context.Context, op *core.PreparedOperation) (outputs fftypes.JSONObject, phase core.OpPhase, err error)
 
-	// Starts the namespace on each of the configured token plugins
-	Start() error
+	// Starts the namespace on the named token plugin, with the active pools for its connector
+	StartConnector(name string) error
 }
 
 type assetManager struct {
@@ -173,26 +173,25 @@ func (am *assetManager) GetTokenConnectors(ctx context.Context) []*core.TokenCon
 	return connectors
 }
 
-func (am *assetManager) Start() error {
+func (am *assetManager) StartConnector(name string) error {
+	plugin, err := am.selectTokenPlugin(am.ctx, name)
+	if err != nil {
+		return err
+	}
+
 	f := database.TokenPoolQueryFactory.NewFilter(am.ctx).And()
 	pools, _, err := am.database.GetTokenPools(am.ctx, am.namespace, f)
 	if err != nil {
 		return err
 	}
 
-	for _, plugin := range am.tokens {
-		activePools := []*core.TokenPool{}
-		for _, pool := range pools {
-			if pool.Connector == plugin.ConnectorName() && pool.Active {
-				activePools = append(activePools, pool)
-			}
-		}
-		err := plugin.StartNamespace(am.ctx, am.namespace, activePools)
-		if err != nil {
-			return err
+	activePools := []*core.TokenPool{}
+	for _, pool := range pools {
+		if pool.Connector == plugin.ConnectorName() && pool.Active {
+			activePools = append(activePools, pool)
 		}
 	}
-	return nil
+	return plugin.StartNamespace(am.ctx, am.namespace, activePools)
 }
 
 func (am *assetManager) getDefaultTokenConnector(ctx context.Context) (string, error) {
@@ -185,10 +185,18 @@ func TestStart(t *testing.T) {
 	txHelper, _ := txcommon.NewTransactionHelper(context.Background(), "ns1", mdi, mdm, cmi)
 	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
 	assert.NoError(t, err)
-	err = am.Start()
+	err = am.StartConnector("magic-tokens")
 	assert.NoError(t, err)
 }
 
+func TestStartUnknownConnector(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	err := am.StartConnector("unknown")
+	assert.Regexp(t, "FF10272", err)
+}
+
 func TestStartDBError(t *testing.T) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
@@ -207,7 +215,7 @@ func TestStartDBError(t *testing.T) {
 	txHelper, _ := txcommon.NewTransactionHelper(context.Background(), "ns1", mdi, mdm, cmi)
 	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
 	assert.NoError(t, err)
-	err = am.Start()
+	err = am.StartConnector("magic-tokens")
 	assert.Regexp(t, "pop", err)
 }
 
@@ -236,6 +244,6 @@ func TestStartError(t *testing.T) {
 	txHelper, _ := txcommon.NewTransactionHelper(context.Background(), "ns1", mdi, mdm, cmi)
 	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
 	assert.NoError(t, err)
-	err = am.Start()
+	err = am.StartConnector("magic-tokens")
 	assert.Regexp(t, "pop", err)
 }
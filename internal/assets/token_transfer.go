@@ -199,8 +199,12 @@ func (s *transferSender) resolveAndSend(ctx context.Context, method sendMethod)
 }
 
 func (s *transferSender) resolve(ctx context.Context) (opResubmitted bool, err error) {
+	if err := core.ValidateTransactionLabels(ctx, "txlabels", s.transfer.TxLabels); err != nil {
+		return false, err
+	}
+
 	// Create a transaction and attach to the transfer
-	txid, err := s.mgr.txHelper.SubmitNewTransaction(ctx, core.TransactionTypeTokenTransfer, s.transfer.IdempotencyKey)
+	txid, err := s.mgr.txHelper.SubmitNewTransaction(ctx, core.TransactionTypeTokenTransfer, s.transfer.IdempotencyKey, s.transfer.TxLabels...)
 	if err != nil {
 		// Check if we've clashed on idempotency key. There might be operations still in "Initialized" state that need
 		// submitting to their handlers. Note that we'll return the result of resubmitting the operation, not a 409 Conflict error
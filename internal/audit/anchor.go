@@ -0,0 +1,377 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"database/sql/driver"
+	"math"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// anchoredEventTypes are the events committed to by an audit anchor. Each refers either to a message,
+// or to an operation that has reached a final outcome.
+var anchoredEventTypes = []driver.Value{
+	core.EventTypeMessageConfirmed,
+	core.EventTypeMessageRejected,
+	core.EventTypeBlockchainInvokeOpSucceeded,
+	core.EventTypeBlockchainInvokeOpFailed,
+	core.EventTypeBlockchainContractDeployOpSucceeded,
+	core.EventTypeBlockchainContractDeployOpFailed,
+	core.EventTypePoolOpFailed,
+	core.EventTypeTransferOpFailed,
+	core.EventTypeApprovalOpFailed,
+}
+
+func isMessageEvent(eventType core.EventType) bool {
+	return eventType == core.EventTypeMessageConfirmed || eventType == core.EventTypeMessageRejected
+}
+
+// Anchorer periodically commits to the history of a namespace, by pinning the Merkle root over the
+// messages and operation outcomes recorded in the latest range of events to the blockchain. The tree
+// can be rebuilt from the database at any time, to prove none of the history it covers has changed.
+type Anchorer interface {
+	Start()
+	WaitStop()
+	Verify(ctx context.Context, anchorID *fftypes.UUID) (*core.AuditAnchorVerification, error)
+	Proof(ctx context.Context, anchorID, eventID *fftypes.UUID) (*core.AuditAnchorProof, error)
+}
+
+type anchorer struct {
+	ctx        context.Context
+	namespace  string
+	database   database.Plugin
+	multiparty multiparty.Manager
+	identity   identity.Manager
+	interval   time.Duration
+	maxLeaves  int
+	maxPins    int
+	retryDelay time.Duration
+	alerted    *fftypes.UUID
+	done       chan struct{}
+}
+
+// NewAnchorer returns nil if anchoring is not enabled, or the namespace is not a multiparty namespace
+func NewAnchorer(ctx context.Context, ns string, di database.Plugin, mm multiparty.Manager, im identity.Manager) (Anchorer, error) {
+	if !config.GetBool(coreconfig.AuditAnchorEnabled) {
+		return nil, nil
+	}
+	if mm == nil {
+		log.L(ctx).Warnf("Audit anchoring is disabled for namespace '%s' as it is not a multiparty namespace", ns)
+		return nil, nil
+	}
+	if di == nil || im == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "AuditAnchorer")
+	}
+	maxLeaves := config.GetInt(coreconfig.AuditAnchorMaxLeaves)
+	if maxLeaves <= 0 {
+		maxLeaves = math.MaxInt
+	}
+	return &anchorer{
+		ctx:        ctx,
+		namespace:  ns,
+		database:   di,
+		multiparty: mm,
+		identity:   im,
+		interval:   config.GetDuration(coreconfig.AuditAnchorInterval),
+		maxLeaves:  maxLeaves,
+		maxPins:    config.GetInt(coreconfig.AuditAnchorPinMaxAttempts),
+		retryDelay: config.GetDuration(coreconfig.AuditAnchorPinRetryDelay),
+	}, nil
+}
+
+func (a *anchorer) Start() {
+	a.done = make(chan struct{})
+	go a.anchorLoop()
+}
+
+func (a *anchorer) WaitStop() {
+	if a.done != nil {
+		<-a.done
+	}
+}
+
+func (a *anchorer) anchorLoop() {
+	defer close(a.done)
+	for {
+		select {
+		case <-time.After(a.interval):
+		case <-a.ctx.Done():
+			log.L(a.ctx).Debugf("Audit anchorer exiting")
+			return
+		}
+		a.anchorAll(a.ctx)
+	}
+}
+
+// anchorAll submits anchors until every anchored event is covered, so a backlog larger than
+// the maximum size of an anchor does not fall further behind on each interval
+func (a *anchorer) anchorAll(ctx context.Context) {
+	for {
+		full, err := a.anchor(ctx)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to submit audit anchor: %s", err)
+			return
+		}
+		if !full {
+			return
+		}
+	}
+}
+
+func (a *anchorer) anchor(ctx context.Context) (full bool, err error) {
+	fb := database.AuditAnchorQueryFactory.NewFilter(ctx)
+	last, _, err := a.database.GetAuditAnchors(ctx, a.namespace, fb.And().Sort("-lastevent").Limit(1))
+	if err != nil {
+		return false, err
+	}
+	var after int64
+	if len(last) > 0 {
+		// Only move past the last anchor once its root is on the blockchain, so a failed pin is retried
+		// rather than leaving a range of the history anchored but never pinned
+		pinned, exhausted, err := a.multiparty.CheckAuditAnchorPin(ctx, last[0], a.maxPins, a.retryDelay)
+		if exhausted {
+			a.alertPinFailed(ctx, last[0])
+		}
+		if err != nil || !pinned {
+			return false, err
+		}
+		after = last[0].LastEvent
+	}
+
+	leaves, err := a.buildLeaves(ctx, after, 0, a.maxLeaves)
+	if err != nil || len(leaves) == 0 {
+		return false, err
+	}
+
+	key, err := a.identity.ResolveInputSigningKey(ctx, "", identity.KeyNormalizationBlockchainPlugin)
+	if err != nil {
+		return false, err
+	}
+	anchor := &core.AuditAnchor{
+		ID:         fftypes.NewUUID(),
+		Namespace:  a.namespace,
+		FirstEvent: leaves[0].Sequence,
+		LastEvent:  leaves[len(leaves)-1].Sequence,
+		Leaves:     int64(len(leaves)),
+		Root:       core.MerkleRoot(leafHashes(leaves)),
+		Created:    fftypes.Now(),
+	}
+	if err := a.multiparty.SubmitAuditAnchor(ctx, key, anchor); err != nil {
+		return false, err
+	}
+	log.L(ctx).Infof("Submitted audit anchor %s covering %d events from sequence %d to %d with root %s",
+		anchor.ID, anchor.Leaves, anchor.FirstEvent, anchor.LastEvent, anchor.Root)
+	return len(leaves) == a.maxLeaves, nil
+}
+
+// alertPinFailed emits an event the first time the pin of an anchor is found to have failed on every
+// attempt, as anchoring of the namespace cannot continue until it is resolved
+func (a *anchorer) alertPinFailed(ctx context.Context, anchor *core.AuditAnchor) {
+	if anchor.ID.Equals(a.alerted) {
+		return
+	}
+	event := core.NewEvent(core.EventTypeAuditAnchorFailed, a.namespace, anchor.ID, anchor.TX.ID, core.SystemTopicAuditAnchors)
+	if err := a.database.InsertEvent(ctx, event); err != nil {
+		log.L(ctx).Errorf("Failed to record %s event for audit anchor %s: %s", event.Type, anchor.ID, err)
+		return
+	}
+	a.alerted = anchor.ID
+}
+
+// buildLeaves reads up to limit anchored events after the given sequence, and up to and including
+// the until sequence if it is set
+func (a *anchorer) buildLeaves(ctx context.Context, after, until int64, limit int) ([]*core.AuditAnchorLeaf, error) {
+	leaves := []*core.AuditAnchorLeaf{}
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	for len(leaves) < limit {
+		pageSize := verifyPageSize
+		if limit-len(leaves) < pageSize {
+			pageSize = limit - len(leaves)
+		}
+		filters := []ffapi.Filter{
+			fb.Gt("sequence", after),
+			fb.In("type", anchoredEventTypes),
+		}
+		if until > 0 {
+			filters = append(filters, fb.Lte("sequence", until))
+		}
+		events, _, err := a.database.GetEvents(ctx, a.namespace, fb.And(filters...).Sort("sequence").Limit(uint64(pageSize)))
+		if err != nil {
+			return nil, err
+		}
+		page, err := a.leavesForEvents(ctx, events)
+		if err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, page...)
+		if len(events) < pageSize {
+			break
+		}
+		after = events[len(events)-1].Sequence
+	}
+	return leaves, nil
+}
+
+func (a *anchorer) leavesForEvents(ctx context.Context, events []*core.Event) ([]*core.AuditAnchorLeaf, error) {
+	var msgIDs, opIDs []driver.Value
+	for _, event := range events {
+		if event.Reference == nil {
+			continue
+		}
+		if isMessageEvent(event.Type) {
+			msgIDs = append(msgIDs, event.Reference)
+		} else {
+			opIDs = append(opIDs, event.Reference)
+		}
+	}
+
+	msgHashes := make(map[fftypes.UUID]*fftypes.Bytes32)
+	if len(msgIDs) > 0 {
+		fb := database.MessageQueryFactory.NewFilter(ctx)
+		msgs, _, err := a.database.GetMessages(ctx, a.namespace, fb.In("id", msgIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			msgHashes[*msg.Header.ID] = msg.Hash
+		}
+	}
+
+	ops := make(map[fftypes.UUID]*core.Operation)
+	if len(opIDs) > 0 {
+		fb := database.OperationQueryFactory.NewFilter(ctx)
+		opList, _, err := a.database.GetOperations(ctx, a.namespace, fb.In("id", opIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range opList {
+			ops[*op.ID] = op
+		}
+	}
+
+	// A message or operation that cannot be found is committed to as missing, rather than skipped,
+	// so that it is detected if it is later inserted
+	leaves := make([]*core.AuditAnchorLeaf, len(events))
+	for i, event := range events {
+		leaf := &core.AuditAnchorLeaf{
+			Sequence:    event.Sequence,
+			Event:       event.ID,
+			Type:        event.Type,
+			Reference:   event.Reference,
+			Transaction: event.Transaction,
+		}
+		if event.Reference != nil {
+			if isMessageEvent(event.Type) {
+				leaf.MessageHash = msgHashes[*event.Reference]
+			} else if op := ops[*event.Reference]; op != nil {
+				leaf.OpStatus = op.Status
+				leaf.OpError = op.Error
+			}
+		}
+		leaves[i] = leaf
+	}
+	return leaves, nil
+}
+
+func leafHashes(leaves []*core.AuditAnchorLeaf) []*fftypes.Bytes32 {
+	hashes := make([]*fftypes.Bytes32, len(leaves))
+	for i, leaf := range leaves {
+		hashes[i] = leaf.Hash()
+	}
+	return hashes
+}
+
+func (a *anchorer) getAnchor(ctx context.Context, anchorID *fftypes.UUID) (*core.AuditAnchor, error) {
+	anchor, err := a.database.GetAuditAnchorByID(ctx, a.namespace, anchorID)
+	if err != nil {
+		return nil, err
+	} else if anchor == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return anchor, nil
+}
+
+func (a *anchorer) Verify(ctx context.Context, anchorID *fftypes.UUID) (*core.AuditAnchorVerification, error) {
+	anchor, err := a.getAnchor(ctx, anchorID)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := a.buildLeaves(ctx, anchor.FirstEvent-1, anchor.LastEvent, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	result := &core.AuditAnchorVerification{
+		Anchor: anchor,
+		Leaves: int64(len(leaves)),
+		Root:   core.MerkleRoot(leafHashes(leaves)),
+	}
+
+	// Compare against the root that was actually pinned, where the blockchain event has been received
+	fb := database.BlockchainEventQueryFactory.NewFilter(ctx)
+	chainEvents, _, err := a.database.GetBlockchainEvents(ctx, a.namespace, fb.Eq("tx.id", anchor.TX.ID))
+	if err != nil {
+		return nil, err
+	}
+	if len(chainEvents) > 0 {
+		result.BlockchainEvent = chainEvents[0].ID
+		if pinned, err := fftypes.ParseBytes32(ctx, chainEvents[0].Output.GetString("batchHash")); err == nil {
+			result.PinnedRoot = pinned
+		}
+	}
+	result.Valid = result.Root.Equals(anchor.Root) &&
+		(result.PinnedRoot == nil || result.PinnedRoot.Equals(anchor.Root))
+	return result, nil
+}
+
+func (a *anchorer) Proof(ctx context.Context, anchorID, eventID *fftypes.UUID) (*core.AuditAnchorProof, error) {
+	anchor, err := a.getAnchor(ctx, anchorID)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := a.buildLeaves(ctx, anchor.FirstEvent-1, anchor.LastEvent, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	hashes := leafHashes(leaves)
+	for i, leaf := range leaves {
+		if leaf.Event.Equals(eventID) {
+			path := core.MerklePath(hashes, i)
+			return &core.AuditAnchorProof{
+				Anchor:   anchor,
+				Leaf:     leaf,
+				LeafHash: hashes[i],
+				Index:    int64(i),
+				Path:     path,
+				Valid:    core.MerklePathRoot(hashes[i], path).Equals(anchor.Root),
+			}, nil
+		}
+	}
+	return nil, i18n.NewError(ctx, coremsgs.MsgAuditAnchorEventNotFound, eventID, anchorID)
+}
@@ -0,0 +1,519 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testAnchorer struct {
+	anchorer
+	mdi *databasemocks.Plugin
+	mmp *multipartymocks.Manager
+	mim *identitymanagermocks.Manager
+}
+
+func (ta *testAnchorer) cleanup(t *testing.T) {
+	ta.mdi.AssertExpectations(t)
+	ta.mmp.AssertExpectations(t)
+	ta.mim.AssertExpectations(t)
+}
+
+func newTestAnchorer(t *testing.T) *testAnchorer {
+	coreconfig.Reset()
+	config.Set(coreconfig.AuditAnchorEnabled, true)
+	mdi := &databasemocks.Plugin{}
+	mmp := &multipartymocks.Manager{}
+	mim := &identitymanagermocks.Manager{}
+	a, err := NewAnchorer(context.Background(), "ns1", mdi, mmp, mim)
+	assert.NoError(t, err)
+	return &testAnchorer{
+		anchorer: *a.(*anchorer),
+		mdi:      mdi,
+		mmp:      mmp,
+		mim:      mim,
+	}
+}
+
+type testHistory struct {
+	events []*core.Event
+	msgs   []*core.Message
+	ops    []*core.Operation
+}
+
+func newTestHistory() *testHistory {
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, Hash: fftypes.NewRandB32()}
+	msg2 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, Hash: fftypes.NewRandB32()}
+	op1 := &core.Operation{ID: fftypes.NewUUID(), Status: core.OpStatusSucceeded}
+	op2 := &core.Operation{ID: fftypes.NewUUID(), Status: core.OpStatusFailed, Error: "pop"}
+	return &testHistory{
+		events: []*core.Event{
+			{Sequence: 6, ID: fftypes.NewUUID(), Type: core.EventTypeMessageConfirmed, Reference: msg1.Header.ID},
+			{Sequence: 8, ID: fftypes.NewUUID(), Type: core.EventTypeBlockchainInvokeOpSucceeded, Reference: op1.ID, Transaction: fftypes.NewUUID()},
+			{Sequence: 9, ID: fftypes.NewUUID(), Type: core.EventTypeMessageRejected, Reference: msg2.Header.ID},
+			{Sequence: 12, ID: fftypes.NewUUID(), Type: core.EventTypeTransferOpFailed, Reference: op2.ID, Transaction: fftypes.NewUUID()},
+		},
+		msgs: []*core.Message{msg1, msg2},
+		ops:  []*core.Operation{op1, op2},
+	}
+}
+
+func (th *testHistory) root() *fftypes.Bytes32 {
+	hashes := []*fftypes.Bytes32{
+		(&core.AuditAnchorLeaf{Sequence: 6, Event: th.events[0].ID, Type: th.events[0].Type, Reference: th.events[0].Reference, MessageHash: th.msgs[0].Hash}).Hash(),
+		(&core.AuditAnchorLeaf{Sequence: 8, Event: th.events[1].ID, Type: th.events[1].Type, Reference: th.events[1].Reference, Transaction: th.events[1].Transaction, OpStatus: core.OpStatusSucceeded}).Hash(),
+		(&core.AuditAnchorLeaf{Sequence: 9, Event: th.events[2].ID, Type: th.events[2].Type, Reference: th.events[2].Reference, MessageHash: th.msgs[1].Hash}).Hash(),
+		(&core.AuditAnchorLeaf{Sequence: 12, Event: th.events[3].ID, Type: th.events[3].Type, Reference: th.events[3].Reference, Transaction: th.events[3].Transaction, OpStatus: core.OpStatusFailed, OpError: "pop"}).Hash(),
+	}
+	return core.MerkleRoot(hashes)
+}
+
+func (ta *testAnchorer) expectHistory(th *testHistory) {
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(th.events, nil, nil).Once()
+	ta.mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(th.msgs, nil, nil).Once()
+	ta.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(th.ops, nil, nil).Once()
+}
+
+func TestNewAnchorerDisabled(t *testing.T) {
+	coreconfig.Reset()
+	a, err := NewAnchorer(context.Background(), "ns1", nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+}
+
+func TestNewAnchorerNotMultiparty(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.AuditAnchorEnabled, true)
+	a, err := NewAnchorer(context.Background(), "ns1", &databasemocks.Plugin{}, nil, &identitymanagermocks.Manager{})
+	assert.NoError(t, err)
+	assert.Nil(t, a)
+}
+
+func TestNewAnchorerNilDatabase(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.AuditAnchorEnabled, true)
+	_, err := NewAnchorer(context.Background(), "ns1", nil, &multipartymocks.Manager{}, &identitymanagermocks.Manager{})
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestNewAnchorerUnlimitedLeaves(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.AuditAnchorEnabled, true)
+	config.Set(coreconfig.AuditAnchorMaxLeaves, 0)
+	a, err := NewAnchorer(context.Background(), "ns1", &databasemocks.Plugin{}, &multipartymocks.Manager{}, &identitymanagermocks.Manager{})
+	assert.NoError(t, err)
+	assert.Greater(t, a.(*anchorer).maxLeaves, 1000000)
+}
+
+func TestAnchorSubmitsRoot(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{{LastEvent: 5}}, nil, nil)
+	ta.mmp.On("CheckAuditAnchorPin", mock.Anything, mock.Anything, 5, time.Minute).Return(true, false, nil)
+	ta.expectHistory(th)
+	ta.mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("0x123", nil)
+	ta.mmp.On("SubmitAuditAnchor", mock.Anything, "0x123", mock.MatchedBy(func(anchor *core.AuditAnchor) bool {
+		return anchor.Namespace == "ns1" &&
+			anchor.FirstEvent == 6 &&
+			anchor.LastEvent == 12 &&
+			anchor.Leaves == 4 &&
+			anchor.Root.Equals(th.root())
+	})).Return(nil)
+
+	full, err := ta.anchor(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, full)
+}
+
+func TestAnchorNoEvents(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{}, nil, nil)
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+
+	full, err := ta.anchor(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, full)
+}
+
+func TestAnchorAllContinuesWhileFull(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	ta.maxLeaves = 1
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, Hash: fftypes.NewRandB32()}
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{}, nil, nil).Once()
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{{LastEvent: 1}}, nil, nil).Once()
+	ta.mmp.On("CheckAuditAnchorPin", mock.Anything, mock.Anything, 5, time.Minute).Return(true, false, nil).Once()
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		{Sequence: 1, ID: fftypes.NewUUID(), Type: core.EventTypeMessageConfirmed, Reference: msg.Header.ID},
+	}, nil, nil).Once()
+	ta.mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil).Once()
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+	ta.mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("0x123", nil)
+	ta.mmp.On("SubmitAuditAnchor", mock.Anything, "0x123", mock.Anything).Return(nil).Once()
+
+	ta.anchorAll(context.Background())
+}
+
+func TestAnchorAllSubmitFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{}, nil, nil)
+	ta.expectHistory(th)
+	ta.mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("0x123", nil)
+	ta.mmp.On("SubmitAuditAnchor", mock.Anything, "0x123", mock.Anything).Return(fmt.Errorf("pop")).Once()
+
+	ta.anchorAll(context.Background())
+}
+
+func TestAnchorWaitsForLastAnchorPin(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	last := &core.AuditAnchor{ID: fftypes.NewUUID(), LastEvent: 5}
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{last}, nil, nil)
+	ta.mmp.On("CheckAuditAnchorPin", mock.Anything, last, 5, time.Minute).Return(false, false, nil).Once()
+	ta.mmp.On("CheckAuditAnchorPin", mock.Anything, last, 5, time.Minute).Return(false, false, fmt.Errorf("pop")).Once()
+
+	full, err := ta.anchor(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, full)
+
+	_, err = ta.anchor(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAnchorAlertsWhenPinExhausted(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	last := &core.AuditAnchor{ID: fftypes.NewUUID(), LastEvent: 5, TX: core.TransactionRef{ID: fftypes.NewUUID()}}
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{last}, nil, nil)
+	ta.mmp.On("CheckAuditAnchorPin", mock.Anything, last, 5, time.Minute).Return(false, true, nil)
+	ta.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeAuditAnchorFailed &&
+			event.Reference.Equals(last.ID) &&
+			event.Transaction.Equals(last.TX.ID) &&
+			event.Topic == core.SystemTopicAuditAnchors
+	})).Return(nil).Once()
+
+	// The alert is only emitted once for each anchor
+	for i := 0; i < 2; i++ {
+		full, err := ta.anchor(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, full)
+	}
+}
+
+func TestAnchorAlertInsertFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	last := &core.AuditAnchor{ID: fftypes.NewUUID(), LastEvent: 5}
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{last}, nil, nil)
+	ta.mmp.On("CheckAuditAnchorPin", mock.Anything, last, 5, time.Minute).Return(false, true, nil)
+	ta.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Twice()
+
+	// A failed alert is attempted again on the next interval
+	for i := 0; i < 2; i++ {
+		_, err := ta.anchor(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Nil(t, ta.alerted)
+}
+
+func TestAnchorGetAnchorsFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ta.anchor(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAnchorGetEventsFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{}, nil, nil)
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ta.anchor(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAnchorGetMessagesFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{}, nil, nil)
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(th.events, nil, nil)
+	ta.mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ta.anchor(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAnchorGetOperationsFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{}, nil, nil)
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(th.events, nil, nil)
+	ta.mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(th.msgs, nil, nil)
+	ta.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ta.anchor(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAnchorResolveKeyFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditAnchor{}, nil, nil)
+	ta.expectHistory(th)
+	ta.mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("", fmt.Errorf("pop"))
+
+	_, err := ta.anchor(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyValid(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+	anchor := &core.AuditAnchor{
+		ID:         fftypes.NewUUID(),
+		FirstEvent: 6,
+		LastEvent:  12,
+		Leaves:     4,
+		Root:       th.root(),
+		TX:         core.TransactionRef{ID: fftypes.NewUUID()},
+	}
+	chainEvent := &core.BlockchainEvent{
+		ID:     fftypes.NewUUID(),
+		Output: fftypes.JSONObject{"batchHash": "0x" + anchor.Root.String()},
+	}
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchor.ID).Return(anchor, nil)
+	ta.expectHistory(th)
+	ta.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{chainEvent}, nil, nil)
+
+	result, err := ta.Verify(context.Background(), anchor.ID)
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, int64(4), result.Leaves)
+	assert.Equal(t, *anchor.Root, *result.PinnedRoot)
+	assert.Equal(t, *chainEvent.ID, *result.BlockchainEvent)
+}
+
+func TestVerifyMessageChanged(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+	anchor := &core.AuditAnchor{
+		ID:         fftypes.NewUUID(),
+		FirstEvent: 6,
+		LastEvent:  12,
+		Leaves:     4,
+		Root:       th.root(),
+		TX:         core.TransactionRef{ID: fftypes.NewUUID()},
+	}
+	th.msgs[1].Hash = fftypes.NewRandB32()
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchor.ID).Return(anchor, nil)
+	ta.expectHistory(th)
+	ta.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{}, nil, nil)
+
+	result, err := ta.Verify(context.Background(), anchor.ID)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Nil(t, result.BlockchainEvent)
+}
+
+func TestVerifyPinnedRootMismatch(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+	anchor := &core.AuditAnchor{
+		ID:         fftypes.NewUUID(),
+		FirstEvent: 6,
+		LastEvent:  12,
+		Leaves:     4,
+		Root:       th.root(),
+		TX:         core.TransactionRef{ID: fftypes.NewUUID()},
+	}
+	chainEvent := &core.BlockchainEvent{
+		ID:     fftypes.NewUUID(),
+		Output: fftypes.JSONObject{"batchHash": fftypes.NewRandB32().String()},
+	}
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchor.ID).Return(anchor, nil)
+	ta.expectHistory(th)
+	ta.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{chainEvent}, nil, nil)
+
+	result, err := ta.Verify(context.Background(), anchor.ID)
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, *anchor.Root, *result.Root)
+}
+
+func TestVerifyNotFound(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	anchorID := fftypes.NewUUID()
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchorID).Return(nil, nil)
+
+	_, err := ta.Verify(context.Background(), anchorID)
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestVerifyGetAnchorFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	anchorID := fftypes.NewUUID()
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchorID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ta.Verify(context.Background(), anchorID)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyGetEventsFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	anchor := &core.AuditAnchor{ID: fftypes.NewUUID(), FirstEvent: 1, LastEvent: 2}
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchor.ID).Return(anchor, nil)
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ta.Verify(context.Background(), anchor.ID)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyGetBlockchainEventsFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	anchor := &core.AuditAnchor{ID: fftypes.NewUUID(), FirstEvent: 1, LastEvent: 2}
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchor.ID).Return(anchor, nil)
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+	ta.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ta.Verify(context.Background(), anchor.ID)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestProof(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+	anchor := &core.AuditAnchor{
+		ID:         fftypes.NewUUID(),
+		FirstEvent: 6,
+		LastEvent:  12,
+		Leaves:     4,
+		Root:       th.root(),
+	}
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchor.ID).Return(anchor, nil)
+	ta.expectHistory(th)
+
+	proof, err := ta.Proof(context.Background(), anchor.ID, th.events[2].ID)
+	assert.NoError(t, err)
+	assert.True(t, proof.Valid)
+	assert.Equal(t, int64(2), proof.Index)
+	assert.Equal(t, *th.msgs[1].Hash, *proof.Leaf.MessageHash)
+	assert.Equal(t, *anchor.Root, *core.MerklePathRoot(proof.Leaf.Hash(), proof.Path))
+}
+
+func TestProofEventNotInAnchor(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	th := newTestHistory()
+	anchor := &core.AuditAnchor{ID: fftypes.NewUUID(), FirstEvent: 6, LastEvent: 12}
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchor.ID).Return(anchor, nil)
+	ta.expectHistory(th)
+
+	_, err := ta.Proof(context.Background(), anchor.ID, fftypes.NewUUID())
+	assert.Regexp(t, "FF10585", err)
+}
+
+func TestProofNotFound(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	anchorID := fftypes.NewUUID()
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchorID).Return(nil, nil)
+
+	_, err := ta.Proof(context.Background(), anchorID, fftypes.NewUUID())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestProofGetEventsFail(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	anchor := &core.AuditAnchor{ID: fftypes.NewUUID(), FirstEvent: 1, LastEvent: 2}
+
+	ta.mdi.On("GetAuditAnchorByID", mock.Anything, "ns1", anchor.ID).Return(anchor, nil)
+	ta.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ta.Proof(context.Background(), anchor.ID, fftypes.NewUUID())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAnchorLoop(t *testing.T) {
+	ta := newTestAnchorer(t)
+	defer ta.cleanup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	ta.ctx = ctx
+	ta.interval = time.Millisecond
+
+	ta.mdi.On("GetAuditAnchors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		cancel()
+	}).Once()
+
+	ta.Start()
+	ta.WaitStop()
+}
@@ -0,0 +1,123 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// verifyPageSize is the number of records read from the database in each page when verifying the log
+const verifyPageSize = 100
+
+// Logger appends records to the audit log of a namespace. Each record contains the hash of the record
+// before it, so an edit to, or removal of, any record in the database breaks the chain and is detected
+// by Verify.
+type Logger interface {
+	Record(ctx context.Context, record *core.AuditRecord) error
+	Verify(ctx context.Context) (*core.AuditVerification, error)
+}
+
+type logger struct {
+	namespace string
+	database  database.Plugin
+	mux       sync.Mutex
+	lastHash  *fftypes.Bytes32
+	loaded    bool
+}
+
+// NewLogger returns nil if auditing is not enabled
+func NewLogger(ctx context.Context, ns string, di database.Plugin) (Logger, error) {
+	if !config.GetBool(coreconfig.AuditEnabled) {
+		return nil, nil
+	}
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "AuditLogger")
+	}
+	return &logger{
+		namespace: ns,
+		database:  di,
+	}, nil
+}
+
+func (l *logger) Record(ctx context.Context, record *core.AuditRecord) error {
+	// Records are chained, so must be written one at a time
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if !l.loaded {
+		fb := database.AuditQueryFactory.NewFilter(ctx)
+		last, _, err := l.database.GetAuditRecords(ctx, l.namespace, fb.And().Sort("-sequence").Limit(1))
+		if err != nil {
+			return err
+		}
+		if len(last) > 0 {
+			l.lastHash = last[0].Hash
+		}
+		l.loaded = true
+	}
+
+	record.ID = fftypes.NewUUID()
+	record.Namespace = l.namespace
+	if record.Created == nil {
+		record.Created = fftypes.Now()
+	}
+	record.PreviousHash = l.lastHash
+	record.Hash = record.CalcHash()
+	if err := l.database.InsertAuditRecord(ctx, record); err != nil {
+		// We cannot be sure whether the record was written, so re-read the tail of the chain next time
+		l.loaded = false
+		return err
+	}
+	l.lastHash = record.Hash
+	return nil
+}
+
+func (l *logger) Verify(ctx context.Context) (*core.AuditVerification, error) {
+	result := &core.AuditVerification{Valid: true}
+	var previousHash *fftypes.Bytes32
+	var lastSequence int64 = -1
+	fb := database.AuditQueryFactory.NewFilter(ctx)
+	for {
+		records, _, err := l.database.GetAuditRecords(ctx, l.namespace,
+			fb.Gt("sequence", lastSequence).Sort("sequence").Limit(verifyPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			result.Records++
+			if !record.PreviousHash.Equals(previousHash) || !record.Hash.Equals(record.CalcHash()) {
+				result.Valid = false
+				result.FirstInvalid = record.ID
+				return result, nil
+			}
+			previousHash = record.Hash
+			lastSequence = record.Sequence
+		}
+		if len(records) < verifyPageSize {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestLogger(t *testing.T) (*logger, *databasemocks.Plugin) {
+	coreconfig.Reset()
+	config.Set(coreconfig.AuditEnabled, true)
+	mdi := &databasemocks.Plugin{}
+	l, err := NewLogger(context.Background(), "ns1", mdi)
+	assert.NoError(t, err)
+	return l.(*logger), mdi
+}
+
+func testChain(n int) []*core.AuditRecord {
+	records := make([]*core.AuditRecord, n)
+	var previousHash *fftypes.Bytes32
+	for i := range records {
+		records[i] = &core.AuditRecord{
+			Sequence:     int64(i + 1),
+			ID:           fftypes.NewUUID(),
+			Namespace:    "ns1",
+			Method:       "POST",
+			Path:         "/api/v1/namespaces/ns1/messages/broadcast",
+			Route:        "postNewMessageBroadcast",
+			Status:       202,
+			Created:      fftypes.Now(),
+			PreviousHash: previousHash,
+		}
+		records[i].Hash = records[i].CalcHash()
+		previousHash = records[i].Hash
+	}
+	return records
+}
+
+func TestNewLoggerDisabled(t *testing.T) {
+	coreconfig.Reset()
+	l, err := NewLogger(context.Background(), "ns1", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+}
+
+func TestNewLoggerNilDatabase(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.AuditEnabled, true)
+	_, err := NewLogger(context.Background(), "ns1", nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestRecordChainsHashes(t *testing.T) {
+	l, mdi := newTestLogger(t)
+	existing := testChain(1)[0]
+
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditRecord{existing}, nil, nil).Once()
+	mdi.On("InsertAuditRecord", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	record1 := &core.AuditRecord{Method: "POST", Route: "postData", Status: 201}
+	err := l.Record(context.Background(), record1)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1", record1.Namespace)
+	assert.NotNil(t, record1.ID)
+	assert.NotNil(t, record1.Created)
+	assert.Equal(t, existing.Hash, record1.PreviousHash)
+	assert.Equal(t, record1.CalcHash(), record1.Hash)
+
+	record2 := &core.AuditRecord{Method: "DELETE", Route: "deleteData", Status: 204}
+	err = l.Record(context.Background(), record2)
+	assert.NoError(t, err)
+	assert.Equal(t, record1.Hash, record2.PreviousHash)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRecordFirstInChain(t *testing.T) {
+	l, mdi := newTestLogger(t)
+
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditRecord{}, nil, nil)
+	mdi.On("InsertAuditRecord", mock.Anything, mock.Anything).Return(nil)
+
+	record := &core.AuditRecord{Method: "POST", Route: "postData", Status: 201}
+	err := l.Record(context.Background(), record)
+	assert.NoError(t, err)
+	assert.Nil(t, record.PreviousHash)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRecordLoadFail(t *testing.T) {
+	l, mdi := newTestLogger(t)
+
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := l.Record(context.Background(), &core.AuditRecord{})
+	assert.EqualError(t, err, "pop")
+	assert.False(t, l.loaded)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRecordInsertFailReloads(t *testing.T) {
+	l, mdi := newTestLogger(t)
+
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditRecord{}, nil, nil).Twice()
+	mdi.On("InsertAuditRecord", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
+	mdi.On("InsertAuditRecord", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := l.Record(context.Background(), &core.AuditRecord{})
+	assert.EqualError(t, err, "pop")
+	assert.False(t, l.loaded)
+
+	err = l.Record(context.Background(), &core.AuditRecord{})
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyValid(t *testing.T) {
+	l, mdi := newTestLogger(t)
+	records := testChain(verifyPageSize + 1)
+
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return(records[:verifyPageSize], nil, nil).Once()
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return(records[verifyPageSize:], nil, nil).Once()
+
+	result, err := l.Verify(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, int64(verifyPageSize+1), result.Records)
+	assert.Nil(t, result.FirstInvalid)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyTamperedContent(t *testing.T) {
+	l, mdi := newTestLogger(t)
+	records := testChain(3)
+	records[1].Status = 200
+
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return(records, nil, nil).Once()
+
+	result, err := l.Verify(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, int64(2), result.Records)
+	assert.Equal(t, records[1].ID, result.FirstInvalid)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyRemovedRecord(t *testing.T) {
+	l, mdi := newTestLogger(t)
+	records := testChain(3)
+
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return([]*core.AuditRecord{records[0], records[2]}, nil, nil).Once()
+
+	result, err := l.Verify(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, records[2].ID, result.FirstInvalid)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyQueryFail(t *testing.T) {
+	l, mdi := newTestLogger(t)
+
+	mdi.On("GetAuditRecords", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := l.Verify(context.Background())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
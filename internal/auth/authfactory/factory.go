@@ -0,0 +1,45 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authfactory
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/auth"
+	commonauthfactory "github.com/hyperledger/firefly-common/pkg/auth/authfactory"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/auth/oidc"
+)
+
+// pluginsByName are the auth plugins provided by FireFly core, in addition to those in firefly-common
+var pluginsByName = map[string]func() auth.Plugin{
+	(*oidc.Auth)(nil).Name(): func() auth.Plugin { return &oidc.Auth{} },
+}
+
+func InitConfigArray(config config.ArraySection) {
+	commonauthfactory.InitConfigArray(config)
+	for name, plugin := range pluginsByName {
+		plugin().InitConfig(config.SubSection(name))
+	}
+}
+
+func GetPlugin(ctx context.Context, pluginType string) (auth.Plugin, error) {
+	if plugin, ok := pluginsByName[pluginType]; ok {
+		return plugin(), nil
+	}
+	return commonauthfactory.GetPlugin(ctx, pluginType)
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authfactory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPluginOIDC(t *testing.T) {
+	InitConfigArray(config.RootArray("authfactory_unit_tests"))
+	plugin, err := GetPlugin(context.Background(), "oidc")
+	assert.NoError(t, err)
+	assert.Equal(t, "oidc", plugin.Name())
+}
+
+func TestGetPluginCommon(t *testing.T) {
+	plugin, err := GetPlugin(context.Background(), "basic")
+	assert.NoError(t, err)
+	assert.Equal(t, "basic", plugin.Name())
+}
+
+func TestGetPluginUnknown(t *testing.T) {
+	_, err := GetPlugin(context.Background(), "wrong")
+	assert.Error(t, err)
+}
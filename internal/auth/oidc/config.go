@@ -0,0 +1,58 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+)
+
+const (
+	// OIDCConfAudience is the audience that must be in the aud claim of tokens, if set
+	OIDCConfAudience = "audience"
+	// OIDCConfJWKSURL overrides the URL of the signing keys of the issuer, instead of discovering it
+	OIDCConfJWKSURL = "jwksURL"
+	// OIDCConfJWKSRefreshInterval is the minimum time between reloads of the keys, when a token is signed by an unknown key
+	OIDCConfJWKSRefreshInterval = "jwksRefreshInterval"
+	// OIDCConfClockSkew is the tolerance allowed when checking the expiry and not-before times of tokens
+	OIDCConfClockSkew = "clockSkew"
+	// OIDCConfRolesClaim is the claim containing the roles of the caller, with dots separating nested claims
+	OIDCConfRolesClaim = "rolesClaim"
+	// OIDCConfRoles maps role names to the permissions they grant in each namespace
+	OIDCConfRoles = "roles"
+	// OIDCConfRoleName is the name of the role, as it appears in the roles claim
+	OIDCConfRoleName = "name"
+	// OIDCConfRoleNamespaces is the list of namespaces the role grants permissions in, where "*" matches any namespace
+	OIDCConfRoleNamespaces = "namespaces"
+	// OIDCConfRolePermissions is the list of permissions the role grants - read, send, admin or spi
+	OIDCConfRolePermissions = "permissions"
+)
+
+func (a *Auth) InitConfig(config config.Section) {
+	// The URL of the HTTP config is the issuer, which must match the iss claim of tokens
+	ffresty.InitConfig(config)
+	config.AddKnownKey(OIDCConfAudience)
+	config.AddKnownKey(OIDCConfJWKSURL)
+	config.AddKnownKey(OIDCConfJWKSRefreshInterval, "1m")
+	config.AddKnownKey(OIDCConfClockSkew, "30s")
+	config.AddKnownKey(OIDCConfRolesClaim, "roles")
+
+	roles := config.SubArray(OIDCConfRoles)
+	roles.AddKnownKey(OIDCConfRoleName)
+	roles.AddKnownKey(OIDCConfRoleNamespaces, []string{"*"})
+	roles.AddKnownKey(OIDCConfRolePermissions)
+}
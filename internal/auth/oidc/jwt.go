@@ -0,0 +1,184 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// jsonWebKey is an entry in the JWKS document of the issuer (RFC 7517)
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use,omitempty"`
+	Curve   string `json:"crv,omitempty"`
+	N       string `json:"n,omitempty"`
+	E       string `json:"e,omitempty"`
+	X       string `json:"x,omitempty"`
+	Y       string `json:"y,omitempty"`
+}
+
+type jsonWebKeySet struct {
+	Keys []*jsonWebKey `json:"keys"`
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// signedToken is a JWT that has been decoded, but not yet verified
+type signedToken struct {
+	header       jwtHeader
+	claims       map[string]interface{}
+	signingInput []byte
+	signature    []byte
+}
+
+// signingAlgorithm binds a JWS algorithm to the type of key it is verified with, so a token
+// cannot select an algorithm of one family to be checked against a key of another
+type signingAlgorithm struct {
+	keyType string
+	hash    crypto.Hash
+	curve   elliptic.Curve // only for EC
+}
+
+var signingAlgorithms = map[string]signingAlgorithm{
+	"RS256": {keyType: "RSA", hash: crypto.SHA256},
+	"RS384": {keyType: "RSA", hash: crypto.SHA384},
+	"RS512": {keyType: "RSA", hash: crypto.SHA512},
+	"ES256": {keyType: "EC", hash: crypto.SHA256, curve: elliptic.P256()},
+	"ES384": {keyType: "EC", hash: crypto.SHA384, curve: elliptic.P384()},
+	"ES512": {keyType: "EC", hash: crypto.SHA512, curve: elliptic.P521()},
+}
+
+var curvesByName = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+}
+
+func parseToken(token string) (*signedToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token must have three parts")
+	}
+	t := &signedToken{signingInput: []byte(parts[0] + "." + parts[1])}
+	headerBytes, err := decodeSegment(parts[0])
+	if err == nil {
+		err = json.Unmarshal(headerBytes, &t.header)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid header: %s", err)
+	}
+	claimBytes, err := decodeSegment(parts[1])
+	if err == nil {
+		d := json.NewDecoder(strings.NewReader(string(claimBytes)))
+		d.UseNumber()
+		err = d.Decode(&t.claims)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid claims: %s", err)
+	}
+	if t.signature, err = decodeSegment(parts[2]); err != nil {
+		return nil, fmt.Errorf("invalid signature: %s", err)
+	}
+	return t, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := decodeSegment(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil || !e.IsInt64() {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, ok := curvesByName[k.Curve]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve '%s'", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve '%s'", k.Curve)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type '%s'", k.KeyType)
+	}
+}
+
+// verify checks the signature of the token against a key of the issuer
+func (t *signedToken) verify(key crypto.PublicKey) bool {
+	alg, ok := signingAlgorithms[t.header.Algorithm]
+	if !ok {
+		return false
+	}
+	hasher := alg.hash.New()
+	hasher.Write(t.signingInput)
+	digest := hasher.Sum(nil)
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return alg.keyType == "RSA" && rsa.VerifyPKCS1v15(k, alg.hash, digest, t.signature) == nil
+	case *ecdsa.PublicKey:
+		if alg.keyType != "EC" || alg.curve == nil {
+			return false
+		}
+		// JWS uses the fixed length concatenation of R and S, rather than ASN.1
+		size := (alg.curve.Params().BitSize + 7) / 8
+		if k.Curve != alg.curve || len(t.signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(t.signature[:size])
+		s := new(big.Int).SetBytes(t.signature[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicKeyErrors(t *testing.T) {
+	_, err := (&jsonWebKey{KeyType: "oct"}).publicKey()
+	assert.Regexp(t, "unsupported key type", err)
+
+	_, err = (&jsonWebKey{KeyType: "RSA", N: "!!!", E: "AQAB"}).publicKey()
+	assert.Regexp(t, "invalid key parameter", err)
+
+	_, err = (&jsonWebKey{KeyType: "RSA", N: "AQAB", E: ""}).publicKey()
+	assert.Regexp(t, "invalid RSA exponent", err)
+
+	_, err = (&jsonWebKey{KeyType: "EC", Curve: "P-192"}).publicKey()
+	assert.Regexp(t, "unsupported curve", err)
+
+	_, err = (&jsonWebKey{KeyType: "EC", Curve: "P-256", X: "", Y: "AQAB"}).publicKey()
+	assert.Regexp(t, "invalid key parameter", err)
+
+	_, err = (&jsonWebKey{KeyType: "EC", Curve: "P-256", X: "AQAB", Y: ""}).publicKey()
+	assert.Regexp(t, "invalid key parameter", err)
+
+	_, err = (&jsonWebKey{KeyType: "EC", Curve: "P-256", X: "AQAB", Y: "AQAB"}).publicKey()
+	assert.Regexp(t, "not on curve", err)
+}
+
+func TestVerifyUnsupported(t *testing.T) {
+	token := &signedToken{header: jwtHeader{Algorithm: "HS256"}}
+	assert.False(t, token.verify(nil))
+
+	token = &signedToken{header: jwtHeader{Algorithm: "RS256"}}
+	assert.False(t, token.verify("not a key"))
+}
+
+func TestVerifyAlgorithmKeyTypeMismatch(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	token := &signedToken{header: jwtHeader{Algorithm: "RS256"}, signature: make([]byte, 64)}
+	assert.False(t, token.verify(&ecKey.PublicKey))
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	token = &signedToken{header: jwtHeader{Algorithm: "ES256"}, signature: make([]byte, 256)}
+	assert.False(t, token.verify(&rsaKey.PublicKey))
+}
@@ -0,0 +1,305 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Auth validates the bearer token of each request as a JWT signed by an OIDC issuer, and authorizes
+// the request if a role in the token grants the permission required by the route in the namespace.
+type Auth struct {
+	ctx             context.Context
+	name            string
+	client          *resty.Client
+	issuer          string
+	audience        string
+	jwksURL         string
+	refreshInterval time.Duration
+	clockSkew       time.Duration
+	rolesClaim      []string
+	roles           map[string][]*roleGrant
+
+	keysMux     sync.Mutex
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+type roleGrant struct {
+	namespaces  []string
+	permissions []core.APIPermission
+}
+
+type discoveryDocument struct {
+	JWKSURI string `json:"jwks_uri"`
+}
+
+func (a *Auth) Name() string {
+	return "oidc"
+}
+
+func (a *Auth) Init(ctx context.Context, name string, config config.Section) (err error) {
+	a.ctx = log.WithLogField(ctx, "auth", name)
+	a.name = name
+	a.issuer = strings.TrimSuffix(config.GetString(ffresty.HTTPConfigURL), "/")
+	if a.issuer == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(ffresty.HTTPConfigURL), a.Name())
+	}
+	if a.client, err = ffresty.New(a.ctx, config); err != nil {
+		return err
+	}
+	a.audience = config.GetString(OIDCConfAudience)
+	a.jwksURL = config.GetString(OIDCConfJWKSURL)
+	a.refreshInterval = config.GetDuration(OIDCConfJWKSRefreshInterval)
+	a.clockSkew = config.GetDuration(OIDCConfClockSkew)
+	a.rolesClaim = strings.Split(config.GetString(OIDCConfRolesClaim), ".")
+	a.roles = make(map[string][]*roleGrant)
+
+	roles := config.SubArray(OIDCConfRoles)
+	for i := 0; i < roles.ArraySize(); i++ {
+		role := roles.ArrayEntry(i)
+		roleName := role.GetString(OIDCConfRoleName)
+		if roleName == "" {
+			return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, role.Resolve(OIDCConfRoleName), a.Name())
+		}
+		grant := &roleGrant{namespaces: role.GetStringSlice(OIDCConfRoleNamespaces)}
+		for _, p := range role.GetStringSlice(OIDCConfRolePermissions) {
+			permission, err := fftypes.FFEnumParseString(ctx, "apipermission", p)
+			if err != nil {
+				return i18n.NewError(ctx, coremsgs.MsgOIDCInvalidPermission, p, roleName)
+			}
+			grant.permissions = append(grant.permissions, permission)
+		}
+		a.roles[roleName] = append(a.roles[roleName], grant)
+	}
+	return nil
+}
+
+func (a *Auth) Authorize(ctx context.Context, req *fftypes.AuthReq) error {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return i18n.NewError(ctx, coremsgs.MsgAuthTokenMissing)
+	}
+	claims, err := a.validateToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	permission := core.GetAPIPermission(ctx, req.Method)
+	for _, roleName := range a.callerRoles(claims) {
+		for _, grant := range a.roles[roleName] {
+			if grant.allows(req.Namespace, permission) {
+				if sub, _ := claims["sub"].(string); sub != "" {
+					core.SetAuthPrincipal(ctx, sub)
+				}
+				return nil
+			}
+		}
+	}
+	log.L(ctx).Debugf("Caller '%v' with roles %v denied permission '%s' in namespace '%s'", claims["sub"], a.callerRoles(claims), permission, req.Namespace)
+	return i18n.NewError(ctx, coremsgs.MsgAuthPermissionDenied, permission, req.Namespace)
+}
+
+func (g *roleGrant) allows(namespace string, permission core.APIPermission) bool {
+	nsMatch := false
+	for _, ns := range g.namespaces {
+		if ns == "*" || ns == namespace {
+			nsMatch = true
+			break
+		}
+	}
+	if !nsMatch {
+		return false
+	}
+	for _, p := range g.permissions {
+		// Admin grants every permission, and send includes read
+		if p == permission || p == core.APIPermissionAdmin ||
+			(p == core.APIPermissionSend && permission == core.APIPermissionRead) {
+			return true
+		}
+	}
+	return false
+}
+
+// callerRoles reads the roles claim, which is an array of role names, or a single space separated string
+func (a *Auth) callerRoles(claims map[string]interface{}) []string {
+	var value interface{} = claims
+	for _, name := range a.rolesClaim {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[name]
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		roles := make([]string, 0, len(v))
+		for _, r := range v {
+			if s, ok := r.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	default:
+		return nil
+	}
+}
+
+func (a *Auth) validateToken(ctx context.Context, token string) (map[string]interface{}, error) {
+	t, err := parseToken(token)
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuthTokenInvalid, err)
+	}
+	if _, ok := signingAlgorithms[t.header.Algorithm]; !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuthTokenInvalid, "unsupported algorithm")
+	}
+	keys, err := a.getKeys(ctx, t.header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	verified := false
+	for _, key := range keys {
+		if t.verify(key) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuthTokenInvalid, "signature does not match a key of the issuer")
+	}
+
+	if iss, _ := t.claims["iss"].(string); strings.TrimSuffix(iss, "/") != a.issuer {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuthTokenInvalid, "wrong issuer")
+	}
+	if a.audience != "" && !audienceMatches(t.claims["aud"], a.audience) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuthTokenInvalid, "wrong audience")
+	}
+	now := time.Now()
+	exp, ok := numericDate(t.claims["exp"])
+	if !ok || now.After(exp.Add(a.clockSkew)) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuthTokenInvalid, "expired")
+	}
+	if nbf, ok := numericDate(t.claims["nbf"]); ok && now.Add(a.clockSkew).Before(nbf) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuthTokenInvalid, "not yet valid")
+	}
+	return t.claims, nil
+}
+
+func audienceMatches(aud interface{}, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []interface{}:
+		for _, a := range v {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func numericDate(v interface{}) (time.Time, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(f), 0), true
+}
+
+// getKeys returns the keys that might have signed a token. Keys are loaded on first use, and reloaded
+// when a token names a key we do not have - which is how issuers roll their signing keys.
+func (a *Auth) getKeys(ctx context.Context, keyID string) ([]crypto.PublicKey, error) {
+	a.keysMux.Lock()
+	defer a.keysMux.Unlock()
+
+	_, known := a.keys[keyID]
+	if a.keys == nil || (keyID != "" && !known && time.Since(a.lastRefresh) >= a.refreshInterval) {
+		if err := a.loadKeys(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if keyID != "" {
+		if key, ok := a.keys[keyID]; ok {
+			return []crypto.PublicKey{key}, nil
+		}
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuthTokenInvalid, "unknown key")
+	}
+	keys := make([]crypto.PublicKey, 0, len(a.keys))
+	for _, key := range a.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (a *Auth) loadKeys(ctx context.Context) error {
+	a.lastRefresh = time.Now()
+	jwksURL := a.jwksURL
+	if jwksURL == "" {
+		var discovery discoveryDocument
+		res, err := a.client.R().
+			SetContext(ctx).
+			SetResult(&discovery).
+			Get(a.issuer + "/.well-known/openid-configuration")
+		if err != nil || !res.IsSuccess() {
+			return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgOIDCRESTErr)
+		}
+		jwksURL = discovery.JWKSURI
+	}
+
+	var jwks jsonWebKeySet
+	res, err := a.client.R().
+		SetContext(ctx).
+		SetResult(&jwks).
+		Get(jwksURL)
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgOIDCRESTErr)
+	}
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.L(ctx).Warnf("Ignoring key '%s' from OIDC issuer: %s", jwk.KeyID, err)
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	a.keys = keys
+	return nil
+}
@@ -0,0 +1,321 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIssuer = "https://issuer.example.com"
+
+var utConfig = config.RootSection("oidc_unit_tests")
+
+type testKeys struct {
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	return &testKeys{rsaKey: rsaKey, ecKey: ecKey}
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (k *testKeys) jwks() *jsonWebKeySet {
+	return &jsonWebKeySet{Keys: []*jsonWebKey{
+		{KeyType: "RSA", KeyID: "rsa1", Use: "sig", N: b64(k.rsaKey.N.Bytes()), E: b64(big.NewInt(int64(k.rsaKey.E)).Bytes())},
+		{KeyType: "EC", KeyID: "ec1", Curve: "P-256", X: b64(k.ecKey.X.FillBytes(make([]byte, 32))), Y: b64(k.ecKey.Y.FillBytes(make([]byte, 32)))},
+		{KeyType: "RSA", KeyID: "enc1", Use: "enc", N: b64(k.rsaKey.N.Bytes()), E: "AQAB"},
+		{KeyType: "oct", KeyID: "hmac1"},
+	}}
+}
+
+func (k *testKeys) sign(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(&jwtHeader{Algorithm: alg, KeyID: kid})
+	payload, _ := json.Marshal(claims)
+	input := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(input))
+	var sig []byte
+	var err error
+	switch alg {
+	case "RS256":
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k.rsaKey, crypto.SHA256, digest[:])
+	case "ES256":
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k.ecKey, digest[:])
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	assert.NoError(t, err)
+	return input + "." + b64(sig)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss": testIssuer,
+		"sub": "alice",
+		"aud": []string{"firefly"},
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"nbf": time.Now().Add(-time.Minute).Unix(),
+		"realm_access": map[string]interface{}{
+			"roles": []string{"ns1-writer", "auditor"},
+		},
+	}
+}
+
+func newTestAuth(t *testing.T, keys *testKeys) (*Auth, func()) {
+	coreconfig.Reset()
+	a := &Auth{}
+	a.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, testIssuer)
+	utConfig.Set(OIDCConfAudience, "firefly")
+	utConfig.Set(OIDCConfRolesClaim, "realm_access.roles")
+	roles := utConfig.SubArray(OIDCConfRoles)
+	utConfig.Set(OIDCConfRoles, []fftypes.JSONObject{
+		{"name": "ns1-writer", "namespaces": []string{"ns1"}, "permissions": []string{"send"}},
+		{"name": "auditor", "permissions": []string{"spi"}},
+		{"name": "operator", "permissions": []string{"admin"}},
+	})
+	assert.Equal(t, 3, roles.ArraySize())
+	err := a.Init(context.Background(), "oidc1", utConfig)
+	assert.NoError(t, err)
+
+	httpmock.ActivateNonDefault(a.client.GetClient())
+	httpmock.RegisterResponder("GET", testIssuer+"/.well-known/openid-configuration",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{"jwks_uri": testIssuer + "/keys"}))
+	if keys != nil {
+		httpmock.RegisterResponder("GET", testIssuer+"/keys", httpmock.NewJsonResponderOrPanic(200, keys.jwks()))
+	}
+	return a, httpmock.DeactivateAndReset
+}
+
+func authReq(namespace, method, token string) *fftypes.AuthReq {
+	header := http.Header{}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	u, _ := url.Parse("http://localhost:5000/api/v1/namespaces/" + namespace + "/messages")
+	return &fftypes.AuthReq{Namespace: namespace, Method: method, URL: u, Header: header}
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "oidc", (&Auth{}).Name())
+}
+
+func TestInitMissingURL(t *testing.T) {
+	coreconfig.Reset()
+	a := &Auth{}
+	a.InitConfig(utConfig)
+	err := a.Init(context.Background(), "oidc1", utConfig)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestInitBadPermission(t *testing.T) {
+	coreconfig.Reset()
+	a := &Auth{}
+	a.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, testIssuer)
+	utConfig.Set(OIDCConfRoles, []fftypes.JSONObject{
+		{"name": "writer", "permissions": []string{"write"}},
+	})
+	err := a.Init(context.Background(), "oidc1", utConfig)
+	assert.Regexp(t, "FF10541.*write.*writer", err)
+}
+
+func TestInitMissingRoleName(t *testing.T) {
+	coreconfig.Reset()
+	a := &Auth{}
+	a.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, testIssuer)
+	utConfig.Set(OIDCConfRoles, []fftypes.JSONObject{
+		{"permissions": []string{"read"}},
+	})
+	err := a.Init(context.Background(), "oidc1", utConfig)
+	assert.Regexp(t, "FF10138.*name", err)
+}
+
+func TestAuthorizeRolePermissions(t *testing.T) {
+	keys := newTestKeys(t)
+	a, done := newTestAuth(t, keys)
+	defer done()
+	ctx := context.Background()
+	token := keys.sign(t, "RS256", "rsa1", validClaims())
+
+	// ns1-writer grants send, which includes read, in ns1 only
+	assert.NoError(t, a.Authorize(ctx, authReq("ns1", http.MethodPost, token)))
+	assert.NoError(t, a.Authorize(ctx, authReq("ns1", http.MethodGet, token)))
+	err := a.Authorize(ctx, authReq("ns2", http.MethodGet, token))
+	assert.Regexp(t, "FF10544.*read.*ns2", err)
+	err = a.Authorize(core.WithAPIPermission(ctx, core.APIPermissionAdmin), authReq("ns1", http.MethodPost, token))
+	assert.Regexp(t, "FF10544.*admin.*ns1", err)
+
+	// auditor grants spi in every namespace
+	assert.NoError(t, a.Authorize(core.WithAPIPermission(ctx, core.APIPermissionSPI), authReq("ns2", http.MethodGet, token)))
+
+	// The keys are only loaded once
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET "+testIssuer+"/keys"])
+}
+
+func TestAuthorizeAdminRoleStringClaim(t *testing.T) {
+	keys := newTestKeys(t)
+	a, done := newTestAuth(t, keys)
+	defer done()
+	a.rolesClaim = []string{"scope"}
+	claims := validClaims()
+	claims["scope"] = "openid operator"
+	token := keys.sign(t, "ES256", "ec1", claims)
+
+	ctx := core.WithAuthPrincipal(core.WithAPIPermission(context.Background(), core.APIPermissionAdmin))
+	assert.NoError(t, a.Authorize(ctx, authReq("ns3", http.MethodPost, token)))
+	assert.Equal(t, claims["sub"], core.GetAuthPrincipal(ctx))
+}
+
+func TestAuthorizeNoRoles(t *testing.T) {
+	keys := newTestKeys(t)
+	a, done := newTestAuth(t, keys)
+	defer done()
+	claims := validClaims()
+	claims["realm_access"] = "not an object"
+	token := keys.sign(t, "RS256", "", claims)
+
+	err := a.Authorize(context.Background(), authReq("ns1", http.MethodGet, token))
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestAuthorizeMissingToken(t *testing.T) {
+	a, done := newTestAuth(t, nil)
+	defer done()
+
+	err := a.Authorize(context.Background(), authReq("ns1", http.MethodGet, ""))
+	assert.Regexp(t, "FF10542", err)
+}
+
+func TestAuthorizeInvalidTokens(t *testing.T) {
+	keys := newTestKeys(t)
+	otherKeys := newTestKeys(t)
+	a, done := newTestAuth(t, keys)
+	defer done()
+	ctx := context.Background()
+
+	withClaim := func(name string, value interface{}) map[string]interface{} {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+	tests := map[string]string{
+		"three parts":          "not.a-token",
+		"invalid header":       "!!!.e30.e30",
+		"invalid claims":       b64([]byte(`{"alg":"RS256"}`)) + ".!!!.e30",
+		"invalid signature":    b64([]byte(`{"alg":"RS256"}`)) + ".e30.!!!",
+		"unsupported":          b64([]byte(`{"alg":"none"}`)) + ".e30.",
+		"unknown key":          keys.sign(t, "RS256", "rsa2", validClaims()),
+		"does not match":       otherKeys.sign(t, "RS256", "rsa1", validClaims()),
+		"wrong alg for key":    keys.sign(t, "ES256", "rsa1", validClaims()),
+		"wrong issuer":         keys.sign(t, "RS256", "rsa1", withClaim("iss", "https://other.example.com")),
+		"wrong audience":       keys.sign(t, "RS256", "rsa1", withClaim("aud", "other")),
+		"expired":              keys.sign(t, "RS256", "rsa1", withClaim("exp", time.Now().Add(-time.Hour).Unix())),
+		"expired ":             keys.sign(t, "RS256", "rsa1", withClaim("exp", nil)),
+		"not yet valid":        keys.sign(t, "RS256", "rsa1", withClaim("nbf", time.Now().Add(time.Hour).Unix())),
+		"does not match a key": keys.sign(t, "RS256", "ec1", validClaims()),
+	}
+	for expected, token := range tests {
+		err := a.Authorize(ctx, authReq("ns1", http.MethodGet, token))
+		assert.Regexp(t, "FF10543", err, expected)
+	}
+}
+
+func TestAuthorizeRefreshesKeys(t *testing.T) {
+	keys := newTestKeys(t)
+	a, done := newTestAuth(t, keys)
+	defer done()
+	ctx := context.Background()
+	a.refreshInterval = 0
+
+	// A token signed by a key that is not yet published by the issuer
+	_ = a.Authorize(ctx, authReq("ns1", http.MethodGet, keys.sign(t, "RS256", "rsa1", validClaims())))
+	newKeys := newTestKeys(t)
+	jwks := keys.jwks()
+	jwks.Keys[0].KeyID = "rsa2"
+	jwks.Keys[0].N = b64(newKeys.rsaKey.N.Bytes())
+	httpmock.RegisterResponder("GET", testIssuer+"/keys", httpmock.NewJsonResponderOrPanic(200, jwks))
+
+	err := a.Authorize(ctx, authReq("ns1", http.MethodGet, newKeys.sign(t, "RS256", "rsa2", validClaims())))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpmock.GetCallCountInfo()["GET "+testIssuer+"/keys"])
+}
+
+func TestAuthorizeJWKSURLOverride(t *testing.T) {
+	keys := newTestKeys(t)
+	a, done := newTestAuth(t, keys)
+	defer done()
+	a.jwksURL = testIssuer + "/keys"
+
+	err := a.Authorize(context.Background(), authReq("ns1", http.MethodGet, keys.sign(t, "RS256", "rsa1", validClaims())))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, httpmock.GetCallCountInfo()["GET "+testIssuer+"/.well-known/openid-configuration"])
+}
+
+func TestAuthorizeDiscoveryFail(t *testing.T) {
+	keys := newTestKeys(t)
+	a, done := newTestAuth(t, keys)
+	defer done()
+	httpmock.RegisterResponder("GET", testIssuer+"/.well-known/openid-configuration",
+		httpmock.NewStringResponder(500, "pop"))
+
+	err := a.Authorize(context.Background(), authReq("ns1", http.MethodGet, keys.sign(t, "RS256", "rsa1", validClaims())))
+	assert.Regexp(t, "FF10540.*pop", err)
+}
+
+func TestAuthorizeJWKSFail(t *testing.T) {
+	keys := newTestKeys(t)
+	a, done := newTestAuth(t, keys)
+	defer done()
+	httpmock.RegisterResponder("GET", testIssuer+"/keys", httpmock.NewStringResponder(500, "pop"))
+
+	err := a.Authorize(context.Background(), authReq("ns1", http.MethodGet, keys.sign(t, "RS256", "rsa1", validClaims())))
+	assert.Regexp(t, "FF10540.*pop", err)
+}
@@ -0,0 +1,64 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsauth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedHeadersStable(t *testing.T) {
+	s := &Signer{
+		Service:         "secretsmanager",
+		Host:            "secretsmanager.us-east-1.amazonaws.com",
+		Region:          "us-east-1",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret1",
+		Now:             func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) },
+	}
+	headers1 := s.SignedHeaders("secretsmanager.GetSecretValue", []byte(`{"SecretId":"secret1"}`))
+	headers2 := s.SignedHeaders("secretsmanager.GetSecretValue", []byte(`{"SecretId":"secret1"}`))
+	headers3 := s.SignedHeaders("secretsmanager.GetSecretValue", []byte(`{"SecretId":"secret2"}`))
+	assert.Equal(t, headers1["authorization"], headers2["authorization"])
+	assert.NotEqual(t, headers1["authorization"], headers3["authorization"])
+	assert.True(t, strings.HasPrefix(headers1["authorization"], "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-east-1/secretsmanager/aws4_request, "))
+	assert.Equal(t, "20240102T030405Z", headers1["x-amz-date"])
+	assert.Equal(t, ContentType, headers1["content-type"])
+	assert.NotContains(t, headers1, "host")
+	assert.NotContains(t, headers1, "x-amz-security-token")
+}
+
+func TestSignedHeadersSessionToken(t *testing.T) {
+	s := &Signer{
+		Service:      "kms",
+		Host:         "kms.us-east-1.amazonaws.com",
+		Region:       "us-east-1",
+		SessionToken: "session1",
+	}
+	headers := s.SignedHeaders("TrentService.Sign", []byte(`{}`))
+	assert.Equal(t, "session1", headers["x-amz-security-token"])
+	assert.Contains(t, headers["authorization"], "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, ")
+}
+
+func TestConfigOrEnv(t *testing.T) {
+	t.Setenv("AWS_REGION", "eu-west-2")
+	assert.Equal(t, "us-east-1", ConfigOrEnv("us-east-1", "AWS_REGION"))
+	assert.Equal(t, "eu-west-2", ConfigOrEnv("", "AWS_REGION"))
+}
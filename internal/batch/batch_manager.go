@@ -521,8 +521,9 @@ func (bm *batchManager) getNextNonce(ctx context.Context, state *dispatchState,
 
 func (bm *batchManager) maskContext(ctx context.Context, state *dispatchState, msg *core.Message, topic string) (msgPinString string, contextOrPin *fftypes.Bytes32, err error) {
 
+	// Any context qualifier on the message is hashed along with the topic
 	hashBuilder := sha256.New()
-	hashBuilder.Write([]byte(topic))
+	hashBuilder.Write([]byte(msg.Header.ContextTopic(topic)))
 
 	// For broadcast we do not need to mask the context, which is just the hash
 	// of the topic. There would be no way to unmask it if we did, because we don't have
@@ -48,6 +48,7 @@ func newTestBatchManager(t *testing.T) (*batchManager, func()) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	ctx := context.Background()
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
@@ -64,6 +65,7 @@ func TestE2EDispatchBroadcast(t *testing.T) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	ctx := context.Background()
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
@@ -184,6 +186,7 @@ func TestE2EDispatchPrivateUnpinned(t *testing.T) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	ctx := context.Background()
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
@@ -410,6 +413,7 @@ func TestMessageSequencerUpdateMessagesFail(t *testing.T) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	ctx := context.Background()
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
@@ -468,6 +472,7 @@ func TestMessageSequencerDispatchFail(t *testing.T) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	ctx := context.Background()
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
@@ -511,6 +516,7 @@ func TestMessageSequencerUpdateBatchFail(t *testing.T) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
@@ -676,6 +682,28 @@ func TestLoadContextsBroadcast(t *testing.T) {
 	assert.Equal(t, expected, payload.Pins)
 }
 
+func TestLoadContextsBroadcastQualified(t *testing.T) {
+	bm, cancel := newTestBatchManager(t)
+	defer cancel()
+
+	payload := &DispatchPayload{
+		Batch: core.BatchPersisted{},
+		Messages: []*core.Message{{
+			Header: core.MessageHeader{
+				Topics:           fftypes.FFStringArray{"topic1"},
+				ContextQualifier: "app1",
+			},
+		}},
+	}
+
+	err := bm.LoadContexts(context.Background(), payload)
+
+	// The qualifier is hashed with the topic, so is not visible in the pin
+	assert.NoError(t, err)
+	assert.Equal(t, []*fftypes.Bytes32{fftypes.HashString("topic1/app1")}, payload.Pins)
+	assert.NotEqual(t, fftypes.MustParseBytes32("9e065a7cbddfc57be742bc32956674c3c389521ac2bbb1dce0500d5131fede75"), payload.Pins[0])
+}
+
 func TestLoadContextsPrivate(t *testing.T) {
 	bm, cancel := newTestBatchManager(t)
 	defer cancel()
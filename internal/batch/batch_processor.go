@@ -30,9 +30,12 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type batchWork struct {
@@ -112,7 +115,9 @@ type DispatchPayload struct {
 	Messages       []*core.Message
 	Data           core.DataArray
 	Pins           []*fftypes.Bytes32
+	Signature      *core.PayloadSignature
 	MessageUpdates map[string]*MessageUpdate
+	TxLabels       fftypes.FFStringArray // local only - the merged labels of the messages, for the batch transaction
 }
 
 func (dp *DispatchPayload) addMessageUpdate(messages []*core.Message, fromState core.MessageState, toState core.MessageState) {
@@ -398,27 +403,49 @@ func (bp *batchProcessor) assemblyLoop() {
 	}
 }
 
-func (bp *batchProcessor) flush(overflow bool) error {
+func (bp *batchProcessor) flush(overflow bool) (err error) {
 	id, flushWork, byteSize := bp.startFlush(overflow)
 
-	log.L(bp.ctx).Debugf("Flushing batch %s", id)
+	msgIDs := make([]*fftypes.UUID, len(flushWork))
+	ctx := bp.ctx
+	for i, w := range flushWork {
+		msgIDs[i] = w.msg.Header.ID
+		if w.msg.Trace && !tracing.HasTraceFlag(ctx) {
+			ctx = tracing.WithTraceFlag(ctx, "batch", id)
+		}
+	}
+	ctx, span := tracing.StartSpan(ctx, "batch.flush", tracing.Links(msgIDs...), trace.WithAttributes(
+		attribute.String("firefly.batch.id", id.String()),
+		attribute.String("firefly.batch.processor", bp.conf.name),
+		attribute.Int("firefly.batch.messages", len(flushWork)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+	tracing.Remember(ctx, id)
+
+	tracing.Logf(ctx, "Flushing batch %s with %d messages", id, len(flushWork))
 	state := bp.initPayload(id, flushWork)
+	state.Batch.Trace = tracing.HasTraceFlag(ctx)
 
 	// Sealing phase: assigns persisted pins to messages, and finalizes the manifest
-	err := bp.sealBatch(state)
+	err = bp.sealBatch(state)
 	if err != nil {
 		return err
 	}
-	log.L(bp.ctx).Debugf("Sealed batch %s", id)
+	tracing.Logf(ctx, "Sealed batch %s with %d pins", id, len(state.Pins))
+	for _, msg := range state.Messages {
+		if msg.Trace {
+			tracing.Logf(ctx, "Sealed message %s into batch %s with %d topics and private pins [%s]", msg.Header.ID, id, len(msg.Header.Topics), msg.Pins)
+		}
+	}
 
 	// Dispatch phase: the heavy lifting work - calling plugins to do the hard work of the batch.
 	//   The dispatcher can update the state, such as appending to the BlobsPublished array,
 	//   to affect DB updates as part of the finalization phase.
-	err = bp.dispatchBatch(state)
+	err = bp.dispatchBatch(ctx, state)
 	if err != nil {
 		return err
 	}
-	log.L(bp.ctx).Debugf("Dispatched batch %s", id)
+	tracing.Logf(ctx, "Dispatched batch %s", id)
 
 	// Finalization phase: Writes back the changes to the DB, so that these messages
 	//   are all tagged as part of this batch, and won't be included in any future batches.
@@ -426,7 +453,7 @@ func (bp *batchProcessor) flush(overflow bool) error {
 	if err != nil {
 		return err
 	}
-	log.L(bp.ctx).Debugf("Finalized batch %s", id)
+	tracing.Logf(ctx, "Finalized batch %s", id)
 
 	// Notify the manager that we've flushed these sequences
 	bp.notifyFlushComplete(flushWork)
@@ -462,6 +489,7 @@ func (bp *batchProcessor) initPayload(id *fftypes.UUID, flushWork []*batchWork)
 	for _, w := range flushWork {
 		if w.msg != nil {
 			payload.Messages = append(payload.Messages, w.msg.BatchMessage())
+			payload.TxLabels = bp.mergeTxLabels(payload.TxLabels, w.msg)
 		}
 		for _, d := range w.data {
 			log.L(bp.ctx).Debugf("Adding data '%s' to batch '%s' for message '%s'", d.ID, id, w.msg.Header.ID)
@@ -471,6 +499,29 @@ func (bp *batchProcessor) initPayload(id *fftypes.UUID, flushWork []*batchWork)
 	return payload
 }
 
+// mergeTxLabels adds the transaction labels of a message to those of the batch. The labels of
+// every message are carried on the one transaction for the batch, up to the size that can be stored
+func (bp *batchProcessor) mergeTxLabels(labels fftypes.FFStringArray, msg *core.Message) fftypes.FFStringArray {
+	for _, label := range msg.TxLabels {
+		found := false
+		for _, existing := range labels {
+			if existing == label {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if len(labels) >= fftypes.FFStringNameItemsMax || len(labels.String())+len(label)+1 > fftypes.FFStringArrayStandardMax {
+			log.L(bp.ctx).Warnf("Dropping transaction label '%s' of message %s, as the batch has reached the limit for labels", label, msg.Header.ID)
+			continue
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
 // Calculate the contexts/pins for this batch payload
 func (bp *batchProcessor) calculateContexts(ctx context.Context, payload *DispatchPayload, state *dispatchState) error {
 	payload.Pins = make([]*fftypes.Bytes32, 0)
@@ -579,7 +630,7 @@ func (bp *batchProcessor) sealBatch(payload *DispatchPayload) (err error) {
 				payload.Batch.TX.ID = payload.Messages[0].TransactionID
 			} else {
 				// For all others, generate a new transaction
-				payload.Batch.TX.ID, err = bp.txHelper.SubmitNewTransaction(ctx, txType, "" /* no idempotency key */)
+				payload.Batch.TX.ID, err = bp.txHelper.SubmitNewTransaction(ctx, txType, "" /* no idempotency key */, payload.TxLabels...)
 				if err != nil {
 					return err
 				}
@@ -588,6 +639,12 @@ func (bp *batchProcessor) sealBatch(payload *DispatchPayload) (err error) {
 			// The hash of the batch, is the hash of the manifest to minimize the compute cost.
 			// Note in v0.13 and before, it was the hash of the payload - so the inbound route has a fallback to accepting the full payload hash
 			manifest := payload.Batch.GenManifest(payload.Messages, payload.Data)
+			// If payload signing is enabled, the signature is over the manifest before it is signed, and the
+			// signature itself is then protected by the batch hash
+			if manifest.Signature, err = bp.bm.identity.SignPayload(ctx, manifest.PayloadHash()); err != nil {
+				return err
+			}
+			payload.Signature = manifest.Signature
 			manifestString := manifest.String()
 			payload.Batch.Manifest = fftypes.JSONAnyPtr(manifestString)
 			payload.Batch.Hash = fftypes.HashString(manifestString)
@@ -616,9 +673,9 @@ func (bp *batchProcessor) sealBatch(payload *DispatchPayload) (err error) {
 	return nil
 }
 
-func (bp *batchProcessor) dispatchBatch(payload *DispatchPayload) error {
+func (bp *batchProcessor) dispatchBatch(ctx context.Context, payload *DispatchPayload) error {
 	// Call the dispatcher to do the heavy lifting - will only exit if we're closed
-	return operations.RunWithOperationContext(bp.ctx, func(ctx context.Context) error {
+	return operations.RunWithOperationContext(ctx, func(ctx context.Context) error {
 		return bp.retry.Do(ctx, "batch dispatch", func(attempt int) (retry bool, err error) {
 			err = bp.conf.dispatch(ctx, payload)
 			if err != nil {
@@ -629,7 +686,7 @@ func (bp *batchProcessor) dispatchBatch(payload *DispatchPayload) error {
 						payload.addMessageUpdate(payload.Messages, core.MessageStateReady, core.MessageStateCancelled)
 						if gapFillPayload != nil {
 							payload.addMessageUpdate(gapFillPayload.Messages, core.MessageStateStaged, core.MessageStateSent)
-							err = bp.dispatchBatch(gapFillPayload)
+							err = bp.dispatchBatch(ctx, gapFillPayload)
 						}
 					}
 				}
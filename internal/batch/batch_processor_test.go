@@ -140,13 +140,118 @@ func TestUnfilledBatch(t *testing.T) {
 	mim.AssertExpectations(t)
 }
 
+func TestTracedBatch(t *testing.T) {
+	dispatched := make(chan *DispatchPayload)
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		dispatched <- state
+		return nil
+	})
+	defer cancel()
+
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+
+	mth := bp.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeBatchPin, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+
+	mdm := bp.data.(*datamocks.Manager)
+	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return()
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			bp.newWork <- &batchWork{
+				msg: &core.Message{
+					Header: core.MessageHeader{
+						ID:     fftypes.NewUUID(),
+						TxType: core.TransactionTypeBatchPin,
+					},
+					Trace:    i == 1,
+					Sequence: int64(1000 + i)},
+			}
+		}
+	}()
+
+	batch := <-dispatched
+	assert.Equal(t, 2, len(batch.Messages))
+	assert.True(t, batch.Batch.Trace)
+	assert.False(t, batch.Messages[0].Trace)
+	assert.True(t, batch.Messages[1].Trace)
+
+	bp.cancelCtx()
+	<-bp.done
+}
+
+func TestBatchTxLabels(t *testing.T) {
+	dispatched := make(chan *DispatchPayload)
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		dispatched <- state
+		return nil
+	})
+	defer cancel()
+
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+
+	mth := bp.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeBatchPin, core.IdempotencyKey(""), "payroll", "quarter-end").Return(fftypes.NewUUID(), nil)
+
+	mdm := bp.data.(*datamocks.Manager)
+	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return()
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
+
+	txLabels := []fftypes.FFStringArray{{"payroll"}, {"quarter-end", "payroll"}}
+	go func() {
+		for i := 0; i < 2; i++ {
+			bp.newWork <- &batchWork{
+				msg: &core.Message{
+					Header: core.MessageHeader{
+						ID:     fftypes.NewUUID(),
+						TxType: core.TransactionTypeBatchPin,
+					},
+					TxLabels: txLabels[i],
+					Sequence: int64(1000 + i)},
+			}
+		}
+	}()
+
+	batch := <-dispatched
+	assert.Equal(t, 2, len(batch.Messages))
+	assert.Equal(t, "payroll,quarter-end", batch.TxLabels.String())
+	assert.Empty(t, batch.Messages[0].TxLabels)
+
+	bp.cancelCtx()
+	<-bp.done
+
+	mth.AssertExpectations(t)
+}
+
+func TestMergeTxLabelsLimit(t *testing.T) {
+	cancel, _, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	defer cancel()
+
+	var labels fftypes.FFStringArray
+	for i := 0; i < fftypes.FFStringNameItemsMax+1; i++ {
+		labels = bp.mergeTxLabels(labels, &core.Message{TxLabels: fftypes.FFStringArray{fmt.Sprintf("label%d", i)}})
+	}
+	assert.Len(t, labels, fftypes.FFStringNameItemsMax)
+}
+
 func TestHandleDispatchConflictError(t *testing.T) {
 	cancel, _, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
 		conflictErr := testConflictError{err: fmt.Errorf("pop")}
 		return &conflictErr
 	})
 	defer cancel()
-	bp.dispatchBatch(&DispatchPayload{})
+	bp.dispatchBatch(bp.ctx, &DispatchPayload{})
 	bp.cancelCtx()
 	<-bp.done
 }
@@ -214,7 +319,7 @@ func TestCloseToUnblockDispatch(t *testing.T) {
 	})
 	defer cancel()
 	bp.cancelCtx()
-	bp.dispatchBatch(&DispatchPayload{})
+	bp.dispatchBatch(bp.ctx, &DispatchPayload{})
 	<-bp.done
 }
 
@@ -761,6 +866,89 @@ func TestSealBatchTXAlreadyAssigned(t *testing.T) {
 	mdm.AssertExpectations(t)
 }
 
+func TestSealBatchSignPayload(t *testing.T) {
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	cancel()
+
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+
+	signature := &core.PayloadSignature{
+		Algorithm: core.PayloadSignatureAlgorithmEd25519,
+		PublicKey: "pubkey",
+		Signature: "sig",
+	}
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.ExpectedCalls = nil
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(signature, nil)
+
+	txID := fftypes.NewUUID()
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Type:   core.MessageTypePrivate,
+			Group:  fftypes.NewRandB32(),
+			TxType: core.TransactionTypeUnpinned,
+		},
+	}
+
+	state := bp.initPayload(fftypes.NewUUID(), []*batchWork{{msg: msg}})
+	bp.txHelper.(*txcommonmocks.Helper).On("SubmitNewTransaction", mock.Anything, core.TransactionTypeUnpinned, core.IdempotencyKey("")).Return(txID, nil)
+	err := bp.sealBatch(state)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, state.Signature)
+
+	var manifest core.BatchManifest
+	err = state.Batch.Manifest.Unmarshal(context.Background(), &manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, manifest.Signature)
+	assert.Equal(t, fftypes.HashString(state.Batch.Manifest.String()), state.Batch.Hash)
+	mim.AssertCalled(t, "SignPayload", mock.Anything, manifest.PayloadHash())
+
+	bp.cancelCtx()
+	<-bp.done
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestSealBatchSignPayloadFail(t *testing.T) {
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	cancel()
+
+	mockRunAsGroupPassthrough(mdi)
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.ExpectedCalls = nil
+	mim.On("SignPayload", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Type:   core.MessageTypePrivate,
+			Group:  fftypes.NewRandB32(),
+			TxType: core.TransactionTypeUnpinned,
+		},
+	}
+
+	state := bp.initPayload(fftypes.NewUUID(), []*batchWork{{msg: msg}})
+	bp.txHelper.(*txcommonmocks.Helper).On("SubmitNewTransaction", mock.Anything, core.TransactionTypeUnpinned, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	err := bp.sealBatch(state)
+	assert.Regexp(t, "FF00154", err)
+
+	bp.cancelCtx()
+	<-bp.done
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
 func TestCalculateContextsLoadPins(t *testing.T) {
 	cancel, _, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
 		return nil
@@ -54,6 +54,8 @@ type BlockchainCallbacks interface {
 	PrepareBatchPinOrNetworkAction(ctx context.Context, events EventsToDispatch, subInfo *SubscriptionInfo, location *fftypes.JSONAny, event *blockchain.Event, signingKey *core.VerifierRef, params *BatchPinParams)
 	// Common logic for parsing a BatchPinOrNetworkAction event, and if not discarded to add it to the by-namespace map
 	PrepareBlockchainEvent(ctx context.Context, events EventsToDispatch, namespace string, event *blockchain.EventForListener)
+	// Common logic for signalling a chain re-org to a namespace (or all namespaces if empty), in order with the other events in the map
+	PrepareReorg(ctx context.Context, events EventsToDispatch, namespace string, reorg *blockchain.ReorgEvent)
 	// Dispatch logic, that ensures all the right namespace callbacks get called for the event batch
 	DispatchBlockchainEvents(ctx context.Context, events EventsToDispatch) error
 }
@@ -109,11 +111,46 @@ type BlockchainReceiptHeaders struct {
 }
 
 type BlockchainReceiptNotification struct {
-	Headers          BlockchainReceiptHeaders `json:"headers,omitempty"`
-	TxHash           string                   `json:"transactionHash,omitempty"`
-	Message          string                   `json:"errorMessage,omitempty"`
-	ProtocolID       string                   `json:"protocolId,omitempty"`
-	ContractLocation *fftypes.JSONAny         `json:"contractLocation,omitempty"`
+	Headers           BlockchainReceiptHeaders `json:"headers,omitempty"`
+	TxHash            string                   `json:"transactionHash,omitempty"`
+	Message           string                   `json:"errorMessage,omitempty"`
+	ProtocolID        string                   `json:"protocolId,omitempty"`
+	ContractLocation  *fftypes.JSONAny         `json:"contractLocation,omitempty"`
+	GasUsed           *fftypes.FFBigInt        `json:"gasUsed,omitempty"`
+	EffectiveGasPrice *fftypes.FFBigInt        `json:"effectiveGasPrice,omitempty"`
+}
+
+// receiptOutputSchema is the structure of the operation output recorded by HandleReceipt
+var receiptOutputSchema = fftypes.JSONAnyPtr(`{
+	"type": "object",
+	"properties": {
+		"headers": {
+			"type": "object",
+			"properties": {
+				"requestId": {"type": "string"},
+				"type": {"type": "string"}
+			}
+		},
+		"transactionHash": {"type": "string"},
+		"errorMessage": {"type": "string"},
+		"protocolId": {"type": "string"},
+		"contractLocation": {},
+		"gasUsed": {"type": ["string", "number"]},
+		"effectiveGasPrice": {"type": ["string", "number"]}
+	},
+	"required": ["headers"]
+}`)
+
+// ReceiptOutputSchemas declares the output schema of every blockchain operation type, for connectors
+// that report their operation updates via HandleReceipt
+func ReceiptOutputSchemas() map[core.OpType]*fftypes.JSONAny {
+	return map[core.OpType]*fftypes.JSONAny{
+		core.OpTypeBlockchainPinBatch:       receiptOutputSchema,
+		core.OpTypeBlockchainNetworkAction:  receiptOutputSchema,
+		core.OpTypeBlockchainPinAuditAnchor: receiptOutputSchema,
+		core.OpTypeBlockchainInvoke:         receiptOutputSchema,
+		core.OpTypeBlockchainContractDeploy: receiptOutputSchema,
+	}
 }
 
 type BlockchainRESTError struct {
@@ -197,10 +234,10 @@ func (cb *callbacks) PrepareBatchPinOrNetworkAction(ctx context.Context, events
 			for _, localNames := range subInfo.V1Namespace {
 				namespaces = append(namespaces, localNames...)
 			}
-			cb.addNetworkAction(ctx, events, namespaces, action, location, event, signingKey)
+			cb.addNetworkAction(ctx, events, namespaces, action, params.PayloadRef, location, event, signingKey)
 			return
 		}
-		cb.addNetworkAction(ctx, events, []string{subInfo.V2Namespace}, action, location, event, signingKey)
+		cb.addNetworkAction(ctx, events, []string{subInfo.V2Namespace}, action, params.PayloadRef, location, event, signingKey)
 		return
 	}
 
@@ -250,7 +287,7 @@ func (cb *callbacks) addBatchPinComplete(ctx context.Context, events EventsToDis
 	}
 }
 
-func (cb *callbacks) addNetworkAction(ctx context.Context, events EventsToDispatch, namespaces []string, action string, location *fftypes.JSONAny, event *blockchain.Event, signingKey *core.VerifierRef) {
+func (cb *callbacks) addNetworkAction(ctx context.Context, events EventsToDispatch, namespaces []string, action, payload string, location *fftypes.JSONAny, event *blockchain.Event, signingKey *core.VerifierRef) {
 	cb.lock.RLock()
 	defer cb.lock.RUnlock()
 	for _, namespace := range namespaces {
@@ -259,6 +296,7 @@ func (cb *callbacks) addNetworkAction(ctx context.Context, events EventsToDispat
 				Type: blockchain.EventTypeNetworkAction,
 				NetworkAction: &blockchain.NetworkActionEvent{
 					Action:     action,
+					Payload:    payload,
 					Location:   location,
 					Event:      event,
 					SigningKey: signingKey,
@@ -293,6 +331,19 @@ func (cb *callbacks) PrepareBlockchainEvent(ctx context.Context, events EventsTo
 	}
 }
 
+func (cb *callbacks) PrepareReorg(ctx context.Context, events EventsToDispatch, namespace string, reorg *blockchain.ReorgEvent) {
+	cb.lock.RLock()
+	defer cb.lock.RUnlock()
+	for ns := range cb.handlers {
+		if namespace == "" || ns == namespace {
+			events[ns] = append(events[ns], &blockchain.EventToDispatch{
+				Type:  blockchain.EventTypeReorg,
+				Reorg: reorg,
+			})
+		}
+	}
+}
+
 func (cb *callbacks) DispatchBlockchainEvents(ctx context.Context, events EventsToDispatch) error {
 	cb.lock.RLock()
 	defer cb.lock.RUnlock()
@@ -352,6 +403,19 @@ func buildBatchPin(ctx context.Context, event *blockchain.Event, params *BatchPi
 	}, nil
 }
 
+// ParseBatchPinEvent rebuilds the batch pin from a BatchPin event previously recorded in the database
+func ParseBatchPinEvent(ctx context.Context, event *core.BlockchainEvent, params *BatchPinParams) (*blockchain.BatchPin, error) {
+	return buildBatchPin(ctx, &blockchain.Event{
+		Source:         event.Source,
+		Name:           event.Name,
+		ProtocolID:     event.ProtocolID,
+		Output:         event.Output,
+		Info:           event.Info,
+		Timestamp:      event.Timestamp,
+		BlockchainTXID: event.TX.BlockchainID,
+	}, params)
+}
+
 func GetNamespaceFromSubName(subName string) string {
 	// Subscription names post version 1.1 are in the format `ff-sub-<namespace>-<listener ID>`
 	// Priot to that they had the format `ff-sub-<listener ID>`
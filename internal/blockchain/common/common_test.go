@@ -103,6 +103,29 @@ func TestCallbackBlockchainEvent(t *testing.T) {
 	mcb.AssertExpectations(t)
 }
 
+func TestCallbackReorg(t *testing.T) {
+	reorg := &blockchain.ReorgEvent{
+		Source:         "ethereum",
+		BlockNumber:    12345,
+		FromProtocolID: "000000012345",
+	}
+
+	cb := NewBlockchainCallbacks()
+	cb.SetHandler("ns1", &blockchainmocks.Callbacks{})
+	cb.SetHandler("ns2", &blockchainmocks.Callbacks{})
+
+	events := make(EventsToDispatch)
+	cb.PrepareReorg(context.Background(), events, "ns1", reorg)
+	assert.Len(t, events, 1)
+	assert.Equal(t, blockchain.EventTypeReorg, events["ns1"][0].Type)
+	assert.Equal(t, reorg, events["ns1"][0].Reorg)
+
+	events = make(EventsToDispatch)
+	cb.PrepareReorg(context.Background(), events, "", reorg)
+	assert.Len(t, events, 2)
+	assert.Equal(t, reorg, events["ns2"][0].Reorg)
+}
+
 func TestCallbackBatchPinBadBatch(t *testing.T) {
 	event := &blockchain.Event{}
 	verifier := &core.VerifierRef{}
@@ -218,11 +241,12 @@ func TestCallbackBatchPin(t *testing.T) {
 	mcb.AssertExpectations(t)
 }
 
-func matchNetworkActionEvent(action string, verifier core.VerifierRef) interface{} {
+func matchNetworkActionEvent(action, payload string, verifier core.VerifierRef) interface{} {
 	return mock.MatchedBy(func(batch []*blockchain.EventToDispatch) bool {
 		return len(batch) == 1 &&
 			batch[0].Type == blockchain.EventTypeNetworkAction &&
 			batch[0].NetworkAction.Action == action &&
+			batch[0].NetworkAction.Payload == payload &&
 			*batch[0].NetworkAction.SigningKey == verifier
 	})
 }
@@ -234,6 +258,7 @@ func TestCallbackNetworkAction(t *testing.T) {
 	}
 	params := &BatchPinParams{
 		NsOrAction: "firefly:terminate",
+		PayloadRef: `{"effectiveBlock":100}`,
 	}
 
 	mcb := &blockchainmocks.Callbacks{}
@@ -244,13 +269,13 @@ func TestCallbackNetworkAction(t *testing.T) {
 		Version:     2,
 		V2Namespace: "ns1",
 	}
-	mcb.On("BlockchainEventBatch", matchNetworkActionEvent("terminate", verifier)).Return(nil).Once()
+	mcb.On("BlockchainEventBatch", matchNetworkActionEvent("terminate", `{"effectiveBlock":100}`, verifier)).Return(nil).Once()
 	events := make(EventsToDispatch)
 	cb.PrepareBatchPinOrNetworkAction(context.Background(), events, sub, fftypes.JSONAnyPtr("{}"), event, &verifier, params)
 	err := cb.DispatchBlockchainEvents(context.Background(), events)
 	assert.NoError(t, err)
 
-	mcb.On("BlockchainEventBatch", matchNetworkActionEvent("terminate", verifier)).Return(fmt.Errorf("pop")).Once()
+	mcb.On("BlockchainEventBatch", matchNetworkActionEvent("terminate", `{"effectiveBlock":100}`, verifier)).Return(fmt.Errorf("pop")).Once()
 	events = make(EventsToDispatch)
 	cb.PrepareBatchPinOrNetworkAction(context.Background(), events, sub, fftypes.JSONAnyPtr("{}"), event, &verifier, params)
 	err = cb.DispatchBlockchainEvents(context.Background(), events)
@@ -260,7 +285,7 @@ func TestCallbackNetworkAction(t *testing.T) {
 		Version:     1,
 		V1Namespace: map[string][]string{"ns2": {"ns1", "ns"}},
 	}
-	mcb.On("BlockchainEventBatch", matchNetworkActionEvent("terminate", verifier)).Return(nil).Once()
+	mcb.On("BlockchainEventBatch", matchNetworkActionEvent("terminate", `{"effectiveBlock":100}`, verifier)).Return(nil).Once()
 	events = make(EventsToDispatch)
 	cb.PrepareBatchPinOrNetworkAction(context.Background(), events, sub, fftypes.JSONAnyPtr("{}"), event, &verifier, params)
 	err = cb.DispatchBlockchainEvents(context.Background(), events)
@@ -292,6 +317,31 @@ func TestBuildBatchPinErrors(t *testing.T) {
 	assert.Regexp(t, "odd length hex string", err)
 }
 
+func TestParseBatchPinEvent(t *testing.T) {
+	event := &core.BlockchainEvent{
+		Name:       "BatchPin",
+		ProtocolID: "000000000010/000020/000030",
+		TX:         core.BlockchainTransactionRef{BlockchainID: "0x12345"},
+	}
+	params := &BatchPinParams{
+		UUIDs:      "0xe19af8b390604051812d7597d19adfb9847d3bfd074249efb65d3fed15f5b0a6",
+		BatchHash:  "0xd71eb138d74c229a388eb0e1abc03f4c7cbb21d4fc4b839fbf0ec73e4263f6be",
+		PayloadRef: "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD",
+	}
+
+	batch, err := ParseBatchPinEvent(context.Background(), event, params)
+	assert.NoError(t, err)
+	assert.Equal(t, "847d3bfd-0742-49ef-b65d-3fed15f5b0a6", batch.BatchID.String())
+	assert.Equal(t, "d71eb138d74c229a388eb0e1abc03f4c7cbb21d4fc4b839fbf0ec73e4263f6be", batch.BatchHash.String())
+	assert.Equal(t, "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD", batch.BatchPayloadRef)
+	assert.Equal(t, "0x12345", batch.Event.BlockchainTXID)
+	assert.Equal(t, "000000000010/000020/000030", batch.Event.ProtocolID)
+
+	params.UUIDs = ""
+	_, err = ParseBatchPinEvent(context.Background(), event, params)
+	assert.Regexp(t, "missing data", err)
+}
+
 func TestGetNamespaceFromSubName(t *testing.T) {
 	ns := GetNamespaceFromSubName("ff-sub-ns1-03071072-079b-4047-b192-a07186fc9db8")
 	assert.Equal(t, "ns1", ns)
@@ -354,6 +404,32 @@ func TestGoodSuccessReceipt(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestReceiptWithGas(t *testing.T) {
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	var reply BlockchainReceiptNotification
+	err := json.Unmarshal([]byte(`{
+		"headers": {"requestId": "`+nsOpID+`", "type": "TransactionSuccess"},
+		"transactionHash": "0x123",
+		"gasUsed": "21000",
+		"effectiveGasPrice": "1000000000"
+	}`), &reply)
+	assert.NoError(t, err)
+
+	mbi := &blockchainmocks.Plugin{}
+	mcb := &coremocks.OperationCallbacks{}
+	cb := NewBlockchainCallbacks()
+	cb.SetOperationalHandler("ns1", mcb)
+
+	mbi.On("Name").Return("utblockchain")
+	mcb.On("OperationUpdate", mock.MatchedBy(func(update *core.OperationUpdateAsync) bool {
+		return update.Output["gasUsed"] != nil && update.Output["effectiveGasPrice"] != nil
+	})).Return().Once()
+
+	err = HandleReceipt(context.Background(), "ns1", mbi, &reply, cb)
+	assert.NoError(t, err)
+	mcb.AssertExpectations(t)
+}
+
 func TestReceiptMarshallingError(t *testing.T) {
 	var reply BlockchainReceiptNotification
 	reply.Headers.ReceiptID = "ID"
@@ -506,3 +582,11 @@ func TestCallbackBulkOperationUpdate(t *testing.T) {
 
 	mcb.AssertExpectations(t)
 }
+
+func TestReceiptOutputSchemas(t *testing.T) {
+	schemas := ReceiptOutputSchemas()
+	assert.Len(t, schemas, 5)
+	for _, schema := range schemas {
+		assert.Equal(t, "object", schema.JSONObjectNowarn().GetObject("properties").GetObject("headers").GetString("type"))
+	}
+}
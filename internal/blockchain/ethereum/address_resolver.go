@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/credentials"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 )
 
@@ -46,6 +47,7 @@ type addressResolver struct {
 	bodyTemplate   *template.Template
 	responseField  string
 	client         *resty.Client
+	credentials    *credentials.REST
 	cache          cache.CInterface
 }
 
@@ -56,17 +58,19 @@ type addressResolverInserts struct {
 
 func newAddressResolver(ctx context.Context, localConfig config.Section, cacheManager cache.Manager, enableCache bool) (ar *addressResolver, err error) {
 
-	client, err := ffresty.New(ctx, localConfig)
+	restyConfig, err := ffresty.GenerateConfig(ctx, localConfig)
 
 	if err != nil {
 		return nil, err
 	}
+	client := ffresty.NewWithConfig(ctx, *restyConfig)
 
 	ar = &addressResolver{
 		retainOriginal: localConfig.GetBool(AddressResolverRetainOriginal),
 		method:         localConfig.GetString(AddressResolverMethod),
 		responseField:  localConfig.GetString(AddressResolverResponseField),
 		client:         client,
+		credentials:    credentials.NewREST(ctx, client, restyConfig),
 	}
 	if enableCache {
 		ar.cache, err = cacheManager.GetCache(
@@ -99,6 +103,15 @@ func newAddressResolver(ctx context.Context, localConfig config.Section, cacheMa
 	return ar, nil
 }
 
+func (ar *addressResolver) rotateCredentials(ctx context.Context, localConfig config.Section) error {
+	restyConfig, err := ffresty.GenerateConfig(ctx, localConfig)
+	if err != nil {
+		return err
+	}
+	ar.credentials.Update(restyConfig)
+	return nil
+}
+
 func (ar *addressResolver) ResolveSigningKey(ctx context.Context, keyDescriptor string, intent blockchain.ResolveKeyIntent) (string, error) {
 
 	if ar.cache != nil {
@@ -39,7 +39,9 @@ import (
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/credentials"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/sirupsen/logrus"
@@ -67,11 +69,13 @@ type Ethereum struct {
 	capabilities         *blockchain.Capabilities
 	callbacks            common.BlockchainCallbacks
 	client               *resty.Client
+	credentials          *credentials.REST
 	streams              *streamManager
 	streamID             map[string]string
 	wsconn               map[string]wsclient.WSClient
 	wsConfig             *wsclient.WSConfig
 	closed               map[string]chan struct{}
+	stop                 map[string]chan struct{}
 	addressResolveAlways bool
 	addressResolver      *addressResolver
 	metrics              metrics.Manager
@@ -137,6 +141,12 @@ func (e *Ethereum) VerifierType() core.VerifierType {
 	return core.VerifierTypeEthAddress
 }
 
+// CheckHealth confirms the ethereum connector is reachable, by listing its event streams
+func (e *Ethereum) CheckHealth(ctx context.Context) error {
+	_, err := e.streams.getEventStreams(ctx)
+	return err
+}
+
 func (e *Ethereum) Init(ctx context.Context, cancelCtx context.CancelFunc, conf config.Section, metrics metrics.Manager, cacheManager cache.Manager) (err error) {
 	e.InitConfig(conf)
 	ethconnectConf := e.ethconnectConf
@@ -145,7 +155,9 @@ func (e *Ethereum) Init(ctx context.Context, cancelCtx context.CancelFunc, conf
 	e.ctx = log.WithLogField(ctx, "proto", "ethereum")
 	e.cancelCtx = cancelCtx
 	e.metrics = metrics
-	e.capabilities = &blockchain.Capabilities{}
+	e.capabilities = &blockchain.Capabilities{
+		ListenerReset: true,
+	}
 	e.callbacks = common.NewBlockchainCallbacks()
 	e.subs = common.NewFireflySubscriptions()
 
@@ -161,14 +173,18 @@ func (e *Ethereum) Init(ctx context.Context, cancelCtx context.CancelFunc, conf
 		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "url", ethconnectConf)
 	}
 
+	var restyConfig *ffresty.Config
 	e.wsConfig, err = wsclient.GenerateConfig(ctx, ethconnectConf)
 	if err == nil {
-		e.client, err = ffresty.New(e.ctx, ethconnectConf)
+		restyConfig, err = ffresty.GenerateConfig(e.ctx, ethconnectConf)
 	}
 
 	if err != nil {
 		return err
 	}
+	e.client = ffresty.NewWithConfig(e.ctx, *restyConfig)
+	e.credentials = credentials.NewREST(e.ctx, e.client, restyConfig)
+	tracing.InstrumentClient(e.client, "ethereum")
 
 	e.pluginTopic = ethconnectConf.GetString(EthconnectConfigTopic)
 	if e.pluginTopic == "" {
@@ -196,6 +212,7 @@ func (e *Ethereum) Init(ctx context.Context, cancelCtx context.CancelFunc, conf
 
 	e.streamID = make(map[string]string)
 	e.closed = make(map[string]chan struct{})
+	e.stop = make(map[string]chan struct{})
 	e.wsconn = make(map[string]wsclient.WSClient)
 	e.streams = newStreamManager(e.client, e.cache, e.ethconnectConf.GetUint(EthconnectConfigBatchSize), e.ethconnectConf.GetDuration(EthconnectConfigBatchTimeout).Milliseconds())
 
@@ -210,7 +227,30 @@ func (e *Ethereum) StartNamespace(ctx context.Context, namespace string) (err er
 	log.L(e.ctx).Debugf("Starting namespace: %s", namespace)
 	topic := e.getTopic(namespace)
 
-	e.wsconn[namespace], err = wsclient.New(ctx, e.wsConfig, nil, func(ctx context.Context, w wsclient.WSClient) error {
+	e.wsconn[namespace], err = e.newNamespaceWS(ctx, topic)
+	if err != nil {
+		return err
+	}
+	// Make sure that our event stream is in place
+	stream, err := e.streams.ensureEventStream(ctx, topic, e.pluginTopic)
+	if err != nil {
+		return err
+	}
+	log.L(e.ctx).Infof("Event stream: %s (topic=%s)", stream.ID, topic)
+	e.streamID[namespace] = stream.ID
+
+	err = e.wsconn[namespace].Connect()
+	if err != nil {
+		return err
+	}
+
+	e.startEventLoop(namespace)
+
+	return nil
+}
+
+func (e *Ethereum) newNamespaceWS(ctx context.Context, topic string) (wsclient.WSClient, error) {
+	return wsclient.New(ctx, e.wsConfig, nil, func(ctx context.Context, w wsclient.WSClient) error {
 		// Send a subscribe to our topic after each connect/reconnect
 		b, _ := json.Marshal(&ethWSCommandPayload{
 			Type:  "listen",
@@ -225,26 +265,53 @@ func (e *Ethereum) StartNamespace(ctx context.Context, namespace string) (err er
 		}
 		return err
 	})
-	if err != nil {
-		return err
+}
+
+func (e *Ethereum) startEventLoop(namespace string) {
+	e.closed[namespace] = make(chan struct{})
+	e.stop[namespace] = make(chan struct{})
+
+	go e.eventLoop(namespace, e.wsconn[namespace], e.stop[namespace], e.closed[namespace])
+}
+
+// RotateCredentials switches to the credentials in the updated config for the connector and the address
+// resolver. REST requests in flight complete with the previous credentials. The websocket of each namespace
+// is replaced with one using the new credentials, once the event batch in progress has been acknowledged.
+func (e *Ethereum) RotateCredentials(ctx context.Context, conf config.Section) error {
+	ethconnectConf := conf.SubSection(EthconnectConfigKey)
+	var restyConfig *ffresty.Config
+	wsConfig, err := wsclient.GenerateConfig(ctx, ethconnectConf)
+	if err == nil {
+		restyConfig, err = ffresty.GenerateConfig(ctx, ethconnectConf)
 	}
-	// Make sure that our event stream is in place
-	stream, err := e.streams.ensureEventStream(ctx, topic, e.pluginTopic)
-	if err != nil {
-		return err
+	if err == nil && e.addressResolver != nil {
+		err = e.addressResolver.rotateCredentials(ctx, conf.SubSection(AddressResolverConfigKey))
 	}
-	log.L(e.ctx).Infof("Event stream: %s (topic=%s)", stream.ID, topic)
-	e.streamID[namespace] = stream.ID
-
-	err = e.wsconn[namespace].Connect()
 	if err != nil {
 		return err
 	}
+	if wsConfig.WSKeyPath == "" {
+		wsConfig.WSKeyPath = "/ws"
+	}
+	e.credentials.Update(restyConfig)
+	e.wsConfig = wsConfig
 
-	e.closed[namespace] = make(chan struct{})
-
-	go e.eventLoop(namespace, e.wsconn[namespace], e.closed[namespace])
-
+	for namespace := range e.wsconn {
+		wsconn, err := e.newNamespaceWS(e.ctx, e.getTopic(namespace))
+		if err != nil {
+			return err
+		}
+		if stop, ok := e.stop[namespace]; ok {
+			close(stop)
+			<-e.closed[namespace]
+		}
+		log.L(ctx).Infof("Reconnecting websocket for namespace '%s' with new credentials", namespace)
+		e.wsconn[namespace] = wsconn
+		if err := wsconn.Connect(); err != nil {
+			return err
+		}
+		e.startEventLoop(namespace)
+	}
 	return nil
 }
 
@@ -256,6 +323,7 @@ func (e *Ethereum) StopNamespace(ctx context.Context, namespace string) (err err
 	delete(e.wsconn, namespace)
 	delete(e.streamID, namespace)
 	delete(e.closed, namespace)
+	delete(e.stop, namespace)
 
 	return nil
 }
@@ -272,6 +340,10 @@ func (e *Ethereum) Capabilities() *blockchain.Capabilities {
 	return e.capabilities
 }
 
+func (e *Ethereum) OperationOutputSchemas() map[core.OpType]*fftypes.JSONAny {
+	return common.ReceiptOutputSchemas()
+}
+
 func (e *Ethereum) AddFireflySubscription(ctx context.Context, namespace *core.Namespace, contract *blockchain.MultipartyContract, lastProtocolID string) (string, error) {
 	ethLocation, err := e.parseContractLocation(ctx, contract.Location)
 	if err != nil {
@@ -352,33 +424,52 @@ func (e *Ethereum) processBatchPinEvent(ctx context.Context, events common.Event
 		return // move on
 	}
 
-	authorAddress := event.Output.GetString("author")
-	nsOrAction := event.Output.GetString("action")
-	if nsOrAction == "" {
-		nsOrAction = event.Output.GetString("namespace")
+	// Validate the ethereum address - it must already be a valid address, we do not
+	// engage the address resolve on this blockchain-driven path.
+	verifier, err := batchPinAuthor(ctx, event.Output)
+	if err != nil {
+		log.L(ctx).Errorf("BatchPin event is not valid - bad from address (%s): %+v", err, msgJSON)
+		return // move on
 	}
 
-	params := &common.BatchPinParams{
-		UUIDs:      event.Output.GetString("uuids"),
-		BatchHash:  event.Output.GetString("batchHash"),
-		PayloadRef: event.Output.GetString("payloadRef"),
-		Contexts:   event.Output.GetStringArray("contexts"),
+	e.callbacks.PrepareBatchPinOrNetworkAction(ctx, events, subInfo, location, event, verifier, batchPinParams(event.Output))
+}
+
+func batchPinParams(output fftypes.JSONObject) *common.BatchPinParams {
+	nsOrAction := output.GetString("action")
+	if nsOrAction == "" {
+		nsOrAction = output.GetString("namespace")
+	}
+	return &common.BatchPinParams{
+		UUIDs:      output.GetString("uuids"),
+		BatchHash:  output.GetString("batchHash"),
+		PayloadRef: output.GetString("payloadRef"),
+		Contexts:   output.GetStringArray("contexts"),
 		NsOrAction: nsOrAction,
 	}
+}
 
-	// Validate the ethereum address - it must already be a valid address, we do not
-	// engage the address resolve on this blockchain-driven path.
-	authorAddress, err := formatEthAddress(ctx, authorAddress)
+func batchPinAuthor(ctx context.Context, output fftypes.JSONObject) (*core.VerifierRef, error) {
+	authorAddress, err := formatEthAddress(ctx, output.GetString("author"))
 	if err != nil {
-		log.L(ctx).Errorf("BatchPin event is not valid - bad from address (%s): %+v", err, msgJSON)
-		return // move on
+		return nil, err
 	}
-	verifier := &core.VerifierRef{
+	return &core.VerifierRef{
 		Type:  core.VerifierTypeEthAddress,
 		Value: authorAddress,
-	}
+	}, nil
+}
 
-	e.callbacks.PrepareBatchPinOrNetworkAction(ctx, events, subInfo, location, event, verifier, params)
+func (e *Ethereum) ParseBatchPinEvent(ctx context.Context, event *core.BlockchainEvent) (*blockchain.BatchPin, *core.VerifierRef, error) {
+	verifier, err := batchPinAuthor(ctx, event.Output)
+	if err != nil {
+		return nil, nil, err
+	}
+	batch, err := common.ParseBatchPinEvent(ctx, event, batchPinParams(event.Output))
+	if err != nil {
+		return nil, nil, err
+	}
+	return batch, verifier, nil
 }
 
 func (e *Ethereum) processContractEvent(ctx context.Context, events common.EventsToDispatch, msgJSON fftypes.JSONObject) error {
@@ -403,9 +494,39 @@ func (e *Ethereum) buildEventLocationString(msgJSON fftypes.JSONObject) string {
 	return fmt.Sprintf("address=%s", msgJSON.GetString("address"))
 }
 
+func (e *Ethereum) processRemovedEvent(ctx context.Context, events common.EventsToDispatch, reorgs map[string]int64, msgJSON fftypes.JSONObject) error {
+	subID := msgJSON.GetString("subId")
+	var namespace string
+	if subInfo := e.subs.GetSubscription(subID); subInfo != nil {
+		namespace = subInfo.V2Namespace
+	} else {
+		subName, err := e.streams.getSubscriptionName(ctx, subID)
+		if err != nil {
+			return err
+		}
+		namespace = common.GetNamespaceFromSubName(subName)
+	}
+
+	// Every removed event from a block is re-delivered, but only one re-org is needed per namespace
+	blockNumber := msgJSON.GetInt64("blockNumber")
+	if previous, ok := reorgs[namespace]; ok && previous <= blockNumber {
+		return nil
+	}
+	reorgs[namespace] = blockNumber
+	log.L(ctx).Warnf("Block %d removed from the chain by a re-org", blockNumber)
+	e.callbacks.PrepareReorg(ctx, events, namespace, &blockchain.ReorgEvent{
+		Source:         e.Name(),
+		BlockNumber:    blockNumber,
+		BlockHash:      msgJSON.GetString("blockHash"),
+		FromProtocolID: fmt.Sprintf("%.12d", blockNumber),
+	})
+	return nil
+}
+
 func (e *Ethereum) handleMessageBatch(ctx context.Context, batchID int64, messages []interface{}) error {
 	// Build the set of events that need handling
 	events := make(common.EventsToDispatch)
+	reorgs := make(map[string]int64)
 	count := len(messages)
 	for i, msgI := range messages {
 		msgMap, ok := msgI.(map[string]interface{})
@@ -421,6 +542,14 @@ func (e *Ethereum) handleMessageBatch(ctx context.Context, batchID int64, messag
 		logger.Infof("[EVM:%d:%d/%d]: '%s' on '%s'", batchID, i+1, count, signature, sub)
 		logger.Tracef("Message: %+v", msgJSON)
 
+		// The connector re-delivers an event with removed=true when its block is lost in a re-org
+		if msgJSON.GetBool("removed") {
+			if err := e.processRemovedEvent(ctx, events, reorgs, msgJSON); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// Matches one of the active FireFly BatchPin subscriptions
 		if subInfo := e.subs.GetSubscription(sub); subInfo != nil {
 			location, err := e.encodeContractLocation(ctx, &Location{
@@ -453,7 +582,7 @@ func (e *Ethereum) handleMessageBatch(ctx context.Context, batchID int64, messag
 	return e.callbacks.DispatchBlockchainEvents(ctx, events)
 }
 
-func (e *Ethereum) eventLoop(namespace string, wsconn wsclient.WSClient, closed chan struct{}) {
+func (e *Ethereum) eventLoop(namespace string, wsconn wsclient.WSClient, stop, closed chan struct{}) {
 	topic := e.getTopic(namespace)
 	defer wsconn.Close()
 	defer close(closed)
@@ -465,6 +594,9 @@ func (e *Ethereum) eventLoop(namespace string, wsconn wsclient.WSClient, closed
 		case <-ctx.Done():
 			l.Debugf("Event loop exiting (context cancelled)")
 			return
+		case <-stop:
+			l.Debugf("Event loop exiting (websocket replaced)")
+			return
 		case msgBytes, ok := <-wsconn.Receive():
 			if !ok {
 				l.Debugf("Event loop exiting (receive channel closed). Terminating server!")
@@ -703,7 +835,7 @@ func (e *Ethereum) SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace,
 	return err
 }
 
-func (e *Ethereum) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action core.NetworkActionType, location *fftypes.JSONAny) error {
+func (e *Ethereum) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action core.NetworkActionType, payload string, location *fftypes.JSONAny) error {
 	ethLocation, err := e.parseContractLocation(ctx, location)
 	if err != nil {
 		return err
@@ -723,14 +855,14 @@ func (e *Ethereum) SubmitNetworkAction(ctx context.Context, nsOpID string, signi
 			blockchain.FireFlyActionPrefix + action,
 			ethHexFormatB32(nil),
 			ethHexFormatB32(nil),
-			"",
+			payload,
 			[]string{},
 		}
 	} else {
 		method = networkActionMethodABI
 		input = []interface{}{
 			blockchain.FireFlyActionPrefix + action,
-			"",
+			payload,
 		}
 	}
 	var emptyErrors []*abi.Entry
@@ -982,6 +1114,29 @@ func (e *Ethereum) GetContractListenerStatus(ctx context.Context, namespace, sub
 	return true, checkpoint, status, nil
 }
 
+func (e *Ethereum) GetContractListenerCheckpoint(ctx context.Context, namespace, subID string) (*blockchain.ListenerCheckpoint, error) {
+	sub, err := e.streams.getSubscription(ctx, subID, true)
+	if err != nil || sub == nil || sub.Stream != e.streamID[namespace] {
+		return nil, err
+	}
+	return &blockchain.ListenerCheckpoint{
+		Block:   sub.Checkpoint.Block,
+		Catchup: sub.Catchup,
+	}, nil
+}
+
+func (e *Ethereum) ResetContractListener(ctx context.Context, namespace, subID string, fromBlock int64) error {
+	return e.streams.resetSubscription(ctx, subID, fromBlock)
+}
+
+func (e *Ethereum) GetProtocolIDBlock(ctx context.Context, protocolID string) (int64, error) {
+	block, err := strconv.ParseInt(strings.Split(protocolID, "/")[0], 10, 64)
+	if err != nil {
+		return -1, i18n.NewError(ctx, coremsgs.MsgInvalidLastEventProtocolID, protocolID)
+	}
+	return block, nil
+}
+
 func (e *Ethereum) GetFFIParamValidator(ctx context.Context) (fftypes.FFIParamValidator, error) {
 	return &ffi2abi.ParamValidator{}, nil
 }
@@ -134,6 +134,7 @@ func newTestEthereum() (*Ethereum, func()) {
 		streamID:    make(map[string]string),
 		wsconn:      make(map[string]wsclient.WSClient),
 		closed:      make(map[string]chan struct{}),
+		stop:        make(map[string]chan struct{}),
 		wsConfig:    &wsclient.WSConfig{},
 		metrics:     mm,
 		cache:       cache.NewUmanagedCache(ctx, 100, 5*time.Minute),
@@ -267,6 +268,7 @@ func TestInitAndStartWithEthConnect(t *testing.T) {
 	assert.Equal(t, 2, httpmock.GetTotalCallCount())
 	assert.Equal(t, "es12345", e.streamID["ns1"])
 	assert.NotNil(t, e.Capabilities())
+	assert.Len(t, e.OperationOutputSchemas(), 5)
 
 	startupMessage := <-toServer
 	assert.Equal(t, `{"type":"listen","topic":"topic1/ns1"}`, startupMessage)
@@ -1752,7 +1754,7 @@ func TestEventLoopContextCancelled(t *testing.T) {
 	wsm.On("Receive").Return(r)
 	wsm.On("Close").Return()
 	e.closed["ns1"] = make(chan struct{})
-	e.eventLoop("ns1", wsm, e.closed["ns1"]) // we're simply looking for it exiting
+	e.eventLoop("ns1", wsm, make(chan struct{}), e.closed["ns1"]) // we're simply looking for it exiting
 	wsm.AssertExpectations(t)
 }
 
@@ -1766,7 +1768,7 @@ func TestEventLoopReceiveClosed(t *testing.T) {
 	wsm.On("Receive").Return((<-chan []byte)(r))
 	wsm.On("Close").Return()
 	e.closed["ns1"] = make(chan struct{})
-	e.eventLoop("ns1", wsm, e.closed["ns1"]) // we're simply looking for it exiting
+	e.eventLoop("ns1", wsm, make(chan struct{}), e.closed["ns1"]) // we're simply looking for it exiting
 	wsm.AssertExpectations(t)
 }
 
@@ -1784,7 +1786,7 @@ func TestEventLoopSendClosed(t *testing.T) {
 	}).Return(fmt.Errorf("pop"))
 	wsm.On("Close").Return()
 	e.closed["ns1"] = make(chan struct{})
-	e.eventLoop("ns1", wsm, e.closed["ns1"]) // we're simply looking for it exiting
+	e.eventLoop("ns1", wsm, make(chan struct{}), e.closed["ns1"]) // we're simply looking for it exiting
 	wsm.AssertExpectations(t)
 }
 
@@ -1965,7 +1967,7 @@ func TestHandleBadPayloadsAndThenReceiptFailure(t *testing.T) {
 		close(done)
 	}
 
-	go e.eventLoop("ns1", wsm, e.closed["ns1"])
+	go e.eventLoop("ns1", wsm, make(chan struct{}), e.closed["ns1"])
 	r <- []byte(`!badjson`)        // ignored bad json
 	r <- []byte(`"not an object"`) // ignored wrong type
 	r <- data.Bytes()
@@ -2429,6 +2431,128 @@ func TestDeleteSubscriptionFail(t *testing.T) {
 	assert.Regexp(t, "FF10111", err)
 }
 
+func TestGetContractListenerCheckpoint(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	e.streamID["ns1"] = "es-1"
+	e.streams = &streamManager{
+		client: e.client,
+	}
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sb-1",
+		httpmock.NewJsonResponderOrPanic(200, subscription{
+			ID: "sb-1", Stream: "es-1", subscriptionCheckpoint: subscriptionCheckpoint{
+				Catchup:    true,
+				Checkpoint: ListenerCheckpoint{Block: 1000},
+			},
+		}))
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sb-2",
+		httpmock.NewJsonResponderOrPanic(200, subscription{ID: "sb-2", Stream: "es-2"}))
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sb-3",
+		httpmock.NewStringResponder(404, ""))
+
+	checkpoint, err := e.GetContractListenerCheckpoint(context.Background(), "ns1", "sb-1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), checkpoint.Block)
+	assert.True(t, checkpoint.Catchup)
+
+	checkpoint, err = e.GetContractListenerCheckpoint(context.Background(), "ns1", "sb-2")
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	checkpoint, err = e.GetContractListenerCheckpoint(context.Background(), "ns1", "sb-3")
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestResetContractListener(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	e.streams = &streamManager{
+		client: e.client,
+	}
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/subscriptions/sb-1/reset",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]string
+			err := json.NewDecoder(req.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, "100", body["fromBlock"])
+			return httpmock.NewStringResponder(204, "")(req)
+		})
+
+	err := e.ResetContractListener(context.Background(), "ns1", "sb-1", 100)
+	assert.NoError(t, err)
+}
+
+func TestResetContractListenerFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	e.streams = &streamManager{
+		client: e.client,
+	}
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/subscriptions/sb-1/reset",
+		httpmock.NewStringResponder(500, ""))
+
+	err := e.ResetContractListener(context.Background(), "ns1", "sb-1", 100)
+	assert.Regexp(t, "FF10111", err)
+}
+
+func TestGetProtocolIDBlock(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	block, err := e.GetProtocolIDBlock(context.Background(), "000000000100/000002/000003")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), block)
+
+	_, err = e.GetProtocolIDBlock(context.Background(), "bad")
+	assert.Regexp(t, "FF10472", err)
+}
+
+func TestParseBatchPinEvent(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	event := &core.BlockchainEvent{
+		Name:       "BatchPin",
+		ProtocolID: "000000038011/000000/000050",
+		Output: fftypes.JSONObject{
+			"author":     "0X91D2B4381A4CD5C7C0F27565A7D4B829844C8635",
+			"namespace":  "ns1",
+			"uuids":      "0xe19af8b390604051812d7597d19adfb9847d3bfd074249efb65d3fed15f5b0a6",
+			"batchHash":  "0xd71eb138d74c229a388eb0e1abc03f4c7cbb21d4fc4b839fbf0ec73e4263f6be",
+			"payloadRef": "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD",
+		},
+	}
+
+	batch, signer, err := e.ParseBatchPinEvent(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, "847d3bfd-0742-49ef-b65d-3fed15f5b0a6", batch.BatchID.String())
+	assert.Equal(t, "d71eb138d74c229a388eb0e1abc03f4c7cbb21d4fc4b839fbf0ec73e4263f6be", batch.BatchHash.String())
+	assert.Equal(t, "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD", batch.BatchPayloadRef)
+	assert.Equal(t, core.VerifierTypeEthAddress, signer.Type)
+	assert.Equal(t, "0x91d2b4381a4cd5c7c0f27565a7d4b829844c8635", signer.Value)
+
+	event.Output["uuids"] = "bad"
+	_, _, err = e.ParseBatchPinEvent(context.Background(), event)
+	assert.Regexp(t, "FF10418", err)
+
+	event.Output["author"] = "bad"
+	_, _, err = e.ParseBatchPinEvent(context.Background(), event)
+	assert.Regexp(t, "FF10141", err)
+}
+
 func TestDeleteSubscriptionNotFound(t *testing.T) {
 	e, cancel := newTestEthereum()
 	defer cancel()
@@ -2672,6 +2796,127 @@ func TestHandleMessageContractEventWithNamespace(t *testing.T) {
 	em.AssertExpectations(t)
 }
 
+func TestHandleMessageContractEventRemoved(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+  {
+		"address": "0x1C197604587F046FD40684A8f21f4609FB811A7b",
+		"blockNumber": "38011",
+		"blockHash": "0x0102",
+		"transactionIndex": "0x0",
+		"transactionHash": "0xc26df2bf1a733e9249372d61eb11bd8662d26c8129df76890b1beb2f6fa72628",
+		"data": {
+			"from": "0x91D2B4381A4CD5C7C0F27565A7D4B829844C8635",
+			"value": "1"
+    },
+		"subId": "sub2",
+		"signature": "Changed(address,uint256)",
+		"logIndex": "50",
+		"timestamp": "1640811383",
+		"removed": true
+  },
+  {
+		"address": "0x1C197604587F046FD40684A8f21f4609FB811A7b",
+		"blockNumber": "38012",
+		"blockHash": "0x0304",
+		"transactionIndex": "0x0",
+		"transactionHash": "0xc26df2bf1a733e9249372d61eb11bd8662d26c8129df76890b1beb2f6fa72638",
+		"data": {
+			"from": "0x91D2B4381A4CD5C7C0F27565A7D4B829844C8635",
+			"value": "2"
+    },
+		"subId": "sub2",
+		"signature": "Changed(address,uint256)",
+		"logIndex": "10",
+		"timestamp": "1640811384",
+		"removed": true
+  },
+	{
+		"address": "0x1C197604587F046FD40684A8f21f4609FB811A7b",
+		"blockNumber": "38011",
+		"blockHash": "0x0506",
+		"transactionIndex": "0x0",
+		"transactionHash": "0xc26df2bf1a733e9249372d61eb11bd8662d26c8129df76890b1beb2f6fa72648",
+		"data": {
+			"from": "0x91D2B4381A4CD5C7C0F27565A7D4B829844C8635",
+			"value": "1"
+    },
+		"subId": "sub2",
+		"signature": "Changed(address,uint256)",
+		"logIndex": "50",
+		"timestamp": "1640811385"
+  }
+]`)
+
+	em := &blockchainmocks.Callbacks{}
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub2",
+		httpmock.NewJsonResponderOrPanic(200, subscription{
+			ID: "sub2", Stream: "es12345", Name: "ff-sub-ns1-1132312312312",
+		}))
+
+	e.callbacks = common.NewBlockchainCallbacks()
+	e.SetHandler("ns1", em)
+	e.streams = newTestStreamManager(e.client)
+
+	em.On("BlockchainEventBatch", mock.MatchedBy(func(batch []*blockchain.EventToDispatch) bool {
+		return len(batch) == 2
+	})).Return(nil)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = e.handleMessageBatch(context.Background(), 0, events)
+	assert.NoError(t, err)
+
+	batch := em.Calls[0].Arguments[0].([]*blockchain.EventToDispatch)
+	assert.Equal(t, blockchain.EventTypeReorg, batch[0].Type)
+	assert.Equal(t, &blockchain.ReorgEvent{
+		Source:         "ethereum",
+		BlockNumber:    38011,
+		BlockHash:      "0x0102",
+		FromProtocolID: "000000038011",
+	}, batch[0].Reorg)
+	assert.Equal(t, blockchain.EventTypeForListener, batch[1].Type)
+	assert.Equal(t, "000000038011/000000/000050", batch[1].ForListener.ProtocolID)
+
+	em.AssertExpectations(t)
+}
+
+func TestHandleMessageContractEventRemovedBadSubscription(t *testing.T) {
+	data := fftypes.JSONAnyPtr(`
+[
+  {
+		"address": "0x1C197604587F046FD40684A8f21f4609FB811A7b",
+		"blockNumber": "38011",
+		"subId": "sub2",
+		"signature": "Changed(address,uint256)",
+		"removed": true
+  }
+]`)
+
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sub2",
+		httpmock.NewStringResponder(500, "pop"))
+
+	e.callbacks = common.NewBlockchainCallbacks()
+	e.streams = newTestStreamManager(e.client)
+
+	var events []interface{}
+	err := json.Unmarshal(data.Bytes(), &events)
+	assert.NoError(t, err)
+	err = e.handleMessageBatch(context.Background(), 0, events)
+	assert.Regexp(t, "FF10111", err)
+}
+
 func TestHandleMessageContractEventNoNamespaceHandlers(t *testing.T) {
 	data := fftypes.JSONAnyPtr(`
 [
@@ -3858,7 +4103,7 @@ func TestSubmitNetworkAction(t *testing.T) {
 			headers := body["headers"].(map[string]interface{})
 			assert.Equal(t, "SendTransaction", headers["type"])
 			assert.Equal(t, "firefly:terminate", params[0])
-			assert.Equal(t, "", params[1])
+			assert.Equal(t, `{"effectiveBlock":100}`, params[1])
 			return httpmock.NewJsonResponderOrPanic(200, "")(req)
 		})
 
@@ -3866,7 +4111,7 @@ func TestSubmitNetworkAction(t *testing.T) {
 		"address": "0x123",
 	}.String())
 
-	err := e.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), "0x123", core.NetworkActionTerminate, location)
+	err := e.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), "0x123", core.NetworkActionTerminate, `{"effectiveBlock":100}`, location)
 	assert.NoError(t, err)
 }
 
@@ -3888,7 +4133,7 @@ func TestSubmitNetworkActionV1(t *testing.T) {
 			assert.Equal(t, "SendTransaction", headers["type"])
 			assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000000", params[1])
 			assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000000", params[2])
-			assert.Equal(t, "", params[3])
+			assert.Equal(t, `{"effectiveBlock":100}`, params[3])
 			return httpmock.NewJsonResponderOrPanic(200, "")(req)
 		})
 
@@ -3896,7 +4141,7 @@ func TestSubmitNetworkActionV1(t *testing.T) {
 		"address": "0x123",
 	}.String())
 
-	err := e.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), "0x123", core.NetworkActionTerminate, location)
+	err := e.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), "0x123", core.NetworkActionTerminate, `{"effectiveBlock":100}`, location)
 	assert.NoError(t, err)
 }
 
@@ -3921,7 +4166,7 @@ func TestSubmitNetworkActionBadLocation(t *testing.T) {
 		"bad": "pop",
 	}.String())
 
-	err := e.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), "0x123", core.NetworkActionTerminate, location)
+	err := e.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), "0x123", core.NetworkActionTerminate, "", location)
 	assert.Regexp(t, "FF10310", err)
 }
 
@@ -3936,7 +4181,7 @@ func TestSubmitNetworkActionVersionFail(t *testing.T) {
 		"address": "0x123",
 	}.String())
 
-	err := e.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), "0x123", core.NetworkActionTerminate, location)
+	err := e.SubmitNetworkAction(context.Background(), "ns1:"+fftypes.NewUUID().String(), "0x123", core.NetworkActionTerminate, "", location)
 	assert.Regexp(t, "FF10111", err)
 }
 
@@ -5001,3 +5246,108 @@ func TestCheckOverLappingLocationsSame(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, result)
 }
+
+func TestCheckHealth(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/eventstreams",
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{}))
+
+	err := e.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestCheckHealthFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/eventstreams",
+		httpmock.NewStringResponder(500, "pop"))
+
+	err := e.CheckHealth(context.Background())
+	assert.Regexp(t, "FF10111", err)
+}
+
+func TestRotateCredentials(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	authHeaders := make(chan string, 1)
+	checkAuth := func(req *http.Request) { authHeaders <- req.Header.Get("Authorization") }
+	toServer1, _, wsURL1, done1 := wsclient.NewTestWSServer(checkAuth)
+	defer done1()
+	// The test server only accepts one connection, so the new websocket connects to a second one
+	toServer2, _, wsURL2, done2 := wsclient.NewTestWSServer(checkAuth)
+	defer done2()
+
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+	defer httpmock.DeactivateAndReset()
+
+	u, _ := url.Parse(wsURL1)
+	u.Scheme = "http"
+	httpURL := u.String()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/eventstreams", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{}))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/eventstreams", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			username, password, _ := req.BasicAuth()
+			assert.Equal(t, "user1", username)
+			assert.Equal(t, "pass1", password)
+			return httpmock.NewJsonResponderOrPanic(200, eventStream{ID: "es12345"})(req)
+		})
+
+	resetConf(e)
+	utEthconnectConf.Set(ffresty.HTTPConfigURL, httpURL)
+	utEthconnectConf.Set(ffresty.HTTPCustomClient, mockedClient)
+	utEthconnectConf.Set(ffresty.HTTPConfigAuthUsername, "user1")
+	utEthconnectConf.Set(ffresty.HTTPConfigAuthPassword, "pass1")
+	utEthconnectConf.Set(EthconnectConfigTopic, "topic1")
+
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(e.ctx, 100, 5*time.Minute), nil)
+	err := e.Init(e.ctx, e.cancelCtx, utConfig, e.metrics, cmi)
+	assert.NoError(t, err)
+
+	err = e.StartNamespace(e.ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjE6cGFzczE=", <-authHeaders)
+	assert.Equal(t, `{"type":"listen","topic":"topic1/ns1"}`, <-toServer1)
+	assert.Equal(t, `{"type":"listenreplies"}`, <-toServer1)
+
+	u, _ = url.Parse(wsURL2)
+	u.Scheme = "http"
+	utEthconnectConf.Set(ffresty.HTTPConfigURL, u.String())
+	utEthconnectConf.Set(ffresty.HTTPConfigAuthPassword, "pass2")
+	err = e.RotateCredentials(e.ctx, utConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjE6cGFzczI=", <-authHeaders)
+	assert.Equal(t, `{"type":"listen","topic":"topic1/ns1"}`, <-toServer2)
+	assert.Equal(t, `{"type":"listenreplies"}`, <-toServer2)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/eventstreams", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			_, password, _ := req.BasicAuth()
+			assert.Equal(t, "pass2", password)
+			return httpmock.NewJsonResponderOrPanic(200, []eventStream{})(req)
+		})
+	_, err = e.streams.getEventStreams(e.ctx)
+	assert.NoError(t, err)
+}
+
+func TestRotateCredentialsBadTLSConfig(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	resetConf(e)
+	tlsConf := utEthconnectConf.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := e.RotateCredentials(e.ctx, utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
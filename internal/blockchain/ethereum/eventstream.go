@@ -278,6 +278,19 @@ func (s *streamManager) createSubscription(ctx context.Context, stream, subName,
 	return &sub, nil
 }
 
+func (s *streamManager) resetSubscription(ctx context.Context, subID string, fromBlock int64) error {
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetBody(map[string]string{
+			"fromBlock": strconv.FormatInt(fromBlock, 10),
+		}).
+		Post(fmt.Sprintf("/subscriptions/%s/reset", subID))
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgEthConnectorRESTErr)
+	}
+	return nil
+}
+
 func (s *streamManager) deleteSubscription(ctx context.Context, subID string, okNotFound bool) error {
 	res, err := s.client.R().
 		SetContext(ctx).
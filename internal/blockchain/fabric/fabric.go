@@ -38,6 +38,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 )
@@ -188,6 +189,12 @@ func (f *Fabric) VerifierType() core.VerifierType {
 	return core.VerifierTypeMSPIdentity
 }
 
+// CheckHealth confirms the fabconnect is reachable, by listing its event streams
+func (f *Fabric) CheckHealth(ctx context.Context) error {
+	_, err := f.streams.getEventStreams(ctx)
+	return err
+}
+
 func (f *Fabric) Init(ctx context.Context, cancelCtx context.CancelFunc, conf config.Section, metrics metrics.Manager, cacheManager cache.Manager) (err error) {
 	f.InitConfig(conf)
 	fabconnectConf := f.fabconnectConf
@@ -212,6 +219,7 @@ func (f *Fabric) Init(ctx context.Context, cancelCtx context.CancelFunc, conf co
 	if err != nil {
 		return err
 	}
+	tracing.InstrumentClient(f.client, "fabric")
 
 	f.defaultChannel = fabconnectConf.GetString(FabconnectConfigDefaultChannel)
 	// the org identity is guaranteed to be configured by the core
@@ -318,6 +326,10 @@ func (f *Fabric) Capabilities() *blockchain.Capabilities {
 	return f.capabilities
 }
 
+func (f *Fabric) OperationOutputSchemas() map[core.OpType]*fftypes.JSONAny {
+	return common.ReceiptOutputSchemas()
+}
+
 func decodeJSONPayload(ctx context.Context, payloadString string) *fftypes.JSONObject {
 	bytes, err := base64.StdEncoding.DecodeString(payloadString)
 	if err != nil {
@@ -373,22 +385,32 @@ func (f *Fabric) processBatchPinEvent(ctx context.Context, events common.EventsT
 		return // move on
 	}
 
-	signer := event.Output.GetString("signer")
-	nsOrAction := event.Output.GetString("namespace")
-	params := &common.BatchPinParams{
-		UUIDs:      event.Output.GetString("uuids"),
-		BatchHash:  event.Output.GetString("batchHash"),
-		PayloadRef: event.Output.GetString("payloadRef"),
-		Contexts:   event.Output.GetStringArray("contexts"),
-		NsOrAction: nsOrAction,
+	f.callbacks.PrepareBatchPinOrNetworkAction(ctx, events, subInfo, location, event, batchPinSigner(event.Output), batchPinParams(event.Output))
+}
+
+func batchPinParams(output fftypes.JSONObject) *common.BatchPinParams {
+	return &common.BatchPinParams{
+		UUIDs:      output.GetString("uuids"),
+		BatchHash:  output.GetString("batchHash"),
+		PayloadRef: output.GetString("payloadRef"),
+		Contexts:   output.GetStringArray("contexts"),
+		NsOrAction: output.GetString("namespace"),
 	}
+}
 
-	verifier := &core.VerifierRef{
+func batchPinSigner(output fftypes.JSONObject) *core.VerifierRef {
+	return &core.VerifierRef{
 		Type:  core.VerifierTypeMSPIdentity,
-		Value: signer,
+		Value: output.GetString("signer"),
 	}
+}
 
-	f.callbacks.PrepareBatchPinOrNetworkAction(ctx, events, subInfo, location, event, verifier, params)
+func (f *Fabric) ParseBatchPinEvent(ctx context.Context, event *core.BlockchainEvent) (*blockchain.BatchPin, *core.VerifierRef, error) {
+	batch, err := common.ParseBatchPinEvent(ctx, event, batchPinParams(event.Output))
+	if err != nil {
+		return nil, nil, err
+	}
+	return batch, batchPinSigner(event.Output), nil
 }
 
 func (f *Fabric) buildEventLocationString(chaincode string) string {
@@ -700,7 +722,7 @@ func (f *Fabric) SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, s
 	return err
 }
 
-func (f *Fabric) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action core.NetworkActionType, location *fftypes.JSONAny) error {
+func (f *Fabric) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action core.NetworkActionType, payload string, location *fftypes.JSONAny) error {
 	fabricOnChainLocation, err := parseContractLocation(ctx, location)
 	if err != nil {
 		return err
@@ -722,7 +744,7 @@ func (f *Fabric) SubmitNetworkAction(ctx context.Context, nsOpID string, signing
 			"namespace":  "firefly:" + action,
 			"uuids":      hexFormatB32(nil),
 			"batchHash":  hexFormatB32(nil),
-			"payloadRef": "",
+			"payloadRef": payload,
 			"contexts":   []string{},
 		}
 	} else {
@@ -730,7 +752,7 @@ func (f *Fabric) SubmitNetworkAction(ctx context.Context, nsOpID string, signing
 		prefixItems = networkActionPrefixItems
 		pinInput = map[string]interface{}{
 			"action":  "firefly:" + action,
-			"payload": "",
+			"payload": payload,
 		}
 	}
 
@@ -1008,6 +1030,18 @@ func (f *Fabric) GetContractListenerStatus(ctx context.Context, namespace, subID
 	return true, nil, core.ContractListenerStatusUnknown, err
 }
 
+func (f *Fabric) GetContractListenerCheckpoint(ctx context.Context, namespace, subID string) (*blockchain.ListenerCheckpoint, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (f *Fabric) ResetContractListener(ctx context.Context, namespace, subID string, fromBlock int64) error {
+	return i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (f *Fabric) GetProtocolIDBlock(ctx context.Context, protocolID string) (int64, error) {
+	return -1, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
 func (f *Fabric) GetFFIParamValidator(ctx context.Context) (fftypes.FFIParamValidator, error) {
 	// Fabconnect does not require any additional validation beyond "JSON Schema correctness" at this time
 	return nil, nil
@@ -319,6 +319,7 @@ func TestInitAllNewStreamsAndWSEvent(t *testing.T) {
 	assert.Equal(t, 2, httpmock.GetTotalCallCount())
 	assert.Equal(t, "es12345", e.streamID["ns1"])
 	assert.NotNil(t, e.Capabilities())
+	assert.Len(t, e.OperationOutputSchemas(), 5)
 
 	startupMessage := <-toServer
 	assert.Equal(t, `{"type":"listen","topic":"topic1/ns1"}`, startupMessage)
@@ -1272,7 +1273,7 @@ func TestSubmitBatchEmptyPayloadRef(t *testing.T) {
 			assert.Equal(t, signer, (body["headers"].(map[string]interface{}))["signer"])
 			assert.Equal(t, "0x9ffc50ff6bfe4502adc793aea54cc059c5df767cfe444e038eb51c5523097db5", (body["args"].(map[string]interface{}))["uuids"])
 			assert.Equal(t, hexFormatB32(batch.BatchHash), (body["args"].(map[string]interface{}))["batchHash"])
-			assert.Equal(t, "", (body["args"].(map[string]interface{}))["payloadRef"])
+			assert.Equal(t, `{"effectiveBlock":100}`, (body["args"].(map[string]interface{}))["payloadRef"])
 			return httpmock.NewJsonResponderOrPanic(200, "")(req)
 		})
 
@@ -3226,7 +3227,7 @@ func TestSubmitNetworkAction(t *testing.T) {
 			json.NewDecoder(req.Body).Decode(&body)
 			assert.Equal(t, signer, (body["headers"].(map[string]interface{}))["signer"])
 			assert.Equal(t, "\"firefly:terminate\"", (body["args"].(map[string]interface{}))["action"])
-			assert.Equal(t, "", (body["args"].(map[string]interface{}))["payload"])
+			assert.Equal(t, `{"effectiveBlock":100}`, (body["args"].(map[string]interface{}))["payload"])
 			return httpmock.NewJsonResponderOrPanic(200, "")(req)
 		})
 
@@ -3238,7 +3239,7 @@ func TestSubmitNetworkAction(t *testing.T) {
 		"chaincode": "simplestorage",
 	}.String())
 
-	err := e.SubmitNetworkAction(context.Background(), "", signer, core.NetworkActionTerminate, location)
+	err := e.SubmitNetworkAction(context.Background(), "", signer, core.NetworkActionTerminate, `{"effectiveBlock":100}`, location)
 	assert.NoError(t, err)
 }
 
@@ -3269,7 +3270,7 @@ func TestSubmitNetworkActionV1(t *testing.T) {
 		"chaincode": "simplestorage",
 	}.String())
 
-	err := e.SubmitNetworkAction(context.Background(), "", signer, core.NetworkActionTerminate, location)
+	err := e.SubmitNetworkAction(context.Background(), "", signer, core.NetworkActionTerminate, `{"effectiveBlock":100}`, location)
 	assert.NoError(t, err)
 }
 
@@ -3296,7 +3297,7 @@ func TestSubmitNetworkActionBadLocation(t *testing.T) {
 		"bad": "location",
 	}.String())
 
-	err := e.SubmitNetworkAction(context.Background(), "", signer, core.NetworkActionTerminate, location)
+	err := e.SubmitNetworkAction(context.Background(), "", signer, core.NetworkActionTerminate, "", location)
 	assert.Regexp(t, "FF10310", err)
 }
 
@@ -3316,7 +3317,7 @@ func TestSubmitNetworkActionVersionError(t *testing.T) {
 		"chaincode": "simplestorage",
 	}.String())
 
-	err := e.SubmitNetworkAction(context.Background(), "", signer, core.NetworkActionTerminate, location)
+	err := e.SubmitNetworkAction(context.Background(), "", signer, core.NetworkActionTerminate, "", location)
 	assert.Regexp(t, "FF10284", err)
 }
 
@@ -3382,6 +3383,45 @@ func TestGetContractListenerStatusError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestListenerReconciliationNotSupported(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	_, err := e.GetContractListenerCheckpoint(context.Background(), "ns1", "sub1")
+	assert.Regexp(t, "FF10429", err)
+	err = e.ResetContractListener(context.Background(), "ns1", "sub1", 100)
+	assert.Regexp(t, "FF10429", err)
+	_, err = e.GetProtocolIDBlock(context.Background(), "000000000100/000000")
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestParseBatchPinEvent(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	event := &core.BlockchainEvent{
+		Name:       "BatchPin",
+		ProtocolID: "000000000064/000000/000000",
+		Output: fftypes.JSONObject{
+			"signer":     "u0vgwu9s00-x509::CN=user2,OU=client::CN=fabric-ca-server",
+			"namespace":  "ns1",
+			"uuids":      "0xe19af8b390604051812d7597d19adfb9847d3bfd074249efb65d3fed15f5b0a6",
+			"batchHash":  "0xd71eb138d74c229a388eb0e1abc03f4c7cbb21d4fc4b839fbf0ec73e4263f6be",
+			"payloadRef": "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD",
+		},
+	}
+
+	batch, signer, err := e.ParseBatchPinEvent(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, "847d3bfd-0742-49ef-b65d-3fed15f5b0a6", batch.BatchID.String())
+	assert.Equal(t, core.VerifierTypeMSPIdentity, signer.Type)
+	assert.Equal(t, "u0vgwu9s00-x509::CN=user2,OU=client::CN=fabric-ca-server", signer.Value)
+
+	event.Output["uuids"] = "bad"
+	_, _, err = e.ParseBatchPinEvent(context.Background(), event)
+	assert.Regexp(t, "FF10418", err)
+}
+
 func TestGetTransactionStatus(t *testing.T) {
 	e, cancel := newTestFabric()
 	defer cancel()
@@ -3625,3 +3665,31 @@ func TestCheckOverLappingLocationsSameChannelDifferentChaincode(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, result)
 }
+
+func TestCheckHealth(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	e.streams = newTestStreamManager(e.client, "signer")
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/eventstreams",
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{}))
+
+	err := e.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestCheckHealthFail(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	e.streams = newTestStreamManager(e.client, "signer")
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/eventstreams",
+		httpmock.NewStringResponder(500, "pop"))
+
+	err := e.CheckHealth(context.Background())
+	assert.Regexp(t, "FF10284", err)
+}
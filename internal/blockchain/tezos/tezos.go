@@ -37,6 +37,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 )
@@ -144,6 +145,12 @@ func (t *Tezos) VerifierType() core.VerifierType {
 	return core.VerifierTypeTezosAddress
 }
 
+// CheckHealth confirms the tezos connector is reachable, by listing its event streams
+func (t *Tezos) CheckHealth(ctx context.Context) error {
+	_, err := t.streams.getEventStreams(ctx)
+	return err
+}
+
 func (t *Tezos) Init(ctx context.Context, cancelCtx context.CancelFunc, conf config.Section, metrics metrics.Manager, cacheManager cache.Manager) (err error) {
 	t.InitConfig(conf)
 	tezosconnectConf := t.tezosconnectConf
@@ -176,6 +183,7 @@ func (t *Tezos) Init(ctx context.Context, cancelCtx context.CancelFunc, conf con
 	if err != nil {
 		return err
 	}
+	tracing.InstrumentClient(t.client, "tezos")
 
 	t.pluginTopic = tezosconnectConf.GetString(TezosconnectConfigTopic)
 	if t.pluginTopic == "" {
@@ -262,6 +270,10 @@ func (t *Tezos) Capabilities() *blockchain.Capabilities {
 	return t.capabilities
 }
 
+func (t *Tezos) OperationOutputSchemas() map[core.OpType]*fftypes.JSONAny {
+	return common.ReceiptOutputSchemas()
+}
+
 func (t *Tezos) AddFireflySubscription(ctx context.Context,
 	namespace *core.Namespace,
 	contract *blockchain.MultipartyContract,
@@ -314,7 +326,7 @@ func (t *Tezos) SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, si
 	return nil
 }
 
-func (t *Tezos) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action core.NetworkActionType, location *fftypes.JSONAny) error {
+func (t *Tezos) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action core.NetworkActionType, payload string, location *fftypes.JSONAny) error {
 	// TODO: impl
 	return nil
 }
@@ -507,6 +519,22 @@ func (t *Tezos) GetContractListenerStatus(ctx context.Context, namespace, subID
 	return true, checkpoint, status, nil
 }
 
+func (t *Tezos) GetContractListenerCheckpoint(ctx context.Context, namespace, subID string) (*blockchain.ListenerCheckpoint, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) ResetContractListener(ctx context.Context, namespace, subID string, fromBlock int64) error {
+	return i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) GetProtocolIDBlock(ctx context.Context, protocolID string) (int64, error) {
+	return -1, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) ParseBatchPinEvent(ctx context.Context, event *core.BlockchainEvent) (*blockchain.BatchPin, *core.VerifierRef, error) {
+	return nil, nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
 func (t *Tezos) GetFFIParamValidator(ctx context.Context) (fftypes.FFIParamValidator, error) {
 	// Tezosconnect does not require any additional validation beyond "JSON Schema correctness" at this time
 	return nil, nil
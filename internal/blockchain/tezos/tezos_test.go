@@ -221,6 +221,7 @@ func TestInitAndStartWithTezosConnect(t *testing.T) {
 	assert.Equal(t, 2, httpmock.GetTotalCallCount())
 	assert.Equal(t, "es12345", tz.streamID)
 	assert.NotNil(t, tz.Capabilities())
+	assert.Len(t, tz.OperationOutputSchemas(), 5)
 
 	err = tz.Start()
 	assert.NoError(t, err)
@@ -1947,6 +1948,26 @@ func TestGetContractListenerStatusGetSubNotFound(t *testing.T) {
 	assert.False(t, found)
 }
 
+func TestListenerReconciliationNotSupported(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	_, err := tz.GetContractListenerCheckpoint(context.Background(), "ns1", "sub1")
+	assert.Regexp(t, "FF10429", err)
+	err = tz.ResetContractListener(context.Background(), "ns1", "sub1", 100)
+	assert.Regexp(t, "FF10429", err)
+	_, err = tz.GetProtocolIDBlock(context.Background(), "000000000100/000000")
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestParseBatchPinEventNotSupported(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	_, _, err := tz.ParseBatchPinEvent(context.Background(), &core.BlockchainEvent{})
+	assert.Regexp(t, "FF10429", err)
+}
+
 func TestGetTransactionStatusSuccess(t *testing.T) {
 	tz, cancel := newTestTezos()
 	defer cancel()
@@ -2091,7 +2112,7 @@ func TestSubmitNetworkAction(t *testing.T) {
 	}.String())
 	singer := "tz1Y6GnVhC4EpcDDSmD3ibcC4WX6DJ4Q1QLN"
 
-	err := tz.SubmitNetworkAction(context.Background(), "", singer, core.NetworkActionTerminate, location)
+	err := tz.SubmitNetworkAction(context.Background(), "", singer, core.NetworkActionTerminate, "", location)
 	assert.NoError(t, err)
 }
 
@@ -2197,3 +2218,31 @@ func TestCheckOverlappingLocationsSameLocation(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, result)
 }
+
+func TestCheckHealth(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	tz.streams = newTestStreamManager(tz.client)
+	httpmock.ActivateNonDefault(tz.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/eventstreams",
+		httpmock.NewJsonResponderOrPanic(200, []eventStream{}))
+
+	err := tz.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestCheckHealthFail(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	tz.streams = newTestStreamManager(tz.client)
+	httpmock.ActivateNonDefault(tz.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/eventstreams",
+		httpmock.NewStringResponder(500, "pop"))
+
+	err := tz.CheckHealth(context.Background())
+	assert.Regexp(t, "FF10283", err)
+}
@@ -146,6 +146,7 @@ func (bm *broadcastManager) dispatchBatch(ctx context.Context, payload *batch.Di
 		return err
 	}
 	batch := payload.Batch.GenInflight(payload.Messages, payload.Data)
+	batch.Payload.Signature = payload.Signature
 
 	// We are in an (indefinite) retry cycle from the batch processor to dispatch this batch, that is only
 	// terminated with shutdown. So we leave the operation pending on failure, as it is still being retried.
@@ -0,0 +1,206 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+)
+
+// adaptiveSizer resizes the caches within bounds relative to their configured limits, to keep the heap
+// below a memory limit. When the heap is above the high watermark the cold caches are shrunk, and the
+// hot caches are only shrunk once every cold cache is at its smallest. When the heap is below the low
+// watermark the hot caches that are missing lookups are grown. A cache is hot if it is a priority cache,
+// or if it has had at least the median number of lookups since the last check.
+type adaptiveSizer struct {
+	ctx           context.Context
+	cm            *cacheManager
+	interval      time.Duration
+	memoryLimit   int64
+	highWatermark float64
+	lowWatermark  float64
+	minRatio      float64
+	maxRatio      float64
+	priority      map[string]bool
+	readHeap      func() uint64
+	last          map[*managedCache]cacheActivity
+	deltas        map[*managedCache]cacheActivity
+}
+
+type cacheActivity struct {
+	lookups int64
+	misses  int64
+}
+
+func newAdaptiveSizer(ctx context.Context, cm *cacheManager) *adaptiveSizer {
+	memoryLimit := config.GetByteSize(coreconfig.CacheAdaptiveMemoryLimit)
+	if memoryLimit <= 0 {
+		// A negative input only reads the limit set with GOMEMLIMIT, which is MaxInt64 if none is set
+		if runtimeLimit := debug.SetMemoryLimit(-1); runtimeLimit < math.MaxInt64 {
+			memoryLimit = runtimeLimit
+		}
+	}
+	if memoryLimit <= 0 {
+		log.L(ctx).Warnf("Adaptive cache sizing disabled, as there is no memory limit configured or set with GOMEMLIMIT")
+		return nil
+	}
+	as := &adaptiveSizer{
+		ctx:           ctx,
+		cm:            cm,
+		interval:      config.GetDuration(coreconfig.CacheAdaptiveInterval),
+		memoryLimit:   memoryLimit,
+		highWatermark: config.GetFloat64(coreconfig.CacheAdaptiveHighWatermark),
+		lowWatermark:  config.GetFloat64(coreconfig.CacheAdaptiveLowWatermark),
+		minRatio:      config.GetFloat64(coreconfig.CacheAdaptiveMinRatio),
+		maxRatio:      config.GetFloat64(coreconfig.CacheAdaptiveMaxRatio),
+		priority:      make(map[string]bool),
+		readHeap:      readHeap,
+		last:          make(map[*managedCache]cacheActivity),
+	}
+	for _, name := range config.GetStringSlice(coreconfig.CacheAdaptivePriority) {
+		as.priority[name] = true
+	}
+	return as
+}
+
+func readHeap() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
+func (as *adaptiveSizer) run() {
+	ticker := time.NewTicker(as.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-as.ctx.Done():
+			log.L(as.ctx).Debugf("Adaptive cache sizing stopped")
+			return
+		case <-ticker.C:
+			as.adjust()
+		}
+	}
+}
+
+func (as *adaptiveSizer) adjust() {
+	heap := as.readHeap()
+	usage := float64(heap) / float64(as.memoryLimit)
+	hot, cold := as.classify()
+	switch {
+	case usage >= as.highWatermark:
+		log.L(as.ctx).Debugf("Heap %d is %.2f of the memory limit - shrinking caches", heap, usage)
+		if !as.shrink(cold) {
+			as.shrink(hot)
+		}
+	case usage < as.lowWatermark:
+		for _, mc := range hot {
+			if as.deltas[mc].misses > 0 {
+				as.grow(mc)
+			}
+		}
+	}
+}
+
+// classify splits the caches into hot and cold, by their lookups since the last check
+func (as *adaptiveSizer) classify() (hot, cold []*managedCache) {
+	as.cm.managedMux.Lock()
+	caches := make([]*managedCache, 0, len(as.cm.managedCaches))
+	for _, mc := range as.cm.managedCaches {
+		caches = append(caches, mc)
+	}
+	as.cm.managedMux.Unlock()
+	sort.Slice(caches, func(i, j int) bool { return caches[i].name < caches[j].name })
+
+	current := make(map[*managedCache]cacheActivity, len(caches))
+	deltas := make(map[*managedCache]cacheActivity, len(caches))
+	lookups := make([]int64, 0, len(caches))
+	for _, mc := range caches {
+		now := cacheActivity{lookups: mc.hits.Load() + mc.misses.Load(), misses: mc.misses.Load()}
+		delta := now
+		if prev, ok := as.last[mc]; ok && prev.lookups <= now.lookups && prev.misses <= now.misses {
+			// The counts restart from zero when the cache is invalidated
+			delta = cacheActivity{lookups: now.lookups - prev.lookups, misses: now.misses - prev.misses}
+		}
+		current[mc] = now
+		deltas[mc] = delta
+		lookups = append(lookups, delta.lookups)
+	}
+	as.last = current
+	as.deltas = deltas
+
+	sort.Slice(lookups, func(i, j int) bool { return lookups[i] < lookups[j] })
+	var median int64
+	if len(lookups) > 0 {
+		median = lookups[len(lookups)/2]
+	}
+	for _, mc := range caches {
+		if as.priority[mc.name] || (deltas[mc].lookups > 0 && deltas[mc].lookups >= median) {
+			hot = append(hot, mc)
+		} else {
+			cold = append(cold, mc)
+		}
+	}
+	return hot, cold
+}
+
+// shrink halves each of the caches down to its smallest, returning false if all were already at their smallest
+func (as *adaptiveSizer) shrink(caches []*managedCache) bool {
+	shrunk := false
+	for _, mc := range caches {
+		size := mc.size()
+		newSize := size / 2
+		if minSize := as.bound(mc, as.minRatio); newSize < minSize {
+			newSize = minSize
+		}
+		if newSize < size {
+			log.L(as.ctx).Infof("Shrinking cache %s from %d to %d", fullCacheName(mc.namespace, mc.name), size, newSize)
+			mc.resize(newSize)
+			shrunk = true
+		}
+	}
+	return shrunk
+}
+
+// grow increases the size of the cache by half, up to its largest
+func (as *adaptiveSizer) grow(mc *managedCache) {
+	size := mc.size()
+	newSize := size + size/2 + 1
+	if maxSize := as.bound(mc, as.maxRatio); newSize > maxSize {
+		newSize = maxSize
+	}
+	if newSize > size {
+		log.L(as.ctx).Infof("Growing cache %s from %d to %d", fullCacheName(mc.namespace, mc.name), size, newSize)
+		mc.resize(newSize)
+	}
+}
+
+func (as *adaptiveSizer) bound(mc *managedCache, ratio float64) int64 {
+	bound := int64(float64(mc.configured) * ratio)
+	if bound < 1 {
+		return 1
+	}
+	return bound
+}
@@ -0,0 +1,178 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAdaptiveSizer(t *testing.T, heap uint64) (*cacheManager, *adaptiveSizer, context.CancelFunc) {
+	coreconfig.Reset()
+	config.Set(coreconfig.CacheAdaptiveMemoryLimit, "1000")
+	config.Set(coreconfig.CacheAdaptivePriority, []string{"cache.message"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cm, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	as := newAdaptiveSizer(ctx, cm.(*cacheManager))
+	as.readHeap = func() uint64 { return heap }
+	return cm.(*cacheManager), as, cancel
+}
+
+func getTestCaches(t *testing.T, cm *cacheManager) (message, batch, group CInterface) {
+	ctx := context.Background()
+	var err error
+	message, err = cm.GetCache(NewCacheConfig(ctx, "cache.message.size", "cache.message.ttl", "ns1"))
+	assert.NoError(t, err)
+	batch, err = cm.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "ns1"))
+	assert.NoError(t, err)
+	group, err = cm.GetCache(NewCacheConfig(ctx, "cache.group.limit", "cache.group.ttl", "ns1"))
+	assert.NoError(t, err)
+	return message, batch, group
+}
+
+func getTestCacheSizes(cm *cacheManager) map[string]int64 {
+	sizes := make(map[string]int64)
+	for _, status := range cm.GetCacheStatus() {
+		sizes[status.Name] = status.MaxSize
+	}
+	return sizes
+}
+
+func TestAdaptiveShrinksColdCachesFirst(t *testing.T) {
+	cm, as, cancel := newTestAdaptiveSizer(t, 900)
+	defer cancel()
+	_, batch, _ := getTestCaches(t, cm)
+	batch.Get("key1")
+	batch.Get("key2")
+
+	as.adjust()
+	sizes := getTestCacheSizes(cm)
+	assert.Equal(t, int64(100), sizes["ns1:cache.batch"])
+	assert.Equal(t, int64(25), sizes["ns1:cache.group"])
+	assert.Equal(t, config.GetByteSize(coreconfig.CacheMessageSize), sizes["ns1:cache.message"])
+
+	// Without lookups since the last check the batch cache is now cold too
+	as.adjust()
+	sizes = getTestCacheSizes(cm)
+	assert.Equal(t, int64(50), sizes["ns1:cache.batch"])
+	assert.Equal(t, int64(12), sizes["ns1:cache.group"])
+
+	as.adjust()
+	sizes = getTestCacheSizes(cm)
+	assert.Equal(t, int64(25), sizes["ns1:cache.batch"])
+	assert.Equal(t, config.GetByteSize(coreconfig.CacheMessageSize), sizes["ns1:cache.message"])
+
+	// Every cold cache is at its smallest, so the priority cache is shrunk
+	as.adjust()
+	sizes = getTestCacheSizes(cm)
+	assert.Equal(t, int64(25), sizes["ns1:cache.batch"])
+	assert.Equal(t, config.GetByteSize(coreconfig.CacheMessageSize)/2, sizes["ns1:cache.message"])
+}
+
+func TestAdaptiveGrowsHotCaches(t *testing.T) {
+	cm, as, cancel := newTestAdaptiveSizer(t, 100)
+	defer cancel()
+	message, batch, _ := getTestCaches(t, cm)
+	batch.Set("key1", "val1")
+	batch.Get("key1")
+	batch.Get("key2")
+	message.Get("key1")
+
+	as.adjust()
+	sizes := getTestCacheSizes(cm)
+	assert.Equal(t, int64(151), sizes["ns1:cache.batch"])
+	assert.Equal(t, int64(50), sizes["ns1:cache.group"])
+	assert.Greater(t, sizes["ns1:cache.message"], config.GetByteSize(coreconfig.CacheMessageSize))
+
+	// Entries are still found after growing, and are copied into the new cache
+	assert.Equal(t, "val1", batch.Get("key1"))
+	batch.Delete("key1")
+	assert.Nil(t, batch.Get("key1"))
+
+	// Growing stops at the largest size
+	for i := 0; i < 10; i++ {
+		batch.Get("key2")
+		as.adjust()
+	}
+	sizes = getTestCacheSizes(cm)
+	assert.Equal(t, int64(400), sizes["ns1:cache.batch"])
+}
+
+func TestAdaptiveNoChangeBetweenWatermarks(t *testing.T) {
+	cm, as, cancel := newTestAdaptiveSizer(t, 700)
+	defer cancel()
+	_, batch, _ := getTestCaches(t, cm)
+	batch.Get("key1")
+
+	as.adjust()
+	statuses := cm.GetCacheStatus()
+	for _, status := range statuses {
+		assert.Equal(t, status.ConfiguredSize, status.MaxSize)
+		assert.Nil(t, status.Resized)
+	}
+}
+
+func TestAdaptiveCountsRestartOnInvalidate(t *testing.T) {
+	cm, as, cancel := newTestAdaptiveSizer(t, 100)
+	defer cancel()
+	_, batch, _ := getTestCaches(t, cm)
+	batch.Get("key1")
+	batch.Get("key2")
+	as.classify()
+
+	err := cm.InvalidateCache(context.Background(), "ns1:cache.batch")
+	assert.NoError(t, err)
+	batch.Get("key1")
+	as.classify()
+	for mc, delta := range as.deltas {
+		if mc.name == "cache.batch" {
+			assert.Equal(t, int64(1), delta.lookups)
+		}
+	}
+}
+
+func TestAdaptiveNoMemoryLimit(t *testing.T) {
+	coreconfig.Reset()
+	as := newAdaptiveSizer(context.Background(), &cacheManager{})
+	assert.Nil(t, as)
+}
+
+func TestAdaptiveRun(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.CacheAdaptiveEnabled, true)
+	config.Set(coreconfig.CacheAdaptiveMemoryLimit, "1Gb")
+	config.Set(coreconfig.CacheAdaptiveInterval, "1ms")
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+
+	as := newAdaptiveSizer(ctx, &cacheManager{managedCaches: map[string]*managedCache{}})
+	done := make(chan struct{})
+	go func() {
+		as.run()
+		close(done)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+	<-done
+}
@@ -19,6 +19,7 @@ package cache
 import (
 	"context"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/cache"
@@ -27,6 +28,8 @@ import (
 
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/pkg/core"
 )
 
 type CConfig struct {
@@ -101,16 +104,30 @@ type Manager interface {
 	GetCache(cc *CConfig) (CInterface, error)
 	ResetCachesForNamespace(ns string)
 	ListCacheNames(namespace string) []string
+	GetCacheStatus() []*core.CacheStatus
+	InvalidateCache(ctx context.Context, fqName string) error
+	InvalidateCacheKey(ctx context.Context, fqName, key string) error
 }
 
 type CInterface cache.CInterface
 
 type cacheManager struct {
-	ffcache cache.Manager
+	ffcache       cache.Manager
+	metrics       metrics.Manager
+	distributor   *distributor
+	managedMux    sync.Mutex
+	managedCaches map[string]*managedCache
 }
 
 func (cm *cacheManager) ResetCachesForNamespace(ns string) {
 	cm.ffcache.ResetCaches(ns)
+	cm.managedMux.Lock()
+	defer cm.managedMux.Unlock()
+	for fqName, mc := range cm.managedCaches {
+		if mc.namespace == ns {
+			delete(cm.managedCaches, fqName)
+		}
+	}
 }
 func (cm *cacheManager) ListCacheNames(namespace string) []string {
 	return cm.ffcache.ListCacheNames(namespace)
@@ -126,7 +143,7 @@ func (cm *cacheManager) GetCache(cc *CConfig) (CInterface, error) {
 		return nil, err
 	}
 
-	return cm.ffcache.GetCache(
+	c, err := cm.ffcache.GetCache(
 		cc.ctx,
 		cc.namespace,
 		cacheName,
@@ -134,12 +151,78 @@ func (cm *cacheManager) GetCache(cc *CConfig) (CInterface, error) {
 		cc.TTL(),
 		cm.ffcache.IsEnabled(),
 	)
+	if err != nil || !c.IsEnabled() {
+		return c, err
+	}
+	c = cm.managed(cc.ctx, cc.namespace, cacheName, maxSize, cc.TTL(), c)
+	if cm.distributor != nil {
+		c = cm.distributor.wrap(cc.namespace, cacheName, c)
+	}
+	if !cm.metrics.IsMetricsEnabled() {
+		return c, nil
+	}
+	return &meteredCache{
+		CInterface: c,
+		namespace:  cc.namespace,
+		name:       cacheName,
+		metrics:    cm.metrics,
+	}, nil
 }
-func NewCacheManager(ctx context.Context) Manager {
+
+func NewCacheManager(ctx context.Context, mm metrics.Manager) (Manager, error) {
 	cm := &cacheManager{
-		ffcache: cache.NewCacheManager(ctx, config.GetBool(coreconfig.CacheEnabled)),
+		ffcache:       cache.NewCacheManager(ctx, config.GetBool(coreconfig.CacheEnabled)),
+		metrics:       mm,
+		managedCaches: make(map[string]*managedCache),
+	}
+	if config.GetBool(coreconfig.CacheEnabled) && config.GetBool(coreconfig.CacheDistributedEnabled) {
+		backend, err := newBackend(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cm.distributor = newDistributor(ctx, backend)
+	}
+	if config.GetBool(coreconfig.CacheEnabled) && config.GetBool(coreconfig.CacheAdaptiveEnabled) {
+		if as := newAdaptiveSizer(ctx, cm); as != nil {
+			go as.run()
+		}
+	}
+	return cm, nil
+}
+
+// meteredCache records every lookup as a hit or a miss, so the hit ratio of each cache can be monitored
+type meteredCache struct {
+	CInterface
+	namespace string
+	name      string
+	metrics   metrics.Manager
+}
+
+func (mc *meteredCache) Get(key string) interface{} {
+	val := mc.CInterface.Get(key)
+	mc.metrics.CacheLookup(mc.namespace, mc.name, val != nil)
+	return val
+}
+
+func (mc *meteredCache) GetString(key string) string {
+	if val := mc.Get(key); val != nil {
+		return val.(string)
+	}
+	return ""
+}
+
+func (mc *meteredCache) GetInt(key string) int {
+	if val := mc.Get(key); val != nil {
+		return val.(int)
+	}
+	return 0
+}
+
+func (mc *meteredCache) GetInt64(key string) int64 {
+	if val := mc.Get(key); val != nil {
+		return val.(int64)
 	}
-	return cm
+	return 0
 }
 
 // should only be used for testing purpose
@@ -23,13 +23,21 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/stretchr/testify/assert"
 )
 
+func newTestMetrics(enabled bool) *metricsmocks.Manager {
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(enabled).Maybe()
+	return mmi
+}
+
 func TestNewCacheCreationFail(t *testing.T) {
 	ctx := context.Background()
-	cacheManager := NewCacheManager(ctx)
-	_, err := cacheManager.GetCache(NewCacheConfig(ctx, "", "", ""))
+	cacheManager, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	_, err = cacheManager.GetCache(NewCacheConfig(ctx, "", "", ""))
 	assert.Equal(t, "FF10424: could not initialize cache - size limit config key is not provided", err.Error())
 	_, err = cacheManager.GetCache(NewCacheConfig(ctx, "test.limit", "", ""))
 	assert.Equal(t, "FF10425: could not initialize cache - ttl config key is not provided", err.Error())
@@ -41,7 +49,8 @@ func TestNewCacheCreationFail(t *testing.T) {
 
 func TestGetCacheReturnsSameCacheForSameConfig(t *testing.T) {
 	ctx := context.Background()
-	cacheManager := NewCacheManager(ctx)
+	cacheManager, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
 	cache0, _ := cacheManager.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "testnamespace"))
 	cache1, _ := cacheManager.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "testnamespace"))
 
@@ -56,7 +65,8 @@ func TestGetCacheReturnsSameCacheForSameConfig(t *testing.T) {
 
 func TestTwoSeparateCacheWorksIndependently(t *testing.T) {
 	ctx := context.Background()
-	cacheManager := NewCacheManager(ctx)
+	cacheManager, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
 	cache0, _ := cacheManager.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", ""))
 	cache1, _ := cacheManager.GetCache(NewCacheConfig(ctx, "cache.message.size", "cache.message.ttl", ""))
 
@@ -76,7 +86,8 @@ func TestTwoSeparateCacheWorksIndependently(t *testing.T) {
 func TestReturnsDummyCacheWhenCacheDisabled(t *testing.T) {
 	config.Set(coreconfig.CacheEnabled, false)
 	ctx := context.Background()
-	cacheManager := NewCacheManager(ctx)
+	cacheManager, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
 	cache0, _ := cacheManager.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", ""))
 	cache0.SetInt("int0", 100)
 	assert.Equal(t, nil, cache0.Get("int0"))
@@ -90,7 +101,8 @@ func TestUmmanagedCacheInstance(t *testing.T) {
 
 func TestResetCachesForNamespace(t *testing.T) {
 	ctx := context.Background()
-	cacheManager := NewCacheManager(ctx)
+	cacheManager, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
 	cacheNS1, _ := cacheManager.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "ns1"))
 	cacheNS1.Set("key1", "value1")
 
@@ -112,3 +124,30 @@ func TestResetCachesForNamespace(t *testing.T) {
 	assert.Nil(t, cacheNS1_b.Get("key1"))
 
 }
+
+func TestMeteredCacheRecordsLookups(t *testing.T) {
+	config.Set(coreconfig.CacheEnabled, true)
+	ctx := context.Background()
+	mmi := newTestMetrics(true)
+	mmi.On("CacheLookup", "ns1", "cache.batch", true).Return().Times(4)
+	mmi.On("CacheLookup", "ns1", "cache.batch", false).Return().Times(4)
+	cacheManager, err := NewCacheManager(ctx, mmi)
+	assert.NoError(t, err)
+	cache0, err = cacheManager.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "ns1"))
+	assert.NoError(t, err)
+
+	cache0.Set("key1", "value1")
+	cache0.SetString("string1", "val1")
+	cache0.SetInt("int1", 1)
+	cache0.SetInt64("int64", 2)
+	assert.Equal(t, "value1", cache0.Get("key1"))
+	assert.Equal(t, "val1", cache0.GetString("string1"))
+	assert.Equal(t, 1, cache0.GetInt("int1"))
+	assert.Equal(t, int64(2), cache0.GetInt64("int64"))
+	assert.Nil(t, cache0.Get("missing"))
+	assert.Equal(t, "", cache0.GetString("missing"))
+	assert.Equal(t, 0, cache0.GetInt("missing"))
+	assert.Equal(t, int64(0), cache0.GetInt64("missing"))
+
+	mmi.AssertExpectations(t)
+}
@@ -0,0 +1,192 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+const (
+	// BackendTypeRedis shares cache invalidations over a Redis pub/sub channel
+	BackendTypeRedis = "redis"
+)
+
+// Invalidation tells the other FireFly instances that an entry in one of their caches is out of date
+type Invalidation struct {
+	Origin    string `json:"origin"`
+	Namespace string `json:"namespace"`
+	Cache     string `json:"cache"`
+	Key       string `json:"key"` // empty if every entry of the cache is invalid
+}
+
+// Backend shares cache invalidations between the FireFly instances that use the same database.
+//
+// The cached values themselves stay in the memory of each instance, as they are Go objects. Instead every
+// entry that is deleted, or changed in the database (see Changed), is published, and the other instances
+// evict that entry from their own cache - so the next lookup reads the latest value from the database.
+// Filling a cache from the database is not published, as the other instances are not out of date.
+type Backend interface {
+	Publish(ctx context.Context, inv *Invalidation) error
+	// Subscribe calls the handler for every invalidation published, until the context is cancelled
+	Subscribe(ctx context.Context, handler func(inv *Invalidation))
+	Close() error
+}
+
+func newBackend(ctx context.Context) (Backend, error) {
+	backendType := config.GetString(coreconfig.CacheDistributedType)
+	switch backendType {
+	case BackendTypeRedis:
+		return newRedisBackend(ctx)
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedCacheBackend, backendType)
+	}
+}
+
+// distributor publishes the changes made to the local caches, and applies the changes made by other instances
+type distributor struct {
+	ctx       context.Context
+	origin    string
+	backend   Backend
+	queue     chan *Invalidation
+	cachesMux sync.Mutex
+	caches    map[string]CInterface
+	done      chan struct{}
+}
+
+func newDistributor(ctx context.Context, backend Backend) *distributor {
+	d := &distributor{
+		ctx:     ctx,
+		origin:  fftypes.NewUUID().String(),
+		backend: backend,
+		queue:   make(chan *Invalidation, 1000),
+		caches:  make(map[string]CInterface),
+		done:    make(chan struct{}),
+	}
+	backend.Subscribe(ctx, d.invalidate)
+	go d.publishLoop()
+	return d
+}
+
+func cacheKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// wrap returns a cache that publishes an invalidation for every entry that is deleted
+func (d *distributor) wrap(namespace, name string, c CInterface) CInterface {
+	d.cachesMux.Lock()
+	d.caches[cacheKey(namespace, name)] = c
+	d.cachesMux.Unlock()
+	return &distributedCache{
+		CInterface:  c,
+		namespace:   namespace,
+		name:        name,
+		distributor: d,
+	}
+}
+
+// publishLoop publishes in the background, so changing a cache entry does not wait for the backend
+func (d *distributor) publishLoop() {
+	defer close(d.done)
+	for {
+		select {
+		case inv := <-d.queue:
+			if err := d.backend.Publish(d.ctx, inv); err != nil {
+				log.L(d.ctx).Warnf("Failed to publish invalidation of cache %s key=%s: %s", inv.Cache, inv.Key, err)
+			}
+		case <-d.ctx.Done():
+			if err := d.backend.Close(); err != nil {
+				log.L(d.ctx).Warnf("Failed to close distributed cache backend: %s", err)
+			}
+			return
+		}
+	}
+}
+
+// publish queues an invalidation without blocking. If the backend has fallen so far behind that the queue
+// is full, the invalidation is dropped, and the entry expires from the other instances by its TTL.
+func (d *distributor) publish(namespace, name, key string) {
+	select {
+	case d.queue <- &Invalidation{Origin: d.origin, Namespace: namespace, Cache: name, Key: key}:
+	default:
+		log.L(d.ctx).Warnf("Distributed cache queue is full, dropped invalidation of cache %s/%s key=%s", namespace, name, key)
+	}
+}
+
+func (d *distributor) invalidate(inv *Invalidation) {
+	if inv.Origin == d.origin {
+		return
+	}
+	d.cachesMux.Lock()
+	c := d.caches[cacheKey(inv.Namespace, inv.Cache)]
+	d.cachesMux.Unlock()
+	if c == nil {
+		return
+	}
+	log.L(d.ctx).Tracef("Invalidating cache %s/%s key=%s from instance %s", inv.Namespace, inv.Cache, inv.Key, inv.Origin)
+	if inv.Key == "" {
+		// The whole cache was invalidated
+		if mc, ok := c.(*managedCache); ok {
+			mc.Clear()
+		}
+		return
+	}
+	c.Delete(inv.Key)
+}
+
+type distributedCache struct {
+	CInterface
+	namespace   string
+	name        string
+	distributor *distributor
+}
+
+func (dc *distributedCache) Delete(key string) bool {
+	deleted := dc.CInterface.Delete(key)
+	dc.distributor.publish(dc.namespace, dc.name, key)
+	return deleted
+}
+
+// Changed tells the other FireFly instances sharing the database that the entry for a key has been changed in
+// the database, so they evict it from their own cache. If the context holds a database transaction, this
+// happens once the transaction has committed, so they cannot read back the previous value. It has no effect
+// if the cache is not distributed.
+func Changed(ctx context.Context, c CInterface, key string) {
+	if mc, ok := c.(*meteredCache); ok {
+		c = mc.CInterface
+	}
+	dc, ok := c.(*distributedCache)
+	if !ok {
+		return
+	}
+	publish := func() {
+		dc.distributor.publish(dc.namespace, dc.name, key)
+	}
+	if tx := dbsql.GetTXFromContext(ctx); tx != nil {
+		tx.AddPostCommitHook(publish)
+		return
+	}
+	publish()
+}
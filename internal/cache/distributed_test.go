@@ -0,0 +1,188 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBus delivers every invalidation published by any instance to the subscribers of all instances
+type testBus struct {
+	mux       sync.Mutex
+	handlers  []func(inv *Invalidation)
+	published chan *Invalidation
+	fail      bool
+}
+
+type testBackend struct {
+	bus    *testBus
+	closed bool
+}
+
+func newTestBus() *testBus {
+	return &testBus{published: make(chan *Invalidation, 10)}
+}
+
+func (tb *testBackend) Publish(ctx context.Context, inv *Invalidation) error {
+	tb.bus.mux.Lock()
+	handlers := tb.bus.handlers
+	fail := tb.bus.fail
+	tb.bus.mux.Unlock()
+	if fail {
+		return fmt.Errorf("pop")
+	}
+	for _, h := range handlers {
+		h(inv)
+	}
+	tb.bus.published <- inv
+	return nil
+}
+
+func (tb *testBackend) Subscribe(ctx context.Context, handler func(inv *Invalidation)) {
+	tb.bus.mux.Lock()
+	defer tb.bus.mux.Unlock()
+	tb.bus.handlers = append(tb.bus.handlers, handler)
+}
+
+func (tb *testBackend) Close() error {
+	tb.closed = true
+	return fmt.Errorf("pop")
+}
+
+func newTestDistributedCache(ctx context.Context, t *testing.T, bus *testBus, ns string) CInterface {
+	cm, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	cm.(*cacheManager).distributor = newDistributor(ctx, &testBackend{bus: bus})
+	c, err := cm.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", ns))
+	assert.NoError(t, err)
+	return c
+}
+
+func TestDistributedCacheInvalidatesOtherInstances(t *testing.T) {
+	coreconfig.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := newTestBus()
+	instance1 := newTestDistributedCache(ctx, t, bus, "ns1")
+	instance2 := newTestDistributedCache(ctx, t, bus, "ns1")
+	otherNS := newTestDistributedCache(ctx, t, bus, "ns2")
+
+	// Filling the caches is not published
+	instance2.Set("key1", "stale")
+	otherNS.Set("key1", "other")
+	instance1.Set("key1", "latest")
+	instance2.SetString("key2", "val2")
+	instance2.SetInt("key3", 3)
+	instance2.SetInt64("key4", 4)
+	assert.Equal(t, "stale", instance2.Get("key1"))
+
+	Changed(ctx, instance1, "key1")
+	inv := <-bus.published
+	assert.Equal(t, "ns1", inv.Namespace)
+	assert.Equal(t, "cache.batch", inv.Cache)
+	assert.Equal(t, "key1", inv.Key)
+	assert.Equal(t, "latest", instance1.Get("key1"))
+	assert.Nil(t, instance2.Get("key1"))
+	assert.Equal(t, "other", otherNS.Get("key1"))
+	assert.Equal(t, "val2", instance2.GetString("key2"))
+
+	assert.False(t, instance1.Delete("key2"))
+	<-bus.published
+	assert.Equal(t, "", instance2.GetString("key2"))
+	assert.Equal(t, 3, instance2.GetInt("key3"))
+	assert.Equal(t, int64(4), instance2.GetInt64("key4"))
+	assert.Empty(t, bus.published)
+}
+
+func TestDistributedCacheChangedAfterCommit(t *testing.T) {
+	d := &distributor{ctx: context.Background(), queue: make(chan *Invalidation, 1), caches: map[string]CInterface{}}
+	c := d.wrap("ns1", "cache.batch", NewUmanagedCache(context.Background(), 100, 5*time.Minute))
+	mp, mdb := dbsql.NewMockProvider().UTInit()
+	mdb.ExpectBegin()
+	mdb.ExpectCommit()
+
+	err := mp.RunAsGroup(context.Background(), func(ctx context.Context) error {
+		Changed(ctx, c, "key1")
+		assert.Empty(t, d.queue)
+		return nil
+	})
+	assert.NoError(t, err)
+	inv := <-d.queue
+	assert.Equal(t, "key1", inv.Key)
+	assert.NoError(t, mdb.ExpectationsWereMet())
+}
+
+func TestDistributedCacheChangedNotDistributed(t *testing.T) {
+	Changed(context.Background(), NewUmanagedCache(context.Background(), 100, 5*time.Minute), "key1")
+}
+
+func TestDistributedCachePublishQueueFull(t *testing.T) {
+	d := &distributor{ctx: context.Background(), queue: make(chan *Invalidation)}
+	d.publish("ns1", "cache.batch", "key1")
+	assert.Empty(t, d.queue)
+}
+
+func TestDistributedCachePublishFail(t *testing.T) {
+	coreconfig.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	bus := newTestBus()
+	bus.fail = true
+	backend := &testBackend{bus: bus}
+	d := newDistributor(ctx, backend)
+
+	c := d.wrap("ns1", "cache.batch", NewUmanagedCache(ctx, 100, 5*time.Minute))
+	c.Set("key1", "val1")
+	assert.True(t, c.Delete("key1"))
+	assert.Nil(t, c.Get("key1"))
+
+	cancel()
+	<-d.done
+	assert.True(t, backend.closed)
+	d.publish("ns1", "cache.batch", "key2")
+}
+
+func TestDistributedCacheIgnoresUnknownCache(t *testing.T) {
+	d := &distributor{ctx: context.Background(), origin: "me", caches: map[string]CInterface{}}
+	d.invalidate(&Invalidation{Origin: "other", Namespace: "ns1", Cache: "unknown", Key: "key1"})
+}
+
+func TestNewCacheManagerDistributed(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.CacheDistributedEnabled, true)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cm, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	assert.NotNil(t, cm.(*cacheManager).distributor)
+}
+
+func TestNewCacheManagerBadBackend(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.CacheDistributedEnabled, true)
+	config.Set(coreconfig.CacheDistributedType, "wrong")
+	_, err := NewCacheManager(context.Background(), newTestMetrics(false))
+	assert.Regexp(t, "FF10552", err)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/hyperledger/firefly/internal/coreconfig"
+)
+
+// NegativeCache remembers lookups that found nothing, so that retrying the lookup of a record that does not
+// exist yet - such as the identity of a parked pin - does not go to the database every time.
+//
+// Entries expire with the TTL of the negative cache. Forget discards every entry at once, and must be called
+// whenever a record is stored that a remembered lookup might now find.
+type NegativeCache struct {
+	cache      CInterface
+	kind       string
+	generation atomic.Int64
+}
+
+// NewNegativeCache returns a negative cache for one kind of lookup in the namespace. Each kind can be forgotten
+// separately, although the kinds of a namespace share the same underlying cache.
+func NewNegativeCache(ctx context.Context, cm Manager, ns, kind string) (*NegativeCache, error) {
+	c, err := cm.GetCache(NewCacheConfig(ctx, coreconfig.CacheNegativeLimit, coreconfig.CacheNegativeTTL, ns))
+	if err != nil {
+		return nil, err
+	}
+	return &NegativeCache{cache: c, kind: kind}, nil
+}
+
+// IsMissing returns true if the lookup is remembered as having found nothing. Otherwise it returns the entry
+// to pass to SetMissing if the lookup finds nothing, which has no effect if Forget is called in between.
+func (nc *NegativeCache) IsMissing(key string) (missing bool, entry string) {
+	entry = fmt.Sprintf("%s:%d:%s", nc.kind, nc.generation.Load(), key)
+	return nc.cache.Get(entry) != nil, entry
+}
+
+func (nc *NegativeCache) SetMissing(entry string) {
+	nc.cache.Set(entry, true)
+}
+
+// Forget discards every entry, by moving to a new generation of entries
+func (nc *NegativeCache) Forget() {
+	nc.generation.Add(1)
+}
@@ -0,0 +1,80 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegativeCache(t *testing.T) {
+	coreconfig.Reset()
+	ctx := context.Background()
+	cm, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	identities, err := NewNegativeCache(ctx, cm, "ns1", "identity")
+	assert.NoError(t, err)
+	datatypes, err := NewNegativeCache(ctx, cm, "ns1", "datatype")
+	assert.NoError(t, err)
+
+	missing, entry := identities.IsMissing("key1")
+	assert.False(t, missing)
+	identities.SetMissing(entry)
+	missing, _ = identities.IsMissing("key1")
+	assert.True(t, missing)
+	missing, entry = datatypes.IsMissing("key1")
+	assert.False(t, missing)
+	datatypes.SetMissing(entry)
+
+	identities.Forget()
+	missing, _ = identities.IsMissing("key1")
+	assert.False(t, missing)
+	missing, _ = datatypes.IsMissing("key1")
+	assert.True(t, missing)
+}
+
+func TestNegativeCacheForgetDuringLookup(t *testing.T) {
+	coreconfig.Reset()
+	ctx := context.Background()
+	cm, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	nc, err := NewNegativeCache(ctx, cm, "ns1", "identity")
+	assert.NoError(t, err)
+
+	_, entry := nc.IsMissing("key1")
+	nc.Forget()
+	nc.SetMissing(entry)
+	missing, _ := nc.IsMissing("key1")
+	assert.False(t, missing)
+}
+
+type failingManager struct {
+	Manager
+}
+
+func (fm *failingManager) GetCache(cc *CConfig) (CInterface, error) {
+	return nil, fmt.Errorf("pop")
+}
+
+func TestNegativeCacheInitFail(t *testing.T) {
+	_, err := NewNegativeCache(context.Background(), &failingManager{}, "ns1", "identity")
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisBackend struct {
+	client  *redis.Client
+	channel string
+}
+
+func newRedisBackend(ctx context.Context) (Backend, error) {
+	opts, err := redis.ParseURL(config.GetString(coreconfig.CacheDistributedRedisURL))
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgCacheBackendInitFailed)
+	}
+	return &redisBackend{
+		client:  redis.NewClient(opts),
+		channel: config.GetString(coreconfig.CacheDistributedRedisChannel),
+	}, nil
+}
+
+func (rb *redisBackend) Publish(ctx context.Context, inv *Invalidation) error {
+	payload, _ := json.Marshal(inv)
+	return rb.client.Publish(ctx, rb.channel, payload).Err()
+}
+
+// Subscribe receives on a channel from the client, which reconnects automatically if the connection to
+// Redis is lost. Invalidations published while disconnected are missed, so the TTL of each cache remains
+// the bound on how stale an entry can be.
+func (rb *redisBackend) Subscribe(ctx context.Context, handler func(inv *Invalidation)) {
+	ps := rb.client.Subscribe(ctx, rb.channel)
+	go func() {
+		defer ps.Close()
+		messages := ps.Channel()
+		for {
+			select {
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var inv Invalidation
+				if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+					log.L(ctx).Warnf("Ignoring invalid cache invalidation on channel %s: %s", rb.channel, err)
+					continue
+				}
+				handler(&inv)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (rb *redisBackend) Close() error {
+	return rb.client.Close()
+}
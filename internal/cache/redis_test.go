@@ -0,0 +1,50 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRedisBackendBadURL(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.CacheDistributedRedisURL, "!badness")
+	_, err := newRedisBackend(context.Background())
+	assert.Regexp(t, "FF10553", err)
+}
+
+func TestRedisBackendUnavailable(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.CacheDistributedRedisURL, "redis://localhost:1")
+	ctx, cancel := context.WithCancel(context.Background())
+	rb, err := newRedisBackend(ctx)
+	assert.NoError(t, err)
+
+	rb.Subscribe(ctx, func(inv *Invalidation) {
+		assert.Fail(t, "unexpected invalidation")
+	})
+	err = rb.Publish(ctx, &Invalidation{Namespace: "ns1", Cache: "cache.batch", Key: "key1"})
+	assert.Error(t, err)
+
+	cancel()
+	assert.NoError(t, rb.Close())
+}
@@ -0,0 +1,296 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// managedCache counts how each cache is used, and allows all of its entries to be invalidated at runtime.
+// The cache it wraps does not expose its entries, so invalidating it swaps in a new empty cache.
+//
+// The cache it wraps cannot be resized either, so resizing also swaps in a new empty cache. The old one is
+// kept as the previous generation, and entries found there are copied into the new one as they are read. It
+// is discarded once the new cache has been filled to its size, so shrinking a cache keeps its hottest entries
+// and then releases the memory of the rest.
+type managedCache struct {
+	ctx         context.Context
+	namespace   string
+	name        string
+	configured  int64
+	ttl         time.Duration
+	created     *fftypes.FFTime
+	currentMux  sync.RWMutex
+	current     CInterface
+	previous    CInterface
+	filled      atomic.Int64
+	maxSize     int64
+	invalidated *fftypes.FFTime
+	resized     *fftypes.FFTime
+	hits        atomic.Int64
+	misses      atomic.Int64
+	sets        atomic.Int64
+	deletes     atomic.Int64
+}
+
+func fullCacheName(namespace, name string) string {
+	return fmt.Sprintf("%s:%s", namespace, name)
+}
+
+// managed returns the managed cache for the namespace and name, so every user of a cache shares its counts
+func (cm *cacheManager) managed(ctx context.Context, namespace, name string, maxSize int64, ttl time.Duration, c CInterface) *managedCache {
+	cm.managedMux.Lock()
+	defer cm.managedMux.Unlock()
+	fqName := fullCacheName(namespace, name)
+	mc := cm.managedCaches[fqName]
+	if mc == nil {
+		mc = &managedCache{
+			ctx:        ctx,
+			namespace:  namespace,
+			name:       name,
+			configured: maxSize,
+			maxSize:    maxSize,
+			ttl:        ttl,
+			created:    fftypes.Now(),
+			current:    c,
+		}
+		cm.managedCaches[fqName] = mc
+	}
+	return mc
+}
+
+func (cm *cacheManager) getManaged(ctx context.Context, fqName string) (*managedCache, error) {
+	cm.managedMux.Lock()
+	defer cm.managedMux.Unlock()
+	mc := cm.managedCaches[fqName]
+	if mc == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgCacheNotFound, fqName)
+	}
+	return mc, nil
+}
+
+func (cm *cacheManager) GetCacheStatus() []*core.CacheStatus {
+	cm.managedMux.Lock()
+	statuses := make([]*core.CacheStatus, 0, len(cm.managedCaches))
+	for fqName, mc := range cm.managedCaches {
+		statuses = append(statuses, mc.status(fqName))
+	}
+	cm.managedMux.Unlock()
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+func (cm *cacheManager) InvalidateCache(ctx context.Context, fqName string) error {
+	mc, err := cm.getManaged(ctx, fqName)
+	if err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Invalidating all entries of cache %s", fqName)
+	mc.Clear()
+	if cm.distributor != nil {
+		cm.distributor.publish(mc.namespace, mc.name, "")
+	}
+	return nil
+}
+
+func (cm *cacheManager) InvalidateCacheKey(ctx context.Context, fqName, key string) error {
+	mc, err := cm.getManaged(ctx, fqName)
+	if err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Invalidating cache %s key=%s", fqName, key)
+	mc.Delete(key)
+	if cm.distributor != nil {
+		cm.distributor.publish(mc.namespace, mc.name, key)
+	}
+	return nil
+}
+
+func (mc *managedCache) status(fqName string) *core.CacheStatus {
+	mc.currentMux.RLock()
+	maxSize, invalidated, resized := mc.maxSize, mc.invalidated, mc.resized
+	mc.currentMux.RUnlock()
+	status := &core.CacheStatus{
+		Name:           fqName,
+		Namespace:      mc.namespace,
+		MaxSize:        maxSize,
+		ConfiguredSize: mc.configured,
+		TTL:            fftypes.FFDuration(mc.ttl),
+		Hits:           mc.hits.Load(),
+		Misses:         mc.misses.Load(),
+		Sets:           mc.sets.Load(),
+		Deletes:        mc.deletes.Load(),
+		Created:        mc.created,
+		Invalidated:    invalidated,
+		Resized:        resized,
+	}
+	if lookups := status.Hits + status.Misses; lookups > 0 {
+		status.HitRatio = float64(status.Hits) / float64(lookups)
+	}
+	return status
+}
+
+func (mc *managedCache) cache() CInterface {
+	mc.currentMux.RLock()
+	defer mc.currentMux.RUnlock()
+	return mc.current
+}
+
+func (mc *managedCache) generations() (current, previous CInterface) {
+	mc.currentMux.RLock()
+	defer mc.currentMux.RUnlock()
+	return mc.current, mc.previous
+}
+
+func (mc *managedCache) size() int64 {
+	mc.currentMux.RLock()
+	defer mc.currentMux.RUnlock()
+	return mc.maxSize
+}
+
+// resize swaps in an empty cache of the new size, keeping the old one as the previous generation
+func (mc *managedCache) resize(maxSize int64) {
+	mc.currentMux.Lock()
+	defer mc.currentMux.Unlock()
+	if !mc.current.IsEnabled() || maxSize == mc.maxSize {
+		return
+	}
+	mc.previous = mc.current
+	mc.filled.Store(0)
+	mc.current = NewUmanagedCache(mc.ctx, maxSize, mc.ttl)
+	mc.maxSize = maxSize
+	mc.resized = fftypes.Now()
+}
+
+// sized is implemented by values that the wrapped cache counts by their size, rather than as one entry
+type sized interface {
+	Size() int64
+}
+
+// fill counts an entry added to the current cache while there is a previous generation. Once the current cache
+// is full, any entries left in the previous generation are colder than the ones it holds, so it is discarded.
+func (mc *managedCache) fill(val interface{}) {
+	size := int64(1)
+	if s, ok := val.(sized); ok {
+		size = s.Size()
+	}
+	if mc.filled.Add(size) < mc.size() {
+		return
+	}
+	mc.currentMux.Lock()
+	mc.previous = nil
+	mc.currentMux.Unlock()
+}
+
+// Clear discards every entry, and resets the counts
+func (mc *managedCache) Clear() {
+	mc.currentMux.Lock()
+	defer mc.currentMux.Unlock()
+	if mc.current.IsEnabled() {
+		mc.current = NewUmanagedCache(mc.ctx, mc.maxSize, mc.ttl)
+	}
+	mc.previous = nil
+	mc.invalidated = fftypes.Now()
+	mc.hits.Store(0)
+	mc.misses.Store(0)
+	mc.sets.Store(0)
+	mc.deletes.Store(0)
+}
+
+func (mc *managedCache) IsEnabled() bool {
+	return mc.cache().IsEnabled()
+}
+
+func (mc *managedCache) Delete(key string) bool {
+	mc.deletes.Add(1)
+	current, previous := mc.generations()
+	deleted := current.Delete(key)
+	if previous != nil && previous.Delete(key) {
+		deleted = true
+	}
+	return deleted
+}
+
+func (mc *managedCache) Get(key string) interface{} {
+	current, previous := mc.generations()
+	val := current.Get(key)
+	if val == nil && previous != nil {
+		if val = previous.Get(key); val != nil {
+			current.Set(key, val)
+			mc.fill(val)
+		}
+	}
+	if val != nil {
+		mc.hits.Add(1)
+	} else {
+		mc.misses.Add(1)
+	}
+	return val
+}
+
+func (mc *managedCache) Set(key string, val interface{}) {
+	mc.sets.Add(1)
+	current, previous := mc.generations()
+	current.Set(key, val)
+	if previous != nil {
+		mc.fill(val)
+	}
+}
+
+func (mc *managedCache) GetString(key string) string {
+	if val := mc.Get(key); val != nil {
+		return val.(string)
+	}
+	return ""
+}
+
+func (mc *managedCache) SetString(key string, val string) {
+	mc.Set(key, val)
+}
+
+func (mc *managedCache) GetInt(key string) int {
+	if val := mc.Get(key); val != nil {
+		return val.(int)
+	}
+	return 0
+}
+
+func (mc *managedCache) SetInt(key string, val int) {
+	mc.Set(key, val)
+}
+
+func (mc *managedCache) GetInt64(key string) int64 {
+	if val := mc.Get(key); val != nil {
+		return val.(int64)
+	}
+	return 0
+}
+
+func (mc *managedCache) SetInt64(key string, val int64) {
+	mc.Set(key, val)
+}
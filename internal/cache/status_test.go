@@ -0,0 +1,183 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCacheStatus(t *testing.T) {
+	coreconfig.Reset()
+	ctx := context.Background()
+	cm, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	identities, err := cm.GetCache(NewCacheConfig(ctx, "cache.identity.limit", "cache.identity.ttl", "ns1"))
+	assert.NoError(t, err)
+	batches, err := cm.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "ns1"))
+	assert.NoError(t, err)
+	batchesAgain, err := cm.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "ns1"))
+	assert.NoError(t, err)
+
+	batches.Set("key1", "val1")
+	batchesAgain.SetString("key2", "val2")
+	batches.SetInt("key3", 3)
+	batches.SetInt64("key4", 4)
+	assert.Equal(t, "val1", batches.Get("key1"))
+	assert.Equal(t, "val2", batchesAgain.GetString("key2"))
+	assert.Equal(t, 3, batches.GetInt("key3"))
+	assert.Equal(t, int64(4), batches.GetInt64("key4"))
+	assert.Equal(t, "", batches.GetString("missing"))
+	assert.Equal(t, 0, batches.GetInt("missing"))
+	assert.Equal(t, int64(0), batches.GetInt64("missing"))
+	assert.True(t, batches.Delete("key1"))
+	assert.True(t, identities.IsEnabled())
+
+	statuses := cm.GetCacheStatus()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "ns1:cache.batch", statuses[0].Name)
+	assert.Equal(t, "ns1", statuses[0].Namespace)
+	assert.Equal(t, int64(100), statuses[0].MaxSize)
+	assert.Equal(t, fftypes.FFDuration(config.GetDuration(coreconfig.CacheBatchTTL)), statuses[0].TTL)
+	assert.Equal(t, int64(4), statuses[0].Hits)
+	assert.Equal(t, int64(3), statuses[0].Misses)
+	assert.InDelta(t, 4.0/7.0, statuses[0].HitRatio, 0.0001)
+	assert.Equal(t, int64(4), statuses[0].Sets)
+	assert.Equal(t, int64(1), statuses[0].Deletes)
+	assert.NotNil(t, statuses[0].Created)
+	assert.Equal(t, "ns1:cache.identity", statuses[1].Name)
+	assert.Zero(t, statuses[1].HitRatio)
+
+	cm.ResetCachesForNamespace("ns1")
+	assert.Empty(t, cm.GetCacheStatus())
+}
+
+func TestInvalidateCache(t *testing.T) {
+	coreconfig.Reset()
+	ctx := context.Background()
+	cm, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	c, err := cm.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "ns1"))
+	assert.NoError(t, err)
+	c.Set("key1", "val1")
+	c.Set("key2", "val2")
+
+	err = cm.InvalidateCacheKey(ctx, "ns1:cache.batch", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, c.Get("key1"))
+	assert.Equal(t, "val2", c.Get("key2"))
+
+	err = cm.InvalidateCache(ctx, "ns1:cache.batch")
+	assert.NoError(t, err)
+	assert.Nil(t, c.Get("key2"))
+	c.Set("key3", "val3")
+	assert.Equal(t, "val3", c.Get("key3"))
+
+	statuses := cm.GetCacheStatus()
+	assert.NotNil(t, statuses[0].Invalidated)
+	assert.Equal(t, int64(1), statuses[0].Sets)
+}
+
+func TestInvalidateCacheNotFound(t *testing.T) {
+	coreconfig.Reset()
+	ctx := context.Background()
+	cm, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+
+	err = cm.InvalidateCache(ctx, "ns1:cache.unknown")
+	assert.Regexp(t, "FF10554", err)
+	err = cm.InvalidateCacheKey(ctx, "ns1:cache.unknown", "key1")
+	assert.Regexp(t, "FF10554", err)
+}
+
+func TestInvalidateCacheDistributed(t *testing.T) {
+	coreconfig.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := newTestBus()
+	instance1, err := NewCacheManager(ctx, newTestMetrics(false))
+	assert.NoError(t, err)
+	instance1.(*cacheManager).distributor = newDistributor(ctx, &testBackend{bus: bus})
+	c1, err := instance1.GetCache(NewCacheConfig(ctx, "cache.batch.limit", "cache.batch.ttl", "ns1"))
+	assert.NoError(t, err)
+	c2 := newTestDistributedCache(ctx, t, bus, "ns1")
+
+	c2.Set("key1", "val1")
+	c2.Set("key2", "val2")
+	c1.Set("key3", "val3")
+
+	err = instance1.InvalidateCacheKey(ctx, "ns1:cache.batch", "key1")
+	assert.NoError(t, err)
+	<-bus.published
+	assert.Nil(t, c2.Get("key1"))
+	assert.Equal(t, "val2", c2.Get("key2"))
+
+	err = instance1.InvalidateCache(ctx, "ns1:cache.batch")
+	assert.NoError(t, err)
+	inv := <-bus.published
+	assert.Empty(t, inv.Key)
+	assert.Nil(t, c2.Get("key2"))
+	assert.Nil(t, c1.Get("key3"))
+}
+
+type testSizedValue int64
+
+func (v testSizedValue) Size() int64 {
+	return int64(v)
+}
+
+func TestResizeShrinkKeepsHottestEntries(t *testing.T) {
+	ctx := context.Background()
+	mc := &managedCache{ctx: ctx, maxSize: 4, ttl: time.Minute, current: NewUmanagedCache(ctx, 4, time.Minute)}
+	for i := 1; i <= 4; i++ {
+		mc.SetInt(fmt.Sprintf("key%d", i), i)
+	}
+
+	mc.resize(2)
+	assert.Equal(t, int64(2), mc.size())
+	assert.Equal(t, 1, mc.GetInt("key1"))
+	_, previous := mc.generations()
+	assert.NotNil(t, previous)
+
+	// The previous generation is discarded once the smaller cache is full
+	assert.Equal(t, 2, mc.GetInt("key2"))
+	_, previous = mc.generations()
+	assert.Nil(t, previous)
+	assert.Equal(t, 0, mc.GetInt("key3"))
+	assert.Equal(t, 1, mc.GetInt("key1"))
+}
+
+func TestResizeFillCountsSizedValues(t *testing.T) {
+	ctx := context.Background()
+	mc := &managedCache{ctx: ctx, maxSize: 100, ttl: time.Minute, current: NewUmanagedCache(ctx, 100, time.Minute)}
+	mc.Set("key1", testSizedValue(10))
+
+	mc.resize(50)
+	mc.Set("key2", testSizedValue(40))
+	_, previous := mc.generations()
+	assert.NotNil(t, previous)
+	assert.Equal(t, testSizedValue(10), mc.Get("key1"))
+	_, previous = mc.generations()
+	assert.Nil(t, previous)
+}
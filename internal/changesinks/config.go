@@ -0,0 +1,69 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changesinks
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+)
+
+const (
+	// SinkConfName is the unique name of the sink, used in logging
+	SinkConfName = "name"
+	// SinkConfType is the type of the sink - webhook or kafka
+	SinkConfType = "type"
+	// SinkConfCollections is the list of collections forwarded to the sink
+	SinkConfCollections = "collections"
+	// SinkConfNamespaces optionally restricts the sink to changes in the listed namespaces
+	SinkConfNamespaces = "namespaces"
+	// SinkConfTypes optionally restricts the sink to the listed change types - created, updated or deleted
+	SinkConfTypes = "types"
+	// SinkConfBufferLength is the number of change events buffered for the sink, before events are dropped
+	SinkConfBufferLength = "bufferLength"
+	// SinkConfBatchSize is the maximum number of change events published in one request
+	SinkConfBatchSize = "batchSize"
+	// SinkConfBatchTimeout is how long to wait for a batch to fill before publishing it
+	SinkConfBatchTimeout = "batchTimeout"
+	// SinkConfBlockedWarnInterval is how often to warn when events are being dropped for the sink
+	SinkConfBlockedWarnInterval = "blockedWarnInterval"
+	// SinkConfWebhook is the HTTP configuration of the webhook sink type
+	SinkConfWebhook = "webhook"
+	// SinkConfKafka is the configuration of the kafka sink type, which publishes via a Kafka REST Proxy
+	SinkConfKafka = "kafka"
+	// SinkConfKafkaTopic is the Kafka topic change events are published to
+	SinkConfKafkaTopic = "topic"
+)
+
+var sinksConfig = config.RootArray("changesinks")
+
+func InitConfig() {
+	sinksConfig.AddKnownKey(SinkConfName)
+	sinksConfig.AddKnownKey(SinkConfType)
+	sinksConfig.AddKnownKey(SinkConfCollections)
+	sinksConfig.AddKnownKey(SinkConfNamespaces)
+	sinksConfig.AddKnownKey(SinkConfTypes)
+	sinksConfig.AddKnownKey(SinkConfBufferLength, 1000)
+	sinksConfig.AddKnownKey(SinkConfBatchSize, 100)
+	sinksConfig.AddKnownKey(SinkConfBatchTimeout, "250ms")
+	sinksConfig.AddKnownKey(SinkConfBlockedWarnInterval, "1m")
+
+	ffresty.InitConfig(sinksConfig.SubSection(SinkConfWebhook))
+
+	kafkaConfig := sinksConfig.SubSection(SinkConfKafka)
+	ffresty.InitConfig(kafkaConfig)
+	kafkaConfig.AddKnownKey(SinkConfKafkaTopic)
+}
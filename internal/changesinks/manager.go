@@ -0,0 +1,77 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package changesinks forwards the change events emitted as the database is updated, which are otherwise
+// only available to clients of the SPI websocket, to external systems such as a webhook or a Kafka topic.
+// This allows downstream systems to keep an index of FireFly resources up to date without polling the API.
+package changesinks
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type Manager interface {
+	Dispatch(changeEvent *core.ChangeEvent)
+	WaitStop()
+}
+
+type changeSinkManager struct {
+	ctx       context.Context
+	cancelCtx func()
+	sinks     []*sink
+}
+
+func NewChangeSinkManager(ctx context.Context) (Manager, error) {
+	csm := &changeSinkManager{}
+	csm.ctx, csm.cancelCtx = context.WithCancel(
+		log.WithLogField(ctx, "role", "change-sink-manager"),
+	)
+	names := make(map[string]bool)
+	for i := 0; i < sinksConfig.ArraySize(); i++ {
+		s, err := newSink(csm.ctx, sinksConfig.ArrayEntry(i))
+		if err == nil && names[s.name] {
+			err = i18n.NewError(ctx, coremsgs.MsgDuplicateChangeSinkName, s.name)
+		}
+		if err != nil {
+			csm.cancelCtx()
+			return nil, err
+		}
+		names[s.name] = true
+		csm.sinks = append(csm.sinks, s)
+	}
+	for _, s := range csm.sinks {
+		go s.publishLoop()
+	}
+	return csm, nil
+}
+
+func (csm *changeSinkManager) Dispatch(changeEvent *core.ChangeEvent) {
+	for _, s := range csm.sinks {
+		s.dispatch(changeEvent)
+	}
+}
+
+func (csm *changeSinkManager) WaitStop() {
+	csm.cancelCtx()
+	for _, s := range csm.sinks {
+		<-s.done
+	}
+}
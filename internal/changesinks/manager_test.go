@@ -0,0 +1,144 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changesinks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestChangeSinkConfig(sinks ...fftypes.JSONObject) {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set("changesinks", sinks)
+}
+
+func TestNewChangeSinkManagerNoSinks(t *testing.T) {
+	newTestChangeSinkConfig()
+	csm, err := NewChangeSinkManager(context.Background())
+	assert.NoError(t, err)
+	csm.Dispatch(&core.ChangeEvent{Collection: "messages"})
+	csm.WaitStop()
+}
+
+func TestNewChangeSinkManagerDuplicateName(t *testing.T) {
+	sink := fftypes.JSONObject{
+		"name":        "sink1",
+		"type":        "webhook",
+		"collections": []string{"messages"},
+		"webhook":     fftypes.JSONObject{"url": "http://localhost:12345"},
+	}
+	newTestChangeSinkConfig(sink, sink)
+	_, err := NewChangeSinkManager(context.Background())
+	assert.Regexp(t, "FF10565", err)
+}
+
+func TestNewChangeSinkManagerBadSink(t *testing.T) {
+	newTestChangeSinkConfig(fftypes.JSONObject{
+		"name":        "sink1",
+		"type":        "wrong",
+		"collections": []string{"messages"},
+	})
+	_, err := NewChangeSinkManager(context.Background())
+	assert.Regexp(t, "FF10563.*wrong.*sink1", err)
+}
+
+func TestWebhookSinkEndToEnd(t *testing.T) {
+	received := make(chan []*core.ChangeEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var events []*core.ChangeEvent
+		err := json.NewDecoder(r.Body).Decode(&events)
+		assert.NoError(t, err)
+		received <- events
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	newTestChangeSinkConfig(fftypes.JSONObject{
+		"name":        "sink1",
+		"type":        "webhook",
+		"collections": []string{"messages"},
+		"namespaces":  []string{"ns1"},
+		"batchSize":   2,
+		"webhook":     fftypes.JSONObject{"url": server.URL},
+	})
+	csm, err := NewChangeSinkManager(context.Background())
+	assert.NoError(t, err)
+	defer csm.WaitStop()
+
+	msgID := fftypes.NewUUID()
+	csm.Dispatch(&core.ChangeEvent{Collection: "messages", Type: core.ChangeEventTypeCreated, Namespace: "ns2", ID: fftypes.NewUUID()})
+	csm.Dispatch(&core.ChangeEvent{Collection: "events", Type: core.ChangeEventTypeCreated, Namespace: "ns1", ID: fftypes.NewUUID()})
+	csm.Dispatch(&core.ChangeEvent{Collection: "messages", Type: core.ChangeEventTypeCreated, Namespace: "ns1", ID: msgID})
+	csm.Dispatch(&core.ChangeEvent{Collection: "messages", Type: core.ChangeEventTypeUpdated, Namespace: "ns1", ID: msgID})
+
+	events := <-received
+	assert.Len(t, events, 2)
+	assert.Equal(t, msgID, events[0].ID)
+	assert.Equal(t, core.ChangeEventTypeCreated, events[0].Type)
+	assert.Equal(t, core.ChangeEventTypeUpdated, events[1].Type)
+}
+
+func TestKafkaSinkEndToEnd(t *testing.T) {
+	received := make(chan *kafkaRecords, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/topic1", r.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var records kafkaRecords
+		err = json.Unmarshal(b, &records)
+		assert.NoError(t, err)
+		received <- &records
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	newTestChangeSinkConfig(fftypes.JSONObject{
+		"name":         "sink1",
+		"type":         "kafka",
+		"collections":  []string{"messages"},
+		"types":        []string{"created"},
+		"batchTimeout": "1ms",
+		"kafka": fftypes.JSONObject{
+			"url":   server.URL,
+			"topic": "topic1",
+		},
+	})
+	csm, err := NewChangeSinkManager(context.Background())
+	assert.NoError(t, err)
+	defer csm.WaitStop()
+
+	msgID := fftypes.NewUUID()
+	csm.Dispatch(&core.ChangeEvent{Collection: "messages", Type: core.ChangeEventTypeUpdated, Namespace: "ns1", ID: fftypes.NewUUID()})
+	csm.Dispatch(&core.ChangeEvent{Collection: "messages", Type: core.ChangeEventTypeCreated, Namespace: "ns1", ID: msgID})
+
+	records := <-received
+	assert.Len(t, records.Records, 1)
+	assert.Equal(t, "ns1", records.Records[0].Key)
+	assert.Equal(t, msgID, records.Records[0].Value.ID)
+}
@@ -0,0 +1,117 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changesinks
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const (
+	// SinkTypeWebhook posts each batch of change events as a JSON array to a URL
+	SinkTypeWebhook = "webhook"
+	// SinkTypeKafka publishes each change event as a record on a Kafka topic, via a Kafka REST Proxy
+	SinkTypeKafka = "kafka"
+)
+
+type publisher interface {
+	publish(ctx context.Context, events []*core.ChangeEvent) error
+}
+
+func newPublisher(ctx context.Context, name string, conf config.Section) (publisher, error) {
+	sinkType := conf.GetString(SinkConfType)
+	switch sinkType {
+	case SinkTypeWebhook:
+		webhookConf := conf.SubSection(SinkConfWebhook)
+		if webhookConf.GetString(ffresty.HTTPConfigURL) == "" {
+			return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, webhookConf.Resolve(ffresty.HTTPConfigURL), name)
+		}
+		client, err := ffresty.New(ctx, webhookConf)
+		if err != nil {
+			return nil, err
+		}
+		return &webhookPublisher{client: client}, nil
+	case SinkTypeKafka:
+		kafkaConf := conf.SubSection(SinkConfKafka)
+		if kafkaConf.GetString(ffresty.HTTPConfigURL) == "" {
+			return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, kafkaConf.Resolve(ffresty.HTTPConfigURL), name)
+		}
+		topic := kafkaConf.GetString(SinkConfKafkaTopic)
+		if topic == "" {
+			return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, kafkaConf.Resolve(SinkConfKafkaTopic), name)
+		}
+		client, err := ffresty.New(ctx, kafkaConf)
+		if err != nil {
+			return nil, err
+		}
+		return &kafkaPublisher{client: client, topic: topic}, nil
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedChangeSinkType, sinkType, name)
+	}
+}
+
+type webhookPublisher struct {
+	client *resty.Client
+}
+
+func (wp *webhookPublisher) publish(ctx context.Context, events []*core.ChangeEvent) error {
+	res, err := wp.client.R().SetContext(ctx).
+		SetBody(events).
+		Post("")
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgChangeSinkPublishFailed)
+	}
+	return nil
+}
+
+type kafkaPublisher struct {
+	client *resty.Client
+	topic  string
+}
+
+type kafkaRecord struct {
+	Key   string            `json:"key,omitempty"`
+	Value *core.ChangeEvent `json:"value"`
+}
+
+type kafkaRecords struct {
+	Records []*kafkaRecord `json:"records"`
+}
+
+// publish uses the v2 produce API of the Kafka REST Proxy. Records are keyed by namespace, so the changes
+// within a namespace are assigned to the same partition and consumed in order.
+func (kp *kafkaPublisher) publish(ctx context.Context, events []*core.ChangeEvent) error {
+	body := &kafkaRecords{Records: make([]*kafkaRecord, len(events))}
+	for i, event := range events {
+		body.Records[i] = &kafkaRecord{Key: event.Namespace, Value: event}
+	}
+	res, err := kp.client.R().SetContext(ctx).
+		SetHeader("Content-Type", "application/vnd.kafka.json.v2+json").
+		SetBody(body).
+		SetPathParam("topic", kp.topic).
+		Post("/topics/{topic}")
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgChangeSinkPublishFailed)
+	}
+	return nil
+}
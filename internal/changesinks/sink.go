@@ -0,0 +1,204 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changesinks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type sink struct {
+	ctx                 context.Context
+	name                string
+	publisher           publisher
+	collections         []string
+	namespaces          []string
+	types               []core.ChangeEventType
+	events              chan *core.ChangeEvent
+	batchSize           int
+	batchTimeout        time.Duration
+	blockedWarnInterval time.Duration
+	mux                 sync.Mutex
+	blocked             *core.ChangeEvent
+	lastWarnTime        *fftypes.FFTime
+	done                chan struct{}
+}
+
+func newSink(ctx context.Context, conf config.Section) (*sink, error) {
+	name := conf.GetString(SinkConfName)
+	if name == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, conf.Resolve(SinkConfName), "changesinks")
+	}
+	collections := conf.GetStringSlice(SinkConfCollections)
+	if len(collections) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, conf.Resolve(SinkConfCollections), name)
+	}
+	s := &sink{
+		ctx:                 log.WithLogField(ctx, "changesink", name),
+		name:                name,
+		collections:         collections,
+		namespaces:          conf.GetStringSlice(SinkConfNamespaces),
+		events:              make(chan *core.ChangeEvent, conf.GetInt(SinkConfBufferLength)),
+		batchSize:           conf.GetInt(SinkConfBatchSize),
+		batchTimeout:        conf.GetDuration(SinkConfBatchTimeout),
+		blockedWarnInterval: conf.GetDuration(SinkConfBlockedWarnInterval),
+		done:                make(chan struct{}),
+	}
+	for _, t := range conf.GetStringSlice(SinkConfTypes) {
+		s.types = append(s.types, core.ChangeEventType(t))
+	}
+	var err error
+	s.publisher, err = newPublisher(ctx, name, conf)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *sink) eventMatches(changeEvent *core.ChangeEvent) bool {
+	collectionMatches := false
+	for _, c := range s.collections {
+		if c == changeEvent.Collection {
+			collectionMatches = true
+			break
+		}
+	}
+	if !collectionMatches {
+		return false
+	}
+	if len(s.namespaces) > 0 {
+		namespaceMatches := false
+		for _, ns := range s.namespaces {
+			if ns == changeEvent.Namespace {
+				namespaceMatches = true
+				break
+			}
+		}
+		if !namespaceMatches {
+			return false
+		}
+	}
+	if len(s.types) > 0 {
+		typeMatches := false
+		for _, t := range s.types {
+			if t == changeEvent.Type {
+				typeMatches = true
+				break
+			}
+		}
+		if !typeMatches {
+			return false
+		}
+	}
+	return true
+}
+
+// dispatch is called on the critical path of the commit for all database operations, so never blocks.
+// Unlike the websocket we filter before queuing, as the filter is fixed at startup, so that changes the
+// sink is not interested in cannot cause the changes it is interested in to be dropped.
+func (s *sink) dispatch(changeEvent *core.ChangeEvent) {
+	if !s.eventMatches(changeEvent) {
+		return
+	}
+	select {
+	case s.events <- changeEvent:
+	default:
+		s.markDropped(1, nil)
+	}
+}
+
+// markDropped counts events the sink has missed, which are reported to it in a single dropped event
+// at the start of the next batch that is published successfully
+func (s *sink) markDropped(count int64, since *fftypes.FFTime) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.blocked == nil {
+		s.blocked = &core.ChangeEvent{
+			Type:         core.ChangeEventTypeDropped,
+			DroppedSince: fftypes.Now(),
+		}
+	}
+	if since != nil && since.Time().Before(*s.blocked.DroppedSince.Time()) {
+		s.blocked.DroppedSince = since
+	}
+	s.blocked.DroppedCount += count
+	if s.lastWarnTime == nil || time.Since(*s.lastWarnTime.Time()) > s.blockedWarnInterval {
+		log.L(s.ctx).Warnf("Change event sink is blocked and missing %d events (since %s)", s.blocked.DroppedCount, s.blocked.DroppedSince)
+		s.lastWarnTime = fftypes.Now()
+	}
+}
+
+func (s *sink) publishLoop() {
+	l := log.L(s.ctx)
+	defer close(s.done)
+	for {
+		var batch []*core.ChangeEvent
+		select {
+		case changeEvent := <-s.events:
+			batch = append(batch, changeEvent)
+		case <-s.ctx.Done():
+			l.Debugf("Publisher closing - context cancelled")
+			return
+		}
+		timeout := time.NewTimer(s.batchTimeout)
+	batchLoop:
+		for len(batch) < s.batchSize {
+			select {
+			case changeEvent := <-s.events:
+				batch = append(batch, changeEvent)
+			case <-timeout.C:
+				break batchLoop
+			case <-s.ctx.Done():
+				timeout.Stop()
+				l.Debugf("Publisher closing - context cancelled")
+				return
+			}
+		}
+		timeout.Stop()
+		s.publishBatch(batch)
+	}
+}
+
+func (s *sink) publishBatch(batch []*core.ChangeEvent) {
+	s.mux.Lock()
+	blocked := s.blocked
+	s.blocked = nil
+	s.mux.Unlock()
+
+	dropped := int64(len(batch))
+	var droppedSince *fftypes.FFTime
+	if blocked != nil {
+		// Notify the consumer that it missed events, so it can re-synchronize from the API
+		log.L(s.ctx).Debugf("Notifying sink it missed %d events since %s", blocked.DroppedCount, blocked.DroppedSince)
+		dropped += blocked.DroppedCount
+		droppedSince = blocked.DroppedSince
+		batch = append([]*core.ChangeEvent{blocked}, batch...)
+	}
+	if err := s.publisher.publish(s.ctx, batch); err != nil {
+		// Retry is handled by the HTTP client, so once that is exhausted the events count as dropped
+		log.L(s.ctx).Errorf("Failed to publish %d change events: %s", len(batch), err)
+		s.markDropped(dropped, droppedSince)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changesinks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSinkConfig(sink fftypes.JSONObject) config.Section {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set("changesinks", []fftypes.JSONObject{sink})
+	return sinksConfig.ArrayEntry(0)
+}
+
+type testPublisher struct {
+	published [][]*core.ChangeEvent
+	err       error
+}
+
+func (tp *testPublisher) publish(ctx context.Context, events []*core.ChangeEvent) error {
+	tp.published = append(tp.published, events)
+	return tp.err
+}
+
+func newTestSink(bufferLength int) (*sink, *testPublisher) {
+	tp := &testPublisher{}
+	return &sink{
+		ctx:                 context.Background(),
+		name:                "sink1",
+		publisher:           tp,
+		collections:         []string{"messages"},
+		events:              make(chan *core.ChangeEvent, bufferLength),
+		batchSize:           10,
+		batchTimeout:        time.Millisecond,
+		blockedWarnInterval: time.Minute,
+		done:                make(chan struct{}),
+	}, tp
+}
+
+func TestNewSinkMissingName(t *testing.T) {
+	_, err := newSink(context.Background(), newTestSinkConfig(fftypes.JSONObject{
+		"type": "webhook",
+	}))
+	assert.Regexp(t, "FF10138.*name", err)
+}
+
+func TestNewSinkMissingCollections(t *testing.T) {
+	_, err := newSink(context.Background(), newTestSinkConfig(fftypes.JSONObject{
+		"name": "sink1",
+		"type": "webhook",
+	}))
+	assert.Regexp(t, "FF10138.*collections", err)
+}
+
+func TestNewSinkWebhookMissingURL(t *testing.T) {
+	_, err := newSink(context.Background(), newTestSinkConfig(fftypes.JSONObject{
+		"name":        "sink1",
+		"type":        "webhook",
+		"collections": []string{"messages"},
+	}))
+	assert.Regexp(t, "FF10138.*webhook.url", err)
+}
+
+func TestNewSinkWebhookBadTLS(t *testing.T) {
+	_, err := newSink(context.Background(), newTestSinkConfig(fftypes.JSONObject{
+		"name":        "sink1",
+		"type":        "webhook",
+		"collections": []string{"messages"},
+		"webhook": fftypes.JSONObject{
+			"url": "https://localhost:12345",
+			"tls": fftypes.JSONObject{"enabled": true, "caFile": "!!!!!badness"},
+		},
+	}))
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestNewSinkKafkaMissingURL(t *testing.T) {
+	_, err := newSink(context.Background(), newTestSinkConfig(fftypes.JSONObject{
+		"name":        "sink1",
+		"type":        "kafka",
+		"collections": []string{"messages"},
+	}))
+	assert.Regexp(t, "FF10138.*kafka.url", err)
+}
+
+func TestNewSinkKafkaMissingTopic(t *testing.T) {
+	_, err := newSink(context.Background(), newTestSinkConfig(fftypes.JSONObject{
+		"name":        "sink1",
+		"type":        "kafka",
+		"collections": []string{"messages"},
+		"kafka":       fftypes.JSONObject{"url": "http://localhost:12345"},
+	}))
+	assert.Regexp(t, "FF10138.*kafka.topic", err)
+}
+
+func TestNewSinkKafkaBadTLS(t *testing.T) {
+	_, err := newSink(context.Background(), newTestSinkConfig(fftypes.JSONObject{
+		"name":        "sink1",
+		"type":        "kafka",
+		"collections": []string{"messages"},
+		"kafka": fftypes.JSONObject{
+			"url":   "https://localhost:12345",
+			"topic": "topic1",
+			"tls":   fftypes.JSONObject{"enabled": true, "caFile": "!!!!!badness"},
+		},
+	}))
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestEventMatchesTypes(t *testing.T) {
+	s, _ := newTestSink(1)
+	s.types = []core.ChangeEventType{core.ChangeEventTypeDeleted}
+	assert.True(t, s.eventMatches(&core.ChangeEvent{Collection: "messages", Type: core.ChangeEventTypeDeleted}))
+	assert.False(t, s.eventMatches(&core.ChangeEvent{Collection: "messages", Type: core.ChangeEventTypeCreated}))
+}
+
+func TestDispatchDroppedNotified(t *testing.T) {
+	s, tp := newTestSink(1)
+
+	s.dispatch(&core.ChangeEvent{Collection: "messages"})
+	s.dispatch(&core.ChangeEvent{Collection: "messages"})
+	s.dispatch(&core.ChangeEvent{Collection: "messages"})
+	assert.Equal(t, int64(2), s.blocked.DroppedCount)
+
+	s.publishBatch([]*core.ChangeEvent{<-s.events})
+	assert.Nil(t, s.blocked)
+	assert.Len(t, tp.published, 1)
+	assert.Len(t, tp.published[0], 2)
+	assert.Equal(t, core.ChangeEventTypeDropped, tp.published[0][0].Type)
+	assert.Equal(t, int64(2), tp.published[0][0].DroppedCount)
+}
+
+func TestPublishFailMarksDropped(t *testing.T) {
+	s, tp := newTestSink(1)
+	tp.err = assert.AnError
+
+	since := fftypes.FFTime(time.Now().Add(-time.Hour))
+	s.blocked = &core.ChangeEvent{Type: core.ChangeEventTypeDropped, DroppedSince: &since, DroppedCount: 5}
+	s.publishBatch([]*core.ChangeEvent{{Collection: "messages"}, {Collection: "messages"}})
+
+	assert.Equal(t, int64(7), s.blocked.DroppedCount)
+	assert.Equal(t, &since, s.blocked.DroppedSince)
+}
+
+func TestPublishLoopCloseWhileBatching(t *testing.T) {
+	s, tp := newTestSink(10)
+	s.batchTimeout = time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	s.ctx = ctx
+
+	s.events <- &core.ChangeEvent{Collection: "messages"}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	s.publishLoop()
+	<-s.done
+	assert.Empty(t, tp.published)
+}
+
+func TestWebhookPublishFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	conf := newTestSinkConfig(fftypes.JSONObject{}).SubSection(SinkConfWebhook)
+	conf.Set(ffresty.HTTPConfigURL, server.URL)
+	client, err := ffresty.New(context.Background(), conf)
+	assert.NoError(t, err)
+
+	wp := &webhookPublisher{client: client}
+	err = wp.publish(context.Background(), []*core.ChangeEvent{{Collection: "messages"}})
+	assert.Regexp(t, "FF10564", err)
+}
+
+func TestKafkaPublishFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	conf := newTestSinkConfig(fftypes.JSONObject{}).SubSection(SinkConfKafka)
+	conf.Set(ffresty.HTTPConfigURL, server.URL)
+	client, err := ffresty.New(context.Background(), conf)
+	assert.NoError(t, err)
+
+	kp := &kafkaPublisher{client: client, topic: "topic1"}
+	err = kp.publish(context.Background(), []*core.ChangeEvent{{Collection: "messages"}})
+	assert.Regexp(t, "FF10564", err)
+}
@@ -66,6 +66,7 @@ type Manager interface {
 	DeployContract(ctx context.Context, req *core.ContractDeployRequest, waitConfirm bool) (interface{}, error)
 	InvokeContract(ctx context.Context, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error)
 	InvokeContractAPI(ctx context.Context, apiName, methodPath string, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error)
+	InvokeContractSequence(ctx context.Context, req *core.ContractInvokeSequenceRequest) (*core.Transaction, error)
 	GetContractAPI(ctx context.Context, httpServerURL, apiName string) (*core.ContractAPI, error)
 	GetContractAPIInterface(ctx context.Context, apiName string) (*fftypes.FFI, error)
 	GetContractAPIs(ctx context.Context, httpServerURL string, filter ffapi.AndFilter) ([]*core.ContractAPI, *ffapi.FilterResult, error)
@@ -75,6 +76,7 @@ type Manager interface {
 	ConstructContractListenerSignature(ctx context.Context, listener *core.ContractListenerInput) (output *core.ContractListenerSignatureOutput, err error)
 	AddContractListener(ctx context.Context, listener *core.ContractListenerInput) (output *core.ContractListener, err error)
 	AddContractAPIListener(ctx context.Context, apiName, eventPath string, listener *core.ContractListener) (output *core.ContractListener, err error)
+	ReconcileContractAPIListeners(ctx context.Context, api *core.ContractAPI) error
 	GetContractListenerByNameOrID(ctx context.Context, nameOrID string) (*core.ContractListener, error)
 	GetContractListenerByNameOrIDWithStatus(ctx context.Context, nameOrID string) (*core.ContractListenerWithStatus, error)
 	GetContractListeners(ctx context.Context, filter ffapi.AndFilter) ([]*core.ContractListener, *ffapi.FilterResult, error)
@@ -329,7 +331,7 @@ func (cm *contractManager) writeInvokeTransaction(ctx context.Context, req *core
 		return false, nil, err
 	}
 
-	txn, err := cm.txWriter.WriteTransactionAndOps(ctx, txtype, req.IdempotencyKey, op)
+	txn, err := cm.txWriter.WriteTransactionAndOps(ctx, txtype, req.IdempotencyKey, req.TxLabels, op)
 	if err != nil {
 		// Check if we've clashed on idempotency key. There might be operations still in "Initialized" state that need
 		// submitting to their handlers
@@ -370,7 +372,7 @@ func (cm *contractManager) writeDeployTransaction(ctx context.Context, req *core
 	if err := addBlockchainReqInputs(op, req); err != nil {
 		return false, nil, err
 	}
-	_, err := cm.txWriter.WriteTransactionAndOps(ctx, core.TransactionTypeContractDeploy, req.IdempotencyKey, op)
+	_, err := cm.txWriter.WriteTransactionAndOps(ctx, core.TransactionTypeContractDeploy, req.IdempotencyKey, nil, op)
 	if err != nil {
 		// Check if we've clashed on idempotency key. There might be operations still in "Initialized" state that need
 		// submitting to their handlers
@@ -419,6 +421,9 @@ func (cm *contractManager) DeployContract(ctx context.Context, req *core.Contrac
 }
 
 func (cm *contractManager) InvokeContract(ctx context.Context, req *core.ContractCallRequest, waitConfirm bool) (res interface{}, err error) {
+	if err := core.ValidateTransactionLabels(ctx, "txlabels", req.TxLabels); err != nil {
+		return nil, err
+	}
 	keyResolver := cm.identity.ResolveInputSigningKey
 	if req.Type == core.CallTypeQuery {
 		// Special case that we are resolving the key with an intent to query, not sign
@@ -503,6 +508,93 @@ func (cm *contractManager) InvokeContractAPI(ctx context.Context, apiName, metho
 	return cm.InvokeContract(ctx, req, waitConfirm)
 }
 
+// InvokeContractSequence submits an ordered sequence of contract invocations as the saga steps of a single
+// transaction. Only the first step is run here. Each later step is run by the operations manager once the
+// step before it succeeds, and if a step fails permanently the compensations of the earlier steps are submitted.
+func (cm *contractManager) InvokeContractSequence(ctx context.Context, req *core.ContractInvokeSequenceRequest) (*core.Transaction, error) {
+	if len(req.Steps) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractInvokeSequenceEmpty)
+	}
+	if err := core.ValidateTransactionLabels(ctx, "txlabels", req.TxLabels); err != nil {
+		return nil, err
+	}
+	for i, step := range req.Steps {
+		if step.Invoke == nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgContractInvokeSequenceNoInvoke, i)
+		}
+		if err := cm.resolveSequenceCall(ctx, i, step.Invoke); err != nil {
+			return nil, err
+		}
+		if step.Compensation != nil {
+			if err := cm.resolveSequenceCall(ctx, i, step.Compensation); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Idempotent resubmission is not supported, as resubmitting every initialized operation would run
+	// all of the steps at once - so a clash on the idempotency key is returned as a conflict
+	var txid *fftypes.UUID
+	ops := make([]*core.Operation, len(req.Steps))
+	err := cm.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
+		txid, err = cm.txHelper.SubmitNewTransaction(ctx, core.TransactionTypeContractInvoke, req.IdempotencyKey, req.TxLabels...)
+		if err != nil {
+			return err
+		}
+		compensations := make([]*core.SagaCompensation, len(req.Steps))
+		for i, step := range req.Steps {
+			ops[i] = core.NewOperation(cm.blockchain, cm.namespace, txid, core.OpTypeBlockchainInvoke)
+			if err := addBlockchainReqInputs(ops[i], step.Invoke); err != nil {
+				return err
+			}
+			if step.Compensation != nil {
+				compensation := core.NewOperation(cm.blockchain, cm.namespace, txid, core.OpTypeBlockchainInvoke)
+				if err := addBlockchainReqInputs(compensation, step.Compensation); err != nil {
+					return err
+				}
+				compensations[i] = &core.SagaCompensation{
+					Type:   compensation.Type,
+					Plugin: compensation.Plugin,
+					Input:  compensation.Input,
+				}
+			}
+		}
+		if err := cm.operations.BulkInsertOperations(ctx, ops...); err != nil {
+			return err
+		}
+		for i, step := range req.Steps {
+			if err := cm.txHelper.AddSagaStep(ctx, txid, step.Name, ops[i].ID, compensations[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := cm.operations.RunOperation(ctx, txcommon.OpBlockchainInvoke(ops[0], req.Steps[0].Invoke, nil), req.IdempotencyKey != ""); err != nil {
+		return nil, err
+	}
+	return cm.txHelper.GetTransactionByIDCached(ctx, txid)
+}
+
+func (cm *contractManager) resolveSequenceCall(ctx context.Context, idx int, req *core.ContractCallRequest) (err error) {
+	if req.Message != nil {
+		return i18n.NewError(ctx, coremsgs.MsgContractInvokeSequenceMessage, idx)
+	}
+	req.Type = core.CallTypeInvoke
+	req.Key, err = cm.identity.ResolveInputSigningKey(ctx, req.Key, identity.KeyNormalizationBlockchainPlugin)
+	if err != nil {
+		return err
+	}
+	if err := cm.resolveInvokeContractRequest(ctx, req); err != nil {
+		return err
+	}
+	_, err = cm.validateInvokeContractRequest(ctx, req, true)
+	return err
+}
+
 func (cm *contractManager) resolveInvokeContractRequest(ctx context.Context, req *core.ContractCallRequest) (err error) {
 	if req.Method == nil {
 		if req.MethodPath == "" || req.Interface == nil {
@@ -588,6 +680,14 @@ func (cm *contractManager) ResolveContractAPI(ctx context.Context, httpServerURL
 		if err := cm.ResolveFFIReference(ctx, api.Interface); err != nil {
 			return err
 		}
+		for _, template := range api.Listeners {
+			event, err := cm.database.GetFFIEvent(ctx, cm.namespace, api.Interface.ID, template.EventPath)
+			if err != nil {
+				return err
+			} else if event == nil {
+				return i18n.NewError(ctx, coremsgs.MsgEventNotFound, template.EventPath)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -1137,6 +1237,62 @@ func (cm *contractManager) AddContractAPIListener(ctx context.Context, apiName,
 	return cm.AddContractListener(ctx, input)
 }
 
+// ReconcileContractAPIListeners creates the listeners declared by the templates on a contract API.
+// Listeners are named deterministically, so any already created against the API's location are
+// left alone, while those left behind from a previous location are replaced.
+func (cm *contractManager) ReconcileContractAPIListeners(ctx context.Context, api *core.ContractAPI) error {
+	if len(api.Listeners) == 0 || api.Location == nil || api.Interface == nil {
+		return nil
+	}
+	location, err := cm.blockchain.NormalizeContractLocation(ctx, blockchain.NormalizeListener, api.Location)
+	if err != nil {
+		return err
+	}
+
+	for _, template := range api.Listeners {
+		name := api.ListenerName(template)
+		existing, err := cm.database.GetContractListener(ctx, cm.namespace, name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if existing.Interface == nil || !existing.Interface.ID.Equals(api.Interface.ID) {
+				log.L(ctx).Warnf("Contract listener '%s' was not created from API '%s' - skipping template", name, api.Name)
+				continue
+			}
+			if existing.Location.Hash().Equals(location.Hash()) {
+				continue
+			}
+			log.L(ctx).Infof("Replacing contract listener '%s' as the location of API '%s' has changed", name, api.Name)
+			if err = cm.blockchain.DeleteContractListener(ctx, existing, true /* ok if not found */); err != nil {
+				return err
+			}
+			if err = cm.database.DeleteContractListenerByID(ctx, cm.namespace, existing.ID); err != nil {
+				return err
+			}
+		}
+
+		input := &core.ContractListenerInput{
+			ContractListener: core.ContractListener{
+				Interface: &fftypes.FFIReference{ID: api.Interface.ID},
+				Name:      name,
+				Location:  api.Location,
+				Topic:     api.ListenerTopic(template),
+			},
+			EventPath: template.EventPath,
+		}
+		if template.Options != nil {
+			options := *template.Options
+			input.Options = &options
+		}
+		if _, err = cm.AddContractListener(ctx, input); err != nil {
+			return err
+		}
+		log.L(ctx).Infof("Created contract listener '%s' for API '%s'", name, api.Name)
+	}
+	return nil
+}
+
 func (cm *contractManager) MigrateToFiltersIfNeeded(ctx context.Context, listener *core.ContractListener) (bool, *core.ContractListener, error) {
 	migrated := false
 	if len(listener.Filters) == 0 && listener.Event != nil {
@@ -1983,7 +1983,7 @@ func TestDeployContract(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeploy && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, signingKey, identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2018,7 +2018,7 @@ func TestDeployContractIdempotentResubmitOperation(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeploy && op.Plugin == "mockblockchain"
 	})).Return(nil, &sqlcommon.IdempotencyError{
 		ExistingTXID:  id,
@@ -2051,7 +2051,7 @@ func TestDeployContractIdempotentNoOperationToResubmit(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeploy && op.Plugin == "mockblockchain"
 	})).Return(nil, &sqlcommon.IdempotencyError{
 		ExistingTXID:  id,
@@ -2085,7 +2085,7 @@ func TestDeployContractIdempotentErrorOnOperationResubmit(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeploy && op.Plugin == "mockblockchain"
 	})).Return(nil, &sqlcommon.IdempotencyError{
 		ExistingTXID:  id,
@@ -2119,7 +2119,7 @@ func TestDeployContractSync(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeploy && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, signingKey, identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2177,7 +2177,7 @@ func TestDeployContractSubmitNewTransactionFail(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeploy && op.Plugin == "mockblockchain"
 	})).Return(nil, errors.New("pop"))
 	mim.On("ResolveInputSigningKey", mock.Anything, signingKey, identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2212,9 +2212,10 @@ func TestInvokeContract(t *testing.T) {
 			Returns: fftypes.FFIParams{},
 		},
 		IdempotencyKey: "idem1",
+		TxLabels:       fftypes.FFStringArray{"payroll"},
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), []string{"payroll"}, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2237,6 +2238,223 @@ func TestInvokeContract(t *testing.T) {
 	mbi.AssertExpectations(t)
 }
 
+func newTestSequenceCall(method string) *core.ContractCallRequest {
+	return &core.ContractCallRequest{
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			Name:    method,
+			ID:      fftypes.NewUUID(),
+			Params:  fftypes.FFIParams{},
+			Returns: fftypes.FFIParams{},
+		},
+	}
+}
+
+func TestInvokeContractSequence(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	req := &core.ContractInvokeSequenceRequest{
+		Steps: []*core.ContractInvokeStep{
+			{Name: "reserve", Invoke: newTestSequenceCall("reserve"), Compensation: newTestSequenceCall("release")},
+			{Name: "settle", Invoke: newTestSequenceCall("settle")},
+		},
+		IdempotencyKey: "idem1",
+		TxLabels:       fftypes.FFStringArray{"payroll"},
+	}
+	txid := fftypes.NewUUID()
+	var ops []*core.Operation
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), "payroll").Return(txid, nil)
+	mom.On("BulkInsertOperations", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		ops = []*core.Operation{args[1].(*core.Operation), args[2].(*core.Operation)}
+	}).Return(nil)
+	mth.On("AddSagaStep", mock.Anything, txid, "reserve", mock.Anything, mock.MatchedBy(func(compensation *core.SagaCompensation) bool {
+		return compensation.Type == core.OpTypeBlockchainInvoke && compensation.Plugin == "mockblockchain" &&
+			compensation.Input.GetObject("method").GetString("name") == "release"
+	})).Return(nil)
+	mth.On("AddSagaStep", mock.Anything, txid, "settle", mock.Anything, (*core.SagaCompensation)(nil)).Return(nil)
+	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(txcommon.BlockchainInvokeData)
+		return op.ID.Equals(ops[0].ID) && data.Request == req.Steps[0].Invoke
+	}), true).Return(nil, nil)
+	mth.On("GetTransactionByIDCached", mock.Anything, txid).Return(&core.Transaction{ID: txid}, nil)
+
+	tx, err := cm.InvokeContractSequence(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, txid, tx.ID)
+	assert.Equal(t, txid, ops[1].Transaction)
+	assert.Equal(t, "key-resolved", req.Steps[1].Invoke.Key)
+	assert.Equal(t, core.CallTypeInvoke, req.Steps[0].Compensation.Type)
+
+	mth.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractSequenceBadRequests(t *testing.T) {
+	cm := newTestContractManager()
+
+	_, err := cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{})
+	assert.Regexp(t, "FF10674", err)
+
+	_, err = cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps:    []*core.ContractInvokeStep{{Invoke: newTestSequenceCall("settle")}},
+		TxLabels: fftypes.FFStringArray{"!bad"},
+	})
+	assert.Regexp(t, "FF00140.*txlabels", err)
+
+	_, err = cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps: []*core.ContractInvokeStep{{Name: "empty"}},
+	})
+	assert.Regexp(t, "FF10675", err)
+
+	withMessage := newTestSequenceCall("settle")
+	withMessage.Message = &core.MessageInOut{}
+	_, err = cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps: []*core.ContractInvokeStep{{Invoke: withMessage}},
+	})
+	assert.Regexp(t, "FF10676", err)
+}
+
+func TestInvokeContractSequenceBadCompensation(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil).Once()
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("", fmt.Errorf("pop"))
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+
+	_, err := cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps: []*core.ContractInvokeStep{{Invoke: newTestSequenceCall("reserve"), Compensation: newTestSequenceCall("release")}},
+	})
+	assert.EqualError(t, err, "pop")
+
+	mim.AssertExpectations(t)
+}
+
+func TestInvokeContractSequenceResolveFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	_, err := cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps: []*core.ContractInvokeStep{{Invoke: &core.ContractCallRequest{}}},
+	})
+	assert.Regexp(t, "FF10313", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestInvokeContractSequenceSubmitFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1")).Return(nil, &sqlcommon.IdempotencyError{})
+
+	_, err := cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps:          []*core.ContractInvokeStep{{Invoke: newTestSequenceCall("settle")}},
+		IdempotencyKey: "idem1",
+	})
+	assert.IsType(t, &sqlcommon.IdempotencyError{}, err)
+
+	mth.AssertExpectations(t)
+}
+
+func TestInvokeContractSequenceInsertFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mom.On("BulkInsertOperations", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps: []*core.ContractInvokeStep{{Invoke: newTestSequenceCall("settle")}},
+	})
+	assert.EqualError(t, err, "pop")
+
+	mom.AssertExpectations(t)
+}
+
+func TestInvokeContractSequenceAddStepFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mom.On("BulkInsertOperations", mock.Anything, mock.Anything).Return(nil)
+	mth.On("AddSagaStep", mock.Anything, mock.Anything, "", mock.Anything, (*core.SagaCompensation)(nil)).Return(fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps: []*core.ContractInvokeStep{{Invoke: newTestSequenceCall("settle")}},
+	})
+	assert.EqualError(t, err, "pop")
+
+	mth.AssertExpectations(t)
+}
+
+func TestInvokeContractSequenceRunFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mom.On("BulkInsertOperations", mock.Anything, mock.Anything).Return(nil)
+	mth.On("AddSagaStep", mock.Anything, mock.Anything, "", mock.Anything, (*core.SagaCompensation)(nil)).Return(nil)
+	mom.On("RunOperation", mock.Anything, mock.Anything, false).Return(nil, fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractSequence(context.Background(), &core.ContractInvokeSequenceRequest{
+		Steps: []*core.ContractInvokeStep{{Invoke: newTestSequenceCall("settle")}},
+	})
+	assert.EqualError(t, err, "pop")
+
+	mom.AssertExpectations(t)
+}
+
+func TestInvokeContractBadTxLabels(t *testing.T) {
+	cm := newTestContractManager()
+
+	req := &core.ContractCallRequest{
+		Type:     core.CallTypeInvoke,
+		TxLabels: fftypes.FFStringArray{"!bad"},
+	}
+
+	_, err := cm.InvokeContract(context.Background(), req, false)
+	assert.Regexp(t, "FF00140.*txlabels", err)
+}
+
 func TestInvokeContractViaFFI(t *testing.T) {
 	cm := newTestContractManager()
 	mim := cm.identity.(*identitymanagermocks.Manager)
@@ -2261,7 +2479,7 @@ func TestInvokeContractViaFFI(t *testing.T) {
 		Returns: fftypes.FFIParams{},
 	}
 	errors := []*fftypes.FFIError{}
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2322,7 +2540,7 @@ func TestInvokeContractWithBroadcast(t *testing.T) {
 		},
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvokePin, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvokePin, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2380,7 +2598,7 @@ func TestInvokeContractWithBroadcastConfirm(t *testing.T) {
 		},
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvokePin, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvokePin, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2669,7 +2887,7 @@ func TestInvokeContractIdempotentResubmitOperation(t *testing.T) {
 	}
 
 	mbrm.On("NewBroadcast", req.Message).Return(sender, nil)
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvokePin, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvokePin, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(nil, &sqlcommon.IdempotencyError{
 		ExistingTXID:  id,
@@ -2713,7 +2931,7 @@ func TestInvokeContractIdempotentNoOperationToResubmit(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(nil, &sqlcommon.IdempotencyError{
 		ExistingTXID:  id,
@@ -2757,7 +2975,7 @@ func TestInvokeContractIdempotentErrorOnOperationResubmit(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(nil, &sqlcommon.IdempotencyError{
 		ExistingTXID:  id,
@@ -2802,7 +3020,7 @@ func TestInvokeContractConfirm(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2854,7 +3072,7 @@ func TestInvokeContractFail(t *testing.T) {
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
@@ -2965,7 +3183,7 @@ func TestInvokeContractTXFail(t *testing.T) {
 	}
 
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(nil, fmt.Errorf("pop"))
 	opaqueData := "anything"
@@ -3408,6 +3626,201 @@ func TestGetContractAPIListenersEventNotFound(t *testing.T) {
 	mdi.AssertExpectations(t)
 }
 
+func testTemplatedContractAPI() *core.ContractAPI {
+	return &core.ContractAPI{
+		Namespace: "ns1",
+		Name:      "simple",
+		Interface: &fftypes.FFIReference{
+			ID: fftypes.NewUUID(),
+		},
+		Location: fftypes.JSONAnyPtr(fftypes.JSONObject{
+			"address": "0x123",
+		}.String()),
+		Listeners: core.ContractAPIListenerTemplates{
+			{EventPath: "changed", Options: &core.ContractListenerOptions{FirstEvent: "oldest"}},
+		},
+	}
+}
+
+func TestReconcileContractAPIListenersCreate(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	event := &fftypes.FFIEvent{
+		FFIEventDefinition: fftypes.FFIEventDefinition{
+			Name: "changed",
+		},
+	}
+
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeListener, api.Location).Return(api.Location, nil)
+	mdi.On("GetContractListener", context.Background(), "ns1", "simple-changed").Return(nil, nil)
+	mdi.On("GetFFIByID", context.Background(), "ns1", api.Interface.ID).Return(&fftypes.FFI{}, nil)
+	mdi.On("GetFFIEvent", context.Background(), "ns1", api.Interface.ID, "changed").Return(event, nil)
+	mbi.On("GenerateEventSignature", context.Background(), mock.Anything).Return("changed", nil)
+	mbi.On("GenerateEventSignatureWithLocation", context.Background(), mock.Anything, mock.Anything).Return("0x123:changed", nil)
+	mdi.On("GetContractListeners", context.Background(), "ns1", mock.Anything).Return(nil, nil, nil)
+	mbi.On("AddContractListener", context.Background(), mock.MatchedBy(func(l *core.ContractListener) bool {
+		return l.Name == "simple-changed" && l.Topic == "simple" && l.Options.FirstEvent == "oldest"
+	}), "").Return(nil)
+	mdi.On("InsertContractListener", context.Background(), mock.MatchedBy(func(l *core.ContractListener) bool {
+		return l.Name == "simple-changed" && *l.Filters[0].Interface.ID == *api.Interface.ID
+	})).Return(nil)
+
+	err := cm.ReconcileContractAPIListeners(context.Background(), api)
+	assert.NoError(t, err)
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestReconcileContractAPIListenersNoLocation(t *testing.T) {
+	cm := newTestContractManager()
+
+	api := testTemplatedContractAPI()
+	api.Location = nil
+
+	err := cm.ReconcileContractAPIListeners(context.Background(), api)
+	assert.NoError(t, err)
+}
+
+func TestReconcileContractAPIListenersBadLocation(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeListener, api.Location).Return(nil, fmt.Errorf("pop"))
+
+	err := cm.ReconcileContractAPIListeners(context.Background(), api)
+	assert.EqualError(t, err, "pop")
+
+	mbi.AssertExpectations(t)
+}
+
+func TestReconcileContractAPIListenersGetFail(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeListener, api.Location).Return(api.Location, nil)
+	mdi.On("GetContractListener", context.Background(), "ns1", "simple-changed").Return(nil, fmt.Errorf("pop"))
+
+	err := cm.ReconcileContractAPIListeners(context.Background(), api)
+	assert.EqualError(t, err, "pop")
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestReconcileContractAPIListenersExisting(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	api.Listeners = append(api.Listeners, &core.ContractAPIListenerTemplate{EventPath: "other", Name: "not-ours"})
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeListener, api.Location).Return(api.Location, nil)
+	mdi.On("GetContractListener", context.Background(), "ns1", "simple-changed").Return(&core.ContractListener{
+		Interface: &fftypes.FFIReference{ID: api.Interface.ID},
+		Location:  api.Location,
+	}, nil)
+	mdi.On("GetContractListener", context.Background(), "ns1", "not-ours").Return(&core.ContractListener{
+		Interface: &fftypes.FFIReference{ID: fftypes.NewUUID()},
+		Location:  api.Location,
+	}, nil)
+
+	err := cm.ReconcileContractAPIListeners(context.Background(), api)
+	assert.NoError(t, err)
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestReconcileContractAPIListenersReplace(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	existing := &core.ContractListener{
+		ID:        fftypes.NewUUID(),
+		Interface: &fftypes.FFIReference{ID: api.Interface.ID},
+		Location:  fftypes.JSONAnyPtr(`{"address":"0x456"}`),
+	}
+	event := &fftypes.FFIEvent{
+		FFIEventDefinition: fftypes.FFIEventDefinition{
+			Name: "changed",
+		},
+	}
+
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeListener, api.Location).Return(api.Location, nil)
+	mdi.On("GetContractListener", context.Background(), "ns1", "simple-changed").Return(existing, nil).Once()
+	mbi.On("DeleteContractListener", context.Background(), existing, true).Return(nil)
+	mdi.On("DeleteContractListenerByID", context.Background(), "ns1", existing.ID).Return(nil)
+	mdi.On("GetContractListener", context.Background(), "ns1", "simple-changed").Return(nil, nil)
+	mdi.On("GetFFIByID", context.Background(), "ns1", api.Interface.ID).Return(&fftypes.FFI{}, nil)
+	mdi.On("GetFFIEvent", context.Background(), "ns1", api.Interface.ID, "changed").Return(event, nil)
+	mbi.On("GenerateEventSignature", context.Background(), mock.Anything).Return("changed", nil)
+	mbi.On("GenerateEventSignatureWithLocation", context.Background(), mock.Anything, mock.Anything).Return("0x123:changed", nil)
+	mdi.On("GetContractListeners", context.Background(), "ns1", mock.Anything).Return(nil, nil, nil)
+	mbi.On("AddContractListener", context.Background(), mock.Anything, "").Return(fmt.Errorf("pop"))
+
+	err := cm.ReconcileContractAPIListeners(context.Background(), api)
+	assert.EqualError(t, err, "pop")
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestReconcileContractAPIListenersReplaceDeleteFail(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	existing := &core.ContractListener{
+		ID:        fftypes.NewUUID(),
+		Interface: &fftypes.FFIReference{ID: api.Interface.ID},
+		Location:  fftypes.JSONAnyPtr(`{"address":"0x456"}`),
+	}
+
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeListener, api.Location).Return(api.Location, nil)
+	mdi.On("GetContractListener", context.Background(), "ns1", "simple-changed").Return(existing, nil)
+	mbi.On("DeleteContractListener", context.Background(), existing, true).Return(nil)
+	mdi.On("DeleteContractListenerByID", context.Background(), "ns1", existing.ID).Return(fmt.Errorf("pop"))
+
+	err := cm.ReconcileContractAPIListeners(context.Background(), api)
+	assert.EqualError(t, err, "pop")
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestReconcileContractAPIListenersReplaceBlockchainFail(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	existing := &core.ContractListener{
+		ID:        fftypes.NewUUID(),
+		Interface: &fftypes.FFIReference{ID: api.Interface.ID},
+		Location:  fftypes.JSONAnyPtr(`{"address":"0x456"}`),
+	}
+
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeListener, api.Location).Return(api.Location, nil)
+	mdi.On("GetContractListener", context.Background(), "ns1", "simple-changed").Return(existing, nil)
+	mbi.On("DeleteContractListener", context.Background(), existing, true).Return(fmt.Errorf("pop"))
+
+	err := cm.ReconcileContractAPIListeners(context.Background(), api)
+	assert.EqualError(t, err, "pop")
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
 func TestDeleteContractListener(t *testing.T) {
 	cm := newTestContractManager()
 	mbi := cm.blockchain.(*blockchainmocks.Plugin)
@@ -3482,7 +3895,7 @@ func TestInvokeContractAPI(t *testing.T) {
 
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
 	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
 		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
@@ -3665,6 +4078,66 @@ func TestResolveContractAPI(t *testing.T) {
 	mdb.AssertExpectations(t)
 }
 
+func TestResolveContractAPIListenerTemplates(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	api.ID = fftypes.NewUUID()
+
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
+	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", api.Interface.ID).Return(&fftypes.FFI{}, nil)
+	mdb.On("GetFFIEvent", mock.Anything, "ns1", api.Interface.ID, "changed").Return(&fftypes.FFIEvent{}, nil)
+
+	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
+	assert.NoError(t, err)
+
+	mbi.AssertExpectations(t)
+	mdb.AssertExpectations(t)
+}
+
+func TestResolveContractAPIListenerTemplateEventNotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	api.ID = fftypes.NewUUID()
+
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
+	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", api.Interface.ID).Return(&fftypes.FFI{}, nil)
+	mdb.On("GetFFIEvent", mock.Anything, "ns1", api.Interface.ID, "changed").Return(nil, nil)
+
+	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
+	assert.Regexp(t, "FF10370", err)
+
+	mbi.AssertExpectations(t)
+	mdb.AssertExpectations(t)
+}
+
+func TestResolveContractAPIListenerTemplateEventFail(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	api := testTemplatedContractAPI()
+	api.ID = fftypes.NewUUID()
+
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
+	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", api.Interface.ID).Return(&fftypes.FFI{}, nil)
+	mdb.On("GetFFIEvent", mock.Anything, "ns1", api.Interface.ID, "changed").Return(nil, fmt.Errorf("pop"))
+
+	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
+	assert.EqualError(t, err, "pop")
+
+	mbi.AssertExpectations(t)
+	mdb.AssertExpectations(t)
+}
+
 func TestResolveContractAPIValidateFail(t *testing.T) {
 	cm := newTestContractManager()
 
@@ -0,0 +1,274 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Reconciler compares each contract listener of a namespace with the checkpoint of its connector on startup.
+// Where the connector has moved past the latest event recorded locally, the listener is rewound so any events
+// missed while the node was down are re-delivered. Events already recorded are ignored on re-delivery.
+type Reconciler interface {
+	Start()
+	WaitStop()
+	Report() *core.ListenerReconciliationReport
+}
+
+type reconciler struct {
+	ctx          context.Context
+	namespace    string
+	database     database.Plugin
+	blockchain   blockchain.Plugin
+	maxBlocks    int64
+	pollInterval time.Duration
+	timeout      time.Duration
+	reportMux    sync.Mutex
+	report       *core.ListenerReconciliationReport
+	done         chan struct{}
+}
+
+type pendingBackfill struct {
+	listener *core.ContractListener
+	result   *core.ListenerReconciliation
+}
+
+// NewReconciler returns nil if reconciliation is not enabled, or the blockchain plugin cannot rewind listeners
+func NewReconciler(ctx context.Context, ns string, di database.Plugin, bi blockchain.Plugin) (Reconciler, error) {
+	if !config.GetBool(coreconfig.EventReconcileEnabled) {
+		return nil, nil
+	}
+	if di == nil || bi == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "ListenerReconciler")
+	}
+	if !bi.Capabilities().ListenerReset {
+		log.L(ctx).Warnf("Contract listener reconciliation is disabled for namespace '%s' as blockchain plugin '%s' cannot rewind listeners", ns, bi.Name())
+		return nil, nil
+	}
+	return &reconciler{
+		ctx:          ctx,
+		namespace:    ns,
+		database:     di,
+		blockchain:   bi,
+		maxBlocks:    config.GetInt64(coreconfig.EventReconcileMaxBlocks),
+		pollInterval: config.GetDuration(coreconfig.EventReconcilePollInterval),
+		timeout:      config.GetDuration(coreconfig.EventReconcileTimeout),
+	}, nil
+}
+
+func (r *reconciler) Start() {
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		r.reconcile(r.ctx)
+	}()
+}
+
+func (r *reconciler) WaitStop() {
+	if r.done != nil {
+		<-r.done
+	}
+}
+
+// Report returns a copy of the latest report, or nil if reconciliation has not started
+func (r *reconciler) Report() *core.ListenerReconciliationReport {
+	r.reportMux.Lock()
+	defer r.reportMux.Unlock()
+	if r.report == nil {
+		return nil
+	}
+	report := *r.report
+	report.Listeners = make([]*core.ListenerReconciliation, len(r.report.Listeners))
+	for i, l := range r.report.Listeners {
+		result := *l
+		report.Listeners[i] = &result
+	}
+	return &report
+}
+
+func (r *reconciler) publish(report *core.ListenerReconciliationReport) {
+	r.reportMux.Lock()
+	defer r.reportMux.Unlock()
+	r.report = report
+}
+
+func (r *reconciler) reconcile(ctx context.Context) {
+	report := &core.ListenerReconciliationReport{
+		Namespace: r.namespace,
+		Started:   fftypes.Now(),
+		Listeners: []*core.ListenerReconciliation{},
+	}
+	r.publish(report)
+
+	var backfills []*pendingBackfill
+	var page uint64
+	var pageSize uint64 = 50
+	for {
+		f := database.ContractListenerQueryFactory.NewFilterLimit(ctx, pageSize).And().Skip(page * pageSize)
+		listeners, _, err := r.database.GetContractListeners(ctx, r.namespace, f)
+		if err != nil {
+			log.L(ctx).Errorf("Contract listener reconciliation failed: %s", err)
+			return
+		}
+		if len(listeners) == 0 {
+			break
+		}
+		for _, l := range listeners {
+			result, backfill := r.checkListener(ctx, l)
+			r.reportMux.Lock()
+			report.Listeners = append(report.Listeners, result)
+			r.reportMux.Unlock()
+			if backfill {
+				backfills = append(backfills, &pendingBackfill{listener: l, result: result})
+			}
+		}
+		page++
+	}
+
+	r.waitForBackfill(ctx, backfills)
+
+	r.reportMux.Lock()
+	for _, result := range report.Listeners {
+		switch result.Status {
+		case core.ListenerReconciliationStatusInSync:
+			report.InSync++
+		case core.ListenerReconciliationStatusSkipped:
+			report.Skipped++
+		case core.ListenerReconciliationStatusBackfilled:
+			report.Backfilled++
+		default:
+			report.Failed++
+		}
+	}
+	report.Completed = fftypes.Now()
+	r.reportMux.Unlock()
+	log.L(ctx).Infof("Contract listener reconciliation complete. InSync=%d Backfilled=%d Skipped=%d Failed=%d", report.InSync, report.Backfilled, report.Skipped, report.Failed)
+}
+
+// checkListener compares the latest event recorded locally for a listener with the checkpoint of the connector,
+// and rewinds the listener to the block of that event if the connector has moved past it
+func (r *reconciler) checkListener(ctx context.Context, l *core.ContractListener) (result *core.ListenerReconciliation, backfill bool) {
+	result = &core.ListenerReconciliation{
+		Listener:  l.ID,
+		Name:      l.Name,
+		BackendID: l.BackendID,
+	}
+	fail := func(err error) (*core.ListenerReconciliation, bool) {
+		log.L(ctx).Errorf("Failed to reconcile listener %s:%s (BackendID=%s): %s", l.Signature, l.ID, l.BackendID, err)
+		result.Status = core.ListenerReconciliationStatusFailed
+		result.Error = err.Error()
+		return result, false
+	}
+
+	fb := database.BlockchainEventQueryFactory.NewFilter(ctx).Sort("-protocolid").Limit(1)
+	latestEvents, _, err := r.database.GetBlockchainEvents(ctx, r.namespace, fb.Eq("listener", l.ID))
+	if err != nil {
+		return fail(err)
+	}
+	if len(latestEvents) == 0 {
+		// Without a local event we cannot tell which blocks were missed, and rewinding a listener
+		// that started from the newest block would deliver events from before it was created
+		result.Status = core.ListenerReconciliationStatusSkipped
+		return result, false
+	}
+	if result.LocalBlock, err = r.blockchain.GetProtocolIDBlock(ctx, latestEvents[0].ProtocolID); err != nil {
+		return fail(err)
+	}
+
+	checkpoint, err := r.blockchain.GetContractListenerCheckpoint(ctx, r.namespace, l.BackendID)
+	if err == nil && checkpoint == nil {
+		err = i18n.NewError(ctx, coremsgs.MsgListenerNotFoundInConnector, l.ID)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	result.ConnectorBlock = checkpoint.Block
+	if checkpoint.Block <= result.LocalBlock {
+		result.Status = core.ListenerReconciliationStatusInSync
+		return result, false
+	}
+
+	// Replay from the block of the latest local event, so later events in the same block are included
+	result.FromBlock = result.LocalBlock
+	if r.maxBlocks > 0 && checkpoint.Block-result.FromBlock > r.maxBlocks {
+		result.FromBlock = checkpoint.Block - r.maxBlocks
+		result.Truncated = true
+	}
+	log.L(ctx).Infof("Rewinding listener %s:%s (BackendID=%s) from block %d to %d", l.Signature, l.ID, l.BackendID, checkpoint.Block, result.FromBlock)
+	if err := r.blockchain.ResetContractListener(ctx, r.namespace, l.BackendID, result.FromBlock); err != nil {
+		return fail(err)
+	}
+	result.Status = core.ListenerReconciliationStatusBackfilling
+	return result, true
+}
+
+// waitForBackfill polls the connector until each rewound listener has caught up to the block it had reached
+// before it was rewound, or the timeout expires
+func (r *reconciler) waitForBackfill(ctx context.Context, backfills []*pendingBackfill) {
+	deadline := time.Now().Add(r.timeout)
+	for len(backfills) > 0 {
+		remaining := make([]*pendingBackfill, 0, len(backfills))
+		for _, b := range backfills {
+			checkpoint, err := r.blockchain.GetContractListenerCheckpoint(ctx, r.namespace, b.listener.BackendID)
+			if err == nil && checkpoint == nil {
+				err = i18n.NewError(ctx, coremsgs.MsgListenerNotFoundInConnector, b.listener.ID)
+			}
+			r.reportMux.Lock()
+			switch {
+			case err != nil:
+				b.result.Status = core.ListenerReconciliationStatusFailed
+				b.result.Error = err.Error()
+			case !checkpoint.Catchup && checkpoint.Block >= b.result.ConnectorBlock:
+				b.result.Status = core.ListenerReconciliationStatusBackfilled
+			default:
+				remaining = append(remaining, b)
+			}
+			r.reportMux.Unlock()
+		}
+		backfills = remaining
+		if len(backfills) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			r.reportMux.Lock()
+			for _, b := range backfills {
+				b.result.Status = core.ListenerReconciliationStatusFailed
+				b.result.Error = i18n.NewError(ctx, coremsgs.MsgListenerReconcileTimeout, r.timeout, b.listener.ID, b.result.ConnectorBlock).Error()
+			}
+			r.reportMux.Unlock()
+			return
+		}
+		select {
+		case <-time.After(r.pollInterval):
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Contract listener reconciliation exiting")
+			return
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestReconciler(t *testing.T) (*reconciler, *databasemocks.Plugin, *blockchainmocks.Plugin) {
+	coreconfig.Reset()
+	config.Set(coreconfig.EventReconcileEnabled, true)
+	config.Set(coreconfig.EventReconcilePollInterval, "1ms")
+	mdi := &databasemocks.Plugin{}
+	mbi := &blockchainmocks.Plugin{}
+	mbi.On("Capabilities").Return(&blockchain.Capabilities{ListenerReset: true})
+	r, err := NewReconciler(context.Background(), "ns1", mdi, mbi)
+	assert.NoError(t, err)
+	return r.(*reconciler), mdi, mbi
+}
+
+func newTestListener(backendID string) *core.ContractListener {
+	return &core.ContractListener{
+		ID:        fftypes.NewUUID(),
+		Name:      backendID,
+		BackendID: backendID,
+	}
+}
+
+func forListener(l *core.ContractListener) interface{} {
+	return mock.MatchedBy(func(f interface{}) bool {
+		return strings.Contains(fmt.Sprint(f), l.ID.String())
+	})
+}
+
+func TestNewReconcilerDisabled(t *testing.T) {
+	coreconfig.Reset()
+	r, err := NewReconciler(context.Background(), "ns1", &databasemocks.Plugin{}, &blockchainmocks.Plugin{})
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestNewReconcilerNilDeps(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.EventReconcileEnabled, true)
+	_, err := NewReconciler(context.Background(), "ns1", nil, nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestNewReconcilerNoListenerReset(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.EventReconcileEnabled, true)
+	mbi := &blockchainmocks.Plugin{}
+	mbi.On("Capabilities").Return(&blockchain.Capabilities{})
+	mbi.On("Name").Return("fabric")
+	r, err := NewReconciler(context.Background(), "ns1", &databasemocks.Plugin{}, mbi)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+	mbi.AssertExpectations(t)
+}
+
+func TestReconcileBackfill(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+	assert.Nil(t, r.Report())
+
+	l1 := newTestListener("sub1")
+	l2 := newTestListener("sub2")
+	l3 := newTestListener("sub3")
+	mdi.On("GetContractListeners", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractListener{l1, l2, l3}, nil, nil).Once()
+	mdi.On("GetContractListeners", mock.Anything, "ns1", mock.Anything).Return([]*core.ContractListener{}, nil, nil).Once()
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", forListener(l1)).Return([]*core.BlockchainEvent{{ProtocolID: "000000000100/000000/000000"}}, nil, nil)
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", forListener(l2)).Return([]*core.BlockchainEvent{}, nil, nil)
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", forListener(l3)).Return([]*core.BlockchainEvent{{ProtocolID: "000000000300/000000/000000"}}, nil, nil)
+	mbi.On("GetProtocolIDBlock", mock.Anything, "000000000100/000000/000000").Return(int64(100), nil)
+	mbi.On("GetProtocolIDBlock", mock.Anything, "000000000300/000000/000000").Return(int64(300), nil)
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(&blockchain.ListenerCheckpoint{Block: 200}, nil).Once()
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(&blockchain.ListenerCheckpoint{Block: 150, Catchup: true}, nil).Once()
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(&blockchain.ListenerCheckpoint{Block: 201}, nil).Once()
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub3").Return(&blockchain.ListenerCheckpoint{Block: 300}, nil)
+	mbi.On("ResetContractListener", mock.Anything, "ns1", "sub1", int64(100)).Return(nil)
+
+	r.Start()
+	r.WaitStop()
+
+	report := r.Report()
+	assert.NotNil(t, report.Completed)
+	assert.Equal(t, 1, report.Backfilled)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 1, report.InSync)
+	assert.Equal(t, 0, report.Failed)
+	assert.Len(t, report.Listeners, 3)
+	assert.Equal(t, core.ListenerReconciliationStatusBackfilled, report.Listeners[0].Status)
+	assert.Equal(t, int64(100), report.Listeners[0].LocalBlock)
+	assert.Equal(t, int64(200), report.Listeners[0].ConnectorBlock)
+	assert.Equal(t, int64(100), report.Listeners[0].FromBlock)
+	assert.False(t, report.Listeners[0].Truncated)
+	assert.Equal(t, core.ListenerReconciliationStatusSkipped, report.Listeners[1].Status)
+	assert.Equal(t, core.ListenerReconciliationStatusInSync, report.Listeners[2].Status)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestReconcileListenersFail(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+
+	mdi.On("GetContractListeners", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	r.Start()
+	r.WaitStop()
+
+	report := r.Report()
+	assert.Nil(t, report.Completed)
+	assert.Empty(t, report.Listeners)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestReconcileTruncated(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+	r.maxBlocks = 50
+
+	l := newTestListener("sub1")
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ProtocolID: "000000000100/000000/000000"}}, nil, nil)
+	mbi.On("GetProtocolIDBlock", mock.Anything, "000000000100/000000/000000").Return(int64(100), nil)
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(&blockchain.ListenerCheckpoint{Block: 200}, nil)
+	mbi.On("ResetContractListener", mock.Anything, "ns1", "sub1", int64(150)).Return(nil)
+
+	result, backfill := r.checkListener(context.Background(), l)
+	assert.True(t, backfill)
+	assert.Equal(t, core.ListenerReconciliationStatusBackfilling, result.Status)
+	assert.Equal(t, int64(150), result.FromBlock)
+	assert.True(t, result.Truncated)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestReconcileCheckListenerEventsFail(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	result, backfill := r.checkListener(context.Background(), newTestListener("sub1"))
+	assert.False(t, backfill)
+	assert.Equal(t, core.ListenerReconciliationStatusFailed, result.Status)
+	assert.Equal(t, "pop", result.Error)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestReconcileCheckListenerBadProtocolID(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ProtocolID: "bad"}}, nil, nil)
+	mbi.On("GetProtocolIDBlock", mock.Anything, "bad").Return(int64(-1), fmt.Errorf("pop"))
+
+	result, backfill := r.checkListener(context.Background(), newTestListener("sub1"))
+	assert.False(t, backfill)
+	assert.Equal(t, core.ListenerReconciliationStatusFailed, result.Status)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestReconcileCheckListenerNotFound(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ProtocolID: "000000000100/000000/000000"}}, nil, nil)
+	mbi.On("GetProtocolIDBlock", mock.Anything, "000000000100/000000/000000").Return(int64(100), nil)
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(nil, nil)
+
+	result, backfill := r.checkListener(context.Background(), newTestListener("sub1"))
+	assert.False(t, backfill)
+	assert.Equal(t, core.ListenerReconciliationStatusFailed, result.Status)
+	assert.Regexp(t, "FF10591", result.Error)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestReconcileCheckListenerResetFail(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ProtocolID: "000000000100/000000/000000"}}, nil, nil)
+	mbi.On("GetProtocolIDBlock", mock.Anything, "000000000100/000000/000000").Return(int64(100), nil)
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(&blockchain.ListenerCheckpoint{Block: 200}, nil)
+	mbi.On("ResetContractListener", mock.Anything, "ns1", "sub1", int64(100)).Return(fmt.Errorf("pop"))
+
+	result, backfill := r.checkListener(context.Background(), newTestListener("sub1"))
+	assert.False(t, backfill)
+	assert.Equal(t, core.ListenerReconciliationStatusFailed, result.Status)
+	assert.Equal(t, "pop", result.Error)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestWaitForBackfillFail(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+
+	b1 := &pendingBackfill{listener: newTestListener("sub1"), result: &core.ListenerReconciliation{ConnectorBlock: 200}}
+	b2 := &pendingBackfill{listener: newTestListener("sub2"), result: &core.ListenerReconciliation{ConnectorBlock: 200}}
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(nil, fmt.Errorf("pop"))
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub2").Return(nil, nil)
+
+	r.waitForBackfill(context.Background(), []*pendingBackfill{b1, b2})
+	assert.Equal(t, core.ListenerReconciliationStatusFailed, b1.result.Status)
+	assert.Equal(t, "pop", b1.result.Error)
+	assert.Equal(t, core.ListenerReconciliationStatusFailed, b2.result.Status)
+	assert.Regexp(t, "FF10591", b2.result.Error)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestWaitForBackfillTimeout(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+	r.timeout = 0
+
+	b := &pendingBackfill{listener: newTestListener("sub1"), result: &core.ListenerReconciliation{ConnectorBlock: 200}}
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(&blockchain.ListenerCheckpoint{Block: 150, Catchup: true}, nil)
+
+	r.waitForBackfill(context.Background(), []*pendingBackfill{b})
+	assert.Equal(t, core.ListenerReconciliationStatusFailed, b.result.Status)
+	assert.Regexp(t, "FF10592", b.result.Error)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestWaitForBackfillContextClosed(t *testing.T) {
+	r, mdi, mbi := newTestReconciler(t)
+	r.pollInterval = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	b := &pendingBackfill{listener: newTestListener("sub1"), result: &core.ListenerReconciliation{
+		ConnectorBlock: 200,
+		Status:         core.ListenerReconciliationStatusBackfilling,
+	}}
+	mbi.On("GetContractListenerCheckpoint", mock.Anything, "ns1", "sub1").Return(&blockchain.ListenerCheckpoint{Block: 150, Catchup: true}, nil)
+
+	r.waitForBackfill(ctx, []*pendingBackfill{b})
+	assert.Equal(t, core.ListenerReconciliationStatusBackfilling, b.result.Status)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
@@ -31,6 +31,16 @@ const (
 	PluginConfigType = "type"
 	// PluginBroadcastName is the plugin name to be sent in multi-party broadcasts, if it differs from the local plugin name
 	PluginBroadcastName = "broadcastName"
+	// PluginStartupWait is how long a namespace retries starting a plugin connector, before giving up on it
+	PluginStartupWait = "startup.wait"
+	// PluginStartupOptional allows a namespace to start in a degraded mode, while the plugin connector continues to start in the background
+	PluginStartupOptional = "startup.optional"
+	// PluginStartupRetryInitDelay is the initial delay between attempts to start a plugin connector
+	PluginStartupRetryInitDelay = "startup.retry.initDelay"
+	// PluginStartupRetryMaxDelay is the maximum delay between attempts to start a plugin connector
+	PluginStartupRetryMaxDelay = "startup.retry.maxDelay"
+	// PluginStartupRetryFactor is the backoff factor between attempts to start a plugin connector
+	PluginStartupRetryFactor = "startup.retry.factor"
 	// NamespaceName is the short name for a pre-defined namespace
 	NamespaceName = "name"
 	// NamespaceName is the long description for a pre-defined namespace
@@ -45,6 +55,12 @@ const (
 	NamespaceTLSConfigTLSSection = "tls"
 	// NamespaceDefaultKey is the default signing key for blockchain transactions within this namespace
 	NamespaceDefaultKey = "defaultKey"
+	// NamespaceReadOnly disables all submission APIs for a namespace, which only consumes and indexes data from the network
+	NamespaceReadOnly = "readOnly"
+	// NamespaceSandbox marks a namespace as safe for synthetic traffic, enabling the load generator
+	NamespaceSandbox = "sandbox"
+	// NamespaceRequiredConfirmations is the number of blocks an event must be buried by before its state is confirmed in the namespace
+	NamespaceRequiredConfirmations = "requiredConfirmations"
 	// NamespaceAssetKeyNormalization mechanism to normalize keys before using them. Valid options: "blockchain_plugin" - use blockchain plugin (default), "none" - do not attempt normalization
 	NamespaceAssetKeyNormalization = "asset.manager.keyNormalization"
 	// NamespaceMultiparty contains the multiparty configuration for a namespace
@@ -71,6 +87,62 @@ const (
 	NamespaceMultipartyContractLocation = "location"
 	// NamespaceMultipartyContractOptions is an object of additional blockchain-specific configuration
 	NamespaceMultipartyContractOptions = "options"
+	// NamespaceMultipartyBootstrapEnabled enables automatic registration of the local org and node on startup
+	NamespaceMultipartyBootstrapEnabled = "bootstrap.enabled"
+	// NamespaceMultipartyBootstrapRetryInitDelay is the initial delay between automatic registration attempts
+	NamespaceMultipartyBootstrapRetryInitDelay = "bootstrap.retry.initDelay"
+	// NamespaceMultipartyBootstrapRetryMaxDelay is the maximum delay between automatic registration attempts
+	NamespaceMultipartyBootstrapRetryMaxDelay = "bootstrap.retry.maxDelay"
+	// NamespaceMultipartyBootstrapRetryFactor is the backoff factor between automatic registration attempts
+	NamespaceMultipartyBootstrapRetryFactor = "bootstrap.retry.factor"
+	// NamespaceMultipartyApprovalsRequired is the number of approvals required before a gated definition is processed
+	NamespaceMultipartyApprovalsRequired = "approvals.required"
+	// NamespaceMultipartyApprovalsTags is the list of definition tags that require approval
+	NamespaceMultipartyApprovalsTags = "approvals.tags"
+	// NamespaceMultipartyApprovalsApprovers is the list of org DIDs whose approvals are counted
+	NamespaceMultipartyApprovalsApprovers = "approvals.approvers"
+	// NamespaceMultipartyPayloadSigningEnabled enables signing the payload of outbound batches with an org-level key
+	NamespaceMultipartyPayloadSigningEnabled = "payloadSigning.enabled"
+	// NamespaceMultipartyPayloadSigningKeyFile is a PEM encoded PKCS #8 private key file used to sign batch payloads
+	NamespaceMultipartyPayloadSigningKeyFile = "payloadSigning.keyFile"
+	// NamespaceMultipartyPayloadSigningKeyID is the key in the KMS of the identity plugin used to sign batch payloads
+	NamespaceMultipartyPayloadSigningKeyID = "payloadSigning.keyId"
+	// NamespaceMultipartyPayloadSigningRequired rejects inbound batches that do not have a valid payload signature
+	NamespaceMultipartyPayloadSigningRequired = "payloadSigning.required"
+	// NamespaceQuotasMessagesPerDay is the maximum number of messages that local identities can send in a namespace each day (UTC)
+	NamespaceQuotasMessagesPerDay = "quotas.messagesPerDay"
+	// NamespaceQuotasBlobBytes is the maximum total size of blobs that can be stored in a namespace
+	NamespaceQuotasBlobBytes = "quotas.blobBytes"
+	// NamespaceQuotasContractListeners is the maximum number of contract listeners in a namespace
+	NamespaceQuotasContractListeners = "quotas.contractListeners"
+	// NamespaceQuotasSubscriptions is the maximum number of subscriptions in a namespace
+	NamespaceQuotasSubscriptions = "quotas.subscriptions"
+	// NamespaceRateLimit is the section containing the rate limit of each route group of a namespace
+	NamespaceRateLimit = "rateLimit"
+	// NamespaceRateLimitRequestsPerSecond is the rate at which each identity can call a route group
+	NamespaceRateLimitRequestsPerSecond = "requestsPerSecond"
+	// NamespaceRateLimitBurst is the number of requests each identity can make to a route group at once
+	NamespaceRateLimitBurst = "burst"
+	// OperationsApprovalPolicyType is the operation type an approval policy applies to
+	OperationsApprovalPolicyType = "type"
+	// OperationsApprovalPolicyMinAmount is the smallest token transfer amount that requires approval
+	OperationsApprovalPolicyMinAmount = "minAmount"
+	// OperationsApprovalPolicyApprovers is the number of distinct local approvers required before submission
+	OperationsApprovalPolicyApprovers = "approvers"
+	// OperationsApprovalPolicyExpiry is how long approvals can be given before the operation is failed
+	OperationsApprovalPolicyExpiry = "expiry"
+	// OperationsRetryPolicyType is the operation type an automatic retry policy applies to
+	OperationsRetryPolicyType = "type"
+	// OperationsRetryPolicyMaxAttempts is the maximum number of attempts, including the first, for an operation
+	OperationsRetryPolicyMaxAttempts = "maxAttempts"
+	// OperationsRetryPolicyInitialDelay is the delay before the first automatic retry
+	OperationsRetryPolicyInitialDelay = "initialDelay"
+	// OperationsRetryPolicyMaxDelay is the maximum delay between automatic retries
+	OperationsRetryPolicyMaxDelay = "maxDelay"
+	// OperationsRetryPolicyFactor is the backoff factor applied to the delay after each retry
+	OperationsRetryPolicyFactor = "factor"
+	// OperationsRetryPolicyJitter is the fraction of the delay to randomly add or subtract
+	OperationsRetryPolicyJitter = "jitter"
 )
 
 // The following keys can be access from the root configuration.
@@ -131,6 +203,30 @@ var (
 
 	// CacheEnabled determines whether cache will be enabled or not, default to true
 	CacheEnabled = ffc("cache.enabled")
+	// CacheAdaptiveEnabled whether the limit of each cache is adjusted to keep the heap below a memory limit
+	CacheAdaptiveEnabled = ffc("cache.adaptive.enabled")
+	// CacheAdaptiveInterval how often the memory used is checked, and the caches resized
+	CacheAdaptiveInterval = ffc("cache.adaptive.interval")
+	// CacheAdaptiveMemoryLimit the heap size the caches are sized to keep within, defaulting to the Go runtime memory limit
+	CacheAdaptiveMemoryLimit = ffc("cache.adaptive.memoryLimit")
+	// CacheAdaptiveHighWatermark the fraction of the memory limit above which caches are shrunk
+	CacheAdaptiveHighWatermark = ffc("cache.adaptive.highWatermark")
+	// CacheAdaptiveLowWatermark the fraction of the memory limit below which hot caches are grown
+	CacheAdaptiveLowWatermark = ffc("cache.adaptive.lowWatermark")
+	// CacheAdaptiveMinRatio the smallest a cache is shrunk to, relative to its configured limit
+	CacheAdaptiveMinRatio = ffc("cache.adaptive.minRatio")
+	// CacheAdaptiveMaxRatio the largest a cache is grown to, relative to its configured limit
+	CacheAdaptiveMaxRatio = ffc("cache.adaptive.maxRatio")
+	// CacheAdaptivePriority the caches that are always treated as hot
+	CacheAdaptivePriority = ffc("cache.adaptive.priority")
+	// CacheDistributedEnabled whether cache invalidations are shared with other FireFly instances using the same database
+	CacheDistributedEnabled = ffc("cache.distributed.enabled")
+	// CacheDistributedType the backend that cache invalidations are shared through
+	CacheDistributedType = ffc("cache.distributed.type")
+	// CacheDistributedRedisURL the URL of the Redis server, for the redis type
+	CacheDistributedRedisURL = ffc("cache.distributed.redis.url")
+	// CacheDistributedRedisChannel the Redis pub/sub channel cache invalidations are published to
+	CacheDistributedRedisChannel = ffc("cache.distributed.redis.channel")
 
 	// AddressResolver cache config
 	CacheAddressResolverLimit = ffc("cache.addressresolver.limit")
@@ -175,6 +271,10 @@ var (
 	CacheBlockchainTTL   = ffc("cache.blockchain.ttl")
 	CacheBlockchainLimit = ffc("cache.blockchain.limit")
 
+	// Negative cache config, for lookups that found nothing
+	CacheNegativeLimit = ffc("cache.negative.limit")
+	CacheNegativeTTL   = ffc("cache.negative.ttl")
+
 	// Operations cache config
 	CacheOperationsLimit = ffc("cache.operations.limit")
 	CacheOperationsTTL   = ffc("cache.operations.ttl")
@@ -183,6 +283,16 @@ var (
 	CacheMethodsLimit = ffc("cache.methods.limit")
 	CacheMethodsTTL   = ffc("cache.methods.ttl")
 
+	// DownloadCatchUpEnabled enables bulk download of historical broadcast batches while the node catches up with the network
+	DownloadCatchUpEnabled = ffc("download.catchup.enabled")
+	// DownloadCatchUpWorkers is the number of batches the catch-up downloads in parallel
+	DownloadCatchUpWorkers = ffc("download.catchup.workers")
+	// DownloadCatchUpBatchSize is the number of BatchPin events the catch-up reads from the DB in each page
+	DownloadCatchUpBatchSize = ffc("download.catchup.batchSize")
+	// DownloadCatchUpPollInterval is how long the catch-up waits before checking again for new BatchPin events
+	DownloadCatchUpPollInterval = ffc("download.catchup.pollInterval")
+	// DownloadCatchUpIdleTimeout is how long the catch-up must go without new BatchPin events before it completes
+	DownloadCatchUpIdleTimeout = ffc("download.catchup.idleTimeout")
 	// DownloadWorkerCount is the number of download workers created to pull data from shared storage to the local DX
 	DownloadWorkerCount = ffc("download.worker.count")
 	// DownloadWorkerQueueLength is the length of the work queue in the channel to the workers - defaults to 2x the worker count
@@ -195,6 +305,8 @@ var (
 	DownloadRetryMaxDelay = ffc("download.retry.maxDelay")
 	// DownloadRetryFactor is the backoff factor to use for retries
 	DownloadRetryFactor = ffc("download.retry.factor")
+	// PrivateMessagingAcksEnabled whether to acknowledge received private batches back to their sender, once persisted and aggregated
+	PrivateMessagingAcksEnabled = ffc("privatemessaging.acks.enabled")
 	// PrivateMessagingBatchAgentTimeout how long to keep around a batching agent for a sending identity before disposal
 	PrivateMessagingBatchAgentTimeout = ffc("privatemessaging.batch.agentTimeout")
 	// PrivateMessagingBatchSize is the maximum size of a batch for broadcast messages
@@ -203,12 +315,30 @@ var (
 	PrivateMessagingBatchPayloadLimit = ffc("privatemessaging.batch.payloadLimit")
 	// PrivateMessagingBatchTimeout is the timeout to wait for a batch to fill, before sending
 	PrivateMessagingBatchTimeout = ffc("privatemessaging.batch.timeout")
+	// PrivateMessagingLatencyWindow the period of recent batch deliveries measured for the latency of group members
+	PrivateMessagingLatencyWindow = ffc("privatemessaging.latency.window")
+	// PrivateMessagingLatencySampleLimit the maximum number of batch deliveries measured for the latency of a group
+	PrivateMessagingLatencySampleLimit = ffc("privatemessaging.latency.sampleLimit")
+	// PrivateMessagingQueueEnabled whether to persist outbound data exchange sends in a prioritized database queue
+	PrivateMessagingQueueEnabled = ffc("privatemessaging.queue.enabled")
+	// PrivateMessagingQueuePageSize the number of queued sends to read from the database at a time
+	PrivateMessagingQueuePageSize = ffc("privatemessaging.queue.pageSize")
+	// PrivateMessagingQueuePollInterval how often to check the queue for sends, in addition to being notified as they are queued
+	PrivateMessagingQueuePollInterval = ffc("privatemessaging.queue.pollInterval")
 	// PrivateMessagingRetryFactor the backoff factor to use for retry of database operations
 	PrivateMessagingRetryFactor = ffc("privatemessaging.retry.factor")
 	// PrivateMessagingRetryInitDelay the initial delay to use for retry of data base operations
 	PrivateMessagingRetryInitDelay = ffc("privatemessaging.retry.initDelay")
 	// PrivateMessagingRetryMaxDelay the maximum delay to use for retry of data base operations
 	PrivateMessagingRetryMaxDelay = ffc("privatemessaging.retry.maxDelay")
+	// HealthProbeEnabled whether each namespace periodically probes the plugins it depends on
+	HealthProbeEnabled = ffc("health.probe.enabled")
+	// HealthProbeInterval the time between health probes of the plugins of a namespace
+	HealthProbeInterval = ffc("health.probe.interval")
+	// HealthProbeTimeout the maximum time to wait for a plugin to respond to a health probe
+	HealthProbeTimeout = ffc("health.probe.timeout")
+	// HealthProbeDegradedLatency the probe latency above which a plugin is reported as degraded
+	HealthProbeDegradedLatency = ffc("health.probe.degradedLatency")
 	// DatabaseType the type of the database interface plugin to use
 	HistogramsMaxChartRows = ffc("histograms.maxChartRows")
 	// TokensList is the root key containing a list of supported token connectors
@@ -231,6 +361,16 @@ var (
 	DebugPort = ffc("debug.port")
 	// DebugAddress the HTTP interface for the debugger to listen on
 	DebugAddress = ffc("debug.address")
+	// DebugCaptureMaxLines the maximum number of log lines held in memory by a log capture
+	DebugCaptureMaxLines = ffc("debug.capture.maxLines")
+	// DebugCaptureMaxDuration the maximum time a log capture can run for
+	DebugCaptureMaxDuration = ffc("debug.capture.maxDuration")
+	// DebugDiagnosticsErrorEvents the number of recent error events from each namespace included in a diagnostics bundle
+	DebugDiagnosticsErrorEvents = ffc("debug.diagnostics.errorEvents")
+	// DisclosuresVerifierInterval the time between scans for disclosures that this node has not yet verified
+	DisclosuresVerifierInterval = ffc("disclosures.verifier.interval")
+	// DisclosuresVerifierBatchSize the maximum number of disclosures verified in each scan
+	DisclosuresVerifierBatchSize = ffc("disclosures.verifier.batchSize")
 	// EventTransportsDefault the default event transport for new subscriptions
 	EventTransportsDefault = ffc("event.transports.default")
 	// EventTransportsEnabled which event interface plugins are enabled
@@ -249,6 +389,16 @@ var (
 	EventAggregatorRewindQueueLength = ffc("event.aggregator.rewindQueueLength")
 	// EventAggregatorRewindQueryLimit safety limit on the maximum number of records to search when performing queries to search for rewinds
 	EventAggregatorRewindQueryLimit = ffc("event.aggregator.rewindQueryLimit")
+	// EventAggregatorCheckpointEnabled whether to periodically compact the pins the aggregator has finished with into a checkpoint
+	EventAggregatorCheckpointEnabled = ffc("event.aggregator.checkpoint.enabled")
+	// EventAggregatorCheckpointInterval how often to advance the aggregator checkpoint
+	EventAggregatorCheckpointInterval = ffc("event.aggregator.checkpoint.interval")
+	// EventAggregatorCheckpointPruneLimit the maximum number of pins to delete in each database transaction when compacting
+	EventAggregatorCheckpointPruneLimit = ffc("event.aggregator.checkpoint.pruneLimit")
+	// EventAggregatorQuarantineAuthors the authors whose inbound messages are quarantined until released
+	EventAggregatorQuarantineAuthors = ffc("event.aggregator.quarantine.authors")
+	// EventAggregatorQuarantineTags the tags of inbound messages that are quarantined until released
+	EventAggregatorQuarantineTags = ffc("event.aggregator.quarantine.tags")
 	// EventAggregatorRetryFactor the backoff factor to use for retry of database operations
 	EventAggregatorRetryFactor = ffc("event.aggregator.retry.factor")
 	// EventAggregatorRetryInitDelay the initial delay to use for retry of data base operations
@@ -267,8 +417,18 @@ var (
 	EventDispatcherRetryInitDelay = ffc("event.dispatcher.retry.initDelay")
 	// EventDispatcherRetryMaxDelay he maximum delay to use for retry of data base operations
 	EventDispatcherRetryMaxDelay = ffc("event.dispatcher.retry.maxDelay")
+	// EventConfirmationsHeadPollInterval how often the connector is queried for the chain head, to release events held for confirmations
+	EventConfirmationsHeadPollInterval = ffc("event.confirmations.headPollInterval")
 	// EventDBEventsBufferSize the size of the buffer of change events
 	EventDBEventsBufferSize = ffc("event.dbevents.bufferSize")
+	// EventReconcileEnabled whether contract listeners are compared with their connector checkpoints, and rewound to backfill gaps, on namespace start
+	EventReconcileEnabled = ffc("event.reconcile.enabled")
+	// EventReconcileMaxBlocks the maximum number of blocks a contract listener is rewound by during reconciliation
+	EventReconcileMaxBlocks = ffc("event.reconcile.maxBlocks")
+	// EventReconcilePollInterval how often to check the progress of rewound listeners
+	EventReconcilePollInterval = ffc("event.reconcile.pollInterval")
+	// EventReconcileTimeout how long to wait for rewound listeners to catch up before reporting them as failed
+	EventReconcileTimeout = ffc("event.reconcile.timeout")
 	// LegacyAdminEnabled is the deprecated key that pre-dates spi.enabled
 	LegacyAdminEnabled = ffc("admin.enabled")
 	// SPIEnabled determines whether the admin interface will be enabled or not
@@ -277,6 +437,8 @@ var (
 	SPIWebSocketEventQueueLength = ffc("spi.ws.eventQueueLength")
 	// SPIWebSocketBlockedWarnInterval how often to emit a warning if an admin.ws is blocked and not receiving events
 	SPIWebSocketBlockedWarnInterval = ffc("spi.ws.blockedWarnInterval")
+	// SPIWebSocketHistoryLength is the number of recent change events kept in memory, to replay to listeners that reconnect with resumeFrom
+	SPIWebSocketHistoryLength = ffc("spi.ws.historyLength")
 	// SPIWebSocketReadBufferSize is the WebSocket read buffer size for the admin change-event WebSocket
 	SPIWebSocketReadBufferSize = ffc("spi.ws.readBufferSize")
 	// SPIWebSocketWriteBufferSize is the WebSocket write buffer size for the admin change-event WebSocket
@@ -309,6 +471,32 @@ var (
 	NodeName = ffc("node.name")
 	// NodeDescription is a description for the node
 	NodeDescription = ffc("node.description")
+	// OperationsApprovalsSweepInterval the time between scans for operation approvals that have expired
+	OperationsApprovalsSweepInterval = ffc("operations.approvals.sweepInterval")
+	// OperationsCircuitBreakerEnabled whether calls to a plugin are short-circuited after repeated operation failures
+	OperationsCircuitBreakerEnabled = ffc("operations.circuitBreaker.enabled")
+	// OperationsCircuitBreakerFailureThreshold the number of consecutive failures against a plugin that opens the breaker
+	OperationsCircuitBreakerFailureThreshold = ffc("operations.circuitBreaker.failureThreshold")
+	// OperationsCircuitBreakerCooldown how long the breaker stays open before a trial call is allowed through
+	OperationsCircuitBreakerCooldown = ffc("operations.circuitBreaker.cooldown")
+	// OperationsBulkRetryConcurrency the default number of retries submitted in parallel by a bulk retry
+	OperationsBulkRetryConcurrency = ffc("operations.bulkRetry.concurrency")
+	// OperationsBulkRetryMaxConcurrency the upper limit on the concurrency a bulk retry request can ask for
+	OperationsBulkRetryMaxConcurrency = ffc("operations.bulkRetry.maxConcurrency")
+	// OperationsReaperEnabled whether a background reaper looks for operations that are stuck in pending
+	OperationsReaperEnabled = ffc("operations.reaper.enabled")
+	// OperationsReaperInterval the time between scans for stale operations
+	OperationsReaperInterval = ffc("operations.reaper.interval")
+	// OperationsReaperStaleAfter how long an operation can be pending before its plugin is re-queried and an alert event emitted
+	OperationsReaperStaleAfter = ffc("operations.reaper.staleAfter")
+	// OperationsReaperFailAfter how long an operation can be pending before it is marked failed, if re-querying its plugin did not resolve it
+	OperationsReaperFailAfter = ffc("operations.reaper.failAfter")
+	// OperationsReaperBatchSize the maximum number of stale operations handled in each scan
+	OperationsReaperBatchSize = ffc("operations.reaper.batchSize")
+	// OperationsFeesEnabled whether the gas used and fees paid, reported in blockchain receipts, are recorded for each operation
+	OperationsFeesEnabled = ffc("operations.fees.enabled")
+	// OperationsHistoryEnabled whether every operation status transition is recorded in the operation history
+	OperationsHistoryEnabled = ffc("operations.history.enabled")
 	// OpUpdateRetryInitDelay is the initial retry delay
 	OpUpdateRetryInitDelay = ffc("opupdate.retry.initialDelay")
 	// OpUpdatedRetryMaxDelay is the maximum retry delay
@@ -331,6 +519,88 @@ var (
 	OrgDescription = ffc("org.description")
 	// OrchestratorStartupAttempts is how many time to attempt to connect to core infrastructure on startup
 	OrchestratorStartupAttempts = ffc("orchestrator.startupAttempts")
+	// RetentionInterval the time between runs of the data retention policy
+	RetentionInterval = ffc("retention.interval")
+	// RetentionBatchSize the maximum number of records deleted in each database transaction when pruning
+	RetentionBatchSize = ffc("retention.batchSize")
+	// RetentionEventsMaxAge the age after which events are pruned. Zero keeps events indefinitely
+	RetentionEventsMaxAge = ffc("retention.events.maxAge")
+	// RetentionOperationsMaxAge the age after which completed operations are pruned. Zero keeps operations indefinitely
+	RetentionOperationsMaxAge = ffc("retention.operations.maxAge")
+	// RetentionMessagesEnabled whether the message retention policies of each namespace are enforced
+	RetentionMessagesEnabled = ffc("retention.messages.enabled")
+	// RetentionPinsMaxAge the age after which dispatched pins are pruned. Zero keeps pins indefinitely
+	RetentionPinsMaxAge = ffc("retention.pins.maxAge")
+	// RetentionArchiveEnabled whether rows are exported to the archive store before they are pruned
+	RetentionArchiveEnabled = ffc("retention.archive.enabled")
+	// RetentionArchiveFormat the file format of archived rows
+	RetentionArchiveFormat = ffc("retention.archive.format")
+	// RetentionArchiveCollections the collections exported to the archive store
+	RetentionArchiveCollections = ffc("retention.archive.collections")
+	// RetentionArchiveBatchSize the maximum number of rows written to each archive file
+	RetentionArchiveBatchSize = ffc("retention.archive.batchSize")
+	// RetentionArchiveMinAge the age after which rows of collections that are not pruned are archived
+	RetentionArchiveMinAge = ffc("retention.archive.minAge")
+	// RetentionArchiveStoreType the type of store archive files are written to
+	RetentionArchiveStoreType = ffc("retention.archive.store.type")
+	// RetentionArchiveStorePath the directory archive files are written to, for the filesystem store
+	RetentionArchiveStorePath = ffc("retention.archive.store.path")
+	// OnlineMigrationsStepInterval the delay between the steps of an online database migration
+	OnlineMigrationsStepInterval = ffc("onlineMigrations.stepInterval")
+	// SearchEnabled whether messages are indexed for full-text search
+	SearchEnabled = ffc("search.enabled")
+	// SearchType the search index messages are written to - the database, or OpenSearch
+	SearchType = ffc("search.type")
+	// SearchInterval the time between polls for new messages to index
+	SearchInterval = ffc("search.interval")
+	// SearchBatchSize the maximum number of messages indexed in each batch
+	SearchBatchSize = ffc("search.batchSize")
+	// SearchOpenSearchIndex the name of the OpenSearch index messages are written to
+	SearchOpenSearchIndex = ffc("search.opensearch.index")
+	// TrafficEnabled whether the traffic exchanged with each counterparty org is totalled for the traffic API
+	TrafficEnabled = ffc("traffic.enabled")
+	// TrafficInterval the time between polls for new events to total
+	TrafficInterval = ffc("traffic.interval")
+	// TrafficBatchSize the maximum number of events totalled in each batch
+	TrafficBatchSize = ffc("traffic.batchSize")
+	// TrafficDefaultWindow the time window returned by the traffic API, if no start time is specified
+	TrafficDefaultWindow = ffc("traffic.defaultWindow")
+	// SLOInterval the time between evaluations of the service level objectives
+	SLOInterval = ffc("slo.interval")
+	// SLOWindow the period of recent activity the message confirmation objective is measured over
+	SLOWindow = ffc("slo.window")
+	// SLOMessageConfirmationThreshold the maximum time for a message to be confirmed at the percentile, or 0 to disable
+	SLOMessageConfirmationThreshold = ffc("slo.messageConfirmation.threshold")
+	// SLOMessageConfirmationPercentile the percentile of message confirmation times compared to the threshold
+	SLOMessageConfirmationPercentile = ffc("slo.messageConfirmation.percentile")
+	// SLOMessageConfirmationSampleLimit the maximum number of recently confirmed messages measured in each evaluation
+	SLOMessageConfirmationSampleLimit = ffc("slo.messageConfirmation.sampleLimit")
+	// SLOEventDeliveryLagThreshold the maximum number of events a durable subscription can be behind, or 0 to disable
+	SLOEventDeliveryLagThreshold = ffc("slo.eventDeliveryLag.threshold")
+	// FaultsEnabled allows fault injection rules to be set on the plugins of each namespace through the SPI. Never enable in production
+	FaultsEnabled = ffc("faults.enabled")
+	// SnapshotVerifyInterval the time between checks of the batches of an imported snapshot against the pins received from the blockchain
+	SnapshotVerifyInterval = ffc("snapshot.verifyInterval")
+	// GraphQLMaxDepth the maximum nesting of fields in a GraphQL query
+	GraphQLMaxDepth = ffc("graphql.maxDepth")
+	// GraphQLMaxFields the maximum number of fields selected in a GraphQL query
+	GraphQLMaxFields = ffc("graphql.maxFields")
+	// GraphQLMaxAliases the maximum number of aliased fields in a GraphQL query
+	GraphQLMaxAliases = ffc("graphql.maxAliases")
+	// GraphQLMaxCost the maximum estimated number of objects a GraphQL query can resolve
+	GraphQLMaxCost = ffc("graphql.maxCost")
+	// AuditEnabled whether mutating API calls are recorded in the hash-chained audit log of each namespace
+	AuditEnabled = ffc("audit.enabled")
+	// AuditAnchorEnabled whether each multiparty namespace periodically pins a Merkle root of its history to the blockchain
+	AuditAnchorEnabled = ffc("audit.anchor.enabled")
+	// AuditAnchorInterval the time between audit anchors
+	AuditAnchorInterval = ffc("audit.anchor.interval")
+	// AuditAnchorMaxLeaves the maximum number of events committed to by a single audit anchor
+	AuditAnchorMaxLeaves = ffc("audit.anchor.maxLeaves")
+	// AuditAnchorPinMaxAttempts the number of times the pin of an audit anchor is attempted before anchoring stops and an alert is emitted
+	AuditAnchorPinMaxAttempts = ffc("audit.anchor.pinMaxAttempts")
+	// AuditAnchorPinRetryDelay the initial delay before a failed pin of an audit anchor is retried, doubling on each attempt
+	AuditAnchorPinRetryDelay = ffc("audit.anchor.pinRetryDelay")
 	// SubscriptionDefaultsBatchSize default read ahead to enable for subscriptions that do not explicitly configure readahead
 	SubscriptionDefaultsBatchSize = ffc("subscription.defaults.batchSize")
 	// SubscriptionDefaultsBatchTimeout default batch timeout
@@ -345,6 +615,20 @@ var (
 	SubscriptionsRetryFactor = ffc("subscription.retry.factor")
 	// SubscriptionMaxHistoricalEventScanLength the maximum amount of historical events we scan for in the DB when indexing through old events against a subscription
 	SubscriptionMaxHistoricalEventScanLength = ffc("subscription.events.maxScanLength")
+	// TracingEnabled whether OpenTelemetry spans are created and exported
+	TracingEnabled = ffc("tracing.enabled")
+	// TracingEndpoint the URL of the OTLP/HTTP endpoint spans are exported to
+	TracingEndpoint = ffc("tracing.endpoint")
+	// TracingServiceName the service name spans are exported with
+	TracingServiceName = ffc("tracing.serviceName")
+	// TracingSampleRatio the fraction of new traces that are sampled
+	TracingSampleRatio = ffc("tracing.sampleRatio")
+	// TracingLinkCacheSize the number of message and batch span contexts remembered, so later stages can link to them
+	TracingLinkCacheSize = ffc("tracing.linkCacheSize")
+	// TransactionIdempotencyKeysExpiry how long after its transaction was created an idempotency key is automatically released. Zero disables expiry
+	TransactionIdempotencyKeysExpiry = ffc("transaction.idempotencyKeys.expiry")
+	// TransactionIdempotencyKeysExpiryInterval the time between checks for expired idempotency keys
+	TransactionIdempotencyKeysExpiryInterval = ffc("transaction.idempotencyKeys.expiryInterval")
 	// TransactionWriterCount
 	TransactionWriterCount = ffc("transaction.writer.count")
 	// TransactionWriterBatchTimeout
@@ -398,13 +682,41 @@ func setDefaults() {
 	viper.SetDefault(string(CacheAddressResolverLimit), 1000)
 	viper.SetDefault(string(CacheAddressResolverTTL), "24h")
 	viper.SetDefault(string(CacheEnabled), true)
+	viper.SetDefault(string(CacheAdaptiveEnabled), false)
+	viper.SetDefault(string(CacheAdaptiveInterval), "30s")
+	viper.SetDefault(string(CacheAdaptiveMemoryLimit), "0")
+	viper.SetDefault(string(CacheAdaptiveHighWatermark), 0.85)
+	viper.SetDefault(string(CacheAdaptiveLowWatermark), 0.6)
+	viper.SetDefault(string(CacheAdaptiveMinRatio), 0.25)
+	viper.SetDefault(string(CacheAdaptiveMaxRatio), 4)
+	viper.SetDefault(string(CacheAdaptivePriority), []string{"cache.message", "cache.batch"})
+	viper.SetDefault(string(CacheDistributedEnabled), false)
+	viper.SetDefault(string(CacheDistributedType), "redis")
+	viper.SetDefault(string(CacheDistributedRedisURL), "redis://localhost:6379")
+	viper.SetDefault(string(CacheDistributedRedisChannel), "firefly-cache")
 	viper.SetDefault(string(CacheOperationsLimit), 1000)
 	viper.SetDefault(string(CacheOperationsTTL), "5m")
+	viper.SetDefault(string(CacheNegativeLimit), 1000)
+	viper.SetDefault(string(CacheNegativeTTL), "10s")
 	viper.SetDefault(string(CacheMethodsLimit), 200)
 	viper.SetDefault(string(CacheMethodsTTL), "5m")
+	viper.SetDefault(string(HealthProbeEnabled), true)
+	viper.SetDefault(string(HealthProbeInterval), "30s")
+	viper.SetDefault(string(HealthProbeTimeout), "5s")
+	viper.SetDefault(string(HealthProbeDegradedLatency), "2s")
 	viper.SetDefault(string(HistogramsMaxChartRows), 100)
 	viper.SetDefault(string(DebugPort), -1)
 	viper.SetDefault(string(DebugAddress), "localhost")
+	viper.SetDefault(string(DebugCaptureMaxLines), 10000)
+	viper.SetDefault(string(DebugCaptureMaxDuration), "1h")
+	viper.SetDefault(string(DebugDiagnosticsErrorEvents), 100)
+	viper.SetDefault(string(DisclosuresVerifierInterval), "5s")
+	viper.SetDefault(string(DisclosuresVerifierBatchSize), 50)
+	viper.SetDefault(string(DownloadCatchUpEnabled), false)
+	viper.SetDefault(string(DownloadCatchUpWorkers), 10)
+	viper.SetDefault(string(DownloadCatchUpBatchSize), 100)
+	viper.SetDefault(string(DownloadCatchUpPollInterval), "1s")
+	viper.SetDefault(string(DownloadCatchUpIdleTimeout), "30s")
 	viper.SetDefault(string(DownloadWorkerCount), 10)
 	viper.SetDefault(string(DownloadRetryMaxAttempts), 100)
 	viper.SetDefault(string(DownloadRetryInitDelay), "100ms")
@@ -417,10 +729,20 @@ func setDefaults() {
 	viper.SetDefault(string(EventAggregatorRewindTimeout), "50ms")
 	viper.SetDefault(string(EventAggregatorRewindQueueLength), 10)
 	viper.SetDefault(string(EventAggregatorRewindQueryLimit), 1000)
+	viper.SetDefault(string(EventAggregatorCheckpointEnabled), false)
+	viper.SetDefault(string(EventAggregatorCheckpointInterval), "5m")
+	viper.SetDefault(string(EventAggregatorCheckpointPruneLimit), 1000)
+	viper.SetDefault(string(EventAggregatorQuarantineAuthors), []string{})
+	viper.SetDefault(string(EventAggregatorQuarantineTags), []string{})
 	viper.SetDefault(string(EventAggregatorRetryFactor), 2.0)
 	viper.SetDefault(string(EventAggregatorRetryInitDelay), "100ms")
 	viper.SetDefault(string(EventAggregatorRetryMaxDelay), "30s")
+	viper.SetDefault(string(EventConfirmationsHeadPollInterval), "10s")
 	viper.SetDefault(string(EventDBEventsBufferSize), 100)
+	viper.SetDefault(string(EventReconcileEnabled), false)
+	viper.SetDefault(string(EventReconcileMaxBlocks), 10000)
+	viper.SetDefault(string(EventReconcilePollInterval), "1s")
+	viper.SetDefault(string(EventReconcileTimeout), "5m")
 	viper.SetDefault(string(EventDispatcherBufferLength), 5)
 	viper.SetDefault(string(EventDispatcherBatchTimeout), "0ms")
 	viper.SetDefault(string(EventDispatcherPollTimeout), "30s")
@@ -435,6 +757,7 @@ func setDefaults() {
 	viper.SetDefault(string(SPIWebSocketWriteBufferSize), "16Kb")
 	viper.SetDefault(string(SPIWebSocketBlockedWarnInterval), "1m")
 	viper.SetDefault(string(SPIWebSocketEventQueueLength), 250)
+	viper.SetDefault(string(SPIWebSocketHistoryLength), 1000)
 	viper.SetDefault(string(CacheMessageSize), "50Mb")
 	viper.SetDefault(string(CacheMessageTTL), "5m")
 	viper.SetDefault(string(MessageWriterBatchMaxInserts), 200)
@@ -445,6 +768,19 @@ func setDefaults() {
 	viper.SetDefault(string(NamespacesRetryMaxDelay), "1m")
 	viper.SetDefault(string(NamespacesRetryInitDelay), "5s")
 	viper.SetDefault(string(OrchestratorStartupAttempts), 5)
+	viper.SetDefault(string(OperationsCircuitBreakerEnabled), true)
+	viper.SetDefault(string(OperationsCircuitBreakerFailureThreshold), 5)
+	viper.SetDefault(string(OperationsCircuitBreakerCooldown), "30s")
+	viper.SetDefault(string(OperationsFeesEnabled), true)
+	viper.SetDefault(string(OperationsHistoryEnabled), true)
+	viper.SetDefault(string(OperationsReaperEnabled), true)
+	viper.SetDefault(string(OperationsReaperInterval), "5m")
+	viper.SetDefault(string(OperationsReaperStaleAfter), "30m")
+	viper.SetDefault(string(OperationsReaperFailAfter), "24h")
+	viper.SetDefault(string(OperationsReaperBatchSize), 100)
+	viper.SetDefault(string(OperationsApprovalsSweepInterval), "1m")
+	viper.SetDefault(string(OperationsBulkRetryConcurrency), 5)
+	viper.SetDefault(string(OperationsBulkRetryMaxConcurrency), 50)
 	viper.SetDefault(string(OpUpdateRetryInitDelay), "250ms")
 	viper.SetDefault(string(OpUpdateRetryMaxDelay), "1m")
 	viper.SetDefault(string(OpUpdateRetryFactor), 2.0)
@@ -452,6 +788,7 @@ func setDefaults() {
 	viper.SetDefault(string(OpUpdateWorkerCount), 5)
 	viper.SetDefault(string(OpUpdateWorkerBatchMaxInserts), 200)
 	viper.SetDefault(string(OpUpdateWorkerQueueLength), 50)
+	viper.SetDefault(string(PrivateMessagingAcksEnabled), false)
 	viper.SetDefault(string(PrivateMessagingRetryFactor), 2.0)
 	viper.SetDefault(string(PrivateMessagingRetryInitDelay), "100ms")
 	viper.SetDefault(string(PrivateMessagingRetryMaxDelay), "30s")
@@ -459,6 +796,51 @@ func setDefaults() {
 	viper.SetDefault(string(PrivateMessagingBatchSize), 200)
 	viper.SetDefault(string(PrivateMessagingBatchTimeout), "1s")
 	viper.SetDefault(string(PrivateMessagingBatchPayloadLimit), "800Kb")
+	viper.SetDefault(string(PrivateMessagingLatencyWindow), "24h")
+	viper.SetDefault(string(PrivateMessagingLatencySampleLimit), 1000)
+	viper.SetDefault(string(PrivateMessagingQueueEnabled), false)
+	viper.SetDefault(string(PrivateMessagingQueuePageSize), 50)
+	viper.SetDefault(string(PrivateMessagingQueuePollInterval), "30s")
+	viper.SetDefault(string(RetentionInterval), "1h")
+	viper.SetDefault(string(RetentionBatchSize), 1000)
+	viper.SetDefault(string(RetentionEventsMaxAge), "0")
+	viper.SetDefault(string(RetentionOperationsMaxAge), "0")
+	viper.SetDefault(string(RetentionMessagesEnabled), false)
+	viper.SetDefault(string(RetentionPinsMaxAge), "0")
+	viper.SetDefault(string(RetentionArchiveEnabled), false)
+	viper.SetDefault(string(RetentionArchiveFormat), "csv")
+	viper.SetDefault(string(RetentionArchiveCollections), []string{"messages", "events", "tokentransfers"})
+	viper.SetDefault(string(RetentionArchiveBatchSize), 10000)
+	viper.SetDefault(string(RetentionArchiveMinAge), "24h")
+	viper.SetDefault(string(RetentionArchiveStoreType), "filesystem")
+	viper.SetDefault(string(OnlineMigrationsStepInterval), "100ms")
+	viper.SetDefault(string(SearchEnabled), false)
+	viper.SetDefault(string(SearchType), "database")
+	viper.SetDefault(string(SearchInterval), "1s")
+	viper.SetDefault(string(SearchBatchSize), 100)
+	viper.SetDefault(string(SearchOpenSearchIndex), "firefly")
+	viper.SetDefault(string(TrafficEnabled), false)
+	viper.SetDefault(string(TrafficInterval), "1s")
+	viper.SetDefault(string(TrafficBatchSize), 100)
+	viper.SetDefault(string(TrafficDefaultWindow), "24h")
+	viper.SetDefault(string(SLOInterval), "1m")
+	viper.SetDefault(string(SLOWindow), "5m")
+	viper.SetDefault(string(SLOMessageConfirmationThreshold), "0")
+	viper.SetDefault(string(SLOMessageConfirmationPercentile), 95)
+	viper.SetDefault(string(SLOMessageConfirmationSampleLimit), 1000)
+	viper.SetDefault(string(SLOEventDeliveryLagThreshold), 0)
+	viper.SetDefault(string(SnapshotVerifyInterval), "10s")
+	viper.SetDefault(string(FaultsEnabled), false)
+	viper.SetDefault(string(GraphQLMaxDepth), 6)
+	viper.SetDefault(string(GraphQLMaxFields), 200)
+	viper.SetDefault(string(GraphQLMaxAliases), 20)
+	viper.SetDefault(string(GraphQLMaxCost), 10000)
+	viper.SetDefault(string(AuditEnabled), false)
+	viper.SetDefault(string(AuditAnchorEnabled), false)
+	viper.SetDefault(string(AuditAnchorInterval), "1h")
+	viper.SetDefault(string(AuditAnchorMaxLeaves), 10000)
+	viper.SetDefault(string(AuditAnchorPinMaxAttempts), 5)
+	viper.SetDefault(string(AuditAnchorPinRetryDelay), "1m")
 	viper.SetDefault(string(SubscriptionDefaultsBatchSize), 50)
 	viper.SetDefault(string(SubscriptionDefaultsBatchTimeout), "50ms")
 	viper.SetDefault(string(SubscriptionMax), 500)
@@ -466,6 +848,12 @@ func setDefaults() {
 	viper.SetDefault(string(SubscriptionsRetryMaxDelay), "30s")
 	viper.SetDefault(string(SubscriptionsRetryFactor), 2.0)
 	viper.SetDefault(string(SubscriptionMaxHistoricalEventScanLength), 1000)
+	viper.SetDefault(string(TracingEnabled), false)
+	viper.SetDefault(string(TracingServiceName), "firefly")
+	viper.SetDefault(string(TracingSampleRatio), 1.0)
+	viper.SetDefault(string(TracingLinkCacheSize), 10000)
+	viper.SetDefault(string(TransactionIdempotencyKeysExpiry), "0")
+	viper.SetDefault(string(TransactionIdempotencyKeysExpiryInterval), "10m")
 	viper.SetDefault(string(TransactionWriterBatchMaxTransactions), 100)
 	viper.SetDefault(string(TransactionWriterBatchTimeout), "10ms")
 	viper.SetDefault(string(TransactionWriterCount), 5)
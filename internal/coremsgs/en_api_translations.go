@@ -25,27 +25,37 @@ var (
 	APIParamsOperationIDGet                 = ffm("api.params.operationID.get", "The operation ID key to get")
 	APIParamsOperationNamespacedID          = ffm("api.params.spiOperationID", "The operation ID as passed to the connector when the operation was performed, including the 'namespace:' prefix")
 	APIParamsNamespace                      = ffm("api.params.namespace", "The namespace which scopes this request")
+	APIParamsCacheName                      = ffm("api.params.cacheName", "The name of the cache, prefixed with its namespace, such as ns1:cache.identity")
+	APIParamsCacheKey                       = ffm("api.params.cacheKey", "The key of the cache entry")
 	APIParamsContractListenerNameOrID       = ffm("api.params.contractListenerNameOrID", "The contract listener name or ID")
 	APIParamsContractListenerID             = ffm("api.params.contractListenerID", "The contract listener ID")
 	APIParamsSubscriptionID                 = ffm("api.params.subscriptionID", "The subscription ID")
 	APIParamsBatchID                        = ffm("api.params.batchId", "The batch ID")
 	APIParamsBlockchainEventID              = ffm("api.params.blockchainEventID", "The blockchain event ID")
+	APIParamsAuditAnchorID                  = ffm("api.params.auditAnchorID", "The audit anchor ID")
 	APIParamsCollectionID                   = ffm("api.params.collectionID", "The collection ID")
 	APIParamsContractAPIName                = ffm("api.params.contractAPIName", "The name of the contract API")
 	APIParamsContractInterfaceName          = ffm("api.params.contractInterfaceName", "The name of the contract interface")
 	APIParamsContractInterfaceVersion       = ffm("api.params.contractInterfaceVersion", "The version of the contract interface")
 	APIParamsContractInterfaceID            = ffm("api.params.contractInterfaceID", "The ID of the contract interface")
+	APIParamsConfigReloadDryRun             = ffm("api.params.configReloadDryRun", "When set, the configuration file is read and validated, and the namespaces, plugins and settings that would change are returned without applying them")
 	APIParamsContractInterfaceFetchChildren = ffm("api.params.contractInterfaceFetchChildren", "When set, the API will return the full FireFly Interface document including all methods, events, and parameters")
 	APIParamsNSIncludeInitializing          = ffm("api.params.nsIncludeInitializing", "When set, the API will return namespaces even if they are not yet initialized, including in error cases where an initializationError is included")
 	APIParamsBlobID                         = ffm("api.params.blobID", "The blob ID")
+	APIParamsIdempotencyKey                 = ffm("api.params.idempotencyKey", "The idempotency key")
 	APIParamsDataID                         = ffm("api.params.dataID", "The data item ID")
+	APIParamsDataHash                       = ffm("api.params.dataHash", "The hash of the data item")
+	APIParamsBlobHash                       = ffm("api.params.blobHash", "The hash of the blob attached to the data item")
 	APIParamsDatatypeName                   = ffm("api.params.datatypeName", "The name of the datatype")
 	APIParamsDatatypeVersion                = ffm("api.params.datatypeVersion", "The version of the datatype")
+	APIParamsDisclosureID                   = ffm("api.params.disclosureID", "The disclosure ID")
 	APIParamsDataParentPath                 = ffm("api.params.dataParentPath", "The parent path to query")
 	APIParamsEventID                        = ffm("api.params.eventID", "The event ID")
 	APIParamsFetchReferences                = ffm("api.params.fetchReferences", "When set, the API will return the record that this item references in its 'reference' field")
 	APIParamsFetchReference                 = ffm("api.params.fetchReference", "When set, the API will return the record that this item references in its 'reference' field")
+	APIParamsErrorCode                      = ffm("api.params.errorCode", "The code of the error, such as FF10109")
 	APIParamsGroupHash                      = ffm("api.params.groupID", "The hash of the group")
+	APIParamsGroupDisplayName               = ffm("api.params.groupDisplayName", "The human-readable display name of the group")
 	APIParamsFetchVerifiers                 = ffm("api.params.fetchVerifiers", "When set, the API will return the verifier for this identity")
 	APIParamsIdentityID                     = ffm("api.params.identityID", "The identity ID, which is a UUID generated by FireFly")
 	APIParamsMessageID                      = ffm("api.params.messageID", "The message ID")
@@ -66,23 +76,63 @@ var (
 	APIParamsAutometa                       = ffm("api.params.autometa", "When set, FireFly will automatically generate JSON metadata with the upload details")
 	APIParamsContractAPIID                  = ffm("api.params.contractAPIID", "The ID of the contract API")
 	APIParamsFetchStatus                    = ffm("api.params.fetchStatus", "When set, the API will return additional status information if available")
+	APIParamsMigrationID                    = ffm("api.params.migrationID", "The contract migration ID")
+	APIParamsOnlineMigrationName            = ffm("api.params.onlineMigrationName", "The name of the online database migration")
+	APIParamsRebuildTarget                  = ffm("api.params.rebuildTarget", "The derived records to rebuild - nextpins, messagestates or tokenbalances")
 
-	APIEndpointsAdminGetNamespaceByName = ffm("api.endpoints.adminGetNamespaceByName", "Gets a namespace by name")
-	APIEndpointsAdminGetNamespaces      = ffm("api.endpoints.adminGetNamespaces", "List namespaces")
-	APIEndpointsAdminGetOpByID          = ffm("api.endpoints.adminGetOpByID", "Gets an operation by ID")
-	APIEndpointsAdminGetOps             = ffm("api.endpoints.adminGetOps", "Lists operations")
-	APIEndpointsAdminPostReset          = ffm("api.endpoints.adminPostResetConfig", "Restarts FireFly Core HTTP servers and apply all configuration updates")
-	APIEndpointsAdminPatchOpByID        = ffm("api.endpoints.adminPatchOpByID", "Updates an operation by ID")
-	APIEndpointsAdminGetListenerByID    = ffm("api.endpoints.adminGetListenerByID", "Gets a contract listener by ID")
-	APIEndpointsAdminGetListeners       = ffm("api.endpoints.adminGetListeners", "Lists contract listeners")
+	APIEndpointsAdminGetNamespaceByName     = ffm("api.endpoints.adminGetNamespaceByName", "Gets a namespace by name")
+	APIEndpointsAdminGetNamespaces          = ffm("api.endpoints.adminGetNamespaces", "List namespaces")
+	APIEndpointsAdminGetOpByID              = ffm("api.endpoints.adminGetOpByID", "Gets an operation by ID")
+	APIEndpointsAdminGetOps                 = ffm("api.endpoints.adminGetOps", "Lists operations")
+	APIEndpointsAdminGetOnlineMigrations    = ffm("api.endpoints.adminGetOnlineMigrations", "Lists the online database migrations and their progress")
+	APIEndpointsAdminGetAuditRecords        = ffm("api.endpoints.adminGetAuditRecords", "Lists the records of the audit log of mutating API calls to the namespace")
+	APIEndpointsAdminGetAuditVerify         = ffm("api.endpoints.adminGetAuditVerify", "Checks the hash chain of the audit log of the namespace, to detect records that have been changed or removed")
+	APIEndpointsAdminGetAuditAnchors        = ffm("api.endpoints.adminGetAuditAnchors", "Lists the Merkle commitments to the history of the namespace that have been pinned to the blockchain")
+	APIEndpointsAdminGetAuditAnchorVerify   = ffm("api.endpoints.adminGetAuditAnchorVerify", "Rebuilds the Merkle tree of an audit anchor from the local history, and checks it against the root that was pinned to the blockchain")
+	APIEndpointsAdminGetAuditAnchorProof    = ffm("api.endpoints.adminGetAuditAnchorProof", "Gets the Merkle proof that an event, and the message or operation it refers to, is included in an audit anchor")
+	APIEndpointsAdminGetQuotas              = ffm("api.endpoints.adminGetQuotas", "Gets the quota limits and current usage of the namespace")
+	APIEndpointsAdminPutQuotas              = ffm("api.endpoints.adminPutQuotas", "Adjusts the quota limits of the namespace. The limits are stored, and take precedence over the configured limits")
+	APIEndpointsAdminGetFaults              = ffm("api.endpoints.adminGetFaults", "Gets the fault injection rules of the namespace, with the number of times each has been injected")
+	APIEndpointsAdminPutFaults              = ffm("api.endpoints.adminPutFaults", "Replaces the fault injection rules of the namespace, until it is next restarted. Requires faults.enabled in the configuration")
+	APIEndpointsAdminPostLoadTest           = ffm("api.endpoints.adminPostLoadTest", "Starts sending synthetic broadcast messages, private messages and token transfers through the namespace at the given rates. Requires sandbox in the configuration of the namespace")
+	APIEndpointsAdminGetLoadTest            = ffm("api.endpoints.adminGetLoadTest", "Gets the progress of the latest load test of the namespace, with the confirmation latency percentiles of each type of request")
+	APIEndpointsAdminDeleteLoadTest         = ffm("api.endpoints.adminDeleteLoadTest", "Stops the running load test of the namespace, cancelling the requests waiting for confirmation")
+	APIEndpointsAdminGetDefinitionRecording = ffm("api.endpoints.adminGetDefinitionRecording", "Lists the definition broadcasts processed by the namespace, with their data and recorded outcome, in the format accepted by the definition replay API")
+	APIEndpointsAdminPostDefinitionReplay   = ffm("api.endpoints.adminPostDefinitionReplay", "Replays a recorded sequence of definition broadcasts through the definition handlers of this node, against a scratch state that is discarded afterwards, and reports where the outcome differs from the recorded outcome. Requires sandbox in the configuration of the namespace")
+	APIEndpointsAdminPostOnlineMigrationRun = ffm("api.endpoints.adminPostOnlineMigrationRun", "Starts or resumes an online database migration, which backfills a new table in the background and then swaps it into place")
+	APIEndpointsAdminGetRebuildStatus       = ffm("api.endpoints.adminGetRebuildStatus", "Lists the progress of the latest rebuild of each set of derived records in the namespace on this node")
+	APIEndpointsAdminPostRebuild            = ffm("api.endpoints.adminPostRebuild", "Starts regenerating a set of derived records in the namespace from the records they are derived from, pausing event ingestion until it completes")
+	APIEndpointsAdminGetLogLevels           = ffm("api.endpoints.adminGetLogLevels", "Gets the log level of the node, and of each subsystem that has its own level")
+	APIEndpointsAdminPutLogLevels           = ffm("api.endpoints.adminPutLogLevels", "Changes the log level of the node or of individual subsystems until the node restarts")
+	APIEndpointsAdminGetLogCapture          = ffm("api.endpoints.adminGetLogCapture", "Gets the status of the latest log capture")
+	APIEndpointsAdminPostLogCapture         = ffm("api.endpoints.adminPostLogCapture", "Starts capturing log output into memory for a limited time, replacing any previous capture")
+	APIEndpointsAdminDeleteLogCapture       = ffm("api.endpoints.adminDeleteLogCapture", "Stops the current log capture early, keeping its output for download")
+	APIEndpointsAdminGetLogCaptureBundle    = ffm("api.endpoints.adminGetLogCaptureBundle", "Downloads the output of the latest log capture as a zip file")
+	APIEndpointsAdminGetCaches              = ffm("api.endpoints.adminGetCaches", "Lists the caches of every namespace, with their configuration and hit and miss counts")
+	APIEndpointsAdminDeleteCache            = ffm("api.endpoints.adminDeleteCache", "Invalidates every entry of a cache, so they are read again from the database")
+	APIEndpointsAdminDeleteCacheKey         = ffm("api.endpoints.adminDeleteCacheKey", "Invalidates a single entry of a cache, so it is read again from the database")
+	APIEndpointsAdminGetDiagnostics         = ffm("api.endpoints.adminGetDiagnostics", "Downloads a zip file of diagnostics for a support ticket, including the redacted configuration, the status of every namespace and plugin, and goroutine and heap profiles")
+	APIEndpointsAdminPostReset              = ffm("api.endpoints.adminPostResetConfig", "Restarts FireFly Core HTTP servers and apply all configuration updates. With dryRun, previews the changes in the configuration file without applying them")
+	APIEndpointsAdminPutNamespaceConfig     = ffm("api.endpoints.adminPutNamespaceConfig", "Applies a new configuration for a single namespace and its plugins, restarting only that namespace")
+	APIEndpointsAdminPostNamespaceClone     = ffm("api.endpoints.adminPostNamespaceClone", "Provisions a new namespace with the same plugin wiring as an existing namespace, optionally copying its datatypes, contract APIs and subscriptions")
+	APIEndpointsAdminPostNamespaceImport    = ffm("api.endpoints.adminPostNamespaceImport", "Imports a namespace archive, exported from this or another node, into a namespace that contains no records yet")
+	APIEndpointsAdminPostCredentialIssue    = ffm("api.endpoints.adminPostCredentialIssue", "Issues a verifiable credential from the root org of the namespace, signed as a JWT with the payload signing key of the org")
+	APIEndpointsAdminPostSignTypedData      = ffm("api.endpoints.adminPostSignTypedData", "Signs EIP-712 typed data with a secp256k1 key held in the KMS of the signing plugin of the namespace")
+	APIEndpointsAdminPatchOpByID            = ffm("api.endpoints.adminPatchOpByID", "Updates an operation by ID")
+	APIEndpointsAdminGetListenerByID        = ffm("api.endpoints.adminGetListenerByID", "Gets a contract listener by ID")
+	APIEndpointsAdminGetListeners           = ffm("api.endpoints.adminGetListeners", "Lists contract listeners")
 
 	APIEndpointsDeleteContractAPI               = ffm("api.endpoints.deleteContractAPI", "Delete a contract API")
 	APIEndpointsDeleteContractInterface         = ffm("api.endpoints.deleteContractInterface", "Delete a contract interface")
 	APIEndpointsDeleteContractListener          = ffm("api.endpoints.deleteContractListener", "Deletes a contract listener referenced by its name or its ID")
+	APIEndpointsDeleteIdempotencyKey            = ffm("api.endpoints.deleteIdempotencyKey", "Expires an idempotency key, releasing it so that it can be submitted again")
 	APIEndpointsDeleteSubscription              = ffm("api.endpoints.deleteSubscription", "Deletes a subscription")
 	APIEndpointsDeleteTokenPool                 = ffm("api.endpoints.deleteTokenPool", "Delete a token pool")
+	APIEndpointsGetActivity                     = ffm("api.endpoints.getActivity", "Gets the activity timeline of the namespace, newest first, merging messages, token transfers, contract invocations, identity changes and network definitions with a summary of each")
+	APIEndpointsGetBatchAcks                    = ffm("api.endpoints.getBatchAcks", "Gets the acknowledgements recorded for a private batch sent from this node, showing how far each recipient has got with it")
 	APIEndpointsGetBatchBbyID                   = ffm("api.endpoints.getBatchByID", "Gets a message batch")
 	APIEndpointsGetBatches                      = ffm("api.endpoints.getBatches", "Gets a list of message batches")
+	APIEndpointsGetBatchVerify                  = ffm("api.endpoints.getBatchVerify", "Verifies a confirmed batch against the local copies of its messages and data, the BatchPin event recorded on the blockchain, and for broadcast batches the payload in shared storage")
 	APIEndpointsGetBlockchainEventByID          = ffm("api.endpoints.getBlockchainEventByID", "Gets a blockchain event")
 	APIEndpointsListBlockchainEvents            = ffm("api.endpoints.getBlockchainEvents", "Gets a list of blockchain events")
 	APIEndpointsGetChartHistogram               = ffm("api.endpoints.getChartHistogram", "Gets a JSON object containing statistics data that can be used to build a graphical representation of recent activity in a given database collection")
@@ -97,26 +147,53 @@ var (
 	APIEndpointsGetDataValue                    = ffm("api.endpoints.getDataValue", "Downloads the JSON value of the data resource, without the associated metadata")
 	APIEndpointsGetDataByID                     = ffm("api.endpoints.getDataByID", "Gets a data item by its ID, including metadata about this item")
 	APIEndpointsDeleteData                      = ffm("api.endpoints.deleteData", "Deletes a data item by its ID, including metadata about this item")
+	APIEndpointsDeleteMsgRetentionPolicy        = ffm("api.endpoints.deleteMsgRetentionPolicy", "Deletes a message retention policy. The data of messages on its topic or tag is no longer deleted")
 	APIEndpointsGetDataMsgs                     = ffm("api.endpoints.getDataMsgs", "Gets a list of the messages associated with a data item")
+	APIEndpointsGetDataHashMsgs                 = ffm("api.endpoints.getDataHashMsgs", "Gets a list of the messages that reference data with the given hash")
+	APIEndpointsGetDataHashBatches              = ffm("api.endpoints.getDataHashBatches", "Gets a list of the batches containing messages that reference data with the given hash")
+	APIEndpointsGetBlobHashMsgs                 = ffm("api.endpoints.getBlobHashMsgs", "Gets a list of the messages that reference data with a blob of the given hash")
+	APIEndpointsGetBlobHashBatches              = ffm("api.endpoints.getBlobHashBatches", "Gets a list of the batches containing messages that reference data with a blob of the given hash")
 	APIEndpointsGetData                         = ffm("api.endpoints.getData", "Gets a list of data items")
 	APIEndpointsGetDataSubPaths                 = ffm("api.endpoints.getDataSubPaths", "Gets a list of path names of named blob data, underneath a given parent path ('/' path prefixes are automatically pre-prepended)")
 	APIEndpointsGetDatatypeByName               = ffm("api.endpoints.getDatatypeByName", "Gets a datatype by its name and version")
 	APIEndpointsGetDatatypes                    = ffm("api.endpoints.getDatatypes", "Gets a list of datatypes that have been published")
+	APIEndpointsGetDisclosureByID               = ffm("api.endpoints.getDisclosureByID", "Gets a zero-knowledge disclosure about the private data of a message, by its ID")
+	APIEndpointsGetDisclosures                  = ffm("api.endpoints.getDisclosures", "Gets a list of zero-knowledge disclosures about the private data of messages, that have been broadcast to the network")
 	APIEndpointsGetEventByID                    = ffm("api.endpoints.eventID", "Gets an event by its ID")
 	APIEndpointsGetEvents                       = ffm("api.endpoints.getEvents", "Gets a list of events")
+	APIEndpointsGetFeeSummary                   = ffm("api.endpoints.getFeeSummary", "Gets the total gas used and fees paid by each signing key per UTC day, for the fee records matching the filter")
+	APIEndpointsGetOpApprovalByID               = ffm("api.endpoints.getOpApprovalByID", "Gets an operation approval by its ID")
+	APIEndpointsGetOpApprovals                  = ffm("api.endpoints.getOpApprovals", "Gets a list of the approvals of operations held back by an approval policy")
+	APIEndpointsGetFees                         = ffm("api.endpoints.getFees", "Gets a list of the gas used and fees paid by blockchain operations")
+	APIEndpointsGetErrorCodes                   = ffm("api.endpoints.getErrorCodes", "Gets the catalog of error codes the API can return, with the template, HTTP status and retryability of each")
+	APIEndpointsGetErrorCodeByCode              = ffm("api.endpoints.getErrorCodeByCode", "Gets an error code from the catalog of error codes the API can return")
 	APIEndpointsGetGroupByHash                  = ffm("api.endpoints.getGroupByHash", "Gets a group by its ID (hash)")
+	APIEndpointsGetGroupLatency                 = ffm("api.endpoints.getGroupLatency", "Gets how long the node of each group member takes to acknowledge receipt of the private batches sent to it")
+	APIEndpointsGetGroupMemberStatus            = ffm("api.endpoints.getGroupMemberStatus", "Gets the members of a group, with whether their identities are registered and when the node of each member was last seen, so members whose nodes have been offline can be found before sending")
 	APIEndpointsGetGroups                       = ffm("api.endpoints.getGroups", "Gets a list of groups")
+	APIEndpointsGetGroupsByName                 = ffm("api.endpoints.getGroupsByName", "Gets the groups with a given display name. Display names are not unique, so a list is returned")
+	APIEndpointsGetIdempotencyKey               = ffm("api.endpoints.getIdempotencyKey", "Gets an idempotency key, with the transaction and operations it is bound to")
+	APIEndpointsGetIdempotencyKeys              = ffm("api.endpoints.getIdempotencyKeys", "Gets a list of the idempotency keys reserved in the namespace")
 	APIEndpointsGetIdentities                   = ffm("api.endpoints.getIdentities", "Gets a list of all identities that have been registered in the namespace")
 	APIEndpointsGetIdentityByID                 = ffm("api.endpoints.getIdentityByID", "Gets an identity by its ID")
 	APIEndpointsGetIdentityDID                  = ffm("api.endpoints.getIdentityDID", "Gets the DID for an identity based on its ID")
 	APIEndpointsGetIdentityVerifiers            = ffm("api.endpoints.getIdentityVerifiers", "Gets the verifiers for an identity")
+	APIEndpointsGetLedgerBalances               = ffm("api.endpoints.getLedgerBalances", "Gets the token balances of an account in the standardized account/operation model")
+	APIEndpointsGetLedgerTransactions           = ffm("api.endpoints.getLedgerTransactions", "Gets the blockchain events of the namespace as transactions made up of operations on accounts, presenting pins, token transfers and contract events in a standardized account/operation model")
+	APIEndpointsGetNamespaceExport              = ffm("api.endpoints.getNamespaceExport", "Exports the definitions, identities and optionally the messages of the namespace to a portable archive")
+	APIEndpointsGetNamespaceSnapshot            = ffm("api.endpoints.getNamespaceSnapshot", "Exports the confirmed state of the namespace, including batches and their message manifests, as a snapshot signed with the payload signing key of this org")
+	APIEndpointsGetMsgApproval                  = ffm("api.endpoints.getMsgApproval", "Gets the governance approval status of a definition message that requires approval")
 	APIEndpointsGetMsgByID                      = ffm("api.endpoints.getMsgByID", "Gets a message by its ID")
 	APIEndpointsGetMsgData                      = ffm("api.endpoints.getMsgData", "Gets the list of data items that are attached to a message")
 	APIEndpointsGetMsgEvents                    = ffm("api.endpoints.getMsgEvents", "Gets the list of events for a message")
 	APIEndpointsGetMsgTxn                       = ffm("api.endpoints.getMsgTxn", "Gets the transaction for a message")
+	APIEndpointsGetMsgBatchVerify               = ffm("api.endpoints.getMsgBatchVerify", "Verifies the batch a confirmed message was sent in against its BatchPin event on the blockchain and its payload in shared storage")
 	APIEndpointsGetMsgs                         = ffm("api.endpoints.getMsgs", "Gets a list of messages")
+	APIEndpointsGetMsgRetentionPolicies         = ffm("api.endpoints.getMsgRetentionPolicies", "Gets a list of the message retention policies of the namespace")
+	APIEndpointsGetMsgRetentionPolicyByID       = ffm("api.endpoints.getMsgRetentionPolicyByID", "Gets a message retention policy by its ID")
 	APIEndpointsGetNamespace                    = ffm("api.endpoints.getNamespace", "Gets a namespace")
 	APIEndpointsGetNamespaces                   = ffm("api.endpoints.getNamespaces", "Gets a list of namespaces")
+	APIEndpointsGetNetworkActions               = ffm("api.endpoints.getNetworkActions", "Gets the network actions that have been received, and are waiting for their effective block or time")
 	APIEndpointsGetNetworkIdentityByDID         = ffm("api.endpoints.getNetworkIdentityByDID", "Gets an identity by its DID (deprecated - use /identities/{did} instead of /network/identities/{did})")
 	APIEndpointsGetIdentityByDID                = ffm("api.endpoints.getIdentityByDID", "Gets an identity by its DID")
 	APIEndpointsGetDIDDocByDID                  = ffm("api.endpoints.getDIDDocByDID", "Gets a DID document by its DID")
@@ -126,15 +203,28 @@ var (
 	APIEndpointsGetNetworkOrg                   = ffm("api.endpoints.getNetworkOrg", "Gets information about a specific org in the network")
 	APIEndpointsGetNetworkOrgs                  = ffm("api.endpoints.APIEndpointsGetNetworkOrgs", "Gets a list of orgs in the network")
 	APIEndpointsGetOpByID                       = ffm("api.endpoints.getOpByID", "Gets an operation by ID")
+	APIEndpointsGetOpHistory                    = ffm("api.endpoints.getOpHistory", "Gets the history of status transitions recorded for an operation")
+	APIEndpointsGetOpOutputSchemas              = ffm("api.endpoints.getOpOutputSchemas", "Lists the output schemas declared by plugins for each operation type")
 	APIEndpointsGetOps                          = ffm("api.endpoints.getOps", "Gets a a list of operations")
+	APIEndpointsGetSearch                       = ffm("api.endpoints.getSearch", "Searches the tag, topics and data values of messages, returning the matching messages ranked by relevance")
+	APIEndpointsPostGroupMetadata               = ffm("api.endpoints.postGroupMetadata", "Changes the display name and description of a group, by sending the new metadata privately to all members of the group. The change is applied when the message is confirmed")
+	APIEndpointsPostGraphQL                     = ffm("api.endpoints.postGraphQL", "Runs a GraphQL query over the messages, data, batches, events, transactions, token transfers, token pools and identities of the namespace, following the relationships between them")
+	APIEndpointsGetRetentionEstimate            = ffm("api.endpoints.getRetentionEstimate", "Estimates the number of records the data retention policy would prune if it ran now, without deleting anything")
 	APIEndpointsGetStatusBatchManager           = ffm("api.endpoints.getStatusBatchManager", "Gets the status of the batch manager")
 	APIEndpointsGetPins                         = ffm("api.endpoints.getPins", "Queries the list of pins received from the blockchain")
 	APIEndpointsGetNextPins                     = ffm("api.endpoints.getNextPins", "Queries the list of next-pins that determine the next masked message sequence for each member of a privacy group, on each context/topic")
 	APIEndpointsGetWebSockets                   = ffm("api.endpoints.getStatusWebSockets", "Gets a list of the current WebSocket connections to this node")
 	APIEndpointsGetStatus                       = ffm("api.endpoints.getStatus", "Gets the status of this namespace")
+	APIEndpointsGetStatusBootstrap              = ffm("api.endpoints.getStatusBootstrap", "Gets the progress of automatic org and node registration for this namespace")
+	APIEndpointsGetStatusHealth                 = ffm("api.endpoints.getStatusHealth", "Gets the latest health probe results for the plugins this namespace depends on")
+	APIEndpointsGetStatusSLOs                   = ffm("api.endpoints.getStatusSLOs", "Gets the latest measurement of each service level objective configured for this namespace")
+	APIEndpointsGetStatusListenerReconciliation = ffm("api.endpoints.getStatusListenerReconciliation", "Gets the report of the reconciliation of contract listeners with the blockchain connector when this namespace started")
+	APIEndpointsGetStatusCatchUp                = ffm("api.endpoints.getStatusCatchUp", "Gets the progress of the bulk download of historical broadcast batches, while the node catches up with the network")
+	APIEndpointsGetStatusSnapshot               = ffm("api.endpoints.getStatusSnapshot", "Gets the progress of verifying the batches of the imported snapshot against the pins received from the blockchain")
 	APIEndpointsGetMultipartyStatus             = ffm("api.endpoints.getMultipartyStatus", "Gets the registration status of this organization and node on the configured multiparty network")
 	APIEndpointsGetSubscriptionByID             = ffm("api.endpoints.getSubscriptionByID", "Gets a subscription by its ID")
-	APIEndpointsGetSubscriptionEventsFiltered   = ffm("api.endpoints.getSubscriptionEventsFiltered", "Gets a collection of events filtered by the subscription for further filtering")
+	APIEndpointsGetSubscriptionEventsFiltered   = ffm("api.endpoints.getSubscriptionEventsFiltered", "Gets a collection of events filtered by the subscription for further filtering. For a subscription using the poll transport, and no sequence range, gets the next page of events after the stored offset")
+	APIEndpointsGetSubscriptionOffset           = ffm("api.endpoints.getSubscriptionOffset", "Gets the stored offset of a durable subscription")
 	APIEndpointsGetSubscriptions                = ffm("api.endpoints.getSubscriptions", "Gets a list of subscriptions")
 	APIEndpointsGetTokenAccountPools            = ffm("api.endpoints.getTokenAccountPools", "Gets a list of token pools that contain a given token account key")
 	APIEndpointsGetTokenAccounts                = ffm("api.endpoints.getTokenAccounts", "Gets a list of token accounts")
@@ -145,6 +235,7 @@ var (
 	APIEndpointsGetTokenPools                   = ffm("api.endpoints.getTokenPools", "Gets a list of token pools")
 	APIEndpointsGetTokenTransferByID            = ffm("api.endpoints.getTokenTransferByID", "Gets a token transfer by its ID")
 	APIEndpointsGetTokenTransfers               = ffm("api.endpoints.getTokenTransfers", "Gets a list of token transfers")
+	APIEndpointsGetTrafficMatrix                = ffm("api.endpoints.getTrafficMatrix", "Gets the number of messages and token transfers, and the bytes of message data, exchanged between the local org and each counterparty org over a time window")
 	APIEndpointsGetTxnBlockchainEvents          = ffm("api.endpoints.getTxnBlockchainEvents", "Gets a list blockchain events for a specific transaction")
 	APIEndpointsGetTxnByID                      = ffm("api.endpoints.getTxnByID", "Gets a transaction by its ID")
 	APIEndpointsGetTxnOps                       = ffm("api.endpoints.getTxnOps", "Gets a list of operations in a specific transaction")
@@ -163,25 +254,35 @@ var (
 	APIEndpointsPostContractInterfaceQuery      = ffm("api.endpoints.postContractInterfaceQuery", "Queries a method on a smart contract that matches a given contract interface. Performs a read-only query.")
 	APIEndpointsPostContractInterfacePublish    = ffm("api.endpoints.postContractInterfacePublish", "Publish a contract interface to all other members of the multiparty network")
 	APIEndpointsPostContractInvoke              = ffm("api.endpoints.postContractInvoke", "Invokes a method on a smart contract. Performs a blockchain transaction.")
+	APIEndpointsPostContractInvokeSequence      = ffm("api.endpoints.postContractInvokeSequence", "Invokes an ordered sequence of smart contract methods as the steps of a single FireFly transaction. Each step is submitted once the step before it succeeds. If a step fails permanently, the compensating invocations of the earlier steps are submitted in reverse order")
 	APIEndpointsPostContractQuery               = ffm("api.endpoints.postContractQuery", "Queries a method on a smart contract. Performs a read-only query.")
 	APIEndpointsPostData                        = ffm("api.endpoints.postData", "Creates a new data item in this FireFly node")
 	APIEndpointsPostDataValuePublish            = ffm("api.endpoints.postDataValuePublish", "Publishes the JSON value from the specified data resource, to shared storage")
 	APIEndpointsPostDataBlobPublish             = ffm("api.endpoints.postDataBlobPublish", "Publishes the binary blob attachment stored in your local data exchange, to shared storage")
+	APIEndpointsPostNamespaceSnapshot           = ffm("api.endpoints.postNamespaceSnapshot", "Imports a snapshot exported by another node of this org, to bootstrap a new node. The pinned batches of the snapshot are verified against the blockchain in the background")
+	APIEndpointsPostMsgApprove                  = ffm("api.endpoints.postMsgApprove", "Broadcasts an approval from this node's org, for a definition message that is pending approval")
+	APIEndpointsPostMsgDisclosure               = ffm("api.endpoints.postMsgDisclosure", "Generates a zero-knowledge proof of a statement about the data of a message, using the ZK proof plugin, and broadcasts it to the network without revealing the data")
+	APIEndpointsPostMsgRelease                  = ffm("api.endpoints.postMsgRelease", "Releases a confirmed message held by the quarantine policy, delivering it to subscriptions")
 	APIEndpointsPostNewContractAPI              = ffm("api.endpoints.postNewContractAPI", "Creates and broadcasts a new custom smart contract API")
 	APIEndpointsPostNewContractInterface        = ffm("api.endpoints.postNewContractInterface", "Creates and broadcasts a new custom smart contract interface")
 	APIEndpointsPostNewContractListener         = ffm("api.endpoints.postNewContractListener", "Creates a new blockchain listener for events emitted by custom smart contracts")
 	APIEndpointsPostContractListenerHash        = ffm("api.endpoints.postContractListenerHash", "Calculates the hash of a blockchain listener filters and events")
 	APIEndpointsPostNewDatatype                 = ffm("api.endpoints.postNewDatatype", "Creates and broadcasts a new datatype")
+	APIEndpointsPostDatatypeInfer               = ffm("api.endpoints.postDatatypeInfer", "Infers a JSON schema datatype from sample payloads. The datatype is only created, by broadcasting it, when publish is set. Otherwise it is returned for review")
 	APIEndpointsPostNewIdentity                 = ffm("api.endpoints.postNewIdentity", "Registers a new identity in the network")
 	APIEndpointsPostNewMessageBroadcast         = ffm("api.endpoints.postNewMessageBroadcast", "Broadcasts a message to all members in the network")
 	APIEndpointsPostNewMessagePrivate           = ffm("api.endpoints.postNewMessagePrivate", "Privately sends a message to one or more members in the network")
 	APIEndpointsPostNewMessageRequestReply      = ffm("api.endpoints.postNewMessageRequestReply", "Sends a message with a blocking HTTP request, waits for a reply to that message, then sends the reply as the HTTP response.")
+	APIEndpointsPostNewMsgRetentionPolicy       = ffm("api.endpoints.postNewMsgRetentionPolicy", "Creates a message retention policy, which deletes the data of confirmed messages on a topic or with a tag once they are older than its max age")
 	APIEndpointsPostNewNamespace                = ffm("api.endpoints.postNewNamespace", "Creates and broadcasts a new namespace")
 	APIEndpointsPostNodesSelf                   = ffm("api.endpoints.postNodesSelf", "Instructs this FireFly node to register itself on the network")
 	APIEndpointsPostNewOrganizationSelf         = ffm("api.endpoints.postNewOrganizationSelf", "Instructs this FireFly node to register its org on the network")
 	APIEndpointsPostNewOrganization             = ffm("api.endpoints.postNewOrganization", "Registers a new org in the network")
 	APIEndpointsPostNewSubscription             = ffm("api.endpoints.postNewSubscription", "Creates a new subscription for an application to receive events from FireFly")
+	APIEndpointsPostSubscriptionEventsAck       = ffm("api.endpoints.postSubscriptionEventsAck", "Acknowledges the events polled from a subscription using the poll transport, up to and including the supplied sequence")
+	APIEndpointsPostOpApprovalApprove           = ffm("api.endpoints.postOpApprovalApprove", "Approves an operation that is held back by an approval policy, as the authenticated user. The operation is submitted once the required number of approvals is reached")
 	APIEndpointsPostOpRetry                     = ffm("api.endpoints.postOpRetry", "Retries a failed operation")
+	APIEndpointsPostOpsRetry                    = ffm("api.endpoints.postOpsRetry", "Retries all failed operations matching a filter, or previews them with dryRun")
 	APIEndpointsPostPinsRewind                  = ffm("api.endpoints.postPinsRewind", "Force a rewind of the event aggregator to a previous position, to re-evaluate (and possibly dispatch) that pin and others after it. Only accepts a sequence or batch ID for a currently undispatched pin")
 	APIEndpointsPostTokenApproval               = ffm("api.endpoints.postTokenApproval", "Creates a token approval")
 	APIEndpointsPostTokenBurn                   = ffm("api.endpoints.postTokenBurn", "Burns some tokens")
@@ -190,9 +291,13 @@ var (
 	APIEndpointsPostTokenPoolPublish            = ffm("api.endpoints.postTokenPoolPublish", "Publish a token pool to all other members of the multiparty network")
 	APIEndpointsPostTokenTransfer               = ffm("api.endpoints.postTokenTransfer", "Transfers some tokens")
 	APIEndpointsPutContractAPI                  = ffm("api.endpoints.putContractAPI", "Updates an existing contract API")
+	APIEndpointsPutMsgRetentionPolicy           = ffm("api.endpoints.putMsgRetentionPolicy", "Updates a message retention policy. Changing its topic or tag restarts its checks from the first message")
 	APIEndpointsPutSubscription                 = ffm("api.endpoints.putSubscription", "Update an existing subscription")
+	APIEndpointsPutSubscriptionOffset           = ffm("api.endpoints.putSubscriptionOffset", "Commits the offset of a durable subscription, for consumers that poll for events instead of connecting. Rejected while an application is connected to the subscription")
 	APIEndpointsGetContractAPIInterface         = ffm("api.endpoints.getContractAPIInterface", "Gets a contract interface for a contract API")
-	APIEndpointsPostNetworkAction               = ffm("api.endpoints.postNetworkAction", "Notify all nodes in the network of a new governance action")
+	APIEndpointsPostNetworkAction               = ffm("api.endpoints.postNetworkAction", "Notify all nodes in the network of a new governance action. The action can be scheduled for a future effectiveBlock or effectiveTime, at which every node applies it")
+	APIEndpointsPostNetworkMigration            = ffm("api.endpoints.postNetworkMigration", "Propose that all members of the network switch to the next configured FireFly contract at an agreed block")
+	APIEndpointsPostNetworkMigrationAck         = ffm("api.endpoints.postNetworkMigrationAck", "Acknowledges a proposed contract migration on behalf of this node's org, after checking the new contract is configured locally")
 	APIEndpointsPostVerifiersResolve            = ffm("api.endpoints.postVerifiersResolve", "Resolves an input key to a signing key")
 
 	APIFilterParamDesc         = ffm("api.filterParam", "Data filter field. Prefixes supported: > >= < <= @ ^ ! !@ !^")
@@ -205,10 +310,16 @@ var (
 	APIFetchDataDesc           = ffm("api.fetchData", "Fetch the data and include it in the messages returned")
 	APIConfirmMsgQueryParam    = ffm("api.confirmMsgQueryParam", "When true the HTTP request blocks until the message is confirmed")
 	APIConfirmInvokeQueryParam = ffm("api.confirmInvokeQueryParam", "When true the HTTP request blocks until the blockchain transaction is confirmed")
+	APIExportMsgsQueryParam    = ffm("api.exportMessagesQueryParam", "When true the archive includes all messages and data records of the namespace")
 	APIPublishQueryParam       = ffm("api.publishQueryParam", "When true the definition will be published to all other members of the multiparty network")
 	APIHistogramStartTimeParam = ffm("api.histogramStartTime", "Start time of the data to be fetched")
 	APIHistogramEndTimeParam   = ffm("api.histogramEndTime", "End time of the data to be fetched")
 	APIHistogramBucketsParam   = ffm("api.histogramBuckets", "Number of buckets between start time and end time")
+	APISearchQueryParam        = ffm("api.searchQuery", "The text to search for within the tag, topics and data values of messages")
+	APISearchLimitParam        = ffm("api.searchLimit", "The maximum number of matching messages to return, most relevant first")
+	APIActivityCategoryParam   = ffm("api.activityCategory", "A comma-separated list of the categories of activity to include - message, tokentransfer, contractinvoke, identity or network. Defaults to all categories")
+	APITrafficStartTimeParam   = ffm("api.trafficStartTime", "The start of the time window, rounded down to the hour. Defaults to the configured traffic.defaultWindow before the end time")
+	APITrafficEndTimeParam     = ffm("api.trafficEndTime", "The end of the time window. Defaults to now")
 
 	APISmartContractDetails      = ffm("api.smartContractDetails", "Additional smart contract details")
 	APISmartContractDetailsKey   = ffm("api.smartContractDetailsKey", "Key")
@@ -17,14 +17,35 @@
 package coremsgs
 
 import (
+	"net/http"
+
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"golang.org/x/text/language"
 )
 
+// ErrorTemplate is the English template of an error defined by the core, with the HTTP status it maps to
+type ErrorTemplate struct {
+	Code     string
+	Template string
+	Status   int
+}
+
+var errorTemplates = []*ErrorTemplate{}
+
 var ffe = func(key, translation string, statusHint ...int) i18n.ErrorMessageKey {
+	status := http.StatusInternalServerError
+	if len(statusHint) > 0 {
+		status = statusHint[0]
+	}
+	errorTemplates = append(errorTemplates, &ErrorTemplate{Code: key, Template: translation, Status: status})
 	return i18n.FFE(language.AmericanEnglish, key, translation, statusHint...)
 }
 
+// ErrorTemplates returns every error defined by the core, in the order they are defined
+func ErrorTemplates() []*ErrorTemplate {
+	return errorTemplates
+}
+
 //revive:disable
 var (
 	MsgConfigFailed                            = ffe("FF10101", "Failed to read config")
@@ -321,4 +342,210 @@ var (
 	MsgInvalidIdentityPatch                    = ffe("FF10480", "A profile must be provided when updating an identity", 400)
 	MsgNodeNotProvidedForCheck                 = ffe("FF10481", "Node not provided for check", 500)
 	MsgNodeMissingProfile                      = ffe("FF10482", "Node provided for check does not have a profile", 500)
+	MsgCustomDefinitionTagInvalid              = ffe("FF10483", "Custom definition tag '%s' must begin with '%s'", 400)
+	MsgCustomDefinitionTagReserved             = ffe("FF10484", "Custom definition tag '%s' is reserved for a built-in definition type", 400)
+	MsgCustomDefinitionTagDuplicate            = ffe("FF10485", "Custom definition tag '%s' is already registered", 409)
+	MsgCustomDefinitionUnknown                 = ffe("FF10486", "No handler is registered for custom definition tag '%s'", 400)
+	MsgDefinitionApprovalNotRequired           = ffe("FF10487", "Message '%s' is not a definition that requires approval", 400)
+	MsgDefinitionApprovalNotPending            = ffe("FF10488", "Definition '%s' is not pending approval", 409)
+	MsgNamespaceConfigUpdateEmpty              = ffe("FF10489", "Namespace configuration update must include namespace or plugin configuration", 400)
+	MsgNamespaceConfigNameMismatch             = ffe("FF10490", "Namespace name '%s' in configuration does not match '%s'", 400)
+	MsgUnknownPluginCategory                   = ffe("FF10491", "Unknown plugin category '%s'", 400)
+	MsgNamespaceConfigUpdateOutOfScope         = ffe("FF10492", "Configuration update for namespace '%s' would also restart namespace '%s'", 409)
+	MsgNamespaceArchiveVersion                 = ffe("FF10493", "Unsupported namespace archive version %d - expected %d", 400)
+	MsgNamespaceQuotaExceeded                  = ffe("FF10494", "Namespace '%s' would exceed its %s quota of %d", 429)
+	MsgNamespaceQuotaInvalid                   = ffe("FF10495", "Namespace quota limits must not be negative", 400)
+	MsgNamespaceAlreadyExists                  = ffe("FF10496", "Namespace '%s' already exists", 409)
+	MsgNamespaceReadOnly                       = ffe("FF10497", "Namespace '%s' is a read-only replica and does not accept submissions", 403)
+	MsgContractMigrationLocationMismatch       = ffe("FF10498", "Contract migration location %s does not match the next configured FireFly contract %s", 400)
+	MsgContractMigrationVersionMismatch        = ffe("FF10499", "Contract migration version %d does not match version %d of the next configured FireFly contract", 400)
+	MsgContractMigrationInProgress             = ffe("FF10500", "Contract migration '%s' is already in progress", 409)
+	MsgContractMigrationNotPending             = ffe("FF10501", "Contract migration '%s' is not awaiting acknowledgements", 409)
+	MsgCircuitBreakerOpen                      = ffe("FF10502", "Circuit breaker for plugin '%s' is open after repeated failures - retry after %s", 503)
+	MsgDuplicateRetryPolicy                    = ffe("FF10503", "More than one retry policy is configured for operation type '%s'")
+	MsgInvalidRetryPolicyJitter                = ffe("FF10504", "Retry policy for operation type '%s' has jitter %f - must be between 0 and 1")
+	MsgTransactionNotFound                     = ffe("FF10505", "Transaction '%s' not found", 404)
+	MsgSagaNotActive                           = ffe("FF10506", "Transaction '%s' has saga state '%s' - no further steps can be added", 409)
+	MsgSagaStepSkipped                         = ffe("FF10673", "Not run, as an earlier step of the transaction failed with operation '%s'")
+	MsgContractInvokeSequenceEmpty             = ffe("FF10674", "A contract invoke sequence must have at least one step", 400)
+	MsgContractInvokeSequenceNoInvoke          = ffe("FF10675", "Step %d of a contract invoke sequence must have an invoke request", 400)
+	MsgContractInvokeSequenceMessage           = ffe("FF10676", "Step %d of a contract invoke sequence cannot have a message - pinned messages are not supported in a sequence", 400)
+	MsgInvalidPartitionConfig                  = ffe("FF10507", "Invalid database partitioning configuration: %s")
+	MsgInvalidReplicaStaleness                 = ffe("FF10508", "Invalid read replica staleness '%v' for collection '%s'")
+	MsgNotPrunableCollection                   = ffe("FF10509", "Collection '%s' does not support retention", 400)
+	MsgNotArchivableCollection                 = ffe("FF10510", "Collection '%s' does not support archiving")
+	MsgUnsupportedArchiveFormat                = ffe("FF10511", "Archive format '%s' is not supported")
+	MsgUnsupportedArchiveStore                 = ffe("FF10512", "Archive store type '%s' is not supported")
+	MsgArchiveStoreErr                         = ffe("FF10513", "Error from archive store: %s")
+	MsgOnlineMigrationNotFound                 = ffe("FF10514", "Online migration '%s' not found", 404)
+	MsgOnlineMigrationBadDefinition            = ffe("FF10515", "Invalid online migration definition '%s': %s")
+	MsgOnlineMigrationRowMismatch              = ffe("FF10516", "Online migration '%s' verification failed: %d rows of table '%s' are missing or different in the new table, which has %d rows not in the original")
+	MsgOnlineMigrationRunning                  = ffe("FF10517", "Online migration '%s' is already running", 409)
+	MsgOnlineMigrationBadStatus                = ffe("FF10518", "Online migration '%s' has status '%s'", 409)
+	MsgOnlineMigrationStatementFailed          = ffe("FF10519", "Online migration statement failed: %s")
+	MsgSearchNotEnabled                        = ffe("FF10520", "Full-text search is not enabled", 400)
+	MsgUnsupportedSearchType                   = ffe("FF10521", "Search type '%s' is not supported")
+	MsgSearchIndexErr                          = ffe("FF10522", "Error from search index: %s")
+	MsgSearchQueryRequired                     = ffe("FF10523", "A search query must be provided with the 'q' parameter", 400)
+	MsgInvalidSearchLimit                      = ffe("FF10524", "Invalid search limit '%s' - must be a positive number", 400)
+	MsgGraphQLSyntaxError                      = ffe("FF10525", "Invalid GraphQL query at offset %d: %s")
+	MsgGraphQLUnsupported                      = ffe("FF10526", "GraphQL %s are not supported")
+	MsgGraphQLOperationNotFound                = ffe("FF10527", "GraphQL operation '%s' not found")
+	MsgGraphQLOperationRequired                = ffe("FF10528", "An operationName must be provided when the GraphQL document contains multiple operations")
+	MsgGraphQLUnknownField                     = ffe("FF10529", "Cannot query field '%s' on type '%s'")
+	MsgGraphQLSubselectionRequired             = ffe("FF10530", "Field '%s' of type '%s' must have a selection of subfields")
+	MsgGraphQLMissingArgument                  = ffe("FF10531", "Missing required argument '%s'")
+	MsgGraphQLBadArgument                      = ffe("FF10532", "Invalid value for argument '%s'")
+	MsgGraphQLUndefinedVariable                = ffe("FF10533", "Variable '$%s' is not defined")
+	MsgGraphQLMaxDepth                         = ffe("FF10534", "GraphQL query exceeds the maximum depth of %d")
+	MsgInvalidCursor                           = ffe("FF10535", "Invalid pagination cursor", 400)
+	MsgCursorWithSortOrSkip                    = ffe("FF10536", "A pagination cursor cannot be combined with the sort or skip parameters", 400)
+	MsgCursorNotSupported                      = ffe("FF10537", "Pagination cursors are not supported for this collection", 400)
+	MsgStreamWithSort                          = ffe("FF10538", "NDJSON exports are streamed in cursor order, so cannot be combined with the sort parameter", 400)
+	MsgAuditNotEnabled                         = ffe("FF10539", "The audit log is not enabled", 400)
+	MsgOIDCRESTErr                             = ffe("FF10540", "Error from OIDC issuer: %s")
+	MsgOIDCInvalidPermission                   = ffe("FF10541", "Invalid permission '%s' in role '%s' of OIDC auth plugin")
+	MsgAuthTokenMissing                        = ffe("FF10542", "A bearer token is required", 401)
+	MsgAuthTokenInvalid                        = ffe("FF10543", "Invalid bearer token: %s", 401)
+	MsgAuthPermissionDenied                    = ffe("FF10544", "Permission '%s' is not granted in namespace '%s'", 403)
+	MsgRateLimitExceeded                       = ffe("FF10545", "Rate limit of the %s routes of namespace '%s' exceeded. Retry after %d seconds", 429)
+	MsgRebuildRunning                          = ffe("FF10546", "A rebuild of %s is already running in namespace '%s'", 409)
+	MsgRebuildUnknownTarget                    = ffe("FF10547", "Unknown rebuild target '%s' - must be one of nextpins, messagestates or tokenbalances", 400)
+	MsgInvalidLogLevel                         = ffe("FF10548", "Invalid log level '%s'", 400)
+	MsgUnknownLogSubsystem                     = ffe("FF10549", "Unknown log subsystem '%s' - must be one of batch, events, aggregator or plugins", 400)
+	MsgNoLogCapture                            = ffe("FF10550", "No log capture has been started", 404)
+	MsgSLONotifyFailed                         = ffe("FF10551", "Failed to post SLO notification: %s")
+	MsgUnsupportedCacheBackend                 = ffe("FF10552", "Unsupported distributed cache type '%s'")
+	MsgCacheBackendInitFailed                  = ffe("FF10553", "Failed to initialize distributed cache backend")
+	MsgCacheNotFound                           = ffe("FF10554", "Cache '%s' not found", 404)
+	MsgP2PDXInvalidPeer                        = ffe("FF10555", "Invalid peer '%s' for the p2pdx data exchange - an id, endpoint and cert are required")
+	MsgP2PDXUnknownPeer                        = ffe("FF10556", "Unknown data exchange peer '%s'")
+	MsgP2PDXSenderMismatch                     = ffe("FF10557", "TLS client certificate does not match the registered certificate of peer '%s'", 403)
+	MsgP2PDXInvalidBlobPath                    = ffe("FF10558", "Invalid blob path '%s'", 400)
+	MsgP2PDXBadCert                            = ffe("FF10559", "Failed to load the TLS certificate and key for the p2pdx data exchange")
+	MsgP2PDXAckTimeout                         = ffe("FF10560", "Timed out waiting for the data exchange event '%s' to be processed", 503)
+	MsgP2PDXInvalidChunk                       = ffe("FF10561", "Invalid blob chunk offset='%s' total='%s'", 400)
+	MsgP2PDXChunkOffsetMismatch                = ffe("FF10562", "Blob chunk at offset %d does not continue from the %d bytes already received", 409)
+	MsgUnsupportedChangeSinkType               = ffe("FF10563", "Change event sink type '%s' is not supported, for sink '%s'")
+	MsgChangeSinkPublishFailed                 = ffe("FF10564", "Failed to publish change events: %s")
+	MsgDuplicateChangeSinkName                 = ffe("FF10565", "Duplicate change event sink name '%s'")
+	MsgInvalidChangeEventResumeFrom            = ffe("FF10566", "Invalid resumeFrom '%s' - must be the changeSequence of a previously received change event", 400)
+	MsgSPIWebhookFailed                        = ffe("FF10567", "Failed to post lifecycle event to SPI webhook: %s")
+	MsgSecretProviderUnknown                   = ffe("FF10568", "Unknown secrets provider '%s' referenced in config key '%s'")
+	MsgSecretResolveFailed                     = ffe("FF10569", "Failed to resolve secret '%s' from provider '%s' for config key '%s'")
+	MsgSecretProviderRequestFailed             = ffe("FF10570", "Secrets provider request failed: %s")
+	MsgSecretFieldNotFound                     = ffe("FF10571", "Field '%s' not found in secret")
+	MsgSecretFileOutsideDirectory              = ffe("FF10572", "Secret file '%s' is outside of the secrets directory '%s'")
+	MsgDXNotConnectedForRotation               = ffe("FF10573", "Cannot rotate credentials while the data exchange at %s has not yet connected")
+	MsgPayloadSigningNotConfigured             = ffe("FF10574", "Payload signing is enabled, but neither a key file nor a signing plugin is configured for the namespace")
+	MsgPayloadSigningKeyInvalid                = ffe("FF10575", "Invalid payload signing key file '%s'")
+	MsgPayloadSigningFailed                    = ffe("FF10576", "Failed to sign batch payload")
+	MsgPayloadSignatureMissing                 = ffe("FF10577", "Batch '%s' does not have a payload signature, and payload signatures are required")
+	MsgPayloadSignatureInvalid                 = ffe("FF10578", "Invalid payload signature on batch '%s'")
+	MsgPayloadSigningKeyNotRegistered          = ffe("FF10579", "The payload signing key of batch '%s' is not in the profile of author '%s' or of its parent orgs")
+	MsgUnknownSigningPlugin                    = ffe("FF10580", "Unknown signing plugin '%s'")
+	MsgSigningRequestFailed                    = ffe("FF10581", "Signing plugin request failed: %s")
+	MsgSigningPublicKeyInvalid                 = ffe("FF10582", "Invalid or unsupported public key '%s' returned by the signing plugin")
+	MsgSigningSignatureInvalid                 = ffe("FF10583", "Invalid signature returned by the signing plugin for key '%s'")
+	MsgAuditAnchorNotEnabled                   = ffe("FF10584", "Audit anchoring is not enabled for this namespace", 400)
+	MsgAuditAnchorEventNotFound                = ffe("FF10585", "Event '%s' is not committed to by audit anchor '%s'", 404)
+	MsgUnknownZKProofPlugin                    = ffe("FF10586", "Unknown ZK proof plugin '%s'")
+	MsgZKProofRequestFailed                    = ffe("FF10587", "ZK proof service request failed: %s")
+	MsgZKProofEmpty                            = ffe("FF10588", "ZK proof service returned an empty proof for circuit '%s'")
+	MsgZKProofNotConfigured                    = ffe("FF10589", "No ZK proof plugin is configured for this namespace", 400)
+	MsgDisclosureProofInvalid                  = ffe("FF10590", "Proof of disclosure '%s' is not valid for circuit '%s'")
+	MsgListenerNotFoundInConnector             = ffe("FF10591", "Contract listener '%s' was not found in the blockchain connector")
+	MsgListenerReconcileTimeout                = ffe("FF10592", "Timed out after %s waiting for contract listener '%s' to catch up to block %d")
+	MsgListenerReconcileNotEnabled             = ffe("FF10593", "Contract listener reconciliation is not enabled for this namespace", 404)
+	MsgBatchNotPinned                          = ffe("FF10594", "Batch '%s' was sent with transaction type '%s', so has no pin to verify", 400)
+	MsgMessageNotInBatch                       = ffe("FF10595", "Message '%s' has not been assigned to a batch", 400)
+	MsgBatchManifestEntryMissing               = ffe("FF10596", "%s '%s' in the manifest of the batch was not found")
+	MsgBatchManifestEntryHashInvalid           = ffe("FF10597", "Hash of %s '%s' does not match its content")
+	MsgBatchPinEventMissing                    = ffe("FF10598", "No BatchPin event is recorded for batch transaction '%s'")
+	MsgCatchUpNotEnabled                       = ffe("FF10599", "Historical catch-up is not enabled for this namespace", 404)
+	MsgSnapshotSigningNotEnabled               = ffe("FF10600", "Payload signing must be enabled for the namespace to export or import a snapshot", 400)
+	MsgSnapshotHashMismatch                    = ffe("FF10601", "The hash of the snapshot archive '%s' does not match the hash of the snapshot '%s'", 400)
+	MsgSnapshotSignatureInvalid                = ffe("FF10602", "The snapshot is not signed with the payload signing key of this org", 400)
+	MsgSnapshotNotImported                     = ffe("FF10603", "No snapshot has been imported into this namespace since the node started", 404)
+	MsgFaultInjectionNotEnabled                = ffe("FF10604", "Fault injection is not enabled. It can only be enabled with faults.enabled in the configuration of a non-production node", 409)
+	MsgFaultRuleInvalid                        = ffe("FF10605", "Fault rule %d is invalid: %s", 400)
+	MsgFaultInjected                           = ffe("FF10606", "Fault injected into %s call '%s'", 503)
+	MsgLoadTestNotSandbox                      = ffe("FF10607", "Namespace '%s' is not a sandbox. Load tests can only be run in a namespace with sandbox set in its configuration", 409)
+	MsgLoadTestRunning                         = ffe("FF10608", "Load test '%s' is already running in this namespace", 409)
+	MsgLoadTestInvalid                         = ffe("FF10609", "Invalid load test: %s", 400)
+	MsgLoadTestNotFound                        = ffe("FF10610", "No load test has been run in this namespace since the node started", 404)
+	MsgInvalidTokenPoolFirstEvent              = ffe("FF10611", "Invalid firstEvent '%s' for token pool - must be 'newest', 'oldest' or a block number", 400)
+	MsgInvalidOperationOutputSchema            = ffe("FF10612", "Invalid output schema declared by plugin '%s' for operation type '%s': %s")
+	MsgOperationOutputInvalidPerSchema         = ffe("FF10613", "Output from plugin '%s' for operation type '%s' does not match its declared schema: %s")
+	MsgDatatypeInferenceNoSamples              = ffe("FF10614", "At least one sample payload is required to infer a datatype", 400)
+	MsgDatatypeInferenceBadSample              = ffe("FF10615", "Sample %d is not a valid JSON payload", 400)
+	MsgGroupMetadataNotMember                  = ffe("FF10616", "Signing identity '%s' on node '%s' is not a member of group '%s'", 403)
+	MsgSubscriptionOffsetActive                = ffe("FF10617", "Subscription '%s' has an active connection, so its offset cannot be set", 409)
+	MsgInvalidSubscriptionOffset               = ffe("FF10618", "Invalid subscription offset %d - must be zero or greater", 400)
+	MsgPollTransportNoDelivery                 = ffe("FF10619", "Events on subscriptions using the '%s' transport are fetched by the consumer, and cannot be delivered", 500)
+	MsgSubscriptionNotPolled                   = ffe("FF10620", "Subscription '%s' uses the '%s' transport - events can only be polled and acknowledged on subscriptions using the 'poll' transport", 400)
+	MsgDependencyStartupFailed                 = ffe("FF10621", "The %s plugin '%s' did not start: %s")
+	MsgConfigReloadNoFile                      = ffe("FF10622", "No configuration file is in use, so there is no reload to preview", 400)
+	MsgConfigReloadReadFailed                  = ffe("FF10623", "Failed to read configuration file '%s'", 400)
+	MsgBatchStreamUnexpectedToken              = ffe("FF10624", "Invalid batch - expected '%s' at offset %d")
+	MsgBatchStreamDataHashMismatch             = ffe("FF10625", "Invalid data entry %d in batch: Hash=%v Expected=%v")
+	MsgBatchStreamInvalidMessage               = ffe("FF10626", "Invalid message entry %d in batch")
+	MsgDuplicateApprovalPolicy                 = ffe("FF10627", "More than one approval policy is configured for operation type '%s'")
+	MsgInvalidApprovalPolicyApprovers          = ffe("FF10628", "Approval policy for operation type '%s' requires %d approvers - must be at least 1")
+	MsgInvalidApprovalPolicyMinAmount          = ffe("FF10629", "Approval policy for operation type '%s' has invalid minAmount '%s' - a minimum amount can only be set for token_transfer operations, as an integer")
+	MsgOperationApprovalNotFound               = ffe("FF10630", "Operation approval '%s' not found", 404)
+	MsgOperationApprovalNotPending             = ffe("FF10631", "Operation approval '%s' is %s, and can no longer be approved", 409)
+	MsgOperationAlreadyApproved                = ffe("FF10632", "'%s' has already approved operation approval '%s'", 409)
+	MsgOperationApprovalNoIdentity             = ffe("FF10633", "Approving an operation requires a user authenticated by the auth plugin of the namespace", 401)
+	MsgOperationApprovalExpired                = ffe("FF10634", "Approval of operation '%s' expired at %s before %d of %d approvals were given")
+	MsgUnknownPolicyPlugin                     = ffe("FF10635", "Unknown policy plugin '%s'")
+	MsgPolicyRequestFailed                     = ffe("FF10636", "Policy decision request failed: %s", 503)
+	MsgPolicyDecisionUndefined                 = ffe("FF10637", "Policy decision '%s' is not defined", 503)
+	MsgPolicyDenied                            = ffe("FF10638", "Request denied by policy: %s", 403)
+	MsgMessageNotQuarantined                   = ffe("FF10639", "Message '%s' is %s, and is not quarantined", 409)
+	MsgMessageRetentionPolicyNotFound          = ffe("FF10640", "Message retention policy '%s' not found", 404)
+	MsgMessageRetentionPolicyNoScope           = ffe("FF10641", "A message retention policy must have a topic or a tag", 400)
+	MsgMessageRetentionPolicyMaxAge            = ffe("FF10642", "A message retention policy must have a maxAge greater than zero", 400)
+	MsgMessageRetentionPolicyExists            = ffe("FF10643", "A message retention policy named '%s' already exists", 409)
+	MsgTrafficNotEnabled                       = ffe("FF10644", "Traffic totals are not enabled", 400)
+	MsgInvalidTimeParam                        = ffe("FF10645", "Invalid %s. Must be an RFC3339 time or a UNIX timestamp", 400)
+	MsgNetworkActionScheduleConflict           = ffe("FF10646", "A network action can have an effectiveBlock or an effectiveTime, but not both", 400)
+	MsgNetworkActionScheduleInPast             = ffe("FF10647", "The effectiveTime of a network action must be in the future", 400)
+	MsgDuplicateEventBridgeName                = ffe("FF10648", "Duplicate event bridge name '%s' in namespace '%s'")
+	MsgEventBridgeNoSelector                   = ffe("FF10649", "Event bridge '%s' must set a listener or a topic, to select the blockchain events it bridges")
+	MsgUnsupportedEventBridgeMessageType       = ffe("FF10650", "Event bridge message type '%s' is not supported, for bridge '%s'")
+	MsgEventBridgeNoMembers                    = ffe("FF10651", "Event bridge '%s' sends private messages, but has no members")
+	MsgEventBridgeInvalidTemplate              = ffe("FF10652", "Invalid payload template for event bridge '%s': %s")
+	MsgEventBridgeMessagingDisabled            = ffe("FF10653", "Event bridge '%s' cannot send %s messages, as messaging is not enabled in namespace '%s'")
+	MsgDuplicateMessageTriggerName             = ffe("FF10654", "Duplicate message trigger name '%s' in namespace '%s'")
+	MsgMessageTriggerNoSelector                = ffe("FF10655", "Message trigger '%s' must set a topic, a tag or a datatype, to select the messages it is triggered by")
+	MsgUnsupportedMessageTriggerAction         = ffe("FF10656", "Message trigger action '%s' is not supported, for trigger '%s'")
+	MsgMessageTriggerInvalidTemplate           = ffe("FF10657", "Invalid request template for message trigger '%s': %s")
+	MsgMessageTriggerActionDisabled            = ffe("FF10658", "Message trigger '%s' cannot perform '%s' actions, as contracts are not enabled in namespace '%s'")
+	MsgDuplicateExtensionName                  = ffe("FF10659", "Duplicate extension name '%s' in namespace '%s'")
+	MsgUnsupportedExtensionHook                = ffe("FF10660", "Hook '%s' is not supported, for extension '%s'")
+	MsgExtensionLoadFailed                     = ffe("FF10661", "Failed to load WASM module for extension '%s': %s")
+	MsgExtensionCallFailed                     = ffe("FF10662", "Extension '%s' failed running hook '%s': %s")
+	MsgExtensionInvalidOutput                  = ffe("FF10663", "Extension '%s' returned invalid output from hook '%s': %s")
+	MsgExtensionRejectedMessage                = ffe("FF10664", "Message rejected by extension '%s': %s", 400)
+	MsgDefinitionReplayNotSandbox              = ffe("FF10665", "Namespace '%s' is not a sandbox. Definition replay can only be run in a namespace with sandbox set in its configuration", 409)
+	MsgDefinitionReplayInvalidRecord           = ffe("FF10666", "Definition replay record %d must have a definition message, and a recorded outcome of confirmed or rejected", 400)
+	MsgWSInvalidWatchAction                    = ffe("FF10667", "A watch or unwatch action must set namespace and at least one operation or transaction ID")
+	MsgGraphQLMaxFields                        = ffe("FF10668", "GraphQL query exceeds the maximum of %d selected fields")
+	MsgGraphQLMaxAliases                       = ffe("FF10669", "GraphQL query exceeds the maximum of %d aliases")
+	MsgGraphQLMaxCost                          = ffe("FF10670", "GraphQL query has an estimated cost of %d, which exceeds the maximum of %d")
+	MsgOnlineMigrationNotSupported             = ffe("FF10671", "Online migrations are not supported by this database provider")
+	MsgNamespaceImportNotEmpty                 = ffe("FF10677", "Cannot import into namespace '%s' as it already contains %s - an archive can only be imported into a new namespace", 409)
+	MsgPKCS11LoadFailed                        = ffe("FF10678", "Failed to load PKCS#11 module '%s'")
+	MsgPKCS11TokenNotFound                     = ffe("FF10679", "No PKCS#11 token found with label '%s'")
+	MsgPKCS11KeyNotFound                       = ffe("FF10680", "Key '%s' not found in the PKCS#11 token")
+	MsgSigningPluginNotConfigured              = ffe("FF10681", "No signing plugin is configured for this namespace", 400)
+	MsgTypedDataKeyNotSecp256k1                = ffe("FF10682", "Key '%s' is not a secp256k1 key, so cannot sign EIP-712 typed data", 400)
+	MsgTypedDataInvalid                        = ffe("FF10683", "Invalid EIP-712 typed data", 400)
+	MsgTypedDataSignatureInvalid               = ffe("FF10684", "The signature returned for key '%s' does not match its public key")
+	MsgCredentialSigningKeyUnsupported         = ffe("FF10685", "Credentials can only be issued with a P-256 ECDSA or RSA payload signing key", 400)
+	MsgCredentialSubjectMissing                = ffe("FF10686", "A credentialSubject is required to issue a credential", 400)
+	MsgCredentialIssuanceNotEnabled            = ffe("FF10687", "Payload signing must be enabled for the namespace to issue credentials", 400)
+	MsgCredentialSignatureInvalid              = ffe("FF10688", "Invalid signature from the payload signing key of the namespace")
+	MsgNamespaceQuotaSizeUnknown               = ffe("FF10689", "Namespace '%s' cannot check the %s quota for a request without a Content-Length", 411)
 )
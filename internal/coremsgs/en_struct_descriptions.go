@@ -48,17 +48,18 @@ var ffm = func(key, translation string) i18n.MessageKey {
 
 var (
 	// MessageHeader field descriptions
-	MessageHeaderID        = ffm("MessageHeader.id", "The UUID of the message. Unique to each message")
-	MessageHeaderCID       = ffm("MessageHeader.cid", "The correlation ID of the message. Set this when a message is a response to another message")
-	MessageHeaderType      = ffm("MessageHeader.type", "The type of the message")
-	MessageHeaderTxType    = ffm("MessageHeader.txtype", "The type of transaction used to order/deliver this message")
-	MessageHeaderCreated   = ffm("MessageHeader.created", "The creation time of the message")
-	MessageHeaderNamespace = ffm("MessageHeader.namespace", "The namespace of the message within the multiparty network")
-	MessageHeaderGroup     = ffm("MessageHeader.group", "Private messages only - the identifier hash of the privacy group. Derived from the name and member list of the group")
-	MessageHeaderTopics    = ffm("MessageHeader.topics", "A message topic associates this message with an ordered stream of data. A custom topic should be assigned - using the default topic is discouraged")
-	MessageHeaderTag       = ffm("MessageHeader.tag", "The message tag indicates the purpose of the message to the applications that process it")
-	MessageHeaderDataHash  = ffm("MessageHeader.datahash", "A single hash representing all data in the message. Derived from the array of data ids+hashes attached to this message")
-	MessageTxParent        = ffm("MessageHeader.txparent", "The parent transaction that originally triggered this message")
+	MessageHeaderID               = ffm("MessageHeader.id", "The UUID of the message. Unique to each message")
+	MessageHeaderCID              = ffm("MessageHeader.cid", "The correlation ID of the message. Set this when a message is a response to another message")
+	MessageHeaderType             = ffm("MessageHeader.type", "The type of the message")
+	MessageHeaderTxType           = ffm("MessageHeader.txtype", "The type of transaction used to order/deliver this message")
+	MessageHeaderCreated          = ffm("MessageHeader.created", "The creation time of the message")
+	MessageHeaderNamespace        = ffm("MessageHeader.namespace", "The namespace of the message within the multiparty network")
+	MessageHeaderGroup            = ffm("MessageHeader.group", "Private messages only - the identifier hash of the privacy group. Derived from the name and member list of the group")
+	MessageHeaderTopics           = ffm("MessageHeader.topics", "A message topic associates this message with an ordered stream of data. A custom topic should be assigned - using the default topic is discouraged")
+	MessageHeaderTag              = ffm("MessageHeader.tag", "The message tag indicates the purpose of the message to the applications that process it")
+	MessageHeaderContextQualifier = ffm("MessageHeader.contextqualifier", "An optional qualifier mixed into the pin context of each topic, to give an application its own ordered stream within a topic. Only a hash including the qualifier is written to the blockchain")
+	MessageHeaderDataHash         = ffm("MessageHeader.datahash", "A single hash representing all data in the message. Derived from the array of data ids+hashes attached to this message")
+	MessageTxParent               = ffm("MessageHeader.txparent", "The parent transaction that originally triggered this message")
 
 	// Message field descriptions
 	MessageHeader         = ffm("Message.header", "The message header contains all fields that are used to build the message hash")
@@ -72,14 +73,18 @@ var (
 	MessagePins           = ffm("Message.pins", "For private messages, a unique pin hash:nonce is assigned for each topic")
 	MessageTransactionID  = ffm("Message.txid", "The ID of the transaction used to order/deliver this message")
 	MessageIdempotencyKey = ffm("Message.idempotencyKey", "An optional unique identifier for a message. Cannot be duplicated within a namespace, thus allowing idempotent submission of messages to the API. Local only - not transferred when the message is sent to other members of the network")
+	MessageTxLabels       = ffm("Message.txlabels", "Labels to attach to the FireFly transaction that sends this message. Where messages are batched together, the transaction carries the labels of every message in the batch. Local only - not transferred when the message is sent to other members of the network")
+	MessageTrace          = ffm("Message.trace", "Set on submission to log every stage of the processing of this message at info level, and to capture spans for it regardless of the tracing sample ratio. Transferred to the other members of the network, but not covered by the message hash")
 
 	// MessageInOut field descriptions
 	MessageInOutData  = ffm("MessageInOut.data", "For input allows you to specify data in-line in the message, that will be turned into data attachments. For output when fetchdata is used on API calls, includes the in-line data payloads of all data attachments")
 	MessageInOutGroup = ffm("MessageInOut.group", "Allows you to specify details of the private group of recipients in-line in the message. Alternative to using the header.group to specify the hash of a group that has been previously resolved")
 
 	// InputGroup field descriptions
-	InputGroupName    = ffm("InputGroup.name", "Optional name for the group. Allows you to have multiple separate groups with the same list of participants")
-	InputGroupMembers = ffm("InputGroup.members", "An array of members of the group. If no identities local to the sending node are included, then the organization owner of the local node is added automatically")
+	InputGroupName        = ffm("InputGroup.name", "Optional name for the group. Allows you to have multiple separate groups with the same list of participants")
+	InputGroupMembers     = ffm("InputGroup.members", "An array of members of the group. If no identities local to the sending node are included, then the organization owner of the local node is added automatically")
+	InputGroupDisplayName = ffm("InputGroup.displayName", "An optional human-readable name for the group, sent to the members when the group is created. It can be changed later, and is not part of the group hash")
+	InputGroupDescription = ffm("InputGroup.description", "An optional description of the group, sent to the members when the group is created")
 
 	// DataRefOrValue field descriptions
 	DataRefOrValueValidator = ffm("DataRefOrValue.validator", "The data validator type to use for in-line data")
@@ -99,6 +104,14 @@ var (
 	GroupMessage        = ffm("Group.message", "The message used to broadcast this group privately to the members")
 	GroupHash           = ffm("Group.hash", "The identifier hash of this group. Derived from the name and group members")
 	GroupCreated        = ffm("Group.created", "The time when the group was first used to send a message in the network")
+	GroupDisplayName    = ffm("Group.displayName", "The human-readable name of the group. It can be changed by any member, and is not part of the group hash")
+	GroupDescription    = ffm("Group.description", "The description of the group")
+	GroupUpdated        = ffm("Group.updated", "The time when the display name and description of the group were last changed")
+
+	// GroupMetadata field descriptions
+	GroupMetadataGroup       = ffm("GroupMetadata.group", "The hash of the group the metadata applies to")
+	GroupMetadataDisplayName = ffm("GroupMetadata.displayName", "The new human-readable name of the group")
+	GroupMetadataDescription = ffm("GroupMetadata.description", "The new description of the group")
 
 	// MemberInput field descriptions
 	MemberInputIdentity = ffm("MemberInput.identity", "The DID of the group member. On input can be a UUID or org name, and will be resolved to a DID")
@@ -108,6 +121,40 @@ var (
 	MemberIdentity = ffm("Member.identity", "The DID of the group member")
 	MemberNode     = ffm("Member.node", "The UUID of the node that receives a copy of the off-chain message for the identity")
 
+	// MemberLatency field descriptions
+	MemberLatencyIdentity = ffm("MemberLatency.identity", "The DID of the group member")
+	MemberLatencyNode     = ffm("MemberLatency.node", "The UUID of the node that receives a copy of the off-chain message for the identity")
+	MemberLatencyLocal    = ffm("MemberLatency.local", "True if the node of the member is the local node, in which case no batches are sent to it")
+	MemberLatencyBatches  = ffm("MemberLatency.batches", "The number of batches the node acknowledged receipt of within the window")
+	MemberLatencyFailed   = ffm("MemberLatency.failed", "The number of batches that failed to be delivered to the node within the window")
+	MemberLatencyAverage  = ffm("MemberLatency.average", "The mean time from a batch being dispatched, to the node acknowledging receipt")
+	MemberLatencyP95      = ffm("MemberLatency.p95", "The 95th percentile of the time from a batch being dispatched, to the node acknowledging receipt")
+	MemberLatencyMax      = ffm("MemberLatency.max", "The longest time from a batch being dispatched, to the node acknowledging receipt")
+	MemberLatencyLastAck  = ffm("MemberLatency.lastAck", "The time the node most recently acknowledged receipt of a batch")
+
+	// ErrorCode field descriptions
+	ErrorCodeCode      = ffm("ErrorCode.code", "The code of the error, which prefixes the message of every error of this type")
+	ErrorCodeTemplate  = ffm("ErrorCode.template", "The English template of the error message. The params of an error are substituted for the verbs in the template")
+	ErrorCodeStatus    = ffm("ErrorCode.status", "The HTTP status the API returns for this error")
+	ErrorCodeRetryable = ffm("ErrorCode.retryable", "True if a request that fails with this error might succeed if it is submitted again unchanged")
+
+	// APIError field descriptions
+	APIErrorError         = ffm("APIError.error", "The message of the error, prefixed by its code")
+	APIErrorCode          = ffm("APIError.code", "The code of the error, which can be looked up in the error catalog")
+	APIErrorParams        = ffm("APIError.params", "The values substituted into the template of the error message, in order")
+	APIErrorRetryable     = ffm("APIError.retryable", "True if the request might succeed if it is submitted again unchanged")
+	APIErrorCorrelationID = ffm("APIError.correlationId", "The ID of the request, which is included in the logs of the node. Taken from the X-FireFly-Request-ID header of the request, if set")
+
+	// MemberStatus field descriptions
+	MemberStatusIdentity          = ffm("MemberStatus.identity", "The DID of the group member")
+	MemberStatusNode              = ffm("MemberStatus.node", "The UUID of the node that receives a copy of the off-chain message for the identity")
+	MemberStatusNodeName          = ffm("MemberStatus.nodeName", "The name of the node, if it is registered")
+	MemberStatusLocal             = ffm("MemberStatus.local", "True if the node of the member is the local node, in which case the liveness fields are not set")
+	MemberStatusIdentityStatus    = ffm("MemberStatus.identityStatus", "Whether the org and node of the member are registered, and the node is owned by the org")
+	MemberStatusLastBatchReceived = ffm("MemberStatus.lastBatchReceived", "The creation time of the most recent private batch received from the node")
+	MemberStatusLastDXContact     = ffm("MemberStatus.lastDXContact", "The most recent time data exchange delivered a batch to the node, or the node acknowledged a batch")
+	MemberStatusLastSeen          = ffm("MemberStatus.lastSeen", "The later of lastBatchReceived and lastDXContact. Not set if the node has never been seen")
+
 	// DataRef field descriptions
 	DataRefID   = ffm("DataRef.id", "The UUID of the referenced data resource")
 	DataRefHash = ffm("DataRef.hash", "The hash of the referenced data")
@@ -145,6 +192,11 @@ var (
 	DatatypeCreated   = ffm("Datatype.created", "The time the datatype was created")
 	DatatypeValue     = ffm("Datatype.value", "The definition of the datatype, in the syntax supported by the validator (such as a JSON Schema definition)")
 
+	// DatatypeInference field descriptions
+	DatatypeInferenceName    = ffm("DatatypeInference.name", "The name of the datatype to create from the inferred schema")
+	DatatypeInferenceVersion = ffm("DatatypeInference.version", "The version of the datatype to create from the inferred schema")
+	DatatypeInferenceSamples = ffm("DatatypeInference.samples", "Sample JSON payloads. The inferred schema accepts every sample, and only marks as required the object fields present in all of them")
+
 	// SignerRef field descriptions
 	SignerRefAuthor = ffm("SignerRef.author", "The DID of identity of the submitter")
 	SignerRefKey    = ffm("SignerRef.key", "The on-chain signing key used to sign the transaction")
@@ -159,6 +211,7 @@ var (
 	BatchHeaderNode      = ffm("BatchHeader.node", "The UUID of the node that generated the batch")
 	BatchHeaderGroup     = ffm("BatchHeader.group", "The privacy group the batch is sent to, for private batches")
 	BatchHeaderCreated   = ffm("BatchHeader.created", "The time the batch was sealed")
+	BatchHeaderTrace     = ffm("BatchHeader.trace", "True if any message in the batch was submitted with the trace flag")
 
 	// BatchManifest field descriptions
 	BatchManifestVersion  = ffm("BatchManifest.version", "The version of the manifest generated")
@@ -174,6 +227,14 @@ var (
 	BatchPersistedPayloadRef = ffm("Batch.payloadRef", "For broadcast batches, this is the reference to the binary batch in shared storage")
 	BatchPersistedConfirmed  = ffm("Batch.confirmed", "The time when the batch was confirmed")
 
+	// BatchAck field descriptions
+	BatchAckNamespace = ffm("BatchAck.namespace", "The namespace of the batch")
+	BatchAckBatch     = ffm("BatchAck.batch", "The UUID of the private batch sent from this node")
+	BatchAckNode      = ffm("BatchAck.node", "The UUID of the recipient node")
+	BatchAckState     = ffm("BatchAck.state", "The furthest the recipient is known to have got with the batch - transferred by data exchange, received by the recipient's FireFly core, persisted, or aggregated")
+	BatchAckCreated   = ffm("BatchAck.created", "The time the first acknowledgement was recorded for the batch from this recipient")
+	BatchAckUpdated   = ffm("BatchAck.updated", "The time the acknowledgement last moved to a new state")
+
 	// Transaction field descriptions
 	TransactionID             = ffm("Transaction.id", "The UUID of the FireFly transaction")
 	TransactionType           = ffm("Transaction.type", "The type of the FireFly transaction")
@@ -182,24 +243,426 @@ var (
 	TransactionIdempotencyKey = ffm("Transaction.idempotencyKey", "An optional unique identifier for a transaction. Cannot be duplicated within a namespace, thus allowing idempotent submission of transactions to the API")
 	TransactionBlockchainID   = ffm("Transaction.blockchainId", "The blockchain transaction ID, in the format specific to the blockchain involved in the transaction. Not all FireFly transactions include a blockchain")
 	TransactionBlockchainIDs  = ffm("Transaction.blockchainIds", "The blockchain transaction ID, in the format specific to the blockchain involved in the transaction. Not all FireFly transactions include a blockchain. FireFly transactions are extensible to support multiple blockchain transactions")
+	TransactionCorrelationID  = ffm("Transaction.correlationId", "The correlation ID of the API request that submitted the transaction, from the X-Correlation-ID header or generated by FireFly")
+	TransactionLabels         = ffm("Transaction.labels", "Labels attached to the transaction by the submitter, to allow activity to be grouped by business process")
+	TransactionSaga           = ffm("Transaction.saga", "The ordered steps declared on the transaction, with their compensating operations and the composite state")
 
 	// Operation field description
-	OperationID          = ffm("Operation.id", "The UUID of the operation")
-	OperationNamespace   = ffm("Operation.namespace", "The namespace of the operation")
-	OperationTransaction = ffm("Operation.tx", "The UUID of the FireFly transaction the operation is part of")
-	OperationType        = ffm("Operation.type", "The type of the operation")
-	OperationStatus      = ffm("Operation.status", "The current status of the operation")
-	OperationPlugin      = ffm("Operation.plugin", "The plugin responsible for performing the operation")
-	OperationInput       = ffm("Operation.input", "The input to this operation")
-	OperationOutput      = ffm("Operation.output", "Any output reported back from the plugin for this operation")
-	OperationError       = ffm("Operation.error", "Any error reported back from the plugin for this operation")
-	OperationCreated     = ffm("Operation.created", "The time the operation was created")
-	OperationUpdated     = ffm("Operation.updated", "The last update time of the operation")
-	OperationRetry       = ffm("Operation.retry", "If this operation was initiated as a retry to a previous operation, this field points to the UUID of the operation being retried")
+	OperationID            = ffm("Operation.id", "The UUID of the operation")
+	OperationNamespace     = ffm("Operation.namespace", "The namespace of the operation")
+	OperationTransaction   = ffm("Operation.tx", "The UUID of the FireFly transaction the operation is part of")
+	OperationType          = ffm("Operation.type", "The type of the operation")
+	OperationStatus        = ffm("Operation.status", "The current status of the operation")
+	OperationPlugin        = ffm("Operation.plugin", "The plugin responsible for performing the operation")
+	OperationInput         = ffm("Operation.input", "The input to this operation")
+	OperationOutput        = ffm("Operation.output", "Any output reported back from the plugin for this operation")
+	OperationError         = ffm("Operation.error", "Any error reported back from the plugin for this operation")
+	OperationCreated       = ffm("Operation.created", "The time the operation was created")
+	OperationUpdated       = ffm("Operation.updated", "The last update time of the operation")
+	OperationRetry         = ffm("Operation.retry", "If this operation was initiated as a retry to a previous operation, this field points to the UUID of the operation being retried")
+	OperationProgress      = ffm("Operation.progress", "The last progress reported by the plugin for a long running transfer, such as a large blob sent over data exchange")
+	OperationCorrelationID = ffm("Operation.correlationId", "The correlation ID of the API request that created the operation, from the X-Correlation-ID header or generated by FireFly")
+
+	// OperationProgress field descriptions
+	OperationProgressBytesSent  = ffm("OperationProgress.bytesSent", "The number of bytes the recipient has acknowledged so far")
+	OperationProgressBytesTotal = ffm("OperationProgress.bytesTotal", "The total number of bytes to transfer")
 
 	// OperationWithDetail field description
 	OperationWithDetail = ffm("OperationWithDetail.detail", "Additional detailed information about an operation provided by the connector")
 
+	// OperationHistoryEntry field descriptions
+	OperationHistoryEntryID             = ffm("OperationHistoryEntry.id", "The UUID of the history entry")
+	OperationHistoryEntryNamespace      = ffm("OperationHistoryEntry.namespace", "The namespace of the operation")
+	OperationHistoryEntryOperation      = ffm("OperationHistoryEntry.operation", "The UUID of the operation that was updated")
+	OperationHistoryEntryTransaction    = ffm("OperationHistoryEntry.tx", "The UUID of the FireFly transaction the operation is part of")
+	OperationHistoryEntryStatus         = ffm("OperationHistoryEntry.status", "The status the operation moved to in this update")
+	OperationHistoryEntryError          = ffm("OperationHistoryEntry.error", "Any error reported in this update")
+	OperationHistoryEntryPlugin         = ffm("OperationHistoryEntry.plugin", "The plugin that reported this update. Empty if the operation was updated through the SPI")
+	OperationHistoryEntryBlockchainTXID = ffm("OperationHistoryEntry.blockchainId", "The blockchain transaction ID reported by the connector in this update, if any")
+	OperationHistoryEntryReceipt        = ffm("OperationHistoryEntry.receipt", "The receipt payload returned by the plugin in this update")
+	OperationHistoryEntryCreated        = ffm("OperationHistoryEntry.created", "The time the update was applied to the operation")
+
+	// OperationOutputSchema field descriptions
+	OperationOutputSchemaPlugin = ffm("OperationOutputSchema.plugin", "The name of the plugin that declared the schema")
+	OperationOutputSchemaType   = ffm("OperationOutputSchema.type", "The type of operation the schema applies to")
+	OperationOutputSchemaSchema = ffm("OperationOutputSchema.schema", "The JSON schema that the output of every operation of this type from this plugin conforms to")
+
+	// BulkOperationRetry field descriptions
+	BulkOperationRetryDryRun      = ffm("BulkOperationRetry.dryRun", "When true, the matching operations are returned without being retried")
+	BulkOperationRetryConcurrency = ffm("BulkOperationRetry.concurrency", "The number of retries to submit in parallel. Defaults to the configured bulk retry concurrency")
+
+	// BulkOperationRetryItem field descriptions
+	BulkOperationRetryItemOperation = ffm("BulkOperationRetryItem.operation", "The UUID of the failed operation")
+	BulkOperationRetryItemType      = ffm("BulkOperationRetryItem.type", "The type of the failed operation")
+	BulkOperationRetryItemRetry     = ffm("BulkOperationRetryItem.retry", "The UUID of the new operation created to retry the failed one")
+	BulkOperationRetryItemError     = ffm("BulkOperationRetryItem.error", "The error returned when retrying this operation, if any")
+
+	// BulkOperationRetryResult field descriptions
+	BulkOperationRetryResultDryRun     = ffm("BulkOperationRetryResult.dryRun", "True if this was a preview, and no operations were retried")
+	BulkOperationRetryResultMatched    = ffm("BulkOperationRetryResult.matched", "The number of failed operations that matched the filter")
+	BulkOperationRetryResultRetried    = ffm("BulkOperationRetryResult.retried", "The number of operations successfully resubmitted")
+	BulkOperationRetryResultFailed     = ffm("BulkOperationRetryResult.failed", "The number of operations that could not be resubmitted")
+	BulkOperationRetryResultOperations = ffm("BulkOperationRetryResult.operations", "The outcome for each matching operation")
+
+	// OperationFee field descriptions
+	OperationFeeOperation      = ffm("OperationFee.operation", "The UUID of the operation")
+	OperationFeeNamespace      = ffm("OperationFee.namespace", "The namespace of the operation")
+	OperationFeeTransaction    = ffm("OperationFee.tx", "The UUID of the FireFly transaction the operation is part of")
+	OperationFeeType           = ffm("OperationFee.type", "The type of the operation")
+	OperationFeeStatus         = ffm("OperationFee.status", "The final status of the operation. Failed blockchain transactions still consume gas")
+	OperationFeePlugin         = ffm("OperationFee.plugin", "The plugin that submitted the blockchain transaction")
+	OperationFeeKey            = ffm("OperationFee.key", "The signing key that submitted the blockchain transaction, where recorded in the operation input")
+	OperationFeeBlockchainTXID = ffm("OperationFee.blockchainId", "The blockchain transaction ID")
+	OperationFeeGasUsed        = ffm("OperationFee.gasUsed", "The gas used by the blockchain transaction, as reported in the connector receipt")
+	OperationFeeGasPrice       = ffm("OperationFee.gasPrice", "The effective gas price of the blockchain transaction, as reported in the connector receipt")
+	OperationFeeFee            = ffm("OperationFee.fee", "The fee paid, being the gas used multiplied by the effective gas price, in the smallest unit of the native currency")
+	OperationFeeCreated        = ffm("OperationFee.created", "The time the fee was recorded")
+
+	// OperationApproval field descriptions
+	OperationApprovalID          = ffm("OperationApproval.id", "The UUID of the operation approval")
+	OperationApprovalNamespace   = ffm("OperationApproval.namespace", "The namespace of the operation")
+	OperationApprovalOperation   = ffm("OperationApproval.operation", "The UUID of the operation that is held back until it is approved")
+	OperationApprovalTransaction = ffm("OperationApproval.tx", "The UUID of the FireFly transaction the operation is part of")
+	OperationApprovalType        = ffm("OperationApproval.type", "The type of the operation")
+	OperationApprovalStatus      = ffm("OperationApproval.status", "Whether the operation is pending approval, was approved and submitted, or expired before it was approved")
+	OperationApprovalRequired    = ffm("OperationApproval.required", "The number of distinct approvers required by the approval policy")
+	OperationApprovalApprovers   = ffm("OperationApproval.approvers", "The authenticated users that have approved the operation")
+	OperationApprovalCreated     = ffm("OperationApproval.created", "The time the operation was held back for approval")
+	OperationApprovalUpdated     = ffm("OperationApproval.updated", "The time of the last approval, or change in status")
+	OperationApprovalExpires     = ffm("OperationApproval.expires", "The time after which the operation is failed, if it has not been approved")
+
+	// MessageRetentionPolicy field descriptions
+	MessageRetentionPolicyID         = ffm("MessageRetentionPolicy.id", "The UUID of the message retention policy")
+	MessageRetentionPolicyNamespace  = ffm("MessageRetentionPolicy.namespace", "The namespace of the message retention policy")
+	MessageRetentionPolicyName       = ffm("MessageRetentionPolicy.name", "The name of the message retention policy, unique within the namespace")
+	MessageRetentionPolicyTopic      = ffm("MessageRetentionPolicy.topic", "The topic of the messages the policy applies to. If a tag is also set, messages must match both")
+	MessageRetentionPolicyTag        = ffm("MessageRetentionPolicy.tag", "The tag of the messages the policy applies to. If a topic is also set, messages must match both")
+	MessageRetentionPolicyMaxAge     = ffm("MessageRetentionPolicy.maxAge", "The age after which the data of confirmed messages the policy applies to is deleted")
+	MessageRetentionPolicyCheckpoint = ffm("MessageRetentionPolicy.checkpoint", "The local sequence of the last message checked by the policy. Later messages are checked on the next run")
+	MessageRetentionPolicyCreated    = ffm("MessageRetentionPolicy.created", "The time the message retention policy was created")
+	MessageRetentionPolicyUpdated    = ffm("MessageRetentionPolicy.updated", "The time the message retention policy was last updated")
+
+	// FeeSummary field descriptions
+	FeeSummaryDay        = ffm("FeeSummary.day", "The UTC day, in YYYY-MM-DD format")
+	FeeSummaryKey        = ffm("FeeSummary.key", "The signing key")
+	FeeSummaryOperations = ffm("FeeSummary.operations", "The number of operations with fees recorded")
+	FeeSummaryGasUsed    = ffm("FeeSummary.gasUsed", "The total gas used")
+	FeeSummaryFee        = ffm("FeeSummary.fee", "The total fees paid")
+
+	// OnlineMigration field descriptions
+	OnlineMigrationName     = ffm("OnlineMigration.name", "The name of the online migration, from the name of its definition file")
+	OnlineMigrationTable    = ffm("OnlineMigration.table", "The table being migrated")
+	OnlineMigrationStatus   = ffm("OnlineMigration.status", "The step the migration has reached - pending, backfilling, backfilled or complete")
+	OnlineMigrationSequence = ffm("OnlineMigration.sequence", "The highest sequence of the table that has been copied into the new table")
+	OnlineMigrationRows     = ffm("OnlineMigration.rows", "The number of rows copied into the new table")
+	OnlineMigrationUpdated  = ffm("OnlineMigration.updated", "The time the migration last made progress")
+	OnlineMigrationRunning  = ffm("OnlineMigration.running", "True if this node is currently running the migration")
+	OnlineMigrationError    = ffm("OnlineMigration.error", "The error that stopped the last run of the migration on this node, if any")
+
+	// RebuildStatus field descriptions
+	RebuildStatusTarget    = ffm("RebuildStatus.target", "The derived records being rebuilt - nextpins, messagestates or tokenbalances")
+	RebuildStatusRunning   = ffm("RebuildStatus.running", "True if the rebuild is in progress")
+	RebuildStatusProcessed = ffm("RebuildStatus.processed", "The number of source records read so far")
+	RebuildStatusRepaired  = ffm("RebuildStatus.repaired", "The number of derived records corrected so far. For token balances this is the number of pools whose balances have been recalculated")
+	RebuildStatusStarted   = ffm("RebuildStatus.started", "The time the rebuild started")
+	RebuildStatusCompleted = ffm("RebuildStatus.completed", "The time the rebuild finished, whether or not it succeeded")
+	RebuildStatusError     = ffm("RebuildStatus.error", "The error that stopped the rebuild, if any")
+
+	// LogLevels field descriptions
+	LogLevelsLevel      = ffm("LogLevels.level", "The log level of all output that is not in a subsystem with its own level - trace, debug, info, warn or error")
+	LogLevelsSubsystems = ffm("LogLevels.subsystems", "The log level of each subsystem with its own level, keyed by batch, events, aggregator or plugins. Set a subsystem to an empty string to return it to the default level")
+
+	// LogCaptureRequest field descriptions
+	LogCaptureRequestLevel    = ffm("LogCaptureRequest.level", "The level to capture at, regardless of the log levels of the node. Defaults to debug")
+	LogCaptureRequestDuration = ffm("LogCaptureRequest.duration", "How long to capture for. Defaults to 5m, and is limited by the debug.capture.maxDuration config")
+	LogCaptureRequestMaxLines = ffm("LogCaptureRequest.maxLines", "The number of lines to keep, discarding the oldest. Limited by the debug.capture.maxLines config")
+
+	// LogCaptureStatus field descriptions
+	LogCaptureStatusActive   = ffm("LogCaptureStatus.active", "True if log output is currently being captured")
+	LogCaptureStatusLevel    = ffm("LogCaptureStatus.level", "The level log output is captured at")
+	LogCaptureStatusStarted  = ffm("LogCaptureStatus.started", "The time the capture started")
+	LogCaptureStatusExpires  = ffm("LogCaptureStatus.expires", "The time the capture stops, or stopped")
+	LogCaptureStatusMaxLines = ffm("LogCaptureStatus.maxLines", "The number of lines kept by the capture")
+	LogCaptureStatusLines    = ffm("LogCaptureStatus.lines", "The number of lines currently held by the capture")
+	LogCaptureStatusDropped  = ffm("LogCaptureStatus.dropped", "The number of lines discarded because the capture was full")
+
+	// CacheStatus field descriptions
+	CacheStatusName           = ffm("CacheStatus.name", "The name of the cache, prefixed with the namespace it belongs to, or 'global'")
+	CacheStatusNamespace      = ffm("CacheStatus.namespace", "The namespace the cache belongs to, or 'global'")
+	CacheStatusMaxSize        = ffm("CacheStatus.maxSize", "The current limit of the cache - a number of entries, or a size in bytes for caches configured with a size. Differs from the configured size when adaptive sizing has resized the cache")
+	CacheStatusConfiguredSize = ffm("CacheStatus.configuredSize", "The configured limit of the cache, that adaptive sizing resizes it relative to")
+	CacheStatusTTL            = ffm("CacheStatus.ttl", "The configured time to live of cache entries")
+	CacheStatusHits           = ffm("CacheStatus.hits", "The number of lookups that found an entry")
+	CacheStatusMisses         = ffm("CacheStatus.misses", "The number of lookups that did not find an entry, including those for entries that had been evicted or had expired")
+	CacheStatusHitRatio       = ffm("CacheStatus.hitRatio", "The proportion of lookups that found an entry")
+	CacheStatusSets           = ffm("CacheStatus.sets", "The number of entries added or replaced")
+	CacheStatusDeletes        = ffm("CacheStatus.deletes", "The number of entries explicitly removed, including by invalidations from other FireFly instances")
+	CacheStatusCreated        = ffm("CacheStatus.created", "The time the cache was created")
+	CacheStatusInvalidated    = ffm("CacheStatus.invalidated", "The time all entries of the cache were last invalidated")
+	CacheStatusResized        = ffm("CacheStatus.resized", "The time adaptive sizing last resized the cache")
+
+	// ActivityItem field descriptions
+	ActivityItemID          = ffm("ActivityItem.id", "The UUID of the event the activity item was built from")
+	ActivityItemSequence    = ffm("ActivityItem.sequence", "The sequence of the event the activity item was built from. Items are returned newest first, in descending sequence order")
+	ActivityItemCategory    = ffm("ActivityItem.category", "The category of the activity item, grouping related event types")
+	ActivityItemType        = ffm("ActivityItem.type", "The type of the event the activity item was built from")
+	ActivityItemReference   = ffm("ActivityItem.reference", "The UUID of the object the activity item refers to, which depends on the event type")
+	ActivityItemTransaction = ffm("ActivityItem.tx", "The UUID of the transaction the activity item is part of, if any")
+	ActivityItemTopic       = ffm("ActivityItem.topic", "The topic of the event the activity item was built from")
+	ActivityItemCreated     = ffm("ActivityItem.created", "The time the event the activity item was built from was emitted")
+	ActivityItemSummary     = ffm("ActivityItem.summary", "The headline details of the object the activity item refers to")
+
+	// ActivitySummary field descriptions
+	ActivitySummaryAuthor   = ffm("ActivitySummary.author", "The author of a message, or the signing key of a token transfer or contract invocation")
+	ActivitySummaryTag      = ffm("ActivitySummary.tag", "The tag of a message")
+	ActivitySummaryTopics   = ffm("ActivitySummary.topics", "The topics of a message")
+	ActivitySummaryState    = ffm("ActivitySummary.state", "The state of a message, or the status of an operation")
+	ActivitySummaryName     = ffm("ActivitySummary.name", "The name of a definition, the DID of an identity, or the blockchain plugin that reported a re-org")
+	ActivitySummaryPool     = ffm("ActivitySummary.pool", "The UUID of the token pool of a token transfer")
+	ActivitySummaryFrom     = ffm("ActivitySummary.from", "The account tokens were transferred from")
+	ActivitySummaryTo       = ffm("ActivitySummary.to", "The account tokens were transferred to")
+	ActivitySummaryAmount   = ffm("ActivitySummary.amount", "The amount of tokens transferred")
+	ActivitySummaryMethod   = ffm("ActivitySummary.method", "The name of the smart contract method invoked")
+	ActivitySummaryLocation = ffm("ActivitySummary.location", "The location of the smart contract invoked")
+	ActivitySummaryError    = ffm("ActivitySummary.error", "The error of a failed operation")
+
+	// TrafficMatrix field descriptions
+	TrafficMatrixLocal          = ffm("TrafficMatrix.local", "The DID of the local org")
+	TrafficMatrixStartTime      = ffm("TrafficMatrix.startTime", "The start of the time window, rounded down to the hourly bucket it falls in")
+	TrafficMatrixEndTime        = ffm("TrafficMatrix.endTime", "The end of the time window")
+	TrafficMatrixCounterparties = ffm("TrafficMatrix.counterparties", "The traffic exchanged with each counterparty org within the time window, ordered by DID")
+
+	// TrafficCounterparty field descriptions
+	TrafficCounterpartyCounterparty = ffm("TrafficCounterparty.counterparty", "The DID of the counterparty org")
+	TrafficCounterpartySent         = ffm("TrafficCounterparty.sent", "The traffic sent from the local org to the counterparty")
+	TrafficCounterpartyReceived     = ffm("TrafficCounterparty.received", "The traffic received by the local org from the counterparty")
+
+	// TrafficTotals field descriptions
+	TrafficTotalsMessages     = ffm("TrafficTotals.messages", "The number of confirmed messages")
+	TrafficTotalsMessageBytes = ffm("TrafficTotals.messageBytes", "The total size of the data of the confirmed messages, including blobs")
+	TrafficTotalsTransfers    = ffm("TrafficTotals.transfers", "The number of confirmed token transfers")
+
+	// LedgerTransaction field descriptions
+	LedgerTransactionID         = ffm("LedgerTransaction.id", "The UUID of the blockchain event the ledger transaction was built from")
+	LedgerTransactionBlock      = ffm("LedgerTransaction.block", "The block the blockchain event was included in, if the blockchain plugin reports block numbers in the protocol ID of its events")
+	LedgerTransactionHash       = ffm("LedgerTransaction.hash", "The hash of the blockchain transaction that emitted the event")
+	LedgerTransactionSource     = ffm("LedgerTransaction.source", "The blockchain plugin or token connector that reported the event")
+	LedgerTransactionProtocolID = ffm("LedgerTransaction.protocolId", "An alphanumerically sortable string that represents this event uniquely on the blockchain")
+	LedgerTransactionTX         = ffm("LedgerTransaction.tx", "The UUID of the FireFly transaction the event is part of, if any")
+	LedgerTransactionTimestamp  = ffm("LedgerTransaction.timestamp", "The time the event was emitted. Not guaranteed to be unique, or to increase between events in the same order as the final sequence events are delivered to your application")
+	LedgerTransactionOperations = ffm("LedgerTransaction.operations", "The changes made by the transaction, in the order they apply")
+
+	// LedgerBlock field descriptions
+	LedgerBlockIndex = ffm("LedgerBlock.index", "The block number")
+
+	// LedgerOperation field descriptions
+	LedgerOperationIndex             = ffm("LedgerOperation.index", "The index of the operation within the transaction")
+	LedgerOperationRelatedOperations = ffm("LedgerOperation.relatedOperations", "The indexes of other operations in the transaction this one is part of, such as the debit side of a token transfer")
+	LedgerOperationType              = ffm("LedgerOperation.type", "The kind of change - pin, mint, burn, transfer or event")
+	LedgerOperationAccount           = ffm("LedgerOperation.account", "The account the operation applies to, if any")
+	LedgerOperationAmount            = ffm("LedgerOperation.amount", "The change to the balance of the account. Negative for debits")
+	LedgerOperationMetadata          = ffm("LedgerOperation.metadata", "Additional details of the operation that are specific to its type")
+
+	// LedgerAccount field descriptions
+	LedgerAccountAddress = ffm("LedgerAccount.address", "The blockchain address of the account")
+
+	// LedgerAmount field descriptions
+	LedgerAmountValue    = ffm("LedgerAmount.value", "The amount as a signed integer string, in the smallest unit of the currency")
+	LedgerAmountCurrency = ffm("LedgerAmount.currency", "The currency of the amount")
+
+	// LedgerCurrency field descriptions
+	LedgerCurrencySymbol     = ffm("LedgerCurrency.symbol", "The symbol of the token pool")
+	LedgerCurrencyDecimals   = ffm("LedgerCurrency.decimals", "The number of decimal places of the smallest unit of the currency")
+	LedgerCurrencyPool       = ffm("LedgerCurrency.pool", "The UUID of the token pool")
+	LedgerCurrencyTokenIndex = ffm("LedgerCurrency.tokenIndex", "The index of a non-fungible token within the pool")
+
+	// LedgerBalance field descriptions
+	LedgerBalanceAccount = ffm("LedgerBalance.account", "The account holding the balance")
+	LedgerBalanceAmount  = ffm("LedgerBalance.amount", "The balance held of the currency")
+	LedgerBalanceUpdated = ffm("LedgerBalance.updated", "The last time the balance was updated")
+
+	// SearchResult field descriptions
+	SearchResultScore   = ffm("SearchResult.score", "The relevance of the message to the search query. Higher scores are more relevant")
+	SearchResultMessage = ffm("SearchResult.message", "The message that matched the search query")
+
+	// AuditRecord field descriptions
+	AuditRecordSequence       = ffm("AuditRecord.sequence", "The order of the record in the audit log of the namespace")
+	AuditRecordID             = ffm("AuditRecord.id", "The UUID of the audit record")
+	AuditRecordNamespace      = ffm("AuditRecord.namespace", "The namespace the API call was made against")
+	AuditRecordUser           = ffm("AuditRecord.user", "The user that made the API call, where the request was authenticated")
+	AuditRecordSource         = ffm("AuditRecord.source", "The network address the API call was made from")
+	AuditRecordMethod         = ffm("AuditRecord.method", "The HTTP method of the API call")
+	AuditRecordPath           = ffm("AuditRecord.path", "The URL path of the API call")
+	AuditRecordRoute          = ffm("AuditRecord.route", "The name of the API route that was called")
+	AuditRecordIdempotencyKey = ffm("AuditRecord.idempotencyKey", "The idempotency key supplied in the body of the API call, if any")
+	AuditRecordStatus         = ffm("AuditRecord.status", "The HTTP status code returned for the API call")
+	AuditRecordLatency        = ffm("AuditRecord.latencyMs", "The time taken to process the API call, in milliseconds")
+	AuditRecordCreated        = ffm("AuditRecord.created", "The time the API call completed")
+	AuditRecordPreviousHash   = ffm("AuditRecord.previousHash", "The hash of the previous record in the audit log of the namespace")
+	AuditRecordHash           = ffm("AuditRecord.hash", "The hash of the content of this record, including the hash of the previous record")
+
+	// AuditVerification field descriptions
+	AuditVerificationRecords      = ffm("AuditVerification.records", "The number of audit records that were checked")
+	AuditVerificationValid        = ffm("AuditVerification.valid", "True if every record matches its hash, and the hash of the record before it")
+	AuditVerificationFirstInvalid = ffm("AuditVerification.firstInvalid", "The ID of the first record that does not match the hash chain, if the log has been tampered with")
+
+	// AuditAnchor field descriptions
+	AuditAnchorID         = ffm("AuditAnchor.id", "The UUID of the audit anchor")
+	AuditAnchorNamespace  = ffm("AuditAnchor.namespace", "The namespace of the audit anchor")
+	AuditAnchorFirstEvent = ffm("AuditAnchor.firstEvent", "The sequence of the first event committed to by the anchor")
+	AuditAnchorLastEvent  = ffm("AuditAnchor.lastEvent", "The sequence of the last event committed to by the anchor")
+	AuditAnchorLeaves     = ffm("AuditAnchor.leaves", "The number of leaves in the Merkle tree of the anchor")
+	AuditAnchorRoot       = ffm("AuditAnchor.root", "The Merkle root over the leaves of the anchor, as pinned to the blockchain")
+	AuditAnchorTX         = ffm("AuditAnchor.tx", "The FireFly transaction that pinned the root to the blockchain")
+	AuditAnchorCreated    = ffm("AuditAnchor.created", "The time the anchor was created")
+
+	// AuditAnchorLeaf field descriptions
+	AuditAnchorLeafSequence    = ffm("AuditAnchorLeaf.sequence", "The sequence of the event")
+	AuditAnchorLeafEvent       = ffm("AuditAnchorLeaf.event", "The UUID of the event")
+	AuditAnchorLeafType        = ffm("AuditAnchorLeaf.type", "The type of the event")
+	AuditAnchorLeafReference   = ffm("AuditAnchorLeaf.reference", "The UUID of the message or operation the event refers to")
+	AuditAnchorLeafTransaction = ffm("AuditAnchorLeaf.tx", "The UUID of the transaction of the event, if any")
+	AuditAnchorLeafMessageHash = ffm("AuditAnchorLeaf.messageHash", "The hash of the message, for message events")
+	AuditAnchorLeafOpStatus    = ffm("AuditAnchorLeaf.opStatus", "The status of the operation, for operation events")
+	AuditAnchorLeafOpError     = ffm("AuditAnchorLeaf.opError", "The error of the operation, for operation events that failed")
+
+	// AuditAnchorVerification field descriptions
+	AuditAnchorVerificationAnchor          = ffm("AuditAnchorVerification.anchor", "The audit anchor that was verified")
+	AuditAnchorVerificationLeaves          = ffm("AuditAnchorVerification.leaves", "The number of leaves rebuilt from the local history")
+	AuditAnchorVerificationRoot            = ffm("AuditAnchorVerification.root", "The Merkle root rebuilt from the local history")
+	AuditAnchorVerificationBlockchainEvent = ffm("AuditAnchorVerification.blockchainEvent", "The UUID of the blockchain event that confirmed the root was pinned, once received")
+	AuditAnchorVerificationPinnedRoot      = ffm("AuditAnchorVerification.pinnedRoot", "The root read from the blockchain event that pinned the anchor")
+	AuditAnchorVerificationValid           = ffm("AuditAnchorVerification.valid", "True if the rebuilt root matches the root of the anchor, and the root pinned to the blockchain where it has been received")
+
+	// AuditAnchorProofStep field descriptions
+	AuditAnchorProofStepHash = ffm("AuditAnchorProofStep.hash", "The hash of the sibling node")
+	AuditAnchorProofStepLeft = ffm("AuditAnchorProofStep.left", "True if the sibling is on the left of the node being proved")
+
+	// AuditAnchorProof field descriptions
+	AuditAnchorProofAnchor   = ffm("AuditAnchorProof.anchor", "The audit anchor the proof is against")
+	AuditAnchorProofLeaf     = ffm("AuditAnchorProof.leaf", "The content of the leaf being proved")
+	AuditAnchorProofLeafHash = ffm("AuditAnchorProof.leafHash", "The hash of the leaf, which is the SHA-256 of a zero byte followed by the JSON of the leaf")
+	AuditAnchorProofIndex    = ffm("AuditAnchorProof.index", "The position of the leaf in the Merkle tree")
+	AuditAnchorProofPath     = ffm("AuditAnchorProof.path", "The siblings from the leaf to the root. Each node is the SHA-256 of a one byte followed by the left and right child hashes")
+	AuditAnchorProofValid    = ffm("AuditAnchorProof.valid", "True if applying the path to the leaf hash gives the root of the anchor")
+
+	// DisclosureInput field descriptions
+	DisclosureInputCircuit      = ffm("DisclosureInput.circuit", "The circuit of the proof service that defines the statement to prove about the data of the message")
+	DisclosureInputPublicInputs = ffm("DisclosureInput.publicInputs", "The public inputs of the circuit, such as the limit in an 'amount < limit' statement")
+
+	// Disclosure field descriptions
+	DisclosureID                = ffm("Disclosure.id", "The UUID of the disclosure")
+	DisclosureNamespace         = ffm("Disclosure.namespace", "The namespace of the disclosure")
+	DisclosureMessage           = ffm("Disclosure.message", "The UUID of the message whose private data the statement is about")
+	DisclosureMessageHash       = ffm("Disclosure.messageHash", "The hash of the message, which members holding the message check against their copy")
+	DisclosureCircuit           = ffm("Disclosure.circuit", "The circuit of the proof service that defines the statement proved")
+	DisclosurePublicInputs      = ffm("Disclosure.publicInputs", "The public inputs of the circuit")
+	DisclosureProof             = ffm("Disclosure.proof", "The zero-knowledge proof generated by the proof service")
+	DisclosureAuthor            = ffm("Disclosure.author", "The DID of the identity that broadcast the disclosure")
+	DisclosureStatus            = ffm("Disclosure.status", "Whether this node has verified the disclosure, which it does in the background after it is confirmed. Pending until checked, then verified, invalid, or unverified if no ZK proof plugin is configured. This status is local to each node")
+	DisclosureDefinitionMessage = ffm("Disclosure.definitionMessage", "The UUID of the broadcast message that published the disclosure")
+	DisclosureCreated           = ffm("Disclosure.created", "The time the disclosure was created")
+
+	// ListenerReconciliation field descriptions
+	ListenerReconciliationListener       = ffm("ListenerReconciliation.listener", "The UUID of the contract listener")
+	ListenerReconciliationName           = ffm("ListenerReconciliation.name", "The name of the contract listener")
+	ListenerReconciliationBackendID      = ffm("ListenerReconciliation.backendId", "The ID of the listener in the blockchain connector")
+	ListenerReconciliationLocalBlock     = ffm("ListenerReconciliation.localBlock", "The block of the latest blockchain event recorded locally for the listener")
+	ListenerReconciliationConnectorBlock = ffm("ListenerReconciliation.connectorBlock", "The block the connector reported it had delivered events up to")
+	ListenerReconciliationFromBlock      = ffm("ListenerReconciliation.fromBlock", "The block the listener was rewound to, so the connector re-delivers the events after it")
+	ListenerReconciliationTruncated      = ffm("ListenerReconciliation.truncated", "True if the gap was larger than the configured maximum, so only the most recent blocks were replayed")
+	ListenerReconciliationStatus         = ffm("ListenerReconciliation.status", "The outcome of reconciling the listener")
+	ListenerReconciliationError          = ffm("ListenerReconciliation.error", "The error, if the listener could not be reconciled")
+
+	// ListenerReconciliationReport field descriptions
+	ListenerReconciliationReportNamespace  = ffm("ListenerReconciliationReport.namespace", "The namespace whose contract listeners were reconciled")
+	ListenerReconciliationReportStarted    = ffm("ListenerReconciliationReport.started", "The time reconciliation started")
+	ListenerReconciliationReportCompleted  = ffm("ListenerReconciliationReport.completed", "The time reconciliation completed, or empty if it is still running")
+	ListenerReconciliationReportInSync     = ffm("ListenerReconciliationReport.inSync", "The number of listeners where the connector had not passed the latest locally recorded event")
+	ListenerReconciliationReportSkipped    = ffm("ListenerReconciliationReport.skipped", "The number of listeners with no locally recorded events to compare against")
+	ListenerReconciliationReportBackfilled = ffm("ListenerReconciliationReport.backfilled", "The number of listeners rewound and caught up again by the connector")
+	ListenerReconciliationReportFailed     = ffm("ListenerReconciliationReport.failed", "The number of listeners that could not be reconciled")
+	ListenerReconciliationReportListeners  = ffm("ListenerReconciliationReport.listeners", "The result for each contract listener")
+
+	// BatchPinVerification field descriptions
+	BatchPinVerificationBatch            = ffm("BatchPinVerification.batch", "The UUID of the batch")
+	BatchPinVerificationMessage          = ffm("BatchPinVerification.message", "The UUID of the message, if the batch was verified for a message")
+	BatchPinVerificationType             = ffm("BatchPinVerification.type", "The type of the batch")
+	BatchPinVerificationHash             = ffm("BatchPinVerification.hash", "The hash of the batch, as stored locally")
+	BatchPinVerificationManifestHash     = ffm("BatchPinVerification.manifestHash", "The hash of the batch manifest, recomputed from the local messages and data")
+	BatchPinVerificationManifestMatch    = ffm("BatchPinVerification.manifestMatch", "True if the recomputed manifest hash matches the hash of the batch")
+	BatchPinVerificationBlockchainEvent  = ffm("BatchPinVerification.blockchainEvent", "The UUID of the BatchPin blockchain event")
+	BatchPinVerificationBlockchainTXID   = ffm("BatchPinVerification.blockchainTxId", "The blockchain transaction ID of the BatchPin event")
+	BatchPinVerificationBlockNumber      = ffm("BatchPinVerification.blockNumber", "The block that contains the BatchPin event")
+	BatchPinVerificationPinnedHash       = ffm("BatchPinVerification.pinnedHash", "The batch hash recorded on the blockchain")
+	BatchPinVerificationPinnedHashMatch  = ffm("BatchPinVerification.pinnedHashMatch", "True if the batch hash recorded on the blockchain matches the hash of the batch")
+	BatchPinVerificationSigner           = ffm("BatchPinVerification.signer", "The blockchain key that signed the BatchPin transaction")
+	BatchPinVerificationSignerMatch      = ffm("BatchPinVerification.signerMatch", "True if the key that signed the BatchPin transaction is the signing key of the batch")
+	BatchPinVerificationPayloadRef       = ffm("BatchPinVerification.payloadRef", "The shared storage reference of a broadcast batch, as recorded on the blockchain")
+	BatchPinVerificationPayloadHash      = ffm("BatchPinVerification.payloadHash", "The manifest hash of the batch downloaded from shared storage")
+	BatchPinVerificationPayloadHashMatch = ffm("BatchPinVerification.payloadHashMatch", "True if the batch in shared storage matches the batch hash recorded on the blockchain. Empty for private batches")
+	BatchPinVerificationVerified         = ffm("BatchPinVerification.verified", "True if all the checks passed")
+	BatchPinVerificationErrors           = ffm("BatchPinVerification.errors", "The reasons any check could not be completed")
+
+	// CatchUpStatus field descriptions
+	CatchUpStatusActive     = ffm("CatchUpStatus.active", "True while broadcast batches are being downloaded in bulk, rather than by the regular download workers")
+	CatchUpStatusStarted    = ffm("CatchUpStatus.started", "The time catch-up started")
+	CatchUpStatusCompleted  = ffm("CatchUpStatus.completed", "The time catch-up completed, after no new BatchPin events arrived within the idle timeout")
+	CatchUpStatusOffset     = ffm("CatchUpStatus.offset", "The sequence of the last BatchPin event processed by catch-up, from which it resumes after a restart")
+	CatchUpStatusScanned    = ffm("CatchUpStatus.scanned", "The number of BatchPin events scanned since catch-up started")
+	CatchUpStatusDownloaded = ffm("CatchUpStatus.downloaded", "The number of broadcast batches downloaded and stored since catch-up started")
+	CatchUpStatusSkipped    = ffm("CatchUpStatus.skipped", "The number of BatchPin events skipped because they were for private batches, or the batch was already stored")
+	CatchUpStatusRequeued   = ffm("CatchUpStatus.requeued", "The number of batches that failed to download during catch-up, and were handed to the regular download workers to retry")
+
+	// GraphQLRequest field descriptions
+	GraphQLRequestQuery         = ffm("GraphQLRequest.query", "The GraphQL query document")
+	GraphQLRequestOperationName = ffm("GraphQLRequest.operationName", "The name of the operation to run, when the document contains more than one")
+	GraphQLRequestVariables     = ffm("GraphQLRequest.variables", "The values of the variables used by the operation")
+
+	// GraphQLError field descriptions
+	GraphQLErrorMessage = ffm("GraphQLError.message", "The error message")
+	GraphQLErrorPath    = ffm("GraphQLError.path", "The path of the field in the response that failed to resolve")
+
+	// GraphQLResponse field descriptions
+	GraphQLResponseData   = ffm("GraphQLResponse.data", "The result of the query, with the shape of the query's selection set")
+	GraphQLResponseErrors = ffm("GraphQLResponse.errors", "Any errors that occurred parsing the query or resolving its fields. Fields that failed to resolve are null in the data")
+
+	// RetentionEstimate field descriptions
+	RetentionEstimateCollections = ffm("RetentionEstimate.collections", "The collections that have a retention policy configured")
+
+	// RetentionCollectionEstimate field descriptions
+	RetentionCollectionEstimateCollection = ffm("RetentionCollectionEstimate.collection", "The name of the collection")
+	RetentionCollectionEstimateMaxAge     = ffm("RetentionCollectionEstimate.maxAge", "The configured age after which records in the collection are pruned")
+	RetentionCollectionEstimateBefore     = ffm("RetentionCollectionEstimate.before", "Records created before this time are eligible to be pruned")
+	RetentionCollectionEstimateRecords    = ffm("RetentionCollectionEstimate.records", "The number of records that would be pruned if the retention policy ran now")
+
+	// TransactionSaga field descriptions
+	TransactionSagaState      = ffm("TransactionSaga.state", "The composite state of the steps in the transaction")
+	TransactionSagaSteps      = ffm("TransactionSaga.steps", "The ordered steps of the transaction")
+	TransactionSagaFailedStep = ffm("TransactionSaga.failedStep", "The operation of the step that failed permanently, causing the earlier steps to be compensated")
+	TransactionSagaUpdated    = ffm("TransactionSaga.updated", "The time the saga was last updated")
+
+	// SagaStep field descriptions
+	SagaStepName                  = ffm("SagaStep.name", "An optional name for the step")
+	SagaStepOperation             = ffm("SagaStep.operation", "The UUID of the operation performing the step")
+	SagaStepStatus                = ffm("SagaStep.status", "The status of the operation performing the step")
+	SagaStepCompensation          = ffm("SagaStep.compensation", "The operation to submit to undo the step, if a later step fails permanently")
+	SagaStepCompensationOperation = ffm("SagaStep.compensationOperation", "The UUID of the compensating operation, once it has been submitted")
+	SagaStepCompensationStatus    = ffm("SagaStep.compensationStatus", "The status of the compensating operation")
+
+	// SagaCompensation field descriptions
+	SagaCompensationType   = ffm("SagaCompensation.type", "The type of the compensating operation")
+	SagaCompensationPlugin = ffm("SagaCompensation.plugin", "The plugin that performs the compensating operation")
+	SagaCompensationInput  = ffm("SagaCompensation.input", "The input to the compensating operation")
+
+	// IdempotencyKeyRecord field descriptions
+	IdempotencyKeyRecordKey         = ffm("IdempotencyKeyRecord.key", "The idempotency key")
+	IdempotencyKeyRecordNamespace   = ffm("IdempotencyKeyRecord.namespace", "The namespace the key is reserved in")
+	IdempotencyKeyRecordTransaction = ffm("IdempotencyKeyRecord.transaction", "The UUID of the transaction that was submitted with the key")
+	IdempotencyKeyRecordType        = ffm("IdempotencyKeyRecord.type", "The type of the transaction")
+	IdempotencyKeyRecordCreated     = ffm("IdempotencyKeyRecord.created", "The time the transaction was created, from which the automatic expiry of the key is calculated")
+	IdempotencyKeyRecordOperations  = ffm("IdempotencyKeyRecord.operations", "The UUIDs of the operations of the transaction")
+
 	// BlockchainEvent field descriptions
 	BlockchainEventID         = ffm("BlockchainEvent.id", "The UUID assigned to the event by FireFly")
 	BlockchainEventSource     = ffm("BlockchainEvent.source", "The blockchain plugin or token service that detected the event")
@@ -212,6 +675,21 @@ var (
 	BlockchainEventTimestamp  = ffm("BlockchainEvent.timestamp", "The time allocated to this event by the blockchain. This is the block timestamp for most blockchain connectors")
 	BlockchainEventTX         = ffm("BlockchainEvent.tx", "If this blockchain event is coorelated to FireFly transaction such as a FireFly submitted token transfer, this field is set to the UUID of the FireFly transaction")
 
+	// BlockchainReorg field descriptions
+	BlockchainReorgID          = ffm("BlockchainReorg.id", "The UUID assigned to the re-org by FireFly")
+	BlockchainReorgNamespace   = ffm("BlockchainReorg.namespace", "The namespace in which the state derived from the removed blocks was reverted")
+	BlockchainReorgSource      = ffm("BlockchainReorg.source", "The blockchain plugin that reported the re-org")
+	BlockchainReorgBlockNumber = ffm("BlockchainReorg.blockNumber", "The first block that was removed from the chain - everything from this block onwards was reverted")
+	BlockchainReorgBlockHash   = ffm("BlockchainReorg.blockHash", "The hash of the removed block, if reported by the blockchain plugin")
+	BlockchainReorgReverted    = ffm("BlockchainReorg.reverted", "The records that were reverted, or affected, because they were derived from the removed blocks")
+	BlockchainReorgCreated     = ffm("BlockchainReorg.created", "The time the re-org was processed by FireFly")
+
+	// BlockchainReorgReverted field descriptions
+	BlockchainReorgRevertedBlockchainEvents  = ffm("BlockchainReorgReverted.blockchainEvents", "The blockchain events that were deleted")
+	BlockchainReorgRevertedTokenTransfers    = ffm("BlockchainReorgReverted.tokenTransfers", "The token transfers that were deleted, after reversing their effect on token balances")
+	BlockchainReorgRevertedBatches           = ffm("BlockchainReorgReverted.batches", "The batches whose pins were removed - messages not yet confirmed wait for the pin to be re-delivered from the replacing blocks")
+	BlockchainReorgRevertedConfirmedMessages = ffm("BlockchainReorgReverted.confirmedMessages", "Messages from the removed blocks that had already been confirmed, rejected or quarantined. They are moved back to pending, and processed again when their pins are re-delivered from the replacing blocks")
+
 	// ChartHistogram field descriptions
 	ChartHistogramCount     = ffm("ChartHistogram.count", "Total count of entries in this time bucket within the histogram")
 	ChartHistogramTimestamp = ffm("ChartHistogram.timestamp", "Starting timestamp for the bucket")
@@ -232,6 +710,13 @@ var (
 	ContractAPIMessage     = ffm("ContractAPI.message", "The UUID of the broadcast message that was used to publish this API to the network")
 	ContractAPIURLs        = ffm("ContractAPI.urls", "The URLs to use to access the API")
 	ContractAPIPublished   = ffm("ContractAPI.published", "Indicates if the API is published to other members of the multiparty network")
+	ContractAPIListeners   = ffm("ContractAPI.listeners", "Templates for contract listeners that are created automatically for events of the interface, whenever the API is defined with a location")
+
+	// ContractAPIListenerTemplate field descriptions
+	ContractAPIListenerTemplateName      = ffm("ContractAPIListenerTemplate.name", "The name of the contract listener to create. Defaults to the API name followed by the event path")
+	ContractAPIListenerTemplateEventPath = ffm("ContractAPIListenerTemplate.eventPath", "The path of the event within the API's interface to listen for")
+	ContractAPIListenerTemplateTopic     = ffm("ContractAPIListenerTemplate.topic", "The topic to assign to events from the listener. Defaults to the API name")
+	ContractAPIListenerTemplateOptions   = ffm("ContractAPIListenerTemplate.options", "Options for the contract listener, such as the block to start listening from")
 
 	// ContractURLs field descriptions
 	ContractURLsAPI     = ffm("ContractURLs.api", "The URL to use to invoke the API")
@@ -352,6 +837,7 @@ var (
 
 	// EnrichedEvent field descriptions
 	EnrichedEventBlockchainEvent   = ffm("EnrichedEvent.blockchainEvent", "A blockchain event if referenced by the FireFly event")
+	EnrichedEventBlockchainReorg   = ffm("EnrichedEvent.blockchainReorg", "A blockchain re-org if referenced by the FireFly event")
 	EnrichedEventContractAPI       = ffm("EnrichedEvent.contractAPI", "A Contract API if referenced by the FireFly event")
 	EnrichedEventContractInterface = ffm("EnrichedEvent.contractInterface", "A Contract Interface (FFI) if referenced by the FireFly event")
 	EnrichedEventDatatype          = ffm("EnrichedEvent.datatype", "A Datatype if referenced by the FireFly event")
@@ -362,6 +848,7 @@ var (
 	EnrichedEventTokenPool         = ffm("EnrichedEvent.tokenPool", "A Token Pool if referenced by the FireFly event")
 	EnrichedEventTokenTransfer     = ffm("EnrichedEvent.tokenTransfer", "A Token Transfer if referenced by the FireFly event")
 	EnrichedEventTransaction       = ffm("EnrichedEvent.transaction", "A Transaction if associated with the FireFly event")
+	EnrichedEventCorrelationID     = ffm("EnrichedEvent.correlationId", "The correlation ID of the API request that submitted the transaction or operation associated with the FireFly event")
 
 	// IdentityMessages field descriptions
 	IdentityMessagesClaim        = ffm("IdentityMessages.claim", "The UUID of claim message")
@@ -416,6 +903,8 @@ var (
 	NamespaceCreated               = ffm("Namespace.created", "The time the namespace was created")
 	MultipartyContractsActive      = ffm("MultipartyContracts.active", "The currently active FireFly smart contract")
 	MultipartyContractsTerminated  = ffm("MultipartyContracts.terminated", "Previously-terminated FireFly smart contracts")
+	MultipartyContractsMigration   = ffm("MultipartyContracts.migration", "The most recent coordinated migration to a new FireFly smart contract")
+	MultipartyContractsScheduled   = ffm("MultipartyContracts.scheduled", "Network actions that have been received from the blockchain, and are waiting for their effective block or time")
 	MultipartyContractIndex        = ffm("MultipartyContract.index", "The index of this contract in the config file")
 	MultipartyContractVersion      = ffm("MultipartyContract.version", "The version of this multiparty contract")
 	MultipartyContractFinalEvent   = ffm("MultipartyContract.finalEvent", "The identifier for the final blockchain event received from this contract before termination")
@@ -425,17 +914,28 @@ var (
 	MultipartyContractStatus       = ffm("MultipartyContract.status", "The status of the contract listener. One of 'syncing', 'synced', or 'unknown'")
 	MultipartyContractInfo         = ffm("MultipartyContract.info", "Additional info about the current status of the multi-party contract")
 	NetworkActionType              = ffm("NetworkAction.type", "The action to be performed")
+	NetworkActionEffectiveBlock    = ffm("NetworkAction.effectiveBlock", "The block number at which every member applies the action. The action is applied when processing the first event from the FireFly contract at or after this block")
+	NetworkActionEffectiveTime     = ffm("NetworkAction.effectiveTime", "The time at which every member applies the action. The action is applied when processing the first event from the FireFly contract with a blockchain timestamp at or after this time")
+	ScheduledNetworkActionAuthor   = ffm("ScheduledNetworkAction.author", "The DID of the root org that submitted the action")
+	ScheduledNetworkActionLocation = ffm("ScheduledNetworkAction.location", "The FireFly contract the action was submitted to")
+	ScheduledNetworkActionEvent    = ffm("ScheduledNetworkAction.event", "The protocol ID of the blockchain event that scheduled the action")
+	ScheduledNetworkActionCreated  = ffm("ScheduledNetworkAction.created", "The time the action was received by this node")
 
 	// NamespaceWithInitStatus field descriptions
 	NamespaceWithInitStatusInitializing        = ffm("NamespaceWithInitStatus.initializing", "Set to true if the namespace is still initializing")
 	NamespaceWithInitStatusInitializationError = ffm("NamespaceWithInitStatus.initializationError", "Set to a non-empty string in the case that the namespace is currently failing to initialize")
 
 	// NamespaceStatus field descriptions
-	NodeNamespace       = ffm("NamespaceStatus.namespace", "The namespace that this status applies to")
-	NamespaceStatusNode = ffm("NamespaceStatus.node", "Details of the local node")
-	NamespaceStatusOrg  = ffm("NamespaceStatus.org", "Details of the root organization identity registered for this namespace on the local node")
-	NamespacePlugins    = ffm("NamespaceStatus.plugins", "Information about plugins configured on this namespace")
-	NamespaceMultiparty = ffm("NamespaceStatus.multiparty", "Information about the multi-party system configured on this namespace")
+	NodeNamespace         = ffm("NamespaceStatus.namespace", "The namespace that this status applies to")
+	NamespaceStatusNode   = ffm("NamespaceStatus.node", "Details of the local node")
+	NamespaceStatusOrg    = ffm("NamespaceStatus.org", "Details of the root organization identity registered for this namespace on the local node")
+	NamespacePlugins      = ffm("NamespaceStatus.plugins", "Information about plugins configured on this namespace")
+	NamespaceMultiparty   = ffm("NamespaceStatus.multiparty", "Information about the multi-party system configured on this namespace")
+	NamespaceReadOnly     = ffm("NamespaceStatus.readOnly", "True if the namespace is a read-only replica, which rejects all APIs that submit messages or transactions")
+	NamespaceBreakers     = ffm("NamespaceStatus.circuitBreakers", "The state of the circuit breaker for each plugin that operations have been submitted to")
+	NamespaceFeatures     = ffm("NamespaceStatus.features", "The optional features available on this namespace, so applications can adapt to the configuration")
+	NamespaceDegraded     = ffm("NamespaceStatus.degraded", "True if the namespace started without one or more optional plugin connectors, which are still being retried in the background")
+	NamespaceDependencies = ffm("NamespaceStatus.dependencies", "The startup progress of each plugin connector the namespace depends on")
 
 	// NamespaceStatusNode field descriptions
 	NamespaceStatusNodeName                  = ffm("NamespaceStatusNode.name", "The name of this node, as specified in the local configuration")
@@ -469,6 +969,28 @@ var (
 	NamespaceStatusPluginName = ffm("NamespaceStatusPlugin.name", "The name of the plugin")
 	NamespaceStatusPluginType = ffm("NamespaceStatusPlugin.pluginType", "The type of the plugin")
 
+	// NamespaceStatusFeatures field descriptions
+	NamespaceStatusFeaturesMultiparty    = ffm("NamespaceStatusFeatures.multiparty", "Multi-party messaging, with the version of the active multi-party contract")
+	NamespaceStatusFeaturesTokens        = ffm("NamespaceStatusFeatures.tokens", "Tokens, with the names of the configured token connectors")
+	NamespaceStatusFeaturesContracts     = ffm("NamespaceStatusFeatures.contracts", "Custom smart contracts, with the type of the blockchain plugin")
+	NamespaceStatusFeaturesDataExchange  = ffm("NamespaceStatusFeatures.dataExchange", "Private data exchange between nodes, with the type of the data exchange plugin")
+	NamespaceStatusFeaturesSharedStorage = ffm("NamespaceStatusFeatures.sharedStorage", "Shared storage of broadcast data, with the type of the shared storage plugin")
+	NamespaceStatusFeaturesTransports    = ffm("NamespaceStatusFeatures.transports", "Event delivery, with the event transports that subscriptions can use")
+
+	// NamespaceStatusFeature field descriptions
+	NamespaceStatusFeatureEnabled = ffm("NamespaceStatusFeature.enabled", "Whether the feature is available on this namespace")
+	NamespaceStatusFeaturePlugins = ffm("NamespaceStatusFeature.plugins", "The plugins that provide the feature")
+	NamespaceStatusFeatureVersion = ffm("NamespaceStatusFeature.version", "The version of the feature, where it is versioned")
+
+	// NamespaceStatusDependency field descriptions
+	NamespaceStatusDependencyType      = ffm("NamespaceStatusDependency.type", "The type of plugin, such as tokens")
+	NamespaceStatusDependencyName      = ffm("NamespaceStatusDependency.name", "The name of the plugin")
+	NamespaceStatusDependencyStatus    = ffm("NamespaceStatusDependency.status", "Whether the plugin connector is starting, pending in the background, or started")
+	NamespaceStatusDependencyOptional  = ffm("NamespaceStatusDependency.optional", "Whether the namespace can run in a degraded mode without this plugin connector")
+	NamespaceStatusDependencyAttempts  = ffm("NamespaceStatusDependency.attempts", "The number of attempts made to start the plugin connector")
+	NamespaceStatusDependencyLastError = ffm("NamespaceStatusDependency.lastError", "The error from the most recent failed attempt to start the plugin connector")
+	NamespaceStatusDependencyStarted   = ffm("NamespaceStatusDependency.started", "The time the plugin connector started")
+
 	// NamespaceStatusMultiparty field descriptions
 	NamespaceMultipartyEnabled  = ffm("NamespaceStatusMultiparty.enabled", "Whether multi-party mode is enabled for this namespace")
 	NamespaceMultipartyContract = ffm("NamespaceStatusMultiparty.contract", "Information about the multi-party smart contract configured for this namespace")
@@ -479,6 +1001,252 @@ var (
 	NamespaceMultipartyStatusOrg       = ffm("NamespaceMultipartyStatus.org", "Details of the root organization identity registered for this namespace on the local node")
 	NamespaceMultipartyStatusContracts = ffm("NamespaceMultipartyStatus.contracts", "Information about the active and terminated multi-party smart contracts configured for this namespace")
 
+	// NamespaceBootstrapStatus field descriptions
+	NamespaceBootstrapStatusEnabled   = ffm("NamespaceBootstrapStatus.enabled", "Whether automatic registration of the local org and node is enabled for this namespace")
+	NamespaceBootstrapStatusStarted   = ffm("NamespaceBootstrapStatus.started", "The time the bootstrap sequence started")
+	NamespaceBootstrapStatusCompleted = ffm("NamespaceBootstrapStatus.completed", "The time the bootstrap sequence completed, once all steps are complete")
+	NamespaceBootstrapStatusSteps     = ffm("NamespaceBootstrapStatus.steps", "The ordered list of registration steps, and the progress of each")
+
+	// NamespaceBootstrapStep field descriptions
+	NamespaceBootstrapStepName      = ffm("NamespaceBootstrapStep.name", "The name of the step, one of 'org' or 'node'")
+	NamespaceBootstrapStepStatus    = ffm("NamespaceBootstrapStep.status", "The status of the step, one of 'pending', 'running', 'retrying' or 'complete'")
+	NamespaceBootstrapStepAttempts  = ffm("NamespaceBootstrapStep.attempts", "The number of attempts made to complete this step")
+	NamespaceBootstrapStepLastError = ffm("NamespaceBootstrapStep.lastError", "The error from the most recent failed attempt, if any")
+	NamespaceBootstrapStepUpdated   = ffm("NamespaceBootstrapStep.updated", "The time the status of this step last changed")
+
+	// DefinitionApproval field descriptions
+	DefinitionApprovalMessage = ffm("DefinitionApproval.message", "The ID of the definition message being approved")
+	DefinitionApprovalHash    = ffm("DefinitionApproval.hash", "The hash of the definition message being approved")
+
+	// DefinitionApprovalStatus field descriptions
+	DefinitionApprovalStatusMessage   = ffm("DefinitionApprovalStatus.message", "The ID of the definition message")
+	DefinitionApprovalStatusTag       = ffm("DefinitionApprovalStatus.tag", "The tag of the definition message, which determines the type of definition")
+	DefinitionApprovalStatusState     = ffm("DefinitionApprovalStatus.state", "The approval state of the definition, one of 'pending_approval', 'approved' or 'rejected'")
+	DefinitionApprovalStatusRequired  = ffm("DefinitionApprovalStatus.required", "The number of approvals from distinct approvers required before the definition is processed")
+	DefinitionApprovalStatusApprovers = ffm("DefinitionApprovalStatus.approvers", "The DIDs of the members that have approved the definition")
+
+	// NamespaceConfigUpdate field descriptions
+	NamespaceConfigUpdateNamespace = ffm("NamespaceConfigUpdate.namespace", "The full configuration for the namespace, in the same structure as an entry in namespaces.predefined of the config file")
+	NamespaceConfigUpdatePlugins   = ffm("NamespaceConfigUpdate.plugins", "Plugin configuration entries to add or replace by name, keyed by plugin category (such as 'tokens' or 'dataexchange') in the same structure as the plugins section of the config file")
+
+	// NamespaceConfigUpdateResult field descriptions
+	NamespaceConfigUpdateResultNamespace = ffm("NamespaceConfigUpdateResult.namespace", "The name of the namespace that was updated")
+	NamespaceConfigUpdateResultRestarted = ffm("NamespaceConfigUpdateResult.restarted", "True if the namespace was restarted to apply the configuration, false if there were no changes")
+	NamespaceConfigUpdateResultChanges   = ffm("NamespaceConfigUpdateResult.changes", "The configuration changes that caused the namespace to be restarted")
+
+	// ConfigReloadPreview field descriptions
+	ConfigReloadPreviewFile       = ffm("ConfigReloadPreview.file", "The configuration file that was read")
+	ConfigReloadPreviewNamespaces = ffm("ConfigReloadPreview.namespaces", "The namespaces that would be started, stopped or restarted by the reload")
+	ConfigReloadPreviewPlugins    = ffm("ConfigReloadPreview.plugins", "The plugins that would be started, stopped, restarted or have their credentials rotated by the reload")
+	ConfigReloadPreviewSettings   = ffm("ConfigReloadPreview.settings", "The settings that differ in the configuration file, with secret values redacted")
+
+	// ConfigReloadChange field descriptions
+	ConfigReloadChangeName    = ffm("ConfigReloadChange.name", "The name of the namespace or plugin")
+	ConfigReloadChangeType    = ffm("ConfigReloadChange.type", "The type of plugin, such as tokens")
+	ConfigReloadChangeAction  = ffm("ConfigReloadChange.action", "What the reload would do to the namespace or plugin")
+	ConfigReloadChangeChanges = ffm("ConfigReloadChange.changes", "The reasons a namespace would be restarted - either its own configuration, or that of a plugin it uses")
+
+	// ConfigSettingChange field descriptions
+	ConfigSettingChangeKey = ffm("ConfigSettingChange.key", "The configuration key")
+	ConfigSettingChangeOld = ffm("ConfigSettingChange.old", "The current value, if set")
+	ConfigSettingChangeNew = ffm("ConfigSettingChange.new", "The value in the configuration file, if set")
+
+	// NamespaceClone field descriptions
+	NamespaceCloneName          = ffm("NamespaceClone.name", "The name of the new namespace")
+	NamespaceCloneDescription   = ffm("NamespaceClone.description", "A description for the new namespace. Defaults to the description of the template namespace")
+	NamespaceCloneDatatypes     = ffm("NamespaceClone.datatypes", "Copy the datatypes of the template namespace into the new namespace")
+	NamespaceCloneContractAPIs  = ffm("NamespaceClone.contractAPIs", "Copy the contract APIs of the template namespace, and the contract interfaces they use, into the new namespace")
+	NamespaceCloneSubscriptions = ffm("NamespaceClone.subscriptions", "Copy the subscriptions of the template namespace into the new namespace")
+
+	// NamespaceCloneResult field descriptions
+	NamespaceCloneResultNamespace = ffm("NamespaceCloneResult.namespace", "The name of the new namespace")
+	NamespaceCloneResultSource    = ffm("NamespaceCloneResult.source", "The name of the template namespace it was cloned from")
+	NamespaceCloneResultCopied    = ffm("NamespaceCloneResult.copied", "The number of each type of definition copied from the template namespace")
+
+	// ContractMigrationProposal field descriptions
+	ContractMigrationProposalID              = ffm("ContractMigrationProposal.id", "The UUID of the contract migration")
+	ContractMigrationProposalLocation        = ffm("ContractMigrationProposal.location", "A blockchain specific identifier for the new FireFly contract. It must match the next contract configured for the namespace on every member")
+	ContractMigrationProposalVersion         = ffm("ContractMigrationProposal.version", "The expected network version of the new FireFly contract. Checked by every member if set")
+	ContractMigrationProposalActivationBlock = ffm("ContractMigrationProposal.activationBlock", "The block number at which all members switch to the new FireFly contract, once every member has acknowledged")
+	ContractMigrationProposalMessage         = ffm("ContractMigrationProposal.message", "The UUID of the broadcast message used to propose the migration")
+
+	// ContractMigrationAck field descriptions
+	ContractMigrationAckMigration = ffm("ContractMigrationAck.migration", "The UUID of the contract migration being acknowledged")
+	ContractMigrationAckLocation  = ffm("ContractMigrationAck.location", "The location of the new FireFly contract, as configured on the acknowledging member")
+
+	// ContractMigration field descriptions
+	ContractMigrationProposer         = ffm("ContractMigration.proposer", "The DID of the root org that proposed the migration")
+	ContractMigrationState            = ffm("ContractMigration.state", "The state of the migration on this node")
+	ContractMigrationMembers          = ffm("ContractMigration.members", "The DIDs of the root orgs that must acknowledge the migration")
+	ContractMigrationAcknowledgements = ffm("ContractMigration.acknowledgements", "The DIDs of the root orgs that have acknowledged the migration")
+	ContractMigrationError            = ffm("ContractMigration.error", "The reason the migration was rolled back on this node")
+	ContractMigrationCreated          = ffm("ContractMigration.created", "The time the migration proposal was received by this node")
+	ContractMigrationUpdated          = ffm("ContractMigration.updated", "The time the migration state last changed on this node")
+
+	// DependencyHealth field descriptions
+	DependencyHealthID         = ffm("DependencyHealth.id", "A UUID identifying this dependency, used as the reference on health events")
+	DependencyHealthName       = ffm("DependencyHealth.name", "The configured name of the plugin")
+	DependencyHealthType       = ffm("DependencyHealth.type", "The kind of plugin - database, blockchain, dataexchange, sharedstorage or tokens")
+	DependencyHealthPluginType = ffm("DependencyHealth.pluginType", "The plugin implementation in use")
+	DependencyHealthStatus     = ffm("DependencyHealth.status", "The result of the latest probe")
+	DependencyHealthLatency    = ffm("DependencyHealth.latency", "How long the latest probe took to complete")
+	DependencyHealthError      = ffm("DependencyHealth.error", "The error returned by the latest probe, if it failed")
+	DependencyHealthChecked    = ffm("DependencyHealth.checked", "The time of the latest probe")
+	DependencyHealthChanged    = ffm("DependencyHealth.changed", "The time the status last changed")
+
+	// NamespaceHealth field descriptions
+	NamespaceHealthStatus       = ffm("NamespaceHealth.status", "The worst status across all probed dependencies of the namespace")
+	NamespaceHealthDependencies = ffm("NamespaceHealth.dependencies", "The health of each plugin the namespace depends on")
+
+	// SLOStatus field descriptions
+	SLOStatusID        = ffm("SLOStatus.id", "A UUID identifying this service level objective, used as the reference on SLO events")
+	SLOStatusType      = ffm("SLOStatus.type", "The measurement the service level objective applies to")
+	SLOStatusThreshold = ffm("SLOStatus.threshold", "The value above which the objective is breached - in seconds for message confirmation, and in events for event delivery lag")
+	SLOStatusValue     = ffm("SLOStatus.value", "The latest measured value")
+	SLOStatusSamples   = ffm("SLOStatus.samples", "The number of messages or subscriptions the latest value was measured from")
+	SLOStatusBreached  = ffm("SLOStatus.breached", "Whether the latest value exceeds the threshold")
+	SLOStatusChecked   = ffm("SLOStatus.checked", "The time of the latest measurement")
+	SLOStatusChanged   = ffm("SLOStatus.changed", "The time the objective was last breached or recovered")
+
+	// CircuitBreakerStatus field descriptions
+	CircuitBreakerStatusPlugin     = ffm("CircuitBreakerStatus.plugin", "The plugin the circuit breaker protects")
+	CircuitBreakerStatusState      = ffm("CircuitBreakerStatus.state", "The state of the circuit breaker")
+	CircuitBreakerStatusFailures   = ffm("CircuitBreakerStatus.failures", "The number of consecutive operation failures against the plugin")
+	CircuitBreakerStatusOpened     = ffm("CircuitBreakerStatus.opened", "The time the circuit breaker last opened")
+	CircuitBreakerStatusRetryAfter = ffm("CircuitBreakerStatus.retryAfter", "The time after which a trial operation will be allowed through to the plugin")
+
+	// NamespaceArchive field descriptions
+	NamespaceArchiveVersion    = ffm("NamespaceArchive.version", "The version of the archive format")
+	NamespaceArchiveNamespace  = ffm("NamespaceArchive.namespace", "The namespace the archive was exported from")
+	NamespaceArchiveCreated    = ffm("NamespaceArchive.created", "The time the archive was exported")
+	NamespaceArchiveIdentities = ffm("NamespaceArchive.identities", "The identities registered in the namespace")
+	NamespaceArchiveVerifiers  = ffm("NamespaceArchive.verifiers", "The verifiers of the identities registered in the namespace")
+	NamespaceArchiveGroups     = ffm("NamespaceArchive.groups", "The private messaging groups of the namespace")
+	NamespaceArchiveDatatypes  = ffm("NamespaceArchive.datatypes", "The datatype definitions of the namespace")
+	NamespaceArchiveInterfaces = ffm("NamespaceArchive.interfaces", "The contract interfaces of the namespace, including their methods, events and errors")
+	NamespaceArchiveAPIs       = ffm("NamespaceArchive.apis", "The contract APIs of the namespace")
+	NamespaceArchiveTokenPools = ffm("NamespaceArchive.tokenPools", "The token pools of the namespace")
+	NamespaceArchiveMessages   = ffm("NamespaceArchive.messages", "The messages of the namespace, if requested in the export")
+	NamespaceArchiveData       = ffm("NamespaceArchive.data", "The data records of the namespace, if requested in the export. Blob payloads are not included")
+	NamespaceArchiveBatches    = ffm("NamespaceArchive.batches", "The confirmed batches of the namespace, including their manifests, if requested in the export")
+
+	// NamespaceImportResult field descriptions
+	NamespaceImportResultNamespace = ffm("NamespaceImportResult.namespace", "The namespace the archive was imported into")
+	NamespaceImportResultImported  = ffm("NamespaceImportResult.imported", "The number of records imported, for each type of record in the archive")
+
+	// NamespaceSnapshot field descriptions
+	NamespaceSnapshotHash      = ffm("NamespaceSnapshot.hash", "The hash of the archive")
+	NamespaceSnapshotSignature = ffm("NamespaceSnapshot.signature", "The signature over the hash, made with the payload signing key of the org that exported the snapshot")
+	NamespaceSnapshotArchive   = ffm("NamespaceSnapshot.archive", "The archive of the confirmed state of the namespace")
+
+	// SnapshotVerification field descriptions
+	SnapshotVerificationHash       = ffm("SnapshotVerification.hash", "The hash of the imported snapshot")
+	SnapshotVerificationImported   = ffm("SnapshotVerification.imported", "The time the snapshot was imported")
+	SnapshotVerificationCompleted  = ffm("SnapshotVerification.completed", "The time every pinned batch of the snapshot had been checked against a pin received from the blockchain")
+	SnapshotVerificationBatches    = ffm("SnapshotVerification.batches", "The number of pinned batches in the snapshot")
+	SnapshotVerificationVerified   = ffm("SnapshotVerification.verified", "The number of batches with a pin received from the blockchain that matches the batch hash recomputed from the messages and data in the snapshot")
+	SnapshotVerificationPending    = ffm("SnapshotVerification.pending", "The number of batches that no pin has been received for yet")
+	SnapshotVerificationMismatched = ffm("SnapshotVerification.mismatched", "The IDs of batches that do not match their manifest, or with a pin received from the blockchain that does not match the batch hash recomputed from the messages and data in the snapshot")
+
+	// NamespaceQuotas field descriptions
+	NamespaceQuotasMessagesPerDay    = ffm("NamespaceQuotas.messagesPerDay", "The number of messages sent by local identities in the namespace in the current day (UTC). A limit of 0 means no limit")
+	NamespaceQuotasBlobBytes         = ffm("NamespaceQuotas.blobBytes", "The total size in bytes of the blobs stored in the namespace. A limit of 0 means no limit")
+	NamespaceQuotasContractListeners = ffm("NamespaceQuotas.contractListeners", "The number of contract listeners in the namespace. A limit of 0 means no limit")
+	NamespaceQuotasSubscriptions     = ffm("NamespaceQuotas.subscriptions", "The number of subscriptions in the namespace. A limit of 0 means no limit")
+
+	// NamespaceQuotaStatus field descriptions
+	NamespaceQuotaStatusLimits = ffm("NamespaceQuotaStatus.limits", "The quota limits of the namespace")
+	NamespaceQuotaStatusUsage  = ffm("NamespaceQuotaStatus.usage", "The current usage of each quota of the namespace")
+
+	// NamespaceFaults field descriptions
+	NamespaceFaultsRules = ffm("NamespaceFaults.rules", "The fault injection rules of the namespace. Each call to a plugin is checked against every rule in order")
+
+	// FaultRule field descriptions
+	FaultRulePlugin      = ffm("FaultRule.plugin", "The type of plugin the rule applies to - database, blockchain or dataexchange")
+	FaultRuleMethod      = ffm("FaultRule.method", "The name of the plugin method the rule applies to, such as SubmitBatchPin. Empty to apply to every method of the plugin that supports fault injection")
+	FaultRuleAction      = ffm("FaultRule.action", "The fault to inject - delay holds the call for the delay, fail returns an error without making the call, and duplicate makes the call twice")
+	FaultRuleProbability = ffm("FaultRule.probability", "The probability the fault is injected into each matching call, greater than 0 and at most 1")
+	FaultRuleDelay       = ffm("FaultRule.delay", "The time to hold each matching call, for the delay action")
+	FaultRuleInjected    = ffm("FaultRule.injected", "The number of times the fault has been injected since the rule was set")
+
+	// LoadTestSpec field descriptions
+	LoadTestSpecDuration       = ffm("LoadTestSpec.duration", "How long to send requests for. Requests still in flight at the end are waited for")
+	LoadTestSpecConcurrency    = ffm("LoadTestSpec.concurrency", "The maximum number of requests of each type waiting for confirmation at once. Defaults to 50")
+	LoadTestSpecPayloadSize    = ffm("LoadTestSpec.payloadSize", "The size in bytes of the synthetic data sent with each message. Defaults to 128")
+	LoadTestSpecBroadcastRate  = ffm("LoadTestSpec.broadcastRate", "The number of broadcast messages to send per second")
+	LoadTestSpecPrivateRate    = ffm("LoadTestSpec.privateRate", "The number of private messages to send per second")
+	LoadTestSpecPrivateMembers = ffm("LoadTestSpec.privateMembers", "The identities of the members of the group the private messages are sent to")
+	LoadTestSpecTransferRate   = ffm("LoadTestSpec.transferRate", "The number of token transfers to send per second")
+	LoadTestSpecTransferPool   = ffm("LoadTestSpec.transferPool", "The name or ID of the token pool to transfer from")
+	LoadTestSpecTransferTo     = ffm("LoadTestSpec.transferTo", "The identity or key to transfer tokens to")
+	LoadTestSpecTransferAmount = ffm("LoadTestSpec.transferAmount", "The amount of each token transfer. Defaults to 1")
+
+	// LoadTestResult field descriptions
+	LoadTestResultType       = ffm("LoadTestResult.type", "The type of request - broadcast, private or transfer")
+	LoadTestResultSent       = ffm("LoadTestResult.sent", "The number of requests sent")
+	LoadTestResultConfirmed  = ffm("LoadTestResult.confirmed", "The number of requests that were confirmed")
+	LoadTestResultFailed     = ffm("LoadTestResult.failed", "The number of requests that failed or were not confirmed")
+	LoadTestResultSkipped    = ffm("LoadTestResult.skipped", "The number of requests not sent at their scheduled time, because the concurrency limit was reached")
+	LoadTestResultLatencyP50 = ffm("LoadTestResult.latencyP50", "The 50th percentile of the time from sending a request to its confirmation")
+	LoadTestResultLatencyP90 = ffm("LoadTestResult.latencyP90", "The 90th percentile of the time from sending a request to its confirmation")
+	LoadTestResultLatencyP99 = ffm("LoadTestResult.latencyP99", "The 99th percentile of the time from sending a request to its confirmation")
+	LoadTestResultLatencyMax = ffm("LoadTestResult.latencyMax", "The longest time from sending a request to its confirmation")
+	LoadTestResultLastError  = ffm("LoadTestResult.lastError", "The error of the most recent failed request")
+
+	// LoadTestStatus field descriptions
+	LoadTestStatusID      = ffm("LoadTestStatus.id", "The UUID of the load test")
+	LoadTestStatusSpec    = ffm("LoadTestStatus.spec", "The rate and shape of the requests sent by the load test")
+	LoadTestStatusRunning = ffm("LoadTestStatus.running", "True while the load test is sending requests, or waiting for them to be confirmed")
+	LoadTestStatusStarted = ffm("LoadTestStatus.started", "The time the load test started")
+	LoadTestStatusEnded   = ffm("LoadTestStatus.ended", "The time the load test ended")
+	LoadTestStatusResults = ffm("LoadTestStatus.results", "The outcome of each type of request sent by the load test")
+
+	// DefinitionReplayRecord field descriptions
+	DefinitionReplayRecordMessage = ffm("DefinitionReplayRecord.message", "The definition message, as it was broadcast")
+	DefinitionReplayRecordData    = ffm("DefinitionReplayRecord.data", "The data of the definition message, with the definition as the value")
+	DefinitionReplayRecordOutcome = ffm("DefinitionReplayRecord.outcome", "The state the message reached when the definition was processed - confirmed or rejected")
+
+	// DefinitionReplayInput field descriptions
+	DefinitionReplayInputRecords = ffm("DefinitionReplayInput.records", "The recorded definition broadcasts to replay, in the order they were processed")
+
+	// DefinitionReplayResult field descriptions
+	DefinitionReplayResultMessage  = ffm("DefinitionReplayResult.message", "The UUID of the definition message")
+	DefinitionReplayResultTag      = ffm("DefinitionReplayResult.tag", "The tag of the definition message, which identifies the type of definition")
+	DefinitionReplayResultRecorded = ffm("DefinitionReplayResult.recorded", "The state the message reached when the definition was originally processed")
+	DefinitionReplayResultReplayed = ffm("DefinitionReplayResult.replayed", "The state the message would reach with the definition handlers of this node")
+	DefinitionReplayResultDiverged = ffm("DefinitionReplayResult.diverged", "True if the replayed state differs from the recorded state")
+	DefinitionReplayResultSkipped  = ffm("DefinitionReplayResult.skipped", "True if the definition was not replayed, as its handler has effects outside of the database")
+	DefinitionReplayResultError    = ffm("DefinitionReplayResult.error", "The error returned by the handler, if the definition was rejected or could not be processed")
+
+	// DefinitionReplayReport field descriptions
+	DefinitionReplayReportTotal    = ffm("DefinitionReplayReport.total", "The number of records submitted")
+	DefinitionReplayReportReplayed = ffm("DefinitionReplayReport.replayed", "The number of records replayed. Replay stops at the first record whose handler returns a transient error")
+	DefinitionReplayReportMatched  = ffm("DefinitionReplayReport.matched", "The number of replayed records whose outcome matched the recorded outcome")
+	DefinitionReplayReportDiverged = ffm("DefinitionReplayReport.diverged", "The number of replayed records whose outcome differed from the recorded outcome")
+	DefinitionReplayReportSkipped  = ffm("DefinitionReplayReport.skipped", "The number of records skipped, as their handlers have effects outside of the database")
+	DefinitionReplayReportResults  = ffm("DefinitionReplayReport.results", "The outcome of each record, in order")
+
+	// TypedDataSignRequest field descriptions
+	TypedDataSignRequestKeyID     = ffm("TypedDataSignRequest.keyId", "The ID of a secp256k1 key in the KMS of the signing plugin")
+	TypedDataSignRequestTypedData = ffm("TypedDataSignRequest.typedData", "The EIP-712 typed data to sign, with types, primaryType, domain and message")
+
+	// TypedDataSignature field descriptions
+	TypedDataSignatureKeyID     = ffm("TypedDataSignature.keyId", "The ID of the key in the KMS that signed the typed data")
+	TypedDataSignatureAddress   = ffm("TypedDataSignature.address", "The Ethereum address of the key that signed the typed data")
+	TypedDataSignatureHash      = ffm("TypedDataSignature.hash", "The EIP-712 hash of the typed data that was signed")
+	TypedDataSignatureSignature = ffm("TypedDataSignature.signature", "The 65 byte signature in r, s, v order, hex encoded")
+
+	// CredentialIssueRequest field descriptions
+	CredentialIssueRequestType              = ffm("CredentialIssueRequest.type", "The types of the credential, in addition to VerifiableCredential")
+	CredentialIssueRequestCredentialSubject = ffm("CredentialIssueRequest.credentialSubject", "The claims made about the subject of the credential, optionally with the id of the subject")
+	CredentialIssueRequestExpirationDate    = ffm("CredentialIssueRequest.expirationDate", "The time the credential expires, if it should expire")
+
+	// IssuedCredential field descriptions
+	IssuedCredentialID         = ffm("IssuedCredential.id", "The URN of the credential")
+	IssuedCredentialIssuer     = ffm("IssuedCredential.issuer", "The DID of the org that issued the credential")
+	IssuedCredentialCredential = ffm("IssuedCredential.credential", "The credential, in the JSON form of the W3C verifiable credentials data model")
+	IssuedCredentialJWT        = ffm("IssuedCredential.jwt", "The credential as a JWT, signed with the payload signing key of the issuing org")
+
 	// BatchManagerStatus field descriptions
 	BatchManagerStatusProcessors = ffm("BatchManagerStatus.processors", "An array of currently active batch processors")
 
@@ -487,6 +1255,14 @@ var (
 	BatchProcessorStatusName       = ffm("BatchProcessorStatus.name", "The name of the processor, which includes details of the attributes of message are allocated to this processor")
 	BatchProcessorStatusStatus     = ffm("BatchProcessorStatus.status", "The flush status for this batch processor")
 
+	// AggregatorStatus field descriptions
+	AggregatorStatusPollingOffset = ffm("AggregatorStatus.pollingOffset", "The sequence of the last pin the aggregator has processed")
+	AggregatorStatusQueuedRewinds = ffm("AggregatorStatus.queuedRewinds", "The number of rewinds requested, that the aggregator has not yet picked up")
+	AggregatorStatusStagedRewinds = ffm("AggregatorStatus.stagedRewinds", "The number of rewinds picked up by the aggregator, that are being resolved to batches")
+	AggregatorStatusReadyRewinds  = ffm("AggregatorStatus.readyRewinds", "The number of batches the aggregator will rewind to on its next poll")
+	AggregatorStatusCheckpoint    = ffm("AggregatorStatus.checkpoint", "The sequence of the pin at or below which every pin has been dispatched and compacted, if checkpoints are enabled")
+	AggregatorStatusCompactedPins = ffm("AggregatorStatus.compactedPins", "The number of dispatched pins deleted by checkpoint compaction since the namespace started")
+
 	// BatchFlushStatus field descriptions
 	BatchFlushStatusLastFlushTime        = ffm("BatchFlushStatus.lastFlushStartTime", "The last time a flush was performed")
 	BatchFlushStatusFlushing             = ffm("BatchFlushStatus.flushing", "If a flush is in progress, this is the UUID of the batch being flushed")
@@ -533,6 +1309,13 @@ var (
 	SubscriptionCreated   = ffm("Subscription.created", "Creation time of the subscription")
 	SubscriptionUpdated   = ffm("Subscription.updated", "Last time the subscription was updated")
 
+	// SubscriptionOffset field descriptions
+	SubscriptionOffsetSubscription = ffm("SubscriptionOffset.subscription", "The UUID of the subscription")
+	SubscriptionOffsetCurrent      = ffm("SubscriptionOffset.current", "The sequence of the last event processed by consumers of the subscription. Delivery resumes from the next event. Zero if no offset has been stored yet")
+
+	// SubscriptionEventsAck field descriptions
+	SubscriptionEventsAckSequence = ffm("SubscriptionEventsAck.sequence", "The sequence of the highest event the consumer has processed. Acknowledging a sequence at or below the current offset has no effect")
+
 	// SubscriptionFilter field descriptions
 	SubscriptionFilterEvents           = ffm("SubscriptionFilter.events", "Regular expression to apply to the event type, to subscribe to a subset of event types")
 	SubscriptionFilterTopic            = ffm("SubscriptionFilter.topic", "Regular expression to apply to the topic of the event, to subscribe to a subset of topics. Note for messages sent with multiple topics, a separate event is emitted for each topic")
@@ -550,7 +1333,8 @@ var (
 	SubscriptionMessageFilterAuthor = ffm("SubscriptionMessageFilter.author", "Regular expression to apply to the message 'header.author' field")
 
 	// SubscriptionTransactionFilter field descriptions
-	SubscriptionTransactionFilterType = ffm("SubscriptionTransactionFilter.type", "Regular expression to apply to the transaction 'type' field")
+	SubscriptionTransactionFilterType   = ffm("SubscriptionTransactionFilter.type", "Regular expression to apply to the transaction 'type' field")
+	SubscriptionTransactionFilterLabels = ffm("SubscriptionTransactionFilter.labels", "Regular expression to apply to the transaction 'labels' field. Matches if any one of the labels matches")
 
 	// SubscriptionBlockchainEventFilter field descriptions
 	SubscriptionBlockchainEventFilterName     = ffm("SubscriptionBlockchainEventFilter.name", "Regular expression to apply to the blockchain event 'name' field, which is the name of the event in the underlying blockchain smart contract")
@@ -622,6 +1406,7 @@ var (
 	TokenPoolInterfaceFormat = ffm("TokenPool.interfaceFormat", "The interface encoding format supported by the connector for this token pool")
 	TokenPoolMethods         = ffm("TokenPool.methods", "The method definitions resolved by the token connector to be used by each token operation")
 	TokenPoolPublished       = ffm("TokenPool.published", "Indicates if the token pool is published to other members of the multiparty network")
+	TokenPoolFirstEvent      = ffm("TokenPool.firstEvent", "For a pool on an already-deployed token contract, the block number from which to index historical transfer events, so balances and transfer history are built retroactively. The special strings 'oldest' and 'newest' are supported. Default is 'newest'")
 
 	// TokenPoolInput field descriptions
 	TokenPoolInputIdempotencyKey = ffm("TokenPoolInput.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
@@ -650,6 +1435,7 @@ var (
 	TokenTransferInputMessage        = ffm("TokenTransferInput.message", "You can specify a message to correlate with the transfer, which can be of type broadcast or private. Your chosen token connector and on-chain smart contract must support on-chain/off-chain correlation by taking a `data` input on the transfer")
 	TokenTransferInputPool           = ffm("TokenTransferInput.pool", "The name or UUID of a token pool")
 	TokenTransferInputIdempotencyKey = ffm("TokenTransferInput.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
+	TokenTransferInputTxLabels       = ffm("TokenTransferInput.txlabels", "Optional labels to store on the FireFly transaction for the transfer, so it can be filtered in queries and event subscriptions")
 
 	// TransactionStatus field descriptions
 	TransactionStatusStatus  = ffm("TransactionStatus.status", "The overall computed status of the transaction, after analyzing the details during the API call")
@@ -686,6 +1472,17 @@ var (
 	ContractCallRequestOptions    = ffm("ContractCallRequest.options", "A map of named inputs that will be passed through to the blockchain connector")
 	ContractCallMessage           = ffm("ContractCallRequest.message", "You can specify a message to correlate with the invocation, which can be of type broadcast or private. Your specified method must support on-chain/off-chain correlation by taking a data input on the call")
 	ContractCallIdempotencyKey    = ffm("ContractCallRequest.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
+	ContractCallTxLabels          = ffm("ContractCallRequest.txlabels", "Optional labels to store on the FireFly transaction for the invocation, so it can be filtered in queries and event subscriptions")
+
+	// ContractInvokeStep field descriptions
+	ContractInvokeStepName         = ffm("ContractInvokeStep.name", "An optional name for the step, recorded on the saga of the transaction")
+	ContractInvokeStepInvoke       = ffm("ContractInvokeStep.invoke", "The contract invocation performed by the step. It is submitted once all earlier steps have succeeded")
+	ContractInvokeStepCompensation = ffm("ContractInvokeStep.compensation", "An optional contract invocation that undoes the step. It is submitted if a later step fails permanently")
+
+	// ContractInvokeSequenceRequest field descriptions
+	ContractInvokeSequenceRequestSteps          = ffm("ContractInvokeSequenceRequest.steps", "The ordered steps of the sequence")
+	ContractInvokeSequenceRequestIdempotencyKey = ffm("ContractInvokeSequenceRequest.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
+	ContractInvokeSequenceRequestTxLabels       = ffm("ContractInvokeSequenceRequest.txlabels", "Optional labels to store on the FireFly transaction for the sequence, so it can be filtered in queries and event subscriptions")
 
 	// WebSocketStatus field descriptions
 	WebSocketStatusEnabled     = ffm("WebSocketStatus.enabled", "Indicates whether the websockets plugin is enabled")
@@ -0,0 +1,151 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package credentials lets plugins switch the credentials used to connect to their connector while they
+// are running, so a credential rotation does not require the plugin and its namespaces to be restarted.
+package credentials
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// REST holds the credentials for a REST client, applying them to each request as it is sent
+type REST struct {
+	headers   atomic.Pointer[http.Header]
+	cert      atomic.Pointer[tls.Certificate]
+	transport *http.Transport
+}
+
+// credentialKeys are the plugin config keys that hold credentials, at any level of the plugin config.
+// A change to one of these can be applied to a running plugin that supports rotation.
+var credentialKeys = map[string]bool{
+	"auth":    true,
+	"headers": true,
+}
+
+// tlsCredentialKeys are the keys within a TLS config section for the client certificate. Changes to
+// the CA, or to whether TLS is enabled, still require a restart.
+var tlsCredentialKeys = map[string]bool{
+	"certfile": true,
+	"keyfile":  true,
+	"cert":     true,
+	"key":      true,
+}
+
+// StripCredentials returns a copy of the raw config of a plugin, with all the credentials removed.
+// Keys are compared case insensitively, as the config is read from viper with lower case keys.
+func StripCredentials(rawConfig fftypes.JSONObject) fftypes.JSONObject {
+	return stripKeys(rawConfig, credentialKeys)
+}
+
+func stripKeys(rawConfig fftypes.JSONObject, keys map[string]bool) fftypes.JSONObject {
+	stripped := make(fftypes.JSONObject, len(rawConfig))
+	for k, v := range rawConfig {
+		lk := strings.ToLower(k)
+		if keys[lk] {
+			continue
+		}
+		childKeys := credentialKeys
+		if lk == "tls" {
+			childKeys = tlsCredentialKeys
+		}
+		switch vt := v.(type) {
+		case map[string]interface{}:
+			stripped[k] = stripKeys(vt, childKeys)
+		case fftypes.JSONObject:
+			stripped[k] = stripKeys(vt, childKeys)
+		default:
+			stripped[k] = v
+		}
+	}
+	return stripped
+}
+
+// NewREST moves the credentials from a newly created REST client into a holder, so they can be replaced
+// later with Update. It must be called before the client is used.
+func NewREST(ctx context.Context, client *resty.Client, conf *ffresty.Config) *REST {
+	r := &REST{}
+	headers := restHeaders(conf)
+	for k := range headers {
+		client.Header.Del(k)
+	}
+	r.headers.Store(&headers)
+	client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		for k, v := range *r.headers.Load() {
+			req.Header[k] = v
+		}
+		return nil
+	})
+
+	// Client certificates are supplied during each handshake, rather than fixed in the TLS config.
+	// This does not apply if a custom transport is in use (such as in unit tests).
+	transport, err := client.Transport()
+	if err != nil || transport.TLSClientConfig == nil {
+		log.L(ctx).Debugf("REST client does not use a TLS transport - client certificates cannot be rotated")
+		return r
+	}
+	r.transport = transport
+	r.cert.Store(clientCertificate(conf))
+	tlsConfig := transport.TLSClientConfig
+	tlsConfig.Certificates = nil
+	tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		return r.cert.Load(), nil
+	}
+	return r
+}
+
+// Update switches to the credentials in an updated config. Requests that are in flight complete with the
+// previous credentials, and idle connections are closed so the next request uses the new client certificate.
+func (r *REST) Update(conf *ffresty.Config) {
+	headers := restHeaders(conf)
+	r.headers.Store(&headers)
+	if r.transport != nil {
+		r.cert.Store(clientCertificate(conf))
+		r.transport.CloseIdleConnections()
+	}
+}
+
+// restHeaders builds the headers ffresty would set on the client, for the custom headers and basic auth
+func restHeaders(conf *ffresty.Config) http.Header {
+	headers := make(http.Header)
+	for k, v := range conf.HTTPHeaders {
+		if vs, ok := v.(string); ok {
+			headers.Set(k, vs)
+		}
+	}
+	if conf.AuthUsername != "" && conf.AuthPassword != "" {
+		headers.Set("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", conf.AuthUsername, conf.AuthPassword)))))
+	}
+	return headers
+}
+
+func clientCertificate(conf *ffresty.Config) *tls.Certificate {
+	if conf.TLSClientConfig == nil || len(conf.TLSClientConfig.Certificates) == 0 {
+		return &tls.Certificate{}
+	}
+	return &conf.TLSClientConfig.Certificates[0]
+}
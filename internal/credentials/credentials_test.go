@@ -0,0 +1,140 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripCredentials(t *testing.T) {
+	stripped := StripCredentials(fftypes.JSONObject{
+		"type": "ethereum",
+		"ethereum": map[string]interface{}{
+			"ethconnect": map[string]interface{}{
+				"url":     "http://ethconnect:5000",
+				"auth":    map[string]interface{}{"username": "user1", "password": "pass1"},
+				"headers": map[string]interface{}{"x-api-key": "key1"},
+				"tls": map[string]interface{}{
+					"enabled":  true,
+					"cafile":   "ca.pem",
+					"certfile": "cert.pem",
+					"keyfile":  "key.pem",
+				},
+			},
+			"addressResolver": fftypes.JSONObject{
+				"urlTemplate": "http://resolver/{{.Key}}",
+				"key":         "kept",
+				"Headers":     map[string]interface{}{"x-api-key": "key1"},
+			},
+		},
+	})
+	assert.Equal(t, fftypes.JSONObject{
+		"type": "ethereum",
+		"ethereum": fftypes.JSONObject{
+			"ethconnect": fftypes.JSONObject{
+				"url": "http://ethconnect:5000",
+				"tls": fftypes.JSONObject{
+					"enabled": true,
+					"cafile":  "ca.pem",
+				},
+			},
+			"addressResolver": fftypes.JSONObject{
+				"urlTemplate": "http://resolver/{{.Key}}",
+				"key":         "kept",
+			},
+		},
+	}, stripped)
+}
+
+func TestRESTUpdateHeaders(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+	}))
+	defer server.Close()
+
+	conf := &ffresty.Config{
+		URL: server.URL,
+		HTTPConfig: ffresty.HTTPConfig{
+			AuthUsername: "user1",
+			AuthPassword: "pass1",
+			HTTPHeaders:  fftypes.JSONObject{"X-API-Key": "key1", "X-Other": "other1"},
+		},
+	}
+	client := ffresty.NewWithConfig(context.Background(), *conf)
+	creds := NewREST(context.Background(), client, conf)
+
+	_, err := client.R().Get("/")
+	assert.NoError(t, err)
+	h := <-headers
+	assert.Equal(t, "Basic dXNlcjE6cGFzczE=", h.Get("Authorization"))
+	assert.Equal(t, "key1", h.Get("X-API-Key"))
+	assert.Equal(t, "other1", h.Get("X-Other"))
+
+	creds.Update(&ffresty.Config{
+		HTTPConfig: ffresty.HTTPConfig{
+			HTTPHeaders: fftypes.JSONObject{"X-API-Key": "key2"},
+		},
+	})
+	_, err = client.R().Get("/")
+	assert.NoError(t, err)
+	h = <-headers
+	assert.Empty(t, h.Get("Authorization"))
+	assert.Equal(t, "key2", h.Get("X-API-Key"))
+	assert.Empty(t, h.Get("X-Other"))
+}
+
+func TestRESTUpdateClientCertificate(t *testing.T) {
+	peerCerts := make(chan int, 1)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peerCerts <- len(r.TLS.PeerCertificates)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	conf := &ffresty.Config{
+		URL: server.URL,
+		HTTPConfig: ffresty.HTTPConfig{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+	client := ffresty.NewWithConfig(context.Background(), *conf)
+	creds := NewREST(context.Background(), client, conf)
+
+	_, err := client.R().Get("/")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, <-peerCerts)
+
+	// The certificate of the test server is good enough to present as a client certificate
+	creds.Update(&ffresty.Config{
+		HTTPConfig: ffresty.HTTPConfig{
+			TLSClientConfig: &tls.Config{Certificates: server.TLS.Certificates},
+		},
+	})
+	_, err = client.R().Get("/")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, <-peerCerts)
+}
@@ -29,13 +29,17 @@ import (
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Manager interface {
 	CheckDatatype(ctx context.Context, datatype *core.Datatype) error
+	InferDatatype(ctx context.Context, input *core.DatatypeInference) (*core.Datatype, error)
 	ValidateAll(ctx context.Context, data core.DataArray) (valid bool, err error)
 	GetMessageWithDataCached(ctx context.Context, msgID *fftypes.UUID, options ...CacheReadOption) (msg *core.Message, data core.DataArray, foundAllData bool, err error)
 	GetMessageDataCached(ctx context.Context, msg *core.Message, options ...CacheReadOption) (data core.DataArray, foundAll bool, err error)
@@ -44,13 +48,21 @@ type Manager interface {
 	UpdateMessageIfCached(ctx context.Context, msg *core.Message)
 	UpdateMessageStateIfCached(ctx context.Context, id *fftypes.UUID, state core.MessageState, confirmed *fftypes.FFTime, rejectReason string)
 	ResolveInlineData(ctx context.Context, msg *NewMessage) error
+	SetMessageValidator(validator MessageValidator)
 	WriteNewMessage(ctx context.Context, newMsg *NewMessage) error
 	BlobsEnabled() bool
+	ForgetMissingDatatypes()
 
 	UploadJSON(ctx context.Context, inData *core.DataRefOrValue) (*core.Data, error)
 	UploadBlob(ctx context.Context, inData *core.DataRefOrValue, blob *ffapi.Multipart, autoMeta bool) (*core.Data, error)
 	DownloadBlob(ctx context.Context, dataID string) (*core.Blob, io.ReadCloser, error)
 	DeleteData(ctx context.Context, dataID string) error
+	CreateMessageRetentionPolicy(ctx context.Context, policy *core.MessageRetentionPolicy) (*core.MessageRetentionPolicy, error)
+	UpdateMessageRetentionPolicy(ctx context.Context, id string, policy *core.MessageRetentionPolicy) (*core.MessageRetentionPolicy, error)
+	DeleteMessageRetentionPolicy(ctx context.Context, id string) error
+	GetMessageRetentionPolicyByID(ctx context.Context, id string) (*core.MessageRetentionPolicy, error)
+	GetMessageRetentionPolicies(ctx context.Context, filter ffapi.AndFilter) ([]*core.MessageRetentionPolicy, *ffapi.FilterResult, error)
+	PruneMessageData(ctx context.Context, policy *core.MessageRetentionPolicy, limit int) (deleted int64, err error)
 	HydrateBatch(ctx context.Context, persistedBatch *core.BatchPersisted) (*core.Batch, error)
 	Start()
 	WaitStop()
@@ -62,9 +74,14 @@ type dataManager struct {
 	database       database.Plugin
 	validatorCache cache.CInterface
 	messageCache   cache.CInterface
+	missingCache   *cache.NegativeCache
 	messageWriter  *messageWriter
+	validator      MessageValidator
 }
 
+// MessageValidator is called with each new message once its data has been resolved, and can reject the message
+type MessageValidator func(ctx context.Context, msg *core.Message, data core.DataArray) error
+
 type messageCacheEntry struct {
 	msg  *core.Message
 	data []*core.Data
@@ -132,6 +149,10 @@ func NewDataManager(ctx context.Context, ns *core.Namespace, di database.Plugin,
 		return nil, err
 	}
 	dm.messageCache = messageCache
+	dm.missingCache, err = cache.NewNegativeCache(ctx, cacheManager, ns.Name, "datatype")
+	if err != nil {
+		return nil, err
+	}
 	dm.messageWriter = newMessageWriter(ctx, di, &messageWriterConf{
 		workerCount:  config.GetInt(coreconfig.MessageWriterCount),
 		batchTimeout: config.GetDuration(coreconfig.MessageWriterBatchTimeout),
@@ -148,6 +169,11 @@ func (dm *dataManager) BlobsEnabled() bool {
 	return dm.blobStore.exchange != nil
 }
 
+// ForgetMissingDatatypes discards the remembered lookups that found nothing, as a datatype has been stored
+func (dm *dataManager) ForgetMissingDatatypes() {
+	dm.missingCache.Forget()
+}
+
 func (dm *dataManager) CheckDatatype(ctx context.Context, datatype *core.Datatype) error {
 	_, err := newJSONValidator(ctx, dm.namespace.Name, datatype)
 	return err
@@ -168,12 +194,17 @@ func (dm *dataManager) getValidatorForDatatype(ctx context.Context, validator co
 	if cachedValue := dm.validatorCache.Get(key); cachedValue != nil {
 		return cachedValue.(Validator), nil
 	}
+	missing, missingEntry := dm.missingCache.IsMissing(key)
+	if missing {
+		return nil, nil
+	}
 
 	datatype, err := dm.database.GetDatatypeByName(ctx, dm.namespace.Name, datatypeRef.Name, datatypeRef.Version)
 	if err != nil {
 		return nil, err
 	}
 	if datatype == nil {
+		dm.missingCache.SetMissing(missingEntry)
 		return nil, nil
 	}
 	v, err := newJSONValidator(ctx, dm.namespace.Name, datatype)
@@ -294,6 +325,7 @@ func (dm *dataManager) UpdateMessageStateIfCached(ctx context.Context, id *fftyp
 		mce.msg.Confirmed = confirmed
 		mce.msg.RejectReason = rejectReason
 	}
+	cache.Changed(ctx, dm.messageCache, id.String())
 }
 
 func (dm *dataManager) getMessageData(ctx context.Context, msg *core.Message) (data core.DataArray, foundAll bool, err error) {
@@ -483,9 +515,18 @@ func (dm *dataManager) ResolveInlineData(ctx context.Context, newMessage *NewMes
 
 	}
 	newMessage.Message.Data = newMessage.AllData.Refs()
+	if dm.validator != nil {
+		return dm.validator(ctx, &newMessage.Message.Message, newMessage.AllData)
+	}
 	return nil
 }
 
+// SetMessageValidator sets a validator that each new message must pass, in addition to the validation
+// of its data. Must be called before any messages are sent.
+func (dm *dataManager) SetMessageValidator(validator MessageValidator) {
+	dm.validator = validator
+}
+
 // HydrateBatch fetches the full messages for a persisted batch, ready for transmission
 func (dm *dataManager) HydrateBatch(ctx context.Context, persistedBatch *core.BatchPersisted) (*core.Batch, error) {
 
@@ -496,6 +537,7 @@ func (dm *dataManager) HydrateBatch(ctx context.Context, persistedBatch *core.Ba
 	}
 
 	batch := persistedBatch.GenInflight(make([]*core.Message, len(manifest.Messages)), make(core.DataArray, len(manifest.Data)))
+	batch.Payload.Signature = manifest.Signature
 
 	for i, mr := range manifest.Messages {
 		m, err := dm.database.GetMessageByID(ctx, dm.namespace.Name, mr.ID)
@@ -521,22 +563,30 @@ func (dm *dataManager) HydrateBatch(ctx context.Context, persistedBatch *core.Ba
 // worker (or foreground if no DB concurrency) has written. The caller MUST NOT call this inside of a
 // DB RunAsGroup - because if a large number of routines enter the same function they could starve the background
 // worker of the spare connection required to execute (and thus deadlock).
-func (dm *dataManager) WriteNewMessage(ctx context.Context, newMsg *NewMessage) error {
+func (dm *dataManager) WriteNewMessage(ctx context.Context, newMsg *NewMessage) (err error) {
 
 	if newMsg.Message == nil {
 		return i18n.NewError(ctx, i18n.MsgNilOrNullObject)
 	}
 
+	if newMsg.Message.Trace {
+		ctx = tracing.WithTraceFlag(ctx, "message", newMsg.Message.Header.ID)
+	}
+	ctx, span := tracing.StartSpan(ctx, "data.WriteNewMessage", trace.WithAttributes(
+		attribute.String("firefly.message.id", newMsg.Message.Header.ID.String()),
+		attribute.String("firefly.message.type", string(newMsg.Message.Header.Type)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+	// The batch processor links its span back to this one, as it runs separately
+	tracing.Remember(ctx, newMsg.Message.Header.ID)
+	tracing.Logf(ctx, "Writing new %s message %s with %d data", newMsg.Message.Header.Type, newMsg.Message.Header.ID, len(newMsg.AllData))
+
 	// We add the message to the cache before we write it, because the batch aggregator might
 	// pick up our message from the message-writer before we return. The batch processor
 	// writes a more authoritative cache entry, with pings/batchID etc.
 	dm.UpdateMessageCache(&newMsg.Message.Message, newMsg.AllData)
 
-	err := dm.messageWriter.WriteNewMessage(ctx, newMsg)
-	if err != nil {
-		return err
-	}
-	return nil
+	return dm.messageWriter.WriteNewMessage(ctx, newMsg)
 }
 
 func (dm *dataManager) WaitStop() {
@@ -556,6 +606,11 @@ func (dm *dataManager) DeleteData(ctx context.Context, dataID string) error {
 	if data == nil {
 		return i18n.NewError(ctx, coremsgs.Msg404NoResult)
 	}
+	return dm.deleteData(ctx, data)
+}
+
+// deleteData deletes a data record along with its blob, if it has one
+func (dm *dataManager) deleteData(ctx context.Context, data *core.Data) error {
 	if data.Blob != nil && data.Blob.Hash != nil {
 		fb := database.BlobQueryFactory.NewFilter(ctx)
 		blobs, _, err := dm.database.GetBlobs(ctx, dm.namespace.Name, fb.And(fb.Eq("data_id", data.ID), fb.Eq("hash", data.Blob.Hash)))
@@ -579,6 +634,7 @@ func (dm *dataManager) DeleteData(ctx context.Context, dataID string) error {
 	}
 	for _, msg := range msgs {
 		dm.messageCache.Set(msg.Header.ID.String(), nil)
+		cache.Changed(ctx, dm.messageCache, msg.Header.ID.String())
 	}
 
 	return dm.database.DeleteData(ctx, data.Namespace, data.ID)
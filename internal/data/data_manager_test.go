@@ -485,6 +485,30 @@ func TestResolveInlineDataRefIDOnlyOK(t *testing.T) {
 	assert.Empty(t, newMsg.NewData)
 }
 
+func TestResolveInlineDataMessageValidatorFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	dataID, dataHash, newMsg := testNewMessage()
+
+	mdi.On("GetDataByID", ctx, "ns1", dataID, true).Return(&core.Data{
+		ID:        dataID,
+		Namespace: "ns1",
+		Hash:      dataHash,
+	}, nil)
+
+	dm.SetMessageValidator(func(ctx context.Context, msg *core.Message, data core.DataArray) error {
+		assert.Equal(t, newMsg.Message.Header.ID, msg.Header.ID)
+		assert.Len(t, msg.Data, 1)
+		assert.Equal(t, dataID, data[0].ID)
+		return fmt.Errorf("pop")
+	})
+
+	err := dm.ResolveInlineData(ctx, newMsg)
+	assert.Regexp(t, "pop", err)
+}
+
 func TestResolveInlineDataDataToPublish(t *testing.T) {
 	dm, ctx, cancel := newTestDataManager(t)
 	defer cancel()
@@ -715,6 +739,28 @@ func TestValidateAndStoreLoadValidatorUnknown(t *testing.T) {
 
 }
 
+func TestGetValidatorForDatatypeNegativeCaching(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "customer", "0.0.1").Return(nil, nil).Twice()
+
+	datatypeRef := &core.DatatypeRef{Name: "customer", Version: "0.0.1"}
+	for i := 0; i < 2; i++ {
+		v, err := dm.getValidatorForDatatype(ctx, core.ValidatorTypeJSON, datatypeRef)
+		assert.NoError(t, err)
+		assert.Nil(t, v)
+	}
+
+	dm.ForgetMissingDatatypes()
+	v, err := dm.getValidatorForDatatype(ctx, core.ValidatorTypeJSON, datatypeRef)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	mdi.AssertExpectations(t)
+}
+
 func TestValidateAndStoreLoadBadRef(t *testing.T) {
 
 	dm, ctx, cancel := newTestDataManager(t)
@@ -841,7 +887,7 @@ func TestHydrateBatchOK(t *testing.T) {
 			ID:        batchID,
 			Namespace: "ns1",
 		},
-		Manifest: fftypes.JSONAnyPtr(fmt.Sprintf(`{"id":"%s","messages":[{"id":"%s","hash":"%s"}],"data":[{"id":"%s","hash":"%s"}]}`,
+		Manifest: fftypes.JSONAnyPtr(fmt.Sprintf(`{"id":"%s","messages":[{"id":"%s","hash":"%s"}],"data":[{"id":"%s","hash":"%s"}],"signature":{"algorithm":"ed25519","publicKey":"pubkey","signature":"sig"}}`,
 			batchID, msgID, msgHash, dataID, dataHash,
 		)),
 		TX: core.TransactionRef{
@@ -868,6 +914,7 @@ func TestHydrateBatchOK(t *testing.T) {
 	assert.Equal(t, msgID, batch.Payload.Messages[0].Header.ID)
 	assert.Equal(t, msgHash, batch.Payload.Messages[0].Hash)
 	assert.Nil(t, batch.Payload.Messages[0].Confirmed)
+	assert.Equal(t, "sig", batch.Payload.Signature.Signature)
 	assert.Equal(t, dataID, batch.Payload.Data[0].ID)
 	assert.Equal(t, dataHash, batch.Payload.Data[0].Hash)
 	assert.Equal(t, dataHash, batch.Payload.Data[0].Hash)
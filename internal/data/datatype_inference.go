@@ -0,0 +1,159 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// inferredSchema accumulates the shape of every value observed at one position in the sample payloads
+type inferredSchema struct {
+	types      map[string]bool
+	objects    int
+	properties map[string]*inferredSchema
+	seen       map[string]int
+	items      *inferredSchema
+}
+
+func newInferredSchema() *inferredSchema {
+	return &inferredSchema{types: make(map[string]bool)}
+}
+
+func (is *inferredSchema) observe(value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		is.types["null"] = true
+	case bool:
+		is.types["boolean"] = true
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			is.types["number"] = true
+		} else {
+			is.types["integer"] = true
+		}
+	case string:
+		is.types["string"] = true
+	case []interface{}:
+		is.types["array"] = true
+		for _, item := range v {
+			if is.items == nil {
+				is.items = newInferredSchema()
+			}
+			is.items.observe(item)
+		}
+	case map[string]interface{}:
+		is.types["object"] = true
+		if is.properties == nil {
+			is.properties = make(map[string]*inferredSchema)
+			is.seen = make(map[string]int)
+		}
+		is.objects++
+		for name, propValue := range v {
+			prop, ok := is.properties[name]
+			if !ok {
+				prop = newInferredSchema()
+				is.properties[name] = prop
+			}
+			prop.observe(propValue)
+			is.seen[name]++
+		}
+	}
+}
+
+func (is *inferredSchema) toJSONSchema() map[string]interface{} {
+	if is.types["integer"] && is.types["number"] {
+		// Every integer is also a number
+		delete(is.types, "integer")
+	}
+	types := make([]string, 0, len(is.types))
+	for t := range is.types {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	schema := map[string]interface{}{}
+	if len(types) == 1 {
+		schema["type"] = types[0]
+	} else {
+		schema["type"] = types
+	}
+	if is.properties != nil {
+		properties := make(map[string]interface{}, len(is.properties))
+		required := []string{}
+		for name, prop := range is.properties {
+			properties[name] = prop.toJSONSchema()
+			if is.seen[name] == is.objects {
+				required = append(required, name)
+			}
+		}
+		sort.Strings(required)
+		schema["properties"] = properties
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+	}
+	if is.items != nil {
+		schema["items"] = is.items.toJSONSchema()
+	}
+	return schema
+}
+
+// InferDatatype builds a JSON schema datatype that accepts all of the supplied sample payloads.
+// Object fields are only marked as required when they appear in every sample.
+func (dm *dataManager) InferDatatype(ctx context.Context, input *core.DatatypeInference) (*core.Datatype, error) {
+	if len(input.Samples) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDatatypeInferenceNoSamples)
+	}
+
+	inferred := newInferredSchema()
+	for i, sample := range input.Samples {
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(sample.Bytes()))
+		decoder.UseNumber()
+		if sample.IsNil() || decoder.Decode(&value) != nil || decoder.More() {
+			return nil, i18n.NewError(ctx, coremsgs.MsgDatatypeInferenceBadSample, i)
+		}
+		inferred.observe(value)
+	}
+
+	schema := inferred.toJSONSchema()
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	if input.Name != "" {
+		schema["title"] = input.Name
+	}
+	schemaBytes, _ := json.Marshal(schema)
+	datatype := &core.Datatype{
+		Validator: core.ValidatorTypeJSON,
+		Name:      input.Name,
+		Version:   input.Version,
+		Value:     fftypes.JSONAnyPtrBytes(schemaBytes),
+	}
+
+	if err := dm.CheckDatatype(ctx, datatype); err != nil {
+		return nil, err
+	}
+	return datatype, nil
+}
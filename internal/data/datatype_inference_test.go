@@ -0,0 +1,104 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferDatatype(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	samples := []*fftypes.JSONAny{
+		fftypes.JSONAnyPtr(`{"id": 1, "name": "widget", "tags": ["a", "b"], "price": 1.5, "meta": {"active": true}}`),
+		fftypes.JSONAnyPtr(`{"id": 2, "name": null, "tags": [], "price": 2, "extra": "value"}`),
+	}
+	dt, err := dm.InferDatatype(ctx, &core.DatatypeInference{
+		Name:    "widget",
+		Version: "1.0.0",
+		Samples: samples,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ValidatorTypeJSON, dt.Validator)
+	assert.Equal(t, "widget", dt.Name)
+	assert.Equal(t, "1.0.0", dt.Version)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "widget",
+		"type": "object",
+		"properties": {
+			"extra": {"type": "string"},
+			"id": {"type": "integer"},
+			"meta": {
+				"type": "object",
+				"properties": {"active": {"type": "boolean"}},
+				"required": ["active"]
+			},
+			"name": {"type": ["null", "string"]},
+			"price": {"type": "number"},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		},
+		"required": ["id", "name", "price", "tags"]
+	}`, dt.Value.String())
+
+	jv, err := newJSONValidator(ctx, "ns1", dt)
+	assert.NoError(t, err)
+	for _, sample := range samples {
+		assert.NoError(t, jv.ValidateValue(ctx, sample, nil))
+	}
+	assert.Regexp(t, "FF10198", jv.ValidateValue(ctx, fftypes.JSONAnyPtr(`{"id": "one"}`), nil))
+}
+
+func TestInferDatatypeNoSamples(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	_, err := dm.InferDatatype(ctx, &core.DatatypeInference{Name: "widget", Version: "1.0.0"})
+	assert.Regexp(t, "FF10614", err)
+}
+
+func TestInferDatatypeBadSample(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	_, err := dm.InferDatatype(ctx, &core.DatatypeInference{
+		Name:    "widget",
+		Version: "1.0.0",
+		Samples: []*fftypes.JSONAny{
+			fftypes.JSONAnyPtr(`{"id": 1}`),
+			fftypes.JSONAnyPtr(`{"id": 1} {"id": 2}`),
+		},
+	})
+	assert.Regexp(t, "FF10615.*1", err)
+}
+
+func TestInferDatatypeBadName(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	_, err := dm.InferDatatype(ctx, &core.DatatypeInference{
+		Name:    "widget#1",
+		Version: "1.0.0",
+		Samples: []*fftypes.JSONAny{fftypes.JSONAnyPtr(`{"id": 1}`)},
+	})
+	assert.Regexp(t, "FF10196", err)
+}
@@ -0,0 +1,201 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func (dm *dataManager) CreateMessageRetentionPolicy(ctx context.Context, policy *core.MessageRetentionPolicy) (*core.MessageRetentionPolicy, error) {
+	if err := policy.Validate(ctx); err != nil {
+		return nil, err
+	}
+	if err := dm.checkMessageRetentionPolicyName(ctx, policy.Name, nil); err != nil {
+		return nil, err
+	}
+	policy.ID = fftypes.NewUUID()
+	policy.Namespace = dm.namespace.Name
+	policy.Checkpoint = 0
+	policy.Created = fftypes.Now()
+	policy.Updated = nil
+	if err := dm.database.InsertMessageRetentionPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdateMessageRetentionPolicy replaces the name, topic, tag and max age of a policy. If the topic or tag
+// changes, the messages the policy has already checked are checked again under the new scope.
+func (dm *dataManager) UpdateMessageRetentionPolicy(ctx context.Context, id string, policy *core.MessageRetentionPolicy) (*core.MessageRetentionPolicy, error) {
+	existing, err := dm.getMessageRetentionPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.Validate(ctx); err != nil {
+		return nil, err
+	}
+	if err := dm.checkMessageRetentionPolicyName(ctx, policy.Name, existing.ID); err != nil {
+		return nil, err
+	}
+	update := database.MessageRetentionPolicyQueryFactory.NewUpdate(ctx).
+		Set("name", policy.Name).
+		Set("topic", policy.Topic).
+		Set("tag", policy.Tag).
+		Set("maxage", policy.MaxAge.String())
+	if policy.Topic != existing.Topic || policy.Tag != existing.Tag {
+		update = update.Set("checkpoint", int64(0))
+	}
+	if err := dm.database.UpdateMessageRetentionPolicy(ctx, dm.namespace.Name, existing.ID, update); err != nil {
+		return nil, err
+	}
+	return dm.database.GetMessageRetentionPolicyByID(ctx, dm.namespace.Name, existing.ID)
+}
+
+func (dm *dataManager) DeleteMessageRetentionPolicy(ctx context.Context, id string) error {
+	existing, err := dm.getMessageRetentionPolicy(ctx, id)
+	if err != nil {
+		return err
+	}
+	return dm.database.DeleteMessageRetentionPolicy(ctx, dm.namespace.Name, existing.ID)
+}
+
+func (dm *dataManager) GetMessageRetentionPolicyByID(ctx context.Context, id string) (*core.MessageRetentionPolicy, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dm.database.GetMessageRetentionPolicyByID(ctx, dm.namespace.Name, u)
+}
+
+func (dm *dataManager) GetMessageRetentionPolicies(ctx context.Context, filter ffapi.AndFilter) ([]*core.MessageRetentionPolicy, *ffapi.FilterResult, error) {
+	return dm.database.GetMessageRetentionPolicies(ctx, dm.namespace.Name, filter)
+}
+
+func (dm *dataManager) getMessageRetentionPolicy(ctx context.Context, id string) (*core.MessageRetentionPolicy, error) {
+	policy, err := dm.GetMessageRetentionPolicyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMessageRetentionPolicyNotFound, id)
+	}
+	return policy, nil
+}
+
+// checkMessageRetentionPolicyName rejects a name that is already used by another policy in the namespace
+func (dm *dataManager) checkMessageRetentionPolicyName(ctx context.Context, name string, id *fftypes.UUID) error {
+	fb := database.MessageRetentionPolicyQueryFactory.NewFilter(ctx)
+	policies, _, err := dm.database.GetMessageRetentionPolicies(ctx, dm.namespace.Name, fb.And(fb.Eq("name", name)))
+	if err != nil {
+		return err
+	}
+	for _, p := range policies {
+		if !p.ID.Equals(id) {
+			return i18n.NewError(ctx, coremsgs.MsgMessageRetentionPolicyExists, name)
+		}
+	}
+	return nil
+}
+
+// PruneMessageData deletes the data of the confirmed messages matching a policy that were created more than
+// the max age of the policy ago. Messages are checked in order of their local sequence, with the position
+// stored as the checkpoint of the policy after each page, so each message is only checked once.
+// The checks stop at the first matching message that is not yet confirmed, as it could still be confirmed
+// later - including messages held in quarantine. Data that is shared with other messages is kept.
+func (dm *dataManager) PruneMessageData(ctx context.Context, policy *core.MessageRetentionPolicy, limit int) (deleted int64, err error) {
+	before := fftypes.FFTime(time.Now().Add(-time.Duration(*policy.MaxAge)))
+	for {
+		fb := database.MessageQueryFactory.NewFilter(ctx)
+		conditions := []ffapi.Filter{
+			fb.Gt("sequence", policy.Checkpoint),
+			fb.Lt("created", &before),
+		}
+		if policy.Topic != "" {
+			conditions = append(conditions, fb.Contains("topics", policy.Topic))
+		}
+		if policy.Tag != "" {
+			conditions = append(conditions, fb.Eq("tag", policy.Tag))
+		}
+		msgs, _, err := dm.database.GetMessages(ctx, dm.namespace.Name, fb.And(conditions...).Sort("sequence").Limit(uint64(limit)))
+		if err != nil {
+			return deleted, err
+		}
+
+		checkpoint := policy.Checkpoint
+		blocked := false
+		for _, msg := range msgs {
+			if policy.Matches(msg) && msg.State != core.MessageStateRejected {
+				if msg.State != core.MessageStateConfirmed {
+					log.L(ctx).Debugf("Message retention policy '%s' waiting for message '%s' in state '%s'", policy.Name, msg.Header.ID, msg.State)
+					blocked = true
+					break
+				}
+				count, err := dm.pruneMessageData(ctx, msg)
+				deleted += count
+				if err != nil {
+					return deleted, err
+				}
+			}
+			checkpoint = msg.Sequence
+		}
+
+		if checkpoint != policy.Checkpoint {
+			update := database.MessageRetentionPolicyQueryFactory.NewUpdate(ctx).Set("checkpoint", checkpoint)
+			if err := dm.database.UpdateMessageRetentionPolicy(ctx, dm.namespace.Name, policy.ID, update); err != nil {
+				return deleted, err
+			}
+			policy.Checkpoint = checkpoint
+		}
+		if blocked || len(msgs) < limit {
+			return deleted, nil
+		}
+	}
+}
+
+func (dm *dataManager) pruneMessageData(ctx context.Context, msg *core.Message) (deleted int64, err error) {
+	for _, ref := range msg.Data {
+		data, err := dm.database.GetDataByID(ctx, dm.namespace.Name, ref.ID, false)
+		if err != nil {
+			return deleted, err
+		}
+		if data == nil {
+			continue // already deleted
+		}
+		msgs, _, err := dm.database.GetMessagesForData(ctx, dm.namespace.Name, data.ID, database.MessageQueryFactory.NewFilter(ctx).And())
+		if err != nil {
+			return deleted, err
+		}
+		if len(msgs) > 1 {
+			log.L(ctx).Debugf("Keeping data '%s' of message '%s', as it is shared with other messages", data.ID, msg.Header.ID)
+			continue
+		}
+		if err := dm.deleteData(ctx, data); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
@@ -0,0 +1,448 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestRetentionPolicy() *core.MessageRetentionPolicy {
+	maxAge := fftypes.FFDuration(24 * time.Hour)
+	return &core.MessageRetentionPolicy{
+		ID:     fftypes.NewUUID(),
+		Name:   "heartbeats",
+		Topic:  "heartbeat",
+		MaxAge: &maxAge,
+	}
+}
+
+func newTestRetentionMessage(seq int64, state core.MessageState, dataIDs ...*fftypes.UUID) *core.Message {
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Topics: fftypes.FFStringArray{"heartbeat"},
+		},
+		State:    state,
+		Sequence: seq,
+	}
+	for _, id := range dataIDs {
+		msg.Data = append(msg.Data, &core.DataRef{ID: id})
+	}
+	return msg
+}
+
+func TestCreateMessageRetentionPolicy(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return([]*core.MessageRetentionPolicy{}, nil, nil)
+	mdi.On("InsertMessageRetentionPolicy", ctx, mock.MatchedBy(func(p *core.MessageRetentionPolicy) bool {
+		return p.ID != nil && p.Namespace == "ns1" && p.Created != nil && p.Checkpoint == 0
+	})).Return(nil)
+
+	policy := newTestRetentionPolicy()
+	policy.Checkpoint = 100
+	created, err := dm.CreateMessageRetentionPolicy(ctx, policy)
+	assert.NoError(t, err)
+	assert.Equal(t, "heartbeats", created.Name)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateMessageRetentionPolicyInvalid(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	policy := newTestRetentionPolicy()
+	policy.Topic = ""
+	_, err := dm.CreateMessageRetentionPolicy(ctx, policy)
+	assert.Regexp(t, "FF10641", err)
+}
+
+func TestCreateMessageRetentionPolicyDuplicateName(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return([]*core.MessageRetentionPolicy{
+		{ID: fftypes.NewUUID(), Name: "heartbeats"},
+	}, nil, nil)
+
+	_, err := dm.CreateMessageRetentionPolicy(ctx, newTestRetentionPolicy())
+	assert.Regexp(t, "FF10643", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateMessageRetentionPolicyNameCheckFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := dm.CreateMessageRetentionPolicy(ctx, newTestRetentionPolicy())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateMessageRetentionPolicyInsertFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return([]*core.MessageRetentionPolicy{}, nil, nil)
+	mdi.On("InsertMessageRetentionPolicy", ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := dm.CreateMessageRetentionPolicy(ctx, newTestRetentionPolicy())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUpdateMessageRetentionPolicyResetsCheckpoint(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	existing := newTestRetentionPolicy()
+	existing.Checkpoint = 100
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", existing.ID).Return(existing, nil)
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return([]*core.MessageRetentionPolicy{existing}, nil, nil)
+	mdi.On("UpdateMessageRetentionPolicy", ctx, "ns1", existing.ID, mock.MatchedBy(func(u ffapi.Update) bool {
+		info, _ := u.Finalize()
+		for _, su := range info.SetOperations {
+			if su.Field == "checkpoint" {
+				return true
+			}
+		}
+		return false
+	})).Return(nil)
+
+	policy := newTestRetentionPolicy()
+	policy.Topic = "ping"
+	_, err := dm.UpdateMessageRetentionPolicy(ctx, existing.ID.String(), policy)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUpdateMessageRetentionPolicyKeepsCheckpoint(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	existing := newTestRetentionPolicy()
+	existing.Checkpoint = 100
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", existing.ID).Return(existing, nil)
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return([]*core.MessageRetentionPolicy{}, nil, nil)
+	mdi.On("UpdateMessageRetentionPolicy", ctx, "ns1", existing.ID, mock.MatchedBy(func(u ffapi.Update) bool {
+		info, _ := u.Finalize()
+		for _, su := range info.SetOperations {
+			if su.Field == "checkpoint" {
+				return false
+			}
+		}
+		return true
+	})).Return(nil)
+
+	policy := newTestRetentionPolicy()
+	policy.Name = "renamed"
+	_, err := dm.UpdateMessageRetentionPolicy(ctx, existing.ID.String(), policy)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUpdateMessageRetentionPolicyNotFound(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	id := fftypes.NewUUID()
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", id).Return(nil, nil)
+
+	_, err := dm.UpdateMessageRetentionPolicy(ctx, id.String(), newTestRetentionPolicy())
+	assert.Regexp(t, "FF10640", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUpdateMessageRetentionPolicyBadID(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	_, err := dm.UpdateMessageRetentionPolicy(ctx, "bad", newTestRetentionPolicy())
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestUpdateMessageRetentionPolicyLookupFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	id := fftypes.NewUUID()
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", id).Return(nil, fmt.Errorf("pop"))
+
+	_, err := dm.UpdateMessageRetentionPolicy(ctx, id.String(), newTestRetentionPolicy())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUpdateMessageRetentionPolicyInvalid(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	existing := newTestRetentionPolicy()
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", existing.ID).Return(existing, nil)
+
+	policy := newTestRetentionPolicy()
+	policy.MaxAge = nil
+	_, err := dm.UpdateMessageRetentionPolicy(ctx, existing.ID.String(), policy)
+	assert.Regexp(t, "FF10642", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUpdateMessageRetentionPolicyDuplicateName(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	existing := newTestRetentionPolicy()
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", existing.ID).Return(existing, nil)
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return([]*core.MessageRetentionPolicy{
+		{ID: fftypes.NewUUID(), Name: "other"},
+	}, nil, nil)
+
+	policy := newTestRetentionPolicy()
+	policy.Name = "other"
+	_, err := dm.UpdateMessageRetentionPolicy(ctx, existing.ID.String(), policy)
+	assert.Regexp(t, "FF10643", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUpdateMessageRetentionPolicyUpdateFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	existing := newTestRetentionPolicy()
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", existing.ID).Return(existing, nil)
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return([]*core.MessageRetentionPolicy{}, nil, nil)
+	mdi.On("UpdateMessageRetentionPolicy", ctx, "ns1", existing.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := dm.UpdateMessageRetentionPolicy(ctx, existing.ID.String(), newTestRetentionPolicy())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDeleteMessageRetentionPolicy(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	existing := newTestRetentionPolicy()
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", existing.ID).Return(existing, nil)
+	mdi.On("DeleteMessageRetentionPolicy", ctx, "ns1", existing.ID).Return(nil)
+
+	err := dm.DeleteMessageRetentionPolicy(ctx, existing.ID.String())
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDeleteMessageRetentionPolicyNotFound(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	id := fftypes.NewUUID()
+	mdi.On("GetMessageRetentionPolicyByID", ctx, "ns1", id).Return(nil, nil)
+
+	err := dm.DeleteMessageRetentionPolicy(ctx, id.String())
+	assert.Regexp(t, "FF10640", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetMessageRetentionPolicies(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetMessageRetentionPolicies", ctx, "ns1", mock.Anything).Return([]*core.MessageRetentionPolicy{}, nil, nil)
+
+	fb := database.MessageRetentionPolicyQueryFactory.NewFilter(ctx)
+	_, _, err := dm.GetMessageRetentionPolicies(ctx, fb.And(fb.Eq("topic", "heartbeat")))
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPruneMessageData(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	policy := newTestRetentionPolicy()
+	data1 := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	data2 := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	missing := fftypes.NewUUID()
+	msg1 := newTestRetentionMessage(1, core.MessageStateConfirmed, data1.ID, data2.ID, missing)
+	msg2 := newTestRetentionMessage(2, core.MessageStateRejected)
+	msg3 := newTestRetentionMessage(3, core.MessageStateConfirmed)
+	msg3.Header.Topics = fftypes.FFStringArray{"heartbeats"}
+
+	mdi.On("GetMessages", ctx, "ns1", mock.Anything).Return([]*core.Message{msg1, msg2, msg3}, nil, nil).Once()
+	mdi.On("GetMessages", ctx, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil).Once()
+	mdi.On("GetDataByID", ctx, "ns1", data1.ID, false).Return(data1, nil)
+	mdi.On("GetDataByID", ctx, "ns1", data2.ID, false).Return(data2, nil)
+	mdi.On("GetDataByID", ctx, "ns1", missing, false).Return(nil, nil)
+	mdi.On("GetMessagesForData", ctx, "ns1", data1.ID, mock.Anything).Return([]*core.Message{msg1}, nil, nil)
+	mdi.On("GetMessagesForData", ctx, "ns1", data2.ID, mock.Anything).Return([]*core.Message{msg1, msg3}, nil, nil)
+	mdi.On("DeleteData", ctx, "ns1", data1.ID).Return(nil)
+	mdi.On("UpdateMessageRetentionPolicy", ctx, "ns1", policy.ID, mock.Anything).Return(nil)
+
+	deleted, err := dm.PruneMessageData(ctx, policy, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	assert.Equal(t, int64(3), policy.Checkpoint)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPruneMessageDataWaitsForPending(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	policy := newTestRetentionPolicy()
+	policy.Checkpoint = 10
+	msg := newTestRetentionMessage(11, core.MessageStateQuarantined, fftypes.NewUUID())
+
+	mdi.On("GetMessages", ctx, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil).Once()
+
+	deleted, err := dm.PruneMessageData(ctx, policy, 1)
+	assert.NoError(t, err)
+	assert.Zero(t, deleted)
+	assert.Equal(t, int64(10), policy.Checkpoint)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPruneMessageDataQueryFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	policy := newTestRetentionPolicy()
+	policy.Tag = "beat"
+	mdi.On("GetMessages", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := dm.PruneMessageData(ctx, policy, 10)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPruneMessageDataGetDataFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	policy := newTestRetentionPolicy()
+	dataID := fftypes.NewUUID()
+	msg := newTestRetentionMessage(1, core.MessageStateConfirmed, dataID)
+	mdi.On("GetMessages", ctx, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("GetDataByID", ctx, "ns1", dataID, false).Return(nil, fmt.Errorf("pop"))
+
+	_, err := dm.PruneMessageData(ctx, policy, 10)
+	assert.EqualError(t, err, "pop")
+	assert.Zero(t, policy.Checkpoint)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPruneMessageDataGetMessagesForDataFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	policy := newTestRetentionPolicy()
+	data := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	msg := newTestRetentionMessage(1, core.MessageStateConfirmed, data.ID)
+	mdi.On("GetMessages", ctx, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("GetDataByID", ctx, "ns1", data.ID, false).Return(data, nil)
+	mdi.On("GetMessagesForData", ctx, "ns1", data.ID, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := dm.PruneMessageData(ctx, policy, 10)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPruneMessageDataDeleteFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	policy := newTestRetentionPolicy()
+	data := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	msg := newTestRetentionMessage(1, core.MessageStateConfirmed, data.ID)
+	mdi.On("GetMessages", ctx, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("GetDataByID", ctx, "ns1", data.ID, false).Return(data, nil)
+	mdi.On("GetMessagesForData", ctx, "ns1", data.ID, mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("DeleteData", ctx, "ns1", data.ID).Return(fmt.Errorf("pop"))
+
+	_, err := dm.PruneMessageData(ctx, policy, 10)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPruneMessageDataCheckpointFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	policy := newTestRetentionPolicy()
+	msg := newTestRetentionMessage(1, core.MessageStateConfirmed)
+	mdi.On("GetMessages", ctx, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("UpdateMessageRetentionPolicy", ctx, "ns1", policy.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := dm.PruneMessageData(ctx, policy, 10)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
@@ -21,6 +21,23 @@ import (
 	"github.com/hyperledger/firefly/internal/database/sqlcommon"
 )
 
+const (
+	// PartitioningEnabled converts the configured tables to partitioned tables, and maintains their partitions
+	PartitioningEnabled = "partitioning.enabled"
+	// PartitioningStrategy how tables are split into partitions - "sequence" or "month"
+	PartitioningStrategy = "partitioning.strategy"
+	// PartitioningTables the tables to partition
+	PartitioningTables = "partitioning.tables"
+	// PartitioningSequenceRange the number of sequence values in each partition, when partitioning by sequence
+	PartitioningSequenceRange = "partitioning.sequenceRange"
+	// PartitioningPremake the number of partitions to create ahead of the one currently being written
+	PartitioningPremake = "partitioning.premake"
+	// PartitioningRetain the number of complete partitions to keep before the current one. Older partitions are dropped. Zero keeps all partitions
+	PartitioningRetain = "partitioning.retain"
+	// PartitioningCheckInterval how often partitions are created and pruned
+	PartitioningCheckInterval = "partitioning.checkInterval"
+)
+
 const (
 	defaultConnectionLimitPostgreSQL = 50
 )
@@ -28,4 +45,11 @@ const (
 func (psql *Postgres) InitConfig(config config.Section) {
 	psql.SQLCommon.InitConfig(psql, config)
 	config.SetDefault(sqlcommon.SQLConfMaxConnections, defaultConnectionLimitPostgreSQL)
+	config.AddKnownKey(PartitioningEnabled, false)
+	config.AddKnownKey(PartitioningStrategy, string(partitionByMonth))
+	config.AddKnownKey(PartitioningTables, []string{"messages", "events", "pins", "blockchainevents"})
+	config.AddKnownKey(PartitioningSequenceRange, 10000000)
+	config.AddKnownKey(PartitioningPremake, 2)
+	config.AddKnownKey(PartitioningRetain, 0)
+	config.AddKnownKey(PartitioningCheckInterval, "1h")
 }
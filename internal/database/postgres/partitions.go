@@ -0,0 +1,500 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+type partitionStrategy string
+
+const (
+	partitionBySequence partitionStrategy = "sequence"
+	partitionByMonth    partitionStrategy = "month"
+)
+
+// monthColumns is the time column each collection that supports partitioning is split on, when partitioning by month.
+// Times are stored as nanoseconds since the epoch.
+var monthColumns = map[string]string{
+	"messages":         "created",
+	"events":           "created",
+	"pins":             "created",
+	"blockchainevents": "timestamp",
+}
+
+// pendingRowsQueries check whether a partition still holds rows that are waiting to be processed
+var pendingRowsQueries = map[string]string{
+	"messages": "SELECT EXISTS (SELECT 1 FROM %s WHERE state IN ('staged', 'ready', 'sent', 'pending'))",
+	"pins":     "SELECT EXISTS (SELECT 1 FROM %s WHERE dispatched = false)",
+	"events": `SELECT EXISTS (SELECT 1 FROM %s WHERE seq > (SELECT COALESCE(MIN(current), 9223372036854775807) FROM offsets
+		WHERE otype IN ('subscription', 'eventbridge', 'messagetrigger', 'search', 'traffic')))`,
+}
+
+// unarchivedRowsQuery checks whether a partition holds rows of any namespace above the archive offset of the table
+const unarchivedRowsQuery = `SELECT EXISTS (SELECT 1 FROM %s p
+	LEFT JOIN offsets o ON o.otype = 'archive' AND o.name = p.namespace || ':%s'
+	WHERE o.current IS NULL OR p.seq > o.current)`
+
+var upperBoundRegex = regexp.MustCompile(`TO \('?(-?\d+)'?\)`)
+
+// PartitionPruneHook is called before a partition that has aged out of the retention window is dropped.
+// Returning false keeps the partition, so it can be considered again on the next check.
+type PartitionPruneHook func(ctx context.Context, table, partition string) bool
+
+type partitionManager struct {
+	db            *sql.DB
+	strategy      partitionStrategy
+	tables        []string
+	sequenceRange int64
+	premake       int
+	retain        int
+	checkInterval time.Duration
+	archived      map[string]bool
+	pruneHook     PartitionPruneHook
+	now           func() time.Time
+}
+
+// partitionRange is a half-open range [from,to) of the partition key
+type partitionRange struct {
+	name string
+	from int64
+	to   int64
+}
+
+func newPartitionManager(ctx context.Context, db *sql.DB, conf config.Section) (*partitionManager, error) {
+	pm := &partitionManager{
+		db:            db,
+		strategy:      partitionStrategy(conf.GetString(PartitioningStrategy)),
+		tables:        conf.GetStringSlice(PartitioningTables),
+		sequenceRange: conf.GetInt64(PartitioningSequenceRange),
+		premake:       conf.GetInt(PartitioningPremake),
+		retain:        conf.GetInt(PartitioningRetain),
+		checkInterval: conf.GetDuration(PartitioningCheckInterval),
+		archived:      make(map[string]bool),
+		now:           time.Now,
+	}
+	pm.pruneHook = pm.canPrune
+	if config.GetBool(coreconfig.RetentionArchiveEnabled) {
+		for _, c := range config.GetStringSlice(coreconfig.RetentionArchiveCollections) {
+			pm.archived[c] = true
+		}
+	}
+	switch pm.strategy {
+	case partitionBySequence:
+		if pm.sequenceRange <= 0 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidPartitionConfig, "sequenceRange must be greater than zero")
+		}
+	case partitionByMonth:
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidPartitionConfig, fmt.Sprintf("unknown strategy '%s'", pm.strategy))
+	}
+	for _, table := range pm.tables {
+		if _, ok := monthColumns[table]; !ok {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidPartitionConfig, fmt.Sprintf("table '%s' cannot be partitioned", table))
+		}
+	}
+	if pm.premake < 1 {
+		pm.premake = 1
+	}
+	return pm, nil
+}
+
+func (pm *partitionManager) partitionColumn(table string) string {
+	if pm.strategy == partitionBySequence {
+		return "seq"
+	}
+	return monthColumns[table]
+}
+
+// rangeFor returns the partition that contains the supplied value of the partition key
+func (pm *partitionManager) rangeFor(table string, value int64) *partitionRange {
+	if pm.strategy == partitionBySequence {
+		index := value / pm.sequenceRange
+		return &partitionRange{
+			name: fmt.Sprintf("%s_s%06d", table, index),
+			from: index * pm.sequenceRange,
+			to:   (index + 1) * pm.sequenceRange,
+		}
+	}
+	t := time.Unix(0, value).UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return &partitionRange{
+		name: fmt.Sprintf("%s_m%s", table, start.Format("200601")),
+		from: start.UnixNano(),
+		to:   start.AddDate(0, 1, 0).UnixNano(),
+	}
+}
+
+// currentValue is the value of the partition key new rows are being written at
+func (pm *partitionManager) currentValue(ctx context.Context, table string) (int64, error) {
+	if pm.strategy == partitionByMonth {
+		return pm.now().UnixNano(), nil
+	}
+	var max int64
+	err := pm.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COALESCE(MAX(seq), 0) FROM %s", table)).Scan(&max)
+	return max, err
+}
+
+func (pm *partitionManager) start(ctx context.Context) error {
+	if err := pm.maintain(ctx); err != nil {
+		return err
+	}
+	go pm.maintainLoop(ctx)
+	return nil
+}
+
+func (pm *partitionManager) maintainLoop(ctx context.Context) {
+	for {
+		select {
+		case <-time.After(pm.checkInterval):
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Partition maintenance exiting")
+			return
+		}
+		if err := pm.maintain(ctx); err != nil {
+			log.L(ctx).Errorf("Partition maintenance failed: %s", err)
+		}
+	}
+}
+
+// maintain converts any configured table that is not yet partitioned, creates the partitions
+// that will be needed next, and drops partitions that have aged out of the retention window
+func (pm *partitionManager) maintain(ctx context.Context) error {
+	for _, table := range pm.tables {
+		if err := pm.ensurePartitioned(ctx, table); err != nil {
+			return err
+		}
+		partitions, err := pm.createPartitions(ctx, table)
+		if err != nil {
+			return err
+		}
+		if err := pm.prunePartitions(ctx, table, partitions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensurePartitioned converts a plain table into a partitioned table. The existing table becomes the
+// "legacy" partition, holding all rows up to the end of the range containing the current partition key.
+// Indexes are created on the partitioned table, so they apply to every partition - see uniqueIndexStatements
+// for how unique indexes are kept.
+func (pm *partitionManager) ensurePartitioned(ctx context.Context, table string) error {
+	tx, err := pm.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SELECT pg_advisory_xact_lock(%d)", lockIndex("partition_"+table))); err != nil {
+		return err
+	}
+	var relkind string
+	if err := tx.QueryRowContext(ctx, "SELECT relkind FROM pg_class WHERE relname = $1 AND pg_table_is_visible(oid)", table).Scan(&relkind); err != nil {
+		return err
+	}
+	if relkind == "p" {
+		return nil
+	}
+
+	column := pm.partitionColumn(table)
+	var current int64
+	if pm.strategy == partitionByMonth {
+		current = pm.now().UnixNano()
+	}
+	var max int64
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT COALESCE(MAX(%s), 0) FROM %s", column, table)).Scan(&max); err != nil {
+		return err
+	}
+	if max > current {
+		current = max
+	}
+	legacy := table + "_legacy"
+	bound := pm.rangeFor(table, current).to
+	log.L(ctx).Infof("Converting table '%s' to be partitioned by %s, with existing rows in partition '%s'", table, pm.strategy, legacy)
+
+	indexes, err := pm.indexes(ctx, tx, legacy, table)
+	if err != nil {
+		return err
+	}
+	statements := []string{
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", table, legacy),
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS) PARTITION BY RANGE (%s)", table, legacy, column),
+		fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM (MINVALUE) TO (%d)", table, legacy, bound),
+	}
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		def := indexes[name]
+		if def.unique {
+			statements = append(statements, uniqueIndexStatements(table, column, name, def)...)
+		} else {
+			// Created on the parent, which attaches the matching index that already exists on the legacy partition
+			statements = append(statements, fmt.Sprintf("CREATE INDEX %s_p ON %s %s", name, table, def.using))
+		}
+	}
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+type indexDef struct {
+	unique  bool
+	using   string
+	method  string
+	columns []string
+	where   string
+}
+
+// parseIndexDef splits the "USING method (columns) WHERE predicate" clause of an index definition
+func parseIndexDef(unique bool, using string) *indexDef {
+	def := &indexDef{unique: unique, using: using}
+	open := strings.Index(using, "(")
+	if open < 0 {
+		return def
+	}
+	def.method = strings.TrimSpace(using[:open])
+	depth := 0
+	for i := open; i < len(using); i++ {
+		switch using[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 {
+			for _, c := range strings.Split(using[open+1:i], ",") {
+				def.columns = append(def.columns, strings.TrimSpace(c))
+			}
+			if whereIdx := strings.Index(using[i:], " WHERE "); whereIdx >= 0 {
+				def.where = strings.TrimSpace(using[i+whereIdx+len(" WHERE "):])
+			}
+			break
+		}
+	}
+	return def
+}
+
+// uniqueIndexStatements recreate a unique index on the partitioned table. PostgreSQL requires unique indexes
+// on a partitioned table to include the partition key, so it is added to the index where missing. The index
+// then no longer stops a duplicate key being inserted with a different partition key - such as a message
+// with the same idempotency key, that is created later - so a trigger checks for an existing row with the
+// key across all partitions, and skips the insert as a conflict. Rows are not updated to change their keys.
+// The sequence column needs no check, as it is generated.
+func uniqueIndexStatements(table, column, name string, def *indexDef) []string {
+	for _, c := range def.columns {
+		if c == column {
+			return []string{fmt.Sprintf("CREATE UNIQUE INDEX %s_p ON %s %s", name, table, def.using)}
+		}
+	}
+	where := ""
+	predicate := "TRUE"
+	if def.where != "" {
+		where = " WHERE " + def.where
+		predicate = def.where
+	}
+	statements := []string{
+		fmt.Sprintf("CREATE UNIQUE INDEX %s_p ON %s %s (%s, %s)%s", name, table, def.method, strings.Join(def.columns, ", "), column, where),
+	}
+	if len(def.columns) == 1 && def.columns[0] == "seq" {
+		return statements
+	}
+	newColumns := make([]string, len(def.columns))
+	for i, c := range def.columns {
+		newColumns[i] = "NEW." + c
+	}
+	keys := strings.Join(def.columns, ", ")
+	newKeys := strings.Join(newColumns, ", ")
+	return append(statements,
+		fmt.Sprintf(`CREATE FUNCTION %s_guard() RETURNS trigger AS $$ BEGIN
+	IF EXISTS (SELECT 1 FROM (SELECT (NEW).*) n WHERE %s) THEN
+		PERFORM pg_advisory_xact_lock(hashtextextended('%s:' || ROW(%s)::text, 0));
+		IF EXISTS (SELECT 1 FROM %s WHERE (%s) = (%s) AND %s) THEN
+			RETURN NULL;
+		END IF;
+	END IF;
+	RETURN NEW;
+END; $$ LANGUAGE plpgsql`, name, predicate, name, newKeys, table, keys, newKeys, predicate),
+		fmt.Sprintf("CREATE TRIGGER %s_guard BEFORE INSERT ON %s FOR EACH ROW EXECUTE FUNCTION %s_guard()", name, table, name),
+	)
+}
+
+// indexes reads the indexes of a table, as the "USING ..." clause that can be applied to another table.
+// The table might have been renamed since, hence the index definitions are matched on either name.
+func (pm *partitionManager) indexes(ctx context.Context, tx *sql.Tx, tableNames ...string) (map[string]*indexDef, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT indexname, indexdef FROM pg_indexes WHERE schemaname = current_schema() AND tablename = ANY($1)", "{"+strings.Join(tableNames, ",")+"}")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	indexes := make(map[string]*indexDef)
+	for rows.Next() {
+		var name, def string
+		if err := rows.Scan(&name, &def); err != nil {
+			return nil, err
+		}
+		if usingIdx := strings.Index(def, " USING "); usingIdx >= 0 {
+			indexes[name] = parseIndexDef(strings.HasPrefix(def, "CREATE UNIQUE INDEX"), def[usingIdx+1:])
+		}
+	}
+	return indexes, rows.Err()
+}
+
+// createPartitions makes sure partitions exist for the range currently being written, and the configured
+// number of ranges after it. Returns the ranges of the partitions that exist, sorted oldest first.
+func (pm *partitionManager) createPartitions(ctx context.Context, table string) ([]*partitionRange, error) {
+	existing, err := pm.partitions(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	current, err := pm.currentValue(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var upper int64
+	byName := make(map[string]bool, len(existing))
+	for _, p := range existing {
+		byName[p.name] = true
+		if p.to > upper {
+			upper = p.to
+		}
+	}
+
+	r := pm.rangeFor(table, current)
+	for i := 0; i <= pm.premake; i++ {
+		if !byName[r.name] && r.from >= upper {
+			if err := pm.createPartition(ctx, table, r); err != nil {
+				return nil, err
+			}
+			existing = append(existing, r)
+			upper = r.to
+		}
+		r = pm.rangeFor(table, r.to)
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i].to < existing[j].to })
+	return existing, nil
+}
+
+// createPartition creates a partition, which gets the indexes and triggers of the partitioned table
+func (pm *partitionManager) createPartition(ctx context.Context, table string, r *partitionRange) error {
+	log.L(ctx).Infof("Creating partition '%s' of table '%s' for range [%d,%d)", r.name, table, r.from, r.to)
+	_, err := pm.db.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM (%d) TO (%d)", r.name, table, r.from, r.to))
+	return err
+}
+
+// partitions lists the partitions attached to a table, with the upper bound of each
+func (pm *partitionManager) partitions(ctx context.Context, table string) ([]*partitionRange, error) {
+	rows, err := pm.db.QueryContext(ctx, `SELECT c.relname, pg_get_expr(c.relpartbound, c.oid)
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = $1 AND pg_table_is_visible(p.oid)`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	partitions := []*partitionRange{}
+	for rows.Next() {
+		var name, bound string
+		if err := rows.Scan(&name, &bound); err != nil {
+			return nil, err
+		}
+		match := upperBoundRegex.FindStringSubmatch(bound)
+		if match == nil {
+			continue // not a partition we manage
+		}
+		to, _ := strconv.ParseInt(match[1], 10, 64)
+		partitions = append(partitions, &partitionRange{name: name, to: to})
+	}
+	return partitions, rows.Err()
+}
+
+// canPrune is the default prune hook, which keeps a partition while it holds rows that are still waiting to be
+// processed, or that are still to be archived when archiving is enabled for the table
+func (pm *partitionManager) canPrune(ctx context.Context, table, partition string) bool {
+	var queries []string
+	if q, ok := pendingRowsQueries[table]; ok {
+		queries = append(queries, fmt.Sprintf(q, partition))
+	}
+	if pm.archived[table] {
+		queries = append(queries, fmt.Sprintf(unarchivedRowsQuery, partition, table))
+	}
+	for _, q := range queries {
+		var pending bool
+		if err := pm.db.QueryRowContext(ctx, q).Scan(&pending); err != nil {
+			log.L(ctx).Errorf("Failed to check partition '%s' of table '%s' before pruning: %s", partition, table, err)
+			return false
+		}
+		if pending {
+			return false
+		}
+	}
+	return true
+}
+
+// prunePartitions drops partitions that only hold rows older than the retention window,
+// which is the configured number of complete partitions before the current one
+func (pm *partitionManager) prunePartitions(ctx context.Context, table string, partitions []*partitionRange) error {
+	if pm.retain <= 0 {
+		return nil
+	}
+	current, err := pm.currentValue(ctx, table)
+	if err != nil {
+		return err
+	}
+	cutoff := pm.rangeFor(table, current)
+	for i := 0; i < pm.retain; i++ {
+		cutoff = pm.rangeFor(table, cutoff.from-1)
+	}
+	for _, p := range partitions {
+		if p.to > cutoff.from {
+			continue
+		}
+		if !pm.pruneHook(ctx, table, p.name) {
+			log.L(ctx).Infof("Partition '%s' of table '%s' retained by prune hook", p.name, table)
+			continue
+		}
+		log.L(ctx).Infof("Dropping partition '%s' of table '%s'", p.name, table)
+		if _, err := pm.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", table, p.name)); err != nil {
+			return err
+		}
+		if _, err := pm.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE %s", p.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
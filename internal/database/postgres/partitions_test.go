@@ -0,0 +1,273 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPartitionManager(t *testing.T, strategy partitionStrategy) (*partitionManager, sqlmock.Sqlmock) {
+	conf := config.RootSection("unittest")
+	(&Postgres{}).InitConfig(conf)
+	conf.Set(PartitioningStrategy, string(strategy))
+	conf.Set(PartitioningTables, []string{"messages"})
+	conf.Set(PartitioningSequenceRange, 100)
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	pm, err := newPartitionManager(context.Background(), db, conf)
+	assert.NoError(t, err)
+	pm.now = func() time.Time { return time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC) }
+	return pm, mock
+}
+
+func TestNewPartitionManagerBadConfig(t *testing.T) {
+	conf := config.RootSection("unittest")
+	(&Postgres{}).InitConfig(conf)
+
+	conf.Set(PartitioningStrategy, "weekly")
+	_, err := newPartitionManager(context.Background(), nil, conf)
+	assert.Regexp(t, "FF10507.*weekly", err)
+
+	conf.Set(PartitioningStrategy, "sequence")
+	conf.Set(PartitioningSequenceRange, 0)
+	_, err = newPartitionManager(context.Background(), nil, conf)
+	assert.Regexp(t, "FF10507.*sequenceRange", err)
+
+	conf.Set(PartitioningStrategy, "month")
+	conf.Set(PartitioningTables, []string{"messages", "operations"})
+	_, err = newPartitionManager(context.Background(), nil, conf)
+	assert.Regexp(t, "FF10507.*operations", err)
+}
+
+func TestPartitionRanges(t *testing.T) {
+	pm, _ := newTestPartitionManager(t, partitionBySequence)
+	r := pm.rangeFor("messages", 250)
+	assert.Equal(t, &partitionRange{name: "messages_s000002", from: 200, to: 300}, r)
+	assert.Equal(t, "seq", pm.partitionColumn("blockchainevents"))
+
+	pm.strategy = partitionByMonth
+	r = pm.rangeFor("messages", pm.now().UnixNano())
+	assert.Equal(t, "messages_m202405", r.name)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC).UnixNano(), r.from)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).UnixNano(), r.to)
+	r = pm.rangeFor("events", time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC).UnixNano())
+	assert.Equal(t, "events_m202412", r.name)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano(), r.to)
+	assert.Equal(t, "timestamp", pm.partitionColumn("blockchainevents"))
+}
+
+func TestMaintainConvertAndCreate(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionBySequence)
+
+	indexRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"indexname", "indexdef"}).
+			AddRow("messages_pkey", "CREATE UNIQUE INDEX messages_pkey ON public.messages USING btree (seq)").
+			AddRow("messages_idempotency_keys", "CREATE UNIQUE INDEX messages_idempotency_keys ON public.messages USING btree (namespace, idempotency_key)").
+			AddRow("messages_created", "CREATE INDEX messages_created ON public.messages USING btree (created)")
+	}
+
+	// Conversion
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT relkind").WithArgs("messages").WillReturnRows(sqlmock.NewRows([]string{"relkind"}).AddRow("r"))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(seq\), 0\) FROM messages`).WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(15))
+	mock.ExpectQuery("SELECT indexname, indexdef FROM pg_indexes").WithArgs("{messages_legacy,messages}").WillReturnRows(indexRows())
+	mock.ExpectExec("ALTER TABLE messages RENAME TO messages_legacy").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE messages \(LIKE messages_legacy INCLUDING DEFAULTS INCLUDING CONSTRAINTS\) PARTITION BY RANGE \(seq\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE messages ATTACH PARTITION messages_legacy FOR VALUES FROM \(MINVALUE\) TO \(100\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX messages_created_p ON messages USING btree \(created\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE UNIQUE INDEX messages_idempotency_keys_p ON messages USING btree \(namespace, idempotency_key, seq\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE FUNCTION messages_idempotency_keys_guard\(\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TRIGGER messages_idempotency_keys_guard BEFORE INSERT ON messages`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE UNIQUE INDEX messages_pkey_p ON messages USING btree \(seq\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// Partition creation - the current range is covered by the legacy partition
+	mock.ExpectQuery("SELECT c.relname").WithArgs("messages").WillReturnRows(sqlmock.NewRows([]string{"relname", "bound"}).
+		AddRow("messages_legacy", "FOR VALUES FROM (MINVALUE) TO ('100')"))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(seq\), 0\) FROM messages`).WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(15))
+	for _, p := range []struct {
+		name     string
+		from, to int
+	}{{"messages_s000001", 100, 200}, {"messages_s000002", 200, 300}} {
+		mock.ExpectExec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF messages FOR VALUES FROM \(%d\) TO \(%d\)`, p.name, p.from, p.to)).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	err := pm.maintain(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintainAlreadyPartitioned(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionByMonth)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT relkind").WillReturnRows(sqlmock.NewRows([]string{"relkind"}).AddRow("p"))
+	mock.ExpectRollback()
+	mock.ExpectQuery("SELECT c.relname").WillReturnRows(sqlmock.NewRows([]string{"relname", "bound"}).
+		AddRow("messages_legacy", "FOR VALUES FROM (MINVALUE) TO ('1714521600000000000')").
+		AddRow("messages_m202406", "FOR VALUES FROM ('1717200000000000000') TO ('1719792000000000000')").
+		AddRow("messages_m202407", "FOR VALUES FROM ('1719792000000000000') TO ('1722470400000000000')").
+		AddRow("messages_default", "DEFAULT"))
+
+	err := pm.maintain(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintainConvertFail(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionByMonth)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT relkind").WillReturnRows(sqlmock.NewRows([]string{"relkind"}).AddRow("r"))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(created\), 0\) FROM messages`).WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(0))
+	mock.ExpectQuery("SELECT indexname, indexdef FROM pg_indexes").WillReturnRows(sqlmock.NewRows([]string{"indexname", "indexdef"}))
+	mock.ExpectExec("ALTER TABLE messages RENAME TO messages_legacy").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+
+	err := pm.start(context.Background())
+	assert.EqualError(t, err, "pop")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintainBeginFail(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionByMonth)
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := pm.maintain(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestMaintainListPartitionsFail(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionByMonth)
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT relkind").WillReturnRows(sqlmock.NewRows([]string{"relkind"}).AddRow("p"))
+	mock.ExpectRollback()
+	mock.ExpectQuery("SELECT c.relname").WillReturnError(fmt.Errorf("pop"))
+	err := pm.maintain(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCreatePartitionFail(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionByMonth)
+	mock.ExpectQuery("SELECT c.relname").WillReturnRows(sqlmock.NewRows([]string{"relname", "bound"}))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS messages_m202405").WillReturnError(fmt.Errorf("pop"))
+	_, err := pm.createPartitions(context.Background(), "messages")
+	assert.EqualError(t, err, "pop")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPrunePartitions(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionByMonth)
+	pm.retain = 1
+	pm.pruneHook = func(ctx context.Context, table, partition string) bool {
+		return partition != "messages_legacy"
+	}
+
+	month := func(m time.Month) int64 { return time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC).UnixNano() }
+	partitions := []*partitionRange{
+		{name: "messages_legacy", to: month(3)},
+		{name: "messages_m202403", to: month(4)},
+		{name: "messages_m202404", to: month(5)},
+		{name: "messages_m202405", to: month(6)},
+	}
+	mock.ExpectExec("ALTER TABLE messages DETACH PARTITION messages_m202403").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DROP TABLE messages_m202403").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := pm.prunePartitions(context.Background(), "messages", partitions)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPrunePartitionsDropFail(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionBySequence)
+	pm.retain = 2
+
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(seq\), 0\) FROM messages`).WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(350))
+	mock.ExpectQuery("SELECT EXISTS .* FROM messages_legacy").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec("ALTER TABLE messages DETACH PARTITION messages_legacy").WillReturnError(fmt.Errorf("pop"))
+
+	err := pm.prunePartitions(context.Background(), "messages", []*partitionRange{
+		{name: "messages_legacy", to: 100},
+		{name: "messages_s000001", to: 200},
+	})
+	assert.EqualError(t, err, "pop")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUniqueIndexStatements(t *testing.T) {
+	def := parseIndexDef(true, "USING btree (namespace, protocol_id) WHERE (listener_id IS NULL)")
+	assert.Equal(t, []string{"namespace", "protocol_id"}, def.columns)
+	assert.Equal(t, "(listener_id IS NULL)", def.where)
+
+	statements := uniqueIndexStatements("blockchainevents", "timestamp", "blockchainevents_protocolid", def)
+	assert.Len(t, statements, 3)
+	assert.Equal(t, "CREATE UNIQUE INDEX blockchainevents_protocolid_p ON blockchainevents USING btree (namespace, protocol_id, timestamp) WHERE (listener_id IS NULL)", statements[0])
+	assert.Contains(t, statements[1], "SELECT 1 FROM (SELECT (NEW).*) n WHERE (listener_id IS NULL)")
+	assert.Contains(t, statements[1], "SELECT 1 FROM blockchainevents WHERE (namespace, protocol_id) = (NEW.namespace, NEW.protocol_id) AND (listener_id IS NULL)")
+	assert.Equal(t, "CREATE TRIGGER blockchainevents_protocolid_guard BEFORE INSERT ON blockchainevents FOR EACH ROW EXECUTE FUNCTION blockchainevents_protocolid_guard()", statements[2])
+
+	statements = uniqueIndexStatements("events", "created", "events_pkey", parseIndexDef(true, "USING btree (seq)"))
+	assert.Equal(t, []string{"CREATE UNIQUE INDEX events_pkey_p ON events USING btree (seq, created)"}, statements)
+
+	assert.Empty(t, parseIndexDef(true, "USING btree").columns)
+}
+
+func TestCanPrune(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionBySequence)
+	pm.archived["events"] = true
+
+	mock.ExpectQuery("SELECT EXISTS .* FROM pins_s000001 WHERE dispatched = false").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	assert.False(t, pm.canPrune(context.Background(), "pins", "pins_s000001"))
+
+	mock.ExpectQuery("SELECT EXISTS .* FROM events_s000001 WHERE seq >").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("SELECT EXISTS .* FROM events_s000001 p").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	assert.False(t, pm.canPrune(context.Background(), "events", "events_s000001"))
+
+	mock.ExpectQuery("SELECT EXISTS .* FROM messages_s000001").WillReturnError(fmt.Errorf("pop"))
+	assert.False(t, pm.canPrune(context.Background(), "messages", "messages_s000001"))
+
+	assert.True(t, pm.canPrune(context.Background(), "blockchainevents", "blockchainevents_s000001"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMaintainLoop(t *testing.T) {
+	pm, mock := newTestPartitionManager(t, partitionByMonth)
+	pm.checkInterval = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	done := make(chan struct{})
+	go func() {
+		pm.maintainLoop(ctx)
+		close(done)
+	}()
+	for mock.ExpectationsWereMet() != nil {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+}
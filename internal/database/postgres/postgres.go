@@ -44,7 +44,17 @@ func (psql *Postgres) Init(ctx context.Context, config config.Section) error {
 	if config.GetInt(dbsql.SQLConfMaxConnections) > 1 {
 		capabilities.Concurrency = true
 	}
-	return psql.SQLCommon.Init(ctx, psql, config, capabilities)
+	if err := psql.SQLCommon.Init(ctx, psql, config, capabilities); err != nil {
+		return err
+	}
+	if config.GetBool(PartitioningEnabled) {
+		pm, err := newPartitionManager(ctx, psql.DB(), config)
+		if err != nil {
+			return err
+		}
+		return pm.start(ctx)
+	}
+	return nil
 }
 
 func (psql *Postgres) SetHandler(namespace string, handler database.Callbacks) {
@@ -92,6 +102,48 @@ func (psql *Postgres) ApplyInsertQueryCustomizations(insert sq.InsertBuilder, re
 	return insert.Suffix(suffix), true
 }
 
+// ReplicaLagQuery reports the time since the last transaction replayed on a streaming replica.
+// Note this grows while the primary is idle, which only means reads fall back to the primary.
+func (psql *Postgres) ReplicaLagQuery() string {
+	return "SELECT COALESCE(EXTRACT(EPOCH FROM (now() - pg_last_xact_replay_timestamp())), 0)"
+}
+
+// ResetSequenceStatement advances the serial sequence of a table past the rows copied into it by an online migration
+func (psql *Postgres) ResetSequenceStatement(table string) string {
+	return fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'seq'), COALESCE((SELECT MAX(seq) FROM %s), 0) + 1, false)", table, table)
+}
+
+// ChangeTrackingStatements record the sequence of every row changed in a table while an online migration is running,
+// with a row-level trigger that records both the old and new sequence of an updated row
+func (psql *Postgres) ChangeTrackingStatements(table, changelog string) (create []string, drop []string) {
+	create = []string{
+		fmt.Sprintf("CREATE TABLE %s (id BIGSERIAL PRIMARY KEY, seq BIGINT NOT NULL)", changelog),
+		fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s_record() RETURNS trigger AS $$ BEGIN
+	IF TG_OP <> 'INSERT' THEN INSERT INTO %s (seq) VALUES (OLD.seq); END IF;
+	IF TG_OP <> 'DELETE' THEN INSERT INTO %s (seq) VALUES (NEW.seq); END IF;
+	RETURN NULL;
+END; $$ LANGUAGE plpgsql`, changelog, changelog, changelog),
+		fmt.Sprintf("CREATE TRIGGER %s_trigger AFTER INSERT OR UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION %s_record()", changelog, table, changelog),
+	}
+	drop = []string{
+		fmt.Sprintf("DROP TRIGGER %s_trigger ON %s", changelog, table),
+		fmt.Sprintf("DROP FUNCTION %s_record()", changelog),
+		fmt.Sprintf("DROP TABLE %s", changelog),
+	}
+	return create, drop
+}
+
+// LockTableStatement blocks all writers to the table until the end of the transaction, while still allowing reads
+func (psql *Postgres) LockTableStatement(table string) string {
+	return fmt.Sprintf("LOCK TABLE %s IN EXCLUSIVE MODE", table)
+}
+
+// FullTextSearchMatch matches search documents against the tsvector generated from their content, ranked with ts_rank
+func (psql *Postgres) FullTextSearchMatch(query string) (match sq.Sqlizer, rank sq.Sqlizer) {
+	return sq.Expr("tsv @@ plainto_tsquery('simple', ?)", query),
+		sq.Expr("ts_rank(tsv, plainto_tsquery('simple', ?))", query)
+}
+
 func (psql *Postgres) Open(url string) (*sql.DB, error) {
 	return sql.Open(psql.Name(), url)
 }
@@ -51,3 +51,36 @@ func TestPostgresProvider(t *testing.T) {
 	assert.Equal(t, "INSERT INTO test (col1) VALUES (?)  ON CONFLICT DO NOTHING RETURNING seq", sql)
 	assert.True(t, query)
 }
+
+func TestPostgresResetSequenceStatement(t *testing.T) {
+	psql := &Postgres{}
+	assert.Equal(t, "SELECT setval(pg_get_serial_sequence('events', 'seq'), COALESCE((SELECT MAX(seq) FROM events), 0) + 1, false)", psql.ResetSequenceStatement("events"))
+}
+
+func TestPostgresChangeTrackingStatements(t *testing.T) {
+	psql := &Postgres{}
+	create, drop := psql.ChangeTrackingStatements("events", "events_onlinechanges")
+	assert.Len(t, create, 3)
+	assert.Equal(t, "CREATE TABLE events_onlinechanges (id BIGSERIAL PRIMARY KEY, seq BIGINT NOT NULL)", create[0])
+	assert.Contains(t, create[1], "INSERT INTO events_onlinechanges (seq) VALUES (OLD.seq)")
+	assert.Contains(t, create[1], "INSERT INTO events_onlinechanges (seq) VALUES (NEW.seq)")
+	assert.Equal(t, "CREATE TRIGGER events_onlinechanges_trigger AFTER INSERT OR UPDATE OR DELETE ON events FOR EACH ROW EXECUTE FUNCTION events_onlinechanges_record()", create[2])
+	assert.Equal(t, []string{
+		"DROP TRIGGER events_onlinechanges_trigger ON events",
+		"DROP FUNCTION events_onlinechanges_record()",
+		"DROP TABLE events_onlinechanges",
+	}, drop)
+	assert.Equal(t, "LOCK TABLE events IN EXCLUSIVE MODE", psql.LockTableStatement("events"))
+}
+
+func TestPostgresFullTextSearchMatch(t *testing.T) {
+	psql := &Postgres{}
+	match, rank := psql.FullTextSearchMatch("widget blue")
+	sql, args, err := match.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "tsv @@ plainto_tsquery('simple', ?)", sql)
+	assert.Equal(t, []interface{}{"widget blue"}, args)
+	sql, _, err = rank.ToSql()
+	assert.NoError(t, err)
+	assert.Equal(t, "ts_rank(tsv, plainto_tsquery('simple', ?))", sql)
+}
@@ -0,0 +1,126 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+type archiveTable struct {
+	table           string
+	namespaceColumn string
+}
+
+var archiveTables = map[database.CollectionName]archiveTable{
+	database.CollectionName(database.CollectionMessages):       {messagesTable, "namespace_local"},
+	database.CollectionName(database.CollectionEvents):         {eventsTable, "namespace"},
+	database.CollectionName(database.CollectionPins):           {pinsTable, "namespace"},
+	database.CollectionName(database.CollectionOperations):     {operationsTable, "namespace"},
+	database.CollectionName(database.CollectionTokenTransfers): {tokentransferTable, "namespace"},
+}
+
+func (s *SQLCommon) GetArchiveRows(ctx context.Context, namespace string, collection database.CollectionName, afterSequence int64, createdBefore *fftypes.FFTime, limit int) (*database.ArchiveRows, error) {
+	t, ok := archiveTables[collection]
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNotArchivableCollection, collection)
+	}
+
+	// The creation time is not monotonic in the sequence, so rows are only archived up to the first
+	// row that is too new to be archived. Otherwise the offset would move past that row, and it would
+	// never be archived once it was old enough.
+	seq := s.SequenceColumn()
+	boundary := sq.Expr(
+		fmt.Sprintf("%s < COALESCE((SELECT MIN(%s) FROM %s WHERE %s = ? AND %s > ? AND created >= ?), ?)",
+			seq, seq, t.table, t.namespaceColumn, seq),
+		namespace, afterSequence, createdBefore, int64(math.MaxInt64),
+	)
+	rows, _, err := s.Query(ctx, t.table,
+		sq.Select("*").
+			From(t.table).
+			Where(sq.And{
+				sq.Eq{t.namespaceColumn: namespace},
+				sq.Gt{seq: afterSequence},
+				boundary,
+			}).
+			OrderBy(seq).
+			Limit(uint64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, t.table)
+	}
+	seqIdx := -1
+	for i, c := range columns {
+		if c == s.SequenceColumn() {
+			seqIdx = i
+		}
+	}
+	result := &database.ArchiveRows{Columns: columns}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, t.table)
+		}
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = archiveValueString(v)
+		}
+		var seq int64
+		if seqIdx >= 0 {
+			seq, _ = strconv.ParseInt(row[seqIdx], 10, 64)
+		}
+		result.Rows = append(result.Rows, row)
+		result.Sequences = append(result.Sequences, seq)
+	}
+	return result, nil
+}
+
+func archiveValueString(v interface{}) string {
+	switch vt := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(vt)
+	case string:
+		return vt
+	case int64:
+		return strconv.FormatInt(vt, 10)
+	case time.Time:
+		return vt.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", vt)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestArchiveRowsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	old := fftypes.FFTime(time.Now().Add(-48 * time.Hour))
+	cutoff := fftypes.FFTime(time.Now().Add(-24 * time.Hour))
+	for i := 0; i < 3; i++ {
+		event := core.NewEvent(core.EventTypeMessageConfirmed, "ns1", fftypes.NewUUID(), nil, "topic1")
+		event.Created = &old
+		assert.NoError(t, s.InsertEvent(ctx, event))
+	}
+	assert.NoError(t, s.InsertEvent(ctx, core.NewEvent(core.EventTypeMessageConfirmed, "ns1", fftypes.NewUUID(), nil, "topic1")))
+	// An old row after a new one is not archived, until the new row is also old enough
+	event := core.NewEvent(core.EventTypeMessageConfirmed, "ns1", fftypes.NewUUID(), nil, "topic1")
+	event.Created = &old
+	assert.NoError(t, s.InsertEvent(ctx, event))
+
+	rows, err := s.GetArchiveRows(ctx, "ns1", database.CollectionName(database.CollectionEvents), 0, &cutoff, 2)
+	assert.NoError(t, err)
+	assert.Contains(t, rows.Columns, "seq")
+	assert.Contains(t, rows.Columns, "topic")
+	assert.Len(t, rows.Rows, 2)
+	assert.Len(t, rows.Rows[0], len(rows.Columns))
+	assert.Equal(t, []int64{1, 2}, rows.Sequences)
+
+	rows, err = s.GetArchiveRows(ctx, "ns1", database.CollectionName(database.CollectionEvents), 2, &cutoff, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3}, rows.Sequences)
+
+	rows, err = s.GetArchiveRows(ctx, "ns1", database.CollectionName(database.CollectionEvents), 3, &cutoff, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, rows.Rows)
+
+	later := fftypes.FFTime(time.Now().Add(time.Hour))
+	rows, err = s.GetArchiveRows(ctx, "ns1", database.CollectionName(database.CollectionEvents), 3, &later, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{4, 5}, rows.Sequences)
+
+	rows, err = s.GetArchiveRows(ctx, "ns2", database.CollectionName(database.CollectionEvents), 0, &cutoff, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, rows.Rows)
+}
+
+func TestArchiveRowsBadCollection(t *testing.T) {
+	s, _ := newMockProvider().init()
+	_, err := s.GetArchiveRows(context.Background(), "ns1", "groups", 0, fftypes.Now(), 10)
+	assert.Regexp(t, "FF10510", err)
+}
+
+func TestArchiveRowsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetArchiveRows(context.Background(), "ns1", database.CollectionName(database.CollectionMessages), 0, fftypes.Now(), 10)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArchiveValueString(t *testing.T) {
+	now := time.Unix(0, 0)
+	assert.Equal(t, "", archiveValueString(nil))
+	assert.Equal(t, "abc", archiveValueString([]byte("abc")))
+	assert.Equal(t, "abc", archiveValueString("abc"))
+	assert.Equal(t, "12", archiveValueString(int64(12)))
+	assert.Equal(t, "1970-01-01T00:00:00Z", archiveValueString(now))
+	assert.Equal(t, "true", archiveValueString(true))
+}
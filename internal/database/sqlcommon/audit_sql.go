@@ -0,0 +1,139 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	auditColumns = []string{
+		"id",
+		"namespace",
+		"username",
+		"source",
+		"method",
+		"path",
+		"route",
+		"idempotency_key",
+		"status",
+		"latency",
+		"created",
+		"prev_hash",
+		"hash",
+	}
+	auditFilterFieldMap = map[string]string{
+		"user":           "username",
+		"idempotencykey": "idempotency_key",
+	}
+)
+
+const auditTable = "auditlog"
+
+// InsertAuditRecord appends a record to the audit log. Records are never updated or deleted.
+func (s *SQLCommon) InsertAuditRecord(ctx context.Context, record *core.AuditRecord) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	record.Sequence, err = s.InsertTx(ctx, auditTable, tx,
+		sq.Insert(auditTable).
+			Columns(auditColumns...).
+			Values(
+				record.ID,
+				record.Namespace,
+				record.User,
+				record.Source,
+				record.Method,
+				record.Path,
+				record.Route,
+				record.IdempotencyKey,
+				record.Status,
+				record.Latency,
+				record.Created,
+				record.PreviousHash,
+				record.Hash,
+			),
+		nil, // no change events for audit records
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) auditResult(ctx context.Context, row *sql.Rows) (*core.AuditRecord, error) {
+	var record core.AuditRecord
+	err := row.Scan(
+		&record.ID,
+		&record.Namespace,
+		&record.User,
+		&record.Source,
+		&record.Method,
+		&record.Path,
+		&record.Route,
+		&record.IdempotencyKey,
+		&record.Status,
+		&record.Latency,
+		&record.Created,
+		&record.PreviousHash,
+		&record.Hash,
+		// Must be added to the list of columns in all selects
+		&record.Sequence,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, auditTable)
+	}
+	return &record, nil
+}
+
+func (s *SQLCommon) GetAuditRecords(ctx context.Context, namespace string, filter ffapi.Filter) (records []*core.AuditRecord, res *ffapi.FilterResult, err error) {
+	cols := append([]string{}, auditColumns...)
+	cols = append(cols, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(auditTable), filter, auditFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, auditTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	records = []*core.AuditRecord{}
+	for rows.Next() {
+		record, err := s.auditResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, s.QueryRes(ctx, auditTable, tx, fop, nil, fi), err
+}
@@ -0,0 +1,141 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	record1 := &core.AuditRecord{
+		ID:             fftypes.NewUUID(),
+		Namespace:      "ns1",
+		User:           "alice",
+		Source:         "127.0.0.1:12345",
+		Method:         "POST",
+		Path:           "/api/v1/namespaces/ns1/messages/broadcast",
+		Route:          "postNewMessageBroadcast",
+		IdempotencyKey: "key1",
+		Status:         202,
+		Latency:        12,
+		Created:        fftypes.Now(),
+	}
+	record1.Hash = record1.CalcHash()
+	err := s.InsertAuditRecord(ctx, record1)
+	assert.NoError(t, err)
+
+	record2 := &core.AuditRecord{
+		ID:           fftypes.NewUUID(),
+		Namespace:    "ns1",
+		Method:       "DELETE",
+		Path:         "/api/v1/namespaces/ns1/subscriptions/sub1",
+		Route:        "deleteSubscription",
+		Status:       204,
+		Latency:      3,
+		Created:      fftypes.Now(),
+		PreviousHash: record1.Hash,
+	}
+	record2.Hash = record2.CalcHash()
+	err = s.InsertAuditRecord(ctx, record2)
+	assert.NoError(t, err)
+	assert.Greater(t, record2.Sequence, record1.Sequence)
+
+	fb := database.AuditQueryFactory.NewFilter(ctx)
+	records, res, err := s.GetAuditRecords(ctx, "ns1", fb.And().Sort("sequence").Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	assert.Len(t, records, 2)
+	record1JSON, _ := json.Marshal(record1)
+	readJSON, _ := json.Marshal(records[0])
+	assert.Equal(t, string(record1JSON), string(readJSON))
+	assert.Equal(t, *record2.PreviousHash, *records[1].PreviousHash)
+	assert.Equal(t, *records[1].Hash, *records[1].CalcHash())
+
+	records, _, err = s.GetAuditRecords(ctx, "ns1", fb.And(fb.Eq("user", "alice"), fb.Eq("idempotencykey", "key1")))
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	records, _, err = s.GetAuditRecords(ctx, "ns2", fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestInsertAuditRecordFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertAuditRecord(context.Background(), &core.AuditRecord{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertAuditRecordFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertAuditRecord(context.Background(), &core.AuditRecord{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertAuditRecordFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertAuditRecord(context.Background(), &core.AuditRecord{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuditRecordsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.AuditQueryFactory.NewFilter(context.Background()).Eq("user", "alice")
+	_, _, err := s.GetAuditRecords(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuditRecordsBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.AuditQueryFactory.NewFilter(context.Background()).Eq("id", map[bool]bool{true: false})
+	_, _, err := s.GetAuditRecords(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*id", err)
+}
+
+func TestGetAuditRecordsReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	f := database.AuditQueryFactory.NewFilter(context.Background()).Eq("user", "alice")
+	_, _, err := s.GetAuditRecords(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
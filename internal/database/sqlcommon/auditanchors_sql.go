@@ -0,0 +1,146 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	auditAnchorColumns = []string{
+		"id",
+		"namespace",
+		"first_event",
+		"last_event",
+		"leaves",
+		"root",
+		"tx_type",
+		"tx_id",
+		"created",
+	}
+	auditAnchorFilterFieldMap = map[string]string{
+		"firstevent": "first_event",
+		"lastevent":  "last_event",
+		"tx.type":    "tx_type",
+		"tx.id":      "tx_id",
+	}
+)
+
+const auditAnchorsTable = "auditanchors"
+
+// InsertAuditAnchor records an anchor. Anchors are never updated or deleted.
+func (s *SQLCommon) InsertAuditAnchor(ctx context.Context, anchor *core.AuditAnchor) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	_, err = s.InsertTx(ctx, auditAnchorsTable, tx,
+		sq.Insert(auditAnchorsTable).
+			Columns(auditAnchorColumns...).
+			Values(
+				anchor.ID,
+				anchor.Namespace,
+				anchor.FirstEvent,
+				anchor.LastEvent,
+				anchor.Leaves,
+				anchor.Root,
+				anchor.TX.Type,
+				anchor.TX.ID,
+				anchor.Created,
+			),
+		nil, // no change events for audit anchors
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) auditAnchorResult(ctx context.Context, row *sql.Rows) (*core.AuditAnchor, error) {
+	var anchor core.AuditAnchor
+	err := row.Scan(
+		&anchor.ID,
+		&anchor.Namespace,
+		&anchor.FirstEvent,
+		&anchor.LastEvent,
+		&anchor.Leaves,
+		&anchor.Root,
+		&anchor.TX.Type,
+		&anchor.TX.ID,
+		&anchor.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, auditAnchorsTable)
+	}
+	return &anchor, nil
+}
+
+func (s *SQLCommon) GetAuditAnchorByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.AuditAnchor, error) {
+	rows, _, err := s.Query(ctx, auditAnchorsTable,
+		sq.Select(auditAnchorColumns...).
+			From(auditAnchorsTable).
+			Where(sq.Eq{"id": id, "namespace": namespace}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Audit anchor '%s' not found", id)
+		return nil, nil
+	}
+
+	return s.auditAnchorResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetAuditAnchors(ctx context.Context, namespace string, filter ffapi.Filter) (anchors []*core.AuditAnchor, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(auditAnchorColumns...).From(auditAnchorsTable), filter, auditAnchorFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, auditAnchorsTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	anchors = []*core.AuditAnchor{}
+	for rows.Next() {
+		anchor, err := s.auditAnchorResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		anchors = append(anchors, anchor)
+	}
+
+	return anchors, s.QueryRes(ctx, auditAnchorsTable, tx, fop, nil, fi), err
+}
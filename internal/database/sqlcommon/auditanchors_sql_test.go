@@ -0,0 +1,159 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditAnchorsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	anchor1 := &core.AuditAnchor{
+		ID:         fftypes.NewUUID(),
+		Namespace:  "ns1",
+		FirstEvent: 1,
+		LastEvent:  10,
+		Leaves:     6,
+		Root:       fftypes.NewRandB32(),
+		TX: core.TransactionRef{
+			Type: core.TransactionTypeBatchPin,
+			ID:   fftypes.NewUUID(),
+		},
+		Created: fftypes.Now(),
+	}
+	err := s.InsertAuditAnchor(ctx, anchor1)
+	assert.NoError(t, err)
+
+	anchor2 := &core.AuditAnchor{
+		ID:         fftypes.NewUUID(),
+		Namespace:  "ns1",
+		FirstEvent: 12,
+		LastEvent:  20,
+		Leaves:     3,
+		Root:       fftypes.NewRandB32(),
+		TX: core.TransactionRef{
+			Type: core.TransactionTypeBatchPin,
+			ID:   fftypes.NewUUID(),
+		},
+		Created: fftypes.Now(),
+	}
+	err = s.InsertAuditAnchor(ctx, anchor2)
+	assert.NoError(t, err)
+
+	read, err := s.GetAuditAnchorByID(ctx, "ns1", anchor1.ID)
+	assert.NoError(t, err)
+	anchorJSON, _ := json.Marshal(anchor1)
+	readJSON, _ := json.Marshal(read)
+	assert.Equal(t, string(anchorJSON), string(readJSON))
+
+	read, err = s.GetAuditAnchorByID(ctx, "ns2", anchor1.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, read)
+
+	fb := database.AuditAnchorQueryFactory.NewFilter(ctx)
+	anchors, res, err := s.GetAuditAnchors(ctx, "ns1", fb.And().Sort("-lastevent").Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	assert.Len(t, anchors, 2)
+	assert.Equal(t, *anchor2.ID, *anchors[0].ID)
+
+	anchors, _, err = s.GetAuditAnchors(ctx, "ns1", fb.And(fb.Eq("tx.id", anchor1.TX.ID)))
+	assert.NoError(t, err)
+	assert.Len(t, anchors, 1)
+	assert.Equal(t, *anchor1.ID, *anchors[0].ID)
+}
+
+func TestInsertAuditAnchorFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertAuditAnchor(context.Background(), &core.AuditAnchor{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertAuditAnchorFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertAuditAnchor(context.Background(), &core.AuditAnchor{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertAuditAnchorFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertAuditAnchor(context.Background(), &core.AuditAnchor{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuditAnchorByIDQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetAuditAnchorByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuditAnchorByIDReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetAuditAnchorByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuditAnchorsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.AuditAnchorQueryFactory.NewFilter(context.Background()).Gt("lastevent", 0)
+	_, _, err := s.GetAuditAnchors(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuditAnchorsBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.AuditAnchorQueryFactory.NewFilter(context.Background()).Eq("id", map[bool]bool{true: false})
+	_, _, err := s.GetAuditAnchors(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*id", err)
+}
+
+func TestGetAuditAnchorsReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	f := database.AuditAnchorQueryFactory.NewFilter(context.Background()).Gt("lastevent", 0)
+	_, _, err := s.GetAuditAnchors(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
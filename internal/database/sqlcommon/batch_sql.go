@@ -45,6 +45,7 @@ var (
 		"tx_type",
 		"tx_id",
 		"node_id",
+		"trace",
 	}
 	batchFilterFieldMap = map[string]string{
 		"type":    "btype",
@@ -82,6 +83,7 @@ func (s *SQLCommon) InsertOrGetBatch(ctx context.Context, batch *core.BatchPersi
 				batch.TX.Type,
 				batch.TX.ID,
 				batch.Node,
+				batch.Trace,
 			),
 		func() {
 			s.callbacks.UUIDCollectionNSEvent(database.CollectionBatches, core.ChangeEventTypeCreated, batch.Namespace, batch.ID)
@@ -118,6 +120,8 @@ func (s *SQLCommon) batchResult(ctx context.Context, row *sql.Rows) (*core.Batch
 		&batch.TX.Type,
 		&batch.TX.ID,
 		&batch.Node,
+		&batch.Trace,
+		&batch.Sequence,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, batchesTable)
@@ -127,8 +131,10 @@ func (s *SQLCommon) batchResult(ctx context.Context, row *sql.Rows) (*core.Batch
 
 func (s *SQLCommon) GetBatchByID(ctx context.Context, namespace string, id *fftypes.UUID) (message *core.BatchPersisted, err error) {
 
+	cols := append([]string{}, batchColumns...)
+	cols = append(cols, s.SequenceColumn())
 	rows, _, err := s.Query(ctx, batchesTable,
-		sq.Select(batchColumns...).
+		sq.Select(cols...).
 			From(batchesTable).
 			Where(sq.Eq{"id": id, "namespace": namespace}),
 	)
@@ -152,7 +158,9 @@ func (s *SQLCommon) GetBatchByID(ctx context.Context, namespace string, id *ffty
 
 func (s *SQLCommon) GetBatches(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.BatchPersisted, res *ffapi.FilterResult, err error) {
 
-	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(batchColumns...).From(batchesTable), filter, batchFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	cols := append([]string{}, batchColumns...)
+	cols = append(cols, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(batchesTable), filter, batchFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
 	}
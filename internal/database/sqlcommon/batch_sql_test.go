@@ -50,6 +50,7 @@ func TestBatch2EWithDB(t *testing.T) {
 			Namespace: "ns1",
 			Node:      fftypes.NewUUID(),
 			Created:   fftypes.Now(),
+			Trace:     true,
 		},
 		Hash: fftypes.NewRandB32(),
 		TX: core.TransactionRef{
@@ -75,6 +76,7 @@ func TestBatch2EWithDB(t *testing.T) {
 	batchJson, _ := json.Marshal(&batch)
 	batchReadJson, _ := json.Marshal(&batchRead)
 	assert.Equal(t, string(batchJson), string(batchReadJson))
+	assert.Greater(t, batchRead.Sequence, int64(0))
 
 	// Try to insert again - should get back the existing row
 	existing, err = s.InsertOrGetBatch(ctx, batch)
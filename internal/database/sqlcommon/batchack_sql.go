@@ -0,0 +1,148 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	batchAckColumns = []string{
+		"namespace",
+		"batch_id",
+		"node_id",
+		"ack_state",
+		"created",
+		"updated",
+	}
+	batchAckFilterFieldMap = map[string]string{
+		"batch": "batch_id",
+		"node":  "node_id",
+		"state": "ack_state",
+	}
+)
+
+const batchAcksTable = "batchacks"
+
+// UpsertBatchAck records the state a recipient has reached with a batch. Acknowledgements can arrive
+// out of order, so an existing record is only updated if the new state is further along than the old one.
+func (s *SQLCommon) UpsertBatchAck(ctx context.Context, ack *core.BatchAck) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	ackRows, _, err := s.QueryTx(ctx, batchAcksTable, tx,
+		sq.Select("ack_state").
+			From(batchAcksTable).
+			Where(sq.Eq{"namespace": ack.Namespace, "batch_id": ack.Batch, "node_id": ack.Node}),
+	)
+	if err != nil {
+		return err
+	}
+	existing := ackRows.Next()
+	var existingState core.BatchAckState
+	if existing {
+		err = ackRows.Scan(&existingState)
+	}
+	ackRows.Close()
+	if err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, batchAcksTable)
+	}
+
+	switch {
+	case existing && !core.BatchAckStateAdvances(existingState, ack.State):
+		// Nothing to do - we already know the recipient got at least this far
+	case existing:
+		if _, err = s.UpdateTx(ctx, batchAcksTable, tx,
+			sq.Update(batchAcksTable).
+				Set("ack_state", ack.State).
+				Set("updated", ack.Updated).
+				Where(sq.Eq{"namespace": ack.Namespace, "batch_id": ack.Batch, "node_id": ack.Node}),
+			nil, // no change events for batch acks
+		); err != nil {
+			return err
+		}
+	default:
+		if _, err = s.InsertTx(ctx, batchAcksTable, tx,
+			sq.Insert(batchAcksTable).
+				Columns(batchAckColumns...).
+				Values(
+					ack.Namespace,
+					ack.Batch,
+					ack.Node,
+					ack.State,
+					ack.Created,
+					ack.Updated,
+				),
+			nil, // no change events for batch acks
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) batchAckResult(ctx context.Context, row *sql.Rows) (*core.BatchAck, error) {
+	var ack core.BatchAck
+	err := row.Scan(
+		&ack.Namespace,
+		&ack.Batch,
+		&ack.Node,
+		&ack.State,
+		&ack.Created,
+		&ack.Updated,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, batchAcksTable)
+	}
+	return &ack, nil
+}
+
+func (s *SQLCommon) GetBatchAcks(ctx context.Context, namespace string, filter ffapi.Filter) (acks []*core.BatchAck, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(batchAckColumns...).From(batchAcksTable), filter, batchAckFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, batchAcksTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	acks = []*core.BatchAck{}
+	for rows.Next() {
+		ack, err := s.batchAckResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		acks = append(acks, ack)
+	}
+
+	return acks, s.QueryRes(ctx, batchAcksTable, tx, fop, nil, fi), err
+}
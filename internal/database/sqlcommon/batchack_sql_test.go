@@ -0,0 +1,176 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchAcksE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	ack := &core.BatchAck{
+		Namespace: "ns1",
+		Batch:     fftypes.NewUUID(),
+		Node:      fftypes.NewUUID(),
+		State:     core.BatchAckStatePersisted,
+		Created:   fftypes.Now(),
+		Updated:   fftypes.Now(),
+	}
+	err := s.UpsertBatchAck(ctx, ack)
+	assert.NoError(t, err)
+
+	// A late transfer result does not move the ack backwards
+	err = s.UpsertBatchAck(ctx, &core.BatchAck{
+		Namespace: "ns1",
+		Batch:     ack.Batch,
+		Node:      ack.Node,
+		State:     core.BatchAckStateTransferred,
+		Created:   fftypes.Now(),
+		Updated:   fftypes.Now(),
+	})
+	assert.NoError(t, err)
+
+	fb := database.BatchAckQueryFactory.NewFilter(ctx)
+	acks, res, err := s.GetBatchAcks(ctx, "ns1", fb.And(fb.Eq("batch", ack.Batch)).Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *res.TotalCount)
+	assert.Len(t, acks, 1)
+	ackJSON, _ := json.Marshal(ack)
+	readJSON, _ := json.Marshal(acks[0])
+	assert.Equal(t, string(ackJSON), string(readJSON))
+
+	// Moving forwards updates the state, but keeps the created time
+	ack.State = core.BatchAckStateAggregated
+	ack.Updated = fftypes.Now()
+	err = s.UpsertBatchAck(ctx, &core.BatchAck{
+		Namespace: "ns1",
+		Batch:     ack.Batch,
+		Node:      ack.Node,
+		State:     core.BatchAckStateAggregated,
+		Created:   fftypes.Now(),
+		Updated:   ack.Updated,
+	})
+	assert.NoError(t, err)
+	acks, _, err = s.GetBatchAcks(ctx, "ns1", fb.And(fb.Eq("state", core.BatchAckStateAggregated)))
+	assert.NoError(t, err)
+	assert.Len(t, acks, 1)
+	ackJSON, _ = json.Marshal(ack)
+	readJSON, _ = json.Marshal(acks[0])
+	assert.Equal(t, string(ackJSON), string(readJSON))
+
+	acks, _, err = s.GetBatchAcks(ctx, "ns2", fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, acks)
+}
+
+func TestUpsertBatchAckFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpsertBatchAck(context.Background(), &core.BatchAck{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertBatchAckFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertBatchAck(context.Background(), &core.BatchAck{Batch: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertBatchAckFailScan(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"ack_state", "extra"}).AddRow("persisted", "extra"))
+	mock.ExpectRollback()
+	err := s.UpsertBatchAck(context.Background(), &core.BatchAck{Batch: fftypes.NewUUID()})
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertBatchAckFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertBatchAck(context.Background(), &core.BatchAck{Batch: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertBatchAckFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"ack_state"}).AddRow("transferred"))
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertBatchAck(context.Background(), &core.BatchAck{Batch: fftypes.NewUUID(), State: core.BatchAckStatePersisted})
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertBatchAckFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{}))
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpsertBatchAck(context.Background(), &core.BatchAck{Batch: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBatchAcksQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.BatchAckQueryFactory.NewFilter(context.Background()).Eq("state", "persisted")
+	_, _, err := s.GetBatchAcks(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBatchAcksBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.BatchAckQueryFactory.NewFilter(context.Background()).Eq("batch", map[bool]bool{true: false})
+	_, _, err := s.GetBatchAcks(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*batch", err)
+}
+
+func TestGetBatchAcksReadMessageFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("only one"))
+	f := database.BatchAckQueryFactory.NewFilter(context.Background()).Eq("state", "persisted")
+	_, _, err := s.GetBatchAcks(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
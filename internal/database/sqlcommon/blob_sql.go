@@ -166,6 +166,22 @@ func (s *SQLCommon) GetBlobs(ctx context.Context, namespace string, filter ffapi
 
 }
 
+func (s *SQLCommon) GetBlobsTotalSize(ctx context.Context, namespace string) (total int64, err error) {
+	rows, _, err := s.Query(ctx, blobsTable,
+		sq.Select("COALESCE(SUM(size), 0)").From(blobsTable).Where(sq.Eq{"namespace": namespace}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	if rows.Next() {
+		if err = rows.Scan(&total); err != nil {
+			return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, blobsTable)
+		}
+	}
+	return total, nil
+}
+
 func (s *SQLCommon) DeleteBlob(ctx context.Context, sequence int64) (err error) {
 
 	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
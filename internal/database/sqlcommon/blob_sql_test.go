@@ -52,6 +52,10 @@ func TestBlobsE2EWithDB(t *testing.T) {
 	err := s.InsertBlob(ctx, blob)
 	assert.NoError(t, err)
 
+	total, err := s.GetBlobsTotalSize(ctx, namespace)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12345), total)
+
 	// Check we get the exact same blob back
 	fb := database.BlobQueryFactory.NewFilter(ctx)
 	blobs, _, err := s.GetBlobs(ctx, namespace, fb.Eq("payloadref", blob.PayloadRef))
@@ -219,6 +223,22 @@ func TestGetBlobReadMessageFail(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetBlobsTotalSizeQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetBlobsTotalSize(context.Background(), "ns1")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBlobsTotalSizeScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("not a number"))
+	_, err := s.GetBlobsTotalSize(context.Background(), "ns1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestBlobDeleteBeginFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
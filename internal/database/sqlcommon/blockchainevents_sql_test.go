@@ -116,6 +116,13 @@ func TestBlockchainEventsE2EWithDB(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, event3.ID, existing.ID)
 
+	// Delete the event, and check the protocol ID can be re-used
+	err = s.DeleteBlockchainEvent(ctx, "ns", event3.ID)
+	assert.NoError(t, err)
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionBlockchainEvents, core.ChangeEventTypeCreated, "ns", event4.ID).Return().Once()
+	existing, err = s.InsertOrGetBlockchainEvent(ctx, event4)
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
 }
 
 func TestInsertBlockchainEventFailBegin(t *testing.T) {
@@ -257,3 +264,21 @@ func TestGetBlockchainEventsScanFail(t *testing.T) {
 	assert.Regexp(t, "FF10121", err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestDeleteBlockchainEventBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteBlockchainEvent(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteBlockchainEventDeleteFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteBlockchainEvent(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,108 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	blockchainReorgColumns = []string{
+		"id",
+		"namespace",
+		"source",
+		"block_number",
+		"block_hash",
+		"reverted",
+		"created",
+	}
+)
+
+const blockchainReorgsTable = "blockchainreorgs"
+
+// InsertBlockchainReorg records a re-org. Re-org records are never updated, as they describe a completed revert.
+func (s *SQLCommon) InsertBlockchainReorg(ctx context.Context, reorg *core.BlockchainReorg) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	_, err = s.InsertTx(ctx, blockchainReorgsTable, tx,
+		sq.Insert(blockchainReorgsTable).
+			Columns(blockchainReorgColumns...).
+			Values(
+				reorg.ID,
+				reorg.Namespace,
+				reorg.Source,
+				reorg.BlockNumber,
+				reorg.BlockHash,
+				reorg.Reverted,
+				reorg.Created,
+			),
+		nil, // no change events for re-orgs
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) blockchainReorgResult(ctx context.Context, row *sql.Rows) (*core.BlockchainReorg, error) {
+	var reorg core.BlockchainReorg
+	err := row.Scan(
+		&reorg.ID,
+		&reorg.Namespace,
+		&reorg.Source,
+		&reorg.BlockNumber,
+		&reorg.BlockHash,
+		&reorg.Reverted,
+		&reorg.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, blockchainReorgsTable)
+	}
+	return &reorg, nil
+}
+
+func (s *SQLCommon) GetBlockchainReorgByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.BlockchainReorg, error) {
+	rows, _, err := s.Query(ctx, blockchainReorgsTable,
+		sq.Select(blockchainReorgColumns...).
+			From(blockchainReorgsTable).
+			Where(sq.Eq{"id": id, "namespace": namespace}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Blockchain re-org '%s' not found", id)
+		return nil, nil
+	}
+
+	return s.blockchainReorgResult(ctx, rows)
+}
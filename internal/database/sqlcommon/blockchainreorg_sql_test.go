@@ -0,0 +1,104 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockchainReorgsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	reorg := &core.BlockchainReorg{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Source:      "ethereum",
+		BlockNumber: 12345,
+		BlockHash:   "0x0102",
+		Reverted: &core.BlockchainReorgReverted{
+			BlockchainEvents: []*fftypes.UUID{fftypes.NewUUID()},
+			TokenTransfers:   []*fftypes.UUID{fftypes.NewUUID()},
+		},
+		Created: fftypes.Now(),
+	}
+	err := s.InsertBlockchainReorg(ctx, reorg)
+	assert.NoError(t, err)
+
+	read, err := s.GetBlockchainReorgByID(ctx, "ns1", reorg.ID)
+	assert.NoError(t, err)
+	reorgJSON, _ := json.Marshal(reorg)
+	readJSON, _ := json.Marshal(read)
+	assert.Equal(t, string(reorgJSON), string(readJSON))
+
+	read, err = s.GetBlockchainReorgByID(ctx, "ns2", reorg.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, read)
+}
+
+func TestInsertBlockchainReorgFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertBlockchainReorg(context.Background(), &core.BlockchainReorg{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertBlockchainReorgFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertBlockchainReorg(context.Background(), &core.BlockchainReorg{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertBlockchainReorgFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertBlockchainReorg(context.Background(), &core.BlockchainReorg{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBlockchainReorgByIDQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetBlockchainReorgByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBlockchainReorgByIDReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetBlockchainReorgByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -41,7 +41,28 @@ const (
 )
 
 const (
-	defaultMigrationsDirectoryTemplate = "./db/migrations/%s"
+	// SQLConfReplicaURL is the datasource connection URL string of a read-only replica
+	SQLConfReplicaURL = "replica.url"
+	// SQLConfReplicaMaxConnections maximum connections to the read replica
+	SQLConfReplicaMaxConnections = "replica.maxConns"
+	// SQLConfReplicaMaxStaleness how far behind the primary the replica can be, for collections without their own setting
+	SQLConfReplicaMaxStaleness = "replica.maxStaleness"
+	// SQLConfReplicaCollections per-collection overrides of the max staleness
+	SQLConfReplicaCollections = "replica.collections"
+	// SQLConfReplicaLagCheckInterval how often the replication lag is measured
+	SQLConfReplicaLagCheckInterval = "replica.lagCheckInterval"
+)
+
+const (
+	// SQLConfOnlineMigrationsDirectory is the directory containing the definitions of online migrations
+	SQLConfOnlineMigrationsDirectory = "migrations.online.directory"
+	// SQLConfOnlineMigrationsBatchSize is the number of rows copied in each step of an online migration
+	SQLConfOnlineMigrationsBatchSize = "migrations.online.batchSize"
+)
+
+const (
+	defaultMigrationsDirectoryTemplate       = "./db/migrations/%s"
+	defaultOnlineMigrationsDirectoryTemplate = "./db/migrations/%s/online"
 )
 
 func (s *SQLCommon) InitConfig(provider dbsql.Provider, config config.Section) {
@@ -52,4 +73,11 @@ func (s *SQLCommon) InitConfig(provider dbsql.Provider, config config.Section) {
 	config.AddKnownKey(SQLConfMaxConnIdleTime, "1m")
 	config.AddKnownKey(SQLConfMaxIdleConns) // defaults to the max connections
 	config.AddKnownKey(SQLConfMaxConnLifetime)
+	config.AddKnownKey(SQLConfReplicaURL)
+	config.AddKnownKey(SQLConfReplicaMaxConnections)
+	config.AddKnownKey(SQLConfReplicaMaxStaleness, "5s")
+	config.AddKnownKey(SQLConfReplicaCollections)
+	config.AddKnownKey(SQLConfReplicaLagCheckInterval, "5s")
+	config.AddKnownKey(SQLConfOnlineMigrationsDirectory, fmt.Sprintf(defaultOnlineMigrationsDirectoryTemplate, provider.MigrationsDir()))
+	config.AddKnownKey(SQLConfOnlineMigrationsBatchSize, 1000)
 }
@@ -0,0 +1,134 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	confirmationQueueColumns = []string{
+		"namespace",
+		"block_number",
+		"block_hash",
+		"entry_type",
+		"payload",
+		"created",
+	}
+)
+
+const confirmationQueueTable = "confirmationqueue"
+
+func (s *SQLCommon) InsertConfirmationQueueEntry(ctx context.Context, entry *core.ConfirmationQueueEntry) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	sequence, err := s.InsertTx(ctx, confirmationQueueTable, tx,
+		sq.Insert(confirmationQueueTable).
+			Columns(confirmationQueueColumns...).
+			Values(
+				entry.Namespace,
+				entry.BlockNumber,
+				entry.BlockHash,
+				entry.Type,
+				entry.Payload,
+				entry.Created,
+			),
+		nil, // no change events for the queue
+	)
+	if err != nil {
+		return err
+	}
+	entry.Sequence = sequence
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) confirmationQueueResult(ctx context.Context, row *sql.Rows) (*core.ConfirmationQueueEntry, error) {
+	entry := core.ConfirmationQueueEntry{}
+	var blockHash *string
+	err := row.Scan(
+		&entry.Namespace,
+		&entry.BlockNumber,
+		&blockHash,
+		&entry.Type,
+		&entry.Payload,
+		&entry.Created,
+		&entry.Sequence,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, confirmationQueueTable)
+	}
+	if blockHash != nil {
+		entry.BlockHash = *blockHash
+	}
+	return &entry, nil
+}
+
+// GetConfirmationQueueEntries returns all the events held for confirmation in a namespace, in the order they were received
+func (s *SQLCommon) GetConfirmationQueueEntries(ctx context.Context, namespace string) ([]*core.ConfirmationQueueEntry, error) {
+	cols := append([]string{}, confirmationQueueColumns...)
+	cols = append(cols, s.SequenceColumn())
+
+	rows, _, err := s.Query(ctx, confirmationQueueTable,
+		sq.Select(cols...).
+			From(confirmationQueueTable).
+			Where(sq.Eq{"namespace": namespace}).
+			OrderBy(s.SequenceColumn()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*core.ConfirmationQueueEntry{}
+	for rows.Next() {
+		entry, err := s.confirmationQueueResult(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *SQLCommon) DeleteConfirmationQueueEntry(ctx context.Context, namespace string, sequence int64) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, confirmationQueueTable, tx, sq.Delete(confirmationQueueTable).Where(sq.Eq{
+		"namespace":        namespace,
+		s.SequenceColumn(): sequence,
+	}), nil /* no change events for the queue */)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,129 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirmationQueueE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Queue entries out of block order, as they can arrive from different connectors
+	e1 := &core.ConfirmationQueueEntry{Namespace: "ns1", BlockNumber: 12, BlockHash: "0x12", Type: core.ConfirmationQueueEntryTypeBlockchainEvent, Payload: fftypes.JSONAnyPtr(`{"type":0}`), Created: fftypes.Now()}
+	e2 := &core.ConfirmationQueueEntry{Namespace: "ns1", BlockNumber: 10, BlockHash: "0x10", Type: core.ConfirmationQueueEntryTypeTokenTransfer, Payload: fftypes.JSONAnyPtr(`{"poolLocator":"p1"}`), Created: fftypes.Now()}
+	e3 := &core.ConfirmationQueueEntry{Namespace: "ns1", BlockNumber: 13, Type: core.ConfirmationQueueEntryTypeBlockchainEvent, Payload: fftypes.JSONAnyPtr(`{"type":2}`), Created: fftypes.Now()}
+	other := &core.ConfirmationQueueEntry{Namespace: "ns2", BlockNumber: 1, Type: core.ConfirmationQueueEntryTypeBlockchainEvent, Created: fftypes.Now()}
+	for _, e := range []*core.ConfirmationQueueEntry{e1, e2, e3, other} {
+		err := s.InsertConfirmationQueueEntry(ctx, e)
+		assert.NoError(t, err)
+	}
+
+	// Check we get them back in the order they were queued
+	entries, err := s.GetConfirmationQueueEntries(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	for i, e := range []*core.ConfirmationQueueEntry{e1, e2, e3} {
+		assert.Equal(t, e.BlockNumber, entries[i].BlockNumber)
+		assert.Equal(t, e.BlockHash, entries[i].BlockHash)
+		assert.Equal(t, e.Type, entries[i].Type)
+		assert.Equal(t, e.Payload.String(), entries[i].Payload.String())
+		assert.Equal(t, e.Sequence, entries[i].Sequence)
+	}
+
+	// Delete an entry
+	err = s.DeleteConfirmationQueueEntry(ctx, "ns1", e2.Sequence)
+	assert.NoError(t, err)
+	entries, err = s.GetConfirmationQueueEntries(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, e1.Sequence, entries[0].Sequence)
+	assert.Equal(t, e3.Sequence, entries[1].Sequence)
+
+	// Entries are scoped to the namespace
+	err = s.DeleteConfirmationQueueEntry(ctx, "ns1", other.Sequence)
+	assert.Regexp(t, "FF00167", err)
+}
+
+func TestInsertConfirmationQueueEntryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertConfirmationQueueEntry(context.Background(), &core.ConfirmationQueueEntry{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertConfirmationQueueEntryFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertConfirmationQueueEntry(context.Background(), &core.ConfirmationQueueEntry{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertConfirmationQueueEntryFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertConfirmationQueueEntry(context.Background(), &core.ConfirmationQueueEntry{})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetConfirmationQueueEntriesQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetConfirmationQueueEntries(context.Background(), "ns1")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetConfirmationQueueEntriesReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("only one"))
+	_, err := s.GetConfirmationQueueEntries(context.Background(), "ns1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteConfirmationQueueEntryBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteConfirmationQueueEntry(context.Background(), "ns1", 12345)
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestDeleteConfirmationQueueEntryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteConfirmationQueueEntry(context.Background(), "ns1", 12345)
+	assert.Regexp(t, "FF00179", err)
+}
@@ -41,6 +41,7 @@ var (
 		"namespace",
 		"message_id",
 		"published",
+		"listeners",
 	}
 	contractAPIsFilterFieldMap = map[string]string{
 		"interface":   "interface_id",
@@ -68,6 +69,7 @@ func (s *SQLCommon) attemptContractAPIUpdate(ctx context.Context, tx *dbsql.TXWr
 			Set("network_name", networkName).
 			Set("message_id", api.Message).
 			Set("published", api.Published).
+			Set("listeners", api.Listeners).
 			Where(sq.Eq{"id": api.ID}),
 		func() {
 			s.callbacks.UUIDCollectionNSEvent(database.CollectionContractAPIs, core.ChangeEventTypeUpdated, api.Namespace, api.ID)
@@ -93,6 +95,7 @@ func (s *SQLCommon) setContractAPIInsertValues(query sq.InsertBuilder, api *core
 		api.Namespace,
 		api.Message,
 		api.Published,
+		api.Listeners,
 	)
 }
 
@@ -202,6 +205,7 @@ func (s *SQLCommon) contractAPIResult(ctx context.Context, row *sql.Rows) (*core
 		&api.Namespace,
 		&api.Message,
 		&api.Published,
+		&api.Listeners,
 	)
 	if networkName != nil {
 		api.NetworkName = *networkName
@@ -52,6 +52,15 @@ func TestContractAPIE2EWithDB(t *testing.T) {
 			Version: "v1.0.0",
 		},
 		Message: fftypes.NewUUID(),
+		Listeners: core.ContractAPIListenerTemplates{
+			{
+				EventPath: "Changed",
+				Topic:     "banana-changes",
+				Options: &core.ContractListenerOptions{
+					FirstEvent: "oldest",
+				},
+			},
+		},
 	}
 
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionContractAPIs, core.ChangeEventTypeCreated, "ns1", apiID, mock.Anything).Return()
@@ -67,6 +76,7 @@ func TestContractAPIE2EWithDB(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, dataRead)
 	assert.Equal(t, *apiID, *dataRead.ID)
+	assert.Equal(t, contractAPI.Listeners, dataRead.Listeners)
 
 	contractAPI.Interface.Version = "v1.1.0"
 
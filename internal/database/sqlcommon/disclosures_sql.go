@@ -0,0 +1,172 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	disclosureColumns = []string{
+		"id",
+		"namespace",
+		"message_id",
+		"message_hash",
+		"circuit",
+		"public_inputs",
+		"proof",
+		"author",
+		"status",
+		"definition_message",
+		"created",
+	}
+	disclosureFilterFieldMap = map[string]string{
+		"message":           "message_id",
+		"messagehash":       "message_hash",
+		"definitionmessage": "definition_message",
+	}
+)
+
+const disclosuresTable = "disclosures"
+
+// InsertDisclosure records a disclosure. Only the status is updated afterwards, once this node has verified it.
+func (s *SQLCommon) InsertDisclosure(ctx context.Context, disclosure *core.Disclosure) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	_, err = s.InsertTx(ctx, disclosuresTable, tx,
+		sq.Insert(disclosuresTable).
+			Columns(disclosureColumns...).
+			Values(
+				disclosure.ID,
+				disclosure.Namespace,
+				disclosure.Message,
+				disclosure.MessageHash,
+				disclosure.Circuit,
+				disclosure.PublicInputs,
+				disclosure.Proof,
+				disclosure.Author,
+				disclosure.Status,
+				disclosure.DefinitionMessage,
+				disclosure.Created,
+			),
+		nil, // no change events for disclosures
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) UpdateDisclosure(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	query, err := s.BuildUpdate(sq.Update(disclosuresTable), update, disclosureFilterFieldMap)
+	if err != nil {
+		return err
+	}
+	query = query.Where(sq.Eq{"id": id, "namespace": namespace})
+
+	_, err = s.UpdateTx(ctx, disclosuresTable, tx, query, nil /* no change events for disclosures */)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) disclosureResult(ctx context.Context, row *sql.Rows) (*core.Disclosure, error) {
+	var disclosure core.Disclosure
+	err := row.Scan(
+		&disclosure.ID,
+		&disclosure.Namespace,
+		&disclosure.Message,
+		&disclosure.MessageHash,
+		&disclosure.Circuit,
+		&disclosure.PublicInputs,
+		&disclosure.Proof,
+		&disclosure.Author,
+		&disclosure.Status,
+		&disclosure.DefinitionMessage,
+		&disclosure.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, disclosuresTable)
+	}
+	return &disclosure, nil
+}
+
+func (s *SQLCommon) GetDisclosureByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Disclosure, error) {
+	rows, _, err := s.Query(ctx, disclosuresTable,
+		sq.Select(disclosureColumns...).
+			From(disclosuresTable).
+			Where(sq.Eq{"id": id, "namespace": namespace}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Disclosure '%s' not found", id)
+		return nil, nil
+	}
+
+	return s.disclosureResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetDisclosures(ctx context.Context, namespace string, filter ffapi.Filter) (disclosures []*core.Disclosure, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(disclosureColumns...).From(disclosuresTable), filter, disclosureFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, disclosuresTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	disclosures = []*core.Disclosure{}
+	for rows.Next() {
+		disclosure, err := s.disclosureResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		disclosures = append(disclosures, disclosure)
+	}
+
+	return disclosures, s.QueryRes(ctx, disclosuresTable, tx, fop, nil, fi), err
+}
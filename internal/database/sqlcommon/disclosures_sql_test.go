@@ -0,0 +1,188 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisclosuresE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	disclosure1 := &core.Disclosure{
+		ID:                fftypes.NewUUID(),
+		Namespace:         "ns1",
+		Message:           fftypes.NewUUID(),
+		MessageHash:       fftypes.NewRandB32(),
+		Circuit:           "range",
+		PublicInputs:      fftypes.JSONAnyPtr(`{"limit":100}`),
+		Proof:             fftypes.JSONAnyPtr(`{"pi_a":["1","2"]}`),
+		Author:            "did:firefly:org/org1",
+		Status:            core.DisclosureStatusVerified,
+		DefinitionMessage: fftypes.NewUUID(),
+		Created:           fftypes.Now(),
+	}
+	err := s.InsertDisclosure(ctx, disclosure1)
+	assert.NoError(t, err)
+
+	disclosure2 := &core.Disclosure{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Message:     fftypes.NewUUID(),
+		MessageHash: fftypes.NewRandB32(),
+		Circuit:     "membership",
+		Proof:       fftypes.JSONAnyPtr(`{}`),
+		Status:      core.DisclosureStatusUnverified,
+		Created:     fftypes.Now(),
+	}
+	err = s.InsertDisclosure(ctx, disclosure2)
+	assert.NoError(t, err)
+
+	read, err := s.GetDisclosureByID(ctx, "ns1", disclosure1.ID)
+	assert.NoError(t, err)
+	disclosureJSON, _ := json.Marshal(disclosure1)
+	readJSON, _ := json.Marshal(read)
+	assert.Equal(t, string(disclosureJSON), string(readJSON))
+
+	read, err = s.GetDisclosureByID(ctx, "ns2", disclosure1.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, read)
+
+	fb := database.DisclosureQueryFactory.NewFilter(ctx)
+	disclosures, res, err := s.GetDisclosures(ctx, "ns1", fb.And().Sort("-created").Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	assert.Len(t, disclosures, 2)
+
+	disclosures, _, err = s.GetDisclosures(ctx, "ns1", fb.And(fb.Eq("message", disclosure2.Message)))
+	assert.NoError(t, err)
+	assert.Len(t, disclosures, 1)
+	assert.Equal(t, *disclosure2.ID, *disclosures[0].ID)
+
+	up := database.DisclosureQueryFactory.NewUpdate(ctx).Set("status", core.DisclosureStatusInvalid)
+	err = s.UpdateDisclosure(ctx, "ns1", disclosure2.ID, up)
+	assert.NoError(t, err)
+	read, err = s.GetDisclosureByID(ctx, "ns1", disclosure2.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, core.DisclosureStatusInvalid, read.Status)
+}
+
+func TestInsertDisclosureFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertDisclosure(context.Background(), &core.Disclosure{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertDisclosureFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertDisclosure(context.Background(), &core.Disclosure{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertDisclosureFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertDisclosure(context.Background(), &core.Disclosure{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDisclosureByIDQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetDisclosureByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDisclosureByIDReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetDisclosureByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDisclosuresQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.DisclosureQueryFactory.NewFilter(context.Background()).Eq("circuit", "range")
+	_, _, err := s.GetDisclosures(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDisclosuresBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.DisclosureQueryFactory.NewFilter(context.Background()).Eq("id", map[bool]bool{true: false})
+	_, _, err := s.GetDisclosures(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*id", err)
+}
+
+func TestGetDisclosuresReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	f := database.DisclosureQueryFactory.NewFilter(context.Background()).Eq("circuit", "range")
+	_, _, err := s.GetDisclosures(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateDisclosureBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	u := database.DisclosureQueryFactory.NewUpdate(context.Background()).Set("status", core.DisclosureStatusVerified)
+	err := s.UpdateDisclosure(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestUpdateDisclosureBuildQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	u := database.DisclosureQueryFactory.NewUpdate(context.Background()).Set("id", map[bool]bool{true: false})
+	err := s.UpdateDisclosure(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00143.*id", err)
+}
+
+func TestUpdateDisclosureFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	u := database.DisclosureQueryFactory.NewUpdate(context.Background()).Set("status", core.DisclosureStatusVerified)
+	err := s.UpdateDisclosure(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00178", err)
+}
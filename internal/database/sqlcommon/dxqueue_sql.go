@@ -0,0 +1,125 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	dxQueueColumns = []string{
+		"namespace",
+		"operation_id",
+		"priority",
+		"created",
+	}
+)
+
+const dxQueueTable = "dxqueue"
+
+func (s *SQLCommon) InsertDXQueueEntry(ctx context.Context, entry *core.DXQueueEntry) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	sequence, err := s.InsertTx(ctx, dxQueueTable, tx,
+		sq.Insert(dxQueueTable).
+			Columns(dxQueueColumns...).
+			Values(
+				entry.Namespace,
+				entry.Operation,
+				entry.Priority,
+				entry.Created,
+			),
+		nil, // no change events for the queue
+	)
+	if err != nil {
+		return err
+	}
+	entry.Sequence = sequence
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) dxQueueResult(ctx context.Context, row *sql.Rows) (*core.DXQueueEntry, error) {
+	entry := core.DXQueueEntry{}
+	err := row.Scan(
+		&entry.Namespace,
+		&entry.Operation,
+		&entry.Priority,
+		&entry.Created,
+		&entry.Sequence,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, dxQueueTable)
+	}
+	return &entry, nil
+}
+
+// GetDXQueueEntries returns the next sends to dispatch - highest priority first, then in the order they were queued
+func (s *SQLCommon) GetDXQueueEntries(ctx context.Context, namespace string, limit int) ([]*core.DXQueueEntry, error) {
+	cols := append([]string{}, dxQueueColumns...)
+	cols = append(cols, s.SequenceColumn())
+
+	rows, _, err := s.Query(ctx, dxQueueTable,
+		sq.Select(cols...).
+			From(dxQueueTable).
+			Where(sq.Eq{"namespace": namespace}).
+			OrderBy("priority", s.SequenceColumn()).
+			Limit(uint64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*core.DXQueueEntry{}
+	for rows.Next() {
+		entry, err := s.dxQueueResult(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (s *SQLCommon) DeleteDXQueueEntry(ctx context.Context, namespace string, sequence int64) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, dxQueueTable, tx, sq.Delete(dxQueueTable).Where(sq.Eq{
+		"namespace":        namespace,
+		s.SequenceColumn(): sequence,
+	}), nil /* no change events for the queue */)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,132 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDXQueueE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Queue entries out of priority order
+	blob := &core.DXQueueEntry{Namespace: "ns1", Operation: fftypes.NewUUID(), Priority: core.DXSendPriorityBlob, Created: fftypes.Now()}
+	msg1 := &core.DXQueueEntry{Namespace: "ns1", Operation: fftypes.NewUUID(), Priority: core.DXSendPriorityMessage, Created: fftypes.Now()}
+	def := &core.DXQueueEntry{Namespace: "ns1", Operation: fftypes.NewUUID(), Priority: core.DXSendPriorityDefinition, Created: fftypes.Now()}
+	msg2 := &core.DXQueueEntry{Namespace: "ns1", Operation: fftypes.NewUUID(), Priority: core.DXSendPriorityMessage, Created: fftypes.Now()}
+	other := &core.DXQueueEntry{Namespace: "ns2", Operation: fftypes.NewUUID(), Priority: core.DXSendPriorityDefinition, Created: fftypes.Now()}
+	for _, e := range []*core.DXQueueEntry{blob, msg1, def, msg2, other} {
+		err := s.InsertDXQueueEntry(ctx, e)
+		assert.NoError(t, err)
+	}
+
+	// Check we get them back by priority, then in the order they were queued
+	entries, err := s.GetDXQueueEntries(ctx, "ns1", 10)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+	for i, e := range []*core.DXQueueEntry{def, msg1, msg2, blob} {
+		assert.Equal(t, e.Operation, entries[i].Operation)
+		assert.Equal(t, e.Priority, entries[i].Priority)
+		assert.Equal(t, e.Sequence, entries[i].Sequence)
+	}
+
+	// Check the limit applies
+	entries, err = s.GetDXQueueEntries(ctx, "ns1", 1)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, def.Operation, entries[0].Operation)
+
+	// Delete the head, and check the next entry moves up
+	err = s.DeleteDXQueueEntry(ctx, "ns1", def.Sequence)
+	assert.NoError(t, err)
+	entries, err = s.GetDXQueueEntries(ctx, "ns1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, msg1.Operation, entries[0].Operation)
+
+	// Entries are scoped to the namespace
+	err = s.DeleteDXQueueEntry(ctx, "ns1", other.Sequence)
+	assert.Regexp(t, "FF00167", err)
+}
+
+func TestInsertDXQueueEntryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertDXQueueEntry(context.Background(), &core.DXQueueEntry{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertDXQueueEntryFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertDXQueueEntry(context.Background(), &core.DXQueueEntry{Operation: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertDXQueueEntryFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertDXQueueEntry(context.Background(), &core.DXQueueEntry{Operation: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDXQueueEntriesQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetDXQueueEntries(context.Background(), "ns1", 10)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDXQueueEntriesReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("only one"))
+	_, err := s.GetDXQueueEntries(context.Background(), "ns1", 10)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDXQueueEntryBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteDXQueueEntry(context.Background(), "ns1", 12345)
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestDeleteDXQueueEntryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteDXQueueEntry(context.Background(), "ns1", 12345)
+	assert.Regexp(t, "FF00179", err)
+}
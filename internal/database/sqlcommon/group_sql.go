@@ -39,9 +39,13 @@ var (
 		"name",
 		"hash",
 		"created",
+		"display_name",
+		"description",
+		"updated",
 	}
 	groupFilterFieldMap = map[string]string{
-		"message": "message_id",
+		"message":     "message_id",
+		"displayname": "display_name",
 	}
 )
 
@@ -110,6 +114,9 @@ func (s *SQLCommon) attemptGroupUpdate(ctx context.Context, tx *dbsql.TXWrapper,
 			Set("name", group.Name).
 			Set("hash", group.Hash).
 			Set("created", group.Created).
+			Set("display_name", group.DisplayName).
+			Set("description", group.Description).
+			Set("updated", group.Updated).
 			Where(sq.Eq{"hash": group.Hash, "namespace_local": group.LocalNamespace}),
 		func() {
 			s.callbacks.HashCollectionNSEvent(database.CollectionGroups, core.ChangeEventTypeUpdated, group.LocalNamespace, group.Hash)
@@ -128,6 +135,9 @@ func (s *SQLCommon) attemptGroupInsert(ctx context.Context, tx *dbsql.TXWrapper,
 				group.Name,
 				group.Hash,
 				group.Created,
+				group.DisplayName,
+				group.Description,
+				group.Updated,
 			),
 		func() {
 			s.callbacks.HashCollectionNSEvent(database.CollectionGroups, core.ChangeEventTypeCreated, group.LocalNamespace, group.Hash)
@@ -240,6 +250,9 @@ func (s *SQLCommon) groupResult(ctx context.Context, row *sql.Rows) (*core.Group
 		&group.Name,
 		&group.Hash,
 		&group.Created,
+		&group.DisplayName,
+		&group.Description,
+		&group.Updated,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, groupsTable)
@@ -52,6 +52,8 @@ func TestUpsertGroupE2EWithDB(t *testing.T) {
 		LocalNamespace: "ns1",
 		Hash:           groupHash,
 		Created:        fftypes.Now(),
+		DisplayName:    "Group One",
+		Description:    "The first group",
 	}
 
 	s.callbacks.On("HashCollectionNSEvent", database.CollectionGroups, core.ChangeEventTypeCreated, "ns1", groupHash, mock.Anything).Return()
@@ -79,6 +81,9 @@ func TestUpsertGroupE2EWithDB(t *testing.T) {
 		Created:        fftypes.Now(),
 		Message:        fftypes.NewUUID(),
 		Hash:           groupHash,
+		DisplayName:    "Group One Renamed",
+		Description:    "The first group, renamed",
+		Updated:        fftypes.Now(),
 	}
 
 	err = s.UpsertGroup(context.Background(), groupUpdated, database.UpsertOptimizationExisting)
@@ -96,6 +101,7 @@ func TestUpsertGroupE2EWithDB(t *testing.T) {
 	filter := fb.And(
 		fb.Eq("hash", groupUpdated.Hash),
 		fb.Eq("message", groupUpdated.Message),
+		fb.Eq("displayname", "Group One Renamed"),
 		fb.Gt("created", "0"),
 	)
 	groups, _, err := s.GetGroups(ctx, "ns1", filter)
@@ -332,7 +338,7 @@ func TestGetGroupByIDLoadMembersFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	groupID := fftypes.NewRandB32()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(groupColumns).
-		AddRow(nil, "ns1", "ns1", "name1", fftypes.NewRandB32(), fftypes.Now()))
+		AddRow(nil, "ns1", "ns1", "name1", fftypes.NewRandB32(), fftypes.Now(), "", "", nil))
 	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
 	_, err := s.GetGroupByHash(context.Background(), "ns1", groupID)
 	assert.Regexp(t, "FF00176", err)
@@ -367,7 +373,7 @@ func TestGetGroupsReadGroupFail(t *testing.T) {
 func TestGetGroupsLoadMembersFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(groupColumns).
-		AddRow(nil, "ns1", "ns1", "group1", fftypes.NewRandB32(), fftypes.Now()))
+		AddRow(nil, "ns1", "ns1", "group1", fftypes.NewRandB32(), fftypes.Now(), "", "", nil))
 	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
 	f := database.GroupQueryFactory.NewFilter(context.Background()).Gt("created", "0")
 	_, _, err := s.GetGroups(context.Background(), "ns1", f)
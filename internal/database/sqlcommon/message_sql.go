@@ -57,17 +57,22 @@ var (
 		"tx_parent_id",
 		"batch_id",
 		"idempotency_key",
+		"trace",
+		"context_qualifier",
+		"tx_labels",
 	}
 	msgFilterFieldMap = map[string]string{
-		"type":           "mtype",
-		"txtype":         "tx_type",
-		"txid":           "tx_id",
-		"txparent.type":  "tx_parent_type",
-		"txparent.id":    "tx_parent_id",
-		"batch":          "batch_id",
-		"group":          "group_hash",
-		"idempotencykey": "idempotency_key",
-		"rejectreason":   "reject_reason",
+		"type":             "mtype",
+		"txtype":           "tx_type",
+		"txid":             "tx_id",
+		"txparent.type":    "tx_parent_type",
+		"txparent.id":      "tx_parent_id",
+		"batch":            "batch_id",
+		"group":            "group_hash",
+		"idempotencykey":   "idempotency_key",
+		"rejectreason":     "reject_reason",
+		"contextqualifier": "context_qualifier",
+		"txlabels":         "tx_labels",
 	}
 )
 
@@ -104,6 +109,9 @@ func (s *SQLCommon) attemptMessageUpdate(ctx context.Context, tx *dbsql.TXWrappe
 			Set("tx_parent_id", txParentID).
 			Set("batch_id", message.BatchID).
 			Set("idempotency_key", message.IdempotencyKey).
+			Set("trace", message.Trace).
+			Set("context_qualifier", message.Header.ContextQualifier).
+			Set("tx_labels", message.TxLabels).
 			Where(sq.Eq{
 				"id":              message.Header.ID,
 				"hash":            message.Hash,
@@ -147,6 +155,9 @@ func (s *SQLCommon) setMessageInsertValues(query sq.InsertBuilder, message *core
 		txParentID,
 		message.BatchID,
 		message.IdempotencyKey,
+		message.Trace,
+		message.Header.ContextQualifier,
+		message.TxLabels,
 	)
 }
 
@@ -461,6 +472,9 @@ func (s *SQLCommon) msgResult(ctx context.Context, row *sql.Rows) (*core.Message
 		&txParent.ID,
 		&msg.BatchID,
 		&msg.IdempotencyKey,
+		&msg.Trace,
+		&msg.Header.ContextQualifier,
+		&msg.TxLabels,
 		// Must be added to the list of columns in all selects
 		&msg.Sequence,
 	)
@@ -568,6 +582,20 @@ func (s *SQLCommon) GetBatchIDsForDataAttachments(ctx context.Context, namespace
 	return s.queryBatchIDs(ctx, query)
 }
 
+func (s *SQLCommon) GetBatchIDsForDataHash(ctx context.Context, namespace string, dataHash *fftypes.Bytes32) (batchIDs []*fftypes.UUID, err error) {
+	query := sq.Select("m.batch_id").Distinct().From("messages_data AS md").LeftJoin("messages AS m ON m.id = md.message_id").
+		Where(sq.Eq{"md.data_hash": dataHash, "md.namespace": namespace})
+	return s.queryBatchIDs(ctx, query)
+}
+
+func (s *SQLCommon) GetBatchIDsForBlobHash(ctx context.Context, namespace string, blobHash *fftypes.Bytes32) (batchIDs []*fftypes.UUID, err error) {
+	query := sq.Select("m.batch_id").Distinct().From("data AS d").
+		Join("messages_data AS md ON md.data_id = d.id AND md.namespace = d.namespace").
+		LeftJoin("messages AS m ON m.id = md.message_id").
+		Where(sq.Eq{"d.blob_hash": blobHash, "d.namespace": namespace})
+	return s.queryBatchIDs(ctx, query)
+}
+
 func (s *SQLCommon) GetBatchIDsForMessages(ctx context.Context, namespace string, msgIDs []*fftypes.UUID) (batchIDs []*fftypes.UUID, err error) {
 	return s.queryBatchIDs(ctx, sq.Select("batch_id").From(messagesTable).
 		Where(sq.Eq{"id": msgIDs, "namespace_local": namespace}))
@@ -610,15 +638,33 @@ func (s *SQLCommon) GetMessages(ctx context.Context, namespace string, filter ff
 }
 
 func (s *SQLCommon) GetMessagesForData(ctx context.Context, namespace string, dataID *fftypes.UUID, filter ffapi.Filter) (message []*core.Message, fr *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(
+		ctx, "m", sq.Select(s.prefixedMsgColumns()...).From("messages_data AS md"),
+		filter, msgFilterFieldMap, []interface{}{"sequence"},
+		sq.Eq{"md.data_id": dataID, "md.namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query = query.LeftJoin("messages AS m ON m.id = md.message_id")
+	return s.getMessagesQuery(ctx, namespace, query, fop, fi, false)
+}
+
+func (s *SQLCommon) prefixedMsgColumns() []string {
 	cols := make([]string, len(msgColumns)+1)
 	for i, col := range msgColumns {
 		cols[i] = fmt.Sprintf("m.%s", col)
 	}
 	cols[len(msgColumns)] = "m.seq"
+	return cols
+}
+
+func (s *SQLCommon) GetMessagesForDataHash(ctx context.Context, namespace string, dataHash *fftypes.Bytes32, filter ffapi.Filter) (message []*core.Message, fr *ffapi.FilterResult, err error) {
+	// A message can reference the same data more than once, so the results are made distinct
 	query, fop, fi, err := s.FilterSelect(
-		ctx, "m", sq.Select(cols...).From("messages_data AS md"),
+		ctx, "m", sq.Select(s.prefixedMsgColumns()...).Distinct().From("messages_data AS md"),
 		filter, msgFilterFieldMap, []interface{}{"sequence"},
-		sq.Eq{"md.data_id": dataID, "md.namespace": namespace})
+		sq.Eq{"md.data_hash": dataHash, "md.namespace": namespace})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -627,6 +673,21 @@ func (s *SQLCommon) GetMessagesForData(ctx context.Context, namespace string, da
 	return s.getMessagesQuery(ctx, namespace, query, fop, fi, false)
 }
 
+func (s *SQLCommon) GetMessagesForBlobHash(ctx context.Context, namespace string, blobHash *fftypes.Bytes32, filter ffapi.Filter) (message []*core.Message, fr *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(
+		ctx, "m", sq.Select(s.prefixedMsgColumns()...).Distinct().From("data AS d"),
+		filter, msgFilterFieldMap, []interface{}{"sequence"},
+		sq.Eq{"d.blob_hash": blobHash, "d.namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query = query.
+		Join("messages_data AS md ON md.data_id = d.id AND md.namespace = d.namespace").
+		LeftJoin("messages AS m ON m.id = md.message_id")
+	return s.getMessagesQuery(ctx, namespace, query, fop, fi, false)
+}
+
 func (s *SQLCommon) UpdateMessage(ctx context.Context, namespace string, msgid *fftypes.UUID, update ffapi.Update) (err error) {
 	return s.UpdateMessages(ctx, namespace, database.MessageQueryFactory.NewFilter(ctx).Eq("id", msgid), update)
 }
@@ -106,13 +106,14 @@ func TestUpsertE2EWithDB(t *testing.T) {
 				Key:    "0x12345",
 				Author: "did:firefly:org/abcd",
 			},
-			Created:   fftypes.Now(),
-			Namespace: "ns12345",
-			Topics:    []string{"topic1", "topic2"},
-			Tag:       "tag_1",
-			Group:     gid,
-			DataHash:  fftypes.NewRandB32(),
-			TxType:    core.TransactionTypeBatchPin,
+			Created:          fftypes.Now(),
+			Namespace:        "ns12345",
+			Topics:           []string{"topic1", "topic2"},
+			Tag:              "tag_1",
+			Group:            gid,
+			ContextQualifier: "app1",
+			DataHash:         fftypes.NewRandB32(),
+			TxType:           core.TransactionTypeBatchPin,
 			TxParent: &core.TransactionRef{
 				Type: core.TransactionTypeTokenTransfer,
 				ID:   fftypes.NewUUID(),
@@ -124,6 +125,8 @@ func TestUpsertE2EWithDB(t *testing.T) {
 		Confirmed:      fftypes.Now(),
 		BatchID:        bid,
 		IdempotencyKey: "myBusinessIdentifier",
+		TxLabels:       fftypes.FFStringArray{"payroll"},
+		Trace:          true,
 		Data: []*core.DataRef{
 			{ID: dataID1, Hash: rand1},
 			{ID: dataID2, Hash: rand2}, // Note the data refs cannot change, as it would affect the hash, and the hash is immutable
@@ -200,6 +203,37 @@ func TestUpsertE2EWithDB(t *testing.T) {
 	msgReadJson, _ = json.Marshal(msgs[0])
 	assert.Equal(t, string(msgJson), string(msgReadJson))
 
+	// Check we can find it by the hash of a data reference
+	msgs, _, err = s.GetMessagesForDataHash(ctx, "ns12345", rand2, filter.Count(false))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(msgs))
+	assert.Equal(t, *msgID, *msgs[0].Header.ID)
+	batchIDs, err = s.GetBatchIDsForDataHash(ctx, "ns12345", rand2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*fftypes.UUID{msgUpdated.BatchID}, batchIDs)
+
+	// Check we can find it by the hash of a blob attached to referenced data
+	blobHash := fftypes.NewRandB32()
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionData, core.ChangeEventTypeCreated, "ns12345", dataID2, mock.Anything).Return()
+	err = s.UpsertData(ctx, &core.Data{
+		ID:        dataID2,
+		Namespace: "ns12345",
+		Hash:      rand2,
+		Created:   fftypes.Now(),
+		Blob:      &core.BlobRef{Hash: blobHash},
+	}, database.UpsertOptimizationNew)
+	assert.NoError(t, err)
+	msgs, _, err = s.GetMessagesForBlobHash(ctx, "ns12345", blobHash, filter.Count(false))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(msgs))
+	assert.Equal(t, *msgID, *msgs[0].Header.ID)
+	batchIDs, err = s.GetBatchIDsForBlobHash(ctx, "ns12345", blobHash)
+	assert.NoError(t, err)
+	assert.Equal(t, []*fftypes.UUID{msgUpdated.BatchID}, batchIDs)
+	msgs, _, err = s.GetMessagesForBlobHash(ctx, "ns12345", fftypes.NewRandB32(), filter.Count(false))
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+
 	// Negative test on filter
 	filter = fb.And(
 		fb.Eq("id", msgUpdated.Header.ID.String()),
@@ -607,6 +641,22 @@ func TestGetMessagesForDataBadQuery(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetMessagesForDataHashBadQuery(t *testing.T) {
+	s, mock := newMockProvider().init()
+	f := database.MessageQueryFactory.NewFilter(context.Background()).Eq("!wrong", "")
+	_, _, err := s.GetMessagesForDataHash(context.Background(), "ns1", fftypes.NewRandB32(), f)
+	assert.Regexp(t, "FF00142", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessagesForBlobHashBadQuery(t *testing.T) {
+	s, mock := newMockProvider().init()
+	f := database.MessageQueryFactory.NewFilter(context.Background()).Eq("!wrong", "")
+	_, _, err := s.GetMessagesForBlobHash(context.Background(), "ns1", fftypes.NewRandB32(), f)
+	assert.Regexp(t, "FF00142", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetMessagesReadMessageFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
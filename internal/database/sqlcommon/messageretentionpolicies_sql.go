@@ -0,0 +1,184 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	msgRetentionPolicyColumns = []string{
+		"id",
+		"namespace",
+		"name",
+		"topic",
+		"tag",
+		"max_age",
+		"checkpoint",
+		"created",
+		"updated",
+	}
+	msgRetentionPolicyFilterFieldMap = map[string]string{
+		"maxage": "max_age",
+	}
+)
+
+const messageRetentionPoliciesTable = "messageretentionpolicies"
+
+func (s *SQLCommon) InsertMessageRetentionPolicy(ctx context.Context, policy *core.MessageRetentionPolicy) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	_, err = s.InsertTx(ctx, messageRetentionPoliciesTable, tx,
+		sq.Insert(messageRetentionPoliciesTable).
+			Columns(msgRetentionPolicyColumns...).
+			Values(
+				policy.ID,
+				policy.Namespace,
+				policy.Name,
+				policy.Topic,
+				policy.Tag,
+				policy.MaxAge,
+				policy.Checkpoint,
+				policy.Created,
+				policy.Updated,
+			),
+		nil, // no change events for message retention policies
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) msgRetentionPolicyResult(ctx context.Context, row *sql.Rows) (*core.MessageRetentionPolicy, error) {
+	var policy core.MessageRetentionPolicy
+	var maxAge fftypes.FFDuration
+	err := row.Scan(
+		&policy.ID,
+		&policy.Namespace,
+		&policy.Name,
+		&policy.Topic,
+		&policy.Tag,
+		&maxAge,
+		&policy.Checkpoint,
+		&policy.Created,
+		&policy.Updated,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, messageRetentionPoliciesTable)
+	}
+	policy.MaxAge = &maxAge
+	return &policy, nil
+}
+
+func (s *SQLCommon) GetMessageRetentionPolicyByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.MessageRetentionPolicy, error) {
+	rows, _, err := s.Query(ctx, messageRetentionPoliciesTable,
+		sq.Select(msgRetentionPolicyColumns...).
+			From(messageRetentionPoliciesTable).
+			Where(sq.Eq{"id": id, "namespace": namespace}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Message retention policy '%s' not found", id)
+		return nil, nil
+	}
+
+	return s.msgRetentionPolicyResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetMessageRetentionPolicies(ctx context.Context, namespace string, filter ffapi.Filter) (policies []*core.MessageRetentionPolicy, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(msgRetentionPolicyColumns...).From(messageRetentionPoliciesTable), filter, msgRetentionPolicyFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, messageRetentionPoliciesTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	policies = []*core.MessageRetentionPolicy{}
+	for rows.Next() {
+		policy, err := s.msgRetentionPolicyResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, s.QueryRes(ctx, messageRetentionPoliciesTable, tx, fop, nil, fi), err
+}
+
+func (s *SQLCommon) UpdateMessageRetentionPolicy(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	query, err := s.BuildUpdate(sq.Update(messageRetentionPoliciesTable), update, msgRetentionPolicyFilterFieldMap)
+	if err != nil {
+		return err
+	}
+	query = query.Set("updated", fftypes.Now())
+	query = query.Where(sq.Eq{"id": id, "namespace": namespace})
+
+	_, err = s.UpdateTx(ctx, messageRetentionPoliciesTable, tx, query, nil /* no change events for message retention policies */)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) DeleteMessageRetentionPolicy(ctx context.Context, namespace string, id *fftypes.UUID) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, messageRetentionPoliciesTable, tx,
+		sq.Delete(messageRetentionPoliciesTable).Where(sq.Eq{"id": id, "namespace": namespace}),
+		nil, // no change events for message retention policies
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
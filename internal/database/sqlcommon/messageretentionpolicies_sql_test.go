@@ -0,0 +1,173 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageRetentionPoliciesE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	maxAge := fftypes.FFDuration(72 * time.Hour)
+	policy := &core.MessageRetentionPolicy{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      "heartbeats",
+		Topic:     "heartbeat",
+		MaxAge:    &maxAge,
+		Created:   fftypes.Now(),
+	}
+	err := s.InsertMessageRetentionPolicy(ctx, policy)
+	assert.NoError(t, err)
+
+	read, err := s.GetMessageRetentionPolicyByID(ctx, "ns1", policy.ID)
+	assert.NoError(t, err)
+	policyJSON, _ := json.Marshal(policy)
+	readJSON, _ := json.Marshal(read)
+	assert.Equal(t, string(policyJSON), string(readJSON))
+
+	up := database.MessageRetentionPolicyQueryFactory.NewUpdate(ctx).
+		Set("maxage", "24h").
+		Set("checkpoint", int64(12345))
+	err = s.UpdateMessageRetentionPolicy(ctx, "ns1", policy.ID, up)
+	assert.NoError(t, err)
+
+	fb := database.MessageRetentionPolicyQueryFactory.NewFilter(ctx)
+	policies, res, err := s.GetMessageRetentionPolicies(ctx, "ns1", fb.And(fb.Eq("name", "heartbeats")).Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *res.TotalCount)
+	assert.Len(t, policies, 1)
+	assert.Equal(t, fftypes.FFDuration(24*time.Hour), *policies[0].MaxAge)
+	assert.Equal(t, int64(12345), policies[0].Checkpoint)
+	assert.NotNil(t, policies[0].Updated)
+
+	read, err = s.GetMessageRetentionPolicyByID(ctx, "ns2", policy.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, read)
+
+	err = s.DeleteMessageRetentionPolicy(ctx, "ns1", policy.ID)
+	assert.NoError(t, err)
+	read, err = s.GetMessageRetentionPolicyByID(ctx, "ns1", policy.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, read)
+}
+
+func TestInsertMessageRetentionPolicyFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertMessageRetentionPolicy(context.Background(), &core.MessageRetentionPolicy{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertMessageRetentionPolicyFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertMessageRetentionPolicy(context.Background(), &core.MessageRetentionPolicy{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessageRetentionPolicyByIDQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetMessageRetentionPolicyByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessageRetentionPoliciesQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.MessageRetentionPolicyQueryFactory.NewFilter(context.Background()).Eq("topic", "heartbeat")
+	_, _, err := s.GetMessageRetentionPolicies(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessageRetentionPoliciesBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.MessageRetentionPolicyQueryFactory.NewFilter(context.Background()).Eq("id", map[bool]bool{true: false})
+	_, _, err := s.GetMessageRetentionPolicies(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*id", err)
+}
+
+func TestGetMessageRetentionPoliciesReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	f := database.MessageRetentionPolicyQueryFactory.NewFilter(context.Background()).Eq("topic", "heartbeat")
+	_, _, err := s.GetMessageRetentionPolicies(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateMessageRetentionPolicyBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	u := database.MessageRetentionPolicyQueryFactory.NewUpdate(context.Background()).Set("checkpoint", 1)
+	err := s.UpdateMessageRetentionPolicy(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestUpdateMessageRetentionPolicyBuildQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	u := database.MessageRetentionPolicyQueryFactory.NewUpdate(context.Background()).Set("id", map[bool]bool{true: false})
+	err := s.UpdateMessageRetentionPolicy(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00143.*id", err)
+}
+
+func TestUpdateMessageRetentionPolicyFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	u := database.MessageRetentionPolicyQueryFactory.NewUpdate(context.Background()).Set("checkpoint", 1)
+	err := s.UpdateMessageRetentionPolicy(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00178", err)
+}
+
+func TestDeleteMessageRetentionPolicyBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteMessageRetentionPolicy(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestDeleteMessageRetentionPolicyFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteMessageRetentionPolicy(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+}
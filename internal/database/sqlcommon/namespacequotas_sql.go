@@ -0,0 +1,106 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	namespaceQuotasColumns = []string{
+		"namespace",
+		"limits",
+		"updated",
+	}
+)
+
+const namespaceQuotasTable = "namespacequotas"
+
+// UpsertNamespaceQuotas stores the quota limits set for a namespace, replacing any previous limits
+func (s *SQLCommon) UpsertNamespaceQuotas(ctx context.Context, namespace string, limits *core.NamespaceQuotas) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	rows, _, err := s.QueryTx(ctx, namespaceQuotasTable, tx,
+		sq.Select("seq").
+			From(namespaceQuotasTable).
+			Where(sq.Eq{"namespace": namespace}),
+	)
+	if err != nil {
+		return err
+	}
+	existing := rows.Next()
+	rows.Close()
+
+	if existing {
+		if _, err = s.UpdateTx(ctx, namespaceQuotasTable, tx,
+			sq.Update(namespaceQuotasTable).
+				Set("limits", limits).
+				Set("updated", fftypes.Now()).
+				Where(sq.Eq{"namespace": namespace}),
+			nil, // no change events for namespace quotas
+		); err != nil {
+			return err
+		}
+	} else {
+		if _, err = s.InsertTx(ctx, namespaceQuotasTable, tx,
+			sq.Insert(namespaceQuotasTable).
+				Columns(namespaceQuotasColumns...).
+				Values(
+					namespace,
+					limits,
+					fftypes.Now(),
+				),
+			nil, // no change events for namespace quotas
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+// GetNamespaceQuotas returns the quota limits stored for a namespace, or nil if none have been set
+func (s *SQLCommon) GetNamespaceQuotas(ctx context.Context, namespace string) (*core.NamespaceQuotas, error) {
+	rows, _, err := s.Query(ctx, namespaceQuotasTable,
+		sq.Select("limits").
+			From(namespaceQuotasTable).
+			Where(sq.Eq{"namespace": namespace}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+	var limits core.NamespaceQuotas
+	if err := rows.Scan(&limits); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, namespaceQuotasTable)
+	}
+	return &limits, nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceQuotasE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Nothing stored yet
+	limits, err := s.GetNamespaceQuotas(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Nil(t, limits)
+
+	// Store the initial limits
+	limits1 := &core.NamespaceQuotas{MessagesPerDay: 10, BlobBytes: 1024}
+	err = s.UpsertNamespaceQuotas(ctx, "ns1", limits1)
+	assert.NoError(t, err)
+	limits, err = s.GetNamespaceQuotas(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, limits1, limits)
+
+	// Replace them
+	limits2 := &core.NamespaceQuotas{Subscriptions: 5}
+	err = s.UpsertNamespaceQuotas(ctx, "ns1", limits2)
+	assert.NoError(t, err)
+	limits, err = s.GetNamespaceQuotas(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, limits2, limits)
+
+	// Limits are scoped to the namespace
+	limits, err = s.GetNamespaceQuotas(ctx, "ns2")
+	assert.NoError(t, err)
+	assert.Nil(t, limits)
+}
+
+func TestUpsertNamespaceQuotasFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpsertNamespaceQuotas(context.Background(), "ns1", &core.NamespaceQuotas{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertNamespaceQuotasFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertNamespaceQuotas(context.Background(), "ns1", &core.NamespaceQuotas{})
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertNamespaceQuotasFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertNamespaceQuotas(context.Background(), "ns1", &core.NamespaceQuotas{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertNamespaceQuotasFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(1))
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertNamespaceQuotas(context.Background(), "ns1", &core.NamespaceQuotas{})
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetNamespaceQuotasQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetNamespaceQuotas(context.Background(), "ns1")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetNamespaceQuotasReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"limits"}).AddRow("!json"))
+	_, err := s.GetNamespaceQuotas(context.Background(), "ns1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
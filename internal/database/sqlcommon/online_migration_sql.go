@@ -0,0 +1,439 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// SequenceResetProvider is implemented by providers whose sequence generators are not advanced when rows
+// are inserted with an explicit sequence, so must be reset after an online migration swaps a table into place
+type SequenceResetProvider interface {
+	ResetSequenceStatement(table string) string
+}
+
+// ChangeTrackingProvider is implemented by providers that support online migrations. The create statements add a
+// changelog table, with an "id" column that increases with every entry and a sequence column, and triggers that
+// record in it the sequence of every row inserted, updated or deleted in the table. The lock statement blocks every
+// writer to the table, while still allowing reads, until the end of the transaction.
+type ChangeTrackingProvider interface {
+	ChangeTrackingStatements(table, changelog string) (create []string, drop []string)
+	LockTableStatement(table string) string
+}
+
+type onlineMigrationConfig struct {
+	directory     string
+	batchSize     int
+	resetSequence func(table string) string
+	tracking      ChangeTrackingProvider
+}
+
+// onlineMigrationDefinition is read from a JSON file in the online migrations directory, with the file
+// name being the name of the migration. The create statements build the new version of the table under
+// the name "<table>_online", and the listed columns are copied into it. After the swap, the old table
+// remains as "<table>_premigration" until dropped by one of the finalize statements.
+//
+// Rows are copied in order of sequence, and every row inserted, updated or deleted after the migration starts
+// is recorded in the "<table>_onlinechanges" changelog and copied again. The copied columns must be comparable
+// between the two tables, as the content of the tables is compared before the swap.
+type onlineMigrationDefinition struct {
+	Table    string   `json:"table"`
+	Columns  []string `json:"columns"`
+	Create   []string `json:"create"`
+	Finalize []string `json:"finalize"`
+}
+
+const onlineMigrationsTable = "onlinemigrations"
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (s *SQLCommon) onlineMigrationDefinitions(ctx context.Context) (map[string]*onlineMigrationDefinition, []string, error) {
+	defs := make(map[string]*onlineMigrationDefinition)
+	files, err := os.ReadDir(s.online.directory)
+	if os.IsNotExist(err) {
+		return defs, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	var names []string
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		name := strings.TrimSuffix(f.Name(), ".json")
+		b, err := os.ReadFile(filepath.Join(s.online.directory, f.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		var def onlineMigrationDefinition
+		if err := json.Unmarshal(b, &def); err != nil {
+			return nil, nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationBadDefinition, name, err)
+		}
+		if def.Table == "" || len(def.Create) == 0 || !containsString(def.Columns, s.SequenceColumn()) {
+			return nil, nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationBadDefinition, name, "table, create and columns (including the sequence column) are required")
+		}
+		defs[name] = &def
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return defs, names, nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SQLCommon) onlineMigrationDefinition(ctx context.Context, name string) (*onlineMigrationDefinition, error) {
+	defs, _, err := s.onlineMigrationDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	def, ok := defs[name]
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationNotFound, name)
+	}
+	return def, nil
+}
+
+// onlineMigrationState reads the progress of a migration, returning false if it has not been started
+func (s *SQLCommon) onlineMigrationState(ctx context.Context, db queryRower, name string, def *onlineMigrationDefinition) (*core.OnlineMigration, bool, error) {
+	query, args, _ := sq.Select("status", "backfill_seq", "backfill_rows", "updated").
+		From(onlineMigrationsTable).
+		Where(sq.Eq{"name": name}).
+		PlaceholderFormat(s.Features().PlaceholderFormat).
+		ToSql()
+	migration := &core.OnlineMigration{
+		Name:   name,
+		Table:  def.Table,
+		Status: core.OnlineMigrationStatusPending,
+	}
+	err := db.QueryRowContext(ctx, query, args...).Scan(&migration.Status, &migration.Sequence, &migration.Rows, &migration.Updated)
+	if err == sql.ErrNoRows {
+		return migration, false, nil
+	} else if err != nil {
+		return nil, false, i18n.WrapError(ctx, err, coremsgs.MsgDBQueryFailed)
+	}
+	return migration, true, nil
+}
+
+func (s *SQLCommon) saveOnlineMigrationState(ctx context.Context, tx *sql.Tx, migration *core.OnlineMigration, exists bool) error {
+	migration.Updated = fftypes.Now()
+	var query string
+	var args []interface{}
+	if exists {
+		query, args, _ = sq.Update(onlineMigrationsTable).
+			Set("status", migration.Status).
+			Set("backfill_seq", migration.Sequence).
+			Set("backfill_rows", migration.Rows).
+			Set("updated", migration.Updated).
+			Where(sq.Eq{"name": migration.Name}).
+			PlaceholderFormat(s.Features().PlaceholderFormat).
+			ToSql()
+	} else {
+		query, args, _ = sq.Insert(onlineMigrationsTable).
+			Columns("name", "tbl", "status", "backfill_seq", "backfill_rows", "updated").
+			Values(migration.Name, migration.Table, migration.Status, migration.Sequence, migration.Rows, migration.Updated).
+			PlaceholderFormat(s.Features().PlaceholderFormat).
+			ToSql()
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgDBUpdateFailed)
+	}
+	return nil
+}
+
+func (s *SQLCommon) GetOnlineMigrations(ctx context.Context) ([]*core.OnlineMigration, error) {
+	defs, names, err := s.onlineMigrationDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	migrations := make([]*core.OnlineMigration, len(names))
+	for i, name := range names {
+		if migrations[i], _, err = s.onlineMigrationState(ctx, s.DB(), name, defs[name]); err != nil {
+			return nil, err
+		}
+	}
+	return migrations, nil
+}
+
+// copyOnlineMigrationRows copies rows above the given sequence into the new table, up to the limit if non-zero,
+// returning the number of rows copied and the highest sequence copied
+func (s *SQLCommon) copyOnlineMigrationRows(ctx context.Context, tx *sql.Tx, def *onlineMigrationDefinition, after int64, limit uint64) (int64, int64, error) {
+	seqCol := s.SequenceColumn()
+	batch := sq.Select(seqCol).From(def.Table).Where(sq.Gt{seqCol: after}).OrderBy(seqCol)
+	if limit > 0 {
+		batch = batch.Limit(limit)
+	}
+	query, args, _ := sq.Select("COALESCE(MAX("+seqCol+"), 0)", "COUNT(*)").
+		FromSelect(batch, "batch").
+		PlaceholderFormat(s.Features().PlaceholderFormat).
+		ToSql()
+	var to, count int64
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&to, &count); err != nil {
+		return 0, after, i18n.WrapError(ctx, err, coremsgs.MsgDBQueryFailed)
+	}
+	if count == 0 {
+		return 0, after, nil
+	}
+	query, args, _ = sq.Insert(def.Table + "_online").
+		Columns(def.Columns...).
+		Select(sq.Select(def.Columns...).From(def.Table).Where(sq.And{sq.Gt{seqCol: after}, sq.LtOrEq{seqCol: to}})).
+		PlaceholderFormat(s.Features().PlaceholderFormat).
+		ToSql()
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return 0, after, i18n.WrapError(ctx, err, coremsgs.MsgDBInsertFailed)
+	}
+	return count, to, nil
+}
+
+// copyOnlineMigrationChanges copies again the rows recorded in the changelog since they were last copied, up to the
+// limit if non-zero, returning the number of changelog entries applied. Only rows at or below the given sequence
+// are copied, as rows above it are still to be copied by the backfill.
+func (s *SQLCommon) copyOnlineMigrationChanges(ctx context.Context, tx *sql.Tx, def *onlineMigrationDefinition, upTo int64, limit uint64) (int64, error) {
+	seqCol := s.SequenceColumn()
+	changelog := def.Table + "_onlinechanges"
+	changes := sq.Select("id", seqCol).From(changelog).OrderBy("id")
+	if limit > 0 {
+		changes = changes.Limit(limit)
+	}
+	query, args, _ := changes.PlaceholderFormat(s.Features().PlaceholderFormat).ToSql()
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBQueryFailed)
+	}
+	ids := []int64{}
+	seqs := []int64{}
+	for rows.Next() {
+		var id, seq int64
+		if err := rows.Scan(&id, &seq); err != nil {
+			rows.Close()
+			return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, changelog)
+		}
+		ids = append(ids, id)
+		seqs = append(seqs, seq)
+	}
+	rows.Close()
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// Entries are removed by ID, so any recorded by writers that commit during this step are kept for the next
+	newTable := def.Table + "_online"
+	query, args, _ = sq.Delete(newTable).
+		Where(sq.Eq{seqCol: seqs}).
+		PlaceholderFormat(s.Features().PlaceholderFormat).
+		ToSql()
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBDeleteFailed)
+	}
+	query, args, _ = sq.Insert(newTable).
+		Columns(def.Columns...).
+		Select(sq.Select(def.Columns...).From(def.Table).Where(sq.And{sq.Eq{seqCol: seqs}, sq.LtOrEq{seqCol: upTo}})).
+		PlaceholderFormat(s.Features().PlaceholderFormat).
+		ToSql()
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBInsertFailed)
+	}
+	query, args, _ = sq.Delete(changelog).
+		Where(sq.Eq{"id": ids}).
+		PlaceholderFormat(s.Features().PlaceholderFormat).
+		ToSql()
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBDeleteFailed)
+	}
+	return int64(len(ids)), nil
+}
+
+// countOnlineMigrationDifferences counts the rows of one table that have no identical row in the other
+func (s *SQLCommon) countOnlineMigrationDifferences(ctx context.Context, tx *sql.Tx, def *onlineMigrationDefinition, from, other string) (int64, error) {
+	query, args, _ := sq.Select("COUNT(*)").
+		FromSelect(sq.Select(def.Columns...).From(from).Suffix("EXCEPT SELECT "+strings.Join(def.Columns, ", ")+" FROM "+other), "diff").
+		PlaceholderFormat(s.Features().PlaceholderFormat).
+		ToSql()
+	var count int64
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBQueryFailed)
+	}
+	return count, nil
+}
+
+func (s *SQLCommon) execStatements(ctx context.Context, tx *sql.Tx, statements []string) error {
+	for _, stmt := range statements {
+		log.L(ctx).Debugf("SQL-> online migration: %s", stmt)
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return i18n.WrapError(ctx, err, coremsgs.MsgOnlineMigrationStatementFailed, stmt)
+		}
+	}
+	return nil
+}
+
+// RunOnlineMigrationStep performs one small step of an online migration, each in its own transaction so that
+// locks are only held briefly: first creating the new table and the changelog, then copying one batch of rows
+// per step until the backfill has caught up, and then one batch of changed rows per step until the changelog
+// has caught up.
+func (s *SQLCommon) RunOnlineMigrationStep(ctx context.Context, name string) (*core.OnlineMigration, error) {
+	def, err := s.onlineMigrationDefinition(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.online.tracking == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationNotSupported)
+	}
+	tx, err := s.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBBeginFailed)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	migration, exists, err := s.onlineMigrationState(ctx, tx, name, def)
+	if err != nil {
+		return nil, err
+	}
+	switch migration.Status {
+	case core.OnlineMigrationStatusPending:
+		log.L(ctx).Infof("Online migration '%s' creating new table for '%s'", name, def.Table)
+		create, _ := s.online.tracking.ChangeTrackingStatements(def.Table, def.Table+"_onlinechanges")
+		if err := s.execStatements(ctx, tx, append(append([]string{}, def.Create...), create...)); err != nil {
+			return nil, err
+		}
+		migration.Status = core.OnlineMigrationStatusBackfilling
+	case core.OnlineMigrationStatusBackfilling:
+		count, to, err := s.copyOnlineMigrationRows(ctx, tx, def, migration.Sequence, uint64(s.online.batchSize))
+		if err != nil {
+			return nil, err
+		}
+		migration.Rows += count
+		migration.Sequence = to
+		log.L(ctx).Debugf("Online migration '%s' copied %d rows of '%s' up to sequence %d", name, count, def.Table, to)
+		if count < int64(s.online.batchSize) {
+			changes, err := s.copyOnlineMigrationChanges(ctx, tx, def, to, uint64(s.online.batchSize))
+			if err != nil {
+				return nil, err
+			}
+			log.L(ctx).Debugf("Online migration '%s' applied %d changes to '%s'", name, changes, def.Table)
+			if changes < int64(s.online.batchSize) {
+				migration.Status = core.OnlineMigrationStatusBackfilled
+			}
+		}
+	default:
+		return migration, nil
+	}
+	if err := s.saveOnlineMigrationState(ctx, tx, migration, exists); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBCommitFailed)
+	}
+	return migration, nil
+}
+
+// SwapOnlineMigration completes a backfilled migration in a single transaction, holding a lock that blocks every
+// writer to the table. Rows inserted or changed since the last backfill step are copied, the content of the two
+// tables is compared, and the tables are renamed.
+func (s *SQLCommon) SwapOnlineMigration(ctx context.Context, name string) (*core.OnlineMigration, error) {
+	def, err := s.onlineMigrationDefinition(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.online.tracking == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationNotSupported)
+	}
+	tx, err := s.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBBeginFailed)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	migration, _, err := s.onlineMigrationState(ctx, tx, name, def)
+	if err != nil {
+		return nil, err
+	}
+	if migration.Status != core.OnlineMigrationStatusBackfilled {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationBadStatus, name, migration.Status)
+	}
+
+	// Writers from every API, background process and namespace are blocked until the swap commits
+	if lock := s.online.tracking.LockTableStatement(def.Table); lock != "" {
+		if err := s.execStatements(ctx, tx, []string{lock}); err != nil {
+			return nil, err
+		}
+	}
+
+	count, to, err := s.copyOnlineMigrationRows(ctx, tx, def, migration.Sequence, 0)
+	if err != nil {
+		return nil, err
+	}
+	migration.Rows += count
+	migration.Sequence = to
+	if _, err := s.copyOnlineMigrationChanges(ctx, tx, def, to, 0); err != nil {
+		return nil, err
+	}
+
+	newTable := def.Table + "_online"
+	missing, err := s.countOnlineMigrationDifferences(ctx, tx, def, def.Table, newTable)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := s.countOnlineMigrationDifferences(ctx, tx, def, newTable, def.Table)
+	if err != nil {
+		return nil, err
+	}
+	if missing != 0 || extra != 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationRowMismatch, name, missing, def.Table, extra)
+	}
+
+	log.L(ctx).Infof("Online migration '%s' swapping new table into place for '%s'", name, def.Table)
+	_, drop := s.online.tracking.ChangeTrackingStatements(def.Table, def.Table+"_onlinechanges")
+	statements := append(drop,
+		"ALTER TABLE "+def.Table+" RENAME TO "+def.Table+"_premigration",
+		"ALTER TABLE "+newTable+" RENAME TO "+def.Table,
+	)
+	if s.online.resetSequence != nil {
+		statements = append(statements, s.online.resetSequence(def.Table))
+	}
+	if err := s.execStatements(ctx, tx, append(statements, def.Finalize...)); err != nil {
+		return nil, err
+	}
+	migration.Status = core.OnlineMigrationStatusComplete
+	if err := s.saveOnlineMigrationState(ctx, tx, migration, true); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBCommitFailed)
+	}
+	return migration, nil
+}
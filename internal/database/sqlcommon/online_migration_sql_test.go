@@ -0,0 +1,526 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPinsOnlineMigration = `{
+	"table": "pins",
+	"columns": ["seq", "namespace", "masked", "hash", "batch_id", "batch_hash", "idx", "signer", "dispatched", "created"],
+	"create": [
+		"CREATE TABLE pins_online (seq INTEGER PRIMARY KEY AUTOINCREMENT, namespace VARCHAR(64), masked BOOLEAN, hash CHAR(64), batch_id UUID, batch_hash CHAR(64), idx INT, signer TEXT, dispatched BOOLEAN, created BIGINT, note TEXT)"
+	],
+	"finalize": ["DROP TABLE pins_premigration"]
+}`
+
+func writeOnlineMigration(t *testing.T, name, content string) string {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(content), 0600)
+	assert.NoError(t, err)
+	return dir
+}
+
+func TestOnlineMigrationE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.callbacks.On("OrderedCollectionNSEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	s.online.directory = writeOnlineMigration(t, "000001_pins_note", testPinsOnlineMigration)
+	s.online.batchSize = 2
+
+	newPin := func() *core.Pin {
+		return &core.Pin{Namespace: "ns1", Hash: fftypes.NewRandB32(), Batch: fftypes.NewUUID(), BatchHash: fftypes.NewRandB32(), Created: fftypes.Now()}
+	}
+	assert.NoError(t, s.InsertPins(ctx, []*core.Pin{newPin(), newPin(), newPin()}))
+
+	migrations, err := s.GetOnlineMigrations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 1)
+	assert.Equal(t, "000001_pins_note", migrations[0].Name)
+	assert.Equal(t, "pins", migrations[0].Table)
+	assert.Equal(t, core.OnlineMigrationStatusPending, migrations[0].Status)
+
+	_, err = s.SwapOnlineMigration(ctx, "000001_pins_note")
+	assert.Regexp(t, "FF10518", err)
+
+	// Create
+	migration, err := s.RunOnlineMigrationStep(ctx, "000001_pins_note")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusBackfilling, migration.Status)
+
+	// Backfill in batches, including rows inserted during the backfill
+	migration, err = s.RunOnlineMigrationStep(ctx, "000001_pins_note")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusBackfilling, migration.Status)
+	assert.Equal(t, int64(2), migration.Rows)
+	assert.NoError(t, s.InsertPins(ctx, []*core.Pin{newPin()}))
+	migration, err = s.RunOnlineMigrationStep(ctx, "000001_pins_note")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusBackfilling, migration.Status)
+	assert.Equal(t, int64(4), migration.Rows)
+	assert.Equal(t, int64(4), migration.Sequence)
+
+	// Catch up with the changes to rows that were already copied, in batches
+	_, err = s.DB().Exec("UPDATE pins SET dispatched = true WHERE seq = 2")
+	assert.NoError(t, err)
+	migration, err = s.RunOnlineMigrationStep(ctx, "000001_pins_note")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusBackfilling, migration.Status)
+	migration, err = s.RunOnlineMigrationStep(ctx, "000001_pins_note")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusBackfilled, migration.Status)
+	assert.Equal(t, int64(4), migration.Rows)
+
+	// Nothing more to do until swapped
+	migration, err = s.RunOnlineMigrationStep(ctx, "000001_pins_note")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusBackfilled, migration.Status)
+
+	// Swap, catching up with a late insert, update and delete
+	assert.NoError(t, s.InsertPins(ctx, []*core.Pin{newPin()}))
+	_, err = s.DB().Exec("UPDATE pins SET dispatched = true WHERE seq = 3")
+	assert.NoError(t, err)
+	_, err = s.DB().Exec("DELETE FROM pins WHERE seq = 1")
+	assert.NoError(t, err)
+	migration, err = s.SwapOnlineMigration(ctx, "000001_pins_note")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusComplete, migration.Status)
+	assert.Equal(t, int64(5), migration.Rows)
+
+	// The new table is in place, and continues the sequence
+	var note interface{}
+	err = s.DB().QueryRow("SELECT note FROM pins WHERE seq = 2").Scan(&note)
+	assert.NoError(t, err)
+	var dispatched int64
+	err = s.DB().QueryRow("SELECT COUNT(*) FROM pins WHERE dispatched = true").Scan(&dispatched)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), dispatched)
+	_, err = s.DB().Exec("SELECT COUNT(*) FROM pins_onlinechanges")
+	assert.Regexp(t, "no such table", err)
+	pin := newPin()
+	assert.NoError(t, s.InsertPins(ctx, []*core.Pin{pin}))
+	assert.Equal(t, int64(6), pin.Sequence)
+	var count int64
+	err = s.DB().QueryRow("SELECT COUNT(*) FROM pins").Scan(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+
+	migrations, err = s.GetOnlineMigrations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusComplete, migrations[0].Status)
+}
+
+func TestOnlineMigrationsNoDirectory(t *testing.T) {
+	s, _ := newMockProvider().init()
+	s.online.directory = filepath.Join(t.TempDir(), "missing")
+	migrations, err := s.GetOnlineMigrations(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, migrations)
+	_, err = s.RunOnlineMigrationStep(context.Background(), "unknown")
+	assert.Regexp(t, "FF10514", err)
+	_, err = s.SwapOnlineMigration(context.Background(), "unknown")
+	assert.Regexp(t, "FF10514", err)
+}
+
+func TestOnlineMigrationsNotSupported(t *testing.T) {
+	s, _ := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	s.online.tracking = nil
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10671", err)
+	_, err = s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10671", err)
+}
+
+func TestOnlineMigrationsBadDirectory(t *testing.T) {
+	s, _ := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "notadir", "{}")
+	s.online.directory = filepath.Join(s.online.directory, "notadir.json")
+	_, err := s.GetOnlineMigrations(context.Background())
+	assert.Error(t, err)
+}
+
+func TestOnlineMigrationsBadJSON(t *testing.T) {
+	s, _ := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "bad", "!json")
+	_, err := s.GetOnlineMigrations(context.Background())
+	assert.Regexp(t, "FF10515.*bad", err)
+}
+
+func TestOnlineMigrationsMissingSequenceColumn(t *testing.T) {
+	s, _ := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "bad", `{"table":"pins","columns":["hash"],"create":["CREATE TABLE pins_online (hash CHAR(64))"]}`)
+	_, err := s.GetOnlineMigrations(context.Background())
+	assert.Regexp(t, "FF10515.*bad", err)
+}
+
+func TestOnlineMigrationsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetOnlineMigrations(context.Background())
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10114", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepStateFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepCreateFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"status"}))
+	mock.ExpectExec("CREATE TABLE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10519.*CREATE TABLE", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepSaveFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"status"}))
+	mock.ExpectExec("CREATE TABLE pins_online .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE pins_onlinechanges").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10117", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepCommitFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"status"}))
+	mock.ExpectExec("CREATE TABLE pins_online .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE pins_onlinechanges").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10119", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func backfillingRows(status core.OnlineMigrationStatus) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"status", "backfill_seq", "backfill_rows", "updated"}).AddRow(string(status), 10, 10, fftypes.Now().String())
+}
+
+func TestRunOnlineMigrationStepBackfillQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilling))
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepBackfillInsertFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilling))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(12, 2))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10116", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func changeRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "seq"}).AddRow(1, 5).AddRow(2, 11)
+}
+
+func TestRunOnlineMigrationStepChangesQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilling))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(10, 0))
+	mock.ExpectQuery("SELECT id.*pins_onlinechanges").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepChangesScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilling))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(10, 0))
+	mock.ExpectQuery("SELECT id.*pins_onlinechanges").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepChangesDeleteFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilling))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(10, 0))
+	mock.ExpectQuery("SELECT id.*pins_onlinechanges").WillReturnRows(changeRows())
+	mock.ExpectExec("DELETE FROM pins_online .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10118", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepChangesInsertFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilling))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(10, 0))
+	mock.ExpectQuery("SELECT id.*pins_onlinechanges").WillReturnRows(changeRows())
+	mock.ExpectExec("DELETE FROM pins_online .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO pins_online .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10116", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepChangesClearFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilling))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(10, 0))
+	mock.ExpectQuery("SELECT id.*pins_onlinechanges").WillReturnRows(changeRows())
+	mock.ExpectExec("DELETE FROM pins_online .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO pins_online .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM pins_onlinechanges .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.Regexp(t, "FF10118", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnlineMigrationStepChangesMore(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	s.online.batchSize = 2
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilling))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(10, 0))
+	mock.ExpectQuery("SELECT id.*pins_onlinechanges").WillReturnRows(changeRows())
+	mock.ExpectExec("DELETE FROM pins_online .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO pins_online .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM pins_onlinechanges .*").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	migration, err := s.RunOnlineMigrationStep(context.Background(), "m1")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OnlineMigrationStatusBackfilling, migration.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10114", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationStateFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationLockFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilled))
+	mock.ExpectExec("LOCK TABLE pins").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10519.*LOCK TABLE", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationCopyFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilled))
+	mock.ExpectExec("LOCK TABLE pins").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationChangesFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilled))
+	mock.ExpectExec("LOCK TABLE pins").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(10, 0))
+	mock.ExpectQuery("SELECT id.*pins_onlinechanges").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func expectSwapCatchUp(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(backfillingRows(core.OnlineMigrationStatusBackfilled))
+	mock.ExpectExec("LOCK TABLE pins").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(10, 0))
+	mock.ExpectQuery("SELECT id.*pins_onlinechanges").WillReturnRows(sqlmock.NewRows([]string{"id", "seq"}))
+}
+
+func TestSwapOnlineMigrationCompareOldFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	expectSwapCatchUp(mock)
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationCompareNewFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	expectSwapCatchUp(mock)
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10115", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationRowMismatch(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	expectSwapCatchUp(mock)
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10516", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationRenameFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	s.online.resetSequence = func(table string) string { return "RESET " + table }
+	expectSwapCatchUp(mock)
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("DROP TABLE pins_onlinechanges").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE pins RENAME TO pins_premigration").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE pins_online RENAME TO pins").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RESET pins").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10519", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationSaveFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	expectSwapCatchUp(mock)
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("DROP .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DROP .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10117", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSwapOnlineMigrationCommitFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	s.online.directory = writeOnlineMigration(t, "m1", testPinsOnlineMigration)
+	expectSwapCatchUp(mock)
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT COUNT.*EXCEPT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec("DROP .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DROP .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.SwapOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10119", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
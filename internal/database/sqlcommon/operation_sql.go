@@ -45,12 +45,15 @@ var (
 		"input",
 		"output",
 		"retry_id",
+		"progress",
+		"correlation_id",
 	}
 	opFilterFieldMap = map[string]string{
-		"tx":     "tx_id",
-		"type":   "optype",
-		"status": "opstatus",
-		"retry":  "retry_id",
+		"tx":            "tx_id",
+		"type":          "optype",
+		"status":        "opstatus",
+		"retry":         "retry_id",
+		"correlationid": "correlation_id",
 	}
 )
 
@@ -70,6 +73,8 @@ func (s *SQLCommon) setOperationInsertValues(query sq.InsertBuilder, operation *
 		operation.Input,
 		operation.Output,
 		operation.Retry,
+		operation.Progress,
+		operation.CorrelationID,
 	)
 }
 
@@ -156,6 +161,8 @@ func (s *SQLCommon) opResult(ctx context.Context, row *sql.Rows) (*core.Operatio
 		&op.Input,
 		&op.Output,
 		&op.Retry,
+		&op.Progress,
+		&op.CorrelationID,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, operationsTable)
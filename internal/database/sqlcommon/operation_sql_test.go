@@ -38,17 +38,19 @@ func TestOperationE2EWithDB(t *testing.T) {
 	// Create a new operation entry
 	operationID := fftypes.NewUUID()
 	operation := &core.Operation{
-		ID:          operationID,
-		Namespace:   "ns1",
-		Type:        core.OpTypeBlockchainPinBatch,
-		Transaction: fftypes.NewUUID(),
-		Status:      core.OpStatusFailed,
-		Plugin:      "ethereum",
-		Error:       "pop",
-		Input:       fftypes.JSONObject{"some": "input-info"},
-		Output:      fftypes.JSONObject{"some": "output-info"},
-		Created:     fftypes.Now(),
-		Updated:     fftypes.Now(),
+		ID:            operationID,
+		Namespace:     "ns1",
+		Type:          core.OpTypeBlockchainPinBatch,
+		Transaction:   fftypes.NewUUID(),
+		Status:        core.OpStatusFailed,
+		Plugin:        "ethereum",
+		Error:         "pop",
+		Input:         fftypes.JSONObject{"some": "input-info"},
+		Output:        fftypes.JSONObject{"some": "output-info"},
+		Created:       fftypes.Now(),
+		Updated:       fftypes.Now(),
+		Progress:      &core.OperationProgress{BytesSent: 10, BytesTotal: 100},
+		CorrelationID: "esb-1234",
 	}
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionOperations, core.ChangeEventTypeCreated, "ns1", operationID).Return()
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionOperations, core.ChangeEventTypeUpdated, "ns1", operationID).Return()
@@ -75,6 +77,7 @@ func TestOperationE2EWithDB(t *testing.T) {
 		fb.Eq("status", operation.Status),
 		fb.Eq("error", operation.Error),
 		fb.Eq("plugin", operation.Plugin),
+		fb.Eq("correlationid", operation.CorrelationID),
 		fb.Gt("created", 0),
 		fb.Gt("updated", 0),
 	)
@@ -100,6 +103,7 @@ func TestOperationE2EWithDB(t *testing.T) {
 	update := database.OperationQueryFactory.NewUpdate(ctx).S()
 	update.Set("status", core.OpStatusFailed)
 	update.Set("error", errMsg)
+	update.Set("progress", fftypes.JSONAnyPtr(`{"bytesSent":100,"bytesTotal":100}`))
 	updated, err := s.UpdateOperation(ctx, operation.Namespace, operation.ID, nil, update)
 	assert.True(t, updated)
 	assert.NoError(t, err)
@@ -119,6 +123,7 @@ func TestOperationE2EWithDB(t *testing.T) {
 	operations, _, err = s.GetOperations(ctx, "ns1", filter)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(operations))
+	assert.Equal(t, int64(100), operations[0].Progress.BytesSent)
 
 	s.callbacks.AssertExpectations(t)
 }
@@ -0,0 +1,185 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	opApprovalColumns = []string{
+		"id",
+		"namespace",
+		"operation_id",
+		"tx_id",
+		"optype",
+		"status",
+		"required",
+		"approvers",
+		"created",
+		"updated",
+		"expires",
+	}
+	opApprovalFilterFieldMap = map[string]string{
+		"operation": "operation_id",
+		"tx":        "tx_id",
+		"type":      "optype",
+	}
+)
+
+const operationApprovalsTable = "operationapprovals"
+
+func (s *SQLCommon) InsertOperationApproval(ctx context.Context, approval *core.OperationApproval) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	_, err = s.InsertTx(ctx, operationApprovalsTable, tx,
+		sq.Insert(operationApprovalsTable).
+			Columns(opApprovalColumns...).
+			Values(
+				approval.ID,
+				approval.Namespace,
+				approval.Operation,
+				approval.Transaction,
+				approval.Type,
+				approval.Status,
+				approval.Required,
+				approval.Approvers,
+				approval.Created,
+				approval.Updated,
+				approval.Expires,
+			),
+		nil, // no change events for operation approvals
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) opApprovalResult(ctx context.Context, row *sql.Rows) (*core.OperationApproval, error) {
+	var approval core.OperationApproval
+	err := row.Scan(
+		&approval.ID,
+		&approval.Namespace,
+		&approval.Operation,
+		&approval.Transaction,
+		&approval.Type,
+		&approval.Status,
+		&approval.Required,
+		&approval.Approvers,
+		&approval.Created,
+		&approval.Updated,
+		&approval.Expires,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, operationApprovalsTable)
+	}
+	return &approval, nil
+}
+
+func (s *SQLCommon) GetOperationApprovalByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.OperationApproval, error) {
+	rows, _, err := s.Query(ctx, operationApprovalsTable,
+		sq.Select(opApprovalColumns...).
+			From(operationApprovalsTable).
+			Where(sq.Eq{"id": id, "namespace": namespace}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Operation approval '%s' not found", id)
+		return nil, nil
+	}
+
+	return s.opApprovalResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetOperationApprovals(ctx context.Context, namespace string, filter ffapi.Filter) (approvals []*core.OperationApproval, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(opApprovalColumns...).From(operationApprovalsTable), filter, opApprovalFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, operationApprovalsTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	approvals = []*core.OperationApproval{}
+	for rows.Next() {
+		approval, err := s.opApprovalResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, s.QueryRes(ctx, operationApprovalsTable, tx, fop, nil, fi), err
+}
+
+// UpdateOperationApproval applies the update only if the approval still matches the filter, so callers
+// can make a transition conditional on the status they read - such as only expiring an approval that is
+// still pending
+func (s *SQLCommon) UpdateOperationApproval(ctx context.Context, namespace string, id *fftypes.UUID, filter ffapi.Filter, update ffapi.Update) (updated bool, err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	query, err := s.BuildUpdate(sq.Update(operationApprovalsTable), update, opApprovalFilterFieldMap)
+	if err != nil {
+		return false, err
+	}
+
+	if filter != nil {
+		query, err = s.FilterUpdate(ctx, query, filter, opApprovalFilterFieldMap)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	query = query.Set("updated", fftypes.Now())
+	query = query.Where(sq.And{
+		sq.Eq{"id": id},
+		sq.Eq{"namespace": namespace},
+	})
+
+	ra, err := s.UpdateTx(ctx, operationApprovalsTable, tx, query, nil /* no change events for operation approvals */)
+	if err != nil {
+		return false, err
+	}
+	return ra > 0, s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,166 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationApprovalsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	approval := &core.OperationApproval{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Operation:   fftypes.NewUUID(),
+		Transaction: fftypes.NewUUID(),
+		Type:        core.OpTypeTokenTransfer,
+		Status:      core.OperationApprovalStatusPending,
+		Required:    2,
+		Approvers:   fftypes.FFStringArray{},
+		Created:     fftypes.Now(),
+		Expires:     fftypes.Now(),
+	}
+	err := s.InsertOperationApproval(ctx, approval)
+	assert.NoError(t, err)
+
+	read, err := s.GetOperationApprovalByID(ctx, "ns1", approval.ID)
+	assert.NoError(t, err)
+	approvalJSON, _ := json.Marshal(approval)
+	readJSON, _ := json.Marshal(read)
+	assert.Equal(t, string(approvalJSON), string(readJSON))
+
+	// Only a pending approval is updated
+	fb := database.OperationApprovalQueryFactory.NewFilter(ctx)
+	up := database.OperationApprovalQueryFactory.NewUpdate(ctx).
+		Set("approvers", fftypes.FFStringArray{"user:alice"})
+	updated, err := s.UpdateOperationApproval(ctx, "ns1", approval.ID, fb.Eq("status", core.OperationApprovalStatusPending), up)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	updated, err = s.UpdateOperationApproval(ctx, "ns1", approval.ID, fb.Eq("status", core.OperationApprovalStatusExpired), up)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+
+	approvals, res, err := s.GetOperationApprovals(ctx, "ns1", fb.And(fb.Eq("operation", approval.Operation)).Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *res.TotalCount)
+	assert.Len(t, approvals, 1)
+	assert.Equal(t, []string{"user:alice"}, []string(approvals[0].Approvers))
+	assert.NotNil(t, approvals[0].Updated)
+
+	read, err = s.GetOperationApprovalByID(ctx, "ns2", approval.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, read)
+}
+
+func TestInsertOperationApprovalFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertOperationApproval(context.Background(), &core.OperationApproval{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertOperationApprovalFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertOperationApproval(context.Background(), &core.OperationApproval{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationApprovalByIDQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetOperationApprovalByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationApprovalsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.OperationApprovalQueryFactory.NewFilter(context.Background()).Eq("status", "pending")
+	_, _, err := s.GetOperationApprovals(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationApprovalsBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.OperationApprovalQueryFactory.NewFilter(context.Background()).Eq("operation", map[bool]bool{true: false})
+	_, _, err := s.GetOperationApprovals(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*operation", err)
+}
+
+func TestGetOperationApprovalsReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	f := database.OperationApprovalQueryFactory.NewFilter(context.Background()).Eq("status", "pending")
+	_, _, err := s.GetOperationApprovals(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateOperationApprovalBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	u := database.OperationApprovalQueryFactory.NewUpdate(context.Background()).Set("status", "approved")
+	_, err := s.UpdateOperationApproval(context.Background(), "ns1", fftypes.NewUUID(), nil, u)
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestUpdateOperationApprovalBuildQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	u := database.OperationApprovalQueryFactory.NewUpdate(context.Background()).Set("id", map[bool]bool{true: false})
+	_, err := s.UpdateOperationApproval(context.Background(), "ns1", fftypes.NewUUID(), nil, u)
+	assert.Regexp(t, "FF00143.*id", err)
+}
+
+func TestUpdateOperationApprovalFilterFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	f := database.OperationApprovalQueryFactory.NewFilter(context.Background()).Eq("id", map[bool]bool{true: false})
+	u := database.OperationApprovalQueryFactory.NewUpdate(context.Background()).Set("status", "approved")
+	_, err := s.UpdateOperationApproval(context.Background(), "ns1", fftypes.NewUUID(), f, u)
+	assert.Regexp(t, "FF00143", err)
+}
+
+func TestUpdateOperationApprovalFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	u := database.OperationApprovalQueryFactory.NewUpdate(context.Background()).Set("status", "approved")
+	_, err := s.UpdateOperationApproval(context.Background(), "ns1", fftypes.NewUUID(), nil, u)
+	assert.Regexp(t, "FF00178", err)
+}
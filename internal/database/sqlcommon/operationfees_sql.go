@@ -0,0 +1,251 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	opFeeColumns = []string{
+		"namespace",
+		"operation_id",
+		"tx_id",
+		"optype",
+		"opstatus",
+		"plugin",
+		"signing_key",
+		"blockchain_id",
+		"gas_used",
+		"gas_price",
+		"fee",
+		"created",
+	}
+	opFeeFilterFieldMap = map[string]string{
+		"operation":    "operation_id",
+		"tx":           "tx_id",
+		"type":         "optype",
+		"status":       "opstatus",
+		"key":          "signing_key",
+		"blockchainid": "blockchain_id",
+	}
+)
+
+const operationFeesTable = "operationfees"
+
+const nanosPerDay = int64(24 * time.Hour)
+
+// feeAmount returns the decimal form of an amount, which is also stored in a numeric column so
+// it can be totalled by the database
+func feeAmount(i *fftypes.FFBigInt) interface{} {
+	if i == nil {
+		return nil
+	}
+	return i.String()
+}
+
+// UpsertOperationFee stores the fee for an operation, replacing any fee recorded from an earlier
+// receipt for the same operation, so each operation is only ever counted once
+func (s *SQLCommon) UpsertOperationFee(ctx context.Context, fee *core.OperationFee) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	feeRows, _, err := s.QueryTx(ctx, operationFeesTable, tx,
+		sq.Select("seq").
+			From(operationFeesTable).
+			Where(sq.Eq{"namespace": fee.Namespace, "operation_id": fee.Operation}),
+	)
+	if err != nil {
+		return err
+	}
+	existing := feeRows.Next()
+	feeRows.Close()
+
+	if existing {
+		if _, err = s.UpdateTx(ctx, operationFeesTable, tx,
+			sq.Update(operationFeesTable).
+				Set("tx_id", fee.Transaction).
+				Set("optype", fee.Type).
+				Set("opstatus", fee.Status).
+				Set("plugin", fee.Plugin).
+				Set("signing_key", fee.Key).
+				Set("blockchain_id", fee.BlockchainTXID).
+				Set("gas_used", fee.GasUsed).
+				Set("gas_price", fee.GasPrice).
+				Set("fee", fee.Fee).
+				Set("gas_used_amount", feeAmount(fee.GasUsed)).
+				Set("fee_amount", feeAmount(fee.Fee)).
+				Set("created", fee.Created).
+				Where(sq.Eq{"namespace": fee.Namespace, "operation_id": fee.Operation}),
+			nil, // no change events for operation fees
+		); err != nil {
+			return err
+		}
+	} else {
+		if _, err = s.InsertTx(ctx, operationFeesTable, tx,
+			sq.Insert(operationFeesTable).
+				Columns(append(append([]string{}, opFeeColumns...), "gas_used_amount", "fee_amount")...).
+				Values(
+					fee.Namespace,
+					fee.Operation,
+					fee.Transaction,
+					fee.Type,
+					fee.Status,
+					fee.Plugin,
+					fee.Key,
+					fee.BlockchainTXID,
+					fee.GasUsed,
+					fee.GasPrice,
+					fee.Fee,
+					fee.Created,
+					feeAmount(fee.GasUsed),
+					feeAmount(fee.Fee),
+				),
+			nil, // no change events for operation fees
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) opFeeResult(ctx context.Context, row *sql.Rows) (*core.OperationFee, error) {
+	var fee core.OperationFee
+	err := row.Scan(
+		&fee.Namespace,
+		&fee.Operation,
+		&fee.Transaction,
+		&fee.Type,
+		&fee.Status,
+		&fee.Plugin,
+		&fee.Key,
+		&fee.BlockchainTXID,
+		&fee.GasUsed,
+		&fee.GasPrice,
+		&fee.Fee,
+		&fee.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, operationFeesTable)
+	}
+	return &fee, nil
+}
+
+func (s *SQLCommon) GetOperationFees(ctx context.Context, namespace string, filter ffapi.Filter) (fees []*core.OperationFee, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(opFeeColumns...).From(operationFeesTable), filter, opFeeFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, operationFeesTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	fees = []*core.OperationFee{}
+	for rows.Next() {
+		fee, err := s.opFeeResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		fees = append(fees, fee)
+	}
+
+	return fees, s.QueryRes(ctx, operationFeesTable, tx, fop, nil, fi), err
+}
+
+// GetOperationFeeSummary totals the fees matching the filter by signing key and UTC day, in the database
+func (s *SQLCommon) GetOperationFeeSummary(ctx context.Context, namespace string, filter ffapi.Filter) (summaries []*core.FeeSummary, err error) {
+	// Only the conditions of the filter are used, as the summary always covers every matching record
+	_, fop, _, err := s.FilterSelect(ctx, "", sq.Select("seq").From(operationFeesTable), filter, opFeeFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, err
+	}
+	query := sq.Select(
+		fmt.Sprintf("created / %d AS day", nanosPerDay),
+		"signing_key",
+		"COUNT(*)",
+		"SUM(gas_used_amount)",
+		"SUM(fee_amount)",
+	).From(operationFeesTable).Where(fop).GroupBy("day", "signing_key").OrderBy("day", "signing_key")
+
+	rows, _, err := s.Query(ctx, operationFeesTable, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries = []*core.FeeSummary{}
+	for rows.Next() {
+		var day int64
+		var gasUsed, fee interface{}
+		summary := &core.FeeSummary{}
+		if err := rows.Scan(&day, &summary.Key, &summary.Operations, &gasUsed, &fee); err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, operationFeesTable)
+		}
+		summary.Day = time.Unix(0, day*nanosPerDay).UTC().Format("2006-01-02")
+		summary.GasUsed = numericTotal(gasUsed)
+		summary.Fee = numericTotal(fee)
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+// numericTotal converts a SUM from the database, which depending on the database and the size of the
+// total can be returned as an integer, a float, or the text of a decimal
+func numericTotal(v interface{}) *fftypes.FFBigInt {
+	total := fftypes.NewFFBigInt(0)
+	switch vt := v.(type) {
+	case int64:
+		total.Int().SetInt64(vt)
+	case float64:
+		big.NewFloat(vt).Int(total.Int())
+	case []byte:
+		setDecimal(total, string(vt))
+	case string:
+		setDecimal(total, vt)
+	}
+	return total
+}
+
+func setDecimal(total *fftypes.FFBigInt, s string) {
+	if _, ok := total.Int().SetString(s, 10); !ok {
+		if f, ok := new(big.Float).SetString(s); ok {
+			f.Int(total.Int())
+		} else {
+			total.Int().SetInt64(0)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationFeesE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	fee := &core.OperationFee{
+		Operation:      fftypes.NewUUID(),
+		Namespace:      "ns1",
+		Transaction:    fftypes.NewUUID(),
+		Type:           core.OpTypeBlockchainInvoke,
+		Status:         core.OpStatusPending,
+		Plugin:         "ethereum",
+		Key:            "0x12345",
+		BlockchainTXID: "0xabcd",
+		GasUsed:        fftypes.NewFFBigInt(21000),
+		GasPrice:       fftypes.NewFFBigInt(1000000000),
+		Fee:            fftypes.NewFFBigInt(21000000000000),
+		Created:        fftypes.Now(),
+	}
+	err := s.UpsertOperationFee(ctx, fee)
+	assert.NoError(t, err)
+
+	// A later receipt for the same operation replaces the record
+	fee.Status = core.OpStatusSucceeded
+	fee.GasUsed = fftypes.NewFFBigInt(30000)
+	fee.Fee = fftypes.NewFFBigInt(30000000000000)
+	err = s.UpsertOperationFee(ctx, fee)
+	assert.NoError(t, err)
+
+	fb := database.OperationFeeQueryFactory.NewFilter(ctx)
+	fees, res, err := s.GetOperationFees(ctx, "ns1", fb.And(fb.Eq("key", "0x12345")).Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *res.TotalCount)
+	assert.Len(t, fees, 1)
+	feeJSON, _ := json.Marshal(fee)
+	readJSON, _ := json.Marshal(fees[0])
+	assert.Equal(t, string(feeJSON), string(readJSON))
+
+	fees, _, err = s.GetOperationFees(ctx, "ns2", fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, fees)
+
+	// Summaries are totalled by key and UTC day
+	day1 := fftypes.FFTime(time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC))
+	day2 := fftypes.FFTime(time.Date(2024, 3, 2, 1, 0, 0, 0, time.UTC))
+	for _, f := range []*core.OperationFee{
+		{Key: "0xbbb", Created: &day1, GasUsed: fftypes.NewFFBigInt(1), Fee: fftypes.NewFFBigInt(10)},
+		{Key: "0xbbb", Created: &day1, GasUsed: fftypes.NewFFBigInt(2), Fee: fftypes.NewFFBigInt(20)},
+		{Key: "0xaaa", Created: &day1, GasUsed: fftypes.NewFFBigInt(100), Fee: fftypes.NewFFBigInt(1000)},
+		{Key: "0xbbb", Created: &day2, GasUsed: fftypes.NewFFBigInt(5), Fee: fftypes.NewFFBigInt(50)},
+		{Key: "0xbbb", Created: &day2},
+	} {
+		f.Operation = fftypes.NewUUID()
+		f.Namespace = "ns3"
+		f.Type = core.OpTypeBlockchainInvoke
+		f.Status = core.OpStatusSucceeded
+		f.Plugin = "ethereum"
+		err = s.UpsertOperationFee(ctx, f)
+		assert.NoError(t, err)
+	}
+	summaries, err := s.GetOperationFeeSummary(ctx, "ns3", fb.And().Limit(1))
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 3)
+	assert.Equal(t, "2024-03-01", summaries[0].Day)
+	assert.Equal(t, "0xaaa", summaries[0].Key)
+	assert.Equal(t, int64(1), summaries[0].Operations)
+	assert.Equal(t, int64(1000), summaries[0].Fee.Int().Int64())
+	assert.Equal(t, "2024-03-01", summaries[1].Day)
+	assert.Equal(t, "0xbbb", summaries[1].Key)
+	assert.Equal(t, int64(2), summaries[1].Operations)
+	assert.Equal(t, int64(3), summaries[1].GasUsed.Int().Int64())
+	assert.Equal(t, int64(30), summaries[1].Fee.Int().Int64())
+	assert.Equal(t, "2024-03-02", summaries[2].Day)
+	assert.Equal(t, int64(2), summaries[2].Operations)
+	assert.Equal(t, int64(5), summaries[2].GasUsed.Int().Int64())
+	assert.Equal(t, int64(50), summaries[2].Fee.Int().Int64())
+}
+
+func TestNumericTotal(t *testing.T) {
+	assert.Equal(t, int64(5), numericTotal(int64(5)).Int().Int64())
+	assert.Equal(t, int64(5), numericTotal(float64(5)).Int().Int64())
+	assert.Equal(t, "100000000000000000000", numericTotal([]byte("100000000000000000000")).String())
+	assert.Equal(t, "100000000000000000000", numericTotal("1e20").String())
+	assert.Equal(t, int64(0), numericTotal("bad").Int().Int64())
+	assert.Equal(t, int64(0), numericTotal(nil).Int().Int64())
+}
+
+func TestUpsertOperationFeeFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpsertOperationFee(context.Background(), &core.OperationFee{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertOperationFeeFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertOperationFee(context.Background(), &core.OperationFee{Operation: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertOperationFeeFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertOperationFee(context.Background(), &core.OperationFee{Operation: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertOperationFeeFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(1))
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertOperationFee(context.Background(), &core.OperationFee{Operation: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertOperationFeeFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{}))
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpsertOperationFee(context.Background(), &core.OperationFee{Operation: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationFeesQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.OperationFeeQueryFactory.NewFilter(context.Background()).Eq("key", "0x12345")
+	_, _, err := s.GetOperationFees(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationFeesBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.OperationFeeQueryFactory.NewFilter(context.Background()).Eq("operation", map[bool]bool{true: false})
+	_, _, err := s.GetOperationFees(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*operation", err)
+}
+
+func TestGetOperationFeeSummaryBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.OperationFeeQueryFactory.NewFilter(context.Background()).Eq("key", map[bool]bool{true: false})
+	_, err := s.GetOperationFeeSummary(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*type", err)
+}
+
+func TestGetOperationFeeSummaryQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.OperationFeeQueryFactory.NewFilter(context.Background()).Eq("key", "0x12345")
+	_, err := s.GetOperationFeeSummary(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationFeeSummaryReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"day"}).AddRow(1))
+	f := database.OperationFeeQueryFactory.NewFilter(context.Background()).Eq("key", "0x12345")
+	_, err := s.GetOperationFeeSummary(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationFeesReadMessageFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("only one"))
+	f := database.OperationFeeQueryFactory.NewFilter(context.Background()).Eq("key", "0x12345")
+	_, _, err := s.GetOperationFees(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,130 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	opHistoryColumns = []string{
+		"id",
+		"namespace",
+		"operation_id",
+		"tx_id",
+		"opstatus",
+		"error",
+		"plugin",
+		"blockchain_id",
+		"receipt",
+		"created",
+	}
+	opHistoryFilterFieldMap = map[string]string{
+		"operation":    "operation_id",
+		"tx":           "tx_id",
+		"status":       "opstatus",
+		"blockchainid": "blockchain_id",
+	}
+)
+
+const operationHistoryTable = "operationhistory"
+
+func (s *SQLCommon) InsertOperationHistory(ctx context.Context, entry *core.OperationHistoryEntry) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	entry.Sequence, err = s.InsertTx(ctx, operationHistoryTable, tx,
+		sq.Insert(operationHistoryTable).
+			Columns(opHistoryColumns...).
+			Values(
+				entry.ID,
+				entry.Namespace,
+				entry.Operation,
+				entry.Transaction,
+				string(entry.Status),
+				entry.Error,
+				entry.Plugin,
+				entry.BlockchainTXID,
+				entry.Receipt,
+				entry.Created,
+			),
+		nil, // no change events for operation history
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) opHistoryResult(ctx context.Context, row *sql.Rows) (*core.OperationHistoryEntry, error) {
+	var entry core.OperationHistoryEntry
+	err := row.Scan(
+		&entry.ID,
+		&entry.Namespace,
+		&entry.Operation,
+		&entry.Transaction,
+		&entry.Status,
+		&entry.Error,
+		&entry.Plugin,
+		&entry.BlockchainTXID,
+		&entry.Receipt,
+		&entry.Created,
+		&entry.Sequence,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, operationHistoryTable)
+	}
+	return &entry, nil
+}
+
+func (s *SQLCommon) GetOperationHistory(ctx context.Context, namespace string, filter ffapi.Filter) (entries []*core.OperationHistoryEntry, res *ffapi.FilterResult, err error) {
+	cols := append([]string{}, opHistoryColumns...)
+	cols = append(cols, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(operationHistoryTable), filter, opHistoryFilterFieldMap,
+		[]interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, operationHistoryTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	entries = []*core.OperationHistoryEntry{}
+	for rows.Next() {
+		entry, err := s.opHistoryResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, s.QueryRes(ctx, operationHistoryTable, tx, fop, nil, fi), err
+}
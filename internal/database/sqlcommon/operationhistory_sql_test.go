@@ -0,0 +1,131 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationHistoryE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	opID := fftypes.NewUUID()
+	pending := &core.OperationHistoryEntry{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Operation: opID,
+		Status:    core.OpStatusPending,
+		Plugin:    "ethereum",
+		Created:   fftypes.Now(),
+	}
+	err := s.InsertOperationHistory(ctx, pending)
+	assert.NoError(t, err)
+
+	succeeded := &core.OperationHistoryEntry{
+		ID:             fftypes.NewUUID(),
+		Namespace:      "ns1",
+		Operation:      opID,
+		Transaction:    fftypes.NewUUID(),
+		Status:         core.OpStatusSucceeded,
+		Plugin:         "ethereum",
+		BlockchainTXID: "0x12345",
+		Receipt:        fftypes.JSONObject{"blockNumber": "10"},
+		Created:        fftypes.Now(),
+	}
+	err = s.InsertOperationHistory(ctx, succeeded)
+	assert.NoError(t, err)
+
+	fb := database.OperationHistoryQueryFactory.NewFilter(ctx)
+	entries, res, err := s.GetOperationHistory(ctx, "ns1", fb.And(fb.Eq("operation", opID)).Sort("sequence").Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	assert.Len(t, entries, 2)
+	pendingJSON, _ := json.Marshal(pending)
+	readJSON, _ := json.Marshal(entries[0])
+	assert.Equal(t, string(pendingJSON), string(readJSON))
+	succeededJSON, _ := json.Marshal(succeeded)
+	readJSON, _ = json.Marshal(entries[1])
+	assert.Equal(t, string(succeededJSON), string(readJSON))
+
+	entries, _, err = s.GetOperationHistory(ctx, "ns1", fb.And(fb.Eq("blockchainid", "0x12345")))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestInsertOperationHistoryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertOperationHistory(context.Background(), &core.OperationHistoryEntry{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertOperationHistoryFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertOperationHistory(context.Background(), &core.OperationHistoryEntry{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertOperationHistoryFailCommit(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertOperationHistory(context.Background(), &core.OperationHistoryEntry{ID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00180", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationHistoryQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.OperationHistoryQueryFactory.NewFilter(context.Background()).Eq("operation", fftypes.NewUUID())
+	_, _, err := s.GetOperationHistory(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationHistoryBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.OperationHistoryQueryFactory.NewFilter(context.Background()).Eq("operation", map[bool]bool{true: false})
+	_, _, err := s.GetOperationHistory(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*operation", err)
+}
+
+func TestGetOperationHistoryReadMessageFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	f := database.OperationHistoryQueryFactory.NewFilter(context.Background()).Eq("operation", fftypes.NewUUID())
+	_, _, err := s.GetOperationHistory(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -83,6 +83,13 @@ func TestPinsE2EWithDB(t *testing.T) {
 	assert.Equal(t, existingSequence, pin.Sequence)
 	assert.True(t, pin.Dispatched)
 
+	// Pins are deleted whether or not they have been dispatched
+	err = s.DeleteBatchPins(ctx, "ns", pin.Batch)
+	assert.NoError(t, err)
+	pinRes, _, err = s.GetPins(ctx, "ns", filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(pinRes))
+
 	s.callbacks.AssertExpectations(t)
 }
 
@@ -253,3 +260,21 @@ func TestUpdatePinsBadUpdate(t *testing.T) {
 	err := s.UpdatePins(ctx, "ns1", database.PinQueryFactory.NewFilter(ctx).Eq("bad", 1), database.PinQueryFactory.NewUpdate(ctx).Set("dispatched", true))
 	assert.Regexp(t, "FF00142", err)
 }
+
+func TestDeleteBatchPinsBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteBatchPins(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteBatchPinsDeleteFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteBatchPins(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -41,6 +41,10 @@ type mockProvider struct {
 	mockDB *sql.DB
 	mdb    sqlmock.Sqlmock
 
+	mockReplicaDB *sql.DB
+	mrdb          sqlmock.Sqlmock
+	lagQuery      string
+
 	fakePSQLInsert          bool
 	openError               error
 	getMigrationDriverError error
@@ -59,6 +63,7 @@ func newMockProvider() *mockProvider {
 	mp.SQLCommon.InitConfig(mp, mp.config)
 	mp.config.Set(SQLConfMaxConnections, 10)
 	mp.mockDB, mp.mdb, _ = sqlmock.New()
+	mp.mockReplicaDB, mp.mrdb, _ = sqlmock.New()
 	mp.multiRowInsert = false
 	return mp
 }
@@ -100,9 +105,24 @@ func (mp *mockProvider) ApplyInsertQueryCustomizations(insert sq.InsertBuilder,
 }
 
 func (mp *mockProvider) Open(url string) (*sql.DB, error) {
+	if url == "replica" {
+		return mp.mockReplicaDB, mp.openError
+	}
 	return mp.mockDB, mp.openError
 }
 
+func (mp *mockProvider) ReplicaLagQuery() string {
+	return mp.lagQuery
+}
+
+func (mp *mockProvider) ChangeTrackingStatements(table, changelog string) (create []string, drop []string) {
+	return []string{"CREATE TABLE " + changelog}, []string{"DROP TABLE " + changelog}
+}
+
+func (mp *mockProvider) LockTableStatement(table string) string {
+	return "LOCK TABLE " + table
+}
+
 func (mp *mockProvider) GetMigrationDriver(db *sql.DB) (migratedb.Driver, error) {
 	return nil, mp.getMigrationDriverError
 }
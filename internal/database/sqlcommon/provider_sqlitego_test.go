@@ -19,6 +19,7 @@ package sqlcommon
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -98,6 +99,26 @@ func (tp *sqliteGoTestProvider) ApplyInsertQueryCustomizations(insert sq.InsertB
 	return insert, false
 }
 
+func (tp *sqliteGoTestProvider) ChangeTrackingStatements(table, changelog string) (create []string, drop []string) {
+	create = []string{
+		fmt.Sprintf("CREATE TABLE %s (id INTEGER PRIMARY KEY AUTOINCREMENT, seq BIGINT NOT NULL)", changelog),
+		fmt.Sprintf("CREATE TRIGGER %s_insert AFTER INSERT ON %s BEGIN INSERT INTO %s (seq) VALUES (NEW.seq); END", changelog, table, changelog),
+		fmt.Sprintf("CREATE TRIGGER %s_update AFTER UPDATE ON %s BEGIN INSERT INTO %s (seq) VALUES (OLD.seq); INSERT INTO %s (seq) VALUES (NEW.seq); END", changelog, table, changelog, changelog),
+		fmt.Sprintf("CREATE TRIGGER %s_delete AFTER DELETE ON %s BEGIN INSERT INTO %s (seq) VALUES (OLD.seq); END", changelog, table, changelog),
+	}
+	drop = []string{
+		fmt.Sprintf("DROP TRIGGER %s_insert", changelog),
+		fmt.Sprintf("DROP TRIGGER %s_update", changelog),
+		fmt.Sprintf("DROP TRIGGER %s_delete", changelog),
+		fmt.Sprintf("DROP TABLE %s", changelog),
+	}
+	return create, drop
+}
+
+func (tp *sqliteGoTestProvider) LockTableStatement(table string) string {
+	return ""
+}
+
 func (tp *sqliteGoTestProvider) Open(url string) (*sql.DB, error) {
 	return sql.Open("sqlite3", url)
 }
@@ -0,0 +1,160 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// ReplicaLagProvider is implemented by providers that can report how far behind the primary a read replica is
+type ReplicaLagProvider interface {
+	// ReplicaLagQuery is a query run against the replica, returning the replication lag in seconds
+	ReplicaLagQuery() string
+}
+
+// replicaRouter sends reads that tolerate stale data to a read-only replica, as long as
+// the replica is close enough to the primary for the collection being read
+type replicaRouter struct {
+	db                *sql.DB
+	placeholderFormat sq.PlaceholderFormat
+	lagQuery          string
+	defaultStaleness  time.Duration
+	staleness         map[string]time.Duration
+	checkInterval     time.Duration
+
+	mux       sync.RWMutex
+	lag       time.Duration
+	available bool
+}
+
+func newReplicaRouter(ctx context.Context, provider dbsql.Provider, conf config.Section) (*replicaRouter, error) {
+	db, err := provider.Open(conf.GetString(SQLConfReplicaURL))
+	if err != nil {
+		return nil, err
+	}
+	if maxConns := conf.GetInt(SQLConfReplicaMaxConnections); maxConns > 0 {
+		db.SetMaxOpenConns(maxConns)
+	}
+	rr := &replicaRouter{
+		db:                db,
+		placeholderFormat: provider.Features().PlaceholderFormat,
+		defaultStaleness:  conf.GetDuration(SQLConfReplicaMaxStaleness),
+		staleness:         make(map[string]time.Duration),
+		checkInterval:     conf.GetDuration(SQLConfReplicaLagCheckInterval),
+	}
+	for collection, value := range conf.GetObject(SQLConfReplicaCollections) {
+		// A bare number is treated as seconds
+		d, err := fftypes.ParseDurationString(fmt.Sprintf("%v", value), time.Second)
+		if err != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidReplicaStaleness, value, collection)
+		}
+		rr.staleness[collection] = d
+	}
+	if lp, ok := provider.(ReplicaLagProvider); ok {
+		rr.lagQuery = lp.ReplicaLagQuery()
+	}
+	rr.checkLag(ctx)
+	go rr.lagLoop(ctx)
+	return rr, nil
+}
+
+func (rr *replicaRouter) lagLoop(ctx context.Context) {
+	for {
+		select {
+		case <-time.After(rr.checkInterval):
+			rr.checkLag(ctx)
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Replica lag checker exiting")
+			return
+		}
+	}
+}
+
+// checkLag records the current replication lag. A replica that cannot be reached is
+// marked unavailable, so all reads go to the primary until it recovers.
+func (rr *replicaRouter) checkLag(ctx context.Context) {
+	var lagSeconds float64
+	var err error
+	if rr.lagQuery != "" {
+		err = rr.db.QueryRowContext(ctx, rr.lagQuery).Scan(&lagSeconds)
+	} else {
+		err = rr.db.PingContext(ctx)
+	}
+	rr.mux.Lock()
+	defer rr.mux.Unlock()
+	if err != nil {
+		if rr.available {
+			log.L(ctx).Warnf("Read replica unavailable, routing all reads to the primary: %s", err)
+		}
+		rr.available = false
+		return
+	}
+	if !rr.available {
+		log.L(ctx).Infof("Read replica available")
+	}
+	rr.available = true
+	rr.lag = time.Duration(lagSeconds * float64(time.Second))
+}
+
+// dbFor returns the replica if a read of the collection can be served from it, or nil to use the primary
+func (rr *replicaRouter) dbFor(ctx context.Context, table string) *sql.DB {
+	if rr == nil || !database.ReplicaReadsAllowed(ctx) || dbsql.GetTXFromContext(ctx) != nil {
+		return nil
+	}
+	tolerance, ok := rr.staleness[table]
+	if !ok {
+		tolerance = rr.defaultStaleness
+	}
+	rr.mux.RLock()
+	defer rr.mux.RUnlock()
+	if !rr.available || tolerance <= 0 || rr.lag > tolerance {
+		return nil
+	}
+	return rr.db
+}
+
+// Query shadows the query function of the embedded database, so that reads of collections which
+// tolerate stale data are served from the read replica when one is configured and up to date.
+// Queries inside a transaction, and on contexts that have not opted in to replica reads, always
+// go to the primary.
+func (s *SQLCommon) Query(ctx context.Context, table string, q sq.SelectBuilder) (*sql.Rows, *dbsql.TXWrapper, error) {
+	if db := s.replicas.dbFor(ctx, table); db != nil {
+		sqlQuery, args, err := q.PlaceholderFormat(s.replicas.placeholderFormat).ToSql()
+		if err == nil {
+			var rows *sql.Rows
+			log.L(ctx).Tracef(`SQL-> query (replica): %s (args: %+v)`, sqlQuery, args)
+			if rows, err = db.QueryContext(ctx, sqlQuery, args...); err == nil {
+				return rows, nil, nil
+			}
+		}
+		log.L(ctx).Warnf("Query of %s on read replica failed, retrying on primary: %s", table, err)
+	}
+	return s.Database.Query(ctx, table, q)
+}
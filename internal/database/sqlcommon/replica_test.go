@@ -0,0 +1,154 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReplicaMockProvider(lagSeconds float64) *mockProvider {
+	mp := newMockProvider()
+	mp.lagQuery = "SELECT lag"
+	mp.config.Set(SQLConfReplicaURL, "replica")
+	mp.config.Set(SQLConfReplicaMaxConnections, 5)
+	mp.config.Set(SQLConfReplicaCollections, map[string]interface{}{
+		"messages": "30s",
+		"events":   0,
+	})
+	mp.mrdb.ExpectQuery("SELECT lag").WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(lagSeconds))
+	return mp
+}
+
+func TestReplicaRouting(t *testing.T) {
+	mp := newReplicaMockProvider(2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := mp.Init(ctx, mp, mp.config, mp.capabilities)
+	assert.NoError(t, err)
+	rr := mp.replicas
+
+	replicaCtx := database.WithReplicaReads(ctx)
+	assert.Nil(t, rr.dbFor(ctx, "messages"))
+	assert.Equal(t, mp.mockReplicaDB, rr.dbFor(replicaCtx, "messages"))
+	assert.Equal(t, mp.mockReplicaDB, rr.dbFor(replicaCtx, "operations"))
+	assert.Nil(t, rr.dbFor(replicaCtx, "events"))
+
+	mp.mrdb.ExpectQuery("SELECT lag").WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(10.5))
+	rr.checkLag(ctx)
+	assert.Equal(t, 10500*time.Millisecond, rr.lag)
+	assert.Equal(t, mp.mockReplicaDB, rr.dbFor(replicaCtx, "messages"))
+	assert.Nil(t, rr.dbFor(replicaCtx, "operations"))
+
+	mp.mrdb.ExpectQuery("SELECT lag").WillReturnError(fmt.Errorf("pop"))
+	rr.checkLag(ctx)
+	assert.Nil(t, rr.dbFor(replicaCtx, "messages"))
+
+	mp.mrdb.ExpectQuery("SELECT lag").WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(0))
+	rr.checkLag(ctx)
+	assert.Equal(t, mp.mockReplicaDB, rr.dbFor(replicaCtx, "messages"))
+	assert.NoError(t, mp.mrdb.ExpectationsWereMet())
+}
+
+func TestReplicaRoutingNotConfigured(t *testing.T) {
+	s, _ := newMockProvider().init()
+	assert.Nil(t, s.replicas)
+	assert.Nil(t, s.replicas.dbFor(database.WithReplicaReads(context.Background()), "messages"))
+}
+
+func TestReplicaRoutingInTransaction(t *testing.T) {
+	mp := newReplicaMockProvider(0)
+	err := mp.Init(context.Background(), mp, mp.config, mp.capabilities)
+	assert.NoError(t, err)
+
+	mp.mdb.ExpectBegin()
+	mp.mdb.ExpectCommit()
+	err = mp.RunAsGroup(database.WithReplicaReads(context.Background()), func(ctx context.Context) error {
+		assert.Nil(t, mp.replicas.dbFor(ctx, "messages"))
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestReplicaBadStaleness(t *testing.T) {
+	mp := newMockProvider()
+	mp.config.Set(SQLConfReplicaURL, "replica")
+	mp.config.Set(SQLConfReplicaCollections, map[string]interface{}{
+		"messages": "forever",
+	})
+	err := mp.Init(context.Background(), mp, mp.config, mp.capabilities)
+	assert.Regexp(t, "FF10508.*messages", err)
+}
+
+func TestReplicaPingWithoutLagQuery(t *testing.T) {
+	mp := newMockProvider()
+	mp.config.Set(SQLConfReplicaURL, "replica")
+	err := mp.Init(context.Background(), mp, mp.config, mp.capabilities)
+	assert.NoError(t, err)
+	assert.True(t, mp.replicas.available)
+	assert.Zero(t, mp.replicas.lag)
+}
+
+func TestReplicaQuery(t *testing.T) {
+	mp := newReplicaMockProvider(0)
+	err := mp.Init(context.Background(), mp, mp.config, mp.capabilities)
+	assert.NoError(t, err)
+	ctx := database.WithReplicaReads(context.Background())
+
+	mp.mrdb.ExpectQuery("SELECT id FROM messages").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("r1"))
+	rows, tx, err := mp.Query(ctx, "messages", sq.Select("id").From("messages"))
+	assert.NoError(t, err)
+	assert.Nil(t, tx)
+	assert.True(t, rows.Next())
+	rows.Close()
+
+	// Falls back to the primary on failure
+	mp.mrdb.ExpectQuery("SELECT id FROM messages").WillReturnError(fmt.Errorf("pop"))
+	mp.mdb.ExpectQuery("SELECT id FROM messages").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("p1"))
+	rows, _, err = mp.Query(ctx, "messages", sq.Select("id").From("messages"))
+	assert.NoError(t, err)
+	rows.Close()
+
+	// Collections with no tolerance for staleness go to the primary
+	mp.mdb.ExpectQuery("SELECT id FROM events").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("p1"))
+	rows, _, err = mp.Query(ctx, "events", sq.Select("id").From("events"))
+	assert.NoError(t, err)
+	rows.Close()
+
+	assert.NoError(t, mp.mrdb.ExpectationsWereMet())
+	assert.NoError(t, mp.mdb.ExpectationsWereMet())
+}
+
+func TestReplicaLagLoop(t *testing.T) {
+	mp := newReplicaMockProvider(0)
+	mp.config.Set(SQLConfReplicaLagCheckInterval, "1ms")
+	mp.mrdb.ExpectQuery("SELECT lag").WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	err := mp.Init(ctx, mp, mp.config, mp.capabilities)
+	assert.NoError(t, err)
+	for mp.mrdb.ExpectationsWereMet() != nil {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+}
@@ -0,0 +1,141 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// retentionQuery returns the table, and the condition selecting records eligible for deletion under a retention policy.
+// Batches, messages and blockchain events are never pruned, so the hashes and manifests needed to verify
+// the chain of pins remain available. Token transfers are never pruned either, as token balances are
+// derived from them, and must be possible to rebuild from them.
+func (s *SQLCommon) retentionQuery(ctx context.Context, namespace string, policy *database.RetentionPolicy) (string, sq.And, error) {
+	where := sq.And{
+		sq.Eq{"namespace": namespace},
+		sq.Lt{"created": policy.Before},
+	}
+	if policy.MaxSequence != nil {
+		where = append(where, sq.LtOrEq{s.SequenceColumn(): *policy.MaxSequence})
+	}
+	switch policy.Collection {
+	case database.PrunableEvents:
+		return eventsTable, where, nil
+	case database.PrunableOperations:
+		return operationsTable, append(where, sq.Or{
+			sq.Eq{"opstatus": core.OpStatusSucceeded},
+			sq.And{sq.Eq{"opstatus": core.OpStatusFailed}, sq.NotEq{"retry_id": nil}},
+		}), nil
+	case database.PrunablePins:
+		return pinsTable, append(where, sq.Eq{"dispatched": true}), nil
+	default:
+		return "", nil, i18n.NewError(ctx, coremsgs.MsgNotPrunableCollection, policy.Collection)
+	}
+}
+
+func (s *SQLCommon) CountPrunableRecords(ctx context.Context, namespace string, policy *database.RetentionPolicy) (count int64, err error) {
+	table, where, err := s.retentionQuery(ctx, namespace, policy)
+	if err != nil {
+		return 0, err
+	}
+	rows, _, err := s.Query(ctx, table, sq.Select("COUNT(*)").From(table).Where(where))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	if rows.Next() {
+		if err = rows.Scan(&count); err != nil {
+			return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, table)
+		}
+	}
+	return count, nil
+}
+
+func (s *SQLCommon) PruneRecords(ctx context.Context, namespace string, policy *database.RetentionPolicy, limit int) (deleted int64, err error) {
+	table, where, err := s.retentionQuery(ctx, namespace, policy)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	cols := []string{s.SequenceColumn()}
+	if table == operationsTable {
+		cols = append(cols, "id")
+	}
+	rows, _, err := s.QueryTx(ctx, table, tx,
+		sq.Select(cols...).
+			From(table).
+			Where(where).
+			OrderBy(s.SequenceColumn()).
+			Limit(uint64(limit)),
+	)
+	if err != nil {
+		return 0, err
+	}
+	var seqs []int64
+	var opIDs []*fftypes.UUID
+	for rows.Next() {
+		var seq int64
+		var id fftypes.UUID
+		dest := []interface{}{&seq}
+		if table == operationsTable {
+			dest = append(dest, &id)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			rows.Close()
+			return 0, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, table)
+		}
+		seqs = append(seqs, seq)
+		if table == operationsTable {
+			opIDs = append(opIDs, &id)
+		}
+	}
+	rows.Close()
+	if len(seqs) == 0 {
+		return 0, s.CommitTx(ctx, tx, autoCommit)
+	}
+
+	if err := s.DeleteTx(ctx, table, tx,
+		sq.Delete(table).Where(sq.Eq{s.SequenceColumn(): seqs}),
+		nil, // no change events for pruning
+	); err != nil && err != database.DeleteRecordNotFound {
+		return 0, err
+	}
+	if len(opIDs) > 0 {
+		// The status history of an operation goes with it
+		if err := s.DeleteTx(ctx, operationHistoryTable, tx,
+			sq.Delete(operationHistoryTable).Where(sq.Eq{"namespace": namespace, "operation_id": opIDs}),
+			nil,
+		); err != nil && err != database.DeleteRecordNotFound {
+			return 0, err
+		}
+	}
+
+	return int64(len(seqs)), s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,187 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRetentionE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	s.callbacks.On("OrderedCollectionNSEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+	s.callbacks.On("UUIDCollectionNSEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	old := fftypes.FFTime(time.Now().Add(-48 * time.Hour))
+	cutoff := fftypes.FFTime(time.Now().Add(-24 * time.Hour))
+
+	for i := 0; i < 3; i++ {
+		event := core.NewEvent(core.EventTypeMessageConfirmed, "ns1", fftypes.NewUUID(), nil, "topic1")
+		event.Created = &old
+		assert.NoError(t, s.InsertEvent(ctx, event))
+	}
+	assert.NoError(t, s.InsertEvent(ctx, core.NewEvent(core.EventTypeMessageConfirmed, "ns1", fftypes.NewUUID(), nil, "topic1")))
+
+	succeeded := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusSucceeded, Created: &old}
+	failed := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusFailed, Created: &old}
+	retried := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusFailed, Created: &old, Retry: fftypes.NewUUID()}
+	pending := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusPending, Created: &old}
+	for _, op := range []*core.Operation{succeeded, failed, retried, pending} {
+		assert.NoError(t, s.InsertOperation(ctx, op))
+	}
+	assert.NoError(t, s.InsertOperationHistory(ctx, &core.OperationHistoryEntry{
+		ID: fftypes.NewUUID(), Namespace: "ns1", Operation: succeeded.ID, Status: core.OpStatusSucceeded, Created: &old,
+	}))
+
+	assert.NoError(t, s.InsertPins(ctx, []*core.Pin{
+		{Namespace: "ns1", Hash: fftypes.NewRandB32(), Batch: fftypes.NewUUID(), BatchHash: fftypes.NewRandB32(), Dispatched: true, Created: &old},
+		{Namespace: "ns1", Hash: fftypes.NewRandB32(), Batch: fftypes.NewUUID(), BatchHash: fftypes.NewRandB32(), Dispatched: false, Created: &old},
+	}))
+
+	// Events - limited by sequence, and by batch size
+	maxSeq := int64(2)
+	events := &database.RetentionPolicy{Collection: database.PrunableEvents, Before: &cutoff}
+	count, err := s.CountPrunableRecords(ctx, "ns1", events)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	events.MaxSequence = &maxSeq
+	count, err = s.CountPrunableRecords(ctx, "ns1", events)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	deleted, err := s.PruneRecords(ctx, "ns1", events, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	deleted, err = s.PruneRecords(ctx, "ns1", events, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	deleted, err = s.PruneRecords(ctx, "ns1", events, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	// Operations - only succeeded, and failed with a retry
+	ops := &database.RetentionPolicy{Collection: database.PrunableOperations, Before: &cutoff}
+	count, err = s.CountPrunableRecords(ctx, "ns2", ops)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	deleted, err = s.PruneRecords(ctx, "ns1", ops, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	op, err := s.GetOperationByID(ctx, "ns1", succeeded.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, op)
+	op, err = s.GetOperationByID(ctx, "ns1", failed.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, op)
+	fb := database.OperationHistoryQueryFactory.NewFilter(ctx)
+	history, _, err := s.GetOperationHistory(ctx, "ns1", fb.And(fb.Eq("operation", succeeded.ID)))
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+
+	// Pins - only dispatched
+	pins := &database.RetentionPolicy{Collection: database.PrunablePins, Before: &cutoff}
+	deleted, err = s.PruneRecords(ctx, "ns1", pins, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestRetentionBadCollection(t *testing.T) {
+	s, _ := newMockProvider().init()
+	policy := &database.RetentionPolicy{Collection: "messages", Before: fftypes.Now()}
+	_, err := s.CountPrunableRecords(context.Background(), "ns1", policy)
+	assert.Regexp(t, "FF10509", err)
+	_, err = s.PruneRecords(context.Background(), "ns1", policy, 10)
+	assert.Regexp(t, "FF10509", err)
+}
+
+func TestCountPrunableRecordsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.CountPrunableRecords(context.Background(), "ns1", &database.RetentionPolicy{Collection: database.PrunableEvents, Before: fftypes.Now()})
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountPrunableRecordsScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow("not a number"))
+	_, err := s.CountPrunableRecords(context.Background(), "ns1", &database.RetentionPolicy{Collection: database.PrunableEvents, Before: fftypes.Now()})
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPruneRecordsBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.PruneRecords(context.Background(), "ns1", &database.RetentionPolicy{Collection: database.PrunableEvents, Before: fftypes.Now()}, 10)
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPruneRecordsSelectFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PruneRecords(context.Background(), "ns1", &database.RetentionPolicy{Collection: database.PrunablePins, Before: fftypes.Now()}, 10)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPruneRecordsScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq", "id"}).AddRow(1, "not a uuid"))
+	mock.ExpectRollback()
+	_, err := s.PruneRecords(context.Background(), "ns1", &database.RetentionPolicy{Collection: database.PrunableOperations, Before: fftypes.Now()}, 10)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPruneRecordsDeleteFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(1))
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PruneRecords(context.Background(), "ns1", &database.RetentionPolicy{Collection: database.PrunableEvents, Before: fftypes.Now()}, 10)
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPruneRecordsDeleteHistoryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq", "id"}).AddRow(1, fftypes.NewUUID().String()))
+	mock.ExpectExec("DELETE .*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.PruneRecords(context.Background(), "ns1", &database.RetentionPolicy{Collection: database.PrunableOperations, Before: fftypes.Now()}, 10)
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
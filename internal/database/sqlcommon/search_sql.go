@@ -0,0 +1,156 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+var (
+	searchDocColumns = []string{
+		"namespace",
+		"message_id",
+		"content",
+		"created",
+	}
+)
+
+const searchDocsTable = "searchdocs"
+
+// FullTextSearchProvider is implemented by providers with native full-text search, which is used to match
+// and rank search documents. For other providers, every term of the query must appear within the document,
+// and documents are ranked by how often the terms occur.
+type FullTextSearchProvider interface {
+	FullTextSearchMatch(query string) (match sq.Sqlizer, rank sq.Sqlizer)
+}
+
+func (s *SQLCommon) InsertSearchDocuments(ctx context.Context, docs []*core.SearchDocument) (err error) {
+	if len(docs) == 0 {
+		return nil
+	}
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	// Skip any messages that were indexed before a restart, but not recorded in the indexer's offset
+	ids := make([]*fftypes.UUID, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.Message
+	}
+	rows, _, err := s.QueryTx(ctx, searchDocsTable, tx,
+		sq.Select("namespace", "message_id").
+			From(searchDocsTable).
+			Where(sq.Eq{"message_id": ids}),
+	)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var ns string
+		var id fftypes.UUID
+		if err := rows.Scan(&ns, &id); err != nil {
+			rows.Close()
+			return i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, searchDocsTable)
+		}
+		existing[ns+":"+id.String()] = true
+	}
+	rows.Close()
+
+	for _, doc := range docs {
+		if existing[doc.Namespace+":"+doc.Message.String()] {
+			continue
+		}
+		if _, err := s.InsertTx(ctx, searchDocsTable, tx,
+			sq.Insert(searchDocsTable).
+				Columns(searchDocColumns...).
+				Values(
+					doc.Namespace,
+					doc.Message,
+					doc.Content,
+					doc.Created,
+				),
+			nil, // no change events for search documents
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) SearchDocuments(ctx context.Context, namespace, query string, limit int) ([]*database.SearchHit, error) {
+	var match, rank sq.Sqlizer
+	if s.fullText != nil {
+		match, rank = s.fullText.FullTextSearchMatch(query)
+	} else {
+		match, rank = substringSearchMatch(query)
+	}
+
+	rows, _, err := s.Query(ctx, searchDocsTable,
+		sq.Select("message_id").
+			Column(sq.Alias(rank, "score")).
+			From(searchDocsTable).
+			Where(sq.And{
+				sq.Eq{"namespace": namespace},
+				match,
+			}).
+			OrderBy("score DESC", s.SequenceColumn()+" DESC").
+			Limit(uint64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits := []*database.SearchHit{}
+	for rows.Next() {
+		var hit database.SearchHit
+		if err := rows.Scan(&hit.Message, &hit.Score); err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, searchDocsTable)
+		}
+		hits = append(hits, &hit)
+	}
+	return hits, nil
+}
+
+func substringSearchMatch(query string) (match sq.Sqlizer, rank sq.Sqlizer) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return sq.Expr("1=0"), sq.Expr("0")
+	}
+	and := sq.And{}
+	occurrences := make([]string, len(terms))
+	args := make([]interface{}, 0, len(terms)*2)
+	for i, term := range terms {
+		and = append(and, sq.Like{"LOWER(content)": "%" + term + "%"})
+		occurrences[i] = "(LENGTH(content) - LENGTH(REPLACE(LOWER(content), ?, ''))) / ?"
+		args = append(args, term, len(term))
+	}
+	return and, sq.Expr(fmt.Sprintf("(%s)", strings.Join(occurrences, " + ")), args...)
+}
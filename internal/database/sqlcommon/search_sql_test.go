@@ -0,0 +1,126 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchDocumentsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	msg1, msg2, msg3 := fftypes.NewUUID(), fftypes.NewUUID(), fftypes.NewUUID()
+	docs := []*core.SearchDocument{
+		{Namespace: "ns1", Message: msg1, Content: "invoice Widget blue", Created: fftypes.Now()},
+		{Namespace: "ns1", Message: msg2, Content: "invoice widget widget red", Created: fftypes.Now()},
+		{Namespace: "ns2", Message: msg3, Content: "invoice widget", Created: fftypes.Now()},
+	}
+	err := s.InsertSearchDocuments(ctx, docs)
+	assert.NoError(t, err)
+
+	// Re-indexing is skipped
+	err = s.InsertSearchDocuments(ctx, docs[0:1])
+	assert.NoError(t, err)
+
+	hits, err := s.SearchDocuments(ctx, "ns1", "WIDGET invoice", 10)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 2)
+	assert.Equal(t, *msg2, *hits[0].Message)
+	assert.Equal(t, float64(3), hits[0].Score)
+	assert.Equal(t, *msg1, *hits[1].Message)
+	assert.Equal(t, float64(2), hits[1].Score)
+
+	hits, err = s.SearchDocuments(ctx, "ns1", "blue", 10)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, *msg1, *hits[0].Message)
+
+	hits, err = s.SearchDocuments(ctx, "ns1", "green", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+
+	hits, err = s.SearchDocuments(ctx, "ns1", " ", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+
+	err = s.InsertSearchDocuments(ctx, []*core.SearchDocument{})
+	assert.NoError(t, err)
+}
+
+func TestInsertSearchDocumentsFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertSearchDocuments(context.Background(), []*core.SearchDocument{{Message: fftypes.NewUUID()}})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertSearchDocumentsFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertSearchDocuments(context.Background(), []*core.SearchDocument{{Message: fftypes.NewUUID()}})
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertSearchDocumentsFailScan(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("ns1"))
+	mock.ExpectRollback()
+	err := s.InsertSearchDocuments(context.Background(), []*core.SearchDocument{{Message: fftypes.NewUUID()}})
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertSearchDocumentsFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace", "message_id"}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertSearchDocuments(context.Background(), []*core.SearchDocument{{Message: fftypes.NewUUID()}})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchDocumentsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.SearchDocuments(context.Background(), "ns1", "widget", 10)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchDocumentsScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"message_id"}).AddRow("bad"))
+	_, err := s.SearchDocuments(context.Background(), "ns1", "widget", 10)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,108 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	snapshotVerificationColumns = []string{
+		"namespace",
+		"state",
+		"updated",
+	}
+)
+
+const snapshotVerificationsTable = "snapshotverifications"
+
+// UpsertSnapshotVerification stores the verification state of the snapshot most recently imported into a namespace,
+// replacing any previous state
+func (s *SQLCommon) UpsertSnapshotVerification(ctx context.Context, namespace string, state *core.SnapshotVerificationState) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	rows, _, err := s.QueryTx(ctx, snapshotVerificationsTable, tx,
+		sq.Select("seq").
+			From(snapshotVerificationsTable).
+			Where(sq.Eq{"namespace": namespace}),
+	)
+	if err != nil {
+		return err
+	}
+	existing := rows.Next()
+	rows.Close()
+
+	if existing {
+		if _, err = s.UpdateTx(ctx, snapshotVerificationsTable, tx,
+			sq.Update(snapshotVerificationsTable).
+				Set("state", state).
+				Set("updated", fftypes.Now()).
+				Where(sq.Eq{"namespace": namespace}),
+			nil, // no change events for snapshot verification
+		); err != nil {
+			return err
+		}
+	} else {
+		if _, err = s.InsertTx(ctx, snapshotVerificationsTable, tx,
+			sq.Insert(snapshotVerificationsTable).
+				Columns(snapshotVerificationColumns...).
+				Values(
+					namespace,
+					state,
+					fftypes.Now(),
+				),
+			nil, // no change events for snapshot verification
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+// GetSnapshotVerification returns the verification state of the snapshot most recently imported into a namespace,
+// or nil if no snapshot has been imported
+func (s *SQLCommon) GetSnapshotVerification(ctx context.Context, namespace string) (*core.SnapshotVerificationState, error) {
+	rows, _, err := s.Query(ctx, snapshotVerificationsTable,
+		sq.Select("state").
+			From(snapshotVerificationsTable).
+			Where(sq.Eq{"namespace": namespace}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+	var state core.SnapshotVerificationState
+	if err := rows.Scan(&state); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, snapshotVerificationsTable)
+	}
+	return &state, nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotVerificationE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Nothing imported yet
+	state, err := s.GetSnapshotVerification(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	// Store the initial state
+	batchID := fftypes.NewUUID()
+	state1 := &core.SnapshotVerificationState{
+		SnapshotVerification: core.SnapshotVerification{
+			Hash:     fftypes.NewRandB32(),
+			Imported: fftypes.Now(),
+			Batches:  1,
+			Pending:  1,
+		},
+		Expected: map[string]*fftypes.Bytes32{batchID.String(): fftypes.NewRandB32()},
+	}
+	err = s.UpsertSnapshotVerification(ctx, "ns1", state1)
+	assert.NoError(t, err)
+	state, err = s.GetSnapshotVerification(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.Equal(t, state1.Hash, state.Hash)
+	assert.Equal(t, state1.Expected, state.Expected)
+
+	// Replace it as verification progresses
+	state2 := &core.SnapshotVerificationState{
+		SnapshotVerification: core.SnapshotVerification{
+			Hash:       state1.Hash,
+			Imported:   state1.Imported,
+			Completed:  fftypes.Now(),
+			Batches:    1,
+			Mismatched: []*fftypes.UUID{batchID},
+		},
+	}
+	err = s.UpsertSnapshotVerification(ctx, "ns1", state2)
+	assert.NoError(t, err)
+	state, err = s.GetSnapshotVerification(ctx, "ns1")
+	assert.NoError(t, err)
+	assert.NotNil(t, state.Completed)
+	assert.Empty(t, state.Expected)
+	assert.Equal(t, []*fftypes.UUID{batchID}, state.Mismatched)
+
+	// State is scoped to the namespace
+	state, err = s.GetSnapshotVerification(ctx, "ns2")
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestUpsertSnapshotVerificationFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpsertSnapshotVerification(context.Background(), "ns1", &core.SnapshotVerificationState{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertSnapshotVerificationFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertSnapshotVerification(context.Background(), "ns1", &core.SnapshotVerificationState{})
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertSnapshotVerificationFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertSnapshotVerification(context.Background(), "ns1", &core.SnapshotVerificationState{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertSnapshotVerificationFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(1))
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertSnapshotVerification(context.Background(), "ns1", &core.SnapshotVerificationState{})
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSnapshotVerificationQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetSnapshotVerification(context.Background(), "ns1")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSnapshotVerificationReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"state"}).AddRow("!json"))
+	_, err := s.GetSnapshotVerification(context.Background(), "ns1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -34,6 +34,9 @@ type SQLCommon struct {
 	dbsql.Database
 	capabilities *database.Capabilities
 	callbacks    callbacks
+	replicas     *replicaRouter
+	online       onlineMigrationConfig
+	fullText     FullTextSearchProvider
 }
 
 type callbacks struct {
@@ -79,7 +82,28 @@ func (cb *callbacks) HashCollectionNSEvent(resType database.HashCollectionNS, ev
 
 func (s *SQLCommon) Init(ctx context.Context, provider dbsql.Provider, config config.Section, capabilities *database.Capabilities) (err error) {
 	s.capabilities = capabilities
-	return s.Database.Init(ctx, provider, config)
+	if err := s.Database.Init(ctx, provider, config); err != nil {
+		return err
+	}
+	s.online = onlineMigrationConfig{
+		directory: config.GetString(SQLConfOnlineMigrationsDirectory),
+		batchSize: config.GetInt(SQLConfOnlineMigrationsBatchSize),
+	}
+	if sp, ok := provider.(SequenceResetProvider); ok {
+		s.online.resetSequence = sp.ResetSequenceStatement
+	}
+	if tp, ok := provider.(ChangeTrackingProvider); ok {
+		s.online.tracking = tp
+	}
+	if fp, ok := provider.(FullTextSearchProvider); ok {
+		s.fullText = fp
+	}
+	if config.GetString(SQLConfReplicaURL) != "" {
+		if s.replicas, err = newReplicaRouter(ctx, provider, config); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *SQLCommon) SetHandler(namespace string, handler database.Callbacks) {
@@ -96,3 +120,8 @@ func (s *SQLCommon) SetHandler(namespace string, handler database.Callbacks) {
 }
 
 func (s *SQLCommon) Capabilities() *database.Capabilities { return s.capabilities }
+
+// CheckHealth confirms the database connection is alive
+func (s *SQLCommon) CheckHealth(ctx context.Context) error {
+	return s.DB().PingContext(ctx)
+}
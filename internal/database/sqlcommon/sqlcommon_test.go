@@ -114,3 +114,11 @@ func TestNamespaceCallbacks(t *testing.T) {
 	s.SetHandler("ns1", nil)
 	assert.Empty(t, s.callbacks.handlers)
 }
+
+func TestCheckHealth(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+
+	err := s.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
@@ -54,6 +54,7 @@ var (
 		"methods",
 		"published",
 		"plugin_data",
+		"first_event",
 	}
 	tokenPoolFilterFieldMap = map[string]string{
 		"message":         "message_id",
@@ -95,6 +96,7 @@ func (s *SQLCommon) attemptTokenPoolUpdate(ctx context.Context, tx *dbsql.TXWrap
 			Set("methods", pool.Methods).
 			Set("published", pool.Published).
 			Set("plugin_data", pool.PluginData).
+			Set("first_event", pool.FirstEvent).
 			Where(sq.Eq{"id": pool.ID}),
 		func() {
 			s.callbacks.UUIDCollectionNSEvent(database.CollectionTokenPools, core.ChangeEventTypeUpdated, pool.Namespace, pool.ID)
@@ -133,6 +135,7 @@ func (s *SQLCommon) setTokenPoolInsertValues(query sq.InsertBuilder, pool *core.
 		pool.Methods,
 		pool.Published,
 		pool.PluginData,
+		pool.FirstEvent,
 	)
 }
 
@@ -254,6 +257,8 @@ func (s *SQLCommon) tokenPoolResult(ctx context.Context, row *sql.Rows) (*core.T
 		&pool.Methods,
 		&pool.Published,
 		&pool.PluginData,
+		&pool.FirstEvent,
+		&pool.Sequence,
 	)
 	if iface.ID != nil {
 		pool.Interface = &iface
@@ -268,8 +273,10 @@ func (s *SQLCommon) tokenPoolResult(ctx context.Context, row *sql.Rows) (*core.T
 }
 
 func (s *SQLCommon) getTokenPoolPred(ctx context.Context, desc string, pred interface{}) (*core.TokenPool, error) {
+	cols := append([]string{}, tokenPoolColumns...)
+	cols = append(cols, s.SequenceColumn())
 	rows, _, err := s.Query(ctx, tokenpoolTable,
-		sq.Select(tokenPoolColumns...).
+		sq.Select(cols...).
 			From(tokenpoolTable).
 			Where(pred),
 	)
@@ -304,7 +311,9 @@ func (s *SQLCommon) GetTokenPoolByNetworkName(ctx context.Context, namespace, ne
 }
 
 func (s *SQLCommon) GetTokenPools(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.TokenPool, fr *ffapi.FilterResult, err error) {
-	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(tokenPoolColumns...).From("tokenpool"),
+	cols := append([]string{}, tokenPoolColumns...)
+	cols = append(cols, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From("tokenpool"),
 		filter, tokenPoolFilterFieldMap, []interface{}{"seq"}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
@@ -61,6 +61,7 @@ func TestTokenPoolE2EWithDB(t *testing.T) {
 			ID: fftypes.NewUUID(),
 		},
 		InterfaceFormat: "abi",
+		FirstEvent:      "oldest",
 	}
 
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionTokenPools, core.ChangeEventTypeCreated, "ns1", poolID, mock.Anything).
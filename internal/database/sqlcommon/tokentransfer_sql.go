@@ -147,6 +147,7 @@ func (s *SQLCommon) tokenTransferResult(ctx context.Context, row *sql.Rows) (*co
 		&transfer.TX.ID,
 		&transfer.BlockchainEvent,
 		&transfer.Created,
+		&transfer.Sequence,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, tokentransferTable)
@@ -155,8 +156,10 @@ func (s *SQLCommon) tokenTransferResult(ctx context.Context, row *sql.Rows) (*co
 }
 
 func (s *SQLCommon) getTokenTransferPred(ctx context.Context, desc string, pred interface{}) (*core.TokenTransfer, error) {
+	cols := append([]string{}, tokenTransferColumns...)
+	cols = append(cols, s.SequenceColumn())
 	rows, _, err := s.Query(ctx, tokentransferTable,
-		sq.Select(tokenTransferColumns...).
+		sq.Select(cols...).
 			From(tokentransferTable).
 			Where(pred),
 	)
@@ -191,7 +194,9 @@ func (s *SQLCommon) GetTokenTransferByProtocolID(ctx context.Context, namespace
 }
 
 func (s *SQLCommon) GetTokenTransfers(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.TokenTransfer, fr *ffapi.FilterResult, err error) {
-	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(tokenTransferColumns...).From(tokentransferTable),
+	cols := append([]string{}, tokenTransferColumns...)
+	cols = append(cols, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(tokentransferTable),
 		filter, tokenTransferFilterFieldMap, []interface{}{"seq"}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
@@ -232,3 +237,21 @@ func (s *SQLCommon) DeleteTokenTransfers(ctx context.Context, namespace string,
 
 	return s.CommitTx(ctx, tx, autoCommit)
 }
+
+func (s *SQLCommon) DeleteTokenTransfer(ctx context.Context, namespace string, localID *fftypes.UUID) error {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, tokentransferTable, tx, sq.Delete(tokentransferTable).Where(sq.Eq{
+		"namespace": namespace,
+		"local_id":  localID,
+	}), nil)
+	if err != nil && err != fftypes.DeleteRecordNotFound {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
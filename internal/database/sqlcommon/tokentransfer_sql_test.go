@@ -97,15 +97,24 @@ func TestTokenTransferE2EWithDB(t *testing.T) {
 		fb.Eq("to", transfer.To),
 		fb.Eq("protocolid", transfer.ProtocolID),
 		fb.Eq("created", transfer.Created),
+		fb.Gt("sequence", 0),
 	)
 	transfers, res, err := s.GetTokenTransfers(ctx, "ns1", filter.Count(true))
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(transfers))
 	assert.Equal(t, int64(1), *res.TotalCount)
+	assert.Greater(t, transfers[0].Sequence, int64(0))
 	transferReadJson, _ = json.Marshal(transfers[0])
 	assert.Equal(t, string(transferJson), string(transferReadJson))
 
-	// Delete the token transfer
+	// Delete the single token transfer
+	err = s.DeleteTokenTransfer(ctx, "ns1", transfer.LocalID)
+	assert.NoError(t, err)
+	transferRead, err = s.GetTokenTransferByID(ctx, "ns1", transfer.LocalID)
+	assert.NoError(t, err)
+	assert.Nil(t, transferRead)
+
+	// Delete the token transfers of the pool
 	err = s.DeleteTokenTransfers(ctx, "ns1", transfer.Pool)
 	assert.NoError(t, err)
 }
@@ -220,3 +229,21 @@ func TestDeleteTokenTransfersFailDelete(t *testing.T) {
 	assert.Regexp(t, "FF00179", err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestDeleteTokenTransferFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteTokenTransfer(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTokenTransferFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteTokenTransfer(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,158 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	trafficStatsColumns = []string{
+		"namespace",
+		"bucket",
+		"counterparty",
+		"direction",
+		"messages",
+		"message_bytes",
+		"transfers",
+		"updated",
+	}
+	trafficStatsFilterFieldMap = map[string]string{}
+)
+
+const trafficStatsTable = "trafficstats"
+
+// AddTrafficStats adds to the totals of each bucket, counterparty and direction, creating the row
+// for any that are not yet recorded
+func (s *SQLCommon) AddTrafficStats(ctx context.Context, stats []*core.TrafficStats) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	for _, add := range stats {
+		key := sq.Eq{
+			"namespace":    add.Namespace,
+			"bucket":       add.Bucket,
+			"counterparty": add.Counterparty,
+			"direction":    add.Direction,
+		}
+		rows, _, err := s.QueryTx(ctx, trafficStatsTable, tx,
+			sq.Select(trafficStatsColumns...).
+				From(trafficStatsTable).
+				Where(key),
+		)
+		if err != nil {
+			return err
+		}
+		var existing *core.TrafficStats
+		if rows.Next() {
+			existing, err = s.trafficStatsResult(ctx, rows)
+		}
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		now := fftypes.Now()
+		if existing != nil {
+			if _, err = s.UpdateTx(ctx, trafficStatsTable, tx,
+				sq.Update(trafficStatsTable).
+					Set("messages", existing.Messages+add.Messages).
+					Set("message_bytes", existing.MessageBytes+add.MessageBytes).
+					Set("transfers", existing.Transfers+add.Transfers).
+					Set("updated", now).
+					Where(key),
+				nil, // no change events for traffic stats
+			); err != nil {
+				return err
+			}
+		} else {
+			if _, err = s.InsertTx(ctx, trafficStatsTable, tx,
+				sq.Insert(trafficStatsTable).
+					Columns(trafficStatsColumns...).
+					Values(
+						add.Namespace,
+						add.Bucket,
+						add.Counterparty,
+						add.Direction,
+						add.Messages,
+						add.MessageBytes,
+						add.Transfers,
+						now,
+					),
+				nil, // no change events for traffic stats
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) trafficStatsResult(ctx context.Context, row *sql.Rows) (*core.TrafficStats, error) {
+	var stats core.TrafficStats
+	err := row.Scan(
+		&stats.Namespace,
+		&stats.Bucket,
+		&stats.Counterparty,
+		&stats.Direction,
+		&stats.Messages,
+		&stats.MessageBytes,
+		&stats.Transfers,
+		&stats.Updated,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, trafficStatsTable)
+	}
+	return &stats, nil
+}
+
+func (s *SQLCommon) GetTrafficStats(ctx context.Context, namespace string, filter ffapi.Filter) (stats []*core.TrafficStats, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(trafficStatsColumns...).From(trafficStatsTable), filter, trafficStatsFilterFieldMap,
+		[]interface{}{"seq"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, trafficStatsTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	stats = []*core.TrafficStats{}
+	for rows.Next() {
+		ts, err := s.trafficStatsResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		stats = append(stats, ts)
+	}
+
+	return stats, s.QueryRes(ctx, trafficStatsTable, tx, fop, nil, fi), err
+}
@@ -0,0 +1,146 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrafficStatsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	bucket := fftypes.FFTime(time.Now().Truncate(time.Hour))
+	err := s.AddTrafficStats(ctx, []*core.TrafficStats{
+		{Namespace: "ns1", Bucket: &bucket, Counterparty: "did:firefly:org/org2", Direction: core.TrafficDirectionSent, Messages: 1, MessageBytes: 100},
+		{Namespace: "ns1", Bucket: &bucket, Counterparty: "did:firefly:org/org2", Direction: core.TrafficDirectionReceived, Transfers: 1},
+	})
+	assert.NoError(t, err)
+
+	// Adding to an existing bucket increments the totals
+	err = s.AddTrafficStats(ctx, []*core.TrafficStats{
+		{Namespace: "ns1", Bucket: &bucket, Counterparty: "did:firefly:org/org2", Direction: core.TrafficDirectionSent, Messages: 2, MessageBytes: 50, Transfers: 1},
+	})
+	assert.NoError(t, err)
+
+	fb := database.TrafficStatsQueryFactory.NewFilter(ctx)
+	stats, res, err := s.GetTrafficStats(ctx, "ns1", fb.And(
+		fb.Eq("counterparty", "did:firefly:org/org2"),
+		fb.Gte("bucket", &bucket),
+	).Sort("direction").Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, core.TrafficDirectionReceived, stats[0].Direction)
+	assert.Equal(t, int64(1), stats[0].Transfers)
+	assert.Equal(t, core.TrafficDirectionSent, stats[1].Direction)
+	assert.Equal(t, int64(3), stats[1].Messages)
+	assert.Equal(t, int64(150), stats[1].MessageBytes)
+	assert.Equal(t, int64(1), stats[1].Transfers)
+	assert.Equal(t, bucket.String(), stats[1].Bucket.String())
+	assert.NotNil(t, stats[1].Updated)
+
+	stats, _, err = s.GetTrafficStats(ctx, "ns2", fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, stats)
+}
+
+func TestAddTrafficStatsFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.AddTrafficStats(context.Background(), []*core.TrafficStats{{}})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTrafficStatsFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.AddTrafficStats(context.Background(), []*core.TrafficStats{{}})
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTrafficStatsFailRead(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("only one"))
+	mock.ExpectRollback()
+	err := s.AddTrafficStats(context.Background(), []*core.TrafficStats{{}})
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTrafficStatsFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.AddTrafficStats(context.Background(), []*core.TrafficStats{{}})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddTrafficStatsFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(trafficStatsColumns).
+		AddRow("ns1", 0, "did:firefly:org/org2", "sent", 1, 100, 0, 0))
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.AddTrafficStats(context.Background(), []*core.TrafficStats{{}})
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTrafficStatsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.TrafficStatsQueryFactory.NewFilter(context.Background()).Eq("direction", "sent")
+	_, _, err := s.GetTrafficStats(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTrafficStatsBuildQueryFail(t *testing.T) {
+	s, _ := newMockProvider().init()
+	f := database.TrafficStatsQueryFactory.NewFilter(context.Background()).Eq("direction", map[bool]bool{true: false})
+	_, _, err := s.GetTrafficStats(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF00143.*direction", err)
+}
+
+func TestGetTrafficStatsReadMessageFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("only one"))
+	f := database.TrafficStatsQueryFactory.NewFilter(context.Background()).Eq("direction", "sent")
+	_, _, err := s.GetTrafficStats(context.Background(), "ns1", f)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
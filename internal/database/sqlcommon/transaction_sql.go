@@ -39,11 +39,15 @@ var (
 		"created",
 		"idempotency_key",
 		"blockchain_ids",
+		"labels",
+		"saga",
+		"correlation_id",
 	}
 	transactionFilterFieldMap = map[string]string{
 		"type":           "ttype",
 		"idempotencykey": "idempotency_key",
 		"blockchainids":  "blockchain_ids",
+		"correlationid":  "correlation_id",
 	}
 )
 
@@ -66,6 +70,9 @@ func (s *SQLCommon) setTransactionInsertValues(query sq.InsertBuilder, transacti
 		transaction.Created,
 		transaction.IdempotencyKey,
 		transaction.BlockchainIDs,
+		transaction.Labels,
+		transaction.Saga,
+		transaction.CorrelationID,
 	)
 }
 
@@ -172,6 +179,9 @@ func (s *SQLCommon) transactionResult(ctx context.Context, row *sql.Rows) (*core
 		&transaction.Created,
 		&transaction.IdempotencyKey,
 		&transaction.BlockchainIDs,
+		&transaction.Labels,
+		&transaction.Saga,
+		&transaction.CorrelationID,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, transactionsTable)
@@ -251,3 +261,92 @@ func (s *SQLCommon) UpdateTransaction(ctx context.Context, namespace string, id
 
 	return s.CommitTx(ctx, tx, autoCommit)
 }
+
+func (s *SQLCommon) UpdateTransactionSaga(ctx context.Context, namespace string, id *fftypes.UUID, saga *core.TransactionSaga) (err error) {
+
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	_, err = s.UpdateTx(ctx, transactionsTable, tx,
+		sq.Update(transactionsTable).
+			Set("saga", saga).
+			Where(sq.Eq{"id": id, "namespace": namespace}),
+		func() {
+			s.callbacks.UUIDCollectionNSEvent(database.CollectionTransactions, core.ChangeEventTypeUpdated, namespace, id)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) ClearIdempotencyKey(ctx context.Context, namespace string, key core.IdempotencyKey) (cleared []*fftypes.UUID, err error) {
+	return s.clearIdempotencyKeys(ctx, namespace, sq.Eq{"idempotency_key": key})
+}
+
+func (s *SQLCommon) ExpireIdempotencyKeys(ctx context.Context, namespace string, createdBefore *fftypes.FFTime) (cleared []*fftypes.UUID, err error) {
+	return s.clearIdempotencyKeys(ctx, namespace, sq.And{
+		sq.NotEq{"idempotency_key": nil},
+		sq.Lt{"created": createdBefore},
+	})
+}
+
+// clearIdempotencyKeys releases matching idempotency keys on transactions, and on the messages that were
+// submitted with them, so that they can be used again. Returns the IDs of the transactions updated, so they
+// can be evicted from caches.
+func (s *SQLCommon) clearIdempotencyKeys(ctx context.Context, namespace string, where sq.Sqlizer) (cleared []*fftypes.UUID, err error) {
+
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	txRows, _, err := s.QueryTx(ctx, transactionsTable, tx,
+		sq.Select("id").
+			From(transactionsTable).
+			Where(sq.And{sq.Eq{"namespace": namespace}, where}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	cleared = []*fftypes.UUID{}
+	for txRows.Next() {
+		var id fftypes.UUID
+		if err = txRows.Scan(&id); err != nil {
+			break
+		}
+		cleared = append(cleared, &id)
+	}
+	txRows.Close()
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, transactionsTable)
+	}
+
+	_, err = s.UpdateTx(ctx, transactionsTable, tx,
+		sq.Update(transactionsTable).
+			Set("idempotency_key", nil).
+			Where(sq.And{sq.Eq{"namespace": namespace}, where}),
+		nil, /* no change events for filter based updates */
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.UpdateTx(ctx, messagesTable, tx,
+		sq.Update(messagesTable).
+			Set("idempotency_key", nil).
+			Where(sq.And{sq.Eq{"namespace": namespace}, where}),
+		nil, /* no change events for filter based updates */
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return cleared, s.CommitTx(ctx, tx, autoCommit)
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -44,6 +45,8 @@ func TestTransactionE2EWithDB(t *testing.T) {
 		Type:          core.TransactionTypeBatchPin,
 		Namespace:     "ns1",
 		BlockchainIDs: fftypes.FFStringArray{"tx1"},
+		Labels:        fftypes.FFStringArray{"payroll"},
+		CorrelationID: "esb-1234",
 	}
 
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionTransactions, core.ChangeEventTypeCreated, "ns1", transactionID, mock.Anything).Return()
@@ -65,6 +68,8 @@ func TestTransactionE2EWithDB(t *testing.T) {
 	filter := fb.And(
 		fb.Eq("id", transaction.ID.String()),
 		fb.Gt("created", "0"),
+		fb.Contains("labels", "payroll"),
+		fb.Eq("correlationid", "esb-1234"),
 	)
 	transactions, res, err := s.GetTransactions(ctx, "ns1", filter.Count(true))
 	assert.NoError(t, err)
@@ -107,6 +112,19 @@ func TestTransactionE2EWithDB(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(transactions))
 	assert.Equal(t, (core.IdempotencyKey)("testKey"), transactions[0].IdempotencyKey)
+
+	// Record a saga on the transaction
+	saga := &core.TransactionSaga{
+		State: core.SagaStateActive,
+		Steps: []*core.SagaStep{{Name: "step1", Operation: fftypes.NewUUID(), Status: core.OpStatusPending}},
+	}
+	err = s.UpdateTransactionSaga(ctx, "ns1", transaction.ID, saga)
+	assert.NoError(t, err)
+	transactionRead, err = s.GetTransactionByID(ctx, "ns1", transactionID)
+	assert.NoError(t, err)
+	sagaJson, _ := json.Marshal(saga)
+	sagaReadJson, _ := json.Marshal(transactionRead.Saga)
+	assert.Equal(t, string(sagaJson), string(sagaReadJson))
 }
 
 func TestTransactionE2EInsertManyIdempotency(t *testing.T) {
@@ -291,6 +309,22 @@ func TestTransactionUpdateFail(t *testing.T) {
 	assert.Regexp(t, "FF00178", err)
 }
 
+func TestTransactionUpdateSagaBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpdateTransactionSaga(context.Background(), "ns1", fftypes.NewUUID(), &core.TransactionSaga{})
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestTransactionUpdateSagaFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpdateTransactionSaga(context.Background(), "ns1", fftypes.NewUUID(), &core.TransactionSaga{})
+	assert.Regexp(t, "FF00178", err)
+}
+
 func TestInsertTransactionsBeginFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
@@ -362,3 +396,112 @@ func TestInsertTransactionsSingleRowFail(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 	s.callbacks.AssertExpectations(t)
 }
+
+func TestTransactionE2EClearIdempotencyKeys(t *testing.T) {
+
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionTransactions, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+
+	hourAgo := fftypes.FFTime(time.Now().Add(-1 * time.Hour))
+	tenMinutesAgo := fftypes.FFTime(time.Now().Add(-10 * time.Minute))
+	oldTxn := &core.Transaction{
+		ID:             fftypes.NewUUID(),
+		Type:           core.TransactionTypeBatchPin,
+		Namespace:      "ns1",
+		IdempotencyKey: core.IdempotencyKey("idem_old"),
+		Created:        &hourAgo,
+	}
+	newTxn := &core.Transaction{
+		ID:             fftypes.NewUUID(),
+		Type:           core.TransactionTypeBatchPin,
+		Namespace:      "ns1",
+		IdempotencyKey: core.IdempotencyKey("idem_new"),
+		Created:        fftypes.Now(),
+	}
+	err := s.InsertTransaction(ctx, oldTxn)
+	assert.NoError(t, err)
+	err = s.InsertTransaction(ctx, newTxn)
+	assert.NoError(t, err)
+
+	// Expire keys on anything older than 10 minutes
+	cleared, err := s.ExpireIdempotencyKeys(ctx, "ns1", &tenMinutesAgo)
+	assert.NoError(t, err)
+	assert.Equal(t, []*fftypes.UUID{oldTxn.ID}, cleared)
+	txRead, err := s.GetTransactionByID(ctx, "ns1", oldTxn.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, txRead.IdempotencyKey)
+
+	// Explicitly release the other key
+	cleared, err = s.ClearIdempotencyKey(ctx, "ns1", "idem_new")
+	assert.NoError(t, err)
+	assert.Equal(t, []*fftypes.UUID{newTxn.ID}, cleared)
+	txRead, err = s.GetTransactionByID(ctx, "ns1", newTxn.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, txRead.IdempotencyKey)
+
+	// The key can now be used again
+	err = s.InsertTransaction(ctx, &core.Transaction{
+		ID:             fftypes.NewUUID(),
+		Type:           core.TransactionTypeBatchPin,
+		Namespace:      "ns1",
+		IdempotencyKey: core.IdempotencyKey("idem_new"),
+	})
+	assert.NoError(t, err)
+
+	cleared, err = s.ClearIdempotencyKey(ctx, "ns1", "unknown")
+	assert.NoError(t, err)
+	assert.Empty(t, cleared)
+}
+
+func TestClearIdempotencyKeyBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.ClearIdempotencyKey(context.Background(), "ns1", "idem1")
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestClearIdempotencyKeyQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.ClearIdempotencyKey(context.Background(), "ns1", "idem1")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClearIdempotencyKeyReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("!not a uuid"))
+	mock.ExpectRollback()
+	_, err := s.ClearIdempotencyKey(context.Background(), "ns1", "idem1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClearIdempotencyKeyTransactionsFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fftypes.NewUUID().String()))
+	mock.ExpectExec("UPDATE transactions.*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.ClearIdempotencyKey(context.Background(), "ns1", "idem1")
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExpireIdempotencyKeysMessagesFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec("UPDATE transactions.*").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE messages.*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.ExpireIdempotencyKeys(context.Background(), "ns1", fftypes.Now())
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
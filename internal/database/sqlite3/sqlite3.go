@@ -21,6 +21,7 @@ package sqlite3
 
 import (
 	"context"
+	"fmt"
 
 	"database/sql"
 
@@ -89,6 +90,30 @@ func (sqlite *SQLite3) ApplyInsertQueryCustomizations(insert sq.InsertBuilder, r
 	return insert, false
 }
 
+// ChangeTrackingStatements record the sequence of every row changed in a table while an online migration is running,
+// with triggers that record both the old and new sequence of an updated row
+func (sqlite *SQLite3) ChangeTrackingStatements(table, changelog string) (create []string, drop []string) {
+	create = []string{
+		fmt.Sprintf("CREATE TABLE %s (id INTEGER PRIMARY KEY AUTOINCREMENT, seq BIGINT NOT NULL)", changelog),
+		fmt.Sprintf("CREATE TRIGGER %s_insert AFTER INSERT ON %s BEGIN INSERT INTO %s (seq) VALUES (NEW.seq); END", changelog, table, changelog),
+		fmt.Sprintf("CREATE TRIGGER %s_update AFTER UPDATE ON %s BEGIN INSERT INTO %s (seq) VALUES (OLD.seq); INSERT INTO %s (seq) VALUES (NEW.seq); END", changelog, table, changelog, changelog),
+		fmt.Sprintf("CREATE TRIGGER %s_delete AFTER DELETE ON %s BEGIN INSERT INTO %s (seq) VALUES (OLD.seq); END", changelog, table, changelog),
+	}
+	drop = []string{
+		fmt.Sprintf("DROP TRIGGER %s_insert", changelog),
+		fmt.Sprintf("DROP TRIGGER %s_update", changelog),
+		fmt.Sprintf("DROP TRIGGER %s_delete", changelog),
+		fmt.Sprintf("DROP TABLE %s", changelog),
+	}
+	return create, drop
+}
+
+// LockTableStatement returns no statement, as SQLite only allows one writer to the database at a time, and
+// the first write of the swap holds that lock until the transaction ends
+func (sqlite *SQLite3) LockTableStatement(table string) string {
+	return ""
+}
+
 func (sqlite *SQLite3) Open(url string) (*sql.DB, error) {
 	return sql.Open("sqlite3_ff", url)
 }
@@ -61,3 +61,37 @@ func TestSQLite3GoProvider(t *testing.T) {
 	assert.Equal(t, "INSERT INTO test (col1) VALUES (?)", sql)
 	assert.False(t, query)
 }
+
+func TestSQLite3ChangeTracking(t *testing.T) {
+	sqlite := &SQLite3{}
+	db, err := sqlite.Open("file::memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("CREATE TABLE pins (seq INTEGER PRIMARY KEY AUTOINCREMENT, hash TEXT)")
+	assert.NoError(t, err)
+	create, drop := sqlite.ChangeTrackingStatements("pins", "pins_onlinechanges")
+	for _, stmt := range create {
+		_, err = db.Exec(stmt)
+		assert.NoError(t, err)
+	}
+	_, err = db.Exec("INSERT INTO pins (hash) VALUES ('a')")
+	assert.NoError(t, err)
+	_, err = db.Exec("UPDATE pins SET hash = 'b' WHERE seq = 1")
+	assert.NoError(t, err)
+	_, err = db.Exec("DELETE FROM pins WHERE seq = 1")
+	assert.NoError(t, err)
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM pins_onlinechanges WHERE seq = 1").Scan(&count)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	for _, stmt := range drop {
+		_, err = db.Exec(stmt)
+		assert.NoError(t, err)
+	}
+	_, err = db.Exec("INSERT INTO pins (hash) VALUES ('c')")
+	assert.NoError(t, err)
+	assert.Empty(t, sqlite.LockTableStatement("pins"))
+}
@@ -24,15 +24,18 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/dataexchange/ffdx"
+	"github.com/hyperledger/firefly/internal/dataexchange/p2pdx"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 )
 
 var (
-	NewFFDXPluginName = (*ffdx.FFDX)(nil).Name()
+	NewFFDXPluginName  = (*ffdx.FFDX)(nil).Name()
+	NewP2PDXPluginName = (*p2pdx.P2PDX)(nil).Name()
 )
 
 var pluginsByName = map[string]func() dataexchange.Plugin{
-	NewFFDXPluginName: func() dataexchange.Plugin { return &ffdx.FFDX{} },
+	NewFFDXPluginName:  func() dataexchange.Plugin { return &ffdx.FFDX{} },
+	NewP2PDXPluginName: func() dataexchange.Plugin { return &p2pdx.P2PDX{} },
 }
 
 func InitConfig(config config.ArraySection) {
@@ -36,6 +36,10 @@ func TestGetPlugin(t *testing.T) {
 	plugin, err := GetPlugin(ctx, "ffdx")
 	assert.NoError(t, err)
 	assert.NotNil(t, plugin)
+
+	plugin, err = GetPlugin(ctx, "p2pdx")
+	assert.NoError(t, err)
+	assert.Equal(t, "p2pdx", plugin.Name())
 }
 
 var root = config.RootSection("di")
@@ -40,6 +40,15 @@ const (
 	defaultBackgroundInitialDelay           = "5s"
 	defaultBackgroundRetryFactor            = 2.0
 	defaultBackgroundMaxDelay               = "1m"
+
+	// DataExchangeFailoverURLs are additional DX instances to send messages through, which are only used if they serve the peer identity of the primary
+	DataExchangeFailoverURLs = "failover.urls"
+	// DataExchangeFailoverHealthCheckInterval is how often the health of every DX instance is checked
+	DataExchangeFailoverHealthCheckInterval = "failover.healthCheckInterval"
+	// DataExchangeFailoverScoreWeight is the weight of each request outcome in the rolling health score of a DX instance
+	DataExchangeFailoverScoreWeight = "failover.scoreWeight"
+	// DataExchangeFailoverUnhealthyScore is the health score below which a DX instance is only used if no healthy one is available
+	DataExchangeFailoverUnhealthyScore = "failover.unhealthyScore"
 )
 
 func (h *FFDX) InitConfig(config config.Section) {
@@ -53,4 +62,8 @@ func (h *FFDX) InitConfig(config config.Section) {
 	config.AddKnownKey(DataExchangeBackgroundStartInitialDelay, defaultBackgroundInitialDelay)
 	config.AddKnownKey(DataExchangeBackgroundStartMaxDelay, defaultBackgroundMaxDelay)
 	config.AddKnownKey(DataExchangeBackgroundStartFactor, defaultBackgroundRetryFactor)
+	config.AddKnownKey(DataExchangeFailoverURLs)
+	config.AddKnownKey(DataExchangeFailoverHealthCheckInterval, "10s")
+	config.AddKnownKey(DataExchangeFailoverScoreWeight, 0.3)
+	config.AddKnownKey(DataExchangeFailoverUnhealthyScore, 0.5)
 }
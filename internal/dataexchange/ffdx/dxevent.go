@@ -29,22 +29,24 @@ import (
 )
 
 type wsEvent struct {
-	Type      msgType            `json:"type"`
-	EventID   string             `json:"id"`
-	Sender    string             `json:"sender"`
-	Recipient string             `json:"recipient"`
-	RequestID string             `json:"requestId"`
-	Path      string             `json:"path"`
-	Message   string             `json:"message"`
-	Hash      string             `json:"hash"`
-	Size      int64              `json:"size"`
-	Error     string             `json:"error"`
-	Manifest  string             `json:"manifest"`
-	Info      fftypes.JSONObject `json:"info"`
+	Type        msgType            `json:"type"`
+	EventID     string             `json:"id"`
+	Sender      string             `json:"sender"`
+	Recipient   string             `json:"recipient"`
+	RequestID   string             `json:"requestId"`
+	Path        string             `json:"path"`
+	Message     string             `json:"message"`
+	Hash        string             `json:"hash"`
+	Size        int64              `json:"size"`
+	Transferred int64              `json:"transferred"`
+	Error       string             `json:"error"`
+	Manifest    string             `json:"manifest"`
+	Info        fftypes.JSONObject `json:"info"`
 }
 
 type dxEvent struct {
 	ffdx                *FFDX
+	endpoint            *dxEndpoint // the DX instance the event was received from, which must receive the ack
 	id                  string
 	dxType              dataexchange.DXEventType
 	messageReceived     *dataexchange.MessageReceived
@@ -61,7 +63,7 @@ func (e *dxEvent) Type() dataexchange.DXEventType {
 
 func (e *dxEvent) AckWithManifest(manifest string) {
 	select {
-	case e.ffdx.ackChannel <- &ack{
+	case e.endpoint.ackChannel <- &ack{
 		eventID:  e.id,
 		manifest: manifest,
 	}:
@@ -82,14 +84,15 @@ func (e *dxEvent) PrivateBlobReceived() *dataexchange.PrivateBlobReceived {
 	return e.privateBlobReceived
 }
 
-func (h *FFDX) dispatchEvent(msg *wsEvent) {
+func (h *FFDX) dispatchEvent(ep *dxEndpoint, msg *wsEvent) {
 	var dataID string
 	var namespace string
 	var err error
-	e := &dxEvent{ffdx: h, id: msg.EventID}
+	e := &dxEvent{ffdx: h, endpoint: ep, id: msg.EventID}
 
 	switch msg.Type {
 	case messageFailed:
+		h.transferUpdated(msg.RequestID, "message", core.OpStatusFailed)
 		h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
 			OperationUpdate: core.OperationUpdate{
 				Plugin:         h.Name(),
@@ -106,6 +109,7 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 		if h.capabilities.Manifest {
 			status = core.OpStatusPending
 		}
+		h.transferUpdated(msg.RequestID, "message", status)
 		h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
 			OperationUpdate: core.OperationUpdate{
 				Plugin:         h.Name(),
@@ -117,6 +121,7 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 		})
 		return
 	case messageAcknowledged:
+		h.transferUpdated(msg.RequestID, "message", core.OpStatusSucceeded)
 		h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
 			OperationUpdate: core.OperationUpdate{
 				Plugin:         h.Name(),
@@ -130,6 +135,7 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 		})
 		return
 	case blobFailed:
+		h.transferUpdated(msg.RequestID, "blob", core.OpStatusFailed)
 		h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
 			OperationUpdate: core.OperationUpdate{
 				Plugin:         h.Name(),
@@ -146,6 +152,7 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 		if h.capabilities.Manifest {
 			status = core.OpStatusPending
 		}
+		h.transferUpdated(msg.RequestID, "blob", status)
 		h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
 			OperationUpdate: core.OperationUpdate{
 				Plugin:         h.Name(),
@@ -156,7 +163,22 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 			OnComplete: e.Ack,
 		})
 		return
+	case blobProgress:
+		// Progress leaves the status unchanged, and only records how much of the blob the recipient has
+		h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
+			OperationUpdate: core.OperationUpdate{
+				Plugin:         h.Name(),
+				NamespacedOpID: msg.RequestID,
+				Progress: &core.OperationProgress{
+					BytesSent:  msg.Transferred,
+					BytesTotal: msg.Size,
+				},
+			},
+			OnComplete: e.Ack,
+		})
+		return
 	case blobAcknowledged:
+		h.transferUpdated(msg.RequestID, "blob", core.OpStatusSucceeded)
 		h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
 			OperationUpdate: core.OperationUpdate{
 				Plugin:         h.Name(),
@@ -177,10 +199,10 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 		switch {
 		case err != nil:
 			err = fmt.Errorf("invalid transmission from peer '%s': %s", msg.Sender, err)
-		case wrapper.Batch == nil:
+		case wrapper.Batch == nil && wrapper.Ack == nil:
 			err = fmt.Errorf("invalid transmission from peer '%s': nil batch", msg.Sender)
 		default:
-			namespace = wrapper.Batch.Namespace
+			namespace = wrapper.Namespace()
 			e.dxType = dataexchange.DXEventTypeMessageReceived
 			e.messageReceived = &dataexchange.MessageReceived{
 				PeerID:    msg.Sender,
@@ -0,0 +1,231 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ffdx
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/credentials"
+	"github.com/hyperledger/firefly/internal/tracing"
+)
+
+type failoverConfig struct {
+	healthCheckInterval time.Duration
+	scoreWeight         float64
+	unhealthyScore      float64
+}
+
+// dxEndpoint is a connection to one of the DX instances configured for the plugin.
+// Blobs are held by the primary instance, so blob requests are only sent to the primary.
+// Messages fail over to another instance once it has been seen to serve the same peer
+// identity as the primary. Each has its own event stream, and events received on a
+// stream are acknowledged back on the same stream.
+type dxEndpoint struct {
+	url         string
+	client      *resty.Client
+	credentials *credentials.REST
+	wsconn      wsclient.WSClient
+	ackChannel  chan *ack
+	initialized bool   // protected by the plugin initMutex
+	connected   bool   // protected by the plugin initMutex
+	peerID      string // protected by the plugin initMutex
+	cert        string // protected by the plugin initMutex
+	stop        chan struct{}
+	loops       sync.WaitGroup
+
+	scoreMux sync.Mutex
+	score    float64 // rolling average of request outcomes, from 0 (failing) to 1 (healthy)
+	healthy  bool
+}
+
+func (h *FFDX) newEndpoint(ctx context.Context, url string, primary bool, restyConfig *ffresty.Config, wsConfig *wsclient.WSConfig) (ep *dxEndpoint, err error) {
+	ep = &dxEndpoint{
+		url:        url,
+		ackChannel: make(chan *ack),
+		stop:       make(chan struct{}),
+		score:      1,
+		healthy:    true,
+	}
+
+	epRestyConfig := *restyConfig
+	epRestyConfig.URL = url
+	ep.client = ffresty.NewWithConfig(h.ctx, epRestyConfig)
+	ep.credentials = credentials.NewREST(h.ctx, ep.client, &epRestyConfig)
+	tracing.InstrumentClient(ep.client, "ffdx")
+
+	ep.wsconn, err = h.newEndpointWS(ctx, ep, primary, wsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return ep, nil
+}
+
+func (h *FFDX) newEndpointWS(ctx context.Context, ep *dxEndpoint, primary bool, wsConfig *wsclient.WSConfig) (wsclient.WSClient, error) {
+	epWSConfig := *wsConfig
+	epWSConfig.HTTPURL = ep.url
+	if !primary {
+		// An explicit websocket URL only applies to the primary, the failover
+		// endpoints always derive theirs from their own URL
+		epWSConfig.WebSocketURL = ""
+	}
+	return wsclient.New(ctx, &epWSConfig, func(ctx context.Context, w wsclient.WSClient) error {
+		return h.beforeConnect(ctx, ep)
+	}, nil)
+}
+
+// startLoops starts the event and ack loops for the current websocket of the endpoint
+func (h *FFDX) startLoops(ep *dxEndpoint) {
+	ep.loops.Add(2)
+	go func() {
+		defer ep.loops.Done()
+		h.eventLoop(ep)
+	}()
+	go func() {
+		defer ep.loops.Done()
+		h.ackLoop(ep)
+	}()
+}
+
+func (h *FFDX) setConnected(ep *dxEndpoint, connected bool) {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+	ep.connected = connected
+}
+
+// recordResult updates the health score of the endpoint with the outcome of a request
+func (ep *dxEndpoint) recordResult(ctx context.Context, h *FFDX, ok bool) {
+	ep.scoreMux.Lock()
+	defer ep.scoreMux.Unlock()
+
+	outcome := 0.0
+	if ok {
+		outcome = 1
+	}
+	ep.score = ep.score*(1-h.failover.scoreWeight) + outcome*h.failover.scoreWeight
+	healthy := ep.score >= h.failover.unhealthyScore
+	if healthy != ep.healthy {
+		if healthy {
+			log.L(ctx).Infof("DX at %s is healthy again (score=%.2f)", ep.url, ep.score)
+		} else {
+			log.L(ctx).Warnf("DX at %s is unhealthy (score=%.2f)", ep.url, ep.score)
+		}
+		ep.healthy = healthy
+	}
+}
+
+// recordIdentity stores the peer identity reported by the endpoint, and warns if a failover
+// endpoint does not serve the same identity as the primary
+func (h *FFDX) recordIdentity(ctx context.Context, ep *dxEndpoint, peer fftypes.JSONObject) {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+
+	peerID, cert := peer.GetString("id"), peer.GetString("cert")
+	if peerID == ep.peerID && cert == ep.cert {
+		return
+	}
+	ep.peerID, ep.cert = peerID, cert
+	for _, other := range h.endpoints[1:] {
+		if other.peerID != "" && !h.servesPrimaryIdentity(other) {
+			log.L(ctx).Errorf("DX at %s serves peer '%s', which is not the peer '%s' of the primary DX. It will not be used for failover", other.url, other.peerID, h.endpoints[0].peerID)
+		}
+	}
+}
+
+// servesPrimaryIdentity must be called holding the initMutex
+func (h *FFDX) servesPrimaryIdentity(ep *dxEndpoint) bool {
+	primary := h.endpoints[0]
+	return ep == primary || (ep.peerID != "" && ep.peerID == primary.peerID && ep.cert == primary.cert)
+}
+
+func (ep *dxEndpoint) isHealthy() bool {
+	ep.scoreMux.Lock()
+	defer ep.scoreMux.Unlock()
+	return ep.healthy
+}
+
+// candidates returns the endpoints in the order they should be tried. Healthy endpoints come
+// first, in configured order so we stick with the primary while it is healthy, then the unhealthy
+// ones as a last resort. Failover endpoints are only included once they have been seen to serve
+// the same peer identity as the primary, as messages sent from any other identity would be
+// rejected by the recipient.
+func (h *FFDX) candidates(initializedOnly bool) []*dxEndpoint {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+
+	healthy := make([]*dxEndpoint, 0, len(h.endpoints))
+	unhealthy := make([]*dxEndpoint, 0)
+	for _, ep := range h.endpoints {
+		if (initializedOnly && !ep.initialized) || !h.servesPrimaryIdentity(ep) {
+			continue
+		}
+		if ep.isHealthy() {
+			healthy = append(healthy, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// request runs a REST call against each of the endpoints in turn, until one of them can be reached.
+// A server error counts against the health of the endpoint, but is returned rather than retried on
+// the next endpoint, as the request might already have been actioned. The response from the last
+// attempt is returned.
+func (h *FFDX) request(ctx context.Context, endpoints []*dxEndpoint, do func(r *resty.Request) (*resty.Response, error)) (res *resty.Response, err error) {
+	for i, ep := range endpoints {
+		res, err = do(ep.client.R().SetContext(ctx))
+		ep.recordResult(ctx, h, err == nil && res.StatusCode() < http.StatusInternalServerError)
+		if err == nil || i == len(endpoints)-1 {
+			break
+		}
+		log.L(ctx).Warnf("DX at %s could not be reached, trying next endpoint: %s", ep.url, err)
+	}
+	return res, err
+}
+
+// healthCheckLoop periodically queries the identity of every endpoint, so that endpoints we
+// are not currently sending to are still scored, and can be failed back to once they recover.
+// The identity is recorded, so only endpoints serving the same peer as the primary are used.
+func (h *FFDX) healthCheckLoop() {
+	ticker := time.NewTicker(h.failover.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		for _, ep := range h.endpoints {
+			var peer fftypes.JSONObject
+			res, err := ep.client.R().SetContext(h.ctx).SetResult(&peer).Get("/api/v1/id")
+			ok := err == nil && res.IsSuccess()
+			ep.recordResult(h.ctx, h, ok)
+			if ok {
+				h.recordIdentity(h.ctx, ep, peer)
+			}
+		}
+		select {
+		case <-h.ctx.Done():
+			log.L(h.ctx).Debugf("DX health check loop exiting")
+			return
+		case <-ticker.C:
+		}
+	}
+}
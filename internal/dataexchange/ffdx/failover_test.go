@@ -0,0 +1,293 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ffdx
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/wsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestFFDXFailover(t *testing.T) (h *FFDX, toServer2, fromServer2 chan string, httpURL, httpURL2 string, done func()) {
+	toServer2, fromServer2, wsURL2, cancel2 := wsclient.NewTestWSServer(nil)
+	u, _ := url.Parse(wsURL2)
+	u.Scheme = "http"
+	httpURL2 = u.String()
+
+	utConfig.Set(DataExchangeFailoverURLs, []string{httpURL2})
+	h, _, _, httpURL, done1 := newTestFFDX(t, false)
+	utConfig.Set(DataExchangeFailoverURLs, []string{})
+	assert.Len(t, h.endpoints, 2)
+	h.endpoints[1].initialized = true
+	h.endpoints[0].peerID = "peer1"
+	h.endpoints[1].peerID = "peer1"
+
+	return h, toServer2, fromServer2, httpURL, httpURL2, func() {
+		done1()
+		cancel2()
+	}
+}
+
+func TestSendMessageFailover(t *testing.T) {
+	h, _, _, httpURL, httpURL2, done := newTestFFDXFailover(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/messages", httpURL),
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/messages", httpURL2),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
+
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	err := h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
+	assert.NoError(t, err)
+	assert.Equal(t, []*dxEndpoint{h.endpoints[0], h.endpoints[1]}, h.candidates(true))
+
+	// A second failure marks the primary unhealthy, so the failover endpoint is preferred
+	err = h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
+	assert.NoError(t, err)
+	assert.False(t, h.endpoints[0].isHealthy())
+	assert.Equal(t, []*dxEndpoint{h.endpoints[1], h.endpoints[0]}, h.candidates(true))
+
+	err = h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, httpmock.GetTotalCallCount())
+}
+
+func TestSendMessageFailoverAllFail(t *testing.T) {
+	h, _, _, httpURL, httpURL2, done := newTestFFDXFailover(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/messages", httpURL),
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/messages", httpURL2),
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	err := h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
+	assert.Regexp(t, "FF10229", err)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestSendMessageNoFailoverOnServerError(t *testing.T) {
+	h, _, _, httpURL, _, done := newTestFFDXFailover(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/messages", httpURL),
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{}))
+
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	err := h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
+	assert.Regexp(t, "FF10229", err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestSendMessageNoFailoverToOtherIdentity(t *testing.T) {
+	h, _, _, httpURL, _, done := newTestFFDXFailover(t)
+	defer done()
+
+	h.endpoints[1].peerID = "peer2"
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/messages", httpURL),
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	err := h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
+	assert.Regexp(t, "FF10229", err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	assert.Equal(t, []*dxEndpoint{h.endpoints[0]}, h.candidates(true))
+}
+
+func TestTransferBlobPrimaryOnly(t *testing.T) {
+	h, _, _, httpURL, _, done := newTestFFDXFailover(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/transfers", httpURL),
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	err := h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1")
+	assert.Regexp(t, "FF10229", err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	h.endpoints[0].initialized = false
+	err = h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1")
+	assert.Regexp(t, "FF10342", err)
+}
+
+func TestSendMessageNoFailoverOnClientError(t *testing.T) {
+	h, _, _, httpURL, _, done := newTestFFDXFailover(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/messages", httpURL),
+		httpmock.NewJsonResponderOrPanic(400, fftypes.JSONObject{}))
+
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	err := h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
+	assert.Regexp(t, "FF10229", err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+	assert.True(t, h.endpoints[0].isHealthy())
+}
+
+func TestSendMessageSkipsUninitializedEndpoint(t *testing.T) {
+	h, _, _, _, httpURL2, done := newTestFFDXFailover(t)
+	defer done()
+
+	h.endpoints[0].initialized = false
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/messages", httpURL2),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
+
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	err := h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestBlobsPrimaryOnly(t *testing.T) {
+	h, _, _, httpURL, _, done := newTestFFDXFailover(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/blobs/ns1/id1", httpURL),
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/api/v1/blobs/ns1/id1", httpURL),
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+
+	_, err := h.DownloadBlob(context.Background(), "ns1/id1")
+	assert.Regexp(t, "FF10229", err)
+	err = h.DeleteBlob(context.Background(), "ns1/id1")
+	assert.Regexp(t, "FF10229", err)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestAddPeerAllEndpoints(t *testing.T) {
+	h, _, _, httpURL, httpURL2, done := newTestFFDXFailover(t)
+	defer done()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/api/v1/peers/peer1", httpURL),
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{}))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/api/v1/peers/peer1", httpURL2),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
+
+	err := h.AddNode(context.Background(), "ns1", "node1", fftypes.JSONObject{
+		"id": "peer1",
+	})
+	assert.Regexp(t, "FF10229", err)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestEventsFromFailoverEndpoint(t *testing.T) {
+	h, toServer2, fromServer2, _, _, done := newTestFFDXFailover(t)
+	defer done()
+
+	ocb := &coremocks.OperationCallbacks{}
+	h.SetOperationHandler("ns1", ocb)
+
+	err := h.Start()
+	assert.NoError(t, err)
+
+	namespacedID := fmt.Sprintf("ns1:%s", fftypes.NewUUID())
+	ocb.On("OperationUpdate", mock.MatchedBy(func(ev *core.OperationUpdateAsync) bool {
+		return ev.NamespacedOpID == namespacedID &&
+			ev.Status == core.OpStatusSucceeded &&
+			ev.Plugin == "ffdx"
+	})).Run(opAcker()).Return(nil)
+	fromServer2 <- `{"id":"1","type":"message-delivered","requestID":"` + namespacedID + `"}`
+	msg := <-toServer2
+	assert.Equal(t, `{"action":"ack","id":"1"}`, string(msg))
+
+	ocb.AssertExpectations(t)
+}
+
+func TestStartFailoverEndpointUnavailable(t *testing.T) {
+	h, _, _, _, done := newTestFFDX(t, false)
+	defer done()
+
+	wsm := &wsmocks.WSClient{}
+	wsm.On("Connect").Return(fmt.Errorf("pop"))
+	h.endpoints = append(h.endpoints, &dxEndpoint{url: "http://dx2", wsconn: wsm, score: 1, healthy: true})
+
+	err := h.Start()
+	assert.NoError(t, err)
+}
+
+func TestStartAllEndpointsUnavailable(t *testing.T) {
+	h, _, _, _, done := newTestFFDX(t, false)
+	defer done()
+
+	wsm := &wsmocks.WSClient{}
+	wsm.On("Connect").Return(fmt.Errorf("pop"))
+	h.endpoints = []*dxEndpoint{{url: "http://dx2", wsconn: wsm}}
+
+	err := h.Start()
+	assert.Regexp(t, "pop", err)
+}
+
+func TestHealthCheckLoop(t *testing.T) {
+	h, _, _, httpURL, httpURL2, done := newTestFFDXFailover(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/id", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{"id": "peer1", "cert": "cert1"}))
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/id", httpURL2),
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{}))
+
+	h.failover.healthCheckInterval = 1 * time.Millisecond
+	go h.healthCheckLoop()
+
+	assert.Eventually(t, func() bool {
+		return !h.endpoints[1].isHealthy()
+	}, 5*time.Second, 1*time.Millisecond)
+	assert.True(t, h.endpoints[0].isHealthy())
+
+	h.cancelCtx()
+
+	// The failover endpoint has not reported the cert of the primary
+	h.initMutex.Lock()
+	assert.Equal(t, "cert1", h.endpoints[0].cert)
+	assert.False(t, h.servesPrimaryIdentity(h.endpoints[1]))
+	h.initMutex.Unlock()
+}
+
+func TestRecordIdentity(t *testing.T) {
+	h, _, _, _, _, done := newTestFFDXFailover(t)
+	defer done()
+	ctx := context.Background()
+
+	h.recordIdentity(ctx, h.endpoints[0], fftypes.JSONObject{"id": "peer1", "cert": "cert1"})
+	h.recordIdentity(ctx, h.endpoints[1], fftypes.JSONObject{"id": "peer2", "cert": "cert2"})
+	assert.Equal(t, []*dxEndpoint{h.endpoints[0]}, h.candidates(false))
+
+	h.recordIdentity(ctx, h.endpoints[1], fftypes.JSONObject{"id": "peer1", "cert": "cert1"})
+	h.recordIdentity(ctx, h.endpoints[1], fftypes.JSONObject{"id": "peer1", "cert": "cert1"})
+	assert.Equal(t, []*dxEndpoint{h.endpoints[0], h.endpoints[1]}, h.candidates(false))
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -50,16 +51,14 @@ type FFDX struct {
 	cancelCtx       context.CancelFunc
 	capabilities    *dataexchange.Capabilities
 	callbacks       callbacks
-	client          *resty.Client
-	wsconn          wsclient.WSClient
+	endpoints       []*dxEndpoint
 	needsInit       bool
-	initialized     bool
 	initMutex       sync.Mutex
 	nodes           map[string]*dxNode
-	ackChannel      chan *ack
 	retry           *retry.Retry
 	backgroundStart bool
 	backgroundRetry *retry.Retry
+	failover        failoverConfig
 
 	metrics metrics.Manager // optional
 }
@@ -131,6 +130,7 @@ const (
 	blobDelivered       msgType = "blob-delivered"
 	blobAcknowledged    msgType = "blob-acknowledged"
 	blobFailed          msgType = "blob-failed"
+	blobProgress        msgType = "blob-progress"
 )
 
 type responseWithRequestID struct {
@@ -179,7 +179,6 @@ func (h *FFDX) Name() string {
 func (h *FFDX) Init(ctx context.Context, cancelCtx context.CancelFunc, config config.Section, metrics metrics.Manager) (err error) {
 	h.ctx = log.WithLogField(ctx, "dx", "https")
 	h.cancelCtx = cancelCtx
-	h.ackChannel = make(chan *ack)
 	h.callbacks = callbacks{
 		plugin:     h,
 		handlers:   make(map[string]dataexchange.Callbacks),
@@ -194,8 +193,9 @@ func (h *FFDX) Init(ctx context.Context, cancelCtx context.CancelFunc, config co
 	}
 
 	wsConfig, err := wsclient.GenerateConfig(ctx, config)
+	var restyConfig *ffresty.Config
 	if err == nil {
-		h.client, err = ffresty.New(h.ctx, config)
+		restyConfig, err = ffresty.GenerateConfig(h.ctx, config)
 	}
 
 	if err != nil {
@@ -211,28 +211,42 @@ func (h *FFDX) Init(ctx context.Context, cancelCtx context.CancelFunc, config co
 		Factor:       config.GetFloat64(DataExchangeEventRetryFactor),
 	}
 
+	h.failover = failoverConfig{
+		healthCheckInterval: config.GetDuration(DataExchangeFailoverHealthCheckInterval),
+		scoreWeight:         config.GetFloat64(DataExchangeFailoverScoreWeight),
+		unhealthyScore:      config.GetFloat64(DataExchangeFailoverUnhealthyScore),
+	}
+
 	if wsConfig.WSKeyPath == "" {
 		wsConfig.WSKeyPath = "/ws"
 	}
 
-	h.wsconn, err = wsclient.New(ctx, wsConfig, h.beforeConnect, nil)
-	if err != nil {
-		return err
+	// The primary DX instance comes first, followed by any instances we can fail over to
+	h.endpoints = nil
+	urls := append([]string{restyConfig.URL}, config.GetStringSlice(DataExchangeFailoverURLs)...)
+	for i, epURL := range urls {
+		ep, err := h.newEndpoint(ctx, epURL, i == 0, restyConfig, wsConfig)
+		if err != nil {
+			return err
+		}
+		h.endpoints = append(h.endpoints, ep)
 	}
 
 	h.backgroundStart = config.GetBool(DataExchangeBackgroundStart)
+	// The background retry is also used to reconnect failover endpoints that are unavailable on startup
+	h.backgroundRetry = &retry.Retry{
+		InitialDelay: config.GetDuration(DataExchangeBackgroundStartInitialDelay),
+		MaximumDelay: config.GetDuration(DataExchangeBackgroundStartMaxDelay),
+		Factor:       config.GetFloat64(DataExchangeBackgroundStartFactor),
+	}
 
 	if h.backgroundStart {
-		h.backgroundRetry = &retry.Retry{
-			InitialDelay: config.GetDuration(DataExchangeBackgroundStartInitialDelay),
-			MaximumDelay: config.GetDuration(DataExchangeBackgroundStartMaxDelay),
-			Factor:       config.GetFloat64(DataExchangeBackgroundStartFactor),
-		}
 		return nil
 	}
 
-	go h.eventLoop()
-	go h.ackLoop()
+	for _, ep := range h.endpoints {
+		h.startLoops(ep)
+	}
 	return nil
 }
 
@@ -257,43 +271,120 @@ func (h *FFDX) SetOperationHandler(namespace string, handler core.OperationCallb
 	}
 }
 
-func (h *FFDX) backgroundStartLoop() {
-	_ = h.backgroundRetry.Do(h.ctx, fmt.Sprintf("Background start %s", h.Name()), func(attempt int) (retry bool, err error) {
-		err = h.wsconn.Connect()
+func (h *FFDX) backgroundStartLoop(ep *dxEndpoint, startLoops bool) {
+	_ = h.backgroundRetry.Do(h.ctx, fmt.Sprintf("Background start %s (%s)", h.Name(), ep.url), func(attempt int) (retry bool, err error) {
+		err = ep.wsconn.Connect()
 		if err != nil {
 			return true, err
 		}
 
-		go h.eventLoop()
-		go h.ackLoop()
+		if startLoops {
+			h.startLoops(ep)
+		}
+		h.setConnected(ep, true)
 		return false, nil
 	})
 }
 
 func (h *FFDX) Start() error {
+	if len(h.endpoints) > 1 {
+		go h.healthCheckLoop()
+	}
+
 	if h.backgroundStart {
-		go h.backgroundStartLoop()
+		for _, ep := range h.endpoints {
+			go h.backgroundStartLoop(ep, true)
+		}
 		return nil
 	}
-	return h.wsconn.Connect()
+
+	// We can start as long as one DX instance is available. Any that are not
+	// are retried in the background, so they can take over later.
+	var firstErr error
+	unavailable := make([]*dxEndpoint, 0)
+	for _, ep := range h.endpoints {
+		if err := ep.wsconn.Connect(); err != nil {
+			log.L(h.ctx).Warnf("Failed to connect to DX at %s: %s", ep.url, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			unavailable = append(unavailable, ep)
+			continue
+		}
+		h.setConnected(ep, true)
+	}
+	if len(unavailable) == len(h.endpoints) {
+		return firstErr
+	}
+	for _, ep := range unavailable {
+		go h.backgroundStartLoop(ep, false)
+	}
+	return nil
+}
+
+// RotateCredentials switches to the credentials in the updated config. REST requests in flight complete with
+// the previous credentials. The websocket of each DX instance is replaced with one using the new credentials,
+// and events that have not been acknowledged on the old websocket are redelivered on the new one.
+func (h *FFDX) RotateCredentials(ctx context.Context, config config.Section) error {
+	var restyConfig *ffresty.Config
+	wsConfig, err := wsclient.GenerateConfig(ctx, config)
+	if err == nil {
+		restyConfig, err = ffresty.GenerateConfig(ctx, config)
+	}
+	if err != nil {
+		return err
+	}
+	if wsConfig.WSKeyPath == "" {
+		wsConfig.WSKeyPath = "/ws"
+	}
+
+	// Instances still making their first connection are retrying in the background with the old credentials
+	h.initMutex.Lock()
+	for _, ep := range h.endpoints {
+		if !ep.connected {
+			h.initMutex.Unlock()
+			return i18n.NewError(ctx, coremsgs.MsgDXNotConnectedForRotation, ep.url)
+		}
+	}
+	h.initMutex.Unlock()
+
+	for i, ep := range h.endpoints {
+		ep.credentials.Update(restyConfig)
+		wsconn, err := h.newEndpointWS(h.ctx, ep, i == 0, wsConfig)
+		if err != nil {
+			return err
+		}
+		close(ep.stop)
+		ep.loops.Wait()
+		log.L(ctx).Infof("Reconnecting websocket for DX at %s with new credentials", ep.url)
+		h.setConnected(ep, false)
+		ep.wsconn = wsconn
+		ep.stop = make(chan struct{})
+		if err := wsconn.Connect(); err != nil {
+			return err
+		}
+		h.startLoops(ep)
+		h.setConnected(ep, true)
+	}
+	return nil
 }
 
 func (h *FFDX) Capabilities() *dataexchange.Capabilities {
 	return h.capabilities
 }
 
-func (h *FFDX) beforeConnect(ctx context.Context, w wsclient.WSClient) error {
+func (h *FFDX) beforeConnect(ctx context.Context, ep *dxEndpoint) error {
 	h.initMutex.Lock()
 	defer h.initMutex.Unlock()
 
 	if h.needsInit {
-		h.initialized = false
+		ep.initialized = false
 		var status dxStatus
 		body := make([]fftypes.JSONObject, 0)
 		for _, node := range h.nodes {
 			body = append(body, node.Peer)
 		}
-		res, err := h.client.R().SetContext(ctx).
+		res, err := ep.client.R().SetContext(ctx).
 			SetBody(body).
 			SetResult(&status).
 			Post("/api/v1/init")
@@ -305,7 +396,7 @@ func (h *FFDX) beforeConnect(ctx context.Context, w wsclient.WSClient) error {
 		}
 	}
 
-	h.initialized = true
+	ep.initialized = true
 
 	for _, cb := range h.callbacks.handlers {
 		cb.DXConnect(h)
@@ -314,24 +405,47 @@ func (h *FFDX) beforeConnect(ctx context.Context, w wsclient.WSClient) error {
 	return nil
 }
 
-func (h *FFDX) checkInitialized(ctx context.Context) error {
+// blobEndpoints returns only the primary endpoint, as the instance that holds the blobs. Blob requests
+// are not failed over, as the other instances do not have the blob.
+func (h *FFDX) blobEndpoints(ctx context.Context, initializedOnly bool) ([]*dxEndpoint, error) {
 	h.initMutex.Lock()
 	defer h.initMutex.Unlock()
+	primary := h.endpoints[0]
+	if initializedOnly && !primary.initialized {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDXNotInitialized)
+	}
+	return []*dxEndpoint{primary}, nil
+}
 
-	if !h.initialized {
-		return i18n.NewError(ctx, coremsgs.MsgDXNotInitialized)
+// initializedEndpoints returns the endpoints that are ready to send messages, healthiest first
+func (h *FFDX) initializedEndpoints(ctx context.Context) ([]*dxEndpoint, error) {
+	endpoints := h.candidates(true)
+	if len(endpoints) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDXNotInitialized)
 	}
-	return nil
+	return endpoints, nil
 }
 
 func (h *FFDX) GetPeerID(peer fftypes.JSONObject) string {
 	return peer.GetString("id")
 }
 
+// CheckHealth confirms the data exchange is reachable, by querying its identity.
+// With failover configured, it is healthy as long as one of the DX instances is reachable.
+func (h *FFDX) CheckHealth(ctx context.Context) error {
+	res, err := h.request(ctx, h.candidates(false), func(r *resty.Request) (*resty.Response, error) {
+		return r.Get("/api/v1/id")
+	})
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
+	}
+	return nil
+}
+
 func (h *FFDX) GetEndpointInfo(ctx context.Context, nodeName string) (peer fftypes.JSONObject, err error) {
-	res, err := h.client.R().SetContext(ctx).
-		SetResult(&peer).
-		Get("/api/v1/id")
+	res, err := h.request(ctx, h.candidates(false), func(r *resty.Request) (*resty.Response, error) {
+		return r.SetResult(&peer).Get("/api/v1/id")
+	})
 	if err != nil || !res.IsSuccess() {
 		return peer, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
 	}
@@ -353,17 +467,50 @@ func (h *FFDX) AddNode(ctx context.Context, networkNamespace, nodeName string, p
 		Peer: peer,
 		Name: nodeName,
 	}
+	return h.putPeer(ctx, peer)
+}
+
+func (h *FFDX) UpdateNode(ctx context.Context, networkNamespace, nodeName string, peer fftypes.JSONObject) (err error) {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
 
-	if h.initialized {
-		res, err := h.client.R().SetContext(ctx).
+	peerID := h.GetPeerID(peer)
+	for key, node := range h.nodes {
+		switch {
+		case strings.HasPrefix(key, networkNamespace+":") && node.Name == nodeName:
+			// The node might have been registered under a different peer ID before the update
+			delete(h.nodes, key)
+		case h.GetPeerID(node.Peer) == peerID:
+			// The same peer registered in another namespace, which would otherwise be sent stale on re-init
+			node.Peer = peer
+		}
+	}
+	h.nodes[networkNamespace+":"+peerID] = &dxNode{
+		Peer: peer,
+		Name: nodeName,
+	}
+	return h.putPeer(ctx, peer)
+}
+
+// putPeer must be called holding the initMutex
+func (h *FFDX) putPeer(ctx context.Context, peer fftypes.JSONObject) (err error) {
+	// Every DX instance needs to know about the peer, as any of them might be used to send to it
+	for _, ep := range h.endpoints {
+		if !ep.initialized {
+			continue
+		}
+		res, putErr := ep.client.R().SetContext(ctx).
 			SetBody(peer).
 			Put(fmt.Sprintf("/api/v1/peers/%s", peer.GetString("id")))
-		if err != nil || !res.IsSuccess() {
-			return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
+		ep.recordResult(ctx, h, putErr == nil && res.StatusCode() < http.StatusInternalServerError)
+		if putErr != nil || !res.IsSuccess() {
+			if err == nil {
+				err = ffresty.WrapRestErr(ctx, res, putErr, coremsgs.MsgDXRESTErr)
+			}
 		}
 	}
 
-	return nil
+	return err
 }
 
 func (h *FFDX) findNode(namespace, recipient string) *dxNode {
@@ -380,11 +527,16 @@ func (h *FFDX) findNode(namespace, recipient string) *dxNode {
 
 func (h *FFDX) UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (payloadRef string, hash *fftypes.Bytes32, size int64, err error) {
 	payloadRef = joinBlobPath(ns, id.String())
+	endpoints, err := h.blobEndpoints(ctx, false)
+	if err != nil {
+		return "", nil, -1, err
+	}
 	var upload uploadBlob
-	res, err := h.client.R().SetContext(ctx).
-		SetFileReader("file", id.String(), content).
-		SetResult(&upload).
-		Put(fmt.Sprintf("/api/v1/blobs/%s", payloadRef))
+	res, err := h.request(ctx, endpoints, func(r *resty.Request) (*resty.Response, error) {
+		return r.SetFileReader("file", id.String(), content).
+			SetResult(&upload).
+			Put(fmt.Sprintf("/api/v1/blobs/%s", payloadRef))
+	})
 	if err != nil || !res.IsSuccess() {
 		err = ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
 		return "", nil, -1, err
@@ -396,9 +548,14 @@ func (h *FFDX) UploadBlob(ctx context.Context, ns string, id fftypes.UUID, conte
 }
 
 func (h *FFDX) DownloadBlob(ctx context.Context, payloadRef string) (content io.ReadCloser, err error) {
-	res, err := h.client.R().SetContext(ctx).
-		SetDoNotParseResponse(true).
-		Get(fmt.Sprintf("/api/v1/blobs/%s", payloadRef))
+	endpoints, err := h.blobEndpoints(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	res, err := h.request(ctx, endpoints, func(r *resty.Request) (*resty.Response, error) {
+		return r.SetDoNotParseResponse(true).
+			Get(fmt.Sprintf("/api/v1/blobs/%s", payloadRef))
+	})
 	if err != nil || !res.IsSuccess() {
 		if err == nil {
 			_ = res.RawBody().Close()
@@ -409,9 +566,14 @@ func (h *FFDX) DownloadBlob(ctx context.Context, payloadRef string) (content io.
 }
 
 func (h *FFDX) DeleteBlob(ctx context.Context, payloadRef string) (err error) {
-	res, err := h.client.R().SetContext(ctx).
-		SetDoNotParseResponse(true).
-		Delete(fmt.Sprintf("/api/v1/blobs/%s", payloadRef))
+	endpoints, err := h.blobEndpoints(ctx, false)
+	if err != nil {
+		return err
+	}
+	res, err := h.request(ctx, endpoints, func(r *resty.Request) (*resty.Response, error) {
+		return r.SetDoNotParseResponse(true).
+			Delete(fmt.Sprintf("/api/v1/blobs/%s", payloadRef))
+	})
 	if err != nil || !res.IsSuccess() {
 		if err == nil {
 			_ = res.RawBody().Close()
@@ -422,47 +584,66 @@ func (h *FFDX) DeleteBlob(ctx context.Context, payloadRef string) (err error) {
 }
 
 func (h *FFDX) SendMessage(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, data []byte) (err error) {
-	if err := h.checkInitialized(ctx); err != nil {
+	endpoints, err := h.initializedEndpoints(ctx)
+	if err != nil {
 		return err
 	}
 
 	var responseData responseWithRequestID
-	res, err := h.client.R().SetContext(ctx).
-		SetBody(&sendMessage{
+	res, err := h.request(ctx, endpoints, func(r *resty.Request) (*resty.Response, error) {
+		return r.SetBody(&sendMessage{
 			Message:   string(data),
 			Recipient: h.GetPeerID(peer),
 			RequestID: nsOpID,
 			Sender:    h.GetPeerID(sender),
 		}).
-		SetResult(&responseData).
-		Post("/api/v1/messages")
+			SetResult(&responseData).
+			Post("/api/v1/messages")
+	})
 	if err != nil || !res.IsSuccess() {
 		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
 	}
+	h.transferSubmitted(nsOpID)
 	return nil
 }
 
 func (h *FFDX) TransferBlob(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, payloadRef string) (err error) {
-	if err := h.checkInitialized(ctx); err != nil {
+	endpoints, err := h.blobEndpoints(ctx, true)
+	if err != nil {
 		return err
 	}
 
 	var responseData responseWithRequestID
-	res, err := h.client.R().SetContext(ctx).
-		SetBody(&transferBlob{
+	res, err := h.request(ctx, endpoints, func(r *resty.Request) (*resty.Response, error) {
+		return r.SetBody(&transferBlob{
 			Path:      fmt.Sprintf("/%s", payloadRef),
 			Recipient: h.GetPeerID(peer),
 			RequestID: nsOpID,
 			Sender:    h.GetPeerID(sender),
 		}).
-		SetResult(&responseData).
-		Post("/api/v1/transfers")
+			SetResult(&responseData).
+			Post("/api/v1/transfers")
+	})
 	if err != nil || !res.IsSuccess() {
 		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
 	}
+	h.transferSubmitted(nsOpID)
 	return nil
 }
 
+func (h *FFDX) transferSubmitted(nsOpID string) {
+	if h.metrics != nil && h.metrics.IsMetricsEnabled() {
+		h.metrics.DXTransferSubmitted(nsOpID)
+	}
+}
+
+// transferUpdated records the duration of a transfer, once it has reached a final status
+func (h *FFDX) transferUpdated(nsOpID, transferType string, status core.OpStatus) {
+	if status != core.OpStatusPending && h.metrics != nil && h.metrics.IsMetricsEnabled() {
+		h.metrics.DXTransferCompleted(nsOpID, transferType, status)
+	}
+}
+
 func (h *FFDX) CheckNodeIdentityStatus(ctx context.Context, node *core.Identity) error {
 	if node == nil {
 		return i18n.NewError(ctx, coremsgs.MsgNodeNotProvidedForCheck)
@@ -548,20 +729,23 @@ func extractSoonestExpiryFromCertBundle(certBundle string) (time.Time, error) {
 	return expiringCert.NotAfter.UTC(), nil
 }
 
-func (h *FFDX) ackLoop() {
+func (h *FFDX) ackLoop(ep *dxEndpoint) {
 	for {
 		select {
 		case <-h.ctx.Done():
 			log.L(h.ctx).Debugf("Ack loop exiting")
 			return
-		case ack := <-h.ackChannel:
+		case <-ep.stop:
+			log.L(h.ctx).Debugf("Ack loop exiting (websocket replaced)")
+			return
+		case ack := <-ep.ackChannel:
 			// Send the ack
 			ackBytes, _ := json.Marshal(&wsAck{
 				Action:   "ack",
 				ID:       ack.eventID,
 				Manifest: ack.manifest,
 			})
-			err := h.wsconn.Send(h.ctx, ackBytes)
+			err := ep.wsconn.Send(h.ctx, ackBytes)
 			if err != nil {
 				// Note we only get the error in the case we're closing down, so no need to retry
 				log.L(h.ctx).Warnf("Ack loop send failed: %s", err)
@@ -570,16 +754,19 @@ func (h *FFDX) ackLoop() {
 	}
 }
 
-func (h *FFDX) eventLoop() {
-	defer h.wsconn.Close()
-	l := log.L(h.ctx).WithField("role", "event-loop")
+func (h *FFDX) eventLoop(ep *dxEndpoint) {
+	defer ep.wsconn.Close()
+	l := log.L(h.ctx).WithField("role", "event-loop").WithField("endpoint", ep.url)
 	ctx := log.WithLogger(h.ctx, l)
 	for {
 		select {
 		case <-ctx.Done():
 			l.Debugf("Event loop exiting (context cancelled)")
 			return
-		case msgBytes, ok := <-h.wsconn.Receive():
+		case <-ep.stop:
+			l.Debugf("Event loop exiting (websocket replaced)")
+			return
+		case msgBytes, ok := <-ep.wsconn.Receive():
 			if !ok {
 				l.Debugf("Event loop exiting (receive channel closed). Terminating server!")
 				h.cancelCtx()
@@ -594,7 +781,7 @@ func (h *FFDX) eventLoop() {
 				continue // Swallow this and move on
 			}
 			l.Debugf("Received %s event from DX sender=%s", msg.Type, msg.Sender)
-			h.dispatchEvent(&msg)
+			h.dispatchEvent(ep, &msg)
 		}
 	}
 }
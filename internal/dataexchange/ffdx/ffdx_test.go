@@ -167,13 +167,15 @@ func newTestFFDX(t *testing.T, manifestEnabled bool) (h *FFDX, toServer, fromSer
 	utConfig.Set(ffresty.HTTPCustomClient, mockedClient)
 	utConfig.Set(DataExchangeManifestEnabled, manifestEnabled)
 
-	h = &FFDX{initialized: true}
+	h = &FFDX{}
 	h.InitConfig(utConfig)
 
 	mmm := metricsmocks.NewManager(t)
+	mmm.On("IsMetricsEnabled").Return(false).Maybe()
 	dxCtx, dxCancel := context.WithCancel(context.Background())
 	err := h.Init(dxCtx, dxCancel, utConfig, mmm)
 	assert.NoError(t, err)
+	h.endpoints[0].initialized = true
 	assert.Equal(t, "ffdx", h.Name())
 	assert.NotNil(t, h.Capabilities())
 	return h, toServer, fromServer, httpURL, func() {
@@ -325,7 +327,8 @@ func TestAckClosed(t *testing.T) {
 	done()
 
 	dxe := &dxEvent{
-		ffdx: h,
+		ffdx:     h,
+		endpoint: h.endpoints[0],
 	}
 	dxe.AckWithManifest("")
 }
@@ -358,6 +361,54 @@ func TestAddPeerError(t *testing.T) {
 	assert.Regexp(t, "FF10229", err)
 }
 
+func TestUpdatePeer(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/api/v1/peers/peer1", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/api/v1/peers/peer2", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
+
+	ctx := context.Background()
+	err := h.AddNode(ctx, "ns1", "node1", fftypes.JSONObject{"id": "peer1", "endpoint": "https://old.example.com"})
+	assert.NoError(t, err)
+	err = h.AddNode(ctx, "ns2", "node1", fftypes.JSONObject{"id": "peer1", "endpoint": "https://old.example.com"})
+	assert.NoError(t, err)
+	err = h.AddNode(ctx, "ns1", "node2", fftypes.JSONObject{"id": "peer2", "endpoint": "https://other.example.com"})
+	assert.NoError(t, err)
+
+	// A new endpoint is refreshed in every namespace that knows the peer
+	updated := fftypes.JSONObject{"id": "peer1", "endpoint": "https://new.example.com"}
+	err = h.UpdateNode(ctx, "ns1", "node1", updated)
+	assert.NoError(t, err)
+	assert.Equal(t, updated, h.findNode("ns1", "peer1").Peer)
+	assert.Equal(t, updated, h.findNode("ns2", "peer1").Peer)
+	assert.Equal(t, "https://other.example.com", h.findNode("ns1", "peer2").Peer.GetString("endpoint"))
+
+	// A new peer ID replaces the old one in the namespace being updated
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/api/v1/peers/peer3", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
+	err = h.UpdateNode(ctx, "ns1", "node1", fftypes.JSONObject{"id": "peer3"})
+	assert.NoError(t, err)
+	assert.Nil(t, h.findNode("ns1", "peer1"))
+	assert.Equal(t, "node1", h.findNode("ns1", "peer3").Name)
+	assert.NotNil(t, h.findNode("ns2", "peer1"))
+}
+
+func TestUpdatePeerError(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/api/v1/peers/peer1", httpURL),
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{}))
+
+	err := h.UpdateNode(context.Background(), "ns1", "node1", fftypes.JSONObject{
+		"id": "peer1",
+	})
+	assert.Regexp(t, "FF10229", err)
+}
+
 func TestUploadBlob(t *testing.T) {
 
 	h, _, _, httpURL, done := newTestFFDX(t, false)
@@ -490,6 +541,19 @@ func TestTransferBlob(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTransferBlobMetrics(t *testing.T) {
+	mmm := metricsmocks.NewManager(t)
+	mmm.On("IsMetricsEnabled").Return(true)
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	mmm.On("DXTransferSubmitted", nsOpID).Return()
+	mmm.On("DXTransferCompleted", nsOpID, "blob", core.OpStatusSucceeded).Return()
+	h := &FFDX{metrics: mmm}
+
+	h.transferSubmitted(nsOpID)
+	h.transferUpdated(nsOpID, "blob", core.OpStatusPending)
+	h.transferUpdated(nsOpID, "blob", core.OpStatusSucceeded)
+}
+
 func TestTransferBlobError(t *testing.T) {
 	h, _, _, httpURL, done := newTestFFDX(t, false)
 	defer done()
@@ -541,7 +605,7 @@ func TestBadEvents(t *testing.T) {
 }
 
 func TestBackgroundStartWSFail(t *testing.T) {
-	h := &FFDX{initialized: true}
+	h := &FFDX{}
 
 	u, _ := url.Parse("http://localhost:12345")
 	u.Scheme = "http"
@@ -699,6 +763,15 @@ func TestMessageEvents(t *testing.T) {
 	msg = <-toServer
 	assert.Equal(t, `{"action":"ack","id":"4","manifest":"{\"manifest\":true}"}`, string(msg))
 
+	mcb.On("DXEvent", h, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		return ev.EventID() == "5" &&
+			ev.Type() == dataexchange.DXEventTypeMessageReceived &&
+			ev.MessageReceived().Transport.Ack.State == core.BatchAckStateAggregated
+	})).Run(acker()).Return(nil)
+	fromServer <- `{"id":"5","type":"message-received","sender":"peer2","recipient":"peer1","message":"{\"ack\":{\"namespace\":\"ns1\",\"state\":\"aggregated\"}}"}`
+	msg = <-toServer
+	assert.Equal(t, `{"action":"ack","id":"5"}`, string(msg))
+
 	h.SetHandler("ns1", "node1", nil)
 	assert.Empty(t, h.callbacks.handlers)
 	h.SetOperationHandler("ns1", nil)
@@ -756,6 +829,18 @@ func TestBlobEvents(t *testing.T) {
 	msg = <-toServer
 	assert.Equal(t, `{"action":"ack","id":"9"}`, string(msg))
 
+	namespacedID11 := fmt.Sprintf("ns1:%s", fftypes.NewUUID())
+	ocb.On("OperationUpdate", mock.MatchedBy(func(ev *core.OperationUpdateAsync) bool {
+		return ev.NamespacedOpID == namespacedID11 &&
+			ev.Status == "" &&
+			ev.Progress.BytesSent == 1024 &&
+			ev.Progress.BytesTotal == 4096 &&
+			ev.Plugin == "ffdx"
+	})).Run(opAcker()).Return(nil)
+	fromServer <- `{"id":"11","type":"blob-progress","requestID":"` + namespacedID11 + `","transferred":1024,"size":4096}`
+	msg = <-toServer
+	assert.Equal(t, `{"action":"ack","id":"11"}`, string(msg))
+
 	namespacedID10 := fmt.Sprintf("ns1:%s", fftypes.NewUUID())
 	ocb.On("OperationUpdate", mock.MatchedBy(func(ev *core.OperationUpdateAsync) bool {
 		return ev.NamespacedOpID == namespacedID10 &&
@@ -822,13 +907,12 @@ func TestEventLoopReceiveClosed(t *testing.T) {
 		ctx:       context.Background(),
 		cancelCtx: func() { called = true },
 		callbacks: callbacks{handlers: map[string]dataexchange.Callbacks{"ns1": dxc}},
-		wsconn:    wsm,
 	}
 	r := make(chan []byte)
 	close(r)
 	wsm.On("Close").Return()
 	wsm.On("Receive").Return((<-chan []byte)(r))
-	h.eventLoop()
+	h.eventLoop(&dxEndpoint{wsconn: wsm})
 	assert.True(t, called)
 }
 
@@ -837,19 +921,21 @@ func TestEventLoopSendClosed(t *testing.T) {
 	wsm := &wsmocks.WSClient{}
 	ctx, cancelCtx := context.WithCancel(context.Background())
 	h := &FFDX{
-		ctx:        ctx,
-		callbacks:  callbacks{handlers: map[string]dataexchange.Callbacks{"ns1": dxc}},
+		ctx:       ctx,
+		callbacks: callbacks{handlers: map[string]dataexchange.Callbacks{"ns1": dxc}},
+	}
+	ep := &dxEndpoint{
 		wsconn:     wsm,
 		ackChannel: make(chan *ack, 1),
 	}
-	h.ackChannel <- &ack{
+	ep.ackChannel <- &ack{
 		eventID: "12345",
 	}
 	wsm.On("Close").Return()
 	wsm.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Run(func(args mock.Arguments) {
 		cancelCtx()
 	})
-	h.ackLoop() // we're simply looking for it exiting
+	h.ackLoop(ep) // we're simply looking for it exiting
 }
 
 func TestEventLoopClosedContext(t *testing.T) {
@@ -860,14 +946,13 @@ func TestEventLoopClosedContext(t *testing.T) {
 	h := &FFDX{
 		ctx:       ctx,
 		callbacks: callbacks{handlers: map[string]dataexchange.Callbacks{"ns1": dxc}},
-		wsconn:    wsm,
 	}
 	r := make(chan []byte, 1)
 	r <- []byte(`{}`)
 	wsm.On("Close").Return()
 	wsm.On("Receive").Return((<-chan []byte)(r))
 	wsm.On("Send", mock.Anything, mock.Anything).Return(nil)
-	h.eventLoop() // we're simply looking for it exiting
+	h.eventLoop(&dxEndpoint{wsconn: wsm}) // we're simply looking for it exiting
 }
 
 func TestWebsocketWithReinit(t *testing.T) {
@@ -896,7 +981,7 @@ func TestWebsocketWithReinit(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, 1, len(reqNodes))
 
-			assert.False(t, h.initialized)
+			assert.False(t, h.endpoints[0].initialized)
 
 			count++
 			if count == 1 {
@@ -922,7 +1007,7 @@ func TestWebsocketWithReinit(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, 3, httpmock.GetTotalCallCount())
-	assert.True(t, h.initialized)
+	assert.True(t, h.endpoints[0].initialized)
 }
 
 func TestWebsocketWithEmptyNodesInit(t *testing.T) {
@@ -971,14 +1056,14 @@ func TestWebsocketWithEmptyNodesInit(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, 1, httpmock.GetTotalCallCount())
-	assert.True(t, h.initialized)
+	assert.True(t, h.endpoints[0].initialized)
 }
 
 func TestDXUninitialized(t *testing.T) {
 	h, _, _, _, done := newTestFFDX(t, false)
 	defer done()
 
-	h.initialized = false
+	h.endpoints[0].initialized = false
 
 	peer := fftypes.JSONObject{"id": "peer1"}
 	sender := fftypes.JSONObject{"id": "sender1"}
@@ -1075,7 +1160,7 @@ func TestWebsocketDXConnect(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, 1, httpmock.GetTotalCallCount())
-	assert.True(t, h.initialized)
+	assert.True(t, h.endpoints[0].initialized)
 	assert.Equal(t, 1, dxc.connectCalls)
 }
 
@@ -1255,3 +1340,80 @@ func TestCheckNodeIdentityStatusSetsHealthyStateWhenCertsExpire(t *testing.T) {
 	err := h.CheckNodeIdentityStatus(context.Background(), node)
 	assert.NoError(t, err)
 }
+
+func TestCheckHealth(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/id", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{"id": "peer1"}))
+
+	err := h.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestCheckHealthFail(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/id", httpURL),
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{}))
+
+	err := h.CheckHealth(context.Background())
+	assert.Regexp(t, "FF10229", err)
+}
+
+func TestRotateCredentials(t *testing.T) {
+	h, toServer, fromServer, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	err := h.Start()
+	assert.NoError(t, err)
+	fromServer <- `{"id":"0"}`
+	assert.Equal(t, `{"action":"ack","id":"0"}`, <-toServer)
+
+	// The test server only accepts one connection, so the new websocket connects to a second one
+	toServer2, fromServer2, wsURL2, done2 := wsclient.NewTestWSServer(func(req *http.Request) {
+		assert.Equal(t, "key2", req.Header.Get("X-API-Key"))
+	})
+	defer done2()
+	rotatedConfig := config.RootSection("ffdxrotated")
+	h.InitConfig(rotatedConfig)
+	rotatedConfig.Set(wsclient.WSConfigURL, wsURL2)
+	rotatedConfig.Set(ffresty.HTTPConfigHeaders, map[string]interface{}{"X-API-Key": "key2"})
+
+	err = h.RotateCredentials(context.Background(), rotatedConfig)
+	assert.NoError(t, err)
+	fromServer2 <- `{"id":"1"}`
+	assert.Equal(t, `{"action":"ack","id":"1"}`, <-toServer2)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/id", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "key2", req.Header.Get("X-API-Key"))
+			return httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{"id": "dx1"})(req)
+		})
+	err = h.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRotateCredentialsNotConnected(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	err := h.RotateCredentials(context.Background(), utConfig)
+	assert.Regexp(t, "FF10573.*"+httpURL, err)
+}
+
+func TestRotateCredentialsBadTLS(t *testing.T) {
+	h, _, _, _, done := newTestFFDX(t, false)
+	defer done()
+
+	rotatedConfig := config.RootSection("ffdxrotatedtls")
+	h.InitConfig(rotatedConfig)
+	tlsConf := rotatedConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+
+	err := h.RotateCredentials(context.Background(), rotatedConfig)
+	assert.Regexp(t, "FF00153", err)
+}
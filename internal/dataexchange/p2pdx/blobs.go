@@ -0,0 +1,222 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2pdx
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+const (
+	localBlobPrefix    = "local"
+	receivedBlobPrefix = "received"
+
+	// partialSuffix is appended to the path of a blob that is still being received from a peer
+	partialSuffix = ".partial"
+)
+
+// blobStore keeps blobs on the local filesystem. Blobs uploaded locally have a payloadRef
+// of "local/<namespace>/<id>", and those received from peers "received/<peerID>/<namespace>/<id>".
+type blobStore struct {
+	root string
+}
+
+func localBlobRef(ns, id string) string {
+	return strings.Join([]string{localBlobPrefix, ns, id}, "/")
+}
+
+func receivedBlobRef(peerID, ns, id string) string {
+	return strings.Join([]string{receivedBlobPrefix, peerID, ns, id}, "/")
+}
+
+// path maps a payloadRef to a file under the root, rejecting any reference that could escape it
+func (bs *blobStore) path(ctx context.Context, payloadRef string) (string, error) {
+	for _, segment := range strings.Split(payloadRef, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.ContainsAny(segment, "\\:\x00") || strings.HasSuffix(segment, partialSuffix) {
+			return "", i18n.NewError(ctx, coremsgs.MsgP2PDXInvalidBlobPath, payloadRef)
+		}
+	}
+	return filepath.Join(bs.root, filepath.FromSlash(payloadRef)), nil
+}
+
+// write streams the content to a temporary file alongside the target, and only moves it
+// into place once it is complete - so a partial upload never replaces a good blob
+func (bs *blobStore) write(ctx context.Context, payloadRef string, content io.Reader) (hash *fftypes.Bytes32, size int64, err error) {
+	path, err := bs.path(ctx, payloadRef)
+	if err != nil {
+		return nil, -1, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, -1, err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return nil, -1, err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	hasher := sha256.New()
+	size, err = io.Copy(io.MultiWriter(f, hasher), content)
+	closeErr := f.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		return nil, -1, err
+	}
+	return fftypes.HashResult(hasher), size, nil
+}
+
+// progress returns how much of a blob has been received from a peer so far, and whether it is complete
+func (bs *blobStore) progress(ctx context.Context, payloadRef string) (received int64, complete bool, err error) {
+	path, err := bs.path(ctx, payloadRef)
+	if err != nil {
+		return -1, false, err
+	}
+	info, err := os.Stat(path + partialSuffix)
+	if err == nil {
+		return info.Size(), false, nil
+	}
+	if os.IsNotExist(err) {
+		info, err = os.Stat(path)
+		if err == nil {
+			return info.Size(), true, nil
+		}
+	}
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	return -1, false, err
+}
+
+// appendChunk writes the next chunk of a blob being received to a partial file alongside the target.
+// Each chunk must continue exactly where the last one left off, and a chunk that is interrupted part
+// way through is discarded - so the sender can always resume from the end of the last whole chunk.
+// If the chunk is rejected because it does not continue from what we have, received is still returned.
+func (bs *blobStore) appendChunk(ctx context.Context, payloadRef string, offset int64, content io.Reader) (received int64, err error) {
+	path, err := bs.path(ctx, payloadRef)
+	if err != nil {
+		return -1, err
+	}
+	received, complete, err := bs.progress(ctx, payloadRef)
+	if err != nil {
+		return -1, err
+	}
+	if complete && offset == received {
+		// The whole blob was received before, and the sender is retrying to have it delivered
+		return received, nil
+	}
+	if offset != 0 && (complete || offset != received) {
+		return received, i18n.NewError(ctx, coremsgs.MsgP2PDXChunkOffsetMismatch, offset, received)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return -1, err
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if offset == 0 {
+		flags |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(path+partialSuffix, flags, 0600)
+	if err != nil {
+		return -1, err
+	}
+	n, err := io.Copy(f, content)
+	if err != nil {
+		_ = f.Truncate(offset)
+		_ = f.Close()
+		return -1, err
+	}
+	if err := f.Close(); err != nil {
+		return -1, err
+	}
+	return offset + n, nil
+}
+
+// complete moves a fully received blob into place, and returns its hash
+func (bs *blobStore) complete(ctx context.Context, payloadRef string) (hash *fftypes.Bytes32, size int64, err error) {
+	path, err := bs.path(ctx, payloadRef)
+	if err != nil {
+		return nil, -1, err
+	}
+	// If there is no partial file, this is a retry of a blob we completed before
+	if err := os.Rename(path+partialSuffix, path); err != nil && !os.IsNotExist(err) {
+		return nil, -1, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, -1, err
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	size, err = io.Copy(hasher, f)
+	if err != nil {
+		return nil, -1, err
+	}
+	return fftypes.HashResult(hasher), size, nil
+}
+
+func (bs *blobStore) open(ctx context.Context, payloadRef string) (*os.File, error) {
+	path, err := bs.path(ctx, payloadRef)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (bs *blobStore) delete(ctx context.Context, payloadRef string) error {
+	path, err := bs.path(ctx, payloadRef)
+	if err != nil {
+		return err
+	}
+	for _, p := range []string{path, path + partialSuffix} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func splitLast(s string, sep string) (string, string) {
+	split := strings.LastIndex(s, sep)
+	if split == -1 {
+		return "", s
+	}
+	return s[:split], s[split+1:]
+}
+
+// splitBlobRef returns the namespace and data ID from the end of a payloadRef
+func splitBlobRef(payloadRef string) (prefix, namespace, id string) {
+	payloadRef, id = splitLast(payloadRef, "/")
+	payloadRef, namespace = splitLast(payloadRef, "/")
+	return payloadRef, namespace, id
+}
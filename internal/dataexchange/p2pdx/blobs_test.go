@@ -0,0 +1,185 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2pdx
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errors.New("pop")
+}
+
+func TestBlobPathInvalid(t *testing.T) {
+	bs := &blobStore{root: t.TempDir()}
+	for _, ref := range []string{"", "local//id1", "local/../../etc/passwd", "local/./id1", `local\ns1/id1`, "c:/ns1/id1"} {
+		_, err := bs.path(context.Background(), ref)
+		assert.Regexp(t, "FF10558", err, ref)
+	}
+
+	path, err := bs.path(context.Background(), "local/ns1/id1")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(bs.root, "local", "ns1", "id1"), path)
+}
+
+func TestBlobWriteFailCleansUp(t *testing.T) {
+	bs := &blobStore{root: t.TempDir()}
+	_, _, err := bs.write(context.Background(), "local/ns1/id1", errReader{})
+	assert.Regexp(t, "pop", err)
+
+	entries, err := os.ReadDir(filepath.Join(bs.root, "local", "ns1"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestBlobWriteBadPath(t *testing.T) {
+	bs := &blobStore{root: t.TempDir()}
+	_, _, err := bs.write(context.Background(), "../id1", strings.NewReader("data"))
+	assert.Regexp(t, "FF10558", err)
+
+	_, err = bs.open(context.Background(), "../id1")
+	assert.Regexp(t, "FF10558", err)
+
+	err = bs.delete(context.Background(), "../id1")
+	assert.Regexp(t, "FF10558", err)
+}
+
+func TestBlobWriteMkdirFail(t *testing.T) {
+	root := t.TempDir()
+	err := os.WriteFile(filepath.Join(root, "local"), []byte{}, 0600)
+	assert.NoError(t, err)
+	bs := &blobStore{root: root}
+	_, _, err = bs.write(context.Background(), "local/ns1/id1", strings.NewReader("data"))
+	assert.Error(t, err)
+}
+
+func TestBlobDeleteMissing(t *testing.T) {
+	bs := &blobStore{root: t.TempDir()}
+	err := bs.delete(context.Background(), "local/ns1/id1")
+	assert.NoError(t, err)
+}
+
+func TestSplitBlobRef(t *testing.T) {
+	prefix, ns, id := splitBlobRef("received/peer1/node1/ns1/id1")
+	assert.Equal(t, "received/peer1/node1", prefix)
+	assert.Equal(t, "ns1", ns)
+	assert.Equal(t, "id1", id)
+
+	prefix, ns, id = splitBlobRef("id1")
+	assert.Equal(t, "", prefix)
+	assert.Equal(t, "", ns)
+	assert.Equal(t, "id1", id)
+}
+
+func TestBlobAppendChunks(t *testing.T) {
+	bs := &blobStore{root: t.TempDir()}
+	ctx := context.Background()
+
+	received, complete, err := bs.progress(ctx, "received/peer1/ns1/id1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), received)
+	assert.False(t, complete)
+
+	received, err = bs.appendChunk(ctx, "received/peer1/ns1/id1", 0, strings.NewReader("some"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), received)
+
+	// An interrupted chunk is discarded
+	received, err = bs.appendChunk(ctx, "received/peer1/ns1/id1", 4, io.MultiReader(strings.NewReader(" da"), errReader{}))
+	assert.Regexp(t, "pop", err)
+	assert.Equal(t, int64(-1), received)
+
+	// A chunk that does not continue from what was received is rejected
+	received, err = bs.appendChunk(ctx, "received/peer1/ns1/id1", 2, strings.NewReader("me data"))
+	assert.Regexp(t, "FF10562", err)
+	assert.Equal(t, int64(4), received)
+
+	received, err = bs.appendChunk(ctx, "received/peer1/ns1/id1", 4, strings.NewReader(" data"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), received)
+
+	hash, size, err := bs.complete(ctx, "received/peer1/ns1/id1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), size)
+	expectedHash := sha256.Sum256([]byte("some data"))
+	assert.Equal(t, fftypes.Bytes32(expectedHash), *hash)
+
+	received, complete, err = bs.progress(ctx, "received/peer1/ns1/id1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), received)
+	assert.True(t, complete)
+
+	// A retry of the completed blob is accepted without any data, and completes again
+	received, err = bs.appendChunk(ctx, "received/peer1/ns1/id1", 9, strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), received)
+	hash2, _, err := bs.complete(ctx, "received/peer1/ns1/id1")
+	assert.NoError(t, err)
+	assert.Equal(t, *hash, *hash2)
+
+	// But not a chunk part way through it
+	_, err = bs.appendChunk(ctx, "received/peer1/ns1/id1", 4, strings.NewReader(" data"))
+	assert.Regexp(t, "FF10562", err)
+
+	err = bs.delete(ctx, "received/peer1/ns1/id1")
+	assert.NoError(t, err)
+	received, complete, err = bs.progress(ctx, "received/peer1/ns1/id1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), received)
+	assert.False(t, complete)
+}
+
+func TestBlobChunksBadPath(t *testing.T) {
+	bs := &blobStore{root: t.TempDir()}
+	ctx := context.Background()
+
+	_, err := bs.path(ctx, "received/peer1/ns1/id1"+partialSuffix)
+	assert.Regexp(t, "FF10558", err)
+
+	_, _, err = bs.progress(ctx, "../id1")
+	assert.Regexp(t, "FF10558", err)
+
+	_, err = bs.appendChunk(ctx, "../id1", 0, strings.NewReader("data"))
+	assert.Regexp(t, "FF10558", err)
+
+	_, _, err = bs.complete(ctx, "../id1")
+	assert.Regexp(t, "FF10558", err)
+
+	_, _, err = bs.complete(ctx, "received/peer1/ns1/missing")
+	assert.Error(t, err)
+}
+
+func TestBlobAppendChunkMkdirFail(t *testing.T) {
+	root := t.TempDir()
+	err := os.WriteFile(filepath.Join(root, "received"), []byte{}, 0600)
+	assert.NoError(t, err)
+	bs := &blobStore{root: root}
+	_, err = bs.appendChunk(context.Background(), "received/peer1/ns1/id1", 0, strings.NewReader("data"))
+	assert.Error(t, err)
+}
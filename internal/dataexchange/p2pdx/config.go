@@ -0,0 +1,75 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2pdx
+
+import (
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+)
+
+const (
+	// P2PDXAddress is the local interface to listen on for connections from peers
+	P2PDXAddress = "address"
+	// P2PDXPort is the port to listen on for connections from peers
+	P2PDXPort = "port"
+	// P2PDXEndpoint is the URL other nodes use to reach this node, which is published in the node identity
+	P2PDXEndpoint = "endpoint"
+	// P2PDXCertFile is the PEM certificate used both to serve peers and to authenticate to them
+	P2PDXCertFile = "tls.certFile"
+	// P2PDXKeyFile is the PEM private key for the certificate
+	P2PDXKeyFile = "tls.keyFile"
+	// P2PDXBlobsPath is the local directory blobs are stored in
+	P2PDXBlobsPath = "blobsPath"
+	// P2PDXManifestEnabled determines whether to require+validate a manifest from the receiving node
+	P2PDXManifestEnabled = "manifestEnabled"
+	// P2PDXRequestTimeout is the maximum time to send a message or blob to a peer
+	P2PDXRequestTimeout = "requestTimeout"
+	// P2PDXAckTimeout is the maximum time to wait for a received message or blob to be processed, before the sender is told to retry
+	P2PDXAckTimeout = "ackTimeout"
+	// P2PDXChunkSize is the size of each request a blob is sent to a peer in, and how much is resent if a transfer is interrupted
+	P2PDXChunkSize = "chunkSize"
+
+	P2PDXEventRetryInitialDelay = "eventRetry.initialDelay"
+	P2PDXEventRetryMaxDelay     = "eventRetry.maxDelay"
+	P2PDXEventRetryFactor       = "eventRetry.factor"
+
+	P2PDXTransferRetryMaxAttempts  = "transferRetry.maxAttempts"
+	P2PDXTransferRetryInitialDelay = "transferRetry.initialDelay"
+	P2PDXTransferRetryMaxDelay     = "transferRetry.maxDelay"
+	P2PDXTransferRetryFactor       = "transferRetry.factor"
+)
+
+func (h *P2PDX) InitConfig(config config.Section) {
+	config.AddKnownKey(P2PDXAddress, "0.0.0.0")
+	config.AddKnownKey(P2PDXPort, 5200)
+	config.AddKnownKey(P2PDXEndpoint)
+	config.AddKnownKey(P2PDXCertFile)
+	config.AddKnownKey(P2PDXKeyFile)
+	config.AddKnownKey(P2PDXBlobsPath)
+	config.AddKnownKey(P2PDXManifestEnabled, false)
+	config.AddKnownKey(P2PDXRequestTimeout, "2m")
+	config.AddKnownKey(P2PDXAckTimeout, "1m")
+	config.AddKnownKey(P2PDXChunkSize, "4Mb")
+	config.AddKnownKey(P2PDXEventRetryInitialDelay, 50*time.Millisecond)
+	config.AddKnownKey(P2PDXEventRetryMaxDelay, 30*time.Second)
+	config.AddKnownKey(P2PDXEventRetryFactor, 2.0)
+	config.AddKnownKey(P2PDXTransferRetryMaxAttempts, 5)
+	config.AddKnownKey(P2PDXTransferRetryInitialDelay, 250*time.Millisecond)
+	config.AddKnownKey(P2PDXTransferRetryMaxDelay, 10*time.Second)
+	config.AddKnownKey(P2PDXTransferRetryFactor, 2.0)
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2pdx
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+)
+
+// dxEvent is a message or blob received from a peer. The inbound request that carried it
+// is held open until the event is acknowledged, so the sender only sees success once
+// the event has been processed.
+type dxEvent struct {
+	id                  string
+	dxType              dataexchange.DXEventType
+	messageReceived     *dataexchange.MessageReceived
+	privateBlobReceived *dataexchange.PrivateBlobReceived
+	acked               chan string
+}
+
+func (e *dxEvent) EventID() string {
+	return e.id
+}
+
+func (e *dxEvent) Type() dataexchange.DXEventType {
+	return e.dxType
+}
+
+func (e *dxEvent) AckWithManifest(manifest string) {
+	select {
+	case e.acked <- manifest:
+	default:
+		// Already acked
+	}
+}
+
+func (e *dxEvent) Ack() {
+	e.AckWithManifest("")
+}
+
+func (e *dxEvent) MessageReceived() *dataexchange.MessageReceived {
+	return e.messageReceived
+}
+
+func (e *dxEvent) PrivateBlobReceived() *dataexchange.PrivateBlobReceived {
+	return e.privateBlobReceived
+}
+
+// deliver passes the event to the handler for the namespace and recipient, and waits for it to be acked
+func (h *P2PDX) deliver(ctx context.Context, namespace, recipient string, e *dxEvent) (manifest string, ok bool) {
+	ctx, cancel := context.WithTimeout(ctx, h.ackTimeout)
+	defer cancel()
+
+	// We use a retry loop because if the namespace isn't ready to consume the event
+	// we need to hold onto it. If we give up, the sender will retry the whole request.
+	go func() {
+		_ = h.retry.Do(ctx, "dispatch p2pdx event", func(attempt int) (retry bool, err error) {
+			return true, h.callbacks.DXEvent(ctx, namespace, recipient, e)
+		})
+	}()
+
+	select {
+	case manifest = <-e.acked:
+		return manifest, true
+	case <-ctx.Done():
+		return "", false
+	}
+}
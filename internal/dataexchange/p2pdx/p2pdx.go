@@ -0,0 +1,432 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2pdx
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+)
+
+const DXIDSeparator = "/"
+
+// P2PDX is a data exchange plugin that transfers messages and blobs directly between FireFly
+// nodes over HTTPS with mutual TLS, without a separate data exchange runtime. Each node serves
+// an endpoint for its peers, and authenticates them by the certificate in their node identity.
+type P2PDX struct {
+	ctx            context.Context
+	cancelCtx      context.CancelFunc
+	capabilities   *dataexchange.Capabilities
+	callbacks      callbacks
+	peerID         string
+	endpoint       string
+	listenAddress  string
+	cert           tls.Certificate
+	certPEM        string
+	listener       net.Listener
+	server         *http.Server
+	blobs          *blobStore
+	requestTimeout time.Duration
+	ackTimeout     time.Duration
+	chunkSize      int64
+	retry          *retry.Retry
+	transferRetry  *retry.Retry
+	transferTries  int
+	peersMutex     sync.Mutex
+	nodes          map[string]*dxNode
+	peers          map[string]*dxPeer
+
+	metrics metrics.Manager // optional
+}
+
+type dxNode struct {
+	Name string
+	Peer fftypes.JSONObject
+}
+
+type callbacks struct {
+	plugin     *P2PDX
+	writeLock  sync.Mutex
+	handlers   map[string]dataexchange.Callbacks
+	opHandlers map[string]core.OperationCallbacks
+}
+
+func (cb *callbacks) OperationUpdate(ctx context.Context, update *core.OperationUpdateAsync) {
+	namespace, _, _ := core.ParseNamespacedOpID(ctx, update.NamespacedOpID)
+	if handler, ok := cb.opHandlers[namespace]; ok {
+		handler.OperationUpdate(update)
+	} else {
+		log.L(ctx).Errorf("No handler found for DX operation '%s'", update.NamespacedOpID)
+	}
+}
+
+func (cb *callbacks) DXEvent(ctx context.Context, namespace, recipient string, event dataexchange.DXEvent) error {
+	node := cb.plugin.findNode(namespace, recipient)
+	if node != nil {
+		key := namespace + ":" + node.Name
+		if handler, ok := cb.handlers[key]; ok {
+			return handler.DXEvent(cb.plugin, event)
+		}
+		log.L(ctx).Errorf("No handler found for DX event '%s' namespace=%s node=%s", event.EventID(), namespace, node.Name)
+		event.Ack()
+	} else {
+		log.L(ctx).Errorf("Unknown local node for DX event '%s' recipient=%s", event.EventID(), recipient)
+		event.Ack()
+	}
+	return nil
+}
+
+func (h *P2PDX) Name() string {
+	return "p2pdx"
+}
+
+func (h *P2PDX) Init(ctx context.Context, cancelCtx context.CancelFunc, config config.Section, metrics metrics.Manager) (err error) {
+	h.ctx = log.WithLogField(ctx, "dx", "p2p")
+	h.cancelCtx = cancelCtx
+	h.callbacks = callbacks{
+		plugin:     h,
+		handlers:   make(map[string]dataexchange.Callbacks),
+		opHandlers: make(map[string]core.OperationCallbacks),
+	}
+	h.nodes = make(map[string]*dxNode)
+	h.peers = make(map[string]*dxPeer)
+	h.metrics = metrics
+
+	for _, key := range []string{P2PDXEndpoint, P2PDXCertFile, P2PDXKeyFile, P2PDXBlobsPath} {
+		if config.GetString(key) == "" {
+			return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, key, "dataexchange.p2pdx")
+		}
+	}
+
+	h.cert, err = tls.LoadX509KeyPair(config.GetString(P2PDXCertFile), config.GetString(P2PDXKeyFile))
+	if err == nil {
+		h.cert.Leaf, err = x509.ParseCertificate(h.cert.Certificate[0])
+	}
+	if err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgP2PDXBadCert)
+	}
+	h.certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: h.cert.Certificate[0]}))
+	h.peerID = peerIDFromCert(h.cert)
+
+	h.endpoint = strings.TrimSuffix(config.GetString(P2PDXEndpoint), "/")
+	h.listenAddress = net.JoinHostPort(config.GetString(P2PDXAddress), strconv.Itoa(config.GetInt(P2PDXPort)))
+	h.blobs = &blobStore{root: config.GetString(P2PDXBlobsPath)}
+	h.requestTimeout = config.GetDuration(P2PDXRequestTimeout)
+	h.ackTimeout = config.GetDuration(P2PDXAckTimeout)
+	h.chunkSize = config.GetByteSize(P2PDXChunkSize)
+	h.capabilities = &dataexchange.Capabilities{
+		Manifest: config.GetBool(P2PDXManifestEnabled),
+	}
+	h.retry = &retry.Retry{
+		InitialDelay: config.GetDuration(P2PDXEventRetryInitialDelay),
+		MaximumDelay: config.GetDuration(P2PDXEventRetryMaxDelay),
+		Factor:       config.GetFloat64(P2PDXEventRetryFactor),
+	}
+	h.transferRetry = &retry.Retry{
+		InitialDelay: config.GetDuration(P2PDXTransferRetryInitialDelay),
+		MaximumDelay: config.GetDuration(P2PDXTransferRetryMaxDelay),
+		Factor:       config.GetFloat64(P2PDXTransferRetryFactor),
+	}
+	h.transferTries = config.GetInt(P2PDXTransferRetryMaxAttempts)
+	return nil
+}
+
+// peerIDFromCert uses the common name of the certificate as the peer ID, falling back
+// to the fingerprint of the certificate if it does not have one
+func peerIDFromCert(cert tls.Certificate) string {
+	if cert.Leaf.Subject.CommonName != "" {
+		return cert.Leaf.Subject.CommonName
+	}
+	fingerprint := sha256.Sum256(cert.Certificate[0])
+	return hex.EncodeToString(fingerprint[:])
+}
+
+func (h *P2PDX) SetHandler(networkNamespace, nodeName string, handler dataexchange.Callbacks) {
+	h.callbacks.writeLock.Lock()
+	defer h.callbacks.writeLock.Unlock()
+	key := networkNamespace + ":" + nodeName
+	if handler == nil {
+		delete(h.callbacks.handlers, key)
+	} else {
+		h.callbacks.handlers[key] = handler
+	}
+}
+
+func (h *P2PDX) SetOperationHandler(namespace string, handler core.OperationCallbacks) {
+	h.callbacks.writeLock.Lock()
+	defer h.callbacks.writeLock.Unlock()
+	if handler == nil {
+		delete(h.callbacks.opHandlers, namespace)
+	} else {
+		h.callbacks.opHandlers[namespace] = handler
+	}
+}
+
+// Start begins serving peers. Until then no events can be received, as required by the plugin interface.
+func (h *P2PDX) Start() (err error) {
+	h.listener, err = net.Listen("tcp", h.listenAddress)
+	if err != nil {
+		return err
+	}
+	h.server = &http.Server{
+		Handler:           h.router(),
+		ReadHeaderTimeout: 30 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{h.cert},
+			// Client certificates are checked against the registered peers by each handler
+			ClientAuth: tls.RequireAnyClientCert,
+		},
+		BaseContext: func(l net.Listener) context.Context { return h.ctx },
+	}
+	log.L(h.ctx).Infof("Peer-to-peer data exchange listening on %s as peer '%s'", h.listener.Addr(), h.peerID)
+
+	go func() {
+		err := h.server.ServeTLS(h.listener, "", "")
+		if err != http.ErrServerClosed {
+			log.L(h.ctx).Errorf("Peer-to-peer data exchange server exited: %s. Terminating server!", err)
+			h.cancelCtx()
+		}
+	}()
+	go func() {
+		<-h.ctx.Done()
+		_ = h.server.Close()
+	}()
+	return nil
+}
+
+func (h *P2PDX) Capabilities() *dataexchange.Capabilities {
+	return h.capabilities
+}
+
+func (h *P2PDX) GetPeerID(peer fftypes.JSONObject) string {
+	return peer.GetString("id")
+}
+
+// GetEndpointInfo returns the details other nodes need to reach this node, which are published in the node identity
+func (h *P2PDX) GetEndpointInfo(ctx context.Context, nodeName string) (peer fftypes.JSONObject, err error) {
+	return fftypes.JSONObject{
+		"id":       fmt.Sprintf("%s%s%s", h.peerID, DXIDSeparator, nodeName),
+		"endpoint": h.endpoint,
+		"cert":     h.certPEM,
+	}, nil
+}
+
+// AddNode is called for every node identity registered in the network, which is how we discover our peers
+func (h *P2PDX) AddNode(ctx context.Context, networkNamespace, nodeName string, peer fftypes.JSONObject) (err error) {
+	p, err := h.newPeer(ctx, peer)
+	if err != nil {
+		return err
+	}
+
+	h.peersMutex.Lock()
+	defer h.peersMutex.Unlock()
+	h.nodes[networkNamespace+":"+p.id] = &dxNode{
+		Peer: peer,
+		Name: nodeName,
+	}
+	// The same node is added once for each namespace, so keep any existing connection if nothing has changed
+	if existing := h.peers[p.id]; existing == nil || existing.endpoint != p.endpoint || !existing.cert.Equal(p.cert) {
+		h.peers[p.id] = p
+	}
+	return nil
+}
+
+// UpdateNode replaces the details of a node after its identity is updated, for example with a new endpoint or certificate
+func (h *P2PDX) UpdateNode(ctx context.Context, networkNamespace, nodeName string, peer fftypes.JSONObject) (err error) {
+	p, err := h.newPeer(ctx, peer)
+	if err != nil {
+		return err
+	}
+
+	h.peersMutex.Lock()
+	defer h.peersMutex.Unlock()
+	for key, node := range h.nodes {
+		switch {
+		case strings.HasPrefix(key, networkNamespace+":") && node.Name == nodeName:
+			// The node might have been registered under a different peer ID before the update
+			delete(h.nodes, key)
+		case h.GetPeerID(node.Peer) == p.id:
+			node.Peer = peer
+		}
+	}
+	h.nodes[networkNamespace+":"+p.id] = &dxNode{
+		Peer: peer,
+		Name: nodeName,
+	}
+	if existing := h.peers[p.id]; existing == nil || existing.endpoint != p.endpoint || !existing.cert.Equal(p.cert) {
+		h.peers[p.id] = p
+	}
+	return nil
+}
+
+func (h *P2PDX) findNode(namespace, recipient string) *dxNode {
+	h.peersMutex.Lock()
+	defer h.peersMutex.Unlock()
+	node := h.nodes[namespace+":"+recipient]
+	if node == nil {
+		// Fall back to nodes registered on the legacy system namespace
+		// (further verification of the off-chain identity will be performed by the event handler)
+		node = h.nodes[core.LegacySystemNamespace+":"+recipient]
+	}
+	return node
+}
+
+func (h *P2PDX) UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (payloadRef string, hash *fftypes.Bytes32, size int64, err error) {
+	payloadRef = localBlobRef(ns, id.String())
+	hash, size, err = h.blobs.write(ctx, payloadRef, content)
+	if err != nil {
+		return "", nil, -1, err
+	}
+	return payloadRef, hash, size, nil
+}
+
+func (h *P2PDX) DownloadBlob(ctx context.Context, payloadRef string) (content io.ReadCloser, err error) {
+	return h.blobs.open(ctx, payloadRef)
+}
+
+func (h *P2PDX) DeleteBlob(ctx context.Context, payloadRef string) (err error) {
+	return h.blobs.delete(ctx, payloadRef)
+}
+
+func (h *P2PDX) SendMessage(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, data []byte) (err error) {
+	p, err := h.getPeer(ctx, h.GetPeerID(peer))
+	if err != nil {
+		return err
+	}
+
+	msg := &wireMessage{
+		Sender:    h.GetPeerID(sender),
+		Recipient: p.id,
+		Message:   string(data),
+	}
+	h.transferSubmitted(nsOpID)
+	go h.transfer(nsOpID, "message", func(ctx context.Context) (*wireResult, error) {
+		var result wireResult
+		res, err := p.client.R().SetContext(ctx).
+			SetBody(msg).
+			SetResult(&result).
+			Post("/api/v1/messages")
+		if err != nil || !res.IsSuccess() {
+			return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
+		}
+		return &result, nil
+	})
+	return nil
+}
+
+func (h *P2PDX) TransferBlob(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, payloadRef string) (err error) {
+	p, err := h.getPeer(ctx, h.GetPeerID(peer))
+	if err != nil {
+		return err
+	}
+	_, ns, _ := splitBlobRef(payloadRef)
+	if _, err := h.blobs.path(ctx, payloadRef); err != nil || ns == "" {
+		return i18n.NewError(ctx, coremsgs.MsgP2PDXInvalidBlobPath, payloadRef)
+	}
+
+	senderID := h.GetPeerID(sender)
+	h.transferSubmitted(nsOpID)
+	go h.transfer(nsOpID, "blob", func(ctx context.Context) (*wireResult, error) {
+		return h.sendBlob(ctx, nsOpID, p, senderID, payloadRef)
+	})
+	return nil
+}
+
+// transfer runs a send to a peer in the background, and reports the outcome as an operation update.
+// The peer only responds once it has processed what we sent, so success means it was delivered.
+// Each request to the peer is bounded by the request timeout, so a blob sent in many chunks can take longer.
+func (h *P2PDX) transfer(nsOpID, transferType string, send func(ctx context.Context) (*wireResult, error)) {
+	update := core.OperationUpdate{
+		Plugin:         h.Name(),
+		NamespacedOpID: nsOpID,
+	}
+	result, err := send(h.ctx)
+	if err != nil {
+		log.L(h.ctx).Errorf("Failed to send %s for operation '%s': %s", transferType, nsOpID, err)
+		update.Status = core.OpStatusFailed
+		update.ErrorMessage = err.Error()
+	} else {
+		update.Status = core.OpStatusSucceeded
+		update.VerifyManifest = h.capabilities.Manifest
+		update.DXManifest = result.Manifest
+		update.DXHash = result.Hash
+		if transferType == "blob" {
+			update.Progress = &core.OperationProgress{BytesSent: result.Size, BytesTotal: result.Size}
+		}
+	}
+	h.transferUpdated(nsOpID, transferType, update.Status)
+	h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
+		OperationUpdate: update,
+	})
+}
+
+func (h *P2PDX) transferSubmitted(nsOpID string) {
+	if h.metrics != nil && h.metrics.IsMetricsEnabled() {
+		h.metrics.DXTransferSubmitted(nsOpID)
+	}
+}
+
+func (h *P2PDX) transferUpdated(nsOpID, transferType string, status core.OpStatus) {
+	if h.metrics != nil && h.metrics.IsMetricsEnabled() {
+		h.metrics.DXTransferCompleted(nsOpID, transferType, status)
+	}
+}
+
+// CheckNodeIdentityStatus compares the certificate in the node identity with the one we are serving
+func (h *P2PDX) CheckNodeIdentityStatus(ctx context.Context, node *core.Identity) error {
+	if node == nil || node.Profile == nil {
+		return i18n.NewError(ctx, coremsgs.MsgNodeNotProvidedForCheck)
+	}
+
+	mismatchState := metrics.NodeIdentityDXCertMismatchStatusUnknown
+	if nodeCert := node.Profile.GetString("cert"); nodeCert != "" {
+		mismatchState = metrics.NodeIdentityDXCertMismatchStatusHealthy
+		if strings.TrimSpace(nodeCert) != strings.TrimSpace(h.certPEM) {
+			log.L(ctx).Warnf("DX certificate for node '%s' is out-of-sync with on-chain identity", node.Name)
+			mismatchState = metrics.NodeIdentityDXCertMismatchStatusMismatched
+		}
+	}
+
+	if h.metrics != nil && h.metrics.IsMetricsEnabled() {
+		h.metrics.NodeIdentityDXCertMismatch(node.Namespace, mismatchState)
+		h.metrics.NodeIdentityDXCertExpiry(node.Namespace, h.cert.Leaf.NotAfter.UTC())
+	}
+	return nil
+}
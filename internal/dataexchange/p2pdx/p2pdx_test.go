@@ -0,0 +1,796 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2pdx
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func writeTestCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	assert.NoError(t, err)
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	assert.NoError(t, err)
+	return certFile, keyFile
+}
+
+func newTestP2PDX(t *testing.T, name string) (h *P2PDX, done func()) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir, name)
+
+	conf := config.RootSection("p2pdx_unit_tests_" + name)
+	h = &P2PDX{}
+	h.InitConfig(conf)
+	conf.Set(P2PDXAddress, "127.0.0.1")
+	conf.Set(P2PDXPort, 0)
+	conf.Set(P2PDXEndpoint, "https://placeholder")
+	conf.Set(P2PDXCertFile, certFile)
+	conf.Set(P2PDXKeyFile, keyFile)
+	conf.Set(P2PDXBlobsPath, filepath.Join(dir, "blobs"))
+	conf.Set(P2PDXAckTimeout, "5s")
+	conf.Set(P2PDXRequestTimeout, "5s")
+	conf.Set(P2PDXTransferRetryInitialDelay, "1ms")
+	conf.Set(P2PDXTransferRetryMaxAttempts, 3)
+
+	mmm := metricsmocks.NewManager(t)
+	mmm.On("IsMetricsEnabled").Return(false).Maybe()
+	ctx, cancel := context.WithCancel(context.Background())
+	err := h.Init(ctx, cancel, conf, mmm)
+	assert.NoError(t, err)
+	assert.Equal(t, name, h.peerID)
+
+	err = h.Start()
+	assert.NoError(t, err)
+	h.endpoint = fmt.Sprintf("https://%s", h.listener.Addr())
+	return h, cancel
+}
+
+// newTestPeers sets up two nodes that have discovered each other
+func newTestPeers(t *testing.T) (a, b *P2PDX, aPeer, bPeer fftypes.JSONObject, done func()) {
+	a, doneA := newTestP2PDX(t, "peerA")
+	b, doneB := newTestP2PDX(t, "peerB")
+	aPeer, _ = a.GetEndpointInfo(context.Background(), "nodeA")
+	bPeer, _ = b.GetEndpointInfo(context.Background(), "nodeB")
+	for _, h := range []*P2PDX{a, b} {
+		assert.NoError(t, h.AddNode(context.Background(), "ns1", "nodeA", aPeer))
+		assert.NoError(t, h.AddNode(context.Background(), "ns1", "nodeB", bPeer))
+	}
+	return a, b, aPeer, bPeer, func() {
+		doneA()
+		doneB()
+	}
+}
+
+func opUpdates(ocb *coremocks.OperationCallbacks) chan *core.OperationUpdate {
+	updates := make(chan *core.OperationUpdate, 1)
+	ocb.On("OperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		updates <- &args[0].(*core.OperationUpdateAsync).OperationUpdate
+	}).Return()
+	return updates
+}
+
+func TestInitMissingConfig(t *testing.T) {
+	h := &P2PDX{}
+	conf := config.RootSection("p2pdx_unit_tests_missing")
+	h.InitConfig(conf)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := h.Init(ctx, cancel, conf, nil)
+	assert.Regexp(t, "FF10138.*endpoint", err)
+}
+
+func TestInitBadCert(t *testing.T) {
+	h := &P2PDX{}
+	conf := config.RootSection("p2pdx_unit_tests_badcert")
+	h.InitConfig(conf)
+	conf.Set(P2PDXEndpoint, "https://localhost:5200")
+	conf.Set(P2PDXCertFile, "missing.pem")
+	conf.Set(P2PDXKeyFile, "missing.pem")
+	conf.Set(P2PDXBlobsPath, t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := h.Init(ctx, cancel, conf, nil)
+	assert.Regexp(t, "FF10559", err)
+}
+
+func TestStartListenFail(t *testing.T) {
+	h, done := newTestP2PDX(t, "listenfail")
+	defer done()
+
+	h.listenAddress = h.listener.Addr().String()
+	err := h.Start()
+	assert.Error(t, err)
+}
+
+func TestGetEndpointInfo(t *testing.T) {
+	h, done := newTestP2PDX(t, "peer1")
+	defer done()
+
+	assert.Equal(t, "p2pdx", h.Name())
+	assert.False(t, h.Capabilities().Manifest)
+
+	peer, err := h.GetEndpointInfo(context.Background(), "node1")
+	assert.NoError(t, err)
+	assert.Equal(t, "peer1/node1", h.GetPeerID(peer))
+	assert.Equal(t, h.endpoint, peer.GetString("endpoint"))
+	assert.True(t, strings.HasPrefix(peer.GetString("cert"), "-----BEGIN CERTIFICATE-----"))
+}
+
+func TestAddNodeInvalid(t *testing.T) {
+	h, done := newTestP2PDX(t, "peer1")
+	defer done()
+
+	err := h.AddNode(context.Background(), "ns1", "node2", fftypes.JSONObject{"id": "peer2/node2"})
+	assert.Regexp(t, "FF10555", err)
+
+	err = h.AddNode(context.Background(), "ns1", "node2", fftypes.JSONObject{
+		"id":       "peer2/node2",
+		"endpoint": "https://peer2",
+		"cert":     "-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydA==\n-----END CERTIFICATE-----\n",
+	})
+	assert.Regexp(t, "FF10555", err)
+}
+
+func TestUpdateNode(t *testing.T) {
+	a, b, _, bPeer, done := newTestPeers(t)
+	defer done()
+	ctx := context.Background()
+	assert.NoError(t, a.AddNode(ctx, "ns2", "nodeB", bPeer))
+
+	// Moving to a new endpoint refreshes the peer in every namespace
+	moved := fftypes.JSONObject{}
+	for k, v := range bPeer {
+		moved[k] = v
+	}
+	moved["endpoint"] = "https://moved.example.com"
+	err := a.UpdateNode(ctx, "ns1", "nodeB", moved)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://moved.example.com", a.peers[b.peerID+"/nodeB"].endpoint)
+	assert.Equal(t, moved, a.findNode("ns1", "peerB/nodeB").Peer)
+	assert.Equal(t, moved, a.findNode("ns2", "peerB/nodeB").Peer)
+
+	// Re-registering under a new peer ID drops the stale entry for the namespace
+	renamed := fftypes.JSONObject{}
+	for k, v := range moved {
+		renamed[k] = v
+	}
+	renamed["id"] = "peerB/nodeB2"
+	err = a.UpdateNode(ctx, "ns1", "nodeB", renamed)
+	assert.NoError(t, err)
+	assert.Nil(t, a.findNode("ns1", "peerB/nodeB"))
+	assert.Equal(t, "nodeB", a.findNode("ns1", "peerB/nodeB2").Name)
+	assert.NotNil(t, a.findNode("ns2", "peerB/nodeB"))
+}
+
+func TestUpdateNodeInvalid(t *testing.T) {
+	h, done := newTestP2PDX(t, "peer1")
+	defer done()
+
+	err := h.UpdateNode(context.Background(), "ns1", "node2", fftypes.JSONObject{"id": "peer2/node2"})
+	assert.Regexp(t, "FF10555", err)
+}
+
+func TestSendMessage(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		return ev.Type() == dataexchange.DXEventTypeMessageReceived &&
+			ev.MessageReceived().PeerID == "peerA/nodeA" &&
+			ev.MessageReceived().Transport.Batch.Namespace == "ns1"
+	})).Run(func(args mock.Arguments) {
+		args[1].(dataexchange.DXEvent).AckWithManifest(`{"manifest":true}`)
+	}).Return(nil)
+
+	wrapper, _ := json.Marshal(&core.TransportWrapper{
+		Batch: &core.Batch{BatchHeader: core.BatchHeader{Namespace: "ns1"}},
+	})
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	err := a.SendMessage(context.Background(), nsOpID, bPeer, aPeer, wrapper)
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, nsOpID, update.NamespacedOpID)
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.Equal(t, `{"manifest":true}`, update.DXManifest)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestSendBatchAck(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		return ev.Type() == dataexchange.DXEventTypeMessageReceived &&
+			ev.MessageReceived().Transport.Ack.State == core.BatchAckStatePersisted
+	})).Run(func(args mock.Arguments) {
+		args[1].(dataexchange.DXEvent).Ack()
+	}).Return(nil)
+
+	wrapper, _ := json.Marshal(&core.TransportWrapper{
+		Ack: &core.BatchAckNotification{Namespace: "ns1", Batch: fftypes.NewUUID(), State: core.BatchAckStatePersisted},
+	})
+	err := a.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, wrapper)
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+
+	mcb.AssertExpectations(t)
+}
+
+func TestSendMessageUnknownPeer(t *testing.T) {
+	h, done := newTestP2PDX(t, "peer1")
+	defer done()
+
+	err := h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), fftypes.JSONObject{"id": "peer2/node2"}, fftypes.JSONObject{}, []byte(`{}`))
+	assert.Regexp(t, "FF10556", err)
+}
+
+func TestSendMessageSenderNotRegistered(t *testing.T) {
+	a, doneA := newTestP2PDX(t, "peerA")
+	defer doneA()
+	b, doneB := newTestP2PDX(t, "peerB")
+	defer doneB()
+
+	// B does not know about A, so must reject the connection
+	aPeer, _ := a.GetEndpointInfo(context.Background(), "nodeA")
+	bPeer, _ := b.GetEndpointInfo(context.Background(), "nodeB")
+	assert.NoError(t, a.AddNode(context.Background(), "ns1", "nodeB", bPeer))
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	err := a.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, []byte(`{}`))
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10556", update.ErrorMessage)
+}
+
+func TestSendMessageImpersonation(t *testing.T) {
+	a, b, _, bPeer, done := newTestPeers(t)
+	defer done()
+	c, doneC := newTestP2PDX(t, "peerC")
+	defer doneC()
+
+	// C knows B, but claims to be A
+	assert.NoError(t, c.AddNode(context.Background(), "ns1", "nodeB", bPeer))
+	ocb := &coremocks.OperationCallbacks{}
+	c.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	err := c.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, fftypes.JSONObject{"id": "peerA/nodeA"}, []byte(`{}`))
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10557", update.ErrorMessage)
+	assert.NotNil(t, a)
+	assert.NotNil(t, b)
+}
+
+func TestSendMessageWrongServerCert(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+	c, doneC := newTestP2PDX(t, "peerC")
+	defer doneC()
+
+	// B's registered endpoint is actually served by C
+	bPeer["endpoint"] = c.endpoint
+	assert.NoError(t, a.AddNode(context.Background(), "ns1", "nodeB", bPeer))
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	err := a.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, []byte(`{}`))
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.NotNil(t, b)
+}
+
+func TestSendMessageInvalidTransport(t *testing.T) {
+	a, _, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	err := a.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, []byte(`{}`))
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "nil batch", update.ErrorMessage)
+}
+
+func TestSendMessageAckTimeout(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+	b.ackTimeout = 10 * time.Millisecond
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	// The handler never acks
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.Anything).Return(nil)
+
+	wrapper, _ := json.Marshal(&core.TransportWrapper{
+		Batch: &core.Batch{BatchHeader: core.BatchHeader{Namespace: "ns1"}},
+	})
+	err := a.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, wrapper)
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10560", update.ErrorMessage)
+}
+
+func TestReceiveMessageNoHandler(t *testing.T) {
+	a, _, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	// Events for nodes without a handler, or unknown nodes, are acked and dropped
+	wrapper, _ := json.Marshal(&core.TransportWrapper{
+		Batch: &core.Batch{BatchHeader: core.BatchHeader{Namespace: "ns1"}},
+	})
+	err := a.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, wrapper)
+	assert.NoError(t, err)
+	update := <-updates
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+
+	err = a.SendMessage(context.Background(), "ns2:"+fftypes.NewUUID().String(), bPeer, aPeer, wrapper)
+	assert.NoError(t, err)
+}
+
+func TestTransferBlob(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+
+	dataID := fftypes.NewUUID()
+	payloadRef, hash, size, err := a.UploadBlob(context.Background(), "ns1", *dataID, strings.NewReader("some data"))
+	assert.NoError(t, err)
+	assert.Equal(t, "local/ns1/"+dataID.String(), payloadRef)
+	assert.Equal(t, int64(9), size)
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	var received *dataexchange.PrivateBlobReceived
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		return ev.Type() == dataexchange.DXEventTypePrivateBlobReceived
+	})).Run(func(args mock.Arguments) {
+		ev := args[1].(dataexchange.DXEvent)
+		received = ev.PrivateBlobReceived()
+		ev.Ack()
+	}).Return(nil)
+
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	err = a.TransferBlob(context.Background(), nsOpID, bPeer, aPeer, payloadRef)
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.Equal(t, hash.String(), update.DXHash)
+
+	assert.Equal(t, "ns1", received.Namespace)
+	assert.Equal(t, "peerA/nodeA", received.PeerID)
+	assert.Equal(t, *hash, received.Hash)
+	assert.Equal(t, dataID.String(), received.DataID)
+	assert.Equal(t, "received/peerA/nodeA/ns1/"+dataID.String(), received.PayloadRef)
+
+	reader, err := b.DownloadBlob(context.Background(), received.PayloadRef)
+	assert.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	reader.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "some data", string(content))
+
+	err = b.DeleteBlob(context.Background(), received.PayloadRef)
+	assert.NoError(t, err)
+	_, err = b.DownloadBlob(context.Background(), received.PayloadRef)
+	assert.Error(t, err)
+}
+
+func TestTransferBlobChunked(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+	a.chunkSize = 4
+
+	dataID := fftypes.NewUUID()
+	payloadRef, hash, _, err := a.UploadBlob(context.Background(), "ns1", *dataID, strings.NewReader("some data"))
+	assert.NoError(t, err)
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.Anything).Run(func(args mock.Arguments) {
+		args[1].(dataexchange.DXEvent).Ack()
+	}).Return(nil)
+
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	err = a.TransferBlob(context.Background(), nsOpID, bPeer, aPeer, payloadRef)
+	assert.NoError(t, err)
+
+	// Progress is reported for each chunk the peer acknowledges, leaving the status unchanged
+	for _, sent := range []int64{4, 8} {
+		update := <-updates
+		assert.Equal(t, nsOpID, update.NamespacedOpID)
+		assert.Equal(t, core.OpStatus(""), update.Status)
+		assert.Equal(t, &core.OperationProgress{BytesSent: sent, BytesTotal: 9}, update.Progress)
+	}
+	update := <-updates
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.Equal(t, hash.String(), update.DXHash)
+	assert.Equal(t, &core.OperationProgress{BytesSent: 9, BytesTotal: 9}, update.Progress)
+
+	reader, err := b.DownloadBlob(context.Background(), "received/peerA/nodeA/ns1/"+dataID.String())
+	assert.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	reader.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "some data", string(content))
+}
+
+func TestTransferBlobResume(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+	a.chunkSize = 4
+
+	dataID := fftypes.NewUUID()
+	payloadRef, hash, _, err := a.UploadBlob(context.Background(), "ns1", *dataID, strings.NewReader("some data"))
+	assert.NoError(t, err)
+
+	// An earlier attempt was interrupted after the first chunk
+	receivedRef := "received/peerA/nodeA/ns1/" + dataID.String()
+	_, err = b.blobs.appendChunk(context.Background(), receivedRef, 0, strings.NewReader("some"))
+	assert.NoError(t, err)
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.Anything).Run(func(args mock.Arguments) {
+		args[1].(dataexchange.DXEvent).Ack()
+	}).Return(nil)
+
+	err = a.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, payloadRef)
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, &core.OperationProgress{BytesSent: 8, BytesTotal: 9}, update.Progress)
+	update = <-updates
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.Equal(t, hash.String(), update.DXHash)
+}
+
+func TestTransferBlobRestartDifferentBlob(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+
+	dataID := fftypes.NewUUID()
+	payloadRef, hash, _, err := a.UploadBlob(context.Background(), "ns1", *dataID, strings.NewReader("some data"))
+	assert.NoError(t, err)
+
+	// The peer has a complete blob under the same ID that is not the one we are sending
+	receivedRef := "received/peerA/nodeA/ns1/" + dataID.String()
+	_, err = b.blobs.appendChunk(context.Background(), receivedRef, 0, strings.NewReader("other"))
+	assert.NoError(t, err)
+	_, _, err = b.blobs.complete(context.Background(), receivedRef)
+	assert.NoError(t, err)
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.Anything).Run(func(args mock.Arguments) {
+		args[1].(dataexchange.DXEvent).Ack()
+	}).Return(nil)
+
+	err = a.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, payloadRef)
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.Equal(t, hash.String(), update.DXHash)
+}
+
+func TestTransferBlobRedeliverAfterAckTimeout(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+	b.ackTimeout = 50 * time.Millisecond
+
+	dataID := fftypes.NewUUID()
+	payloadRef, hash, _, err := a.UploadBlob(context.Background(), "ns1", *dataID, strings.NewReader("some data"))
+	assert.NoError(t, err)
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	// The first delivery is never acked, so the sender retries with the blob the peer already has
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.Anything).Return(nil).Once()
+	mcb.On("DXEvent", b, mock.Anything).Run(func(args mock.Arguments) {
+		assert.Equal(t, *hash, args[1].(dataexchange.DXEvent).PrivateBlobReceived().Hash)
+		args[1].(dataexchange.DXEvent).Ack()
+	}).Return(nil)
+
+	err = a.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, payloadRef)
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.Equal(t, hash.String(), update.DXHash)
+}
+
+func TestTransferBlobRetriesExhausted(t *testing.T) {
+	a, b, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+	b.ackTimeout = 10 * time.Millisecond
+
+	dataID := fftypes.NewUUID()
+	payloadRef, _, _, err := a.UploadBlob(context.Background(), "ns1", *dataID, strings.NewReader("some data"))
+	assert.NoError(t, err)
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	b.SetHandler("ns1", "nodeB", mcb)
+	mcb.On("DXEvent", b, mock.Anything).Return(nil)
+
+	err = a.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, payloadRef)
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10560", update.ErrorMessage)
+}
+
+func TestReceiveBlobInvalidChunk(t *testing.T) {
+	a, b, _, _, done := newTestPeers(t)
+	defer done()
+
+	p, err := a.getPeer(context.Background(), "peerB/nodeB")
+	assert.NoError(t, err)
+	for _, headers := range []map[string]string{
+		{headerOffset: "bad"},
+		{headerOffset: "1", headerTotal: "bad"},
+		{headerOffset: "10", headerTotal: "5"},
+		{headerOffset: "5"},
+		{headerOffset: "-1", headerTotal: "5"},
+	} {
+		res, err := p.client.R().
+			SetHeader(headerSender, "peerA/nodeA").
+			SetHeaders(headers).
+			SetBody([]byte("data")).
+			Put("/api/v1/blobs/ns1/id1")
+		assert.NoError(t, err)
+		assert.Equal(t, 400, res.StatusCode())
+		assert.Regexp(t, "FF10561", string(res.Body()))
+	}
+
+	// A chunk that does not continue from what was received is rejected
+	res, err := p.client.R().
+		SetHeader(headerSender, "peerA/nodeA").
+		SetHeaders(map[string]string{headerOffset: "4", headerTotal: "9"}).
+		SetBody([]byte("data")).
+		Put("/api/v1/blobs/ns1/id1")
+	assert.NoError(t, err)
+	assert.Equal(t, 409, res.StatusCode())
+	assert.Regexp(t, "FF10562", string(res.Body()))
+
+	received, complete, err := b.blobs.progress(context.Background(), receivedBlobRef("peerA/nodeA", "ns1", "id1"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), received)
+	assert.False(t, complete)
+}
+
+func TestBlobProgressNotPeer(t *testing.T) {
+	a, _, _, _, done := newTestPeers(t)
+	defer done()
+
+	p, err := a.getPeer(context.Background(), "peerB/nodeB")
+	assert.NoError(t, err)
+	res, err := p.client.R().
+		SetHeader(headerSender, "peerB/nodeB").
+		Get("/api/v1/blobs/ns1/id1/progress")
+	assert.NoError(t, err)
+	assert.Equal(t, 403, res.StatusCode())
+}
+
+func TestTransferBlobMissing(t *testing.T) {
+	a, _, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+
+	ocb := &coremocks.OperationCallbacks{}
+	a.SetOperationHandler("ns1", ocb)
+	updates := opUpdates(ocb)
+
+	err := a.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, "local/ns1/missing")
+	assert.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+}
+
+func TestTransferBlobBadRef(t *testing.T) {
+	a, _, aPeer, bPeer, done := newTestPeers(t)
+	defer done()
+
+	err := a.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), bPeer, aPeer, "local/../id1")
+	assert.Regexp(t, "FF10558", err)
+
+	err = a.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), fftypes.JSONObject{"id": "unknown"}, aPeer, "local/ns1/id1")
+	assert.Regexp(t, "FF10556", err)
+}
+
+func TestTransferMetrics(t *testing.T) {
+	mmm := metricsmocks.NewManager(t)
+	mmm.On("IsMetricsEnabled").Return(true)
+	nsOpID := "ns1:" + fftypes.NewUUID().String()
+	mmm.On("DXTransferSubmitted", nsOpID).Return()
+	mmm.On("DXTransferCompleted", nsOpID, "blob", core.OpStatusSucceeded).Return()
+	h := &P2PDX{metrics: mmm}
+
+	h.transferSubmitted(nsOpID)
+	h.transferUpdated(nsOpID, "blob", core.OpStatusSucceeded)
+}
+
+func TestCheckNodeIdentityStatus(t *testing.T) {
+	h, done := newTestP2PDX(t, "peer1")
+	defer done()
+
+	mmm := metricsmocks.NewManager(t)
+	mmm.On("IsMetricsEnabled").Return(true)
+	mmm.On("NodeIdentityDXCertMismatch", "ns1", mock.Anything).Return()
+	mmm.On("NodeIdentityDXCertExpiry", "ns1", mock.Anything).Return()
+	h.metrics = mmm
+
+	err := h.CheckNodeIdentityStatus(context.Background(), nil)
+	assert.Regexp(t, "FF10481", err)
+
+	err = h.CheckNodeIdentityStatus(context.Background(), &core.Identity{
+		IdentityBase:    core.IdentityBase{Namespace: "ns1", Name: "node1"},
+		IdentityProfile: core.IdentityProfile{Profile: fftypes.JSONObject{"cert": h.certPEM}},
+	})
+	assert.NoError(t, err)
+
+	err = h.CheckNodeIdentityStatus(context.Background(), &core.Identity{
+		IdentityBase:    core.IdentityBase{Namespace: "ns1", Name: "node1"},
+		IdentityProfile: core.IdentityProfile{Profile: fftypes.JSONObject{"cert": "other"}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestPeerIDFromCertFingerprint(t *testing.T) {
+	h, done := newTestP2PDX(t, "peer1")
+	defer done()
+
+	h.cert.Leaf.Subject.CommonName = ""
+	assert.Len(t, peerIDFromCert(h.cert), 64)
+}
+
+func TestDXEventAckOnce(t *testing.T) {
+	e := &dxEvent{id: "1", acked: make(chan string, 1)}
+	e.Ack()
+	e.AckWithManifest("ignored")
+	assert.Equal(t, "", <-e.acked)
+	assert.Equal(t, "1", e.EventID())
+	assert.Nil(t, e.MessageReceived())
+	assert.Nil(t, e.PrivateBlobReceived())
+}
+
+func TestOperationUpdateNoHandler(t *testing.T) {
+	h := &P2PDX{}
+	h.callbacks = callbacks{plugin: h, opHandlers: map[string]core.OperationCallbacks{}}
+	h.callbacks.OperationUpdate(context.Background(), &core.OperationUpdateAsync{
+		OperationUpdate: core.OperationUpdate{NamespacedOpID: "ns1:" + fftypes.NewUUID().String()},
+	})
+}
+
+func TestSetHandlers(t *testing.T) {
+	h, done := newTestP2PDX(t, "peer1")
+	defer done()
+
+	h.SetHandler("ns1", "node1", &dataexchangemocks.Callbacks{})
+	h.SetHandler("ns1", "node1", nil)
+	assert.Empty(t, h.callbacks.handlers)
+	h.SetOperationHandler("ns1", &coremocks.OperationCallbacks{})
+	h.SetOperationHandler("ns1", nil)
+	assert.Empty(t, h.callbacks.opHandlers)
+}
@@ -0,0 +1,230 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2pdx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+)
+
+const (
+	headerSender    = "X-FireFly-Sender"
+	headerRecipient = "X-FireFly-Recipient"
+	headerOffset    = "X-FireFly-Offset"
+	headerTotal     = "X-FireFly-Total"
+
+	// maxMessageSize bounds the batches we will read from a peer, well above any configured batch payload limit
+	maxMessageSize = 32 * 1024 * 1024
+)
+
+type wireMessage struct {
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	Message   string `json:"message"`
+}
+
+type wireResult struct {
+	Hash     string `json:"hash,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Manifest string `json:"manifest,omitempty"`
+}
+
+type wireProgress struct {
+	Received int64 `json:"received"`
+	Complete bool  `json:"complete,omitempty"`
+}
+
+type wireError struct {
+	Error string `json:"error"`
+}
+
+func (h *P2PDX) router() *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/api/v1/messages", h.receiveMessage).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/blobs/{ns}/{id}", h.receiveBlob).Methods(http.MethodPut)
+	r.HandleFunc("/api/v1/blobs/{ns}/{id}/progress", h.blobProgress).Methods(http.MethodGet)
+	return r
+}
+
+func (h *P2PDX) receiveMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var msg wireMessage
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxMessageSize)).Decode(&msg); err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.verifySender(ctx, r, msg.Sender); err != nil {
+		h.writeError(ctx, w, http.StatusForbidden, err)
+		return
+	}
+
+	// De-serialize the transport wrapper
+	var wrapper *core.TransportWrapper
+	err := json.Unmarshal([]byte(msg.Message), &wrapper)
+	switch {
+	case err != nil:
+		err = fmt.Errorf("invalid transmission from peer '%s': %s", msg.Sender, err)
+	case wrapper == nil || (wrapper.Batch == nil && wrapper.Ack == nil):
+		err = fmt.Errorf("invalid transmission from peer '%s': nil batch", msg.Sender)
+	}
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	e := &dxEvent{
+		id:     fftypes.NewUUID().String(),
+		dxType: dataexchange.DXEventTypeMessageReceived,
+		messageReceived: &dataexchange.MessageReceived{
+			PeerID:    msg.Sender,
+			Transport: wrapper,
+		},
+		acked: make(chan string, 1),
+	}
+	h.respond(ctx, w, wrapper.Namespace(), msg.Recipient, e, &wireResult{})
+}
+
+// receiveBlob stores a chunk of a blob sent by a peer. Chunks that leave more to come are acknowledged
+// straight away, and the final chunk is answered once the complete blob has been processed. A peer that
+// does not send the chunk headers sends the whole blob in one request.
+func (h *P2PDX) receiveBlob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sender := r.Header.Get(headerSender)
+	if err := h.verifySender(ctx, r, sender); err != nil {
+		h.writeError(ctx, w, http.StatusForbidden, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+	namespace, dataID := vars["ns"], vars["id"]
+	payloadRef := receivedBlobRef(sender, namespace, dataID)
+	if _, err := h.blobs.path(ctx, payloadRef); err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+	offset, total, err := chunkRange(ctx, r)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+	content := io.Reader(r.Body)
+	if total >= 0 {
+		content = io.LimitReader(r.Body, total-offset)
+	}
+	received, err := h.blobs.appendChunk(ctx, payloadRef, offset, content)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if received >= 0 {
+			status = http.StatusConflict
+		}
+		h.writeError(ctx, w, status, err)
+		return
+	}
+	if total >= 0 && received < total {
+		h.writeJSON(w, http.StatusAccepted, &wireProgress{Received: received})
+		return
+	}
+
+	hash, size, err := h.blobs.complete(ctx, payloadRef)
+	if err != nil {
+		h.writeError(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+	e := &dxEvent{
+		id:     fftypes.NewUUID().String(),
+		dxType: dataexchange.DXEventTypePrivateBlobReceived,
+		privateBlobReceived: &dataexchange.PrivateBlobReceived{
+			Namespace:  namespace,
+			PeerID:     sender,
+			Hash:       *hash,
+			Size:       size,
+			PayloadRef: payloadRef,
+			DataID:     dataID,
+		},
+		acked: make(chan string, 1),
+	}
+	h.respond(ctx, w, namespace, r.Header.Get(headerRecipient), e, &wireResult{Hash: hash.String(), Size: size})
+}
+
+// blobProgress tells a peer how much of a blob we have, so it can resume an interrupted transfer
+func (h *P2PDX) blobProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sender := r.Header.Get(headerSender)
+	if err := h.verifySender(ctx, r, sender); err != nil {
+		h.writeError(ctx, w, http.StatusForbidden, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+	received, complete, err := h.blobs.progress(ctx, receivedBlobRef(sender, vars["ns"], vars["id"]))
+	if err != nil {
+		h.writeError(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, &wireProgress{Received: received, Complete: complete})
+}
+
+// chunkRange reads the offset of the chunk in the request, and the total size of the blob
+// it belongs to. The total is -1 if the request carries the whole blob.
+func chunkRange(ctx context.Context, r *http.Request) (offset, total int64, err error) {
+	offsetStr, totalStr := r.Header.Get(headerOffset), r.Header.Get(headerTotal)
+	total = -1
+	if offsetStr != "" {
+		offset, err = strconv.ParseInt(offsetStr, 10, 64)
+	}
+	if err == nil && totalStr != "" {
+		total, err = strconv.ParseInt(totalStr, 10, 64)
+	}
+	if err != nil || offset < 0 || (total >= 0 && offset > total) || (total < 0 && offset > 0) {
+		return -1, -1, i18n.NewError(ctx, coremsgs.MsgP2PDXInvalidChunk, offsetStr, totalStr)
+	}
+	return offset, total, nil
+}
+
+// respond only replies to the peer once the event has been processed, so it knows the transfer is complete
+func (h *P2PDX) respond(ctx context.Context, w http.ResponseWriter, namespace, recipient string, e *dxEvent, result *wireResult) {
+	manifest, ok := h.deliver(ctx, namespace, recipient, e)
+	if !ok {
+		h.writeError(ctx, w, http.StatusServiceUnavailable, i18n.NewError(ctx, coremsgs.MsgP2PDXAckTimeout, e.id))
+		return
+	}
+	result.Manifest = manifest
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+func (h *P2PDX) writeError(ctx context.Context, w http.ResponseWriter, status int, err error) {
+	log.L(ctx).Warnf("Rejected request from peer: %s", err)
+	h.writeJSON(w, status, &wireError{Error: err.Error()})
+}
+
+func (h *P2PDX) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,120 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package p2pdx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// sendBlob sends a blob to a peer in chunks. The peer acknowledges each chunk once it is stored, and we
+// report the progress on the operation. If the transfer is interrupted, we ask the peer how much it has
+// and resume from there - rather than sending the whole blob again.
+func (h *P2PDX) sendBlob(ctx context.Context, nsOpID string, p *dxPeer, senderID, payloadRef string) (*wireResult, error) {
+	f, err := h.blobs.open(ctx, payloadRef)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	total := info.Size()
+	_, ns, id := splitBlobRef(payloadRef)
+	blobPath := fmt.Sprintf("/api/v1/blobs/%s/%s", ns, id)
+
+	var result *wireResult
+	err = h.transferRetry.Do(ctx, "p2pdx blob transfer", func(attempt int) (retry bool, err error) {
+		offset, err := h.remoteProgress(ctx, p, senderID, blobPath, total)
+		if err == nil {
+			if offset > 0 {
+				log.L(ctx).Infof("Resuming blob transfer for operation '%s' at %d/%d bytes", nsOpID, offset, total)
+			}
+			result, err = h.sendChunks(ctx, nsOpID, p, senderID, blobPath, f, offset, total)
+		}
+		return attempt < h.transferTries, err
+	})
+	return result, err
+}
+
+// remoteProgress asks the peer how much of the blob it has already received
+func (h *P2PDX) remoteProgress(ctx context.Context, p *dxPeer, senderID, blobPath string, total int64) (int64, error) {
+	var progress wireProgress
+	res, err := p.client.R().SetContext(ctx).
+		SetHeader(headerSender, senderID).
+		SetResult(&progress).
+		Get(blobPath + "/progress")
+	if err != nil || !res.IsSuccess() {
+		return -1, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
+	}
+	if progress.Received > total || (progress.Complete && progress.Received != total) {
+		// Whatever the peer has is not this blob, so start again
+		return 0, nil
+	}
+	return progress.Received, nil
+}
+
+func (h *P2PDX) sendChunks(ctx context.Context, nsOpID string, p *dxPeer, senderID, blobPath string, f *os.File, offset, total int64) (*wireResult, error) {
+	for {
+		length := total - offset
+		if h.chunkSize > 0 && length > h.chunkSize {
+			length = h.chunkSize
+		}
+		var result wireResult
+		res, err := p.client.R().SetContext(ctx).
+			SetHeader(headerSender, senderID).
+			SetHeader(headerRecipient, p.id).
+			SetHeader(headerOffset, strconv.FormatInt(offset, 10)).
+			SetHeader(headerTotal, strconv.FormatInt(total, 10)).
+			SetHeader("Content-Type", "application/octet-stream").
+			SetBody(io.NewSectionReader(f, offset, length)).
+			SetResult(&result).
+			Put(blobPath)
+		if err != nil || !res.IsSuccess() {
+			return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
+		}
+		offset += length
+		if res.StatusCode() != http.StatusAccepted {
+			// The last chunk is only answered once the peer has processed the whole blob
+			return &result, nil
+		}
+		h.transferProgress(nsOpID, offset, total)
+	}
+}
+
+func (h *P2PDX) transferProgress(nsOpID string, sent, total int64) {
+	h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdateAsync{
+		OperationUpdate: core.OperationUpdate{
+			Plugin:         h.Name(),
+			NamespacedOpID: nsOpID,
+			Progress: &core.OperationProgress{
+				BytesSent:  sent,
+				BytesTotal: total,
+			},
+		},
+	})
+}
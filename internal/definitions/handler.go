@@ -29,10 +29,12 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/multiparty"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/hyperledger/firefly/pkg/zkproof"
 )
 
 type Handler interface {
@@ -55,12 +57,20 @@ type definitionHandler struct {
 	assets     assets.Manager
 	contracts  contracts.Manager // optional
 	tokenNames map[string]string // mapping of token connector remote name => name
+	custom     map[string]core.CustomDefinitionHandler
+	approvals  *ApprovalPolicy
+	mpManager  multiparty.Manager // optional
+	zkproof    zkproof.Plugin     // optional
 }
 
-func newDefinitionHandler(ctx context.Context, ns *core.Namespace, multiparty bool, di database.Plugin, bi blockchain.Plugin, dx dataexchange.Plugin, dm data.Manager, im identity.Manager, am assets.Manager, cm contracts.Manager, tokenNames map[string]string) (*definitionHandler, error) {
+func newDefinitionHandler(ctx context.Context, ns *core.Namespace, multiparty bool, di database.Plugin, bi blockchain.Plugin, dx dataexchange.Plugin, dm data.Manager, im identity.Manager, am assets.Manager, cm contracts.Manager, tokenNames map[string]string, approvals *ApprovalPolicy, mm multiparty.Manager, zk zkproof.Plugin) (*definitionHandler, error) {
 	if di == nil || dm == nil || im == nil || am == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "DefinitionHandler")
 	}
+	custom, err := newCustomHandlers(ctx, ns, di)
+	if err != nil {
+		return nil, err
+	}
 	return &definitionHandler{
 		namespace:  ns,
 		multiparty: multiparty,
@@ -72,12 +82,25 @@ func newDefinitionHandler(ctx context.Context, ns *core.Namespace, multiparty bo
 		assets:     am,
 		contracts:  cm,
 		tokenNames: tokenNames,
+		custom:     custom,
+		approvals:  approvals,
+		mpManager:  mm,
+		zkproof:    zk,
 	}, nil
 }
 
 func (dh *definitionHandler) HandleDefinitionBroadcast(ctx context.Context, state *core.BatchState, msg *core.Message, data core.DataArray, tx *fftypes.UUID) (msgAction HandlerResult, err error) {
 	l := log.L(ctx)
 	l.Infof("Processing system definition '%s' [%s]", msg.Header.Tag, msg.Header.ID)
+	if dh.requiresApproval(msg.Header.Tag) {
+		approved, err := dh.checkDefinitionApproved(ctx, msg)
+		if err != nil {
+			return HandlerResult{Action: core.ActionRetry}, err
+		}
+		if !approved {
+			return HandlerResult{Action: core.ActionWait}, nil
+		}
+	}
 	switch msg.Header.Tag {
 	case core.SystemTagDefineDatatype:
 		return dh.handleDatatypeBroadcast(ctx, state, msg, data, tx)
@@ -97,7 +120,18 @@ func (dh *definitionHandler) HandleDefinitionBroadcast(ctx context.Context, stat
 		return dh.handleFFIBroadcast(ctx, state, msg, data, tx)
 	case core.SystemTagDefineContractAPI:
 		return dh.handleContractAPIBroadcast(ctx, state, msg, data, tx)
+	case core.SystemTagApproveDefinition:
+		return dh.handleDefinitionApprovalBroadcast(ctx, state, msg, data)
+	case core.SystemTagProposeContractMigration:
+		return dh.handleContractMigrationProposalBroadcast(ctx, msg, data)
+	case core.SystemTagAckContractMigration:
+		return dh.handleContractMigrationAckBroadcast(ctx, msg, data)
+	case core.SystemTagDefineDisclosure:
+		return dh.handleDisclosureBroadcast(ctx, state, msg, data, tx)
 	default:
+		if handler, ok := dh.custom[msg.Header.Tag]; ok {
+			return dh.handleCustomDefinitionBroadcast(ctx, handler, state, msg, data, tx)
+		}
 		return HandlerResult{Action: core.ActionReject}, fmt.Errorf("unknown system tag '%s' for definition ID '%s'", msg.Header.Tag, msg.Header.ID)
 	}
 }
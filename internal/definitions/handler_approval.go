@@ -0,0 +1,123 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// ApprovalPolicy configures M-of-N governance approval of selected definition types. A definition broadcast with
+// one of the tags is held in pending_approval (blocking later definitions) until the required number of distinct
+// approvers have broadcast an approval of that exact message. The policy must be identical on every member.
+type ApprovalPolicy struct {
+	Required  int
+	Tags      []string
+	Approvers []string
+}
+
+func (dh *definitionHandler) requiresApproval(tag string) bool {
+	if !dh.multiparty || dh.approvals == nil || dh.approvals.Required <= 0 {
+		return false
+	}
+	for _, t := range dh.approvals.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (dh *definitionHandler) isApprover(did string) bool {
+	if dh.approvals == nil || len(dh.approvals.Approvers) == 0 {
+		return true
+	}
+	for _, approver := range dh.approvals.Approvers {
+		if approver == did {
+			return true
+		}
+	}
+	return false
+}
+
+// getDefinitionApprovers returns the distinct authors of the confirmed approvals for a definition, ignoring
+// any approvals that do not match the hash of the definition message
+func (dh *definitionHandler) getDefinitionApprovers(ctx context.Context, msg *core.Message) ([]string, error) {
+	fb := database.MessageQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("cid", msg.Header.ID),
+		fb.Eq("tag", core.SystemTagApproveDefinition),
+		fb.Eq("state", core.MessageStateConfirmed),
+	).Sort("sequence")
+	approvalMsgs, _, err := dh.database.GetMessages(ctx, dh.namespace.Name, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers := make([]string, 0, len(approvalMsgs))
+	counted := make(map[string]bool)
+	for _, approvalMsg := range approvalMsgs {
+		author := approvalMsg.Header.Author
+		if counted[author] || !dh.isApprover(author) {
+			continue
+		}
+		data, foundAll, err := dh.data.GetMessageDataCached(ctx, approvalMsg)
+		if err != nil {
+			return nil, err
+		}
+		var approval core.DefinitionApproval
+		if !foundAll || !dh.getSystemBroadcastPayload(ctx, approvalMsg, data, &approval) {
+			continue
+		}
+		if !approval.Hash.Equals(msg.Hash) {
+			log.L(ctx).Warnf("Ignoring approval '%s' of definition '%s' - hash mismatch: %s != %s", approvalMsg.Header.ID, msg.Header.ID, approval.Hash, msg.Hash)
+			continue
+		}
+		counted[author] = true
+		approvers = append(approvers, author)
+	}
+	return approvers, nil
+}
+
+func (dh *definitionHandler) checkDefinitionApproved(ctx context.Context, msg *core.Message) (bool, error) {
+	approvers, err := dh.getDefinitionApprovers(ctx, msg)
+	if err != nil {
+		return false, err
+	}
+	log.L(ctx).Infof("Definition '%s' [%s] has %d of %d required approvals", msg.Header.Tag, msg.Header.ID, len(approvers), dh.approvals.Required)
+	return len(approvers) >= dh.approvals.Required, nil
+}
+
+func (dh *definitionHandler) handleDefinitionApprovalBroadcast(ctx context.Context, state *core.BatchState, msg *core.Message, data core.DataArray) (HandlerResult, error) {
+	var approval core.DefinitionApproval
+	valid := dh.getSystemBroadcastPayload(ctx, msg, data, &approval)
+	if !valid || approval.Message == nil || approval.Hash == nil || !approval.Message.Equals(msg.Header.CID) {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedBadPayload, "definition approval", msg.Header.ID)
+	}
+	if !dh.isApprover(msg.Header.Author) {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedWrongAuthor, "definition approval", msg.Header.ID, msg.Header.Author)
+	}
+
+	// Once this batch is committed, the definition is re-processed to count the approvals
+	state.AddApprovedDefinition(approval.Message)
+	return HandlerResult{Action: core.ActionConfirm, CustomCorrelator: approval.Message}, nil
+}
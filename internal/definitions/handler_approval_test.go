@@ -0,0 +1,200 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestGovernedDefinition() *core.Message {
+	return &core.Message{
+		Header: core.MessageHeader{
+			ID:   fftypes.NewUUID(),
+			Type: core.MessageTypeDefinition,
+			Tag:  "ff_define_widget",
+		},
+		Hash:  fftypes.NewRandB32(),
+		State: core.MessageStatePending,
+	}
+}
+
+func newTestDefinitionApproval(author string, def *core.Message, hash *fftypes.Bytes32) (*core.Message, core.DataArray) {
+	b, _ := json.Marshal(&core.DefinitionApproval{
+		Message: def.Header.ID,
+		Hash:    hash,
+	})
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:   fftypes.NewUUID(),
+			CID:  def.Header.ID,
+			Type: core.MessageTypeDefinition,
+			Tag:  core.SystemTagApproveDefinition,
+			SignerRef: core.SignerRef{
+				Author: author,
+			},
+		},
+		State: core.MessageStateConfirmed,
+	}
+	return msg, core.DataArray{{Value: fftypes.JSONAnyPtrBytes(b)}}
+}
+
+func enableTestApprovals(dh *testDefinitionHandler, approvers ...string) *coremocks.CustomDefinitionHandler {
+	mch := &coremocks.CustomDefinitionHandler{}
+	dh.multiparty = true
+	dh.custom = map[string]core.CustomDefinitionHandler{"ff_define_widget": mch}
+	dh.approvals = &ApprovalPolicy{
+		Required:  2,
+		Tags:      []string{"ff_define_widget"},
+		Approvers: approvers,
+	}
+	return mch
+}
+
+func TestHandleDefinitionPendingApproval(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+	enableTestApprovals(dh)
+
+	def := newTestGovernedDefinition()
+	approval1, data1 := newTestDefinitionApproval("did:firefly:org/org1", def, def.Hash)
+	dh.mdi.On("GetMessages", context.Background(), "ns1", mock.Anything).Return([]*core.Message{approval1}, nil, nil)
+	dh.mdm.On("GetMessageDataCached", context.Background(), approval1).Return(data1, true, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, def, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerResult{Action: core.ActionWait}, action)
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionApproved(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+	mch := enableTestApprovals(dh, "did:firefly:org/org1", "did:firefly:org/org2")
+
+	def := newTestGovernedDefinition()
+	approval1, data1 := newTestDefinitionApproval("did:firefly:org/org1", def, def.Hash)
+	approval1Dup, _ := newTestDefinitionApproval("did:firefly:org/org1", def, def.Hash)
+	approvalOther, _ := newTestDefinitionApproval("did:firefly:org/org3", def, def.Hash)
+	approvalWrongHash, dataWrongHash := newTestDefinitionApproval("did:firefly:org/org2", def, fftypes.NewRandB32())
+	approvalMissingData, _ := newTestDefinitionApproval("did:firefly:org/org2", def, def.Hash)
+	approval2, data2 := newTestDefinitionApproval("did:firefly:org/org2", def, def.Hash)
+	dh.mdi.On("GetMessages", context.Background(), "ns1", mock.Anything).Return([]*core.Message{
+		approval1, approval1Dup, approvalOther, approvalWrongHash, approvalMissingData, approval2,
+	}, nil, nil)
+	dh.mdm.On("GetMessageDataCached", context.Background(), approval1).Return(data1, true, nil)
+	dh.mdm.On("GetMessageDataCached", context.Background(), approvalWrongHash).Return(dataWrongHash, true, nil)
+	dh.mdm.On("GetMessageDataCached", context.Background(), approvalMissingData).Return(nil, false, nil)
+	dh.mdm.On("GetMessageDataCached", context.Background(), approval2).Return(data2, true, nil)
+	mch.On("HandleDefinition", context.Background(), &bs.BatchState, def, mock.Anything, mock.Anything).Return(core.ActionConfirm, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, def, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+
+	mch.AssertExpectations(t)
+}
+
+func TestHandleDefinitionApprovalQueryFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+	enableTestApprovals(dh)
+
+	def := newTestGovernedDefinition()
+	dh.mdi.On("GetMessages", context.Background(), "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, def, nil, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+}
+
+func TestHandleDefinitionApprovalDataFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+	enableTestApprovals(dh)
+
+	def := newTestGovernedDefinition()
+	approval1, _ := newTestDefinitionApproval("did:firefly:org/org1", def, def.Hash)
+	dh.mdi.On("GetMessages", context.Background(), "ns1", mock.Anything).Return([]*core.Message{approval1}, nil, nil)
+	dh.mdm.On("GetMessageDataCached", context.Background(), approval1).Return(nil, false, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, def, nil, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+}
+
+func TestHandleDefinitionApprovalBroadcastOk(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+	enableTestApprovals(dh, "did:firefly:org/org1")
+
+	def := newTestGovernedDefinition()
+	approval, data := newTestDefinitionApproval("did:firefly:org/org1", def, def.Hash)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, approval, data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm, CustomCorrelator: def.Header.ID}, action)
+	assert.Equal(t, []*fftypes.UUID{def.Header.ID}, bs.ApprovedDefinitions)
+}
+
+func TestHandleDefinitionApprovalBroadcastBadPayload(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+	enableTestApprovals(dh)
+
+	def := newTestGovernedDefinition()
+	approval, data := newTestDefinitionApproval("did:firefly:org/org1", def, def.Hash)
+	approval.Header.CID = fftypes.NewUUID()
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, approval, data, nil)
+	assert.Regexp(t, "FF10400", err)
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Empty(t, bs.ApprovedDefinitions)
+}
+
+func TestHandleDefinitionApprovalBroadcastNotApprover(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+	enableTestApprovals(dh, "did:firefly:org/org1")
+
+	def := newTestGovernedDefinition()
+	approval, data := newTestDefinitionApproval("did:firefly:org/org2", def, def.Hash)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, approval, data, nil)
+	assert.Regexp(t, "FF10409", err)
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Empty(t, bs.ApprovedDefinitions)
+}
+
+func TestRequiresApprovalDisabled(t *testing.T) {
+	dh, _ := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	assert.False(t, dh.requiresApproval(core.SystemTagDefineDatatype))
+	dh.multiparty = true
+	dh.approvals = &ApprovalPolicy{Required: 1, Tags: []string{core.SystemTagDefineContractAPI}}
+	assert.False(t, dh.requiresApproval(core.SystemTagDefineDatatype))
+	assert.True(t, dh.requiresApproval(core.SystemTagDefineContractAPI))
+}
@@ -239,6 +239,16 @@ func (dh *definitionHandler) handleContractAPIDefinition(ctx context.Context, st
 	}
 
 	l.Infof("Contract API created id=%s", api.ID)
+	if len(api.Listeners) > 0 {
+		// Listener templates are instantiated outside of the DB group, as they call the blockchain connector
+		state.AddPreFinalize(func(ctx context.Context) error {
+			if err := dh.contracts.ReconcileContractAPIListeners(ctx, api); err != nil {
+				log.L(ctx).Errorf("Failed to create listeners for contract API '%s': %s", api.ID, err)
+				return err
+			}
+			return nil
+		})
+	}
 	state.AddFinalize(func(ctx context.Context) error {
 		event := core.NewEvent(core.EventTypeContractAPIConfirmed, api.Namespace, api.ID, tx, core.SystemTopicDefinitions)
 		return dh.database.InsertEvent(ctx, event)
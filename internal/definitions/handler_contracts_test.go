@@ -457,6 +457,69 @@ func TestHandleContractAPIBroadcastOk(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestHandleContractAPIBroadcastListenerTemplates(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	api := testContractAPI()
+	api.Location = fftypes.JSONAnyPtr(`{"address":"0x12345"}`)
+	api.Listeners = core.ContractAPIListenerTemplates{{EventPath: "Changed"}}
+	b, err := json.Marshal(api)
+	assert.NoError(t, err)
+	data := &core.Data{
+		Value: fftypes.JSONAnyPtrBytes(b),
+	}
+
+	dh.mdi.On("InsertOrGetContractAPI", mock.Anything, mock.Anything).Return(nil, nil)
+	dh.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+	dh.mcm.On("ResolveContractAPI", context.Background(), "", mock.Anything).Return(nil)
+	dh.mcm.On("ReconcileContractAPIListeners", context.Background(), mock.MatchedBy(func(a *core.ContractAPI) bool {
+		return len(a.Listeners) == 1 && a.Listeners[0].EventPath == "Changed"
+	})).Return(nil)
+	dh.mim.On("GetRootOrgDID", context.Background()).Return("firefly:org1", nil)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, &core.Message{
+		Header: core.MessageHeader{
+			Tag: core.SystemTagDefineContractAPI,
+		},
+	}, core.DataArray{data}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+	err = bs.RunPreFinalize(context.Background())
+	assert.NoError(t, err)
+	err = bs.RunFinalize(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestHandleContractAPIBroadcastListenerTemplatesFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	api := testContractAPI()
+	api.Location = fftypes.JSONAnyPtr(`{"address":"0x12345"}`)
+	api.Listeners = core.ContractAPIListenerTemplates{{EventPath: "Changed"}}
+	b, err := json.Marshal(api)
+	assert.NoError(t, err)
+	data := &core.Data{
+		Value: fftypes.JSONAnyPtrBytes(b),
+	}
+
+	dh.mdi.On("InsertOrGetContractAPI", mock.Anything, mock.Anything).Return(nil, nil)
+	dh.mcm.On("ResolveContractAPI", context.Background(), "", mock.Anything).Return(nil)
+	dh.mcm.On("ReconcileContractAPIListeners", context.Background(), mock.Anything).Return(fmt.Errorf("pop"))
+	dh.mim.On("GetRootOrgDID", context.Background()).Return("firefly:org1", nil)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, &core.Message{
+		Header: core.MessageHeader{
+			Tag: core.SystemTagDefineContractAPI,
+		},
+	}, core.DataArray{data}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+	err = bs.RunPreFinalize(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
 func TestHandleContractAPIBadPayload(t *testing.T) {
 	dh, bs := newTestDefinitionHandler(t)
 	defer dh.cleanup(t)
@@ -0,0 +1,89 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// CustomHandlerFactory creates the handler for a custom definition type, within a given namespace
+type CustomHandlerFactory func(ctx context.Context, ns *core.Namespace, di database.Plugin) (core.CustomDefinitionHandler, error)
+
+var (
+	customHandlersMux      sync.Mutex
+	customHandlerFactories = map[string]CustomHandlerFactory{}
+)
+
+var builtinDefinitionTags = map[string]bool{
+	core.SystemTagDefineDatatype:               true,
+	core.DeprecatedSystemTagDefineOrganization: true,
+	core.DeprecatedSystemTagDefineNode:         true,
+	core.SystemTagDefineGroup:                  true,
+	core.SystemTagDefinePool:                   true,
+	core.SystemTagDefineFFI:                    true,
+	core.SystemTagDefineContractAPI:            true,
+}
+
+// RegisterCustomHandler registers a custom definition type, identified by its tag. A handler is created
+// from the factory for every namespace initialized after registration, so this must be called before
+// the namespaces start (typically from an init function), and identically on every member of the network.
+func RegisterCustomHandler(ctx context.Context, tag string, factory CustomHandlerFactory) error {
+	if !strings.HasPrefix(tag, core.CustomDefinitionTagPrefix) || len(tag) == len(core.CustomDefinitionTagPrefix) {
+		return i18n.NewError(ctx, coremsgs.MsgCustomDefinitionTagInvalid, tag, core.CustomDefinitionTagPrefix)
+	}
+	if builtinDefinitionTags[tag] {
+		return i18n.NewError(ctx, coremsgs.MsgCustomDefinitionTagReserved, tag)
+	}
+	customHandlersMux.Lock()
+	defer customHandlersMux.Unlock()
+	if _, exists := customHandlerFactories[tag]; exists {
+		return i18n.NewError(ctx, coremsgs.MsgCustomDefinitionTagDuplicate, tag)
+	}
+	customHandlerFactories[tag] = factory
+	return nil
+}
+
+func newCustomHandlers(ctx context.Context, ns *core.Namespace, di database.Plugin) (map[string]core.CustomDefinitionHandler, error) {
+	customHandlersMux.Lock()
+	defer customHandlersMux.Unlock()
+	handlers := make(map[string]core.CustomDefinitionHandler, len(customHandlerFactories))
+	for tag, factory := range customHandlerFactories {
+		handler, err := factory(ctx, ns, di)
+		if err != nil {
+			return nil, err
+		}
+		handlers[tag] = handler
+	}
+	return handlers, nil
+}
+
+func (dh *definitionHandler) handleCustomDefinitionBroadcast(ctx context.Context, handler core.CustomDefinitionHandler, state *core.BatchState, msg *core.Message, data core.DataArray, tx *fftypes.UUID) (HandlerResult, error) {
+	action, err := handler.HandleDefinition(ctx, state, msg, data, tx)
+	if err != nil && action == core.ActionConfirm {
+		// A handler cannot confirm a definition while also reporting an error
+		action = core.ActionRetry
+	}
+	return HandlerResult{Action: action}, err
+}
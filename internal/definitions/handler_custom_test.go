@@ -0,0 +1,141 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/assetmocks"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/datamocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func registerTestCustomHandler(t *testing.T, tag string, handler core.CustomDefinitionHandler, err error) func() {
+	regErr := RegisterCustomHandler(context.Background(), tag, func(ctx context.Context, ns *core.Namespace, di database.Plugin) (core.CustomDefinitionHandler, error) {
+		return handler, err
+	})
+	assert.NoError(t, regErr)
+	return func() {
+		customHandlersMux.Lock()
+		defer customHandlersMux.Unlock()
+		delete(customHandlerFactories, tag)
+	}
+}
+
+func TestRegisterCustomHandlerBadTags(t *testing.T) {
+	factory := func(ctx context.Context, ns *core.Namespace, di database.Plugin) (core.CustomDefinitionHandler, error) {
+		return nil, nil
+	}
+	err := RegisterCustomHandler(context.Background(), "my_tag", factory)
+	assert.Regexp(t, "FF10483", err)
+	err = RegisterCustomHandler(context.Background(), core.CustomDefinitionTagPrefix, factory)
+	assert.Regexp(t, "FF10483", err)
+	err = RegisterCustomHandler(context.Background(), core.SystemTagDefineDatatype, factory)
+	assert.Regexp(t, "FF10484", err)
+}
+
+func TestRegisterCustomHandlerDuplicate(t *testing.T) {
+	mch := &coremocks.CustomDefinitionHandler{}
+	defer registerTestCustomHandler(t, "ff_define_widget", mch, nil)()
+
+	err := RegisterCustomHandler(context.Background(), "ff_define_widget", nil)
+	assert.Regexp(t, "FF10485", err)
+}
+
+func TestNewDefinitionHandlerCustomFactoryFail(t *testing.T) {
+	defer registerTestCustomHandler(t, "ff_define_widget", nil, fmt.Errorf("pop"))()
+
+	_, err := newDefinitionHandler(context.Background(), &core.Namespace{Name: "ns1"}, false, &databasemocks.Plugin{}, nil, nil, &datamocks.Manager{}, &identitymanagermocks.Manager{}, &assetmocks.Manager{}, nil, nil, nil, nil, nil)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestHandleCustomDefinitionConfirm(t *testing.T) {
+	mch := &coremocks.CustomDefinitionHandler{}
+	defer registerTestCustomHandler(t, "ff_define_widget", mch, nil)()
+
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:  fftypes.NewUUID(),
+			Tag: "ff_define_widget",
+		},
+	}
+	data := core.DataArray{{Value: fftypes.JSONAnyPtr(`{"widget":"a"}`)}}
+	tx := fftypes.NewUUID()
+	mch.On("HandleDefinition", context.Background(), &bs.BatchState, msg, data, tx).Return(core.ActionConfirm, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, tx)
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+
+	mch.AssertExpectations(t)
+}
+
+func TestHandleCustomDefinitionReject(t *testing.T) {
+	mch := &coremocks.CustomDefinitionHandler{}
+	defer registerTestCustomHandler(t, "ff_define_widget", mch, nil)()
+
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:  fftypes.NewUUID(),
+			Tag: "ff_define_widget",
+		},
+	}
+	mch.On("HandleDefinition", context.Background(), &bs.BatchState, msg, mock.Anything, mock.Anything).Return(core.ActionReject, fmt.Errorf("invalid widget"))
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, nil, nil)
+	assert.EqualError(t, err, "invalid widget")
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+
+	mch.AssertExpectations(t)
+}
+
+func TestHandleCustomDefinitionConfirmWithError(t *testing.T) {
+	mch := &coremocks.CustomDefinitionHandler{}
+	defer registerTestCustomHandler(t, "ff_define_widget", mch, nil)()
+
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:  fftypes.NewUUID(),
+			Tag: "ff_define_widget",
+		},
+	}
+	mch.On("HandleDefinition", context.Background(), &bs.BatchState, msg, mock.Anything, mock.Anything).Return(core.ActionConfirm, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, nil, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+
+	mch.AssertExpectations(t)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (dh *definitionHandler) handleDisclosureBroadcast(ctx context.Context, state *core.BatchState, msg *core.Message, data core.DataArray, tx *fftypes.UUID) (HandlerResult, error) {
+	var disclosure core.Disclosure
+	valid := dh.getSystemBroadcastPayload(ctx, msg, data, &disclosure)
+	if !valid || disclosure.ID == nil || disclosure.Message == nil || disclosure.MessageHash == nil || disclosure.Circuit == "" || disclosure.Proof.IsNil() {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedBadPayload, "disclosure", msg.Header.ID)
+	}
+	disclosure.Namespace = dh.namespace.Name
+	disclosure.Author = msg.Header.Author
+	disclosure.DefinitionMessage = msg.Header.ID
+	if disclosure.Created == nil {
+		disclosure.Created = fftypes.Now()
+	}
+
+	existing, err := dh.database.GetDisclosureByID(ctx, dh.namespace.Name, disclosure.ID)
+	if err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	} else if existing != nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedConflict, "disclosure", disclosure.ID, existing.ID)
+	}
+
+	// The definition is confirmed the same way by every member. Whether the proof verifies, and whether the hash
+	// matches the message, depend on the plugins and data of each node, so they are checked in the background
+	// after the batch commits (without holding up the aggregator) and only recorded as a local status.
+	disclosure.Status = core.DisclosureStatusPending
+
+	if err = dh.database.InsertDisclosure(ctx, &disclosure); err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	}
+
+	state.AddFinalize(func(ctx context.Context) error {
+		event := core.NewEvent(core.EventTypeDisclosureConfirmed, disclosure.Namespace, disclosure.ID, tx, core.SystemTopicDefinitions)
+		return dh.database.InsertEvent(ctx, event)
+	})
+	return HandlerResult{Action: core.ActionConfirm}, nil
+}
@@ -0,0 +1,158 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/zkproofmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestDisclosureBroadcast(t *testing.T, disclosure *core.Disclosure) (*core.Message, core.DataArray) {
+	b, err := json.Marshal(disclosure)
+	assert.NoError(t, err)
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:  fftypes.NewUUID(),
+			Tag: core.SystemTagDefineDisclosure,
+			SignerRef: core.SignerRef{
+				Author: "did:firefly:org/org1",
+			},
+		},
+	}
+	return msg, core.DataArray{{Value: fftypes.JSONAnyPtrBytes(b)}}
+}
+
+func newTestDisclosure() *core.Disclosure {
+	return &core.Disclosure{
+		ID:           fftypes.NewUUID(),
+		Message:      fftypes.NewUUID(),
+		MessageHash:  fftypes.NewRandB32(),
+		Circuit:      "range",
+		PublicInputs: fftypes.JSONAnyPtr(`{"limit":100}`),
+		Proof:        fftypes.JSONAnyPtr(`{"pi_a":["1"]}`),
+	}
+}
+
+func TestHandleDisclosureBroadcastPending(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	disclosure := newTestDisclosure()
+	msg, data := newTestDisclosureBroadcast(t, disclosure)
+	dh.mdi.On("GetDisclosureByID", context.Background(), "ns1", disclosure.ID).Return(nil, nil)
+	dh.mdi.On("InsertDisclosure", context.Background(), mock.MatchedBy(func(d *core.Disclosure) bool {
+		return d.Status == core.DisclosureStatusPending && d.Created != nil &&
+			d.Author == "did:firefly:org/org1" && d.DefinitionMessage.Equals(msg.Header.ID)
+	})).Return(nil)
+	dh.mdi.On("InsertEvent", context.Background(), mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeDisclosureConfirmed && e.Reference.Equals(disclosure.ID)
+	})).Return(nil)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+	err = bs.RunFinalize(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestHandleDisclosureBroadcastNotVerifiedInline(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+	mzk := &zkproofmocks.Plugin{}
+	dh.zkproof = mzk
+
+	// Neither the proof nor the local copy of the message affect the outcome
+	disclosure := newTestDisclosure()
+	msg, data := newTestDisclosureBroadcast(t, disclosure)
+	dh.mdi.On("GetDisclosureByID", context.Background(), "ns1", disclosure.ID).Return(nil, nil)
+	dh.mdi.On("InsertDisclosure", context.Background(), mock.MatchedBy(func(d *core.Disclosure) bool {
+		return d.Status == core.DisclosureStatusPending
+	})).Return(nil)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+	assert.Len(t, bs.Finalize, 1)
+
+	mzk.AssertNotCalled(t, "VerifyProof", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	dh.mdi.AssertNotCalled(t, "GetMessageByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleDisclosureBroadcastBadPayload(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	disclosure := newTestDisclosure()
+	disclosure.Proof = nil
+	msg, data := newTestDisclosureBroadcast(t, disclosure)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10400", err)
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDisclosureBroadcastLookupFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	disclosure := newTestDisclosure()
+	msg, data := newTestDisclosureBroadcast(t, disclosure)
+	dh.mdi.On("GetDisclosureByID", context.Background(), "ns1", disclosure.ID).Return(nil, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.EqualError(t, err, "pop")
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDisclosureBroadcastExisting(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	disclosure := newTestDisclosure()
+	msg, data := newTestDisclosureBroadcast(t, disclosure)
+	dh.mdi.On("GetDisclosureByID", context.Background(), "ns1", disclosure.ID).Return(&core.Disclosure{ID: disclosure.ID}, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10407", err)
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDisclosureBroadcastInsertFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	disclosure := newTestDisclosure()
+	msg, data := newTestDisclosureBroadcast(t, disclosure)
+	dh.mdi.On("GetDisclosureByID", context.Background(), "ns1", disclosure.ID).Return(nil, nil)
+	dh.mdi.On("InsertDisclosure", context.Background(), mock.Anything).Return(fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.EqualError(t, err, "pop")
+	bs.assertNoFinalizers()
+}
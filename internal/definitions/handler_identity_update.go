@@ -76,6 +76,7 @@ func (dh *definitionHandler) handleIdentityUpdate(ctx context.Context, state *co
 	}
 
 	// Update the profile
+	profileChanged := identity.Profile.String() != update.Updates.Profile.String()
 	identity.IdentityProfile = update.Updates
 	identity.Messages.Update = msg.ID
 	err = dh.database.UpsertIdentity(ctx, identity, database.UpsertOptimizationExisting)
@@ -89,6 +90,15 @@ func (dh *definitionHandler) handleIdentityUpdate(ctx context.Context, state *co
 	})
 
 	if dh.multiparty && identity.Type == core.IdentityTypeNode {
+		if profileChanged {
+			state.AddPreFinalize(
+				func(ctx context.Context) error {
+					// Push the new endpoint details to the data exchange, so sends do not go to a stale peer config.
+					// Treat these errors like database errors - and return for retry processing
+					return dh.exchange.UpdateNode(ctx, dh.namespace.NetworkName, identity.Name, identity.Profile)
+				})
+		}
+
 		nodeDID, err := dh.identity.GetLocalNodeDID(ctx)
 		if err != nil {
 			return HandlerResult{Action: core.ActionRetry}, err
@@ -342,3 +342,53 @@ func TestHandleDefinitionIdentityUpdateLocalNodeMisconfigured(t *testing.T) {
 	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
 	assert.Error(t, err)
 }
+
+func TestHandleDefinitionIdentityUpdateRemoteNodeProfile(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, node1, updateMsg, updateData, iu := testNodeIdentityUpdate(t)
+
+	dh.mim.On("VerifyIdentityChain", ctx, node1).Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupByID", ctx, node1.ID).Return(node1, nil)
+	dh.mdi.On("UpsertIdentity", ctx, mock.Anything, database.UpsertOptimizationExisting).Return(nil)
+	dh.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeIdentityUpdated
+	})).Return(nil)
+	dh.mim.On("GetLocalNodeDID", ctx).Return("did:firefly:node/other", nil)
+	dh.mdx.On("UpdateNode", ctx, "ns1", node1.Name, iu.Updates.Profile).Return(nil)
+
+	dh.multiparty = true
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, updateMsg, core.DataArray{updateData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+
+	err = bs.RunPreFinalize(ctx)
+	assert.NoError(t, err)
+	err = bs.RunFinalize(ctx)
+	assert.NoError(t, err)
+
+	dh.mdx.AssertExpectations(t)
+}
+
+func TestHandleDefinitionIdentityUpdateNodeProfileUnchanged(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, node1, updateMsg, updateData, iu := testNodeIdentityUpdate(t)
+	node1.Profile = iu.Updates.Profile
+
+	dh.mim.On("VerifyIdentityChain", ctx, node1).Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupByID", ctx, node1.ID).Return(node1, nil)
+	dh.mdi.On("UpsertIdentity", ctx, mock.Anything, database.UpsertOptimizationExisting).Return(nil)
+	dh.mim.On("GetLocalNodeDID", ctx).Return("did:firefly:node/other", nil)
+
+	dh.multiparty = true
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, updateMsg, core.DataArray{updateData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+
+	err = bs.RunPreFinalize(ctx)
+	assert.NoError(t, err)
+	dh.mdx.AssertNotCalled(t, "UpdateNode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
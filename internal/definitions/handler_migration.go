@@ -0,0 +1,153 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// getRootOrgDIDs returns the DIDs of all root orgs registered in the network, which must all
+// acknowledge a contract migration before it can take effect
+func (dh *definitionHandler) getRootOrgDIDs(ctx context.Context) ([]string, error) {
+	fb := database.IdentityQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("type", core.IdentityTypeOrg),
+		fb.Eq("parent", nil),
+	).Sort("created")
+	orgs, _, err := dh.database.GetIdentities(ctx, dh.namespace.Name, filter)
+	if err != nil {
+		return nil, err
+	}
+	dids := make([]string, len(orgs))
+	for i, org := range orgs {
+		dids[i] = org.DID
+	}
+	return dids, nil
+}
+
+func (dh *definitionHandler) getRootOrgAuthor(ctx context.Context, msg *core.Message, defType string) (HandlerResult, error) {
+	author, retryable, err := dh.identity.CachedIdentityLookupMustExist(ctx, msg.Header.Author)
+	if err != nil {
+		if retryable {
+			return HandlerResult{Action: core.ActionRetry}, err
+		}
+		return HandlerResult{Action: core.ActionReject}, err
+	}
+	if author.Type != core.IdentityTypeOrg || author.Parent != nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedWrongAuthor, defType, msg.Header.ID, msg.Header.Author)
+	}
+	return HandlerResult{Action: core.ActionConfirm}, nil
+}
+
+func allAcknowledged(migration *core.ContractMigration) bool {
+	for _, member := range migration.Members {
+		if !migration.IsAcknowledged(member) {
+			return false
+		}
+	}
+	return true
+}
+
+func (dh *definitionHandler) handleContractMigrationProposalBroadcast(ctx context.Context, msg *core.Message, data core.DataArray) (HandlerResult, error) {
+	var proposal core.ContractMigrationProposal
+	valid := dh.getSystemBroadcastPayload(ctx, msg, data, &proposal)
+	if !valid || dh.mpManager == nil || proposal.ID == nil || proposal.Location.IsNil() {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedBadPayload, "contract migration proposal", msg.Header.ID)
+	}
+	if hr, err := dh.getRootOrgAuthor(ctx, msg, "contract migration proposal"); err != nil {
+		return hr, err
+	}
+	// SetContractMigration updates the in-memory state of the namespace before the batch commits. If the batch
+	// is retried, the proposal finds the migration it recorded itself, and must rebuild it from the proposal in
+	// the same way, rather than be rejected as conflicting with a migration in progress.
+	if current := dh.mpManager.GetContractMigration(); current.InProgress() && !current.ID.Equals(proposal.ID) {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgContractMigrationInProgress, current.ID)
+	}
+
+	members, err := dh.getRootOrgDIDs(ctx)
+	if err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	}
+	migration := &core.ContractMigration{
+		ContractMigrationProposal: proposal,
+		Proposer:                  msg.Header.Author,
+		State:                     core.ContractMigrationStateProposed,
+		Members:                   members,
+		Acknowledgements:          []string{msg.Header.Author},
+		Created:                   fftypes.Now(),
+	}
+	if allAcknowledged(migration) {
+		migration.State = core.ContractMigrationStateReady
+	}
+	log.L(ctx).Infof("Contract migration '%s' proposed by '%s' to %s at block %d", proposal.ID, msg.Header.Author, proposal.Location, proposal.ActivationBlock)
+	if err := dh.mpManager.SetContractMigration(ctx, migration); err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	}
+	return HandlerResult{Action: core.ActionConfirm, CustomCorrelator: proposal.ID}, nil
+}
+
+func (dh *definitionHandler) handleContractMigrationAckBroadcast(ctx context.Context, msg *core.Message, data core.DataArray) (HandlerResult, error) {
+	var ack core.ContractMigrationAck
+	valid := dh.getSystemBroadcastPayload(ctx, msg, data, &ack)
+	if !valid || dh.mpManager == nil || ack.Migration == nil || ack.Location.IsNil() {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedBadPayload, "contract migration acknowledgement", msg.Header.ID)
+	}
+	migration := dh.mpManager.GetContractMigration()
+	if migration == nil || !migration.ID.Equals(ack.Migration) || !migration.InProgress() {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgContractMigrationNotPending, ack.Migration)
+	}
+	if ack.Location.String() != migration.Location.String() {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgContractMigrationLocationMismatch, ack.Location, migration.Location)
+	}
+	isMember := false
+	for _, member := range migration.Members {
+		isMember = isMember || member == msg.Header.Author
+	}
+	if !isMember {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedWrongAuthor, "contract migration acknowledgement", msg.Header.ID, msg.Header.Author)
+	}
+	if migration.IsAcknowledged(msg.Header.Author) {
+		// Either a duplicate, or a retry of this batch after an attempt that rolled back, in which case this
+		// acknowledgement is already in memory (and might have made the migration ready) but not in the database.
+		// The migration is persisted again, so both cases confirm the same way, with the same resulting state.
+		if err := dh.mpManager.SetContractMigration(ctx, migration); err != nil {
+			return HandlerResult{Action: core.ActionRetry}, err
+		}
+		return HandlerResult{Action: core.ActionConfirm, CustomCorrelator: migration.ID}, nil
+	}
+	if migration.State != core.ContractMigrationStateProposed {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgContractMigrationNotPending, ack.Migration)
+	}
+
+	migration.Acknowledgements = append(migration.Acknowledgements, msg.Header.Author)
+	migration.Updated = fftypes.Now()
+	if allAcknowledged(migration) {
+		log.L(ctx).Infof("Contract migration '%s' acknowledged by all %d members - switching at block %d", migration.ID, len(migration.Members), migration.ActivationBlock)
+		migration.State = core.ContractMigrationStateReady
+	}
+	if err := dh.mpManager.SetContractMigration(ctx, migration); err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	}
+	return HandlerResult{Action: core.ActionConfirm, CustomCorrelator: migration.ID}, nil
+}
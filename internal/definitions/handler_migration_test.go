@@ -0,0 +1,444 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testMigrationLocation = fftypes.JSONAnyPtr(fftypes.JSONObject{"address": "0x456"}.String())
+
+func newTestMigrationMessage(tag, author string, def interface{}) (*core.Message, core.DataArray) {
+	b, _ := json.Marshal(def)
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:   fftypes.NewUUID(),
+			Type: core.MessageTypeDefinition,
+			Tag:  tag,
+			SignerRef: core.SignerRef{
+				Author: author,
+			},
+		},
+	}
+	return msg, core.DataArray{{Value: fftypes.JSONAnyPtrBytes(b)}}
+}
+
+func newTestRootOrg(did string) *core.Identity {
+	return &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:   fftypes.NewUUID(),
+			DID:  did,
+			Type: core.IdentityTypeOrg,
+		},
+	}
+}
+
+func newTestProposedMigration() *core.ContractMigration {
+	return &core.ContractMigration{
+		ContractMigrationProposal: core.ContractMigrationProposal{
+			ID:              fftypes.NewUUID(),
+			Location:        testMigrationLocation,
+			ActivationBlock: 100,
+		},
+		Proposer:         "did:firefly:org/org1",
+		State:            core.ContractMigrationStateProposed,
+		Members:          []string{"did:firefly:org/org1", "did:firefly:org/org2"},
+		Acknowledgements: []string{"did:firefly:org/org1"},
+	}
+}
+
+func TestHandleContractMigrationProposalOk(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	proposal := &core.ContractMigrationProposal{ID: fftypes.NewUUID(), Location: testMigrationLocation, ActivationBlock: 100}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", proposal)
+
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/org1").Return(newTestRootOrg("did:firefly:org/org1"), false, nil)
+	dh.mmp.On("GetContractMigration").Return(nil)
+	dh.mdi.On("GetIdentities", context.Background(), "ns1", mock.Anything).Return([]*core.Identity{
+		newTestRootOrg("did:firefly:org/org1"),
+		newTestRootOrg("did:firefly:org/org2"),
+	}, nil, nil)
+	dh.mmp.On("SetContractMigration", context.Background(), mock.MatchedBy(func(migration *core.ContractMigration) bool {
+		return migration.ID.Equals(proposal.ID) &&
+			migration.Message.Equals(msg.Header.ID) &&
+			migration.State == core.ContractMigrationStateProposed &&
+			len(migration.Members) == 2 &&
+			migration.IsAcknowledged("did:firefly:org/org1")
+	})).Return(nil)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm, CustomCorrelator: proposal.ID}, hr)
+}
+
+func TestHandleContractMigrationProposalSingleMember(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	proposal := &core.ContractMigrationProposal{ID: fftypes.NewUUID(), Location: testMigrationLocation, ActivationBlock: 100}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", proposal)
+
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/org1").Return(newTestRootOrg("did:firefly:org/org1"), false, nil)
+	dh.mmp.On("GetContractMigration").Return(nil)
+	dh.mdi.On("GetIdentities", context.Background(), "ns1", mock.Anything).Return([]*core.Identity{
+		newTestRootOrg("did:firefly:org/org1"),
+	}, nil, nil)
+	dh.mmp.On("SetContractMigration", context.Background(), mock.MatchedBy(func(migration *core.ContractMigration) bool {
+		return migration.State == core.ContractMigrationStateReady
+	})).Return(nil)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionConfirm, hr.Action)
+}
+
+func TestHandleContractMigrationProposalBadPayload(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", &core.ContractMigrationProposal{})
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.Regexp(t, "FF10400", err)
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationProposalAuthorLookupFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	proposal := &core.ContractMigrationProposal{ID: fftypes.NewUUID(), Location: testMigrationLocation}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", proposal)
+
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/org1").Return(nil, true, fmt.Errorf("pop"))
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.ActionRetry, hr.Action)
+}
+
+func TestHandleContractMigrationProposalAuthorNotFound(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	proposal := &core.ContractMigrationProposal{ID: fftypes.NewUUID(), Location: testMigrationLocation}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", proposal)
+
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/org1").Return(nil, false, fmt.Errorf("pop"))
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationProposalNotRootOrg(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	proposal := &core.ContractMigrationProposal{ID: fftypes.NewUUID(), Location: testMigrationLocation}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/child", proposal)
+
+	child := newTestRootOrg("did:firefly:org/child")
+	child.Parent = fftypes.NewUUID()
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/child").Return(child, false, nil)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.Regexp(t, "FF10409", err)
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationProposalInProgress(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	proposal := &core.ContractMigrationProposal{ID: fftypes.NewUUID(), Location: testMigrationLocation}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", proposal)
+
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/org1").Return(newTestRootOrg("did:firefly:org/org1"), false, nil)
+	dh.mmp.On("GetContractMigration").Return(newTestProposedMigration())
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.Regexp(t, "FF10500", err)
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationProposalRetry(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	// An earlier attempt at this batch recorded the migration in memory (and an ack made it ready) before rolling back
+	current := newTestProposedMigration()
+	current.State = core.ContractMigrationStateReady
+	current.Acknowledgements = append(current.Acknowledgements, "did:firefly:org/org2")
+	proposal := &core.ContractMigrationProposal{ID: current.ID, Location: testMigrationLocation, ActivationBlock: 100}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", proposal)
+
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/org1").Return(newTestRootOrg("did:firefly:org/org1"), false, nil)
+	dh.mmp.On("GetContractMigration").Return(current)
+	dh.mdi.On("GetIdentities", context.Background(), "ns1", mock.Anything).Return([]*core.Identity{
+		newTestRootOrg("did:firefly:org/org1"),
+		newTestRootOrg("did:firefly:org/org2"),
+	}, nil, nil)
+	dh.mmp.On("SetContractMigration", context.Background(), mock.MatchedBy(func(migration *core.ContractMigration) bool {
+		return migration.ID.Equals(proposal.ID) &&
+			migration.State == core.ContractMigrationStateProposed &&
+			len(migration.Acknowledgements) == 1
+	})).Return(nil)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm, CustomCorrelator: proposal.ID}, hr)
+}
+
+func TestHandleContractMigrationProposalMembersFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	proposal := &core.ContractMigrationProposal{ID: fftypes.NewUUID(), Location: testMigrationLocation}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", proposal)
+
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/org1").Return(newTestRootOrg("did:firefly:org/org1"), false, nil)
+	dh.mmp.On("GetContractMigration").Return(nil)
+	dh.mdi.On("GetIdentities", context.Background(), "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.ActionRetry, hr.Action)
+}
+
+func TestHandleContractMigrationProposalSetFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	proposal := &core.ContractMigrationProposal{ID: fftypes.NewUUID(), Location: testMigrationLocation}
+	msg, data := newTestMigrationMessage(core.SystemTagProposeContractMigration, "did:firefly:org/org1", proposal)
+
+	dh.mim.On("CachedIdentityLookupMustExist", context.Background(), "did:firefly:org/org1").Return(newTestRootOrg("did:firefly:org/org1"), false, nil)
+	dh.mmp.On("GetContractMigration").Return(nil)
+	dh.mdi.On("GetIdentities", context.Background(), "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	dh.mmp.On("SetContractMigration", context.Background(), mock.Anything).Return(fmt.Errorf("pop"))
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.ActionRetry, hr.Action)
+}
+
+func TestHandleContractMigrationAckReady(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org2", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+	dh.mmp.On("SetContractMigration", context.Background(), mock.MatchedBy(func(m *core.ContractMigration) bool {
+		return m.State == core.ContractMigrationStateReady && m.IsAcknowledged("did:firefly:org/org2")
+	})).Return(nil)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm, CustomCorrelator: migration.ID}, hr)
+}
+
+func TestHandleContractMigrationAckPartial(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	migration.Members = append(migration.Members, "did:firefly:org/org3")
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org2", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+	dh.mmp.On("SetContractMigration", context.Background(), mock.MatchedBy(func(m *core.ContractMigration) bool {
+		return m.State == core.ContractMigrationStateProposed && len(m.Acknowledgements) == 2
+	})).Return(nil)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionConfirm, hr.Action)
+}
+
+func TestHandleContractMigrationAckDuplicate(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org1", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+	dh.mmp.On("SetContractMigration", context.Background(), migration).Return(nil)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionConfirm, hr.Action)
+}
+
+func TestHandleContractMigrationAckRetryReady(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	// An earlier attempt at this batch applied the ack in memory, making the migration ready, before rolling back
+	migration := newTestProposedMigration()
+	migration.State = core.ContractMigrationStateReady
+	migration.Acknowledgements = append(migration.Acknowledgements, "did:firefly:org/org2")
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org2", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+	dh.mmp.On("SetContractMigration", context.Background(), migration).Return(nil)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm, CustomCorrelator: migration.ID}, hr)
+}
+
+func TestHandleContractMigrationAckDuplicateSetFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org1", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+	dh.mmp.On("SetContractMigration", context.Background(), migration).Return(fmt.Errorf("pop"))
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.ActionRetry, hr.Action)
+}
+
+func TestHandleContractMigrationAckReadyNotAcknowledged(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	migration.State = core.ContractMigrationStateReady
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org2", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.Regexp(t, "FF10501", err)
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationAckBadPayload(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org2", &core.ContractMigrationAck{})
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.Regexp(t, "FF10400", err)
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationAckNotPending(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org2", &core.ContractMigrationAck{
+		Migration: fftypes.NewUUID(),
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.Regexp(t, "FF10501", err)
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationAckWrongLocation(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org2", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  fftypes.JSONAnyPtr(fftypes.JSONObject{"address": "0x789"}.String()),
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.Regexp(t, "FF10498", err)
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationAckNotMember(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org3", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.Regexp(t, "FF10409", err)
+	assert.Equal(t, core.ActionReject, hr.Action)
+}
+
+func TestHandleContractMigrationAckSetFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	migration := newTestProposedMigration()
+	msg, data := newTestMigrationMessage(core.SystemTagAckContractMigration, "did:firefly:org/org2", &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  testMigrationLocation,
+	})
+
+	dh.mmp.On("GetContractMigration").Return(migration)
+	dh.mmp.On("SetContractMigration", context.Background(), mock.Anything).Return(fmt.Errorf("pop"))
+
+	hr, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, msg, data, nil)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.ActionRetry, hr.Action)
+}
@@ -28,11 +28,13 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/multiparty"
 	"github.com/hyperledger/firefly/internal/syncasync"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/hyperledger/firefly/pkg/zkproof"
 )
 
 type Sender interface {
@@ -47,6 +49,12 @@ type Sender interface {
 	PublishFFI(ctx context.Context, name, version, networkName string, waitConfirm bool) (*fftypes.FFI, error)
 	DefineContractAPI(ctx context.Context, httpServerURL string, api *core.ContractAPI, waitConfirm bool) error
 	PublishContractAPI(ctx context.Context, httpServerURL, name, networkName string, waitConfirm bool) (api *core.ContractAPI, err error)
+	DefineCustom(ctx context.Context, tag string, def core.Definition, waitConfirm bool) error
+	ApproveDefinition(ctx context.Context, msgID string, waitConfirm bool) (*core.Message, error)
+	GetDefinitionApprovalStatus(ctx context.Context, msgID string) (*core.DefinitionApprovalStatus, error)
+	ProposeContractMigration(ctx context.Context, proposal *core.ContractMigrationProposal, waitConfirm bool) (*core.ContractMigrationProposal, error)
+	AcknowledgeContractMigration(ctx context.Context, migrationID string, waitConfirm bool) (*core.Message, error)
+	DefineDisclosure(ctx context.Context, msgID string, input *core.DisclosureInput, waitConfirm bool) (*core.Disclosure, error)
 }
 
 type definitionSender struct {
@@ -76,7 +84,7 @@ func fakeBatch(ctx context.Context, handler func(context.Context, *core.BatchSta
 	return err
 }
 
-func NewDefinitionSender(ctx context.Context, ns *core.Namespace, multiparty bool, di database.Plugin, bi blockchain.Plugin, dx dataexchange.Plugin, bm broadcast.Manager, im identity.Manager, dm data.Manager, am assets.Manager, cm contracts.Manager, tokenBroadcastNames map[string]string) (Sender, Handler, error) {
+func NewDefinitionSender(ctx context.Context, ns *core.Namespace, multiparty bool, di database.Plugin, bi blockchain.Plugin, dx dataexchange.Plugin, bm broadcast.Manager, im identity.Manager, dm data.Manager, am assets.Manager, cm contracts.Manager, tokenBroadcastNames map[string]string, approvals *ApprovalPolicy, mm multiparty.Manager, zk zkproof.Plugin) (Sender, Handler, error) {
 	if di == nil || im == nil || dm == nil {
 		return nil, nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "DefinitionSender")
 	}
@@ -92,7 +100,7 @@ func NewDefinitionSender(ctx context.Context, ns *core.Namespace, multiparty boo
 		assets:              am,
 		tokenBroadcastNames: tokenBroadcastNames,
 	}
-	dh, err := newDefinitionHandler(ctx, ns, multiparty, di, bi, dx, dm, im, am, cm, reverseMap(tokenBroadcastNames))
+	dh, err := newDefinitionHandler(ctx, ns, multiparty, di, bi, dx, dm, im, am, cm, reverseMap(tokenBroadcastNames), approvals, mm, zk)
 	if err != nil {
 		return nil, nil, err
 	}
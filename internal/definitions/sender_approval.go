@@ -0,0 +1,95 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (ds *definitionSender) getGovernedDefinition(ctx context.Context, msgID string) (*core.Message, error) {
+	id, err := fftypes.ParseUUID(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := ds.database.GetMessageByID(ctx, ds.namespace, id)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	if msg.Header.Type != core.MessageTypeDefinition || !ds.handler.requiresApproval(msg.Header.Tag) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDefinitionApprovalNotRequired, msg.Header.ID)
+	}
+	return msg, nil
+}
+
+// ApproveDefinition broadcasts an approval from the local org, for a definition that is pending approval
+func (ds *definitionSender) ApproveDefinition(ctx context.Context, msgID string, waitConfirm bool) (*core.Message, error) {
+	if !ds.multiparty {
+		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	msg, err := ds.getGovernedDefinition(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.State != core.MessageStatePending {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDefinitionApprovalNotPending, msg.Header.ID)
+	}
+
+	approval := &core.DefinitionApproval{
+		Message: msg.Header.ID,
+		Hash:    msg.Hash,
+	}
+	sender := ds.getSenderDefault(ctx, approval, core.SystemTagApproveDefinition)
+	if sender.message != nil {
+		sender.message.Header.CID = msg.Header.ID
+	}
+	return sender.send(ctx, waitConfirm)
+}
+
+// GetDefinitionApprovalStatus returns the approvals received so far for a definition that requires approval
+func (ds *definitionSender) GetDefinitionApprovalStatus(ctx context.Context, msgID string) (*core.DefinitionApprovalStatus, error) {
+	msg, err := ds.getGovernedDefinition(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+	approvers, err := ds.handler.getDefinitionApprovers(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &core.DefinitionApprovalStatus{
+		Message:   msg.Header.ID,
+		Tag:       msg.Header.Tag,
+		State:     core.DefinitionApprovalStatePending,
+		Required:  ds.handler.approvals.Required,
+		Approvers: approvers,
+	}
+	switch msg.State {
+	case core.MessageStateConfirmed:
+		status.State = core.DefinitionApprovalStateApproved
+	case core.MessageStateRejected:
+		status.State = core.DefinitionApprovalStateRejected
+	}
+	return status, nil
+}
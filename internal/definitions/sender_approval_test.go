@@ -0,0 +1,192 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableTestSenderApprovals(ds *testDefinitionSender) {
+	ds.multiparty = true
+	ds.handler.multiparty = true
+	ds.handler.approvals = &ApprovalPolicy{
+		Required: 2,
+		Tags:     []string{"ff_define_widget"},
+	}
+}
+
+func TestApproveDefinitionOk(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+	mms := &syncasyncmocks.Sender{}
+
+	def := newTestGovernedDefinition()
+	ds.mdi.On("GetMessageByID", context.Background(), "ns1", def.Header.ID).Return(def, nil)
+	ds.mim.On("GetRootOrg", context.Background()).Return(&core.Identity{
+		IdentityBase: core.IdentityBase{
+			DID: "firefly:org1",
+		},
+	}, nil)
+	ds.mim.On("ResolveInputSigningIdentity", mock.Anything, mock.Anything).Return(nil)
+	ds.mbm.On("NewBroadcast", mock.Anything).Return(mms)
+	mms.On("SendAndWait", context.Background()).Return(nil)
+
+	msg, err := ds.ApproveDefinition(context.Background(), def.Header.ID.String(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, def.Header.ID, msg.Header.CID)
+	assert.Equal(t, core.SystemTagApproveDefinition, msg.Header.Tag)
+	assert.Equal(t, core.SystemTopicDefinitionApprovals, msg.Header.Topics[0])
+
+	mms.AssertExpectations(t)
+}
+
+func TestApproveDefinitionNonMultiparty(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	_, err := ds.ApproveDefinition(context.Background(), "id1", false)
+	assert.Regexp(t, "FF10414", err)
+}
+
+func TestApproveDefinitionBadID(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	_, err := ds.ApproveDefinition(context.Background(), "bad", false)
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestApproveDefinitionLookupFail(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	def := newTestGovernedDefinition()
+	ds.mdi.On("GetMessageByID", context.Background(), "ns1", def.Header.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ds.ApproveDefinition(context.Background(), def.Header.ID.String(), false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestApproveDefinitionNotFound(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	def := newTestGovernedDefinition()
+	ds.mdi.On("GetMessageByID", context.Background(), "ns1", def.Header.ID).Return(nil, nil)
+
+	_, err := ds.ApproveDefinition(context.Background(), def.Header.ID.String(), false)
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestApproveDefinitionNotRequired(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	def := newTestGovernedDefinition()
+	def.Header.Tag = core.SystemTagDefineDatatype
+	ds.mdi.On("GetMessageByID", context.Background(), "ns1", def.Header.ID).Return(def, nil)
+
+	_, err := ds.ApproveDefinition(context.Background(), def.Header.ID.String(), false)
+	assert.Regexp(t, "FF10487", err)
+}
+
+func TestApproveDefinitionNotPending(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	def := newTestGovernedDefinition()
+	def.State = core.MessageStateConfirmed
+	ds.mdi.On("GetMessageByID", context.Background(), "ns1", def.Header.ID).Return(def, nil)
+
+	_, err := ds.ApproveDefinition(context.Background(), def.Header.ID.String(), false)
+	assert.Regexp(t, "FF10488", err)
+}
+
+func TestGetDefinitionApprovalStatus(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	def := newTestGovernedDefinition()
+	approval1, data1 := newTestDefinitionApproval("did:firefly:org/org1", def, def.Hash)
+	ds.mdi.On("GetMessageByID", context.Background(), "ns1", def.Header.ID).Return(def, nil)
+	ds.mdi.On("GetMessages", context.Background(), "ns1", mock.Anything).Return([]*core.Message{approval1}, nil, nil)
+	ds.mdm.On("GetMessageDataCached", context.Background(), approval1).Return(data1, true, nil)
+
+	status, err := ds.GetDefinitionApprovalStatus(context.Background(), def.Header.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, core.DefinitionApprovalStatePending, status.State)
+	assert.Equal(t, 2, status.Required)
+	assert.Equal(t, []string{"did:firefly:org/org1"}, status.Approvers)
+}
+
+func TestGetDefinitionApprovalStatusComplete(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	def := newTestGovernedDefinition()
+	def.State = core.MessageStateRejected
+	ds.mdi.On("GetMessageByID", context.Background(), "ns1", def.Header.ID).Return(def, nil)
+	ds.mdi.On("GetMessages", context.Background(), "ns1", mock.Anything).Return([]*core.Message{}, nil, nil).Once()
+
+	status, err := ds.GetDefinitionApprovalStatus(context.Background(), def.Header.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, core.DefinitionApprovalStateRejected, status.State)
+
+	def.State = core.MessageStateConfirmed
+	ds.mdi.On("GetMessages", context.Background(), "ns1", mock.Anything).Return([]*core.Message{}, nil, nil).Once()
+
+	status, err = ds.GetDefinitionApprovalStatus(context.Background(), def.Header.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, core.DefinitionApprovalStateApproved, status.State)
+}
+
+func TestGetDefinitionApprovalStatusQueryFail(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	def := newTestGovernedDefinition()
+	ds.mdi.On("GetMessageByID", context.Background(), "ns1", def.Header.ID).Return(def, nil)
+	ds.mdi.On("GetMessages", context.Background(), "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ds.GetDefinitionApprovalStatus(context.Background(), def.Header.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetDefinitionApprovalStatusNotFound(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderApprovals(ds)
+
+	_, err := ds.GetDefinitionApprovalStatus(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
@@ -0,0 +1,41 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// DefineCustom broadcasts a definition of a custom type, which must have a handler registered for its tag
+func (ds *definitionSender) DefineCustom(ctx context.Context, tag string, def core.Definition, waitConfirm bool) error {
+	if !ds.multiparty {
+		return i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	if _, ok := ds.handler.custom[tag]; !ok {
+		return i18n.NewError(ctx, coremsgs.MsgCustomDefinitionUnknown, tag)
+	}
+
+	msg, err := ds.getSenderDefault(ctx, def, tag).send(ctx, waitConfirm)
+	if msg != nil {
+		def.SetBroadcastMessage(msg.Header.ID)
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDefineCustomOk(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	ds.multiparty = true
+	ds.handler.custom = map[string]core.CustomDefinitionHandler{
+		"ff_define_widget": &coremocks.CustomDefinitionHandler{},
+	}
+	mms := &syncasyncmocks.Sender{}
+
+	ds.mim.On("GetRootOrg", context.Background()).Return(&core.Identity{
+		IdentityBase: core.IdentityBase{
+			DID: "firefly:org1",
+		},
+	}, nil)
+	ds.mim.On("ResolveInputSigningIdentity", mock.Anything, mock.Anything).Return(nil)
+	ds.mbm.On("NewBroadcast", mock.Anything).Return(mms)
+	mms.On("Send", context.Background()).Return(nil)
+
+	err := ds.DefineCustom(context.Background(), "ff_define_widget", &core.Datatype{Name: "widget1"}, false)
+	assert.NoError(t, err)
+
+	mms.AssertExpectations(t)
+}
+
+func TestDefineCustomUnknownTag(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	ds.multiparty = true
+
+	err := ds.DefineCustom(context.Background(), "ff_define_widget", &core.Datatype{Name: "widget1"}, false)
+	assert.Regexp(t, "FF10486", err)
+}
+
+func TestDefineCustomNonMultiparty(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	ds.multiparty = false
+
+	err := ds.DefineCustom(context.Background(), "ff_define_widget", &core.Datatype{Name: "widget1"}, false)
+	assert.Regexp(t, "FF10414", err)
+}
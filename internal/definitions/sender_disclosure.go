@@ -0,0 +1,78 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// DefineDisclosure generates a zero-knowledge proof of a statement about the data of a message held by this node,
+// and broadcasts it so that members that do not hold the data can verify the statement
+func (ds *definitionSender) DefineDisclosure(ctx context.Context, msgID string, input *core.DisclosureInput, waitConfirm bool) (*core.Disclosure, error) {
+	if !ds.multiparty {
+		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	if ds.handler.zkproof == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgZKProofNotConfigured)
+	}
+	if err := fftypes.ValidateFFNameField(ctx, input.Circuit, "circuit"); err != nil {
+		return nil, err
+	}
+	id, err := fftypes.ParseUUID(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+	msg, data, foundAll, err := ds.data.GetMessageWithDataCached(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if msg == nil || !foundAll {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+
+	// The private inputs are the values of the data of the message, in order
+	values := make([]*fftypes.JSONAny, len(data))
+	for i, d := range data {
+		values[i] = d.Value
+	}
+	privateInputs, _ := json.Marshal(values)
+	proof, err := ds.handler.zkproof.GenerateProof(ctx, input.Circuit, input.PublicInputs, fftypes.JSONAnyPtrBytes(privateInputs))
+	if err != nil {
+		return nil, err
+	}
+
+	disclosure := &core.Disclosure{
+		ID:           fftypes.NewUUID(),
+		Message:      msg.Header.ID,
+		MessageHash:  msg.Hash,
+		Circuit:      input.Circuit,
+		PublicInputs: input.PublicInputs,
+		Proof:        proof,
+		Created:      fftypes.Now(),
+	}
+	defMsg, err := ds.getSenderDefault(ctx, disclosure, core.SystemTagDefineDisclosure).send(ctx, waitConfirm)
+	if defMsg != nil {
+		disclosure.DefinitionMessage = defMsg.Header.ID
+	}
+	disclosure.Namespace = ds.namespace
+	return disclosure, err
+}
@@ -0,0 +1,162 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/zkproofmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableTestSenderDisclosures(ds *testDefinitionSender) *zkproofmocks.Plugin {
+	mzk := &zkproofmocks.Plugin{}
+	ds.multiparty = true
+	ds.handler.zkproof = mzk
+	return mzk
+}
+
+func newTestDisclosureSubject() (*core.Message, core.DataArray) {
+	msg := &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID()},
+		Hash:   fftypes.NewRandB32(),
+	}
+	data := core.DataArray{
+		{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`{"amount":50}`)},
+	}
+	return msg, data
+}
+
+func TestDefineDisclosureOk(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	mzk := enableTestSenderDisclosures(ds)
+
+	msg, data := newTestDisclosureSubject()
+	input := &core.DisclosureInput{Circuit: "range", PublicInputs: fftypes.JSONAnyPtr(`{"limit":100}`)}
+	ds.mdm.On("GetMessageWithDataCached", context.Background(), msg.Header.ID).Return(msg, data, true, nil)
+	mzk.On("GenerateProof", context.Background(), "range", input.PublicInputs, mock.MatchedBy(func(privateInputs *fftypes.JSONAny) bool {
+		return privateInputs.String() == `[{"amount":50}]`
+	})).Return(fftypes.JSONAnyPtr(`{"pi_a":["1"]}`), nil)
+	mms := mockTestMigrationBroadcast(ds)
+	mms.On("Send", context.Background()).Return(nil)
+
+	disclosure, err := ds.DefineDisclosure(context.Background(), msg.Header.ID.String(), input, false)
+	assert.NoError(t, err)
+	assert.Equal(t, msg.Header.ID, disclosure.Message)
+	assert.Equal(t, msg.Hash, disclosure.MessageHash)
+	assert.Equal(t, `{"pi_a":["1"]}`, disclosure.Proof.String())
+	assert.Equal(t, "ns1", disclosure.Namespace)
+
+	mms.AssertExpectations(t)
+	mzk.AssertExpectations(t)
+}
+
+func TestDefineDisclosureNonMultiparty(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	_, err := ds.DefineDisclosure(context.Background(), fftypes.NewUUID().String(), &core.DisclosureInput{}, false)
+	assert.Regexp(t, "FF10414", err)
+}
+
+func TestDefineDisclosureNoPlugin(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	ds.multiparty = true
+
+	_, err := ds.DefineDisclosure(context.Background(), fftypes.NewUUID().String(), &core.DisclosureInput{}, false)
+	assert.Regexp(t, "FF10589", err)
+}
+
+func TestDefineDisclosureBadCircuit(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderDisclosures(ds)
+
+	_, err := ds.DefineDisclosure(context.Background(), fftypes.NewUUID().String(), &core.DisclosureInput{Circuit: "!bad"}, false)
+	assert.Regexp(t, "FF00140.*circuit", err)
+}
+
+func TestDefineDisclosureBadID(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderDisclosures(ds)
+
+	_, err := ds.DefineDisclosure(context.Background(), "bad", &core.DisclosureInput{Circuit: "range"}, false)
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestDefineDisclosureMessageFail(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderDisclosures(ds)
+
+	msgID := fftypes.NewUUID()
+	ds.mdm.On("GetMessageWithDataCached", context.Background(), msgID).Return(nil, nil, false, fmt.Errorf("pop"))
+
+	_, err := ds.DefineDisclosure(context.Background(), msgID.String(), &core.DisclosureInput{Circuit: "range"}, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestDefineDisclosureMessageNotFound(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderDisclosures(ds)
+
+	msgID := fftypes.NewUUID()
+	ds.mdm.On("GetMessageWithDataCached", context.Background(), msgID).Return(nil, nil, false, nil)
+
+	_, err := ds.DefineDisclosure(context.Background(), msgID.String(), &core.DisclosureInput{Circuit: "range"}, false)
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestDefineDisclosureProofFail(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	mzk := enableTestSenderDisclosures(ds)
+
+	msg, data := newTestDisclosureSubject()
+	ds.mdm.On("GetMessageWithDataCached", context.Background(), msg.Header.ID).Return(msg, data, true, nil)
+	mzk.On("GenerateProof", context.Background(), "range", (*fftypes.JSONAny)(nil), mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ds.DefineDisclosure(context.Background(), msg.Header.ID.String(), &core.DisclosureInput{Circuit: "range"}, false)
+	assert.EqualError(t, err, "pop")
+
+	mzk.AssertExpectations(t)
+}
+
+func TestDefineDisclosureSendFail(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	mzk := enableTestSenderDisclosures(ds)
+
+	msg, data := newTestDisclosureSubject()
+	ds.mdm.On("GetMessageWithDataCached", context.Background(), msg.Header.ID).Return(msg, data, true, nil)
+	mzk.On("GenerateProof", context.Background(), "range", (*fftypes.JSONAny)(nil), mock.Anything).Return(fftypes.JSONAnyPtr(`{}`), nil)
+	ds.mim.On("GetRootOrg", context.Background()).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ds.DefineDisclosure(context.Background(), msg.Header.ID.String(), &core.DisclosureInput{Circuit: "range"}, true)
+	assert.EqualError(t, err, "pop")
+
+	mzk.AssertExpectations(t)
+}
@@ -0,0 +1,79 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// ProposeContractMigration broadcasts a proposal for all members to switch to the next configured FireFly contract
+// at the given block, once every root org in the network has acknowledged it
+func (ds *definitionSender) ProposeContractMigration(ctx context.Context, proposal *core.ContractMigrationProposal, waitConfirm bool) (*core.ContractMigrationProposal, error) {
+	if !ds.multiparty || ds.handler.mpManager == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	if current := ds.handler.mpManager.GetContractMigration(); current.InProgress() {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractMigrationInProgress, current.ID)
+	}
+	if err := ds.handler.mpManager.ValidateContractMigration(ctx, proposal.Location, proposal.Version); err != nil {
+		return nil, err
+	}
+
+	proposal.ID = fftypes.NewUUID()
+	_, err := ds.getSenderDefault(ctx, proposal, core.SystemTagProposeContractMigration).send(ctx, waitConfirm)
+	if err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// AcknowledgeContractMigration broadcasts an acknowledgement from the local org that it is ready to switch
+// to the contract of a proposed migration
+func (ds *definitionSender) AcknowledgeContractMigration(ctx context.Context, migrationID string, waitConfirm bool) (*core.Message, error) {
+	if !ds.multiparty || ds.handler.mpManager == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	id, err := fftypes.ParseUUID(ctx, migrationID)
+	if err != nil {
+		return nil, err
+	}
+	migration := ds.handler.mpManager.GetContractMigration()
+	if migration == nil || !migration.ID.Equals(id) {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	if migration.State != core.ContractMigrationStateProposed {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractMigrationNotPending, migration.ID)
+	}
+	if err := ds.handler.mpManager.ValidateContractMigration(ctx, migration.Location, migration.Version); err != nil {
+		return nil, err
+	}
+
+	ack := &core.ContractMigrationAck{
+		Migration: migration.ID,
+		Location:  migration.Location,
+	}
+	sender := ds.getSenderDefault(ctx, ack, core.SystemTagAckContractMigration)
+	if sender.message != nil {
+		sender.message.Header.CID = migration.Message
+	}
+	return sender.send(ctx, waitConfirm)
+}
@@ -0,0 +1,188 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func enableTestSenderMigrations(ds *testDefinitionSender) {
+	ds.multiparty = true
+	ds.handler.multiparty = true
+	ds.handler.mpManager = ds.mmp
+}
+
+func mockTestMigrationBroadcast(ds *testDefinitionSender) *syncasyncmocks.Sender {
+	mms := &syncasyncmocks.Sender{}
+	ds.mim.On("GetRootOrg", context.Background()).Return(&core.Identity{
+		IdentityBase: core.IdentityBase{
+			DID: "did:firefly:org/org2",
+		},
+	}, nil)
+	ds.mim.On("ResolveInputSigningIdentity", mock.Anything, mock.Anything).Return(nil)
+	ds.mbm.On("NewBroadcast", mock.Anything).Return(mms)
+	return mms
+}
+
+func TestProposeContractMigrationOk(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	proposal := &core.ContractMigrationProposal{Location: testMigrationLocation, Version: 2, ActivationBlock: 100}
+	ds.mmp.On("GetContractMigration").Return(nil)
+	ds.mmp.On("ValidateContractMigration", context.Background(), testMigrationLocation, 2).Return(nil)
+	mms := mockTestMigrationBroadcast(ds)
+	mms.On("SendAndWait", context.Background()).Return(nil)
+
+	result, err := ds.ProposeContractMigration(context.Background(), proposal, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.ID)
+
+	mms.AssertExpectations(t)
+}
+
+func TestProposeContractMigrationNonMultiparty(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	_, err := ds.ProposeContractMigration(context.Background(), &core.ContractMigrationProposal{}, false)
+	assert.Regexp(t, "FF10414", err)
+}
+
+func TestProposeContractMigrationInProgress(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	ds.mmp.On("GetContractMigration").Return(newTestProposedMigration())
+
+	_, err := ds.ProposeContractMigration(context.Background(), &core.ContractMigrationProposal{}, false)
+	assert.Regexp(t, "FF10500", err)
+}
+
+func TestProposeContractMigrationInvalid(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	proposal := &core.ContractMigrationProposal{Location: testMigrationLocation}
+	ds.mmp.On("GetContractMigration").Return(nil)
+	ds.mmp.On("ValidateContractMigration", context.Background(), testMigrationLocation, 0).Return(fmt.Errorf("pop"))
+
+	_, err := ds.ProposeContractMigration(context.Background(), proposal, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestProposeContractMigrationSendFail(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	proposal := &core.ContractMigrationProposal{Location: testMigrationLocation}
+	ds.mmp.On("GetContractMigration").Return(nil)
+	ds.mmp.On("ValidateContractMigration", context.Background(), testMigrationLocation, 0).Return(nil)
+	mms := mockTestMigrationBroadcast(ds)
+	mms.On("Send", context.Background()).Return(fmt.Errorf("pop"))
+
+	_, err := ds.ProposeContractMigration(context.Background(), proposal, false)
+	assert.EqualError(t, err, "pop")
+
+	mms.AssertExpectations(t)
+}
+
+func TestAcknowledgeContractMigrationOk(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	migration := newTestProposedMigration()
+	migration.Message = fftypes.NewUUID()
+	ds.mmp.On("GetContractMigration").Return(migration)
+	ds.mmp.On("ValidateContractMigration", context.Background(), testMigrationLocation, 0).Return(nil)
+	mms := mockTestMigrationBroadcast(ds)
+	mms.On("Send", context.Background()).Return(nil)
+
+	msg, err := ds.AcknowledgeContractMigration(context.Background(), migration.ID.String(), false)
+	assert.NoError(t, err)
+	assert.Equal(t, migration.Message, msg.Header.CID)
+	assert.Equal(t, core.SystemTagAckContractMigration, msg.Header.Tag)
+
+	mms.AssertExpectations(t)
+}
+
+func TestAcknowledgeContractMigrationNonMultiparty(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	_, err := ds.AcknowledgeContractMigration(context.Background(), "id1", false)
+	assert.Regexp(t, "FF10414", err)
+}
+
+func TestAcknowledgeContractMigrationBadID(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	_, err := ds.AcknowledgeContractMigration(context.Background(), "bad", false)
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestAcknowledgeContractMigrationNotFound(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	ds.mmp.On("GetContractMigration").Return(newTestProposedMigration())
+
+	_, err := ds.AcknowledgeContractMigration(context.Background(), fftypes.NewUUID().String(), false)
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestAcknowledgeContractMigrationNotPending(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	migration := newTestProposedMigration()
+	migration.State = core.ContractMigrationStateReady
+	ds.mmp.On("GetContractMigration").Return(migration)
+
+	_, err := ds.AcknowledgeContractMigration(context.Background(), migration.ID.String(), false)
+	assert.Regexp(t, "FF10501", err)
+}
+
+func TestAcknowledgeContractMigrationInvalid(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	enableTestSenderMigrations(ds)
+
+	migration := newTestProposedMigration()
+	ds.mmp.On("GetContractMigration").Return(migration)
+	ds.mmp.On("ValidateContractMigration", context.Background(), testMigrationLocation, 0).Return(fmt.Errorf("pop"))
+
+	_, err := ds.AcknowledgeContractMigration(context.Background(), migration.ID.String(), false)
+	assert.EqualError(t, err, "pop")
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
 	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
@@ -47,6 +48,7 @@ type testDefinitionSender struct {
 	mdm    *datamocks.Manager
 	mam    *assetmocks.Manager
 	mcm    *contractmocks.Manager
+	mmp    *multipartymocks.Manager
 }
 
 func (tds *testDefinitionSender) cleanup(t *testing.T) {
@@ -59,6 +61,7 @@ func (tds *testDefinitionSender) cleanup(t *testing.T) {
 	tds.mdm.AssertExpectations(t)
 	tds.mam.AssertExpectations(t)
 	tds.mcm.AssertExpectations(t)
+	tds.mmp.AssertExpectations(t)
 }
 
 func newTestDefinitionSender(t *testing.T) *testDefinitionSender {
@@ -70,12 +73,13 @@ func newTestDefinitionSender(t *testing.T) *testDefinitionSender {
 	mdm := &datamocks.Manager{}
 	mam := &assetmocks.Manager{}
 	mcm := &contractmocks.Manager{}
+	mmp := &multipartymocks.Manager{}
 	tokenBroadcastNames := make(map[string]string)
 	tokenBroadcastNames["connector1"] = "remote1"
 
 	ctx, cancel := context.WithCancel(context.Background())
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
-	ds, _, err := NewDefinitionSender(ctx, ns, false, mdi, mbi, mdx, mbm, mim, mdm, mam, mcm, tokenBroadcastNames)
+	ds, _, err := NewDefinitionSender(ctx, ns, false, mdi, mbi, mdx, mbm, mim, mdm, mam, mcm, tokenBroadcastNames, &ApprovalPolicy{}, mmp, nil)
 	assert.NoError(t, err)
 
 	return &testDefinitionSender{
@@ -89,6 +93,7 @@ func newTestDefinitionSender(t *testing.T) *testDefinitionSender {
 		mdm:              mdm,
 		mam:              mam,
 		mcm:              mcm,
+		mmp:              mmp,
 	}
 }
 
@@ -101,7 +106,7 @@ func mockRunAsGroupPassthrough(mdi *databasemocks.Plugin) {
 }
 
 func TestInitSenderFail(t *testing.T) {
-	_, _, err := NewDefinitionSender(context.Background(), &core.Namespace{}, false, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, _, err := NewDefinitionSender(context.Background(), &core.Namespace{}, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -119,7 +124,7 @@ func TestNewDefinitionSenderHandlerThrows(t *testing.T) {
 
 	ctx := context.Background()
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
-	ds, dh, err := NewDefinitionSender(ctx, ns, false, mdi, mbi, mdx, mbm, mim, mdm, nil, mcm, tokenBroadcastNames)
+	ds, dh, err := NewDefinitionSender(ctx, ns, false, mdi, mbi, mdx, mbm, mim, mdm, nil, mcm, tokenBroadcastNames, &ApprovalPolicy{}, nil, nil)
 	assert.Nil(t, ds)
 	assert.Nil(t, dh)
 	assert.NotNil(t, err)
@@ -0,0 +1,336 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventbridge converts selected blockchain events into broadcast or private messages, so that members
+// of the network without access to the chain can follow what happens on it through the normal messaging path.
+package eventbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/broadcast"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/privatemessaging"
+	"github.com/hyperledger/firefly/internal/syncasync"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Manager runs the event bridges configured for a namespace.
+//
+// Each bridge follows the blockchain_event_received events of the namespace in sequence order, and sends a
+// message for each one that was delivered by the selected listener or on the selected topic. The message is
+// given an idempotency key derived from the bridge name and the blockchain event, which is checked before
+// sending, so an event is bridged at most once even if the bridge restarts before its offset is written.
+type Manager interface {
+	Start()
+	WaitStop()
+}
+
+type bridgeManager struct {
+	bridges []*bridge
+}
+
+type bridge struct {
+	ctx          context.Context
+	name         string
+	namespace    string
+	database     database.Plugin
+	broadcast    broadcast.Manager
+	messaging    privatemessaging.Manager
+	listener     string
+	topic        string
+	eventName    string
+	messageType  core.MessageType
+	messageTopic string
+	messageTag   string
+	members      []core.MemberInput
+	template     *template.Template
+	batchSize    int
+	interval     time.Duration
+	done         chan struct{}
+}
+
+// NewEventBridgeManager returns nil if no event bridges are configured for the namespace
+func NewEventBridgeManager(ctx context.Context, ns string, di database.Plugin, bm broadcast.Manager, pm privatemessaging.Manager) (Manager, error) {
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "EventBridgeManager")
+	}
+	bmgr := &bridgeManager{}
+	names := make(map[string]bool)
+	for i := 0; i < bridgesConfig.ArraySize(); i++ {
+		conf := bridgesConfig.ArrayEntry(i)
+		if conf.GetString(BridgeConfNamespace) != ns {
+			continue
+		}
+		b, err := newBridge(ctx, ns, conf, di, bm, pm)
+		if err == nil && names[b.name] {
+			err = i18n.NewError(ctx, coremsgs.MsgDuplicateEventBridgeName, b.name, ns)
+		}
+		if err != nil {
+			return nil, err
+		}
+		names[b.name] = true
+		bmgr.bridges = append(bmgr.bridges, b)
+	}
+	if len(bmgr.bridges) == 0 {
+		return nil, nil
+	}
+	return bmgr, nil
+}
+
+func newBridge(ctx context.Context, ns string, conf config.Section, di database.Plugin, bm broadcast.Manager, pm privatemessaging.Manager) (*bridge, error) {
+	name := conf.GetString(BridgeConfName)
+	if name == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, conf.Resolve(BridgeConfName), "eventbridges")
+	}
+	b := &bridge{
+		ctx:          log.WithLogField(ctx, "eventbridge", name),
+		name:         name,
+		namespace:    ns,
+		database:     di,
+		broadcast:    bm,
+		messaging:    pm,
+		listener:     conf.GetString(BridgeConfListener),
+		topic:        conf.GetString(BridgeConfTopic),
+		eventName:    conf.GetString(BridgeConfEventName),
+		messageType:  core.MessageType(conf.GetString(BridgeConfMessageType)),
+		messageTopic: conf.GetString(BridgeConfMessageTopic),
+		messageTag:   conf.GetString(BridgeConfMessageTag),
+		batchSize:    conf.GetInt(BridgeConfBatchSize),
+		interval:     conf.GetDuration(BridgeConfInterval),
+	}
+	if b.listener == "" && b.topic == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgEventBridgeNoSelector, name)
+	}
+	if b.messageTopic == "" {
+		b.messageTopic = name
+	}
+
+	switch b.messageType {
+	case core.MessageTypeBroadcast:
+		if bm == nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgEventBridgeMessagingDisabled, name, b.messageType, ns)
+		}
+	case core.MessageTypePrivate:
+		if pm == nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgEventBridgeMessagingDisabled, name, b.messageType, ns)
+		}
+		for _, member := range conf.GetStringSlice(BridgeConfMembers) {
+			b.members = append(b.members, core.MemberInput{Identity: member})
+		}
+		if len(b.members) == 0 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgEventBridgeNoMembers, name)
+		}
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedEventBridgeMessageType, b.messageType, name)
+	}
+
+	if tmpl := conf.GetString(BridgeConfTemplate); tmpl != "" {
+		var err error
+		b.template, err = template.New(name).Option("missingkey=zero").Funcs(template.FuncMap{
+			"json": func(v interface{}) (string, error) {
+				out, err := json.Marshal(v)
+				return string(out), err
+			},
+		}).Parse(tmpl)
+		if err != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgEventBridgeInvalidTemplate, name, err)
+		}
+	}
+	return b, nil
+}
+
+func (bmgr *bridgeManager) Start() {
+	for _, b := range bmgr.bridges {
+		b.done = make(chan struct{})
+		go b.bridgeLoop()
+	}
+}
+
+func (bmgr *bridgeManager) WaitStop() {
+	for _, b := range bmgr.bridges {
+		if b.done != nil {
+			<-b.done
+		}
+	}
+}
+
+func (b *bridge) bridgeLoop() {
+	defer close(b.done)
+	for {
+		processed, err := b.bridgeBatch(b.ctx)
+		if err != nil {
+			log.L(b.ctx).Errorf("Event bridge failed: %s", err)
+		}
+		if err != nil || processed < b.batchSize {
+			select {
+			case <-time.After(b.interval):
+			case <-b.ctx.Done():
+				log.L(b.ctx).Debugf("Event bridge exiting")
+				return
+			}
+		} else if b.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (b *bridge) bridgeBatch(ctx context.Context) (processed int, err error) {
+	offsetName := fmt.Sprintf("%s:%s", b.namespace, b.name)
+	offset, err := b.database.GetOffset(ctx, core.OffsetTypeEventBridge, offsetName)
+	if err != nil {
+		return 0, err
+	}
+	if offset == nil {
+		offset = &core.Offset{Type: core.OffsetTypeEventBridge, Name: offsetName}
+	}
+
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	filters := []ffapi.Filter{
+		fb.Gt("sequence", offset.Current),
+		fb.Eq("type", core.EventTypeBlockchainEventReceived),
+	}
+	if b.topic != "" {
+		filters = append(filters, fb.Eq("topic", b.topic))
+	}
+	events, _, err := b.database.GetEvents(ctx, b.namespace, fb.And(filters...).Sort("sequence").Limit(uint64(b.batchSize)))
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	var listenerID *fftypes.UUID
+	if b.listener != "" {
+		listener, err := b.resolveListener(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if listener == nil {
+			// The listener does not exist (yet), so none of these events can have been delivered by it
+			log.L(ctx).Debugf("Listener '%s' not found - skipping %d events", b.listener, len(events))
+		} else {
+			listenerID = listener.ID
+		}
+	}
+	if b.listener == "" || listenerID != nil {
+		for _, event := range events {
+			if err := b.bridgeEvent(ctx, listenerID, event); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	offset.Current = events[len(events)-1].Sequence
+	if err = b.database.UpsertOffset(ctx, offset, true); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func (b *bridge) resolveListener(ctx context.Context) (*core.ContractListener, error) {
+	if id, err := fftypes.ParseUUID(ctx, b.listener); err == nil {
+		return b.database.GetContractListenerByID(ctx, b.namespace, id)
+	}
+	return b.database.GetContractListener(ctx, b.namespace, b.listener)
+}
+
+func (b *bridge) bridgeEvent(ctx context.Context, listenerID *fftypes.UUID, event *core.Event) error {
+	bcEvent, err := b.database.GetBlockchainEventByID(ctx, b.namespace, event.Reference)
+	if err != nil || bcEvent == nil {
+		return err
+	}
+	if listenerID != nil && !listenerID.Equals(bcEvent.Listener) {
+		return nil
+	}
+	if b.eventName != "" && bcEvent.Name != b.eventName {
+		return nil
+	}
+
+	idempotencyKey := core.IdempotencyKey(fmt.Sprintf("eventbridge:%s:%s", b.name, bcEvent.ID))
+	fb := database.MessageQueryFactory.NewFilter(ctx)
+	existing, _, err := b.database.GetMessages(ctx, b.namespace, fb.Eq("idempotencykey", string(idempotencyKey)))
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		log.L(ctx).Debugf("Blockchain event %s already bridged to message %s", bcEvent.ID, existing[0].Header.ID)
+		return nil
+	}
+
+	payload, err := b.renderPayload(bcEvent)
+	if err != nil {
+		// The template cannot be rendered for this event, so retrying would block the bridge forever
+		log.L(ctx).Errorf("Skipping blockchain event %s, as the payload template failed: %s", bcEvent.ID, err)
+		return nil
+	}
+
+	in := &core.MessageInOut{
+		Message: core.Message{
+			Header: core.MessageHeader{
+				Type:   b.messageType,
+				Topics: fftypes.FFStringArray{b.messageTopic},
+				Tag:    b.messageTag,
+			},
+			IdempotencyKey: idempotencyKey,
+		},
+		InlineData: core.InlineData{
+			{Value: payload},
+		},
+	}
+	var sender syncasync.Sender
+	if b.messageType == core.MessageTypePrivate {
+		in.Group = &core.InputGroup{Members: b.members}
+		sender = b.messaging.NewMessage(in)
+	} else {
+		sender = b.broadcast.NewBroadcast(in)
+	}
+	if err := sender.Send(ctx); err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Bridged blockchain event %s (%s) to %s message %s", bcEvent.ID, bcEvent.Name, b.messageType, in.Header.ID)
+	return nil
+}
+
+// renderPayload returns the blockchain event itself if there is no template. A template that renders
+// valid JSON is sent as that JSON value, and any other output is sent as a JSON string.
+func (b *bridge) renderPayload(event *core.BlockchainEvent) (*fftypes.JSONAny, error) {
+	if b.template == nil {
+		eventJSON, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		return fftypes.JSONAnyPtrBytes(eventJSON), nil
+	}
+	buf := new(bytes.Buffer)
+	if err := b.template.Execute(buf, event); err != nil {
+		return nil, err
+	}
+	if json.Valid(buf.Bytes()) {
+		return fftypes.JSONAnyPtrBytes(buf.Bytes()), nil
+	}
+	str, _ := json.Marshal(buf.String())
+	return fftypes.JSONAnyPtrBytes(str), nil
+}
@@ -0,0 +1,348 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/broadcastmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
+	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestBridgeConfig(bridges ...fftypes.JSONObject) {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set("eventbridges", bridges)
+}
+
+func newTestBridge(t *testing.T, conf fftypes.JSONObject) (*bridge, *databasemocks.Plugin, *broadcastmocks.Manager, *privatemessagingmocks.Manager, func()) {
+	conf["namespace"] = "ns1"
+	conf["interval"] = "1ms"
+	newTestBridgeConfig(conf)
+	mdi := &databasemocks.Plugin{}
+	mbm := &broadcastmocks.Manager{}
+	mpm := &privatemessagingmocks.Manager{}
+	ctx, cancel := context.WithCancel(context.Background())
+	bm, err := NewEventBridgeManager(ctx, "ns1", mdi, mbm, mpm)
+	assert.NoError(t, err)
+	return bm.(*bridgeManager).bridges[0], mdi, mbm, mpm, func() {
+		cancel()
+		bm.WaitStop()
+		mdi.AssertExpectations(t)
+		mbm.AssertExpectations(t)
+		mpm.AssertExpectations(t)
+	}
+}
+
+func newTestBlockchainEvent(listener *fftypes.UUID, name string) *core.BlockchainEvent {
+	return &core.BlockchainEvent{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      name,
+		Listener:  listener,
+		Output:    fftypes.JSONObject{"value": "12345"},
+	}
+}
+
+func newTestEvent(seq int64, bcEvent *core.BlockchainEvent) *core.Event {
+	return &core.Event{ID: fftypes.NewUUID(), Sequence: seq, Type: core.EventTypeBlockchainEventReceived, Reference: bcEvent.ID}
+}
+
+func TestNewEventBridgeManagerNoBridges(t *testing.T) {
+	newTestBridgeConfig(fftypes.JSONObject{"name": "bridge1", "namespace": "ns2", "topic": "t1"})
+	bm, err := NewEventBridgeManager(context.Background(), "ns1", &databasemocks.Plugin{}, nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, bm)
+}
+
+func TestNewEventBridgeManagerNilDependency(t *testing.T) {
+	newTestBridgeConfig()
+	_, err := NewEventBridgeManager(context.Background(), "ns1", nil, nil, nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestNewEventBridgeManagerBadConfig(t *testing.T) {
+	for _, tc := range []struct {
+		conf     fftypes.JSONObject
+		expected string
+	}{
+		{fftypes.JSONObject{"topic": "t1"}, "FF10138"},
+		{fftypes.JSONObject{"name": "bridge1"}, "FF10649"},
+		{fftypes.JSONObject{"name": "bridge1", "topic": "t1", "messageType": "wrong"}, "FF10650.*wrong.*bridge1"},
+		{fftypes.JSONObject{"name": "bridge1", "topic": "t1", "messageType": "private"}, "FF10651"},
+		{fftypes.JSONObject{"name": "bridge1", "topic": "t1", "template": "{{ .Output"}, "FF10652"},
+	} {
+		tc.conf["namespace"] = "ns1"
+		newTestBridgeConfig(tc.conf)
+		_, err := NewEventBridgeManager(context.Background(), "ns1", &databasemocks.Plugin{}, &broadcastmocks.Manager{}, &privatemessagingmocks.Manager{})
+		assert.Regexp(t, tc.expected, err)
+	}
+}
+
+func TestNewEventBridgeManagerMessagingDisabled(t *testing.T) {
+	newTestBridgeConfig(fftypes.JSONObject{"name": "bridge1", "namespace": "ns1", "topic": "t1"})
+	_, err := NewEventBridgeManager(context.Background(), "ns1", &databasemocks.Plugin{}, nil, nil)
+	assert.Regexp(t, "FF10653.*broadcast", err)
+
+	newTestBridgeConfig(fftypes.JSONObject{"name": "bridge1", "namespace": "ns1", "topic": "t1", "messageType": "private", "members": []string{"org2"}})
+	_, err = NewEventBridgeManager(context.Background(), "ns1", &databasemocks.Plugin{}, nil, nil)
+	assert.Regexp(t, "FF10653.*private", err)
+}
+
+func TestNewEventBridgeManagerDuplicateName(t *testing.T) {
+	bridge := fftypes.JSONObject{"name": "bridge1", "namespace": "ns1", "topic": "t1"}
+	newTestBridgeConfig(bridge, bridge)
+	_, err := NewEventBridgeManager(context.Background(), "ns1", &databasemocks.Plugin{}, &broadcastmocks.Manager{}, nil)
+	assert.Regexp(t, "FF10648", err)
+}
+
+func TestBridgeLoopNoEvents(t *testing.T) {
+	b, mdi, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "topic": "t1"})
+	polled := make(chan struct{})
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Run(func(args mock.Arguments) {
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	})
+	assert.Equal(t, "bridge1", b.messageTopic)
+	bm := &bridgeManager{bridges: []*bridge{b}}
+	bm.Start()
+	<-polled
+	cleanup()
+}
+
+func TestBridgeLoopFail(t *testing.T) {
+	b, mdi, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "topic": "t1"})
+	polled := make(chan struct{})
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	})
+	bm := &bridgeManager{bridges: []*bridge{b}}
+	bm.Start()
+	<-polled
+	cleanup()
+}
+
+func TestBridgeBatchBroadcast(t *testing.T) {
+	b, mdi, mbm, _, cleanup := newTestBridge(t, fftypes.JSONObject{
+		"name":         "bridge1",
+		"listener":     "listener1",
+		"eventName":    "Changed",
+		"messageTopic": "changes",
+		"messageTag":   "onchain",
+		"template":     `{"changed": {{ json .Output.value }}}`,
+	})
+	defer cleanup()
+
+	listener := &core.ContractListener{ID: fftypes.NewUUID()}
+	bridged := newTestBlockchainEvent(listener.ID, "Changed")
+	already := newTestBlockchainEvent(listener.ID, "Changed")
+	otherListener := newTestBlockchainEvent(fftypes.NewUUID(), "Changed")
+	otherName := newTestBlockchainEvent(listener.ID, "Other")
+	events := []*core.Event{
+		newTestEvent(11, bridged),
+		newTestEvent(12, already),
+		newTestEvent(13, otherListener),
+		newTestEvent(14, otherName),
+		{Sequence: 15, Reference: fftypes.NewUUID()},
+	}
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(&core.Offset{Current: 10}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(events, nil, nil)
+	mdi.On("GetContractListener", mock.Anything, "ns1", "listener1").Return(listener, nil)
+	for _, e := range []*core.BlockchainEvent{bridged, already, otherListener, otherName} {
+		mdi.On("GetBlockchainEventByID", mock.Anything, "ns1", e.ID).Return(e, nil)
+	}
+	mdi.On("GetBlockchainEventByID", mock.Anything, "ns1", events[4].Reference).Return(nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil).Once()
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{{Header: core.MessageHeader{ID: fftypes.NewUUID()}}}, nil, nil)
+	mms := &syncasyncmocks.Sender{}
+	mbm.On("NewBroadcast", mock.MatchedBy(func(in *core.MessageInOut) bool {
+		return in.Header.Type == core.MessageTypeBroadcast &&
+			in.Header.Topics[0] == "changes" &&
+			in.Header.Tag == "onchain" &&
+			in.IdempotencyKey == core.IdempotencyKey("eventbridge:bridge1:"+bridged.ID.String()) &&
+			in.InlineData[0].Value.String() == `{"changed": "12345"}`
+	})).Return(mms)
+	mms.On("Send", mock.Anything).Return(nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Current == 15
+	}), true).Return(nil)
+
+	processed, err := b.bridgeBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, processed)
+	mms.AssertExpectations(t)
+}
+
+func TestBridgeBatchPrivate(t *testing.T) {
+	b, mdi, _, mpm, cleanup := newTestBridge(t, fftypes.JSONObject{
+		"name":        "bridge1",
+		"topic":       "t1",
+		"messageType": "private",
+		"members":     []string{"org2", "org3"},
+	})
+	defer cleanup()
+
+	bcEvent := newTestBlockchainEvent(fftypes.NewUUID(), "Changed")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, bcEvent)}, nil, nil)
+	mdi.On("GetBlockchainEventByID", mock.Anything, "ns1", bcEvent.ID).Return(bcEvent, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil)
+	mms := &syncasyncmocks.Sender{}
+	mpm.On("NewMessage", mock.MatchedBy(func(in *core.MessageInOut) bool {
+		var sent core.BlockchainEvent
+		err := json.Unmarshal([]byte(in.InlineData[0].Value.String()), &sent)
+		return err == nil && sent.ID.Equals(bcEvent.ID) &&
+			in.Header.Type == core.MessageTypePrivate &&
+			len(in.Group.Members) == 2 && in.Group.Members[1].Identity == "org3"
+	})).Return(mms)
+	mms.On("Send", mock.Anything).Return(nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Type == core.OffsetTypeEventBridge && o.Name == "ns1:bridge1" && o.Current == 1
+	}), true).Return(nil)
+
+	processed, err := b.bridgeBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	mms.AssertExpectations(t)
+}
+
+func TestBridgeBatchListenerNotFound(t *testing.T) {
+	listenerID := fftypes.NewUUID()
+	b, mdi, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "listener": listenerID.String()})
+	defer cleanup()
+
+	bcEvent := newTestBlockchainEvent(listenerID, "Changed")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, bcEvent)}, nil, nil)
+	mdi.On("GetContractListenerByID", mock.Anything, "ns1", listenerID).Return(nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+	processed, err := b.bridgeBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
+
+func TestBridgeBatchListenerFail(t *testing.T) {
+	b, mdi, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "listener": "listener1"})
+	defer cleanup()
+
+	bcEvent := newTestBlockchainEvent(fftypes.NewUUID(), "Changed")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, bcEvent)}, nil, nil)
+	mdi.On("GetContractListener", mock.Anything, "ns1", "listener1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := b.bridgeBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBridgeBatchGetEventsFail(t *testing.T) {
+	b, mdi, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "topic": "t1"})
+	defer cleanup()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := b.bridgeBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBridgeBatchGetBlockchainEventFail(t *testing.T) {
+	b, mdi, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "topic": "t1"})
+	defer cleanup()
+
+	bcEvent := newTestBlockchainEvent(fftypes.NewUUID(), "Changed")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, bcEvent)}, nil, nil)
+	mdi.On("GetBlockchainEventByID", mock.Anything, "ns1", bcEvent.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := b.bridgeBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBridgeBatchGetMessagesFail(t *testing.T) {
+	b, mdi, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "topic": "t1"})
+	defer cleanup()
+
+	bcEvent := newTestBlockchainEvent(fftypes.NewUUID(), "Changed")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, bcEvent)}, nil, nil)
+	mdi.On("GetBlockchainEventByID", mock.Anything, "ns1", bcEvent.ID).Return(bcEvent, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := b.bridgeBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBridgeBatchSendFail(t *testing.T) {
+	b, mdi, mbm, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "topic": "t1"})
+	defer cleanup()
+
+	bcEvent := newTestBlockchainEvent(fftypes.NewUUID(), "Changed")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, bcEvent)}, nil, nil)
+	mdi.On("GetBlockchainEventByID", mock.Anything, "ns1", bcEvent.ID).Return(bcEvent, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil)
+	mms := &syncasyncmocks.Sender{}
+	mbm.On("NewBroadcast", mock.Anything).Return(mms)
+	mms.On("Send", mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := b.bridgeBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+	mms.AssertExpectations(t)
+}
+
+func TestBridgeBatchTemplateFailSkipped(t *testing.T) {
+	b, mdi, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "topic": "t1", "template": `{{ .Output.value.missing }}`})
+	defer cleanup()
+
+	bcEvent := newTestBlockchainEvent(fftypes.NewUUID(), "Changed")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeEventBridge, "ns1:bridge1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, bcEvent)}, nil, nil)
+	mdi.On("GetBlockchainEventByID", mock.Anything, "ns1", bcEvent.ID).Return(bcEvent, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(fmt.Errorf("pop"))
+
+	_, err := b.bridgeBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRenderPayloadString(t *testing.T) {
+	b, _, _, _, cleanup := newTestBridge(t, fftypes.JSONObject{"name": "bridge1", "topic": "t1", "template": `{{ .Name }} set to {{ .Output.value }}`})
+	defer cleanup()
+
+	payload, err := b.renderPayload(newTestBlockchainEvent(nil, "Changed"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"Changed set to 12345"`, payload.String())
+}
@@ -0,0 +1,65 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbridge
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+)
+
+const (
+	// BridgeConfName is the name of the bridge, which must be unique within its namespace
+	BridgeConfName = "name"
+	// BridgeConfNamespace is the namespace the bridge follows blockchain events in, and sends messages to
+	BridgeConfNamespace = "namespace"
+	// BridgeConfListener selects the blockchain events of a contract listener, by name or ID
+	BridgeConfListener = "listener"
+	// BridgeConfTopic selects the blockchain events delivered on a topic
+	BridgeConfTopic = "topic"
+	// BridgeConfEventName optionally restricts the bridge to blockchain events with this name
+	BridgeConfEventName = "eventName"
+	// BridgeConfMessageType is the type of message sent for each event - broadcast or private
+	BridgeConfMessageType = "messageType"
+	// BridgeConfMessageTopic is the topic of the messages sent
+	BridgeConfMessageTopic = "messageTopic"
+	// BridgeConfMessageTag is the tag of the messages sent
+	BridgeConfMessageTag = "messageTag"
+	// BridgeConfMembers is the list of identities private messages are sent to
+	BridgeConfMembers = "members"
+	// BridgeConfTemplate is a Go template rendering the payload of each message from the blockchain event
+	BridgeConfTemplate = "template"
+	// BridgeConfBatchSize is the maximum number of events read in each poll
+	BridgeConfBatchSize = "batchSize"
+	// BridgeConfInterval is the time between polls for new events, once the bridge has caught up
+	BridgeConfInterval = "interval"
+)
+
+var bridgesConfig = config.RootArray("eventbridges")
+
+func InitConfig() {
+	bridgesConfig.AddKnownKey(BridgeConfName)
+	bridgesConfig.AddKnownKey(BridgeConfNamespace)
+	bridgesConfig.AddKnownKey(BridgeConfListener)
+	bridgesConfig.AddKnownKey(BridgeConfTopic)
+	bridgesConfig.AddKnownKey(BridgeConfEventName)
+	bridgesConfig.AddKnownKey(BridgeConfMessageType, "broadcast")
+	bridgesConfig.AddKnownKey(BridgeConfMessageTopic)
+	bridgesConfig.AddKnownKey(BridgeConfMessageTag)
+	bridgesConfig.AddKnownKey(BridgeConfMembers)
+	bridgesConfig.AddKnownKey(BridgeConfTemplate)
+	bridgesConfig.AddKnownKey(BridgeConfBatchSize, 50)
+	bridgesConfig.AddKnownKey(BridgeConfInterval, "1s")
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
@@ -37,9 +38,12 @@ import (
 	"github.com/hyperledger/firefly/internal/identity"
 	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -60,6 +64,20 @@ type aggregator struct {
 	metrics      metrics.Manager
 	batchCache   cache.CInterface
 	rewinder     *rewinder
+	checkpointer *checkpointer // only if enabled
+	quarantine   *quarantinePolicy
+	ingestMux    sync.RWMutex
+	batchAcks    bool
+}
+
+// AggregatorStatus is a snapshot of the internal state of the aggregator, for diagnostics
+type AggregatorStatus struct {
+	PollingOffset int64 `ffstruct:"AggregatorStatus" json:"pollingOffset"`
+	QueuedRewinds int   `ffstruct:"AggregatorStatus" json:"queuedRewinds"`
+	StagedRewinds int   `ffstruct:"AggregatorStatus" json:"stagedRewinds"`
+	ReadyRewinds  int   `ffstruct:"AggregatorStatus" json:"readyRewinds"`
+	Checkpoint    int64 `ffstruct:"AggregatorStatus" json:"checkpoint,omitempty"`
+	CompactedPins int64 `ffstruct:"AggregatorStatus" json:"compactedPins,omitempty"`
 }
 
 type batchCacheEntry struct {
@@ -107,6 +125,8 @@ func newAggregator(ctx context.Context, ns string, di database.Plugin, bi blockc
 		data:         dm,
 		verifierType: bi.VerifierType(),
 		metrics:      mm,
+		batchAcks:    pm != nil && config.GetBool(coreconfig.PrivateMessagingAcksEnabled),
+		quarantine:   newQuarantinePolicy(),
 	}
 
 	batchCache, err := cacheManager.GetCache(
@@ -147,12 +167,35 @@ func newAggregator(ctx context.Context, ns string, di database.Plugin, bi blockc
 	})
 	ag.retry = &ag.eventPoller.conf.retry
 	ag.rewinder = newRewinder(ag)
+	if config.GetBool(coreconfig.EventAggregatorCheckpointEnabled) {
+		ag.checkpointer = newCheckpointer(ag)
+	}
 	return ag, nil
 }
 
 func (ag *aggregator) start() {
 	ag.rewinder.start()
 	ag.eventPoller.start()
+	if ag.checkpointer != nil {
+		ag.checkpointer.start()
+	}
+}
+
+func (ag *aggregator) status() *AggregatorStatus {
+	rw := ag.rewinder
+	rw.mux.Lock()
+	status := &AggregatorStatus{
+		PollingOffset: ag.eventPoller.getPollingOffset(),
+		QueuedRewinds: len(rw.queuedRewinds),
+		StagedRewinds: len(rw.stagedRewinds),
+		ReadyRewinds:  len(rw.readyRewinds),
+	}
+	rw.mux.Unlock()
+	if ag.checkpointer != nil {
+		status.Checkpoint = ag.checkpointer.getCheckpoint()
+		status.CompactedPins = ag.checkpointer.getCompacted()
+	}
+	return status
 }
 
 func (ag *aggregator) queueBatchRewind(batchID *fftypes.UUID) {
@@ -199,10 +242,17 @@ func (ag *aggregator) rewindOffchainBatches() (bool, int64) {
 	var offset int64
 	_ = ag.retry.Do(ag.ctx, "check for off-chain batch deliveries", func(attempt int) (retry bool, err error) {
 		pfb := database.PinQueryFactory.NewFilter(ag.ctx)
-		pinFilter := pfb.And(
+		conditions := []ffapi.Filter{
 			pfb.In("batch", batchIDs),
 			pfb.Eq("dispatched", false),
-		).Sort("sequence").Limit(1) // only need the one oldest sequence
+		}
+		if ag.checkpointer != nil {
+			// Every pin up to the checkpoint is dispatched, so only the pins after it need to be searched
+			if checkpoint := ag.checkpointer.getCheckpoint(); checkpoint >= 0 {
+				conditions = append(conditions, pfb.Gt("sequence", checkpoint))
+			}
+		}
+		pinFilter := pfb.And(conditions...).Sort("sequence").Limit(1) // only need the one oldest sequence
 		sequences, _, err := ag.database.GetPins(ag.ctx, ag.namespace, pinFilter)
 		if err != nil {
 			return true, err
@@ -247,18 +297,37 @@ func (ag *aggregator) processWithBatchState(callback func(ctx context.Context, s
 		}
 	}
 	state.queueRewinds(ag)
+	if len(state.aggregatedBatches) > 0 {
+		// Acks go over data exchange, so are sent without holding up the aggregator
+		go state.sendBatchAcks(ag.ctx)
+	}
 	return nil
 }
 
 func (ag *aggregator) processPinsEventsHandler(items []core.LocallySequenced) (repoll bool, err error) {
+	ag.ingestMux.RLock()
+	defer ag.ingestMux.RUnlock()
+
 	pins := make([]*core.Pin, len(items))
+	batchIDs := make([]*fftypes.UUID, len(items))
 	for i, item := range items {
 		pins[i] = item.(*core.Pin)
+		batchIDs[i] = pins[i].Batch
 	}
 
-	return false, ag.processWithBatchState(func(ctx context.Context, state *batchState) error {
+	_, span := tracing.StartSpan(ag.ctx, "aggregator.processPins", tracing.Links(batchIDs...), trace.WithAttributes(
+		attribute.String("firefly.namespace", ag.namespace),
+		attribute.Int("firefly.pins", len(pins)),
+	))
+	err = ag.processWithBatchState(func(ctx context.Context, state *batchState) error {
 		return ag.processPins(ctx, pins, state)
 	})
+	tracing.EndSpan(span, err)
+	if err == nil && len(pins) > 0 && ag.metrics.IsMetricsEnabled() {
+		lag := ag.eventPoller.eventNotifier.getLatestSequence() - pins[len(pins)-1].Sequence
+		ag.metrics.AggregatorPinsProcessed(ag.namespace, len(pins), lag)
+	}
+	return false, err
 }
 
 func (ag *aggregator) getPins(ctx context.Context, filter ffapi.Filter, offset int64) ([]core.LocallySequenced, error) {
@@ -474,6 +543,11 @@ func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchMa
 		cro = data.CRORequirePublicBlobRefs
 	}
 	msg, data, dataAvailable, err := ag.data.GetMessageWithDataCached(ctx, msgEntry.ID, cro)
+	if msg != nil && msg.Trace {
+		ctx = tracing.WithTraceFlag(ctx, "message", msg.Header.ID)
+		l = log.L(ctx)
+		tracing.Logf(ctx, "Processing pin %d of batch '%s' for message '%s' masked=%t", pin.Sequence, manifest.ID, msg.Header.ID, pin.Masked)
+	}
 	switch {
 	case err != nil:
 		return err
@@ -510,7 +584,7 @@ func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchMa
 					l.Errorf("Message '%s' in batch '%s' has invalid pin at index %d: '%s'", msg.Header.ID, manifest.ID, i, pinStr)
 					return nil
 				}
-				nextPin, err := state.checkMaskedContextReady(ctx, msg, batch, msg.Header.Topics[i], pin.Sequence, &msgContext, nonceStr)
+				nextPin, err := state.checkMaskedContextReady(ctx, msg, batch, msg.Header.ContextTopic(msg.Header.Topics[i]), pin.Sequence, &msgContext, nonceStr)
 				if err != nil || nextPin == nil {
 					return err
 				}
@@ -518,7 +592,7 @@ func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchMa
 			}
 		} else {
 			for _, topic := range msg.Header.Topics {
-				msgContext := broadcastContext(topic)
+				msgContext := broadcastContext(msg.Header.ContextTopic(topic))
 				unmaskedContexts = append(unmaskedContexts, msgContext)
 				ready, err := state.checkUnmaskedContextReady(ctx, msgContext, msg, pin.Sequence)
 				if err != nil || !ready {
@@ -528,7 +602,7 @@ func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchMa
 		}
 
 		if action == core.ActionConfirm {
-			l.Debugf("Attempt dispatch msg=%s broadcastContexts=%v privatePins=%v", msg.Header.ID, unmaskedContexts, msg.Pins)
+			tracing.Logf(ctx, "Attempt dispatch msg=%s broadcastContexts=%v privatePins=%v", msg.Header.ID, unmaskedContexts, msg.Pins)
 			action, correlator, err = ag.readyForDispatch(ctx, msg, data, manifest.TX.ID, state)
 		}
 	}
@@ -536,6 +610,9 @@ func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchMa
 	if action == core.ActionRetry {
 		return err
 	} else if action == core.ActionWait {
+		if msg != nil {
+			tracing.Logf(ctx, "Message '%s' in batch '%s' is waiting", msg.Header.ID, manifest.ID)
+		}
 		// We need to prevent dispatch of any subsequent messages on the same topic in the batch
 		for _, unmaskedContext := range unmaskedContexts {
 			state.SetContextBlockedBy(ctx, *unmaskedContext, pin.Sequence)
@@ -549,6 +626,7 @@ func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchMa
 	}
 
 	newState := ag.completeDispatch(action, correlator, msg, manifest.TX.ID, state)
+	tracing.Logf(ctx, "Message '%s' in batch '%s' dispatched with action=%s state=%s", msg.Header.ID, manifest.ID, action, newState)
 
 	// Mark all message pins dispatched, and increment all nextPins
 	for _, np := range nextPins {
@@ -638,6 +716,10 @@ func (ag *aggregator) readyForDispatch(ctx context.Context, msg *core.Message, d
 		correlator = handlerResult.CustomCorrelator
 		action = handlerResult.Action
 
+	case msg.Header.Type == core.MessageTypeGroupInit && msg.Header.Tag == core.SystemTagUpdateGroup:
+		// Metadata updates are applied in-line, in the order they are confirmed within the group
+		action, err = ag.messaging.HandleGroupMetadataUpdate(ctx, msg, data)
+
 	case msg.Header.Type == core.MessageTypeGroupInit:
 		// Already handled as part of resolving the context
 		action = core.ActionConfirm
@@ -663,6 +745,11 @@ func (ag *aggregator) completeDispatch(action core.MessageAction, correlator *ff
 	newState := core.MessageStateConfirmed
 	eventType := core.EventTypeMessageConfirmed
 	if action == core.ActionConfirm {
+		if ag.quarantine.quarantined(msg) {
+			// No events are generated until the message is released, so it is not delivered to subscriptions
+			log.L(ag.ctx).Infof("Message '%s' from '%s' with tag '%s' quarantined", msg.Header.ID, msg.Header.Author, msg.Header.Tag)
+			return core.MessageStateQuarantined
+		}
 		state.AddPendingConfirm(msg.Header.ID, msg)
 	} else {
 		newState = core.MessageStateRejected
@@ -38,6 +38,7 @@ func newBatchState(ag *aggregator) *batchState {
 		maskedContexts:     make(map[fftypes.Bytes32]*nextPinGroupState),
 		unmaskedContexts:   make(map[fftypes.Bytes32]*contextState),
 		dispatchedMessages: make([]*dispatchedMessage, 0),
+		trackBatchAcks:     ag.batchAcks,
 		BatchState: core.BatchState{
 			PendingConfirms: make(map[fftypes.UUID]*core.Message),
 		},
@@ -75,6 +76,7 @@ type dispatchedMessage struct {
 	firstPinIndex int64
 	topicCount    int
 	msgPins       fftypes.FFStringArray
+	private       bool
 	newState      core.MessageState
 	rejectReason  string
 }
@@ -103,6 +105,8 @@ type batchState struct {
 	maskedContexts     map[fftypes.Bytes32]*nextPinGroupState
 	unmaskedContexts   map[fftypes.Bytes32]*contextState
 	dispatchedMessages []*dispatchedMessage
+	trackBatchAcks     bool
+	aggregatedBatches  []*fftypes.UUID
 }
 
 func (bs *batchState) RunPreFinalize(ctx context.Context) error {
@@ -120,6 +124,9 @@ func (bs *batchState) queueRewinds(ag *aggregator) {
 	for _, did := range bs.ConfirmedDIDClaims {
 		ag.queueDIDRewind(did)
 	}
+	for _, msgID := range bs.ApprovedDefinitions {
+		ag.queueMessageRewind(msgID)
+	}
 }
 
 func (bs *batchState) checkUnmaskedContextReady(ctx context.Context, contextUnmasked *fftypes.Bytes32, msg *core.Message, firstMsgPinSequence int64) (bool, error) {
@@ -203,6 +210,7 @@ func (bs *batchState) markMessageDispatched(batchID *fftypes.UUID, msg *core.Mes
 		firstPinIndex: msgBaseIndex,
 		topicCount:    len(msg.Header.Topics),
 		msgPins:       msg.Pins,
+		private:       msg.Header.Group != nil,
 		newState:      newState,
 		rejectReason:  msg.RejectReason,
 	})
@@ -265,8 +273,12 @@ func (bs *batchState) flushPins(ctx context.Context) error {
 	// Note that this might include pins not in the batch we read from the database, as the page size
 	// cannot be guaranteed to overlap with the set of indexes of a message within a batch.
 	pinsDispatched := make(map[fftypes.UUID][]driver.Value)
+	privateBatches := make(map[fftypes.UUID]bool)
 	msgStateUpdates := make(map[core.MessageState][]*fftypes.UUID)
 	for _, dm := range bs.dispatchedMessages {
+		if dm.private {
+			privateBatches[*dm.batchID] = true
+		}
 		batchDispatched := pinsDispatched[*dm.batchID]
 		l.Debugf("Marking message dispatched batch=%s msg=%s firstIndex=%d topics=%d pins=%s", dm.batchID, dm.msgID, dm.firstPinIndex, dm.topicCount, dm.msgPins)
 		for i := 0; i < dm.topicCount; i++ {
@@ -301,6 +313,12 @@ func (bs *batchState) flushPins(ctx context.Context) error {
 		}
 	}
 
+	if bs.trackBatchAcks {
+		if err := bs.checkBatchesAggregated(ctx, privateBatches); err != nil {
+			return err
+		}
+	}
+
 	// Also do the same for each type of state update, to mark messages dispatched with a new state
 	for msgState, msgIDs := range msgStateUpdates {
 		if err := bs.confirmMessages(ctx, msgIDs, msgState, confirmTime, ""); err != nil {
@@ -311,6 +329,34 @@ func (bs *batchState) flushPins(ctx context.Context) error {
 	return nil
 }
 
+// checkBatchesAggregated finds the private batches with no pins left to dispatch, so they can be
+// acknowledged back to their sender once the transaction has committed
+func (bs *batchState) checkBatchesAggregated(ctx context.Context, batchIDs map[fftypes.UUID]bool) error {
+	for batchID := range batchIDs {
+		fb := database.PinQueryFactory.NewFilter(ctx)
+		pins, _, err := bs.database.GetPins(ctx, bs.namespace, fb.And(
+			fb.Eq("batch", batchID),
+			fb.Eq("dispatched", false),
+		).Limit(1))
+		if err != nil {
+			return err
+		}
+		if len(pins) == 0 {
+			id := batchID
+			bs.aggregatedBatches = append(bs.aggregatedBatches, &id)
+		}
+	}
+	return nil
+}
+
+func (bs *batchState) sendBatchAcks(ctx context.Context) {
+	for _, batchID := range bs.aggregatedBatches {
+		if err := bs.messaging.SendBatchAck(ctx, batchID, core.BatchAckStateAggregated); err != nil {
+			log.L(ctx).Warnf("Failed to acknowledge aggregated batch '%s': %s", batchID, err)
+		}
+	}
+}
+
 func (nps *nextPinState) IncrementNextPin(ctx context.Context, namespace string) {
 	npg := nps.nextPinGroup
 	np := nps.nextPin
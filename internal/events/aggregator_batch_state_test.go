@@ -18,8 +18,10 @@ package events
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
@@ -67,6 +69,73 @@ func TestFlushPinsFailUpdateMessages(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestFlushPinsTracksAggregatedBatches(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.batchAcks = true
+	bs := newBatchState(&ag.aggregator)
+	batch1 := fftypes.NewUUID()
+	batch2 := fftypes.NewUUID()
+	msgIDs := []*fftypes.UUID{fftypes.NewUUID(), fftypes.NewUUID(), fftypes.NewUUID()}
+
+	ag.mdi.On("UpdatePins", ag.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, _ := f.Finalize()
+		return strings.Contains(fi.String(), batch1.String())
+	})).Return([]*core.Pin{}, nil, nil)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, _ := f.Finalize()
+		return strings.Contains(fi.String(), batch2.String())
+	})).Return([]*core.Pin{{Batch: batch2}}, nil, nil)
+	ag.mdi.On("UpdateMessages", ag.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+	ag.mdm.On("UpdateMessageStateIfCached", ag.ctx, mock.Anything, core.MessageStateConfirmed, mock.Anything, "").Return()
+
+	// Two private batches, only one of which has been fully aggregated, and a broadcast batch
+	for i, batchID := range []*fftypes.UUID{batch1, batch2, fftypes.NewUUID()} {
+		var group *fftypes.Bytes32
+		if i < 2 {
+			group = fftypes.NewRandB32()
+		}
+		bs.markMessageDispatched(batchID, &core.Message{
+			Header: core.MessageHeader{
+				ID:     msgIDs[i],
+				Group:  group,
+				Topics: fftypes.FFStringArray{"topic1"},
+			},
+			Pins: fftypes.FFStringArray{"pin1"},
+		}, 0, core.MessageStateConfirmed)
+	}
+
+	err := bs.flushPins(ag.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*fftypes.UUID{batch1}, bs.aggregatedBatches)
+
+	ag.mpm.On("SendBatchAck", ag.ctx, batch1, core.BatchAckStateAggregated).Return(fmt.Errorf("pop"))
+	bs.sendBatchAcks(ag.ctx)
+}
+
+func TestFlushPinsFailCheckAggregated(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.batchAcks = true
+	bs := newBatchState(&ag.aggregator)
+
+	ag.mdi.On("UpdatePins", ag.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	bs.markMessageDispatched(fftypes.NewUUID(), &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Group:  fftypes.NewRandB32(),
+			Topics: fftypes.FFStringArray{"topic1"},
+		},
+		Pins: fftypes.FFStringArray{"pin1"},
+	}, 0, core.MessageStateConfirmed)
+
+	err := bs.flushPins(ag.ctx)
+	assert.Regexp(t, "pop", err)
+}
+
 func TestSetContextBlockedByNoState(t *testing.T) {
 	ag := newTestAggregator()
 	defer ag.cleanup(t)
@@ -0,0 +1,175 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// checkpointer periodically compacts the pins the aggregator has finished with into a checkpoint.
+//
+// The checkpoint is the sequence at or below which every pin has been dispatched, and is stored as an offset.
+// Dispatched pins up to the checkpoint are deleted, so the pins table only holds the pins the aggregator might
+// still need. Rewinds only search the pins after the checkpoint, so restart recovery and rewinds scan bounded
+// state however many pins have been processed over the life of the namespace.
+//
+// Next pins are not compacted, as they already hold a single row for each context and member.
+type checkpointer struct {
+	ctx        context.Context
+	aggregator *aggregator
+	database   database.Plugin
+	interval   time.Duration
+	pruneLimit int
+	done       chan struct{}
+	mux        sync.Mutex
+	checkpoint int64
+	compacted  int64
+}
+
+func newCheckpointer(ag *aggregator) *checkpointer {
+	cp := &checkpointer{
+		ctx:        log.WithLogField(ag.ctx, "role", "aggregator-checkpoint"),
+		aggregator: ag,
+		database:   ag.database,
+		interval:   config.GetDuration(coreconfig.EventAggregatorCheckpointInterval),
+		pruneLimit: config.GetInt(coreconfig.EventAggregatorCheckpointPruneLimit),
+		done:       make(chan struct{}),
+		checkpoint: -1,
+	}
+	if cp.pruneLimit < 1 {
+		cp.pruneLimit = 1
+	}
+	return cp
+}
+
+func (cp *checkpointer) start() {
+	go cp.checkpointLoop()
+}
+
+func (cp *checkpointer) getCheckpoint() int64 {
+	cp.mux.Lock()
+	defer cp.mux.Unlock()
+	return cp.checkpoint
+}
+
+func (cp *checkpointer) getCompacted() int64 {
+	cp.mux.Lock()
+	defer cp.mux.Unlock()
+	return cp.compacted
+}
+
+func (cp *checkpointer) checkpointLoop() {
+	defer close(cp.done)
+
+	err := cp.aggregator.retry.Do(cp.ctx, "restore checkpoint", func(attempt int) (retry bool, err error) {
+		return true, cp.restoreCheckpoint()
+	})
+	if err != nil {
+		log.L(cp.ctx).Debugf("Checkpoint loop exiting before restoring checkpoint: %s", err)
+		return
+	}
+
+	for {
+		select {
+		case <-time.After(cp.interval):
+		case <-cp.ctx.Done():
+			log.L(cp.ctx).Debugf("Checkpoint loop exiting")
+			return
+		}
+		if err := cp.compact(cp.ctx); err != nil {
+			log.L(cp.ctx).Errorf("Failed to compact pins into the aggregator checkpoint: %s", err)
+		}
+	}
+}
+
+func (cp *checkpointer) restoreCheckpoint() error {
+	offset, err := cp.database.GetOffset(cp.ctx, core.OffsetTypeAggregatorCheckpoint, aggregatorOffsetName)
+	if err != nil || offset == nil {
+		return err
+	}
+	cp.mux.Lock()
+	cp.checkpoint = offset.Current
+	cp.mux.Unlock()
+	log.L(cp.ctx).Infof("Aggregator checkpoint restored %d", offset.Current)
+	return nil
+}
+
+// compact moves the checkpoint up to the last pin before the oldest one the aggregator has not dispatched,
+// and deletes the dispatched pins up to it. The pins are deleted before the checkpoint is stored, so a failure
+// part way through is picked up by the next run.
+func (cp *checkpointer) compact(ctx context.Context) error {
+	// Nothing past the last pin the aggregator has processed can be compacted
+	target := cp.aggregator.eventPoller.getPollingOffset()
+	fb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := cp.database.GetPins(ctx, cp.aggregator.namespace, fb.And(
+		fb.Eq("dispatched", false),
+		fb.Lte("sequence", target),
+	).Sort("sequence").Limit(1))
+	if err != nil {
+		return err
+	}
+	if len(pins) > 0 {
+		target = pins[0].Sequence - 1
+	}
+	if target <= cp.getCheckpoint() {
+		log.L(ctx).Debugf("Aggregator checkpoint %d not advanced (target=%d)", cp.getCheckpoint(), target)
+		return nil
+	}
+
+	policy := &database.RetentionPolicy{
+		Collection:  database.PrunablePins,
+		Before:      fftypes.Now(),
+		MaxSequence: &target,
+	}
+	var compacted int64
+	for {
+		deleted, err := cp.database.PruneRecords(ctx, cp.aggregator.namespace, policy, cp.pruneLimit)
+		if err != nil {
+			return err
+		}
+		compacted += deleted
+		cp.mux.Lock()
+		cp.compacted += deleted
+		cp.mux.Unlock()
+		if deleted < int64(cp.pruneLimit) {
+			break
+		}
+	}
+
+	err = cp.database.UpsertOffset(ctx, &core.Offset{
+		Type:    core.OffsetTypeAggregatorCheckpoint,
+		Name:    aggregatorOffsetName,
+		Current: target,
+	}, true)
+	if err != nil {
+		return err
+	}
+	cp.mux.Lock()
+	cp.checkpoint = target
+	cp.mux.Unlock()
+	log.L(ctx).Infof("Aggregator checkpoint advanced to %d (compacted=%d)", target, compacted)
+	return nil
+}
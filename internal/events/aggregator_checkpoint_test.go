@@ -0,0 +1,211 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCheckpointer(ag *testAggregator) *checkpointer {
+	cp := newCheckpointer(&ag.aggregator)
+	ag.checkpointer = cp
+	return cp
+}
+
+func TestNewAggregatorCheckpointEnabled(t *testing.T) {
+	coreconfig.Reset()
+	defer coreconfig.Reset()
+	config.Set(coreconfig.EventAggregatorCheckpointEnabled, true)
+	config.Set(coreconfig.EventAggregatorCheckpointPruneLimit, 0)
+
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	assert.NotNil(t, ag.checkpointer)
+	assert.Equal(t, 1, ag.checkpointer.pruneLimit)
+	assert.Equal(t, int64(-1), ag.checkpointer.getCheckpoint())
+}
+
+func TestCheckpointCompactAdvances(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+	cp.pruneLimit = 2
+	ag.eventPoller.pollingOffset = 100
+
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, _ := f.Finalize()
+		return strings.Contains(fi.String(), "dispatched == false") && strings.Contains(fi.String(), "sequence <= 100")
+	})).Return([]*core.Pin{{Sequence: 51}}, nil, nil)
+	ag.mdi.On("PruneRecords", ag.ctx, "ns1", mock.MatchedBy(func(policy *database.RetentionPolicy) bool {
+		return policy.Collection == database.PrunablePins && *policy.MaxSequence == 50
+	}), 2).Return(int64(2), nil).Once()
+	ag.mdi.On("PruneRecords", ag.ctx, "ns1", mock.Anything, 2).Return(int64(1), nil).Once()
+	ag.mdi.On("UpsertOffset", ag.ctx, mock.MatchedBy(func(offset *core.Offset) bool {
+		return offset.Type == core.OffsetTypeAggregatorCheckpoint && offset.Name == aggregatorOffsetName && offset.Current == 50
+	}), true).Return(nil)
+
+	err := cp.compact(ag.ctx)
+	assert.NoError(t, err)
+
+	status := ag.status()
+	assert.Equal(t, int64(50), status.Checkpoint)
+	assert.Equal(t, int64(3), status.CompactedPins)
+}
+
+func TestCheckpointCompactAllDispatched(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+	ag.eventPoller.pollingOffset = 100
+
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	ag.mdi.On("PruneRecords", ag.ctx, "ns1", mock.Anything, 1000).Return(int64(10), nil)
+	ag.mdi.On("UpsertOffset", ag.ctx, mock.MatchedBy(func(offset *core.Offset) bool {
+		return offset.Current == 100
+	}), true).Return(nil)
+
+	err := cp.compact(ag.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), cp.getCheckpoint())
+}
+
+func TestCheckpointCompactNotAdvanced(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+	cp.checkpoint = 50
+	ag.eventPoller.pollingOffset = 100
+
+	// The oldest undispatched pin has not moved on
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{{Sequence: 51}}, nil, nil)
+
+	err := cp.compact(ag.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), cp.getCheckpoint())
+}
+
+func TestCheckpointCompactGetPinsFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := cp.compact(ag.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckpointCompactPruneFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+	ag.eventPoller.pollingOffset = 100
+
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	ag.mdi.On("PruneRecords", ag.ctx, "ns1", mock.Anything, 1000).Return(int64(0), fmt.Errorf("pop"))
+
+	err := cp.compact(ag.ctx)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, int64(-1), cp.getCheckpoint())
+}
+
+func TestCheckpointCompactUpsertOffsetFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+	ag.eventPoller.pollingOffset = 100
+
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	ag.mdi.On("PruneRecords", ag.ctx, "ns1", mock.Anything, 1000).Return(int64(0), nil)
+	ag.mdi.On("UpsertOffset", ag.ctx, mock.Anything, true).Return(fmt.Errorf("pop"))
+
+	err := cp.compact(ag.ctx)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, int64(-1), cp.getCheckpoint())
+}
+
+func TestCheckpointLoop(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+	cp.interval = 0
+	ag.eventPoller.pollingOffset = 100
+
+	ag.mdi.On("GetOffset", mock.Anything, core.OffsetTypeAggregatorCheckpoint, aggregatorOffsetName).Return(nil, fmt.Errorf("pop")).Once()
+	ag.mdi.On("GetOffset", mock.Anything, core.OffsetTypeAggregatorCheckpoint, aggregatorOffsetName).Return(&core.Offset{Current: 42}, nil).Once()
+	ag.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	ag.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	ag.mdi.On("PruneRecords", mock.Anything, "ns1", mock.Anything, 1000).Return(int64(0), nil)
+	ag.mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil).Run(func(args mock.Arguments) {
+		ag.cancel()
+	})
+
+	cp.start()
+	<-cp.done
+
+	assert.Equal(t, int64(100), cp.getCheckpoint())
+}
+
+func TestCheckpointLoopExitBeforeRestore(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+
+	ag.mdi.On("GetOffset", mock.Anything, core.OffsetTypeAggregatorCheckpoint, aggregatorOffsetName).Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		ag.cancel()
+	})
+
+	cp.start()
+	<-cp.done
+
+	assert.Equal(t, int64(-1), cp.getCheckpoint())
+}
+
+func TestRewindOffchainBatchesAfterCheckpoint(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	cp := newTestCheckpointer(ag)
+	cp.checkpoint = 12000
+
+	batchID := fftypes.NewUUID()
+	ag.rewinder.readyRewinds = map[fftypes.UUID]bool{
+		*batchID: true,
+	}
+
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, _ := f.Finalize()
+		return strings.Contains(fi.String(), "sequence >> 12000")
+	})).Return([]*core.Pin{
+		{Sequence: 12345, Batch: batchID},
+	}, nil, nil)
+
+	rewind, offset := ag.rewindOffchainBatches()
+	assert.True(t, rewind)
+	assert.Equal(t, int64(12344), offset)
+}
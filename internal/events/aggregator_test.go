@@ -85,6 +85,7 @@ func newTestAggregatorCommon(metrics bool) *testAggregator {
 	mbi := &blockchainmocks.Plugin{}
 	if metrics {
 		mmi.On("MessageConfirmed", mock.Anything, core.EventTypeMessageConfirmed).Return()
+		mmi.On("AggregatorPinsProcessed", "ns1", mock.Anything, mock.Anything).Return().Maybe()
 	}
 	mmi.On("IsMetricsEnabled").Return(metrics).Maybe()
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
@@ -775,6 +776,33 @@ func TestProcessPinsDBGroupFail(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestProcessPinsEventsHandlerMetrics(t *testing.T) {
+	ag := newTestAggregatorWithMetrics()
+	defer ag.cleanup(t)
+
+	rag := ag.mdi.On("RunAsGroup", ag.ctx, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{
+			a[1].(func(context.Context) error)(a[0].(context.Context)),
+		}
+	}
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", mock.Anything).Return(nil, nil)
+
+	en := ag.eventPoller.eventNotifier
+	en.cond.L.Lock()
+	en.latestSequence = 12350
+	en.cond.L.Unlock()
+
+	_, err := ag.processPinsEventsHandler([]core.LocallySequenced{
+		&core.Pin{
+			Sequence: 12345,
+			Batch:    fftypes.NewUUID(),
+		},
+	})
+	assert.NoError(t, err)
+	ag.mmi.AssertCalled(t, "AggregatorPinsProcessed", "ns1", 1, int64(5))
+}
+
 func TestGetPins(t *testing.T) {
 	ag := newTestAggregator()
 	defer ag.cleanup(t)
@@ -2089,6 +2117,29 @@ func TestReadyForDispatchGroupInit(t *testing.T) {
 
 }
 
+func TestReadyForDispatchGroupMetadataUpdate(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	bs := newBatchState(&ag.aggregator)
+	org1 := newTestOrg("org1")
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			Type:      core.MessageTypeGroupInit,
+			Tag:       core.SystemTagUpdateGroup,
+			SignerRef: core.SignerRef{Key: "0x12345", Author: org1.DID},
+		},
+	}
+	data := core.DataArray{{ID: fftypes.NewUUID()}}
+	ag.mpm.On("HandleGroupMetadataUpdate", ag.ctx, msg, data).Return(core.ActionReject, nil)
+
+	action, _, err := ag.readyForDispatch(ag.ctx, msg, data, nil, bs)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionReject, action)
+
+}
+
 func TestRewindOffchainBatchesNoBatches(t *testing.T) {
 	ag := newTestAggregator()
 	defer ag.cleanup(t)
@@ -2304,6 +2355,7 @@ func TestProcessWithBatchRewindsSuccess(t *testing.T) {
 
 	err := ag.processWithBatchState(func(ctx context.Context, actions *batchState) error {
 		actions.AddConfirmedDIDClaim("did:firefly:org/test")
+		actions.AddApprovedDefinition(fftypes.NewUUID())
 		return nil
 	})
 	assert.NoError(t, err)
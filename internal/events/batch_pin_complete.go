@@ -46,6 +46,14 @@ func (em *eventManager) handleBlockchainBatchPinEvent(ctx context.Context, event
 		log.L(ctx).Debugf("Ignoring batch pin from different namespace '%s'", event.Namespace)
 		return nil // move on
 	}
+	// Switch to the next contract if this pin has reached the activation block of an agreed migration
+	if err := em.multiparty.CheckContractMigration(ctx, &batchPin.Event); err != nil {
+		return err
+	}
+	// Apply any network actions that were broadcast in advance, and have reached their effective point
+	if err := em.applyScheduledNetworkActions(ctx, &batchPin.Event, bc); err != nil {
+		return err
+	}
 
 	if batchPin.TransactionType == "" {
 		batchPin.TransactionType = core.TransactionTypeBatchPin
@@ -65,8 +73,9 @@ func (em *eventManager) handleBlockchainBatchPinEvent(ctx context.Context, event
 		ID:           batchPin.TransactionID,
 		BlockchainID: batchPin.Event.BlockchainTXID,
 	})
-	// Defer the event insert itself to the end
+	// Defer the event insert itself, and the pins, to the end
 	bc.addEventToInsert(chainEvent, em.getTopicForChainListener(nil))
+	bc.pinsToInsert = append(bc.pinsToInsert, em.buildContextPins(batchPin, event.SigningKey)...)
 	bc.postInsert = append(bc.postInsert, func() error {
 		em.emitBlockchainEventMetric(&batchPin.Event)
 		return em.postBlockchainBatchPinEventInsert(ctx, event)
@@ -77,10 +86,6 @@ func (em *eventManager) handleBlockchainBatchPinEvent(ctx context.Context, event
 func (em *eventManager) postBlockchainBatchPinEventInsert(ctx context.Context, event *blockchain.BatchPinCompleteEvent) error {
 	batchPin := event.Batch
 	private := batchPin.BatchPayloadRef == ""
-	if err := em.persistContexts(ctx, batchPin, event.SigningKey, private); err != nil {
-		return err
-	}
-
 	batch, _, err := em.aggregator.GetBatchForPin(ctx, &core.Pin{
 		Batch:     batchPin.BatchID,
 		BatchHash: batchPin.BatchHash,
@@ -102,7 +107,8 @@ func (em *eventManager) persistBatchTransaction(ctx context.Context, batchPin *b
 	return err
 }
 
-func (em *eventManager) persistContexts(ctx context.Context, batchPin *blockchain.BatchPin, signingKey *core.VerifierRef, private bool) error {
+func (em *eventManager) buildContextPins(batchPin *blockchain.BatchPin, signingKey *core.VerifierRef) []*core.Pin {
+	private := batchPin.BatchPayloadRef == ""
 	pins := make([]*core.Pin, len(batchPin.Contexts))
 	for idx, hash := range batchPin.Contexts {
 		pins[idx] = &core.Pin{
@@ -116,7 +122,11 @@ func (em *eventManager) persistContexts(ctx context.Context, batchPin *blockchai
 			Created:   fftypes.Now(),
 		}
 	}
+	return pins
+}
 
+// persistPins writes the pins of all the batches in a poll cycle
+func (em *eventManager) persistPins(ctx context.Context, pins []*core.Pin) error {
 	// First attempt a single batch insert
 	err := em.database.InsertPins(ctx, pins)
 	if err == nil {
@@ -86,6 +86,9 @@ func sampleBatch(t *testing.T, batchType core.BatchType, txType core.Transaction
 func TestBatchPinCompleteOkBroadcast(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mmp.On("CheckContractMigration", mock.Anything, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", mock.Anything, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
 
 	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
@@ -148,6 +151,9 @@ func TestBatchPinCompleteOkBroadcast(t *testing.T) {
 func TestBatchPinCompleteOkBroadcastExistingBatch(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mmp.On("CheckContractMigration", mock.Anything, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", mock.Anything, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
 
 	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
@@ -217,6 +223,9 @@ func TestBatchPinCompleteOkBroadcastExistingBatch(t *testing.T) {
 func TestBatchPinCompleteOkPrivate(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mmp.On("CheckContractMigration", mock.Anything, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", mock.Anything, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
 
 	batchPin := &blockchain.BatchPin{
 		TransactionID: fftypes.NewUUID(),
@@ -260,9 +269,71 @@ func TestBatchPinCompleteOkPrivate(t *testing.T) {
 
 }
 
+func TestBatchPinCompleteCoalescesPins(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.mmp.On("CheckContractMigration", mock.Anything, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", mock.Anything, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
+
+	batchPin1 := &blockchain.BatchPin{
+		TransactionID: fftypes.NewUUID(),
+		BatchID:       fftypes.NewUUID(),
+		Contexts:      []*fftypes.Bytes32{fftypes.NewRandB32(), fftypes.NewRandB32()},
+		Event: blockchain.Event{
+			BlockchainTXID: "0x12345",
+			ProtocolID:     "10/20/30",
+		},
+	}
+	batchPin2 := &blockchain.BatchPin{
+		TransactionID: fftypes.NewUUID(),
+		BatchID:       fftypes.NewUUID(),
+		Contexts:      []*fftypes.Bytes32{fftypes.NewRandB32()},
+		Event: blockchain.Event{
+			BlockchainTXID: "0x67890",
+			ProtocolID:     "10/20/31",
+		},
+	}
+
+	em.mth.On("PersistTransaction", mock.Anything, mock.Anything, core.TransactionTypeBatchPin, mock.Anything).Return(true, nil)
+	rag := em.mdi.On("RunAsGroup", mock.Anything, mock.Anything).Return(nil)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{
+			a[1].(func(ctx context.Context) error)(a[0].(context.Context)),
+		}
+	}
+	em.mth.On("InsertNewBlockchainEvents", mock.Anything, mock.MatchedBy(func(e []*core.BlockchainEvent) bool {
+		return len(e) == 2
+	})).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}, {ID: fftypes.NewUUID()}}, nil).Once()
+	em.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil).Twice()
+	em.mdi.On("InsertPins", mock.Anything, mock.MatchedBy(func(pins []*core.Pin) bool {
+		return len(pins) == 3 &&
+			pins[0].Batch.Equals(batchPin1.BatchID) && pins[0].Index == 0 &&
+			pins[1].Batch.Equals(batchPin1.BatchID) && pins[1].Index == 1 &&
+			pins[2].Batch.Equals(batchPin2.BatchID) && pins[2].Index == 0 && pins[2].Masked
+	})).Return(nil).Once()
+	em.mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+
+	signingKey := &core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		{
+			Type:             blockchain.EventTypeBatchPinComplete,
+			BatchPinComplete: &blockchain.BatchPinCompleteEvent{Namespace: "ns1", Batch: batchPin1, SigningKey: signingKey},
+		},
+		{
+			Type:             blockchain.EventTypeBatchPinComplete,
+			BatchPinComplete: &blockchain.BatchPinCompleteEvent{Namespace: "ns1", Batch: batchPin2, SigningKey: signingKey},
+		},
+	})
+	assert.NoError(t, err)
+}
+
 func TestBatchPinCompleteInsertPinsFail(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mmp.On("CheckContractMigration", mock.Anything, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", mock.Anything, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
 	em.cancel()
 
 	batchPin := &blockchain.BatchPin{
@@ -305,6 +376,9 @@ func TestBatchPinCompleteInsertPinsFail(t *testing.T) {
 func TestBatchPinCompleteGetBatchByIDFails(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mmp.On("CheckContractMigration", mock.Anything, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", mock.Anything, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
 	em.cancel()
 
 	batchPin := &blockchain.BatchPin{
@@ -347,6 +421,9 @@ func TestBatchPinCompleteGetBatchByIDFails(t *testing.T) {
 func TestSequencedBroadcastInitiateDownloadFail(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mmp.On("CheckContractMigration", mock.Anything, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", mock.Anything, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
 
 	batchPin := &blockchain.BatchPin{
 		TransactionID:   fftypes.NewUUID(),
@@ -436,6 +513,46 @@ func TestBatchPinCompleteWrongNamespace(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBatchPinCompleteCheckMigrationFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	batch := &blockchain.BatchPin{
+		TransactionID: fftypes.NewUUID(),
+		Event: blockchain.Event{
+			BlockchainTXID: "0x12345",
+		},
+	}
+	em.mmp.On("CheckContractMigration", mock.Anything, &batch.Event).Return(fmt.Errorf("pop"))
+
+	err := em.handleBlockchainBatchPinEvent(em.ctx, &blockchain.BatchPinCompleteEvent{
+		Namespace: "ns1",
+		Batch:     batch,
+	}, nil)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBatchPinCompleteApplyScheduledFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	batch := &blockchain.BatchPin{
+		TransactionID: fftypes.NewUUID(),
+		Event: blockchain.Event{
+			BlockchainTXID: "0x12345",
+		},
+	}
+	em.mmp.On("CheckContractMigration", mock.Anything, &batch.Event).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", mock.Anything, []*core.ScheduledNetworkAction{}, &batch.Event).Return(nil, fmt.Errorf("pop"))
+
+	err := em.handleBlockchainBatchPinEvent(em.ctx, &blockchain.BatchPinCompleteEvent{
+		Namespace: "ns1",
+		Batch:     batch,
+	}, &eventBatchContext{topicsByEventID: map[string]string{}})
+	assert.EqualError(t, err, "pop")
+}
+
 func TestBatchPinCompleteNonMultiparty(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -0,0 +1,173 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// batchStreamReader counts the bytes read from a downloaded batch, and records any failure of the
+// underlying stream - which is retryable, unlike a batch that fails to parse or verify
+type batchStreamReader struct {
+	r       io.Reader
+	size    int64
+	readErr error
+}
+
+func (br *batchStreamReader) Read(p []byte) (int, error) {
+	n, err := br.r.Read(p)
+	br.size += int64(n)
+	if err != nil && err != io.EOF {
+		br.readErr = err
+	}
+	return n, err
+}
+
+// decodeBatchStream parses a serialized batch one entry at a time, so the raw payload is never held in
+// memory alongside the parsed batch. Each message and data entry is verified against its hash as soon as
+// it is parsed, so a batch with a mismatch is rejected without reading the rest of the stream.
+// The batch hash (over the manifest of all entries) is still verified when the batch is persisted.
+func decodeBatchStream(ctx context.Context, r io.Reader) (*core.Batch, error) {
+	dec := json.NewDecoder(r)
+	header := make(map[string]json.RawMessage)
+	var payload core.BatchPayload
+	err := decodeStreamObject(ctx, dec, func(key string) error {
+		if strings.EqualFold(key, "payload") {
+			return decodeBatchStreamPayload(ctx, dec, &payload)
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		header[key] = value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The header fields are small, so are parsed in one go once the payload has been streamed
+	batch := &core.Batch{}
+	b, _ := json.Marshal(header)
+	if err := json.Unmarshal(b, batch); err != nil {
+		return nil, err
+	}
+	batch.Payload = payload
+	return batch, nil
+}
+
+func decodeBatchStreamPayload(ctx context.Context, dec *json.Decoder, payload *core.BatchPayload) error {
+	return decodeStreamObject(ctx, dec, func(key string) error {
+		switch strings.ToLower(key) {
+		case "messages":
+			return decodeStreamArray(ctx, dec, func() error {
+				var msg *core.Message
+				if err := dec.Decode(&msg); err != nil {
+					return err
+				}
+				if msg != nil {
+					if err := msg.Verify(ctx); err != nil {
+						return i18n.WrapError(ctx, err, coremsgs.MsgBatchStreamInvalidMessage, len(payload.Messages))
+					}
+				}
+				payload.Messages = append(payload.Messages, msg)
+				return nil
+			})
+		case "data":
+			return decodeStreamArray(ctx, dec, func() error {
+				var data *core.Data
+				if err := dec.Decode(&data); err != nil {
+					return err
+				}
+				if data != nil {
+					hash, err := data.CalcHash(ctx)
+					if err != nil {
+						return err
+					}
+					if data.Hash == nil || *data.Hash != *hash {
+						return i18n.NewError(ctx, coremsgs.MsgBatchStreamDataHashMismatch, len(payload.Data), data.Hash, hash)
+					}
+				}
+				payload.Data = append(payload.Data, data)
+				return nil
+			})
+		case "tx":
+			return dec.Decode(&payload.TX)
+		case "signature":
+			return dec.Decode(&payload.Signature)
+		default:
+			var ignored json.RawMessage
+			return dec.Decode(&ignored)
+		}
+	})
+}
+
+// decodeStreamObject calls fn for each key in a JSON object, which must consume the value.
+// A null is treated as an empty object, as it would be by json.Unmarshal.
+func decodeStreamObject(ctx context.Context, dec *json.Decoder, fn func(key string) error) error {
+	if isNull, err := expectStreamDelim(ctx, dec, '{'); isNull || err != nil {
+		return err
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := t.(string)
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // closing brace
+	return err
+}
+
+// decodeStreamArray calls fn for each entry in a JSON array, which must consume the entry.
+// A null is treated as an empty array, as it would be by json.Unmarshal.
+func decodeStreamArray(ctx context.Context, dec *json.Decoder, fn func() error) error {
+	if isNull, err := expectStreamDelim(ctx, dec, '['); isNull || err != nil {
+		return err
+	}
+	for dec.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // closing bracket
+	return err
+}
+
+func expectStreamDelim(ctx context.Context, dec *json.Decoder, delim json.Delim) (isNull bool, err error) {
+	t, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+	if t == nil {
+		return true, nil
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return false, i18n.NewError(ctx, coremsgs.MsgBatchStreamUnexpectedToken, delim, dec.InputOffset())
+	}
+	return false, nil
+}
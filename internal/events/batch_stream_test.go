@@ -0,0 +1,96 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeBatchStreamMatchesUnmarshal(t *testing.T) {
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`{"some":"data"}`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	batch.Payload.Signature = &core.PayloadSignature{Algorithm: core.PayloadSignatureAlgorithmEd25519, PublicKey: "cHVi", Signature: "c2ln"}
+	b, _ := json.Marshal(&batch)
+
+	var expected *core.Batch
+	err := json.Unmarshal(b, &expected)
+	assert.NoError(t, err)
+
+	decoded, err := decodeBatchStream(context.Background(), strings.NewReader(string(b)))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, decoded)
+}
+
+func TestDecodeBatchStreamNulls(t *testing.T) {
+	id := fftypes.NewUUID()
+	decoded, err := decodeBatchStream(context.Background(), strings.NewReader(`{
+		"id": "`+id.String()+`",
+		"unknown": {"ignored": true},
+		"payload": {"messages": null, "data": null, "other": [1,2,3]}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, id, decoded.ID)
+	assert.Empty(t, decoded.Payload.Messages)
+	assert.Empty(t, decoded.Payload.Data)
+
+	decoded, err = decodeBatchStream(context.Background(), strings.NewReader(`{"payload": null}`))
+	assert.NoError(t, err)
+	assert.Nil(t, decoded.ID)
+}
+
+func TestDecodeBatchStreamUnexpectedToken(t *testing.T) {
+	_, err := decodeBatchStream(context.Background(), strings.NewReader(`{"payload": "wrong"}`))
+	assert.Regexp(t, "FF10624", err)
+
+	_, err = decodeBatchStream(context.Background(), strings.NewReader(`{"payload": {"data": {}}}`))
+	assert.Regexp(t, "FF10624", err)
+}
+
+func TestDecodeBatchStreamBadJSON(t *testing.T) {
+	_, err := decodeBatchStream(context.Background(), strings.NewReader(`{"payload": {"data": [!`))
+	assert.Error(t, err)
+
+	_, err = decodeBatchStream(context.Background(), strings.NewReader(`{"id": "not a uuid"}`))
+	assert.Error(t, err)
+}
+
+func TestDecodeBatchStreamInvalidMessage(t *testing.T) {
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	batch.Payload.Messages[0].Hash = fftypes.NewRandB32()
+	b, _ := json.Marshal(&batch)
+
+	_, err := decodeBatchStream(context.Background(), strings.NewReader(string(b)))
+	assert.Regexp(t, "FF10626", err)
+}
+
+func TestDecodeBatchStreamDataHashMismatch(t *testing.T) {
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	data.Value = fftypes.JSONAnyPtr(`"tampered"`)
+	b, _ := json.Marshal(&batch)
+
+	_, err := decodeBatchStream(context.Background(), strings.NewReader(string(b)))
+	assert.Regexp(t, "FF10625", err)
+}
@@ -22,15 +22,22 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type eventBatchContext struct {
 	contractListenerResults map[string]*core.ContractListener
 	topicsByEventID         map[string]string
 	chainEventsToInsert     []*core.BlockchainEvent
+	pinsToInsert            []*core.Pin
 	postInsert              []func() error
+	scheduled               []*core.ScheduledNetworkAction
+	scheduledChanged        bool
 }
 
 func (bc *eventBatchContext) addEventToInsert(event *core.BlockchainEvent, topic string) {
@@ -38,6 +45,33 @@ func (bc *eventBatchContext) addEventToInsert(event *core.BlockchainEvent, topic
 	bc.topicsByEventID[event.ID.String()] = topic
 }
 
+// getScheduled returns the schedule of network actions, including the changes made by earlier events in this batch.
+// Changes are only swapped into the multiparty manager once the batch commits, so a retry starts from the same schedule.
+func (bc *eventBatchContext) getScheduled(mm multiparty.Manager) []*core.ScheduledNetworkAction {
+	if bc.scheduled == nil {
+		bc.scheduled = mm.GetScheduledNetworkActions()
+	}
+	return bc.scheduled
+}
+
+func (bc *eventBatchContext) setScheduled(scheduled []*core.ScheduledNetworkAction) {
+	bc.scheduled = scheduled
+	bc.scheduledChanged = true
+}
+
+// applyScheduledNetworkActions applies any scheduled network actions that the event has reached the effective point of
+func (em *eventManager) applyScheduledNetworkActions(ctx context.Context, event *blockchain.Event, bc *eventBatchContext) error {
+	scheduled := bc.getScheduled(em.multiparty)
+	remaining, err := em.multiparty.ApplyScheduledNetworkActions(ctx, scheduled, event)
+	if err != nil {
+		return err
+	}
+	if len(remaining) != len(scheduled) {
+		bc.setScheduled(remaining)
+	}
+	return nil
+}
+
 func buildBlockchainEvent(ns string, subID *fftypes.UUID, event *blockchain.Event, tx *core.BlockchainTransactionRef) *core.BlockchainEvent {
 	ev := &core.BlockchainEvent{
 		ID:         fftypes.NewUUID(),
@@ -137,13 +171,59 @@ func (em *eventManager) emitBlockchainEventMetric(event *blockchain.Event) {
 	}
 }
 
-func (em *eventManager) BlockchainEventBatch(batch []*blockchain.EventToDispatch) error {
-	return em.retry.Do(em.ctx, "persist blockchain event", func(attempt int) (bool, error) {
+func (em *eventManager) BlockchainEventBatch(batch []*blockchain.EventToDispatch) (err error) {
+	if em.confirmations != nil {
+		// Events with a block number are held until they have the required confirmations
+		if batch, err = em.confirmations.holdBlockchainEvents(batch); err != nil || len(batch) == 0 {
+			return err
+		}
+	}
+	return em.processBlockchainEventBatch(batch)
+}
+
+// processBlockchainEventBatch persists the events before a re-org, then reverts the re-org, then persists the
+// events after it - so the events of the replacing blocks are processed against the reverted state
+func (em *eventManager) processBlockchainEventBatch(batch []*blockchain.EventToDispatch) error {
+	for i, event := range batch {
+		if event.Type == blockchain.EventTypeReorg {
+			if err := em.persistBlockchainEventBatch(batch[:i]); err != nil {
+				return err
+			}
+			if err := em.handleBlockchainReorg(event.Reorg); err != nil {
+				return err
+			}
+			return em.processBlockchainEventBatch(batch[i+1:])
+		}
+	}
+	return em.persistBlockchainEventBatch(batch)
+}
+
+func (em *eventManager) persistBlockchainEventBatch(batch []*blockchain.EventToDispatch) (err error) {
+	if len(batch) == 0 {
+		return nil
+	}
+	em.ingestMux.RLock()
+	defer em.ingestMux.RUnlock()
+
+	// Link to the spans that flushed the batches being confirmed, if they were flushed by this node
+	var batchIDs []*fftypes.UUID
+	for _, event := range batch {
+		if event.Type == blockchain.EventTypeBatchPinComplete && event.BatchPinComplete.Batch != nil {
+			batchIDs = append(batchIDs, event.BatchPinComplete.Batch.BatchID)
+		}
+	}
+	spanCtx, span := tracing.StartSpan(em.ctx, "events.BlockchainEventBatch", tracing.Links(batchIDs...), trace.WithAttributes(
+		attribute.String("firefly.namespace", em.namespace.Name),
+		attribute.Int("firefly.events", len(batch)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return em.retry.Do(spanCtx, "persist blockchain event", func(attempt int) (bool, error) {
 		bc := &eventBatchContext{
 			contractListenerResults: make(map[string]*core.ContractListener),
 			topicsByEventID:         make(map[string]string),
 		}
-		return true, em.database.RunAsGroup(em.ctx, func(ctx context.Context) error {
+		err := em.database.RunAsGroup(spanCtx, func(ctx context.Context) error {
 			// Process the events, generating the optimized list of event inserts
 			for _, event := range batch {
 				switch event.Type {
@@ -161,20 +241,34 @@ func (em *eventManager) BlockchainEventBatch(batch []*blockchain.EventToDispatch
 					}
 				}
 			}
-			// Do the optimized inserts
+			// Do the optimized inserts - the blockchain events, and the pins of every batch in the poll
+			// cycle, are each written with a single multi-row insert. The events emitted for them are
+			// coalesced into a single insert when the group commits.
 			if len(bc.chainEventsToInsert) > 0 {
 				if err := em.maybePersistBlockchainEvents(ctx, bc); err != nil {
 					return err
 				}
 			}
+			if len(bc.pinsToInsert) > 0 {
+				if err := em.persistPins(ctx, bc.pinsToInsert); err != nil {
+					return err
+				}
+			}
 			// Batch pins require processing after the event is inserted
 			for _, postEvent := range bc.postInsert {
 				if err := postEvent(); err != nil {
 					return err
 				}
 			}
+			if bc.scheduledChanged {
+				return em.multiparty.PersistScheduledNetworkActions(ctx, bc.scheduled)
+			}
 			return nil
 		})
+		if err == nil && bc.scheduledChanged {
+			em.multiparty.SetScheduledNetworkActions(bc.scheduled)
+		}
+		return true, err
 	})
 }
 
@@ -0,0 +1,208 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const reorgPageSize = 100
+
+// handleBlockchainReorg reverts everything that was derived from the blocks removed by a re-org, and records
+// what was reverted in a blockchain_reorg event. Events from the replacing blocks are delivered after this,
+// and are processed exactly as if they were new.
+func (em *eventManager) handleBlockchainReorg(reorg *blockchain.ReorgEvent) error {
+	em.ingestMux.RLock()
+	defer em.ingestMux.RUnlock()
+
+	log.L(em.ctx).Warnf("Reverting blockchain events from block %d after re-org reported by %s", reorg.BlockNumber, reorg.Source)
+	return em.retry.Do(em.ctx, "revert blockchain reorg", func(attempt int) (bool, error) {
+		record := &core.BlockchainReorg{
+			ID:          fftypes.NewUUID(),
+			Namespace:   em.namespace.Name,
+			Source:      reorg.Source,
+			BlockNumber: reorg.BlockNumber,
+			BlockHash:   reorg.BlockHash,
+			Reverted:    &core.BlockchainReorgReverted{},
+			Created:     fftypes.Now(),
+		}
+		err := em.database.RunAsGroup(em.ctx, func(ctx context.Context) error {
+			if err := em.revertBlockchainEvents(ctx, reorg, record.Reverted); err != nil {
+				return err
+			}
+			if err := em.database.InsertBlockchainReorg(ctx, record); err != nil {
+				return err
+			}
+			event := core.NewEvent(core.EventTypeBlockchainReorg, em.namespace.Name, record.ID, nil, em.getTopicForChainListener(nil))
+			return em.database.InsertEvent(ctx, event)
+		})
+		return err != nil, err
+	})
+}
+
+func (em *eventManager) revertBlockchainEvents(ctx context.Context, reorg *blockchain.ReorgEvent, reverted *core.BlockchainReorgReverted) error {
+	// Each page is deleted as it is processed, so the same query returns the next page
+	fb := database.BlockchainEventQueryFactory.NewFilter(ctx)
+	filter := fb.And(fb.Gte("protocolid", reorg.FromProtocolID)).Sort("protocolid").Limit(reorgPageSize)
+	for {
+		events, _, err := em.database.GetBlockchainEvents(ctx, em.namespace.Name, filter)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		for _, event := range events {
+			if err := em.revertBlockchainEvent(ctx, event, reverted); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (em *eventManager) revertBlockchainEvent(ctx context.Context, event *core.BlockchainEvent, reverted *core.BlockchainReorgReverted) error {
+	if err := em.revertTokenTransfers(ctx, event, reverted); err != nil {
+		return err
+	}
+	if (event.TX.Type == core.TransactionTypeBatchPin || event.TX.Type == core.TransactionTypeContractInvokePin) && event.TX.ID != nil {
+		if err := em.revertBatchPins(ctx, event.TX.ID, reverted); err != nil {
+			return err
+		}
+	}
+	if err := em.database.DeleteBlockchainEvent(ctx, em.namespace.Name, event.ID); err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Reverted blockchain event %s protocolId=%s", event.ID, event.ProtocolID)
+	reverted.BlockchainEvents = append(reverted.BlockchainEvents, event.ID)
+	return nil
+}
+
+func (em *eventManager) revertTokenTransfers(ctx context.Context, event *core.BlockchainEvent, reverted *core.BlockchainReorgReverted) error {
+	fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+	transfers, _, err := em.database.GetTokenTransfers(ctx, em.namespace.Name, fb.And(fb.Eq("blockchainevent", event.ID)))
+	if err != nil {
+		return err
+	}
+	for _, transfer := range transfers {
+		// Moving the amount back from the recipient to the sender restores the balances before the transfer
+		reversal := *transfer
+		reversal.From, reversal.To = transfer.To, transfer.From
+		if err := em.database.UpdateTokenBalances(ctx, &reversal); err != nil {
+			return err
+		}
+		if err := em.database.DeleteTokenTransfer(ctx, em.namespace.Name, transfer.LocalID); err != nil {
+			return err
+		}
+		reverted.TokenTransfers = append(reverted.TokenTransfers, transfer.LocalID)
+	}
+	return nil
+}
+
+func (em *eventManager) revertBatchPins(ctx context.Context, txID *fftypes.UUID, reverted *core.BlockchainReorgReverted) error {
+	fb := database.BatchQueryFactory.NewFilter(ctx)
+	batches, _, err := em.database.GetBatches(ctx, em.namespace.Name, fb.And(fb.Eq("tx.id", txID)))
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		// The pins are inserted again when they are re-delivered from the replacing blocks, so every message
+		// in the batch is aggregated again - including the messages that were already dispatched
+		if err := em.database.DeleteBatchPins(ctx, em.namespace.Name, batch.ID); err != nil {
+			return err
+		}
+		reverted.Batches = append(reverted.Batches, batch.ID)
+
+		mfb := database.MessageQueryFactory.NewFilter(ctx)
+		msgs, _, err := em.database.GetMessages(ctx, em.namespace.Name, mfb.And(
+			mfb.Eq("batch", batch.ID),
+			mfb.In("state", []driver.Value{core.MessageStateConfirmed, core.MessageStateRejected, core.MessageStateQuarantined}),
+		))
+		if err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			if err := em.revertMessageConfirmation(ctx, msg); err != nil {
+				return err
+			}
+			reverted.ConfirmedMessages = append(reverted.ConfirmedMessages, msg.Header.ID)
+		}
+	}
+	return nil
+}
+
+// revertMessageConfirmation moves a dispatched message back to pending, so it is confirmed, rejected or
+// quarantined again when its pins are re-delivered from the replacing blocks
+func (em *eventManager) revertMessageConfirmation(ctx context.Context, msg *core.Message) error {
+	if msg.Header.Group != nil {
+		if err := em.revertNextPins(ctx, msg); err != nil {
+			return err
+		}
+	}
+	update := database.MessageQueryFactory.NewUpdate(ctx).
+		Set("state", core.MessageStatePending).
+		Set("confirmed", nil).
+		Set("rejectreason", "")
+	if err := em.database.UpdateMessage(ctx, em.namespace.Name, msg.Header.ID, update); err != nil {
+		return err
+	}
+	em.data.UpdateMessageStateIfCached(ctx, msg.Header.ID, core.MessageStatePending, nil, "")
+	log.L(ctx).Infof("Reverted %s message %s", msg.State, msg.Header.ID)
+	return nil
+}
+
+// revertNextPins moves the next pin of the author on each context of a private message back to the pin of
+// the message, so the message is the next one expected from the author when its pins are re-delivered.
+// Next pins are only moved backwards, as an earlier message of the author may already have been reverted.
+func (em *eventManager) revertNextPins(ctx context.Context, msg *core.Message) error {
+	for i, topic := range msg.Header.Topics {
+		nonce, ok := pinNonce(msg, i)
+		if !ok {
+			log.L(ctx).Warnf("Unable to revert invalid pin %d of message %s", i, msg.Header.ID)
+			continue
+		}
+		var pin fftypes.Bytes32
+		hash, _, _ := strings.Cut(msg.Pins[i], ":")
+		if err := pin.UnmarshalText([]byte(hash)); err != nil {
+			log.L(ctx).Warnf("Unable to revert invalid pin %d of message %s", i, msg.Header.ID)
+			continue
+		}
+		contextUnmasked := privateContext(msg.Header.ContextTopic(topic), msg.Header.Group)
+		nextPins, err := em.database.GetNextPinsForContext(ctx, em.namespace.Name, contextUnmasked)
+		if err != nil {
+			return err
+		}
+		for _, np := range nextPins {
+			if np.Identity == msg.Header.Author && np.Nonce > nonce {
+				update := database.NextPinQueryFactory.NewUpdate(ctx).
+					Set("nonce", nonce).
+					Set("hash", &pin)
+				if err := em.database.UpdateNextPin(ctx, em.namespace.Name, np.Sequence, update); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newReorgEvent() *blockchain.EventToDispatch {
+	return &blockchain.EventToDispatch{
+		Type: blockchain.EventTypeReorg,
+		Reorg: &blockchain.ReorgEvent{
+			Source:         "ethereum",
+			BlockNumber:    10,
+			BlockHash:      "0x10",
+			FromProtocolID: "000000000010",
+		},
+	}
+}
+
+func TestBlockchainReorgRevertsState(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	pinEvent := &core.BlockchainEvent{
+		ID:         fftypes.NewUUID(),
+		ProtocolID: "000000000010/000000/000000",
+		TX:         core.BlockchainTransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+	}
+	transferEvent := &core.BlockchainEvent{
+		ID:         fftypes.NewUUID(),
+		ProtocolID: "000000000011/000000/000000",
+	}
+	batch := &core.BatchPersisted{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}}
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, State: core.MessageStateConfirmed}
+	transfer := &core.TokenTransfer{LocalID: fftypes.NewUUID(), From: "0x1", To: "0x2", Amount: *fftypes.NewFFBigInt(5)}
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{pinEvent, transferEvent}, nil, nil).Once()
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{}, nil, nil).Once()
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil).Once()
+	em.mdi.On("GetBatches", em.ctx, "ns1", mock.Anything).Return([]*core.BatchPersisted{batch}, nil, nil).Once()
+	em.mdi.On("DeleteBatchPins", em.ctx, "ns1", batch.ID).Return(nil).Once()
+	em.mdi.On("GetMessages", em.ctx, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil).Once()
+	em.mdi.On("UpdateMessage", em.ctx, "ns1", msg.Header.ID, mock.Anything).Return(nil).Once()
+	em.mdm.On("UpdateMessageStateIfCached", em.ctx, msg.Header.ID, core.MessageStatePending, (*fftypes.FFTime)(nil), "").Return().Once()
+	em.mdi.On("DeleteBlockchainEvent", em.ctx, "ns1", pinEvent.ID).Return(nil).Once()
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{transfer}, nil, nil).Once()
+	em.mdi.On("UpdateTokenBalances", em.ctx, mock.MatchedBy(func(reversal *core.TokenTransfer) bool {
+		return reversal.From == "0x2" && reversal.To == "0x1" && reversal.Amount.Int().Int64() == 5
+	})).Return(nil).Once()
+	em.mdi.On("DeleteTokenTransfer", em.ctx, "ns1", transfer.LocalID).Return(nil).Once()
+	em.mdi.On("DeleteBlockchainEvent", em.ctx, "ns1", transferEvent.ID).Return(nil).Once()
+	var reorgID *fftypes.UUID
+	em.mdi.On("InsertBlockchainReorg", em.ctx, mock.MatchedBy(func(reorg *core.BlockchainReorg) bool {
+		reorgID = reorg.ID
+		return reorg.BlockNumber == 10 && reorg.Source == "ethereum" &&
+			assert.Equal(t, &core.BlockchainReorgReverted{
+				BlockchainEvents:  []*fftypes.UUID{pinEvent.ID, transferEvent.ID},
+				TokenTransfers:    []*fftypes.UUID{transfer.LocalID},
+				Batches:           []*fftypes.UUID{batch.ID},
+				ConfirmedMessages: []*fftypes.UUID{msg.Header.ID},
+			}, reorg.Reverted)
+	})).Return(nil).Once()
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeBlockchainReorg && e.Reference.Equals(reorgID) && e.Topic == core.SystemBatchPinTopic
+	})).Return(nil).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.NoError(t, err)
+}
+
+func TestBlockchainReorgBeforeReplacingEvents(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	// The re-org must be applied before the replacing event is looked up against its listener
+	reverted := false
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{}, nil, nil).Once()
+	em.mdi.On("InsertBlockchainReorg", em.ctx, mock.Anything).Return(nil).Once()
+	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(nil).Once().Run(func(args mock.Arguments) {
+		reverted = true
+	})
+	em.mdi.On("GetContractListenerByBackendID", mock.Anything, "ns1", "sb-1").Return(nil, nil).Once().Run(func(args mock.Arguments) {
+		assert.True(t, reverted)
+	})
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		newReorgEvent(),
+		newHeldListenerEvent("10", "0x11"),
+	})
+	assert.NoError(t, err)
+}
+
+func TestBlockchainReorgPersistBeforeFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetContractListenerByBackendID", mock.Anything, "ns1", "sb-1").Return(nil, fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		newHeldListenerEvent("9", "0x09"),
+		newReorgEvent(),
+	})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgGetEventsFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgGetTransfersFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil, nil)
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgUpdateBalancesFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil, nil)
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{{LocalID: fftypes.NewUUID()}}, nil, nil)
+	em.mdi.On("UpdateTokenBalances", em.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgDeleteTransferFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil, nil)
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{{LocalID: fftypes.NewUUID()}}, nil, nil)
+	em.mdi.On("UpdateTokenBalances", em.ctx, mock.Anything).Return(nil)
+	em.mdi.On("DeleteTokenTransfer", em.ctx, "ns1", mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgGetBatchesFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{
+		ID: fftypes.NewUUID(),
+		TX: core.BlockchainTransactionRef{Type: core.TransactionTypeContractInvokePin, ID: fftypes.NewUUID()},
+	}}, nil, nil)
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+	em.mdi.On("GetBatches", em.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgDeletePinsFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{
+		ID: fftypes.NewUUID(),
+		TX: core.BlockchainTransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+	}}, nil, nil)
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+	em.mdi.On("GetBatches", em.ctx, "ns1", mock.Anything).Return([]*core.BatchPersisted{{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}}}, nil, nil)
+	em.mdi.On("DeleteBatchPins", em.ctx, "ns1", mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgGetMessagesFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{
+		ID: fftypes.NewUUID(),
+		TX: core.BlockchainTransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+	}}, nil, nil)
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+	em.mdi.On("GetBatches", em.ctx, "ns1", mock.Anything).Return([]*core.BatchPersisted{{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}}}, nil, nil)
+	em.mdi.On("DeleteBatchPins", em.ctx, "ns1", mock.Anything).Return(nil)
+	em.mdi.On("GetMessages", em.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func newReorgPrivateMessage(author string, nonce int64) *core.Message {
+	group := fftypes.NewRandB32()
+	return &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Group:  group,
+			Topics: fftypes.FFStringArray{"topic1"},
+			SignerRef: core.SignerRef{
+				Author: author,
+			},
+		},
+		Pins:  fftypes.FFStringArray{fmt.Sprintf("%s:%d", privatePinHash("topic1", group, author, nonce), nonce)},
+		State: core.MessageStateConfirmed,
+	}
+}
+
+func mockReorgMessages(em *testEventManager, msgs ...*core.Message) {
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{
+		ID: fftypes.NewUUID(),
+		TX: core.BlockchainTransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+	}}, nil, nil).Once()
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+	em.mdi.On("GetBatches", em.ctx, "ns1", mock.Anything).Return([]*core.BatchPersisted{{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}}}, nil, nil)
+	em.mdi.On("DeleteBatchPins", em.ctx, "ns1", mock.Anything).Return(nil)
+	em.mdi.On("GetMessages", em.ctx, "ns1", mock.Anything).Return(msgs, nil, nil)
+}
+
+func TestBlockchainReorgRevertsPrivateMessage(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newReorgPrivateMessage("did:firefly:org/org1", 5)
+	badPin := newReorgPrivateMessage("did:firefly:org/org1", 5)
+	badPin.Pins = fftypes.FFStringArray{"!bad:5"}
+	badNonce := newReorgPrivateMessage("did:firefly:org/org1", 5)
+	badNonce.Pins = fftypes.FFStringArray{"!bad"}
+	mockReorgMessages(em, msg, badPin, badNonce)
+	contextUnmasked := privateContext(msg.Header.ContextTopic("topic1"), msg.Header.Group)
+	em.mdi.On("GetNextPinsForContext", em.ctx, "ns1", contextUnmasked).Return([]*core.NextPin{
+		{Identity: "did:firefly:org/org1", Nonce: 7, Sequence: 12},
+		{Identity: "did:firefly:org/org2", Nonce: 9, Sequence: 13},
+	}, nil).Once()
+	em.mdi.On("UpdateNextPin", em.ctx, "ns1", int64(12), mock.Anything).Return(nil).Once()
+	em.mdi.On("UpdateMessage", em.ctx, "ns1", mock.Anything, mock.Anything).Return(nil).Times(3)
+	em.mdm.On("UpdateMessageStateIfCached", em.ctx, mock.Anything, core.MessageStatePending, (*fftypes.FFTime)(nil), "").Return().Times(3)
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{}, nil, nil).Once()
+	em.mdi.On("DeleteBlockchainEvent", em.ctx, "ns1", mock.Anything).Return(nil).Once()
+	em.mdi.On("InsertBlockchainReorg", em.ctx, mock.MatchedBy(func(reorg *core.BlockchainReorg) bool {
+		return len(reorg.Reverted.ConfirmedMessages) == 3
+	})).Return(nil).Once()
+	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(nil).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.NoError(t, err)
+}
+
+func TestBlockchainReorgGetNextPinsFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	mockReorgMessages(em, newReorgPrivateMessage("did:firefly:org/org1", 5))
+	em.mdi.On("GetNextPinsForContext", em.ctx, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgUpdateNextPinFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	mockReorgMessages(em, newReorgPrivateMessage("did:firefly:org/org1", 5))
+	em.mdi.On("GetNextPinsForContext", em.ctx, "ns1", mock.Anything).Return([]*core.NextPin{
+		{Identity: "did:firefly:org/org1", Nonce: 6, Sequence: 12},
+	}, nil)
+	em.mdi.On("UpdateNextPin", em.ctx, "ns1", int64(12), mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgUpdateMessageFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	mockReorgMessages(em, &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, State: core.MessageStateRejected})
+	em.mdi.On("UpdateMessage", em.ctx, "ns1", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgDeleteEventFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil, nil)
+	em.mdi.On("GetTokenTransfers", em.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+	em.mdi.On("DeleteBlockchainEvent", em.ctx, "ns1", mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestBlockchainReorgInsertFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel()
+
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{}, nil, nil)
+	em.mdi.On("InsertBlockchainReorg", em.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
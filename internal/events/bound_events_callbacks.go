@@ -17,6 +17,7 @@
 package events
 
 import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/events"
 )
@@ -38,6 +39,14 @@ func (bc *boundCallbacks) DeliveryResponse(connID string, inflight *core.EventDe
 	bc.sm.deliveryResponse(bc.ei, connID, inflight)
 }
 
+func (bc *boundCallbacks) WatchOperations(connID string, operations, transactions []*fftypes.UUID, watcher events.OperationWatcher) error {
+	return bc.sm.watchOperations(connID, operations, transactions, watcher)
+}
+
+func (bc *boundCallbacks) UnwatchOperations(connID string, operations, transactions []*fftypes.UUID) {
+	bc.sm.unwatchOperations(connID, operations, transactions)
+}
+
 func (bc *boundCallbacks) ConnectionClosed(connID string) {
 	bc.sm.connectionClosed(bc.ei, connID)
 }
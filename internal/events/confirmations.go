@@ -0,0 +1,396 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/tokens"
+)
+
+// confirmationQueue holds blockchain events until they are buried by the namespace's required number
+// of confirmations, before any state is derived from them. The depth is measured against the chain head,
+// which is the highest block seen in any event delivered to the namespace, or reported by the connector.
+// While events are held the connector is polled for the checkpoints of the namespace's subscriptions, so
+// the last events are still released when no further events arrive on a quiet chain.
+//
+// Held events are persisted, so they survive a restart. If an event arrives for a block that is already
+// held, but with a different block hash, the chain has reorganized within the confirmation depth - every
+// held event from that block onwards is rolled back, and the connector is relied on to deliver the events
+// of the new chain.
+type confirmationQueue struct {
+	em           *eventManager
+	required     int64
+	pollInterval time.Duration
+	done         chan struct{}
+	mux          sync.Mutex
+	loaded       bool
+	head         int64
+	entries      []*core.ConfirmationQueueEntry
+}
+
+func newConfirmationQueue(em *eventManager, required int) *confirmationQueue {
+	return &confirmationQueue{
+		em:           em,
+		required:     int64(required),
+		pollInterval: config.GetDuration(coreconfig.EventConfirmationsHeadPollInterval),
+	}
+}
+
+func (cq *confirmationQueue) start() {
+	if cq.em.blockchain != nil {
+		cq.done = make(chan struct{})
+		go cq.headLoop()
+	}
+}
+
+func (cq *confirmationQueue) waitStop() {
+	if cq.done != nil {
+		<-cq.done
+	}
+}
+
+func (cq *confirmationQueue) headLoop() {
+	defer close(cq.done)
+	for {
+		select {
+		case <-time.After(cq.pollInterval):
+		case <-cq.em.ctx.Done():
+			log.L(cq.em.ctx).Debugf("Confirmation head poller exiting")
+			return
+		}
+		if err := cq.pollHead(); err != nil {
+			log.L(cq.em.ctx).Errorf("Failed to release events held for confirmations: %s", err)
+		}
+	}
+}
+
+// pollHead queries the connector for the chain head while any events are held, and releases
+// those that the head now buries deeply enough
+func (cq *confirmationQueue) pollHead() error {
+	cq.mux.Lock()
+	err := cq.load()
+	waiting := len(cq.entries) > 0
+	cq.mux.Unlock()
+	if err != nil || !waiting {
+		return err
+	}
+
+	head, err := cq.queryHead()
+	if err != nil || head < 0 {
+		return err
+	}
+	return cq.advanceHead(head)
+}
+
+// queryHead returns the highest block checkpointed by the connector for any subscription in the namespace,
+// or -1 if the connector did not report one
+func (cq *confirmationQueue) queryHead() (int64, error) {
+	ctx := cq.em.ctx
+	var subIDs []string
+	if cq.em.namespace.Contracts != nil && cq.em.namespace.Contracts.Active != nil && cq.em.namespace.Contracts.Active.Info.Subscription != "" {
+		subIDs = append(subIDs, cq.em.namespace.Contracts.Active.Info.Subscription)
+	}
+	var pageSize uint64 = 50
+	for page := uint64(0); ; page++ {
+		f := database.ContractListenerQueryFactory.NewFilterLimit(ctx, pageSize).And().Skip(page * pageSize)
+		listeners, _, err := cq.em.database.GetContractListeners(ctx, cq.em.namespace.Name, f)
+		if err != nil {
+			return -1, err
+		}
+		for _, l := range listeners {
+			subIDs = append(subIDs, l.BackendID)
+		}
+		if len(listeners) < int(pageSize) {
+			break
+		}
+	}
+
+	head := int64(-1)
+	for _, subID := range subIDs {
+		checkpoint, err := cq.em.blockchain.GetContractListenerCheckpoint(ctx, cq.em.namespace.Name, subID)
+		if err != nil {
+			log.L(ctx).Debugf("Unable to query checkpoint of subscription %s: %s", subID, err)
+			continue
+		}
+		if checkpoint != nil && checkpoint.Block > head {
+			head = checkpoint.Block
+		}
+	}
+	return head, nil
+}
+
+// advanceHead moves the head up to the given block, releasing any held events that are now confirmed
+func (cq *confirmationQueue) advanceHead(number int64) (err error) {
+	cq.mux.Lock()
+	defer cq.mux.Unlock()
+	defer cq.reloadOnError(&err)
+	if err := cq.load(); err != nil {
+		return err
+	}
+	if number <= cq.head {
+		return nil
+	}
+	log.L(cq.em.ctx).Debugf("Connector reported chain head %d", number)
+	cq.head = number
+	return cq.release()
+}
+
+// blockOf returns the block number and hash of a blockchain event, if the connector supplied them
+func blockOf(event *blockchain.Event) (number int64, hash string, ok bool) {
+	if event == nil || event.Info == nil {
+		return 0, "", false
+	}
+	if _, ok := event.Info["blockNumber"]; !ok {
+		return 0, "", false
+	}
+	return event.Info.GetInt64("blockNumber"), event.Info.GetString("blockHash"), true
+}
+
+func blockOfDispatch(event *blockchain.EventToDispatch) (number int64, hash string, ok bool) {
+	switch event.Type {
+	case blockchain.EventTypeBatchPinComplete:
+		if event.BatchPinComplete.Batch != nil {
+			return blockOf(&event.BatchPinComplete.Batch.Event)
+		}
+	case blockchain.EventTypeNetworkAction:
+		return blockOf(event.NetworkAction.Event)
+	case blockchain.EventTypeForListener:
+		return blockOf(event.ForListener.Event)
+	}
+	return 0, "", false
+}
+
+// holdBlockchainEvents queues every event in the batch that has a block number, and returns the
+// remainder that must be processed immediately
+func (cq *confirmationQueue) holdBlockchainEvents(batch []*blockchain.EventToDispatch) (unheld []*blockchain.EventToDispatch, err error) {
+	cq.mux.Lock()
+	defer cq.mux.Unlock()
+	defer cq.reloadOnError(&err)
+
+	for _, event := range batch {
+		if event.Type == blockchain.EventTypeReorg {
+			// Held events from the removed blocks are discarded, and the re-org itself is processed immediately
+			// to revert anything from those blocks that was already released
+			if err := cq.load(); err != nil {
+				return nil, err
+			}
+			if err := cq.rollback(event.Reorg.BlockNumber); err != nil {
+				return nil, err
+			}
+			unheld = append(unheld, event)
+			continue
+		}
+		number, hash, ok := blockOfDispatch(event)
+		if !ok {
+			unheld = append(unheld, event)
+			continue
+		}
+		if err := cq.hold(core.ConfirmationQueueEntryTypeBlockchainEvent, number, hash, event); err != nil {
+			return nil, err
+		}
+	}
+	return unheld, cq.release()
+}
+
+// holdTokenTransfer queues the transfer if it has a block number, returning false if it must be processed immediately
+func (cq *confirmationQueue) holdTokenTransfer(transfer *tokens.TokenTransfer) (held bool, err error) {
+	number, hash, ok := blockOf(transfer.Event)
+	if !ok {
+		return false, nil
+	}
+
+	cq.mux.Lock()
+	defer cq.mux.Unlock()
+	defer cq.reloadOnError(&err)
+	if err := cq.hold(core.ConfirmationQueueEntryTypeTokenTransfer, number, hash, transfer); err != nil {
+		return false, err
+	}
+	return true, cq.release()
+}
+
+// reloadOnError discards the in-memory view of the queue after a failure part way through an update,
+// so it is rebuilt from the database (which is updated as each event is held, processed or rolled back)
+func (cq *confirmationQueue) reloadOnError(err *error) {
+	if *err != nil {
+		cq.loaded = false
+	}
+}
+
+func (cq *confirmationQueue) load() error {
+	if cq.loaded {
+		return nil
+	}
+	return cq.em.retry.Do(cq.em.ctx, "load confirmation queue", func(attempt int) (bool, error) {
+		entries, err := cq.em.database.GetConfirmationQueueEntries(cq.em.ctx, cq.em.namespace.Name)
+		if err != nil {
+			return true, err
+		}
+		cq.entries = entries
+		cq.head = 0
+		for _, entry := range entries {
+			if entry.BlockNumber > cq.head {
+				cq.head = entry.BlockNumber
+			}
+		}
+		cq.loaded = true
+		return false, nil
+	})
+}
+
+func (cq *confirmationQueue) hold(entryType core.ConfirmationQueueEntryType, number int64, hash string, payload interface{}) error {
+	if err := cq.load(); err != nil {
+		return err
+	}
+
+	// Any held event for the same block, but with a different hash, means the chain has reorganized
+	if hash != "" {
+		for _, entry := range cq.entries {
+			if entry.BlockNumber == number && entry.BlockHash != "" && entry.BlockHash != hash {
+				log.L(cq.em.ctx).Warnf("Chain reorganized at block %d (hash %s replaced by %s) - rolling back held events", number, entry.BlockHash, hash)
+				if err := cq.rollback(number); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+
+	payloadBytes, _ := json.Marshal(payload)
+	entry := &core.ConfirmationQueueEntry{
+		Namespace:   cq.em.namespace.Name,
+		BlockNumber: number,
+		BlockHash:   hash,
+		Type:        entryType,
+		Payload:     fftypes.JSONAnyPtrBytes(payloadBytes),
+		Created:     fftypes.Now(),
+	}
+	err := cq.em.retry.Do(cq.em.ctx, "hold blockchain event", func(attempt int) (bool, error) {
+		err := cq.em.database.InsertConfirmationQueueEntry(cq.em.ctx, entry)
+		return err != nil, err
+	})
+	if err != nil {
+		return err
+	}
+	cq.entries = append(cq.entries, entry)
+	if number > cq.head {
+		cq.head = number
+	}
+	log.L(cq.em.ctx).Debugf("Holding %s from block %d until it has %d confirmations", entryType, number, cq.required)
+	return nil
+}
+
+// rollback discards every held event from the given block onwards
+func (cq *confirmationQueue) rollback(fromBlock int64) error {
+	remaining := make([]*core.ConfirmationQueueEntry, 0, len(cq.entries))
+	for _, entry := range cq.entries {
+		if entry.BlockNumber < fromBlock {
+			remaining = append(remaining, entry)
+			continue
+		}
+		if err := cq.delete(entry); err != nil {
+			return err
+		}
+		log.L(cq.em.ctx).Infof("Rolled back %s from block %d", entry.Type, entry.BlockNumber)
+	}
+	cq.entries = remaining
+	cq.head = fromBlock - 1
+	for _, entry := range remaining {
+		if entry.BlockNumber > cq.head {
+			cq.head = entry.BlockNumber
+		}
+	}
+	return nil
+}
+
+func (cq *confirmationQueue) delete(entry *core.ConfirmationQueueEntry) error {
+	return cq.em.retry.Do(cq.em.ctx, "delete held blockchain event", func(attempt int) (bool, error) {
+		err := cq.em.database.DeleteConfirmationQueueEntry(cq.em.ctx, cq.em.namespace.Name, entry.Sequence)
+		return err != nil, err
+	})
+}
+
+// release processes every held event that now has the required number of confirmations, in the order
+// the events were received. Consecutive blockchain events are processed as a single batch.
+func (cq *confirmationQueue) release() error {
+	var batch []*blockchain.EventToDispatch
+	var batchEntries []*core.ConfirmationQueueEntry
+	flushBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := cq.em.processBlockchainEventBatch(batch); err != nil {
+			return err
+		}
+		for _, entry := range batchEntries {
+			if err := cq.delete(entry); err != nil {
+				return err
+			}
+		}
+		batch, batchEntries = nil, nil
+		return nil
+	}
+
+	remaining := make([]*core.ConfirmationQueueEntry, 0, len(cq.entries))
+	for _, entry := range cq.entries {
+		if cq.head-entry.BlockNumber < cq.required {
+			remaining = append(remaining, entry)
+			continue
+		}
+		switch entry.Type {
+		case core.ConfirmationQueueEntryTypeBlockchainEvent:
+			var event blockchain.EventToDispatch
+			if err := json.Unmarshal(entry.Payload.Bytes(), &event); err != nil {
+				log.L(cq.em.ctx).Errorf("Discarding held blockchain event %d that could not be parsed: %s", entry.Sequence, err)
+				if err := cq.delete(entry); err != nil {
+					return err
+				}
+				continue
+			}
+			batch = append(batch, &event)
+			batchEntries = append(batchEntries, entry)
+		default:
+			if err := flushBatch(); err != nil {
+				return err
+			}
+			var transfer tokens.TokenTransfer
+			if err := json.Unmarshal(entry.Payload.Bytes(), &transfer); err != nil {
+				log.L(cq.em.ctx).Errorf("Discarding held token transfer %d that could not be parsed: %s", entry.Sequence, err)
+			} else if err := cq.em.processTokensTransferred(&transfer); err != nil {
+				return err
+			}
+			if err := cq.delete(entry); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flushBatch(); err != nil {
+		return err
+	}
+	cq.entries = remaining
+	return nil
+}
@@ -0,0 +1,356 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/tokenmocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newHeldListenerEvent(block, hash string) *blockchain.EventToDispatch {
+	return &blockchain.EventToDispatch{
+		Type: blockchain.EventTypeForListener,
+		ForListener: &blockchain.EventForListener{
+			ListenerID: "sb-1",
+			Event: &blockchain.Event{
+				BlockchainTXID: "0xabcd1234",
+				ProtocolID:     block + "/000000/000000",
+				Name:           "Changed",
+				Info: fftypes.JSONObject{
+					"blockNumber": block,
+					"blockHash":   hash,
+				},
+			},
+		},
+	}
+}
+
+func mockConfirmationQueueInserts(em *testEventManager) {
+	seq := int64(0)
+	em.mdi.On("InsertConfirmationQueueEntry", em.ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		seq++
+		args[1].(*core.ConfirmationQueueEntry).Sequence = seq
+	})
+}
+
+func TestSetRequiredConfirmations(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	em.SetRequiredConfirmations(0)
+	assert.Nil(t, em.confirmations)
+
+	em.SetRequiredConfirmations(3)
+	assert.Equal(t, int64(3), em.confirmations.required)
+}
+
+func TestConfirmationsHoldAndRelease(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(1)
+
+	transfer := newTransfer()
+	transfer.Event.Info = fftypes.JSONObject{"blockNumber": "10", "blockHash": "0x10"}
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{}, nil).Once()
+	mockConfirmationQueueInserts(em)
+
+	// Nothing is processed until there is a later block
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newHeldListenerEvent("10", "0x10")})
+	assert.NoError(t, err)
+	err = em.TokensTransferred(&tokenmocks.Plugin{}, transfer)
+	assert.NoError(t, err)
+	assert.Len(t, em.confirmations.entries, 2)
+
+	// Block 11 confirms both events from block 10, in the order they were received
+	em.mdi.On("GetContractListenerByBackendID", mock.Anything, "ns1", "sb-1").Return(nil, nil)
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(1)).Return(nil).Once()
+	em.mam.On("GetTokenPoolByLocator", mock.Anything, "erc1155", "F1").Return(nil, nil).Once()
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(2)).Return(nil).Once()
+
+	err = em.BlockchainEventBatch([]*blockchain.EventToDispatch{newHeldListenerEvent("11", "0x11")})
+	assert.NoError(t, err)
+	assert.Len(t, em.confirmations.entries, 1)
+	assert.Equal(t, int64(11), em.confirmations.entries[0].BlockNumber)
+}
+
+func TestConfirmationsPassthroughWithoutBlock(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(1)
+
+	ev := newHeldListenerEvent("10", "0x10")
+	ev.ForListener.Event.Info = fftypes.JSONObject{}
+	transfer := newTransfer()
+
+	em.mdi.On("GetContractListenerByBackendID", mock.Anything, "ns1", "sb-1").Return(nil, nil)
+	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(nil, nil).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{ev})
+	assert.NoError(t, err)
+	err = em.TokensTransferred(&tokenmocks.Plugin{}, transfer)
+	assert.NoError(t, err)
+	assert.Empty(t, em.confirmations.entries)
+}
+
+func TestConfirmationsReorgRollsBack(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(5)
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{}, nil).Once()
+	mockConfirmationQueueInserts(em)
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		newHeldListenerEvent("9", "0x09"),
+		newHeldListenerEvent("10", "0xaa"),
+		newHeldListenerEvent("11", "0xbb"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), em.confirmations.head)
+
+	// A different hash for block 10 rolls back blocks 10 and 11, but not 9
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(2)).Return(nil).Once()
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(3)).Return(nil).Once()
+
+	err = em.BlockchainEventBatch([]*blockchain.EventToDispatch{newHeldListenerEvent("10", "0xcc")})
+	assert.NoError(t, err)
+	assert.Len(t, em.confirmations.entries, 2)
+	assert.Equal(t, int64(1), em.confirmations.entries[0].Sequence)
+	assert.Equal(t, "0xcc", em.confirmations.entries[1].BlockHash)
+	assert.Equal(t, int64(10), em.confirmations.head)
+}
+
+func TestConfirmationsReorgEventRollsBackAndPassesThrough(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(5)
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{
+		{Namespace: "ns1", BlockNumber: 9, Type: core.ConfirmationQueueEntryTypeBlockchainEvent, Sequence: 1},
+		{Namespace: "ns1", BlockNumber: 10, Type: core.ConfirmationQueueEntryTypeBlockchainEvent, Sequence: 2},
+	}, nil).Once()
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(2)).Return(nil).Once()
+	em.mdi.On("GetBlockchainEvents", em.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{}, nil, nil).Once()
+	em.mdi.On("InsertBlockchainReorg", em.ctx, mock.Anything).Return(nil).Once()
+	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(nil).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.NoError(t, err)
+	assert.Len(t, em.confirmations.entries, 1)
+	assert.Equal(t, int64(9), em.confirmations.head)
+}
+
+func TestConfirmationsReorgEventLoadFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(1)
+	em.cancel()
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return(nil, fmt.Errorf("pop")).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestConfirmationsReorgEventRollbackFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(1)
+	em.cancel()
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{
+		{Namespace: "ns1", BlockNumber: 10, Type: core.ConfirmationQueueEntryTypeBlockchainEvent, Sequence: 1},
+	}, nil).Once()
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(1)).Return(fmt.Errorf("pop")).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newReorgEvent()})
+	assert.Regexp(t, "FF00154", err)
+	assert.False(t, em.confirmations.loaded)
+}
+
+func TestConfirmationsResumeAfterRestart(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(1)
+
+	held := newTransfer()
+	held.Event.Info = fftypes.JSONObject{"blockNumber": "20"}
+	heldBytes, _ := json.Marshal(held)
+	transfer := newTransfer()
+	transfer.Event.Info = fftypes.JSONObject{"blockNumber": "21"}
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{
+		{Namespace: "ns1", BlockNumber: 20, Type: core.ConfirmationQueueEntryTypeTokenTransfer, Payload: fftypes.JSONAnyPtrBytes(heldBytes), Sequence: 100},
+		{Namespace: "ns1", BlockNumber: 20, Type: core.ConfirmationQueueEntryTypeBlockchainEvent, Payload: fftypes.JSONAnyPtr("!bad json"), Sequence: 101},
+	}, nil).Once()
+	mockConfirmationQueueInserts(em)
+	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(nil, nil).Once()
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(100)).Return(nil).Once()
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(101)).Return(nil).Once()
+
+	err := em.TokensTransferred(&tokenmocks.Plugin{}, transfer)
+	assert.NoError(t, err)
+	assert.Len(t, em.confirmations.entries, 1)
+	assert.Equal(t, int64(21), em.confirmations.entries[0].BlockNumber)
+}
+
+func TestConfirmationsLoadFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(1)
+	em.cancel()
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return(nil, fmt.Errorf("pop")).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newHeldListenerEvent("10", "0x10")})
+	assert.Regexp(t, "FF00154", err)
+	assert.False(t, em.confirmations.loaded)
+}
+
+func TestConfirmationsReleaseFailReloads(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(1)
+	em.cancel()
+
+	transfer := newTransfer()
+	transfer.Event.Info = fftypes.JSONObject{"blockNumber": "10"}
+	transferBytes, _ := json.Marshal(transfer)
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{
+		{Namespace: "ns1", BlockNumber: 10, Type: core.ConfirmationQueueEntryTypeTokenTransfer, Payload: fftypes.JSONAnyPtrBytes(transferBytes), Sequence: 1},
+	}, nil).Once()
+	mockConfirmationQueueInserts(em)
+	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(nil, fmt.Errorf("pop")).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{newHeldListenerEvent("11", "0x11")})
+	assert.Regexp(t, "FF00154", err)
+	assert.False(t, em.confirmations.loaded)
+}
+
+func TestConfirmationsPollHeadReleases(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(2)
+	em.namespace.Contracts = &core.MultipartyContracts{
+		Active: &core.MultipartyContract{Info: core.MultipartyContractInfo{Subscription: "sub1"}},
+	}
+
+	transfer := newTransfer()
+	transfer.Event.Info = fftypes.JSONObject{"blockNumber": "10"}
+	transferBytes, _ := json.Marshal(transfer)
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{
+		{Namespace: "ns1", BlockNumber: 10, Type: core.ConfirmationQueueEntryTypeTokenTransfer, Payload: fftypes.JSONAnyPtrBytes(transferBytes), Sequence: 1},
+	}, nil).Once()
+	em.mdi.On("GetContractListeners", em.ctx, "ns1", mock.Anything).Return([]*core.ContractListener{
+		{BackendID: "sb-1"},
+	}, nil, nil).Once()
+	em.mbi.On("GetContractListenerCheckpoint", em.ctx, "ns1", "sub1").Return(&blockchain.ListenerCheckpoint{Block: 12}, nil).Once()
+	em.mbi.On("GetContractListenerCheckpoint", em.ctx, "ns1", "sb-1").Return(nil, fmt.Errorf("pop")).Once()
+	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(nil, nil).Once()
+	em.mdi.On("DeleteConfirmationQueueEntry", em.ctx, "ns1", int64(1)).Return(nil).Once()
+
+	err := em.confirmations.pollHead()
+	assert.NoError(t, err)
+	assert.Empty(t, em.confirmations.entries)
+	assert.Equal(t, int64(12), em.confirmations.head)
+}
+
+func TestConfirmationsPollHeadNotConfirmed(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(2)
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{
+		{Namespace: "ns1", BlockNumber: 10, Type: core.ConfirmationQueueEntryTypeTokenTransfer, Sequence: 1},
+	}, nil).Once()
+	em.mdi.On("GetContractListeners", em.ctx, "ns1", mock.Anything).Return([]*core.ContractListener{
+		{BackendID: "sb-1"},
+	}, nil, nil).Once()
+	em.mbi.On("GetContractListenerCheckpoint", em.ctx, "ns1", "sb-1").Return(&blockchain.ListenerCheckpoint{Block: 11}, nil).Once()
+
+	err := em.confirmations.pollHead()
+	assert.NoError(t, err)
+	assert.Len(t, em.confirmations.entries, 1)
+	assert.Equal(t, int64(11), em.confirmations.head)
+}
+
+func TestConfirmationsPollHeadBehind(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(2)
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{
+		{Namespace: "ns1", BlockNumber: 10, Type: core.ConfirmationQueueEntryTypeTokenTransfer, Sequence: 1},
+	}, nil).Once()
+	em.mdi.On("GetContractListeners", em.ctx, "ns1", mock.Anything).Return([]*core.ContractListener{
+		{BackendID: "sb-1"},
+	}, nil, nil).Once()
+	em.mbi.On("GetContractListenerCheckpoint", em.ctx, "ns1", "sb-1").Return(&blockchain.ListenerCheckpoint{Block: 5}, nil).Once()
+
+	err := em.confirmations.pollHead()
+	assert.NoError(t, err)
+	assert.Len(t, em.confirmations.entries, 1)
+	assert.Equal(t, int64(10), em.confirmations.head)
+}
+
+func TestConfirmationsPollHeadNothingHeld(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(2)
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{}, nil).Once()
+
+	err := em.confirmations.pollHead()
+	assert.NoError(t, err)
+}
+
+func TestConfirmationsPollHeadListenersFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(2)
+
+	em.mdi.On("GetConfirmationQueueEntries", em.ctx, "ns1").Return([]*core.ConfirmationQueueEntry{
+		{Namespace: "ns1", BlockNumber: 10, Type: core.ConfirmationQueueEntryTypeTokenTransfer, Sequence: 1},
+	}, nil).Once()
+	em.mdi.On("GetContractListeners", em.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+
+	err := em.confirmations.pollHead()
+	assert.EqualError(t, err, "pop")
+}
+
+func TestConfirmationsHeadLoopStop(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.SetRequiredConfirmations(2)
+	em.confirmations.pollInterval = time.Hour
+
+	em.confirmations.start()
+	em.cancel()
+	em.confirmations.waitStop()
+}
@@ -132,28 +132,31 @@ func (em *eventManager) privateBatchReceived(peerID string, batch *core.Batch, w
 
 func (em *eventManager) markUnpinnedMessagesConfirmed(ctx context.Context, batch *core.Batch) error {
 
-	// Update all the messages in the batch with the batch ID
-	msgIDs := make([]driver.Value, len(batch.Payload.Messages))
-	for i, msg := range batch.Payload.Messages {
-		msgIDs[i] = msg.Header.ID
+	// Update all the messages in the batch with the batch ID, holding back any the quarantine policy matches
+	var msgIDs, quarantinedIDs []driver.Value
+	for _, msg := range batch.Payload.Messages {
+		if em.aggregator.quarantine.quarantined(msg) {
+			log.L(ctx).Infof("Message '%s' from '%s' with tag '%s' quarantined", msg.Header.ID, msg.Header.Author, msg.Header.Tag)
+			quarantinedIDs = append(quarantinedIDs, msg.Header.ID)
+		} else {
+			msgIDs = append(msgIDs, msg.Header.ID)
+		}
 	}
-	fb := database.MessageQueryFactory.NewFilter(ctx)
-	filter := fb.And(
-		fb.In("id", msgIDs),
-		fb.Eq("state", core.MessageStatePending), // In the outside chance another state transition happens first (which supersedes this)
-	)
-
-	// Immediate confirmation if no transaction
-	update := database.MessageQueryFactory.NewUpdate(ctx).
-		Set("batch", batch.ID).
-		Set("state", core.MessageStateConfirmed).
-		Set("confirmed", fftypes.Now())
-
-	if err := em.database.UpdateMessages(ctx, em.namespace.Name, filter, update); err != nil {
-		return err
+	for state, ids := range map[core.MessageState][]driver.Value{
+		core.MessageStateConfirmed:   msgIDs,
+		core.MessageStateQuarantined: quarantinedIDs,
+	} {
+		if len(ids) > 0 {
+			if err := em.setUnpinnedMessagesState(ctx, batch, ids, state); err != nil {
+				return err
+			}
+		}
 	}
 
 	for _, msg := range batch.Payload.Messages {
+		if em.aggregator.quarantine.quarantined(msg) {
+			continue
+		}
 		for _, topic := range msg.Header.Topics {
 			// One event per topic
 			event := core.NewEvent(core.EventTypeMessageConfirmed, batch.Namespace, msg.Header.ID, batch.Payload.TX.ID, topic)
@@ -167,6 +170,22 @@ func (em *eventManager) markUnpinnedMessagesConfirmed(ctx context.Context, batch
 	return nil
 }
 
+func (em *eventManager) setUnpinnedMessagesState(ctx context.Context, batch *core.Batch, msgIDs []driver.Value, state core.MessageState) error {
+	fb := database.MessageQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.In("id", msgIDs),
+		fb.Eq("state", core.MessageStatePending), // In the outside chance another state transition happens first (which supersedes this)
+	)
+
+	// Immediate confirmation if no transaction
+	update := database.MessageQueryFactory.NewUpdate(ctx).
+		Set("batch", batch.ID).
+		Set("state", state).
+		Set("confirmed", fftypes.Now())
+
+	return em.database.UpdateMessages(ctx, em.namespace.Name, filter, update)
+}
+
 func (em *eventManager) DXEvent(dx dataexchange.Plugin, event dataexchange.DXEvent) error {
 	switch event.Type() {
 	case dataexchange.DXEventTypePrivateBlobReceived:
@@ -185,15 +204,52 @@ func (em *eventManager) messageReceived(dx dataexchange.Plugin, event dataexchan
 	l := log.L(em.ctx)
 
 	mr := event.MessageReceived()
+	if mr.Transport.Ack != nil {
+		em.batchAckReceived(dx, event)
+		return
+	}
 	l.Infof("Private batch received from %s peer '%s'", dx.Name(), mr.PeerID)
 
-	manifestString, err := em.privateBatchReceived(mr.PeerID, mr.Transport.Batch, mr.Transport.Group)
+	batch := mr.Transport.Batch
+	manifestString, err := em.privateBatchReceived(mr.PeerID, batch, mr.Transport.Group)
 	if err != nil {
 		l.Warnf("Exited while persisting batch: %s", err)
 		// We do NOT ack here as we broke out of the retry
 		return
 	}
 	event.AckWithManifest(manifestString)
+
+	if manifestString != "" {
+		// Unpinned batches are confirmed as soon as they are persisted, so there is no aggregation to wait for
+		ackState := core.BatchAckStateAggregated
+		if core.IsPinned(batch.Payload.TX.Type) {
+			ackState = core.BatchAckStatePersisted
+		}
+		if err := em.messaging.SendBatchAck(em.ctx, batch.ID, ackState); err != nil {
+			l.Warnf("Failed to acknowledge batch '%s': %s", batch.ID, err)
+		}
+	}
+}
+
+func (em *eventManager) batchAckReceived(dx dataexchange.Plugin, event dataexchange.DXEvent) {
+	mr := event.MessageReceived()
+	log.L(em.ctx).Debugf("Batch acknowledgement received from %s peer '%s'", dx.Name(), mr.PeerID)
+
+	if em.multiparty == nil {
+		log.L(em.ctx).Errorf("Ignoring batch acknowledgement from non-multiparty network!")
+		event.Ack()
+		return
+	}
+
+	// Retry for persistence errors - invalid acknowledgements are logged and dropped
+	err := em.retry.Do(em.ctx, "batch ack received", func(attempt int) (bool, error) {
+		return true, em.messaging.BatchAckReceived(em.ctx, mr.PeerID, mr.Transport.Ack)
+	})
+	if err != nil {
+		log.L(em.ctx).Warnf("Exited while recording batch acknowledgement: %s", err)
+		return
+	}
+	event.Ack()
 }
 
 func (em *eventManager) privateBlobReceived(dx dataexchange.Plugin, event dataexchange.DXEvent) {
@@ -163,7 +163,8 @@ func TestPinnedReceiveOK(t *testing.T) {
 
 	done := make(chan struct{})
 	mde := newMessageReceivedNoAck("peer1", b)
-	mde.On("AckWithManifest", batch.Payload.Manifest(batch.ID).String()).Run(func(args mock.Arguments) {
+	mde.On("AckWithManifest", batch.Payload.Manifest(batch.ID).String()).Return()
+	em.mpm.On("SendBatchAck", em.ctx, batch.ID, core.BatchAckStatePersisted).Return(nil).Run(func(args mock.Arguments) {
 		close(done)
 	})
 	em.DXEvent(mdx, mde)
@@ -695,6 +696,8 @@ func TestMessageReceiveUnpinnedBatchOk(t *testing.T) {
 	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(nil)
 	em.mdm.On("UpdateMessageCache", mock.Anything, mock.Anything).Return()
 
+	em.mpm.On("SendBatchAck", em.ctx, batch.ID, core.BatchAckStateAggregated).Return(fmt.Errorf("pop"))
+
 	mde := newMessageReceived("peer1", tw, batch.Payload.Manifest(batch.ID).String())
 	em.messageReceived(mdx, mde)
 
@@ -835,3 +838,61 @@ func TestMessageReceiveMessageEnsureLocalGroupReject(t *testing.T) {
 	mde.AssertExpectations(t)
 	mdx.AssertExpectations(t)
 }
+
+func TestBatchAckReceivedOk(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	ack := &core.BatchAckNotification{
+		Namespace: "ns1",
+		Batch:     fftypes.NewUUID(),
+		State:     core.BatchAckStateAggregated,
+	}
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+	em.mpm.On("BatchAckReceived", em.ctx, "peer1", ack).Return(nil)
+
+	mde := newMessageReceivedNoAck("peer1", &core.TransportWrapper{Ack: ack})
+	mde.On("Ack").Return()
+	em.messageReceived(mdx, mde)
+
+	mde.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+}
+
+func TestBatchAckReceivedFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel() // to avoid infinite retry
+
+	ack := &core.BatchAckNotification{
+		Namespace: "ns1",
+		Batch:     fftypes.NewUUID(),
+		State:     core.BatchAckStateAggregated,
+	}
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+	em.mpm.On("BatchAckReceived", em.ctx, "peer1", ack).Return(fmt.Errorf("pop"))
+
+	mde := newMessageReceivedNoAck("peer1", &core.TransportWrapper{Ack: ack})
+	em.messageReceived(mdx, mde)
+
+	mde.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+}
+
+func TestBatchAckReceivedNonMultiparty(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.multiparty = nil
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	mde := newMessageReceivedNoAck("peer1", &core.TransportWrapper{Ack: &core.BatchAckNotification{}})
+	mde.On("Ack").Return()
+	em.messageReceived(mdx, mde)
+
+	mde.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+}
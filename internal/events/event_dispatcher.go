@@ -32,7 +32,9 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -62,9 +64,11 @@ type eventDispatcher struct {
 	transport     events.Plugin
 	broadcast     broadcast.Manager        // optional
 	messaging     privatemessaging.Manager // optional
+	metrics       metrics.Manager
 	elected       bool
 	eventPoller   *eventPoller
 	inflight      map[fftypes.UUID]*core.Event
+	dispatchTimes map[fftypes.UUID]time.Time
 	eventDelivery chan []*core.EventDelivery
 	mux           sync.Mutex
 	namespace     string
@@ -74,7 +78,7 @@ type eventDispatcher struct {
 	txHelper      txcommon.Helper
 }
 
-func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.Plugin, di database.Plugin, dm data.Manager, bm broadcast.Manager, pm privatemessaging.Manager, connID string, sub *subscription, en *eventNotifier, txHelper txcommon.Helper) *eventDispatcher {
+func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.Plugin, di database.Plugin, dm data.Manager, bm broadcast.Manager, pm privatemessaging.Manager, mm metrics.Manager, connID string, sub *subscription, en *eventNotifier, txHelper txcommon.Helper) *eventDispatcher {
 	ctx, cancelCtx := context.WithCancel(ctx)
 	readAhead := uint64(0)
 	if sub.definition.Options.ReadAhead != nil {
@@ -98,12 +102,14 @@ func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.
 		transport:     ei,
 		broadcast:     bm,
 		messaging:     pm,
+		metrics:       mm,
 		data:          dm,
 		connID:        connID,
 		cancelCtx:     cancelCtx,
 		subscription:  sub,
 		namespace:     sub.definition.Namespace,
 		inflight:      make(map[fftypes.UUID]*core.Event),
+		dispatchTimes: make(map[fftypes.UUID]time.Time),
 		eventDelivery: make(chan []*core.EventDelivery, readAhead+1),
 		readAhead:     readAhead,
 		acksNacks:     make(chan ackNack),
@@ -198,6 +204,11 @@ func (ed *eventDispatcher) enrichEvents(events []core.LocallySequenced) ([]*core
 		if err != nil {
 			return nil, err
 		}
+		if ed.subscription.requiresTransaction() {
+			if err := ed.enricher.enrichTransaction(ed.ctx, enrichedEvent); err != nil {
+				return nil, err
+			}
+		}
 		enriched[i] = &core.EventDelivery{
 			EnrichedEvent: *enrichedEvent,
 			Subscription:  ed.subscription.definition.SubscriptionRef,
@@ -216,6 +227,20 @@ func (ed *eventDispatcher) filterEvents(candidates []*core.EventDelivery) []*cor
 	return matchingEvents
 }
 
+func (ed *eventDispatcher) transformEvents(events []*core.EventDelivery) ([]*core.EventDelivery, error) {
+	if ed.enricher.transformer == nil {
+		return events, nil
+	}
+	for i, event := range events {
+		transformed, err := ed.enricher.transformer(ed.ctx, event)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = transformed
+	}
+	return events, nil
+}
+
 func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (bool, error) {
 	// At this point, the page of messages we've been given are loaded from the DB into memory,
 	// but we can only make them in-flight and push them to the client up to the maximum
@@ -234,7 +259,10 @@ func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (boo
 		return false, err
 	}
 
-	matching := ed.filterEvents(candidates)
+	matching, err := ed.transformEvents(ed.filterEvents(candidates))
+	if err != nil {
+		return false, err
+	}
 	matchCount := len(matching)
 	dispatched := 0
 
@@ -260,8 +288,10 @@ func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (boo
 		for _, event := range dispatchable {
 			ed.mux.Lock()
 			ed.inflight[*event.ID] = &event.Event
+			ed.dispatchTimes[*event.ID] = time.Now()
 			inflightCount = uint64(len(ed.inflight))
 			ed.mux.Unlock()
+			ed.recordInflight(int(inflightCount))
 
 			dispatched++
 			if !ed.batch {
@@ -311,6 +341,11 @@ func (ed *eventDispatcher) handleNackOffsetUpdate(nack ackNack) {
 		ed.eventPoller.rewindPollingOffset(nack.offset - 1)
 	}
 	ed.inflight = map[fftypes.UUID]*core.Event{}
+	ed.dispatchTimes = map[fftypes.UUID]time.Time{}
+	if ed.recordMetrics() {
+		ed.metrics.SubscriptionRedelivery(ed.namespace, ed.subscription.definition.Name)
+	}
+	ed.recordInflight(0)
 }
 
 func (ed *eventDispatcher) handleAckOffsetUpdate(ack ackNack) {
@@ -323,7 +358,9 @@ func (ed *eventDispatcher) handleAckOffsetUpdate(ack ackNack) {
 			lowestInflight = inflight.Sequence
 		}
 	}
+	inflightCount := len(ed.inflight)
 	ed.mux.Unlock()
+	ed.recordInflight(inflightCount)
 	if (lowestInflight == -1 || lowestInflight > ack.offset) && ack.offset > oldOffset {
 		// This was the lowest in flight, and we can move the offset forwards
 		ed.eventPoller.commitOffset(ack.offset)
@@ -351,7 +388,11 @@ func (ed *eventDispatcher) deliverEvents() {
 				eventsWithData[i] = e
 				// The first error we encounter stops us attempting to enrich or dispatch any more events
 				if err == nil {
-					log.L(ed.ctx).Debugf("Dispatching %s event: %.10d/%s [%s]: ref=%s/%s", ed.transport.Name(), e.Event.Sequence, e.Event.ID, e.Event.Type, e.Event.Namespace, e.Event.Reference)
+					ctx := ed.ctx
+					if e.Event.Message != nil && e.Event.Message.Trace {
+						ctx = tracing.WithTraceFlag(ctx, "message", e.Event.Message.Header.ID)
+					}
+					tracing.Logf(ctx, "Dispatching %s event: %.10d/%s [%s]: ref=%s/%s", ed.transport.Name(), e.Event.Sequence, e.Event.ID, e.Event.Type, e.Event.Namespace, e.Event.Reference)
 					if withData && e.Event.Message != nil {
 						e.Data, _, err = ed.data.GetMessageDataCached(ed.ctx, e.Event.Message)
 					}
@@ -395,10 +436,12 @@ func (ed *eventDispatcher) deliveryResponse(response *core.EventDeliveryResponse
 	ed.mux.Lock()
 	var an ackNack
 	event, found := ed.inflight[*response.ID]
+	dispatchTime, timed := ed.dispatchTimes[*response.ID]
 	if found {
 		an.id = *response.ID
 		an.offset = event.Sequence
 		an.isNack = response.Rejected
+		delete(ed.dispatchTimes, *response.ID)
 	}
 	ed.mux.Unlock()
 
@@ -415,6 +458,9 @@ func (ed *eventDispatcher) deliveryResponse(response *core.EventDeliveryResponse
 	}
 
 	l.Debugf("Response for %s event: %.10d/%s [%s]: ref=%s/%s rejected=%t info='%s'", ed.transport.Name(), event.Sequence, event.ID, event.Type, event.Namespace, event.Reference, response.Rejected, response.Info)
+	if timed && !response.Rejected && ed.metrics.IsMetricsEnabled() {
+		ed.metrics.EventDelivered(ed.namespace, ed.transport.Name(), time.Since(dispatchTime))
+	}
 	// We don't do any meaningful work in this call, we just set things up so the right thing
 	// will happen when the poller wakes up. So we need to pass it over
 	select {
@@ -433,4 +479,19 @@ func (ed *eventDispatcher) close() {
 		close(ed.eventDelivery)
 		ed.elected = false
 	}
+	if ed.recordMetrics() {
+		ed.metrics.SubscriptionClosed(ed.namespace, ed.subscription.definition.Name)
+	}
+}
+
+// recordMetrics is false for ephemeral subscriptions, as they are named with a new random ID
+// on every connection, so labelling metrics by their name would grow the series without bound
+func (ed *eventDispatcher) recordMetrics() bool {
+	return !ed.subscription.definition.Ephemeral && ed.metrics.IsMetricsEnabled()
+}
+
+func (ed *eventDispatcher) recordInflight(count int) {
+	if ed.recordMetrics() {
+		ed.metrics.SubscriptionInflight(ed.namespace, ed.subscription.definition.Name, count)
+	}
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
@@ -57,8 +58,10 @@ func newTestEventDispatcher(sub *subscription) (*eventDispatcher, func()) {
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
 	enricher := newEventEnricher("ns1", mdi, mdm, mom, txHelper)
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
 	ctx, cancel := context.WithCancel(context.Background())
-	return newEventDispatcher(ctx, enricher, mei, mdi, mdm, mbm, mpm, fftypes.NewUUID().String(), sub, newEventNotifier(ctx, "ut"), txHelper), func() {
+	return newEventDispatcher(ctx, enricher, mei, mdi, mdm, mbm, mpm, mmi, fftypes.NewUUID().String(), sub, newEventNotifier(ctx, "ut"), txHelper), func() {
 		cancel()
 	}
 }
@@ -982,6 +985,70 @@ func TestBufferedDeliveryEnrichFail(t *testing.T) {
 
 }
 
+func TestBufferedDeliveryTransformFail(t *testing.T) {
+
+	sub := &subscription{
+		definition: &core.Subscription{},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	ed.enricher.transformer = func(ctx context.Context, event *core.EventDelivery) (*core.EventDelivery, error) {
+		return nil, fmt.Errorf("pop")
+	}
+
+	repoll, err := ed.bufferedDelivery([]core.LocallySequenced{&core.Event{ID: fftypes.NewUUID()}})
+	assert.False(t, repoll)
+	assert.EqualError(t, err, "pop")
+
+}
+
+func TestBufferedDeliveryTransformed(t *testing.T) {
+
+	sub := &subscription{
+		definition: &core.Subscription{},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+	go ed.deliverEvents()
+
+	ed.enricher.transformer = func(ctx context.Context, event *core.EventDelivery) (*core.EventDelivery, error) {
+		transformed := *event
+		transformed.Topic = "transformed"
+		return &transformed, nil
+	}
+
+	mdi := ed.database.(*databasemocks.Plugin)
+	mei := ed.transport.(*eventsmocks.Plugin)
+	mdi.On("GetDataRefs", mock.Anything, mock.Anything).Return(nil, nil, nil)
+	mdi.On("UpdateOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	delivered := make(chan struct{})
+	deliver := mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(event *core.EventDelivery) bool {
+		return event.Topic == "transformed"
+	}), mock.Anything).Return(nil)
+	deliver.RunFn = func(a mock.Arguments) {
+		close(delivered)
+	}
+
+	bdDone := make(chan struct{})
+	ev1 := fftypes.NewUUID()
+	go func() {
+		repoll, err := ed.bufferedDelivery([]core.LocallySequenced{&core.Event{ID: ev1, Sequence: 100001, Topic: "topic1"}})
+		assert.NoError(t, err)
+		assert.True(t, repoll)
+		close(bdDone)
+	}()
+
+	<-delivered
+	ed.deliveryResponse(&core.EventDeliveryResponse{
+		ID: ev1,
+	})
+	<-bdDone
+
+	mei.AssertExpectations(t)
+}
+
 func TestBufferedDeliveryClosedContext(t *testing.T) {
 
 	sub := &subscription{
@@ -1045,6 +1112,98 @@ func TestBufferedDeliveryNackRewind(t *testing.T) {
 	assert.Equal(t, int64(100000), ed.eventPoller.pollingOffset)
 }
 
+func TestBufferedDeliveryMetrics(t *testing.T) {
+	sub := &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub1"},
+		},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+	go ed.deliverEvents()
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("SubscriptionInflight", "ns1", "sub1", 1).Return().Twice()
+	mmi.On("SubscriptionInflight", "ns1", "sub1", 0).Return().Twice()
+	mmi.On("EventDelivered", "ns1", "ut", mock.Anything).Return().Once()
+	mmi.On("SubscriptionRedelivery", "ns1", "sub1").Return().Once()
+	ed.metrics = mmi
+
+	mdi := ed.database.(*databasemocks.Plugin)
+	mei := ed.transport.(*eventsmocks.Plugin)
+	mdi.On("GetDataRefs", mock.Anything, mock.Anything).Return(nil, nil, nil)
+	mdi.On("UpdateOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	delivered := make(chan struct{}, 1)
+	deliver := mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	deliver.RunFn = func(a mock.Arguments) {
+		delivered <- struct{}{}
+	}
+
+	for _, rejected := range []bool{false, true} {
+		bdDone := make(chan struct{})
+		ev1 := fftypes.NewUUID()
+		ed.eventPoller.pollingOffset = 100000
+		go func() {
+			repoll, err := ed.bufferedDelivery([]core.LocallySequenced{&core.Event{ID: ev1, Sequence: 100001}})
+			assert.NoError(t, err)
+			assert.True(t, repoll)
+			close(bdDone)
+		}()
+
+		<-delivered
+		ed.deliveryResponse(&core.EventDeliveryResponse{
+			ID:       ev1,
+			Rejected: rejected,
+		})
+		<-bdDone
+	}
+
+	mmi.AssertExpectations(t)
+}
+
+func TestEventDispatcherMetricsClosed(t *testing.T) {
+	sub := &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub1"},
+		},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("SubscriptionClosed", "ns1", "sub1").Return().Once()
+	ed.metrics = mmi
+
+	close(ed.closed)
+	ed.close()
+
+	mmi.AssertExpectations(t)
+}
+
+func TestEventDispatcherMetricsSkipEphemeral(t *testing.T) {
+	sub := &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: fftypes.NewUUID().String()},
+			Ephemeral:       true,
+		},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	mmi := &metricsmocks.Manager{}
+	ed.metrics = mmi
+
+	ed.recordInflight(1)
+	ed.handleNackOffsetUpdate(ackNack{id: *fftypes.NewUUID(), offset: 1})
+	close(ed.closed)
+	ed.close()
+
+	mmi.AssertExpectations(t)
+}
+
 func TestBufferedDeliveryFailNack(t *testing.T) {
 	log.SetLevel("trace")
 
@@ -26,12 +26,16 @@ import (
 	"github.com/hyperledger/firefly/pkg/database"
 )
 
+// EventTransformer is called with each event matched by a subscription, and returns the event to deliver
+type EventTransformer func(ctx context.Context, event *core.EventDelivery) (*core.EventDelivery, error)
+
 type eventEnricher struct {
-	namespace  string
-	data       data.Manager
-	database   database.Plugin
-	operations operations.Manager
-	txHelper   txcommon.Helper
+	namespace   string
+	data        data.Manager
+	database    database.Plugin
+	operations  operations.Manager
+	txHelper    txcommon.Helper
+	transformer EventTransformer // optional
 }
 
 func newEventEnricher(ns string, di database.Plugin, dm data.Manager, om operations.Manager, txHelper txcommon.Helper) *eventEnricher {
@@ -56,6 +60,20 @@ func (em *eventEnricher) enrichEvents(ctx context.Context, events []*core.Event)
 	return enriched, nil
 }
 
+// enrichTransaction adds the transaction to an event that refers to one, where it was not added
+// by the enrichment for the event type
+func (em *eventEnricher) enrichTransaction(ctx context.Context, e *core.EnrichedEvent) error {
+	if e.Transaction != nil || e.Event.Transaction == nil {
+		return nil
+	}
+	tx, err := em.txHelper.GetTransactionByIDCached(ctx, e.Event.Transaction)
+	if err != nil {
+		return err
+	}
+	e.Transaction = tx
+	return nil
+}
+
 func (em *eventEnricher) enrichEvent(ctx context.Context, event *core.Event) (*core.EnrichedEvent, error) {
 	e := &core.EnrichedEvent{
 		Event: *event,
@@ -80,6 +98,12 @@ func (em *eventEnricher) enrichEvent(ctx context.Context, event *core.Event) (*c
 			return nil, err
 		}
 		e.BlockchainEvent = be
+	case core.EventTypeBlockchainReorg:
+		reorg, err := em.database.GetBlockchainReorgByID(ctx, em.namespace, event.Reference)
+		if err != nil {
+			return nil, err
+		}
+		e.BlockchainReorg = reorg
 	case core.EventTypeContractAPIConfirmed:
 		contractAPI, err := em.database.GetContractAPIByID(ctx, em.namespace, event.Reference)
 		if err != nil {
@@ -135,5 +159,21 @@ func (em *eventEnricher) enrichEvent(ctx context.Context, event *core.Event) (*c
 		}
 		e.Operation = operation
 	}
+
+	// Carry through the correlation ID of the API request that started the work, for joined-up tracing
+	switch {
+	case e.Operation != nil:
+		e.CorrelationID = e.Operation.CorrelationID
+	case e.Transaction != nil:
+		e.CorrelationID = e.Transaction.CorrelationID
+	case event.Transaction != nil:
+		tx, err := em.txHelper.GetTransactionByIDCached(ctx, event.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		if tx != nil {
+			e.CorrelationID = tx.CorrelationID
+		}
+	}
 	return e, nil
 }
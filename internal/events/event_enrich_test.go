@@ -572,6 +572,65 @@ func TestEnrichTokenTransferConfirmed(t *testing.T) {
 	assert.Equal(t, ref1, enriched.TokenTransfer.LocalID)
 }
 
+func TestEnrichTokenTransferConfirmedCorrelationID(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+	tx1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdi := em.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", ref1).Return(&core.TokenTransfer{
+		LocalID: ref1,
+	}, nil)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx1).Return(&core.Transaction{
+		ID:            tx1,
+		CorrelationID: "esb-1234",
+	}, nil)
+
+	event := &core.Event{
+		ID:          ev1,
+		Type:        core.EventTypeTransferConfirmed,
+		Reference:   ref1,
+		Transaction: tx1,
+	}
+
+	enriched, err := em.enrichEvent(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, "esb-1234", enriched.CorrelationID)
+	assert.Nil(t, enriched.Transaction)
+}
+
+func TestEnrichTokenTransferConfirmedCorrelationIDFail(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+	tx1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdi := em.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", ref1).Return(&core.TokenTransfer{
+		LocalID: ref1,
+	}, nil)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx1).Return(nil, fmt.Errorf("pop"))
+
+	event := &core.Event{
+		ID:          ev1,
+		Type:        core.EventTypeTransferConfirmed,
+		Reference:   ref1,
+		Transaction: tx1,
+	}
+
+	_, err := em.enrichEvent(ctx, event)
+	assert.EqualError(t, err, "pop")
+}
+
 func TestEnrichTokenTransferFailed(t *testing.T) {
 	em := newTestEventEnricher()
 	ctx := context.Background()
@@ -619,6 +678,53 @@ func TestEnrichTokenTransferConfirmedFail(t *testing.T) {
 	assert.EqualError(t, err, "pop")
 }
 
+func TestEnrichBlockchainReorg(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdi := em.database.(*databasemocks.Plugin)
+	mdi.On("GetBlockchainReorgByID", mock.Anything, "ns1", ref1).Return(&core.BlockchainReorg{
+		ID: ref1,
+	}, nil)
+
+	event := &core.Event{
+		ID:        ev1,
+		Type:      core.EventTypeBlockchainReorg,
+		Reference: ref1,
+	}
+
+	enriched, err := em.enrichEvent(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, ref1, enriched.BlockchainReorg.ID)
+}
+
+func TestEnrichBlockchainReorgFail(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdi := em.database.(*databasemocks.Plugin)
+	mdi.On("GetBlockchainReorgByID", mock.Anything, "ns1", ref1).Return(nil, fmt.Errorf("pop"))
+
+	event := &core.Event{
+		ID:        ev1,
+		Type:      core.EventTypeBlockchainReorg,
+		Reference: ref1,
+	}
+
+	_, err := em.enrichEvent(ctx, event)
+	assert.EqualError(t, err, "pop")
+}
+
 func TestEnrichOperationFail(t *testing.T) {
 	em := newTestEventEnricher()
 	ctx := context.Background()
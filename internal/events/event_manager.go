@@ -20,7 +20,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"strconv"
+	"sync"
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -58,13 +60,17 @@ type EventManager interface {
 	NewSubscriptions() chan<- *fftypes.UUID
 	SubscriptionUpdates() chan<- *fftypes.UUID
 	DeletedSubscriptions() chan<- *fftypes.UUID
+	OperationUpdates() chan<- *fftypes.UUID
 	DeleteDurableSubscription(ctx context.Context, subDef *core.Subscription) (err error)
 	CreateUpdateDurableSubscription(ctx context.Context, subDef *core.Subscription, mustNew bool) (err error)
 	EnrichEvent(ctx context.Context, event *core.Event) (*core.EnrichedEvent, error)
 	EnrichEvents(ctx context.Context, events []*core.Event) ([]*core.EnrichedEvent, error)
 	FilterHistoricalEventsOnSubscription(ctx context.Context, events []*core.EnrichedEvent, sub *core.Subscription) ([]*core.EnrichedEvent, error)
+	PollDurableSubscriptionEvents(ctx context.Context, subDef *core.Subscription, limit uint64) ([]*core.EnrichedEvent, error)
 	QueueBatchRewind(batchID *fftypes.UUID)
+	ReleaseMessage(ctx context.Context, msgID *fftypes.UUID) (*core.Message, error)
 	ResolveTransportAndCapabilities(ctx context.Context, transportName string) (string, *events.Capabilities, error)
+	SetDurableSubscriptionOffset(ctx context.Context, subDef *core.Subscription, offset int64) (err error)
 	Start() error
 	WaitStop()
 
@@ -75,7 +81,9 @@ type EventManager interface {
 	DXEvent(plugin dataexchange.Plugin, event dataexchange.DXEvent) error
 
 	// Bound sharedstorage callbacks
-	SharedStorageBatchDownloaded(ss sharedstorage.Plugin, payloadRef string, data []byte) (*fftypes.UUID, error)
+	SharedStorageBatchDownloaded(ss sharedstorage.Plugin, payloadRef string, reader io.Reader) (*fftypes.UUID, error)
+	SharedStorageBatchVerify(ss sharedstorage.Plugin, payloadRef string, reader io.Reader) (*core.Batch, error)
+	SharedStorageBatchDeliver(batch *core.Batch) (*fftypes.UUID, error)
 	SharedStorageBlobDownloaded(ss sharedstorage.Plugin, hash fftypes.Bytes32, size int64, payloadRef string, dataID *fftypes.UUID) error
 
 	// Bound token callbacks
@@ -84,6 +92,11 @@ type EventManager interface {
 	TokensApproved(ti tokens.Plugin, approval *tokens.TokenApproval) error
 
 	GetPlugins() []*core.NamespaceStatusPlugin
+	AggregatorStatus() *AggregatorStatus
+	PauseIngestion() (resume func())
+	SetRequiredConfirmations(required int)
+	SetEventTransformer(transformer EventTransformer)
+	RebuildDerivedState(ctx context.Context, target core.RebuildTarget, progress func(processed, repaired int64)) error
 
 	// Internal events
 	system.EventInterface
@@ -94,6 +107,7 @@ type eventManager struct {
 	namespace          *core.Namespace
 	enricher           *eventEnricher
 	database           database.Plugin
+	blockchain         blockchain.Plugin // optional
 	txHelper           txcommon.Helper
 	identity           identity.Manager
 	defsender          definitions.Sender
@@ -114,6 +128,8 @@ type eventManager struct {
 	metrics            metrics.Manager
 	chainListenerCache cache.CInterface
 	multiparty         multiparty.Manager // optional
+	confirmations      *confirmationQueue // optional
+	ingestMux          sync.RWMutex
 }
 
 func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin, bi blockchain.Plugin, im identity.Manager, dh definitions.Handler, dm data.Manager, ds definitions.Sender, bm broadcast.Manager, pm privatemessaging.Manager, am assets.Manager, sd shareddownload.Manager, mm metrics.Manager, om operations.Manager, txHelper txcommon.Helper, transports map[string]events.Plugin, mp multiparty.Manager, cacheManager cache.Manager) (EventManager, error) {
@@ -139,6 +155,7 @@ func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin
 		ctx:            log.WithLogField(ctx, "role", "event-manager"),
 		namespace:      ns,
 		database:       di,
+		blockchain:     bi,
 		txHelper:       txHelper,
 		identity:       im,
 		defsender:      ds,
@@ -173,7 +190,7 @@ func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin
 
 	em.enricher = newEventEnricher(ns.Name, di, dm, om, txHelper)
 
-	if em.subManager, err = newSubscriptionManager(ctx, ns, em.enricher, di, dm, newEventNotifier, bm, pm, txHelper, transports); err != nil {
+	if em.subManager, err = newSubscriptionManager(ctx, ns, em.enricher, di, dm, newEventNotifier, bm, pm, mm, txHelper, transports); err != nil {
 		return nil, err
 	}
 
@@ -187,6 +204,9 @@ func (em *eventManager) Start() (err error) {
 			em.aggregator.start()
 			em.blobReceiver.start()
 		}
+		if em.confirmations != nil {
+			em.confirmations.start()
+		}
 	}
 	return err
 }
@@ -211,6 +231,10 @@ func (em *eventManager) DeletedSubscriptions() chan<- *fftypes.UUID {
 	return em.subManager.deletedSubscriptions
 }
 
+func (em *eventManager) OperationUpdates() chan<- *fftypes.UUID {
+	return em.subManager.operationUpdates
+}
+
 func (em *eventManager) ResolveTransportAndCapabilities(ctx context.Context, transportName string) (string, *events.Capabilities, error) {
 	if transportName == "" {
 		transportName = em.defaultTransport
@@ -230,6 +254,12 @@ func (em *eventManager) WaitStop() {
 	}
 	if em.aggregator != nil {
 		<-em.aggregator.eventPoller.closed
+		if em.aggregator.checkpointer != nil {
+			<-em.aggregator.checkpointer.done
+		}
+	}
+	if em.confirmations != nil {
+		em.confirmations.waitStop()
 	}
 }
 
@@ -281,10 +311,57 @@ func (em *eventManager) DeleteDurableSubscription(ctx context.Context, subDef *c
 	return em.database.DeleteSubscriptionByID(ctx, em.namespace.Name, subDef.ID)
 }
 
+func (em *eventManager) PollDurableSubscriptionEvents(ctx context.Context, subDef *core.Subscription, limit uint64) ([]*core.EnrichedEvent, error) {
+	return em.subManager.pollDurableSubscriptionEvents(ctx, subDef, limit)
+}
+
+func (em *eventManager) SetDurableSubscriptionOffset(ctx context.Context, subDef *core.Subscription, offset int64) (err error) {
+	return em.subManager.setDurableSubscriptionOffset(ctx, subDef.ID, offset)
+}
+
 func (em *eventManager) AddSystemEventListener(ns string, el system.EventListener) error {
 	return em.internalEvents.AddListener(ns, el)
 }
 
+// PauseIngestion stops the processing of new blockchain events, and the aggregation of pins into messages,
+// until the returned function is called. Processing that is in flight completes before this returns.
+func (em *eventManager) PauseIngestion() (resume func()) {
+	em.ingestMux.Lock()
+	if em.aggregator != nil {
+		em.aggregator.ingestMux.Lock()
+	}
+	return func() {
+		if em.aggregator != nil {
+			em.aggregator.ingestMux.Unlock()
+		}
+		em.ingestMux.Unlock()
+	}
+}
+
+// AggregatorStatus returns the internal state of the aggregator, or nil if this namespace has no aggregator
+// SetRequiredConfirmations holds blockchain events until they are buried by the given number of blocks.
+// Must be called before the event manager is started.
+func (em *eventManager) SetRequiredConfirmations(required int) {
+	if required > 0 {
+		em.confirmations = newConfirmationQueue(em, required)
+	} else {
+		em.confirmations = nil
+	}
+}
+
+// SetEventTransformer sets a transformer applied to each event before it is delivered to a subscription.
+// Must be called before the event manager is started.
+func (em *eventManager) SetEventTransformer(transformer EventTransformer) {
+	em.enricher.transformer = transformer
+}
+
+func (em *eventManager) AggregatorStatus() *AggregatorStatus {
+	if em.aggregator == nil {
+		return nil
+	}
+	return em.aggregator.status()
+}
+
 func (em *eventManager) GetPlugins() []*core.NamespaceStatusPlugin {
 	eventsArray := make([]*core.NamespaceStatusPlugin, 0)
 	plugins := em.subManager.transports
@@ -320,6 +397,11 @@ func (em *eventManager) FilterHistoricalEventsOnSubscription(ctx context.Context
 
 	matchingEvents := []*core.EnrichedEvent{}
 	for _, event := range events {
+		if subscriptionDef.requiresTransaction() {
+			if err := em.enricher.enrichTransaction(ctx, event); err != nil {
+				return nil, err
+			}
+		}
 		if subscriptionDef.MatchesEvent(event) {
 			matchingEvents = append(matchingEvents, event)
 		}
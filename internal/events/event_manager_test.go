@@ -143,6 +143,7 @@ func newTestEventManagerCommon(t *testing.T, metrics, dbconcurrency bool) *testE
 	}
 	met.On("Name").Return("ut").Maybe()
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress).Maybe()
+	mim.On("VerifyPayloadSignature", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Maybe()
 	mdi.On("Capabilities").Return(&database.Capabilities{Concurrency: dbconcurrency}).Maybe()
 	mev.On("SetHandler", "ns1", mock.Anything).Return(nil).Maybe()
 	mev.On("ValidateOptions", mock.Anything, mock.Anything).Return(nil).Maybe()
@@ -379,6 +380,9 @@ func TestEmitSubscriptionEventsNoops(t *testing.T) {
 	em.DeletedSubscriptions() <- fftypes.NewUUID()
 	close(getSubCallReady)
 	<-delOffsetCalled
+
+	// No connections are watching operations, so updates are dropped without a lookup
+	em.OperationUpdates() <- fftypes.NewUUID()
 }
 
 func TestCreateDurableSubscriptionBadSub(t *testing.T) {
@@ -575,6 +579,26 @@ func TestCreateDeleteDurableSubscriptionOk(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPollDurableSubscriptionEventsNotPolled(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1"}, Transport: "websockets"}
+	_, err := em.PollDurableSubscriptionEvents(em.ctx, sub, 25)
+	assert.Regexp(t, "FF10620", err)
+}
+
+func TestSetDurableSubscriptionOffsetOk(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	subID := fftypes.NewUUID()
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: subID, Namespace: "ns1"}}
+	em.mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Type == core.OffsetTypeSubscription && o.Name == subID.String() && o.Current == 12345
+	}), true).Return(nil)
+	err := em.SetDurableSubscriptionOffset(em.ctx, sub, 12345)
+	assert.NoError(t, err)
+}
+
 func TestAddInternalListener(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -608,6 +632,31 @@ func TestGetPlugins(t *testing.T) {
 	assert.ElementsMatch(t, em.GetPlugins(), expectedPlugins)
 }
 
+func TestPauseIngestion(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	resume := em.PauseIngestion()
+	assert.False(t, em.ingestMux.TryRLock())
+	if em.aggregator != nil {
+		assert.False(t, em.aggregator.ingestMux.TryRLock())
+	}
+	resume()
+	assert.True(t, em.ingestMux.TryRLock())
+	em.ingestMux.RUnlock()
+}
+
+func TestSetEventTransformer(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	em.SetEventTransformer(func(ctx context.Context, event *core.EventDelivery) (*core.EventDelivery, error) {
+		return event, nil
+	})
+	assert.NotNil(t, em.enricher.transformer)
+	assert.Equal(t, em.enricher, em.subManager.enricher)
+}
+
 func TestResolveTransportAndCapabilities(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -747,6 +796,70 @@ func TestEventFilterOnSubscriptionMatchesEventType(t *testing.T) {
 	assert.Equal(t, 1, len(filteredEvents))
 }
 
+func TestEventFilterOnSubscriptionTxLabels(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	txID := fftypes.NewUUID()
+	events := []*core.EnrichedEvent{
+		{
+			Event: core.Event{
+				Type:        core.EventTypeTransferConfirmed,
+				Transaction: txID,
+			},
+		},
+	}
+	em.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(&core.Transaction{
+		ID:     txID,
+		Labels: fftypes.FFStringArray{"quarter-end", "payroll"},
+	}, nil)
+
+	subscription := &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Transaction: core.TransactionFilter{
+				Labels: "^payroll$",
+			},
+		},
+	}
+
+	filteredEvents, err := em.FilterHistoricalEventsOnSubscription(context.Background(), events, subscription)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(filteredEvents))
+	assert.Equal(t, txID, filteredEvents[0].Transaction.ID)
+
+	subscription.Filter.Transaction.Labels = "^invoicing$"
+	filteredEvents, err = em.FilterHistoricalEventsOnSubscription(context.Background(), events, subscription)
+	assert.NoError(t, err)
+	assert.Empty(t, filteredEvents)
+}
+
+func TestEventFilterOnSubscriptionTxLabelsLookupFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	txID := fftypes.NewUUID()
+	events := []*core.EnrichedEvent{
+		{
+			Event: core.Event{
+				Type:        core.EventTypeTransferConfirmed,
+				Transaction: txID,
+			},
+		},
+	}
+	em.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(nil, fmt.Errorf("pop"))
+
+	subscription := &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Transaction: core.TransactionFilter{
+				Labels: "payroll",
+			},
+		},
+	}
+
+	_, err := em.FilterHistoricalEventsOnSubscription(context.Background(), events, subscription)
+	assert.Regexp(t, "pop", err)
+}
+
 func TestEventFilterOnSubscriptionFailsWithBadRegex(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
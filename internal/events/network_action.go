@@ -18,6 +18,7 @@ package events
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -48,10 +49,25 @@ func (em *eventManager) handleBlockchainNetworkAction(ctx context.Context, event
 		log.L(ctx).Errorf("Ignoring network action %s from non-root identity %s", event.Action, event.SigningKey.Value)
 		return nil
 	}
+	if err = em.multiparty.CheckContractMigration(ctx, event.Event); err != nil {
+		return err
+	}
+	if err = em.applyScheduledNetworkActions(ctx, event.Event, bc); err != nil {
+		return err
+	}
 
-	if event.Action == core.NetworkActionTerminate.String() {
+	switch event.Action {
+	case core.NetworkActionTerminate.String():
+		// Any payload is ignored, as it was by versions before scheduling was supported
 		err = em.actionTerminate(ctx, event.Location, event.Event)
-	} else {
+	case core.NetworkActionScheduledTerminate.String():
+		var schedule core.NetworkActionSchedule
+		if err := json.Unmarshal([]byte(event.Payload), &schedule); err != nil || !schedule.IsScheduled() {
+			log.L(ctx).Errorf("Ignoring network action %s with invalid schedule: %s", event.Action, event.Payload)
+			return nil
+		}
+		err = em.scheduleTerminate(ctx, &schedule, resolvedAuthor.DID, event, bc)
+	default:
 		log.L(ctx).Errorf("Ignoring unrecognized network action: %s", event.Action)
 		return nil
 	}
@@ -64,3 +80,24 @@ func (em *eventManager) handleBlockchainNetworkAction(ctx context.Context, event
 	}
 	return err
 }
+
+// scheduleTerminate adds a terminate to the schedule of network actions, or applies it straight away if the event
+// is already at or past its effective point
+func (em *eventManager) scheduleTerminate(ctx context.Context, schedule *core.NetworkActionSchedule, author string, event *blockchain.NetworkActionEvent, bc *eventBatchContext) error {
+	action := &core.ScheduledNetworkAction{
+		NetworkAction: core.NetworkAction{
+			Type:                  core.NetworkActionTerminate,
+			NetworkActionSchedule: *schedule,
+		},
+		Author:   author,
+		Location: event.Location,
+		Event:    event.Event.ProtocolID,
+		Created:  fftypes.Now(),
+	}
+	scheduled, ok := em.multiparty.ScheduleNetworkAction(ctx, bc.getScheduled(em.multiparty), action, event.Event)
+	if !ok {
+		return em.actionTerminate(ctx, event.Location, event.Event)
+	}
+	bc.setScheduled(scheduled)
+	return nil
+}
@@ -39,6 +39,9 @@ func TestNetworkAction(t *testing.T) {
 	}
 
 	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", em.ctx, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
 	em.mth.On("InsertNewBlockchainEvents", em.ctx, mock.MatchedBy(func(be []*core.BlockchainEvent) bool {
 		return len(be) == 1 && be[0].ProtocolID == "0001"
 	})).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil)
@@ -152,6 +155,9 @@ func TestNetworkActionUnknown(t *testing.T) {
 	}
 
 	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, mock.Anything).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", em.ctx, mock.Anything, mock.Anything).Return([]*core.ScheduledNetworkAction{}, nil)
 
 	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
 		{
@@ -167,6 +173,219 @@ func TestNetworkActionUnknown(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNetworkActionCheckMigrationFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	event := &blockchain.Event{ProtocolID: "0001"}
+	verifier := &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x1234",
+	}
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, event).Return(fmt.Errorf("pop"))
+
+	err := em.handleBlockchainNetworkAction(em.ctx, &blockchain.NetworkActionEvent{
+		Action:     "terminate",
+		Location:   fftypes.JSONAnyPtr("{}"),
+		Event:      event,
+		SigningKey: verifier,
+	}, nil)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestNetworkActionScheduled(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	location := fftypes.JSONAnyPtr("{}")
+	event := &blockchain.Event{ProtocolID: "0001"}
+	verifier := &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x1234",
+	}
+	scheduled := []*core.ScheduledNetworkAction{{Event: "0001"}}
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{
+		IdentityBase: core.IdentityBase{DID: "did:firefly:org/org1"},
+	}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, event).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", em.ctx, []*core.ScheduledNetworkAction{}, event).Return([]*core.ScheduledNetworkAction{}, nil)
+	em.mmp.On("ScheduleNetworkAction", em.ctx, []*core.ScheduledNetworkAction{}, mock.MatchedBy(func(action *core.ScheduledNetworkAction) bool {
+		return action.Type == core.NetworkActionTerminate &&
+			action.EffectiveBlock == 100 &&
+			action.Author == "did:firefly:org/org1" &&
+			action.Location == location &&
+			action.Event == "0001"
+	}), event).Return(scheduled, true)
+	em.mth.On("InsertNewBlockchainEvents", em.ctx, mock.MatchedBy(func(be []*core.BlockchainEvent) bool {
+		return len(be) == 1 && be[0].ProtocolID == "0001"
+	})).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(nil)
+	em.mmp.On("PersistScheduledNetworkActions", em.ctx, scheduled).Return(nil)
+	em.mmp.On("SetScheduledNetworkActions", scheduled).Return()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		{
+			Type: blockchain.EventTypeNetworkAction,
+			NetworkAction: &blockchain.NetworkActionEvent{
+				Action:     "scheduled_terminate",
+				Payload:    `{"effectiveBlock":100}`,
+				Location:   location,
+				Event:      event,
+				SigningKey: verifier,
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestNetworkActionScheduledPersistFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	location := fftypes.JSONAnyPtr("{}")
+	event := &blockchain.Event{ProtocolID: "0001"}
+	verifier := &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x1234",
+	}
+	scheduled := []*core.ScheduledNetworkAction{{Event: "0001"}}
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, event).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", em.ctx, mock.Anything, event).Return([]*core.ScheduledNetworkAction{}, nil)
+	em.mmp.On("ScheduleNetworkAction", em.ctx, mock.Anything, mock.Anything, event).Return(scheduled, true)
+	em.mth.On("InsertNewBlockchainEvents", em.ctx, mock.Anything).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(nil)
+	em.mmp.On("PersistScheduledNetworkActions", em.ctx, scheduled).Return(fmt.Errorf("pop")).Once()
+	em.mmp.On("PersistScheduledNetworkActions", em.ctx, scheduled).Return(nil).Once()
+	em.mmp.On("SetScheduledNetworkActions", scheduled).Return().Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		{
+			Type: blockchain.EventTypeNetworkAction,
+			NetworkAction: &blockchain.NetworkActionEvent{
+				Action:     "scheduled_terminate",
+				Payload:    `{"effectiveBlock":100}`,
+				Location:   location,
+				Event:      event,
+				SigningKey: verifier,
+			},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestNetworkActionScheduledAlreadyDue(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	location := fftypes.JSONAnyPtr("{}")
+	event := &blockchain.Event{ProtocolID: "0001"}
+	verifier := &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x1234",
+	}
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, event).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", em.ctx, []*core.ScheduledNetworkAction{}, event).Return([]*core.ScheduledNetworkAction{}, nil)
+	em.mmp.On("ScheduleNetworkAction", em.ctx, []*core.ScheduledNetworkAction{}, mock.Anything, event).Return(nil, false)
+	em.mmp.On("TerminateContract", em.ctx, location, event).Return(nil)
+
+	bc := &eventBatchContext{topicsByEventID: map[string]string{}}
+	err := em.handleBlockchainNetworkAction(em.ctx, &blockchain.NetworkActionEvent{
+		Action:     "scheduled_terminate",
+		Payload:    `{"effectiveTime":"2024-01-01T00:00:00Z"}`,
+		Location:   location,
+		Event:      event,
+		SigningKey: verifier,
+	}, bc)
+	assert.NoError(t, err)
+	assert.False(t, bc.scheduledChanged)
+}
+
+func TestNetworkActionTerminateIgnoresPayload(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	location := fftypes.JSONAnyPtr("{}")
+	event := &blockchain.Event{ProtocolID: "0001"}
+	verifier := &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x1234",
+	}
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, event).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", em.ctx, []*core.ScheduledNetworkAction{}, event).Return([]*core.ScheduledNetworkAction{}, nil)
+	em.mmp.On("TerminateContract", em.ctx, location, event).Return(nil)
+
+	err := em.handleBlockchainNetworkAction(em.ctx, &blockchain.NetworkActionEvent{
+		Action:     "terminate",
+		Payload:    `{"effectiveBlock":100}`,
+		Location:   location,
+		Event:      event,
+		SigningKey: verifier,
+	}, &eventBatchContext{topicsByEventID: map[string]string{}})
+	assert.NoError(t, err)
+}
+
+func TestNetworkActionBadPayload(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	event := &blockchain.Event{ProtocolID: "0001"}
+	verifier := &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x1234",
+	}
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, event).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", em.ctx, []*core.ScheduledNetworkAction{}, event).Return([]*core.ScheduledNetworkAction{}, nil)
+
+	err := em.handleBlockchainNetworkAction(em.ctx, &blockchain.NetworkActionEvent{
+		Action:     "scheduled_terminate",
+		Payload:    "!json",
+		Location:   fftypes.JSONAnyPtr("{}"),
+		Event:      event,
+		SigningKey: verifier,
+	}, &eventBatchContext{topicsByEventID: map[string]string{}})
+	assert.NoError(t, err)
+}
+
+func TestNetworkActionApplyScheduledFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	event := &blockchain.Event{ProtocolID: "0001"}
+	verifier := &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x1234",
+	}
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeOrg}, verifier).Return(&core.Identity{}, nil)
+	em.mmp.On("CheckContractMigration", em.ctx, event).Return(nil)
+	em.mmp.On("GetScheduledNetworkActions").Return([]*core.ScheduledNetworkAction{})
+	em.mmp.On("ApplyScheduledNetworkActions", em.ctx, []*core.ScheduledNetworkAction{}, event).Return(nil, fmt.Errorf("pop"))
+
+	err := em.handleBlockchainNetworkAction(em.ctx, &blockchain.NetworkActionEvent{
+		Action:     "terminate",
+		Location:   fftypes.JSONAnyPtr("{}"),
+		Event:      event,
+		SigningKey: verifier,
+	}, &eventBatchContext{topicsByEventID: map[string]string{}})
+	assert.EqualError(t, err, "pop")
+}
+
 func TestActionTerminateFail(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -0,0 +1,163 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"database/sql/driver"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/events"
+)
+
+// operationWatch is the set of operations and transactions watched by a connection
+type operationWatch struct {
+	watcher      events.OperationWatcher
+	operations   map[fftypes.UUID]bool
+	transactions map[fftypes.UUID]bool
+}
+
+func (ow *operationWatch) matches(op *core.Operation) bool {
+	return (op.ID != nil && ow.operations[*op.ID]) ||
+		(op.Transaction != nil && ow.transactions[*op.Transaction])
+}
+
+func (sm *subscriptionManager) watchOperations(connID string, operations, transactions []*fftypes.UUID, watcher events.OperationWatcher) error {
+	sm.mux.Lock()
+	ow, ok := sm.operationWatches[connID]
+	if !ok {
+		ow = &operationWatch{
+			operations:   make(map[fftypes.UUID]bool),
+			transactions: make(map[fftypes.UUID]bool),
+		}
+		sm.operationWatches[connID] = ow
+	}
+	ow.watcher = watcher
+	for _, id := range operations {
+		ow.operations[*id] = true
+	}
+	for _, id := range transactions {
+		ow.transactions[*id] = true
+	}
+	sm.mux.Unlock()
+
+	// Updates from now on are pushed by the listener, so send the current state of everything just added.
+	// An operation that is updated in the meantime might be sent twice, which is harmless as the latest
+	// state is always sent last.
+	ops, err := sm.getWatchedOperations(operations, transactions)
+	if err != nil {
+		return err
+	}
+	log.L(sm.ctx).Debugf("Connection %s watching %d operations and %d transactions (%d existing operations)", connID, len(operations), len(transactions), len(ops))
+	for _, op := range ops {
+		if err := watcher(op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sm *subscriptionManager) getWatchedOperations(operations, transactions []*fftypes.UUID) ([]*core.Operation, error) {
+	if len(operations) == 0 && len(transactions) == 0 {
+		return nil, nil
+	}
+	fb := database.OperationQueryFactory.NewFilter(sm.ctx)
+	var conditions []ffapi.Filter
+	if len(operations) > 0 {
+		conditions = append(conditions, fb.In("id", uuidValues(operations)))
+	}
+	if len(transactions) > 0 {
+		conditions = append(conditions, fb.In("tx", uuidValues(transactions)))
+	}
+	ops, _, err := sm.database.GetOperations(sm.ctx, sm.namespace.Name, fb.Or(conditions...).Sort("created"))
+	return ops, err
+}
+
+func uuidValues(ids []*fftypes.UUID) []driver.Value {
+	values := make([]driver.Value, len(ids))
+	for i, id := range ids {
+		values[i] = id
+	}
+	return values
+}
+
+func (sm *subscriptionManager) unwatchOperations(connID string, operations, transactions []*fftypes.UUID) {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	ow, ok := sm.operationWatches[connID]
+	if !ok {
+		return
+	}
+	for _, id := range operations {
+		delete(ow.operations, *id)
+	}
+	for _, id := range transactions {
+		delete(ow.transactions, *id)
+	}
+	if len(ow.operations) == 0 && len(ow.transactions) == 0 {
+		delete(sm.operationWatches, connID)
+	}
+}
+
+// operationUpdateListener passes each created or updated operation to the connections watching it. Updates are
+// handled one at a time, so each connection sees the changes to an operation in the order they were made.
+func (sm *subscriptionManager) operationUpdateListener() {
+	for {
+		select {
+		case id := <-sm.operationUpdates:
+			sm.operationUpdated(id)
+		case <-sm.ctx.Done():
+			return
+		}
+	}
+}
+
+func (sm *subscriptionManager) operationUpdated(id *fftypes.UUID) {
+	sm.mux.Lock()
+	watching := len(sm.operationWatches) > 0
+	sm.mux.Unlock()
+	if !watching {
+		return
+	}
+
+	op, err := sm.database.GetOperationByID(sm.ctx, sm.namespace.Name, id)
+	if err != nil || op == nil {
+		log.L(sm.ctx).Warnf("Unable to read watched operation %s: %v", id, err)
+		return
+	}
+
+	sm.mux.Lock()
+	watchers := make(map[string]events.OperationWatcher)
+	for connID, ow := range sm.operationWatches {
+		if ow.matches(op) {
+			watchers[connID] = ow.watcher
+		}
+	}
+	sm.mux.Unlock()
+
+	for connID, watcher := range watchers {
+		if err := watcher(op); err != nil {
+			log.L(sm.ctx).Warnf("Removing operation watches for connection %s: %s", connID, err)
+			sm.mux.Lock()
+			delete(sm.operationWatches, connID)
+			sm.mux.Unlock()
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWatchOperations(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mdi := sm.database.(*databasemocks.Plugin)
+
+	opID := fftypes.NewUUID()
+	txID := fftypes.NewUUID()
+	existing := []*core.Operation{
+		{ID: opID, Status: core.OpStatusPending},
+		{ID: fftypes.NewUUID(), Transaction: txID, Status: core.OpStatusSucceeded},
+	}
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(existing, nil, nil)
+
+	be := &boundCallbacks{sm: sm, ei: mei}
+	var received []*core.Operation
+	err := be.WatchOperations("conn1", []*fftypes.UUID{opID}, []*fftypes.UUID{txID}, func(op *core.Operation) error {
+		received = append(received, op)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, existing, received)
+
+	// An update to a watched operation, then one to an operation of a watched transaction, then an unwatched one
+	updated := &core.Operation{ID: opID, Status: core.OpStatusSucceeded, Output: fftypes.JSONObject{"receipt": "r1"}}
+	mdi.On("GetOperationByID", mock.Anything, "ns1", opID).Return(updated, nil)
+	txOp := &core.Operation{ID: fftypes.NewUUID(), Transaction: txID, Status: core.OpStatusInitialized}
+	mdi.On("GetOperationByID", mock.Anything, "ns1", txOp.ID).Return(txOp, nil)
+	other := &core.Operation{ID: fftypes.NewUUID(), Transaction: fftypes.NewUUID()}
+	mdi.On("GetOperationByID", mock.Anything, "ns1", other.ID).Return(other, nil)
+	sm.operationUpdated(opID)
+	sm.operationUpdated(txOp.ID)
+	sm.operationUpdated(other.ID)
+	assert.Len(t, received, 4)
+	assert.Equal(t, updated, received[2])
+	assert.Equal(t, txOp, received[3])
+
+	// Once everything is unwatched, there is no lookup
+	be.UnwatchOperations("conn1", []*fftypes.UUID{opID}, nil)
+	be.UnwatchOperations("conn1", nil, []*fftypes.UUID{txID})
+	assert.Empty(t, sm.operationWatches)
+	sm.unwatchOperations("conn1", []*fftypes.UUID{opID}, nil)
+	sm.operationUpdated(fftypes.NewUUID())
+	assert.Len(t, received, 4)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchOperationsListener(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	mdi := sm.database.(*databasemocks.Plugin)
+
+	opID := fftypes.NewUUID()
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", opID).Return(&core.Operation{ID: opID}, nil)
+
+	received := make(chan *core.Operation, 1)
+	err := sm.watchOperations("conn1", []*fftypes.UUID{opID}, nil, func(op *core.Operation) error {
+		received <- op
+		return nil
+	})
+	assert.NoError(t, err)
+
+	go sm.operationUpdateListener()
+	sm.operationUpdates <- opID
+	op := <-received
+	assert.Equal(t, opID, op.ID)
+
+	cancel()
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchOperationsGetFail(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mdi := sm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := sm.watchOperations("conn1", nil, []*fftypes.UUID{fftypes.NewUUID()}, func(op *core.Operation) error {
+		return nil
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchOperationsWatcherFail(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mdi := sm.database.(*databasemocks.Plugin)
+
+	opID := fftypes.NewUUID()
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{{ID: opID}}, nil, nil)
+
+	err := sm.watchOperations("conn1", []*fftypes.UUID{opID}, nil, func(op *core.Operation) error {
+		return fmt.Errorf("pop")
+	})
+	assert.EqualError(t, err, "pop")
+
+	// A failure to deliver an update removes the watches of the connection
+	mdi.On("GetOperationByID", mock.Anything, "ns1", opID).Return(&core.Operation{ID: opID}, nil)
+	sm.operationUpdated(opID)
+	assert.Empty(t, sm.operationWatches)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchOperationsLookupFail(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mdi := sm.database.(*databasemocks.Plugin)
+
+	opID := fftypes.NewUUID()
+	err := sm.watchOperations("conn1", nil, nil, func(op *core.Operation) error {
+		assert.Fail(t, "unexpected update")
+		return nil
+	})
+	assert.NoError(t, err)
+	sm.operationWatches["conn1"].operations[*opID] = true
+
+	mdi.On("GetOperationByID", mock.Anything, "ns1", opID).Return(nil, fmt.Errorf("pop"))
+	sm.operationUpdated(opID)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchOperationsConnectionClosed(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mdi := sm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	err := sm.watchOperations("conn1", []*fftypes.UUID{fftypes.NewUUID()}, nil, func(op *core.Operation) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	sm.connectionClosed(mei, "conn1")
+	assert.Empty(t, sm.operationWatches)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 )
@@ -57,6 +58,12 @@ func (em *eventManager) persistBatch(ctx context.Context, batch *core.Batch) (pe
 		return nil, false, nil // This is not retryable. skip this batch
 	}
 
+	if batch.Trace {
+		ctx = tracing.WithTraceFlag(ctx, "batch", batch.ID)
+		l = log.L(ctx)
+	}
+	tracing.Logf(ctx, "Persisting batch '%s' from author=%s node=%s with %d messages", batch.ID, batch.Author, batch.Node, len(batch.Payload.Messages))
+
 	// Set confirmed on the batch (the messages should not be confirmed at this point - that's the aggregator's job)
 	persistedBatch, manifest := batch.Confirmed()
 	manifestHash := fftypes.HashString(persistedBatch.Manifest.String())
@@ -73,6 +80,15 @@ func (em *eventManager) persistBatch(ctx context.Context, batch *core.Batch) (pe
 		}
 	}
 
+	// Verify the payload signature, which is checked (if present) even for unpinned batches
+	if retryable, err := em.identity.VerifyPayloadSignature(ctx, batch.Author, manifest); err != nil {
+		if retryable {
+			return nil, false, err
+		}
+		l.Errorf("Invalid batch '%s'. %s", batch.ID, err)
+		return nil, false, nil // This is not retryable. skip this batch
+	}
+
 	// Insert the batch
 	existing, err := em.database.InsertOrGetBatch(ctx, persistedBatch)
 	if err != nil {
@@ -109,6 +109,42 @@ func TestPersistBatchAlreadyExisting(t *testing.T) {
 
 }
 
+func TestPersistBatchPayloadSignatureInvalid(t *testing.T) {
+
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypePrivate, core.TransactionTypeUnpinned, core.DataArray{data})
+
+	em.mim.ExpectedCalls = nil
+	em.mim.On("VerifyPayloadSignature", em.ctx, batch.Author, mock.MatchedBy(func(manifest *core.BatchManifest) bool {
+		return manifest.ID.Equals(batch.ID)
+	})).Return(false, fmt.Errorf("pop"))
+
+	_, valid, err := em.persistBatch(em.ctx, batch)
+	assert.False(t, valid)
+	assert.NoError(t, err)
+
+}
+
+func TestPersistBatchPayloadSignatureLookupFail(t *testing.T) {
+
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypePrivate, core.TransactionTypeUnpinned, core.DataArray{data})
+
+	em.mim.ExpectedCalls = nil
+	em.mim.On("VerifyPayloadSignature", em.ctx, batch.Author, mock.Anything).Return(true, fmt.Errorf("pop"))
+
+	_, valid, err := em.persistBatch(em.ctx, batch)
+	assert.False(t, valid)
+	assert.EqualError(t, err, "pop")
+
+}
+
 func TestPersistBatchNoCacheDataNotInBatch(t *testing.T) {
 
 	em := newTestEventManager(t)
@@ -0,0 +1,75 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poll
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+)
+
+const (
+	PollTransport = "poll"
+)
+
+// Poll is the transport for durable subscriptions whose consumers fetch pages of events over REST,
+// and acknowledge the highest sequence they have processed. It never registers a connection, so no
+// dispatcher is started for its subscriptions and events accumulate behind the stored offset.
+type Poll struct {
+	capabilities *events.Capabilities
+}
+
+func (p *Poll) Name() string { return PollTransport }
+
+func (p *Poll) InitConfig(config config.Section) {}
+
+func (p *Poll) Init(ctx context.Context, config config.Section) (err error) {
+	*p = Poll{
+		capabilities: &events.Capabilities{},
+	}
+	return nil
+}
+
+func (p *Poll) SetHandler(namespace string, handler events.Callbacks) error {
+	// Consumers come to us, so there is never a connection to register
+	return nil
+}
+
+func (p *Poll) Capabilities() *events.Capabilities {
+	return p.capabilities
+}
+
+func (p *Poll) ValidateOptions(ctx context.Context, options *core.SubscriptionOptions) error {
+	return nil
+}
+
+func (p *Poll) DeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, event *core.EventDelivery, data core.DataArray) error {
+	return i18n.NewError(ctx, coremsgs.MsgPollTransportNoDelivery, p.Name()) // should never happen
+}
+
+func (p *Poll) BatchDeliveryRequest(ctx context.Context, connID string, sub *core.Subscription, events []*core.CombinedEventDataDelivery) error {
+	return i18n.NewError(ctx, coremsgs.MsgPollTransportNoDelivery, p.Name()) // should never happen
+}
+
+func (p *Poll) NamespaceRestarted(ns string, startTime time.Time) {
+	// no-op
+}
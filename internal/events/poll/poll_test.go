@@ -0,0 +1,62 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poll
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPoll(t *testing.T) (p *Poll, ctx context.Context) {
+	p = &Poll{}
+	ctx = context.Background()
+	config := config.RootSection("ut.events")
+	p.InitConfig(config)
+	err := p.Init(ctx, config)
+	assert.NoError(t, err)
+	return p, ctx
+}
+
+func TestPollPlugin(t *testing.T) {
+	p, ctx := newTestPoll(t)
+
+	cbs := &eventsmocks.Callbacks{}
+	err := p.SetHandler("ns1", cbs)
+	assert.NoError(t, err)
+	cbs.AssertExpectations(t) // no connection is ever registered
+
+	assert.Equal(t, "poll", p.Name())
+	assert.False(t, p.Capabilities().BatchDelivery)
+	assert.NoError(t, p.ValidateOptions(ctx, &core.SubscriptionOptions{}))
+	p.NamespaceRestarted("ns1", time.Now())
+}
+
+func TestPollPluginNoDelivery(t *testing.T) {
+	p, ctx := newTestPoll(t)
+
+	err := p.DeliveryRequest(ctx, "conn1", &core.Subscription{}, &core.EventDelivery{}, nil)
+	assert.Regexp(t, "FF10619", err)
+
+	err = p.BatchDeliveryRequest(ctx, "conn1", &core.Subscription{}, []*core.CombinedEventDataDelivery{})
+	assert.Regexp(t, "FF10619", err)
+}
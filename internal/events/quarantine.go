@@ -0,0 +1,110 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// quarantinePolicy decides which inbound messages are held back from applications once confirmed,
+// so they can be reviewed before they are released to subscriptions
+type quarantinePolicy struct {
+	authors map[string]bool
+	tags    map[string]bool
+}
+
+func newQuarantinePolicy() *quarantinePolicy {
+	qp := &quarantinePolicy{
+		authors: make(map[string]bool),
+		tags:    make(map[string]bool),
+	}
+	for _, author := range config.GetStringSlice(coreconfig.EventAggregatorQuarantineAuthors) {
+		qp.authors[author] = true
+	}
+	for _, tag := range config.GetStringSlice(coreconfig.EventAggregatorQuarantineTags) {
+		qp.tags[tag] = true
+	}
+	return qp
+}
+
+// quarantined returns true if the message should be held once confirmed. Definitions are never quarantined,
+// as they are processed in-line by the aggregator rather than delivered to applications.
+func (qp *quarantinePolicy) quarantined(msg *core.Message) bool {
+	switch msg.Header.Type {
+	case core.MessageTypeDefinition, core.MessageTypeGroupInit:
+		return false
+	}
+	return qp.authors[msg.Header.Author] || (msg.Header.Tag != "" && qp.tags[msg.Header.Tag])
+}
+
+// ReleaseMessage confirms a quarantined message, delivering it to subscriptions
+func (em *eventManager) ReleaseMessage(ctx context.Context, msgID *fftypes.UUID) (msg *core.Message, err error) {
+	err = em.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		msg, err = em.database.GetMessageByID(ctx, em.namespace.Name, msgID)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			return i18n.NewError(ctx, coremsgs.Msg404NotFound)
+		}
+		if msg.State != core.MessageStateQuarantined {
+			return i18n.NewError(ctx, coremsgs.MsgMessageNotQuarantined, msgID, msg.State)
+		}
+
+		msg.State = core.MessageStateConfirmed
+		msg.Confirmed = fftypes.Now()
+		fb := database.MessageQueryFactory.NewFilter(ctx)
+		filter := fb.And(
+			fb.Eq("id", msgID),
+			fb.Eq("state", core.MessageStateQuarantined),
+		)
+		update := database.MessageQueryFactory.NewUpdate(ctx).
+			Set("state", msg.State).
+			Set("confirmed", msg.Confirmed)
+		if err := em.database.UpdateMessages(ctx, em.namespace.Name, filter, update); err != nil {
+			return err
+		}
+
+		// The events are generated as they would have been on confirmation - one per topic
+		for _, topic := range msg.Header.Topics {
+			event := core.NewEvent(core.EventTypeMessageConfirmed, em.namespace.Name, msg.Header.ID, msg.TransactionID, topic)
+			event.Correlator = msg.Header.CID
+			if err := em.database.InsertEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Released quarantined message '%s'", msgID)
+	em.data.UpdateMessageStateIfCached(ctx, msgID, msg.State, msg.Confirmed, "")
+	if em.metrics.IsMetricsEnabled() {
+		em.metrics.MessageConfirmed(msg, core.EventTypeMessageConfirmed)
+	}
+	return msg, nil
+}
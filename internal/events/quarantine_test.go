@@ -0,0 +1,199 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestQuarantinedMessage() *core.Message {
+	return &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			CID:    fftypes.NewUUID(),
+			Type:   core.MessageTypePrivate,
+			Tag:    "held",
+			Topics: fftypes.FFStringArray{"topic1", "topic2"},
+		},
+		State:         core.MessageStateQuarantined,
+		TransactionID: fftypes.NewUUID(),
+	}
+}
+
+func TestQuarantinePolicy(t *testing.T) {
+	coreconfig.Reset()
+	defer coreconfig.Reset()
+	config.Set(coreconfig.EventAggregatorQuarantineAuthors, []string{"did:firefly:org/org2"})
+	config.Set(coreconfig.EventAggregatorQuarantineTags, []string{"held"})
+	qp := newQuarantinePolicy()
+
+	msg := &core.Message{Header: core.MessageHeader{Type: core.MessageTypeBroadcast, Author: "did:firefly:org/org1"}}
+	assert.False(t, qp.quarantined(msg))
+	msg.Header.Tag = "held"
+	assert.True(t, qp.quarantined(msg))
+	msg.Header.Tag = ""
+	msg.Header.Author = "did:firefly:org/org2"
+	assert.True(t, qp.quarantined(msg))
+	msg.Header.Type = core.MessageTypeDefinition
+	assert.False(t, qp.quarantined(msg))
+}
+
+func TestCompleteDispatchQuarantined(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.quarantine = &quarantinePolicy{tags: map[string]bool{"held": true}}
+	bs := newBatchState(&ag.aggregator)
+	msg := newTestQuarantinedMessage()
+
+	newState := ag.completeDispatch(core.ActionConfirm, nil, msg, nil, bs)
+	assert.Equal(t, core.MessageStateQuarantined, newState)
+
+	err := bs.RunFinalize(ag.ctx)
+	assert.NoError(t, err)
+	ag.mdi.AssertNotCalled(t, "InsertEvent", mock.Anything, mock.Anything)
+}
+
+func TestMarkUnpinnedMessagesQuarantined(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.aggregator.quarantine = &quarantinePolicy{tags: map[string]bool{"held": true}}
+
+	held := newTestQuarantinedMessage()
+	confirmed := newTestQuarantinedMessage()
+	confirmed.Header.Tag = ""
+	batch := &core.Batch{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Namespace: "ns1"},
+		Payload: core.BatchPayload{
+			Messages: []*core.Message{held, confirmed},
+		},
+	}
+
+	em.mdi.On("UpdateMessages", em.ctx, "ns1", mock.Anything, mock.Anything).Return(nil).Twice()
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Reference.Equals(confirmed.Header.ID)
+	})).Return(nil).Twice()
+
+	err := em.markUnpinnedMessagesConfirmed(em.ctx, batch)
+	assert.NoError(t, err)
+}
+
+func TestMarkUnpinnedMessagesQuarantinedFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.aggregator.quarantine = &quarantinePolicy{tags: map[string]bool{"held": true}}
+
+	batch := &core.Batch{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Namespace: "ns1"},
+		Payload: core.BatchPayload{
+			Messages: []*core.Message{newTestQuarantinedMessage()},
+		},
+	}
+
+	em.mdi.On("UpdateMessages", em.ctx, "ns1", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.markUnpinnedMessagesConfirmed(em.ctx, batch)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReleaseMessage(t *testing.T) {
+	em := newTestEventManagerWithMetrics(t)
+	defer em.cleanup(t)
+	msg := newTestQuarantinedMessage()
+
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("UpdateMessages", em.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeMessageConfirmed &&
+			event.Reference.Equals(msg.Header.ID) &&
+			event.Correlator.Equals(msg.Header.CID) &&
+			event.Transaction.Equals(msg.TransactionID)
+	})).Return(nil).Twice()
+	em.mdm.On("UpdateMessageStateIfCached", em.ctx, msg.Header.ID, core.MessageStateConfirmed, mock.Anything, "").Return()
+	em.mmi.On("MessageConfirmed", msg, core.EventTypeMessageConfirmed).Return()
+
+	released, err := em.ReleaseMessage(em.ctx, msg.Header.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageStateConfirmed, released.State)
+	assert.NotNil(t, released.Confirmed)
+}
+
+func TestReleaseMessageNotQuarantined(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	msg := newTestQuarantinedMessage()
+	msg.State = core.MessageStateConfirmed
+
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+
+	_, err := em.ReleaseMessage(em.ctx, msg.Header.ID)
+	assert.Regexp(t, "FF10639", err)
+}
+
+func TestReleaseMessageNotFound(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	msgID := fftypes.NewUUID()
+
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msgID).Return(nil, nil)
+
+	_, err := em.ReleaseMessage(em.ctx, msgID)
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestReleaseMessageGetFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	msgID := fftypes.NewUUID()
+
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msgID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := em.ReleaseMessage(em.ctx, msgID)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReleaseMessageUpdateFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	msg := newTestQuarantinedMessage()
+
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("UpdateMessages", em.ctx, "ns1", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := em.ReleaseMessage(em.ctx, msg.Header.ID)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReleaseMessageInsertEventFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	msg := newTestQuarantinedMessage()
+
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("UpdateMessages", em.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := em.ReleaseMessage(em.ctx, msg.Header.ID)
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,336 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"database/sql/driver"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const rebuildPageSize = 100
+
+// RebuildDerivedState regenerates a set of derived records from the records they are derived from, reporting
+// the number of source records read and derived records corrected after each page.
+// Ingestion must be paused by the caller, so the records do not change underneath the rebuild.
+func (em *eventManager) RebuildDerivedState(ctx context.Context, target core.RebuildTarget, progress func(processed, repaired int64)) error {
+	if em.aggregator == nil && target != core.RebuildTargetTokenBalances {
+		return nil // pins are only recorded by multiparty namespaces
+	}
+	switch target {
+	case core.RebuildTargetNextPins:
+		return em.rebuildNextPins(ctx, progress)
+	case core.RebuildTargetMessageStates:
+		return em.rebuildMessageStates(ctx, progress)
+	default:
+		return em.rebuildTokenBalances(ctx, progress)
+	}
+}
+
+type rebuildContext struct {
+	topic  string
+	group  *fftypes.Bytes32
+	nonces map[string]int64 // highest nonce sent by each member
+}
+
+// rebuildNextPins finds the highest nonce sent by each member of each private context, from the pins of
+// private messages that have been confirmed, rejected or quarantined. Missing next pins are created, and next pins that
+// are behind are moved forwards. Next pins are never moved backwards, as the messages that advanced them
+// might have been removed by the retention policy.
+func (em *eventManager) rebuildNextPins(ctx context.Context, progress func(processed, repaired int64)) error {
+	contexts := make(map[fftypes.Bytes32]*rebuildContext)
+	var lastSequence int64 = -1
+	for {
+		fb := database.MessageQueryFactory.NewFilter(ctx)
+		msgs, _, err := em.database.GetMessages(ctx, em.namespace.Name, fb.And(
+			fb.Gt("sequence", lastSequence),
+			fb.In("state", []driver.Value{core.MessageStateConfirmed, core.MessageStateRejected, core.MessageStateQuarantined}),
+		).Sort("sequence").Limit(rebuildPageSize))
+		if err != nil {
+			return err
+		}
+		for _, msg := range msgs {
+			lastSequence = msg.Sequence
+			if msg.Header.Group == nil {
+				continue
+			}
+			for i, topic := range msg.Header.Topics {
+				nonce, ok := pinNonce(msg, i)
+				if !ok {
+					log.L(ctx).Warnf("Rebuild skipping invalid pin %d of message %s", i, msg.Header.ID)
+					continue
+				}
+				contextTopic := msg.Header.ContextTopic(topic)
+				contextUnmasked := privateContext(contextTopic, msg.Header.Group)
+				rc := contexts[*contextUnmasked]
+				if rc == nil {
+					rc = &rebuildContext{topic: contextTopic, group: msg.Header.Group, nonces: make(map[string]int64)}
+					contexts[*contextUnmasked] = rc
+				}
+				if existing, ok := rc.nonces[msg.Header.Author]; !ok || nonce > existing {
+					rc.nonces[msg.Header.Author] = nonce
+				}
+			}
+		}
+		progress(int64(len(msgs)), 0)
+		if len(msgs) < rebuildPageSize {
+			break
+		}
+	}
+
+	for contextUnmasked, rc := range contexts {
+		contextUnmasked := contextUnmasked
+		repaired, err := em.rebuildContextNextPins(ctx, &contextUnmasked, rc)
+		if err != nil {
+			return err
+		}
+		progress(0, repaired)
+	}
+	return nil
+}
+
+func (em *eventManager) rebuildContextNextPins(ctx context.Context, contextUnmasked *fftypes.Bytes32, rc *rebuildContext) (repaired int64, err error) {
+	group, err := em.database.GetGroupByHash(ctx, em.namespace.Name, rc.group)
+	if err != nil {
+		return 0, err
+	}
+	if group == nil {
+		log.L(ctx).Warnf("Rebuild skipping context %s of unknown group %s", contextUnmasked, rc.group)
+		return 0, nil
+	}
+	nextPins, err := em.database.GetNextPinsForContext(ctx, em.namespace.Name, contextUnmasked)
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[string]*core.NextPin, len(nextPins))
+	for _, np := range nextPins {
+		existing[np.Identity] = np
+	}
+	err = em.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		for _, member := range group.Members {
+			var nonce int64
+			if sent, ok := rc.nonces[member.Identity]; ok {
+				nonce = sent + 1
+			}
+			np := existing[member.Identity]
+			switch {
+			case np == nil:
+				log.L(ctx).Infof("Rebuild creating next pin for %s on context %s with nonce %d", member.Identity, contextUnmasked, nonce)
+				if err := em.database.InsertNextPin(ctx, &core.NextPin{
+					Namespace: em.namespace.Name,
+					Context:   contextUnmasked,
+					Identity:  member.Identity,
+					Hash:      privatePinHash(rc.topic, rc.group, member.Identity, nonce),
+					Nonce:     nonce,
+				}); err != nil {
+					return err
+				}
+			case np.Nonce < nonce:
+				log.L(ctx).Infof("Rebuild moving next pin for %s on context %s from nonce %d to %d", member.Identity, contextUnmasked, np.Nonce, nonce)
+				update := database.NextPinQueryFactory.NewUpdate(ctx).
+					Set("nonce", nonce).
+					Set("hash", privatePinHash(rc.topic, rc.group, member.Identity, nonce))
+				if err := em.database.UpdateNextPin(ctx, em.namespace.Name, np.Sequence, update); err != nil {
+					return err
+				}
+			default:
+				continue
+			}
+			repaired++
+		}
+		return nil
+	})
+	return repaired, err
+}
+
+// pinNonce returns the nonce of a private message pin, which is in the form "hash:nonce"
+func pinNonce(msg *core.Message, i int) (int64, bool) {
+	if i >= len(msg.Pins) {
+		return 0, false
+	}
+	_, nonceStr, ok := strings.Cut(msg.Pins[i], ":")
+	if !ok {
+		return 0, false
+	}
+	nonce, err := strconv.ParseInt(nonceStr, 10, 64)
+	return nonce, err == nil
+}
+
+// rebuildMessageStates finds the messages whose pins have all been dispatched, but which have not been
+// marked confirmed or rejected. They are marked rejected if a rejection event was recorded for them, and
+// confirmed otherwise. No new events are emitted.
+func (em *eventManager) rebuildMessageStates(ctx context.Context, progress func(processed, repaired int64)) error {
+	var lastSequence int64 = -1
+	for {
+		fb := database.BatchQueryFactory.NewFilter(ctx)
+		batches, _, err := em.database.GetBatches(ctx, em.namespace.Name, fb.Gt("sequence", lastSequence).Sort("sequence").Limit(rebuildPageSize))
+		if err != nil {
+			return err
+		}
+		for _, batch := range batches {
+			lastSequence = batch.Sequence
+			repaired, err := em.rebuildBatchMessageStates(ctx, batch)
+			if err != nil {
+				return err
+			}
+			progress(1, repaired)
+		}
+		if len(batches) < rebuildPageSize {
+			return nil
+		}
+	}
+}
+
+func (em *eventManager) rebuildBatchMessageStates(ctx context.Context, batch *core.BatchPersisted) (repaired int64, err error) {
+	manifest := em.aggregator.extractManifest(ctx, batch)
+	if manifest == nil {
+		log.L(ctx).Warnf("Rebuild skipping batch %s with invalid manifest", batch.ID)
+		return 0, nil
+	}
+	fb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := em.database.GetPins(ctx, em.namespace.Name, fb.Eq("batch", batch.ID))
+	if err != nil || len(pins) == 0 {
+		return 0, err // unpinned batches have no pins to derive state from
+	}
+	dispatched := make(map[int64]bool, len(pins))
+	for _, pin := range pins {
+		dispatched[pin.Index] = pin.Dispatched
+	}
+
+	var index int64
+	for _, entry := range manifest.Messages {
+		allDispatched := entry.Topics > 0
+		for i := 0; i < entry.Topics; i++ {
+			allDispatched = allDispatched && dispatched[index]
+			index++
+		}
+		if !allDispatched {
+			continue
+		}
+		fixed, err := em.rebuildMessageState(ctx, entry.ID)
+		if err != nil {
+			return repaired, err
+		}
+		if fixed {
+			repaired++
+		}
+	}
+	return repaired, nil
+}
+
+func (em *eventManager) rebuildMessageState(ctx context.Context, id *fftypes.UUID) (bool, error) {
+	msg, err := em.database.GetMessageByID(ctx, em.namespace.Name, id)
+	if err != nil || msg == nil {
+		return false, err
+	}
+	switch msg.State {
+	case core.MessageStateConfirmed, core.MessageStateRejected, core.MessageStateQuarantined:
+		// Quarantined messages have been dispatched, and are only confirmed when released
+		return false, nil
+	}
+
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	rejections, _, err := em.database.GetEvents(ctx, em.namespace.Name, fb.And(
+		fb.Eq("reference", id),
+		fb.Eq("type", core.EventTypeMessageRejected),
+	).Limit(1))
+	if err != nil {
+		return false, err
+	}
+	state := core.MessageStateConfirmed
+	if len(rejections) > 0 {
+		state = core.MessageStateRejected
+	}
+
+	log.L(ctx).Infof("Rebuild moving message %s from state %s to %s", id, msg.State, state)
+	confirmed := fftypes.Now()
+	update := database.MessageQueryFactory.NewUpdate(ctx).
+		Set("state", state).
+		Set("confirmed", confirmed)
+	if err := em.database.UpdateMessage(ctx, em.namespace.Name, id, update); err != nil {
+		return false, err
+	}
+	em.data.UpdateMessageStateIfCached(ctx, id, state, confirmed, msg.RejectReason)
+	return true, nil
+}
+
+// rebuildTokenBalances recalculates the balances of each token pool from its transfers. Token transfers are
+// never pruned by the retention policies, so every transfer of the pool is replayed.
+func (em *eventManager) rebuildTokenBalances(ctx context.Context, progress func(processed, repaired int64)) error {
+	var lastSequence int64 = -1
+	for {
+		fb := database.TokenPoolQueryFactory.NewFilter(ctx)
+		pools, _, err := em.database.GetTokenPools(ctx, em.namespace.Name, fb.Gt("sequence", lastSequence).Sort("sequence").Limit(rebuildPageSize))
+		if err != nil {
+			return err
+		}
+		for _, pool := range pools {
+			lastSequence = pool.Sequence
+			if err := em.rebuildPoolBalances(ctx, pool, progress); err != nil {
+				return err
+			}
+			progress(0, 1)
+		}
+		if len(pools) < rebuildPageSize {
+			return nil
+		}
+	}
+}
+
+// rebuildPoolBalances applies the transfers of a pool to its balances a page at a time, so a pool with
+// a large number of transfers is not rebuilt in a single database transaction
+func (em *eventManager) rebuildPoolBalances(ctx context.Context, pool *core.TokenPool, progress func(processed, repaired int64)) error {
+	log.L(ctx).Infof("Rebuild recalculating balances of token pool %s", pool.ID)
+	if err := em.database.DeleteTokenBalances(ctx, em.namespace.Name, pool.ID); err != nil {
+		return err
+	}
+	var lastSequence int64 = -1
+	for {
+		fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+		transfers, _, err := em.database.GetTokenTransfers(ctx, em.namespace.Name, fb.And(
+			fb.Eq("pool", pool.ID),
+			fb.Gt("sequence", lastSequence),
+		).Sort("sequence").Limit(rebuildPageSize))
+		if err != nil {
+			return err
+		}
+		if len(transfers) > 0 {
+			lastSequence = transfers[len(transfers)-1].Sequence
+		}
+		err = em.database.RunAsGroup(ctx, func(ctx context.Context) error {
+			for _, transfer := range transfers {
+				if err := em.database.UpdateTokenBalances(ctx, transfer); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		progress(int64(len(transfers)), 0)
+		if len(transfers) < rebuildPageSize {
+			return nil
+		}
+	}
+}
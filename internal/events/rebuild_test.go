@@ -0,0 +1,318 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type rebuildProgress struct {
+	processed int64
+	repaired  int64
+}
+
+func (p *rebuildProgress) report(processed, repaired int64) {
+	p.processed += processed
+	p.repaired += repaired
+}
+
+func mockRunAsGroup(em *testEventManager) {
+	rag := em.mdi.On("RunAsGroup", mock.Anything, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
+	}
+}
+
+func TestRebuildNextPins(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	mockRunAsGroup(em)
+
+	group := fftypes.NewRandB32()
+	contextUnmasked := privateContext("topic1", group)
+	em.mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{
+		{
+			Header: core.MessageHeader{ID: fftypes.NewUUID(), Topics: fftypes.FFStringArray{"topic1"}, Group: group,
+				SignerRef: core.SignerRef{Author: "did:firefly:org/a"}},
+			Pins: fftypes.FFStringArray{fmt.Sprintf("%s:%.16d", fftypes.NewRandB32(), 3)},
+		},
+		{
+			Header: core.MessageHeader{ID: fftypes.NewUUID(), Topics: fftypes.FFStringArray{"topic1"}, Group: group,
+				SignerRef: core.SignerRef{Author: "did:firefly:org/a"}},
+			Pins: fftypes.FFStringArray{"bad"},
+		},
+		{
+			Header: core.MessageHeader{ID: fftypes.NewUUID(), Topics: fftypes.FFStringArray{"topic1"}},
+		},
+	}, nil, nil)
+	em.mdi.On("GetGroupByHash", mock.Anything, "ns1", group).Return(&core.Group{
+		GroupIdentity: core.GroupIdentity{Members: core.Members{
+			{Identity: "did:firefly:org/a"},
+			{Identity: "did:firefly:org/b"},
+			{Identity: "did:firefly:org/c"},
+		}},
+	}, nil)
+	em.mdi.On("GetNextPinsForContext", mock.Anything, "ns1", contextUnmasked).Return([]*core.NextPin{
+		{Identity: "did:firefly:org/a", Nonce: 2, Sequence: 10},
+		{Identity: "did:firefly:org/c", Nonce: 0, Sequence: 11},
+	}, nil)
+	em.mdi.On("UpdateNextPin", mock.Anything, "ns1", int64(10), mock.Anything).Return(nil)
+	em.mdi.On("InsertNextPin", mock.Anything, mock.MatchedBy(func(np *core.NextPin) bool {
+		return np.Identity == "did:firefly:org/b" && np.Nonce == 0 &&
+			np.Hash.Equals(privatePinHash("topic1", group, "did:firefly:org/b", 0))
+	})).Return(nil)
+
+	progress := &rebuildProgress{}
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetNextPins, progress.report)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), progress.processed)
+	assert.Equal(t, int64(2), progress.repaired)
+}
+
+func TestRebuildNextPinsUnknownGroup(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	group := fftypes.NewRandB32()
+	em.mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{
+		{
+			Header: core.MessageHeader{ID: fftypes.NewUUID(), Topics: fftypes.FFStringArray{"topic1"}, Group: group},
+			Pins:   fftypes.FFStringArray{fmt.Sprintf("%s:%.16d", fftypes.NewRandB32(), 0)},
+		},
+	}, nil, nil)
+	em.mdi.On("GetGroupByHash", mock.Anything, "ns1", group).Return(nil, nil)
+
+	progress := &rebuildProgress{}
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetNextPins, progress.report)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), progress.repaired)
+}
+
+func TestRebuildNextPinsFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	em.mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetNextPins, (&rebuildProgress{}).report)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRebuildNextPinsNotMultiparty(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.aggregator = nil
+
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetNextPins, (&rebuildProgress{}).report)
+	assert.NoError(t, err)
+}
+
+func TestRebuildMessageStates(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	msg1 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, State: core.MessageStatePending}
+	msg2 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, State: core.MessageStateConfirmed}
+	msg3 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, State: core.MessageStatePending}
+	manifest, _ := json.Marshal(&core.BatchManifest{
+		Version: core.ManifestVersion1,
+		ID:      batchID,
+		Messages: []*core.MessageManifestEntry{
+			{MessageRef: core.MessageRef{ID: msg1.Header.ID}, Topics: 1},
+			{MessageRef: core.MessageRef{ID: msg2.Header.ID}, Topics: 1},
+			{MessageRef: core.MessageRef{ID: msg3.Header.ID}, Topics: 2},
+		},
+	})
+	em.mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{ID: batchID}, Manifest: fftypes.JSONAnyPtrBytes(manifest)},
+	}, nil, nil)
+	em.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: batchID, Index: 0, Dispatched: true},
+		{Batch: batchID, Index: 1, Dispatched: true},
+		{Batch: batchID, Index: 2, Dispatched: true},
+		{Batch: batchID, Index: 3, Dispatched: false},
+	}, nil, nil)
+	em.mdi.On("GetMessageByID", mock.Anything, "ns1", msg1.Header.ID).Return(msg1, nil)
+	em.mdi.On("GetMessageByID", mock.Anything, "ns1", msg2.Header.ID).Return(msg2, nil)
+	em.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		{Type: core.EventTypeMessageRejected, Reference: msg1.Header.ID},
+	}, nil, nil)
+	em.mdi.On("UpdateMessage", mock.Anything, "ns1", msg1.Header.ID, mock.Anything).Return(nil)
+	em.mdm.On("UpdateMessageStateIfCached", mock.Anything, msg1.Header.ID, core.MessageStateRejected, mock.Anything, "")
+
+	progress := &rebuildProgress{}
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetMessageStates, progress.report)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), progress.processed)
+	assert.Equal(t, int64(1), progress.repaired)
+}
+
+func TestRebuildMessageStateQuarantined(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, State: core.MessageStateQuarantined}
+	em.mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+
+	fixed, err := em.rebuildMessageState(em.ctx, msg.Header.ID)
+	assert.NoError(t, err)
+	assert.False(t, fixed)
+}
+
+func TestRebuildMessageStatesBadManifest(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	em.mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}, Manifest: fftypes.JSONAnyPtr("!json")},
+	}, nil, nil)
+
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetMessageStates, (&rebuildProgress{}).report)
+	assert.NoError(t, err)
+}
+
+func TestRebuildMessageStatesUpdateFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, State: core.MessageStatePending}
+	manifest, _ := json.Marshal(&core.BatchManifest{
+		Version:  core.ManifestVersion1,
+		ID:       batchID,
+		Messages: []*core.MessageManifestEntry{{MessageRef: core.MessageRef{ID: msg.Header.ID}, Topics: 1}},
+	})
+	em.mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{ID: batchID}, Manifest: fftypes.JSONAnyPtrBytes(manifest)},
+	}, nil, nil)
+	em.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: batchID, Index: 0, Dispatched: true},
+	}, nil, nil)
+	em.mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+	em.mdi.On("UpdateMessage", mock.Anything, "ns1", msg.Header.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetMessageStates, (&rebuildProgress{}).report)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRebuildMessageStatesFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	em.mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetMessageStates, (&rebuildProgress{}).report)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRebuildTokenBalances(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	mockRunAsGroup(em)
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID()}
+	transfers := []*core.TokenTransfer{{Pool: pool.ID}, {Pool: pool.ID}}
+	em.mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{pool}, nil, nil)
+	em.mdi.On("DeleteTokenBalances", mock.Anything, "ns1", pool.ID).Return(nil)
+	em.mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return(transfers, nil, nil)
+	em.mdi.On("UpdateTokenBalances", mock.Anything, transfers[0]).Return(nil)
+	em.mdi.On("UpdateTokenBalances", mock.Anything, transfers[1]).Return(nil)
+
+	progress := &rebuildProgress{}
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetTokenBalances, progress.report)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), progress.processed)
+	assert.Equal(t, int64(1), progress.repaired)
+}
+
+func TestRebuildTokenBalancesPagesOnSequence(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	mockRunAsGroup(em)
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Sequence: 7}
+	page := make([]*core.TokenTransfer, rebuildPageSize)
+	for i := range page {
+		page[i] = &core.TokenTransfer{Pool: pool.ID, Sequence: int64(1000 + i)}
+	}
+	afterSequence := func(seq string) interface{} {
+		return mock.MatchedBy(func(f ffapi.Filter) bool {
+			fi, _ := f.Finalize()
+			return strings.Contains(fi.String(), "sequence >> "+seq)
+		})
+	}
+	em.mdi.On("GetTokenPools", mock.Anything, "ns1", afterSequence("-1")).Return([]*core.TokenPool{pool}, nil, nil)
+	em.mdi.On("DeleteTokenBalances", mock.Anything, "ns1", pool.ID).Return(nil)
+	em.mdi.On("GetTokenTransfers", mock.Anything, "ns1", afterSequence("-1")).Return(page, nil, nil).Once()
+	em.mdi.On("GetTokenTransfers", mock.Anything, "ns1", afterSequence("1099")).Return([]*core.TokenTransfer{}, nil, nil).Once()
+	em.mdi.On("UpdateTokenBalances", mock.Anything, mock.Anything).Return(nil).Times(rebuildPageSize)
+
+	progress := &rebuildProgress{}
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetTokenBalances, progress.report)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(rebuildPageSize), progress.processed)
+}
+
+func TestRebuildTokenBalancesDeleteFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID()}
+	em.mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{pool}, nil, nil)
+	em.mdi.On("DeleteTokenBalances", mock.Anything, "ns1", pool.ID).Return(fmt.Errorf("pop"))
+
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetTokenBalances, (&rebuildProgress{}).report)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRebuildTokenBalancesUpdateFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	mockRunAsGroup(em)
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID()}
+	transfers := []*core.TokenTransfer{{Pool: pool.ID}}
+	em.mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{pool}, nil, nil)
+	em.mdi.On("DeleteTokenBalances", mock.Anything, "ns1", pool.ID).Return(nil)
+	em.mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return(transfers, nil, nil)
+	em.mdi.On("UpdateTokenBalances", mock.Anything, transfers[0]).Return(fmt.Errorf("pop"))
+
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetTokenBalances, (&rebuildProgress{}).report)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRebuildTokenBalancesFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	em.mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := em.RebuildDerivedState(em.ctx, core.RebuildTargetTokenBalances, (&rebuildProgress{}).report)
+	assert.EqualError(t, err, "pop")
+}
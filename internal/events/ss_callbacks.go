@@ -18,7 +18,7 @@ package events
 
 import (
 	"context"
-	"encoding/json"
+	"io"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -26,7 +26,18 @@ import (
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
 )
 
-func (em *eventManager) SharedStorageBatchDownloaded(ss sharedstorage.Plugin, payloadRef string, data []byte) (*fftypes.UUID, error) {
+func (em *eventManager) SharedStorageBatchDownloaded(ss sharedstorage.Plugin, payloadRef string, reader io.Reader) (*fftypes.UUID, error) {
+	batch, err := em.SharedStorageBatchVerify(ss, payloadRef, reader)
+	if err != nil || batch == nil {
+		return nil, err
+	}
+	return em.SharedStorageBatchDeliver(batch)
+}
+
+// SharedStorageBatchVerify parses and verifies a batch as it is streamed from shared storage, without storing it.
+// A nil batch with no error is returned for a batch that is invalid, or is not for this namespace, as retrying
+// the download cannot fix it.
+func (em *eventManager) SharedStorageBatchVerify(ss sharedstorage.Plugin, payloadRef string, reader io.Reader) (*core.Batch, error) {
 
 	l := log.L(em.ctx)
 
@@ -35,22 +46,29 @@ func (em *eventManager) SharedStorageBatchDownloaded(ss sharedstorage.Plugin, pa
 		return nil, nil
 	}
 
-	// De-serialize the batch
-	var batch *core.Batch
-	err := json.Unmarshal(data, &batch)
+	// De-serialize and verify the batch as it is streamed
+	br := &batchStreamReader{r: reader}
+	batch, err := decodeBatchStream(em.ctx, br)
+	if br.readErr != nil {
+		return nil, br.readErr // retryable
+	}
 	if err != nil {
-		l.Errorf("Invalid batch downloaded from %s '%s': %s", ss.Name(), payloadRef, err)
+		l.Errorf("Invalid batch downloaded from %s '%s' after %d bytes: %s", ss.Name(), payloadRef, br.size, err)
 		return nil, nil
 	}
-	l.Infof("Shared storage batch downloaded from %s '%s' id=%s (len=%d)", ss.Name(), payloadRef, batch.ID, len(data))
+	l.Infof("Shared storage batch downloaded from %s '%s' id=%s (len=%d)", ss.Name(), payloadRef, batch.ID, br.size)
 
 	if batch.Namespace != em.namespace.NetworkName {
 		log.L(em.ctx).Debugf("Ignoring shared storage batch from different namespace '%s'", batch.Namespace)
 		return nil, nil // This is not retryable. skip this batch
 	}
 	batch.Namespace = em.namespace.Name
+	return batch, nil
+}
 
-	err = em.retry.Do(em.ctx, "persist batch", func(attempt int) (bool, error) {
+// SharedStorageBatchDeliver stores a batch previously returned by SharedStorageBatchVerify, and hands it to the aggregator
+func (em *eventManager) SharedStorageBatchDeliver(batch *core.Batch) (*fftypes.UUID, error) {
+	err := em.retry.Do(em.ctx, "persist batch", func(attempt int) (bool, error) {
 		err := em.database.RunAsGroup(em.ctx, func(ctx context.Context) error {
 			_, _, err := em.persistBatch(ctx, batch)
 			return err
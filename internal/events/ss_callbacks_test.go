@@ -17,9 +17,13 @@
 package events
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
@@ -49,7 +53,7 @@ func TestSharedStorageBatchDownloadedOk(t *testing.T) {
 
 	em.mim.On("GetLocalNode", mock.Anything).Return(testNode, nil)
 
-	bid, err := em.SharedStorageBatchDownloaded(mss, "payload1", b)
+	bid, err := em.SharedStorageBatchDownloaded(mss, "payload1", bytes.NewReader(b))
 	assert.NoError(t, err)
 	assert.Equal(t, batch.ID, bid)
 
@@ -60,6 +64,28 @@ func TestSharedStorageBatchDownloadedOk(t *testing.T) {
 
 }
 
+func TestSharedStorageBatchVerifyDoesNotStore(t *testing.T) {
+
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	b, _ := json.Marshal(&batch)
+
+	mss := &sharedstoragemocks.Plugin{}
+	mss.On("Name").Return("utdx").Maybe()
+
+	verified, err := em.SharedStorageBatchVerify(mss, "payload1", bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, batch.ID, verified.ID)
+	assert.Equal(t, "ns1", verified.Namespace)
+	assert.Len(t, verified.Payload.Data, 1)
+
+	mss.AssertExpectations(t)
+
+}
+
 func TestSharedStorageBatchDownloadedPersistFail(t *testing.T) {
 
 	em := newTestEventManager(t)
@@ -74,7 +100,7 @@ func TestSharedStorageBatchDownloadedPersistFail(t *testing.T) {
 	em.mdi.On("InsertOrGetBatch", em.ctx, mock.Anything).Return(nil, fmt.Errorf("pop"))
 	mss.On("Name").Return("utdx").Maybe()
 
-	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", b)
+	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", bytes.NewReader(b))
 	assert.Regexp(t, "FF00154", err)
 
 	mss.AssertExpectations(t)
@@ -94,7 +120,7 @@ func TestSharedStorageBatchDownloadedNSMismatch(t *testing.T) {
 	mss.On("Name").Return("utdx").Maybe()
 
 	em.namespace.NetworkName = "ns2"
-	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", b)
+	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", bytes.NewReader(b))
 	assert.NoError(t, err)
 
 	mss.AssertExpectations(t)
@@ -112,7 +138,7 @@ func TestSharedStorageBatchDownloadedNonMultiparty(t *testing.T) {
 	b, _ := json.Marshal(&batch)
 
 	mss := &sharedstoragemocks.Plugin{}
-	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", b)
+	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", bytes.NewReader(b))
 	assert.NoError(t, err)
 
 	mss.AssertExpectations(t)
@@ -127,8 +153,48 @@ func TestSharedStorageBatchDownloadedBadData(t *testing.T) {
 	mss := &sharedstoragemocks.Plugin{}
 	mss.On("Name").Return("utdx").Maybe()
 
-	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", []byte("!json"))
+	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", strings.NewReader("!json"))
+	assert.NoError(t, err)
+
+	mss.AssertExpectations(t)
+
+}
+
+func TestSharedStorageBatchDownloadedReadFail(t *testing.T) {
+
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	mss := &sharedstoragemocks.Plugin{}
+
+	_, err := em.SharedStorageBatchDownloaded(mss, "payload1", io.MultiReader(strings.NewReader(`{"id":`), iotest.ErrReader(fmt.Errorf("pop"))))
+	assert.EqualError(t, err, "pop")
+
+	mss.AssertExpectations(t)
+
+}
+
+func TestSharedStorageBatchDownloadedDataHashMismatchEarly(t *testing.T) {
+
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data1 := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test1"`)}
+	data2 := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test2"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data1, data2})
+	data1.Hash = fftypes.NewRandB32()
+	b, _ := json.Marshal(&batch)
+	d1, _ := json.Marshal(&data1)
+	cut := bytes.Index(b, d1) + len(d1)
+
+	mss := &sharedstoragemocks.Plugin{}
+	mss.On("Name").Return("utdx").Maybe()
+
+	// The rest of the batch is never read, so the read failure is not hit
+	reader := io.MultiReader(bytes.NewReader(b[:cut]), iotest.ErrReader(fmt.Errorf("pop")))
+	bid, err := em.SharedStorageBatchDownloaded(mss, "payload1", reader)
 	assert.NoError(t, err)
+	assert.Nil(t, bid)
 
 	mss.AssertExpectations(t)
 
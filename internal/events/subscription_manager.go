@@ -31,6 +31,8 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
+	"github.com/hyperledger/firefly/internal/events/poll"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -61,7 +63,8 @@ type blockchainFilter struct {
 }
 
 type transactionFilter struct {
-	typeFilter *regexp.Regexp
+	typeFilter   *regexp.Regexp
+	labelsFilter *regexp.Regexp
 }
 
 type connection struct {
@@ -82,6 +85,7 @@ type subscriptionManager struct {
 	eventNotifier             *eventNotifier
 	broadcast                 broadcast.Manager
 	messaging                 privatemessaging.Manager
+	metrics                   metrics.Manager
 	transports                map[string]events.Plugin
 	connections               map[string]*connection
 	mux                       sync.Mutex
@@ -90,13 +94,15 @@ type subscriptionManager struct {
 	cancelCtx                 func()
 	newOrUpdatedSubscriptions chan *fftypes.UUID
 	deletedSubscriptions      chan *fftypes.UUID
+	operationUpdates          chan *fftypes.UUID
+	operationWatches          map[string]*operationWatch
 	retry                     retry.Retry
 
 	defaultBatchSize    uint
 	defaultBatchTimeout time.Duration
 }
 
-func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *eventEnricher, di database.Plugin, dm data.Manager, en *eventNotifier, bm broadcast.Manager, pm privatemessaging.Manager, txHelper txcommon.Helper, transports map[string]events.Plugin) (*subscriptionManager, error) {
+func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *eventEnricher, di database.Plugin, dm data.Manager, en *eventNotifier, bm broadcast.Manager, pm privatemessaging.Manager, mm metrics.Manager, txHelper txcommon.Helper, transports map[string]events.Plugin) (*subscriptionManager, error) {
 	ctx, cancelCtx := context.WithCancel(ctx)
 	sm := &subscriptionManager{
 		ctx:                       ctx,
@@ -109,11 +115,14 @@ func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *e
 		durableSubs:               make(map[fftypes.UUID]*subscription),
 		newOrUpdatedSubscriptions: make(chan *fftypes.UUID),
 		deletedSubscriptions:      make(chan *fftypes.UUID),
+		operationUpdates:          make(chan *fftypes.UUID),
+		operationWatches:          make(map[string]*operationWatch),
 		maxSubs:                   uint64(config.GetUint(coreconfig.SubscriptionMax)),
 		cancelCtx:                 cancelCtx,
 		eventNotifier:             en,
 		broadcast:                 bm, // optional
 		messaging:                 pm, // optional
+		metrics:                   mm,
 		txHelper:                  txHelper,
 		retry: retry.Retry{
 			InitialDelay: config.GetDuration(coreconfig.SubscriptionsRetryInitialDelay),
@@ -156,6 +165,7 @@ func (sm *subscriptionManager) start() error {
 	}
 	log.L(sm.ctx).Infof("Subscription manager started - loaded %d durable subscriptions", len(sm.durableSubs))
 	go sm.subscriptionEventListener()
+	go sm.operationUpdateListener()
 	return nil
 }
 
@@ -193,6 +203,13 @@ func (sm *subscriptionManager) newOrUpdatedDurableSubscription(id *fftypes.UUID)
 		return
 	}
 
+	if subDef.Transport == poll.PollTransport {
+		// Establish the offset now, so events accumulate from the point the subscription was created
+		if _, err := sm.getCreatePollOffset(sm.ctx, subDef); err != nil {
+			log.L(sm.ctx).Errorf("Failed to initialize offset for subscription %s: %s", subDef.ID, err)
+		}
+	}
+
 	// Now we're ready to update our locked state, adding this subscription to our
 	// in-memory table, and creating any missing dispatchers
 	sm.mux.Lock()
@@ -237,6 +254,25 @@ func (sm *subscriptionManager) closeDurableSubscriptionLocked(id *fftypes.UUID)
 	return loaded, dispatchers
 }
 
+// setDurableSubscriptionOffset stores the offset of a durable subscription out-of-band, for
+// consumers that poll for events and checkpoint their own progress. The offset of a subscription
+// with an active dispatcher is owned by its event poller, so it cannot be moved underneath it.
+func (sm *subscriptionManager) setDurableSubscriptionOffset(ctx context.Context, id *fftypes.UUID, offset int64) error {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+
+	for _, conn := range sm.connections {
+		if _, ok := conn.dispatchers[*id]; ok {
+			return i18n.NewError(ctx, coremsgs.MsgSubscriptionOffsetActive, id)
+		}
+	}
+	return sm.database.UpsertOffset(ctx, &core.Offset{
+		Type:    core.OffsetTypeSubscription,
+		Name:    id.String(),
+		Current: offset,
+	}, true)
+}
+
 func (sm *subscriptionManager) deletedDurableSubscription(id *fftypes.UUID) {
 	sm.mux.Lock()
 	loaded, dispatchers := sm.closeDurableSubscriptionLocked(id)
@@ -412,8 +448,17 @@ func (sm *subscriptionManager) parseSubscriptionDef(ctx context.Context, subDef
 			}
 		}
 
+		var labelsFilter *regexp.Regexp
+		if filter.Transaction.Labels != "" {
+			labelsFilter, err = regexp.Compile(filter.Transaction.Labels)
+			if err != nil {
+				return nil, i18n.WrapError(ctx, err, coremsgs.MsgRegexpCompileFailed, "filter.transaction.labels", filter.Transaction.Labels)
+			}
+		}
+
 		tf := &transactionFilter{
-			typeFilter: typeFilter,
+			typeFilter:   typeFilter,
+			labelsFilter: labelsFilter,
 		}
 		sub.transactionFilter = tf
 	}
@@ -483,7 +528,7 @@ func (sm *subscriptionManager) matchSubToConnLocked(conn *connection, sub *subsc
 	}
 	if conn.transport == sub.definition.Transport && conn.matcher(sub.definition.SubscriptionRef) {
 		if _, ok := conn.dispatchers[*sub.definition.ID]; !ok {
-			dispatcher := newEventDispatcher(sm.ctx, sm.enricher, conn.ei, sm.database, sm.data, sm.broadcast, sm.messaging, conn.id, sub, sm.eventNotifier, sm.txHelper)
+			dispatcher := newEventDispatcher(sm.ctx, sm.enricher, conn.ei, sm.database, sm.data, sm.broadcast, sm.messaging, sm.metrics, conn.id, sub, sm.eventNotifier, sm.txHelper)
 			conn.dispatchers[*sub.definition.ID] = dispatcher
 			dispatcher.start()
 		}
@@ -520,7 +565,7 @@ func (sm *subscriptionManager) ephemeralSubscription(ei events.Plugin, connID, n
 	}
 
 	// Create the dispatcher, and start immediately
-	dispatcher := newEventDispatcher(sm.ctx, sm.enricher, ei, sm.database, sm.data, sm.broadcast, sm.messaging, connID, newSub, sm.eventNotifier, sm.txHelper)
+	dispatcher := newEventDispatcher(sm.ctx, sm.enricher, ei, sm.database, sm.data, sm.broadcast, sm.messaging, sm.metrics, connID, newSub, sm.eventNotifier, sm.txHelper)
 	dispatcher.start()
 
 	conn.dispatchers[*subID] = dispatcher
@@ -539,6 +584,7 @@ func (sm *subscriptionManager) connectionClosed(ei events.Plugin, connID string)
 		return
 	}
 	delete(sm.connections, connID)
+	delete(sm.operationWatches, connID)
 	sm.mux.Unlock()
 
 	if !ok {
@@ -574,6 +620,12 @@ func (sm *subscriptionManager) deliveryResponse(ei events.Plugin, connID string,
 	dispatcher.deliveryResponse(inflight)
 }
 
+// requiresTransaction is true if the subscription filters on fields of the transaction that are
+// not otherwise available on the event, so events must be enriched with their transaction
+func (sub *subscription) requiresTransaction() bool {
+	return sub.transactionFilter != nil && sub.transactionFilter.labelsFilter != nil
+}
+
 func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
 	if sub.eventMatcher != nil && !sub.eventMatcher.MatchString(string(event.Type)) {
 		return false
@@ -587,6 +639,7 @@ func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
 	group := ""
 	author := ""
 	txType := ""
+	var txLabels fftypes.FFStringArray
 	beName := ""
 	beListener := ""
 
@@ -600,6 +653,7 @@ func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
 
 	if tx != nil {
 		txType = tx.Type.String()
+		txLabels = tx.Labels
 	}
 
 	if be != nil {
@@ -633,6 +687,18 @@ func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
 		if sub.transactionFilter.typeFilter != nil && !sub.transactionFilter.typeFilter.MatchString(txType) {
 			return false
 		}
+		if sub.transactionFilter.labelsFilter != nil {
+			labelsMatch := false
+			for _, label := range txLabels {
+				if sub.transactionFilter.labelsFilter.MatchString(label) {
+					labelsMatch = true
+					break
+				}
+			}
+			if !labelsMatch {
+				return false
+			}
+		}
 	}
 
 	if sub.blockchainFilter != nil {
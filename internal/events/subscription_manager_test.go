@@ -34,6 +34,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -63,7 +64,9 @@ func newTestSubManager(t *testing.T, mei *eventsmocks.Plugin) (*subscriptionMana
 	mei.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mdi.On("GetEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*core.Event{}, nil, nil).Maybe()
 	mdi.On("GetOffset", mock.Anything, mock.Anything, mock.Anything).Return(&core.Offset{RowID: 3333333, Current: 0}, nil).Maybe()
-	sm, err := newSubscriptionManager(ctx, &core.Namespace{Name: "ns1"}, enricher, mdi, mdm, newEventNotifier(ctx, "ut"), mbm, mpm, txHelper, nil)
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
+	sm, err := newSubscriptionManager(ctx, &core.Namespace{Name: "ns1"}, enricher, mdi, mdm, newEventNotifier(ctx, "ut"), mbm, mpm, mmi, txHelper, nil)
 	assert.NoError(t, err)
 	sm.transports = map[string]events.Plugin{
 		"ut": mei,
@@ -372,6 +375,22 @@ func TestCreateSubscriptionBadTxTypeFilter(t *testing.T) {
 	assert.Regexp(t, "FF10171.*type", err)
 }
 
+func TestCreateSubscriptionBadTxLabelsFilter(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mei.On("ValidateOptions", mock.Anything, mock.Anything).Return(nil)
+	_, err := sm.parseSubscriptionDef(sm.ctx, &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Transaction: core.TransactionFilter{
+				Labels: "[[[[! badness",
+			},
+		},
+		Transport: "ut",
+	})
+	assert.Regexp(t, "FF10171.*labels", err)
+}
+
 func TestCreateSubscriptionBadBlockchainEventNameFilter(t *testing.T) {
 	mei := &eventsmocks.Plugin{}
 	sm, cancel := newTestSubManager(t, mei)
@@ -500,7 +519,8 @@ func TestCreateSubscriptionSuccessTxFilter(t *testing.T) {
 	_, err := sm.parseSubscriptionDef(sm.ctx, &core.Subscription{
 		Filter: core.SubscriptionFilter{
 			Transaction: core.TransactionFilter{
-				Type: "flapflip",
+				Type:   "flapflip",
+				Labels: "payroll",
 			},
 		},
 		Transport: "ut",
@@ -904,3 +924,42 @@ func TestDeleteDurableSubscriptionOk(t *testing.T) {
 	assert.Empty(t, sm.durableSubs)
 	<-ed.closed
 }
+
+func TestSetDurableSubscriptionOffsetActive(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+
+	subID := fftypes.NewUUID()
+	sm.connections["conn1"] = &connection{
+		ei:        mei,
+		id:        "conn1",
+		transport: "ut",
+		dispatchers: map[fftypes.UUID]*eventDispatcher{
+			*subID: {},
+		},
+	}
+
+	err := sm.setDurableSubscriptionOffset(sm.ctx, subID, 12345)
+	assert.Regexp(t, "FF10617", err)
+}
+
+func TestSetDurableSubscriptionOffsetFail(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	mdi := sm.database.(*databasemocks.Plugin)
+
+	subID := fftypes.NewUUID()
+	sm.connections["conn1"] = &connection{
+		ei:          mei,
+		id:          "conn1",
+		transport:   "ut",
+		dispatchers: map[fftypes.UUID]*eventDispatcher{},
+	}
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(fmt.Errorf("pop"))
+
+	err := sm.setDurableSubscriptionOffset(sm.ctx, subID, 12345)
+	assert.EqualError(t, err, "pop")
+	mdi.AssertExpectations(t)
+}
@@ -0,0 +1,114 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/events/poll"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// getCreatePollOffset returns the stored offset of a subscription using the poll transport,
+// establishing it from the firstEvent option if this is the first time it has been needed
+func (sm *subscriptionManager) getCreatePollOffset(ctx context.Context, subDef *core.Subscription) (int64, error) {
+	offset, err := sm.database.GetOffset(ctx, core.OffsetTypeSubscription, subDef.ID.String())
+	if err != nil {
+		return -1, err
+	}
+	if offset != nil {
+		return offset.Current, nil
+	}
+	firstOffset, err := calcFirstOffset(ctx, sm.namespace.Name, sm.database, subDef.Options.FirstEvent)
+	if err != nil {
+		return -1, err
+	}
+	err = sm.database.UpsertOffset(ctx, &core.Offset{
+		Type:    core.OffsetTypeSubscription,
+		Name:    subDef.ID.String(),
+		Current: firstOffset,
+	}, true)
+	return firstOffset, err
+}
+
+// pollDurableSubscriptionEvents returns up to limit events matching a subscription using the poll
+// transport, after its stored offset. The offset is only moved when the consumer acknowledges the
+// highest sequence it has processed, so the same page is returned until then.
+func (sm *subscriptionManager) pollDurableSubscriptionEvents(ctx context.Context, subDef *core.Subscription, limit uint64) ([]*core.EnrichedEvent, error) {
+	if subDef.Transport != poll.PollTransport {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSubscriptionNotPolled, subDef.ID, subDef.Transport)
+	}
+	sub, err := sm.parseSubscriptionDef(ctx, subDef)
+	if err != nil {
+		return nil, err
+	}
+	startOffset, err := sm.getCreatePollOffset(ctx, subDef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scan forwards in pages, skipping events that do not match the subscription filter
+	maxScan := config.GetInt(coreconfig.SubscriptionMaxHistoricalEventScanLength)
+	matched := make([]*core.EnrichedEvent, 0)
+	scanned := 0
+	offset := startOffset
+	for uint64(len(matched)) < limit && scanned < maxScan {
+		fb := database.EventQueryFactory.NewFilter(ctx)
+		filter := fb.And(
+			fb.Gt("sequence", offset),
+		).Sort("sequence").Limit(limit)
+		events, _, err := sm.database.GetEvents(ctx, sm.namespace.Name, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		enriched, err := sm.enricher.enrichEvents(ctx, events)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range enriched {
+			if sub.requiresTransaction() {
+				if err := sm.enricher.enrichTransaction(ctx, event); err != nil {
+					return nil, err
+				}
+			}
+			if uint64(len(matched)) < limit && sub.MatchesEvent(event) {
+				matched = append(matched, event)
+			}
+		}
+		offset = events[len(events)-1].Sequence
+		scanned += len(events)
+	}
+
+	// If nothing matched, the consumer has nothing to acknowledge. Move the offset past the events we
+	// skipped, so the next poll does not scan them again.
+	if len(matched) == 0 && offset > startOffset {
+		log.L(ctx).Debugf("Poll of subscription %s skipped events %d-%d with no match", subDef.ID, startOffset+1, offset)
+		if err := sm.setDurableSubscriptionOffset(ctx, subDef.ID, offset); err != nil {
+			return nil, err
+		}
+	}
+	return matched, nil
+}
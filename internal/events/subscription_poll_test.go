@@ -0,0 +1,210 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/events/poll"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestPollSubManager(t *testing.T) (*subscriptionManager, *databasemocks.Plugin, *core.Subscription, func()) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	mdi := &databasemocks.Plugin{}
+	sm.database = mdi
+
+	pt := &poll.Poll{}
+	err := pt.Init(sm.ctx, config.RootSection("ut.events.poll"))
+	assert.NoError(t, err)
+	sm.transports[poll.PollTransport] = pt
+
+	subDef := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+		Transport: poll.PollTransport,
+		Filter: core.SubscriptionFilter{
+			Events: string(core.EventTypeDependencyDegraded),
+		},
+	}
+	return sm, mdi, subDef, cancel
+}
+
+func testPollEvent(sequence int64, eventType core.EventType) *core.Event {
+	return &core.Event{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Type:      eventType,
+		Sequence:  sequence,
+	}
+}
+
+func TestPollDurableSubscriptionEventsOk(t *testing.T) {
+	sm, mdi, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(&core.Offset{Current: 10}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		testPollEvent(11, core.EventTypeDependencyDegraded),
+		testPollEvent(12, core.EventTypeDependencyRecovered),
+	}, nil, nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		testPollEvent(13, core.EventTypeDependencyDegraded),
+		testPollEvent(14, core.EventTypeDependencyDegraded),
+	}, nil, nil).Once()
+
+	events, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 2)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, int64(11), events[0].Sequence)
+	assert.Equal(t, int64(13), events[1].Sequence)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPollDurableSubscriptionEventsNoMatchSkips(t *testing.T) {
+	sm, mdi, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(&core.Offset{Current: 10}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		testPollEvent(11, core.EventTypeDependencyRecovered),
+	}, nil, nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Name == subDef.ID.String() && o.Current == 11
+	}), true).Return(nil)
+
+	events, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 25)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPollDurableSubscriptionEventsNoMatchSkipFail(t *testing.T) {
+	sm, mdi, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(&core.Offset{Current: 10}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		testPollEvent(11, core.EventTypeDependencyRecovered),
+	}, nil, nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(fmt.Errorf("pop"))
+
+	_, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 25)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPollDurableSubscriptionEventsCreateOffset(t *testing.T) {
+	sm, mdi, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		testPollEvent(20, core.EventTypeDependencyDegraded),
+	}, nil, nil).Once() // newest event, for the first offset
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Name == subDef.ID.String() && o.Current == 20
+	}), true).Return(nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+
+	events, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 25)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPollDurableSubscriptionEventsCreateOffsetFail(t *testing.T) {
+	sm, mdi, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 25)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPollDurableSubscriptionEventsGetOffsetFail(t *testing.T) {
+	sm, mdi, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(nil, fmt.Errorf("pop"))
+
+	_, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 25)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPollDurableSubscriptionEventsGetEventsFail(t *testing.T) {
+	sm, mdi, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(&core.Offset{Current: 10}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 25)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPollDurableSubscriptionEventsBadFilter(t *testing.T) {
+	sm, _, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	subDef.Filter.Events = "![[[[["
+	_, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 25)
+	assert.Regexp(t, "FF10171", err)
+}
+
+func TestPollDurableSubscriptionEventsNotPolled(t *testing.T) {
+	sm, _, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	subDef.Transport = "websockets"
+	_, err := sm.pollDurableSubscriptionEvents(sm.ctx, subDef, 25)
+	assert.Regexp(t, "FF10620", err)
+}
+
+func TestNewDurablePollSubscriptionCreatesOffset(t *testing.T) {
+	sm, mdi, subDef, cancel := newTestPollSubManager(t)
+	defer cancel()
+
+	mdi.On("GetSubscriptionByID", mock.Anything, "ns1", subDef.ID).Return(subDef, nil)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subDef.ID.String()).Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Name == subDef.ID.String() && o.Current == -1
+	}), true).Return(fmt.Errorf("this error is logged and swallowed"))
+
+	sm.newOrUpdatedDurableSubscription(subDef.ID)
+	assert.NotNil(t, sm.durableSubs[*subDef.ID])
+
+	mdi.AssertExpectations(t)
+}
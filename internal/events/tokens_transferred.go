@@ -124,6 +124,16 @@ func (em *eventManager) persistTokenTransfer(ctx context.Context, transfer *toke
 }
 
 func (em *eventManager) TokensTransferred(ti tokens.Plugin, transfer *tokens.TokenTransfer) error {
+	if em.confirmations != nil {
+		// Transfers with a block number are held until they have the required confirmations
+		if held, err := em.confirmations.holdTokenTransfer(transfer); held || err != nil {
+			return err
+		}
+	}
+	return em.processTokensTransferred(transfer)
+}
+
+func (em *eventManager) processTokensTransferred(transfer *tokens.TokenTransfer) error {
 	var msgIDforRewind *fftypes.UUID
 
 	err := em.retry.Do(em.ctx, "persist token transfer", func(attempt int) (bool, error) {
@@ -50,6 +50,7 @@ type websocketConnection struct {
 	receiverDone    chan struct{}
 	autoAck         bool
 	started         []*websocketStartedSub
+	watches         []*core.WSWatch
 	inflight        []*core.EventDeliveryResponse
 	inflightBatches []*core.WSEventBatch
 	mux             sync.Mutex
@@ -219,6 +220,18 @@ func (wc *websocketConnection) receiveLoop() {
 					err = wc.handleStart(&msg)
 				}
 			}
+		case core.WSClientActionWatch, core.WSClientActionUnwatch:
+			var msg core.WSWatch
+			err = json.Unmarshal(msgData, &msg)
+			if err == nil {
+				msg.Namespace, err = wc.assertNamespace(msg.Namespace)
+				if err == nil {
+					err = wc.authorizeMessage(msg.Namespace)
+				}
+				if err == nil {
+					err = wc.handleWatch(&msg)
+				}
+			}
 		case core.WSClientActionAck:
 			var msg core.WSAck
 			err = json.Unmarshal(msgData, &msg)
@@ -346,7 +359,20 @@ func (wc *websocketConnection) restartForNamespace(ns string, startTime time.Tim
 			s.startTime = fftypes.Now()
 		}
 	}
+	toWatch := []*core.WSWatch{}
+	for _, w := range wc.watches {
+		if w.Namespace == ns {
+			toWatch = append(toWatch, w)
+		}
+	}
 	wc.mux.Unlock()
+	for _, w := range toWatch {
+		if err := wc.ws.watch(wc, w); err != nil {
+			log.L(wc.ctx).Errorf("Failed to restore operation watch in namespace '%s' (closing): %s", ns, err)
+			wc.close()
+			return
+		}
+	}
 	for _, s := range toStart {
 		if err := wc.ws.start(wc, s); err != nil {
 			log.L(wc.ctx).Errorf("Failed restart subscription '%s:%s' (closing): %s", s.Namespace, s.Name, err)
@@ -380,6 +406,24 @@ func (wc *websocketConnection) handleStart(start *core.WSStart) (err error) {
 	return wc.ws.start(wc, start)
 }
 
+func (wc *websocketConnection) handleWatch(watch *core.WSWatch) error {
+	if watch.Namespace == "" || (len(watch.Operations) == 0 && len(watch.Transactions) == 0) {
+		return i18n.NewError(wc.ctx, coremsgs.MsgWSInvalidWatchAction)
+	}
+	// Watches and unwatches are kept in order, so they can be applied again if the namespace restarts
+	wc.mux.Lock()
+	wc.watches = append(wc.watches, watch)
+	wc.mux.Unlock()
+	return wc.ws.watch(wc, watch)
+}
+
+func (wc *websocketConnection) sendOperationUpdate(op *core.Operation) error {
+	return wc.send(&core.WSOperationUpdate{
+		Type:      core.WSOperationUpdateType,
+		Operation: op,
+	})
+}
+
 func (wc *websocketConnection) durableSubMatcher(sr core.SubscriptionRef) bool {
 	wc.mux.Lock()
 	defer wc.mux.Unlock()
@@ -169,6 +169,18 @@ func (ws *WebSockets) start(wc *websocketConnection, start *core.WSStart) error
 	return i18n.NewError(ws.ctx, coremsgs.MsgNamespaceDoesNotExist)
 }
 
+func (ws *WebSockets) watch(wc *websocketConnection, watch *core.WSWatch) error {
+	cb, ok := ws.callbacks.handlers[watch.Namespace]
+	if !ok {
+		return i18n.NewError(ws.ctx, coremsgs.MsgNamespaceDoesNotExist)
+	}
+	if watch.Type == core.WSClientActionUnwatch {
+		cb.UnwatchOperations(wc.connID, watch.Operations, watch.Transactions)
+		return nil
+	}
+	return cb.WatchOperations(wc.connID, watch.Operations, watch.Transactions, wc.sendOperationUpdate)
+}
+
 func (ws *WebSockets) connClosed(connID string) {
 	ws.connMux.Lock()
 	delete(ws.connections, connID)
@@ -1219,3 +1219,140 @@ func TestHandleStartWrongNamespace(t *testing.T) {
 	assert.Error(t, err)
 	assert.Regexp(t, "FF10462", err)
 }
+
+func TestWatchReceiveOperationUpdates(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	_, wsc, cancel := newTestWebsockets(t, cbs, nil)
+	defer cancel()
+
+	opID := fftypes.NewUUID()
+	watch := cbs.On("WatchOperations", mock.Anything, []*fftypes.UUID{opID}, []*fftypes.UUID(nil), mock.Anything).Return(nil)
+	watch.RunFn = func(a mock.Arguments) {
+		err := a[3].(events.OperationWatcher)(&core.Operation{
+			ID:     opID,
+			Status: core.OpStatusSucceeded,
+			Output: fftypes.JSONObject{"receipt": "r1"},
+		})
+		assert.NoError(t, err)
+	}
+	unwatched := make(chan struct{})
+	unwatch := cbs.On("UnwatchOperations", mock.Anything, []*fftypes.UUID{opID}, []*fftypes.UUID(nil)).Return()
+	unwatch.RunFn = func(a mock.Arguments) {
+		close(unwatched)
+	}
+
+	err := wsc.Send(context.Background(), []byte(fmt.Sprintf(`{"type":"watch","namespace":"ns1","operations":["%s"]}`, opID)))
+	assert.NoError(t, err)
+
+	b := <-wsc.Receive()
+	var res core.WSOperationUpdate
+	err = json.Unmarshal(b, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, core.WSOperationUpdateType, res.Type)
+	assert.Equal(t, opID, res.Operation.ID)
+	assert.Equal(t, "r1", res.Operation.Output.GetString("receipt"))
+
+	err = wsc.Send(context.Background(), []byte(fmt.Sprintf(`{"type":"unwatch","namespace":"ns1","operations":["%s"]}`, opID)))
+	assert.NoError(t, err)
+
+	<-unwatched
+	cbs.AssertExpectations(t)
+}
+
+func TestSendEmptyWatchAction(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	_, wsc, cancel := newTestWebsockets(t, cbs, nil)
+	defer cancel()
+
+	err := wsc.Send(context.Background(), []byte(`{"type":"watch","namespace":"ns1"}`))
+	assert.NoError(t, err)
+	b := <-wsc.Receive()
+	var res core.WSError
+	err = json.Unmarshal(b, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, core.WSProtocolErrorEventType, res.Type)
+	assert.Regexp(t, "FF10667", res.Error)
+}
+
+func TestWatchUnknownNamespace(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	_, wsc, cancel := newTestWebsockets(t, cbs, nil)
+	defer cancel()
+
+	err := wsc.Send(context.Background(), []byte(fmt.Sprintf(`{"type":"watch","namespace":"ns2","transactions":["%s"]}`, fftypes.NewUUID())))
+	assert.NoError(t, err)
+	b := <-wsc.Receive()
+	var res core.WSError
+	err = json.Unmarshal(b, &res)
+	assert.NoError(t, err)
+	assert.Equal(t, core.WSProtocolErrorEventType, res.Type)
+	assert.Regexp(t, "FF10187", res.Error)
+}
+
+func TestNamespaceRestartedRestoresWatches(t *testing.T) {
+	mcb := &eventsmocks.Callbacks{}
+	opID := fftypes.NewUUID()
+	txID := fftypes.NewUUID()
+	mcb.On("WatchOperations", "id1", []*fftypes.UUID{opID}, []*fftypes.UUID{txID}, mock.Anything).Return(nil).Once()
+	mcb.On("UnwatchOperations", "id1", []*fftypes.UUID{opID}, []*fftypes.UUID(nil)).Return().Once()
+	ws := &WebSockets{
+		ctx:         context.Background(),
+		connections: make(map[string]*websocketConnection),
+		callbacks: callbacks{
+			handlers: map[string]events.Callbacks{"ns1": mcb},
+		},
+	}
+	ws.connections["id1"] = &websocketConnection{
+		ctx:    context.Background(),
+		ws:     ws,
+		connID: "id1",
+		watches: []*core.WSWatch{
+			{
+				WSActionBase: core.WSActionBase{Type: core.WSClientActionWatch},
+				Namespace:    "ns1", Operations: []*fftypes.UUID{opID}, Transactions: []*fftypes.UUID{txID},
+			},
+			{
+				WSActionBase: core.WSActionBase{Type: core.WSClientActionUnwatch},
+				Namespace:    "ns1", Operations: []*fftypes.UUID{opID},
+			},
+			{
+				WSActionBase: core.WSActionBase{Type: core.WSClientActionWatch},
+				Namespace:    "ns2", Operations: []*fftypes.UUID{fftypes.NewUUID()},
+			},
+		},
+	}
+
+	ws.NamespaceRestarted("ns1", time.Now())
+
+	mcb.AssertExpectations(t)
+}
+
+func TestNamespaceRestartedRestoreWatchFailClose(t *testing.T) {
+	mcb := &eventsmocks.Callbacks{}
+	mcb.On("WatchOperations", "id1", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mcb.On("ConnectionClosed", mock.Anything, mock.Anything).Return(nil)
+	ws := &WebSockets{
+		ctx:         context.Background(),
+		connections: make(map[string]*websocketConnection),
+		callbacks: callbacks{
+			handlers: map[string]events.Callbacks{"ns1": mcb},
+		},
+	}
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	ws.connections["id1"] = &websocketConnection{
+		ctx:       ctx,
+		cancelCtx: cancelCtx,
+		ws:        ws,
+		connID:    "id1",
+		watches: []*core.WSWatch{
+			{
+				WSActionBase: core.WSActionBase{Type: core.WSClientActionWatch},
+				Namespace:    "ns1", Transactions: []*fftypes.UUID{fftypes.NewUUID()},
+			},
+		},
+	}
+
+	ws.NamespaceRestarted("ns1", time.Now())
+
+	mcb.AssertExpectations(t)
+}
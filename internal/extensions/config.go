@@ -0,0 +1,47 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+)
+
+const (
+	// ExtensionConfName is the name of the extension, which must be unique within its namespace
+	ExtensionConfName = "name"
+	// ExtensionConfNamespace is the namespace the extension is enabled in
+	ExtensionConfNamespace = "namespace"
+	// ExtensionConfPath is the path of the WASM module file
+	ExtensionConfPath = "path"
+	// ExtensionConfHooks is the list of hook points the extension is called for
+	ExtensionConfHooks = "hooks"
+	// ExtensionConfMaxMemory is the maximum linear memory of the module, on each call
+	ExtensionConfMaxMemory = "maxMemory"
+	// ExtensionConfTimeout is the maximum time each call to the module can run for
+	ExtensionConfTimeout = "timeout"
+)
+
+var extensionsConfig = config.RootArray("extensions")
+
+func InitConfig() {
+	extensionsConfig.AddKnownKey(ExtensionConfName)
+	extensionsConfig.AddKnownKey(ExtensionConfNamespace)
+	extensionsConfig.AddKnownKey(ExtensionConfPath)
+	extensionsConfig.AddKnownKey(ExtensionConfHooks)
+	extensionsConfig.AddKnownKey(ExtensionConfMaxMemory, "16mb")
+	extensionsConfig.AddKnownKey(ExtensionConfTimeout, "250ms")
+}
@@ -0,0 +1,212 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package extensions hosts WASM modules that implement hook points of a namespace, so that message
+// validation, event transformation and policy rules can be added to FireFly without recompiling it.
+package extensions
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/policy"
+)
+
+// Hook is a point in the processing of a namespace that an extension can be called at. The name of
+// the hook is also the name of the function the WASM module exports to implement it.
+type Hook string
+
+const (
+	// HookValidateMessage is called with each message sent by the namespace, before it is stored
+	HookValidateMessage Hook = "validateMessage"
+	// HookTransformEvent is called with each event before it is delivered to a subscription
+	HookTransformEvent Hook = "transformEvent"
+	// HookCheckPolicy is called with each mutating API call, after any policy plugin allows it
+	HookCheckPolicy Hook = "checkPolicy"
+)
+
+var supportedHooks = map[Hook]bool{
+	HookValidateMessage: true,
+	HookTransformEvent:  true,
+	HookCheckPolicy:     true,
+}
+
+// Manager runs the extensions configured for a namespace.
+//
+// Each hook is passed a JSON document, and returns one:
+//   - validateMessage is passed {"message":...,"data":[...]}, and returns {"valid":bool,"reason":"..."}
+//   - transformEvent is passed the event delivery, and returns the event delivery to send instead
+//   - checkPolicy is passed the policy request, and returns {"allow":bool,"reason":"..."}
+//
+// Where several extensions implement a hook, they are called in the order they are configured. A
+// message must be valid for all of them, and a request allowed by all of them, while each transform
+// is passed the output of the one before.
+type Manager interface {
+	ValidateMessage(ctx context.Context, msg *core.Message, data core.DataArray) error
+	TransformEvent(ctx context.Context, event *core.EventDelivery) (*core.EventDelivery, error)
+	CheckPolicy(ctx context.Context, req *policy.Request) error
+	Close(ctx context.Context)
+}
+
+type extensionManager struct {
+	modules []*module
+}
+
+type validateMessageInput struct {
+	Message *core.Message  `json:"message"`
+	Data    core.DataArray `json:"data"`
+}
+
+type validateMessageOutput struct {
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// NewExtensionManager returns nil if no extensions are configured for the namespace
+func NewExtensionManager(ctx context.Context, ns string) (Manager, error) {
+	em := &extensionManager{}
+	names := make(map[string]bool)
+	for i := 0; i < extensionsConfig.ArraySize(); i++ {
+		conf := extensionsConfig.ArrayEntry(i)
+		if conf.GetString(ExtensionConfNamespace) != ns {
+			continue
+		}
+		m, err := newModule(ctx, conf)
+		if err == nil && names[m.name] {
+			m.close(ctx)
+			err = i18n.NewError(ctx, coremsgs.MsgDuplicateExtensionName, m.name, ns)
+		}
+		if err != nil {
+			em.Close(ctx)
+			return nil, err
+		}
+		names[m.name] = true
+		em.modules = append(em.modules, m)
+	}
+	if len(em.modules) == 0 {
+		return nil, nil
+	}
+	return em, nil
+}
+
+func newModule(ctx context.Context, conf config.Section) (*module, error) {
+	name := conf.GetString(ExtensionConfName)
+	if name == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, conf.Resolve(ExtensionConfName), "extensions")
+	}
+	path := conf.GetString(ExtensionConfPath)
+	if path == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, conf.Resolve(ExtensionConfPath), "extensions")
+	}
+	hooks := conf.GetStringSlice(ExtensionConfHooks)
+	if len(hooks) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, conf.Resolve(ExtensionConfHooks), "extensions")
+	}
+	for _, hook := range hooks {
+		if !supportedHooks[Hook(hook)] {
+			return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedExtensionHook, hook, name)
+		}
+	}
+	m, err := loadModule(ctx, name, path, conf.GetByteSize(ExtensionConfMaxMemory), conf.GetDuration(ExtensionConfTimeout))
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range hooks {
+		m.hooks[Hook(hook)] = true
+	}
+	log.L(ctx).Infof("Loaded extension '%s' from '%s' for hooks %v", name, path, hooks)
+	return m, nil
+}
+
+// callJSON runs a hook of a module, with JSON input and output
+func (em *extensionManager) callJSON(ctx context.Context, m *module, hook Hook, input, output interface{}) error {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	if b, err = m.call(ctx, hook, b); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, output); err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgExtensionInvalidOutput, m.name, hook, err)
+	}
+	return nil
+}
+
+func (em *extensionManager) ValidateMessage(ctx context.Context, msg *core.Message, data core.DataArray) error {
+	input := &validateMessageInput{Message: msg, Data: data}
+	for _, m := range em.modules {
+		if !m.hooks[HookValidateMessage] {
+			continue
+		}
+		var result validateMessageOutput
+		if err := em.callJSON(ctx, m, HookValidateMessage, input, &result); err != nil {
+			return err
+		}
+		if !result.Valid {
+			log.L(ctx).Infof("Extension '%s' rejected message %s: %s", m.name, msg.Header.ID, result.Reason)
+			return i18n.NewError(ctx, coremsgs.MsgExtensionRejectedMessage, m.name, result.Reason)
+		}
+	}
+	return nil
+}
+
+func (em *extensionManager) TransformEvent(ctx context.Context, event *core.EventDelivery) (*core.EventDelivery, error) {
+	for _, m := range em.modules {
+		if !m.hooks[HookTransformEvent] {
+			continue
+		}
+		var transformed core.EventDelivery
+		if err := em.callJSON(ctx, m, HookTransformEvent, event, &transformed); err != nil {
+			return nil, err
+		}
+		// The identity of the event cannot be changed, as it is used to track the delivery
+		transformed.ID = event.ID
+		transformed.Sequence = event.Sequence
+		transformed.Namespace = event.Namespace
+		transformed.Subscription = event.Subscription
+		event = &transformed
+	}
+	return event, nil
+}
+
+func (em *extensionManager) CheckPolicy(ctx context.Context, req *policy.Request) error {
+	for _, m := range em.modules {
+		if !m.hooks[HookCheckPolicy] {
+			continue
+		}
+		var decision policy.Decision
+		if err := em.callJSON(ctx, m, HookCheckPolicy, req, &decision); err != nil {
+			return err
+		}
+		if !decision.Allow {
+			log.L(ctx).Infof("Extension '%s' denied %s %s: %s", m.name, req.Method, req.Path, decision.Reason)
+			return i18n.NewError(ctx, coremsgs.MsgPolicyDenied, decision.Reason)
+		}
+	}
+	return nil
+}
+
+func (em *extensionManager) Close(ctx context.Context) {
+	for _, m := range em.modules {
+		m.close(ctx)
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+// Bodies of hook functions, for test modules built by newTestModule
+var (
+	// echoBody returns the input as the output
+	echoBody = []byte{
+		0x20, 0x00, 0xad, 0x42, 0x20, 0x86, // (i64.extend_i32_u (local.get 0)) << 32
+		0x20, 0x01, 0xad, 0x84, // | i64.extend_i32_u (local.get 1)
+		0x0b,
+	}
+	// spinBody never returns
+	spinBody = []byte{0x03, 0x40, 0x0c, 0x00, 0x0b, 0x42, 0x00, 0x0b}
+)
+
+// outputBody returns the output held at offset zero of the memory of the module
+func outputBody(length int) []byte {
+	return append(append([]byte{0x42}, sleb128(int64(length))...), 0x0b)
+}
+
+func uleb128(v uint64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			c |= 0x80
+		}
+		b = append(b, c)
+		if v == 0 {
+			return b
+		}
+	}
+}
+
+func sleb128(v int64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func wasmVec(items ...[]byte) []byte {
+	return append(uleb128(uint64(len(items))), bytes.Join(items, nil)...)
+}
+
+func wasmName(name string) []byte {
+	return append(uleb128(uint64(len(name))), name...)
+}
+
+func wasmSection(id byte, contents []byte) []byte {
+	return append(append([]byte{id}, uleb128(uint64(len(contents)))...), contents...)
+}
+
+type testHook struct {
+	hook Hook
+	body []byte
+}
+
+// newTestModule assembles a WASM module with a single page of memory holding the output at offset zero,
+// an alloc export that always returns offset 1024, and an export for each of the hooks
+func newTestModule(output string, hooks ...testHook) []byte {
+	funcs := [][]byte{{0x00}}
+	exports := [][]byte{
+		append(wasmName("memory"), 0x02, 0x00),
+		append(wasmName(wasmAllocExport), 0x00, 0x00),
+	}
+	allocBody := []byte{0x00, 0x41, 0x80, 0x08, 0x0b} // no locals, i32.const 1024
+	code := [][]byte{append(uleb128(uint64(len(allocBody))), allocBody...)}
+	for i, h := range hooks {
+		funcs = append(funcs, []byte{0x01})
+		exports = append(exports, append(wasmName(string(h.hook)), 0x00, byte(i+1)))
+		body := append([]byte{0x00}, h.body...)
+		code = append(code, append(uleb128(uint64(len(body))), body...))
+	}
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, wasmSection(1, wasmVec(
+		[]byte{0x60, 0x01, 0x7f, 0x01, 0x7f},       // (i32) -> i32
+		[]byte{0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e}, // (i32, i32) -> i64
+	))...)
+	module = append(module, wasmSection(3, wasmVec(funcs...))...)
+	module = append(module, wasmSection(5, wasmVec([]byte{0x00, 0x01}))...)
+	module = append(module, wasmSection(7, wasmVec(exports...))...)
+	module = append(module, wasmSection(10, wasmVec(code...))...)
+	module = append(module, wasmSection(11, wasmVec(
+		append([]byte{0x00, 0x41, 0x00, 0x0b}, wasmName(output)...),
+	))...)
+	return module
+}
+
+func writeTestModule(t *testing.T, module []byte) string {
+	path := filepath.Join(t.TempDir(), "ext.wasm")
+	err := os.WriteFile(path, module, 0600)
+	assert.NoError(t, err)
+	return path
+}
+
+func newTestExtensionConfig(extensions ...fftypes.JSONObject) {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set("extensions", extensions)
+}
+
+func newTestExtensionManager(t *testing.T, hooks []string, module []byte) (*extensionManager, func()) {
+	newTestExtensionConfig(fftypes.JSONObject{
+		"name":      "ext1",
+		"namespace": "ns1",
+		"path":      writeTestModule(t, module),
+		"hooks":     hooks,
+		"timeout":   "100ms",
+	})
+	ctx := context.Background()
+	em, err := NewExtensionManager(ctx, "ns1")
+	assert.NoError(t, err)
+	return em.(*extensionManager), func() {
+		em.Close(ctx)
+	}
+}
+
+func TestNewExtensionManagerNone(t *testing.T) {
+	newTestExtensionConfig(fftypes.JSONObject{
+		"name":      "ext1",
+		"namespace": "ns2",
+	})
+	em, err := NewExtensionManager(context.Background(), "ns1")
+	assert.NoError(t, err)
+	assert.Nil(t, em)
+}
+
+func TestNewExtensionManagerMissingName(t *testing.T) {
+	newTestExtensionConfig(fftypes.JSONObject{
+		"namespace": "ns1",
+	})
+	_, err := NewExtensionManager(context.Background(), "ns1")
+	assert.Regexp(t, "FF10138.*name", err)
+}
+
+func TestNewExtensionManagerMissingPath(t *testing.T) {
+	newTestExtensionConfig(fftypes.JSONObject{
+		"name":      "ext1",
+		"namespace": "ns1",
+	})
+	_, err := NewExtensionManager(context.Background(), "ns1")
+	assert.Regexp(t, "FF10138.*path", err)
+}
+
+func TestNewExtensionManagerMissingHooks(t *testing.T) {
+	newTestExtensionConfig(fftypes.JSONObject{
+		"name":      "ext1",
+		"namespace": "ns1",
+		"path":      "ext.wasm",
+	})
+	_, err := NewExtensionManager(context.Background(), "ns1")
+	assert.Regexp(t, "FF10138.*hooks", err)
+}
+
+func TestNewExtensionManagerBadHook(t *testing.T) {
+	newTestExtensionConfig(fftypes.JSONObject{
+		"name":      "ext1",
+		"namespace": "ns1",
+		"path":      "ext.wasm",
+		"hooks":     []string{"wrong"},
+	})
+	_, err := NewExtensionManager(context.Background(), "ns1")
+	assert.Regexp(t, "FF10660.*wrong", err)
+}
+
+func TestNewExtensionManagerMissingFile(t *testing.T) {
+	newTestExtensionConfig(fftypes.JSONObject{
+		"name":      "ext1",
+		"namespace": "ns1",
+		"path":      filepath.Join(t.TempDir(), "missing.wasm"),
+		"hooks":     []string{"checkPolicy"},
+	})
+	_, err := NewExtensionManager(context.Background(), "ns1")
+	assert.Regexp(t, "FF10661.*ext1", err)
+}
+
+func TestNewExtensionManagerBadModule(t *testing.T) {
+	newTestExtensionConfig(fftypes.JSONObject{
+		"name":      "ext1",
+		"namespace": "ns1",
+		"path":      writeTestModule(t, []byte("not wasm")),
+		"hooks":     []string{"checkPolicy"},
+	})
+	_, err := NewExtensionManager(context.Background(), "ns1")
+	assert.Regexp(t, "FF10661.*ext1", err)
+}
+
+func TestNewExtensionManagerDuplicateName(t *testing.T) {
+	ext := fftypes.JSONObject{
+		"name":      "ext1",
+		"namespace": "ns1",
+		"path":      writeTestModule(t, newTestModule("")),
+		"hooks":     []string{"checkPolicy"},
+	}
+	newTestExtensionConfig(ext, ext)
+	_, err := NewExtensionManager(context.Background(), "ns1")
+	assert.Regexp(t, "FF10659.*ext1", err)
+}
+
+func TestValidateMessageOK(t *testing.T) {
+	output := `{"valid": true}`
+	em, done := newTestExtensionManager(t, []string{"validateMessage"},
+		newTestModule(output, testHook{HookValidateMessage, outputBody(len(output))}))
+	defer done()
+
+	err := em.ValidateMessage(context.Background(), &core.Message{}, core.DataArray{})
+	assert.NoError(t, err)
+}
+
+func TestValidateMessageRejected(t *testing.T) {
+	output := `{"valid": false, "reason": "missing purchase order"}`
+	em, done := newTestExtensionManager(t, []string{"validateMessage"},
+		newTestModule(output, testHook{HookValidateMessage, outputBody(len(output))}))
+	defer done()
+
+	err := em.ValidateMessage(context.Background(), &core.Message{}, core.DataArray{})
+	assert.Regexp(t, "FF10664.*ext1.*missing purchase order", err)
+}
+
+func TestValidateMessageInvalidOutput(t *testing.T) {
+	output := `not json`
+	em, done := newTestExtensionManager(t, []string{"validateMessage"},
+		newTestModule(output, testHook{HookValidateMessage, outputBody(len(output))}))
+	defer done()
+
+	err := em.ValidateMessage(context.Background(), &core.Message{}, core.DataArray{})
+	assert.Regexp(t, "FF10663.*ext1.*validateMessage", err)
+}
+
+func TestValidateMessageMissingExport(t *testing.T) {
+	em, done := newTestExtensionManager(t, []string{"validateMessage"}, newTestModule(""))
+	defer done()
+
+	err := em.ValidateMessage(context.Background(), &core.Message{}, core.DataArray{})
+	assert.Regexp(t, "FF10662.*ext1.*validateMessage.*missing export", err)
+}
+
+func TestValidateMessageHookNotEnabled(t *testing.T) {
+	em, done := newTestExtensionManager(t, []string{"checkPolicy"}, newTestModule(""))
+	defer done()
+
+	err := em.ValidateMessage(context.Background(), &core.Message{}, core.DataArray{})
+	assert.NoError(t, err)
+}
+
+func TestTransformEvent(t *testing.T) {
+	output := `{"id": "` + fftypes.NewUUID().String() + `", "topic": "transformed"}`
+	em, done := newTestExtensionManager(t, []string{"transformEvent"},
+		newTestModule(output, testHook{HookTransformEvent, outputBody(len(output))}))
+	defer done()
+
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:        fftypes.NewUUID(),
+				Sequence:  12345,
+				Namespace: "ns1",
+				Topic:     "topic1",
+			},
+		},
+		Subscription: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "sub1"},
+	}
+	transformed, err := em.TransformEvent(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, "transformed", transformed.Topic)
+	assert.Equal(t, event.ID, transformed.ID)
+	assert.Equal(t, int64(12345), transformed.Sequence)
+	assert.Equal(t, "ns1", transformed.Namespace)
+	assert.Equal(t, event.Subscription, transformed.Subscription)
+	assert.Equal(t, "topic1", event.Topic)
+}
+
+func TestTransformEventEcho(t *testing.T) {
+	em, done := newTestExtensionManager(t, []string{"transformEvent"},
+		newTestModule("", testHook{HookTransformEvent, echoBody}))
+	defer done()
+
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:    fftypes.NewUUID(),
+				Type:  core.EventTypeMessageConfirmed,
+				Topic: "topic1",
+			},
+		},
+	}
+	transformed, err := em.TransformEvent(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, event.ID, transformed.ID)
+	assert.Equal(t, core.EventTypeMessageConfirmed, transformed.Type)
+	assert.Equal(t, "topic1", transformed.Topic)
+}
+
+func TestTransformEventTimeout(t *testing.T) {
+	em, done := newTestExtensionManager(t, []string{"transformEvent"},
+		newTestModule("", testHook{HookTransformEvent, spinBody}))
+	defer done()
+
+	_, err := em.TransformEvent(context.Background(), &core.EventDelivery{})
+	assert.Regexp(t, "FF10662.*ext1.*transformEvent", err)
+}
+
+func TestCheckPolicyAllowed(t *testing.T) {
+	output := `{"allow": true}`
+	em, done := newTestExtensionManager(t, []string{"checkPolicy"},
+		newTestModule(output, testHook{HookCheckPolicy, outputBody(len(output))}))
+	defer done()
+
+	err := em.CheckPolicy(context.Background(), &policy.Request{Namespace: "ns1", Method: "POST", Path: "/api/v1/namespaces/ns1/messages/broadcast"})
+	assert.NoError(t, err)
+}
+
+func TestCheckPolicyDenied(t *testing.T) {
+	output := `{"allow": false, "reason": "outside business hours"}`
+	em, done := newTestExtensionManager(t, []string{"checkPolicy"},
+		newTestModule(output, testHook{HookCheckPolicy, outputBody(len(output))}))
+	defer done()
+
+	err := em.CheckPolicy(context.Background(), &policy.Request{Namespace: "ns1", Method: "POST", Path: "/api/v1/namespaces/ns1/messages/broadcast"})
+	assert.Regexp(t, "FF10638.*outside business hours", err)
+}
+
+func TestCallInputOutOfRange(t *testing.T) {
+	em, done := newTestExtensionManager(t, []string{"checkPolicy"},
+		newTestModule("", testHook{HookCheckPolicy, echoBody}))
+	defer done()
+
+	_, err := em.modules[0].call(context.Background(), HookCheckPolicy, make([]byte, 65536))
+	assert.Regexp(t, "FF10662.*input out of range", err)
+}
+
+func TestCallOutputOutOfRange(t *testing.T) {
+	em, done := newTestExtensionManager(t, []string{"checkPolicy"},
+		newTestModule("", testHook{HookCheckPolicy, outputBody(1 << 20)}))
+	defer done()
+
+	_, err := em.modules[0].call(context.Background(), HookCheckPolicy, []byte("{}"))
+	assert.Regexp(t, "FF10662.*output out of range", err)
+}
@@ -0,0 +1,140 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	wasmPageSize    = 65536
+	wasmAllocExport = "alloc"
+)
+
+// module is a compiled WASM module, in a runtime of its own so that the memory limit applies to it alone.
+//
+// A fresh instance of the module is created for each call, so no state is carried between calls, and a
+// call that traps or is aborted cannot affect the next one. The only host functions available are those
+// of WASI, with no filesystem, network or environment access.
+type module struct {
+	name     string
+	hooks    map[Hook]bool
+	timeout  time.Duration
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+}
+
+func loadModule(ctx context.Context, name, path string, maxMemory int64, timeout time.Duration) (*module, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionLoadFailed, name, err)
+	}
+	pages := uint32(maxMemory / wasmPageSize)
+	if pages < 1 {
+		pages = 1
+	}
+	m := &module{
+		name:    name,
+		hooks:   make(map[Hook]bool),
+		timeout: timeout,
+		runtime: wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+			WithMemoryLimitPages(pages).
+			WithCloseOnContextDone(true)),
+	}
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, m.runtime); err == nil {
+		m.compiled, err = m.runtime.CompileModule(ctx, code)
+	}
+	if err != nil {
+		_ = m.runtime.Close(ctx)
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionLoadFailed, name, err)
+	}
+	return m, nil
+}
+
+// call passes the input to the export of the module named after the hook, and returns its output.
+//
+// The input is written to memory obtained from the "alloc" export of the module. The hook export is
+// passed the pointer and length of the input, and returns the pointer of its output in the upper 32
+// bits of an i64 result, and the length in the lower 32 bits.
+func (m *module) call(ctx context.Context, hook Hook, input []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	inst, err := m.runtime.InstantiateModule(ctx, m.compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionCallFailed, m.name, hook, err)
+	}
+	defer inst.Close(ctx)
+
+	alloc := inst.ExportedFunction(wasmAllocExport)
+	fn := inst.ExportedFunction(string(hook))
+	mem := inst.Memory()
+	switch {
+	case alloc == nil:
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionCallFailed, m.name, hook, "missing export 'alloc'")
+	case fn == nil:
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionCallFailed, m.name, hook, "missing export '"+string(hook)+"'")
+	case mem == nil:
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionCallFailed, m.name, hook, "missing memory export")
+	}
+
+	res, err := callExport(ctx, alloc, uint64(len(input)))
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionCallFailed, m.name, hook, err)
+	}
+	inPtr := uint32(res)
+	if !mem.Write(inPtr, input) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionCallFailed, m.name, hook, "input out of range of memory")
+	}
+	res, err = callExport(ctx, fn, uint64(inPtr), uint64(len(input)))
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionCallFailed, m.name, hook, err)
+	}
+	outPtr, outLen := uint32(res>>32), uint32(res)
+	output, ok := mem.Read(outPtr, outLen)
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgExtensionCallFailed, m.name, hook, "output out of range of memory")
+	}
+	// The memory is released when the instance is closed
+	return append([]byte{}, output...), nil
+}
+
+func callExport(ctx context.Context, fn api.Function, params ...uint64) (uint64, error) {
+	res, err := fn.Call(ctx, params...)
+	if err != nil {
+		return 0, err
+	}
+	if len(res) != 1 {
+		return 0, fmt.Errorf("export '%s' must return a single result", fn.Definition().Name())
+	}
+	return res[0], nil
+}
+
+func (m *module) close(ctx context.Context) {
+	_ = m.runtime.Close(ctx)
+}
@@ -0,0 +1,116 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// blockchainFaults injects faults into the calls that are made to the blockchain connector.
+// All other calls pass straight through to the plugin.
+type blockchainFaults struct {
+	blockchain.Plugin
+	inj Injector
+}
+
+// WrapBlockchain returns the plugin unchanged if there is no injector
+func WrapBlockchain(inj Injector, bi blockchain.Plugin) blockchain.Plugin {
+	if inj == nil || bi == nil {
+		return bi
+	}
+	return &blockchainFaults{Plugin: bi, inj: inj}
+}
+
+func (bf *blockchainFaults) inject(ctx context.Context, method string, call func() error) error {
+	return bf.inj.Inject(ctx, core.FaultPluginBlockchain, method, call)
+}
+
+func (bf *blockchainFaults) ResolveSigningKey(ctx context.Context, keyRef string, intent blockchain.ResolveKeyIntent) (resolved string, err error) {
+	err = bf.inject(ctx, "ResolveSigningKey", func() (err error) {
+		resolved, err = bf.Plugin.ResolveSigningKey(ctx, keyRef, intent)
+		return err
+	})
+	return resolved, err
+}
+
+func (bf *blockchainFaults) SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, signingKey string, batch *blockchain.BatchPin, location *fftypes.JSONAny) error {
+	return bf.inject(ctx, "SubmitBatchPin", func() error {
+		return bf.Plugin.SubmitBatchPin(ctx, nsOpID, networkNamespace, signingKey, batch, location)
+	})
+}
+
+func (bf *blockchainFaults) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action fftypes.FFEnum, payload string, location *fftypes.JSONAny) error {
+	return bf.inject(ctx, "SubmitNetworkAction", func() error {
+		return bf.Plugin.SubmitNetworkAction(ctx, nsOpID, signingKey, action, payload, location)
+	})
+}
+
+func (bf *blockchainFaults) DeployContract(ctx context.Context, nsOpID, signingKey string, definition, contract *fftypes.JSONAny, input []interface{}, options map[string]interface{}) (submissionRejected bool, err error) {
+	err = bf.inject(ctx, "DeployContract", func() (err error) {
+		submissionRejected, err = bf.Plugin.DeployContract(ctx, nsOpID, signingKey, definition, contract, input, options)
+		return err
+	})
+	return submissionRejected, err
+}
+
+func (bf *blockchainFaults) InvokeContract(ctx context.Context, nsOpID string, signingKey string, location *fftypes.JSONAny, parsedMethod interface{}, input map[string]interface{}, options map[string]interface{}, batch *blockchain.BatchPin) (submissionRejected bool, err error) {
+	err = bf.inject(ctx, "InvokeContract", func() (err error) {
+		submissionRejected, err = bf.Plugin.InvokeContract(ctx, nsOpID, signingKey, location, parsedMethod, input, options, batch)
+		return err
+	})
+	return submissionRejected, err
+}
+
+func (bf *blockchainFaults) QueryContract(ctx context.Context, signingKey string, location *fftypes.JSONAny, parsedMethod interface{}, input map[string]interface{}, options map[string]interface{}) (result interface{}, err error) {
+	err = bf.inject(ctx, "QueryContract", func() (err error) {
+		result, err = bf.Plugin.QueryContract(ctx, signingKey, location, parsedMethod, input, options)
+		return err
+	})
+	return result, err
+}
+
+func (bf *blockchainFaults) AddContractListener(ctx context.Context, subscription *core.ContractListener, lastProtocolID string) error {
+	return bf.inject(ctx, "AddContractListener", func() error {
+		return bf.Plugin.AddContractListener(ctx, subscription, lastProtocolID)
+	})
+}
+
+func (bf *blockchainFaults) DeleteContractListener(ctx context.Context, subscription *core.ContractListener, okNotFound bool) error {
+	return bf.inject(ctx, "DeleteContractListener", func() error {
+		return bf.Plugin.DeleteContractListener(ctx, subscription, okNotFound)
+	})
+}
+
+func (bf *blockchainFaults) GetContractListenerStatus(ctx context.Context, namespace, subID string, okNotFound bool) (found bool, detail interface{}, status core.ContractListenerStatus, err error) {
+	err = bf.inject(ctx, "GetContractListenerStatus", func() (err error) {
+		found, detail, status, err = bf.Plugin.GetContractListenerStatus(ctx, namespace, subID, okNotFound)
+		return err
+	})
+	return found, detail, status, err
+}
+
+func (bf *blockchainFaults) GetTransactionStatus(ctx context.Context, operation *core.Operation) (status interface{}, err error) {
+	err = bf.inject(ctx, "GetTransactionStatus", func() (err error) {
+		status, err = bf.Plugin.GetTransactionStatus(ctx, operation)
+		return err
+	})
+	return status, err
+}
@@ -0,0 +1,102 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func blockchainCalls(bi blockchain.Plugin) []func(ctx context.Context) error {
+	return []func(ctx context.Context) error{
+		func(ctx context.Context) error {
+			_, err := bi.ResolveSigningKey(ctx, "0x12345", blockchain.ResolveKeyIntentSign)
+			return err
+		},
+		func(ctx context.Context) error { return bi.SubmitBatchPin(ctx, "ns1:op1", "ns1", "0x12345", nil, nil) },
+		func(ctx context.Context) error {
+			return bi.SubmitNetworkAction(ctx, "ns1:op1", "0x12345", core.NetworkActionTerminate, "", nil)
+		},
+		func(ctx context.Context) error {
+			_, err := bi.DeployContract(ctx, "ns1:op1", "0x12345", nil, nil, nil, nil)
+			return err
+		},
+		func(ctx context.Context) error {
+			_, err := bi.InvokeContract(ctx, "ns1:op1", "0x12345", nil, nil, nil, nil, nil)
+			return err
+		},
+		func(ctx context.Context) error {
+			_, err := bi.QueryContract(ctx, "0x12345", nil, nil, nil, nil)
+			return err
+		},
+		func(ctx context.Context) error { return bi.AddContractListener(ctx, nil, "") },
+		func(ctx context.Context) error { return bi.DeleteContractListener(ctx, nil, true) },
+		func(ctx context.Context) error {
+			_, _, _, err := bi.GetContractListenerStatus(ctx, "ns1", "sub1", true)
+			return err
+		},
+		func(ctx context.Context) error {
+			_, err := bi.GetTransactionStatus(ctx, nil)
+			return err
+		},
+	}
+}
+
+func TestWrapBlockchainDisabled(t *testing.T) {
+	mbi := &blockchainmocks.Plugin{}
+	assert.Equal(t, mbi, WrapBlockchain(nil, mbi))
+	assert.Nil(t, WrapBlockchain(newTestInjector(t), nil))
+}
+
+func TestWrapBlockchainFail(t *testing.T) {
+	inj := newTestInjector(t, &core.FaultRule{Plugin: core.FaultPluginBlockchain, Action: core.FaultActionFail, Probability: 1})
+	mbi := &blockchainmocks.Plugin{}
+	bi := WrapBlockchain(inj, mbi)
+
+	for _, call := range blockchainCalls(bi) {
+		assert.Regexp(t, "FF10606", call(context.Background()))
+	}
+	assert.Equal(t, int64(10), inj.GetRules().Rules[0].Injected)
+	mbi.AssertExpectations(t)
+}
+
+func TestWrapBlockchainPassThrough(t *testing.T) {
+	inj := newTestInjector(t)
+	mbi := &blockchainmocks.Plugin{}
+	mbi.On("ResolveSigningKey", mock.Anything, "0x12345", blockchain.ResolveKeyIntentSign).Return("0x12345", nil)
+	mbi.On("SubmitBatchPin", mock.Anything, "ns1:op1", "ns1", "0x12345", mock.Anything, mock.Anything).Return(nil)
+	mbi.On("SubmitNetworkAction", mock.Anything, "ns1:op1", "0x12345", core.NetworkActionTerminate, "", mock.Anything).Return(nil)
+	mbi.On("DeployContract", mock.Anything, "ns1:op1", "0x12345", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	mbi.On("InvokeContract", mock.Anything, "ns1:op1", "0x12345", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	mbi.On("QueryContract", mock.Anything, "0x12345", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	mbi.On("AddContractListener", mock.Anything, mock.Anything, "").Return(nil)
+	mbi.On("DeleteContractListener", mock.Anything, mock.Anything, true).Return(nil)
+	mbi.On("GetContractListenerStatus", mock.Anything, "ns1", "sub1", true).Return(true, nil, core.ContractListenerStatusSynced, nil)
+	mbi.On("GetTransactionStatus", mock.Anything, mock.Anything).Return(nil, nil)
+	bi := WrapBlockchain(inj, mbi)
+
+	for _, call := range blockchainCalls(bi) {
+		assert.NoError(t, call(context.Background()))
+	}
+	mbi.AssertExpectations(t)
+}
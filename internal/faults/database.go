@@ -0,0 +1,179 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// databaseFaults injects faults into database groups, and the reads and writes of the core collections
+// processed by the event pipeline. All other calls pass straight through to the plugin.
+type databaseFaults struct {
+	database.Plugin
+	inj Injector
+}
+
+// WrapDatabase returns the plugin unchanged if there is no injector
+func WrapDatabase(inj Injector, di database.Plugin) database.Plugin {
+	if inj == nil || di == nil {
+		return di
+	}
+	return &databaseFaults{Plugin: di, inj: inj}
+}
+
+func (df *databaseFaults) inject(ctx context.Context, method string, call func() error) error {
+	return df.inj.Inject(ctx, core.FaultPluginDatabase, method, call)
+}
+
+func (df *databaseFaults) RunAsGroup(ctx context.Context, fn func(ctx context.Context) error) error {
+	return df.inject(ctx, "RunAsGroup", func() error {
+		return df.Plugin.RunAsGroup(ctx, fn)
+	})
+}
+
+func (df *databaseFaults) UpsertMessage(ctx context.Context, message *core.Message, optimization database.UpsertOptimization, hooks ...database.PostCompletionHook) error {
+	return df.inject(ctx, "UpsertMessage", func() error {
+		return df.Plugin.UpsertMessage(ctx, message, optimization, hooks...)
+	})
+}
+
+func (df *databaseFaults) InsertMessages(ctx context.Context, messages []*core.Message, hooks ...database.PostCompletionHook) error {
+	return df.inject(ctx, "InsertMessages", func() error {
+		return df.Plugin.InsertMessages(ctx, messages, hooks...)
+	})
+}
+
+func (df *databaseFaults) GetMessageByID(ctx context.Context, namespace string, id *fftypes.UUID) (message *core.Message, err error) {
+	err = df.inject(ctx, "GetMessageByID", func() (err error) {
+		message, err = df.Plugin.GetMessageByID(ctx, namespace, id)
+		return err
+	})
+	return message, err
+}
+
+func (df *databaseFaults) GetMessages(ctx context.Context, namespace string, filter ffapi.Filter) (messages []*core.Message, res *ffapi.FilterResult, err error) {
+	err = df.inject(ctx, "GetMessages", func() (err error) {
+		messages, res, err = df.Plugin.GetMessages(ctx, namespace, filter)
+		return err
+	})
+	return messages, res, err
+}
+
+func (df *databaseFaults) UpsertData(ctx context.Context, data *core.Data, optimization database.UpsertOptimization) error {
+	return df.inject(ctx, "UpsertData", func() error {
+		return df.Plugin.UpsertData(ctx, data, optimization)
+	})
+}
+
+func (df *databaseFaults) GetDataByID(ctx context.Context, namespace string, id *fftypes.UUID, withValue bool) (data *core.Data, err error) {
+	err = df.inject(ctx, "GetDataByID", func() (err error) {
+		data, err = df.Plugin.GetDataByID(ctx, namespace, id, withValue)
+		return err
+	})
+	return data, err
+}
+
+func (df *databaseFaults) GetData(ctx context.Context, namespace string, filter ffapi.Filter) (data core.DataArray, res *ffapi.FilterResult, err error) {
+	err = df.inject(ctx, "GetData", func() (err error) {
+		data, res, err = df.Plugin.GetData(ctx, namespace, filter)
+		return err
+	})
+	return data, res, err
+}
+
+func (df *databaseFaults) InsertOrGetBatch(ctx context.Context, batch *core.BatchPersisted) (existing *core.BatchPersisted, err error) {
+	err = df.inject(ctx, "InsertOrGetBatch", func() (err error) {
+		existing, err = df.Plugin.InsertOrGetBatch(ctx, batch)
+		return err
+	})
+	return existing, err
+}
+
+func (df *databaseFaults) GetBatchByID(ctx context.Context, namespace string, id *fftypes.UUID) (batch *core.BatchPersisted, err error) {
+	err = df.inject(ctx, "GetBatchByID", func() (err error) {
+		batch, err = df.Plugin.GetBatchByID(ctx, namespace, id)
+		return err
+	})
+	return batch, err
+}
+
+func (df *databaseFaults) InsertTransaction(ctx context.Context, txn *core.Transaction) error {
+	return df.inject(ctx, "InsertTransaction", func() error {
+		return df.Plugin.InsertTransaction(ctx, txn)
+	})
+}
+
+func (df *databaseFaults) UpdateTransaction(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error {
+	return df.inject(ctx, "UpdateTransaction", func() error {
+		return df.Plugin.UpdateTransaction(ctx, namespace, id, update)
+	})
+}
+
+func (df *databaseFaults) InsertPins(ctx context.Context, pins []*core.Pin) error {
+	return df.inject(ctx, "InsertPins", func() error {
+		return df.Plugin.InsertPins(ctx, pins)
+	})
+}
+
+func (df *databaseFaults) GetPins(ctx context.Context, namespace string, filter ffapi.Filter) (pins []*core.Pin, res *ffapi.FilterResult, err error) {
+	err = df.inject(ctx, "GetPins", func() (err error) {
+		pins, res, err = df.Plugin.GetPins(ctx, namespace, filter)
+		return err
+	})
+	return pins, res, err
+}
+
+func (df *databaseFaults) InsertOperation(ctx context.Context, operation *core.Operation, hooks ...database.PostCompletionHook) error {
+	return df.inject(ctx, "InsertOperation", func() error {
+		return df.Plugin.InsertOperation(ctx, operation, hooks...)
+	})
+}
+
+func (df *databaseFaults) UpdateOperation(ctx context.Context, namespace string, id *fftypes.UUID, filter ffapi.Filter, update ffapi.Update) (updated bool, err error) {
+	err = df.inject(ctx, "UpdateOperation", func() (err error) {
+		updated, err = df.Plugin.UpdateOperation(ctx, namespace, id, filter, update)
+		return err
+	})
+	return updated, err
+}
+
+func (df *databaseFaults) InsertEvent(ctx context.Context, event *core.Event) error {
+	return df.inject(ctx, "InsertEvent", func() error {
+		return df.Plugin.InsertEvent(ctx, event)
+	})
+}
+
+func (df *databaseFaults) GetEvents(ctx context.Context, namespace string, filter ffapi.Filter) (events []*core.Event, res *ffapi.FilterResult, err error) {
+	err = df.inject(ctx, "GetEvents", func() (err error) {
+		events, res, err = df.Plugin.GetEvents(ctx, namespace, filter)
+		return err
+	})
+	return events, res, err
+}
+
+func (df *databaseFaults) InsertOrGetBlockchainEvent(ctx context.Context, event *core.BlockchainEvent) (existing *core.BlockchainEvent, err error) {
+	err = df.inject(ctx, "InsertOrGetBlockchainEvent", func() (err error) {
+		existing, err = df.Plugin.InsertOrGetBlockchainEvent(ctx, event)
+		return err
+	})
+	return existing, err
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func databaseCalls(di database.Plugin) []func(ctx context.Context) error {
+	return []func(ctx context.Context) error{
+		func(ctx context.Context) error {
+			return di.RunAsGroup(ctx, func(ctx context.Context) error { return nil })
+		},
+		func(ctx context.Context) error { return di.UpsertMessage(ctx, nil, database.UpsertOptimizationSkip) },
+		func(ctx context.Context) error { return di.InsertMessages(ctx, nil) },
+		func(ctx context.Context) error { _, err := di.GetMessageByID(ctx, "ns1", nil); return err },
+		func(ctx context.Context) error { _, _, err := di.GetMessages(ctx, "ns1", nil); return err },
+		func(ctx context.Context) error { return di.UpsertData(ctx, nil, database.UpsertOptimizationSkip) },
+		func(ctx context.Context) error { _, err := di.GetDataByID(ctx, "ns1", nil, true); return err },
+		func(ctx context.Context) error { _, _, err := di.GetData(ctx, "ns1", nil); return err },
+		func(ctx context.Context) error { _, err := di.InsertOrGetBatch(ctx, nil); return err },
+		func(ctx context.Context) error { _, err := di.GetBatchByID(ctx, "ns1", nil); return err },
+		func(ctx context.Context) error { return di.InsertTransaction(ctx, nil) },
+		func(ctx context.Context) error { return di.UpdateTransaction(ctx, "ns1", nil, nil) },
+		func(ctx context.Context) error { return di.InsertPins(ctx, nil) },
+		func(ctx context.Context) error { _, _, err := di.GetPins(ctx, "ns1", nil); return err },
+		func(ctx context.Context) error { return di.InsertOperation(ctx, nil) },
+		func(ctx context.Context) error { _, err := di.UpdateOperation(ctx, "ns1", nil, nil, nil); return err },
+		func(ctx context.Context) error { return di.InsertEvent(ctx, nil) },
+		func(ctx context.Context) error { _, _, err := di.GetEvents(ctx, "ns1", nil); return err },
+		func(ctx context.Context) error { _, err := di.InsertOrGetBlockchainEvent(ctx, nil); return err },
+	}
+}
+
+func TestWrapDatabaseDisabled(t *testing.T) {
+	mdi := &databasemocks.Plugin{}
+	assert.Equal(t, mdi, WrapDatabase(nil, mdi))
+}
+
+func TestWrapDatabaseFail(t *testing.T) {
+	inj := newTestInjector(t, &core.FaultRule{Plugin: core.FaultPluginDatabase, Action: core.FaultActionFail, Probability: 1})
+	mdi := &databasemocks.Plugin{}
+	di := WrapDatabase(inj, mdi)
+
+	for _, call := range databaseCalls(di) {
+		assert.Regexp(t, "FF10606", call(context.Background()))
+	}
+	assert.Equal(t, int64(19), inj.GetRules().Rules[0].Injected)
+	mdi.AssertExpectations(t)
+}
+
+func TestWrapDatabasePassThrough(t *testing.T) {
+	inj := newTestInjector(t, &core.FaultRule{Plugin: core.FaultPluginBlockchain, Action: core.FaultActionFail, Probability: 1})
+	mdi := &databasemocks.Plugin{}
+	mdi.On("RunAsGroup", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("UpsertMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertMessages", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdi.On("UpsertData", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetDataByID", mock.Anything, "ns1", mock.Anything, true).Return(nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+	mdi.On("InsertTransaction", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("UpdateTransaction", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertPins", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdi.On("InsertOperation", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdi.On("InsertOrGetBlockchainEvent", mock.Anything, mock.Anything).Return(nil, nil)
+	di := WrapDatabase(inj, mdi)
+
+	for _, call := range databaseCalls(di) {
+		assert.NoError(t, call(context.Background()))
+	}
+	mdi.AssertExpectations(t)
+}
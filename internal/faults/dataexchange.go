@@ -0,0 +1,89 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+)
+
+// dataExchangeFaults injects faults into the calls that are made to the data exchange connector.
+// Blob uploads and downloads stream their content, so cannot be duplicated, and pass straight through
+// to the plugin along with all other calls.
+type dataExchangeFaults struct {
+	dataexchange.Plugin
+	inj Injector
+}
+
+// WrapDataExchange returns the plugin unchanged if there is no injector
+func WrapDataExchange(inj Injector, dx dataexchange.Plugin) dataexchange.Plugin {
+	if inj == nil || dx == nil {
+		return dx
+	}
+	return &dataExchangeFaults{Plugin: dx, inj: inj}
+}
+
+func (xf *dataExchangeFaults) inject(ctx context.Context, method string, call func() error) error {
+	return xf.inj.Inject(ctx, core.FaultPluginDataExchange, method, call)
+}
+
+func (xf *dataExchangeFaults) GetEndpointInfo(ctx context.Context, nodeName string) (peer fftypes.JSONObject, err error) {
+	err = xf.inject(ctx, "GetEndpointInfo", func() (err error) {
+		peer, err = xf.Plugin.GetEndpointInfo(ctx, nodeName)
+		return err
+	})
+	return peer, err
+}
+
+func (xf *dataExchangeFaults) AddNode(ctx context.Context, networkNamespace, nodeName string, peer fftypes.JSONObject) error {
+	return xf.inject(ctx, "AddNode", func() error {
+		return xf.Plugin.AddNode(ctx, networkNamespace, nodeName, peer)
+	})
+}
+
+func (xf *dataExchangeFaults) UpdateNode(ctx context.Context, networkNamespace, nodeName string, peer fftypes.JSONObject) error {
+	return xf.inject(ctx, "UpdateNode", func() error {
+		return xf.Plugin.UpdateNode(ctx, networkNamespace, nodeName, peer)
+	})
+}
+
+func (xf *dataExchangeFaults) DeleteBlob(ctx context.Context, payloadRef string) error {
+	return xf.inject(ctx, "DeleteBlob", func() error {
+		return xf.Plugin.DeleteBlob(ctx, payloadRef)
+	})
+}
+
+func (xf *dataExchangeFaults) SendMessage(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, data []byte) error {
+	return xf.inject(ctx, "SendMessage", func() error {
+		return xf.Plugin.SendMessage(ctx, nsOpID, peer, sender, data)
+	})
+}
+
+func (xf *dataExchangeFaults) TransferBlob(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, payloadRef string) error {
+	return xf.inject(ctx, "TransferBlob", func() error {
+		return xf.Plugin.TransferBlob(ctx, nsOpID, peer, sender, payloadRef)
+	})
+}
+
+func (xf *dataExchangeFaults) CheckNodeIdentityStatus(ctx context.Context, node *core.Identity) error {
+	return xf.inject(ctx, "CheckNodeIdentityStatus", func() error {
+		return xf.Plugin.CheckNodeIdentityStatus(ctx, node)
+	})
+}
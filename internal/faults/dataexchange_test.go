@@ -0,0 +1,78 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func dataExchangeCalls(dx dataexchange.Plugin) []func(ctx context.Context) error {
+	return []func(ctx context.Context) error{
+		func(ctx context.Context) error {
+			_, err := dx.GetEndpointInfo(ctx, "node1")
+			return err
+		},
+		func(ctx context.Context) error { return dx.AddNode(ctx, "ns1", "node1", nil) },
+		func(ctx context.Context) error { return dx.UpdateNode(ctx, "ns1", "node1", nil) },
+		func(ctx context.Context) error { return dx.DeleteBlob(ctx, "blob1") },
+		func(ctx context.Context) error { return dx.SendMessage(ctx, "ns1:op1", nil, nil, []byte("hello")) },
+		func(ctx context.Context) error { return dx.TransferBlob(ctx, "ns1:op1", nil, nil, "blob1") },
+		func(ctx context.Context) error { return dx.CheckNodeIdentityStatus(ctx, nil) },
+	}
+}
+
+func TestWrapDataExchangeDisabled(t *testing.T) {
+	mdx := &dataexchangemocks.Plugin{}
+	assert.Equal(t, mdx, WrapDataExchange(nil, mdx))
+}
+
+func TestWrapDataExchangeFail(t *testing.T) {
+	inj := newTestInjector(t, &core.FaultRule{Plugin: core.FaultPluginDataExchange, Action: core.FaultActionFail, Probability: 1})
+	mdx := &dataexchangemocks.Plugin{}
+	dx := WrapDataExchange(inj, mdx)
+
+	for _, call := range dataExchangeCalls(dx) {
+		assert.Regexp(t, "FF10606", call(context.Background()))
+	}
+	assert.Equal(t, int64(7), inj.GetRules().Rules[0].Injected)
+	mdx.AssertExpectations(t)
+}
+
+func TestWrapDataExchangeDuplicate(t *testing.T) {
+	inj := newTestInjector(t, &core.FaultRule{Plugin: core.FaultPluginDataExchange, Action: core.FaultActionDuplicate, Probability: 1})
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("GetEndpointInfo", mock.Anything, "node1").Return(nil, nil).Twice()
+	mdx.On("AddNode", mock.Anything, "ns1", "node1", mock.Anything).Return(nil).Twice()
+	mdx.On("UpdateNode", mock.Anything, "ns1", "node1", mock.Anything).Return(nil).Twice()
+	mdx.On("DeleteBlob", mock.Anything, "blob1").Return(nil).Twice()
+	mdx.On("SendMessage", mock.Anything, "ns1:op1", mock.Anything, mock.Anything, []byte("hello")).Return(nil).Twice()
+	mdx.On("TransferBlob", mock.Anything, "ns1:op1", mock.Anything, mock.Anything, "blob1").Return(nil).Twice()
+	mdx.On("CheckNodeIdentityStatus", mock.Anything, mock.Anything).Return(nil).Twice()
+	dx := WrapDataExchange(inj, mdx)
+
+	for _, call := range dataExchangeCalls(dx) {
+		assert.NoError(t, call(context.Background()))
+	}
+	mdx.AssertExpectations(t)
+}
@@ -0,0 +1,157 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Injector holds the fault injection rules of a namespace, and applies them to the plugin calls made through
+// the wrappers in this package. Rules are held in memory only, so a restart of the namespace clears them.
+type Injector interface {
+	GetRules() *core.NamespaceFaults
+	SetRules(ctx context.Context, faults *core.NamespaceFaults) (*core.NamespaceFaults, error)
+	Inject(ctx context.Context, plugin core.FaultPlugin, method string, call func() error) error
+}
+
+type injector struct {
+	namespace string
+	mux       sync.Mutex
+	rules     []*core.FaultRule
+	random    func() float64
+}
+
+// NewInjector returns nil if fault injection is not enabled
+func NewInjector(ctx context.Context, ns string) Injector {
+	if !config.GetBool(coreconfig.FaultsEnabled) {
+		return nil
+	}
+	log.L(ctx).Warnf("Fault injection is enabled for namespace '%s' - this must never be enabled in production", ns)
+	return &injector{
+		namespace: ns,
+		rules:     []*core.FaultRule{},
+		random:    rand.Float64, //nolint:gosec
+	}
+}
+
+func (inj *injector) GetRules() *core.NamespaceFaults {
+	inj.mux.Lock()
+	defer inj.mux.Unlock()
+	faults := &core.NamespaceFaults{
+		Rules: make([]*core.FaultRule, len(inj.rules)),
+	}
+	for i, rule := range inj.rules {
+		ruleCopy := *rule
+		faults.Rules[i] = &ruleCopy
+	}
+	return faults
+}
+
+// SetRules replaces all of the rules of the namespace, resetting the injected count of each rule
+func (inj *injector) SetRules(ctx context.Context, faults *core.NamespaceFaults) (*core.NamespaceFaults, error) {
+	rules := make([]*core.FaultRule, len(faults.Rules))
+	for i, rule := range faults.Rules {
+		if err := validateRule(ctx, rule); err != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgFaultRuleInvalid, i, err)
+		}
+		ruleCopy := *rule
+		ruleCopy.Injected = 0
+		rules[i] = &ruleCopy
+	}
+	inj.mux.Lock()
+	inj.rules = rules
+	inj.mux.Unlock()
+	log.L(ctx).Warnf("Fault injection rules of namespace '%s' set: count=%d", inj.namespace, len(rules))
+	return inj.GetRules(), nil
+}
+
+func validateRule(ctx context.Context, rule *core.FaultRule) error {
+	switch {
+	case rule == nil:
+		return fmt.Errorf("missing rule")
+	case !fftypes.FFEnumValid(ctx, "faultplugin", rule.Plugin):
+		return fmt.Errorf("unknown plugin '%s'", rule.Plugin)
+	case !fftypes.FFEnumValid(ctx, "faultaction", rule.Action):
+		return fmt.Errorf("unknown action '%s'", rule.Action)
+	case rule.Probability <= 0 || rule.Probability > 1:
+		return fmt.Errorf("probability must be greater than 0 and at most 1")
+	case rule.Action == core.FaultActionDelay && (rule.Delay == nil || *rule.Delay <= 0):
+		return fmt.Errorf("a delay is required")
+	}
+	return nil
+}
+
+// Inject makes the call, subject to the faults of every rule that matches the plugin and method.
+// Delays are applied first, then a failure returns without making the call, then a duplicate makes the
+// call a second time - as if the response to the first call was lost, and the call was retried.
+func (inj *injector) Inject(ctx context.Context, plugin core.FaultPlugin, method string, call func() error) error {
+	delay, fail, duplicate := inj.roll(plugin, method)
+	if delay > 0 {
+		log.L(ctx).Warnf("Injecting delay of %s into %s call '%s'", delay, plugin, method)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+		}
+	}
+	if fail {
+		log.L(ctx).Warnf("Injecting failure into %s call '%s'", plugin, method)
+		return i18n.NewError(ctx, coremsgs.MsgFaultInjected, plugin, method)
+	}
+	err := call()
+	if duplicate {
+		log.L(ctx).Warnf("Injecting duplicate of %s call '%s'", plugin, method)
+		err = call()
+	}
+	return err
+}
+
+func (inj *injector) roll(plugin core.FaultPlugin, method string) (delay time.Duration, fail, duplicate bool) {
+	inj.mux.Lock()
+	defer inj.mux.Unlock()
+	for _, rule := range inj.rules {
+		if rule.Plugin != plugin || (rule.Method != "" && !strings.EqualFold(rule.Method, method)) {
+			continue
+		}
+		if inj.random() >= rule.Probability {
+			continue
+		}
+		rule.Injected++
+		switch rule.Action {
+		case core.FaultActionDelay:
+			delay += time.Duration(*rule.Delay)
+		case core.FaultActionFail:
+			fail = true
+		case core.FaultActionDuplicate:
+			duplicate = true
+		}
+	}
+	return delay, fail, duplicate
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faults
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestInjector(t *testing.T, rules ...*core.FaultRule) *injector {
+	coreconfig.Reset()
+	config.Set(coreconfig.FaultsEnabled, true)
+	inj := NewInjector(context.Background(), "ns1").(*injector)
+	inj.random = func() float64 { return 0.5 }
+	_, err := inj.SetRules(context.Background(), &core.NamespaceFaults{Rules: rules})
+	assert.NoError(t, err)
+	return inj
+}
+
+func TestNewInjectorDisabled(t *testing.T) {
+	coreconfig.Reset()
+	assert.Nil(t, NewInjector(context.Background(), "ns1"))
+}
+
+func TestInjectNoRules(t *testing.T) {
+	inj := newTestInjector(t)
+	calls := 0
+	err := inj.Inject(context.Background(), core.FaultPluginDatabase, "GetMessages", func() error {
+		calls++
+		return fmt.Errorf("pop")
+	})
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, 1, calls)
+}
+
+func TestInjectFail(t *testing.T) {
+	inj := newTestInjector(t,
+		&core.FaultRule{Plugin: core.FaultPluginBlockchain, Method: "submitbatchpin", Action: core.FaultActionFail, Probability: 1},
+		&core.FaultRule{Plugin: core.FaultPluginBlockchain, Method: "InvokeContract", Action: core.FaultActionFail, Probability: 1},
+		&core.FaultRule{Plugin: core.FaultPluginDatabase, Action: core.FaultActionFail, Probability: 0.1},
+	)
+	calls := 0
+	call := func() error {
+		calls++
+		return nil
+	}
+	err := inj.Inject(context.Background(), core.FaultPluginBlockchain, "SubmitBatchPin", call)
+	assert.Regexp(t, "FF10606.*blockchain.*SubmitBatchPin", err)
+	err = inj.Inject(context.Background(), core.FaultPluginDatabase, "GetMessages", call)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	faults := inj.GetRules()
+	assert.Equal(t, int64(1), faults.Rules[0].Injected)
+	assert.Equal(t, int64(0), faults.Rules[1].Injected)
+	assert.Equal(t, int64(0), faults.Rules[2].Injected)
+}
+
+func TestInjectDelayAndDuplicate(t *testing.T) {
+	delay := fftypes.FFDuration(1 * time.Millisecond)
+	inj := newTestInjector(t,
+		&core.FaultRule{Plugin: core.FaultPluginDataExchange, Action: core.FaultActionDelay, Delay: &delay, Probability: 1},
+		&core.FaultRule{Plugin: core.FaultPluginDataExchange, Method: "SendMessage", Action: core.FaultActionDuplicate, Probability: 1},
+	)
+	calls := 0
+	err := inj.Inject(context.Background(), core.FaultPluginDataExchange, "SendMessage", func() error {
+		calls++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestInjectDelayCancelled(t *testing.T) {
+	delay := fftypes.FFDuration(1 * time.Minute)
+	inj := newTestInjector(t,
+		&core.FaultRule{Plugin: core.FaultPluginDatabase, Action: core.FaultActionDelay, Delay: &delay, Probability: 1},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := inj.Inject(ctx, core.FaultPluginDatabase, "RunAsGroup", func() error {
+		panic("should not be called")
+	})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestSetRulesInvalid(t *testing.T) {
+	inj := newTestInjector(t)
+	delay := fftypes.FFDuration(0)
+	for _, rule := range []*core.FaultRule{
+		nil,
+		{Plugin: "tokens", Action: core.FaultActionFail, Probability: 1},
+		{Plugin: core.FaultPluginDatabase, Action: "explode", Probability: 1},
+		{Plugin: core.FaultPluginDatabase, Action: core.FaultActionFail, Probability: 0},
+		{Plugin: core.FaultPluginDatabase, Action: core.FaultActionFail, Probability: 1.5},
+		{Plugin: core.FaultPluginDatabase, Action: core.FaultActionDelay, Probability: 1},
+		{Plugin: core.FaultPluginDatabase, Action: core.FaultActionDelay, Delay: &delay, Probability: 1},
+	} {
+		_, err := inj.SetRules(context.Background(), &core.NamespaceFaults{Rules: []*core.FaultRule{rule}})
+		assert.Regexp(t, "FF10605", err)
+	}
+}
+
+func TestSetRulesResetsCount(t *testing.T) {
+	inj := newTestInjector(t)
+	faults, err := inj.SetRules(context.Background(), &core.NamespaceFaults{Rules: []*core.FaultRule{
+		{Plugin: core.FaultPluginDatabase, Action: core.FaultActionFail, Probability: 1, Injected: 10},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), faults.Rules[0].Injected)
+}
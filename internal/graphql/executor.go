@@ -0,0 +1,449 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+type executor struct {
+	namespace string
+	database  database.Plugin
+	variables map[string]interface{}
+	errors    []*core.GraphQLError
+	// loaded holds the objects looked up by each loader, keyed by the value of the loader's key field.
+	// Keys that were looked up but not found are held with a nil value.
+	loaded map[*loader]map[fftypes.UUID]interface{}
+}
+
+// Execute runs a GraphQL query against the collections of a namespace. Errors resolving individual
+// fields are returned alongside the rest of the data, with the failed fields set to null.
+//
+// The depth, number of fields and number of aliases of the query are limited while it is parsed, and
+// its estimated cost is limited before any of it is resolved.
+func Execute(ctx context.Context, ns string, di database.Plugin, req *core.GraphQLRequest) *core.GraphQLResponse {
+	var op *operation
+	doc, err := parseDocument(ctx, req.Query, &limits{
+		maxDepth:   config.GetInt(coreconfig.GraphQLMaxDepth),
+		maxFields:  config.GetInt(coreconfig.GraphQLMaxFields),
+		maxAliases: config.GetInt(coreconfig.GraphQLMaxAliases),
+	})
+	if err == nil {
+		op, err = doc.operation(ctx, req.OperationName)
+	}
+	if err != nil {
+		return &core.GraphQLResponse{Errors: []*core.GraphQLError{{Message: err.Error()}}}
+	}
+
+	ex := &executor{
+		namespace: ns,
+		database:  di,
+		variables: make(map[string]interface{}),
+		loaded:    make(map[*loader]map[fftypes.UUID]interface{}),
+	}
+	for name, defaultValue := range op.variables {
+		ex.variables[name] = defaultValue
+		if v, ok := req.Variables[name]; ok {
+			ex.variables[name] = v
+		}
+	}
+	maxCost := config.GetInt(coreconfig.GraphQLMaxCost)
+	if cost := ex.cost(ctx, types[queryTypeName], op.selections, 1, maxCost); cost > maxCost {
+		err := i18n.NewError(ctx, coremsgs.MsgGraphQLMaxCost, cost, maxCost)
+		return &core.GraphQLResponse{Errors: []*core.GraphQLError{{Message: err.Error()}}}
+	}
+	data := ex.selectFields(ctx, types[queryTypeName], nil, nil, op.selections, nil)
+	b, _ := json.Marshal(data)
+	return &core.GraphQLResponse{Data: fftypes.JSONAnyPtrBytes(b), Errors: ex.errors}
+}
+
+// cost estimates the number of objects a selection set resolves, for each of the given number of parents.
+// Every list is assumed to return its full limit. The estimate stops once it exceeds the maximum.
+func (ex *executor) cost(ctx context.Context, t *objectType, selections []*field, parents, maxCost int) int {
+	total := 0
+	for _, f := range selections {
+		rel := t.relations[f.name]
+		if rel == nil {
+			continue // scalar and JSON fields are returned with their object
+		}
+		items := parents
+		if rel.list {
+			items *= ex.listLimit(ctx, f.args)
+		}
+		total += items
+		if total > maxCost {
+			return total
+		}
+		total += ex.cost(ctx, types[rel.typeName], f.selections, items, maxCost-total)
+		if total > maxCost {
+			return total
+		}
+	}
+	return total
+}
+
+// listLimit returns the limit a list field is resolved with. Invalid arguments are reported when the field is resolved.
+func (ex *executor) listLimit(ctx context.Context, fieldArgs map[string]interface{}) int {
+	limit := config.GetInt(coreconfig.APIDefaultFilterLimit)
+	if args, err := ex.resolveValue(ctx, fieldArgs); err == nil {
+		if value, isSet, err := intArg(ctx, args.(map[string]interface{}), "limit"); err == nil && isSet {
+			limit = value
+		}
+	}
+	if maxLimit := config.GetInt(coreconfig.APIMaxFilterLimit); limit > maxLimit {
+		limit = maxLimit
+	}
+	return limit
+}
+
+// load returns the objects with the given keys, querying the database only for keys that have not
+// already been loaded
+func (ex *executor) load(ctx context.Context, l *loader, keys []*fftypes.UUID) (map[fftypes.UUID]interface{}, error) {
+	loaded := ex.loaded[l]
+	if loaded == nil {
+		loaded = make(map[fftypes.UUID]interface{})
+		ex.loaded[l] = loaded
+	}
+	var missing []driver.Value
+	queried := make(map[fftypes.UUID]bool)
+	for _, key := range keys {
+		if key == nil || queried[*key] {
+			continue
+		}
+		if _, ok := loaded[*key]; !ok {
+			queried[*key] = true
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return loaded, nil
+	}
+
+	fb := l.qf.NewFilter(ctx)
+	results, err := l.get(ex, ctx, fb.In(l.field, missing))
+	if err != nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(results)
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i).Interface()
+		if key := l.keyOf(item); key != nil && loaded[*key] == nil {
+			loaded[*key] = item
+		}
+	}
+	for key := range queried {
+		if _, ok := loaded[key]; !ok {
+			loaded[key] = nil
+		}
+	}
+	return loaded, nil
+}
+
+// prefetch loads the objects of the relations selected on a list of objects with one query per relation,
+// rather than one query per object. Any error is reported when the relation is resolved for each object.
+func (ex *executor) prefetch(ctx context.Context, t *objectType, items reflect.Value, selections []*field) {
+	for _, f := range selections {
+		rel := t.relations[f.name]
+		if rel == nil || rel.loader == nil || f.selections == nil {
+			continue
+		}
+		var keys []*fftypes.UUID
+		for i := 0; i < items.Len(); i++ {
+			item := items.Index(i)
+			if item.Kind() == reflect.Ptr && item.IsNil() {
+				continue
+			}
+			keys = append(keys, rel.prefetch(item.Interface())...)
+		}
+		_, _ = ex.load(ctx, rel.loader, keys)
+	}
+}
+
+func (ex *executor) addError(err error, path []interface{}) {
+	ex.errors = append(ex.errors, &core.GraphQLError{Message: err.Error(), Path: path})
+}
+
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	newPath := make([]interface{}, len(path)+1)
+	copy(newPath, path)
+	newPath[len(path)] = elem
+	return newPath
+}
+
+// selectFields builds the response for a selection set. The object is either an object of a schema type,
+// or a nested JSON object within one (in which case t is nil, and only its JSON values can be selected).
+func (ex *executor) selectFields(ctx context.Context, t *objectType, parent interface{}, values map[string]interface{}, selections []*field, path []interface{}) *orderedMap {
+	result := &orderedMap{values: make(map[string]interface{})}
+	for _, f := range selections {
+		fieldPath := appendPath(path, f.responseKey())
+		v, err := ex.resolveField(ctx, t, parent, values, f, fieldPath)
+		if err != nil {
+			ex.addError(err, fieldPath)
+			v = nil
+		}
+		result.set(f.responseKey(), v)
+	}
+	return result
+}
+
+func (ex *executor) resolveField(ctx context.Context, t *objectType, parent interface{}, values map[string]interface{}, f *field, path []interface{}) (interface{}, error) {
+	if t != nil {
+		if f.name == "__typename" {
+			return t.name, nil
+		}
+		if rel := t.relations[f.name]; rel != nil {
+			if f.selections == nil {
+				return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLSubselectionRequired, f.name, rel.typeName)
+			}
+			args, err := ex.resolveValue(ctx, f.args)
+			if err != nil {
+				return nil, err
+			}
+			v, err := rel.resolve(ctx, ex, parent, args.(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			return ex.complete(ctx, types[rel.typeName], v, f.selections, path), nil
+		}
+		if !t.fields[f.name] {
+			return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLUnknownField, f.name, t.name)
+		}
+	}
+	return ex.completeJSON(ctx, values[f.name], f.selections, path), nil
+}
+
+// complete builds the response for an object, or list of objects, returned by a resolver
+func (ex *executor) complete(ctx context.Context, t *objectType, v interface{}, selections []*field, path []interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return nil
+	case rv.Kind() == reflect.Slice:
+		ex.prefetch(ctx, t, rv, selections)
+		items := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			items[i] = ex.complete(ctx, t, rv.Index(i).Interface(), selections, appendPath(path, i))
+		}
+		return items
+	case rv.Kind() == reflect.Ptr && rv.IsNil():
+		return nil
+	}
+	// Scalar fields are taken from the JSON representation of the object, so they match the REST API
+	var values map[string]interface{}
+	b, _ := json.Marshal(v)
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	_ = decoder.Decode(&values)
+	return ex.selectFields(ctx, t, v, values, selections, path)
+}
+
+// completeJSON builds the response for a JSON value within an object
+func (ex *executor) completeJSON(ctx context.Context, v interface{}, selections []*field, path []interface{}) interface{} {
+	if selections == nil {
+		return v
+	}
+	switch vt := v.(type) {
+	case map[string]interface{}:
+		return ex.selectFields(ctx, nil, nil, vt, selections, path)
+	case []interface{}:
+		items := make([]interface{}, len(vt))
+		for i, item := range vt {
+			items[i] = ex.completeJSON(ctx, item, selections, appendPath(path, i))
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+// resolveValue replaces the variables within an argument value with their values
+func (ex *executor) resolveValue(ctx context.Context, v interface{}) (interface{}, error) {
+	switch vt := v.(type) {
+	case variable:
+		value, ok := ex.variables[string(vt)]
+		if !ok {
+			return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLUndefinedVariable, string(vt))
+		}
+		return value, nil
+	case []interface{}:
+		items := make([]interface{}, len(vt))
+		for i, item := range vt {
+			var err error
+			if items[i], err = ex.resolveValue(ctx, item); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(vt))
+		for k, item := range vt {
+			var err error
+			if obj[k], err = ex.resolveValue(ctx, item); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	case nil:
+		return map[string]interface{}{}, nil // fields without arguments
+	default:
+		return v, nil
+	}
+}
+
+func (ex *executor) uuidArg(ctx context.Context, args map[string]interface{}, name string) (*fftypes.UUID, error) {
+	v, ok := args[name]
+	if !ok || v == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLMissingArgument, name)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLBadArgument, name)
+	}
+	return fftypes.ParseUUID(ctx, s)
+}
+
+func intArg(ctx context.Context, args map[string]interface{}, name string) (value int, isSet bool, err error) {
+	switch vt := args[name].(type) {
+	case nil:
+		return 0, false, nil
+	case int64:
+		value = int(vt)
+	case float64:
+		if vt != math.Trunc(vt) {
+			return 0, false, i18n.NewError(ctx, coremsgs.MsgGraphQLBadArgument, name)
+		}
+		value = int(vt)
+	default:
+		return 0, false, i18n.NewError(ctx, coremsgs.MsgGraphQLBadArgument, name)
+	}
+	if value < 0 {
+		return 0, false, i18n.NewError(ctx, coremsgs.MsgGraphQLBadArgument, name)
+	}
+	return value, true, nil
+}
+
+// listFilter builds the filter for a list field from its arguments:
+// - where: an object of field names and the values they must equal
+// - limit: the maximum number of items, which defaults to the API default limit and is capped at the API maximum
+// - skip: the number of items to skip
+// - sort: a field name to sort by, prefixed with "-" for descending order, or a list of them
+func (ex *executor) listFilter(ctx context.Context, fb ffapi.FilterBuilder, conditions []ffapi.Filter, args map[string]interface{}) (ffapi.Filter, error) {
+	if where, ok := args["where"]; ok && where != nil {
+		m, ok := where.(map[string]interface{})
+		if !ok {
+			return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLBadArgument, "where")
+		}
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := m[name]
+			if f, ok := value.(float64); ok && f == math.Trunc(f) {
+				value = int64(f)
+			}
+			conditions = append(conditions, fb.Eq(strings.ToLower(name), value))
+		}
+	}
+
+	limit, isSet, err := intArg(ctx, args, "limit")
+	if err != nil {
+		return nil, err
+	}
+	if !isSet {
+		limit = config.GetInt(coreconfig.APIDefaultFilterLimit)
+	}
+	if maxLimit := config.GetInt(coreconfig.APIMaxFilterLimit); limit > maxLimit {
+		limit = maxLimit
+	}
+	filter := fb.And(conditions...).Limit(uint64(limit))
+
+	skip, isSet, err := intArg(ctx, args, "skip")
+	if err != nil {
+		return nil, err
+	}
+	if isSet {
+		filter = filter.Skip(uint64(skip))
+	}
+
+	switch vt := args["sort"].(type) {
+	case nil:
+	case string:
+		filter = filter.Sort(vt)
+	case []interface{}:
+		for _, item := range vt {
+			s, ok := item.(string)
+			if !ok {
+				return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLBadArgument, "sort")
+			}
+			filter = filter.Sort(s)
+		}
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLBadArgument, "sort")
+	}
+	return filter, nil
+}
+
+// orderedMap is a JSON object that keeps its keys in the order of the query's selection set
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (om *orderedMap) set(key string, value interface{}) {
+	if _, exists := om.values[key]; !exists {
+		om.keys = append(om.keys, key)
+	}
+	om.values[key] = value
+}
+
+func (om *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range om.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		v, err := json.Marshal(om.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
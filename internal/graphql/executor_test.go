@@ -0,0 +1,421 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestExecute(t *testing.T, query string, variables fftypes.JSONObject) (*databasemocks.Plugin, func() *core.GraphQLResponse) {
+	coreconfig.Reset()
+	mdi := &databasemocks.Plugin{}
+	t.Cleanup(func() { mdi.AssertExpectations(t) })
+	return mdi, func() *core.GraphQLResponse {
+		return Execute(context.Background(), "ns1", mdi, &core.GraphQLRequest{
+			Query:     query,
+			Variables: variables,
+		})
+	}
+}
+
+func finalize(t *testing.T, filter ffapi.Filter) *ffapi.FilterInfo {
+	fi, err := filter.Finalize()
+	assert.NoError(t, err)
+	return fi
+}
+
+func TestExecuteMessageDataBlob(t *testing.T) {
+	msgID := fftypes.MustParseUUID("4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e")
+	data1 := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`{"a":1}`)}
+	data2 := &core.Data{ID: fftypes.NewUUID(), Blob: &core.BlobRef{Hash: fftypes.NewRandB32()}}
+	msg := &core.Message{
+		Header: core.MessageHeader{ID: msgID, Tag: "invoice"},
+		Data:   core.DataRefs{{ID: data2.ID}, {ID: data1.ID}},
+	}
+
+	mdi, execute := newTestExecute(t, `{
+		message(id: "4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e") {
+			__typename
+			header { tag id }
+			data { value blob { size } }
+			batch { id }
+		}
+	}`, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msgID).Return(msg, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return finalize(t, filter).String() == fmt.Sprintf("id IN ['%s','%s']", data2.ID, data1.ID)
+	})).Return(core.DataArray{data1, data2}, nil, nil)
+	mdi.On("GetBlobs", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return finalize(t, filter).String() == fmt.Sprintf("data_id IN ['%s']", data2.ID)
+	})).Return([]*core.Blob{{DataID: data2.ID, Size: 12345}}, nil, nil).Once()
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{
+		"message": {
+			"__typename": "Message",
+			"header": {"tag": "invoice", "id": "4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e"},
+			"data": [
+				{"value": null, "blob": {"size": 12345}},
+				{"value": {"a": 1}, "blob": null}
+			],
+			"batch": null
+		}
+	}`, res.Data.String())
+	assert.True(t, strings.HasPrefix(res.Data.String(), `{"message":{"__typename":"Message","header":{"tag":"invoice",`))
+}
+
+func TestExecuteListArgumentsAndVariables(t *testing.T) {
+	mdi, execute := newTestExecute(t, `query Q($tag: String, $limit: Int = 5, $sort: [String]) {
+		recent: messages(where: {tag: $tag, txType: "batch_pin"}, limit: $limit, skip: 10, sort: $sort) { hash }
+		pools: tokenPools(limit: 1000, sort: "name") { name }
+	}`, fftypes.JSONObject{"tag": "invoice", "sort": []interface{}{"-created", "sequence"}, "unused": true})
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		fi := finalize(t, filter)
+		return fi.String() == "( tag == 'invoice' ) && ( txtype == 'batch_pin' )" &&
+			fi.Limit == 5 && fi.Skip == 10 && len(fi.Sort) == 2 && fi.Sort[0].Field == "created" && fi.Sort[0].Descending
+	})).Return([]*core.Message{{Hash: fftypes.NewRandB32()}, {}}, nil, nil)
+	mdi.On("GetTokenPools", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		fi := finalize(t, filter)
+		return fi.Limit == uint64(config.GetInt(coreconfig.APIMaxFilterLimit)) && fi.Sort[0].Field == "name"
+	})).Return([]*core.TokenPool{}, nil, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.Regexp(t, `^\{"recent":\[\{"hash":"[0-9a-f]{64}"\},\{"hash":null\}\],"pools":\[\]\}$`, res.Data.String())
+}
+
+func TestExecuteDefaultLimit(t *testing.T) {
+	mdi, execute := newTestExecute(t, `{ events { type message { hash } } }`, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return finalize(t, filter).Limit == uint64(config.GetInt(coreconfig.APIDefaultFilterLimit))
+	})).Return([]*core.Event{{Type: core.EventTypeTransactionSubmitted}}, nil, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"events": [{"type": "transaction_submitted", "message": null}]}`, res.Data.String())
+}
+
+func TestExecuteRelationLists(t *testing.T) {
+	txID := fftypes.NewUUID()
+	mdi, execute := newTestExecute(t, `{
+		transaction(id: "`+txID.String()+`") {
+			operations { type }
+			blockchainEvents(limit: 1) { name }
+		}
+	}`, nil)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", txID).Return(&core.Transaction{ID: txID}, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return finalize(t, filter).String() == fmt.Sprintf("tx == '%s'", txID)
+	})).Return([]*core.Operation{{Type: core.OpTypeBlockchainPinBatch}}, nil, nil)
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		fi := finalize(t, filter)
+		return fi.String() == fmt.Sprintf("tx.id == '%s'", txID) && fi.Limit == 1
+	})).Return([]*core.BlockchainEvent{{Name: "BatchPin"}}, nil, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"transaction": {
+		"operations": [{"type": "blockchain_pin_batch"}],
+		"blockchainEvents": [{"name": "BatchPin"}]
+	}}`, res.Data.String())
+}
+
+func TestExecuteNestedJSONSelection(t *testing.T) {
+	mdi, execute := newTestExecute(t, `{ data { value { customer items { sku } missing } } }`, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{
+		{Value: fftypes.JSONAnyPtr(`{"customer":"acme","items":[{"sku":"w1","qty":1}],"other":true}`)},
+		{Value: fftypes.JSONAnyPtr(`"scalar"`)},
+	}, nil, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"data": [
+		{"value": {"customer": "acme", "items": [{"sku": "w1"}], "missing": null}},
+		{"value": "scalar"}
+	]}`, res.Data.String())
+}
+
+func TestExecuteFieldErrors(t *testing.T) {
+	mdi, execute := newTestExecute(t, `{
+		ok: message(id: "4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e") { hash unknown batch }
+		noID: message { hash }
+		badID: message(id: 12345) { hash }
+		notUUID: message(id: "wrong") { hash }
+		undefined: messages(limit: $nope) { hash }
+		badLimit: messages(limit: "ten") { hash }
+		negative: messages(limit: -1) { hash }
+		fraction: messages(skip: 1.5) { hash }
+		badWhere: messages(where: "tag") { hash }
+		badSort: messages(sort: 1) { hash }
+		badSortItem: messages(sort: [1]) { hash }
+		failed: events { type }
+		unknownRoot { id }
+	}`, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(&core.Message{}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	res := execute()
+	errors := map[string]string{}
+	for _, e := range res.Errors {
+		errors[fmt.Sprint(e.Path...)] = e.Message
+	}
+	assert.Len(t, errors, 14)
+	assert.Regexp(t, "FF10529.*unknown.*Message", errors["okunknown"])
+	assert.Regexp(t, "FF10530.*batch.*Batch", errors["okbatch"])
+	assert.Regexp(t, "FF10531.*id", errors["noID"])
+	assert.Regexp(t, "FF10532.*id", errors["badID"])
+	assert.Regexp(t, "FF00138", errors["notUUID"])
+	assert.Regexp(t, "FF10533.*nope", errors["undefined"])
+	assert.Regexp(t, "FF10532.*limit", errors["badLimit"])
+	assert.Regexp(t, "FF10532.*limit", errors["negative"])
+	assert.Regexp(t, "FF10532.*skip", errors["fraction"])
+	assert.Regexp(t, "FF10532.*where", errors["badWhere"])
+	assert.Regexp(t, "FF10532.*sort", errors["badSort"])
+	assert.Regexp(t, "FF10532.*sort", errors["badSortItem"])
+	assert.Regexp(t, "pop", errors["failed"])
+	assert.Regexp(t, "FF10529.*unknownRoot.*Query", errors["unknownRoot"])
+	assert.JSONEq(t, `{
+		"ok": {"hash": null, "unknown": null, "batch": null},
+		"noID": null, "badID": null, "notUUID": null, "undefined": null, "badLimit": null, "negative": null,
+		"fraction": null, "badWhere": null, "badSort": null, "badSortItem": null, "failed": null, "unknownRoot": null
+	}`, res.Data.String())
+}
+
+func TestExecuteDataMessages(t *testing.T) {
+	dataID := fftypes.NewUUID()
+	mdi, execute := newTestExecute(t, `query ($id: String!) { dataItem(id: $id) { messages(limit: 2) { hash } } }`,
+		fftypes.JSONObject{"id": dataID.String()})
+	mdi.On("GetDataByID", mock.Anything, "ns1", dataID, true).Return(&core.Data{ID: dataID}, nil)
+	mdi.On("GetMessagesForData", mock.Anything, "ns1", dataID, mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return finalize(t, filter).Limit == 2
+	})).Return([]*core.Message{}, nil, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"dataItem": {"messages": []}}`, res.Data.String())
+}
+
+func TestExecuteDataMessagesBadArgs(t *testing.T) {
+	dataID := fftypes.NewUUID()
+	mdi, execute := newTestExecute(t, `{ dataItem(id: "`+dataID.String()+`") { messages(limit: false) { hash } } }`, nil)
+	mdi.On("GetDataByID", mock.Anything, "ns1", dataID, true).Return(&core.Data{ID: dataID}, nil)
+
+	res := execute()
+	assert.Len(t, res.Errors, 1)
+	assert.Equal(t, []interface{}{"dataItem", "messages"}, res.Errors[0].Path)
+	assert.Regexp(t, "FF10532", res.Errors[0].Message)
+}
+
+func TestExecuteMessageNoData(t *testing.T) {
+	mdi, execute := newTestExecute(t, `{ messages { data { id } } }`, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{{}}, nil, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"messages": [{"data": []}]}`, res.Data.String())
+}
+
+func TestExecuteMessageDataFail(t *testing.T) {
+	mdi, execute := newTestExecute(t, `{ messages { data { id } } }`, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{
+		{Data: core.DataRefs{{ID: fftypes.NewUUID()}}},
+	}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	res := execute()
+	assert.Len(t, res.Errors, 1)
+	assert.Equal(t, []interface{}{"messages", 0, "data"}, res.Errors[0].Path)
+	assert.JSONEq(t, `{"messages": [{"data": null}]}`, res.Data.String())
+}
+
+func TestExecuteBlobNotFound(t *testing.T) {
+	mdi, execute := newTestExecute(t, `{ data { blob { hash } } }`, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{
+		{ID: fftypes.NewUUID(), Blob: &core.BlobRef{Hash: fftypes.NewRandB32()}},
+	}, nil, nil)
+	mdi.On("GetBlobs", mock.Anything, "ns1", mock.Anything).Return([]*core.Blob{}, nil, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"data": [{"blob": null}]}`, res.Data.String())
+}
+
+func TestExecuteAllRootFields(t *testing.T) {
+	id := fftypes.NewUUID()
+	mdi, execute := newTestExecute(t, `query ($id: String) {
+		dataItem(id: $id) { __typename }
+		batch(id: $id) { __typename messages { __typename } transaction { __typename } }
+		batches { __typename }
+		events { __typename }
+		transactions { __typename }
+		tokenTransfer(id: $id) { __typename pool { __typename } message { __typename } transaction { __typename } }
+		tokenTransfers { __typename }
+		tokenPool(id: $id) { __typename transfers { __typename } }
+		tokenPools { __typename }
+		identity(id: $id) { __typename parent { __typename } verifiers { __typename } }
+		identities { __typename }
+		data { __typename }
+		event(id: $id) { __typename transaction { __typename } }
+	}`, fftypes.JSONObject{"id": id.String()})
+	mdi.On("GetDataByID", mock.Anything, "ns1", id, true).Return(nil, nil)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", id).Return(&core.BatchPersisted{BatchHeader: core.BatchHeader{ID: id}, TX: core.TransactionRef{ID: id}}, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{{Header: core.MessageHeader{ID: id}}}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{{ID: id}}, nil, nil)
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", id).Return(&core.TokenTransfer{Pool: id, Message: id, TX: core.TransactionRef{ID: id}}, nil)
+	mdi.On("GetTokenPoolByID", mock.Anything, "ns1", id).Return(&core.TokenPool{ID: id}, nil)
+	mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+	mdi.On("GetTokenPools", mock.Anything, "ns1", mock.Anything).Return([]*core.TokenPool{{ID: id}}, nil, nil)
+	mdi.On("GetIdentityByID", mock.Anything, "ns1", id).Return(&core.Identity{IdentityBase: core.IdentityBase{ID: id}}, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{}, nil, nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{}, nil, nil)
+	mdi.On("GetEventByID", mock.Anything, "ns1", id).Return(&core.Event{}, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{
+		"dataItem": null,
+		"batch": {"__typename": "Batch", "messages": [{"__typename": "Message"}], "transaction": {"__typename": "Transaction"}},
+		"batches": [],
+		"events": [],
+		"transactions": [{"__typename": "Transaction"}],
+		"tokenTransfer": {"__typename": "TokenTransfer", "pool": {"__typename": "TokenPool"}, "message": {"__typename": "Message"}, "transaction": {"__typename": "Transaction"}},
+		"tokenTransfers": [],
+		"tokenPool": {"__typename": "TokenPool", "transfers": []},
+		"tokenPools": [{"__typename": "TokenPool"}],
+		"identity": {"__typename": "Identity", "parent": null, "verifiers": []},
+		"identities": [],
+		"data": [],
+		"event": {"__typename": "Event", "transaction": null}
+	}`, res.Data.String())
+}
+
+func TestExecuteParseError(t *testing.T) {
+	_, execute := newTestExecute(t, `{ messages {`, nil)
+	res := execute()
+	assert.Nil(t, res.Data)
+	assert.Len(t, res.Errors, 1)
+	assert.Regexp(t, "FF10525", res.Errors[0].Message)
+}
+
+func TestExecuteOperationNotFound(t *testing.T) {
+	coreconfig.Reset()
+	res := Execute(context.Background(), "ns1", &databasemocks.Plugin{}, &core.GraphQLRequest{
+		Query:         `query A { messages { hash } }`,
+		OperationName: "B",
+	})
+	assert.Nil(t, res.Data)
+	assert.Regexp(t, "FF10527", res.Errors[0].Message)
+}
+
+func TestExecuteMaxDepth(t *testing.T) {
+	_, execute := newTestExecute(t, `{ messages { batch { messages { batch { messages { batch { id } } } } } } }`, nil)
+	config.Set(coreconfig.GraphQLMaxDepth, 6)
+	res := execute()
+	assert.Nil(t, res.Data)
+	assert.Regexp(t, "FF10534.*6", res.Errors[0].Message)
+}
+
+func TestExecuteBatchedRelations(t *testing.T) {
+	msg1, msg2, tx1 := fftypes.NewUUID(), fftypes.NewUUID(), fftypes.NewUUID()
+	mdi, execute := newTestExecute(t, `{
+		events { message { header { id } } transaction { id } }
+		again: event(id: "4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e") { message { header { id } } }
+	}`, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		{Reference: msg1, Transaction: tx1},
+		{Reference: msg2, Transaction: tx1},
+		{Reference: msg1},
+		{},
+	}, nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return finalize(t, filter).String() == fmt.Sprintf("id IN ['%s','%s']", msg1, msg2)
+	})).Return([]*core.Message{{Header: core.MessageHeader{ID: msg1}}}, nil, nil).Once()
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return finalize(t, filter).String() == fmt.Sprintf("id IN ['%s']", tx1)
+	})).Return([]*core.Transaction{{ID: tx1}}, nil, nil).Once()
+	mdi.On("GetEventByID", mock.Anything, "ns1", mock.Anything).Return(&core.Event{Reference: msg2}, nil)
+
+	res := execute()
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"events": [
+			{"message": {"header": {"id": "%[1]s"}}, "transaction": {"id": "%[2]s"}},
+			{"message": null, "transaction": {"id": "%[2]s"}},
+			{"message": {"header": {"id": "%[1]s"}}, "transaction": null},
+			{"message": null, "transaction": null}
+		],
+		"again": {"message": null}
+	}`, msg1, tx1), res.Data.String())
+}
+
+func TestExecuteBatchedRelationFail(t *testing.T) {
+	mdi, execute := newTestExecute(t, `{ events { message { hash } } }`, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		{Reference: fftypes.NewUUID()},
+	}, nil, nil)
+	// Once for the list, and once more when resolving the event
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Twice()
+
+	res := execute()
+	assert.Len(t, res.Errors, 1)
+	assert.Equal(t, []interface{}{"events", 0, "message"}, res.Errors[0].Path)
+	assert.Regexp(t, "pop", res.Errors[0].Message)
+}
+
+func TestExecuteMaxCost(t *testing.T) {
+	_, execute := newTestExecute(t, `{ messages(limit: 100) { data { id } batch { id } } }`, nil)
+	config.Set(coreconfig.GraphQLMaxCost, 1000)
+	res := execute()
+	assert.Nil(t, res.Data)
+	assert.Regexp(t, "FF10670.*1000", res.Errors[0].Message)
+}
+
+func TestExecuteCostWithinLimit(t *testing.T) {
+	mdi, execute := newTestExecute(t, `query ($limit: Int) { messages(limit: $limit, skip: "bad") { batch { id } } events(limit: "bad") { type } }`,
+		fftypes.JSONObject{"limit": float64(10)})
+	// 10 messages, 10 batches and the default limit of events
+	config.Set(coreconfig.GraphQLMaxCost, 20+config.GetInt(coreconfig.APIDefaultFilterLimit))
+	res := execute()
+	assert.Len(t, res.Errors, 2)
+	assert.Regexp(t, "FF10532.*skip", res.Errors[0].Message)
+	assert.Regexp(t, "FF10532.*limit", res.Errors[1].Message)
+	mdi.AssertNotCalled(t, "GetMessages", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecuteMaxFields(t *testing.T) {
+	_, execute := newTestExecute(t, `{ messages { hash } events { type } }`, nil)
+	config.Set(coreconfig.GraphQLMaxFields, 3)
+	res := execute()
+	assert.Nil(t, res.Data)
+	assert.Regexp(t, "FF10668.*3", res.Errors[0].Message)
+}
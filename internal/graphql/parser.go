@@ -0,0 +1,538 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// The parser supports the subset of the GraphQL query language needed to read nested views of the
+// core collections: query operations, fields, aliases, arguments and variables. Mutations, subscriptions,
+// fragments and directives are rejected.
+//
+// The depth, number of fields and number of aliases are limited while parsing, so an oversized query is
+// rejected before any of it is built or resolved.
+
+// maxValueDepth limits the nesting of lists and objects within argument values and variable types
+const maxValueDepth = 16
+
+type limits struct {
+	maxDepth   int
+	maxFields  int
+	maxAliases int
+}
+
+type document struct {
+	operations []*operation
+}
+
+type operation struct {
+	name       string
+	variables  map[string]interface{} // default values, or nil where there is no default
+	selections []*field
+}
+
+type field struct {
+	alias      string
+	name       string
+	args       map[string]interface{}
+	selections []*field
+}
+
+// variable is an argument value that refers to a variable of the operation
+type variable string
+
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+type parser struct {
+	ctx        context.Context
+	src        string
+	pos        int
+	tok        token
+	limits     *limits
+	depth      int
+	valueDepth int
+	fields     int
+	aliases    int
+}
+
+func parseDocument(ctx context.Context, src string, limits *limits) (*document, error) {
+	p := &parser{ctx: ctx, src: src, limits: limits}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	doc := &document{}
+	for p.tok.kind != tokenEOF {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		doc.operations = append(doc.operations, op)
+	}
+	if len(doc.operations) == 0 {
+		return nil, p.syntaxError("empty document")
+	}
+	return doc, nil
+}
+
+func (doc *document) operation(ctx context.Context, name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLOperationRequired)
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, i18n.NewError(ctx, coremsgs.MsgGraphQLOperationNotFound, name)
+}
+
+func (p *parser) syntaxError(msg string) error {
+	return i18n.NewError(p.ctx, coremsgs.MsgGraphQLSyntaxError, p.tok.pos, msg)
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return p.syntaxError("unexpected end of document")
+	}
+	return p.syntaxError("unexpected '" + p.tok.value + "'")
+}
+
+func (p *parser) is(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *parser) expect(value string) error {
+	if !p.is(tokenPunctuator, value) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) parseOperation() (op *operation, err error) {
+	op = &operation{}
+	if p.tok.kind == tokenName {
+		switch p.tok.value {
+		case "query":
+		case "mutation":
+			return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLUnsupported, "mutations")
+		case "subscription":
+			return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLUnsupported, "subscriptions")
+		case "fragment":
+			return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLUnsupported, "fragments")
+		default:
+			return nil, p.unexpected()
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName {
+			op.name = p.tok.value
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		if p.is(tokenPunctuator, "(") {
+			if op.variables, err = p.parseVariableDefinitions(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if p.is(tokenPunctuator, "@") {
+		return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLUnsupported, "directives")
+	}
+	op.selections, err = p.parseSelectionSet()
+	return op, err
+}
+
+func (p *parser) parseVariableDefinitions() (map[string]interface{}, error) {
+	vars := make(map[string]interface{})
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	for !p.is(tokenPunctuator, ")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if err := p.skipType(); err != nil {
+			return nil, err
+		}
+		vars[name] = nil
+		if p.is(tokenPunctuator, "=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if vars[name], err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return vars, p.advance()
+}
+
+func (p *parser) enterValue() error {
+	p.valueDepth++
+	if p.valueDepth > maxValueDepth {
+		return p.syntaxError("value nested too deeply")
+	}
+	return nil
+}
+
+// skipType consumes a type reference such as [String!]! - values are checked by the fields that use them
+func (p *parser) skipType() error {
+	if p.is(tokenPunctuator, "[") {
+		if err := p.enterValue(); err != nil {
+			return err
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		p.valueDepth--
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return err
+	}
+	if p.is(tokenPunctuator, "!") {
+		return p.advance()
+	}
+	return nil
+}
+
+func (p *parser) parseSelectionSet() ([]*field, error) {
+	p.depth++
+	if p.depth > p.limits.maxDepth {
+		return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLMaxDepth, p.limits.maxDepth)
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var fields []*field
+	for !p.is(tokenPunctuator, "}") {
+		if p.is(tokenPunctuator, "...") {
+			return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLUnsupported, "fragments")
+		}
+		f, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, p.syntaxError("empty selection set")
+	}
+	p.depth--
+	return fields, p.advance()
+}
+
+func (p *parser) parseField() (f *field, err error) {
+	p.fields++
+	if p.fields > p.limits.maxFields {
+		return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLMaxFields, p.limits.maxFields)
+	}
+	f = &field{}
+	if f.name, err = p.expectName(); err != nil {
+		return nil, err
+	}
+	if p.is(tokenPunctuator, ":") {
+		p.aliases++
+		if p.aliases > p.limits.maxAliases {
+			return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLMaxAliases, p.limits.maxAliases)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		f.alias = f.name
+		if f.name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is(tokenPunctuator, "(") {
+		if f.args, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is(tokenPunctuator, "@") {
+		return nil, i18n.NewError(p.ctx, coremsgs.MsgGraphQLUnsupported, "directives")
+	}
+	if p.is(tokenPunctuator, "{") {
+		if f.selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseArguments() (map[string]interface{}, error) {
+	args := make(map[string]interface{})
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	for !p.is(tokenPunctuator, ")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if args[name], err = p.parseValue(false); err != nil {
+			return nil, err
+		}
+	}
+	return args, p.advance()
+}
+
+func (p *parser) parseValue(constant bool) (v interface{}, err error) {
+	tok := p.tok
+	switch {
+	case tok.kind == tokenPunctuator && tok.value == "$" && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		return variable(name), err
+	case tok.kind == tokenPunctuator && tok.value == "[":
+		list := []interface{}{}
+		if err := p.enterValue(); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.is(tokenPunctuator, "]") {
+			item, err := p.parseValue(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		p.valueDepth--
+		return list, p.advance()
+	case tok.kind == tokenPunctuator && tok.value == "{":
+		obj := map[string]interface{}{}
+		if err := p.enterValue(); err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		for !p.is(tokenPunctuator, "}") {
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if obj[name], err = p.parseValue(constant); err != nil {
+				return nil, err
+			}
+		}
+		p.valueDepth--
+		return obj, p.advance()
+	case tok.kind == tokenInt:
+		v, err = strconv.ParseInt(tok.value, 10, 64)
+	case tok.kind == tokenFloat:
+		v, err = strconv.ParseFloat(tok.value, 64)
+	case tok.kind == tokenString:
+		v = tok.value
+	case tok.kind == tokenName && tok.value == "true":
+		v = true
+	case tok.kind == tokenName && tok.value == "false":
+		v = false
+	case tok.kind == tokenName && tok.value == "null":
+		v = nil
+	case tok.kind == tokenName:
+		v = tok.value // enum values are passed as strings
+	default:
+		return nil, p.unexpected()
+	}
+	if err != nil {
+		return nil, p.syntaxError(err.Error())
+	}
+	return v, p.advance()
+}
+
+// advance reads the next token into p.tok, skipping whitespace, commas and comments
+func (p *parser) advance() error {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+		} else if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			p.pos++
+		} else if strings.HasPrefix(p.src[p.pos:], "\uFEFF") {
+			p.pos += len("\uFEFF")
+		} else {
+			break
+		}
+	}
+	start := p.pos
+	p.tok = token{pos: start}
+	if p.pos >= len(p.src) {
+		p.tok.kind = tokenEOF
+		return nil
+	}
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok.kind = tokenPunctuator
+	case strings.ContainsRune("!$&()/:=@[]{}|", rune(c)):
+		p.pos++
+		p.tok.kind = tokenPunctuator
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok.kind = tokenName
+	case c == '-' || isDigit(c):
+		return p.readNumber()
+	case c == '"':
+		return p.readString()
+	default:
+		p.tok.value = string(c)
+		return p.unexpected()
+	}
+	p.tok.value = p.src[start:p.pos]
+	return nil
+}
+
+func (p *parser) readNumber() error {
+	start := p.pos
+	p.tok.kind = tokenInt
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() int {
+		n := 0
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+			n++
+		}
+		return n
+	}
+	if digits() == 0 {
+		return p.syntaxError("invalid number")
+	}
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		p.pos++
+		p.tok.kind = tokenFloat
+		if digits() == 0 {
+			return p.syntaxError("invalid number")
+		}
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		p.pos++
+		p.tok.kind = tokenFloat
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		if digits() == 0 {
+			return p.syntaxError("invalid number")
+		}
+	}
+	p.tok.value = p.src[start:p.pos]
+	return nil
+}
+
+func (p *parser) readString() error {
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		return i18n.NewError(p.ctx, coremsgs.MsgGraphQLUnsupported, "block strings")
+	}
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.src) && p.src[p.pos] != '"' {
+		if p.src[p.pos] == '\\' {
+			p.pos++
+		} else if p.src[p.pos] == '\n' || p.src[p.pos] == '\r' {
+			break
+		}
+		p.pos++
+	}
+	if p.pos >= len(p.src) || p.src[p.pos] != '"' {
+		return p.syntaxError("unterminated string")
+	}
+	p.pos++
+	// GraphQL string escapes are the same as JSON string escapes
+	if err := json.Unmarshal([]byte(p.src[start:p.pos]), &p.tok.value); err != nil {
+		return p.syntaxError("invalid string")
+	}
+	p.tok.kind = tokenString
+	return nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,170 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testLimits = &limits{maxDepth: 10, maxFields: 100, maxAliases: 10}
+
+func TestParseQuery(t *testing.T) {
+	doc, err := parseDocument(context.Background(), `
+		# A named query with variables
+		query Recent($limit: Int = 5, $tag: String!, $ids: [String!]) {
+			latest: messages(limit: $limit, where: {tag: $tag}, sort: ["-created"]) {
+				header { id tag }
+				data { value }
+			}
+			event(id: "4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e") { type }
+			tokenPools(skip: 2, where: {active: true, decimals: 18, name: null, ratio: 1.5e2, type: FUNGIBLE}) { name }
+		}`, testLimits)
+	assert.NoError(t, err)
+	assert.Len(t, doc.operations, 1)
+	op := doc.operations[0]
+	assert.Equal(t, "Recent", op.name)
+	assert.Equal(t, map[string]interface{}{"limit": int64(5), "tag": nil, "ids": nil}, op.variables)
+	assert.Len(t, op.selections, 3)
+
+	latest := op.selections[0]
+	assert.Equal(t, "latest", latest.alias)
+	assert.Equal(t, "messages", latest.name)
+	assert.Equal(t, "latest", latest.responseKey())
+	assert.Equal(t, variable("limit"), latest.args["limit"])
+	assert.Equal(t, map[string]interface{}{"tag": variable("tag")}, latest.args["where"])
+	assert.Equal(t, []interface{}{"-created"}, latest.args["sort"])
+	assert.Equal(t, "header", latest.selections[0].name)
+	assert.Len(t, latest.selections[0].selections, 2)
+
+	assert.Equal(t, "event", op.selections[1].responseKey())
+	assert.Equal(t, "4b7bd2ba-7ac0-4e3a-a5e7-7b36da9d4b7e", op.selections[1].args["id"])
+
+	assert.Equal(t, map[string]interface{}{
+		"active":   true,
+		"decimals": int64(18),
+		"name":     nil,
+		"ratio":    float64(150),
+		"type":     "FUNGIBLE",
+	}, op.selections[2].args["where"])
+}
+
+func TestParseShorthandQuery(t *testing.T) {
+	doc, err := parseDocument(context.Background(), "\uFEFF{ messages { header { id } } }", testLimits)
+	assert.NoError(t, err)
+	assert.Len(t, doc.operations, 1)
+	assert.Equal(t, "", doc.operations[0].name)
+
+	op, err := doc.operation(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, doc.operations[0], op)
+}
+
+func TestParseStringEscapes(t *testing.T) {
+	doc, err := parseDocument(context.Background(), `{ message(id: "a\"b\\cé\n") { hash } }`, testLimits)
+	assert.NoError(t, err)
+	assert.Equal(t, "a\"b\\cé\n", doc.operations[0].selections[0].args["id"])
+}
+
+func TestParseMultipleOperations(t *testing.T) {
+	doc, err := parseDocument(context.Background(), `query A { messages { hash } } query B { events { type } }`, testLimits)
+	assert.NoError(t, err)
+
+	_, err = doc.operation(context.Background(), "")
+	assert.Regexp(t, "FF10528", err)
+
+	op, err := doc.operation(context.Background(), "B")
+	assert.NoError(t, err)
+	assert.Equal(t, "events", op.selections[0].name)
+
+	_, err = doc.operation(context.Background(), "C")
+	assert.Regexp(t, "FF10527", err)
+}
+
+func TestParseUnsupported(t *testing.T) {
+	for _, q := range []string{
+		`mutation { x }`,
+		`subscription { x }`,
+		`fragment F on Message { hash }`,
+		`{ messages { ...F } }`,
+		`{ messages @include(if: true) { hash } }`,
+		`query @skip(if: true) { messages { hash } }`,
+		`{ message(id: """x""") { hash } }`,
+	} {
+		_, err := parseDocument(context.Background(), q, testLimits)
+		assert.Regexp(t, "FF10526", err, q)
+	}
+}
+
+func TestParseSyntaxErrors(t *testing.T) {
+	for _, q := range []string{
+		``,
+		`# only a comment`,
+		`{}`,
+		`{ messages `,
+		`{ messages( }`,
+		`{ messages(limit 1) { hash } }`,
+		`{ messages(limit: ) { hash } }`,
+		`{ messages(limit: -) { hash } }`,
+		`{ messages(limit: 1.) { hash } }`,
+		`{ messages(limit: 1e) { hash } }`,
+		`{ messages(limit: 99999999999999999999) { hash } }`,
+		`{ messages(where: {tag "x"}) { hash } }`,
+		`{ messages(where: {1: "x"}) { hash } }`,
+		`{ messages(sort: ["x" 1 ) { hash } }`,
+		`{ message(id: "x
+") { hash } }`,
+		`{ message(id: "\q") { hash } }`,
+		`{ message(id: "x`,
+		`{ messages { hash } } %`,
+		`{ a: 1 }`,
+		`query Q($limit: Int = $other) { messages { hash } }`,
+		`query Q($limit Int) { messages { hash } }`,
+		`query Q(limit: Int) { messages { hash } }`,
+		`query Q($limit: [Int) { messages { hash } }`,
+		`query Q($limit: ) { messages { hash } }`,
+		`other { messages { hash } }`,
+	} {
+		_, err := parseDocument(context.Background(), q, testLimits)
+		assert.Error(t, err, q)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	lim := &limits{maxDepth: 3, maxFields: 4, maxAliases: 1}
+
+	_, err := parseDocument(context.Background(), `{ a: messages { batch { id } } }`, lim)
+	assert.NoError(t, err)
+
+	_, err = parseDocument(context.Background(), `{ messages { batch { messages { hash } } } }`, lim)
+	assert.Regexp(t, "FF10534.*3", err)
+
+	_, err = parseDocument(context.Background(), `{ messages { hash id } events { type id } }`, lim)
+	assert.Regexp(t, "FF10668.*4", err)
+
+	_, err = parseDocument(context.Background(), `{ a: messages { hash } b: events { id } }`, lim)
+	assert.Regexp(t, "FF10669.*1", err)
+
+	_, err = parseDocument(context.Background(), `{ messages(where: `+strings.Repeat("[", maxValueDepth+1)+`) { hash } }`, lim)
+	assert.Regexp(t, "FF10525.*nested", err)
+
+	_, err = parseDocument(context.Background(), `query Q($v: `+strings.Repeat("[", maxValueDepth+1)+`) { messages { hash } }`, lim)
+	assert.Regexp(t, "FF10525.*nested", err)
+}
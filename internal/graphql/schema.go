@@ -0,0 +1,387 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// objectType is a type in the schema. Its scalar fields are the JSON fields of the Go type returned by
+// the REST API, and its relations are fields that are resolved with a further database query.
+type objectType struct {
+	name      string
+	fields    map[string]bool
+	relations map[string]*relation
+}
+
+type resolver func(ctx context.Context, ex *executor, parent interface{}, args map[string]interface{}) (interface{}, error)
+
+type relation struct {
+	typeName string
+	resolve  resolver
+	// list is set for relations that resolve to a list of objects, for estimating the cost of a query
+	list bool
+	// loader and prefetch are set for relations that look up objects by key, so the objects for every
+	// parent in a list can be loaded with a single query before the relation is resolved for each parent
+	loader   *loader
+	prefetch func(parent interface{}) []*fftypes.UUID
+}
+
+// loader looks up the objects of a type by the values of a key field, and returns the key of each object found
+type loader struct {
+	qf    *ffapi.QueryFields
+	field string
+	get   getList
+	keyOf func(item interface{}) *fftypes.UUID
+}
+
+var types = map[string]*objectType{}
+
+const queryTypeName = "Query"
+
+var (
+	messageLoader = &loader{database.MessageQueryFactory, "id", getMessages, func(v interface{}) *fftypes.UUID { return v.(*core.Message).Header.ID }}
+	dataLoader    = &loader{database.DataQueryFactory, "id", getData, func(v interface{}) *fftypes.UUID { return v.(*core.Data).ID }}
+	blobLoader    = &loader{database.BlobQueryFactory, "data_id", getBlobs, func(v interface{}) *fftypes.UUID { return v.(*core.Blob).DataID }}
+	batchLoader   = &loader{database.BatchQueryFactory, "id", getBatches, func(v interface{}) *fftypes.UUID { return v.(*core.BatchPersisted).ID }}
+	txLoader      = &loader{database.TransactionQueryFactory, "id", getTransactions, func(v interface{}) *fftypes.UUID { return v.(*core.Transaction).ID }}
+	poolLoader    = &loader{database.TokenPoolQueryFactory, "id", getTokenPools, func(v interface{}) *fftypes.UUID { return v.(*core.TokenPool).ID }}
+	idLoader      = &loader{database.IdentityQueryFactory, "id", getIdentities, func(v interface{}) *fftypes.UUID { return v.(*core.Identity).ID }}
+)
+
+func addType(name string, goType interface{}, relations map[string]*relation) {
+	t := &objectType{name: name, relations: relations}
+	if goType != nil {
+		t.fields = jsonFieldNames(reflect.TypeOf(goType))
+	}
+	types[name] = t
+}
+
+func init() {
+	addType(queryTypeName, nil, map[string]*relation{
+		"message":        byID("Message", getMessage),
+		"messages":       list("Message", database.MessageQueryFactory, nil, getMessages),
+		"dataItem":       byID("Data", getDataByID),
+		"data":           list("Data", database.DataQueryFactory, nil, getData),
+		"batch":          byID("Batch", getBatch),
+		"batches":        list("Batch", database.BatchQueryFactory, nil, getBatches),
+		"event":          byID("Event", getEvent),
+		"events":         list("Event", database.EventQueryFactory, nil, getEvents),
+		"transaction":    byID("Transaction", getTransaction),
+		"transactions":   list("Transaction", database.TransactionQueryFactory, nil, getTransactions),
+		"tokenTransfer":  byID("TokenTransfer", getTokenTransfer),
+		"tokenTransfers": list("TokenTransfer", database.TokenTransferQueryFactory, nil, getTokenTransfers),
+		"tokenPool":      byID("TokenPool", getTokenPool),
+		"tokenPools":     list("TokenPool", database.TokenPoolQueryFactory, nil, getTokenPools),
+		"identity":       byID("Identity", getIdentity),
+		"identities":     list("Identity", database.IdentityQueryFactory, nil, getIdentities),
+	})
+
+	addType("Message", core.Message{}, map[string]*relation{
+		"data":        {typeName: "Data", resolve: messageData, list: true, loader: dataLoader, prefetch: messageDataIDs},
+		"batch":       related("Batch", batchLoader, func(p interface{}) *fftypes.UUID { return p.(*core.Message).BatchID }),
+		"transaction": related("Transaction", txLoader, func(p interface{}) *fftypes.UUID { return p.(*core.Message).TransactionID }),
+		"events": list("Event", database.EventQueryFactory, func(fb ffapi.FilterBuilder, p interface{}) ffapi.Filter {
+			return fb.Eq("reference", p.(*core.Message).Header.ID)
+		}, getEvents),
+	})
+	addType("Data", core.Data{}, map[string]*relation{
+		"blob":     {typeName: "Blob", resolve: dataBlob, loader: blobLoader, prefetch: dataBlobID},
+		"messages": {typeName: "Message", resolve: dataMessages, list: true},
+	})
+	addType("Blob", core.Blob{}, nil)
+	addType("Batch", core.BatchPersisted{}, map[string]*relation{
+		"messages": list("Message", database.MessageQueryFactory, func(fb ffapi.FilterBuilder, p interface{}) ffapi.Filter {
+			return fb.Eq("batch", p.(*core.BatchPersisted).ID)
+		}, getMessages),
+		"transaction": related("Transaction", txLoader, func(p interface{}) *fftypes.UUID { return p.(*core.BatchPersisted).TX.ID }),
+	})
+	addType("Event", core.Event{}, map[string]*relation{
+		// Resolves to null for events that do not reference a message
+		"message":     related("Message", messageLoader, func(p interface{}) *fftypes.UUID { return p.(*core.Event).Reference }),
+		"transaction": related("Transaction", txLoader, func(p interface{}) *fftypes.UUID { return p.(*core.Event).Transaction }),
+	})
+	addType("Transaction", core.Transaction{}, map[string]*relation{
+		"operations": list("Operation", database.OperationQueryFactory, func(fb ffapi.FilterBuilder, p interface{}) ffapi.Filter {
+			return fb.Eq("tx", p.(*core.Transaction).ID)
+		}, getOperations),
+		"blockchainEvents": list("BlockchainEvent", database.BlockchainEventQueryFactory, func(fb ffapi.FilterBuilder, p interface{}) ffapi.Filter {
+			return fb.Eq("tx.id", p.(*core.Transaction).ID)
+		}, getBlockchainEvents),
+	})
+	addType("Operation", core.Operation{}, nil)
+	addType("BlockchainEvent", core.BlockchainEvent{}, nil)
+	addType("TokenTransfer", core.TokenTransfer{}, map[string]*relation{
+		"pool":        related("TokenPool", poolLoader, func(p interface{}) *fftypes.UUID { return p.(*core.TokenTransfer).Pool }),
+		"message":     related("Message", messageLoader, func(p interface{}) *fftypes.UUID { return p.(*core.TokenTransfer).Message }),
+		"transaction": related("Transaction", txLoader, func(p interface{}) *fftypes.UUID { return p.(*core.TokenTransfer).TX.ID }),
+	})
+	addType("TokenPool", core.TokenPool{}, map[string]*relation{
+		"transfers": list("TokenTransfer", database.TokenTransferQueryFactory, func(fb ffapi.FilterBuilder, p interface{}) ffapi.Filter {
+			return fb.Eq("pool", p.(*core.TokenPool).ID)
+		}, getTokenTransfers),
+	})
+	addType("Identity", core.Identity{}, map[string]*relation{
+		"parent": related("Identity", idLoader, func(p interface{}) *fftypes.UUID { return p.(*core.Identity).Parent }),
+		"verifiers": list("Verifier", database.VerifierQueryFactory, func(fb ffapi.FilterBuilder, p interface{}) ffapi.Filter {
+			return fb.Eq("identity", p.(*core.Identity).ID)
+		}, getVerifiers),
+	})
+	addType("Verifier", core.Verifier{}, nil)
+}
+
+type getByID func(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error)
+
+type getList func(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error)
+
+// byID is a root field that looks up a single object from its "id" argument
+func byID(typeName string, get getByID) *relation {
+	return &relation{typeName: typeName, resolve: func(ctx context.Context, ex *executor, parent interface{}, args map[string]interface{}) (interface{}, error) {
+		id, err := ex.uuidArg(ctx, args, "id")
+		if err != nil {
+			return nil, err
+		}
+		return get(ex, ctx, id)
+	}}
+}
+
+// related is a single object with an ID held by the parent, which might not be set
+func related(typeName string, l *loader, idOf func(parent interface{}) *fftypes.UUID) *relation {
+	return &relation{
+		typeName: typeName,
+		loader:   l,
+		prefetch: func(parent interface{}) []*fftypes.UUID { return []*fftypes.UUID{idOf(parent)} },
+		resolve: func(ctx context.Context, ex *executor, parent interface{}, args map[string]interface{}) (interface{}, error) {
+			id := idOf(parent)
+			if id == nil {
+				return nil, nil
+			}
+			loaded, err := ex.load(ctx, l, []*fftypes.UUID{id})
+			if err != nil {
+				return nil, err
+			}
+			return loaded[*id], nil
+		},
+	}
+}
+
+// list is a list of objects, filtered by the "where", "limit", "skip" and "sort" arguments.
+// For relations the condition restricts the list to the objects related to the parent.
+func list(typeName string, qf *ffapi.QueryFields, condition func(fb ffapi.FilterBuilder, parent interface{}) ffapi.Filter, get getList) *relation {
+	return &relation{typeName: typeName, list: true, resolve: func(ctx context.Context, ex *executor, parent interface{}, args map[string]interface{}) (interface{}, error) {
+		fb := qf.NewFilter(ctx)
+		var conditions []ffapi.Filter
+		if condition != nil {
+			conditions = append(conditions, condition(fb, parent))
+		}
+		filter, err := ex.listFilter(ctx, fb, conditions, args)
+		if err != nil {
+			return nil, err
+		}
+		return get(ex, ctx, filter)
+	}}
+}
+
+func getMessage(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error) {
+	return ex.database.GetMessageByID(ctx, ex.namespace, id)
+}
+
+func getMessages(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	msgs, _, err := ex.database.GetMessages(ctx, ex.namespace, filter)
+	return msgs, err
+}
+
+func getDataByID(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error) {
+	return ex.database.GetDataByID(ctx, ex.namespace, id, true)
+}
+
+func getData(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	data, _, err := ex.database.GetData(ctx, ex.namespace, filter)
+	return data, err
+}
+
+func getBatch(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error) {
+	return ex.database.GetBatchByID(ctx, ex.namespace, id)
+}
+
+func getBatches(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	batches, _, err := ex.database.GetBatches(ctx, ex.namespace, filter)
+	return batches, err
+}
+
+func getEvent(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error) {
+	return ex.database.GetEventByID(ctx, ex.namespace, id)
+}
+
+func getEvents(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	events, _, err := ex.database.GetEvents(ctx, ex.namespace, filter)
+	return events, err
+}
+
+func getTransaction(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error) {
+	return ex.database.GetTransactionByID(ctx, ex.namespace, id)
+}
+
+func getTransactions(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	txs, _, err := ex.database.GetTransactions(ctx, ex.namespace, filter)
+	return txs, err
+}
+
+func getOperations(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	ops, _, err := ex.database.GetOperations(ctx, ex.namespace, filter)
+	return ops, err
+}
+
+func getBlockchainEvents(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	events, _, err := ex.database.GetBlockchainEvents(ctx, ex.namespace, filter)
+	return events, err
+}
+
+func getTokenTransfer(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error) {
+	return ex.database.GetTokenTransferByID(ctx, ex.namespace, id)
+}
+
+func getTokenTransfers(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	transfers, _, err := ex.database.GetTokenTransfers(ctx, ex.namespace, filter)
+	return transfers, err
+}
+
+func getTokenPool(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error) {
+	return ex.database.GetTokenPoolByID(ctx, ex.namespace, id)
+}
+
+func getTokenPools(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	pools, _, err := ex.database.GetTokenPools(ctx, ex.namespace, filter)
+	return pools, err
+}
+
+func getIdentity(ex *executor, ctx context.Context, id *fftypes.UUID) (interface{}, error) {
+	return ex.database.GetIdentityByID(ctx, ex.namespace, id)
+}
+
+func getIdentities(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	identities, _, err := ex.database.GetIdentities(ctx, ex.namespace, filter)
+	return identities, err
+}
+
+func getVerifiers(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	verifiers, _, err := ex.database.GetVerifiers(ctx, ex.namespace, filter)
+	return verifiers, err
+}
+
+func getBlobs(ex *executor, ctx context.Context, filter ffapi.Filter) (interface{}, error) {
+	blobs, _, err := ex.database.GetBlobs(ctx, ex.namespace, filter)
+	return blobs, err
+}
+
+func messageDataIDs(parent interface{}) []*fftypes.UUID {
+	msg := parent.(*core.Message)
+	ids := make([]*fftypes.UUID, len(msg.Data))
+	for i, ref := range msg.Data {
+		ids[i] = ref.ID
+	}
+	return ids
+}
+
+// messageData returns the data of a message, in the order of the message's data references
+func messageData(ctx context.Context, ex *executor, parent interface{}, args map[string]interface{}) (interface{}, error) {
+	msg := parent.(*core.Message)
+	if len(msg.Data) == 0 {
+		return core.DataArray{}, nil
+	}
+	loaded, err := ex.load(ctx, dataLoader, messageDataIDs(msg))
+	if err != nil {
+		return nil, err
+	}
+	ordered := make(core.DataArray, 0, len(msg.Data))
+	for _, ref := range msg.Data {
+		if d, ok := loaded[*ref.ID].(*core.Data); ok && d != nil {
+			ordered = append(ordered, d)
+		}
+	}
+	return ordered, nil
+}
+
+func dataBlobID(parent interface{}) []*fftypes.UUID {
+	d := parent.(*core.Data)
+	if d.Blob == nil {
+		return nil
+	}
+	return []*fftypes.UUID{d.ID}
+}
+
+func dataBlob(ctx context.Context, ex *executor, parent interface{}, args map[string]interface{}) (interface{}, error) {
+	d := parent.(*core.Data)
+	if d.Blob == nil || d.ID == nil {
+		return nil, nil
+	}
+	loaded, err := ex.load(ctx, blobLoader, []*fftypes.UUID{d.ID})
+	if err != nil {
+		return nil, err
+	}
+	return loaded[*d.ID], nil
+}
+
+func dataMessages(ctx context.Context, ex *executor, parent interface{}, args map[string]interface{}) (interface{}, error) {
+	fb := database.MessageQueryFactory.NewFilter(ctx)
+	filter, err := ex.listFilter(ctx, fb, nil, args)
+	if err != nil {
+		return nil, err
+	}
+	msgs, _, err := ex.database.GetMessagesForData(ctx, ex.namespace, parent.(*core.Data).ID, filter)
+	return msgs, err
+}
+
+// jsonFieldNames returns the names of the fields of a type in its JSON representation
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for n := range jsonFieldNames(embedded) {
+					names[n] = true
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	return names
+}
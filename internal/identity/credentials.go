@@ -0,0 +1,158 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const (
+	credentialsContextV1     = "https://www.w3.org/2018/credentials/v1"
+	credentialTypeVerifiable = "VerifiableCredential"
+	jwsAlgorithmES256        = "ES256"
+	jwsAlgorithmRS256        = "RS256"
+)
+
+// IssueCredential issues a verifiable credential from the root org, as a JWT signed with the payload signing
+// key of the org. Verifiers check the signature against the payload signing keys in the profile of the org.
+func (im *identityManager) IssueCredential(ctx context.Context, req *core.CredentialIssueRequest) (*core.IssuedCredential, error) {
+	if im.payloadPublicKey == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgCredentialIssuanceNotEnabled)
+	}
+	if len(req.CredentialSubject) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgCredentialSubjectMissing)
+	}
+	if im.multiparty == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgLocalOrgNotSet)
+	}
+	issuer, err := im.GetRootOrgDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := im.payloadPublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, ok := credentialSigningAlgorithm(publicKey)
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgCredentialSigningKeyUnsupported)
+	}
+
+	id := "urn:uuid:" + fftypes.NewUUID().String()
+	issued := fftypes.Now()
+	credential := fftypes.JSONObject{
+		"@context":          []string{credentialsContextV1},
+		"id":                id,
+		"type":              append([]string{credentialTypeVerifiable}, req.Type...),
+		"issuer":            issuer,
+		"issuanceDate":      issued,
+		"credentialSubject": req.CredentialSubject,
+	}
+	claims := fftypes.JSONObject{
+		"iss": issuer,
+		"jti": id,
+		"nbf": issued.Time().Unix(),
+		"vc":  credential,
+	}
+	if subject, ok := req.CredentialSubject["id"].(string); ok {
+		claims["sub"] = subject
+	}
+	if req.ExpirationDate != nil {
+		credential["expirationDate"] = req.ExpirationDate
+		claims["exp"] = req.ExpirationDate.Time().Unix()
+	}
+	header := fftypes.JSONObject{
+		"alg": algorithm,
+		"typ": "JWT",
+		"kid": issuer,
+	}
+
+	signingInput := encodeJWTSegment(header) + "." + encodeJWTSegment(claims)
+	hash := fftypes.Bytes32(sha256.Sum256([]byte(signingInput)))
+	signature, err := im.payloadSign(ctx, &hash)
+	if err != nil {
+		return nil, err
+	}
+	jws, ok := jwsSignature(algorithm, signature)
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgCredentialSignatureInvalid)
+	}
+	return &core.IssuedCredential{
+		ID:         id,
+		Issuer:     issuer,
+		Credential: credential,
+		JWT:        signingInput + "." + base64.RawURLEncoding.EncodeToString(jws),
+	}, nil
+}
+
+// credentialSigningAlgorithm returns the JWS algorithm for a payload signing key. Ed25519 keys sign the payload
+// hash rather than the JWS signing input, so cannot produce an EdDSA JWS.
+func credentialSigningAlgorithm(publicKey string) (string, bool) {
+	der, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return "", false
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return "", false
+	}
+	switch pk := key.(type) {
+	case *ecdsa.PublicKey:
+		if pk.Curve == elliptic.P256() {
+			return jwsAlgorithmES256, true
+		}
+	case *rsa.PublicKey:
+		return jwsAlgorithmRS256, true
+	}
+	return "", false
+}
+
+func encodeJWTSegment(v fftypes.JSONObject) string {
+	b, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// jwsSignature converts a payload signature to the encoding used by JWS, which for ES256 is the fixed length
+// r and s values rather than ASN.1
+func jwsSignature(algorithm string, signature *core.PayloadSignature) ([]byte, bool) {
+	b, err := base64.StdEncoding.DecodeString(signature.Signature)
+	if err != nil || algorithm != jwsAlgorithmES256 {
+		return b, err == nil
+	}
+	var sig ecdsaSignature
+	if rest, err := asn1.Unmarshal(b, &sig); err != nil || len(rest) > 0 || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 ||
+		sig.R.BitLen() > 256 || sig.S.BitLen() > 256 {
+		return nil, false
+	}
+	raw := make([]byte, 64)
+	sig.R.FillBytes(raw[:32])
+	sig.S.FillBytes(raw[32:])
+	return raw, true
+}
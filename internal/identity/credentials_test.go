@@ -0,0 +1,228 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/mocks/signingmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCredentialIssuer(t *testing.T, key crypto.PrivateKey) (context.Context, *identityManager) {
+	ctx, im := newTestIdentityManager(t)
+	err := im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+		Enabled: true,
+		KeyFile: writeTestSigningKey(t, key),
+	})
+	assert.NoError(t, err)
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("RootOrg").Return(multiparty.RootOrg{Name: "org1"})
+	return ctx, im
+}
+
+func decodeJWTSegment(t *testing.T, segment string) fftypes.JSONObject {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	assert.NoError(t, err)
+	var v fftypes.JSONObject
+	err = json.Unmarshal(b, &v)
+	assert.NoError(t, err)
+	return v
+}
+
+func TestIssueCredentialES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ctx, im := newTestCredentialIssuer(t, key)
+
+	expiry := fftypes.FFTime(fftypes.Now().Time().AddDate(1, 0, 0))
+	issued, err := im.IssueCredential(ctx, &core.CredentialIssueRequest{
+		Type:              []string{"MembershipCredential"},
+		CredentialSubject: fftypes.JSONObject{"id": "did:example:1234", "member": true},
+		ExpirationDate:    &expiry,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "did:firefly:org/org1", issued.Issuer)
+	assert.True(t, strings.HasPrefix(issued.ID, "urn:uuid:"))
+	assert.Equal(t, []string{"VerifiableCredential", "MembershipCredential"}, issued.Credential["type"])
+
+	segments := strings.Split(issued.JWT, ".")
+	assert.Len(t, segments, 3)
+	header := decodeJWTSegment(t, segments[0])
+	assert.Equal(t, "ES256", header.GetString("alg"))
+	assert.Equal(t, "did:firefly:org/org1", header.GetString("kid"))
+	claims := decodeJWTSegment(t, segments[1])
+	assert.Equal(t, "did:firefly:org/org1", claims.GetString("iss"))
+	assert.Equal(t, "did:example:1234", claims.GetString("sub"))
+	assert.Equal(t, issued.ID, claims.GetString("jti"))
+	assert.Equal(t, expiry.Time().Unix(), claims.GetInt64("exp"))
+	assert.Equal(t, issued.ID, claims.GetObject("vc").GetString("id"))
+
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	assert.NoError(t, err)
+	assert.Len(t, signature, 64)
+	hash := sha256.Sum256([]byte(segments[0] + "." + segments[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	assert.True(t, ecdsa.Verify(&key.PublicKey, hash[:], r, s))
+}
+
+func TestIssueCredentialRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ctx, im := newTestCredentialIssuer(t, key)
+
+	issued, err := im.IssueCredential(ctx, &core.CredentialIssueRequest{
+		CredentialSubject: fftypes.JSONObject{"member": true},
+	})
+	assert.NoError(t, err)
+
+	segments := strings.Split(issued.JWT, ".")
+	assert.Len(t, segments, 3)
+	assert.Equal(t, "RS256", decodeJWTSegment(t, segments[0]).GetString("alg"))
+	claims := decodeJWTSegment(t, segments[1])
+	_, hasSubject := claims["sub"]
+	assert.False(t, hasSubject)
+	_, hasExpiry := claims["exp"]
+	assert.False(t, hasExpiry)
+
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte(segments[0] + "." + segments[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+}
+
+func TestIssueCredentialUnsupportedKey(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+
+	for _, key := range []crypto.PrivateKey{edKey, p384Key} {
+		ctx, im := newTestCredentialIssuer(t, key)
+		_, err = im.IssueCredential(ctx, &core.CredentialIssueRequest{
+			CredentialSubject: fftypes.JSONObject{"member": true},
+		})
+		assert.Regexp(t, "FF10685", err)
+	}
+}
+
+func TestIssueCredentialNotEnabled(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	_, err := im.IssueCredential(ctx, &core.CredentialIssueRequest{
+		CredentialSubject: fftypes.JSONObject{"member": true},
+	})
+	assert.Regexp(t, "FF10687", err)
+}
+
+func TestIssueCredentialSubjectMissing(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ctx, im := newTestCredentialIssuer(t, key)
+
+	_, err = im.IssueCredential(ctx, &core.CredentialIssueRequest{})
+	assert.Regexp(t, "FF10686", err)
+}
+
+func TestIssueCredentialNoRootOrg(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	ctx, im := newTestCredentialIssuer(t, key)
+	im.multiparty = nil
+	_, err = im.IssueCredential(ctx, &core.CredentialIssueRequest{
+		CredentialSubject: fftypes.JSONObject{"member": true},
+	})
+	assert.Regexp(t, "FF10281", err)
+
+	ctx, im = newTestCredentialIssuer(t, key)
+	mmp := &multipartymocks.Manager{}
+	mmp.On("RootOrg").Return(multiparty.RootOrg{})
+	im.multiparty = mmp
+	_, err = im.IssueCredential(ctx, &core.CredentialIssueRequest{
+		CredentialSubject: fftypes.JSONObject{"member": true},
+	})
+	assert.Regexp(t, "FF10281", err)
+}
+
+func TestIssueCredentialKMS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	for _, sign := range []func(digest []byte) ([]byte, error){
+		func(digest []byte) ([]byte, error) { return nil, fmt.Errorf("pop") },
+		func(digest []byte) ([]byte, error) { return []byte("bad"), nil },
+	} {
+		ctx, im := newTestIdentityManager(t)
+		msi := &signingmocks.Plugin{}
+		msi.On("PublicKey", ctx, "org1-key").Return(der, nil).Once()
+		msi.On("Sign", ctx, "org1-key", mock.Anything).Return(func(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+			return sign(digest)
+		})
+		err = im.initPayloadSigning(ctx, msi, PayloadSigningConfig{
+			Enabled: true,
+			KeyID:   "org1-key",
+		})
+		assert.NoError(t, err)
+		mmp := im.multiparty.(*multipartymocks.Manager)
+		mmp.On("RootOrg").Return(multiparty.RootOrg{Name: "org1"})
+
+		_, err = im.IssueCredential(ctx, &core.CredentialIssueRequest{
+			CredentialSubject: fftypes.JSONObject{"member": true},
+		})
+		assert.Regexp(t, "FF10576.*pop|FF10688", err)
+	}
+}
+
+func TestIssueCredentialKMSPublicKeyFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "org1-key").Return(nil, fmt.Errorf("pop"))
+	err := im.initPayloadSigning(ctx, msi, PayloadSigningConfig{
+		Enabled: true,
+		KeyID:   "org1-key",
+	})
+	assert.NoError(t, err)
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("RootOrg").Return(multiparty.RootOrg{Name: "org1"})
+
+	_, err = im.IssueCredential(ctx, &core.CredentialIssueRequest{
+		CredentialSubject: fftypes.JSONObject{"member": true},
+	})
+	assert.EqualError(t, err, "pop")
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/signing"
 )
 
 const (
@@ -56,18 +57,31 @@ type Manager interface {
 	GetRootOrg(ctx context.Context) (org *core.Identity, err error)
 	VerifyIdentityChain(ctx context.Context, identity *core.Identity) (immediateParent *core.Identity, retryable bool, err error)
 	ValidateNodeOwner(ctx context.Context, node *core.Identity, identity *core.Identity) (valid bool, err error)
+	ForgetMissingIdentities()
+
+	SignPayload(ctx context.Context, hash *fftypes.Bytes32) (*core.PayloadSignature, error)
+	SignTypedData(ctx context.Context, req *core.TypedDataSignRequest) (*core.TypedDataSignature, error)
+	IssueCredential(ctx context.Context, req *core.CredentialIssueRequest) (*core.IssuedCredential, error)
+	VerifyPayloadSignature(ctx context.Context, author string, manifest *core.BatchManifest) (retryable bool, err error)
+	VerifyOrgPayloadSignature(ctx context.Context, hash *fftypes.Bytes32, signature *core.PayloadSignature) error
 }
 
 type identityManager struct {
 	database      database.Plugin
 	blockchain    blockchain.Plugin  // optional
 	multiparty    multiparty.Manager // optional
+	signing       signing.Plugin     // optional
 	namespace     string
 	defaultKey    string
 	identityCache cache.CInterface
+	missingCache  *cache.NegativeCache
+
+	payloadSign               payloadSignFn // only when payload signing is enabled
+	payloadPublicKey          payloadPublicKeyFn
+	payloadSignaturesRequired bool
 }
 
-func NewIdentityManager(ctx context.Context, ns, defaultKey string, di database.Plugin, bi blockchain.Plugin, mp multiparty.Manager, cacheManager cache.Manager) (Manager, error) {
+func NewIdentityManager(ctx context.Context, ns, defaultKey string, di database.Plugin, bi blockchain.Plugin, si signing.Plugin, mp multiparty.Manager, cacheManager cache.Manager, payloadSigning PayloadSigningConfig) (Manager, error) {
 	if di == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "IdentityManager")
 	}
@@ -76,6 +90,7 @@ func NewIdentityManager(ctx context.Context, ns, defaultKey string, di database.
 		blockchain: bi,
 		namespace:  ns,
 		multiparty: mp,
+		signing:    si,
 		defaultKey: defaultKey,
 	}
 
@@ -91,6 +106,13 @@ func NewIdentityManager(ctx context.Context, ns, defaultKey string, di database.
 		return nil, err
 	}
 	im.identityCache = identityCache
+	im.missingCache, err = cache.NewNegativeCache(ctx, cacheManager, ns, "identity")
+	if err != nil {
+		return nil, err
+	}
+	if err = im.initPayloadSigning(ctx, si, payloadSigning); err != nil {
+		return nil, err
+	}
 
 	return im, nil
 }
@@ -448,6 +470,10 @@ func (im *identityManager) cachedIdentityLookupByVerifierRef(ctx context.Context
 	if cachedValue := im.identityCache.Get(cacheKey); cachedValue != nil {
 		return cachedValue.(*core.Identity), nil
 	}
+	missing, missingEntry := im.missingCache.IsMissing(cacheKey)
+	if missing {
+		return nil, nil
+	}
 	verifier, err := im.database.GetVerifierByValue(ctx, verifierRef.Type, namespace, verifierRef.Value)
 	if err != nil {
 		return nil, err
@@ -457,6 +483,7 @@ func (im *identityManager) cachedIdentityLookupByVerifierRef(ctx context.Context
 			// This assumes that the system namespace shares a database with this manager's namespace!
 			return im.cachedIdentityLookupByVerifierRef(ctx, core.LegacySystemNamespace, verifierRef)
 		}
+		im.missingCache.SetMissing(missingEntry)
 		return nil, err
 	}
 	identity, err := im.database.GetIdentityByID(ctx, namespace, verifier.Identity)
@@ -483,9 +510,10 @@ func (im *identityManager) cachedIdentityLookup(ctx context.Context, namespace,
 		}
 		log.L(ctx).Debugf("Resolved DID '%s' to identity: %s / %s (err=%v)", didLookupStr, uuidResolved, didResolved, err)
 	}()
+	missing, missingEntry := false, ""
 	if cachedValue := im.identityCache.Get(cacheKey); cachedValue != nil {
 		identity = cachedValue.(*core.Identity)
-	} else {
+	} else if missing, missingEntry = im.missingCache.IsMissing(cacheKey); !missing {
 		if strings.HasPrefix(didLookupStr, core.DIDPrefix) {
 			if !strings.HasPrefix(didLookupStr, core.FireFlyDIDPrefix) {
 				return nil, false, i18n.NewError(ctx, coremsgs.MsgDIDResolverUnknown, didLookupStr)
@@ -517,6 +545,8 @@ func (im *identityManager) cachedIdentityLookup(ctx context.Context, namespace,
 			// For V1 networks, fall back to LegacySystemNamespace for looking up identities
 			// This assumes that the system namespace shares a database with this manager's namespace!
 			return im.cachedIdentityLookup(ctx, core.LegacySystemNamespace, didLookupStr)
+		} else {
+			im.missingCache.SetMissing(missingEntry)
 		}
 	}
 	return identity, false, nil
@@ -540,9 +570,10 @@ func (im *identityManager) CachedIdentityLookupMustExist(ctx context.Context, di
 func (im *identityManager) cachedIdentityLookupByID(ctx context.Context, namespace string, id *fftypes.UUID) (identity *core.Identity, err error) {
 	// Use an LRU cache for the author identity, as it's likely for the same identity to be re-used over and over
 	cacheKey := fmt.Sprintf("ns=%s,id=%s", namespace, id)
+	missing, missingEntry := false, ""
 	if cachedValue := im.identityCache.Get(cacheKey); cachedValue != nil {
 		identity = cachedValue.(*core.Identity)
-	} else {
+	} else if missing, missingEntry = im.missingCache.IsMissing(cacheKey); !missing {
 		identity, err = im.database.GetIdentityByID(ctx, namespace, id)
 		if err != nil {
 			return nil, err
@@ -553,6 +584,7 @@ func (im *identityManager) cachedIdentityLookupByID(ctx context.Context, namespa
 				// This assumes that the system namespace shares a database with this manager's namespace!
 				return im.cachedIdentityLookupByID(ctx, core.LegacySystemNamespace, id)
 			}
+			im.missingCache.SetMissing(missingEntry)
 			return nil, nil
 		}
 		// Cache the result
@@ -565,6 +597,11 @@ func (im *identityManager) CachedIdentityLookupByID(ctx context.Context, id *fft
 	return im.cachedIdentityLookupByID(ctx, im.namespace, id)
 }
 
+// ForgetMissingIdentities discards the remembered lookups that found nothing, as an identity or verifier has been stored
+func (im *identityManager) ForgetMissingIdentities() {
+	im.missingCache.Forget()
+}
+
 // Validate that the given identity or one of its ancestors owns the given node.
 func (im *identityManager) ValidateNodeOwner(ctx context.Context, node *core.Identity, identity *core.Identity) (valid bool, err error) {
 	l := log.L(ctx)
@@ -47,7 +47,7 @@ func newTestIdentityManager(t *testing.T) (context.Context, *identityManager) {
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress).Maybe()
 	ns := "ns1"
-	im, err := NewIdentityManager(ctx, ns, "", mdi, mbi, mmp, cmi)
+	im, err := NewIdentityManager(ctx, ns, "", mdi, mbi, nil, mmp, cmi, PayloadSigningConfig{})
 	assert.NoError(t, err)
 	cmi.AssertCalled(t, "GetCache", cache.NewCacheConfig(
 		ctx,
@@ -60,7 +60,7 @@ func newTestIdentityManager(t *testing.T) (context.Context, *identityManager) {
 }
 
 func TestNewIdentityManagerMissingDeps(t *testing.T) {
-	_, err := NewIdentityManager(context.Background(), "", "", nil, nil, nil, nil)
+	_, err := NewIdentityManager(context.Background(), "", "", nil, nil, nil, nil, nil, PayloadSigningConfig{})
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -83,7 +83,7 @@ func TestCacheInitFail(t *testing.T) {
 		ns,
 	)).Return(nil, cacheInitError).Once()
 	defer iErrcmi.AssertExpectations(t)
-	_, err := NewIdentityManager(ctx, ns, "", mdi, mbi, mmp, iErrcmi)
+	_, err := NewIdentityManager(ctx, ns, "", mdi, mbi, nil, mmp, iErrcmi, PayloadSigningConfig{})
 	assert.Equal(t, cacheInitError, err)
 
 }
@@ -975,6 +975,39 @@ func TestCachedIdentityLookupMustExistCaching(t *testing.T) {
 	assert.Equal(t, id, v2)
 }
 
+func TestCachedIdentityLookupNegativeCaching(t *testing.T) {
+
+	ctx, im := newTestIdentityManager(t)
+
+	idID := fftypes.NewUUID()
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("GetNetworkVersion").Return(2)
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByDID", ctx, "ns1", "did:firefly:node/peer1").Return(nil, nil).Twice()
+	mdi.On("GetIdentityByID", ctx, "ns1", idID).Return(nil, nil).Once()
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		_, _, err := im.CachedIdentityLookupMustExist(ctx, "did:firefly:node/peer1")
+		assert.Regexp(t, "FF10277", err)
+		identity, err := im.CachedIdentityLookupByID(ctx, idID)
+		assert.NoError(t, err)
+		assert.Nil(t, identity)
+		identity, err = im.cachedIdentityLookupByVerifierRef(ctx, "ns1", &core.VerifierRef{
+			Type:  core.VerifierTypeEthAddress,
+			Value: "0x12345",
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, identity)
+	}
+
+	im.ForgetMissingIdentities()
+	_, _, err := im.CachedIdentityLookupMustExist(ctx, "did:firefly:node/peer1")
+	assert.Regexp(t, "FF10277", err)
+
+	mdi.AssertExpectations(t)
+}
+
 func TestCachedIdentityLookupMustExistUnknownResolver(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
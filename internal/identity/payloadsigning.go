@@ -0,0 +1,293 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/signing"
+)
+
+// PayloadSigningConfig configures signing the payload of outbound batches with an org-level key, and
+// verifying the payload signatures of inbound batches
+type PayloadSigningConfig struct {
+	Enabled  bool
+	KeyFile  string // a PEM encoded PKCS #8 private key - if not set, the signing plugin of the namespace is used
+	KeyID    string // the key to sign with in the KMS of the signing plugin
+	Required bool   // inbound batches without a valid payload signature are rejected
+}
+
+type payloadSignFn func(ctx context.Context, hash *fftypes.Bytes32) (*core.PayloadSignature, error)
+
+type payloadPublicKeyFn func(ctx context.Context) (string, error)
+
+type localPayloadSigningKey struct {
+	signer    crypto.Signer
+	opts      crypto.SignerOpts
+	algorithm core.PayloadSignatureAlgorithm
+	publicKey string
+}
+
+// kmsPayloadSigningKey signs with a key held in the KMS of a signing plugin. The public key is fetched
+// on first use, so the KMS does not need to be available for the namespace to start.
+type kmsPayloadSigningKey struct {
+	plugin    signing.Plugin
+	keyID     string
+	keyMux    sync.Mutex
+	algorithm core.PayloadSignatureAlgorithm
+	publicKey string
+}
+
+func (im *identityManager) initPayloadSigning(ctx context.Context, si signing.Plugin, conf PayloadSigningConfig) error {
+	im.payloadSignaturesRequired = conf.Required
+	if !conf.Enabled {
+		return nil
+	}
+	if conf.KeyFile == "" {
+		if si == nil {
+			return i18n.NewError(ctx, coremsgs.MsgPayloadSigningNotConfigured)
+		}
+		key := &kmsPayloadSigningKey{plugin: si, keyID: conf.KeyID}
+		im.payloadSign = key.sign
+		im.payloadPublicKey = key.getPublicKey
+		return nil
+	}
+	key, err := loadPayloadSigningKey(ctx, conf.KeyFile)
+	if err != nil {
+		return err
+	}
+	im.payloadSign = key.sign
+	im.payloadPublicKey = key.getPublicKey
+	return nil
+}
+
+func loadPayloadSigningKey(ctx context.Context, keyFile string) (*localPayloadSigningKey, error) {
+	pemBytes, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgPayloadSigningKeyInvalid, keyFile)
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgPayloadSigningKeyInvalid, keyFile)
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgPayloadSigningKeyInvalid, keyFile)
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgPayloadSigningKeyInvalid, keyFile)
+	}
+	key := &localPayloadSigningKey{signer: signer, opts: crypto.SHA256}
+	if key.algorithm = payloadSignatureAlgorithm(signer.Public()); key.algorithm == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgPayloadSigningKeyInvalid, keyFile)
+	}
+	if key.algorithm == core.PayloadSignatureAlgorithmEd25519 {
+		key.opts = crypto.Hash(0)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgPayloadSigningKeyInvalid, keyFile)
+	}
+	key.publicKey = base64.StdEncoding.EncodeToString(publicKey)
+	return key, nil
+}
+
+// payloadSignatureAlgorithm returns the algorithm used to sign payloads with a key, or an empty string
+// for a key type that is not supported
+func payloadSignatureAlgorithm(publicKey crypto.PublicKey) core.PayloadSignatureAlgorithm {
+	switch publicKey.(type) {
+	case ed25519.PublicKey:
+		return core.PayloadSignatureAlgorithmEd25519
+	case *ecdsa.PublicKey:
+		return core.PayloadSignatureAlgorithmECDSASHA256
+	case *rsa.PublicKey:
+		return core.PayloadSignatureAlgorithmRSASHA256
+	default:
+		return ""
+	}
+}
+
+func (k *localPayloadSigningKey) sign(ctx context.Context, hash *fftypes.Bytes32) (*core.PayloadSignature, error) {
+	signature, err := k.signer.Sign(rand.Reader, hash[:], k.opts)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgPayloadSigningFailed)
+	}
+	return &core.PayloadSignature{
+		Algorithm: k.algorithm,
+		PublicKey: k.publicKey,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+func (k *localPayloadSigningKey) getPublicKey(ctx context.Context) (string, error) {
+	return k.publicKey, nil
+}
+
+func (k *kmsPayloadSigningKey) resolvePublicKey(ctx context.Context) error {
+	k.keyMux.Lock()
+	defer k.keyMux.Unlock()
+	if k.publicKey != "" {
+		return nil
+	}
+	der, err := k.plugin.PublicKey(ctx, k.keyID)
+	if err != nil {
+		return err
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgSigningPublicKeyInvalid, k.keyID)
+	}
+	if k.algorithm = payloadSignatureAlgorithm(publicKey); k.algorithm == "" {
+		return i18n.NewError(ctx, coremsgs.MsgSigningPublicKeyInvalid, k.keyID)
+	}
+	k.publicKey = base64.StdEncoding.EncodeToString(der)
+	return nil
+}
+
+func (k *kmsPayloadSigningKey) getPublicKey(ctx context.Context) (string, error) {
+	if err := k.resolvePublicKey(ctx); err != nil {
+		return "", err
+	}
+	return k.publicKey, nil
+}
+
+func (k *kmsPayloadSigningKey) sign(ctx context.Context, hash *fftypes.Bytes32) (*core.PayloadSignature, error) {
+	if err := k.resolvePublicKey(ctx); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgPayloadSigningFailed)
+	}
+	signature, err := k.plugin.Sign(ctx, k.keyID, hash[:])
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgPayloadSigningFailed)
+	}
+	return &core.PayloadSignature{
+		Algorithm: k.algorithm,
+		PublicKey: k.publicKey,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	}, nil
+}
+
+// SignPayload signs the payload hash of an outbound batch, returning nil if payload signing is not enabled
+func (im *identityManager) SignPayload(ctx context.Context, hash *fftypes.Bytes32) (*core.PayloadSignature, error) {
+	if im.payloadSign == nil {
+		return nil, nil
+	}
+	return im.payloadSign(ctx, hash)
+}
+
+// VerifyPayloadSignature checks the payload signature in the manifest of an inbound batch was made over
+// the payload, using a key that is listed in the profile of the author or one of the orgs above it.
+func (im *identityManager) VerifyPayloadSignature(ctx context.Context, author string, manifest *core.BatchManifest) (retryable bool, err error) {
+	signature := manifest.Signature
+	if signature == nil {
+		if im.payloadSignaturesRequired {
+			return false, i18n.NewError(ctx, coremsgs.MsgPayloadSignatureMissing, manifest.ID)
+		}
+		return false, nil
+	}
+	if !checkPayloadSignature(signature, manifest.PayloadHash()) {
+		return false, i18n.NewError(ctx, coremsgs.MsgPayloadSignatureInvalid, manifest.ID)
+	}
+
+	identity, retryable, err := im.CachedIdentityLookupMustExist(ctx, author)
+	if err != nil {
+		return retryable, err
+	}
+	loopDetect := make(map[fftypes.UUID]bool)
+	for identity != nil && !loopDetect[*identity.ID] {
+		if hasPayloadSigningKey(identity, signature.PublicKey) {
+			return false, nil
+		}
+		loopDetect[*identity.ID] = true
+		if identity.Parent == nil {
+			break
+		}
+		if identity, err = im.CachedIdentityLookupByID(ctx, identity.Parent); err != nil {
+			return true /* DB Error */, err
+		}
+	}
+	return false, i18n.NewError(ctx, coremsgs.MsgPayloadSigningKeyNotRegistered, manifest.ID, author)
+}
+
+// VerifyOrgPayloadSignature checks a signature was made over a hash with the payload signing key of this node.
+// All nodes of an org share the payload signing key, so this establishes that data was exported by another
+// node of the same org, without depending on any identities being registered locally.
+func (im *identityManager) VerifyOrgPayloadSignature(ctx context.Context, hash *fftypes.Bytes32, signature *core.PayloadSignature) error {
+	if im.payloadPublicKey == nil {
+		return i18n.NewError(ctx, coremsgs.MsgSnapshotSigningNotEnabled)
+	}
+	publicKey, err := im.payloadPublicKey(ctx)
+	if err != nil {
+		return err
+	}
+	if signature == nil || signature.PublicKey != publicKey || !checkPayloadSignature(signature, hash) {
+		return i18n.NewError(ctx, coremsgs.MsgSnapshotSignatureInvalid)
+	}
+	return nil
+}
+
+func hasPayloadSigningKey(identity *core.Identity, publicKey string) bool {
+	if _, ok := identity.Profile[core.IdentityProfilePayloadSigningKeys]; !ok {
+		return false
+	}
+	for _, key := range identity.Profile.GetStringArray(core.IdentityProfilePayloadSigningKeys) {
+		if key == publicKey {
+			return true
+		}
+	}
+	return false
+}
+
+func checkPayloadSignature(signature *core.PayloadSignature, hash *fftypes.Bytes32) bool {
+	publicKeyBytes, err := base64.StdEncoding.DecodeString(signature.PublicKey)
+	if err != nil {
+		return false
+	}
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature.Signature)
+	if err != nil {
+		return false
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(publicKeyBytes)
+	if err != nil {
+		return false
+	}
+	switch pk := publicKey.(type) {
+	case ed25519.PublicKey:
+		return signature.Algorithm == core.PayloadSignatureAlgorithmEd25519 && ed25519.Verify(pk, hash[:], signatureBytes)
+	case *ecdsa.PublicKey:
+		return signature.Algorithm == core.PayloadSignatureAlgorithmECDSASHA256 && ecdsa.VerifyASN1(pk, hash[:], signatureBytes)
+	case *rsa.PublicKey:
+		return signature.Algorithm == core.PayloadSignatureAlgorithmRSASHA256 && rsa.VerifyPKCS1v15(pk, crypto.SHA256, hash[:], signatureBytes) == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,466 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/signingmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func writeTestSigningKey(t *testing.T, key crypto.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+	assert.NoError(t, err)
+	return keyFile
+}
+
+func newTestSignedManifest(t *testing.T, ctx context.Context, im *identityManager) *core.BatchManifest {
+	manifest := &core.BatchManifest{
+		Version: core.ManifestVersion1,
+		ID:      fftypes.NewUUID(),
+		Messages: []*core.MessageManifestEntry{
+			{MessageRef: core.MessageRef{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()}},
+		},
+	}
+	var err error
+	manifest.Signature, err = im.SignPayload(ctx, manifest.PayloadHash())
+	assert.NoError(t, err)
+	return manifest
+}
+
+func TestPayloadSigningDisabled(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	err := im.initPayloadSigning(ctx, nil, PayloadSigningConfig{})
+	assert.NoError(t, err)
+
+	signature, err := im.SignPayload(ctx, fftypes.NewRandB32())
+	assert.NoError(t, err)
+	assert.Nil(t, signature)
+}
+
+func TestPayloadSigningKeyTypes(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	for algorithm, key := range map[core.PayloadSignatureAlgorithm]crypto.PrivateKey{
+		core.PayloadSignatureAlgorithmEd25519:     edKey,
+		core.PayloadSignatureAlgorithmECDSASHA256: ecKey,
+		core.PayloadSignatureAlgorithmRSASHA256:   rsaKey,
+	} {
+		ctx, im := newTestIdentityManager(t)
+		err := im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+			Enabled: true,
+			KeyFile: writeTestSigningKey(t, key),
+		})
+		assert.NoError(t, err)
+
+		manifest := newTestSignedManifest(t, ctx, im)
+		assert.Equal(t, algorithm, manifest.Signature.Algorithm)
+		assert.True(t, checkPayloadSignature(manifest.Signature, manifest.PayloadHash()))
+		assert.False(t, checkPayloadSignature(manifest.Signature, fftypes.NewRandB32()))
+	}
+}
+
+func TestPayloadSigningKMS(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "org1-key").Return(der, nil).Once()
+	msi.On("Sign", ctx, "org1-key", mock.Anything).Return(func(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+		return ecdsa.SignASN1(rand.Reader, key, digest)
+	})
+	err = im.initPayloadSigning(ctx, msi, PayloadSigningConfig{
+		Enabled: true,
+		KeyID:   "org1-key",
+	})
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		manifest := newTestSignedManifest(t, ctx, im)
+		assert.Equal(t, core.PayloadSignatureAlgorithmECDSASHA256, manifest.Signature.Algorithm)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(der), manifest.Signature.PublicKey)
+		assert.True(t, checkPayloadSignature(manifest.Signature, manifest.PayloadHash()))
+	}
+
+	msi.AssertExpectations(t)
+}
+
+func TestPayloadSigningKMSSignFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	assert.NoError(t, err)
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "org1-key").Return(der, nil)
+	msi.On("Sign", ctx, "org1-key", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	err = im.initPayloadSigning(ctx, msi, PayloadSigningConfig{
+		Enabled: true,
+		KeyID:   "org1-key",
+	})
+	assert.NoError(t, err)
+
+	_, err = im.SignPayload(ctx, fftypes.NewRandB32())
+	assert.Regexp(t, "FF10576.*pop", err)
+
+	msi.AssertExpectations(t)
+}
+
+func TestPayloadSigningKMSPublicKeyFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "org1-key").Return(nil, fmt.Errorf("pop"))
+	err := im.initPayloadSigning(ctx, msi, PayloadSigningConfig{
+		Enabled: true,
+		KeyID:   "org1-key",
+	})
+	assert.NoError(t, err)
+
+	_, err = im.SignPayload(ctx, fftypes.NewRandB32())
+	assert.Regexp(t, "FF10576.*pop", err)
+
+	msi.AssertExpectations(t)
+}
+
+func TestPayloadSigningKMSPublicKeyInvalid(t *testing.T) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.PublicKey())
+	assert.NoError(t, err)
+
+	for _, publicKey := range [][]byte{[]byte("bad"), der} {
+		ctx, im := newTestIdentityManager(t)
+		msi := &signingmocks.Plugin{}
+		msi.On("PublicKey", ctx, "org1-key").Return(publicKey, nil)
+		err = im.initPayloadSigning(ctx, msi, PayloadSigningConfig{
+			Enabled: true,
+			KeyID:   "org1-key",
+		})
+		assert.NoError(t, err)
+
+		_, err = im.SignPayload(ctx, fftypes.NewRandB32())
+		assert.Regexp(t, "FF10582", err)
+	}
+}
+
+func TestPayloadSigningNotConfigured(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	err := im.initPayloadSigning(ctx, nil, PayloadSigningConfig{Enabled: true})
+	assert.Regexp(t, "FF10574", err)
+}
+
+func TestPayloadSigningKeyFileMissing(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	err := im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+		Enabled: true,
+		KeyFile: filepath.Join(t.TempDir(), "missing.pem"),
+	})
+	assert.Regexp(t, "FF10575", err)
+}
+
+func TestPayloadSigningKeyFileNotPEM(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	err := os.WriteFile(keyFile, []byte("not a key"), 0600)
+	assert.NoError(t, err)
+
+	_, err = loadPayloadSigningKey(context.Background(), keyFile)
+	assert.Regexp(t, "FF10575", err)
+}
+
+func TestPayloadSigningKeyFileNotPKCS8(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("bad")}), 0600)
+	assert.NoError(t, err)
+
+	_, err = loadPayloadSigningKey(context.Background(), keyFile)
+	assert.Regexp(t, "FF10575", err)
+}
+
+func TestPayloadSigningKeyFileUnsupported(t *testing.T) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	_, err = loadPayloadSigningKey(context.Background(), writeTestSigningKey(t, key))
+	assert.Regexp(t, "FF10575", err)
+}
+
+func TestVerifyPayloadSignatureParentOrg(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ctx, im := newTestIdentityManager(t)
+	err = im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+		Enabled: true,
+		KeyFile: writeTestSigningKey(t, key),
+	})
+	assert.NoError(t, err)
+	manifest := newTestSignedManifest(t, ctx, im)
+
+	org := &core.Identity{
+		IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:org/org1"},
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{
+				core.IdentityProfilePayloadSigningKeys: []interface{}{"otherkey", manifest.Signature.PublicKey},
+			},
+		},
+	}
+	custom := &core.Identity{
+		IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:ns/ns1/custom1", Parent: org.ID},
+	}
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByDID", ctx, "ns1", custom.DID).Return(custom, nil)
+	mdi.On("GetIdentityByID", ctx, "ns1", org.ID).Return(org, nil)
+
+	retryable, err := im.VerifyPayloadSignature(ctx, custom.DID, manifest)
+	assert.NoError(t, err)
+	assert.False(t, retryable)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyPayloadSignatureKeyNotRegistered(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ctx, im := newTestIdentityManager(t)
+	err = im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+		Enabled: true,
+		KeyFile: writeTestSigningKey(t, key),
+	})
+	assert.NoError(t, err)
+	manifest := newTestSignedManifest(t, ctx, im)
+
+	org := &core.Identity{
+		IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:org/org1"},
+	}
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByDID", ctx, "ns1", org.DID).Return(org, nil)
+
+	retryable, err := im.VerifyPayloadSignature(ctx, org.DID, manifest)
+	assert.Regexp(t, "FF10579", err)
+	assert.False(t, retryable)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyPayloadSignatureParentLookupFail(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ctx, im := newTestIdentityManager(t)
+	err = im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+		Enabled: true,
+		KeyFile: writeTestSigningKey(t, key),
+	})
+	assert.NoError(t, err)
+	manifest := newTestSignedManifest(t, ctx, im)
+
+	custom := &core.Identity{
+		IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:ns/ns1/custom1", Parent: fftypes.NewUUID()},
+	}
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByDID", ctx, "ns1", custom.DID).Return(custom, nil)
+	mdi.On("GetIdentityByID", ctx, "ns1", custom.Parent).Return(nil, fmt.Errorf("pop"))
+
+	retryable, err := im.VerifyPayloadSignature(ctx, custom.DID, manifest)
+	assert.Regexp(t, "pop", err)
+	assert.True(t, retryable)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyPayloadSignatureAuthorLookupFail(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ctx, im := newTestIdentityManager(t)
+	err = im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+		Enabled: true,
+		KeyFile: writeTestSigningKey(t, key),
+	})
+	assert.NoError(t, err)
+	manifest := newTestSignedManifest(t, ctx, im)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByDID", ctx, "ns1", "did:firefly:org/org1").Return(nil, fmt.Errorf("pop"))
+
+	retryable, err := im.VerifyPayloadSignature(ctx, "did:firefly:org/org1", manifest)
+	assert.Regexp(t, "pop", err)
+	assert.True(t, retryable)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestVerifyPayloadSignatureTampered(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	ctx, im := newTestIdentityManager(t)
+	err = im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+		Enabled: true,
+		KeyFile: writeTestSigningKey(t, key),
+	})
+	assert.NoError(t, err)
+	manifest := newTestSignedManifest(t, ctx, im)
+	manifest.Messages[0].Hash = fftypes.NewRandB32()
+
+	retryable, err := im.VerifyPayloadSignature(ctx, "did:firefly:org/org1", manifest)
+	assert.Regexp(t, "FF10578", err)
+	assert.False(t, retryable)
+}
+
+func TestVerifyPayloadSignatureMissing(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+	manifest := &core.BatchManifest{ID: fftypes.NewUUID()}
+
+	retryable, err := im.VerifyPayloadSignature(ctx, "did:firefly:org/org1", manifest)
+	assert.NoError(t, err)
+	assert.False(t, retryable)
+
+	err = im.initPayloadSigning(ctx, nil, PayloadSigningConfig{Required: true})
+	assert.NoError(t, err)
+	retryable, err = im.VerifyPayloadSignature(ctx, "did:firefly:org/org1", manifest)
+	assert.Regexp(t, "FF10577", err)
+	assert.False(t, retryable)
+}
+
+func TestVerifyOrgPayloadSignature(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	err = im.initPayloadSigning(ctx, nil, PayloadSigningConfig{
+		Enabled: true,
+		KeyFile: writeTestSigningKey(t, key),
+	})
+	assert.NoError(t, err)
+
+	hash := fftypes.NewRandB32()
+	signature, err := im.SignPayload(ctx, hash)
+	assert.NoError(t, err)
+	err = im.VerifyOrgPayloadSignature(ctx, hash, signature)
+	assert.NoError(t, err)
+
+	err = im.VerifyOrgPayloadSignature(ctx, fftypes.NewRandB32(), signature)
+	assert.Regexp(t, "FF10602", err)
+	err = im.VerifyOrgPayloadSignature(ctx, hash, nil)
+	assert.Regexp(t, "FF10602", err)
+
+	// A valid signature with a key from another org
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	other, err := loadPayloadSigningKey(ctx, writeTestSigningKey(t, otherKey))
+	assert.NoError(t, err)
+	signature, err = other.sign(ctx, hash)
+	assert.NoError(t, err)
+	err = im.VerifyOrgPayloadSignature(ctx, hash, signature)
+	assert.Regexp(t, "FF10602", err)
+}
+
+func TestVerifyOrgPayloadSignatureKMS(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "org1-key").Return(der, nil).Once()
+	msi.On("Sign", ctx, "org1-key", mock.Anything).Return(func(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+		return ecdsa.SignASN1(rand.Reader, key, digest)
+	})
+	err = im.initPayloadSigning(ctx, msi, PayloadSigningConfig{
+		Enabled: true,
+		KeyID:   "org1-key",
+	})
+	assert.NoError(t, err)
+
+	hash := fftypes.NewRandB32()
+	signature, err := im.SignPayload(ctx, hash)
+	assert.NoError(t, err)
+	err = im.VerifyOrgPayloadSignature(ctx, hash, signature)
+	assert.NoError(t, err)
+
+	msi.AssertExpectations(t)
+}
+
+func TestVerifyOrgPayloadSignatureKMSPublicKeyFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "org1-key").Return(nil, fmt.Errorf("pop"))
+	err := im.initPayloadSigning(ctx, msi, PayloadSigningConfig{
+		Enabled: true,
+		KeyID:   "org1-key",
+	})
+	assert.NoError(t, err)
+
+	err = im.VerifyOrgPayloadSignature(ctx, fftypes.NewRandB32(), &core.PayloadSignature{})
+	assert.EqualError(t, err, "pop")
+
+	msi.AssertExpectations(t)
+}
+
+func TestVerifyOrgPayloadSignatureNotEnabled(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	err := im.VerifyOrgPayloadSignature(ctx, fftypes.NewRandB32(), &core.PayloadSignature{})
+	assert.Regexp(t, "FF10600", err)
+}
+
+func TestCheckPayloadSignatureBadEncoding(t *testing.T) {
+	hash := fftypes.NewRandB32()
+	assert.False(t, checkPayloadSignature(&core.PayloadSignature{PublicKey: "!!"}, hash))
+	assert.False(t, checkPayloadSignature(&core.PayloadSignature{PublicKey: "AA==", Signature: "!!"}, hash))
+	assert.False(t, checkPayloadSignature(&core.PayloadSignature{PublicKey: "AA==", Signature: "AA=="}, hash))
+
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.PublicKey())
+	assert.NoError(t, err)
+	assert.False(t, checkPayloadSignature(&core.PayloadSignature{
+		PublicKey: base64.StdEncoding.EncodeToString(der),
+		Signature: "AA==",
+	}, hash))
+}
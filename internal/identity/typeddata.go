@@ -0,0 +1,142 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-signer/pkg/eip712"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	oidPublicKeyECDSA      = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// ecdsaSignature is the ASN.1 structure of an ECDSA signature
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// SignTypedData signs EIP-712 typed data with a secp256k1 key in the KMS of the signing plugin, returning
+// a signature that recovers to the Ethereum address of the key
+func (im *identityManager) SignTypedData(ctx context.Context, req *core.TypedDataSignRequest) (*core.TypedDataSignature, error) {
+	if im.signing == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSigningPluginNotConfigured)
+	}
+	var typedData eip712.TypedData
+	if req.TypedData == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgTypedDataInvalid)
+	}
+	if err := req.TypedData.Unmarshal(ctx, &typedData); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgTypedDataInvalid)
+	}
+	hash, err := eip712.EncodeTypedDataV4(ctx, &typedData)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgTypedDataInvalid)
+	}
+
+	der, err := im.signing.PublicKey(ctx, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := parseSecp256k1PublicKey(der)
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgTypedDataKeyNotSecp256k1, req.KeyID)
+	}
+	signature, err := im.signing.Sign(ctx, req.KeyID, hash)
+	if err != nil {
+		return nil, err
+	}
+	rsv, ok := recoverableSignature(publicKey, hash, signature)
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgTypedDataSignatureInvalid, req.KeyID)
+	}
+	return &core.TypedDataSignature{
+		KeyID:     req.KeyID,
+		Address:   ethAddress(publicKey).String(),
+		Hash:      hash.String(),
+		Signature: ethtypes.HexBytes0xPrefix(rsv).String(),
+	}, nil
+}
+
+// parseSecp256k1PublicKey parses a PKIX public key on the secp256k1 curve, which the x509 package does not support
+func parseSecp256k1PublicKey(der []byte) (*secp256k1.PublicKey, bool) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if rest, err := asn1.Unmarshal(der, &spki); err != nil || len(rest) > 0 || !spki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, false
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &curve); err != nil || !curve.Equal(oidNamedCurveSecp256k1) {
+		return nil, false
+	}
+	publicKey, err := secp256k1.ParsePubKey(spki.PublicKey.RightAlign())
+	if err != nil {
+		return nil, false
+	}
+	return publicKey, true
+}
+
+func ethAddress(publicKey *secp256k1.PublicKey) ethtypes.Address0xHex {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(publicKey.SerializeUncompressed()[1:])
+	var address ethtypes.Address0xHex
+	copy(address[:], hash.Sum(nil)[12:])
+	return address
+}
+
+// recoverableSignature converts an ASN.1 ECDSA signature from the KMS into the 65 byte r, s, v form used by
+// Ethereum, which includes the recovery ID that the KMS does not return
+func recoverableSignature(publicKey *secp256k1.PublicKey, hash, der []byte) ([]byte, bool) {
+	var sig ecdsaSignature
+	if rest, err := asn1.Unmarshal(der, &sig); err != nil || len(rest) > 0 || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, false
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(sig.R.Bytes()) || s.SetByteSlice(sig.S.Bytes()) {
+		return nil, false
+	}
+	// Ethereum only accepts signatures with a low S value, which a KMS does not guarantee
+	if s.IsOverHalfOrder() {
+		s.Negate()
+	}
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	compact := make([]byte, 65)
+	copy(compact[1:33], rBytes[:])
+	copy(compact[33:], sBytes[:])
+	for recoveryID := byte(0); recoveryID < 2; recoveryID++ {
+		compact[0] = 27 + recoveryID
+		recovered, _, err := secp256k1ecdsa.RecoverCompact(compact, hash)
+		if err == nil && recovered.IsEqual(publicKey) {
+			return append(compact[1:], 27+recoveryID), true
+		}
+	}
+	return nil, false
+}
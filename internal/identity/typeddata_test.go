@@ -0,0 +1,214 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/signingmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/sha3"
+)
+
+// emptyTypedData is the EIP-712 typed data with an empty domain, and its hash
+const emptyTypedData = `{"types":{},"primaryType":"EIP712Domain"}`
+const emptyTypedDataHash = "0x8d4a3f4082945b7879e2b55f181c31a77c8c0a464b70669458abbaaf99de4c38"
+
+func marshalSecp256k1PublicKey(t *testing.T, publicKey *secp256k1.PublicKey) []byte {
+	curve, err := asn1.Marshal(oidNamedCurveSecp256k1)
+	assert.NoError(t, err)
+	point := publicKey.SerializeUncompressed()
+	der, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: curve}},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+	assert.NoError(t, err)
+	return der
+}
+
+// highSSignature signs a digest, returning the ASN.1 signature with the S value a KMS might return that
+// Ethereum would reject
+func highSSignature(t *testing.T, key *secp256k1.PrivateKey, digest []byte) []byte {
+	var sig ecdsaSignature
+	_, err := asn1.Unmarshal(secp256k1ecdsa.Sign(key, digest).Serialize(), &sig)
+	assert.NoError(t, err)
+	sig.S = new(big.Int).Sub(secp256k1.S256().N, sig.S)
+	der, err := asn1.Marshal(sig)
+	assert.NoError(t, err)
+	return der
+}
+
+func newTestTypedDataSigner(t *testing.T, sign func(key *secp256k1.PrivateKey, digest []byte) []byte) (context.Context, *identityManager, *secp256k1.PrivateKey) {
+	ctx, im := newTestIdentityManager(t)
+	key, err := secp256k1.GeneratePrivateKey()
+	assert.NoError(t, err)
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "eth-key").Return(marshalSecp256k1PublicKey(t, key.PubKey()), nil)
+	msi.On("Sign", ctx, "eth-key", mock.Anything).Return(func(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+		return sign(key, digest), nil
+	}).Maybe()
+	im.signing = msi
+	return ctx, im, key
+}
+
+func TestSignTypedData(t *testing.T) {
+	for _, sign := range []func(key *secp256k1.PrivateKey, digest []byte) []byte{
+		func(key *secp256k1.PrivateKey, digest []byte) []byte {
+			return secp256k1ecdsa.Sign(key, digest).Serialize()
+		},
+		func(key *secp256k1.PrivateKey, digest []byte) []byte { return highSSignature(t, key, digest) },
+	} {
+		ctx, im, key := newTestTypedDataSigner(t, sign)
+
+		result, err := im.SignTypedData(ctx, &core.TypedDataSignRequest{
+			KeyID:     "eth-key",
+			TypedData: fftypes.JSONAnyPtr(emptyTypedData),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "eth-key", result.KeyID)
+		assert.Equal(t, emptyTypedDataHash, result.Hash)
+		assert.Equal(t, ethAddress(key.PubKey()).String(), result.Address)
+
+		rsv, err := hex.DecodeString(result.Signature[2:])
+		assert.NoError(t, err)
+		assert.Len(t, rsv, 65)
+		hash, err := hex.DecodeString(emptyTypedDataHash[2:])
+		assert.NoError(t, err)
+		recovered, _, err := secp256k1ecdsa.RecoverCompact(append([]byte{rsv[64]}, rsv[:64]...), hash)
+		assert.NoError(t, err)
+		assert.True(t, recovered.IsEqual(key.PubKey()))
+	}
+}
+
+func TestEthAddress(t *testing.T) {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte("cow"))
+	key := secp256k1.PrivKeyFromBytes(hash.Sum(nil))
+	assert.Equal(t, "0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826", ethAddress(key.PubKey()).String())
+}
+
+func TestSignTypedDataNoSigningPlugin(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	_, err := im.SignTypedData(ctx, &core.TypedDataSignRequest{
+		KeyID:     "eth-key",
+		TypedData: fftypes.JSONAnyPtr(emptyTypedData),
+	})
+	assert.Regexp(t, "FF10681", err)
+}
+
+func TestSignTypedDataInvalid(t *testing.T) {
+	ctx, im, _ := newTestTypedDataSigner(t, nil)
+
+	for _, typedData := range []*fftypes.JSONAny{
+		nil,
+		fftypes.JSONAnyPtr(`[]`),
+		fftypes.JSONAnyPtr(`{"types":{},"primaryType":"Missing"}`),
+	} {
+		_, err := im.SignTypedData(ctx, &core.TypedDataSignRequest{
+			KeyID:     "eth-key",
+			TypedData: typedData,
+		})
+		assert.Regexp(t, "FF10683", err)
+	}
+}
+
+func TestSignTypedDataPublicKeyFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "eth-key").Return(nil, fmt.Errorf("pop"))
+	im.signing = msi
+
+	_, err := im.SignTypedData(ctx, &core.TypedDataSignRequest{
+		KeyID:     "eth-key",
+		TypedData: fftypes.JSONAnyPtr(emptyTypedData),
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSignTypedDataNotSecp256k1(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	for _, publicKey := range [][]byte{[]byte("bad"), der} {
+		ctx, im := newTestIdentityManager(t)
+		msi := &signingmocks.Plugin{}
+		msi.On("PublicKey", ctx, "eth-key").Return(publicKey, nil)
+		im.signing = msi
+
+		_, err := im.SignTypedData(ctx, &core.TypedDataSignRequest{
+			KeyID:     "eth-key",
+			TypedData: fftypes.JSONAnyPtr(emptyTypedData),
+		})
+		assert.Regexp(t, "FF10682", err)
+	}
+}
+
+func TestSignTypedDataSignFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+	key, err := secp256k1.GeneratePrivateKey()
+	assert.NoError(t, err)
+	msi := &signingmocks.Plugin{}
+	msi.On("PublicKey", ctx, "eth-key").Return(marshalSecp256k1PublicKey(t, key.PubKey()), nil)
+	msi.On("Sign", ctx, "eth-key", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	im.signing = msi
+
+	_, err = im.SignTypedData(ctx, &core.TypedDataSignRequest{
+		KeyID:     "eth-key",
+		TypedData: fftypes.JSONAnyPtr(emptyTypedData),
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSignTypedDataWrongKey(t *testing.T) {
+	otherKey, err := secp256k1.GeneratePrivateKey()
+	assert.NoError(t, err)
+	for _, sign := range []func(key *secp256k1.PrivateKey, digest []byte) []byte{
+		func(key *secp256k1.PrivateKey, digest []byte) []byte { return []byte("bad") },
+		func(key *secp256k1.PrivateKey, digest []byte) []byte {
+			return secp256k1ecdsa.Sign(otherKey, digest).Serialize()
+		},
+	} {
+		ctx, im, _ := newTestTypedDataSigner(t, sign)
+
+		_, err := im.SignTypedData(ctx, &core.TypedDataSignRequest{
+			KeyID:     "eth-key",
+			TypedData: fftypes.JSONAnyPtr(emptyTypedData),
+		})
+		assert.Regexp(t, "FF10684", err)
+	}
+}
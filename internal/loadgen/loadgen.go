@@ -0,0 +1,344 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loadgen sends synthetic broadcast messages, private messages and token transfers through the
+// managers of a sandbox namespace at fixed rates, and measures the time each takes to be confirmed.
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/assets"
+	"github.com/hyperledger/firefly/internal/broadcast"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/privatemessaging"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const (
+	defaultConcurrency = 50
+	defaultPayloadSize = 128
+	loadTestTag        = "loadtest"
+)
+
+// Generator runs one load test at a time in a namespace. The status of the latest load test is held in
+// memory only, so a restart of the namespace clears it.
+type Generator interface {
+	Start(ctx context.Context, spec *core.LoadTestSpec) (*core.LoadTestStatus, error)
+	Stop(ctx context.Context) (*core.LoadTestStatus, error)
+	Status(ctx context.Context) (*core.LoadTestStatus, error)
+	WaitStop()
+}
+
+type sender struct {
+	result    core.LoadTestResult
+	rate      float64
+	latencies []time.Duration
+	send      func(ctx context.Context, seq int64) error
+}
+
+type loadTestRun struct {
+	status      core.LoadTestStatus
+	concurrency int
+	senders     []*sender
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+type generator struct {
+	ctx       context.Context
+	namespace string
+	broadcast broadcast.Manager
+	messaging privatemessaging.Manager
+	assets    assets.Manager
+	statusMux sync.Mutex
+	run       *loadTestRun
+}
+
+// NewGenerator returns a generator for the managers available in the namespace. The broadcast and private
+// messaging managers are only available in multiparty mode, and are nil otherwise.
+func NewGenerator(ctx context.Context, ns string, bm broadcast.Manager, pm privatemessaging.Manager, am assets.Manager) Generator {
+	return &generator{
+		ctx:       ctx,
+		namespace: ns,
+		broadcast: bm,
+		messaging: pm,
+		assets:    am,
+	}
+}
+
+func (g *generator) validate(ctx context.Context, spec *core.LoadTestSpec) error {
+	var reason string
+	switch {
+	case spec.Duration == nil || *spec.Duration <= 0:
+		reason = "duration must be greater than 0"
+	case spec.Concurrency < 0 || spec.PayloadSize < 0:
+		reason = "concurrency and payloadSize must not be negative"
+	case spec.BroadcastRate < 0 || spec.PrivateRate < 0 || spec.TransferRate < 0:
+		reason = "rates must not be negative"
+	case spec.BroadcastRate == 0 && spec.PrivateRate == 0 && spec.TransferRate == 0:
+		reason = "at least one of broadcastRate, privateRate or transferRate must be set"
+	case (spec.BroadcastRate > 0 && g.broadcast == nil) || (spec.PrivateRate > 0 && g.messaging == nil):
+		reason = "messages can only be sent in a multiparty namespace"
+	case spec.PrivateRate > 0 && len(spec.PrivateMembers) == 0:
+		reason = "privateMembers must be set to send private messages"
+	case spec.TransferRate > 0 && (spec.TransferPool == "" || spec.TransferTo == ""):
+		reason = "transferPool and transferTo must be set to send token transfers"
+	case spec.TransferAmount != nil && spec.TransferAmount.Int().Sign() <= 0:
+		reason = "transferAmount must be greater than 0"
+	default:
+		return nil
+	}
+	return i18n.NewError(ctx, coremsgs.MsgLoadTestInvalid, reason)
+}
+
+// Start begins a load test in the background, returning its initial status
+func (g *generator) Start(ctx context.Context, spec *core.LoadTestSpec) (*core.LoadTestStatus, error) {
+	if err := g.validate(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	g.statusMux.Lock()
+	defer g.statusMux.Unlock()
+	if g.run != nil && g.run.status.Running {
+		return nil, i18n.NewError(ctx, coremsgs.MsgLoadTestRunning, g.run.status.ID)
+	}
+
+	run := &loadTestRun{
+		status: core.LoadTestStatus{
+			ID:      fftypes.NewUUID(),
+			Spec:    spec,
+			Running: true,
+			Started: fftypes.Now(),
+		},
+		concurrency: spec.Concurrency,
+		done:        make(chan struct{}),
+	}
+	if run.concurrency == 0 {
+		run.concurrency = defaultConcurrency
+	}
+	payloadSize := spec.PayloadSize
+	if payloadSize == 0 {
+		payloadSize = defaultPayloadSize
+	}
+	padding := strings.Repeat("x", payloadSize)
+	if spec.BroadcastRate > 0 {
+		run.senders = append(run.senders, g.newSender(core.LoadTestTypeBroadcast, spec.BroadcastRate, func(ctx context.Context, seq int64) error {
+			_, err := g.broadcast.BroadcastMessage(ctx, newMessage(run.status.ID, seq, padding), true)
+			return err
+		}))
+	}
+	if spec.PrivateRate > 0 {
+		members := make([]core.MemberInput, len(spec.PrivateMembers))
+		for i, member := range spec.PrivateMembers {
+			members[i] = core.MemberInput{Identity: member}
+		}
+		run.senders = append(run.senders, g.newSender(core.LoadTestTypePrivate, spec.PrivateRate, func(ctx context.Context, seq int64) error {
+			msg := newMessage(run.status.ID, seq, padding)
+			msg.Group = &core.InputGroup{Members: members}
+			_, err := g.messaging.SendMessage(ctx, msg, true)
+			return err
+		}))
+	}
+	if spec.TransferRate > 0 {
+		amount := spec.TransferAmount
+		if amount == nil {
+			amount = fftypes.NewFFBigInt(1)
+		}
+		run.senders = append(run.senders, g.newSender(core.LoadTestTypeTransfer, spec.TransferRate, func(ctx context.Context, seq int64) error {
+			transfer := &core.TokenTransferInput{
+				TokenTransfer: core.TokenTransfer{To: spec.TransferTo, Amount: *amount},
+				Pool:          spec.TransferPool,
+			}
+			_, err := g.assets.TransferTokens(ctx, transfer, true)
+			return err
+		}))
+	}
+
+	// Requests still in flight when the duration elapses are waited for, unless the test is stopped
+	sendCtx, cancel := context.WithCancel(g.ctx)
+	run.cancel = cancel
+	g.run = run
+	log.L(ctx).Infof("Starting load test %s in namespace '%s' for %s", run.status.ID, g.namespace, spec.Duration)
+	go g.runLoop(run, sendCtx, time.Duration(*spec.Duration))
+
+	return g.statusLocked(run), nil
+}
+
+// Stop cancels the running load test, including the requests waiting for confirmation
+func (g *generator) Stop(ctx context.Context) (*core.LoadTestStatus, error) {
+	g.statusMux.Lock()
+	run := g.run
+	g.statusMux.Unlock()
+	if run == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgLoadTestNotFound)
+	}
+	run.cancel()
+	<-run.done
+	return g.Status(ctx)
+}
+
+func (g *generator) Status(ctx context.Context) (*core.LoadTestStatus, error) {
+	g.statusMux.Lock()
+	defer g.statusMux.Unlock()
+	if g.run == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgLoadTestNotFound)
+	}
+	return g.statusLocked(g.run), nil
+}
+
+func (g *generator) WaitStop() {
+	g.statusMux.Lock()
+	run := g.run
+	g.statusMux.Unlock()
+	if run != nil {
+		<-run.done
+	}
+}
+
+func (g *generator) newSender(loadTestType core.LoadTestType, rate float64, send func(ctx context.Context, seq int64) error) *sender {
+	return &sender{
+		result: core.LoadTestResult{Type: loadTestType},
+		rate:   rate,
+		send:   send,
+	}
+}
+
+func newMessage(id *fftypes.UUID, seq int64, padding string) *core.MessageInOut {
+	return &core.MessageInOut{
+		Message: core.Message{
+			Header: core.MessageHeader{
+				Tag:    loadTestTag,
+				Topics: fftypes.FFStringArray{loadTestTag},
+			},
+		},
+		InlineData: core.InlineData{
+			{Value: fftypes.JSONAnyPtr(fmt.Sprintf(`{"loadTest":"%s","seq":%d,"payload":"%s"}`, id, seq, padding))},
+		},
+	}
+}
+
+func (g *generator) runLoop(run *loadTestRun, sendCtx context.Context, duration time.Duration) {
+	defer close(run.done)
+	defer run.cancel()
+
+	genCtx, cancelGen := context.WithTimeout(sendCtx, duration)
+	defer cancelGen()
+	var wg sync.WaitGroup
+	for _, s := range run.senders {
+		wg.Add(1)
+		go func(s *sender) {
+			defer wg.Done()
+			g.sendLoop(genCtx, sendCtx, run, s)
+		}(s)
+	}
+	wg.Wait()
+
+	g.statusMux.Lock()
+	defer g.statusMux.Unlock()
+	run.status.Running = false
+	run.status.Ended = fftypes.Now()
+	log.L(g.ctx).Infof("Load test %s in namespace '%s' complete", run.status.ID, g.namespace)
+}
+
+// sendLoop sends requests at the rate of the sender until genCtx is done, then waits for the requests in
+// flight. A request that is due while the concurrency limit is reached is skipped rather than queued, so
+// that a slow confirmation path shows up as skipped requests rather than as growing latency.
+func (g *generator) sendLoop(genCtx, sendCtx context.Context, run *loadTestRun, s *sender) {
+	var inflight sync.WaitGroup
+	defer inflight.Wait()
+	slots := make(chan struct{}, run.concurrency)
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / s.rate))
+	defer ticker.Stop()
+	for seq := int64(0); ; seq++ {
+		select {
+		case <-ticker.C:
+		case <-genCtx.Done():
+			return
+		}
+		select {
+		case slots <- struct{}{}:
+		default:
+			g.statusMux.Lock()
+			s.result.Skipped++
+			g.statusMux.Unlock()
+			continue
+		}
+		g.statusMux.Lock()
+		s.result.Sent++
+		g.statusMux.Unlock()
+		inflight.Add(1)
+		go func(seq int64) {
+			defer inflight.Done()
+			start := time.Now()
+			err := s.send(sendCtx, seq)
+			<-slots
+			g.record(s, time.Since(start), err)
+		}(seq)
+	}
+}
+
+func (g *generator) record(s *sender, latency time.Duration, err error) {
+	g.statusMux.Lock()
+	defer g.statusMux.Unlock()
+	if err != nil {
+		s.result.Failed++
+		s.result.LastError = err.Error()
+		return
+	}
+	s.result.Confirmed++
+	s.latencies = append(s.latencies, latency)
+}
+
+func (g *generator) statusLocked(run *loadTestRun) *core.LoadTestStatus {
+	status := run.status
+	status.Results = make([]*core.LoadTestResult, len(run.senders))
+	for i, s := range run.senders {
+		result := s.result
+		if len(s.latencies) > 0 {
+			latencies := make([]time.Duration, len(s.latencies))
+			copy(latencies, s.latencies)
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+			result.LatencyP50 = percentile(latencies, 50)
+			result.LatencyP90 = percentile(latencies, 90)
+			result.LatencyP99 = percentile(latencies, 99)
+			result.LatencyMax = percentile(latencies, 100)
+		}
+		status.Results[i] = &result
+	}
+	return &status
+}
+
+// percentile uses the nearest-rank method on sorted values, so the result is always one of the values
+func percentile(sorted []time.Duration, p int) *fftypes.FFDuration {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	} else if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	d := fftypes.FFDuration(sorted[rank-1])
+	return &d
+}
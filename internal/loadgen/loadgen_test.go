@@ -0,0 +1,169 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/assetmocks"
+	"github.com/hyperledger/firefly/mocks/broadcastmocks"
+	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestGenerator(t *testing.T) (*generator, *broadcastmocks.Manager, *privatemessagingmocks.Manager, *assetmocks.Manager) {
+	mbm := &broadcastmocks.Manager{}
+	mpm := &privatemessagingmocks.Manager{}
+	mam := &assetmocks.Manager{}
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewGenerator(ctx, "ns1", mbm, mpm, mam).(*generator)
+	t.Cleanup(func() {
+		cancel()
+		g.WaitStop()
+	})
+	return g, mbm, mpm, mam
+}
+
+func duration(d time.Duration) *fftypes.FFDuration {
+	fd := fftypes.FFDuration(d)
+	return &fd
+}
+
+func TestLoadTestAllTypes(t *testing.T) {
+	g, mbm, mpm, mam := newTestGenerator(t)
+
+	mbm.On("BroadcastMessage", mock.Anything, mock.MatchedBy(func(in *core.MessageInOut) bool {
+		return in.Header.Tag == "loadtest" && len(in.InlineData) == 1
+	}), true).Return(&core.Message{}, nil)
+	mpm.On("SendMessage", mock.Anything, mock.MatchedBy(func(in *core.MessageInOut) bool {
+		return in.Group.Members[0].Identity == "org1"
+	}), true).Return(&core.Message{}, nil)
+	mam.On("TransferTokens", mock.Anything, mock.MatchedBy(func(in *core.TokenTransferInput) bool {
+		return in.Pool == "pool1" && in.To == "0x12345" && in.Amount.Int().Int64() == 1
+	}), true).Return(nil, fmt.Errorf("pop"))
+
+	status, err := g.Start(context.Background(), &core.LoadTestSpec{
+		Duration:       duration(100 * time.Millisecond),
+		BroadcastRate:  100,
+		PrivateRate:    100,
+		PrivateMembers: []string{"org1"},
+		TransferRate:   100,
+		TransferPool:   "pool1",
+		TransferTo:     "0x12345",
+	})
+	assert.NoError(t, err)
+	assert.True(t, status.Running)
+	assert.Len(t, status.Results, 3)
+
+	g.WaitStop()
+	status, err = g.Status(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, status.Running)
+	assert.NotNil(t, status.Ended)
+	assert.Equal(t, core.LoadTestTypeBroadcast, status.Results[0].Type)
+	assert.Greater(t, status.Results[0].Confirmed, int64(0))
+	assert.Equal(t, status.Results[0].Sent, status.Results[0].Confirmed)
+	assert.NotNil(t, status.Results[0].LatencyP99)
+	assert.Equal(t, core.LoadTestTypePrivate, status.Results[1].Type)
+	assert.Greater(t, status.Results[1].Confirmed, int64(0))
+	assert.Equal(t, core.LoadTestTypeTransfer, status.Results[2].Type)
+	assert.Equal(t, status.Results[2].Sent, status.Results[2].Failed)
+	assert.Equal(t, "pop", status.Results[2].LastError)
+	assert.Nil(t, status.Results[2].LatencyP50)
+}
+
+func TestLoadTestStopAndSkip(t *testing.T) {
+	g, mbm, _, _ := newTestGenerator(t)
+
+	mbm.On("BroadcastMessage", mock.Anything, mock.Anything, true).Return(func(ctx context.Context, in *core.MessageInOut, waitConfirm bool) (*core.Message, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	_, err := g.Start(context.Background(), &core.LoadTestSpec{
+		Duration:      duration(time.Minute),
+		Concurrency:   1,
+		BroadcastRate: 1000,
+	})
+	assert.NoError(t, err)
+
+	_, err = g.Start(context.Background(), &core.LoadTestSpec{
+		Duration:      duration(time.Minute),
+		BroadcastRate: 1,
+	})
+	assert.Regexp(t, "FF10608", err)
+
+	for {
+		status, err := g.Status(context.Background())
+		assert.NoError(t, err)
+		if status.Results[0].Skipped > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	status, err := g.Stop(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, status.Running)
+	assert.Equal(t, int64(1), status.Results[0].Sent)
+	assert.Equal(t, int64(1), status.Results[0].Failed)
+}
+
+func TestLoadTestNotFound(t *testing.T) {
+	g, _, _, _ := newTestGenerator(t)
+
+	_, err := g.Status(context.Background())
+	assert.Regexp(t, "FF10610", err)
+	_, err = g.Stop(context.Background())
+	assert.Regexp(t, "FF10610", err)
+}
+
+func TestLoadTestInvalid(t *testing.T) {
+	g, _, _, _ := newTestGenerator(t)
+	g.broadcast = nil
+
+	for _, spec := range []*core.LoadTestSpec{
+		{},
+		{Duration: duration(time.Second), Concurrency: -1, TransferRate: 1},
+		{Duration: duration(time.Second), PrivateRate: -1},
+		{Duration: duration(time.Second)},
+		{Duration: duration(time.Second), BroadcastRate: 1},
+		{Duration: duration(time.Second), PrivateRate: 1},
+		{Duration: duration(time.Second), TransferRate: 1, TransferPool: "pool1"},
+		{Duration: duration(time.Second), TransferRate: 1, TransferPool: "pool1", TransferTo: "0x12345", TransferAmount: fftypes.NewFFBigInt(0)},
+	} {
+		_, err := g.Start(context.Background(), spec)
+		assert.Regexp(t, "FF10609", err)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, fftypes.FFDuration(50*time.Millisecond), *percentile(sorted, 50))
+	assert.Equal(t, fftypes.FFDuration(99*time.Millisecond), *percentile(sorted, 99))
+	assert.Equal(t, fftypes.FFDuration(100*time.Millisecond), *percentile(sorted, 100))
+	assert.Equal(t, fftypes.FFDuration(1*time.Millisecond), *percentile(sorted, 0))
+}
@@ -0,0 +1,381 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logcontrol allows the log level of individual subsystems to be changed while the node is running,
+// and captures log output temporarily into memory so it can be downloaded to diagnose a problem.
+//
+// Logrus has a single level for the whole process, so the level of the logger is set to the most verbose
+// level that is needed, and the formatter discards entries that are below the level of their subsystem.
+package logcontrol
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/sirupsen/logrus"
+)
+
+type controller struct {
+	mux          sync.Mutex
+	logger       *logrus.Logger
+	formatter    logrus.Formatter
+	defaultLevel logrus.Level
+	levels       map[core.LogSubsystem]logrus.Level
+	capture      *capture
+}
+
+type capture struct {
+	status core.LogCaptureStatus
+	level  logrus.Level
+	lines  [][]byte
+	next   int
+	timer  *time.Timer
+}
+
+var ctrl *controller
+var ctrlMux sync.Mutex
+
+// Install wraps the formatter of the standard logger, so that subsystem levels and captures apply to it.
+// It must be called after logging has been configured, and is idempotent.
+func Install() {
+	ctrlMux.Lock()
+	defer ctrlMux.Unlock()
+	logger := logrus.StandardLogger()
+	if ctrl != nil && ctrl.logger == logger && logger.Formatter == ctrl {
+		return
+	}
+	ctrl = &controller{
+		logger:       logger,
+		formatter:    logger.Formatter,
+		defaultLevel: logger.GetLevel(),
+		levels:       make(map[core.LogSubsystem]logrus.Level),
+	}
+	logger.SetFormatter(ctrl)
+}
+
+func get() *controller {
+	ctrlMux.Lock()
+	installed := ctrl != nil
+	ctrlMux.Unlock()
+	if !installed {
+		Install()
+	}
+	return ctrl
+}
+
+// SetDefaultLevel sets the level of entries that are not in a subsystem with its own level
+func SetDefaultLevel(level string) {
+	c := get()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if l, err := logrus.ParseLevel(level); err == nil {
+		c.defaultLevel = l
+	}
+	c.applyLevel()
+}
+
+// GetLevels returns the default level, and the level of each subsystem that has its own
+func GetLevels() *core.LogLevels {
+	c := get()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.getLevels()
+}
+
+// SetLevels changes the default level if one is supplied, and the level of each subsystem supplied.
+// An empty level for a subsystem returns it to the default level.
+func SetLevels(ctx context.Context, levels *core.LogLevels) (*core.LogLevels, error) {
+	var defaultLevel *logrus.Level
+	if levels.Level != "" {
+		l, err := logrus.ParseLevel(levels.Level)
+		if err != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidLogLevel, levels.Level)
+		}
+		defaultLevel = &l
+	}
+	subsystemLevels := make(map[core.LogSubsystem]*logrus.Level, len(levels.Subsystems))
+	for subsystem, level := range levels.Subsystems {
+		if !isSubsystem(subsystem) {
+			return nil, i18n.NewError(ctx, coremsgs.MsgUnknownLogSubsystem, subsystem)
+		}
+		if level == "" {
+			subsystemLevels[subsystem] = nil
+			continue
+		}
+		l, err := logrus.ParseLevel(level)
+		if err != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidLogLevel, level)
+		}
+		subsystemLevels[subsystem] = &l
+	}
+
+	c := get()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if defaultLevel != nil {
+		c.defaultLevel = *defaultLevel
+	}
+	for subsystem, level := range subsystemLevels {
+		if level == nil {
+			delete(c.levels, subsystem)
+		} else {
+			c.levels[subsystem] = *level
+		}
+	}
+	c.applyLevel()
+	return c.getLevels(), nil
+}
+
+// StartCapture replaces any previous capture with a new one, which records all log output at the requested
+// level into a ring buffer until it expires
+func StartCapture(ctx context.Context, req *core.LogCaptureRequest) (*core.LogCaptureStatus, error) {
+	level := logrus.DebugLevel
+	if req.Level != "" {
+		l, err := logrus.ParseLevel(req.Level)
+		if err != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidLogLevel, req.Level)
+		}
+		level = l
+	}
+	maxDuration := config.GetDuration(coreconfig.DebugCaptureMaxDuration)
+	duration := 5 * time.Minute
+	if req.Duration != nil && *req.Duration > 0 {
+		duration = time.Duration(*req.Duration)
+	}
+	if duration > maxDuration {
+		duration = maxDuration
+	}
+	maxLines := config.GetInt(coreconfig.DebugCaptureMaxLines)
+	if req.MaxLines > 0 && req.MaxLines < maxLines {
+		maxLines = req.MaxLines
+	}
+
+	c := get()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.capture != nil && c.capture.timer != nil {
+		c.capture.timer.Stop()
+	}
+	started := fftypes.Now()
+	expires := fftypes.FFTime(time.Time(*started).Add(duration))
+	lc := &capture{
+		status: core.LogCaptureStatus{
+			Active:   true,
+			Level:    level.String(),
+			Started:  started,
+			Expires:  &expires,
+			MaxLines: maxLines,
+		},
+		level: level,
+		lines: make([][]byte, 0, maxLines),
+	}
+	lc.timer = time.AfterFunc(duration, func() { c.stopCapture(lc) })
+	c.capture = lc
+	c.applyLevel()
+	return c.getCaptureStatus(), nil
+}
+
+// StopCapture ends the current capture early. The captured output remains available for download.
+func StopCapture() *core.LogCaptureStatus {
+	c := get()
+	c.mux.Lock()
+	lc := c.capture
+	c.mux.Unlock()
+	if lc != nil {
+		c.stopCapture(lc)
+	}
+	return GetCaptureStatus()
+}
+
+// GetCaptureStatus returns the state of the latest capture
+func GetCaptureStatus() *core.LogCaptureStatus {
+	c := get()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.getCaptureStatus()
+}
+
+// GetCaptureBundle returns a zip file containing the output of the latest capture, along with
+// the capture status and the log levels at the time of download
+func GetCaptureBundle(ctx context.Context) ([]byte, error) {
+	c := get()
+	c.mux.Lock()
+	if c.capture == nil {
+		c.mux.Unlock()
+		return nil, i18n.NewError(ctx, coremsgs.MsgNoLogCapture)
+	}
+	status := c.getCaptureStatus()
+	levels := c.getLevels()
+	var output bytes.Buffer
+	for i := 0; i < len(c.capture.lines); i++ {
+		output.Write(c.capture.lines[(c.capture.next+i)%len(c.capture.lines)])
+	}
+	c.mux.Unlock()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{name: "capture.json", data: status},
+		{name: "levels.json", data: levels},
+	}
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err == nil {
+			err = json.NewEncoder(w).Encode(f.data)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	w, err := zw.Create("firefly.log")
+	if err == nil {
+		_, err = w.Write(output.Bytes())
+	}
+	if err == nil {
+		err = zw.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *controller) stopCapture(lc *capture) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	lc.timer.Stop()
+	if lc.status.Active {
+		lc.status.Active = false
+		lc.status.Expires = fftypes.Now()
+		c.applyLevel()
+	}
+}
+
+func (c *controller) getLevels() *core.LogLevels {
+	levels := &core.LogLevels{
+		Level:      c.defaultLevel.String(),
+		Subsystems: make(map[core.LogSubsystem]string, len(c.levels)),
+	}
+	for subsystem, level := range c.levels {
+		levels.Subsystems[subsystem] = level.String()
+	}
+	return levels
+}
+
+func (c *controller) getCaptureStatus() *core.LogCaptureStatus {
+	if c.capture == nil {
+		return &core.LogCaptureStatus{}
+	}
+	status := c.capture.status
+	status.Lines = len(c.capture.lines)
+	return &status
+}
+
+// applyLevel sets the level of the logger to the most verbose level that is needed
+func (c *controller) applyLevel() {
+	level := c.defaultLevel
+	for _, l := range c.levels {
+		if l > level {
+			level = l
+		}
+	}
+	if c.capture != nil && c.capture.status.Active && c.capture.level > level {
+		level = c.capture.level
+	}
+	c.logger.SetLevel(level)
+}
+
+// Format writes the entry if it is within the level of its subsystem, and records it in the active capture
+func (c *controller) Format(entry *logrus.Entry) ([]byte, error) {
+	c.mux.Lock()
+	threshold, ok := c.levels[subsystemOf(entry)]
+	if !ok {
+		threshold = c.defaultLevel
+	}
+	lc := c.capture
+	capturing := lc != nil && lc.status.Active && entry.Level <= lc.level
+	c.mux.Unlock()
+
+	if entry.Level > threshold && !capturing {
+		return nil, nil
+	}
+	formatted, err := c.formatter.Format(entry)
+	if err != nil || !capturing {
+		return formatted, err
+	}
+
+	c.mux.Lock()
+	lc.record(formatted)
+	c.mux.Unlock()
+	if entry.Level > threshold {
+		return nil, nil
+	}
+	return formatted, nil
+}
+
+func (lc *capture) record(line []byte) {
+	line = append([]byte{}, line...) // the formatter may reuse its buffer
+	if len(lc.lines) < lc.status.MaxLines {
+		lc.lines = append(lc.lines, line)
+		return
+	}
+	lc.lines[lc.next] = line
+	lc.next = (lc.next + 1) % len(lc.lines)
+	lc.status.Dropped++
+}
+
+// subsystemOf uses the fields that components add to the logging context to find the subsystem of an entry
+func subsystemOf(entry *logrus.Entry) core.LogSubsystem {
+	for _, field := range []string{"proto", "dx", "sharedstorage", "auth"} {
+		if _, ok := entry.Data[field]; ok {
+			return core.LogSubsystemPlugins
+		}
+	}
+	role, _ := entry.Data["role"].(string)
+	switch {
+	case strings.HasPrefix(role, "aggregator"):
+		return core.LogSubsystemAggregator
+	case role == "batchmgr":
+		return core.LogSubsystemBatch
+	case role == "event-manager" || strings.HasPrefix(role, "ep["):
+		return core.LogSubsystemEvents
+	default:
+		return ""
+	}
+}
+
+func isSubsystem(subsystem core.LogSubsystem) bool {
+	for _, s := range core.LogSubsystems {
+		if s == subsystem {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logcontrol
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(t *testing.T) *bytes.Buffer {
+	coreconfig.Reset()
+	logger := logrus.StandardLogger()
+	out := &bytes.Buffer{}
+	formatter, level := logger.Formatter, logger.GetLevel()
+	logger.SetOutput(out)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+	logger.SetLevel(logrus.InfoLevel)
+	ctrlMux.Lock()
+	ctrl = nil
+	ctrlMux.Unlock()
+	Install()
+	t.Cleanup(func() {
+		ctrlMux.Lock()
+		ctrl = nil
+		ctrlMux.Unlock()
+		logger.SetOutput(io.Discard)
+		logger.SetFormatter(formatter)
+		logger.SetLevel(level)
+	})
+	return out
+}
+
+func TestInstallIdempotent(t *testing.T) {
+	newTestLogger(t)
+	installed := ctrl
+	Install()
+	assert.Same(t, installed, ctrl)
+	assert.Same(t, installed, logrus.StandardLogger().Formatter)
+}
+
+func TestSubsystemLevels(t *testing.T) {
+	out := newTestLogger(t)
+
+	levels, err := SetLevels(context.Background(), &core.LogLevels{
+		Subsystems: map[core.LogSubsystem]string{core.LogSubsystemAggregator: "debug"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "info", levels.Level)
+	assert.Equal(t, "debug", levels.Subsystems[core.LogSubsystemAggregator])
+	assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+
+	logrus.WithField("role", "aggregator").Debug("aggregator detail")
+	logrus.WithField("role", "batchmgr").Debug("batch detail")
+	logrus.WithField("role", "batchmgr").Info("batch summary")
+	assert.Contains(t, out.String(), "aggregator detail")
+	assert.NotContains(t, out.String(), "batch detail")
+	assert.Contains(t, out.String(), "batch summary")
+
+	levels, err = SetLevels(context.Background(), &core.LogLevels{
+		Level:      "warn",
+		Subsystems: map[core.LogSubsystem]string{core.LogSubsystemAggregator: ""},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "warning", levels.Level)
+	assert.Empty(t, levels.Subsystems)
+	assert.Equal(t, logrus.WarnLevel, logrus.GetLevel())
+	assert.Equal(t, "warning", GetLevels().Level)
+}
+
+func TestSetLevelsInvalid(t *testing.T) {
+	newTestLogger(t)
+
+	_, err := SetLevels(context.Background(), &core.LogLevels{Level: "loud"})
+	assert.Regexp(t, "FF10548", err)
+
+	_, err = SetLevels(context.Background(), &core.LogLevels{
+		Subsystems: map[core.LogSubsystem]string{core.LogSubsystemBatch: "loud"},
+	})
+	assert.Regexp(t, "FF10548", err)
+
+	_, err = SetLevels(context.Background(), &core.LogLevels{
+		Subsystems: map[core.LogSubsystem]string{"everything": "debug"},
+	})
+	assert.Regexp(t, "FF10549", err)
+}
+
+func TestSetDefaultLevel(t *testing.T) {
+	newTestLogger(t)
+	SetDefaultLevel("error")
+	assert.Equal(t, logrus.ErrorLevel, logrus.GetLevel())
+	SetDefaultLevel("bad")
+	assert.Equal(t, logrus.ErrorLevel, logrus.GetLevel())
+}
+
+func TestSubsystemOf(t *testing.T) {
+	assert.Equal(t, core.LogSubsystemPlugins, subsystemOf(logrus.WithField("proto", "ethereum")))
+	assert.Equal(t, core.LogSubsystemAggregator, subsystemOf(logrus.WithField("role", "aggregator-rewind")))
+	assert.Equal(t, core.LogSubsystemEvents, subsystemOf(logrus.WithField("role", "ep[ns1:sub1]")))
+	assert.Equal(t, core.LogSubsystemBatch, subsystemOf(logrus.WithField("role", "batchmgr")))
+	assert.Equal(t, core.LogSubsystem(""), subsystemOf(logrus.WithField("role", "sync-async-bridge")))
+}
+
+func TestCapture(t *testing.T) {
+	out := newTestLogger(t)
+
+	_, err := GetCaptureBundle(context.Background())
+	assert.Regexp(t, "FF10550", err)
+	assert.False(t, GetCaptureStatus().Active)
+
+	status, err := StartCapture(context.Background(), &core.LogCaptureRequest{MaxLines: 2})
+	assert.NoError(t, err)
+	assert.True(t, status.Active)
+	assert.Equal(t, "debug", status.Level)
+	assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+
+	logrus.Debug("line one")
+	logrus.Debug("line two")
+	logrus.Info("line three")
+	assert.NotContains(t, out.String(), "line two")
+	assert.Contains(t, out.String(), "line three")
+
+	status = GetCaptureStatus()
+	assert.Equal(t, 2, status.Lines)
+	assert.Equal(t, int64(1), status.Dropped)
+
+	status = StopCapture()
+	assert.False(t, status.Active)
+	assert.Equal(t, logrus.InfoLevel, logrus.GetLevel())
+
+	bundle, err := GetCaptureBundle(context.Background())
+	assert.NoError(t, err)
+	zr, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	assert.NoError(t, err)
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		r, _ := f.Open()
+		b, _ := io.ReadAll(r)
+		files[f.Name] = string(b)
+	}
+	assert.Regexp(t, "(?s)line two.*line three", files["firefly.log"])
+	assert.NotContains(t, files["firefly.log"], "line one")
+	assert.Contains(t, files["capture.json"], `"dropped":1`)
+	assert.Contains(t, files["levels.json"], `"level":"info"`)
+}
+
+func TestCaptureExpires(t *testing.T) {
+	newTestLogger(t)
+	config.Set(coreconfig.DebugCaptureMaxDuration, "10ms")
+
+	duration := fftypes.FFDuration(time.Hour)
+	status, err := StartCapture(context.Background(), &core.LogCaptureRequest{Level: "trace", Duration: &duration})
+	assert.NoError(t, err)
+	assert.Equal(t, logrus.TraceLevel, logrus.GetLevel())
+	assert.WithinDuration(t, time.Time(*status.Started).Add(10*time.Millisecond), time.Time(*status.Expires), time.Millisecond)
+
+	assert.Eventually(t, func() bool { return !GetCaptureStatus().Active }, time.Second, time.Millisecond)
+	assert.Equal(t, logrus.InfoLevel, logrus.GetLevel())
+}
+
+func TestCaptureBadLevel(t *testing.T) {
+	newTestLogger(t)
+	_, err := StartCapture(context.Background(), &core.LogCaptureRequest{Level: "loud"})
+	assert.Regexp(t, "FF10548", err)
+}
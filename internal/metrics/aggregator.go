@@ -0,0 +1,54 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var AggregatorPinsProcessedCounter *prometheus.CounterVec
+var AggregatorLagGauge *prometheus.GaugeVec
+
+const (
+	MetricsAggregatorPinsProcessed = "ff_aggregator_pins_processed_total"
+	MetricsAggregatorLag           = "ff_aggregator_lag_pins"
+)
+
+func InitAggregatorMetrics() {
+	AggregatorPinsProcessedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsAggregatorPinsProcessed,
+		Help: "Number of pins processed by the aggregator",
+	}, namespaceLabels)
+	AggregatorLagGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricsAggregatorLag,
+		Help: "Number of pins between the latest pin sequence written and the last pin sequence processed by the aggregator",
+	}, namespaceLabels)
+}
+
+func RegisterAggregatorMetrics() {
+	registry.MustRegister(AggregatorPinsProcessedCounter)
+	registry.MustRegister(AggregatorLagGauge)
+}
+
+func (mm *metricsManager) AggregatorPinsProcessed(namespace string, count int, lag int64) {
+	if lag < 0 {
+		// The latest sequence is only known once a pin is written after startup
+		lag = 0
+	}
+	AggregatorPinsProcessedCounter.WithLabelValues(namespace).Add(float64(count))
+	AggregatorLagGauge.WithLabelValues(namespace).Set(float64(lag))
+}
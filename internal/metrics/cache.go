@@ -0,0 +1,83 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var CacheHitCounter *prometheus.CounterVec
+var CacheMissCounter *prometheus.CounterVec
+var CacheHitRatioGauge *prometheus.GaugeVec
+
+const (
+	MetricsCacheHits     = "ff_cache_hits_total"
+	MetricsCacheMisses   = "ff_cache_misses_total"
+	MetricsCacheHitRatio = "ff_cache_hit_ratio"
+)
+
+var cacheLabels = []string{"ns", "cache"}
+
+type cacheStats struct {
+	hits   int64
+	misses int64
+}
+
+func InitCacheMetrics() {
+	CacheHitCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsCacheHits,
+		Help: "Number of lookups that found an entry in a cache",
+	}, cacheLabels)
+	CacheMissCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsCacheMisses,
+		Help: "Number of lookups that did not find an entry in a cache",
+	}, cacheLabels)
+	CacheHitRatioGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricsCacheHitRatio,
+		Help: "Ratio of lookups that found an entry in a cache, since startup",
+	}, cacheLabels)
+}
+
+func RegisterCacheMetrics() {
+	registry.MustRegister(CacheHitCounter)
+	registry.MustRegister(CacheMissCounter)
+	registry.MustRegister(CacheHitRatioGauge)
+}
+
+func (mm *metricsManager) CacheLookup(namespace, cache string, hit bool) {
+	if hit {
+		CacheHitCounter.WithLabelValues(namespace, cache).Inc()
+	} else {
+		CacheMissCounter.WithLabelValues(namespace, cache).Inc()
+	}
+
+	key := namespace + ":" + cache
+	mm.cacheMux.Lock()
+	stats := mm.cacheStats[key]
+	if stats == nil {
+		stats = &cacheStats{}
+		mm.cacheStats[key] = stats
+	}
+	if hit {
+		stats.hits++
+	} else {
+		stats.misses++
+	}
+	ratio := float64(stats.hits) / float64(stats.hits+stats.misses)
+	mm.cacheMux.Unlock()
+	CacheHitRatioGauge.WithLabelValues(namespace, cache).Set(ratio)
+}
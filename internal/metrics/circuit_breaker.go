@@ -0,0 +1,63 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var CircuitBreakerStateGauge *prometheus.GaugeVec
+var CircuitBreakerTripCounter *prometheus.CounterVec
+
+const (
+	MetricsCircuitBreakerState = "ff_circuit_breaker_state"
+	MetricsCircuitBreakerTrips = "ff_circuit_breaker_trips_total"
+)
+
+var circuitBreakerLabels = []string{"ns", "plugin"}
+
+func InitCircuitBreakerMetrics() {
+	CircuitBreakerStateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricsCircuitBreakerState,
+		Help: "State of the circuit breaker for a plugin - 0 closed, 1 half open, 2 open",
+	}, circuitBreakerLabels)
+
+	CircuitBreakerTripCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsCircuitBreakerTrips,
+		Help: "Number of times the circuit breaker for a plugin has opened",
+	}, circuitBreakerLabels)
+}
+
+func RegisterCircuitBreakerMetrics() {
+	registry.MustRegister(CircuitBreakerStateGauge)
+	registry.MustRegister(CircuitBreakerTripCounter)
+}
+
+func (mm *metricsManager) CircuitBreakerState(namespace, plugin string, state core.CircuitBreakerState) {
+	var gaugeState float64
+	switch state {
+	case core.CircuitBreakerStateOpen:
+		gaugeState = 2.0
+		CircuitBreakerTripCounter.WithLabelValues(namespace, plugin).Inc()
+	case core.CircuitBreakerStateHalfOpen:
+		gaugeState = 1.0
+	default:
+		gaugeState = 0.0
+	}
+	CircuitBreakerStateGauge.WithLabelValues(namespace, plugin).Set(gaugeState)
+}
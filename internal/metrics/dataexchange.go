@@ -0,0 +1,61 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var DXTransferHistogram *prometheus.HistogramVec
+
+const (
+	MetricsDXTransferSeconds = "ff_dx_transfer_seconds"
+)
+
+var dxTransferLabels = []string{"ns", "type", "status"}
+
+func InitDataExchangeMetrics() {
+	DXTransferHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: MetricsDXTransferSeconds,
+		Help: "Histogram of the time from submitting a message or blob to data exchange, to the transfer succeeding or failing",
+	}, dxTransferLabels)
+}
+
+func RegisterDataExchangeMetrics() {
+	registry.MustRegister(DXTransferHistogram)
+}
+
+func (mm *metricsManager) DXTransferSubmitted(nsOpID string) {
+	mm.AddTime(nsOpID)
+}
+
+func (mm *metricsManager) DXTransferCompleted(nsOpID, transferType string, status core.OpStatus) {
+	submitted := mm.GetTime(nsOpID)
+	if submitted.IsZero() {
+		// Submitted before a restart, or by a different FireFly node sharing this data exchange
+		return
+	}
+	mm.DeleteTime(nsOpID)
+	namespace, _, err := core.ParseNamespacedOpID(mm.ctx, nsOpID)
+	if err != nil {
+		return
+	}
+	DXTransferHistogram.WithLabelValues(namespace, transferType, string(status)).Observe(time.Since(submitted).Seconds())
+}
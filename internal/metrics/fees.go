@@ -0,0 +1,53 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var BlockchainGasUsedCounter *prometheus.CounterVec
+var BlockchainFeeCounter *prometheus.CounterVec
+
+const (
+	MetricsBlockchainGasUsed = "ff_blockchain_gas_used_total"
+	MetricsBlockchainFees    = "ff_blockchain_fees_total"
+)
+
+var feeLabels = []string{"ns", "key"}
+
+func InitFeeMetrics() {
+	BlockchainGasUsedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsBlockchainGasUsed,
+		Help: "Gas used by blockchain transactions submitted by a signing key, as reported in connector receipts",
+	}, feeLabels)
+
+	BlockchainFeeCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsBlockchainFees,
+		Help: "Fees paid for blockchain transactions submitted by a signing key, in the smallest unit of the native currency",
+	}, feeLabels)
+}
+
+func RegisterFeeMetrics() {
+	registry.MustRegister(BlockchainGasUsedCounter)
+	registry.MustRegister(BlockchainFeeCounter)
+}
+
+func (mm *metricsManager) BlockchainFee(namespace, key string, gasUsed, fee float64) {
+	BlockchainGasUsedCounter.WithLabelValues(namespace, key).Add(gasUsed)
+	BlockchainFeeCounter.WithLabelValues(namespace, key).Add(fee)
+}
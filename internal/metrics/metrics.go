@@ -43,6 +43,18 @@ type Manager interface {
 	BlockchainEvent(location, signature string)
 	NodeIdentityDXCertMismatch(namespace string, mismatch NodeIdentityDXCertMismatchStatus)
 	NodeIdentityDXCertExpiry(namespace string, expiry time.Time)
+	CircuitBreakerState(namespace, plugin string, state core.CircuitBreakerState)
+	BlockchainFee(namespace, key string, gasUsed, fee float64)
+	RecordsPruned(namespace, collection string, count int64)
+	RateLimitChecked(namespace, group string, allowed bool)
+	AggregatorPinsProcessed(namespace string, count int, lag int64)
+	SubscriptionInflight(namespace, subscription string, count int)
+	SubscriptionRedelivery(namespace, subscription string)
+	SubscriptionClosed(namespace, subscription string)
+	EventDelivered(namespace, transport string, elapsed time.Duration)
+	DXTransferSubmitted(nsOpID string)
+	DXTransferCompleted(nsOpID, transferType string, status core.OpStatus)
+	CacheLookup(namespace, cache string, hit bool)
 	AddTime(id string)
 	GetTime(id string) time.Time
 	DeleteTime(id string)
@@ -53,6 +65,8 @@ type metricsManager struct {
 	ctx            context.Context
 	metricsEnabled bool
 	timeMap        map[string]time.Time
+	cacheMux       sync.Mutex
+	cacheStats     map[string]*cacheStats
 }
 
 func NewMetricsManager(ctx context.Context) Manager {
@@ -60,6 +74,7 @@ func NewMetricsManager(ctx context.Context) Manager {
 		ctx:            ctx,
 		metricsEnabled: config.GetBool(coreconfig.DeprecatedMetricsEnabled) || config.GetBool(coreconfig.MonitoringEnabled),
 		timeMap:        make(map[string]time.Time),
+		cacheStats:     make(map[string]*cacheStats),
 	}
 
 	return mm
@@ -268,3 +268,97 @@ func TestNodeIdentityDXCertExpiry(t *testing.T) {
 	gaugeValue := testutil.ToFloat64(NodeIdentityDXCertExpiryGauge.WithLabelValues("test-namespace"))
 	assert.Equal(t, float64(now.Unix()), gaugeValue)
 }
+
+func TestCircuitBreakerState(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.CircuitBreakerState("test-namespace", "ethereum", core.CircuitBreakerStateOpen)
+	assert.Equal(t, 2.0, testutil.ToFloat64(CircuitBreakerStateGauge.WithLabelValues("test-namespace", "ethereum")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(CircuitBreakerTripCounter.WithLabelValues("test-namespace", "ethereum")))
+	mm.CircuitBreakerState("test-namespace", "ethereum", core.CircuitBreakerStateHalfOpen)
+	assert.Equal(t, 1.0, testutil.ToFloat64(CircuitBreakerStateGauge.WithLabelValues("test-namespace", "ethereum")))
+	mm.CircuitBreakerState("test-namespace", "ethereum", core.CircuitBreakerStateClosed)
+	assert.Equal(t, 0.0, testutil.ToFloat64(CircuitBreakerStateGauge.WithLabelValues("test-namespace", "ethereum")))
+}
+
+func TestBlockchainFee(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.BlockchainFee("test-namespace", "0x12345", 21000, 21000000000000)
+	mm.BlockchainFee("test-namespace", "0x12345", 21000, 21000000000000)
+	assert.Equal(t, 42000.0, testutil.ToFloat64(BlockchainGasUsedCounter.WithLabelValues("test-namespace", "0x12345")))
+	assert.Equal(t, 42000000000000.0, testutil.ToFloat64(BlockchainFeeCounter.WithLabelValues("test-namespace", "0x12345")))
+}
+
+func TestRecordsPruned(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.RecordsPruned("test-namespace", "events", 100)
+	mm.RecordsPruned("test-namespace", "events", 50)
+	assert.Equal(t, 150.0, testutil.ToFloat64(RetentionPrunedCounter.WithLabelValues("test-namespace", "events")))
+}
+
+func TestRateLimitChecked(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.RateLimitChecked("test-namespace", "messages", true)
+	mm.RateLimitChecked("test-namespace", "messages", true)
+	mm.RateLimitChecked("test-namespace", "messages", false)
+	assert.Equal(t, 2.0, testutil.ToFloat64(RateLimitAllowedCounter.WithLabelValues("test-namespace", "messages")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(RateLimitRejectedCounter.WithLabelValues("test-namespace", "messages")))
+}
+
+func TestAggregatorPinsProcessed(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.AggregatorPinsProcessed("test-namespace", 10, 5)
+	mm.AggregatorPinsProcessed("test-namespace", 5, -1)
+	assert.Equal(t, 15.0, testutil.ToFloat64(AggregatorPinsProcessedCounter.WithLabelValues("test-namespace")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(AggregatorLagGauge.WithLabelValues("test-namespace")))
+}
+
+func TestSubscriptionMetrics(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.SubscriptionInflight("test-namespace", "sub1", 3)
+	mm.SubscriptionRedelivery("test-namespace", "sub1")
+	mm.EventDelivered("test-namespace", "webhooks", 50*time.Millisecond)
+	assert.Equal(t, 3.0, testutil.ToFloat64(SubscriptionInflightGauge.WithLabelValues("test-namespace", "sub1")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(SubscriptionRedeliveryCounter.WithLabelValues("test-namespace", "sub1")))
+	assert.Equal(t, 1, testutil.CollectAndCount(EventDeliveryHistogram))
+	mm.SubscriptionClosed("test-namespace", "sub1")
+	assert.Equal(t, 0, testutil.CollectAndCount(SubscriptionInflightGauge))
+}
+
+func TestDXTransferCompleted(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	nsOpID := "test-namespace:" + fftypes.NewUUID().String()
+	mm.DXTransferCompleted(nsOpID, "blob", core.OpStatusSucceeded)
+	assert.Equal(t, 0, testutil.CollectAndCount(DXTransferHistogram))
+
+	mm.DXTransferSubmitted(nsOpID)
+	mm.DXTransferCompleted(nsOpID, "blob", core.OpStatusSucceeded)
+	assert.Equal(t, 1, testutil.CollectAndCount(DXTransferHistogram))
+	assert.True(t, mm.GetTime(nsOpID).IsZero())
+}
+
+func TestDXTransferCompletedBadOpID(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.DXTransferSubmitted("bad")
+	mm.DXTransferCompleted("bad", "message", core.OpStatusFailed)
+	assert.Equal(t, 0, testutil.CollectAndCount(DXTransferHistogram))
+}
+
+func TestCacheLookup(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.CacheLookup("test-namespace", "cache.batch", true)
+	mm.CacheLookup("test-namespace", "cache.batch", true)
+	mm.CacheLookup("test-namespace", "cache.batch", true)
+	mm.CacheLookup("test-namespace", "cache.batch", false)
+	assert.Equal(t, 3.0, testutil.ToFloat64(CacheHitCounter.WithLabelValues("test-namespace", "cache.batch")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(CacheMissCounter.WithLabelValues("test-namespace", "cache.batch")))
+	assert.Equal(t, 0.75, testutil.ToFloat64(CacheHitRatioGauge.WithLabelValues("test-namespace", "cache.batch")))
+}
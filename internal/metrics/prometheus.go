@@ -89,6 +89,14 @@ func initMetricsCollectors() {
 	InitBatchPinMetrics()
 	InitBlockchainMetrics()
 	InitIdentityMetrics()
+	InitCircuitBreakerMetrics()
+	InitFeeMetrics()
+	InitRetentionMetrics()
+	InitRateLimitMetrics()
+	InitAggregatorMetrics()
+	InitSubscriptionMetrics()
+	InitDataExchangeMetrics()
+	InitCacheMetrics()
 }
 
 func registerMetricsCollectors() {
@@ -103,4 +111,12 @@ func registerMetricsCollectors() {
 	RegisterTokenBurnMetrics()
 	RegisterBlockchainMetrics()
 	RegisterIdentityMetrics()
+	RegisterCircuitBreakerMetrics()
+	RegisterFeeMetrics()
+	RegisterRetentionMetrics()
+	RegisterRateLimitMetrics()
+	RegisterAggregatorMetrics()
+	RegisterSubscriptionMetrics()
+	RegisterDataExchangeMetrics()
+	RegisterCacheMetrics()
 }
@@ -0,0 +1,55 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var RateLimitAllowedCounter *prometheus.CounterVec
+var RateLimitRejectedCounter *prometheus.CounterVec
+
+const (
+	MetricsRateLimitAllowed  = "ff_api_ratelimit_allowed_total"
+	MetricsRateLimitRejected = "ff_api_ratelimit_rejected_total"
+)
+
+var rateLimitLabels = []string{"ns", "group"}
+
+func InitRateLimitMetrics() {
+	RateLimitAllowedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsRateLimitAllowed,
+		Help: "Number of API requests allowed by the rate limit of a route group",
+	}, rateLimitLabels)
+	RateLimitRejectedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsRateLimitRejected,
+		Help: "Number of API requests rejected by the rate limit of a route group",
+	}, rateLimitLabels)
+}
+
+func RegisterRateLimitMetrics() {
+	registry.MustRegister(RateLimitAllowedCounter)
+	registry.MustRegister(RateLimitRejectedCounter)
+}
+
+func (mm *metricsManager) RateLimitChecked(namespace, group string, allowed bool) {
+	if allowed {
+		RateLimitAllowedCounter.WithLabelValues(namespace, group).Inc()
+	} else {
+		RateLimitRejectedCounter.WithLabelValues(namespace, group).Inc()
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var RetentionPrunedCounter *prometheus.CounterVec
+
+const (
+	MetricsRetentionPruned = "ff_retention_pruned_rows_total"
+)
+
+var retentionLabels = []string{"ns", "collection"}
+
+func InitRetentionMetrics() {
+	RetentionPrunedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsRetentionPruned,
+		Help: "Number of rows deleted from a collection by the data retention policy",
+	}, retentionLabels)
+}
+
+func RegisterRetentionMetrics() {
+	registry.MustRegister(RetentionPrunedCounter)
+}
+
+func (mm *metricsManager) RecordsPruned(namespace, collection string, count int64) {
+	RetentionPrunedCounter.WithLabelValues(namespace, collection).Add(float64(count))
+}
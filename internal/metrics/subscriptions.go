@@ -0,0 +1,75 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var SubscriptionInflightGauge *prometheus.GaugeVec
+var SubscriptionRedeliveryCounter *prometheus.CounterVec
+var EventDeliveryHistogram *prometheus.HistogramVec
+
+const (
+	MetricsSubscriptionInflight     = "ff_subscription_inflight_events"
+	MetricsSubscriptionRedeliveries = "ff_subscription_redeliveries_total"
+	MetricsEventDeliverySeconds     = "ff_event_delivery_seconds"
+)
+
+var subscriptionLabels = []string{"ns", "subscription"}
+var eventDeliveryLabels = []string{"ns", "transport"}
+
+func InitSubscriptionMetrics() {
+	SubscriptionInflightGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: MetricsSubscriptionInflight,
+		Help: "Number of events dispatched to a subscription that are waiting for an ack",
+	}, subscriptionLabels)
+	SubscriptionRedeliveryCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: MetricsSubscriptionRedeliveries,
+		Help: "Number of times a subscription rejected an event, causing events to be redelivered from that event onwards",
+	}, subscriptionLabels)
+	EventDeliveryHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: MetricsEventDeliverySeconds,
+		Help: "Histogram of the time from dispatching an event to a transport, to the transport acking it - for webhooks this is the latency of the webhook call",
+	}, eventDeliveryLabels)
+}
+
+func RegisterSubscriptionMetrics() {
+	registry.MustRegister(SubscriptionInflightGauge)
+	registry.MustRegister(SubscriptionRedeliveryCounter)
+	registry.MustRegister(EventDeliveryHistogram)
+}
+
+func (mm *metricsManager) SubscriptionInflight(namespace, subscription string, count int) {
+	SubscriptionInflightGauge.WithLabelValues(namespace, subscription).Set(float64(count))
+}
+
+func (mm *metricsManager) SubscriptionRedelivery(namespace, subscription string) {
+	SubscriptionRedeliveryCounter.WithLabelValues(namespace, subscription).Inc()
+}
+
+// SubscriptionClosed removes the in-flight series of a subscription whose dispatcher has closed,
+// rather than leaving it behind at zero
+func (mm *metricsManager) SubscriptionClosed(namespace, subscription string) {
+	SubscriptionInflightGauge.DeleteLabelValues(namespace, subscription)
+}
+
+func (mm *metricsManager) EventDelivered(namespace, transport string, elapsed time.Duration) {
+	EventDeliveryHistogram.WithLabelValues(namespace, transport).Observe(elapsed.Seconds())
+}
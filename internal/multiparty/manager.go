@@ -19,13 +19,17 @@ package multiparty
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -55,12 +59,53 @@ type Manager interface {
 	// GetNetworkVersion returns the network version of the active FireFly contract
 	GetNetworkVersion() int
 
+	// GetContractMigration returns a copy of the current (or most recent) coordinated contract migration, if any
+	GetContractMigration() *core.ContractMigration
+
+	// SetContractMigration records the state of a coordinated contract migration, as agreed by the network
+	SetContractMigration(ctx context.Context, migration *core.ContractMigration) error
+
+	// ValidateContractMigration checks the proposed contract matches the next FireFly contract in the local configuration
+	ValidateContractMigration(ctx context.Context, location *fftypes.JSONAny, version int) error
+
+	// GetScheduledNetworkActions returns a copy of the network actions that are waiting for their effective block or time
+	GetScheduledNetworkActions() []*core.ScheduledNetworkAction
+
+	// ScheduleNetworkAction adds a network action received from the blockchain to a copy of the given schedule, to be applied
+	// at its effective block or time. Returns false, and the schedule unchanged, if the event has already reached the
+	// effective point, so the action should be applied immediately.
+	ScheduleNetworkAction(ctx context.Context, scheduled []*core.ScheduledNetworkAction, action *core.ScheduledNetworkAction, event *blockchain.Event) ([]*core.ScheduledNetworkAction, bool)
+
+	// ApplyScheduledNetworkActions is called for every event from the active FireFly contract, and applies any actions
+	// in the given schedule for which the event is at or after the effective block or time. Returns a copy of the
+	// schedule without the applied actions.
+	ApplyScheduledNetworkActions(ctx context.Context, scheduled []*core.ScheduledNetworkAction, event *blockchain.Event) ([]*core.ScheduledNetworkAction, error)
+
+	// PersistScheduledNetworkActions writes the schedule to the namespace in the database, without changing it in memory
+	PersistScheduledNetworkActions(ctx context.Context, scheduled []*core.ScheduledNetworkAction) error
+
+	// SetScheduledNetworkActions swaps in the schedule in memory, once the transaction that persisted it has committed
+	SetScheduledNetworkActions(scheduled []*core.ScheduledNetworkAction)
+
+	// CheckContractMigration is called for every event from the active FireFly contract
+	// - If a migration is ready, and the event is at or after the activation block, switches to the next FireFly contract
+	// - If the switch fails, rolls back to the current contract and marks the migration as rolled back
+	CheckContractMigration(ctx context.Context, event *blockchain.Event) error
+
 	// SubmitBatchPin sequences a batch of message globally to all viewers of a given ledger
 	SubmitBatchPin(ctx context.Context, batch *core.BatchPersisted, contexts []*fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error
 
 	// SubmitNetworkAction writes a special "BatchPin" event which signals the plugin to take an action
 	SubmitNetworkAction(ctx context.Context, signingKey string, action *core.NetworkAction, idempotentSubmit bool) error
 
+	// SubmitAuditAnchor records an audit anchor against a new transaction, and pins its Merkle root to the blockchain
+	SubmitAuditAnchor(ctx context.Context, signingKey string, anchor *core.AuditAnchor) error
+
+	// CheckAuditAnchorPin returns whether the Merkle root of an audit anchor has been pinned to the blockchain
+	// - If the latest pin operation for the anchor failed, it is retried after a delay that doubles from retryDelay on each attempt
+	// - Once maxAttempts have failed it is not retried again, and exhausted is returned
+	CheckAuditAnchorPin(ctx context.Context, anchor *core.AuditAnchor, maxAttempts int, retryDelay time.Duration) (pinned, exhausted bool, err error)
+
 	// From operations.OperationHandler
 	PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error)
 	RunOperation(ctx context.Context, op *core.PreparedOperation) (outputs fftypes.JSONObject, phase core.OpPhase, err error)
@@ -71,6 +116,7 @@ type Config struct {
 	Org       RootOrg
 	Node      LocalNode
 	Contracts []blockchain.MultipartyContract
+	Bootstrap Bootstrap
 }
 
 type RootOrg struct {
@@ -84,14 +130,21 @@ type LocalNode struct {
 	Description string
 }
 
+// Bootstrap configures automatic registration of the root org and local node
+type Bootstrap struct {
+	Enabled bool
+	Retry   retry.Retry
+}
+
 type multipartyManager struct {
-	namespace  *core.Namespace
-	database   database.Plugin
-	blockchain blockchain.Plugin
-	operations operations.Manager
-	metrics    metrics.Manager
-	txHelper   txcommon.Helper
-	config     Config
+	contractMux sync.Mutex
+	namespace   *core.Namespace
+	database    database.Plugin
+	blockchain  blockchain.Plugin
+	operations  operations.Manager
+	metrics     metrics.Manager
+	txHelper    txcommon.Helper
+	config      Config
 }
 
 func NewMultipartyManager(ctx context.Context, ns *core.Namespace, config Config, di database.Plugin, bi blockchain.Plugin, om operations.Manager, mm metrics.Manager, th txcommon.Helper) (Manager, error) {
@@ -110,6 +163,7 @@ func NewMultipartyManager(ctx context.Context, ns *core.Namespace, config Config
 	om.RegisterHandler(ctx, mp, []core.OpType{
 		core.OpTypeBlockchainPinBatch,
 		core.OpTypeBlockchainNetworkAction,
+		core.OpTypeBlockchainPinAuditAnchor,
 	})
 	return mp, nil
 }
@@ -203,11 +257,31 @@ func (mm *multipartyManager) resolveFireFlyContract(ctx context.Context, contrac
 }
 
 func (mm *multipartyManager) TerminateContract(ctx context.Context, location *fftypes.JSONAny, termination *blockchain.Event) (err error) {
+	mm.contractMux.Lock()
+	defer mm.contractMux.Unlock()
+	return mm.terminateContract(ctx, location, termination)
+}
+
+func (mm *multipartyManager) terminateContract(ctx context.Context, location *fftypes.JSONAny, termination *blockchain.Event) error {
 	contracts := mm.namespace.Contracts
 	if contracts.Active.Location.String() != location.String() {
 		log.L(ctx).Warnf("Ignoring termination event from contract at '%s', which does not match active '%s'", location, contracts.Active.Location)
 		return nil
 	}
+	if err := mm.terminateActiveContract(ctx, termination); err != nil {
+		return err
+	}
+	// A termination also fulfils any coordinated migration to the same contract
+	if migration := contracts.Migration; migration.InProgress() && migration.Location.String() == contracts.Active.Location.String() {
+		migration.State = core.ContractMigrationStateComplete
+		migration.Updated = fftypes.Now()
+		return mm.database.UpsertNamespace(ctx, mm.namespace, true)
+	}
+	return nil
+}
+
+func (mm *multipartyManager) terminateActiveContract(ctx context.Context, termination *blockchain.Event) error {
+	contracts := mm.namespace.Contracts
 	log.L(ctx).Infof("Processing termination of contract #%d at '%s'", contracts.Active.Index, contracts.Active.Location)
 	mm.blockchain.RemoveFireflySubscription(ctx, contracts.Active.Info.Subscription)
 	contracts.Active.Info.FinalEvent = termination.ProtocolID
@@ -224,6 +298,14 @@ func (mm *multipartyManager) SubmitNetworkAction(ctx context.Context, signingKey
 	if action.Type != core.NetworkActionTerminate {
 		return i18n.NewError(ctx, coremsgs.MsgUnrecognizedNetworkAction, action.Type)
 	}
+	payload, err := networkActionPayload(ctx, &action.NetworkActionSchedule)
+	if err != nil {
+		return err
+	}
+	actionType := action.Type
+	if payload != "" {
+		actionType = core.NetworkActionScheduledTerminate
+	}
 
 	txid, err := mm.txHelper.SubmitNewTransaction(ctx, core.TransactionTypeNetworkAction, "")
 	if err != nil {
@@ -235,15 +317,79 @@ func (mm *multipartyManager) SubmitNetworkAction(ctx context.Context, signingKey
 		mm.namespace.Name,
 		txid,
 		core.OpTypeBlockchainNetworkAction)
-	addNetworkActionInputs(op, action.Type, signingKey)
+	addNetworkActionInputs(op, actionType, signingKey, payload)
 	if err := mm.operations.AddOrReuseOperation(ctx, op); err != nil {
 		return err
 	}
 
-	_, err = mm.operations.RunOperation(ctx, opNetworkAction(op, action.Type, signingKey), idempotentSubmit)
+	_, err = mm.operations.RunOperation(ctx, opNetworkAction(op, actionType, signingKey, payload), idempotentSubmit)
+	return err
+}
+
+func (mm *multipartyManager) SubmitAuditAnchor(ctx context.Context, signingKey string, anchor *core.AuditAnchor) error {
+	var op *core.Operation
+	err := mm.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		txid, err := mm.txHelper.SubmitNewTransaction(ctx, core.TransactionTypeBatchPin, "")
+		if err != nil {
+			return err
+		}
+		anchor.TX = core.TransactionRef{Type: core.TransactionTypeBatchPin, ID: txid}
+		if err := mm.database.InsertAuditAnchor(ctx, anchor); err != nil {
+			return err
+		}
+
+		op = core.NewOperation(
+			mm.blockchain,
+			mm.namespace.Name,
+			txid,
+			core.OpTypeBlockchainPinAuditAnchor)
+		addAuditAnchorPinInputs(op, anchor.ID, anchor.Root, signingKey)
+		return mm.operations.AddOrReuseOperation(ctx, op)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = mm.operations.RunOperation(ctx, opAuditAnchorPin(op, anchor.ID, anchor.Root, signingKey), false)
 	return err
 }
 
+func (mm *multipartyManager) CheckAuditAnchorPin(ctx context.Context, anchor *core.AuditAnchor, maxAttempts int, retryDelay time.Duration) (bool, bool, error) {
+	// Each attempt is a separate operation in the transaction of the anchor, so all are read to count the attempts
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	ops, _, err := mm.database.GetOperations(ctx, mm.namespace.Name, fb.And(
+		fb.Eq("tx", anchor.TX.ID),
+		fb.Eq("type", core.OpTypeBlockchainPinAuditAnchor),
+	).Sort("-created"))
+	if err != nil {
+		return false, false, err
+	}
+	if len(ops) == 0 {
+		log.L(ctx).Warnf("No pin operation found for audit anchor %s", anchor.ID)
+		return true, false, nil
+	}
+
+	switch op := ops[0]; op.Status {
+	case core.OpStatusSucceeded:
+		return true, false, nil
+	case core.OpStatusFailed:
+		attempts := len(ops)
+		if attempts >= maxAttempts {
+			log.L(ctx).Errorf("Pin of audit anchor %s failed after %d attempts - no further retries: %s", anchor.ID, attempts, op.Error)
+			return false, true, nil
+		}
+		delay := retryDelay << min(attempts-1, 16)
+		if op.Updated != nil && time.Since(*op.Updated.Time()) < delay {
+			return false, false, nil
+		}
+		log.L(ctx).Infof("Retrying failed pin operation %s for audit anchor %s (attempt %d/%d)", op.ID, anchor.ID, attempts+1, maxAttempts)
+		_, err := mm.operations.RetryOperation(ctx, op.ID)
+		return false, false, err
+	default:
+		return false, false, nil
+	}
+}
+
 func (mm *multipartyManager) prepareInvokeOperation(ctx context.Context, batch *core.BatchPersisted, contexts []*fftypes.Bytes32, payloadRef string) (*core.PreparedOperation, error) {
 	op, err := mm.txHelper.FindOperationInTransaction(ctx, batch.TX.ID, core.OpTypeBlockchainInvoke)
 	if err != nil || op == nil {
@@ -285,6 +431,7 @@ func (mm *multipartyManager) SubmitBatchPin(ctx context.Context, batch *core.Bat
 	if mm.metrics.IsMetricsEnabled() {
 		mm.metrics.CountBatchPin(mm.namespace.Name)
 	}
+	tracing.Logf(ctx, "Submitting pin of batch %s with %d contexts operation=%s", batch.ID, len(contexts), op.ID)
 	_, err := mm.operations.RunOperation(ctx, opBatchPin(op, batch, contexts, payloadRef), idempotentSubmit)
 	return err
 }
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -92,6 +93,7 @@ func TestNewMultipartyManager(t *testing.T) {
 	mom.On("RegisterHandler", mock.Anything, mock.Anything, []core.OpType{
 		core.OpTypeBlockchainPinBatch,
 		core.OpTypeBlockchainNetworkAction,
+		core.OpTypeBlockchainPinAuditAnchor,
 	}).Return()
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
 	nm, err := NewMultipartyManager(context.Background(), ns, config, mdi, mbi, mom, mmi, mth)
@@ -330,6 +332,158 @@ func TestSubmitNetworkActionTXFail(t *testing.T) {
 	mp.mth.AssertExpectations(t)
 }
 
+func TestSubmitAuditAnchor(t *testing.T) {
+	txid := fftypes.NewUUID()
+	anchor := &core.AuditAnchor{
+		ID:   fftypes.NewUUID(),
+		Root: fftypes.NewRandB32(),
+	}
+
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	rag := mp.mdi.On("RunAsGroup", mock.Anything, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
+	}
+	mp.mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeBatchPin, core.IdempotencyKey("")).Return(txid, nil)
+	mp.mdi.On("InsertAuditAnchor", mock.Anything, anchor).Return(nil)
+	mp.mbi.On("Name").Return("ut")
+	mp.mom.On("AddOrReuseOperation", mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
+		assert.Equal(t, core.OpTypeBlockchainPinAuditAnchor, op.Type)
+		assert.Equal(t, *txid, *op.Transaction)
+		assert.Equal(t, anchor.Root.String(), op.Input.GetString("root"))
+		return true
+	})).Return(nil)
+	mp.mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(auditAnchorPinData)
+		return *data.TX == *txid && *data.Anchor == *anchor.ID && data.Key == "0x123"
+	}), false).Return(nil, nil)
+
+	err := mp.SubmitAuditAnchor(context.Background(), "0x123", anchor)
+	assert.NoError(t, err)
+	assert.Equal(t, core.TransactionTypeBatchPin, anchor.TX.Type)
+	assert.Equal(t, *txid, *anchor.TX.ID)
+
+	mp.mth.AssertExpectations(t)
+	mp.mom.AssertExpectations(t)
+}
+
+func TestSubmitAuditAnchorTXFail(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	rag := mp.mdi.On("RunAsGroup", mock.Anything, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
+	}
+	mp.mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeBatchPin, core.IdempotencyKey("")).Return(nil, fmt.Errorf("pop"))
+
+	err := mp.SubmitAuditAnchor(context.Background(), "0x123", &core.AuditAnchor{ID: fftypes.NewUUID()})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSubmitAuditAnchorInsertFail(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	rag := mp.mdi.On("RunAsGroup", mock.Anything, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
+	}
+	mp.mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeBatchPin, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mp.mdi.On("InsertAuditAnchor", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := mp.SubmitAuditAnchor(context.Background(), "0x123", &core.AuditAnchor{ID: fftypes.NewUUID()})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckAuditAnchorPin(t *testing.T) {
+	anchor := &core.AuditAnchor{
+		ID: fftypes.NewUUID(),
+		TX: core.TransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+	}
+	failed := &core.Operation{ID: fftypes.NewUUID(), Status: core.OpStatusFailed}
+
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	mp.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{{Status: core.OpStatusSucceeded}}, nil, nil).Once()
+	mp.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{{Status: core.OpStatusPending}}, nil, nil).Once()
+	mp.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{failed}, nil, nil).Once()
+	mp.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil).Once()
+	mp.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	mp.mom.On("RetryOperation", mock.Anything, failed.ID).Return(&core.Operation{}, nil)
+
+	pinned, exhausted, err := mp.CheckAuditAnchorPin(context.Background(), anchor, 5, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, pinned)
+	assert.False(t, exhausted)
+
+	pinned, _, err = mp.CheckAuditAnchorPin(context.Background(), anchor, 5, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, pinned)
+
+	pinned, exhausted, err = mp.CheckAuditAnchorPin(context.Background(), anchor, 5, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, pinned)
+	assert.False(t, exhausted)
+
+	pinned, _, err = mp.CheckAuditAnchorPin(context.Background(), anchor, 5, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, pinned)
+
+	_, _, err = mp.CheckAuditAnchorPin(context.Background(), anchor, 5, time.Minute)
+	assert.EqualError(t, err, "pop")
+
+	mp.mom.AssertExpectations(t)
+}
+
+func TestCheckAuditAnchorPinBackoff(t *testing.T) {
+	anchor := &core.AuditAnchor{
+		ID: fftypes.NewUUID(),
+		TX: core.TransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+	}
+	justFailed := &core.Operation{ID: fftypes.NewUUID(), Status: core.OpStatusFailed, Updated: fftypes.Now()}
+	earlier := &core.Operation{ID: fftypes.NewUUID(), Status: core.OpStatusFailed}
+
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	// The second attempt failed just now, so the retry waits for double the retry delay
+	mp.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{justFailed, earlier}, nil, nil)
+
+	pinned, exhausted, err := mp.CheckAuditAnchorPin(context.Background(), anchor, 5, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, pinned)
+	assert.False(t, exhausted)
+
+	mp.mom.AssertNotCalled(t, "RetryOperation", mock.Anything, mock.Anything)
+}
+
+func TestCheckAuditAnchorPinExhausted(t *testing.T) {
+	anchor := &core.AuditAnchor{
+		ID: fftypes.NewUUID(),
+		TX: core.TransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+	}
+
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	mp.mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{
+		{ID: fftypes.NewUUID(), Status: core.OpStatusFailed},
+		{ID: fftypes.NewUUID(), Status: core.OpStatusFailed},
+		{ID: fftypes.NewUUID(), Status: core.OpStatusFailed},
+	}, nil, nil)
+
+	pinned, exhausted, err := mp.CheckAuditAnchorPin(context.Background(), anchor, 3, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, pinned)
+	assert.True(t, exhausted)
+
+	mp.mom.AssertNotCalled(t, "RetryOperation", mock.Anything, mock.Anything)
+}
+
 func TestConfigureContractLookupBlockchainEventFail(t *testing.T) {
 	location := fftypes.JSONAnyPtr(fftypes.JSONObject{
 		"address": "0x123",
@@ -0,0 +1,113 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multiparty
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (mm *multipartyManager) GetContractMigration() *core.ContractMigration {
+	mm.contractMux.Lock()
+	defer mm.contractMux.Unlock()
+	if mm.namespace.Contracts == nil || mm.namespace.Contracts.Migration == nil {
+		return nil
+	}
+	migration := *mm.namespace.Contracts.Migration
+	migration.Members = append([]string{}, migration.Members...)
+	migration.Acknowledgements = append([]string{}, migration.Acknowledgements...)
+	return &migration
+}
+
+func (mm *multipartyManager) SetContractMigration(ctx context.Context, migration *core.ContractMigration) error {
+	mm.contractMux.Lock()
+	defer mm.contractMux.Unlock()
+	mm.namespace.Contracts.Migration = migration
+	return mm.database.UpsertNamespace(ctx, mm.namespace, true)
+}
+
+func (mm *multipartyManager) ValidateContractMigration(ctx context.Context, location *fftypes.JSONAny, version int) error {
+	mm.contractMux.Lock()
+	nextIndex := mm.namespace.Contracts.Active.Index + 1
+	mm.contractMux.Unlock()
+
+	next, err := mm.resolveFireFlyContract(ctx, nextIndex)
+	if err != nil {
+		return err
+	}
+	if next.Location.String() != location.String() {
+		return i18n.NewError(ctx, coremsgs.MsgContractMigrationLocationMismatch, location, next.Location)
+	}
+	if version > 0 {
+		nextVersion, err := mm.blockchain.GetNetworkVersion(ctx, next.Location)
+		if err != nil {
+			return err
+		}
+		if nextVersion != version {
+			return i18n.NewError(ctx, coremsgs.MsgContractMigrationVersionMismatch, version, nextVersion)
+		}
+	}
+	return nil
+}
+
+func (mm *multipartyManager) CheckContractMigration(ctx context.Context, event *blockchain.Event) error {
+	mm.contractMux.Lock()
+	defer mm.contractMux.Unlock()
+	contracts := mm.namespace.Contracts
+	migration := contracts.Migration
+	if migration == nil || migration.State != core.ContractMigrationStateReady {
+		return nil
+	}
+	blockNumber := event.Info.GetInt64("blockNumber")
+	if blockNumber < int64(migration.ActivationBlock) {
+		return nil
+	}
+
+	log.L(ctx).Infof("Migration %s reached activation block %d at event %s", migration.ID, migration.ActivationBlock, event.ProtocolID)
+	previous := contracts.Active
+	previousTerminated := contracts.Terminated
+	err := mm.terminateActiveContract(ctx, event)
+	if err == nil && contracts.Active.Location.String() != migration.Location.String() {
+		err = i18n.NewError(ctx, coremsgs.MsgContractMigrationLocationMismatch, migration.Location, contracts.Active.Location)
+	}
+	if err == nil && migration.Version > 0 && contracts.Active.Info.Version != migration.Version {
+		err = i18n.NewError(ctx, coremsgs.MsgContractMigrationVersionMismatch, migration.Version, contracts.Active.Info.Version)
+	}
+	migration.Updated = fftypes.Now()
+	if err != nil {
+		log.L(ctx).Errorf("Rolling back migration %s to contract #%d: %s", migration.ID, previous.Index, err)
+		if contracts.Active.Info.Subscription != "" {
+			mm.blockchain.RemoveFireflySubscription(ctx, contracts.Active.Info.Subscription)
+		}
+		previous.Info.FinalEvent = ""
+		contracts.Active = previous
+		contracts.Terminated = previousTerminated
+		migration.State = core.ContractMigrationStateRolledBack
+		migration.Error = err.Error()
+		return mm.configureContractCommon(ctx, true)
+	}
+
+	log.L(ctx).Infof("Migration %s switched to contract #%d at '%s'", migration.ID, contracts.Active.Index, contracts.Active.Location)
+	migration.State = core.ContractMigrationStateComplete
+	return mm.database.UpsertNamespace(ctx, mm.namespace, true)
+}
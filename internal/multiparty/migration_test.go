@@ -0,0 +1,277 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multiparty
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testOldLocation = fftypes.JSONAnyPtr(fftypes.JSONObject{"address": "0x123"}.String())
+	testNewLocation = fftypes.JSONAnyPtr(fftypes.JSONObject{"address": "0x456"}.String())
+)
+
+func newTestMigration(state core.ContractMigrationState) *core.ContractMigration {
+	return &core.ContractMigration{
+		ContractMigrationProposal: core.ContractMigrationProposal{
+			ID:              fftypes.NewUUID(),
+			Location:        testNewLocation,
+			ActivationBlock: 100,
+		},
+		Proposer:         "did:firefly:org/org1",
+		State:            state,
+		Members:          []string{"did:firefly:org/org1", "did:firefly:org/org2"},
+		Acknowledgements: []string{"did:firefly:org/org1", "did:firefly:org/org2"},
+	}
+}
+
+func newTestMigrationManager(state core.ContractMigrationState) *testMultipartyManager {
+	mp := newTestMultipartyManager()
+	mp.namespace.Contracts = &core.MultipartyContracts{
+		Active: &core.MultipartyContract{
+			Index:    0,
+			Location: testOldLocation,
+			Info:     core.MultipartyContractInfo{Subscription: "sub1", Version: 2},
+		},
+		Migration: newTestMigration(state),
+	}
+	mp.config.Contracts = []blockchain.MultipartyContract{
+		{Location: testOldLocation},
+		{Location: testNewLocation},
+	}
+	return mp
+}
+
+func migrationEvent(block int64) *blockchain.Event {
+	return &blockchain.Event{
+		ProtocolID: fmt.Sprintf("%.12d/000000/000000", block),
+		Info:       fftypes.JSONObject{"blockNumber": fmt.Sprintf("%d", block)},
+	}
+}
+
+func TestGetContractMigration(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	migration := mp.GetContractMigration()
+	assert.Equal(t, mp.namespace.Contracts.Migration.ID, migration.ID)
+	migration.Acknowledgements = append(migration.Acknowledgements, "did:firefly:org/org3")
+	assert.Len(t, mp.namespace.Contracts.Migration.Acknowledgements, 2)
+}
+
+func TestGetContractMigrationNone(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	assert.Nil(t, mp.GetContractMigration())
+}
+
+func TestSetContractMigration(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	migration := newTestMigration(core.ContractMigrationStateProposed)
+	mp.mdi.On("UpsertNamespace", context.Background(), mp.namespace, true).Return(nil)
+
+	err := mp.SetContractMigration(context.Background(), migration)
+	assert.NoError(t, err)
+	assert.Equal(t, migration, mp.namespace.Contracts.Migration)
+}
+
+func TestValidateContractMigration(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	mp.mbi.On("GetNetworkVersion", context.Background(), testNewLocation).Return(2, nil)
+
+	err := mp.ValidateContractMigration(context.Background(), testNewLocation, 2)
+	assert.NoError(t, err)
+}
+
+func TestValidateContractMigrationNoVersion(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	err := mp.ValidateContractMigration(context.Background(), testNewLocation, 0)
+	assert.NoError(t, err)
+}
+
+func TestValidateContractMigrationNoNextContract(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	mp.config.Contracts = mp.config.Contracts[0:1]
+
+	err := mp.ValidateContractMigration(context.Background(), testNewLocation, 0)
+	assert.Regexp(t, "FF10396", err)
+}
+
+func TestValidateContractMigrationWrongLocation(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	err := mp.ValidateContractMigration(context.Background(), testOldLocation, 0)
+	assert.Regexp(t, "FF10498", err)
+}
+
+func TestValidateContractMigrationVersionFail(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	mp.mbi.On("GetNetworkVersion", context.Background(), testNewLocation).Return(0, fmt.Errorf("pop"))
+
+	err := mp.ValidateContractMigration(context.Background(), testNewLocation, 2)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestValidateContractMigrationWrongVersion(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	mp.mbi.On("GetNetworkVersion", context.Background(), testNewLocation).Return(1, nil)
+
+	err := mp.ValidateContractMigration(context.Background(), testNewLocation, 2)
+	assert.Regexp(t, "FF10499", err)
+}
+
+func TestCheckContractMigrationNotReady(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	err := mp.CheckContractMigration(context.Background(), migrationEvent(200))
+	assert.NoError(t, err)
+	assert.Equal(t, testOldLocation, mp.namespace.Contracts.Active.Location)
+}
+
+func TestCheckContractMigrationBeforeActivation(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateReady)
+	defer mp.cleanup(t)
+
+	err := mp.CheckContractMigration(context.Background(), migrationEvent(99))
+	assert.NoError(t, err)
+	assert.Equal(t, testOldLocation, mp.namespace.Contracts.Active.Location)
+}
+
+func TestCheckContractMigrationSwitch(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateReady)
+	defer mp.cleanup(t)
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mbi.On("GetNetworkVersion", mock.Anything, testNewLocation).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.Anything, "").Return("sub2", nil)
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	err := mp.CheckContractMigration(context.Background(), migrationEvent(100))
+	assert.NoError(t, err)
+
+	contracts := mp.namespace.Contracts
+	assert.Equal(t, 1, contracts.Active.Index)
+	assert.Equal(t, testNewLocation, contracts.Active.Location)
+	assert.Equal(t, "sub2", contracts.Active.Info.Subscription)
+	assert.Len(t, contracts.Terminated, 1)
+	assert.Equal(t, "000000000100/000000/000000", contracts.Terminated[0].Info.FinalEvent)
+	assert.Equal(t, core.ContractMigrationStateComplete, contracts.Migration.State)
+	assert.NotNil(t, contracts.Migration.Updated)
+}
+
+func TestCheckContractMigrationVersionRollback(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateReady)
+	defer mp.cleanup(t)
+	mp.namespace.Contracts.Migration.Version = 2
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub2").Return()
+	mp.mbi.On("GetNetworkVersion", mock.Anything, testNewLocation).Return(1, nil)
+	mp.mbi.On("GetNetworkVersion", mock.Anything, testOldLocation).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, &mp.config.Contracts[1], "").Return("sub2", nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, &mp.config.Contracts[0], "").Return("sub3", nil)
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	err := mp.CheckContractMigration(context.Background(), migrationEvent(101))
+	assert.NoError(t, err)
+
+	contracts := mp.namespace.Contracts
+	assert.Equal(t, 0, contracts.Active.Index)
+	assert.Equal(t, testOldLocation, contracts.Active.Location)
+	assert.Equal(t, "sub3", contracts.Active.Info.Subscription)
+	assert.Empty(t, contracts.Active.Info.FinalEvent)
+	assert.Empty(t, contracts.Terminated)
+	assert.Equal(t, core.ContractMigrationStateRolledBack, contracts.Migration.State)
+	assert.Regexp(t, "FF10499", contracts.Migration.Error)
+}
+
+func TestCheckContractMigrationLocationRollback(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateReady)
+	defer mp.cleanup(t)
+	mp.namespace.Contracts.Migration.Location = fftypes.JSONAnyPtr(fftypes.JSONObject{"address": "0x789"}.String())
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub2").Return()
+	mp.mbi.On("GetNetworkVersion", mock.Anything, mock.Anything).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, &mp.config.Contracts[1], "").Return("sub2", nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, &mp.config.Contracts[0], "").Return("sub3", nil)
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	err := mp.CheckContractMigration(context.Background(), migrationEvent(100))
+	assert.NoError(t, err)
+	assert.Equal(t, core.ContractMigrationStateRolledBack, mp.namespace.Contracts.Migration.State)
+	assert.Regexp(t, "FF10498", mp.namespace.Contracts.Migration.Error)
+}
+
+func TestCheckContractMigrationNoNextContractRollback(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateReady)
+	defer mp.cleanup(t)
+	mp.config.Contracts = mp.config.Contracts[0:1]
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mbi.On("GetNetworkVersion", mock.Anything, testOldLocation).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, &mp.config.Contracts[0], "").Return("sub3", nil)
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	err := mp.CheckContractMigration(context.Background(), migrationEvent(100))
+	assert.NoError(t, err)
+	assert.Equal(t, "sub3", mp.namespace.Contracts.Active.Info.Subscription)
+	assert.Equal(t, core.ContractMigrationStateRolledBack, mp.namespace.Contracts.Migration.State)
+	assert.Regexp(t, "FF10396", mp.namespace.Contracts.Migration.Error)
+}
+
+func TestTerminateContractCompletesMigration(t *testing.T) {
+	mp := newTestMigrationManager(core.ContractMigrationStateProposed)
+	defer mp.cleanup(t)
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mbi.On("GetNetworkVersion", mock.Anything, testNewLocation).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.Anything, "").Return("sub2", nil)
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	err := mp.TerminateContract(context.Background(), testOldLocation, migrationEvent(50))
+	assert.NoError(t, err)
+	assert.Equal(t, core.ContractMigrationStateComplete, mp.namespace.Contracts.Migration.State)
+}
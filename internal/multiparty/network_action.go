@@ -0,0 +1,115 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multiparty
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// networkActionPayload validates the schedule of a network action being submitted, and encodes it as the payload
+// written to the blockchain with the action. Actions without a schedule have an empty payload, as before.
+func networkActionPayload(ctx context.Context, schedule *core.NetworkActionSchedule) (string, error) {
+	if !schedule.IsScheduled() {
+		return "", nil
+	}
+	if schedule.EffectiveBlock > 0 && schedule.EffectiveTime != nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgNetworkActionScheduleConflict)
+	}
+	if schedule.EffectiveTime != nil && !schedule.EffectiveTime.Time().After(time.Now()) {
+		return "", i18n.NewError(ctx, coremsgs.MsgNetworkActionScheduleInPast)
+	}
+	payload, _ := json.Marshal(schedule)
+	return string(payload), nil
+}
+
+// networkActionDue is true if the event is at or after the effective point of the schedule. Only the block number and
+// timestamp of the event are used, so every member reaches the same decision for the same event.
+func networkActionDue(schedule *core.NetworkActionSchedule, event *blockchain.Event) bool {
+	if schedule.EffectiveBlock > 0 && event.Info.GetInt64("blockNumber") < int64(schedule.EffectiveBlock) {
+		return false
+	}
+	if schedule.EffectiveTime != nil && (event.Timestamp == nil || event.Timestamp.Time().Before(*schedule.EffectiveTime.Time())) {
+		return false
+	}
+	return true
+}
+
+func (mm *multipartyManager) GetScheduledNetworkActions() []*core.ScheduledNetworkAction {
+	mm.contractMux.Lock()
+	defer mm.contractMux.Unlock()
+	if mm.namespace.Contracts == nil {
+		return []*core.ScheduledNetworkAction{}
+	}
+	return append([]*core.ScheduledNetworkAction{}, mm.namespace.Contracts.Scheduled...)
+}
+
+func (mm *multipartyManager) ScheduleNetworkAction(ctx context.Context, scheduled []*core.ScheduledNetworkAction, action *core.ScheduledNetworkAction, event *blockchain.Event) ([]*core.ScheduledNetworkAction, bool) {
+	if networkActionDue(&action.NetworkActionSchedule, event) {
+		return scheduled, false
+	}
+	log.L(ctx).Infof("Scheduling network action %s from '%s' at event %s (effectiveBlock=%d effectiveTime=%v)",
+		action.Type, action.Author, action.Event, action.EffectiveBlock, action.EffectiveTime)
+	return append(append(make([]*core.ScheduledNetworkAction, 0, len(scheduled)+1), scheduled...), action), true
+}
+
+func (mm *multipartyManager) ApplyScheduledNetworkActions(ctx context.Context, scheduled []*core.ScheduledNetworkAction, event *blockchain.Event) ([]*core.ScheduledNetworkAction, error) {
+	var due []*core.ScheduledNetworkAction
+	remaining := make([]*core.ScheduledNetworkAction, 0, len(scheduled))
+	for _, action := range scheduled {
+		if networkActionDue(&action.NetworkActionSchedule, event) {
+			due = append(due, action)
+		} else {
+			remaining = append(remaining, action)
+		}
+	}
+	if len(due) == 0 {
+		return scheduled, nil
+	}
+
+	// Actions are applied in the order they were received. Terminate is the only action that can be scheduled.
+	for _, action := range due {
+		log.L(ctx).Infof("Network action %s scheduled at event %s reached its effective point at event %s", action.Type, action.Event, event.ProtocolID)
+		if err := mm.TerminateContract(ctx, action.Location, event); err != nil {
+			return nil, err
+		}
+	}
+	return remaining, nil
+}
+
+func (mm *multipartyManager) PersistScheduledNetworkActions(ctx context.Context, scheduled []*core.ScheduledNetworkAction) error {
+	mm.contractMux.Lock()
+	defer mm.contractMux.Unlock()
+	ns := *mm.namespace
+	contracts := *ns.Contracts
+	contracts.Scheduled = scheduled
+	ns.Contracts = &contracts
+	return mm.database.UpsertNamespace(ctx, &ns, true)
+}
+
+func (mm *multipartyManager) SetScheduledNetworkActions(scheduled []*core.ScheduledNetworkAction) {
+	mm.contractMux.Lock()
+	defer mm.contractMux.Unlock()
+	mm.namespace.Contracts.Scheduled = scheduled
+}
@@ -0,0 +1,232 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multiparty
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestScheduledAction(block uint64) *core.ScheduledNetworkAction {
+	return &core.ScheduledNetworkAction{
+		NetworkAction: core.NetworkAction{
+			Type:                  core.NetworkActionTerminate,
+			NetworkActionSchedule: core.NetworkActionSchedule{EffectiveBlock: block},
+		},
+		Author:   "did:firefly:org/org1",
+		Location: testOldLocation,
+		Event:    "000000000050/000000/000000",
+	}
+}
+
+func newTestScheduleManager(actions ...*core.ScheduledNetworkAction) *testMultipartyManager {
+	mp := newTestMigrationManager(core.ContractMigrationStateComplete)
+	mp.namespace.Contracts.Scheduled = actions
+	return mp
+}
+
+func TestNetworkActionPayload(t *testing.T) {
+	payload, err := networkActionPayload(context.Background(), &core.NetworkActionSchedule{})
+	assert.NoError(t, err)
+	assert.Empty(t, payload)
+
+	payload, err = networkActionPayload(context.Background(), &core.NetworkActionSchedule{EffectiveBlock: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"effectiveBlock":100}`, payload)
+
+	future := fftypes.FFTime(time.Now().Add(time.Hour))
+	_, err = networkActionPayload(context.Background(), &core.NetworkActionSchedule{EffectiveBlock: 100, EffectiveTime: &future})
+	assert.Regexp(t, "FF10646", err)
+
+	past := fftypes.FFTime(time.Now().Add(-time.Hour))
+	_, err = networkActionPayload(context.Background(), &core.NetworkActionSchedule{EffectiveTime: &past})
+	assert.Regexp(t, "FF10647", err)
+}
+
+func TestNetworkActionDue(t *testing.T) {
+	assert.False(t, networkActionDue(&core.NetworkActionSchedule{EffectiveBlock: 100}, migrationEvent(99)))
+	assert.True(t, networkActionDue(&core.NetworkActionSchedule{EffectiveBlock: 100}, migrationEvent(100)))
+
+	effective := fftypes.FFTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	before := fftypes.FFTime(time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC))
+	assert.False(t, networkActionDue(&core.NetworkActionSchedule{EffectiveTime: &effective}, &blockchain.Event{}))
+	assert.False(t, networkActionDue(&core.NetworkActionSchedule{EffectiveTime: &effective}, &blockchain.Event{Timestamp: &before}))
+	assert.True(t, networkActionDue(&core.NetworkActionSchedule{EffectiveTime: &effective}, &blockchain.Event{Timestamp: &effective}))
+}
+
+func TestSubmitNetworkActionScheduled(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	txid := fftypes.NewUUID()
+
+	mp.mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeNetworkAction, core.IdempotencyKey("")).Return(txid, nil)
+	mp.mbi.On("Name").Return("ut")
+	mp.mom.On("AddOrReuseOperation", context.Background(), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Input.GetString("payload") == `{"effectiveBlock":100}` &&
+			op.Input.GetString("type") == core.NetworkActionScheduledTerminate.String()
+	})).Return(nil)
+	mp.mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(networkActionData)
+		return data.Type == core.NetworkActionScheduledTerminate && data.Payload == `{"effectiveBlock":100}`
+	}), false).Return(nil, nil)
+
+	err := mp.SubmitNetworkAction(context.Background(), "0x123", &core.NetworkAction{
+		Type:                  core.NetworkActionTerminate,
+		NetworkActionSchedule: core.NetworkActionSchedule{EffectiveBlock: 100},
+	}, false)
+	assert.NoError(t, err)
+}
+
+func TestSubmitNetworkActionBadSchedule(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	past := fftypes.FFTime(time.Now().Add(-time.Hour))
+	err := mp.SubmitNetworkAction(context.Background(), "0x123", &core.NetworkAction{
+		Type:                  core.NetworkActionTerminate,
+		NetworkActionSchedule: core.NetworkActionSchedule{EffectiveTime: &past},
+	}, false)
+	assert.Regexp(t, "FF10647", err)
+}
+
+func TestGetScheduledNetworkActions(t *testing.T) {
+	mp := newTestScheduleManager(newTestScheduledAction(100))
+	defer mp.cleanup(t)
+
+	actions := mp.GetScheduledNetworkActions()
+	assert.Len(t, actions, 1)
+	actions[0] = nil
+	assert.NotNil(t, mp.namespace.Contracts.Scheduled[0])
+
+	mp.namespace.Contracts = nil
+	assert.Empty(t, mp.GetScheduledNetworkActions())
+}
+
+func TestScheduleNetworkAction(t *testing.T) {
+	existing := newTestScheduledAction(200)
+	mp := newTestScheduleManager(existing)
+	defer mp.cleanup(t)
+
+	action := newTestScheduledAction(100)
+	current := mp.GetScheduledNetworkActions()
+	updated, scheduled := mp.ScheduleNetworkAction(context.Background(), current, action, migrationEvent(50))
+	assert.True(t, scheduled)
+	assert.Equal(t, []*core.ScheduledNetworkAction{existing, action}, updated)
+	// Neither the schedule passed in, nor the schedule in memory, are changed until it is swapped in
+	assert.Equal(t, []*core.ScheduledNetworkAction{existing}, current)
+	assert.Equal(t, []*core.ScheduledNetworkAction{existing}, mp.namespace.Contracts.Scheduled)
+}
+
+func TestScheduleNetworkActionAlreadyDue(t *testing.T) {
+	mp := newTestScheduleManager()
+	defer mp.cleanup(t)
+
+	updated, scheduled := mp.ScheduleNetworkAction(context.Background(), nil, newTestScheduledAction(100), migrationEvent(100))
+	assert.False(t, scheduled)
+	assert.Empty(t, updated)
+}
+
+func TestApplyScheduledNetworkActionsNone(t *testing.T) {
+	mp := newTestScheduleManager()
+	defer mp.cleanup(t)
+
+	remaining, err := mp.ApplyScheduledNetworkActions(context.Background(), nil, migrationEvent(100))
+	assert.NoError(t, err)
+	assert.Empty(t, remaining)
+}
+
+func TestApplyScheduledNetworkActionsNotDue(t *testing.T) {
+	action := newTestScheduledAction(100)
+	mp := newTestScheduleManager(action)
+	defer mp.cleanup(t)
+
+	remaining, err := mp.ApplyScheduledNetworkActions(context.Background(), mp.GetScheduledNetworkActions(), migrationEvent(99))
+	assert.NoError(t, err)
+	assert.Equal(t, []*core.ScheduledNetworkAction{action}, remaining)
+}
+
+func TestApplyScheduledNetworkActionsTerminate(t *testing.T) {
+	later := newTestScheduledAction(200)
+	mp := newTestScheduleManager(newTestScheduledAction(100), later)
+	defer mp.cleanup(t)
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mbi.On("GetNetworkVersion", mock.Anything, testNewLocation).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.Anything, "").Return("sub2", nil)
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	remaining, err := mp.ApplyScheduledNetworkActions(context.Background(), mp.GetScheduledNetworkActions(), migrationEvent(150))
+	assert.NoError(t, err)
+	assert.Equal(t, []*core.ScheduledNetworkAction{later}, remaining)
+
+	contracts := mp.namespace.Contracts
+	assert.Equal(t, testNewLocation, contracts.Active.Location)
+	assert.Equal(t, "000000000150/000000/000000", contracts.Terminated[0].Info.FinalEvent)
+	assert.Len(t, contracts.Scheduled, 2)
+}
+
+func TestApplyScheduledNetworkActionsOtherContract(t *testing.T) {
+	action := newTestScheduledAction(100)
+	action.Location = testNewLocation
+	mp := newTestScheduleManager(action)
+	defer mp.cleanup(t)
+
+	remaining, err := mp.ApplyScheduledNetworkActions(context.Background(), mp.GetScheduledNetworkActions(), migrationEvent(100))
+	assert.NoError(t, err)
+	assert.Equal(t, testOldLocation, mp.namespace.Contracts.Active.Location)
+	assert.Empty(t, remaining)
+}
+
+func TestApplyScheduledNetworkActionsFail(t *testing.T) {
+	action := newTestScheduledAction(100)
+	mp := newTestScheduleManager(action)
+	defer mp.cleanup(t)
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mbi.On("GetNetworkVersion", mock.Anything, testNewLocation).Return(0, fmt.Errorf("pop"))
+
+	_, err := mp.ApplyScheduledNetworkActions(context.Background(), mp.GetScheduledNetworkActions(), migrationEvent(100))
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, []*core.ScheduledNetworkAction{action}, mp.namespace.Contracts.Scheduled)
+}
+
+func TestPersistAndSetScheduledNetworkActions(t *testing.T) {
+	action := newTestScheduledAction(100)
+	mp := newTestScheduleManager()
+	defer mp.cleanup(t)
+
+	mp.mdi.On("UpsertNamespace", mock.Anything, mock.MatchedBy(func(ns *core.Namespace) bool {
+		return ns != mp.namespace && ns.Name == mp.namespace.Name &&
+			len(ns.Contracts.Scheduled) == 1 && ns.Contracts.Active == mp.namespace.Contracts.Active
+	}), true).Return(nil)
+
+	err := mp.PersistScheduledNetworkActions(context.Background(), []*core.ScheduledNetworkAction{action})
+	assert.NoError(t, err)
+	assert.Empty(t, mp.namespace.Contracts.Scheduled)
+
+	mp.SetScheduledNetworkActions([]*core.ScheduledNetworkAction{action})
+	assert.Equal(t, []*core.ScheduledNetworkAction{action}, mp.namespace.Contracts.Scheduled)
+}
@@ -29,8 +29,16 @@ import (
 )
 
 type networkActionData struct {
-	Type core.NetworkActionType `json:"type"`
-	Key  string                 `json:"key"`
+	Type    core.NetworkActionType `json:"type"`
+	Key     string                 `json:"key"`
+	Payload string                 `json:"payload,omitempty"`
+}
+
+type auditAnchorPinData struct {
+	TX     *fftypes.UUID    `json:"tx"`
+	Anchor *fftypes.UUID    `json:"anchor"`
+	Root   *fftypes.Bytes32 `json:"root"`
+	Key    string           `json:"key"`
 }
 
 func addBatchPinInputs(op *core.Operation, batchID *fftypes.UUID, contexts []*fftypes.Bytes32, payloadRef string) {
@@ -45,11 +53,22 @@ func addBatchPinInputs(op *core.Operation, batchID *fftypes.UUID, contexts []*ff
 	}
 }
 
-func addNetworkActionInputs(op *core.Operation, actionType core.NetworkActionType, signingKey string) {
+func addNetworkActionInputs(op *core.Operation, actionType core.NetworkActionType, signingKey, payload string) {
 	op.Input = fftypes.JSONObject{
 		"type": actionType.String(),
 		"key":  signingKey,
 	}
+	if payload != "" {
+		op.Input["payload"] = payload
+	}
+}
+
+func addAuditAnchorPinInputs(op *core.Operation, anchorID *fftypes.UUID, root *fftypes.Bytes32, signingKey string) {
+	op.Input = fftypes.JSONObject{
+		"anchor": anchorID.String(),
+		"root":   root.String(),
+		"key":    signingKey,
+	}
 }
 
 func retrieveBatchPinInputs(ctx context.Context, op *core.Operation) (batchID *fftypes.UUID, contexts []*fftypes.Bytes32, payloadRef string, err error) {
@@ -69,10 +88,19 @@ func retrieveBatchPinInputs(ctx context.Context, op *core.Operation) (batchID *f
 	return batchID, contexts, payloadRef, nil
 }
 
-func retrieveNetworkActionInputs(op *core.Operation) (actionType core.NetworkActionType, signingKey string) {
+func retrieveNetworkActionInputs(op *core.Operation) (actionType core.NetworkActionType, signingKey, payload string) {
 	actionType = fftypes.FFEnum(op.Input.GetString("type"))
 	signingKey = op.Input.GetString("key")
-	return actionType, signingKey
+	payload = op.Input.GetString("payload")
+	return actionType, signingKey, payload
+}
+
+func retrieveAuditAnchorPinInputs(ctx context.Context, op *core.Operation) (anchorID *fftypes.UUID, root *fftypes.Bytes32, signingKey string, err error) {
+	anchorID, err = fftypes.ParseUUID(ctx, op.Input.GetString("anchor"))
+	if err == nil {
+		root, err = fftypes.ParseBytes32(ctx, op.Input.GetString("root"))
+	}
+	return anchorID, root, op.Input.GetString("key"), err
 }
 
 func (mm *multipartyManager) PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error) {
@@ -91,8 +119,15 @@ func (mm *multipartyManager) PrepareOperation(ctx context.Context, op *core.Oper
 		return opBatchPin(op, batch, contexts, payloadRef), nil
 
 	case core.OpTypeBlockchainNetworkAction:
-		actionType, signingKey := retrieveNetworkActionInputs(op)
-		return opNetworkAction(op, actionType, signingKey), nil
+		actionType, signingKey, payload := retrieveNetworkActionInputs(op)
+		return opNetworkAction(op, actionType, signingKey, payload), nil
+
+	case core.OpTypeBlockchainPinAuditAnchor:
+		anchorID, root, signingKey, err := retrieveAuditAnchorPinInputs(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		return opAuditAnchorPin(op, anchorID, root, signingKey), nil
 
 	default:
 		return nil, i18n.NewError(ctx, coremsgs.MsgOperationNotSupported, op.Type)
@@ -114,7 +149,18 @@ func (mm *multipartyManager) RunOperation(ctx context.Context, op *core.Prepared
 		return nil, operations.ErrTernary(err, core.OpPhaseInitializing, core.OpPhasePending), err
 	case networkActionData:
 		contract := mm.namespace.Contracts.Active
-		err = mm.blockchain.SubmitNetworkAction(ctx, op.NamespacedIDString(), data.Key, data.Type, contract.Location)
+		err = mm.blockchain.SubmitNetworkAction(ctx, op.NamespacedIDString(), data.Key, data.Type, data.Payload, contract.Location)
+		return nil, operations.ErrTernary(err, core.OpPhaseInitializing, core.OpPhasePending), err
+	case auditAnchorPinData:
+		// The root is pinned exactly like a batch with no contexts, and no payload to download,
+		// so other members of the network record the transaction without taking any action
+		contract := mm.namespace.Contracts.Active
+		err = mm.blockchain.SubmitBatchPin(ctx, op.NamespacedIDString(), mm.namespace.NetworkName, data.Key, &blockchain.BatchPin{
+			TransactionID: data.TX,
+			BatchID:       data.Anchor,
+			BatchHash:     data.Root,
+			Contexts:      []*fftypes.Bytes32{},
+		}, contract.Location)
 		return nil, operations.ErrTernary(err, core.OpPhaseInitializing, core.OpPhasePending), err
 	default:
 		return nil, core.OpPhaseInitializing, i18n.NewError(ctx, coremsgs.MsgOperationDataIncorrect, op.Data)
@@ -135,15 +181,31 @@ func opBatchPin(op *core.Operation, batch *core.BatchPersisted, contexts []*ffty
 	}
 }
 
-func opNetworkAction(op *core.Operation, actionType core.NetworkActionType, key string) *core.PreparedOperation {
+func opNetworkAction(op *core.Operation, actionType core.NetworkActionType, key, payload string) *core.PreparedOperation {
 	return &core.PreparedOperation{
 		ID:        op.ID,
 		Namespace: op.Namespace,
 		Plugin:    op.Plugin,
 		Type:      op.Type,
 		Data: networkActionData{
-			Type: actionType,
-			Key:  key,
+			Type:    actionType,
+			Key:     key,
+			Payload: payload,
+		},
+	}
+}
+
+func opAuditAnchorPin(op *core.Operation, anchorID *fftypes.UUID, root *fftypes.Bytes32, key string) *core.PreparedOperation {
+	return &core.PreparedOperation{
+		ID:        op.ID,
+		Namespace: op.Namespace,
+		Plugin:    op.Plugin,
+		Type:      op.Type,
+		Data: auditAnchorPinData{
+			TX:     op.Transaction,
+			Anchor: anchorID,
+			Root:   root,
+			Key:    key,
 		},
 	}
 }
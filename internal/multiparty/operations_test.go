@@ -23,6 +23,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -74,15 +75,16 @@ func TestPrepareAndRunNetworkAction(t *testing.T) {
 		ID:        fftypes.NewUUID(),
 		Namespace: "ns1",
 	}
-	addNetworkActionInputs(op, core.NetworkActionTerminate, "0x123")
+	addNetworkActionInputs(op, core.NetworkActionTerminate, "0x123", `{"effectiveBlock":100}`)
 
-	mp.mbi.On("SubmitNetworkAction", context.Background(), "ns1:"+op.ID.String(), "0x123", core.NetworkActionTerminate, mock.Anything).Return(nil)
+	mp.mbi.On("SubmitNetworkAction", context.Background(), "ns1:"+op.ID.String(), "0x123", core.NetworkActionTerminate, `{"effectiveBlock":100}`, mock.Anything).Return(nil)
 
 	po, err := mp.PrepareOperation(context.Background(), op)
 	assert.NoError(t, err)
 	assert.Equal(t, core.NetworkActionTerminate, po.Data.(networkActionData).Type)
+	assert.Equal(t, `{"effectiveBlock":100}`, po.Data.(networkActionData).Payload)
 
-	_, phase, err := mp.RunOperation(context.Background(), opNetworkAction(op, core.NetworkActionTerminate, "0x123"))
+	_, phase, err := mp.RunOperation(context.Background(), po)
 
 	assert.Equal(t, core.OpPhasePending, phase)
 	assert.NoError(t, err)
@@ -90,6 +92,56 @@ func TestPrepareAndRunNetworkAction(t *testing.T) {
 	mp.mbi.AssertExpectations(t)
 }
 
+func TestPrepareAndRunAuditAnchorPin(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	op := &core.Operation{
+		Type:        core.OpTypeBlockchainPinAuditAnchor,
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Transaction: fftypes.NewUUID(),
+	}
+	anchorID := fftypes.NewUUID()
+	root := fftypes.NewRandB32()
+	addAuditAnchorPinInputs(op, anchorID, root, "0x123")
+
+	mp.mbi.On("SubmitBatchPin", context.Background(), "ns1:"+op.ID.String(), "ns1", "0x123", mock.MatchedBy(func(pin *blockchain.BatchPin) bool {
+		return *pin.TransactionID == *op.Transaction &&
+			*pin.BatchID == *anchorID &&
+			*pin.BatchHash == *root &&
+			pin.BatchPayloadRef == "" &&
+			len(pin.Contexts) == 0
+	}), mock.Anything).Return(nil)
+
+	po, err := mp.PrepareOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, *root, *po.Data.(auditAnchorPinData).Root)
+
+	_, phase, err := mp.RunOperation(context.Background(), po)
+
+	assert.Equal(t, core.OpPhasePending, phase)
+	assert.NoError(t, err)
+
+	mp.mbi.AssertExpectations(t)
+}
+
+func TestPrepareOperationAuditAnchorPinBadRoot(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	op := &core.Operation{
+		Type: core.OpTypeBlockchainPinAuditAnchor,
+		Input: fftypes.JSONObject{
+			"anchor": fftypes.NewUUID().String(),
+			"root":   "bad",
+		},
+	}
+
+	_, err := mp.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF00107", err)
+}
+
 func TestPrepareOperationNotSupported(t *testing.T) {
 	mp := newTestMultipartyManager()
 	defer mp.cleanup(t)
@@ -0,0 +1,36 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (nm *namespaceManager) GetCaches(ctx context.Context) []*core.CacheStatus {
+	return nm.cacheManager.GetCacheStatus()
+}
+
+// InvalidateCache discards every entry of a cache, by its fully qualified name of "namespace:name"
+func (nm *namespaceManager) InvalidateCache(ctx context.Context, name string) error {
+	return nm.cacheManager.InvalidateCache(ctx, name)
+}
+
+func (nm *namespaceManager) InvalidateCacheKey(ctx context.Context, name, key string) error {
+	return nm.cacheManager.InvalidateCacheKey(ctx, name, key)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/cachemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCaches(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	mcm := &cachemocks.Manager{}
+	nm.cacheManager = mcm
+	mcm.On("GetCacheStatus").Return([]*core.CacheStatus{{Name: "ns1:cache.batch"}})
+
+	caches := nm.GetCaches(nm.ctx)
+	assert.Len(t, caches, 1)
+
+	mcm.AssertExpectations(t)
+}
+
+func TestInvalidateCache(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	mcm := &cachemocks.Manager{}
+	nm.cacheManager = mcm
+	mcm.On("InvalidateCache", mock.Anything, "ns1:cache.batch").Return(nil)
+	mcm.On("InvalidateCacheKey", mock.Anything, "ns1:cache.batch", "key1").Return(fmt.Errorf("pop"))
+
+	err := nm.InvalidateCache(nm.ctx, "ns1:cache.batch")
+	assert.NoError(t, err)
+	err = nm.InvalidateCacheKey(nm.ctx, "ns1:cache.batch", "key1")
+	assert.EqualError(t, err, "pop")
+
+	mcm.AssertExpectations(t)
+}
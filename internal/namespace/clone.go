@@ -0,0 +1,113 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/spf13/viper"
+)
+
+// CloneNamespace provisions a new namespace with the same plugin wiring as an existing template namespace,
+// and copies the selected local definitions from the template into it before it starts. Any multiparty
+// network namespace override is dropped from the copied configuration, so the clone never joins the
+// same network namespace as its template.
+//
+// As with UpdateNamespaceConfig, the new namespace is held in memory only - it is replaced by the
+// next reload of the configuration file, or restart.
+func (nm *namespaceManager) CloneNamespace(ctx context.Context, source string, clone *core.NamespaceClone) (*core.NamespaceCloneResult, error) {
+	if clone == nil {
+		clone = &core.NamespaceClone{}
+	}
+	if err := fftypes.ValidateFFNameField(ctx, clone.Name, "name"); err != nil {
+		return nil, err
+	}
+
+	nm.reloadMux.Lock()
+	defer nm.reloadMux.Unlock()
+
+	nm.nsMux.Lock()
+	sourceNS := nm.namespaces[source]
+	_, exists := nm.namespaces[clone.Name]
+	nm.nsMux.Unlock()
+	if sourceNS == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceDoesNotExist, source)
+	}
+	if exists {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceAlreadyExists, clone.Name)
+	}
+	sourceOr, err := nm.Orchestrator(ctx, source, false)
+	if err != nil {
+		return nil, err
+	}
+
+	predefined := nm.dumpRootConfig().GetObject("namespaces").GetObjectArray("predefined")
+	var nsConfig map[string]interface{}
+	for _, entry := range predefined {
+		if entry.GetString("name") == source {
+			nsConfig = lowerCaseConfigKeys(entry).(map[string]interface{})
+		}
+	}
+	if nsConfig == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceDoesNotExist, source)
+	}
+	nsConfig[coreconfig.NamespaceName] = clone.Name
+	if clone.Description != "" {
+		nsConfig[coreconfig.NamespaceDescription] = clone.Description
+	}
+	if multiparty, ok := nsConfig[coreconfig.NamespaceMultiparty].(map[string]interface{}); ok {
+		delete(multiparty, coreconfig.NamespaceMultipartyNetworkNamespace)
+	}
+
+	// Add the new namespace to the root config, keeping the previous value so we can revert
+	previous := viper.Get("namespaces.predefined")
+	viper.Set("namespaces.predefined", configArray(append(predefined, nsConfig)))
+	revert := func() {
+		viper.Set("namespaces.predefined", previous)
+	}
+
+	reload, err := nm.loadReloadedConfig(ctx)
+	if err == nil {
+		err = nm.checkConfigUpdateScope(ctx, clone.Name, reload)
+	}
+	var copied map[string]int
+	if err == nil {
+		// Copy the definitions before the namespace starts, so they are picked up on startup
+		copied, err = sourceOr.Archive().Clone(ctx, clone.Name, clone)
+	}
+	if err != nil {
+		log.L(ctx).Errorf("Failed to clone namespace '%s' to '%s': %s", source, clone.Name, err)
+		revert()
+		return nil, err
+	}
+
+	log.L(ctx).Infof("Starting namespace '%s' cloned from '%s'", clone.Name, source)
+	if err := nm.applyReloadedConfig(ctx, reload); err != nil {
+		return nil, err
+	}
+	return &core.NamespaceCloneResult{
+		Namespace: clone.Name,
+		Source:    source,
+		Copied:    copied,
+	}, nil
+}
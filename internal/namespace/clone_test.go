@@ -0,0 +1,114 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/archivemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloneNamespace(t *testing.T) {
+	nm, nmm, cleanup := startTestNamespaceManagerConfig1(t)
+	defer cleanup()
+
+	originalNS := nm.namespaces
+
+	clone := &core.NamespaceClone{
+		Name:          "ns3",
+		Description:   "Project namespace",
+		Datatypes:     true,
+		Subscriptions: true,
+	}
+	mar := &archivemocks.Manager{}
+	mar.On("Clone", mock.Anything, "ns3", clone).Return(map[string]int{"datatypes": 2, "subscriptions": 1}, nil)
+	nmm.mo.On("Archive").Return(mar)
+
+	waitInit := namespaceInitWaiter(t, nmm, []string{"ns3"})
+	result, err := nm.CloneNamespace(nm.ctx, "ns2", clone)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns3", result.Namespace)
+	assert.Equal(t, "ns2", result.Source)
+	assert.Equal(t, 2, result.Copied["datatypes"])
+
+	// The existing namespaces are not restarted
+	assert.True(t, originalNS["ns1"] == nm.namespaces["ns1"])
+	assert.True(t, originalNS["ns2"] == nm.namespaces["ns2"])
+	assert.NotNil(t, nm.namespaces["ns3"])
+	assert.Equal(t, "Project namespace", nm.namespaces["ns3"].Description)
+	assert.Equal(t, nm.namespaces["ns2"].pluginNames, nm.namespaces["ns3"].pluginNames)
+	assert.Equal(t, "ns3", viper.GetString("namespaces.predefined.2.name"))
+
+	waitInit.Wait()
+	mar.AssertExpectations(t)
+}
+
+func TestCloneNamespaceCopyFail(t *testing.T) {
+	nm, nmm, cleanup := startTestNamespaceManagerConfig1(t)
+	defer cleanup()
+
+	mar := &archivemocks.Manager{}
+	mar.On("Clone", mock.Anything, "ns3", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	nmm.mo.On("Archive").Return(mar)
+
+	_, err := nm.CloneNamespace(nm.ctx, "ns2", &core.NamespaceClone{Name: "ns3"})
+	assert.Regexp(t, "pop", err)
+
+	// Check the config was reverted
+	assert.Nil(t, nm.namespaces["ns3"])
+	assert.Len(t, viper.Get("namespaces.predefined"), 2)
+	mar.AssertExpectations(t)
+}
+
+func TestCloneNamespaceAlreadyExists(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	nm.namespaces = map[string]*namespace{"ns1": {}, "ns2": {}}
+
+	_, err := nm.CloneNamespace(nm.ctx, "ns1", &core.NamespaceClone{Name: "ns2"})
+	assert.Regexp(t, "FF10496", err)
+}
+
+func TestCloneNamespaceUnknownSource(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	_, err := nm.CloneNamespace(nm.ctx, "ns99", &core.NamespaceClone{Name: "ns2"})
+	assert.Regexp(t, "FF10187", err)
+}
+
+func TestCloneNamespaceBadName(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	_, err := nm.CloneNamespace(nm.ctx, "ns1", nil)
+	assert.Regexp(t, "FF00140", err)
+}
+
+func TestCloneNamespaceNotStarted(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	nm.namespaces = map[string]*namespace{"ns1": {}}
+
+	_, err := nm.CloneNamespace(nm.ctx, "ns1", &core.NamespaceClone{Name: "ns2"})
+	assert.Regexp(t, "FF10441", err)
+}
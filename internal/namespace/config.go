@@ -17,17 +17,30 @@
 package namespace
 
 import (
-	"github.com/hyperledger/firefly-common/pkg/auth/authfactory"
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/archivestore"
+	"github.com/hyperledger/firefly/internal/auth/authfactory"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
+	"github.com/hyperledger/firefly/internal/changesinks"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/database/difactory"
 	"github.com/hyperledger/firefly/internal/dataexchange/dxfactory"
+	"github.com/hyperledger/firefly/internal/eventbridge"
 	"github.com/hyperledger/firefly/internal/events/eifactory"
+	"github.com/hyperledger/firefly/internal/extensions"
 	"github.com/hyperledger/firefly/internal/identity/iifactory"
+	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/internal/policy/policyfactory"
+	"github.com/hyperledger/firefly/internal/search"
+	"github.com/hyperledger/firefly/internal/secrets"
 	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
+	"github.com/hyperledger/firefly/internal/signing/sifactory"
+	"github.com/hyperledger/firefly/internal/slo"
+	"github.com/hyperledger/firefly/internal/spievents"
 	"github.com/hyperledger/firefly/internal/tokens/tifactory"
+	"github.com/hyperledger/firefly/internal/triggers"
+	"github.com/hyperledger/firefly/internal/zkproof/zkfactory"
 	"github.com/hyperledger/firefly/pkg/core"
 )
 
@@ -48,6 +61,9 @@ var (
 	dataexchangeConfig  = config.RootArray("plugins.dataexchange")
 	identityConfig      = config.RootArray("plugins.identity")
 	authConfig          = config.RootArray("plugins.auth")
+	signingConfig       = config.RootArray("plugins.signing")
+	zkproofConfig       = config.RootArray("plugins.zkproof")
+	policyConfig        = config.RootArray("plugins.policy")
 	eventsConfig        = config.RootSection("events") // still at root
 )
 
@@ -57,6 +73,20 @@ func InitConfig() {
 	namespacePredefined.AddKnownKey(coreconfig.NamespacePlugins)
 	namespacePredefined.AddKnownKey(coreconfig.NamespaceDefaultKey)
 	namespacePredefined.AddKnownKey(coreconfig.NamespaceAssetKeyNormalization)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceReadOnly, false)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceSandbox, false)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceRequiredConfirmations, 0)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceQuotasMessagesPerDay, 0)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceQuotasBlobBytes, 0)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceQuotasContractListeners, 0)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceQuotasSubscriptions, 0)
+
+	rateLimitConf := namespacePredefined.SubSection(coreconfig.NamespaceRateLimit)
+	for _, group := range core.RateLimitGroups {
+		groupConf := rateLimitConf.SubSection(string(group))
+		groupConf.AddKnownKey(coreconfig.NamespaceRateLimitRequestsPerSecond, 0)
+		groupConf.AddKnownKey(coreconfig.NamespaceRateLimitBurst, 0)
+	}
 
 	multipartyConf := namespacePredefined.SubSection(coreconfig.NamespaceMultiparty)
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyEnabled)
@@ -66,6 +96,17 @@ func InitConfig() {
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyOrgKey)
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyNodeName)
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyNodeDescription)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyBootstrapEnabled, false)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyBootstrapRetryInitDelay, "5s")
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyBootstrapRetryMaxDelay, "1m")
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyBootstrapRetryFactor, 2.0)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyApprovalsRequired, 0)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyApprovalsTags)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyApprovalsApprovers)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyPayloadSigningEnabled, false)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyPayloadSigningKeyFile)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyPayloadSigningKeyID)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyPayloadSigningRequired, false)
 
 	contractConf := multipartyConf.SubArray(coreconfig.NamespaceMultipartyContract)
 	contractConf.AddKnownKey(coreconfig.NamespaceMultipartyContractFirstEvent, string(core.SubOptsFirstEventOldest))
@@ -84,5 +125,18 @@ func InitConfig() {
 	iifactory.InitConfig(identityConfig)
 	tifactory.InitConfig(tokensConfig)
 	authfactory.InitConfigArray(authConfig)
+	sifactory.InitConfig(signingConfig)
+	zkfactory.InitConfig(zkproofConfig)
+	policyfactory.InitConfig(policyConfig)
 	eifactory.InitConfig(eventsConfig)
+	operations.InitConfig()
+	archivestore.InitConfig()
+	search.InitConfig()
+	slo.InitConfig()
+	changesinks.InitConfig()
+	eventbridge.InitConfig()
+	extensions.InitConfig()
+	spievents.InitConfig()
+	triggers.InitConfig()
+	secrets.InitConfig()
 }
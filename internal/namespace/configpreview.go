@@ -0,0 +1,244 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/spf13/viper"
+)
+
+// PreviewConfigReload reads the configuration file as it is now, and reports which namespaces and plugins a
+// reload would start, stop or restart, and which settings have changed - without applying any of it.
+//
+// The namespace and plugin configuration from the file is validated exactly as it would be on a reload,
+// by temporarily overriding the root config in the same way as UpdateNamespaceConfig.
+func (nm *namespaceManager) PreviewConfigReload(ctx context.Context) (*core.ConfigReloadPreview, error) {
+	nm.reloadMux.Lock()
+	defer nm.reloadMux.Unlock()
+
+	file := viper.ConfigFileUsed()
+	newConfig, err := readConfigFile(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	preview := &core.ConfigReloadPreview{
+		File:     file,
+		Settings: diffConfigSettings(nm.dumpRootConfig(), newConfig),
+	}
+
+	// Loading the token plugins records their broadcast names, which are shared with running namespaces
+	broadcastNames := make(map[string]string, len(nm.tokenBroadcastNames))
+	for name, broadcastName := range nm.tokenBroadcastNames {
+		broadcastNames[name] = broadcastName
+	}
+
+	previous := make(map[string]interface{})
+	for key, value := range reloadConfigOverrides(newConfig) {
+		previous[key] = viper.Get(key)
+		viper.Set(key, value)
+	}
+	defer func() {
+		for key, value := range previous {
+			viper.Set(key, value)
+		}
+		for name := range nm.tokenBroadcastNames {
+			delete(nm.tokenBroadcastNames, name)
+		}
+		for name, broadcastName := range broadcastNames {
+			nm.tokenBroadcastNames[name] = broadcastName
+		}
+	}()
+
+	rawConfig := nm.dumpRootConfig()
+	newPlugins, err := nm.loadPlugins(ctx, rawConfig)
+	for _, p := range newPlugins {
+		p.cancelCtx() // never initialized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var availablePlugins map[string]*plugin
+	availablePlugins, preview.Plugins = nm.previewPluginChanges(newPlugins)
+	newNamespaces, err := nm.loadNamespaces(ctx, rawConfig, availablePlugins)
+	if err != nil {
+		return nil, err
+	}
+	preview.Namespaces = nm.previewNamespaceChanges(newNamespaces, availablePlugins)
+	return preview, nil
+}
+
+func readConfigFile(ctx context.Context, file string) (fftypes.JSONObject, error) {
+	if file == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgConfigReloadNoFile)
+	}
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgConfigReloadReadFailed, file)
+	}
+	var jsonTree fftypes.JSONObject
+	b, _ := json.Marshal(v.AllSettings())
+	_ = json.Unmarshal(b, &jsonTree)
+	return jsonTree, nil
+}
+
+// reloadConfigOverrides returns the namespace and plugin arrays from the file, replacing the ones in the
+// root config. Arrays missing from the file are replaced with empty arrays, as they would be on a reload.
+func reloadConfigOverrides(newConfig fftypes.JSONObject) map[string]interface{} {
+	overrides := make(map[string]interface{})
+	namespaces := newConfig.GetObject("namespaces")
+	if defaultNS := namespaces.GetString("default"); defaultNS != "" {
+		overrides["namespaces.default"] = defaultNS
+	}
+	overrides["namespaces.predefined"] = configArray(namespaces.GetObjectArray("predefined"))
+	plugins := newConfig.GetObject("plugins")
+	for _, category := range allPluginCategories {
+		overrides["plugins."+string(category)] = configArray(plugins.GetObjectArray(string(category)))
+	}
+	return overrides
+}
+
+// previewPluginChanges matches the logic of analyzePluginChanges, without stopping or rotating anything
+func (nm *namespaceManager) previewPluginChanges(newPlugins map[string]*plugin) (availablePlugins map[string]*plugin, changes []*core.ConfigReloadChange) {
+	availablePlugins = make(map[string]*plugin)
+	changes = make([]*core.ConfigReloadChange, 0)
+	for pluginName, newPlugin := range newPlugins {
+		change := &core.ConfigReloadChange{Name: pluginName, Type: string(newPlugin.category)}
+		existingPlugin := nm.plugins[pluginName]
+		switch {
+		case existingPlugin == nil:
+			change.Action = core.ConfigReloadActionAdded
+		case existingPlugin.configHash.Equals(newPlugin.configHash):
+			availablePlugins[pluginName] = existingPlugin
+			continue
+		case existingPlugin.settingsHash.Equals(newPlugin.settingsHash) && isCredentialRotator(existingPlugin):
+			change.Action = core.ConfigReloadActionRotated
+			availablePlugins[pluginName] = existingPlugin
+			changes = append(changes, change)
+			continue
+		default:
+			change.Action = core.ConfigReloadActionRestarted
+		}
+		availablePlugins[pluginName] = newPlugin
+		changes = append(changes, change)
+	}
+	for pluginName, existingPlugin := range nm.plugins {
+		if newPlugins[pluginName] == nil {
+			changes = append(changes, &core.ConfigReloadChange{
+				Name:   pluginName,
+				Type:   string(existingPlugin.category),
+				Action: core.ConfigReloadActionRemoved,
+			})
+		}
+	}
+	sortConfigReloadChanges(changes)
+	return availablePlugins, changes
+}
+
+func isCredentialRotator(p *plugin) bool {
+	_, ok := p.implementation().(core.CredentialRotator)
+	return ok
+}
+
+// previewNamespaceChanges matches the logic of stopDefunctNamespaces, without stopping anything
+func (nm *namespaceManager) previewNamespaceChanges(newNamespaces map[string]*namespace, availablePlugins map[string]*plugin) []*core.ConfigReloadChange {
+	nm.nsMux.Lock()
+	defer nm.nsMux.Unlock()
+
+	changes := make([]*core.ConfigReloadChange, 0)
+	for nsName, newNS := range newNamespaces {
+		existingNS := nm.namespaces[nsName]
+		if existingNS == nil {
+			changes = append(changes, &core.ConfigReloadChange{Name: nsName, Action: core.ConfigReloadActionAdded})
+			continue
+		}
+		if nsChanges := nm.namespaceConfigChanges(existingNS, newNS, availablePlugins); len(nsChanges) > 0 {
+			changes = append(changes, &core.ConfigReloadChange{Name: nsName, Action: core.ConfigReloadActionRestarted, Changes: nsChanges})
+		}
+	}
+	for nsName := range nm.namespaces {
+		if newNamespaces[nsName] == nil {
+			changes = append(changes, &core.ConfigReloadChange{Name: nsName, Action: core.ConfigReloadActionRemoved})
+		}
+	}
+	sortConfigReloadChanges(changes)
+	return changes
+}
+
+func sortConfigReloadChanges(changes []*core.ConfigReloadChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+}
+
+// diffConfigSettings compares each setting in the file with the current value. Settings that are only
+// missing from the file are reported if they were set by the previous file, rather than by defaults
+// or environment variables.
+func diffConfigSettings(oldConfig, newConfig fftypes.JSONObject) []*core.ConfigSettingChange {
+	oldSettings := make(map[string]interface{})
+	flattenConfig("", map[string]interface{}(oldConfig), oldSettings)
+	newSettings := make(map[string]interface{})
+	flattenConfig("", map[string]interface{}(newConfig), newSettings)
+
+	changes := make([]*core.ConfigSettingChange, 0)
+	for key, newValue := range newSettings {
+		if oldValue, ok := oldSettings[key]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, &core.ConfigSettingChange{
+				Key: key,
+				Old: redactConfig(key, oldValue),
+				New: redactConfig(key, newValue),
+			})
+		}
+	}
+	for key, oldValue := range oldSettings {
+		if _, ok := newSettings[key]; !ok && viper.InConfig(key) {
+			changes = append(changes, &core.ConfigSettingChange{
+				Key: key,
+				Old: redactConfig(key, oldValue),
+			})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// flattenConfig records each leaf of the config tree by its dotted key - arrays are treated as a single value
+func flattenConfig(prefix string, value interface{}, settings map[string]interface{}) {
+	if m, ok := value.(map[string]interface{}); ok {
+		for k, child := range m {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flattenConfig(key, child, settings)
+		}
+		return
+	}
+	settings[prefix] = value
+}
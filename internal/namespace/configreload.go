@@ -25,7 +25,9 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/logcontrol"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/spf13/viper"
 )
 
@@ -46,6 +48,10 @@ func (nm *namespaceManager) startConfigListener() error {
 func (nm *namespaceManager) configFileChanged() {
 	log.L(nm.ctx).Infof("Detected configuration file reload")
 
+	// Serialize with any namespace configuration update in progress via the API
+	nm.reloadMux.Lock()
+	defer nm.reloadMux.Unlock()
+
 	// Because of the things we do to make defaults work with arrays, we have to reset
 	// the config when it changes and re-read it.
 	// We are passed this by our parent, as the config initialization of defaults and sections
@@ -60,10 +66,35 @@ func (nm *namespaceManager) configFileChanged() {
 	nm.configReloaded(nm.ctx)
 }
 
+// configReload is the set of plugins and namespaces built from a new configuration, before it is applied
+type configReload struct {
+	availablePlugins map[string]*plugin
+	updatedPlugins   map[string]*plugin
+	pluginsToStop    map[string]*plugin
+	namespaces       map[string]*namespace
+}
+
 func (nm *namespaceManager) configReloaded(ctx context.Context) {
 	// Always make sure log level is up to date
-	log.SetLevel(config.GetString(config.LogLevel))
+	logcontrol.SetDefaultLevel(config.GetString(config.LogLevel))
 
+	reload, err := nm.loadReloadedConfig(ctx)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to load configuration after config reload: %s", err)
+		nm.notifyLifecycle(core.LifecycleEventTypeConfigReloadFailed, "", "", err)
+		return
+	}
+
+	if err := nm.applyReloadedConfig(ctx, reload); err != nil {
+		nm.notifyLifecycle(core.LifecycleEventTypeConfigReloadFailed, "", "", err)
+		return
+	}
+	nm.notifyLifecycle(core.LifecycleEventTypeConfigReloaded, "", "", nil)
+}
+
+// loadReloadedConfig builds the plugins and namespaces from the current root configuration, without
+// affecting anything that is currently running
+func (nm *namespaceManager) loadReloadedConfig(ctx context.Context) (reload *configReload, err error) {
 	// Get Viper to dump the whole new config, with everything resolved across env vars
 	// and the config file etc.
 	// We use this to detect if anything has changed.
@@ -72,31 +103,33 @@ func (nm *namespaceManager) configReloaded(ctx context.Context) {
 	// Build the new set of plugins from the config (including those that are unchanged)
 	allPluginsInNewConf, err := nm.loadPlugins(ctx, rawConfig)
 	if err != nil {
-		log.L(ctx).Errorf("Failed to initialize plugins after config reload: %s", err)
-		return
+		return nil, err
 	}
 
 	// Analyze the new list to see which plugins need to be updated,
 	// so we load the namespaces against the correct list of plugins
-	availablePlugins, updatedPlugins, pluginsToStop := nm.analyzePluginChanges(ctx, allPluginsInNewConf)
+	reload = &configReload{}
+	reload.availablePlugins, reload.updatedPlugins, reload.pluginsToStop = nm.analyzePluginChanges(ctx, allPluginsInNewConf)
 
 	// Build the new set of namespaces (including those that are unchanged)
-	allNewNamespaces, err := nm.loadNamespaces(ctx, rawConfig, availablePlugins)
-	if err != nil {
-		log.L(ctx).Errorf("Failed to load namespaces after config reload: %s", err)
-		return
+	if reload.namespaces, err = nm.loadNamespaces(ctx, rawConfig, reload.availablePlugins); err != nil {
+		return nil, err
 	}
+	return reload, nil
+}
 
+// applyReloadedConfig stops (draining) everything that has changed, and starts the replacements
+func (nm *namespaceManager) applyReloadedConfig(ctx context.Context, reload *configReload) (err error) {
 	// From this point we need to block any API calls resolving namespaces,
 	// until the reload is complete
 	nm.nsMux.Lock()
 	defer nm.nsMux.Unlock()
 
 	// Stop all defunct namespaces
-	availableNS, updatedNamespaces := nm.stopDefunctNamespaces(ctx, availablePlugins, allNewNamespaces)
+	availableNS, updatedNamespaces := nm.stopDefunctNamespaces(ctx, reload.availablePlugins, reload.namespaces)
 
 	// Stop all defunct plugins - now the namespaces using them are all stopped
-	nm.stopDefunctPlugins(ctx, pluginsToStop)
+	nm.stopDefunctPlugins(ctx, reload.pluginsToStop)
 
 	// If there are any namespaces that are completely gone at this point we need to purge
 	// them from the system (all the handlers/callback registrations), before we update the
@@ -108,23 +141,40 @@ func (nm *namespaceManager) configReloaded(ctx context.Context) {
 	}
 
 	// Update the new lists
-	nm.plugins = availablePlugins
+	nm.plugins = reload.availablePlugins
 	nm.namespaces = availableNS
 
 	// Only initialize updated plugins
-	if err = nm.initPlugins(updatedPlugins); err != nil {
+	if err = nm.initPlugins(reload.updatedPlugins); err != nil {
 		log.L(ctx).Errorf("Failed to initialize plugins after config reload: %s", err)
 		nm.cancelCtx() // stop the world
-		return
+		return err
 	}
 
 	// Now we can start all the new things
-	if err = nm.startNamespacesAndPlugins(updatedNamespaces, updatedPlugins); err != nil {
+	if err = nm.startNamespacesAndPlugins(updatedNamespaces, reload.updatedPlugins); err != nil {
 		log.L(ctx).Errorf("Failed to initialize namespaces after config reload: %s", err)
 		nm.cancelCtx() // stop the world
-		return
+		return err
 	}
 
+	return nil
+}
+
+// namespaceConfigChanges lists the reasons a namespace needs to be restarted, if any
+func (nm *namespaceManager) namespaceConfigChanges(existingNS, newNS *namespace, newPlugins map[string]*plugin) (changes []string) {
+	if !existingNS.configHash.Equals(newNS.configHash) {
+		changes = append(changes, "namespace_config") // Encompasses the list of plugins
+	}
+	for _, pluginName := range newNS.pluginNames {
+		existingPlugin := nm.plugins[pluginName]
+		newPlugin := newPlugins[pluginName]
+		if existingPlugin == nil || newPlugin == nil ||
+			!existingPlugin.configHash.Equals(newPlugin.configHash) {
+			changes = append(changes, fmt.Sprintf("plugin:%s", pluginName))
+		}
+	}
+	return changes
 }
 
 func (nm *namespaceManager) stopDefunctNamespaces(ctx context.Context, newPlugins map[string]*plugin, newNamespaces map[string]*namespace) (availableNamespaces, updatedNamespaces map[string]*namespace) {
@@ -138,18 +188,7 @@ func (nm *namespaceManager) stopDefunctNamespaces(ctx context.Context, newPlugin
 	for nsName, newNS := range newNamespaces {
 		newNamespaceNames = append(newNamespaceNames, nsName)
 		if existingNS := nm.namespaces[nsName]; existingNS != nil {
-			var changes []string
-			if !existingNS.configHash.Equals(newNS.configHash) {
-				changes = append(changes, "namespace_config") // Encompasses the list of plugins
-			}
-			for _, pluginName := range newNS.pluginNames {
-				existingPlugin := nm.plugins[pluginName]
-				newPlugin := newPlugins[pluginName]
-				if existingPlugin == nil || newPlugin == nil ||
-					!existingPlugin.configHash.Equals(newPlugin.configHash) {
-					changes = append(changes, fmt.Sprintf("plugin:%s", pluginName))
-				}
-			}
+			changes := nm.namespaceConfigChanges(existingNS, newNS, newPlugins)
 			if len(changes) == 0 {
 				log.L(ctx).Debugf("Namespace '%s' unchanged after config reload", nsName)
 				availableNamespaces[nsName] = existingNS
@@ -198,6 +237,10 @@ func (nm *namespaceManager) analyzePluginChanges(ctx context.Context, newPlugins
 				availablePlugins[pluginName] = existingPlugin
 				continue
 			}
+			if nm.rotatePluginCredentials(ctx, existingPlugin, newPlugin) {
+				availablePlugins[pluginName] = existingPlugin
+				continue
+			}
 			// We need to stop the existing plugin
 			pluginsToStop[pluginName] = existingPlugin
 		}
@@ -216,9 +259,33 @@ func (nm *namespaceManager) analyzePluginChanges(ctx context.Context, newPlugins
 	return
 }
 
+// rotatePluginCredentials applies a config change that only affects the credentials of a plugin to the running
+// plugin, if it supports that. Operations in flight on the plugin, and the namespaces using it, are not
+// interrupted. Returns false if the plugin needs to be restarted to apply the change.
+func (nm *namespaceManager) rotatePluginCredentials(ctx context.Context, existingPlugin, newPlugin *plugin) bool {
+	if !existingPlugin.settingsHash.Equals(newPlugin.settingsHash) {
+		return false
+	}
+	rotator, ok := existingPlugin.implementation().(core.CredentialRotator)
+	if !ok {
+		log.L(ctx).Debugf("Plugin '%s' does not support credential rotation", existingPlugin.name)
+		return false
+	}
+	if err := rotator.RotateCredentials(ctx, newPlugin.config); err != nil {
+		log.L(ctx).Warnf("Failed to rotate credentials for plugin '%s', restarting it: %s", existingPlugin.name, err)
+		return false
+	}
+	log.L(ctx).Infof("Plugin '%s' credentials rotated after config reload", existingPlugin.name)
+	existingPlugin.config = newPlugin.config
+	existingPlugin.configHash = newPlugin.configHash
+	nm.notifyLifecycle(core.LifecycleEventTypePluginCredentialsRotated, "", existingPlugin.name, nil)
+	return true
+}
+
 func (nm *namespaceManager) stopDefunctPlugins(ctx context.Context, pluginsToStop map[string]*plugin) {
 	for pluginName, plugin := range pluginsToStop {
 		log.L(ctx).Debugf("Stopping plugin '%s' after config reload. Loaded at %s", pluginName, plugin.loadTime)
 		plugin.cancelCtx()
+		nm.notifyLifecycle(core.LifecycleEventTypePluginStopped, "", pluginName, nil)
 	}
 }
@@ -30,6 +30,9 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/credentials"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/sirupsen/logrus"
@@ -732,6 +735,10 @@ plugins:
 	assert.Len(t, nm.plugins, len(originalPlugins))
 	assert.Len(t, nm.namespaces, len(originaNS))
 
+	nmm.mae.AssertCalled(t, "NotifyLifecycle", mock.MatchedBy(func(event *core.LifecycleEvent) bool {
+		return event.Type == core.LifecycleEventTypeConfigReloadFailed && event.Error != ""
+	}))
+
 }
 
 func TestConfigReloadBadNSMissingRequiredPlugins(t *testing.T) {
@@ -1059,3 +1066,92 @@ namespaces:
 	nm.WaitStop()
 
 }
+
+type rotatingBlockchainPlugin struct {
+	*blockchainmocks.Plugin
+	rotatedConfig config.Section
+	rotateErr     error
+}
+
+func (p *rotatingBlockchainPlugin) RotateCredentials(ctx context.Context, config config.Section) error {
+	p.rotatedConfig = config
+	return p.rotateErr
+}
+
+func newRotationTestPlugins(nm *namespaceManager, rawConfig, newRawConfig fftypes.JSONObject) (existingPlugin, newPlugin *plugin) {
+	existingPlugin = &plugin{
+		name:         "ethereum",
+		category:     pluginCategoryBlockchain,
+		configHash:   nm.configHash(rawConfig),
+		settingsHash: nm.configHash(credentials.StripCredentials(rawConfig)),
+		blockchain:   &rotatingBlockchainPlugin{Plugin: &blockchainmocks.Plugin{}},
+	}
+	newPlugin = &plugin{
+		name:         "ethereum",
+		category:     pluginCategoryBlockchain,
+		config:       config.RootSection("rotated"),
+		configHash:   nm.configHash(newRawConfig),
+		settingsHash: nm.configHash(credentials.StripCredentials(newRawConfig)),
+	}
+	return existingPlugin, newPlugin
+}
+
+func TestRotatePluginCredentials(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	existingPlugin, newPlugin := newRotationTestPlugins(nm,
+		fftypes.JSONObject{"ethconnect": map[string]interface{}{"url": "http://eth", "auth": map[string]interface{}{"password": "pass1"}}},
+		fftypes.JSONObject{"ethconnect": map[string]interface{}{"url": "http://eth", "auth": map[string]interface{}{"password": "pass2"}}},
+	)
+	nm.plugins = map[string]*plugin{"ethereum": existingPlugin}
+
+	available, updated, toStop := nm.analyzePluginChanges(nm.ctx, map[string]*plugin{"ethereum": newPlugin})
+	assert.Equal(t, existingPlugin, available["ethereum"])
+	assert.Empty(t, updated)
+	assert.Empty(t, toStop)
+	assert.Equal(t, newPlugin.config, existingPlugin.blockchain.(*rotatingBlockchainPlugin).rotatedConfig)
+	assert.Equal(t, newPlugin.configHash, existingPlugin.configHash)
+}
+
+func TestRotatePluginCredentialsSettingsChanged(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	existingPlugin, newPlugin := newRotationTestPlugins(nm,
+		fftypes.JSONObject{"ethconnect": map[string]interface{}{"url": "http://eth1", "auth": map[string]interface{}{"password": "pass1"}}},
+		fftypes.JSONObject{"ethconnect": map[string]interface{}{"url": "http://eth2", "auth": map[string]interface{}{"password": "pass2"}}},
+	)
+
+	assert.False(t, nm.rotatePluginCredentials(nm.ctx, existingPlugin, newPlugin))
+	assert.Nil(t, existingPlugin.blockchain.(*rotatingBlockchainPlugin).rotatedConfig)
+}
+
+func TestRotatePluginCredentialsNotSupported(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	existingPlugin, newPlugin := newRotationTestPlugins(nm,
+		fftypes.JSONObject{"headers": map[string]interface{}{"x-api-key": "key1"}},
+		fftypes.JSONObject{"headers": map[string]interface{}{"x-api-key": "key2"}},
+	)
+	existingPlugin.category = pluginCategoryDatabase
+	existingPlugin.database = &databasemocks.Plugin{}
+
+	assert.False(t, nm.rotatePluginCredentials(nm.ctx, existingPlugin, newPlugin))
+}
+
+func TestRotatePluginCredentialsFail(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	existingPlugin, newPlugin := newRotationTestPlugins(nm,
+		fftypes.JSONObject{"tls": map[string]interface{}{"enabled": true, "certfile": "cert1.pem"}},
+		fftypes.JSONObject{"tls": map[string]interface{}{"enabled": true, "certfile": "cert2.pem"}},
+	)
+	existingPlugin.blockchain.(*rotatingBlockchainPlugin).rotateErr = fmt.Errorf("pop")
+	existingHash := existingPlugin.configHash
+
+	assert.False(t, nm.rotatePluginCredentials(nm.ctx, existingPlugin, newPlugin))
+	assert.Equal(t, existingHash, existingPlugin.configHash)
+}
@@ -0,0 +1,217 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/spf13/viper"
+)
+
+var allPluginCategories = []pluginCategory{
+	pluginCategoryBlockchain,
+	pluginCategoryDatabase,
+	pluginCategoryDataexchange,
+	pluginCategorySharedstorage,
+	pluginCategoryTokens,
+	pluginCategoryIdentity,
+	pluginCategoryEvents,
+	pluginCategoryAuth,
+	pluginCategorySigning,
+	pluginCategoryZKProof,
+	pluginCategoryPolicy,
+}
+
+// UpdateNamespaceConfig applies a new configuration for a single namespace, and any plugins it references,
+// on top of the currently loaded configuration. The full configuration is re-validated, and the update is
+// rejected if it would cause any other namespace to restart. If the namespace is affected, it is stopped
+// (draining in-flight work) and restarted along with any new or changed plugins.
+//
+// The update is held in memory only - it is replaced by the next reload of the configuration file, or restart.
+func (nm *namespaceManager) UpdateNamespaceConfig(ctx context.Context, name string, update *core.NamespaceConfigUpdate) (*core.NamespaceConfigUpdateResult, error) {
+	if update == nil || (len(update.Namespace) == 0 && len(update.Plugins) == 0) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceConfigUpdateEmpty)
+	}
+
+	nm.reloadMux.Lock()
+	defer nm.reloadMux.Unlock()
+
+	nm.nsMux.Lock()
+	existingNS := nm.namespaces[name]
+	nm.nsMux.Unlock()
+	if existingNS == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceDoesNotExist, name)
+	}
+
+	overrides, err := nm.buildConfigOverrides(ctx, name, update)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the overrides to the root config, keeping the previous values so we can revert
+	previous := make(map[string]interface{}, len(overrides))
+	for key, value := range overrides {
+		previous[key] = viper.Get(key)
+		viper.Set(key, value)
+	}
+	revert := func() {
+		for key, value := range previous {
+			viper.Set(key, value)
+		}
+	}
+
+	reload, err := nm.loadReloadedConfig(ctx)
+	if err == nil {
+		err = nm.checkConfigUpdateScope(ctx, name, reload)
+	}
+	if err != nil {
+		log.L(ctx).Errorf("Rejected configuration update for namespace '%s': %s", name, err)
+		revert()
+		return nil, err
+	}
+
+	result := &core.NamespaceConfigUpdateResult{
+		Namespace: name,
+		Changes:   nm.namespaceConfigChanges(existingNS, reload.namespaces[name], reload.availablePlugins),
+	}
+	result.Restarted = len(result.Changes) > 0
+	log.L(ctx).Infof("Applying configuration update for namespace '%s' changes=%v", name, result.Changes)
+
+	if err := nm.applyReloadedConfig(ctx, reload); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// buildConfigOverrides merges the update into the current config, returning the new values for each
+// of the root config keys that need to be replaced
+func (nm *namespaceManager) buildConfigOverrides(ctx context.Context, name string, update *core.NamespaceConfigUpdate) (map[string]interface{}, error) {
+	rawConfig := nm.dumpRootConfig()
+	overrides := make(map[string]interface{})
+
+	if len(update.Namespace) > 0 {
+		nsConfig := lowerCaseConfigKeys(update.Namespace).(map[string]interface{})
+		nsName, _ := nsConfig["name"].(string)
+		switch nsName {
+		case "":
+			nsConfig["name"] = name
+		case name:
+		default:
+			return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceConfigNameMismatch, nsName, name)
+		}
+		overrides["namespaces.predefined"] = configArray(replaceConfigEntry(rawConfig.GetObject("namespaces").GetObjectArray("predefined"), nsConfig))
+	}
+
+	categories := make([]string, 0, len(update.Plugins))
+	for category := range update.Plugins {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	rawPluginsConfig := rawConfig.GetObject("plugins")
+	for _, category := range categories {
+		if !isPluginCategory(category) {
+			return nil, i18n.NewError(ctx, coremsgs.MsgUnknownPluginCategory, category)
+		}
+		entries := rawPluginsConfig.GetObjectArray(category)
+		for _, pluginConfig := range update.Plugins.GetObjectArray(category) {
+			pluginConfig := lowerCaseConfigKeys(pluginConfig).(map[string]interface{})
+			if pluginName, _ := pluginConfig["name"].(string); pluginName == "" {
+				return nil, i18n.NewError(ctx, coremsgs.MsgInvalidPluginConfiguration, category)
+			}
+			entries = replaceConfigEntry(entries, pluginConfig)
+		}
+		overrides["plugins."+category] = configArray(entries)
+	}
+	return overrides, nil
+}
+
+// checkConfigUpdateScope ensures the reloaded config only affects the namespace being updated
+func (nm *namespaceManager) checkConfigUpdateScope(ctx context.Context, name string, reload *configReload) error {
+	if _, ok := reload.namespaces[name]; !ok {
+		return i18n.NewError(ctx, coremsgs.MsgNamespaceDoesNotExist, name)
+	}
+	nm.nsMux.Lock()
+	defer nm.nsMux.Unlock()
+	for otherName, otherNS := range nm.namespaces {
+		if otherName == name {
+			continue
+		}
+		newNS := reload.namespaces[otherName]
+		if newNS == nil || len(nm.namespaceConfigChanges(otherNS, newNS, reload.availablePlugins)) > 0 {
+			return i18n.NewError(ctx, coremsgs.MsgNamespaceConfigUpdateOutOfScope, name, otherName)
+		}
+	}
+	return nil
+}
+
+func isPluginCategory(category string) bool {
+	for _, c := range allPluginCategories {
+		if string(c) == category {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceConfigEntry replaces the entry in a config array with the same name, or appends it if there is none
+func replaceConfigEntry(entries fftypes.JSONObjectArray, newEntry map[string]interface{}) fftypes.JSONObjectArray {
+	for i, entry := range entries {
+		if entry.GetString("name") == newEntry["name"] {
+			entries[i] = newEntry
+			return entries
+		}
+	}
+	return append(entries, newEntry)
+}
+
+// configArray converts to the generic form viper holds arrays in, when read from a config file
+func configArray(entries fftypes.JSONObjectArray) []interface{} {
+	array := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		array[i] = map[string]interface{}(entry)
+	}
+	return array
+}
+
+// lowerCaseConfigKeys matches the case-insensitive keys viper uses for everything read from the config file
+func lowerCaseConfigKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case fftypes.JSONObject:
+		return lowerCaseConfigKeys(map[string]interface{}(v))
+	case map[string]interface{}:
+		lowered := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			lowered[strings.ToLower(key)] = lowerCaseConfigKeys(child)
+		}
+		return lowered
+	case []interface{}:
+		lowered := make([]interface{}, len(v))
+		for i, child := range v {
+			lowered[i] = lowerCaseConfigKeys(child)
+		}
+		return lowered
+	default:
+		return value
+	}
+}
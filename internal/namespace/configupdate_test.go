@@ -0,0 +1,196 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func startTestNamespaceManagerConfig1(t *testing.T) (*namespaceManager, *nmMocks, func()) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, false)
+
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(exampleConfig1base))
+	assert.NoError(t, err)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+
+	mockInitConfig(nmm)
+	waitInit := namespaceInitWaiter(t, nmm, []string{"ns1", "ns2"})
+
+	err = nm.Init(ctx, cancelCtx, make(chan bool), func() error { return nil })
+	assert.NoError(t, err)
+
+	err = nm.Start()
+	assert.NoError(t, err)
+
+	waitInit.Wait()
+	return nm, nmm, func() {
+		cancelCtx()
+		cleanup()
+	}
+}
+
+func TestUpdateNamespaceConfigPluginChange(t *testing.T) {
+	logrus.SetLevel(logrus.TraceLevel)
+
+	nm, nmm, cleanup := startTestNamespaceManagerConfig1(t)
+	defer cleanup()
+
+	originalPlugins := nm.plugins
+	originalNS := nm.namespaces
+
+	waitInit := namespaceInitWaiter(t, nmm, []string{"ns2"})
+	result, err := nm.UpdateNamespaceConfig(nm.ctx, "ns2", &core.NamespaceConfigUpdate{
+		Plugins: fftypes.JSONObject{
+			"auth": []interface{}{
+				map[string]interface{}{
+					"name": "test_user_auth-ns2",
+					"type": "basic",
+					"basic": map[string]interface{}{
+						"passwordFile": "/etc/firefly/test_users_new",
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ns2", result.Namespace)
+	assert.True(t, result.Restarted)
+	assert.Equal(t, []string{"plugin:test_user_auth-ns2"}, result.Changes)
+
+	// Check that we didn't cancel the context
+	select {
+	case <-nm.ctx.Done():
+		assert.Fail(t, "Error occurred in config update")
+	default:
+	}
+
+	// Only the changed plugin, and the namespace using it, are replaced
+	for name := range originalPlugins {
+		assert.Equal(t, name != "test_user_auth-ns2", originalPlugins[name] == nm.plugins[name], name)
+	}
+	assert.True(t, originalNS["ns1"] == nm.namespaces["ns1"])
+	assert.False(t, originalNS["ns2"] == nm.namespaces["ns2"])
+	assert.Equal(t, "/etc/firefly/test_users_new", viper.GetString("plugins.auth.1.basic.passwordfile"))
+
+	waitInit.Wait()
+}
+
+func TestUpdateNamespaceConfigAffectsOtherNamespace(t *testing.T) {
+	nm, _, cleanup := startTestNamespaceManagerConfig1(t)
+	defer cleanup()
+
+	originalNS := nm.namespaces
+
+	_, err := nm.UpdateNamespaceConfig(nm.ctx, "ns2", &core.NamespaceConfigUpdate{
+		Plugins: fftypes.JSONObject{
+			"database": []interface{}{
+				map[string]interface{}{
+					"name": "database0",
+					"type": "postgres",
+					"postgres": map[string]interface{}{
+						"url": "postgres://other.example.com:5432/firefly",
+					},
+				},
+			},
+		},
+	})
+	assert.Regexp(t, "FF10492.*ns1", err)
+
+	// Check the config was reverted, and nothing was restarted
+	assert.Equal(t, "postgres://postgrs.example.com:5432/firefly?sslmode=require", viper.GetString("plugins.database.0.postgres.url"))
+	assert.True(t, originalNS["ns1"] == nm.namespaces["ns1"])
+	assert.True(t, originalNS["ns2"] == nm.namespaces["ns2"])
+}
+
+func TestUpdateNamespaceConfigBadNamespace(t *testing.T) {
+	nm, _, cleanup := startTestNamespaceManagerConfig1(t)
+	defer cleanup()
+
+	_, err := nm.UpdateNamespaceConfig(nm.ctx, "ns2", &core.NamespaceConfigUpdate{
+		Namespace: fftypes.JSONObject{
+			"name":    "ns2",
+			"plugins": []interface{}{"database0", "unknown"},
+		},
+	})
+	assert.Regexp(t, "FF10390", err)
+	assert.Equal(t, []interface{}{"database0", "blockchain-ns2", "test_user_auth-ns2"}, viper.Get("namespaces.predefined.1.plugins"))
+}
+
+func TestUpdateNamespaceConfigEmpty(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	_, err := nm.UpdateNamespaceConfig(nm.ctx, "ns1", &core.NamespaceConfigUpdate{})
+	assert.Regexp(t, "FF10489", err)
+}
+
+func TestUpdateNamespaceConfigUnknownNamespace(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	_, err := nm.UpdateNamespaceConfig(nm.ctx, "ns99", &core.NamespaceConfigUpdate{
+		Namespace: fftypes.JSONObject{"name": "ns99"},
+	})
+	assert.Regexp(t, "FF10187", err)
+}
+
+func TestUpdateNamespaceConfigNameMismatch(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	nm.namespaces = map[string]*namespace{"ns1": {}}
+
+	_, err := nm.UpdateNamespaceConfig(nm.ctx, "ns1", &core.NamespaceConfigUpdate{
+		Namespace: fftypes.JSONObject{"name": "ns2"},
+	})
+	assert.Regexp(t, "FF10490", err)
+}
+
+func TestUpdateNamespaceConfigUnknownPluginCategory(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	nm.namespaces = map[string]*namespace{"ns1": {}}
+
+	_, err := nm.UpdateNamespaceConfig(nm.ctx, "ns1", &core.NamespaceConfigUpdate{
+		Plugins: fftypes.JSONObject{"wrong": []interface{}{}},
+	})
+	assert.Regexp(t, "FF10491", err)
+}
+
+func TestUpdateNamespaceConfigPluginMissingName(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	nm.namespaces = map[string]*namespace{"ns1": {}}
+
+	_, err := nm.UpdateNamespaceConfig(nm.ctx, "ns1", &core.NamespaceConfigUpdate{
+		Plugins: fftypes.JSONObject{
+			"tokens": []interface{}{
+				map[string]interface{}{"type": "fftokens"},
+			},
+		},
+	})
+	assert.Regexp(t, "FF10386", err)
+}
@@ -0,0 +1,225 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"runtime/pprof"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/logcontrol"
+	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const redactedValue = "*****"
+
+// Config keys are matched case-insensitively against these, to decide if their values are secret
+var secretConfigKeys = []string{"password", "secret", "passphrase", "apikey", "privatekey", "token", "authorization"}
+
+var errorEventTypes = []driver.Value{
+	core.EventTypeMessageRejected,
+	core.EventTypePoolOpFailed,
+	core.EventTypeTransferOpFailed,
+	core.EventTypeApprovalOpFailed,
+	core.EventTypeBlockchainInvokeOpFailed,
+	core.EventTypeBlockchainContractDeployOpFailed,
+}
+
+type diagnosticsPlugin struct {
+	Name     string          `json:"name"`
+	Category string          `json:"category"`
+	Type     string          `json:"type"`
+	Loaded   *fftypes.FFTime `json:"loaded,omitempty"`
+}
+
+type diagnosticsBundle struct {
+	zw     *zip.Writer
+	errors map[string]string
+}
+
+// GetDiagnosticsBundle builds a zip file to attach to a support ticket. It is best-effort - anything that
+// cannot be collected is listed in errors.json in the bundle, rather than failing the whole request.
+func (nm *namespaceManager) GetDiagnosticsBundle(ctx context.Context) ([]byte, error) {
+	var buf bytes.Buffer
+	db := &diagnosticsBundle{
+		zw:     zip.NewWriter(&buf),
+		errors: make(map[string]string),
+	}
+
+	db.addJSON(ctx, "config.json", redactConfig("", map[string]interface{}(nm.dumpRootConfig())))
+	db.addJSON(ctx, "logging.json", logcontrol.GetLevels())
+	db.addJSON(ctx, "plugins.json", nm.diagnosticsPlugins())
+	db.addJSON(ctx, "caches.json", map[string][]string{"global": nm.cacheManager.ListCacheNames("global")})
+
+	namespaces, _ := nm.GetNamespaces(ctx, true)
+	db.addJSON(ctx, "namespaces.json", namespaces)
+	for _, ns := range namespaces {
+		or, err := nm.Orchestrator(ctx, ns.Name, false)
+		if err != nil {
+			continue // the init error is already in namespaces.json
+		}
+		nm.addNamespaceDiagnostics(ctx, db, ns.Name, or)
+	}
+
+	for _, profile := range []struct {
+		name     string
+		filename string
+		debug    int
+	}{
+		{name: "goroutine", filename: "goroutines.txt", debug: 2},
+		{name: "heap", filename: "heap.pb.gz", debug: 0},
+	} {
+		w, err := db.zw.Create(profile.filename)
+		if err == nil {
+			err = pprof.Lookup(profile.name).WriteTo(w, profile.debug)
+		}
+		db.recordError(ctx, profile.filename, err)
+	}
+
+	db.addJSON(ctx, "errors.json", db.errors)
+	if err := db.zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (nm *namespaceManager) addNamespaceDiagnostics(ctx context.Context, db *diagnosticsBundle, ns string, or orchestrator.Orchestrator) {
+	dir := fmt.Sprintf("namespaces/%s/", ns)
+
+	status, err := or.GetStatus(ctx)
+	db.recordError(ctx, dir+"status.json", err)
+	if err == nil {
+		db.addJSON(ctx, dir+"status.json", status)
+	}
+
+	health, err := or.GetHealth(ctx)
+	db.recordError(ctx, dir+"health.json", err)
+	if err == nil {
+		db.addJSON(ctx, dir+"health.json", health)
+	}
+
+	if bm := or.BatchManager(); bm != nil {
+		db.addJSON(ctx, dir+"batchmanager.json", bm.Status())
+	}
+	if em := or.Events(); em != nil {
+		if status := em.AggregatorStatus(); status != nil {
+			db.addJSON(ctx, dir+"aggregator.json", status)
+		}
+	}
+
+	db.addJSON(ctx, dir+"caches.json", nm.cacheManager.ListCacheNames(ns))
+
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	filter := fb.And(fb.In("type", errorEventTypes))
+	filter.Sort("-sequence").Limit(uint64(config.GetInt(coreconfig.DebugDiagnosticsErrorEvents)))
+	events, _, err := or.GetEvents(ctx, filter)
+	db.recordError(ctx, dir+"errorevents.json", err)
+	if err == nil {
+		db.addJSON(ctx, dir+"errorevents.json", events)
+	}
+}
+
+func (nm *namespaceManager) diagnosticsPlugins() []*diagnosticsPlugin {
+	nm.nsMux.Lock()
+	defer nm.nsMux.Unlock()
+	plugins := make([]*diagnosticsPlugin, 0, len(nm.plugins))
+	for _, p := range nm.plugins {
+		plugins = append(plugins, &diagnosticsPlugin{
+			Name:     p.name,
+			Category: string(p.category),
+			Type:     p.pluginType,
+			Loaded:   p.loadTime,
+		})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+func (db *diagnosticsBundle) addJSON(ctx context.Context, filename string, data interface{}) {
+	w, err := db.zw.Create(filename)
+	if err == nil {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(data)
+	}
+	db.recordError(ctx, filename, err)
+}
+
+func (db *diagnosticsBundle) recordError(ctx context.Context, filename string, err error) {
+	if err != nil {
+		log.L(ctx).Warnf("Failed to collect %s for diagnostics: %s", filename, err)
+		db.errors[filename] = err.Error()
+	}
+}
+
+// redactConfig returns a copy of the config tree, with the values of secret keys masked, and any
+// credentials removed from URLs
+func redactConfig(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			redacted[k] = redactConfig(k, child)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, child := range v {
+			redacted[i] = redactConfig(key, child)
+		}
+		return redacted
+	case string:
+		if v != "" && isSecretConfigKey(key) {
+			return redactedValue
+		}
+		if u, err := url.Parse(v); err == nil && u.User != nil {
+			if _, hasPassword := u.User.Password(); hasPassword {
+				u.User = url.UserPassword(u.User.Username(), redactedValue)
+				return u.String()
+			}
+		}
+		return v
+	default:
+		if v != nil && isSecretConfigKey(key) {
+			return redactedValue
+		}
+		return v
+	}
+}
+
+func isSecretConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretConfigKeys {
+		if strings.Contains(key, strings.ToLower(secret)) {
+			return true
+		}
+	}
+	return false
+}
@@ -25,35 +25,45 @@ import (
 	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/auth"
-	"github.com/hyperledger/firefly-common/pkg/auth/authfactory"
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftls"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/auth/authfactory"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
 	"github.com/hyperledger/firefly/internal/cache"
+	"github.com/hyperledger/firefly/internal/changesinks"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/credentials"
 	"github.com/hyperledger/firefly/internal/database/difactory"
 	"github.com/hyperledger/firefly/internal/dataexchange/dxfactory"
+	"github.com/hyperledger/firefly/internal/definitions"
 	"github.com/hyperledger/firefly/internal/events/eifactory"
 	"github.com/hyperledger/firefly/internal/events/system"
+	identitymanager "github.com/hyperledger/firefly/internal/identity"
 	"github.com/hyperledger/firefly/internal/identity/iifactory"
 	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/internal/policy/policyfactory"
 	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
+	"github.com/hyperledger/firefly/internal/signing/sifactory"
 	"github.com/hyperledger/firefly/internal/spievents"
 	"github.com/hyperledger/firefly/internal/tokens/tifactory"
+	"github.com/hyperledger/firefly/internal/zkproof/zkfactory"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 	"github.com/hyperledger/firefly/pkg/events"
 	"github.com/hyperledger/firefly/pkg/identity"
+	"github.com/hyperledger/firefly/pkg/policy"
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
+	"github.com/hyperledger/firefly/pkg/signing"
 	"github.com/hyperledger/firefly/pkg/tokens"
+	"github.com/hyperledger/firefly/pkg/zkproof"
 	"github.com/spf13/viper"
 )
 
@@ -62,6 +72,9 @@ type Manager interface {
 	Start() error
 	WaitStop()
 	Reset(ctx context.Context) error
+	PreviewConfigReload(ctx context.Context) (*core.ConfigReloadPreview, error)
+	UpdateNamespaceConfig(ctx context.Context, name string, update *core.NamespaceConfigUpdate) (*core.NamespaceConfigUpdateResult, error)
+	CloneNamespace(ctx context.Context, source string, clone *core.NamespaceClone) (*core.NamespaceCloneResult, error)
 
 	Orchestrator(ctx context.Context, ns string, includeInitializing bool) (orchestrator.Orchestrator, error)
 	MustOrchestrator(ns string) orchestrator.Orchestrator
@@ -70,6 +83,10 @@ type Manager interface {
 	GetOperationByNamespacedID(ctx context.Context, nsOpID string) (*core.Operation, error)
 	ResolveOperationByNamespacedID(ctx context.Context, nsOpID string, op *core.OperationUpdateDTO) error
 	Authorize(ctx context.Context, authReq *fftypes.AuthReq) error
+	GetDiagnosticsBundle(ctx context.Context) ([]byte, error)
+	GetCaches(ctx context.Context) []*core.CacheStatus
+	InvalidateCache(ctx context.Context, name string) error
+	InvalidateCacheKey(ctx context.Context, name, key string) error
 }
 
 type namespace struct {
@@ -92,12 +109,14 @@ type namespaceManager struct {
 	ctx                 context.Context
 	cancelCtx           context.CancelFunc
 	nsMux               sync.Mutex
+	reloadMux           sync.Mutex
 	namespaces          map[string]*namespace
 	plugins             map[string]*plugin
 	metricsEnabled      bool
 	cacheManager        cache.Manager
 	metrics             metrics.Manager
 	adminEvents         spievents.Manager
+	changeSinks         changesinks.Manager
 	tokenBroadcastNames map[string]string
 	watchConfig         func() // indirect from viper.WatchConfig for testing
 	nsStartupRetry      *retry.Retry
@@ -111,6 +130,9 @@ type namespaceManager struct {
 	identityFactory      func(ctx context.Context, pluginType string) (identity.Plugin, error)
 	eventsFactory        func(ctx context.Context, pluginType string) (events.Plugin, error)
 	authFactory          func(ctx context.Context, pluginType string) (auth.Plugin, error)
+	signingFactory       func(ctx context.Context, pluginType string) (signing.Plugin, error)
+	zkproofFactory       func(ctx context.Context, pluginType string) (zkproof.Plugin, error)
+	policyFactory        func(ctx context.Context, pluginType string) (policy.Plugin, error)
 }
 
 type pluginCategory string
@@ -124,6 +146,9 @@ const (
 	pluginCategoryIdentity      pluginCategory = "identity"
 	pluginCategoryEvents        pluginCategory = "events"
 	pluginCategoryAuth          pluginCategory = "auth"
+	pluginCategorySigning       pluginCategory = "signing"
+	pluginCategoryZKProof       pluginCategory = "zkproof"
+	pluginCategoryPolicy        pluginCategory = "policy"
 )
 
 type plugin struct {
@@ -134,7 +159,9 @@ type plugin struct {
 	cancelCtx  context.CancelFunc
 	config     config.Section
 	configHash *fftypes.Bytes32
-	loadTime   *fftypes.FFTime
+	// settingsHash excludes the credentials, which can be rotated without restarting some plugins
+	settingsHash *fftypes.Bytes32
+	loadTime     *fftypes.FFTime
 
 	blockchain    blockchain.Plugin
 	database      database.Plugin
@@ -144,6 +171,42 @@ type plugin struct {
 	identity      identity.Plugin
 	events        events.Plugin
 	auth          auth.Plugin
+	signing       signing.Plugin
+	zkproof       zkproof.Plugin
+	policy        policy.Plugin
+
+	// startup is how namespaces wait for the plugin connector to start
+	startup orchestrator.StartupPolicy
+}
+
+// implementation returns the plugin instance, so optional interfaces it supports can be checked
+func (p *plugin) implementation() interface{} {
+	switch p.category {
+	case pluginCategoryBlockchain:
+		return p.blockchain
+	case pluginCategoryDatabase:
+		return p.database
+	case pluginCategoryDataexchange:
+		return p.dataexchange
+	case pluginCategorySharedstorage:
+		return p.sharedstorage
+	case pluginCategoryTokens:
+		return p.tokens
+	case pluginCategoryIdentity:
+		return p.identity
+	case pluginCategoryEvents:
+		return p.events
+	case pluginCategoryAuth:
+		return p.auth
+	case pluginCategorySigning:
+		return p.signing
+	case pluginCategoryZKProof:
+		return p.zkproof
+	case pluginCategoryPolicy:
+		return p.policy
+	default:
+		return nil
+	}
 }
 
 func stringSlicesEqual(a, b []string) bool {
@@ -174,6 +237,9 @@ func NewNamespaceManager() Manager {
 		identityFactory:      iifactory.GetPlugin,
 		eventsFactory:        eifactory.GetPlugin,
 		authFactory:          authfactory.GetPlugin,
+		signingFactory:       sifactory.GetPlugin,
+		zkproofFactory:       zkfactory.GetPlugin,
+		policyFactory:        policyfactory.GetPlugin,
 		nsStartupRetry: &retry.Retry{
 			InitialDelay: config.GetDuration(coreconfig.NamespacesRetryInitDelay),
 			MaximumDelay: config.GetDuration(coreconfig.NamespacesRetryMaxDelay),
@@ -194,7 +260,9 @@ func (nm *namespaceManager) Init(ctx context.Context, cancelCtx context.CancelFu
 	nm.cancelCtx = cancelCtx
 
 	initTimeRawConfig := nm.dumpRootConfig()
-	nm.loadManagers(ctx)
+	if err = nm.loadManagers(ctx); err != nil {
+		return err
+	}
 	if nm.plugins, err = nm.loadPlugins(ctx, initTimeRawConfig); err != nil {
 		return err
 	}
@@ -310,6 +378,7 @@ func (nm *namespaceManager) namespaceStarter(ns *namespace) {
 			ns.started = true
 			ns.initError = ""
 			nm.nsMux.Unlock()
+			nm.notifyLifecycle(core.LifecycleEventTypeNamespaceStarted, ns.Name, "", nil)
 
 			// Notify all the event plugins of the start, so they can re-register their subs.
 			for _, ep := range ns.plugins.Events {
@@ -320,12 +389,30 @@ func (nm *namespaceManager) namespaceStarter(ns *namespace) {
 		// Otherwise the back-off retry should retry indefinitely (until the context is closed, which is
 		// the responsibility of the retry library to check)
 		nm.nsMux.Lock()
+		errorChanged := ns.initError != err.Error()
 		ns.initError = err.Error()
 		nm.nsMux.Unlock()
+		if errorChanged {
+			// Only notify the first of a series of retries that fail the same way
+			nm.notifyLifecycle(core.LifecycleEventTypeNamespaceStartFailed, ns.Name, "", err)
+		}
 		return true, err
 	})
 }
 
+// notifyLifecycle posts a lifecycle event to the SPI webhook, if one is configured
+func (nm *namespaceManager) notifyLifecycle(eventType core.LifecycleEventType, ns, pluginName string, err error) {
+	event := &core.LifecycleEvent{
+		Type:      eventType,
+		Namespace: ns,
+		Plugin:    pluginName,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	nm.adminEvents.NotifyLifecycle(event)
+}
+
 func (nm *namespaceManager) initAndStartNamespace(ns *namespace) error {
 	if err := nm.initNamespace(ns); err != nil {
 		return err
@@ -386,6 +473,7 @@ func (nm *namespaceManager) stopNamespace(ctx context.Context, ns *namespace) {
 		ns.cancelCtx()
 		ns.orchestrator.WaitStop()
 		log.L(ctx).Infof("Namespace '%s' stopped", ns.Name)
+		nm.notifyLifecycle(core.LifecycleEventTypeNamespaceStopped, ns.Name, "", nil)
 	}
 }
 
@@ -430,6 +518,9 @@ func (nm *namespaceManager) WaitStop() {
 		nm.stopNamespace(nm.ctx, ns)
 	}
 	nm.adminEvents.WaitStop()
+	if nm.changeSinks != nil {
+		nm.changeSinks.WaitStop()
+	}
 }
 
 func (nm *namespaceManager) Reset(ctx context.Context) error {
@@ -451,18 +542,29 @@ func (nm *namespaceManager) Reset(ctx context.Context) error {
 	return nil
 }
 
-func (nm *namespaceManager) loadManagers(ctx context.Context) {
+func (nm *namespaceManager) loadManagers(ctx context.Context) (err error) {
 	if nm.metrics == nil {
 		nm.metrics = metrics.NewMetricsManager(ctx)
 	}
 
 	if nm.cacheManager == nil {
-		nm.cacheManager = cache.NewCacheManager(ctx)
+		if nm.cacheManager, err = cache.NewCacheManager(ctx, nm.metrics); err != nil {
+			return err
+		}
 	}
 
 	if nm.adminEvents == nil {
-		nm.adminEvents = spievents.NewAdminEventManager(ctx)
+		if nm.adminEvents, err = spievents.NewAdminEventManager(ctx); err != nil {
+			return err
+		}
+	}
+
+	if nm.changeSinks == nil {
+		if nm.changeSinks, err = changesinks.NewChangeSinkManager(ctx); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 func (nm *namespaceManager) loadPlugins(ctx context.Context, rawConfig fftypes.JSONObject) (newPlugins map[string]*plugin, err error) {
@@ -501,6 +603,18 @@ func (nm *namespaceManager) loadPlugins(ctx context.Context, rawConfig fftypes.J
 		return nil, err
 	}
 
+	if err := nm.getSigningPlugins(ctx, newPlugins, rawConfig); err != nil {
+		return nil, err
+	}
+
+	if err := nm.getZKProofPlugins(ctx, newPlugins, rawConfig); err != nil {
+		return nil, err
+	}
+
+	if err := nm.getPolicyPlugins(ctx, newPlugins, rawConfig); err != nil {
+		return nil, err
+	}
+
 	return newPlugins, nil
 }
 
@@ -534,6 +648,15 @@ func (nm *namespaceManager) getTokensPlugins(ctx context.Context, plugins map[st
 		}
 		broadcastNames[broadcastName] = true
 		nm.tokenBroadcastNames[pc.name] = broadcastName
+		pc.startup = orchestrator.StartupPolicy{
+			Wait:     config.GetDuration(coreconfig.PluginStartupWait),
+			Optional: config.GetBool(coreconfig.PluginStartupOptional),
+			Retry: retry.Retry{
+				InitialDelay: config.GetDuration(coreconfig.PluginStartupRetryInitDelay),
+				MaximumDelay: config.GetDuration(coreconfig.PluginStartupRetryMaxDelay),
+				Factor:       config.GetFloat64(coreconfig.PluginStartupRetryFactor),
+			},
+		}
 
 		pc.tokens, err = nm.tokensFactory(ctx, pc.pluginType)
 		if err != nil {
@@ -588,12 +711,13 @@ func (nm *namespaceManager) newPluginCommon(ctx context.Context, plugins map[str
 	}
 
 	pc := &plugin{
-		name:       name,
-		category:   category,
-		pluginType: pluginType,
-		config:     config.SubSection(pluginType),
-		configHash: nm.configHash(rawConfig),
-		loadTime:   fftypes.Now(),
+		name:         name,
+		category:     category,
+		pluginType:   pluginType,
+		config:       config.SubSection(pluginType),
+		configHash:   nm.configHash(rawConfig),
+		settingsHash: nm.configHash(credentials.StripCredentials(rawConfig)),
+		loadTime:     fftypes.Now(),
 	}
 	log.L(ctx).Tracef("Plugin %s config: %s", name, rawConfig.String())
 	plugins[name] = pc
@@ -686,6 +810,69 @@ func (nm *namespaceManager) getSharedStoragePlugins(ctx context.Context, plugins
 	return nil
 }
 
+func (nm *namespaceManager) getSigningPlugins(ctx context.Context, plugins map[string]*plugin, rawConfig fftypes.JSONObject) (err error) {
+	configSize := signingConfig.ArraySize()
+	rawPluginSigningConfig := rawConfig.GetObject("plugins").GetObjectArray("signing")
+	if len(rawPluginSigningConfig) != configSize {
+		log.L(ctx).Errorf("Expected len(%d) for plugins.signing: %s", configSize, rawPluginSigningConfig)
+		return i18n.NewError(ctx, coremsgs.MsgConfigArrayVsRawConfigMismatch)
+	}
+	for i := 0; i < configSize; i++ {
+		config := signingConfig.ArrayEntry(i)
+		pc, err := nm.validatePluginConfig(ctx, plugins, pluginCategorySigning, config, rawPluginSigningConfig[i])
+		if err == nil {
+			pc.signing, err = nm.signingFactory(ctx, pc.pluginType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (nm *namespaceManager) getZKProofPlugins(ctx context.Context, plugins map[string]*plugin, rawConfig fftypes.JSONObject) (err error) {
+	configSize := zkproofConfig.ArraySize()
+	rawPluginZKProofConfig := rawConfig.GetObject("plugins").GetObjectArray("zkproof")
+	if len(rawPluginZKProofConfig) != configSize {
+		log.L(ctx).Errorf("Expected len(%d) for plugins.zkproof: %s", configSize, rawPluginZKProofConfig)
+		return i18n.NewError(ctx, coremsgs.MsgConfigArrayVsRawConfigMismatch)
+	}
+	for i := 0; i < configSize; i++ {
+		config := zkproofConfig.ArrayEntry(i)
+		pc, err := nm.validatePluginConfig(ctx, plugins, pluginCategoryZKProof, config, rawPluginZKProofConfig[i])
+		if err == nil {
+			pc.zkproof, err = nm.zkproofFactory(ctx, pc.pluginType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (nm *namespaceManager) getPolicyPlugins(ctx context.Context, plugins map[string]*plugin, rawConfig fftypes.JSONObject) (err error) {
+	configSize := policyConfig.ArraySize()
+	rawPluginPolicyConfig := rawConfig.GetObject("plugins").GetObjectArray("policy")
+	if len(rawPluginPolicyConfig) != configSize {
+		log.L(ctx).Errorf("Expected len(%d) for plugins.policy: %s", configSize, rawPluginPolicyConfig)
+		return i18n.NewError(ctx, coremsgs.MsgConfigArrayVsRawConfigMismatch)
+	}
+	for i := 0; i < configSize; i++ {
+		config := policyConfig.ArrayEntry(i)
+		pc, err := nm.validatePluginConfig(ctx, plugins, pluginCategoryPolicy, config, rawPluginPolicyConfig[i])
+		if err == nil {
+			pc.policy, err = nm.policyFactory(ctx, pc.pluginType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (nm *namespaceManager) initPlugins(pluginsToStart map[string]*plugin) (err error) {
 	for name, p := range nm.plugins {
 		if pluginsToStart[name] == nil {
@@ -721,7 +908,20 @@ func (nm *namespaceManager) initPlugins(pluginsToStart map[string]*plugin) (err
 			if err = p.auth.Init(p.ctx, name, p.config); err != nil {
 				return err
 			}
+		case pluginCategorySigning:
+			if err = p.signing.Init(p.ctx, p.config); err != nil {
+				return err
+			}
+		case pluginCategoryZKProof:
+			if err = p.zkproof.Init(p.ctx, p.config); err != nil {
+				return err
+			}
+		case pluginCategoryPolicy:
+			if err = p.policy.Init(p.ctx, p.config); err != nil {
+				return err
+			}
 		}
+		nm.notifyLifecycle(core.LifecycleEventTypePluginStarted, "", name, nil)
 	}
 	return nil
 }
@@ -866,7 +1066,10 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 				pluginCategoryIdentity,
 				pluginCategorySharedstorage,
 				pluginCategoryTokens,
-				pluginCategoryAuth:
+				pluginCategoryAuth,
+				pluginCategorySigning,
+				pluginCategoryZKProof,
+				pluginCategoryPolicy:
 				pluginNames = append(pluginNames, pluginName)
 			}
 		}
@@ -886,6 +1089,24 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 		TokenBroadcastNames:         nm.tokenBroadcastNames,
 		KeyNormalization:            keyNormalization,
 		MaxHistoricalEventScanLimit: config.GetInt(coreconfig.SubscriptionMaxHistoricalEventScanLength),
+		Quotas: core.NamespaceQuotas{
+			MessagesPerDay:    conf.GetInt64(coreconfig.NamespaceQuotasMessagesPerDay),
+			BlobBytes:         conf.GetByteSize(coreconfig.NamespaceQuotasBlobBytes),
+			ContractListeners: conf.GetInt64(coreconfig.NamespaceQuotasContractListeners),
+			Subscriptions:     conf.GetInt64(coreconfig.NamespaceQuotasSubscriptions),
+		},
+		RateLimits:            make(map[core.RateLimitGroup]core.RateLimit),
+		ReadOnly:              conf.GetBool(coreconfig.NamespaceReadOnly),
+		Sandbox:               conf.GetBool(coreconfig.NamespaceSandbox),
+		RequiredConfirmations: conf.GetInt(coreconfig.NamespaceRequiredConfirmations),
+	}
+	rateLimitConf := conf.SubSection(coreconfig.NamespaceRateLimit)
+	for _, group := range core.RateLimitGroups {
+		groupConf := rateLimitConf.SubSection(string(group))
+		config.RateLimits[group] = core.RateLimit{
+			RequestsPerSecond: groupConf.GetFloat64(coreconfig.NamespaceRateLimitRequestsPerSecond),
+			Burst:             groupConf.GetInt(coreconfig.NamespaceRateLimitBurst),
+		}
 	}
 	if multipartyEnabled.(bool) {
 		contractsConf := multipartyConf.SubArray(coreconfig.NamespaceMultipartyContract)
@@ -911,6 +1132,23 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 		config.Multiparty.Contracts = contracts
 		config.Multiparty.Node.Name = nodeName
 		config.Multiparty.Node.Description = nodeDesc
+		config.Multiparty.Bootstrap.Enabled = multipartyConf.GetBool(coreconfig.NamespaceMultipartyBootstrapEnabled)
+		config.Multiparty.Bootstrap.Retry = retry.Retry{
+			InitialDelay: multipartyConf.GetDuration(coreconfig.NamespaceMultipartyBootstrapRetryInitDelay),
+			MaximumDelay: multipartyConf.GetDuration(coreconfig.NamespaceMultipartyBootstrapRetryMaxDelay),
+			Factor:       multipartyConf.GetFloat64(coreconfig.NamespaceMultipartyBootstrapRetryFactor),
+		}
+		config.DefinitionApprovals = definitions.ApprovalPolicy{
+			Required:  multipartyConf.GetInt(coreconfig.NamespaceMultipartyApprovalsRequired),
+			Tags:      multipartyConf.GetStringSlice(coreconfig.NamespaceMultipartyApprovalsTags),
+			Approvers: multipartyConf.GetStringSlice(coreconfig.NamespaceMultipartyApprovalsApprovers),
+		}
+		config.PayloadSigning = identitymanager.PayloadSigningConfig{
+			Enabled:  multipartyConf.GetBool(coreconfig.NamespaceMultipartyPayloadSigningEnabled),
+			KeyFile:  multipartyConf.GetString(coreconfig.NamespaceMultipartyPayloadSigningKeyFile),
+			KeyID:    multipartyConf.GetString(coreconfig.NamespaceMultipartyPayloadSigningKeyID),
+			Required: multipartyConf.GetBool(coreconfig.NamespaceMultipartyPayloadSigningRequired),
+		}
 	}
 
 	ns = &namespace{
@@ -992,8 +1230,9 @@ func (nm *namespaceManager) validateNSPlugins(ctx context.Context, ns *namespace
 			}
 		case pluginCategoryTokens:
 			result.Tokens = append(result.Tokens, orchestrator.TokensPlugin{
-				Name:   pluginName,
-				Plugin: p.tokens,
+				Name:    pluginName,
+				Plugin:  p.tokens,
+				Startup: p.startup,
 			})
 		case pluginCategoryIdentity:
 			if result.Identity.Plugin != nil {
@@ -1011,6 +1250,30 @@ func (nm *namespaceManager) validateNSPlugins(ctx context.Context, ns *namespace
 				Name:   pluginName,
 				Plugin: p.auth,
 			}
+		case pluginCategorySigning:
+			if result.Signing.Plugin != nil {
+				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceMultiplePluginType, ns.Name, "signing")
+			}
+			result.Signing = orchestrator.SigningPlugin{
+				Name:   pluginName,
+				Plugin: p.signing,
+			}
+		case pluginCategoryZKProof:
+			if result.ZKProof.Plugin != nil {
+				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceMultiplePluginType, ns.Name, "zkproof")
+			}
+			result.ZKProof = orchestrator.ZKProofPlugin{
+				Name:   pluginName,
+				Plugin: p.zkproof,
+			}
+		case pluginCategoryPolicy:
+			if result.Policy.Plugin != nil {
+				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceMultiplePluginType, ns.Name, "policy")
+			}
+			result.Policy = orchestrator.PolicyPlugin{
+				Name:   pluginName,
+				Plugin: p.policy,
+			}
 		}
 	}
 	return &result, nil
@@ -41,6 +41,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/retry"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
 	"github.com/hyperledger/firefly/internal/cache"
+	"github.com/hyperledger/firefly/internal/changesinks"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/database/difactory"
 	"github.com/hyperledger/firefly/internal/dataexchange/dxfactory"
@@ -48,8 +49,11 @@ import (
 	"github.com/hyperledger/firefly/internal/identity/iifactory"
 	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/internal/policy/policyfactory"
 	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
+	"github.com/hyperledger/firefly/internal/signing/sifactory"
 	"github.com/hyperledger/firefly/internal/tokens/tifactory"
+	"github.com/hyperledger/firefly/internal/zkproof/zkfactory"
 	"github.com/hyperledger/firefly/mocks/blockchainmocks"
 	"github.com/hyperledger/firefly/mocks/cachemocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
@@ -59,17 +63,23 @@ import (
 	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/orchestratormocks"
+	"github.com/hyperledger/firefly/mocks/policymocks"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
+	"github.com/hyperledger/firefly/mocks/signingmocks"
 	"github.com/hyperledger/firefly/mocks/spieventsmocks"
 	"github.com/hyperledger/firefly/mocks/tokenmocks"
+	"github.com/hyperledger/firefly/mocks/zkproofmocks"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 	"github.com/hyperledger/firefly/pkg/events"
 	"github.com/hyperledger/firefly/pkg/identity"
+	"github.com/hyperledger/firefly/pkg/policy"
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
+	"github.com/hyperledger/firefly/pkg/signing"
 	"github.com/hyperledger/firefly/pkg/tokens"
+	"github.com/hyperledger/firefly/pkg/zkproof"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -140,6 +150,9 @@ type nmMocks struct {
 	mei []*eventsmocks.Plugin
 	mai *authmocks.Plugin
 	mii *identitymocks.Plugin
+	msi *signingmocks.Plugin
+	mzk *zkproofmocks.Plugin
+	mpp *policymocks.Plugin
 	mo  *orchestratormocks.Orchestrator
 }
 
@@ -155,6 +168,9 @@ func (nmm *nmMocks) cleanup(t *testing.T) {
 	nmm.mti[1].AssertExpectations(t)
 	nmm.mai.AssertExpectations(t)
 	nmm.mii.AssertExpectations(t)
+	nmm.msi.AssertExpectations(t)
+	nmm.mzk.AssertExpectations(t)
+	nmm.mpp.AssertExpectations(t)
 	nmm.mei[0].AssertExpectations(t)
 	nmm.mei[1].AssertExpectations(t)
 	nmm.mei[2].AssertExpectations(t)
@@ -180,6 +196,9 @@ func mockPluginFactories(inm Manager) (nmm *nmMocks) {
 		mei: []*eventsmocks.Plugin{{}, {}, {}},
 		mai: &authmocks.Plugin{},
 		mii: &identitymocks.Plugin{},
+		msi: &signingmocks.Plugin{},
+		mzk: &zkproofmocks.Plugin{},
+		mpp: &policymocks.Plugin{},
 		mo:  &orchestratormocks.Orchestrator{},
 	}
 	factoryMocks(&nmm.mbi.Mock, "ethereum")
@@ -232,6 +251,15 @@ func mockPluginFactories(inm Manager) (nmm *nmMocks) {
 	nm.authFactory = func(ctx context.Context, pluginType string) (auth.Plugin, error) {
 		return nmm.mai, nil
 	}
+	nm.signingFactory = func(ctx context.Context, pluginType string) (signing.Plugin, error) {
+		return nmm.msi, nil
+	}
+	nm.zkproofFactory = func(ctx context.Context, pluginType string) (zkproof.Plugin, error) {
+		return nmm.mzk, nil
+	}
+	nm.policyFactory = func(ctx context.Context, pluginType string) (policy.Plugin, error) {
+		return nmm.mpp, nil
+	}
 
 	nmm.nm = nm
 	return nmm
@@ -263,6 +291,7 @@ func newTestNamespaceManager(t *testing.T, initConfig bool) (*namespaceManager,
 	}
 	nmm.nm.metrics = nmm.mmi
 	nmm.nm.adminEvents = nmm.mae
+	nmm.mae.On("NotifyLifecycle", mock.Anything).Return().Maybe()
 
 	if initConfig {
 		viper.SetConfigType("yaml")
@@ -419,6 +448,60 @@ func TestInitAuthFail(t *testing.T) {
 	assert.EqualError(t, err, "pop")
 }
 
+func TestInitSigningFail(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nm.plugins["kms"] = &plugin{
+		name:     "kms",
+		category: pluginCategorySigning,
+		ctx:      nm.ctx,
+		signing:  nmm.msi,
+	}
+	nmm.msi.On("Init", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := nm.initPlugins(map[string]*plugin{
+		"kms": nm.plugins["kms"],
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestInitZKProofFail(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nm.plugins["prover"] = &plugin{
+		name:     "prover",
+		category: pluginCategoryZKProof,
+		ctx:      nm.ctx,
+		zkproof:  nmm.mzk,
+	}
+	nmm.mzk.On("Init", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := nm.initPlugins(map[string]*plugin{
+		"prover": nm.plugins["prover"],
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestInitPolicyFail(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nm.plugins["opa"] = &plugin{
+		name:     "opa",
+		category: pluginCategoryPolicy,
+		ctx:      nm.ctx,
+		policy:   nmm.mpp,
+	}
+	nmm.mpp.On("Init", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := nm.initPlugins(map[string]*plugin{
+		"opa": nm.plugins["opa"],
+	})
+	assert.EqualError(t, err, "pop")
+}
+
 func TestInitOrchestratorFail(t *testing.T) {
 	nm, nmm, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -999,6 +1082,47 @@ func TestMultipleTokensPluginsWithBroadcastName(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTokensPluginStartupPolicy(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	tifactory.InitConfig(tokensConfig)
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  plugins:
+    tokens:
+    - name: test1
+      type: fftokens
+      startup:
+        wait: 1m
+        optional: true
+        retry:
+          initDelay: 5s
+      fftokens:
+        url: http://tokens:3000
+    - name: test2
+      type: fftokens
+      fftokens:
+        url: http://tokens:3000
+  `))
+	assert.NoError(t, err)
+
+	plugins := make(map[string]*plugin)
+	err = nm.getTokensPlugins(context.Background(), plugins, nm.dumpRootConfig())
+	assert.NoError(t, err)
+
+	startup := plugins["test1"].startup
+	assert.Equal(t, 1*time.Minute, startup.Wait)
+	assert.True(t, startup.Optional)
+	assert.Equal(t, 5*time.Second, startup.Retry.InitialDelay)
+	assert.Equal(t, 30*time.Second, startup.Retry.MaximumDelay)
+
+	// Defaults to a single required attempt
+	startup = plugins["test2"].startup
+	assert.Zero(t, startup.Wait)
+	assert.False(t, startup.Optional)
+}
+
 func TestTokensPluginNoType(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, false)
 	defer cleanup()
@@ -1112,6 +1236,186 @@ func TestAuthPluginDuplicate(t *testing.T) {
 	assert.Regexp(t, "FF10395", err)
 }
 
+func TestSigningPlugin(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	sifactory.InitConfig(signingConfig)
+	signingConfig.AddKnownKey(coreconfig.PluginConfigName, "kms")
+	signingConfig.AddKnownKey(coreconfig.PluginConfigType, "vault")
+	config.Set("plugins.signing", []fftypes.JSONObject{{}})
+	plugins := make(map[string]*plugin)
+	err := nm.getSigningPlugins(context.Background(), plugins, nm.dumpRootConfig())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plugins))
+	assert.Equal(t, pluginCategorySigning, plugins["kms"].category)
+}
+
+func TestSigningPluginBadType(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	sifactory.InitConfig(signingConfig)
+	signingConfig.AddKnownKey(coreconfig.PluginConfigName, "kms")
+	signingConfig.AddKnownKey(coreconfig.PluginConfigType, "wrong")
+	config.Set("plugins.signing", []fftypes.JSONObject{{}})
+	nm.signingFactory = func(ctx context.Context, pluginType string) (signing.Plugin, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	err := nm.getSigningPlugins(context.Background(), make(map[string]*plugin), nm.dumpRootConfig())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestSigningPluginRawConfigMismatch(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	sifactory.InitConfig(signingConfig)
+	config.Set("plugins.signing", []fftypes.JSONObject{{}})
+	err := nm.getSigningPlugins(context.Background(), make(map[string]*plugin), fftypes.JSONObject{})
+	assert.Regexp(t, "FF10439", err)
+}
+
+func TestValidateNSPluginsSigning(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	availablePlugins := map[string]*plugin{
+		"kms1": {name: "kms1", category: pluginCategorySigning, signing: nmm.msi},
+		"kms2": {name: "kms2", category: pluginCategorySigning, signing: nmm.msi},
+	}
+	plugins, err := nm.validateNSPlugins(context.Background(), &namespace{
+		Namespace:   core.Namespace{Name: "ns1"},
+		pluginNames: []string{"kms1"},
+	}, availablePlugins)
+	assert.NoError(t, err)
+	assert.Equal(t, "kms1", plugins.Signing.Name)
+	assert.Equal(t, nmm.msi, plugins.Signing.Plugin)
+
+	_, err = nm.validateNSPlugins(context.Background(), &namespace{
+		Namespace:   core.Namespace{Name: "ns1"},
+		pluginNames: []string{"kms1", "kms2"},
+	}, availablePlugins)
+	assert.Regexp(t, "FF10394.*signing", err)
+}
+
+func TestZKProofPlugin(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	zkfactory.InitConfig(zkproofConfig)
+	zkproofConfig.AddKnownKey(coreconfig.PluginConfigName, "prover")
+	zkproofConfig.AddKnownKey(coreconfig.PluginConfigType, "remote")
+	config.Set("plugins.zkproof", []fftypes.JSONObject{{}})
+	plugins := make(map[string]*plugin)
+	err := nm.getZKProofPlugins(context.Background(), plugins, nm.dumpRootConfig())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plugins))
+	assert.Equal(t, pluginCategoryZKProof, plugins["prover"].category)
+}
+
+func TestZKProofPluginBadType(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	zkfactory.InitConfig(zkproofConfig)
+	zkproofConfig.AddKnownKey(coreconfig.PluginConfigName, "prover")
+	zkproofConfig.AddKnownKey(coreconfig.PluginConfigType, "wrong")
+	config.Set("plugins.zkproof", []fftypes.JSONObject{{}})
+	nm.zkproofFactory = func(ctx context.Context, pluginType string) (zkproof.Plugin, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	err := nm.getZKProofPlugins(context.Background(), make(map[string]*plugin), nm.dumpRootConfig())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestZKProofPluginRawConfigMismatch(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	zkfactory.InitConfig(zkproofConfig)
+	config.Set("plugins.zkproof", []fftypes.JSONObject{{}})
+	err := nm.getZKProofPlugins(context.Background(), make(map[string]*plugin), fftypes.JSONObject{})
+	assert.Regexp(t, "FF10439", err)
+}
+
+func TestValidateNSPluginsZKProof(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	availablePlugins := map[string]*plugin{
+		"prover1": {name: "prover1", category: pluginCategoryZKProof, zkproof: nmm.mzk},
+		"prover2": {name: "prover2", category: pluginCategoryZKProof, zkproof: nmm.mzk},
+	}
+	plugins, err := nm.validateNSPlugins(context.Background(), &namespace{
+		Namespace:   core.Namespace{Name: "ns1"},
+		pluginNames: []string{"prover1"},
+	}, availablePlugins)
+	assert.NoError(t, err)
+	assert.Equal(t, "prover1", plugins.ZKProof.Name)
+	assert.Equal(t, nmm.mzk, plugins.ZKProof.Plugin)
+
+	_, err = nm.validateNSPlugins(context.Background(), &namespace{
+		Namespace:   core.Namespace{Name: "ns1"},
+		pluginNames: []string{"prover1", "prover2"},
+	}, availablePlugins)
+	assert.Regexp(t, "FF10394.*zkproof", err)
+}
+
+func TestPolicyPlugin(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	policyfactory.InitConfig(policyConfig)
+	policyConfig.AddKnownKey(coreconfig.PluginConfigName, "opa1")
+	policyConfig.AddKnownKey(coreconfig.PluginConfigType, "opa")
+	config.Set("plugins.policy", []fftypes.JSONObject{{}})
+	plugins := make(map[string]*plugin)
+	err := nm.getPolicyPlugins(context.Background(), plugins, nm.dumpRootConfig())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plugins))
+	assert.Equal(t, pluginCategoryPolicy, plugins["opa1"].category)
+}
+
+func TestPolicyPluginBadType(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	policyfactory.InitConfig(policyConfig)
+	policyConfig.AddKnownKey(coreconfig.PluginConfigName, "opa1")
+	policyConfig.AddKnownKey(coreconfig.PluginConfigType, "wrong")
+	config.Set("plugins.policy", []fftypes.JSONObject{{}})
+	nm.policyFactory = func(ctx context.Context, pluginType string) (policy.Plugin, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	err := nm.getPolicyPlugins(context.Background(), make(map[string]*plugin), nm.dumpRootConfig())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestPolicyPluginRawConfigMismatch(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	policyfactory.InitConfig(policyConfig)
+	config.Set("plugins.policy", []fftypes.JSONObject{{}})
+	err := nm.getPolicyPlugins(context.Background(), make(map[string]*plugin), fftypes.JSONObject{})
+	assert.Regexp(t, "FF10439", err)
+}
+
+func TestValidateNSPluginsPolicy(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	availablePlugins := map[string]*plugin{
+		"opa1": {name: "opa1", category: pluginCategoryPolicy, policy: nmm.mpp},
+		"opa2": {name: "opa2", category: pluginCategoryPolicy, policy: nmm.mpp},
+	}
+	plugins, err := nm.validateNSPlugins(context.Background(), &namespace{
+		Namespace:   core.Namespace{Name: "ns1"},
+		pluginNames: []string{"opa1"},
+	}, availablePlugins)
+	assert.NoError(t, err)
+	assert.Equal(t, "opa1", plugins.Policy.Name)
+	assert.Equal(t, nmm.mpp, plugins.Policy.Plugin)
+
+	_, err = nm.validateNSPlugins(context.Background(), &namespace{
+		Namespace:   core.Namespace{Name: "ns1"},
+		pluginNames: []string{"opa1", "opa2"},
+	}, availablePlugins)
+	assert.Regexp(t, "FF10394.*policy", err)
+}
+
 func TestRawConfigCorrelation(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -1928,7 +2232,20 @@ func TestLoadMetrics(t *testing.T) {
 
 	nm.metrics = nil
 
-	nm.loadManagers(context.Background())
+	err := nm.loadManagers(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestLoadCacheManagerFail(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nm.cacheManager = nil
+	config.Set(coreconfig.CacheDistributedEnabled, true)
+	config.Set(coreconfig.CacheDistributedType, "wrong")
+
+	err := nm.loadManagers(context.Background())
+	assert.Regexp(t, "FF10552", err)
 }
 
 func TestLoadAdminEvents(t *testing.T) {
@@ -1937,10 +2254,23 @@ func TestLoadAdminEvents(t *testing.T) {
 
 	nm.adminEvents = nil
 
-	nm.loadManagers(context.Background())
+	err := nm.loadManagers(context.Background())
+	assert.NoError(t, err)
 	assert.NotNil(t, nm.SPIEvents())
 }
 
+func TestLoadChangeSinksFail(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nm.changeSinks = nil
+	changesinks.InitConfig()
+	config.Set("changesinks", []fftypes.JSONObject{{"name": "sink1", "type": "wrong", "collections": []string{"messages"}}})
+
+	err := nm.loadManagers(context.Background())
+	assert.Regexp(t, "FF10563", err)
+}
+
 func TestGetNamespaces(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
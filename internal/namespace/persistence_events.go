@@ -22,13 +22,21 @@ import (
 	"github.com/hyperledger/firefly/pkg/database"
 )
 
+// dispatchChangeEvent notifies SPI websocket listeners, and any configured change event sinks
+func (nm *namespaceManager) dispatchChangeEvent(changeEvent *core.ChangeEvent) {
+	nm.adminEvents.Dispatch(changeEvent)
+	if nm.changeSinks != nil {
+		nm.changeSinks.Dispatch(changeEvent)
+	}
+}
+
 func (nm *namespaceManager) OrderedUUIDCollectionNSEvent(resType database.OrderedUUIDCollectionNS, eventType core.ChangeEventType, ns string, id *fftypes.UUID, sequence int64) {
 	var ces *int64
 	if eventType == core.ChangeEventTypeCreated {
 		// Sequence is only provided on create events
 		ces = &sequence
 	}
-	nm.adminEvents.Dispatch(&core.ChangeEvent{
+	nm.dispatchChangeEvent(&core.ChangeEvent{
 		Collection: string(resType),
 		Type:       eventType,
 		Namespace:  ns,
@@ -38,7 +46,7 @@ func (nm *namespaceManager) OrderedUUIDCollectionNSEvent(resType database.Ordere
 }
 
 func (nm *namespaceManager) OrderedCollectionNSEvent(resType database.OrderedCollectionNS, eventType core.ChangeEventType, ns string, sequence int64) {
-	nm.adminEvents.Dispatch(&core.ChangeEvent{
+	nm.dispatchChangeEvent(&core.ChangeEvent{
 		Collection: string(resType),
 		Type:       eventType,
 		Namespace:  ns,
@@ -47,7 +55,7 @@ func (nm *namespaceManager) OrderedCollectionNSEvent(resType database.OrderedCol
 }
 
 func (nm *namespaceManager) UUIDCollectionNSEvent(resType database.UUIDCollectionNS, eventType core.ChangeEventType, ns string, id *fftypes.UUID) {
-	nm.adminEvents.Dispatch(&core.ChangeEvent{
+	nm.dispatchChangeEvent(&core.ChangeEvent{
 		Collection: string(resType),
 		Type:       eventType,
 		Namespace:  ns,
@@ -56,7 +64,7 @@ func (nm *namespaceManager) UUIDCollectionNSEvent(resType database.UUIDCollectio
 }
 
 func (nm *namespaceManager) HashCollectionNSEvent(resType database.HashCollectionNS, eventType core.ChangeEventType, ns string, hash *fftypes.Bytes32) {
-	nm.adminEvents.Dispatch(&core.ChangeEvent{
+	nm.dispatchChangeEvent(&core.ChangeEvent{
 		Collection: string(resType),
 		Type:       eventType,
 		Namespace:  ns,
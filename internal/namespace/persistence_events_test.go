@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/changesinksmocks"
 	"github.com/hyperledger/firefly/mocks/spieventsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -95,3 +96,19 @@ func TestHashCollectionNSEventOk(t *testing.T) {
 	nm.HashCollectionNSEvent(database.CollectionGroups, core.ChangeEventTypeDeleted, "ns1", fftypes.NewRandB32())
 	mae.AssertExpectations(t)
 }
+
+func TestMessageCreatedChangeSinks(t *testing.T) {
+	mae := &spieventsmocks.Manager{}
+	mcs := &changesinksmocks.Manager{}
+	nm := &namespaceManager{
+		adminEvents: mae,
+		changeSinks: mcs,
+	}
+	mae.On("Dispatch", mock.Anything).Return()
+	mcs.On("Dispatch", mock.MatchedBy(func(ce *core.ChangeEvent) bool {
+		return ce.Collection == "messages" && ce.Namespace == "ns1" && *ce.Sequence == 12345
+	})).Return()
+	nm.OrderedUUIDCollectionNSEvent(database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", fftypes.NewUUID(), 12345)
+	mae.AssertExpectations(t)
+	mcs.AssertExpectations(t)
+}
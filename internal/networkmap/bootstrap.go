@@ -0,0 +1,142 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkmap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type bootstrapStep struct {
+	name string
+	run  func(ctx context.Context) (registered bool, err error)
+}
+
+// Bootstrap sequences the registration of the root org, then the local node, for a namespace
+// that has not yet joined the network. Each step is skipped if the identity is already registered,
+// and otherwise is submitted (waiting for confirmation) with backoff retry until it succeeds or the
+// context is cancelled.
+func (nm *networkMap) Bootstrap(ctx context.Context, retry *retry.Retry) error {
+	steps := []*bootstrapStep{
+		{name: core.BootstrapStepOrg, run: nm.bootstrapOrg},
+		{name: core.BootstrapStepNode, run: nm.bootstrapNode},
+	}
+
+	nm.bootstrapMux.Lock()
+	nm.bootstrap = &core.NamespaceBootstrapStatus{
+		Enabled: true,
+		Started: fftypes.Now(),
+		Steps:   make([]*core.NamespaceBootstrapStep, len(steps)),
+	}
+	for i, step := range steps {
+		nm.bootstrap.Steps[i] = &core.NamespaceBootstrapStep{
+			Name:   step.name,
+			Status: core.BootstrapStepStatusPending,
+		}
+	}
+	nm.bootstrapMux.Unlock()
+
+	for i, step := range steps {
+		err := retry.Do(ctx, fmt.Sprintf("bootstrap %s", step.name), func(attempt int) (bool, error) {
+			nm.updateBootstrapStep(i, core.BootstrapStepStatusRunning, nil)
+			registered, err := step.run(ctx)
+			if err != nil {
+				log.L(ctx).Warnf("Bootstrap step '%s' failed (attempt %d): %s", step.name, attempt, err)
+				nm.updateBootstrapStep(i, core.BootstrapStepStatusRetrying, err)
+				return true, err
+			}
+			if registered {
+				log.L(ctx).Infof("Bootstrap step '%s' registered identity", step.name)
+			}
+			nm.updateBootstrapStep(i, core.BootstrapStepStatusComplete, nil)
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	nm.bootstrapMux.Lock()
+	nm.bootstrap.Completed = fftypes.Now()
+	nm.bootstrapMux.Unlock()
+	return nil
+}
+
+func (nm *networkMap) updateBootstrapStep(i int, status core.BootstrapStepStatus, err error) {
+	nm.bootstrapMux.Lock()
+	defer nm.bootstrapMux.Unlock()
+	step := nm.bootstrap.Steps[i]
+	if status == core.BootstrapStepStatusRunning {
+		step.Attempts++
+	}
+	if err != nil {
+		step.LastError = err.Error()
+	}
+	step.Status = status
+	step.Updated = fftypes.Now()
+}
+
+func (nm *networkMap) bootstrapOrg(ctx context.Context) (bool, error) {
+	orgDID, err := nm.identity.GetRootOrgDID(ctx)
+	if err != nil {
+		return false, err
+	}
+	org, _, err := nm.identity.CachedIdentityLookupNilOK(ctx, orgDID)
+	if err != nil {
+		return false, err
+	}
+	if org != nil {
+		log.L(ctx).Debugf("Root org '%s' already registered", orgDID)
+		return false, nil
+	}
+	_, err = nm.RegisterNodeOrganization(ctx, true)
+	return err == nil, err
+}
+
+func (nm *networkMap) bootstrapNode(ctx context.Context) (bool, error) {
+	node, err := nm.identity.GetLocalNode(ctx)
+	if err != nil {
+		return false, err
+	}
+	if node != nil {
+		log.L(ctx).Debugf("Local node '%s' already registered", node.DID)
+		return false, nil
+	}
+	_, err = nm.RegisterNode(ctx, true)
+	return err == nil, err
+}
+
+// GetBootstrapStatus returns a copy of the current bootstrap progress
+func (nm *networkMap) GetBootstrapStatus(ctx context.Context) *core.NamespaceBootstrapStatus {
+	nm.bootstrapMux.Lock()
+	defer nm.bootstrapMux.Unlock()
+	if nm.bootstrap == nil {
+		return &core.NamespaceBootstrapStatus{Enabled: false}
+	}
+	status := *nm.bootstrap
+	status.Steps = make([]*core.NamespaceBootstrapStep, len(nm.bootstrap.Steps))
+	for i, step := range nm.bootstrap.Steps {
+		stepCopy := *step
+		status.Steps[i] = &stepCopy
+	}
+	return &status
+}
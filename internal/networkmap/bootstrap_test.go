@@ -0,0 +1,180 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkmap
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testBootstrapRetry = &retry.Retry{
+	InitialDelay: 1 * time.Millisecond,
+	MaximumDelay: 1 * time.Millisecond,
+}
+
+func testNode(name string, parent *fftypes.UUID) *core.Identity {
+	i := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:        fftypes.NewUUID(),
+			Type:      core.IdentityTypeNode,
+			Namespace: "ns1",
+			Name:      name,
+			Parent:    parent,
+		},
+	}
+	i.DID, _ = i.GenerateDID(context.Background())
+	return i
+}
+
+func TestBootstrapAlreadyRegistered(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	org := testOrg("org1")
+	node := testNode("node1", org.ID)
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetRootOrgDID", nm.ctx).Return(org.DID, nil)
+	mim.On("CachedIdentityLookupNilOK", nm.ctx, org.DID).Return(org, false, nil)
+	mim.On("GetLocalNode", nm.ctx).Return(node, nil)
+
+	err := nm.Bootstrap(nm.ctx, testBootstrapRetry)
+	assert.NoError(t, err)
+
+	status := nm.GetBootstrapStatus(nm.ctx)
+	assert.True(t, status.Enabled)
+	assert.NotNil(t, status.Completed)
+	assert.Len(t, status.Steps, 2)
+	for _, step := range status.Steps {
+		assert.Equal(t, core.BootstrapStepStatusComplete, step.Status)
+		assert.Equal(t, 1, step.Attempts)
+	}
+
+	mim.AssertExpectations(t)
+}
+
+func TestBootstrapRegisterOrgAndNodeWithRetry(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	org := testOrg("org1")
+	node := testNode("node1", org.ID)
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetRootOrgDID", nm.ctx).Return(org.DID, nil)
+	mim.On("CachedIdentityLookupNilOK", nm.ctx, org.DID).Return(nil, true, fmt.Errorf("pop")).Once()
+	mim.On("CachedIdentityLookupNilOK", nm.ctx, org.DID).Return(nil, false, nil).Once()
+	mim.On("ResolveMultipartyRootVerifier", nm.ctx).Return(&core.VerifierRef{Value: "0x12345"}, nil)
+	mim.On("VerifyIdentityChain", nm.ctx, mock.AnythingOfType("*core.Identity")).Return(nil, false, nil).Once()
+	mim.On("GetLocalNode", nm.ctx).Return(nil, nil)
+	mim.On("GetRootOrg", nm.ctx).Return(org, nil)
+	mim.On("VerifyIdentityChain", nm.ctx, mock.AnythingOfType("*core.Identity")).Return(org, false, nil).Once()
+	mim.On("ResolveIdentitySigner", nm.ctx, org).Return(&core.SignerRef{Key: "0x12345"}, nil)
+
+	mmp := nm.multiparty.(*multipartymocks.Manager)
+	mmp.On("RootOrg").Return(multiparty.RootOrg{Name: "org1"})
+	mmp.On("LocalNode").Return(multiparty.LocalNode{Name: "node1"})
+
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetEndpointInfo", nm.ctx, "node1").Return(fftypes.JSONObject{"id": "peer1"}, nil)
+
+	msa := nm.syncasync.(*syncasyncmocks.Bridge)
+	msa.On("WaitForIdentity", nm.ctx, mock.Anything, mock.Anything).Return(org, nil).Once()
+	msa.On("WaitForIdentity", nm.ctx, mock.Anything, mock.Anything).Return(node, nil).Once()
+
+	err := nm.Bootstrap(nm.ctx, testBootstrapRetry)
+	assert.NoError(t, err)
+
+	status := nm.GetBootstrapStatus(nm.ctx)
+	assert.NotNil(t, status.Completed)
+	assert.Equal(t, core.BootstrapStepOrg, status.Steps[0].Name)
+	assert.Equal(t, 2, status.Steps[0].Attempts)
+	assert.Regexp(t, "pop", status.Steps[0].LastError)
+	assert.Equal(t, core.BootstrapStepNode, status.Steps[1].Name)
+	assert.Equal(t, 1, status.Steps[1].Attempts)
+
+	mim.AssertExpectations(t)
+	mmp.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	msa.AssertExpectations(t)
+}
+
+func TestBootstrapCancelled(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	ctx, cancelBootstrap := context.WithCancel(nm.ctx)
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetRootOrgDID", ctx).Return("", fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		cancelBootstrap()
+	})
+
+	err := nm.Bootstrap(ctx, testBootstrapRetry)
+	assert.Error(t, err)
+
+	status := nm.GetBootstrapStatus(nm.ctx)
+	assert.Nil(t, status.Completed)
+	assert.Equal(t, core.BootstrapStepStatusRetrying, status.Steps[0].Status)
+	assert.Equal(t, core.BootstrapStepStatusPending, status.Steps[1].Status)
+
+	mim.AssertExpectations(t)
+}
+
+func TestBootstrapNodeLookupFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	org := testOrg("org1")
+	ctx, cancelBootstrap := context.WithCancel(nm.ctx)
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetRootOrgDID", ctx).Return(org.DID, nil)
+	mim.On("CachedIdentityLookupNilOK", ctx, org.DID).Return(org, false, nil)
+	mim.On("GetLocalNode", ctx).Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		cancelBootstrap()
+	})
+
+	err := nm.Bootstrap(ctx, testBootstrapRetry)
+	assert.Error(t, err)
+
+	status := nm.GetBootstrapStatus(nm.ctx)
+	assert.Equal(t, core.BootstrapStepStatusComplete, status.Steps[0].Status)
+	assert.Equal(t, core.BootstrapStepStatusRetrying, status.Steps[1].Status)
+
+	mim.AssertExpectations(t)
+}
+
+func TestGetBootstrapStatusNotEnabled(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	status := nm.GetBootstrapStatus(nm.ctx)
+	assert.False(t, status.Enabled)
+	assert.Empty(t, status.Steps)
+}
@@ -18,9 +18,11 @@ package networkmap
 
 import (
 	"context"
+	"sync"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/retry"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/definitions"
 	"github.com/hyperledger/firefly/internal/identity"
@@ -38,6 +40,8 @@ type Manager interface {
 	RegisterIdentity(ctx context.Context, dto *core.IdentityCreateDTO, waitConfirm bool) (identity *core.Identity, err error)
 	UpdateIdentity(ctx context.Context, id string, dto *core.IdentityUpdateDTO, waitConfirm bool) (identity *core.Identity, err error)
 	CheckNodeIdentityStatus(ctx context.Context) error
+	Bootstrap(ctx context.Context, retry *retry.Retry) error
+	GetBootstrapStatus(ctx context.Context) *core.NamespaceBootstrapStatus
 
 	GetOrganizationByNameOrID(ctx context.Context, nameOrID string) (*core.Identity, error)
 	GetOrganizations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Identity, *ffapi.FilterResult, error)
@@ -58,14 +62,16 @@ type Manager interface {
 }
 
 type networkMap struct {
-	ctx        context.Context
-	namespace  string
-	database   database.Plugin
-	defsender  definitions.Sender
-	exchange   dataexchange.Plugin // optional
-	identity   identity.Manager
-	syncasync  syncasync.Bridge
-	multiparty multiparty.Manager // optional
+	ctx          context.Context
+	namespace    string
+	database     database.Plugin
+	defsender    definitions.Sender
+	exchange     dataexchange.Plugin // optional
+	identity     identity.Manager
+	syncasync    syncasync.Bridge
+	multiparty   multiparty.Manager // optional
+	bootstrapMux sync.Mutex
+	bootstrap    *core.NamespaceBootstrapStatus
 }
 
 func NewNetworkMap(ctx context.Context, ns string, di database.Plugin, dx dataexchange.Plugin, ds definitions.Sender, im identity.Manager, sa syncasync.Bridge, mm multiparty.Manager) (Manager, error) {
@@ -0,0 +1,299 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+var approvalPoliciesConfig = config.RootArray("operations.approvals.policies")
+
+const approvalSweepBatchSize = 100
+
+type approvalPolicy struct {
+	minAmount *big.Int
+	approvers int
+	expiry    time.Duration
+}
+
+func loadApprovalPolicies(ctx context.Context) (map[core.OpType]*approvalPolicy, error) {
+	policies := make(map[core.OpType]*approvalPolicy)
+	for i := 0; i < approvalPoliciesConfig.ArraySize(); i++ {
+		conf := approvalPoliciesConfig.ArrayEntry(i)
+		opType, err := fftypes.FFEnumParseString(ctx, "optype", conf.GetString(coreconfig.OperationsApprovalPolicyType))
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := policies[opType]; exists {
+			return nil, i18n.NewError(ctx, coremsgs.MsgDuplicateApprovalPolicy, opType)
+		}
+		policy := &approvalPolicy{
+			approvers: conf.GetInt(coreconfig.OperationsApprovalPolicyApprovers),
+			expiry:    conf.GetDuration(coreconfig.OperationsApprovalPolicyExpiry),
+		}
+		if policy.approvers < 1 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidApprovalPolicyApprovers, opType, policy.approvers)
+		}
+		if minAmount := conf.GetString(coreconfig.OperationsApprovalPolicyMinAmount); minAmount != "" {
+			var ok bool
+			policy.minAmount, ok = new(big.Int).SetString(minAmount, 10)
+			if !ok || opType != core.OpTypeTokenTransfer {
+				return nil, i18n.NewError(ctx, coremsgs.MsgInvalidApprovalPolicyMinAmount, opType, minAmount)
+			}
+		}
+		policies[opType] = policy
+	}
+	return policies, nil
+}
+
+func (om *operationsManager) getApprovalForOperation(ctx context.Context, opID *fftypes.UUID) (*core.OperationApproval, error) {
+	fb := database.OperationApprovalQueryFactory.NewFilter(ctx)
+	approvals, _, err := om.database.GetOperationApprovals(ctx, om.namespace, fb.And(fb.Eq("operation", opID)).Limit(1))
+	if err != nil || len(approvals) == 0 {
+		return nil, err
+	}
+	return approvals[0], nil
+}
+
+// awaitingApproval checks whether an operation is held back from submission by an approval policy.
+// The first time an operation that needs approval is run, a pending approval is recorded and the operation
+// is left initialized. It is run again by ApproveOperation, once the required number of approvals is given.
+func (om *operationsManager) awaitingApproval(ctx context.Context, po *core.PreparedOperation) (bool, error) {
+	policy, ok := om.approvalPolicies[po.Type]
+	if !ok {
+		return false, nil
+	}
+	op, err := om.GetOperationByIDCached(ctx, po.ID)
+	if err != nil {
+		return false, err
+	}
+	if op == nil {
+		// Fail closed - an operation we cannot find cannot be checked against the policy
+		return false, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	if policy.minAmount != nil {
+		transfer, err := txcommon.RetrieveTokenTransferInputs(ctx, op)
+		if err != nil {
+			return false, err
+		}
+		if transfer.Amount.Int().Cmp(policy.minAmount) < 0 {
+			return false, nil
+		}
+	}
+
+	approval, err := om.getApprovalForOperation(ctx, po.ID)
+	if err != nil {
+		return false, err
+	}
+	if approval != nil {
+		switch approval.Status {
+		case core.OperationApprovalStatusApproved:
+			return false, nil
+		case core.OperationApprovalStatusExpired:
+			return true, i18n.NewError(ctx, coremsgs.MsgOperationApprovalExpired, po.ID, approval.Expires, len(approval.Approvers), approval.Required)
+		default:
+			log.L(ctx).Infof("Operation %s of type %s is still awaiting approval %s (%d/%d)", po.ID, po.Type, approval.ID, len(approval.Approvers), approval.Required)
+			return true, nil
+		}
+	}
+
+	now := fftypes.Now()
+	expires := fftypes.FFTime(time.Time(*now).Add(policy.expiry))
+	approval = &core.OperationApproval{
+		ID:          fftypes.NewUUID(),
+		Namespace:   om.namespace,
+		Operation:   po.ID,
+		Transaction: op.Transaction,
+		Type:        po.Type,
+		Status:      core.OperationApprovalStatusPending,
+		Required:    policy.approvers,
+		Approvers:   fftypes.FFStringArray{},
+		Created:     now,
+		Expires:     &expires,
+	}
+	if err := om.database.InsertOperationApproval(ctx, approval); err != nil {
+		return false, err
+	}
+	log.L(ctx).Infof("Operation %s of type %s requires %d approvals before submission - awaiting approval %s until %s", po.ID, po.Type, approval.Required, approval.ID, approval.Expires)
+	return true, nil
+}
+
+// ApproveOperation records an approval from an authenticated user. When the number of approvals required
+// by the policy is reached the approval is marked approved, and the operation is submitted.
+func (om *operationsManager) ApproveOperation(ctx context.Context, approvalID *fftypes.UUID, approver string) (*core.OperationApproval, error) {
+	if approver == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOperationApprovalNoIdentity)
+	}
+
+	om.approvalLock.Lock()
+	approval, err := om.recordApproval(ctx, approvalID, approver)
+	om.approvalLock.Unlock()
+	if err != nil || approval.Status != core.OperationApprovalStatusApproved {
+		return approval, err
+	}
+
+	log.L(ctx).Infof("Operation %s approved by %s - submitting", approval.Operation, approval.Approvers)
+	return approval, om.submitApprovedOperation(ctx, approval)
+}
+
+func (om *operationsManager) recordApproval(ctx context.Context, approvalID *fftypes.UUID, approver string) (*core.OperationApproval, error) {
+	approval, err := om.database.GetOperationApprovalByID(ctx, om.namespace, approvalID)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOperationApprovalNotFound, approvalID)
+	}
+	if approval.Status != core.OperationApprovalStatusPending {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOperationApprovalNotPending, approvalID, approval.Status)
+	}
+	if approval.HasApproved(approver) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOperationAlreadyApproved, approver, approvalID)
+	}
+
+	approvers := append(fftypes.FFStringArray{}, approval.Approvers...)
+	approvers = append(approvers, approver)
+	status := core.OperationApprovalStatusPending
+	if len(approvers) >= approval.Required {
+		status = core.OperationApprovalStatusApproved
+	}
+
+	// Only update if still pending, in case another node expired or approved it after we read it
+	fb := database.OperationApprovalQueryFactory.NewFilter(ctx)
+	update := database.OperationApprovalQueryFactory.NewUpdate(ctx).
+		Set("approvers", approvers).
+		Set("status", status)
+	updated, err := om.database.UpdateOperationApproval(ctx, om.namespace, approvalID, fb.Eq("status", core.OperationApprovalStatusPending), update)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOperationApprovalNotPending, approvalID, "no longer pending")
+	}
+	approval.Approvers = approvers
+	approval.Status = status
+	approval.Updated = fftypes.Now()
+	return approval, nil
+}
+
+func (om *operationsManager) submitApprovedOperation(ctx context.Context, approval *core.OperationApproval) error {
+	op, err := om.GetOperationByIDCached(ctx, approval.Operation)
+	if err != nil {
+		return err
+	}
+	if op == nil {
+		return i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+
+	var idempotencyKey core.IdempotencyKey
+	tx, err := om.txHelper.GetTransactionByIDCached(ctx, op.Transaction)
+	if err != nil {
+		return err
+	}
+	if tx != nil {
+		idempotencyKey = tx.IdempotencyKey
+	}
+
+	po, err := om.PrepareOperation(ctx, op)
+	if err != nil {
+		return err
+	}
+	_, err = om.RunOperation(ctx, po, idempotencyKey != "")
+	return err
+}
+
+func (om *operationsManager) startApprovalSweeper() {
+	if len(om.approvalPolicies) == 0 {
+		return
+	}
+	om.approvalSweeperDone = make(chan struct{})
+	go om.approvalSweepLoop()
+}
+
+func (om *operationsManager) approvalSweepLoop() {
+	defer close(om.approvalSweeperDone)
+	interval := config.GetDuration(coreconfig.OperationsApprovalsSweepInterval)
+	for {
+		select {
+		case <-time.After(interval):
+		case <-om.ctx.Done():
+			log.L(om.ctx).Debugf("Operation approval sweeper exiting")
+			return
+		}
+		if err := om.expireApprovals(om.ctx); err != nil {
+			log.L(om.ctx).Errorf("Operation approval sweep failed: %s", err)
+		}
+	}
+}
+
+// expireApprovals marks pending approvals that are past their expiry as expired, and fails the
+// operations they were holding back
+func (om *operationsManager) expireApprovals(ctx context.Context) error {
+	fb := database.OperationApprovalQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("status", core.OperationApprovalStatusPending),
+		fb.Lte("expires", fftypes.Now()),
+	).Sort("expires").Limit(approvalSweepBatchSize)
+	approvals, _, err := om.database.GetOperationApprovals(ctx, om.namespace, filter)
+	if err != nil {
+		return err
+	}
+
+	for _, approval := range approvals {
+		update := database.OperationApprovalQueryFactory.NewUpdate(ctx).Set("status", core.OperationApprovalStatusExpired)
+		om.approvalLock.Lock()
+		updated, err := om.database.UpdateOperationApproval(ctx, om.namespace, approval.ID, fb.Eq("status", core.OperationApprovalStatusPending), update)
+		om.approvalLock.Unlock()
+		if err != nil {
+			return err
+		}
+		if !updated {
+			continue
+		}
+
+		reason := i18n.NewError(ctx, coremsgs.MsgOperationApprovalExpired, approval.Operation, approval.Expires, len(approval.Approvers), approval.Required)
+		log.L(ctx).Warnf("Failing operation %s: %s", approval.Operation, reason)
+		op, err := om.GetOperationByIDCached(ctx, approval.Operation)
+		if err != nil {
+			return err
+		}
+		if op == nil {
+			continue
+		}
+		om.SubmitOperationUpdate(&core.OperationUpdateAsync{
+			OperationUpdate: core.OperationUpdate{
+				NamespacedOpID: om.namespace + ":" + op.ID.String(),
+				Plugin:         op.Plugin,
+				Status:         core.OpStatusFailed,
+				ErrorMessage:   reason.Error(),
+			},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,567 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLoadApprovalPolicies(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  approvals:
+    policies:
+    - type: token_transfer
+      minAmount: "1000000"
+      approvers: 3
+      expiry: 1h
+    - type: blockchain_deploy
+`)
+	defer coreconfig.Reset()
+
+	policies, err := loadApprovalPolicies(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	assert.Equal(t, int64(1000000), policies[core.OpTypeTokenTransfer].minAmount.Int64())
+	assert.Equal(t, 3, policies[core.OpTypeTokenTransfer].approvers)
+	assert.Equal(t, 1*time.Hour, policies[core.OpTypeTokenTransfer].expiry)
+	assert.Nil(t, policies[core.OpTypeBlockchainContractDeploy].minAmount)
+	assert.Equal(t, 2, policies[core.OpTypeBlockchainContractDeploy].approvers)
+	assert.Equal(t, 24*time.Hour, policies[core.OpTypeBlockchainContractDeploy].expiry)
+}
+
+func TestLoadApprovalPoliciesBadType(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  approvals:
+    policies:
+    - type: wrong
+`)
+	defer coreconfig.Reset()
+
+	_, err := loadApprovalPolicies(context.Background())
+	assert.Error(t, err)
+}
+
+func TestLoadApprovalPoliciesDuplicate(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  approvals:
+    policies:
+    - type: blockchain_deploy
+    - type: blockchain_deploy
+`)
+	defer coreconfig.Reset()
+
+	_, err := loadApprovalPolicies(context.Background())
+	assert.Regexp(t, "FF10627", err)
+}
+
+func TestLoadApprovalPoliciesNoApprovers(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  approvals:
+    policies:
+    - type: blockchain_deploy
+      approvers: 0
+`)
+	defer coreconfig.Reset()
+
+	_, err := loadApprovalPolicies(context.Background())
+	assert.Regexp(t, "FF10628", err)
+}
+
+func TestLoadApprovalPoliciesMinAmountNotTransfer(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  approvals:
+    policies:
+    - type: blockchain_deploy
+      minAmount: "10"
+`)
+	defer coreconfig.Reset()
+
+	_, err := loadApprovalPolicies(context.Background())
+	assert.Regexp(t, "FF10629", err)
+}
+
+func TestLoadApprovalPoliciesMinAmountBad(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  approvals:
+    policies:
+    - type: token_transfer
+      minAmount: lots
+`)
+	defer coreconfig.Reset()
+
+	_, err := loadApprovalPolicies(context.Background())
+	assert.Regexp(t, "FF10629", err)
+}
+
+func newTestTransferOp(t *testing.T, amount int64) *core.Operation {
+	op := &core.Operation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Transaction: fftypes.NewUUID(),
+		Plugin:      "erc20",
+		Type:        core.OpTypeTokenTransfer,
+		Status:      core.OpStatusInitialized,
+	}
+	err := txcommon.AddTokenTransferInputs(op, &core.TokenTransfer{Amount: *fftypes.NewFFBigInt(amount)})
+	assert.NoError(t, err)
+	return op
+}
+
+func TestRunOperationHeldForApproval(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeTokenTransfer: {minAmount: big.NewInt(100), approvers: 2, expiry: time.Hour},
+	}
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 500)
+	om.cacheOperation(op)
+	po := &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return([]*core.OperationApproval{}, nil, nil)
+	mdi.On("InsertOperationApproval", ctx, mock.MatchedBy(func(approval *core.OperationApproval) bool {
+		return approval.Operation.Equals(op.ID) &&
+			approval.Transaction.Equals(op.Transaction) &&
+			approval.Status == core.OperationApprovalStatusPending &&
+			approval.Required == 2 &&
+			len(approval.Approvers) == 0
+	})).Return(nil)
+
+	om.RegisterHandler(ctx, &mockHandler{RunErr: fmt.Errorf("should not run")}, []core.OpType{core.OpTypeTokenTransfer})
+	outputs, err := om.RunOperation(ctx, po, false)
+	assert.NoError(t, err)
+	assert.Nil(t, outputs)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRunOperationStillPendingApproval(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeTokenTransfer: {approvers: 2, expiry: time.Hour},
+	}
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 1)
+	om.cacheOperation(op)
+	po := &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return([]*core.OperationApproval{
+		{ID: fftypes.NewUUID(), Operation: op.ID, Status: core.OperationApprovalStatusPending, Required: 2},
+	}, nil, nil)
+
+	om.RegisterHandler(ctx, &mockHandler{RunErr: fmt.Errorf("should not run")}, []core.OpType{core.OpTypeTokenTransfer})
+	_, err := om.RunOperation(ctx, po, false)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRunOperationBelowApprovalThreshold(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.updater.initQueues()
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeTokenTransfer: {minAmount: big.NewInt(100), approvers: 2, expiry: time.Hour},
+	}
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 99)
+	om.cacheOperation(op)
+	po := &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}
+
+	om.RegisterHandler(ctx, &mockHandler{Outputs: fftypes.JSONObject{"test": "output"}}, []core.OpType{core.OpTypeTokenTransfer})
+	outputs, err := om.RunOperation(ctx, po, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "output", outputs.GetString("test"))
+}
+
+func TestRunOperationApproved(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.updater.initQueues()
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeTokenTransfer: {approvers: 2, expiry: time.Hour},
+	}
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 500)
+	om.cacheOperation(op)
+	po := &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return([]*core.OperationApproval{
+		{ID: fftypes.NewUUID(), Operation: op.ID, Status: core.OperationApprovalStatusApproved, Required: 2},
+	}, nil, nil)
+
+	om.RegisterHandler(ctx, &mockHandler{Outputs: fftypes.JSONObject{"test": "output"}}, []core.OpType{core.OpTypeTokenTransfer})
+	outputs, err := om.RunOperation(ctx, po, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "output", outputs.GetString("test"))
+}
+
+func TestRunOperationApprovalExpired(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeTokenTransfer: {approvers: 2, expiry: time.Hour},
+	}
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 500)
+	om.cacheOperation(op)
+	po := &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return([]*core.OperationApproval{
+		{ID: fftypes.NewUUID(), Operation: op.ID, Status: core.OperationApprovalStatusExpired, Required: 2, Expires: fftypes.Now()},
+	}, nil, nil)
+
+	om.RegisterHandler(ctx, &mockHandler{}, []core.OpType{core.OpTypeTokenTransfer})
+	_, err := om.RunOperation(ctx, po, false)
+	assert.Regexp(t, "FF10634", err)
+}
+
+func TestRunOperationApprovalOpNotFound(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeBlockchainContractDeploy: {approvers: 2, expiry: time.Hour},
+	}
+
+	ctx := context.Background()
+	po := &core.PreparedOperation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainContractDeploy}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", ctx, "ns1", po.ID).Return(nil, nil)
+
+	om.RegisterHandler(ctx, &mockHandler{}, []core.OpType{core.OpTypeBlockchainContractDeploy})
+	_, err := om.RunOperation(ctx, po, false)
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestRunOperationApprovalInsertFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeTokenTransfer: {approvers: 2, expiry: time.Hour},
+	}
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 500)
+	om.cacheOperation(op)
+	po := &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return([]*core.OperationApproval{}, nil, nil)
+	mdi.On("InsertOperationApproval", ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	om.RegisterHandler(ctx, &mockHandler{}, []core.OpType{core.OpTypeTokenTransfer})
+	_, err := om.RunOperation(ctx, po, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestApproveOperationNoIdentity(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	_, err := om.ApproveOperation(context.Background(), fftypes.NewUUID(), "")
+	assert.Regexp(t, "FF10633", err)
+}
+
+func TestApproveOperationNotFound(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	approvalID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovalByID", ctx, "ns1", approvalID).Return(nil, nil)
+
+	_, err := om.ApproveOperation(ctx, approvalID, "user:alice")
+	assert.Regexp(t, "FF10630", err)
+}
+
+func TestApproveOperationNotPending(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	approvalID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovalByID", ctx, "ns1", approvalID).Return(&core.OperationApproval{
+		ID:     approvalID,
+		Status: core.OperationApprovalStatusExpired,
+	}, nil)
+
+	_, err := om.ApproveOperation(ctx, approvalID, "user:alice")
+	assert.Regexp(t, "FF10631.*expired", err)
+}
+
+func TestApproveOperationDuplicateApprover(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	approvalID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovalByID", ctx, "ns1", approvalID).Return(&core.OperationApproval{
+		ID:        approvalID,
+		Status:    core.OperationApprovalStatusPending,
+		Required:  2,
+		Approvers: fftypes.FFStringArray{"user:alice"},
+	}, nil)
+
+	_, err := om.ApproveOperation(ctx, approvalID, "user:alice")
+	assert.Regexp(t, "FF10632", err)
+}
+
+func TestApproveOperationFirstOfTwo(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	approvalID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovalByID", ctx, "ns1", approvalID).Return(&core.OperationApproval{
+		ID:        approvalID,
+		Status:    core.OperationApprovalStatusPending,
+		Required:  2,
+		Approvers: fftypes.FFStringArray{},
+	}, nil)
+	mdi.On("UpdateOperationApproval", ctx, "ns1", approvalID, mock.Anything, mock.MatchedBy(func(update ffapi.Update) bool {
+		info, _ := update.Finalize()
+		status, _ := info.SetOperations[1].Value.Value()
+		return status == "pending"
+	})).Return(true, nil)
+
+	approval, err := om.ApproveOperation(ctx, approvalID, "user:alice")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OperationApprovalStatusPending, approval.Status)
+	assert.Equal(t, []string{"user:alice"}, []string(approval.Approvers))
+
+	mdi.AssertExpectations(t)
+}
+
+func TestApproveOperationUpdateRace(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	approvalID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovalByID", ctx, "ns1", approvalID).Return(&core.OperationApproval{
+		ID:       approvalID,
+		Status:   core.OperationApprovalStatusPending,
+		Required: 2,
+	}, nil)
+	mdi.On("UpdateOperationApproval", ctx, "ns1", approvalID, mock.Anything, mock.Anything).Return(false, nil)
+
+	_, err := om.ApproveOperation(ctx, approvalID, "user:alice")
+	assert.Regexp(t, "FF10631", err)
+}
+
+func TestApproveOperationUpdateFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	approvalID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovalByID", ctx, "ns1", approvalID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := om.ApproveOperation(ctx, approvalID, "user:alice")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestApproveOperationFinalApprovalSubmits(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.updater.initQueues()
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeTokenTransfer: {approvers: 2, expiry: time.Hour},
+	}
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 500)
+	om.cacheOperation(op)
+	po := &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}
+	approvalID := fftypes.NewUUID()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovalByID", ctx, "ns1", approvalID).Return(&core.OperationApproval{
+		ID:        approvalID,
+		Operation: op.ID,
+		Status:    core.OperationApprovalStatusPending,
+		Required:  2,
+		Approvers: fftypes.FFStringArray{"user:alice"},
+	}, nil)
+	mdi.On("UpdateOperationApproval", ctx, "ns1", approvalID, mock.Anything, mock.Anything).Return(true, nil)
+	mdi.On("GetTransactionByID", ctx, "ns1", op.Transaction).Return(&core.Transaction{ID: op.Transaction}, nil)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return([]*core.OperationApproval{
+		{ID: approvalID, Operation: op.ID, Status: core.OperationApprovalStatusApproved, Required: 2},
+	}, nil, nil)
+
+	handler := &mockHandler{Prepared: po, Phase: core.OpPhasePending}
+	om.RegisterHandler(ctx, handler, []core.OpType{core.OpTypeTokenTransfer})
+	approval, err := om.ApproveOperation(ctx, approvalID, "sub:bob")
+	assert.NoError(t, err)
+	assert.Equal(t, core.OperationApprovalStatusApproved, approval.Status)
+	assert.Equal(t, []string{"user:alice", "sub:bob"}, []string(approval.Approvers))
+
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusPending, update.Status)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestApproveOperationSubmitTXFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 500)
+	om.cacheOperation(op)
+	approvalID := fftypes.NewUUID()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovalByID", ctx, "ns1", approvalID).Return(&core.OperationApproval{
+		ID:        approvalID,
+		Operation: op.ID,
+		Status:    core.OperationApprovalStatusPending,
+		Required:  1,
+	}, nil)
+	mdi.On("UpdateOperationApproval", ctx, "ns1", approvalID, mock.Anything, mock.Anything).Return(true, nil)
+	mdi.On("GetTransactionByID", ctx, "ns1", op.Transaction).Return(nil, fmt.Errorf("pop"))
+
+	approval, err := om.ApproveOperation(ctx, approvalID, "user:alice")
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.OperationApprovalStatusApproved, approval.Status)
+}
+
+func TestExpireApprovals(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.updater.initQueues()
+
+	ctx := context.Background()
+	op := newTestTransferOp(t, 500)
+	om.cacheOperation(op)
+	approval := &core.OperationApproval{
+		ID:        fftypes.NewUUID(),
+		Operation: op.ID,
+		Status:    core.OperationApprovalStatusPending,
+		Required:  2,
+		Approvers: fftypes.FFStringArray{"user:alice"},
+		Expires:   fftypes.Now(),
+	}
+	raced := &core.OperationApproval{
+		ID:        fftypes.NewUUID(),
+		Operation: fftypes.NewUUID(),
+		Status:    core.OperationApprovalStatusPending,
+	}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return([]*core.OperationApproval{approval, raced}, nil, nil)
+	mdi.On("UpdateOperationApproval", ctx, "ns1", approval.ID, mock.Anything, mock.Anything).Return(true, nil)
+	mdi.On("UpdateOperationApproval", ctx, "ns1", raced.ID, mock.Anything, mock.Anything).Return(false, nil)
+
+	err := om.expireApprovals(ctx)
+	assert.NoError(t, err)
+
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, "ns1:"+op.ID.String(), update.NamespacedOpID)
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10634.*1 of 2", update.ErrorMessage)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExpireApprovalsQueryFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := om.expireApprovals(ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExpireApprovalsUpdateFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := context.Background()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", ctx, "ns1", mock.Anything).Return([]*core.OperationApproval{
+		{ID: fftypes.NewUUID(), Status: core.OperationApprovalStatusPending},
+	}, nil, nil)
+	mdi.On("UpdateOperationApproval", ctx, "ns1", mock.Anything, mock.Anything, mock.Anything).Return(false, fmt.Errorf("pop"))
+
+	err := om.expireApprovals(ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestApprovalSweeperStartStop(t *testing.T) {
+	config.Set(coreconfig.OperationsApprovalsSweepInterval, "1ms")
+	defer coreconfig.Reset()
+	om, cancel := newTestOperations(t)
+	om.approvalPolicies = map[core.OpType]*approvalPolicy{
+		core.OpTypeBlockchainContractDeploy: {approvers: 2, expiry: time.Hour},
+	}
+
+	swept := make(chan struct{}, 1)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationApprovals", mock.Anything, "ns1", mock.Anything).Return([]*core.OperationApproval{}, nil, nil).Run(func(args mock.Arguments) {
+		select {
+		case swept <- struct{}{}:
+		default:
+		}
+	})
+
+	err := om.Start()
+	assert.NoError(t, err)
+	<-swept
+	cancel()
+	om.WaitStop()
+}
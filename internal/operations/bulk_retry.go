@@ -0,0 +1,116 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const bulkRetryPageSize = 100
+
+// BulkRetryOperations retries every failed operation matching the filter that has not
+// already been retried, submitting at most the requested number of retries in parallel.
+func (om *operationsManager) BulkRetryOperations(ctx context.Context, filter ffapi.AndFilter, req *core.BulkOperationRetry) (*core.BulkOperationRetryResult, error) {
+	result := &core.BulkOperationRetryResult{
+		DryRun:     req.DryRun,
+		Operations: make([]*core.BulkOperationRetryItem, 0),
+	}
+
+	// Every matching operation is found before any are retried, so the pages do not shift underneath the query.
+	// The limit and skip of the filter passed in are ignored, as they only describe a single page.
+	for skip := uint64(0); ; skip += bulkRetryPageSize {
+		fb := filter.Builder()
+		page := fb.And(filter, fb.Eq("status", core.OpStatusFailed))
+		page.Sort("created").Sort("id").Skip(skip).Limit(bulkRetryPageSize)
+		ops, _, err := om.database.GetOperations(ctx, om.namespace, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range ops {
+			if op.Retry != nil {
+				// Already superseded by a retry, which is the operation that should be retried instead
+				continue
+			}
+			result.Operations = append(result.Operations, &core.BulkOperationRetryItem{
+				Operation: op.ID,
+				Type:      op.Type,
+			})
+		}
+		if len(ops) < bulkRetryPageSize {
+			break
+		}
+	}
+	result.Matched = len(result.Operations)
+	if req.DryRun || result.Matched == 0 {
+		return result, nil
+	}
+
+	concurrency := om.bulkRetryConcurrency(req.Concurrency)
+	log.L(ctx).Infof("Bulk retry of %d failed operations with concurrency %d", result.Matched, concurrency)
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, item := range result.Operations {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(item *core.BulkOperationRetryItem) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			retry, err := om.RetryOperation(ctx, item.Operation)
+			if retry != nil {
+				item.Retry = retry.ID
+			}
+			if err != nil {
+				log.L(ctx).Warnf("Bulk retry of operation %s failed: %s", item.Operation, err)
+				item.Error = err.Error()
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	for _, item := range result.Operations {
+		if item.Error == "" {
+			result.Retried++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+func (om *operationsManager) bulkRetryConcurrency(requested int) int {
+	concurrency := requested
+	if concurrency <= 0 {
+		concurrency = config.GetInt(coreconfig.OperationsBulkRetryConcurrency)
+	}
+	if limit := config.GetInt(coreconfig.OperationsBulkRetryMaxConcurrency); limit > 0 && concurrency > limit {
+		concurrency = limit
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	return concurrency
+}
@@ -0,0 +1,162 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBulkRetryOperationsDryRun(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	failed := &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusFailed}
+	retried := &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusFailed, Retry: fftypes.NewUUID()}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{failed, retried}, nil, nil)
+
+	fb := database.OperationQueryFactory.NewFilter(context.Background())
+	res, err := om.BulkRetryOperations(context.Background(), fb.And(fb.Eq("type", core.OpTypeBlockchainInvoke)), &core.BulkOperationRetry{DryRun: true})
+	assert.NoError(t, err)
+	assert.True(t, res.DryRun)
+	assert.Equal(t, 1, res.Matched)
+	assert.Equal(t, 0, res.Retried)
+	assert.Len(t, res.Operations, 1)
+	assert.Equal(t, failed.ID, res.Operations[0].Operation)
+	assert.Nil(t, res.Operations[0].Retry)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBulkRetryOperations(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := newTestFailedOp(om)
+	missing := &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainPinBatch, Status: core.OpStatusFailed}
+	om.updater.workQueues = []chan *core.OperationUpdateAsync{
+		make(chan *core.OperationUpdateAsync, 1),
+	}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{op, missing}, nil, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", missing.ID).Return(nil, fmt.Errorf("pop"))
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", op.Transaction).Return(&core.Transaction{
+		ID: op.Transaction,
+	}, nil)
+	mdi.On("InsertOperation", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", op.ID, mock.Anything, mock.Anything).Return(true, nil)
+
+	om.RegisterHandler(om.ctx, &mockHandler{Prepared: &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}}, []core.OpType{core.OpTypeBlockchainPinBatch})
+
+	fb := database.OperationQueryFactory.NewFilter(context.Background())
+	res, err := om.BulkRetryOperations(context.Background(), fb.And(), &core.BulkOperationRetry{Concurrency: 2})
+	assert.NoError(t, err)
+	assert.False(t, res.DryRun)
+	assert.Equal(t, 2, res.Matched)
+	assert.Equal(t, 1, res.Retried)
+	assert.Equal(t, 1, res.Failed)
+	assert.NotNil(t, res.Operations[0].Retry)
+	assert.Empty(t, res.Operations[0].Error)
+	assert.Regexp(t, "pop", res.Operations[1].Error)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBulkRetryOperationsPaged(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	page1 := make([]*core.Operation, bulkRetryPageSize)
+	for i := range page1 {
+		page1[i] = &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusFailed}
+	}
+	page2 := []*core.Operation{{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusFailed}}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, _ := filter.Finalize()
+		return fi.Skip == 0 && fi.Limit == bulkRetryPageSize
+	})).Return(page1, nil, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, _ := filter.Finalize()
+		return fi.Skip == bulkRetryPageSize
+	})).Return(page2, nil, nil)
+
+	fb := database.OperationQueryFactory.NewFilter(context.Background())
+	res, err := om.BulkRetryOperations(context.Background(), fb.And().Limit(25), &core.BulkOperationRetry{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, bulkRetryPageSize+1, res.Matched)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBulkRetryOperationsNoneMatched(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+
+	fb := database.OperationQueryFactory.NewFilter(context.Background())
+	res, err := om.BulkRetryOperations(context.Background(), fb.And(), &core.BulkOperationRetry{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.Matched)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBulkRetryOperationsQueryFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.OperationQueryFactory.NewFilter(context.Background())
+	_, err := om.BulkRetryOperations(context.Background(), fb.And(), &core.BulkOperationRetry{})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBulkRetryConcurrency(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	config.Set(coreconfig.OperationsBulkRetryConcurrency, 5)
+	config.Set(coreconfig.OperationsBulkRetryMaxConcurrency, 10)
+	assert.Equal(t, 5, om.bulkRetryConcurrency(0))
+	assert.Equal(t, 3, om.bulkRetryConcurrency(3))
+	assert.Equal(t, 10, om.bulkRetryConcurrency(100))
+
+	config.Set(coreconfig.OperationsBulkRetryConcurrency, 0)
+	assert.Equal(t, 1, om.bulkRetryConcurrency(0))
+}
@@ -0,0 +1,146 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type circuitBreaker struct {
+	state    core.CircuitBreakerState
+	failures int
+	opened   *fftypes.FFTime
+	trial    bool
+}
+
+// circuitBreakers tracks consecutive operation failures for each plugin, and short-circuits
+// calls to a plugin that keeps failing, so a flapping connector does not cause a retry storm
+type circuitBreakers struct {
+	mux       sync.Mutex
+	enabled   bool
+	threshold int
+	cooldown  time.Duration
+	breakers  map[string]*circuitBreaker
+	onChange  func(plugin string, state core.CircuitBreakerState)
+}
+
+func newCircuitBreakers(onChange func(plugin string, state core.CircuitBreakerState)) *circuitBreakers {
+	return &circuitBreakers{
+		enabled:   config.GetBool(coreconfig.OperationsCircuitBreakerEnabled),
+		threshold: config.GetInt(coreconfig.OperationsCircuitBreakerFailureThreshold),
+		cooldown:  config.GetDuration(coreconfig.OperationsCircuitBreakerCooldown),
+		breakers:  make(map[string]*circuitBreaker),
+		onChange:  onChange,
+	}
+}
+
+func (cb *circuitBreakers) setState(ctx context.Context, plugin string, b *circuitBreaker, state core.CircuitBreakerState) {
+	if b.state == state {
+		return
+	}
+	log.L(ctx).Infof("Circuit breaker for plugin '%s' moving from %s to %s (failures=%d)", plugin, b.state, state, b.failures)
+	b.state = state
+	if state == core.CircuitBreakerStateOpen {
+		b.opened = fftypes.Now()
+	}
+	cb.onChange(plugin, state)
+}
+
+// allow returns an error if calls to the plugin are currently short-circuited
+func (cb *circuitBreakers) allow(ctx context.Context, plugin string) error {
+	if !cb.enabled || plugin == "" {
+		return nil
+	}
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+	b := cb.breakers[plugin]
+	if b == nil {
+		b = &circuitBreaker{state: core.CircuitBreakerStateClosed}
+		cb.breakers[plugin] = b
+	}
+	switch b.state {
+	case core.CircuitBreakerStateOpen:
+		retryAfter := time.Time(*b.opened).Add(cb.cooldown)
+		if time.Now().Before(retryAfter) {
+			return i18n.NewError(ctx, coremsgs.MsgCircuitBreakerOpen, plugin, retryAfter.UTC().Format(time.RFC3339))
+		}
+		cb.setState(ctx, plugin, b, core.CircuitBreakerStateHalfOpen)
+		b.trial = true
+	case core.CircuitBreakerStateHalfOpen:
+		// Only one trial call at a time while half open
+		if b.trial {
+			return i18n.NewError(ctx, coremsgs.MsgCircuitBreakerOpen, plugin, time.Now().UTC().Format(time.RFC3339))
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the breaker for the plugin with the outcome of a call that was allowed through
+func (cb *circuitBreakers) record(ctx context.Context, plugin string, failed bool) {
+	if !cb.enabled || plugin == "" {
+		return
+	}
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+	b := cb.breakers[plugin]
+	if b == nil {
+		return
+	}
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		cb.setState(ctx, plugin, b, core.CircuitBreakerStateClosed)
+		return
+	}
+	b.failures++
+	if b.state == core.CircuitBreakerStateHalfOpen || b.failures >= cb.threshold {
+		cb.setState(ctx, plugin, b, core.CircuitBreakerStateOpen)
+	}
+}
+
+func (cb *circuitBreakers) status() []*core.CircuitBreakerStatus {
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+	result := make([]*core.CircuitBreakerStatus, 0, len(cb.breakers))
+	for plugin, b := range cb.breakers {
+		s := &core.CircuitBreakerStatus{
+			Plugin:   plugin,
+			State:    b.state,
+			Failures: b.failures,
+			Opened:   b.opened,
+		}
+		if b.state == core.CircuitBreakerStateOpen {
+			retryAfter := fftypes.FFTime(time.Time(*b.opened).Add(cb.cooldown))
+			s.RetryAfter = &retryAfter
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Plugin < result[j].Plugin })
+	return result
+}
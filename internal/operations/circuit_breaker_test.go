@@ -0,0 +1,158 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCircuitBreakers(threshold int, cooldown string) (*circuitBreakers, *[]core.CircuitBreakerState) {
+	config.Set(coreconfig.OperationsCircuitBreakerEnabled, true)
+	config.Set(coreconfig.OperationsCircuitBreakerFailureThreshold, threshold)
+	config.Set(coreconfig.OperationsCircuitBreakerCooldown, cooldown)
+	changes := []core.CircuitBreakerState{}
+	return newCircuitBreakers(func(plugin string, state core.CircuitBreakerState) {
+		changes = append(changes, state)
+	}), &changes
+}
+
+func TestCircuitBreakerOpenAndRecover(t *testing.T) {
+	cb, changes := newTestCircuitBreakers(2, "1h")
+	ctx := context.Background()
+
+	assert.NoError(t, cb.allow(ctx, "ethereum"))
+	cb.record(ctx, "ethereum", true)
+	assert.NoError(t, cb.allow(ctx, "ethereum"))
+	cb.record(ctx, "ethereum", true)
+
+	err := cb.allow(ctx, "ethereum")
+	assert.Regexp(t, "FF10502.*ethereum", err)
+
+	status := cb.status()
+	assert.Len(t, status, 1)
+	assert.Equal(t, core.CircuitBreakerStateOpen, status[0].State)
+	assert.Equal(t, 2, status[0].Failures)
+	assert.NotNil(t, status[0].RetryAfter)
+
+	// Expire the cooldown, to allow a single trial through
+	opened := fftypes.FFTime(time.Now().Add(-2 * time.Hour))
+	cb.breakers["ethereum"].opened = &opened
+	assert.NoError(t, cb.allow(ctx, "ethereum"))
+	assert.Regexp(t, "FF10502", cb.allow(ctx, "ethereum"))
+	cb.record(ctx, "ethereum", false)
+
+	assert.NoError(t, cb.allow(ctx, "ethereum"))
+	status = cb.status()
+	assert.Equal(t, core.CircuitBreakerStateClosed, status[0].State)
+	assert.Zero(t, status[0].Failures)
+	assert.Equal(t, []core.CircuitBreakerState{
+		core.CircuitBreakerStateOpen,
+		core.CircuitBreakerStateHalfOpen,
+		core.CircuitBreakerStateClosed,
+	}, *changes)
+}
+
+func TestCircuitBreakerTrialFails(t *testing.T) {
+	cb, changes := newTestCircuitBreakers(1, "0s")
+	ctx := context.Background()
+
+	assert.NoError(t, cb.allow(ctx, "ffdx"))
+	cb.record(ctx, "ffdx", true)
+	assert.NoError(t, cb.allow(ctx, "ffdx"))
+	cb.record(ctx, "ffdx", true)
+
+	assert.Equal(t, []core.CircuitBreakerState{
+		core.CircuitBreakerStateOpen,
+		core.CircuitBreakerStateHalfOpen,
+		core.CircuitBreakerStateOpen,
+	}, *changes)
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	cb, _ := newTestCircuitBreakers(1, "1h")
+	cb.enabled = false
+	ctx := context.Background()
+
+	cb.record(ctx, "ethereum", true)
+	assert.NoError(t, cb.allow(ctx, "ethereum"))
+	assert.NoError(t, cb.allow(ctx, ""))
+	cb.record(ctx, "unknown", true)
+	assert.Empty(t, cb.status())
+}
+
+func TestCircuitBreakerRecordUnknown(t *testing.T) {
+	cb, _ := newTestCircuitBreakers(1, "1h")
+	cb.record(context.Background(), "ethereum", true)
+	assert.Empty(t, cb.status())
+}
+
+func TestRunOperationCircuitBreakerOpen(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("CircuitBreakerState", "ns1", "ethereum", core.CircuitBreakerStateOpen).Return()
+	om.metrics = mmi
+	om.breakers, _ = newTestCircuitBreakers(1, "1h")
+	om.breakers.onChange = om.circuitBreakerChanged
+
+	om.updater.workQueues = []chan *core.OperationUpdateAsync{
+		make(chan *core.OperationUpdateAsync, 2),
+	}
+
+	ctx := context.Background()
+	op := &core.PreparedOperation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Plugin:    "ethereum",
+		Type:      core.OpTypeBlockchainPinBatch,
+	}
+
+	om.RegisterHandler(ctx, &mockHandler{
+		RunErr: fmt.Errorf("pop"),
+		Phase:  core.OpPhaseInitializing,
+	}, []core.OpType{core.OpTypeBlockchainPinBatch})
+	_, err := om.RunOperation(ctx, op, false)
+	assert.EqualError(t, err, "pop")
+	<-om.updater.workQueues[0]
+
+	_, err = om.RunOperation(ctx, op, true)
+	assert.Regexp(t, "FF10502", err)
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusInitialized, update.Status)
+
+	assert.Len(t, om.GetCircuitBreakers(), 1)
+	mmi.AssertExpectations(t)
+}
+
+func TestIsPluginFailure(t *testing.T) {
+	assert.False(t, isPluginFailure(nil, core.OpPhaseInitializing))
+	assert.False(t, isPluginFailure(fmt.Errorf("pop"), core.OpPhasePending))
+	assert.False(t, isPluginFailure(&mockConflictErr{err: fmt.Errorf("pop")}, core.OpPhaseInitializing))
+	assert.True(t, isPluginFailure(fmt.Errorf("pop"), core.OpPhaseInitializing))
+}
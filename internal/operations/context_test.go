@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -128,6 +129,28 @@ func TestAddOrReuseOperationNoContext(t *testing.T) {
 	mdi.AssertExpectations(t)
 }
 
+func TestAddOrReuseOperationCorrelationID(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := tracing.WithCorrelationID(context.Background(), "esb-1234")
+	op := &core.Operation{
+		ID:     fftypes.NewUUID(),
+		Type:   core.OpTypeBlockchainPinBatch,
+		Input:  fftypes.JSONObject{"batch": "1"},
+		Status: core.OpStatusPending,
+	}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("InsertOperation", ctx, op).Return(nil).Once()
+
+	err := om.AddOrReuseOperation(ctx, op)
+	assert.NoError(t, err)
+	assert.Equal(t, "esb-1234", op.CorrelationID)
+
+	mdi.AssertExpectations(t)
+}
+
 func TestGetContextKeyBadJSON(t *testing.T) {
 	op := &core.Operation{
 		Input: fftypes.JSONObject{
@@ -0,0 +1,102 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// recordFee stores the gas used and fee paid by the blockchain transaction of an operation, when the
+// connector includes them in its receipt. Failed transactions are recorded too, as they still consume gas.
+func (ou *operationUpdater) recordFee(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
+	if !ou.manager.feesEnabled {
+		return nil
+	}
+	fee := feeFromReceipt(op, update)
+	if fee == nil {
+		return nil
+	}
+	return ou.database.UpsertOperationFee(ctx, fee)
+}
+
+// observeFee reports the fee for an operation to metrics, once its update has been committed
+func (om *operationsManager) observeFee(opID *fftypes.UUID, update *core.OperationUpdate) {
+	if !om.feesEnabled || !om.metrics.IsMetricsEnabled() {
+		return
+	}
+	op := om.getCachedOperation(opID)
+	if op == nil {
+		return
+	}
+	if fee := feeFromReceipt(op, update); fee != nil {
+		gasUsed, _ := new(big.Float).SetInt(fee.GasUsed.Int()).Float64()
+		paid, _ := new(big.Float).SetInt(fee.Fee.Int()).Float64()
+		om.metrics.BlockchainFee(om.namespace, fee.Key, gasUsed, paid)
+	}
+}
+
+func feeFromReceipt(op *core.Operation, update *core.OperationUpdate) *core.OperationFee {
+	if update.Status != core.OpStatusSucceeded && update.Status != core.OpStatusFailed {
+		return nil
+	}
+	if !op.IsBlockchainOperation() && !op.IsTokenOperation() {
+		return nil
+	}
+	gasUsed := receiptBigInt(update.Output, "gasUsed")
+	if gasUsed == nil {
+		return nil
+	}
+	gasPrice := receiptBigInt(update.Output, "effectiveGasPrice")
+	fee := fftypes.NewFFBigInt(0)
+	if gasPrice != nil {
+		fee.Int().Mul(gasUsed.Int(), gasPrice.Int())
+	}
+	return &core.OperationFee{
+		Operation:      op.ID,
+		Namespace:      op.Namespace,
+		Transaction:    op.Transaction,
+		Type:           op.Type,
+		Status:         update.Status,
+		Plugin:         op.Plugin,
+		Key:            op.Input.GetString("key"),
+		BlockchainTXID: update.BlockchainTXID,
+		GasUsed:        gasUsed,
+		GasPrice:       gasPrice,
+		Fee:            fee,
+		Created:        fftypes.Now(),
+	}
+}
+
+// receiptBigInt reads an integer from a receipt, where connectors might supply it as a JSON number,
+// or as a decimal or hex string
+func receiptBigInt(output fftypes.JSONObject, key string) *fftypes.FFBigInt {
+	raw, ok := output[key]
+	if !ok || raw == nil {
+		return nil
+	}
+	b, _ := json.Marshal(raw)
+	var i fftypes.FFBigInt
+	if err := json.Unmarshal(b, &i); err != nil {
+		return nil
+	}
+	return &i
+}
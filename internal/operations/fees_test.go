@@ -0,0 +1,161 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDoUpdateRecordsFee(t *testing.T) {
+	ou := newTestOperationUpdaterNoConcurrency(t)
+	defer ou.close()
+	ou.manager.feesEnabled = true
+
+	opID1 := fftypes.NewUUID()
+	txID1 := fftypes.NewUUID()
+
+	ou.initQueues()
+
+	mdi := ou.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.Anything).Return(true, nil)
+	mdi.On("UpsertOperationFee", mock.Anything, mock.MatchedBy(func(fee *core.OperationFee) bool {
+		return fee.Operation.Equals(opID1) &&
+			fee.Transaction.Equals(txID1) &&
+			fee.Status == core.OpStatusFailed &&
+			fee.Key == "0x12345" &&
+			fee.BlockchainTXID == "0xabcd" &&
+			fee.GasUsed.Int().Int64() == 21000 &&
+			fee.GasPrice.Int().Int64() == 1000000000 &&
+			fee.Fee.Int().Int64() == 21000000000000
+	})).Return(nil)
+
+	err := ou.doUpdate(ou.ctx, &core.OperationUpdate{
+		NamespacedOpID: "ns1:" + opID1.String(),
+		Status:         core.OpStatusFailed,
+		BlockchainTXID: "0xabcd",
+		Output: fftypes.JSONObject{
+			"gasUsed":           "21000",
+			"effectiveGasPrice": "0x3b9aca00",
+		},
+	}, []*core.Operation{{
+		Namespace:   "ns1",
+		ID:          opID1,
+		Transaction: txID1,
+		Type:        core.OpTypeBlockchainInvoke,
+		Input:       fftypes.JSONObject{"key": "0x12345"},
+	}}, []*core.Transaction{})
+
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDoUpdateRecordFeeFail(t *testing.T) {
+	ou := newTestOperationUpdaterNoConcurrency(t)
+	defer ou.close()
+	ou.manager.feesEnabled = true
+
+	opID1 := fftypes.NewUUID()
+
+	ou.initQueues()
+
+	mdi := ou.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.Anything).Return(true, nil)
+	mdi.On("UpsertOperationFee", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := ou.doUpdate(ou.ctx, &core.OperationUpdate{
+		NamespacedOpID: "ns1:" + opID1.String(),
+		Status:         core.OpStatusSucceeded,
+		Output:         fftypes.JSONObject{"gasUsed": 21000},
+	}, []*core.Operation{{
+		Namespace: "ns1",
+		ID:        opID1,
+		Type:      core.OpTypeTokenTransfer,
+	}}, []*core.Transaction{})
+
+	assert.Regexp(t, "pop", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestFeeFromReceiptIgnored(t *testing.T) {
+	blockchainOp := &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainInvoke}
+	gas := fftypes.JSONObject{"gasUsed": "21000"}
+
+	// Not final
+	assert.Nil(t, feeFromReceipt(blockchainOp, &core.OperationUpdate{Status: core.OpStatusPending, Output: gas}))
+	// Not a blockchain operation
+	assert.Nil(t, feeFromReceipt(&core.Operation{Type: core.OpTypeDataExchangeSendBlob}, &core.OperationUpdate{Status: core.OpStatusSucceeded, Output: gas}))
+	// No gas in the receipt
+	assert.Nil(t, feeFromReceipt(blockchainOp, &core.OperationUpdate{Status: core.OpStatusSucceeded}))
+	// Unparseable gas
+	assert.Nil(t, feeFromReceipt(blockchainOp, &core.OperationUpdate{Status: core.OpStatusSucceeded, Output: fftypes.JSONObject{"gasUsed": "lots"}}))
+
+	// Gas price is optional
+	fee := feeFromReceipt(blockchainOp, &core.OperationUpdate{Status: core.OpStatusSucceeded, Output: gas})
+	assert.Equal(t, int64(21000), fee.GasUsed.Int().Int64())
+	assert.Nil(t, fee.GasPrice)
+	assert.Equal(t, int64(0), fee.Fee.Int().Int64())
+}
+
+func TestObserveFee(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("BlockchainFee", "ns1", "0x12345", float64(21000), float64(21000000000000)).Return().Once()
+	om.metrics = mmi
+
+	op := &core.Operation{
+		ID:    fftypes.NewUUID(),
+		Type:  core.OpTypeBlockchainInvoke,
+		Input: fftypes.JSONObject{"key": "0x12345"},
+	}
+	om.cacheOperation(op)
+
+	update := &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+		Output: fftypes.JSONObject{"gasUsed": "21000", "effectiveGasPrice": "1000000000"},
+	}
+	om.observeFee(op.ID, update)
+	om.observeFee(fftypes.NewUUID(), update)
+	om.observeFee(op.ID, &core.OperationUpdate{Status: core.OpStatusSucceeded})
+
+	mmi.AssertExpectations(t)
+}
+
+func TestObserveFeeDisabled(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.feesEnabled = false
+
+	mmi := &metricsmocks.Manager{}
+	om.metrics = mmi
+
+	om.observeFee(fftypes.NewUUID(), &core.OperationUpdate{Status: core.OpStatusSucceeded})
+
+	mmi.AssertExpectations(t)
+}
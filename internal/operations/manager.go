@@ -20,13 +20,18 @@ import (
 	"context"
 	"database/sql/driver"
 	"fmt"
+	"sync"
 
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -41,9 +46,13 @@ type OperationHandler interface {
 
 type Manager interface {
 	RegisterHandler(ctx context.Context, handler OperationHandler, ops []core.OpType)
+	RegisterOutputSchemas(ctx context.Context, plugin core.OperationOutputSchemaProvider) error
+	GetOutputSchemas() []*core.OperationOutputSchema
 	PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error)
 	RunOperation(ctx context.Context, op *core.PreparedOperation, idempotentSubmit bool) (fftypes.JSONObject, error)
 	RetryOperation(ctx context.Context, opID *fftypes.UUID) (*core.Operation, error)
+	ApproveOperation(ctx context.Context, approvalID *fftypes.UUID, approver string) (*core.OperationApproval, error)
+	BulkRetryOperations(ctx context.Context, filter ffapi.AndFilter, req *core.BulkOperationRetry) (*core.BulkOperationRetryResult, error)
 	ResubmitOperations(ctx context.Context, txID *fftypes.UUID) (total int, resubmit []*core.Operation, err error)
 	AddOrReuseOperation(ctx context.Context, op *core.Operation, hooks ...database.PostCompletionHook) error
 	BulkInsertOperations(ctx context.Context, ops ...*core.Operation) error
@@ -51,6 +60,7 @@ type Manager interface {
 	SubmitOperationUpdate(update *core.OperationUpdateAsync)
 	GetOperationByIDCached(ctx context.Context, opID *fftypes.UUID) (*core.Operation, error)
 	ResolveOperationByID(ctx context.Context, opID *fftypes.UUID, op *core.OperationUpdateDTO) error
+	GetCircuitBreakers() []*core.CircuitBreakerStatus
 	Start() error
 	WaitStop()
 }
@@ -76,6 +86,18 @@ type operationsManager struct {
 	txHelper  txcommon.Helper
 	updater   *operationUpdater
 	cache     cache.CInterface
+	metrics   metrics.Manager
+	breakers  *circuitBreakers
+	schemas   map[string]map[core.OpType]*outputSchema
+
+	retryPolicies  map[core.OpType]*retryPolicy
+	historyEnabled bool
+	feesEnabled    bool
+	readOnly       bool
+
+	approvalPolicies    map[core.OpType]*approvalPolicy
+	approvalLock        sync.Mutex
+	approvalSweeperDone chan struct{}
 }
 
 // SubmitBulkOperationUpdate implements Manager.
@@ -90,8 +112,8 @@ func (om *operationsManager) SubmitBulkOperationUpdates(ctx context.Context, upd
 	return om.updater.SubmitBulkOperationUpdates(ctx, updates)
 }
 
-func NewOperationsManager(ctx context.Context, ns string, di database.Plugin, txHelper txcommon.Helper, cacheManager cache.Manager) (Manager, error) {
-	if di == nil || txHelper == nil {
+func NewOperationsManager(ctx context.Context, ns string, di database.Plugin, txHelper txcommon.Helper, mm metrics.Manager, cacheManager cache.Manager, readOnly bool) (Manager, error) {
+	if di == nil || txHelper == nil || mm == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "OperationsManager")
 	}
 
@@ -108,13 +130,33 @@ func NewOperationsManager(ctx context.Context, ns string, di database.Plugin, tx
 		return nil, err
 	}
 
+	retryPolicies, err := loadRetryPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approvalPolicies, err := loadApprovalPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	om := &operationsManager{
 		ctx:       ctx,
 		namespace: ns,
 		database:  di,
 		txHelper:  txHelper,
+		metrics:   mm,
 		handlers:  make(map[core.OpType]OperationHandler),
+		schemas:   make(map[string]map[core.OpType]*outputSchema),
+
+		retryPolicies:  retryPolicies,
+		historyEnabled: config.GetBool(coreconfig.OperationsHistoryEnabled),
+		feesEnabled:    config.GetBool(coreconfig.OperationsFeesEnabled),
+		readOnly:       readOnly,
+
+		approvalPolicies: approvalPolicies,
 	}
+	om.breakers = newCircuitBreakers(om.circuitBreakerChanged)
 	om.updater = newOperationUpdater(ctx, om, di, txHelper)
 	om.cache = cache
 	return om, nil
@@ -180,9 +222,23 @@ func (om *operationsManager) RunOperation(ctx context.Context, op *core.Prepared
 	if !ok {
 		return nil, i18n.NewError(ctx, coremsgs.MsgOperationNotSupported, op.Type)
 	}
+	if om.readOnly {
+		// Every submission to a plugin passes through here, whichever component requested it,
+		// so this is where a read-only replica is stopped from broadcasting, invoking, transferring or pinning
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceReadOnly, om.namespace)
+	}
+	if held, err := om.awaitingApproval(ctx, op); held || err != nil {
+		return nil, err
+	}
 	log.L(ctx).Infof("Executing %s operation %s via handler %s", op.Type, op.ID, handler.Name())
 	log.L(ctx).Tracef("Operation detail: %+v", op)
-	outputs, phase, err := handler.RunOperation(ctx, op)
+	var outputs fftypes.JSONObject
+	phase := core.OpPhaseInitializing
+	err := om.breakers.allow(ctx, op.Plugin)
+	if err == nil {
+		outputs, phase, err = handler.RunOperation(ctx, op)
+		om.breakers.record(ctx, op.Plugin, isPluginFailure(err, phase))
+	}
 	if err != nil {
 		conflictErr, conflictTestOk := err.(ConflictError)
 		var failState core.OpStatus
@@ -236,6 +292,27 @@ func (om *operationsManager) RunOperation(ctx context.Context, op *core.Prepared
 	return outputs, err
 }
 
+// isPluginFailure decides whether an error from a handler counts against the circuit breaker of the plugin.
+// Conflicts mean the connector is alive and already has the action, and errors after submission are progressed
+// asynchronously by the connector - so only failures to get the operation accepted are counted.
+func isPluginFailure(err error, phase core.OpPhase) bool {
+	if err == nil || phase != core.OpPhaseInitializing {
+		return false
+	}
+	conflictErr, conflictTestOk := err.(ConflictError)
+	return !conflictTestOk || !conflictErr.IsConflictError()
+}
+
+func (om *operationsManager) circuitBreakerChanged(plugin string, state core.CircuitBreakerState) {
+	if om.metrics.IsMetricsEnabled() {
+		om.metrics.CircuitBreakerState(om.namespace, plugin, state)
+	}
+}
+
+func (om *operationsManager) GetCircuitBreakers() []*core.CircuitBreakerStatus {
+	return om.breakers.status()
+}
+
 func (om *operationsManager) findLatestRetry(ctx context.Context, opID *fftypes.UUID) (op *core.Operation, err error) {
 	op, err = om.GetOperationByIDCached(ctx, opID)
 	if err != nil {
@@ -273,6 +350,10 @@ func (om *operationsManager) RetryOperation(ctx context.Context, opID *fftypes.U
 		op.Output = nil
 		op.Created = fftypes.Now()
 		op.Updated = op.Created
+		if correlationID := tracing.GetCorrelationID(ctx); correlationID != "" {
+			// The retry is attributed to the request that initiated it, which is also passed to the connector
+			op.CorrelationID = correlationID
+		}
 		if err = om.database.InsertOperation(ctx, op); err != nil {
 			return err
 		}
@@ -281,6 +362,7 @@ func (om *operationsManager) RetryOperation(ctx context.Context, opID *fftypes.U
 		// Update the old operation to point to the new one
 		update := database.OperationQueryFactory.NewUpdate(ctx).Set("retry", op.ID)
 		om.updateCachedOperation(opID, "", nil, nil, op.ID)
+		cache.Changed(ctx, om.cache, opID.String())
 		if _, err := om.database.UpdateOperation(ctx, om.namespace, opID, nil, update); err != nil {
 			return err
 		}
@@ -298,7 +380,7 @@ func (om *operationsManager) RetryOperation(ctx context.Context, opID *fftypes.U
 }
 
 func (om *operationsManager) ResolveOperationByID(ctx context.Context, opID *fftypes.UUID, op *core.OperationUpdateDTO) error {
-	return om.updater.resolveOperation(ctx, om.namespace, opID, op.Status, op.Error, op.Output)
+	return om.updater.resolveOperation(ctx, om.namespace, opID, op.Status, op.Error, op.Output, "", "")
 }
 
 func (om *operationsManager) SubmitOperationUpdate(update *core.OperationUpdateAsync) {
@@ -312,11 +394,15 @@ func (om *operationsManager) SubmitOperationUpdate(update *core.OperationUpdateA
 
 func (om *operationsManager) Start() error {
 	om.updater.start()
+	om.startApprovalSweeper()
 	return nil
 }
 
 func (om *operationsManager) WaitStop() {
 	om.updater.close()
+	if om.approvalSweeperDone != nil {
+		<-om.approvalSweeperDone
+	}
 }
 
 func (om *operationsManager) GetOperationByIDCached(ctx context.Context, opID *fftypes.UUID) (*core.Operation, error) {
@@ -384,3 +470,11 @@ func (om *operationsManager) updateCachedOperation(id *fftypes.UUID, status core
 		om.cacheOperation(val)
 	}
 }
+
+func (om *operationsManager) updateCachedProgress(id *fftypes.UUID, progress *core.OperationProgress) {
+	if cachedValue := om.cache.Get(id.String()); cachedValue != nil {
+		val := cachedValue.(*core.Operation)
+		val.Progress = progress
+		om.cacheOperation(val)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/cachemocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/stretchr/testify/assert"
@@ -93,8 +94,13 @@ func newTestOperations(t *testing.T) (*operationsManager, func()) {
 		}
 	}
 
+	mdi.On("InsertOperationHistory", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
+
 	ns := "ns1"
-	om, err := NewOperationsManager(ctx, ns, mdi, txHelper, cmi)
+	om, err := NewOperationsManager(ctx, ns, mdi, txHelper, mmi, cmi, false)
 	assert.NoError(t, err)
 	cmi.AssertCalled(t, "GetCache", cache.NewCacheConfig(
 		ctx,
@@ -106,7 +112,7 @@ func newTestOperations(t *testing.T) (*operationsManager, func()) {
 }
 
 func TestInitFail(t *testing.T) {
-	_, err := NewOperationsManager(context.Background(), "ns1", nil, nil, nil)
+	_, err := NewOperationsManager(context.Background(), "ns1", nil, nil, nil, nil, false)
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -125,7 +131,7 @@ func TestCacheInitFail(t *testing.T) {
 	ns := "ns1"
 	ecmi := &cachemocks.Manager{}
 	ecmi.On("GetCache", mock.Anything).Return(nil, cacheInitError)
-	_, err := NewOperationsManager(ctx, ns, mdi, txHelper, ecmi)
+	_, err := NewOperationsManager(ctx, ns, mdi, txHelper, &metricsmocks.Manager{}, ecmi, false)
 	assert.Equal(t, cacheInitError, err)
 }
 
@@ -164,6 +170,21 @@ func TestRunOperationNotSupported(t *testing.T) {
 	assert.Regexp(t, "FF10371", err)
 }
 
+func TestRunOperationReadOnly(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.readOnly = true
+
+	ctx := context.Background()
+	op := &core.PreparedOperation{
+		Type: core.OpTypeBlockchainPinBatch,
+	}
+
+	om.RegisterHandler(ctx, &mockHandler{}, []core.OpType{core.OpTypeBlockchainPinBatch})
+	_, err := om.RunOperation(ctx, op, true)
+	assert.Regexp(t, "FF10497", err)
+}
+
 func TestRunOperationSuccess(t *testing.T) {
 	om, cancel := newTestOperations(t)
 	defer cancel()
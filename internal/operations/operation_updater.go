@@ -18,6 +18,7 @@ package operations
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/txcommon"
@@ -126,6 +128,7 @@ func (ou *operationUpdater) SubmitBulkOperationUpdates(ctx context.Context, upda
 		return err
 	}
 
+	ou.afterCommit(ctx, validUpdates)
 	return nil
 }
 
@@ -233,6 +236,7 @@ func (ou *operationUpdater) doBatchUpdateWithRetry(ctx context.Context, updates
 		if err != nil {
 			return true, err
 		}
+		ou.afterCommit(ctx, syncUpdates)
 
 		for _, update := range updates {
 			if update.OnComplete != nil {
@@ -243,6 +247,24 @@ func (ou *operationUpdater) doBatchUpdateWithRetry(ctx context.Context, updates
 	})
 }
 
+// afterCommit kicks off any automatic retries for operations that have been committed as failed,
+// starts progressing the saga of the transaction, and reports any fee, for operations that have reached a final state
+func (ou *operationUpdater) afterCommit(ctx context.Context, updates []*core.OperationUpdate) {
+	for _, update := range updates {
+		if update.Status != core.OpStatusFailed && update.Status != core.OpStatusSucceeded {
+			continue
+		}
+		if _, id, err := core.ParseNamespacedOpID(ctx, update.NamespacedOpID); err == nil {
+			permanent := true
+			if update.Status == core.OpStatusFailed {
+				permanent = ou.manager.scheduleAutoRetry(ctx, id)
+			}
+			go ou.manager.progressSaga(id, update.Status, permanent)
+			ou.manager.observeFee(id, update)
+		}
+	}
+}
+
 func (ou *operationUpdater) doBatchUpdate(ctx context.Context, updates []*core.OperationUpdate) error {
 	// Get all the operations that match
 	opIDs := make([]*fftypes.UUID, 0, len(updates))
@@ -309,6 +331,17 @@ func (ou *operationUpdater) doUpdate(ctx context.Context, update *core.Operation
 		return nil
 	}
 
+	// Record any transfer progress before the status, so a final update can carry the completed totals
+	if update.Progress != nil {
+		if err := ou.updateProgress(ctx, op.Namespace, op.ID, update.Progress); err != nil {
+			return err
+		}
+		if update.Status == "" {
+			// Progress-only updates do not move the operation on, so there is nothing more to do
+			return nil
+		}
+	}
+
 	// Match a TX we already retrieved, if found add a specified Blockchain Transaction ID to it
 	var tx *core.Transaction
 	if op.Transaction != nil && update.BlockchainTXID != "" {
@@ -325,20 +358,36 @@ func (ou *operationUpdater) doUpdate(ctx context.Context, update *core.Operation
 		}
 	}
 
-	if handler, ok := ou.manager.handlers[op.Type]; ok {
-		if err := handler.OnOperationUpdate(ctx, op, update); err != nil {
+	// Special handling for data exchange manifests - before the handler, so it sees the verified status
+	if update.VerifyManifest {
+		if err := ou.verifyManifest(ctx, update, op); err != nil {
 			return err
 		}
 	}
 
-	// Special handling for data exchange manifests
-	if update.VerifyManifest {
-		if err := ou.verifyManifest(ctx, update, op); err != nil {
+	// An output that does not match the schema declared by the plugin is not stored, so clients
+	// reading operation outputs can rely on their structure. The mismatch is recorded as the error.
+	if update.Output != nil {
+		if err := ou.manager.validateOutput(ctx, op, update.Output); err != nil {
+			log.L(ctx).Errorf("Discarding output of operation %s: %s", op.ID, err)
+			update.Output = nil
+			if update.ErrorMessage == "" {
+				update.ErrorMessage = err.Error()
+			}
+		}
+	}
+
+	if handler, ok := ou.manager.handlers[op.Type]; ok {
+		if err := handler.OnOperationUpdate(ctx, op, update); err != nil {
 			return err
 		}
 	}
 
-	if err := ou.resolveOperation(ctx, op.Namespace, op.ID, update.Status, &update.ErrorMessage, update.Output); err != nil {
+	if err := ou.resolveOperation(ctx, op.Namespace, op.ID, update.Status, &update.ErrorMessage, update.Output, update.Plugin, update.BlockchainTXID); err != nil {
+		return err
+	}
+
+	if err := ou.recordFee(ctx, op, update); err != nil {
 		return err
 	}
 
@@ -392,7 +441,7 @@ func (ou *operationUpdater) close() {
 	}
 }
 
-func (ou *operationUpdater) resolveOperation(ctx context.Context, ns string, id *fftypes.UUID, status core.OpStatus, errorMsg *string, output fftypes.JSONObject) (err error) {
+func (ou *operationUpdater) resolveOperation(ctx context.Context, ns string, id *fftypes.UUID, status core.OpStatus, errorMsg *string, output fftypes.JSONObject, plugin, blockchainTXID string) (err error) {
 	// Never move an operation from Succeeded/Failed back to Pending
 	fb := database.OperationQueryFactory.NewFilter(ctx)
 	var filter ffapi.AndFilter
@@ -416,6 +465,51 @@ func (ou *operationUpdater) resolveOperation(ctx context.Context, ns string, id
 	ok, err := ou.database.UpdateOperation(ctx, ns, id, filter, update)
 	if ok && err == nil {
 		ou.manager.updateCachedOperation(id, status, errorMsg, output, nil)
+		cache.Changed(ctx, ou.manager.cache, id.String())
+		if ou.manager.historyEnabled {
+			err = ou.recordHistory(ctx, ns, id, status, errorMsg, output, plugin, blockchainTXID)
+		}
 	}
 	return err
 }
+
+// updateProgress records how far a long running transfer has got, while the operation has not yet reached a final state
+func (ou *operationUpdater) updateProgress(ctx context.Context, ns string, id *fftypes.UUID, progress *core.OperationProgress) error {
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Neq("status", core.OpStatusSucceeded),
+		fb.Neq("status", core.OpStatusFailed),
+	)
+	progressJSON, _ := json.Marshal(progress)
+	update := database.OperationQueryFactory.NewUpdate(ctx).Set("progress", fftypes.JSONAnyPtrBytes(progressJSON))
+	ok, err := ou.database.UpdateOperation(ctx, ns, id, filter, update)
+	if ok && err == nil {
+		ou.manager.updateCachedProgress(id, progress)
+	}
+	return err
+}
+
+// recordHistory appends the transition to the operation history, so the full
+// sequence of updates remains available after the operation itself is overwritten
+func (ou *operationUpdater) recordHistory(ctx context.Context, ns string, id *fftypes.UUID, status core.OpStatus, errorMsg *string, output fftypes.JSONObject, plugin, blockchainTXID string) error {
+	entry := &core.OperationHistoryEntry{
+		ID:             fftypes.NewUUID(),
+		Namespace:      ns,
+		Operation:      id,
+		Status:         status,
+		Plugin:         plugin,
+		BlockchainTXID: blockchainTXID,
+		Receipt:        output,
+		Created:        fftypes.Now(),
+	}
+	if errorMsg != nil {
+		entry.Error = *errorMsg
+	}
+	if op := ou.manager.getCachedOperation(id); op != nil {
+		entry.Transaction = op.Transaction
+		if entry.Plugin == "" {
+			entry.Plugin = op.Plugin
+		}
+	}
+	return ou.database.InsertOperationHistory(ctx, entry)
+}
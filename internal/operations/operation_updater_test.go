@@ -576,3 +576,158 @@ func TestDoUpdateVerifyBlobManifestFail(t *testing.T) {
 
 	mdi.AssertExpectations(t)
 }
+
+func TestDoUpdateRecordsHistory(t *testing.T) {
+	ou := newTestOperationUpdaterNoConcurrency(t)
+	defer ou.close()
+	ou.manager.historyEnabled = true
+
+	opID1 := fftypes.NewUUID()
+	txID1 := fftypes.NewUUID()
+	ou.manager.cacheOperation(&core.Operation{ID: opID1, Transaction: txID1, Plugin: "ethereum"})
+
+	ou.initQueues()
+
+	mdi := ou.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.Anything).Return(true, nil)
+	mdi.On("InsertOperationHistory", mock.Anything, mock.MatchedBy(func(entry *core.OperationHistoryEntry) bool {
+		return entry.Operation.Equals(opID1) &&
+			entry.Transaction.Equals(txID1) &&
+			entry.Status == core.OpStatusSucceeded &&
+			entry.Plugin == "ethereum" &&
+			entry.BlockchainTXID == "0x12345" &&
+			entry.Receipt.GetString("receipt") == "abc"
+	})).Return(nil)
+
+	err := ou.doUpdate(ou.ctx, &core.OperationUpdate{
+		NamespacedOpID: "ns1:" + opID1.String(),
+		Status:         core.OpStatusSucceeded,
+		BlockchainTXID: "0x12345",
+		Output:         fftypes.JSONObject{"receipt": "abc"},
+	}, []*core.Operation{{
+		Namespace: "ns1",
+		ID:        opID1,
+		Type:      core.OpTypeBlockchainInvoke,
+	}}, []*core.Transaction{})
+
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDoUpdateRecordHistoryFail(t *testing.T) {
+	ou := newTestOperationUpdaterNoConcurrency(t)
+	defer ou.close()
+	ou.manager.historyEnabled = true
+
+	opID1 := fftypes.NewUUID()
+
+	ou.initQueues()
+
+	mdi := ou.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.Anything).Return(true, nil)
+	mdi.On("InsertOperationHistory", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := ou.doUpdate(ou.ctx, &core.OperationUpdate{
+		NamespacedOpID: "ns1:" + opID1.String(),
+		Status:         core.OpStatusFailed,
+		ErrorMessage:   "failed",
+	}, []*core.Operation{{
+		Namespace: "ns1",
+		ID:        opID1,
+		Type:      core.OpTypeBlockchainInvoke,
+	}}, []*core.Transaction{})
+
+	assert.Regexp(t, "pop", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDoUpdateProgressOnly(t *testing.T) {
+	ou := newTestOperationUpdaterNoConcurrency(t)
+	defer ou.close()
+	ou.manager.historyEnabled = true
+
+	opID1 := fftypes.NewUUID()
+	ou.manager.cacheOperation(&core.Operation{ID: opID1, Status: core.OpStatusPending})
+	ou.manager.handlers[core.OpTypeDataExchangeSendBlob] = &mockHandler{UpdateErr: fmt.Errorf("not expected")}
+
+	ou.initQueues()
+
+	mdi := ou.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.MatchedBy(updateMatcher([][]string{
+		{"progress", `{"bytesSent":10,"bytesTotal":100}`},
+	}))).Return(true, nil)
+
+	err := ou.doUpdate(ou.ctx, &core.OperationUpdate{
+		NamespacedOpID: "ns1:" + opID1.String(),
+		Progress:       &core.OperationProgress{BytesSent: 10, BytesTotal: 100},
+	}, []*core.Operation{{
+		Namespace: "ns1",
+		ID:        opID1,
+		Type:      core.OpTypeDataExchangeSendBlob,
+	}}, []*core.Transaction{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), ou.manager.getCachedOperation(opID1).Progress.BytesSent)
+	assert.Equal(t, core.OpStatusPending, ou.manager.getCachedOperation(opID1).Status)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDoUpdateProgressWithStatus(t *testing.T) {
+	ou := newTestOperationUpdaterNoConcurrency(t)
+	defer ou.close()
+
+	opID1 := fftypes.NewUUID()
+
+	ou.initQueues()
+
+	mdi := ou.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.MatchedBy(updateMatcher([][]string{
+		{"progress", `{"bytesSent":100,"bytesTotal":100}`},
+	}))).Return(true, nil).Once()
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.MatchedBy(updateMatcher([][]string{
+		{"status", "Succeeded"},
+		{"error", ""},
+	}))).Return(true, nil).Once()
+
+	err := ou.doUpdate(ou.ctx, &core.OperationUpdate{
+		NamespacedOpID: "ns1:" + opID1.String(),
+		Status:         core.OpStatusSucceeded,
+		Progress:       &core.OperationProgress{BytesSent: 100, BytesTotal: 100},
+	}, []*core.Operation{{
+		Namespace: "ns1",
+		ID:        opID1,
+		Type:      core.OpTypeDataExchangeSendBlob,
+	}}, []*core.Transaction{})
+
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDoUpdateProgressFail(t *testing.T) {
+	ou := newTestOperationUpdaterNoConcurrency(t)
+	defer ou.close()
+
+	opID1 := fftypes.NewUUID()
+
+	ou.initQueues()
+
+	mdi := ou.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.Anything).Return(false, fmt.Errorf("pop"))
+
+	err := ou.doUpdate(ou.ctx, &core.OperationUpdate{
+		NamespacedOpID: "ns1:" + opID1.String(),
+		Progress:       &core.OperationProgress{BytesSent: 10, BytesTotal: 100},
+	}, []*core.Operation{{
+		Namespace: "ns1",
+		ID:        opID1,
+		Type:      core.OpTypeDataExchangeSendBlob,
+	}}, []*core.Transaction{})
+
+	assert.Regexp(t, "pop", err)
+
+	mdi.AssertExpectations(t)
+}
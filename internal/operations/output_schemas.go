@@ -0,0 +1,95 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+type outputSchema struct {
+	declared *fftypes.JSONAny
+	compiled *jsonschema.Schema
+}
+
+// RegisterOutputSchemas compiles the output schemas declared by a plugin. It is called while the namespace
+// is initialized, before any operation updates are processed.
+func (om *operationsManager) RegisterOutputSchemas(ctx context.Context, plugin core.OperationOutputSchemaProvider) error {
+	byType := make(map[core.OpType]*outputSchema)
+	for opType, declared := range plugin.OperationOutputSchemas() {
+		c := jsonschema.NewCompiler()
+		c.Draft = jsonschema.Draft2020
+		name := plugin.Name() + ":" + opType.String()
+		err := c.AddResource(name, strings.NewReader(declared.String()))
+		var compiled *jsonschema.Schema
+		if err == nil {
+			compiled, err = c.Compile(name)
+		}
+		if err != nil {
+			return i18n.NewError(ctx, coremsgs.MsgInvalidOperationOutputSchema, plugin.Name(), opType, err)
+		}
+		log.L(ctx).Debugf("OpType=%s output schema registered by plugin %s", opType, plugin.Name())
+		byType[opType] = &outputSchema{declared: declared, compiled: compiled}
+	}
+	om.schemas[plugin.Name()] = byType
+	return nil
+}
+
+func (om *operationsManager) GetOutputSchemas() []*core.OperationOutputSchema {
+	schemas := make([]*core.OperationOutputSchema, 0)
+	for plugin, byType := range om.schemas {
+		for opType, schema := range byType {
+			schemas = append(schemas, &core.OperationOutputSchema{
+				Plugin: plugin,
+				Type:   opType,
+				Schema: schema.declared,
+			})
+		}
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		if schemas[i].Plugin != schemas[j].Plugin {
+			return schemas[i].Plugin < schemas[j].Plugin
+		}
+		return schemas[i].Type < schemas[j].Type
+	})
+	return schemas
+}
+
+// validateOutput checks an output reported by a plugin against the schema it declared for the operation type, if any
+func (om *operationsManager) validateOutput(ctx context.Context, op *core.Operation, output fftypes.JSONObject) error {
+	schema, ok := om.schemas[op.Plugin][op.Type]
+	if !ok {
+		return nil
+	}
+	// Round-trip through JSON, so the validator sees only the generic types it understands
+	var value interface{}
+	b, _ := json.Marshal(output)
+	_ = json.Unmarshal(b, &value)
+	if err := schema.compiled.Validate(value); err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgOperationOutputInvalidPerSchema, op.Plugin, op.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testReceiptSchema = fftypes.JSONAnyPtr(`{
+	"type": "object",
+	"properties": {
+		"transactionHash": {"type": "string"}
+	},
+	"required": ["transactionHash"]
+}`)
+
+func newTestSchemaProvider(name string, schemas map[core.OpType]*fftypes.JSONAny) *coremocks.OperationOutputSchemaProvider {
+	provider := &coremocks.OperationOutputSchemaProvider{}
+	provider.On("Name").Return(name)
+	provider.On("OperationOutputSchemas").Return(schemas)
+	return provider
+}
+
+func TestRegisterAndGetOutputSchemas(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	err := om.RegisterOutputSchemas(om.ctx, newTestSchemaProvider("fftokens", map[core.OpType]*fftypes.JSONAny{
+		core.OpTypeTokenTransfer: fftypes.JSONAnyPtr(`{"type": "object"}`),
+	}))
+	assert.NoError(t, err)
+	err = om.RegisterOutputSchemas(om.ctx, newTestSchemaProvider("ethereum", map[core.OpType]*fftypes.JSONAny{
+		core.OpTypeBlockchainInvoke:         testReceiptSchema,
+		core.OpTypeBlockchainContractDeploy: testReceiptSchema,
+	}))
+	assert.NoError(t, err)
+
+	schemas := om.GetOutputSchemas()
+	assert.Len(t, schemas, 3)
+	assert.Equal(t, "ethereum", schemas[0].Plugin)
+	assert.Equal(t, core.OpTypeBlockchainContractDeploy, schemas[0].Type)
+	assert.Equal(t, "ethereum", schemas[1].Plugin)
+	assert.Equal(t, core.OpTypeBlockchainInvoke, schemas[1].Type)
+	assert.Equal(t, "fftokens", schemas[2].Plugin)
+	assert.Equal(t, testReceiptSchema, schemas[1].Schema)
+}
+
+func TestRegisterOutputSchemasBadJSON(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	err := om.RegisterOutputSchemas(om.ctx, newTestSchemaProvider("ethereum", map[core.OpType]*fftypes.JSONAny{
+		core.OpTypeBlockchainInvoke: fftypes.JSONAnyPtr(`!json`),
+	}))
+	assert.Regexp(t, "FF10612.*ethereum.*blockchain_invoke", err)
+}
+
+func TestRegisterOutputSchemasBadSchema(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	err := om.RegisterOutputSchemas(om.ctx, newTestSchemaProvider("ethereum", map[core.OpType]*fftypes.JSONAny{
+		core.OpTypeBlockchainInvoke: fftypes.JSONAnyPtr(`{"type": "not a type"}`),
+	}))
+	assert.Regexp(t, "FF10612", err)
+}
+
+func TestValidateOutput(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	err := om.RegisterOutputSchemas(om.ctx, newTestSchemaProvider("ethereum", map[core.OpType]*fftypes.JSONAny{
+		core.OpTypeBlockchainInvoke: testReceiptSchema,
+	}))
+	assert.NoError(t, err)
+
+	op := &core.Operation{Plugin: "ethereum", Type: core.OpTypeBlockchainInvoke}
+	err = om.validateOutput(om.ctx, op, fftypes.JSONObject{"transactionHash": "0x12345"})
+	assert.NoError(t, err)
+	err = om.validateOutput(om.ctx, op, fftypes.JSONObject{"transactionHash": 12345})
+	assert.Regexp(t, "FF10613", err)
+
+	// No schema declared for other plugins or types
+	err = om.validateOutput(om.ctx, &core.Operation{Plugin: "fabric", Type: core.OpTypeBlockchainInvoke}, fftypes.JSONObject{})
+	assert.NoError(t, err)
+	err = om.validateOutput(om.ctx, &core.Operation{Plugin: "ethereum", Type: core.OpTypeBlockchainPinBatch}, fftypes.JSONObject{})
+	assert.NoError(t, err)
+}
+
+func TestDoUpdateOutputSchemaMismatch(t *testing.T) {
+	ou := newTestOperationUpdaterNoConcurrency(t)
+	defer ou.close()
+	ou.manager.schemas = make(map[string]map[core.OpType]*outputSchema)
+	err := ou.manager.RegisterOutputSchemas(ou.ctx, newTestSchemaProvider("ethereum", map[core.OpType]*fftypes.JSONAny{
+		core.OpTypeBlockchainInvoke: testReceiptSchema,
+	}))
+	assert.NoError(t, err)
+
+	opID1 := fftypes.NewUUID()
+	ou.initQueues()
+
+	mdi := ou.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID1, mock.Anything, mock.Anything).Return(true, nil)
+
+	update := &core.OperationUpdate{
+		NamespacedOpID: "ns1:" + opID1.String(),
+		Plugin:         "ethereum",
+		Status:         core.OpStatusSucceeded,
+		Output:         fftypes.JSONObject{"unexpected": true},
+	}
+	err = ou.doUpdate(ou.ctx, update, []*core.Operation{{
+		Namespace: "ns1",
+		ID:        opID1,
+		Plugin:    "ethereum",
+		Type:      core.OpTypeBlockchainInvoke,
+	}}, []*core.Transaction{})
+	assert.NoError(t, err)
+	assert.Nil(t, update.Output)
+	assert.Regexp(t, "FF10613", update.ErrorMessage)
+
+	mdi.AssertExpectations(t)
+}
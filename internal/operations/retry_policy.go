@@ -0,0 +1,158 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+var retryPoliciesConfig = config.RootArray("operations.retryPolicies")
+
+func InitConfig() {
+	retryPoliciesConfig.AddKnownKey(coreconfig.OperationsRetryPolicyType)
+	retryPoliciesConfig.AddKnownKey(coreconfig.OperationsRetryPolicyMaxAttempts, 3)
+	retryPoliciesConfig.AddKnownKey(coreconfig.OperationsRetryPolicyInitialDelay, "5s")
+	retryPoliciesConfig.AddKnownKey(coreconfig.OperationsRetryPolicyMaxDelay, "5m")
+	retryPoliciesConfig.AddKnownKey(coreconfig.OperationsRetryPolicyFactor, 2.0)
+	retryPoliciesConfig.AddKnownKey(coreconfig.OperationsRetryPolicyJitter, 0.1)
+
+	approvalPoliciesConfig.AddKnownKey(coreconfig.OperationsApprovalPolicyType)
+	approvalPoliciesConfig.AddKnownKey(coreconfig.OperationsApprovalPolicyMinAmount)
+	approvalPoliciesConfig.AddKnownKey(coreconfig.OperationsApprovalPolicyApprovers, 2)
+	approvalPoliciesConfig.AddKnownKey(coreconfig.OperationsApprovalPolicyExpiry, "24h")
+}
+
+type retryPolicy struct {
+	maxAttempts  int
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	factor       float64
+	jitter       float64
+}
+
+func loadRetryPolicies(ctx context.Context) (map[core.OpType]*retryPolicy, error) {
+	policies := make(map[core.OpType]*retryPolicy)
+	for i := 0; i < retryPoliciesConfig.ArraySize(); i++ {
+		conf := retryPoliciesConfig.ArrayEntry(i)
+		opType, err := fftypes.FFEnumParseString(ctx, "optype", conf.GetString(coreconfig.OperationsRetryPolicyType))
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := policies[opType]; exists {
+			return nil, i18n.NewError(ctx, coremsgs.MsgDuplicateRetryPolicy, opType)
+		}
+		jitter := conf.GetFloat64(coreconfig.OperationsRetryPolicyJitter)
+		if jitter < 0 || jitter > 1 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidRetryPolicyJitter, opType, jitter)
+		}
+		policies[opType] = &retryPolicy{
+			maxAttempts:  conf.GetInt(coreconfig.OperationsRetryPolicyMaxAttempts),
+			initialDelay: conf.GetDuration(coreconfig.OperationsRetryPolicyInitialDelay),
+			maxDelay:     conf.GetDuration(coreconfig.OperationsRetryPolicyMaxDelay),
+			factor:       conf.GetFloat64(coreconfig.OperationsRetryPolicyFactor),
+			jitter:       jitter,
+		}
+	}
+	return policies, nil
+}
+
+// delay calculates the backoff before the given retry, where retry 1 is the first retry after the original attempt
+func (rp *retryPolicy) delay(retry int) time.Duration {
+	d := float64(rp.initialDelay) * math.Pow(rp.factor, float64(retry-1))
+	if d > float64(rp.maxDelay) {
+		d = float64(rp.maxDelay)
+	}
+	if rp.jitter > 0 {
+		d += d * rp.jitter * (rand.Float64()*2 - 1) //nolint:gosec
+	}
+	return time.Duration(d)
+}
+
+// countAttempts walks back through the chain of retries that led to this operation
+func (om *operationsManager) countAttempts(ctx context.Context, op *core.Operation, limit int) (int, error) {
+	attempts := 1
+	id := op.ID
+	for attempts < limit {
+		fb := database.OperationQueryFactory.NewFilter(ctx)
+		parents, _, err := om.database.GetOperations(ctx, om.namespace, fb.And(fb.Eq("retry", id)).Limit(1))
+		if err != nil {
+			return -1, err
+		}
+		if len(parents) == 0 {
+			break
+		}
+		attempts++
+		id = parents[0].ID
+	}
+	return attempts, nil
+}
+
+// scheduleAutoRetry checks whether a failed operation has a retry policy with attempts remaining,
+// and if so schedules a retry after the backoff delay. The retry is recorded as a new operation,
+// linked from the failed one, exactly as for a manual retry.
+// Returns true if the failure is permanent, because no automatic retry will be attempted.
+func (om *operationsManager) scheduleAutoRetry(ctx context.Context, opID *fftypes.UUID) (permanent bool) {
+	if len(om.retryPolicies) == 0 {
+		return true
+	}
+	op, err := om.GetOperationByIDCached(ctx, opID)
+	if err != nil || op == nil {
+		log.L(ctx).Warnf("Unable to check retry policy for operation %s: %v", opID, err)
+		return false
+	}
+	policy, ok := om.retryPolicies[op.Type]
+	if !ok || op.Status != core.OpStatusFailed || op.Retry != nil {
+		return op.Retry == nil
+	}
+	attempts, err := om.countAttempts(ctx, op, policy.maxAttempts)
+	if err != nil {
+		log.L(ctx).Warnf("Unable to count attempts for operation %s: %s", opID, err)
+		return false
+	}
+	if attempts >= policy.maxAttempts {
+		log.L(ctx).Infof("Operation %s of type %s failed after %d attempts - no further automatic retries", op.ID, op.Type, attempts)
+		return true
+	}
+	delay := policy.delay(attempts)
+	log.L(ctx).Infof("Operation %s of type %s failed on attempt %d/%d - retrying in %s", op.ID, op.Type, attempts, policy.maxAttempts, delay)
+	go om.autoRetryAfter(op.ID, delay)
+	return false
+}
+
+func (om *operationsManager) autoRetryAfter(opID *fftypes.UUID, delay time.Duration) {
+	select {
+	case <-time.After(delay):
+	case <-om.ctx.Done():
+		log.L(om.ctx).Debugf("Automatic retry of operation %s cancelled", opID)
+		return
+	}
+	if _, err := om.RetryOperation(om.ctx, opID); err != nil {
+		log.L(om.ctx).Errorf("Automatic retry of operation %s failed: %s", opID, err)
+	}
+}
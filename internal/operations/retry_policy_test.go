@@ -0,0 +1,266 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/cache"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func loadTestRetryConfig(t *testing.T, yaml string) {
+	coreconfig.Reset()
+	InitConfig()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(yaml))
+	assert.NoError(t, err)
+}
+
+func TestLoadRetryPolicies(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  retryPolicies:
+  - type: blockchain_invoke
+    maxAttempts: 5
+    initialDelay: 1s
+  - type: dataexchange_send_batch
+`)
+	defer coreconfig.Reset()
+
+	policies, err := loadRetryPolicies(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, policies, 2)
+	assert.Equal(t, 5, policies[core.OpTypeBlockchainInvoke].maxAttempts)
+	assert.Equal(t, 1*time.Second, policies[core.OpTypeBlockchainInvoke].initialDelay)
+	assert.Equal(t, 3, policies[core.OpTypeDataExchangeSendBatch].maxAttempts)
+	assert.Equal(t, 5*time.Minute, policies[core.OpTypeDataExchangeSendBatch].maxDelay)
+	assert.Equal(t, 2.0, policies[core.OpTypeDataExchangeSendBatch].factor)
+	assert.Equal(t, 0.1, policies[core.OpTypeDataExchangeSendBatch].jitter)
+}
+
+func TestLoadRetryPoliciesBadType(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  retryPolicies:
+  - type: wrong
+`)
+	defer coreconfig.Reset()
+
+	_, err := loadRetryPolicies(context.Background())
+	assert.Error(t, err)
+}
+
+func TestLoadRetryPoliciesDuplicate(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  retryPolicies:
+  - type: blockchain_invoke
+  - type: blockchain_invoke
+`)
+	defer coreconfig.Reset()
+
+	_, err := loadRetryPolicies(context.Background())
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestLoadRetryPoliciesBadJitter(t *testing.T) {
+	loadTestRetryConfig(t, `
+operations:
+  retryPolicies:
+  - type: blockchain_invoke
+    jitter: 1.5
+`)
+	defer coreconfig.Reset()
+
+	_, err := loadRetryPolicies(context.Background())
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	rp := &retryPolicy{
+		initialDelay: 1 * time.Second,
+		maxDelay:     3 * time.Second,
+		factor:       2.0,
+	}
+	assert.Equal(t, 1*time.Second, rp.delay(1))
+	assert.Equal(t, 2*time.Second, rp.delay(2))
+	assert.Equal(t, 3*time.Second, rp.delay(3))
+
+	rp.jitter = 0.5
+	for i := 0; i < 10; i++ {
+		d := rp.delay(1)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, 1500*time.Millisecond)
+	}
+}
+
+func newTestFailedOp(om *operationsManager) *core.Operation {
+	op := &core.Operation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Plugin:      "blockchain",
+		Transaction: fftypes.NewUUID(),
+		Type:        core.OpTypeBlockchainPinBatch,
+		Status:      core.OpStatusFailed,
+	}
+	om.cache = cache.NewUmanagedCache(om.ctx, 100, 10*time.Minute)
+	om.cacheOperation(op)
+	om.retryPolicies = map[core.OpType]*retryPolicy{
+		core.OpTypeBlockchainPinBatch: {maxAttempts: 3, factor: 2.0},
+	}
+	return op
+}
+
+func TestScheduleAutoRetry(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := newTestFailedOp(om)
+	om.updater.workQueues = []chan *core.OperationUpdateAsync{
+		make(chan *core.OperationUpdateAsync, 1),
+	}
+
+	retried := make(chan struct{})
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", op.Transaction).Return(&core.Transaction{
+		ID: op.Transaction,
+	}, nil)
+	mdi.On("InsertOperation", mock.Anything, mock.MatchedBy(func(newOp *core.Operation) bool {
+		return !newOp.ID.Equals(op.ID) && newOp.Status == core.OpStatusInitialized
+	})).Return(nil)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", op.ID, mock.Anything, mock.Anything).Return(true, nil).Run(func(args mock.Arguments) {
+		close(retried)
+	})
+
+	om.RegisterHandler(om.ctx, &mockHandler{Prepared: &core.PreparedOperation{ID: op.ID, Namespace: "ns1", Type: op.Type}}, []core.OpType{core.OpTypeBlockchainPinBatch})
+	om.scheduleAutoRetry(om.ctx, op.ID)
+	<-retried
+
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	mdi.AssertExpectations(t)
+}
+
+func TestScheduleAutoRetryAttemptsExhausted(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := newTestFailedOp(om)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{{ID: fftypes.NewUUID()}}, nil, nil).Twice()
+
+	om.scheduleAutoRetry(om.ctx, op.ID)
+	mdi.AssertExpectations(t)
+}
+
+func TestScheduleAutoRetryCountFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := newTestFailedOp(om)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	om.scheduleAutoRetry(om.ctx, op.ID)
+	mdi.AssertExpectations(t)
+}
+
+func TestScheduleAutoRetryNoPolicy(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := newTestFailedOp(om)
+	op.Type = core.OpTypeBlockchainInvoke
+
+	om.scheduleAutoRetry(om.ctx, op.ID)
+}
+
+func TestScheduleAutoRetryAlreadyRetried(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := newTestFailedOp(om)
+	op.Retry = fftypes.NewUUID()
+
+	om.scheduleAutoRetry(om.ctx, op.ID)
+}
+
+func TestScheduleAutoRetryGetOpFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	om.retryPolicies = map[core.OpType]*retryPolicy{
+		core.OpTypeBlockchainPinBatch: {maxAttempts: 3},
+	}
+
+	opID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", opID).Return(nil, fmt.Errorf("pop"))
+
+	om.scheduleAutoRetry(om.ctx, opID)
+	mdi.AssertExpectations(t)
+}
+
+func TestAutoRetryAfterCancelled(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	cancel()
+
+	om.autoRetryAfter(fftypes.NewUUID(), 1*time.Hour)
+}
+
+func TestAutoRetryAfterFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	opID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", opID).Return(nil, fmt.Errorf("pop"))
+
+	om.autoRetryAfter(opID, 0)
+	mdi.AssertExpectations(t)
+}
+
+func TestAfterCommitIgnoresNonFailed(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := newTestFailedOp(om)
+	op.Retry = fftypes.NewUUID()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", op.Transaction).Return(&core.Transaction{ID: op.Transaction}, nil)
+
+	om.updater.afterCommit(om.ctx, []*core.OperationUpdate{
+		{NamespacedOpID: "ns1:" + op.ID.String(), Status: core.OpStatusSucceeded},
+		{NamespacedOpID: "bad", Status: core.OpStatusFailed},
+		{NamespacedOpID: "ns1:" + op.ID.String(), Status: core.OpStatusFailed},
+	})
+}
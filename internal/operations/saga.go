@@ -0,0 +1,130 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// progressSaga feeds the final status of an operation into the saga of its transaction, if it has one.
+// It runs on its own goroutine once the update has been committed, so that the next step, the failure of
+// skipped steps, and any compensating operations, are submitted outside of the operation update commit path.
+func (om *operationsManager) progressSaga(opID *fftypes.UUID, status core.OpStatus, permanent bool) {
+	ctx := om.ctx
+	op, err := om.GetOperationByIDCached(ctx, opID)
+	if err != nil || op == nil || op.Transaction == nil {
+		return
+	}
+	tx, err := om.txHelper.GetTransactionByIDCached(ctx, op.Transaction)
+	if err != nil || tx == nil || tx.Saga == nil {
+		return
+	}
+
+	// Steps are declared against the first attempt of an operation, so follow any retries back to it
+	stepOpID, err := om.firstAttempt(ctx, op)
+	if err != nil {
+		log.L(ctx).Errorf("Unable to find first attempt of operation %s for saga of transaction %s: %s", op.ID, op.Transaction, err)
+		return
+	}
+	progress, err := om.txHelper.UpdateSagaOperation(ctx, op.Transaction, stepOpID, status, permanent)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to update saga of transaction %s for operation %s: %s", op.Transaction, op.ID, err)
+		return
+	}
+	if progress.Next != nil {
+		om.submitSagaStep(ctx, progress.Next)
+	}
+	for _, skipped := range progress.Skipped {
+		om.skipSagaStep(ctx, skipped, stepOpID)
+	}
+	for _, compensation := range progress.Compensations {
+		om.submitCompensation(ctx, compensation)
+	}
+}
+
+func (om *operationsManager) firstAttempt(ctx context.Context, op *core.Operation) (*fftypes.UUID, error) {
+	id := op.ID
+	for {
+		fb := database.OperationQueryFactory.NewFilter(ctx)
+		parents, _, err := om.database.GetOperations(ctx, om.namespace, fb.And(fb.Eq("retry", id)).Limit(1))
+		if err != nil {
+			return nil, err
+		}
+		if len(parents) == 0 {
+			return id, nil
+		}
+		id = parents[0].ID
+	}
+}
+
+func (om *operationsManager) submitSagaStep(ctx context.Context, opID *fftypes.UUID) {
+	op, err := om.GetOperationByIDCached(ctx, opID)
+	if err != nil || op == nil {
+		log.L(ctx).Errorf("Unable to find operation %s for the next saga step: %v", opID, err)
+		return
+	}
+	log.L(ctx).Infof("Submitting %s operation %s for the next saga step of transaction %s", op.Type, op.ID, op.Transaction)
+	om.runSagaOperation(ctx, op)
+}
+
+func (om *operationsManager) skipSagaStep(ctx context.Context, opID, failedOpID *fftypes.UUID) {
+	op, err := om.GetOperationByIDCached(ctx, opID)
+	if err != nil || op == nil {
+		log.L(ctx).Errorf("Unable to find operation %s for a skipped saga step: %v", opID, err)
+		return
+	}
+	om.failSagaOperation(ctx, op, i18n.NewError(ctx, coremsgs.MsgSagaStepSkipped, failedOpID).Error())
+}
+
+func (om *operationsManager) submitCompensation(ctx context.Context, op *core.Operation) {
+	log.L(ctx).Infof("Submitting %s compensation operation %s for transaction %s", op.Type, op.ID, op.Transaction)
+	if err := om.database.InsertOperation(ctx, op); err != nil {
+		log.L(ctx).Errorf("Failed to insert compensation operation %s: %s", op.ID, err)
+		return
+	}
+	om.cacheOperation(op)
+	om.runSagaOperation(ctx, op)
+}
+
+func (om *operationsManager) runSagaOperation(ctx context.Context, op *core.Operation) {
+	po, err := om.PrepareOperation(ctx, op)
+	if err == nil {
+		// Failures from running the operation are recorded against it by RunOperation
+		_, _ = om.RunOperation(ctx, po, false)
+		return
+	}
+	om.failSagaOperation(ctx, op, err.Error())
+}
+
+func (om *operationsManager) failSagaOperation(ctx context.Context, op *core.Operation, errorMessage string) {
+	log.L(ctx).Errorf("Failing operation %s of saga of transaction %s: %s", op.ID, op.Transaction, errorMessage)
+	om.SubmitOperationUpdate(&core.OperationUpdateAsync{
+		OperationUpdate: core.OperationUpdate{
+			NamespacedOpID: op.NamespacedIDString(),
+			Plugin:         op.Plugin,
+			Status:         core.OpStatusFailed,
+			ErrorMessage:   errorMessage,
+		},
+	})
+}
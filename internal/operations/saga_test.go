@@ -0,0 +1,263 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSagaOp(om *operationsManager) (*core.Operation, *core.Transaction) {
+	op := newTestFailedOp(om)
+	tx := &core.Transaction{
+		ID:        op.Transaction,
+		Namespace: "ns1",
+		Saga: &core.TransactionSaga{
+			State: core.SagaStateActive,
+			Steps: []*core.SagaStep{
+				{
+					Operation: fftypes.NewUUID(),
+					Status:    core.OpStatusSucceeded,
+					Compensation: &core.SagaCompensation{
+						Type:   core.OpTypeBlockchainInvoke,
+						Plugin: "blockchain",
+						Input:  fftypes.JSONObject{"method": "undo"},
+					},
+				},
+				{Operation: op.ID, Status: core.OpStatusPending},
+			},
+		},
+	}
+	om.updater.workQueues = []chan *core.OperationUpdateAsync{
+		make(chan *core.OperationUpdateAsync, 1),
+	}
+	return op, tx
+}
+
+func TestProgressSagaCompensates(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	op, tx := newTestSagaOp(om)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx.ID).Return(tx, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("UpdateTransactionSaga", mock.Anything, "ns1", tx.ID, mock.MatchedBy(func(saga *core.TransactionSaga) bool {
+		return saga.State == core.SagaStateCompensating && saga.Steps[0].CompensationOperation != nil
+	})).Return(nil)
+	mdi.On("InsertOperation", mock.Anything, mock.MatchedBy(func(compOp *core.Operation) bool {
+		return compOp.Type == core.OpTypeBlockchainInvoke && compOp.Transaction.Equals(tx.ID)
+	})).Return(nil)
+
+	om.RegisterHandler(om.ctx, &mockHandler{
+		Phase:    core.OpPhasePending,
+		Prepared: &core.PreparedOperation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainInvoke, Plugin: "blockchain"},
+	}, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	om.progressSaga(op.ID, core.OpStatusFailed, true)
+
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusPending, update.Status)
+	mdi.AssertExpectations(t)
+}
+
+func TestProgressSagaRunsNextStep(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	op, tx := newTestSagaOp(om)
+	next := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Plugin: "blockchain", Transaction: tx.ID, Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusInitialized}
+	om.cacheOperation(next)
+	tx.Saga.Steps = append(tx.Saga.Steps, &core.SagaStep{Operation: next.ID, Status: core.OpStatusInitialized})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx.ID).Return(tx, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("UpdateTransactionSaga", mock.Anything, "ns1", tx.ID, mock.MatchedBy(func(saga *core.TransactionSaga) bool {
+		return saga.State == core.SagaStateActive && saga.Steps[2].Status == core.OpStatusPending
+	})).Return(nil)
+
+	om.RegisterHandler(om.ctx, &mockHandler{
+		Phase:    core.OpPhasePending,
+		Prepared: &core.PreparedOperation{ID: next.ID, Namespace: "ns1", Type: core.OpTypeBlockchainInvoke, Plugin: "blockchain"},
+	}, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	om.progressSaga(op.ID, core.OpStatusSucceeded, true)
+
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, "ns1:"+next.ID.String(), update.NamespacedOpID)
+	assert.Equal(t, core.OpStatusPending, update.Status)
+	mdi.AssertExpectations(t)
+}
+
+func TestProgressSagaSkipsLaterSteps(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	op, tx := newTestSagaOp(om)
+	later := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Plugin: "blockchain", Transaction: tx.ID, Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusInitialized}
+	om.cacheOperation(later)
+	tx.Saga.Steps[0].Compensation = nil
+	tx.Saga.Steps = append(tx.Saga.Steps, &core.SagaStep{Operation: later.ID, Status: core.OpStatusInitialized})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx.ID).Return(tx, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("UpdateTransactionSaga", mock.Anything, "ns1", tx.ID, mock.MatchedBy(func(saga *core.TransactionSaga) bool {
+		return saga.State == core.SagaStateCompensated && saga.Steps[2].Status == core.OpStatusFailed
+	})).Return(nil)
+
+	om.progressSaga(op.ID, core.OpStatusFailed, true)
+
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, "ns1:"+later.ID.String(), update.NamespacedOpID)
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10673", update.ErrorMessage)
+	mdi.AssertExpectations(t)
+}
+
+func TestProgressSagaNextStepNotFound(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	op, tx := newTestSagaOp(om)
+	nextID := fftypes.NewUUID()
+	tx.Saga.Steps = append(tx.Saga.Steps, &core.SagaStep{Operation: nextID, Status: core.OpStatusInitialized})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx.ID).Return(tx, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("UpdateTransactionSaga", mock.Anything, "ns1", tx.ID, mock.Anything).Return(nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", nextID).Return(nil, fmt.Errorf("pop"))
+
+	om.progressSaga(op.ID, core.OpStatusSucceeded, true)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestSkipSagaStepNotFound(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	opID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", opID).Return(nil, nil)
+
+	om.skipSagaStep(om.ctx, opID, fftypes.NewUUID())
+
+	mdi.AssertExpectations(t)
+}
+
+func TestProgressSagaRetriedStep(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	op, tx := newTestSagaOp(om)
+	retry := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Transaction: tx.ID, Status: core.OpStatusSucceeded}
+	om.cacheOperation(retry)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx.ID).Return(tx, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{op}, nil, nil).Once()
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil).Once()
+	mdi.On("UpdateTransactionSaga", mock.Anything, "ns1", tx.ID, mock.MatchedBy(func(saga *core.TransactionSaga) bool {
+		return saga.State == core.SagaStateCompleted
+	})).Return(nil)
+
+	om.progressSaga(retry.ID, core.OpStatusSucceeded, true)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestProgressSagaNoSaga(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	op := newTestFailedOp(om)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", op.Transaction).Return(&core.Transaction{ID: op.Transaction}, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+
+	om.progressSaga(op.ID, core.OpStatusFailed, true)
+	om.progressSaga(fftypes.NewUUID(), core.OpStatusFailed, true)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestProgressSagaFirstAttemptFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	op, tx := newTestSagaOp(om)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx.ID).Return(tx, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	om.progressSaga(op.ID, core.OpStatusFailed, true)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestProgressSagaUpdateFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	op, tx := newTestSagaOp(om)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", tx.ID).Return(tx, nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("UpdateTransactionSaga", mock.Anything, "ns1", tx.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	om.progressSaga(op.ID, core.OpStatusFailed, true)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestSubmitCompensationInsertFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	compOp := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainInvoke}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("InsertOperation", mock.Anything, compOp).Return(fmt.Errorf("pop"))
+
+	om.submitCompensation(om.ctx, compOp)
+
+	assert.Nil(t, om.getCachedOperation(compOp.ID))
+	mdi.AssertExpectations(t)
+}
+
+func TestSubmitCompensationPrepareFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	om.updater.workQueues = []chan *core.OperationUpdateAsync{
+		make(chan *core.OperationUpdateAsync, 1),
+	}
+
+	compOp := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Type: core.OpTypeBlockchainInvoke, Plugin: "blockchain"}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("InsertOperation", mock.Anything, compOp).Return(nil)
+
+	om.submitCompensation(om.ctx, compOp)
+
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10371", update.ErrorMessage)
+	mdi.AssertExpectations(t)
+}
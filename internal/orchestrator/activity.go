@@ -0,0 +1,143 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"database/sql/driver"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// GetActivity returns the activity timeline of the namespace, built from the events of the requested categories (or all
+// categories if none are requested). The filter applies to the underlying events, so the timeline pages in event order.
+func (or *orchestrator) GetActivity(ctx context.Context, categories []string, filter ffapi.AndFilter) ([]*core.ActivityItem, *ffapi.FilterResult, error) {
+	include := make(map[core.ActivityCategory]bool, len(categories))
+	for _, c := range categories {
+		category, err := fftypes.FFEnumParseString(ctx, "activitycategory", c)
+		if err != nil {
+			return nil, nil, err
+		}
+		include[category] = true
+	}
+	eventTypes := make([]string, 0, len(core.ActivityEventTypes))
+	for eventType, category := range core.ActivityEventTypes {
+		if len(include) == 0 || include[category] {
+			eventTypes = append(eventTypes, eventType.String())
+		}
+	}
+	sort.Strings(eventTypes)
+	values := make([]driver.Value, len(eventTypes))
+	for i, eventType := range eventTypes {
+		values[i] = eventType
+	}
+	filter.Condition(filter.Builder().In("type", values))
+
+	events, fr, err := or.database().GetEvents(ctx, or.namespace.Name, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	enriched, err := or.events.EnrichEvents(ctx, events)
+	if err != nil {
+		return nil, nil, err
+	}
+	items := make([]*core.ActivityItem, len(enriched))
+	for i, e := range enriched {
+		items[i] = &core.ActivityItem{
+			ID:          e.ID,
+			Sequence:    e.Sequence,
+			Category:    core.ActivityEventTypes[e.Type],
+			Type:        e.Type,
+			Reference:   e.Reference,
+			Transaction: e.Transaction,
+			Topic:       e.Topic,
+			Created:     e.Created,
+			Summary:     summarizeActivity(ctx, e),
+		}
+	}
+	return items, fr, nil
+}
+
+func summarizeActivity(ctx context.Context, e *core.EnrichedEvent) *core.ActivitySummary {
+	summary := &core.ActivitySummary{}
+	switch {
+	case e.Message != nil:
+		summary.Author = e.Message.Header.Author
+		summary.Tag = e.Message.Header.Tag
+		summary.Topics = e.Message.Header.Topics
+		summary.State = e.Message.State.String()
+	case e.TokenTransfer != nil:
+		summarizeTransfer(summary, e.TokenTransfer)
+	case e.Identity != nil:
+		summary.Name = e.Identity.DID
+	case e.Datatype != nil:
+		summary.Name = e.Datatype.Name
+	case e.TokenPool != nil:
+		summary.Name = e.TokenPool.Name
+	case e.ContractInterface != nil:
+		summary.Name = e.ContractInterface.Name
+	case e.ContractAPI != nil:
+		summary.Name = e.ContractAPI.Name
+	case e.BlockchainReorg != nil:
+		summary.Name = e.BlockchainReorg.Source
+	case e.Operation != nil:
+		summary.State = string(e.Operation.Status)
+		summary.Error = e.Operation.Error
+		summarizeOperationInput(ctx, summary, e.Operation)
+	}
+	return summary
+}
+
+func summarizeTransfer(summary *core.ActivitySummary, transfer *core.TokenTransfer) {
+	summary.Author = transfer.Key
+	summary.Pool = transfer.Pool
+	summary.From = transfer.From
+	summary.To = transfer.To
+	amount := transfer.Amount
+	summary.Amount = &amount
+}
+
+// summarizeOperationInput adds the details of the request a failed or completed operation was submitted with.
+// The summary is best-effort, as the input of an operation might have been recorded by an older version.
+func summarizeOperationInput(ctx context.Context, summary *core.ActivitySummary, op *core.Operation) {
+	switch op.Type {
+	case core.OpTypeTokenTransfer:
+		transfer, err := txcommon.RetrieveTokenTransferInputs(ctx, op)
+		if err != nil {
+			log.L(ctx).Debugf("Unable to summarize input of operation %s: %s", op.ID, err)
+			return
+		}
+		summarizeTransfer(summary, transfer)
+	case core.OpTypeBlockchainInvoke:
+		req, err := txcommon.RetrieveBlockchainInvokeInputs(ctx, op)
+		if err != nil {
+			log.L(ctx).Debugf("Unable to summarize input of operation %s: %s", op.ID, err)
+			return
+		}
+		summary.Author = req.Key
+		summary.Location = req.Location
+		summary.Method = req.MethodPath
+		if req.Method != nil && req.Method.Name != "" {
+			summary.Method = req.Method.Name
+		}
+	}
+}
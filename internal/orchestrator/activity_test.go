@@ -0,0 +1,190 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetActivity(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	events := []*core.Event{
+		{ID: fftypes.NewUUID(), Sequence: 6, Type: core.EventTypeBlockchainInvokeOpFailed},
+		{ID: fftypes.NewUUID(), Sequence: 5, Type: core.EventTypeTransferOpFailed},
+		{ID: fftypes.NewUUID(), Sequence: 4, Type: core.EventTypeDatatypeConfirmed},
+		{ID: fftypes.NewUUID(), Sequence: 3, Type: core.EventTypeIdentityConfirmed},
+		{ID: fftypes.NewUUID(), Sequence: 2, Type: core.EventTypeTransferConfirmed},
+		{ID: fftypes.NewUUID(), Sequence: 1, Type: core.EventTypeMessageConfirmed, Topic: "topic1"},
+	}
+	pool := fftypes.NewUUID()
+	enriched := []*core.EnrichedEvent{
+		{Event: *events[0], Operation: &core.Operation{
+			Type:   core.OpTypeBlockchainInvoke,
+			Status: core.OpStatusFailed,
+			Error:  "pop",
+			Input: fftypes.JSONObject{
+				"key":      "0x12345",
+				"location": map[string]interface{}{"address": "0xabcd"},
+				"method":   map[string]interface{}{"name": "set"},
+			},
+		}},
+		{Event: *events[1], Operation: &core.Operation{
+			Type:   core.OpTypeTokenTransfer,
+			Status: core.OpStatusFailed,
+			Input:  fftypes.JSONObject{"pool": pool.String(), "key": "0x12345", "to": "0x2", "amount": "10"},
+		}},
+		{Event: *events[2], Datatype: &core.Datatype{Name: "widget"}},
+		{Event: *events[3], Identity: &core.Identity{IdentityBase: core.IdentityBase{DID: "did:firefly:org/org1"}}},
+		{Event: *events[4], TokenTransfer: &core.TokenTransfer{Pool: pool, Key: "0x12345", From: "0x1", To: "0x2", Amount: *fftypes.NewFFBigInt(5)}},
+		{Event: *events[5], Message: &core.Message{
+			Header: core.MessageHeader{
+				SignerRef: core.SignerRef{Author: "did:firefly:org/org1"},
+				Tag:       "tag1",
+				Topics:    fftypes.FFStringArray{"topic1"},
+			},
+			State: core.MessageStateConfirmed,
+		}},
+	}
+
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, err := filter.Finalize()
+		assert.NoError(t, err)
+		f := fi.String()
+		return strings.Contains(f, "type IN [") && strings.Contains(f, "message_confirmed") && strings.Contains(f, "blockchain_reorg")
+	})).Return(events, nil, nil)
+	or.mem.On("EnrichEvents", mock.Anything, events).Return(enriched, nil)
+
+	fb := database.EventQueryFactory.NewFilter(context.Background())
+	items, _, err := or.GetActivity(context.Background(), nil, fb.And())
+	assert.NoError(t, err)
+	assert.Len(t, items, 6)
+
+	assert.Equal(t, core.ActivityCategoryContractInvoke, items[0].Category)
+	assert.Equal(t, int64(6), items[0].Sequence)
+	assert.Equal(t, "set", items[0].Summary.Method)
+	assert.Equal(t, "0x12345", items[0].Summary.Author)
+	assert.Equal(t, `{"address":"0xabcd"}`, items[0].Summary.Location.String())
+	assert.Equal(t, "Failed", items[0].Summary.State)
+	assert.Equal(t, "pop", items[0].Summary.Error)
+
+	assert.Equal(t, core.ActivityCategoryTokenTransfer, items[1].Category)
+	assert.Equal(t, pool, items[1].Summary.Pool)
+	assert.Equal(t, "0x2", items[1].Summary.To)
+	assert.Equal(t, int64(10), items[1].Summary.Amount.Int64())
+
+	assert.Equal(t, core.ActivityCategoryNetwork, items[2].Category)
+	assert.Equal(t, "widget", items[2].Summary.Name)
+
+	assert.Equal(t, core.ActivityCategoryIdentity, items[3].Category)
+	assert.Equal(t, "did:firefly:org/org1", items[3].Summary.Name)
+
+	assert.Equal(t, core.ActivityCategoryTokenTransfer, items[4].Category)
+	assert.Equal(t, "0x1", items[4].Summary.From)
+	assert.Equal(t, int64(5), items[4].Summary.Amount.Int64())
+
+	assert.Equal(t, core.ActivityCategoryMessage, items[5].Category)
+	assert.Equal(t, "topic1", items[5].Topic)
+	assert.Equal(t, "tag1", items[5].Summary.Tag)
+	assert.Equal(t, "did:firefly:org/org1", items[5].Summary.Author)
+	assert.Equal(t, "confirmed", items[5].Summary.State)
+}
+
+func TestGetActivityCategories(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, err := filter.Finalize()
+		assert.NoError(t, err)
+		f := fi.String()
+		return strings.Contains(f, "type IN ['message_confirmed','message_rejected']")
+	})).Return([]*core.Event{}, nil, nil)
+	or.mem.On("EnrichEvents", mock.Anything, []*core.Event{}).Return([]*core.EnrichedEvent{}, nil)
+
+	fb := database.EventQueryFactory.NewFilter(context.Background())
+	items, _, err := or.GetActivity(context.Background(), []string{"Message"}, fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestGetActivityBadOperationInput(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	events := []*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeBlockchainInvokeOpSucceeded},
+		{ID: fftypes.NewUUID(), Type: core.EventTypeTransferOpFailed},
+	}
+	enriched := []*core.EnrichedEvent{
+		{Event: *events[0], Operation: &core.Operation{Type: core.OpTypeBlockchainInvoke, Status: core.OpStatusSucceeded, Input: fftypes.JSONObject{"input": "bad"}}},
+		{Event: *events[1], Operation: &core.Operation{Type: core.OpTypeTokenTransfer, Status: core.OpStatusFailed, Input: fftypes.JSONObject{"amount": "bad"}}},
+	}
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mem.On("EnrichEvents", mock.Anything, events).Return(enriched, nil)
+
+	fb := database.EventQueryFactory.NewFilter(context.Background())
+	items, _, err := or.GetActivity(context.Background(), nil, fb.And())
+	assert.NoError(t, err)
+	assert.Equal(t, "Succeeded", items[0].Summary.State)
+	assert.Empty(t, items[0].Summary.Method)
+	assert.Equal(t, "Failed", items[1].Summary.State)
+	assert.Nil(t, items[1].Summary.Amount)
+}
+
+func TestGetActivityBadCategory(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	fb := database.EventQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetActivity(context.Background(), []string{"wrong"}, fb.And())
+	assert.Regexp(t, "FF00172", err)
+}
+
+func TestGetActivityGetEventsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.EventQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetActivity(context.Background(), nil, fb.And())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetActivityEnrichFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{{ID: fftypes.NewUUID()}}, nil, nil)
+	or.mem.On("EnrichEvents", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	fb := database.EventQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetActivity(context.Background(), nil, fb.And())
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,76 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// AuditAPIRequest appends a record of an API call to the audit log of the namespace, if auditing is enabled
+func (or *orchestrator) AuditAPIRequest(ctx context.Context, record *core.AuditRecord) error {
+	if or.audit == nil {
+		return nil
+	}
+	return or.audit.Record(ctx, record)
+}
+
+func (or *orchestrator) GetAuditRecords(ctx context.Context, filter ffapi.AndFilter) ([]*core.AuditRecord, *ffapi.FilterResult, error) {
+	return or.database().GetAuditRecords(ctx, or.namespace.Name, filter)
+}
+
+func (or *orchestrator) VerifyAuditLog(ctx context.Context) (*core.AuditVerification, error) {
+	if or.audit == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuditNotEnabled)
+	}
+	return or.audit.Verify(ctx)
+}
+
+func (or *orchestrator) GetAuditAnchors(ctx context.Context, filter ffapi.AndFilter) ([]*core.AuditAnchor, *ffapi.FilterResult, error) {
+	return or.database().GetAuditAnchors(ctx, or.namespace.Name, filter)
+}
+
+func (or *orchestrator) VerifyAuditAnchor(ctx context.Context, id string) (*core.AuditAnchorVerification, error) {
+	if or.anchorer == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuditAnchorNotEnabled)
+	}
+	anchorID, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return or.anchorer.Verify(ctx, anchorID)
+}
+
+func (or *orchestrator) GetAuditAnchorProof(ctx context.Context, id, eventID string) (*core.AuditAnchorProof, error) {
+	if or.anchorer == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAuditAnchorNotEnabled)
+	}
+	anchorID, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	eventUUID, err := fftypes.ParseUUID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return or.anchorer.Proof(ctx, anchorID, eventUUID)
+}
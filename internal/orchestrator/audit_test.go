@@ -0,0 +1,182 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/auditmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuditAPIRequest(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mal := &auditmocks.Logger{}
+	or.audit = mal
+
+	record := &core.AuditRecord{Method: "POST", Route: "postData"}
+	mal.On("Record", mock.Anything, record).Return(nil)
+
+	err := or.AuditAPIRequest(context.Background(), record)
+	assert.NoError(t, err)
+	mal.AssertExpectations(t)
+}
+
+func TestAuditAPIRequestNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	err := or.AuditAPIRequest(context.Background(), &core.AuditRecord{})
+	assert.NoError(t, err)
+}
+
+func TestGetAuditRecords(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetAuditRecords", mock.Anything, "ns", mock.Anything).Return([]*core.AuditRecord{}, nil, nil)
+	fb := database.AuditQueryFactory.NewFilter(or.ctx)
+	_, _, err := or.GetAuditRecords(or.ctx, fb.And())
+	assert.NoError(t, err)
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mal := &auditmocks.Logger{}
+	or.audit = mal
+
+	mal.On("Verify", mock.Anything).Return(&core.AuditVerification{Valid: true}, nil)
+
+	result, err := or.VerifyAuditLog(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	mal.AssertExpectations(t)
+}
+
+func TestVerifyAuditLogNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.VerifyAuditLog(context.Background())
+	assert.Regexp(t, "FF10539", err)
+}
+
+func TestGetAuditAnchors(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetAuditAnchors", mock.Anything, "ns", mock.Anything).Return([]*core.AuditAnchor{}, nil, nil)
+	fb := database.AuditAnchorQueryFactory.NewFilter(or.ctx)
+	_, _, err := or.GetAuditAnchors(or.ctx, fb.And())
+	assert.NoError(t, err)
+}
+
+func TestVerifyAuditAnchor(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	maa := &auditmocks.Anchorer{}
+	or.anchorer = maa
+	anchorID := fftypes.NewUUID()
+
+	maa.On("Verify", mock.Anything, anchorID).Return(&core.AuditAnchorVerification{Valid: true}, nil)
+
+	result, err := or.VerifyAuditAnchor(context.Background(), anchorID.String())
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	maa.AssertExpectations(t)
+}
+
+func TestVerifyAuditAnchorBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.anchorer = &auditmocks.Anchorer{}
+
+	_, err := or.VerifyAuditAnchor(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestVerifyAuditAnchorNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.VerifyAuditAnchor(context.Background(), fftypes.NewUUID().String())
+	assert.Regexp(t, "FF10584", err)
+}
+
+func TestGetAuditAnchorProof(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	maa := &auditmocks.Anchorer{}
+	or.anchorer = maa
+	anchorID := fftypes.NewUUID()
+	eventID := fftypes.NewUUID()
+
+	maa.On("Proof", mock.Anything, anchorID, eventID).Return(&core.AuditAnchorProof{Valid: true}, nil)
+
+	result, err := or.GetAuditAnchorProof(context.Background(), anchorID.String(), eventID.String())
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	maa.AssertExpectations(t)
+}
+
+func TestGetAuditAnchorProofBadAnchorID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.anchorer = &auditmocks.Anchorer{}
+
+	_, err := or.GetAuditAnchorProof(context.Background(), "bad", fftypes.NewUUID().String())
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetAuditAnchorProofBadEventID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.anchorer = &auditmocks.Anchorer{}
+
+	_, err := or.GetAuditAnchorProof(context.Background(), fftypes.NewUUID().String(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetAuditAnchorProofNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.GetAuditAnchorProof(context.Background(), fftypes.NewUUID().String(), fftypes.NewUUID().String())
+	assert.Regexp(t, "FF10584", err)
+}
+
+func TestStartAnchorer(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.startAnchorer()
+
+	maa := &auditmocks.Anchorer{}
+	or.anchorer = maa
+	or.config.ReadOnly = true
+	or.startAnchorer()
+
+	maa.On("Start").Return().Once()
+	or.config.ReadOnly = false
+	or.startAnchorer()
+
+	maa.AssertExpectations(t)
+}
@@ -0,0 +1,225 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// VerifyBatchPin checks a confirmed batch against the local copies of its messages and data, the BatchPin event
+// that sequenced it on the blockchain, and for broadcast batches the payload published to shared storage
+func (or *orchestrator) VerifyBatchPin(ctx context.Context, id string) (*core.BatchPinVerification, error) {
+	batchID, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return or.verifyBatchPin(ctx, batchID)
+}
+
+// VerifyMessageBatchPin verifies the batch a message was confirmed in
+func (or *orchestrator) VerifyMessageBatchPin(ctx context.Context, id string) (*core.BatchPinVerification, error) {
+	msgID, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := or.database().GetMessageByID(ctx, or.namespace.Name, msgID)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	if msg.BatchID == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMessageNotInBatch, msgID)
+	}
+	verification, err := or.verifyBatchPin(ctx, msg.BatchID)
+	if err != nil {
+		return nil, err
+	}
+	verification.Message = msgID
+	return verification, nil
+}
+
+func (or *orchestrator) verifyBatchPin(ctx context.Context, batchID *fftypes.UUID) (*core.BatchPinVerification, error) {
+	batch, err := or.database().GetBatchByID(ctx, or.namespace.Name, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	if batch.TX.Type != core.TransactionTypeBatchPin && batch.TX.Type != core.TransactionTypeContractInvokePin {
+		return nil, i18n.NewError(ctx, coremsgs.MsgBatchNotPinned, batch.ID, batch.TX.Type)
+	}
+
+	verification := &core.BatchPinVerification{
+		Batch: batch.ID,
+		Type:  batch.Type,
+		Hash:  batch.Hash,
+	}
+	if err := or.verifyBatchManifest(ctx, batch, verification); err != nil {
+		return nil, err
+	}
+	pin, err := or.verifyBatchPinEvent(ctx, batch, verification)
+	if err != nil {
+		return nil, err
+	}
+	if pin != nil && batch.Type == core.BatchTypeBroadcast {
+		or.verifyBatchPayload(ctx, pin, verification)
+	}
+
+	verification.Verified = verification.ManifestMatch && verification.PinnedHashMatch && verification.SignerMatch &&
+		(batch.Type != core.BatchTypeBroadcast || (verification.PayloadHashMatch != nil && *verification.PayloadHashMatch))
+	return verification, nil
+}
+
+// verifyBatchManifest rebuilds the manifest of the batch from the hashes of the messages and data held locally,
+// so any change to a stored message or data value since the batch was confirmed results in a different hash
+func (or *orchestrator) verifyBatchManifest(ctx context.Context, batch *core.BatchPersisted, verification *core.BatchPinVerification) error {
+	var manifest core.BatchManifest
+	if err := batch.Manifest.Unmarshal(ctx, &manifest); err != nil {
+		verification.Errors = append(verification.Errors, err.Error())
+		return nil
+	}
+
+	rebuilt := manifest
+	rebuilt.Messages = make([]*core.MessageManifestEntry, len(manifest.Messages))
+	for i, entry := range manifest.Messages {
+		msg, err := or.database().GetMessageByID(ctx, or.namespace.Name, entry.ID)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			verification.Errors = append(verification.Errors, i18n.NewError(ctx, coremsgs.MsgBatchManifestEntryMissing, "message", entry.ID).Error())
+			return nil
+		}
+		hash := msg.Header.Hash()
+		if !hash.Equals(msg.Hash) || !msg.Header.DataHash.Equals(msg.Data.Hash()) {
+			verification.Errors = append(verification.Errors, i18n.NewError(ctx, coremsgs.MsgBatchManifestEntryHashInvalid, "message", entry.ID).Error())
+		}
+		rebuilt.Messages[i] = &core.MessageManifestEntry{
+			MessageRef: core.MessageRef{
+				ID:   msg.Header.ID,
+				Hash: hash,
+			},
+			Topics: len(msg.Header.Topics),
+		}
+	}
+
+	rebuilt.Data = make(core.DataRefs, len(manifest.Data))
+	for i, entry := range manifest.Data {
+		d, err := or.database().GetDataByID(ctx, or.namespace.Name, entry.ID, true)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			verification.Errors = append(verification.Errors, i18n.NewError(ctx, coremsgs.MsgBatchManifestEntryMissing, "data", entry.ID).Error())
+			return nil
+		}
+		hash, err := d.CalcHash(ctx)
+		if err != nil || !hash.Equals(d.Hash) {
+			verification.Errors = append(verification.Errors, i18n.NewError(ctx, coremsgs.MsgBatchManifestEntryHashInvalid, "data", entry.ID).Error())
+		}
+		rebuilt.Data[i] = &core.DataRef{
+			ID:   d.ID,
+			Hash: hash,
+		}
+	}
+
+	verification.ManifestHash = fftypes.HashString(rebuilt.String())
+	verification.ManifestMatch = verification.ManifestHash.Equals(batch.Hash)
+	return nil
+}
+
+// verifyBatchPinEvent finds the BatchPin event recorded for the transaction of the batch, and has the blockchain
+// plugin decode the batch hash and signing key that were written to the chain
+func (or *orchestrator) verifyBatchPinEvent(ctx context.Context, batch *core.BatchPersisted, verification *core.BatchPinVerification) (*blockchain.BatchPin, error) {
+	fb := database.BlockchainEventQueryFactory.NewFilter(ctx)
+	events, _, err := or.database().GetBlockchainEvents(ctx, or.namespace.Name, fb.Eq("tx.id", batch.TX.ID))
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.Listener != nil {
+			// Events from custom contract listeners in the same transaction
+			continue
+		}
+		pin, signer, err := or.blockchain().ParseBatchPinEvent(ctx, event)
+		if err != nil {
+			verification.Errors = append(verification.Errors, err.Error())
+			continue
+		}
+		if !pin.BatchID.Equals(batch.ID) {
+			continue
+		}
+		verification.BlockchainEvent = event.ID
+		verification.BlockchainTXID = event.TX.BlockchainID
+		if block, err := or.blockchain().GetProtocolIDBlock(ctx, event.ProtocolID); err == nil {
+			verification.BlockNumber = block
+		}
+		verification.PinnedHash = pin.BatchHash
+		verification.PinnedHashMatch = pin.BatchHash.Equals(batch.Hash)
+		verification.Signer = signer.Value
+		verification.SignerMatch = signer.Value == batch.Key
+		verification.PayloadRef = pin.BatchPayloadRef
+		return pin, nil
+	}
+	verification.Errors = append(verification.Errors, i18n.NewError(ctx, coremsgs.MsgBatchPinEventMissing, batch.TX.ID).Error())
+	return nil, nil
+}
+
+// verifyBatchPayload downloads a broadcast batch from shared storage using the reference recorded on the blockchain,
+// and checks the hash of its manifest matches the hash recorded alongside it
+func (or *orchestrator) verifyBatchPayload(ctx context.Context, pin *blockchain.BatchPin, verification *core.BatchPinVerification) {
+	match := false
+	verification.PayloadHashMatch = &match
+
+	reader, err := or.sharedstorage().DownloadData(ctx, pin.BatchPayloadRef)
+	if err != nil {
+		verification.Errors = append(verification.Errors, i18n.WrapError(ctx, err, coremsgs.MsgDownloadSharedFailed, pin.BatchPayloadRef).Error())
+		return
+	}
+	defer reader.Close()
+
+	maxReadLimit := config.GetByteSize(coreconfig.BroadcastBatchPayloadLimit) + 1024
+	batchBytes, err := io.ReadAll(io.LimitReader(reader, maxReadLimit))
+	if err == nil && int64(len(batchBytes)) == maxReadLimit {
+		err = i18n.NewError(ctx, coremsgs.MsgDownloadBatchMaxBytes, pin.BatchPayloadRef)
+	}
+	var payload *core.Batch
+	if err == nil {
+		err = json.Unmarshal(batchBytes, &payload)
+	}
+	if err != nil {
+		verification.Errors = append(verification.Errors, err.Error())
+		return
+	}
+
+	verification.PayloadHash = fftypes.HashString(payload.Payload.Manifest(payload.ID).String())
+	match = verification.PayloadHash.Equals(pin.BatchHash)
+}
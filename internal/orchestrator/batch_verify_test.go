@@ -0,0 +1,390 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestPinnedBatch(t *testing.T, batchType core.BatchType) (*core.Batch, *core.BatchPersisted, *core.Message, *core.Data) {
+	ctx := context.Background()
+	data := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns", Value: fftypes.JSONAnyPtr(`"some data"`)}
+	assert.NoError(t, data.Seal(ctx, nil))
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			Namespace: "ns",
+			Type:      core.MessageTypeBroadcast,
+			SignerRef: core.SignerRef{Author: "did:firefly:org/org1", Key: "0x12345"},
+		},
+		Data: core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+	}
+	assert.NoError(t, msg.Seal(ctx))
+	msg.BatchID = fftypes.NewUUID()
+	batch := &core.Batch{
+		BatchHeader: core.BatchHeader{
+			ID:        msg.BatchID,
+			Type:      batchType,
+			Namespace: "ns",
+			SignerRef: core.SignerRef{Author: "did:firefly:org/org1", Key: "0x12345"},
+		},
+		Payload: core.BatchPayload{
+			TX:       core.TransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+			Messages: []*core.Message{msg},
+			Data:     core.DataArray{data},
+		},
+	}
+	batch.Hash = fftypes.HashString(batch.Payload.Manifest(batch.ID).String())
+	persisted, _ := batch.Confirmed()
+	return batch, persisted, msg, data
+}
+
+func mockBatchPinEvent(or *testOrchestrator, batch *core.BatchPersisted, payloadRef string) *core.BlockchainEvent {
+	event := &core.BlockchainEvent{
+		ID:         fftypes.NewUUID(),
+		Name:       "BatchPin",
+		ProtocolID: "000000000042/000000/000000",
+		TX:         core.BlockchainTransactionRef{ID: batch.TX.ID, BlockchainID: "0xabcd"},
+	}
+	other := &core.BlockchainEvent{ID: fftypes.NewUUID(), Listener: fftypes.NewUUID()}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return([]*core.BlockchainEvent{other, event}, nil, nil)
+	or.mbi.On("ParseBatchPinEvent", mock.Anything, event).Return(&blockchain.BatchPin{
+		BatchID:         batch.ID,
+		BatchHash:       batch.Hash,
+		BatchPayloadRef: payloadRef,
+	}, &core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: batch.Key}, nil)
+	or.mbi.On("GetProtocolIDBlock", mock.Anything, event.ProtocolID).Return(int64(42), nil)
+	return event
+}
+
+func TestVerifyBatchPinBroadcastOk(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, data := newTestPinnedBatch(t, core.BatchTypeBroadcast)
+	batchJSON, _ := json.Marshal(batch)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+	event := mockBatchPinEvent(or, persisted, "ref1")
+	or.mps.On("DownloadData", mock.Anything, "ref1").Return(io.NopCloser(strings.NewReader(string(batchJSON))), nil)
+
+	v, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.NoError(t, err)
+	assert.Empty(t, v.Errors)
+	assert.True(t, v.ManifestMatch)
+	assert.True(t, v.PinnedHashMatch)
+	assert.True(t, v.SignerMatch)
+	assert.True(t, *v.PayloadHashMatch)
+	assert.True(t, v.Verified)
+	assert.Equal(t, event.ID, v.BlockchainEvent)
+	assert.Equal(t, "0xabcd", v.BlockchainTXID)
+	assert.Equal(t, int64(42), v.BlockNumber)
+	assert.Equal(t, batch.Hash, v.PayloadHash)
+}
+
+func TestVerifyMessageBatchPinPrivateOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, data := newTestPinnedBatch(t, core.BatchTypePrivate)
+
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+	mockBatchPinEvent(or, persisted, "")
+
+	v, err := or.VerifyMessageBatchPin(context.Background(), msg.Header.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, msg.Header.ID, v.Message)
+	assert.Nil(t, v.PayloadHashMatch)
+	assert.True(t, v.Verified)
+}
+
+func TestVerifyBatchPinTamperedData(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, data := newTestPinnedBatch(t, core.BatchTypePrivate)
+	data.Value = fftypes.JSONAnyPtr(`"changed data"`)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+	mockBatchPinEvent(or, persisted, "")
+
+	v, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, v.ManifestMatch)
+	assert.False(t, v.Verified)
+	assert.Regexp(t, "FF10597", v.Errors[0])
+}
+
+func TestVerifyBatchPinTamperedMessage(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, _ := newTestPinnedBatch(t, core.BatchTypePrivate)
+	msg.Header.Topics = fftypes.FFStringArray{"changed"}
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", mock.Anything, true).Return(nil, nil)
+	mockBatchPinEvent(or, persisted, "")
+
+	v, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, v.Verified)
+	assert.Regexp(t, "FF10597", v.Errors[0])
+	assert.Regexp(t, "FF10596", v.Errors[1])
+}
+
+func TestVerifyBatchPinMissingMessage(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, _ := newTestPinnedBatch(t, core.BatchTypePrivate)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(nil, nil)
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return([]*core.BlockchainEvent{}, nil, nil)
+
+	v, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, v.Verified)
+	assert.Nil(t, v.ManifestHash)
+	assert.Regexp(t, "FF10596", v.Errors[0])
+	assert.Regexp(t, "FF10598", v.Errors[1])
+}
+
+func TestVerifyBatchPinBadManifest(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, _, _ := newTestPinnedBatch(t, core.BatchTypePrivate)
+	persisted.Manifest = fftypes.JSONAnyPtr("!json")
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return([]*core.BlockchainEvent{}, nil, nil)
+
+	v, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, v.Verified)
+	assert.Len(t, v.Errors, 2)
+}
+
+func TestVerifyBatchPinManifestDBFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, _, _ := newTestPinnedBatch(t, core.BatchTypePrivate)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyBatchPinDataDBFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, _ := newTestPinnedBatch(t, core.BatchTypePrivate)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", mock.Anything, true).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyBatchPinEventsDBFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, data := newTestPinnedBatch(t, core.BatchTypePrivate)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyBatchPinEventMismatch(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, data := newTestPinnedBatch(t, core.BatchTypePrivate)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+	events := []*core.BlockchainEvent{{ID: fftypes.NewUUID()}, {ID: fftypes.NewUUID()}}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mbi.On("ParseBatchPinEvent", mock.Anything, events[0]).Return(nil, nil, fmt.Errorf("pop"))
+	or.mbi.On("ParseBatchPinEvent", mock.Anything, events[1]).Return(&blockchain.BatchPin{
+		BatchID: fftypes.NewUUID(),
+	}, &core.VerifierRef{}, nil)
+
+	v, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, v.ManifestMatch)
+	assert.False(t, v.Verified)
+	assert.Equal(t, "pop", v.Errors[0])
+	assert.Regexp(t, "FF10598", v.Errors[1])
+}
+
+func TestVerifyBatchPinDownloadFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, data := newTestPinnedBatch(t, core.BatchTypeBroadcast)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+	mockBatchPinEvent(or, persisted, "ref1")
+	or.mps.On("DownloadData", mock.Anything, "ref1").Return(nil, fmt.Errorf("pop"))
+
+	v, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, *v.PayloadHashMatch)
+	assert.False(t, v.Verified)
+	assert.Regexp(t, "FF10376", v.Errors[0])
+}
+
+func TestVerifyBatchPinDownloadBadPayload(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, msg, data := newTestPinnedBatch(t, core.BatchTypeBroadcast)
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+	mockBatchPinEvent(or, persisted, "ref1")
+	or.mps.On("DownloadData", mock.Anything, "ref1").Return(io.NopCloser(strings.NewReader("!json")), nil)
+
+	v, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, v.Verified)
+	assert.Len(t, v.Errors, 1)
+}
+
+func TestVerifyBatchPinNotPinned(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batch, persisted, _, _ := newTestPinnedBatch(t, core.BatchTypePrivate)
+	persisted.TX.Type = core.TransactionTypeUnpinned
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batch.ID).Return(persisted, nil)
+
+	_, err := or.VerifyBatchPin(context.Background(), batch.ID.String())
+	assert.Regexp(t, "FF10594", err)
+}
+
+func TestVerifyBatchPinNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batchID := fftypes.NewUUID()
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batchID).Return(nil, nil)
+
+	_, err := or.VerifyBatchPin(context.Background(), batchID.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestVerifyBatchPinDBFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	batchID := fftypes.NewUUID()
+
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batchID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyBatchPin(context.Background(), batchID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyBatchPinBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.VerifyBatchPin(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestVerifyMessageBatchPinBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.VerifyMessageBatchPin(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestVerifyMessageBatchPinDBFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msgID := fftypes.NewUUID()
+
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyMessageBatchPin(context.Background(), msgID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyMessageBatchPinNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msgID := fftypes.NewUUID()
+
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(nil, nil)
+
+	_, err := or.VerifyMessageBatchPin(context.Background(), msgID.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestVerifyMessageBatchPinNotInBatch(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msgID := fftypes.NewUUID()
+
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(&core.Message{}, nil)
+
+	_, err := or.VerifyMessageBatchPin(context.Background(), msgID.String())
+	assert.Regexp(t, "FF10595", err)
+}
+
+func TestVerifyMessageBatchPinBatchFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msgID := fftypes.NewUUID()
+	batchID := fftypes.NewUUID()
+
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(&core.Message{BatchID: batchID}, nil)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batchID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyMessageBatchPin(context.Background(), msgID.String())
+	assert.EqualError(t, err, "pop")
+}
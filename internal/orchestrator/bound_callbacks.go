@@ -18,6 +18,7 @@ package orchestrator
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -51,11 +52,25 @@ func (bc *boundCallbacks) OperationUpdate(update *core.OperationUpdateAsync) {
 	bc.o.operations.SubmitOperationUpdate(update)
 }
 
-func (bc *boundCallbacks) SharedStorageBatchDownloaded(payloadRef string, data []byte) (*fftypes.UUID, error) {
+func (bc *boundCallbacks) SharedStorageBatchDownloaded(payloadRef string, reader io.Reader) (*fftypes.UUID, error) {
 	if err := bc.checkStopped(); err != nil {
 		return nil, err
 	}
-	return bc.o.events.SharedStorageBatchDownloaded(bc.o.sharedstorage(), payloadRef, data)
+	return bc.o.events.SharedStorageBatchDownloaded(bc.o.sharedstorage(), payloadRef, reader)
+}
+
+func (bc *boundCallbacks) SharedStorageBatchVerify(payloadRef string, reader io.Reader) (*core.Batch, error) {
+	if err := bc.checkStopped(); err != nil {
+		return nil, err
+	}
+	return bc.o.events.SharedStorageBatchVerify(bc.o.sharedstorage(), payloadRef, reader)
+}
+
+func (bc *boundCallbacks) SharedStorageBatchDeliver(batch *core.Batch) (*fftypes.UUID, error) {
+	if err := bc.checkStopped(); err != nil {
+		return nil, err
+	}
+	return bc.o.events.SharedStorageBatchDeliver(batch)
 }
 
 func (bc *boundCallbacks) SharedStorageBlobDownloaded(hash fftypes.Bytes32, size int64, payloadRef string, dataID *fftypes.UUID) error {
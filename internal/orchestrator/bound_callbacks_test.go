@@ -19,6 +19,7 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hyperledger/firefly/mocks/networkmapmocks"
@@ -90,8 +91,18 @@ func TestBoundCallbacks(t *testing.T) {
 	mom.On("SubmitBulkOperationUpdates", ctx, updates).Return(nil).Once()
 	bc.BulkOperationUpdates(ctx, updates)
 
-	mei.On("SharedStorageBatchDownloaded", mss, "payload1", []byte(`{}`)).Return(nil, fmt.Errorf("pop"))
-	_, err := bc.SharedStorageBatchDownloaded("payload1", []byte(`{}`))
+	batchReader := strings.NewReader(`{}`)
+	mei.On("SharedStorageBatchDownloaded", mss, "payload1", batchReader).Return(nil, fmt.Errorf("pop"))
+	_, err := bc.SharedStorageBatchDownloaded("payload1", batchReader)
+	assert.EqualError(t, err, "pop")
+
+	mei.On("SharedStorageBatchVerify", mss, "payload1", batchReader).Return(nil, fmt.Errorf("pop"))
+	_, err = bc.SharedStorageBatchVerify("payload1", batchReader)
+	assert.EqualError(t, err, "pop")
+
+	batch := &core.Batch{}
+	mei.On("SharedStorageBatchDeliver", batch).Return(nil, fmt.Errorf("pop"))
+	_, err = bc.SharedStorageBatchDeliver(batch)
 	assert.EqualError(t, err, "pop")
 
 	mei.On("SharedStorageBlobDownloaded", mss, *hash, int64(12345), "payload1", dataID).Return(nil)
@@ -133,7 +144,13 @@ func TestBoundCallbacksStopped(t *testing.T) {
 	_, _, _, _, bc := newTestBoundCallbacks(t)
 	bc.o.started = false
 
-	_, err := bc.SharedStorageBatchDownloaded("payload1", []byte(`{}`))
+	_, err := bc.SharedStorageBatchDownloaded("payload1", strings.NewReader(`{}`))
+	assert.Regexp(t, "FF10446", err)
+
+	_, err = bc.SharedStorageBatchVerify("payload1", strings.NewReader(`{}`))
+	assert.Regexp(t, "FF10446", err)
+
+	_, err = bc.SharedStorageBatchDeliver(&core.Batch{})
 	assert.Regexp(t, "FF10446", err)
 
 	err = bc.SharedStorageBlobDownloaded(*fftypes.NewRandB32(), 12345, "payload1", nil)
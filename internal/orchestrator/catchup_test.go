@@ -0,0 +1,46 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCatchUpStatus(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.msd.On("GetCatchUpStatus", mock.Anything).Return(&core.CatchUpStatus{Active: true, Downloaded: 10}, nil)
+
+	status, err := or.GetCatchUpStatus(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, status.Active)
+	assert.Equal(t, int64(10), status.Downloaded)
+}
+
+func TestGetCatchUpStatusNoSharedDownload(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.sharedDownload = nil
+
+	_, err := or.GetCatchUpStatus(context.Background())
+	assert.Regexp(t, "FF10599", err)
+}
@@ -97,6 +97,14 @@ func (or *orchestrator) GetBatchByID(ctx context.Context, id string) (*core.Batc
 	return or.database().GetBatchByID(ctx, or.namespace.Name, u)
 }
 
+func (or *orchestrator) GetBatchAcks(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.BatchAck, *ffapi.FilterResult, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return or.database().GetBatchAcks(ctx, or.namespace.Name, filter.Condition(filter.Builder().Eq("batch", u)))
+}
+
 func (or *orchestrator) GetDataByID(ctx context.Context, id string) (*core.Data, error) {
 	u, err := fftypes.ParseUUID(ctx, id)
 	if err != nil {
@@ -168,6 +176,33 @@ func (or *orchestrator) GetOperationByIDWithStatus(ctx context.Context, id strin
 	return enrichedOperation, err
 }
 
+func (or *orchestrator) GetOperationHistory(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.OperationHistoryEntry, *ffapi.FilterResult, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	op, err := or.operations.GetOperationByIDCached(ctx, u)
+	if err != nil {
+		return nil, nil, err
+	}
+	if op == nil {
+		return nil, nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return or.database().GetOperationHistory(ctx, or.namespace.Name, filter.Condition(filter.Builder().Eq("operation", u)))
+}
+
+func (or *orchestrator) GetOperationApprovalByID(ctx context.Context, id string) (*core.OperationApproval, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return or.database().GetOperationApprovalByID(ctx, or.namespace.Name, u)
+}
+
+func (or *orchestrator) GetOperationApprovals(ctx context.Context, filter ffapi.AndFilter) ([]*core.OperationApproval, *ffapi.FilterResult, error) {
+	return or.database().GetOperationApprovals(ctx, or.namespace.Name, filter)
+}
+
 func (or *orchestrator) GetEventByID(ctx context.Context, id string) (*core.Event, error) {
 	u, err := fftypes.ParseUUID(ctx, id)
 	if err != nil {
@@ -275,10 +310,70 @@ func (or *orchestrator) GetMessagesForData(ctx context.Context, id string, filte
 	return or.database().GetMessagesForData(ctx, or.namespace.Name, u, filter)
 }
 
+func (or *orchestrator) GetMessagesForDataHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+	h, err := fftypes.ParseBytes32(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return or.database().GetMessagesForDataHash(ctx, or.namespace.Name, h, filter)
+}
+
+func (or *orchestrator) GetMessagesForBlobHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+	h, err := fftypes.ParseBytes32(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return or.database().GetMessagesForBlobHash(ctx, or.namespace.Name, h, filter)
+}
+
+func (or *orchestrator) getBatchesForIDs(ctx context.Context, batchIDs []*fftypes.UUID, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+	ids := make([]driver.Value, len(batchIDs))
+	for i, id := range batchIDs {
+		ids[i] = id
+	}
+	return or.database().GetBatches(ctx, or.namespace.Name, filter.Condition(filter.Builder().In("id", ids)))
+}
+
+func (or *orchestrator) GetBatchesForDataHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+	h, err := fftypes.ParseBytes32(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	batchIDs, err := or.database().GetBatchIDsForDataHash(ctx, or.namespace.Name, h)
+	if err != nil {
+		return nil, nil, err
+	}
+	return or.getBatchesForIDs(ctx, batchIDs, filter)
+}
+
+func (or *orchestrator) GetBatchesForBlobHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+	h, err := fftypes.ParseBytes32(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	batchIDs, err := or.database().GetBatchIDsForBlobHash(ctx, or.namespace.Name, h)
+	if err != nil {
+		return nil, nil, err
+	}
+	return or.getBatchesForIDs(ctx, batchIDs, filter)
+}
+
 func (or *orchestrator) GetDatatypes(ctx context.Context, filter ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error) {
 	return or.database().GetDatatypes(ctx, or.namespace.Name, filter)
 }
 
+func (or *orchestrator) GetDisclosureByID(ctx context.Context, id string) (*core.Disclosure, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return or.database().GetDisclosureByID(ctx, or.namespace.Name, u)
+}
+
+func (or *orchestrator) GetDisclosures(ctx context.Context, filter ffapi.AndFilter) ([]*core.Disclosure, *ffapi.FilterResult, error) {
+	return or.database().GetDisclosures(ctx, or.namespace.Name, filter)
+}
+
 func (or *orchestrator) GetOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
 	return or.database().GetOperations(ctx, or.namespace.Name, filter)
 }
@@ -248,6 +248,42 @@ func TestGetMessagesForDataBadID(t *testing.T) {
 	assert.Regexp(t, "FF00138", err)
 }
 
+func TestGetMessagesForDataHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	h := fftypes.NewRandB32()
+	or.mdi.On("GetMessagesForDataHash", mock.Anything, "ns", h, mock.Anything).Return([]*core.Message{}, nil, nil)
+	f := database.MessageQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetMessagesForDataHash(context.Background(), h.String(), f)
+	assert.NoError(t, err)
+}
+
+func TestGetMessagesForDataHashBadHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	f := database.MessageQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetMessagesForDataHash(context.Background(), "!bad", f)
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestGetMessagesForBlobHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	h := fftypes.NewRandB32()
+	or.mdi.On("GetMessagesForBlobHash", mock.Anything, "ns", h, mock.Anything).Return([]*core.Message{}, nil, nil)
+	f := database.MessageQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetMessagesForBlobHash(context.Background(), h.String(), f)
+	assert.NoError(t, err)
+}
+
+func TestGetMessagesForBlobHashBadHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	f := database.MessageQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetMessagesForBlobHash(context.Background(), "!bad", f)
+	assert.Regexp(t, "FF00107", err)
+}
+
 func TestGetMessageTransactionOk(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -398,6 +434,24 @@ func TestGetBatchByIDBadID(t *testing.T) {
 	assert.Regexp(t, "FF00138", err)
 }
 
+func TestGetBatchAcks(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	u := fftypes.NewUUID()
+	or.mdi.On("GetBatchAcks", mock.Anything, "ns", mock.Anything).Return([]*core.BatchAck{}, nil, nil)
+	fb := database.BatchAckQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetBatchAcks(context.Background(), u.String(), fb.And(fb.Eq("state", core.BatchAckStateAggregated)))
+	assert.NoError(t, err)
+}
+
+func TestGetBatchAcksBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	fb := database.BatchAckQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetBatchAcks(context.Background(), "", fb.And())
+	assert.Regexp(t, "FF00138", err)
+}
+
 func TestGetBatches(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -409,6 +463,68 @@ func TestGetBatches(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetBatchesForDataHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	h := fftypes.NewRandB32()
+	batchID := fftypes.NewUUID()
+	or.mdi.On("GetBatchIDsForDataHash", mock.Anything, "ns", h).Return([]*fftypes.UUID{batchID}, nil)
+	or.mdi.On("GetBatches", mock.Anything, "ns", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, err := f.Finalize()
+		return err == nil && fi.Children[0].Field == "id" && fi.Children[0].Op == ffapi.FilterOpIn
+	})).Return([]*core.BatchPersisted{}, nil, nil)
+	f := database.BatchQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetBatchesForDataHash(context.Background(), h.String(), f)
+	assert.NoError(t, err)
+}
+
+func TestGetBatchesForDataHashBadHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	f := database.BatchQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetBatchesForDataHash(context.Background(), "!bad", f)
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestGetBatchesForDataHashFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	h := fftypes.NewRandB32()
+	or.mdi.On("GetBatchIDsForDataHash", mock.Anything, "ns", h).Return(nil, fmt.Errorf("pop"))
+	f := database.BatchQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetBatchesForDataHash(context.Background(), h.String(), f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetBatchesForBlobHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	h := fftypes.NewRandB32()
+	or.mdi.On("GetBatchIDsForBlobHash", mock.Anything, "ns", h).Return([]*fftypes.UUID{fftypes.NewUUID()}, nil)
+	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+	f := database.BatchQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetBatchesForBlobHash(context.Background(), h.String(), f)
+	assert.NoError(t, err)
+}
+
+func TestGetBatchesForBlobHashBadHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	f := database.BatchQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetBatchesForBlobHash(context.Background(), "!bad", f)
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestGetBatchesForBlobHashFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	h := fftypes.NewRandB32()
+	or.mdi.On("GetBatchIDsForBlobHash", mock.Anything, "ns", h).Return(nil, fmt.Errorf("pop"))
+	f := database.BatchQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetBatchesForBlobHash(context.Background(), h.String(), f)
+	assert.EqualError(t, err, "pop")
+}
+
 func TestGetDataByID(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -595,6 +711,77 @@ func TestGetOperationByIDWithStatusError(t *testing.T) {
 	assert.Regexp(t, "pop", opStatus.Detail)
 }
 
+func TestGetOperationHistory(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	u := fftypes.NewUUID()
+
+	or.mom.On("GetOperationByIDCached", mock.Anything, u).Return(&core.Operation{ID: u}, nil)
+	or.mdi.On("GetOperationHistory", mock.Anything, "ns", mock.Anything).Return([]*core.OperationHistoryEntry{}, nil, nil)
+	fb := database.OperationHistoryQueryFactory.NewFilter(context.Background())
+	f := fb.And(fb.Eq("status", core.OpStatusFailed))
+	_, _, err := or.GetOperationHistory(context.Background(), u.String(), f)
+	assert.NoError(t, err)
+}
+
+func TestGetOperationApprovalByID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	u := fftypes.NewUUID()
+
+	or.mdi.On("GetOperationApprovalByID", mock.Anything, "ns", u).Return(&core.OperationApproval{ID: u}, nil)
+	approval, err := or.GetOperationApprovalByID(context.Background(), u.String())
+	assert.NoError(t, err)
+	assert.Equal(t, u, approval.ID)
+}
+
+func TestGetOperationApprovalByIDBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	_, err := or.GetOperationApprovalByID(context.Background(), "")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetOperationApprovals(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetOperationApprovals", mock.Anything, "ns", mock.Anything).Return([]*core.OperationApproval{}, nil, nil)
+	fb := database.OperationApprovalQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetOperationApprovals(context.Background(), fb.And(fb.Eq("status", core.OperationApprovalStatusPending)))
+	assert.NoError(t, err)
+}
+
+func TestGetOperationHistoryBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	fb := database.OperationHistoryQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetOperationHistory(context.Background(), "", fb.And())
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetOperationHistoryNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	u := fftypes.NewUUID()
+
+	or.mom.On("GetOperationByIDCached", mock.Anything, u).Return(nil, nil)
+	fb := database.OperationHistoryQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetOperationHistory(context.Background(), u.String(), fb.And())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetOperationHistoryLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	u := fftypes.NewUUID()
+
+	or.mom.On("GetOperationByIDCached", mock.Anything, u).Return(nil, fmt.Errorf("pop"))
+	fb := database.OperationHistoryQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetOperationHistory(context.Background(), u.String(), fb.And())
+	assert.EqualError(t, err, "pop")
+}
+
 func TestGetEventByID(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -624,6 +811,34 @@ func TestGetDatatypes(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetDisclosureByID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	u := fftypes.NewUUID()
+	or.mdi.On("GetDisclosureByID", mock.Anything, "ns", u).Return(&core.Disclosure{ID: u}, nil)
+	disclosure, err := or.GetDisclosureByID(context.Background(), u.String())
+	assert.NoError(t, err)
+	assert.Equal(t, u, disclosure.ID)
+}
+
+func TestGetDisclosureByIDBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	_, err := or.GetDisclosureByID(context.Background(), "")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetDisclosures(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	u := fftypes.NewUUID()
+	or.mdi.On("GetDisclosures", mock.Anything, "ns", mock.Anything).Return([]*core.Disclosure{}, nil, nil)
+	fb := database.DisclosureQueryFactory.NewFilter(context.Background())
+	f := fb.And(fb.Eq("message", u))
+	_, _, err := or.GetDisclosures(context.Background(), f)
+	assert.NoError(t, err)
+}
+
 func TestGetOperations(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -0,0 +1,181 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/definitions"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// definitionReplaySkipTags are the definitions whose handlers change state outside of the database, so
+// cannot be replayed against a scratch state that is discarded
+var definitionReplaySkipTags = map[string]bool{
+	core.SystemTagProposeContractMigration: true,
+	core.SystemTagAckContractMigration:     true,
+}
+
+// errDefinitionReplayRollback is returned from the database group of a replay, so everything written by the
+// definition handlers is rolled back
+var errDefinitionReplayRollback = errors.New("definition replay complete")
+
+// GetDefinitionRecording lists the definition broadcasts processed by the namespace, with their data and outcome,
+// in the format accepted by ReplayDefinitions
+func (or *orchestrator) GetDefinitionRecording(ctx context.Context, filter ffapi.AndFilter) ([]*core.DefinitionReplayRecord, *ffapi.FilterResult, error) {
+	filter = filter.Condition(filter.Builder().Eq("type", core.MessageTypeDefinition))
+	filter = filter.Condition(filter.Builder().In("state", []driver.Value{core.MessageStateConfirmed, core.MessageStateRejected}))
+	msgs, fr, err := or.database().GetMessages(ctx, or.namespace.Name, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	records := make([]*core.DefinitionReplayRecord, len(msgs))
+	for i, msg := range msgs {
+		data, _, err := or.data.GetMessageDataCached(ctx, msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		records[i] = &core.DefinitionReplayRecord{
+			Message: msg,
+			Data:    data,
+			Outcome: msg.State,
+		}
+	}
+	return records, fr, nil
+}
+
+// ReplayDefinitions feeds a recorded sequence of definition broadcasts through the definition handlers of this
+// node, and reports where the outcome differs from the recorded one. This lets members of a network check that
+// an upgraded node reaches the same decisions as the rest of the network, before they upgrade.
+//
+// The namespace must be a sandbox, and is expected to start empty, so that it acts as the scratch state the
+// definitions are replayed against. The whole replay runs in a single database group that is always rolled
+// back, with event ingestion paused, so it can be repeated. Pre-finalize and finalize actions of the handlers,
+// such as activating token pools or registering nodes with data exchange, are not run.
+func (or *orchestrator) ReplayDefinitions(ctx context.Context, input *core.DefinitionReplayInput) (*core.DefinitionReplayReport, error) {
+	if !or.config.Sandbox {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDefinitionReplayNotSandbox, or.namespace.Name)
+	}
+	for i, record := range input.Records {
+		if record.Message == nil || record.Message.Header.Type != core.MessageTypeDefinition ||
+			(record.Outcome != core.MessageStateConfirmed && record.Outcome != core.MessageStateRejected) {
+			return nil, i18n.NewError(ctx, coremsgs.MsgDefinitionReplayInvalidRecord, i)
+		}
+	}
+
+	log.L(ctx).Infof("Definition replay of %d records pausing event ingestion", len(input.Records))
+	resume := or.events.PauseIngestion()
+	defer resume()
+	// Lookups by the handlers can load records that are then rolled back into the caches
+	defer or.cacheManager.ResetCachesForNamespace(or.namespace.Name)
+
+	report := &core.DefinitionReplayReport{
+		Total:   len(input.Records),
+		Results: make([]*core.DefinitionReplayResult, 0, len(input.Records)),
+	}
+	err := or.database().RunAsGroup(ctx, func(ctx context.Context) error {
+		for _, record := range input.Records {
+			result, retry := or.replayDefinition(ctx, record)
+			report.Results = append(report.Results, result)
+			switch {
+			case retry:
+				// A transient error would have halted the batch when it was recorded, and the database
+				// transaction may no longer be usable, so the replay stops here
+				log.L(ctx).Warnf("Definition replay stopped at '%s' [%s]: %s", result.Tag, result.Message, result.Error)
+				return errDefinitionReplayRollback
+			case result.Skipped:
+				report.Skipped++
+			case result.Diverged:
+				report.Replayed++
+				report.Diverged++
+			default:
+				report.Replayed++
+				report.Matched++
+			}
+		}
+		return errDefinitionReplayRollback
+	})
+	if err != errDefinitionReplayRollback {
+		return nil, err
+	}
+	log.L(ctx).Infof("Definition replay complete: replayed=%d matched=%d diverged=%d skipped=%d", report.Replayed, report.Matched, report.Diverged, report.Skipped)
+	return report, nil
+}
+
+func (or *orchestrator) replayDefinition(ctx context.Context, record *core.DefinitionReplayRecord) (result *core.DefinitionReplayResult, retry bool) {
+	msg := record.Message
+	result = &core.DefinitionReplayResult{
+		Message:  msg.Header.ID,
+		Tag:      msg.Header.Tag,
+		Recorded: record.Outcome,
+	}
+	if definitionReplaySkipTags[msg.Header.Tag] {
+		result.Skipped = true
+		return result, false
+	}
+
+	// Store the message and its data as they would be when the batch was received, as handlers
+	// look up earlier definition messages
+	msg.LocalNamespace = or.namespace.Name
+	msg.State = core.MessageStatePending
+	msg.Confirmed = nil
+	msg.RejectReason = ""
+	err := or.database().UpsertMessage(ctx, msg, database.UpsertOptimizationSkip)
+	for _, d := range record.Data {
+		if err == nil {
+			d.Namespace = or.namespace.Name
+			err = or.database().UpsertData(ctx, d, database.UpsertOptimizationSkip)
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result, true
+	}
+
+	var state core.BatchState
+	var handlerResult definitions.HandlerResult
+	handlerResult, err = or.defhandler.HandleDefinitionBroadcast(ctx, &state, msg, record.Data, msg.TransactionID)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	switch handlerResult.Action {
+	case core.ActionRetry:
+		return result, true
+	case core.ActionConfirm:
+		result.Replayed = core.MessageStateConfirmed
+	case core.ActionReject:
+		result.Replayed = core.MessageStateRejected
+	default:
+		result.Replayed = core.MessageStatePending
+	}
+	result.Diverged = result.Replayed != result.Recorded
+
+	// Record the outcome, for handlers of later definitions that check the state of earlier ones
+	update := database.MessageQueryFactory.NewUpdate(ctx).Set("state", result.Replayed)
+	if err := or.database().UpdateMessage(ctx, or.namespace.Name, msg.Header.ID, update); err != nil {
+		result.Error = err.Error()
+		return result, true
+	}
+	return result, false
+}
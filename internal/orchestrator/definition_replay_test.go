@@ -0,0 +1,228 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/definitions"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestDefinitionReplayRecord(tag string, outcome core.MessageState) *core.DefinitionReplayRecord {
+	return &core.DefinitionReplayRecord{
+		Message: &core.Message{
+			Header: core.MessageHeader{
+				ID:   fftypes.NewUUID(),
+				Type: core.MessageTypeDefinition,
+				Tag:  tag,
+			},
+			State: outcome,
+		},
+		Data:    core.DataArray{{ID: fftypes.NewUUID()}},
+		Outcome: outcome,
+	}
+}
+
+func mockDefinitionReplayGroup(or *testOrchestrator) {
+	or.mem.On("PauseIngestion").Return(func() {})
+	or.cmi.On("ResetCachesForNamespace", "ns").Return()
+	rag := or.mdi.On("RunAsGroup", mock.Anything, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{
+			a[1].(func(context.Context) error)(a[0].(context.Context)),
+		}
+	}
+}
+
+func TestGetDefinitionRecording(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	record := newTestDefinitionReplayRecord(core.SystemTagDefineDatatype, core.MessageStateConfirmed)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{record.Message}, nil, nil)
+	or.mdm.On("GetMessageDataCached", mock.Anything, record.Message).Return(record.Data, true, nil)
+
+	f := database.MessageQueryFactory.NewFilter(context.Background()).And()
+	records, _, err := or.GetDefinitionRecording(context.Background(), f)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, core.MessageStateConfirmed, records[0].Outcome)
+	assert.Equal(t, record.Data, records[0].Data)
+}
+
+func TestGetDefinitionRecordingFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	f := database.MessageQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetDefinitionRecording(context.Background(), f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetDefinitionRecordingDataFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	record := newTestDefinitionReplayRecord(core.SystemTagDefineDatatype, core.MessageStateConfirmed)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{record.Message}, nil, nil)
+	or.mdm.On("GetMessageDataCached", mock.Anything, record.Message).Return(nil, false, fmt.Errorf("pop"))
+
+	f := database.MessageQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetDefinitionRecording(context.Background(), f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayDefinitionsNotSandbox(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.ReplayDefinitions(context.Background(), &core.DefinitionReplayInput{})
+	assert.Regexp(t, "FF10665", err)
+}
+
+func TestReplayDefinitionsInvalidRecord(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Sandbox = true
+
+	_, err := or.ReplayDefinitions(context.Background(), &core.DefinitionReplayInput{
+		Records: []*core.DefinitionReplayRecord{
+			newTestDefinitionReplayRecord(core.SystemTagDefineDatatype, core.MessageStatePending),
+		},
+	})
+	assert.Regexp(t, "FF10666", err)
+}
+
+func TestReplayDefinitions(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Sandbox = true
+
+	matched := newTestDefinitionReplayRecord(core.SystemTagDefineDatatype, core.MessageStateConfirmed)
+	diverged := newTestDefinitionReplayRecord(core.SystemTagIdentityClaim, core.MessageStateConfirmed)
+	skipped := newTestDefinitionReplayRecord(core.SystemTagAckContractMigration, core.MessageStateConfirmed)
+
+	mockDefinitionReplayGroup(or)
+	or.mdi.On("UpsertMessage", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	or.mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	or.mdi.On("UpdateMessage", mock.Anything, "ns", mock.Anything, mock.Anything).Return(nil)
+	or.mdh.On("HandleDefinitionBroadcast", mock.Anything, mock.Anything, matched.Message, matched.Data, mock.Anything).
+		Return(definitions.HandlerResult{Action: core.ActionConfirm}, nil)
+	or.mdh.On("HandleDefinitionBroadcast", mock.Anything, mock.Anything, diverged.Message, diverged.Data, mock.Anything).
+		Return(definitions.HandlerResult{Action: core.ActionReject}, fmt.Errorf("rejected"))
+
+	report, err := or.ReplayDefinitions(context.Background(), &core.DefinitionReplayInput{
+		Records: []*core.DefinitionReplayRecord{matched, diverged, skipped},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.Replayed)
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, 1, report.Diverged)
+	assert.Equal(t, 1, report.Skipped)
+	assert.False(t, report.Results[0].Diverged)
+	assert.True(t, report.Results[1].Diverged)
+	assert.Equal(t, core.MessageStateRejected, report.Results[1].Replayed)
+	assert.Equal(t, "rejected", report.Results[1].Error)
+	assert.True(t, report.Results[2].Skipped)
+	assert.Equal(t, "ns", matched.Message.LocalNamespace)
+}
+
+func TestReplayDefinitionsRetryStops(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Sandbox = true
+
+	retry := newTestDefinitionReplayRecord(core.SystemTagDefineDatatype, core.MessageStateConfirmed)
+	unreached := newTestDefinitionReplayRecord(core.SystemTagDefineDatatype, core.MessageStateConfirmed)
+
+	mockDefinitionReplayGroup(or)
+	or.mdi.On("UpsertMessage", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	or.mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	or.mdh.On("HandleDefinitionBroadcast", mock.Anything, mock.Anything, retry.Message, retry.Data, mock.Anything).
+		Return(definitions.HandlerResult{Action: core.ActionRetry}, fmt.Errorf("pop"))
+
+	report, err := or.ReplayDefinitions(context.Background(), &core.DefinitionReplayInput{
+		Records: []*core.DefinitionReplayRecord{retry, unreached},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, 0, report.Replayed)
+	assert.Len(t, report.Results, 1)
+	assert.Equal(t, "pop", report.Results[0].Error)
+}
+
+func TestReplayDefinitionsStoreFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Sandbox = true
+
+	record := newTestDefinitionReplayRecord(core.SystemTagDefineDatatype, core.MessageStateConfirmed)
+
+	mockDefinitionReplayGroup(or)
+	or.mdi.On("UpsertMessage", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(fmt.Errorf("pop"))
+
+	report, err := or.ReplayDefinitions(context.Background(), &core.DefinitionReplayInput{
+		Records: []*core.DefinitionReplayRecord{record},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "pop", report.Results[0].Error)
+}
+
+func TestReplayDefinitionsUpdateFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Sandbox = true
+
+	record := newTestDefinitionReplayRecord(core.SystemTagDefineDatatype, core.MessageStateConfirmed)
+
+	mockDefinitionReplayGroup(or)
+	or.mdi.On("UpsertMessage", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	or.mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(nil)
+	or.mdi.On("UpdateMessage", mock.Anything, "ns", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	or.mdh.On("HandleDefinitionBroadcast", mock.Anything, mock.Anything, record.Message, record.Data, mock.Anything).
+		Return(definitions.HandlerResult{Action: core.ActionConfirm}, nil)
+
+	report, err := or.ReplayDefinitions(context.Background(), &core.DefinitionReplayInput{
+		Records: []*core.DefinitionReplayRecord{record},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.Replayed)
+	assert.Equal(t, "pop", report.Results[0].Error)
+}
+
+func TestReplayDefinitionsGroupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Sandbox = true
+
+	or.mem.On("PauseIngestion").Return(func() {})
+	or.cmi.On("ResetCachesForNamespace", "ns").Return()
+	or.mdi.On("RunAsGroup", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := or.ReplayDefinitions(context.Background(), &core.DefinitionReplayInput{})
+	assert.EqualError(t, err, "pop")
+}
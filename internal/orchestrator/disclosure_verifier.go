@@ -0,0 +1,107 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func (or *orchestrator) startDisclosureVerifier() {
+	if !or.config.Multiparty.Enabled {
+		return
+	}
+	or.disclosureVerifierDone = make(chan struct{})
+	go or.disclosureVerifierLoop()
+}
+
+func (or *orchestrator) disclosureVerifierLoop() {
+	defer close(or.disclosureVerifierDone)
+	interval := config.GetDuration(coreconfig.DisclosuresVerifierInterval)
+	for {
+		select {
+		case <-time.After(interval):
+		case <-or.ctx.Done():
+			log.L(or.ctx).Debugf("Disclosure verifier exiting")
+			return
+		}
+		if err := or.verifyPendingDisclosures(or.ctx); err != nil {
+			log.L(or.ctx).Errorf("Disclosure verifier scan failed: %s", err)
+		}
+	}
+}
+
+// verifyPendingDisclosures checks disclosures that have been confirmed, but not yet verified by this node.
+// This happens outside of the event aggregator, as the result depends on the ZK proof plugin and the messages
+// held by this node, and is only recorded as the local status of the disclosure. A disclosure the ZK proof
+// plugin could not be reached for stays pending, and is tried again on the next scan.
+func (or *orchestrator) verifyPendingDisclosures(ctx context.Context) error {
+	fb := database.DisclosureQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("status", core.DisclosureStatusPending),
+	).Sort("created").Limit(uint64(config.GetInt(coreconfig.DisclosuresVerifierBatchSize)))
+	disclosures, _, err := or.database().GetDisclosures(ctx, or.namespace.Name, filter)
+	if err != nil {
+		return err
+	}
+
+	for _, disclosure := range disclosures {
+		status, err := or.verifyDisclosure(ctx, disclosure)
+		if err != nil {
+			log.L(ctx).Warnf("Failed to verify disclosure '%s': %s", disclosure.ID, err)
+			continue
+		}
+		update := database.DisclosureQueryFactory.NewUpdate(ctx).Set("status", status)
+		if err := or.database().UpdateDisclosure(ctx, or.namespace.Name, disclosure.ID, update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (or *orchestrator) verifyDisclosure(ctx context.Context, disclosure *core.Disclosure) (core.DisclosureStatus, error) {
+	// Members that received the message check the proof is about the same message they hold
+	subject, err := or.database().GetMessageByID(ctx, or.namespace.Name, disclosure.Message)
+	if err != nil {
+		return "", err
+	} else if subject != nil && !subject.Hash.Equals(disclosure.MessageHash) {
+		log.L(ctx).Warnf("Disclosure '%s' is invalid: %s", disclosure.ID, i18n.NewError(ctx, coremsgs.MsgDefRejectedHashMismatch, "disclosure", disclosure.ID, disclosure.MessageHash, subject.Hash))
+		return core.DisclosureStatusInvalid, nil
+	}
+
+	zk := or.plugins.ZKProof.Plugin
+	if zk == nil {
+		log.L(ctx).Warnf("Recording disclosure '%s' without verification, as no ZK proof plugin is configured", disclosure.ID)
+		return core.DisclosureStatusUnverified, nil
+	}
+	valid, err := zk.VerifyProof(ctx, disclosure.Circuit, disclosure.PublicInputs, disclosure.Proof)
+	if err != nil {
+		return "", err
+	} else if !valid {
+		log.L(ctx).Warnf("Disclosure '%s' is invalid: %s", disclosure.ID, i18n.NewError(ctx, coremsgs.MsgDisclosureProofInvalid, disclosure.ID, disclosure.Circuit))
+		return core.DisclosureStatusInvalid, nil
+	}
+	return core.DisclosureStatusVerified, nil
+}
@@ -0,0 +1,171 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/zkproofmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestPendingDisclosure() *core.Disclosure {
+	return &core.Disclosure{
+		ID:           fftypes.NewUUID(),
+		Namespace:    "ns",
+		Message:      fftypes.NewUUID(),
+		MessageHash:  fftypes.NewRandB32(),
+		Circuit:      "range",
+		PublicInputs: fftypes.JSONAnyPtr(`{"limit":100}`),
+		Proof:        fftypes.JSONAnyPtr(`{"pi_a":["1"]}`),
+		Status:       core.DisclosureStatusPending,
+	}
+}
+
+func disclosureStatusUpdate(status core.DisclosureStatus) interface{} {
+	return mock.MatchedBy(func(u ffapi.Update) bool {
+		update, err := u.Finalize()
+		if err != nil || len(update.SetOperations) != 1 || update.SetOperations[0].Field != "status" {
+			return false
+		}
+		v, _ := update.SetOperations[0].Value.Value()
+		return v == string(status)
+	})
+}
+
+func TestVerifyPendingDisclosures(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mzk := &zkproofmocks.Plugin{}
+	or.plugins.ZKProof.Plugin = mzk
+
+	verified := newTestPendingDisclosure()
+	badProof := newTestPendingDisclosure()
+	badHash := newTestPendingDisclosure()
+	unreachable := newTestPendingDisclosure()
+	unreachable.Circuit = "membership"
+	or.mdi.On("GetDisclosures", mock.Anything, "ns", mock.Anything).Return([]*core.Disclosure{verified, badProof, badHash, unreachable}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", verified.Message).Return(&core.Message{Hash: verified.MessageHash}, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", badProof.Message).Return(nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", badHash.Message).Return(&core.Message{Hash: fftypes.NewRandB32()}, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", unreachable.Message).Return(nil, nil)
+	mzk.On("VerifyProof", mock.Anything, "range", verified.PublicInputs, verified.Proof).Return(true, nil).Once()
+	mzk.On("VerifyProof", mock.Anything, "range", badProof.PublicInputs, badProof.Proof).Return(false, nil).Once()
+	mzk.On("VerifyProof", mock.Anything, "membership", mock.Anything, mock.Anything).Return(false, fmt.Errorf("pop"))
+	or.mdi.On("UpdateDisclosure", mock.Anything, "ns", verified.ID, disclosureStatusUpdate(core.DisclosureStatusVerified)).Return(nil)
+	or.mdi.On("UpdateDisclosure", mock.Anything, "ns", badProof.ID, disclosureStatusUpdate(core.DisclosureStatusInvalid)).Return(nil)
+	or.mdi.On("UpdateDisclosure", mock.Anything, "ns", badHash.ID, disclosureStatusUpdate(core.DisclosureStatusInvalid)).Return(nil)
+
+	// The disclosure the plugin failed on stays pending, for the next scan
+	err := or.verifyPendingDisclosures(or.ctx)
+	assert.NoError(t, err)
+
+	mzk.AssertExpectations(t)
+	or.mdi.AssertNotCalled(t, "UpdateDisclosure", mock.Anything, "ns", unreachable.ID, mock.Anything)
+}
+
+func TestVerifyPendingDisclosuresNoPlugin(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	disclosure := newTestPendingDisclosure()
+	or.mdi.On("GetDisclosures", mock.Anything, "ns", mock.Anything).Return([]*core.Disclosure{disclosure}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", disclosure.Message).Return(nil, nil)
+	or.mdi.On("UpdateDisclosure", mock.Anything, "ns", disclosure.ID, disclosureStatusUpdate(core.DisclosureStatusUnverified)).Return(nil)
+
+	err := or.verifyPendingDisclosures(or.ctx)
+	assert.NoError(t, err)
+}
+
+func TestVerifyPendingDisclosuresQueryFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetDisclosures", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := or.verifyPendingDisclosures(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyPendingDisclosuresMessageFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	disclosure := newTestPendingDisclosure()
+	or.mdi.On("GetDisclosures", mock.Anything, "ns", mock.Anything).Return([]*core.Disclosure{disclosure}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", disclosure.Message).Return(nil, fmt.Errorf("pop"))
+
+	err := or.verifyPendingDisclosures(or.ctx)
+	assert.NoError(t, err)
+}
+
+func TestVerifyPendingDisclosuresUpdateFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	disclosure := newTestPendingDisclosure()
+	or.mdi.On("GetDisclosures", mock.Anything, "ns", mock.Anything).Return([]*core.Disclosure{disclosure}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", disclosure.Message).Return(nil, nil)
+	or.mdi.On("UpdateDisclosure", mock.Anything, "ns", disclosure.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := or.verifyPendingDisclosures(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestDisclosureVerifierLoop(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.DisclosuresVerifierInterval, "1ms")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	scanned := make(chan struct{})
+	or.mdi.On("GetDisclosures", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		select {
+		case <-scanned:
+		default:
+			close(scanned)
+		}
+	})
+
+	or.startDisclosureVerifier()
+	assert.NotNil(t, or.disclosureVerifierDone)
+	<-scanned
+	or.cancelCtx()
+	<-or.disclosureVerifierDone
+}
+
+func TestDisclosureVerifierNotMultiparty(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Multiparty.Enabled = false
+
+	or.startDisclosureVerifier()
+	assert.Nil(t, or.disclosureVerifierDone)
+}
@@ -0,0 +1,54 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/faults"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// initFaults wraps the database, blockchain and data exchange plugins of the namespace, if fault injection
+// is enabled. The plugins are wrapped in a copy of the plugin set, as the set is owned by the namespace manager.
+func (or *orchestrator) initFaults(ctx context.Context) {
+	or.faults = faults.NewInjector(ctx, or.namespace.Name)
+	if or.faults == nil {
+		return
+	}
+	plugins := *or.plugins
+	plugins.Database.Plugin = faults.WrapDatabase(or.faults, plugins.Database.Plugin)
+	plugins.Blockchain.Plugin = faults.WrapBlockchain(or.faults, plugins.Blockchain.Plugin)
+	plugins.DataExchange.Plugin = faults.WrapDataExchange(or.faults, plugins.DataExchange.Plugin)
+	or.plugins = &plugins
+}
+
+func (or *orchestrator) GetFaults(ctx context.Context) (*core.NamespaceFaults, error) {
+	if or.faults == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFaultInjectionNotEnabled)
+	}
+	return or.faults.GetRules(), nil
+}
+
+func (or *orchestrator) SetFaults(ctx context.Context, rules *core.NamespaceFaults) (*core.NamespaceFaults, error) {
+	if or.faults == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFaultInjectionNotEnabled)
+	}
+	return or.faults.SetRules(ctx, rules)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultsNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	coreconfig.Reset()
+
+	or.initFaults(or.ctx)
+	assert.Nil(t, or.faults)
+	assert.Equal(t, or.mdi, or.database())
+
+	_, err := or.GetFaults(or.ctx)
+	assert.Regexp(t, "FF10604", err)
+	_, err = or.SetFaults(or.ctx, &core.NamespaceFaults{})
+	assert.Regexp(t, "FF10604", err)
+}
+
+func TestFaultsEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	coreconfig.Reset()
+	config.Set(coreconfig.FaultsEnabled, true)
+	defer coreconfig.Reset()
+	plugins := or.plugins
+
+	or.initFaults(or.ctx)
+	assert.NotNil(t, or.faults)
+	assert.NotEqual(t, or.mdi, or.database())
+	assert.NotEqual(t, or.mbi, or.blockchain())
+	assert.NotEqual(t, or.mdx, or.dataexchange())
+	assert.Equal(t, or.mdi, plugins.Database.Plugin)
+
+	rules, err := or.SetFaults(or.ctx, &core.NamespaceFaults{Rules: []*core.FaultRule{
+		{Plugin: core.FaultPluginBlockchain, Method: "SubmitBatchPin", Action: core.FaultActionFail, Probability: 0.5},
+	}})
+	assert.NoError(t, err)
+	assert.Len(t, rules.Rules, 1)
+
+	rules, err = or.GetFaults(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "SubmitBatchPin", rules.Rules[0].Method)
+}
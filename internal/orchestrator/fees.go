@@ -0,0 +1,35 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (or *orchestrator) GetOperationFees(ctx context.Context, filter ffapi.AndFilter) ([]*core.OperationFee, *ffapi.FilterResult, error) {
+	return or.database().GetOperationFees(ctx, or.namespace.Name, filter)
+}
+
+// GetFeeSummary totals the fees matching the filter by signing key and UTC day. Every matching record is
+// included, regardless of any limit on the filter, and the result is sorted by day and then key so the
+// same set of records always produces the same report.
+func (or *orchestrator) GetFeeSummary(ctx context.Context, filter ffapi.AndFilter) ([]*core.FeeSummary, error) {
+	return or.database().GetOperationFeeSummary(ctx, or.namespace.Name, filter)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetOperationFees(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetOperationFees", mock.Anything, "ns", mock.Anything).Return([]*core.OperationFee{}, nil, nil)
+	fb := database.OperationFeeQueryFactory.NewFilter(or.ctx)
+	_, _, err := or.GetOperationFees(or.ctx, fb.And())
+	assert.NoError(t, err)
+}
+
+func TestGetFeeSummary(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	summaries := []*core.FeeSummary{{Day: "2024-03-01", Key: "0xaaa", Operations: 1}}
+	or.mdi.On("GetOperationFeeSummary", mock.Anything, "ns", mock.Anything).Return(summaries, nil)
+	fb := database.OperationFeeQueryFactory.NewFilter(or.ctx)
+	res, err := or.GetFeeSummary(or.ctx, fb.And())
+	assert.NoError(t, err)
+	assert.Equal(t, summaries, res)
+}
+
+func TestGetFeeSummaryFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetOperationFeeSummary", mock.Anything, "ns", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	fb := database.OperationFeeQueryFactory.NewFilter(or.ctx)
+	_, err := or.GetFeeSummary(or.ctx, fb.And())
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,28 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly/internal/graphql"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (or *orchestrator) QueryGraphQL(ctx context.Context, req *core.GraphQLRequest) *core.GraphQLResponse {
+	return graphql.Execute(ctx, or.namespace.Name, or.database(), req)
+}
@@ -0,0 +1,42 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestQueryGraphQL(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msgID := fftypes.NewUUID()
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(&core.Message{Header: core.MessageHeader{ID: msgID}}, nil)
+
+	res := or.QueryGraphQL(context.Background(), &core.GraphQLRequest{
+		Query:     `query ($id: String!) { message(id: $id) { header { id } } }`,
+		Variables: fftypes.JSONObject{"id": msgID.String()},
+	})
+	assert.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"message": {"header": {"id": "`+msgID.String()+`"}}}`, res.Data.String())
+}
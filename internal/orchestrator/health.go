@@ -0,0 +1,228 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type dependencyProbe struct {
+	health *core.DependencyHealth
+	probe  func(ctx context.Context) error
+}
+
+func (or *orchestrator) addHealthProbe(depType, name string, plugin interface{ Name() string }) {
+	dp := &dependencyProbe{
+		health: &core.DependencyHealth{
+			ID:         fftypes.NewUUID(),
+			Name:       name,
+			Type:       depType,
+			PluginType: plugin.Name(),
+			Status:     core.HealthStatusUnknown,
+		},
+	}
+	if prober, ok := plugin.(core.HealthProber); ok {
+		dp.probe = prober.CheckHealth
+	}
+	or.healthProbes = append(or.healthProbes, dp)
+}
+
+// initHealth must be called with the healthMux held
+func (or *orchestrator) initHealth() {
+	if or.healthProbes != nil {
+		return
+	}
+	or.healthProbes = make([]*dependencyProbe, 0)
+	if or.plugins.Database.Plugin != nil {
+		or.addHealthProbe("database", or.plugins.Database.Name, or.plugins.Database.Plugin)
+	}
+	if or.plugins.Blockchain.Plugin != nil {
+		or.addHealthProbe("blockchain", or.plugins.Blockchain.Name, or.plugins.Blockchain.Plugin)
+	}
+	if or.plugins.DataExchange.Plugin != nil {
+		or.addHealthProbe("dataexchange", or.plugins.DataExchange.Name, or.plugins.DataExchange.Plugin)
+	}
+	if or.plugins.SharedStorage.Plugin != nil {
+		or.addHealthProbe("sharedstorage", or.plugins.SharedStorage.Name, or.plugins.SharedStorage.Plugin)
+	}
+	for _, t := range or.plugins.Tokens {
+		or.addHealthProbe("tokens", t.Name, t.Plugin)
+	}
+}
+
+func (or *orchestrator) startHealthLoop() {
+	if !config.GetBool(coreconfig.HealthProbeEnabled) {
+		return
+	}
+	or.healthMux.Lock()
+	or.initHealth()
+	probeable := false
+	for _, dp := range or.healthProbes {
+		probeable = probeable || dp.probe != nil
+	}
+	or.healthMux.Unlock()
+	if probeable {
+		or.healthDone = make(chan struct{})
+		go or.healthLoop()
+	}
+}
+
+func (or *orchestrator) healthLoop() {
+	defer close(or.healthDone)
+	interval := config.GetDuration(coreconfig.HealthProbeInterval)
+	for {
+		or.probeAll(or.ctx)
+		select {
+		case <-time.After(interval):
+		case <-or.ctx.Done():
+			log.L(or.ctx).Debugf("Health probe loop exiting")
+			return
+		}
+	}
+}
+
+type probeResult struct {
+	dp      *dependencyProbe
+	err     error
+	latency time.Duration
+}
+
+// probeAll runs all the probes in parallel without holding the health lock, so a slow
+// dependency does not block readers of the health status, then applies the results
+func (or *orchestrator) probeAll(ctx context.Context) {
+	or.healthMux.Lock()
+	or.initHealth()
+	probes := make([]*dependencyProbe, 0, len(or.healthProbes))
+	for _, dp := range or.healthProbes {
+		if dp.probe != nil {
+			probes = append(probes, dp)
+		}
+	}
+	or.healthMux.Unlock()
+
+	results := make([]*probeResult, len(probes))
+	var wg sync.WaitGroup
+	for i, dp := range probes {
+		wg.Add(1)
+		go func(i int, dp *dependencyProbe) {
+			defer wg.Done()
+			results[i] = or.probeDependency(ctx, dp)
+		}(i, dp)
+	}
+	wg.Wait()
+
+	or.healthMux.Lock()
+	events := make([]*core.Event, 0)
+	for _, r := range results {
+		if event := or.applyProbeResult(ctx, r); event != nil {
+			events = append(events, event)
+		}
+	}
+	or.healthMux.Unlock()
+
+	for _, event := range events {
+		if err := or.database().InsertEvent(ctx, event); err != nil {
+			log.L(ctx).Errorf("Failed to record %s event for dependency %s: %s", event.Type, event.Reference, err)
+		}
+	}
+}
+
+func (or *orchestrator) probeDependency(ctx context.Context, dp *dependencyProbe) *probeResult {
+	timeout := config.GetDuration(coreconfig.HealthProbeTimeout)
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	startTime := time.Now()
+	err := dp.probe(probeCtx)
+	return &probeResult{
+		dp:      dp,
+		err:     err,
+		latency: time.Since(startTime),
+	}
+}
+
+// applyProbeResult must be called with the healthMux held, and returns any event to record
+func (or *orchestrator) applyProbeResult(ctx context.Context, r *probeResult) *core.Event {
+	degradedLatency := config.GetDuration(coreconfig.HealthProbeDegradedLatency)
+
+	h := r.dp.health
+	newStatus := core.HealthStatusHealthy
+	h.Error = ""
+	switch {
+	case r.err != nil:
+		newStatus = core.HealthStatusUnhealthy
+		h.Error = r.err.Error()
+	case r.latency > degradedLatency:
+		newStatus = core.HealthStatusDegraded
+	}
+	h.Latency = fftypes.FFDuration(r.latency)
+	h.Checked = fftypes.Now()
+
+	oldStatus := h.Status
+	if newStatus == oldStatus {
+		return nil
+	}
+	h.Status = newStatus
+	h.Changed = h.Checked
+	log.L(ctx).Infof("Dependency %s '%s' health changed from %s to %s (latency=%s)", h.Type, h.Name, oldStatus, newStatus, r.latency)
+
+	var eventType core.EventType
+	switch {
+	case newStatus == core.HealthStatusHealthy && oldStatus != core.HealthStatusUnknown:
+		eventType = core.EventTypeDependencyRecovered
+	case newStatus != core.HealthStatusHealthy:
+		eventType = core.EventTypeDependencyDegraded
+	default:
+		return nil
+	}
+	return core.NewEvent(eventType, or.namespace.Name, h.ID, nil, core.SystemTopicHealth)
+}
+
+func (or *orchestrator) GetHealth(ctx context.Context) (*core.NamespaceHealth, error) {
+	if or.healthDone == nil {
+		// No background loop is running, so probe on demand
+		or.probeAll(ctx)
+	}
+
+	or.healthMux.Lock()
+	defer or.healthMux.Unlock()
+	or.initHealth()
+	result := &core.NamespaceHealth{
+		Status:       core.HealthStatusUnknown,
+		Dependencies: make([]*core.DependencyHealth, 0, len(or.healthProbes)),
+	}
+	for _, dp := range or.healthProbes {
+		h := *dp.health
+		result.Dependencies = append(result.Dependencies, &h)
+		switch {
+		case h.Status == core.HealthStatusUnhealthy:
+			result.Status = core.HealthStatusUnhealthy
+		case h.Status == core.HealthStatusDegraded && result.Status != core.HealthStatusUnhealthy:
+			result.Status = core.HealthStatusDegraded
+		case h.Status == core.HealthStatusHealthy && result.Status == core.HealthStatusUnknown:
+			result.Status = core.HealthStatusHealthy
+		}
+	}
+	return result, nil
+}
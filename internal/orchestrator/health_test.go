@@ -0,0 +1,204 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type probedDatabase struct {
+	*databasemocks.Plugin
+	probe func(ctx context.Context) error
+}
+
+func (pd *probedDatabase) CheckHealth(ctx context.Context) error {
+	return pd.probe(ctx)
+}
+
+type probedBlockchain struct {
+	*blockchainmocks.Plugin
+	probe func(ctx context.Context) error
+}
+
+func (pb *probedBlockchain) CheckHealth(ctx context.Context) error {
+	return pb.probe(ctx)
+}
+
+func TestGetHealthNoProbers(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	health, err := or.GetHealth(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.HealthStatusUnknown, health.Status)
+	assert.Len(t, health.Dependencies, 5)
+	for _, d := range health.Dependencies {
+		assert.Equal(t, core.HealthStatusUnknown, d.Status)
+		assert.Nil(t, d.Checked)
+	}
+}
+
+func TestGetHealthTransitions(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	var probeErr error
+	or.plugins.Database.Plugin = &probedDatabase{
+		Plugin: or.mdi,
+		probe:  func(ctx context.Context) error { return probeErr },
+	}
+	or.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeDependencyDegraded && e.Topic == core.SystemTopicHealth
+	})).Return(nil).Once()
+	or.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeDependencyRecovered && e.Topic == core.SystemTopicHealth
+	})).Return(fmt.Errorf("pop")).Once()
+
+	// unknown -> healthy emits no event
+	health, err := or.GetHealth(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.HealthStatusHealthy, health.Status)
+	assert.Equal(t, "database", health.Dependencies[0].Type)
+	assert.Equal(t, core.HealthStatusHealthy, health.Dependencies[0].Status)
+	assert.NotNil(t, health.Dependencies[0].Changed)
+
+	// healthy -> unhealthy
+	probeErr = fmt.Errorf("pop")
+	health, err = or.GetHealth(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.HealthStatusUnhealthy, health.Status)
+	assert.Equal(t, "pop", health.Dependencies[0].Error)
+
+	// unhealthy -> unhealthy emits no event
+	_, err = or.GetHealth(or.ctx)
+	assert.NoError(t, err)
+
+	// unhealthy -> healthy, with the event insert failing
+	probeErr = nil
+	health, err = or.GetHealth(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.HealthStatusHealthy, health.Status)
+	assert.Empty(t, health.Dependencies[0].Error)
+}
+
+func TestGetHealthDegraded(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.HealthProbeDegradedLatency, "0")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.plugins.Blockchain.Plugin = &probedBlockchain{
+		Plugin: or.mbi,
+		probe:  func(ctx context.Context) error { return nil },
+	}
+	or.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeDependencyDegraded
+	})).Return(nil).Once()
+
+	health, err := or.GetHealth(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.HealthStatusDegraded, health.Status)
+	assert.Equal(t, "blockchain", health.Dependencies[1].Type)
+	assert.Equal(t, "mock-bi", health.Dependencies[1].PluginType)
+}
+
+func TestHealthLoop(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	probed := make(chan struct{}, 1)
+	or.plugins.Database.Plugin = &probedDatabase{
+		Plugin: or.mdi,
+		probe: func(ctx context.Context) error {
+			select {
+			case probed <- struct{}{}:
+			default:
+			}
+			return nil
+		},
+	}
+
+	or.startHealthLoop()
+	assert.NotNil(t, or.healthDone)
+	<-probed
+
+	health, err := or.GetHealth(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.HealthStatusHealthy, health.Status)
+
+	or.cancelCtx()
+	<-or.healthDone
+}
+
+func TestHealthLoopDisabled(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.HealthProbeEnabled, false)
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.startHealthLoop()
+	assert.Nil(t, or.healthDone)
+}
+
+func TestHealthLoopNoProbers(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.startHealthLoop()
+	assert.Nil(t, or.healthDone)
+}
+
+func TestProbeAllConcurrent(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	var started sync.WaitGroup
+	started.Add(2)
+	probe := func(ctx context.Context) error {
+		started.Done()
+		started.Wait()
+		// The health status can be read while the probes are in flight
+		or.healthMux.Lock()
+		defer or.healthMux.Unlock()
+		return nil
+	}
+	or.plugins.Database.Plugin = &probedDatabase{Plugin: or.mdi, probe: probe}
+	or.plugins.Blockchain.Plugin = &probedBlockchain{Plugin: or.mbi, probe: probe}
+
+	or.probeAll(or.ctx)
+
+	health, err := or.GetHealth(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.HealthStatusHealthy, health.Dependencies[0].Status)
+	assert.Equal(t, core.HealthStatusHealthy, health.Dependencies[1].Status)
+}
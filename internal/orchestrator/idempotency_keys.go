@@ -0,0 +1,140 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func (or *orchestrator) GetIdempotencyKeys(ctx context.Context, filter ffapi.AndFilter) ([]*core.IdempotencyKeyRecord, *ffapi.FilterResult, error) {
+	txns, fr, err := or.database().GetTransactions(ctx, or.namespace.Name, filter.Condition(filter.Builder().Neq("idempotencykey", "")))
+	if err != nil || len(txns) == 0 {
+		return []*core.IdempotencyKeyRecord{}, fr, err
+	}
+	records, err := or.idempotencyKeyRecords(ctx, txns)
+	return records, fr, err
+}
+
+func (or *orchestrator) GetIdempotencyKey(ctx context.Context, key string) (*core.IdempotencyKeyRecord, error) {
+	fb := database.TransactionQueryFactory.NewFilter(ctx)
+	txns, _, err := or.database().GetTransactions(ctx, or.namespace.Name, fb.Eq("idempotencykey", key))
+	if err != nil {
+		return nil, err
+	}
+	if len(txns) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	records, err := or.idempotencyKeyRecords(ctx, txns)
+	if err != nil {
+		return nil, err
+	}
+	return records[0], nil
+}
+
+func (or *orchestrator) ExpireIdempotencyKey(ctx context.Context, key string) error {
+	cleared, err := or.database().ClearIdempotencyKey(ctx, or.namespace.Name, core.IdempotencyKey(key))
+	if err != nil {
+		return err
+	}
+	if len(cleared) == 0 {
+		return i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	or.txHelper.EvictTransactionsCached(cleared)
+	log.L(ctx).Infof("Released idempotency key '%s'", key)
+	return nil
+}
+
+// idempotencyKeyRecords links each transaction to its operations, using a single query for the whole page
+func (or *orchestrator) idempotencyKeyRecords(ctx context.Context, txns []*core.Transaction) ([]*core.IdempotencyKeyRecord, error) {
+	records := make([]*core.IdempotencyKeyRecord, len(txns))
+	byTx := make(map[fftypes.UUID]*core.IdempotencyKeyRecord, len(txns))
+	txIDs := make([]driver.Value, len(txns))
+	for i, tx := range txns {
+		records[i] = &core.IdempotencyKeyRecord{
+			Key:         tx.IdempotencyKey,
+			Namespace:   tx.Namespace,
+			Transaction: tx.ID,
+			Type:        tx.Type,
+			Created:     tx.Created,
+			Operations:  []*fftypes.UUID{},
+		}
+		byTx[*tx.ID] = records[i]
+		txIDs[i] = tx.ID
+	}
+
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	ops, _, err := or.database().GetOperations(ctx, or.namespace.Name, fb.In("tx", txIDs).Sort("created"))
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if record := byTx[*op.Transaction]; record != nil {
+			record.Operations = append(record.Operations, op.ID)
+		}
+	}
+	return records, nil
+}
+
+func (or *orchestrator) startIdempotencyKeyExpiry() {
+	if config.GetDuration(coreconfig.TransactionIdempotencyKeysExpiry) <= 0 || or.config.ReadOnly {
+		return
+	}
+	or.idempotencyExpiryDone = make(chan struct{})
+	go or.idempotencyKeyExpiryLoop()
+}
+
+func (or *orchestrator) idempotencyKeyExpiryLoop() {
+	defer close(or.idempotencyExpiryDone)
+	interval := config.GetDuration(coreconfig.TransactionIdempotencyKeysExpiryInterval)
+	for {
+		select {
+		case <-time.After(interval):
+		case <-or.ctx.Done():
+			log.L(or.ctx).Debugf("Idempotency key expiry exiting")
+			return
+		}
+		if err := or.expireIdempotencyKeys(or.ctx); err != nil {
+			log.L(or.ctx).Errorf("Idempotency key expiry failed: %s", err)
+		}
+	}
+}
+
+func (or *orchestrator) expireIdempotencyKeys(ctx context.Context) error {
+	expiry := config.GetDuration(coreconfig.TransactionIdempotencyKeysExpiry)
+	createdBefore := fftypes.FFTime(time.Now().Add(-expiry))
+	cleared, err := or.database().ExpireIdempotencyKeys(ctx, or.namespace.Name, &createdBefore)
+	if err != nil {
+		return err
+	}
+	if len(cleared) > 0 {
+		or.txHelper.EvictTransactionsCached(cleared)
+		log.L(ctx).Infof("Released %d idempotency keys created before %s", len(cleared), createdBefore.String())
+	}
+	return nil
+}
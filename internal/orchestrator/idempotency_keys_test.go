@@ -0,0 +1,201 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetIdempotencyKeys(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	tx1 := &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns", Type: core.TransactionTypeContractInvoke, IdempotencyKey: "idem1"}
+	tx2 := &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns", Type: core.TransactionTypeTokenTransfer, IdempotencyKey: "idem2"}
+	op1 := &core.Operation{ID: fftypes.NewUUID(), Transaction: tx1.ID}
+	op2 := &core.Operation{ID: fftypes.NewUUID(), Transaction: tx1.ID}
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return([]*core.Transaction{tx1, tx2}, nil, nil)
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return([]*core.Operation{op1, op2}, nil, nil)
+
+	fb := database.TransactionQueryFactory.NewFilter(or.ctx)
+	records, _, err := or.GetIdempotencyKeys(or.ctx, fb.And())
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, core.IdempotencyKey("idem1"), records[0].Key)
+	assert.Equal(t, tx1.ID, records[0].Transaction)
+	assert.Equal(t, []*fftypes.UUID{op1.ID, op2.ID}, records[0].Operations)
+	assert.Equal(t, core.IdempotencyKey("idem2"), records[1].Key)
+	assert.Empty(t, records[1].Operations)
+}
+
+func TestGetIdempotencyKeysNone(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return([]*core.Transaction{}, nil, nil)
+
+	fb := database.TransactionQueryFactory.NewFilter(or.ctx)
+	records, _, err := or.GetIdempotencyKeys(or.ctx, fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestGetIdempotencyKeysOpsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	tx1 := &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns", IdempotencyKey: "idem1"}
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return([]*core.Transaction{tx1}, nil, nil)
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.TransactionQueryFactory.NewFilter(or.ctx)
+	_, _, err := or.GetIdempotencyKeys(or.ctx, fb.And())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetIdempotencyKey(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	tx1 := &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns", IdempotencyKey: "idem1"}
+	op1 := &core.Operation{ID: fftypes.NewUUID(), Transaction: tx1.ID}
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return([]*core.Transaction{tx1}, nil, nil)
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return([]*core.Operation{op1}, nil, nil)
+
+	record, err := or.GetIdempotencyKey(or.ctx, "idem1")
+	assert.NoError(t, err)
+	assert.Equal(t, tx1.ID, record.Transaction)
+	assert.Equal(t, []*fftypes.UUID{op1.ID}, record.Operations)
+}
+
+func TestGetIdempotencyKeyNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return([]*core.Transaction{}, nil, nil)
+
+	_, err := or.GetIdempotencyKey(or.ctx, "idem1")
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetIdempotencyKeyFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.GetIdempotencyKey(or.ctx, "idem1")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetIdempotencyKeyOpsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	tx1 := &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns", IdempotencyKey: "idem1"}
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return([]*core.Transaction{tx1}, nil, nil)
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.GetIdempotencyKey(or.ctx, "idem1")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExpireIdempotencyKey(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	txID := fftypes.NewUUID()
+	or.mdi.On("ClearIdempotencyKey", mock.Anything, "ns", core.IdempotencyKey("idem1")).Return([]*fftypes.UUID{txID}, nil)
+	or.mth.On("EvictTransactionsCached", []*fftypes.UUID{txID}).Return()
+
+	err := or.ExpireIdempotencyKey(or.ctx, "idem1")
+	assert.NoError(t, err)
+}
+
+func TestExpireIdempotencyKeyNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("ClearIdempotencyKey", mock.Anything, "ns", core.IdempotencyKey("idem1")).Return([]*fftypes.UUID{}, nil)
+
+	err := or.ExpireIdempotencyKey(or.ctx, "idem1")
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestExpireIdempotencyKeyFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("ClearIdempotencyKey", mock.Anything, "ns", core.IdempotencyKey("idem1")).Return(nil, fmt.Errorf("pop"))
+
+	err := or.ExpireIdempotencyKey(or.ctx, "idem1")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestIdempotencyKeyExpiryLoop(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.TransactionIdempotencyKeysExpiry, "24h")
+	config.Set(coreconfig.TransactionIdempotencyKeysExpiryInterval, "1ms")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	expired := make(chan struct{})
+	or.mdi.On("ExpireIdempotencyKeys", mock.Anything, "ns", mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	or.mth.On("EvictTransactionsCached", mock.Anything).Return()
+	or.mdi.On("ExpireIdempotencyKeys", mock.Anything, "ns", mock.Anything).Return([]*fftypes.UUID{fftypes.NewUUID()}, nil).Run(func(args mock.Arguments) {
+		select {
+		case <-expired:
+		default:
+			close(expired)
+		}
+	})
+
+	or.startIdempotencyKeyExpiry()
+	assert.NotNil(t, or.idempotencyExpiryDone)
+	<-expired
+	or.cancelCtx()
+	<-or.idempotencyExpiryDone
+}
+
+func TestIdempotencyKeyExpiryDisabled(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.startIdempotencyKeyExpiry()
+	assert.Nil(t, or.idempotencyExpiryDone)
+}
+
+func TestIdempotencyKeyExpiryReadOnly(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.TransactionIdempotencyKeysExpiry, "24h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.ReadOnly = true
+
+	or.startIdempotencyKeyExpiry()
+	assert.Nil(t, or.idempotencyExpiryDone)
+}
@@ -0,0 +1,303 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"database/sql/driver"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// ledgerView collects what FireFly recorded from a page of blockchain events, to present them in the
+// standardized account/operation model
+type ledgerView struct {
+	or        *orchestrator
+	transfers map[fftypes.UUID][]*core.TokenTransfer
+	pins      map[fftypes.UUID][]*core.Pin
+	pools     map[fftypes.UUID]*core.TokenPool
+}
+
+// GetLedgerTransactions returns the blockchain events of the namespace as ledger transactions. Token transfers
+// are presented as debits and credits of accounts, batch pins as pins signed by an account, and any other event
+// (including events from contract listeners) as a single event operation. The filter applies to the underlying
+// blockchain events.
+func (or *orchestrator) GetLedgerTransactions(ctx context.Context, filter ffapi.AndFilter) ([]*core.LedgerTransaction, *ffapi.FilterResult, error) {
+	events, fr, err := or.database().GetBlockchainEvents(ctx, or.namespace.Name, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	lv := &ledgerView{
+		or:    or,
+		pools: make(map[fftypes.UUID]*core.TokenPool),
+	}
+	if err := lv.loadTransfers(ctx, events); err != nil {
+		return nil, nil, err
+	}
+	if err := lv.loadPins(ctx, events); err != nil {
+		return nil, nil, err
+	}
+	txns := make([]*core.LedgerTransaction, len(events))
+	for i, event := range events {
+		if txns[i], err = lv.transaction(ctx, event); err != nil {
+			return nil, nil, err
+		}
+	}
+	return txns, fr, nil
+}
+
+// GetLedgerBalances returns the token balances held by an account, with the currency of each
+func (or *orchestrator) GetLedgerBalances(ctx context.Context, key string, filter ffapi.AndFilter) ([]*core.LedgerBalance, *ffapi.FilterResult, error) {
+	filter.Condition(filter.Builder().Eq("key", key))
+	balances, fr, err := or.database().GetTokenBalances(ctx, or.namespace.Name, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	lv := &ledgerView{
+		or:    or,
+		pools: make(map[fftypes.UUID]*core.TokenPool),
+	}
+	results := make([]*core.LedgerBalance, len(balances))
+	for i, balance := range balances {
+		currency, err := lv.currency(ctx, balance.Pool, balance.TokenIndex)
+		if err != nil {
+			return nil, nil, err
+		}
+		results[i] = &core.LedgerBalance{
+			Account: &core.LedgerAccount{Address: balance.Key},
+			Amount: &core.LedgerAmount{
+				Value:    balance.Balance.String(),
+				Currency: currency,
+			},
+			Updated: balance.Updated,
+		}
+	}
+	return results, fr, nil
+}
+
+func (lv *ledgerView) loadTransfers(ctx context.Context, events []*core.BlockchainEvent) error {
+	lv.transfers = make(map[fftypes.UUID][]*core.TokenTransfer)
+	var eventIDs []driver.Value
+	for _, event := range events {
+		if event.Listener == nil && !isBatchPinEvent(event) {
+			eventIDs = append(eventIDs, event.ID)
+		}
+	}
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+	transfers, _, err := lv.or.database().GetTokenTransfers(ctx, lv.or.namespace.Name, fb.In("blockchainevent", eventIDs).Sort("created"))
+	if err != nil {
+		return err
+	}
+	for _, transfer := range transfers {
+		lv.transfers[*transfer.BlockchainEvent] = append(lv.transfers[*transfer.BlockchainEvent], transfer)
+	}
+	return nil
+}
+
+// loadPins finds the pins of the batches pinned by batch pin events, via the FireFly transaction of each batch.
+// Pins are only recorded once the batch is pinned, so there might be none yet for a private batch.
+func (lv *ledgerView) loadPins(ctx context.Context, events []*core.BlockchainEvent) error {
+	lv.pins = make(map[fftypes.UUID][]*core.Pin)
+	var txIDs []driver.Value
+	for _, event := range events {
+		if isBatchPinEvent(event) {
+			txIDs = append(txIDs, event.TX.ID)
+		}
+	}
+	if len(txIDs) == 0 {
+		return nil
+	}
+	bfb := database.BatchQueryFactory.NewFilter(ctx)
+	batches, _, err := lv.or.database().GetBatches(ctx, lv.or.namespace.Name, bfb.In("tx.id", txIDs))
+	if err != nil || len(batches) == 0 {
+		return err
+	}
+	batchTX := make(map[fftypes.UUID]*fftypes.UUID, len(batches))
+	batchIDs := make([]driver.Value, len(batches))
+	for i, batch := range batches {
+		batchTX[*batch.ID] = batch.TX.ID
+		batchIDs[i] = batch.ID
+	}
+	pfb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := lv.or.database().GetPins(ctx, lv.or.namespace.Name, pfb.In("batch", batchIDs).Sort("sequence"))
+	if err != nil {
+		return err
+	}
+	for _, pin := range pins {
+		if txID := batchTX[*pin.Batch]; txID != nil {
+			lv.pins[*txID] = append(lv.pins[*txID], pin)
+		}
+	}
+	return nil
+}
+
+func (lv *ledgerView) transaction(ctx context.Context, event *core.BlockchainEvent) (*core.LedgerTransaction, error) {
+	txn := &core.LedgerTransaction{
+		ID:         event.ID,
+		Block:      ledgerBlock(event.ProtocolID),
+		Hash:       event.TX.BlockchainID,
+		Source:     event.Source,
+		ProtocolID: event.ProtocolID,
+		TX:         event.TX.ID,
+		Timestamp:  event.Timestamp,
+		Operations: []*core.LedgerOperation{},
+	}
+	if isBatchPinEvent(event) {
+		for _, pin := range lv.pins[*event.TX.ID] {
+			lv.addPinOperation(txn, pin)
+		}
+	}
+	for _, transfer := range lv.transfers[*event.ID] {
+		if err := lv.addTransferOperations(ctx, txn, transfer); err != nil {
+			return nil, err
+		}
+	}
+	if len(txn.Operations) == 0 {
+		metadata := fftypes.JSONObject{
+			"name":   event.Name,
+			"output": event.Output,
+		}
+		if event.Listener != nil {
+			metadata["listener"] = event.Listener.String()
+		}
+		txn.Operations = append(txn.Operations, &core.LedgerOperation{
+			Type:     core.LedgerOperationTypeEvent,
+			Metadata: metadata,
+		})
+	}
+	return txn, nil
+}
+
+func (lv *ledgerView) addPinOperation(txn *core.LedgerTransaction, pin *core.Pin) {
+	metadata := fftypes.JSONObject{
+		"batch":  pin.Batch.String(),
+		"index":  pin.Index,
+		"masked": pin.Masked,
+	}
+	if pin.Hash != nil {
+		metadata["hash"] = pin.Hash.String()
+	}
+	txn.Operations = append(txn.Operations, &core.LedgerOperation{
+		Index:    len(txn.Operations),
+		Type:     core.LedgerOperationTypePin,
+		Account:  &core.LedgerAccount{Address: pin.Signer},
+		Metadata: metadata,
+	})
+}
+
+// addTransferOperations adds a credit for a mint, a debit for a burn, and a debit of the sender related
+// to a credit of the recipient for a transfer
+func (lv *ledgerView) addTransferOperations(ctx context.Context, txn *core.LedgerTransaction, transfer *core.TokenTransfer) error {
+	currency, err := lv.currency(ctx, transfer.Pool, transfer.TokenIndex)
+	if err != nil {
+		return err
+	}
+	metadata := fftypes.JSONObject{
+		"transfer": transfer.LocalID.String(),
+		"key":      transfer.Key,
+	}
+	if transfer.Message != nil {
+		metadata["message"] = transfer.Message.String()
+	}
+	if transfer.Type != core.TokenTransferTypeMint {
+		txn.Operations = append(txn.Operations, &core.LedgerOperation{
+			Index:    len(txn.Operations),
+			Type:     ledgerTransferOperationType(transfer.Type),
+			Account:  &core.LedgerAccount{Address: transfer.From},
+			Amount:   &core.LedgerAmount{Value: new(big.Int).Neg(transfer.Amount.Int()).String(), Currency: currency},
+			Metadata: metadata,
+		})
+	}
+	if transfer.Type != core.TokenTransferTypeBurn {
+		op := &core.LedgerOperation{
+			Index:    len(txn.Operations),
+			Type:     ledgerTransferOperationType(transfer.Type),
+			Account:  &core.LedgerAccount{Address: transfer.To},
+			Amount:   &core.LedgerAmount{Value: transfer.Amount.String(), Currency: currency},
+			Metadata: metadata,
+		}
+		if transfer.Type == core.TokenTransferTypeTransfer {
+			op.RelatedOperations = []int{op.Index - 1}
+		}
+		txn.Operations = append(txn.Operations, op)
+	}
+	return nil
+}
+
+func (lv *ledgerView) currency(ctx context.Context, poolID *fftypes.UUID, tokenIndex string) (*core.LedgerCurrency, error) {
+	currency := &core.LedgerCurrency{
+		Pool:       poolID,
+		TokenIndex: tokenIndex,
+	}
+	if poolID == nil {
+		return currency, nil
+	}
+	pool, ok := lv.pools[*poolID]
+	if !ok {
+		var err error
+		if pool, err = lv.or.database().GetTokenPoolByID(ctx, lv.or.namespace.Name, poolID); err != nil {
+			return nil, err
+		}
+		lv.pools[*poolID] = pool
+	}
+	if pool != nil {
+		currency.Symbol = pool.Symbol
+		currency.Decimals = pool.Decimals
+	}
+	return currency, nil
+}
+
+func ledgerTransferOperationType(transferType core.TokenTransferType) core.LedgerOperationType {
+	switch transferType {
+	case core.TokenTransferTypeMint:
+		return core.LedgerOperationTypeMint
+	case core.TokenTransferTypeBurn:
+		return core.LedgerOperationTypeBurn
+	default:
+		return core.LedgerOperationTypeTransfer
+	}
+}
+
+// isBatchPinEvent returns true for the events of the multiparty contract that pin batches of messages,
+// including batches pinned as part of a custom contract invocation
+func isBatchPinEvent(event *core.BlockchainEvent) bool {
+	return event.Listener == nil && event.TX.ID != nil &&
+		(event.TX.Type == core.TransactionTypeBatchPin || event.TX.Type == core.TransactionTypeContractInvokePin)
+}
+
+// ledgerBlock returns the block number from the protocol ID of an event, for the blockchain plugins that
+// format their protocol IDs as a zero-padded block number followed by the position within the block
+func ledgerBlock(protocolID string) *core.LedgerBlock {
+	parts := strings.SplitN(protocolID, "/", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	index, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &core.LedgerBlock{Index: index}
+}
@@ -0,0 +1,287 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetLedgerTransactions(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	pinTX := fftypes.NewUUID()
+	batchID := fftypes.NewUUID()
+	pool := fftypes.NewUUID()
+	events := []*core.BlockchainEvent{
+		{
+			ID:         fftypes.NewUUID(),
+			Name:       "BatchPin",
+			ProtocolID: "000000000010/000001/000000",
+			TX:         core.BlockchainTransactionRef{Type: core.TransactionTypeBatchPin, ID: pinTX, BlockchainID: "0xaaaa"},
+		},
+		{
+			ID:         fftypes.NewUUID(),
+			Name:       "Transfer",
+			ProtocolID: "000000000011/000000/000000",
+			TX:         core.BlockchainTransactionRef{Type: core.TransactionTypeTokenTransfer, ID: fftypes.NewUUID(), BlockchainID: "0xbbbb"},
+		},
+		{
+			ID:         fftypes.NewUUID(),
+			Name:       "Transfer",
+			ProtocolID: "000000000012/000000/000000",
+		},
+		{
+			ID:         fftypes.NewUUID(),
+			Name:       "Changed",
+			Listener:   fftypes.NewUUID(),
+			ProtocolID: "not-a-block",
+			Output:     fftypes.JSONObject{"value": "1"},
+		},
+	}
+	transfers := []*core.TokenTransfer{
+		{
+			Type:            core.TokenTransferTypeTransfer,
+			LocalID:         fftypes.NewUUID(),
+			Pool:            pool,
+			From:            "0x1",
+			To:              "0x2",
+			Amount:          *fftypes.NewFFBigInt(5),
+			Message:         fftypes.NewUUID(),
+			BlockchainEvent: events[1].ID,
+		},
+		{
+			Type:            core.TokenTransferTypeMint,
+			LocalID:         fftypes.NewUUID(),
+			Pool:            pool,
+			To:              "0x1",
+			Amount:          *fftypes.NewFFBigInt(10),
+			BlockchainEvent: events[2].ID,
+		},
+	}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mdi.On("GetTokenTransfers", mock.Anything, "ns", mock.Anything).Return(transfers, nil, nil)
+	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return([]*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{ID: batchID}, TX: core.TransactionRef{ID: pinTX}},
+	}, nil, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{
+		{Batch: batchID, Index: 0, Hash: fftypes.NewRandB32(), Signer: "0x3"},
+		{Batch: batchID, Index: 1, Signer: "0x3"},
+	}, nil, nil)
+	or.mdi.On("GetTokenPoolByID", mock.Anything, "ns", pool).Return(&core.TokenPool{Symbol: "FFC", Decimals: 18}, nil).Once()
+
+	f := database.BlockchainEventQueryFactory.NewFilter(context.Background()).And()
+	txns, _, err := or.GetLedgerTransactions(context.Background(), f)
+	assert.NoError(t, err)
+	assert.Len(t, txns, 4)
+
+	assert.Equal(t, int64(10), txns[0].Block.Index)
+	assert.Equal(t, "0xaaaa", txns[0].Hash)
+	assert.Len(t, txns[0].Operations, 2)
+	assert.Equal(t, core.LedgerOperationTypePin, txns[0].Operations[0].Type)
+	assert.Equal(t, "0x3", txns[0].Operations[0].Account.Address)
+	assert.Equal(t, 1, txns[0].Operations[1].Index)
+
+	assert.Len(t, txns[1].Operations, 2)
+	assert.Equal(t, core.LedgerOperationTypeTransfer, txns[1].Operations[0].Type)
+	assert.Equal(t, "0x1", txns[1].Operations[0].Account.Address)
+	assert.Equal(t, "-5", txns[1].Operations[0].Amount.Value)
+	assert.Equal(t, "FFC", txns[1].Operations[0].Amount.Currency.Symbol)
+	assert.Equal(t, 18, txns[1].Operations[0].Amount.Currency.Decimals)
+	assert.Equal(t, "0x2", txns[1].Operations[1].Account.Address)
+	assert.Equal(t, "5", txns[1].Operations[1].Amount.Value)
+	assert.Equal(t, []int{0}, txns[1].Operations[1].RelatedOperations)
+
+	assert.Len(t, txns[2].Operations, 1)
+	assert.Equal(t, core.LedgerOperationTypeMint, txns[2].Operations[0].Type)
+	assert.Equal(t, "10", txns[2].Operations[0].Amount.Value)
+
+	assert.Nil(t, txns[3].Block)
+	assert.Len(t, txns[3].Operations, 1)
+	assert.Equal(t, core.LedgerOperationTypeEvent, txns[3].Operations[0].Type)
+	assert.Equal(t, "Changed", txns[3].Operations[0].Metadata["name"])
+	assert.Equal(t, events[3].Listener.String(), txns[3].Operations[0].Metadata["listener"])
+}
+
+func TestGetLedgerTransactionsBurn(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	events := []*core.BlockchainEvent{{ID: fftypes.NewUUID(), Name: "Transfer"}}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mdi.On("GetTokenTransfers", mock.Anything, "ns", mock.Anything).Return([]*core.TokenTransfer{
+		{Type: core.TokenTransferTypeBurn, LocalID: fftypes.NewUUID(), From: "0x1", Amount: *fftypes.NewFFBigInt(3), BlockchainEvent: events[0].ID},
+	}, nil, nil)
+
+	f := database.BlockchainEventQueryFactory.NewFilter(context.Background()).And()
+	txns, _, err := or.GetLedgerTransactions(context.Background(), f)
+	assert.NoError(t, err)
+	assert.Len(t, txns[0].Operations, 1)
+	assert.Equal(t, core.LedgerOperationTypeBurn, txns[0].Operations[0].Type)
+	assert.Equal(t, "-3", txns[0].Operations[0].Amount.Value)
+	assert.Nil(t, txns[0].Operations[0].Amount.Currency.Pool)
+}
+
+func TestGetLedgerTransactionsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	f := database.BlockchainEventQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetLedgerTransactions(context.Background(), f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetLedgerTransactionsTransfersFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	events := []*core.BlockchainEvent{{ID: fftypes.NewUUID(), Name: "Transfer"}}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mdi.On("GetTokenTransfers", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	f := database.BlockchainEventQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetLedgerTransactions(context.Background(), f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetLedgerTransactionsPoolFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	pool := fftypes.NewUUID()
+	events := []*core.BlockchainEvent{{ID: fftypes.NewUUID(), Name: "Transfer"}}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mdi.On("GetTokenTransfers", mock.Anything, "ns", mock.Anything).Return([]*core.TokenTransfer{
+		{Type: core.TokenTransferTypeMint, LocalID: fftypes.NewUUID(), Pool: pool, To: "0x1", BlockchainEvent: events[0].ID},
+	}, nil, nil)
+	or.mdi.On("GetTokenPoolByID", mock.Anything, "ns", pool).Return(nil, fmt.Errorf("pop"))
+
+	f := database.BlockchainEventQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetLedgerTransactions(context.Background(), f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetLedgerTransactionsBatchesFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	events := []*core.BlockchainEvent{{
+		ID: fftypes.NewUUID(),
+		TX: core.BlockchainTransactionRef{Type: core.TransactionTypeContractInvokePin, ID: fftypes.NewUUID()},
+	}}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	f := database.BlockchainEventQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetLedgerTransactions(context.Background(), f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetLedgerTransactionsPinsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	pinTX := fftypes.NewUUID()
+	events := []*core.BlockchainEvent{{
+		ID: fftypes.NewUUID(),
+		TX: core.BlockchainTransactionRef{Type: core.TransactionTypeBatchPin, ID: pinTX},
+	}}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return([]*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}, TX: core.TransactionRef{ID: pinTX}},
+	}, nil, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	f := database.BlockchainEventQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetLedgerTransactions(context.Background(), f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetLedgerTransactionsBatchNotReceived(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	events := []*core.BlockchainEvent{{
+		ID:   fftypes.NewUUID(),
+		Name: "BatchPin",
+		TX:   core.BlockchainTransactionRef{Type: core.TransactionTypeBatchPin, ID: fftypes.NewUUID()},
+	}}
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+
+	f := database.BlockchainEventQueryFactory.NewFilter(context.Background()).And()
+	txns, _, err := or.GetLedgerTransactions(context.Background(), f)
+	assert.NoError(t, err)
+	assert.Equal(t, core.LedgerOperationTypeEvent, txns[0].Operations[0].Type)
+}
+
+func TestGetLedgerBalances(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	pool := fftypes.NewUUID()
+	or.mdi.On("GetTokenBalances", mock.Anything, "ns", mock.Anything).Return([]*core.TokenBalance{
+		{Pool: pool, Key: "0x1", Balance: *fftypes.NewFFBigInt(10)},
+		{Pool: pool, Key: "0x1", TokenIndex: "1", Balance: *fftypes.NewFFBigInt(1)},
+	}, nil, nil)
+	or.mdi.On("GetTokenPoolByID", mock.Anything, "ns", pool).Return(nil, nil).Once()
+
+	f := database.TokenBalanceQueryFactory.NewFilter(context.Background()).And()
+	balances, _, err := or.GetLedgerBalances(context.Background(), "0x1", f)
+	assert.NoError(t, err)
+	assert.Len(t, balances, 2)
+	assert.Equal(t, "0x1", balances[0].Account.Address)
+	assert.Equal(t, "10", balances[0].Amount.Value)
+	assert.Equal(t, pool, balances[0].Amount.Currency.Pool)
+	assert.Equal(t, "1", balances[1].Amount.Currency.TokenIndex)
+}
+
+func TestGetLedgerBalancesFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetTokenBalances", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	f := database.TokenBalanceQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetLedgerBalances(context.Background(), "0x1", f)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetLedgerBalancesPoolFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	pool := fftypes.NewUUID()
+	or.mdi.On("GetTokenBalances", mock.Anything, "ns", mock.Anything).Return([]*core.TokenBalance{
+		{Pool: pool, Key: "0x1", Balance: *fftypes.NewFFBigInt(10)},
+	}, nil, nil)
+	or.mdi.On("GetTokenPoolByID", mock.Anything, "ns", pool).Return(nil, fmt.Errorf("pop"))
+
+	f := database.TokenBalanceQueryFactory.NewFilter(context.Background()).And()
+	_, _, err := or.GetLedgerBalances(context.Background(), "0x1", f)
+	assert.EqualError(t, err, "pop")
+}
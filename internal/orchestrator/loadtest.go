@@ -0,0 +1,47 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// StartLoadTest sends synthetic traffic through the namespace, which must be configured as a sandbox
+func (or *orchestrator) StartLoadTest(ctx context.Context, spec *core.LoadTestSpec) (*core.LoadTestStatus, error) {
+	if or.loadgen == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgLoadTestNotSandbox, or.namespace.Name)
+	}
+	return or.loadgen.Start(ctx, spec)
+}
+
+func (or *orchestrator) StopLoadTest(ctx context.Context) (*core.LoadTestStatus, error) {
+	if or.loadgen == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgLoadTestNotSandbox, or.namespace.Name)
+	}
+	return or.loadgen.Stop(ctx)
+}
+
+func (or *orchestrator) GetLoadTestStatus(ctx context.Context) (*core.LoadTestStatus, error) {
+	if or.loadgen == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgLoadTestNotSandbox, or.namespace.Name)
+	}
+	return or.loadgen.Status(ctx)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/loadgenmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTestNotSandbox(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.StartLoadTest(or.ctx, &core.LoadTestSpec{})
+	assert.Regexp(t, "FF10607", err)
+	_, err = or.StopLoadTest(or.ctx)
+	assert.Regexp(t, "FF10607", err)
+	_, err = or.GetLoadTestStatus(or.ctx)
+	assert.Regexp(t, "FF10607", err)
+}
+
+func TestLoadTestSandbox(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mlg := &loadgenmocks.Generator{}
+	or.loadgen = mlg
+
+	spec := &core.LoadTestSpec{BroadcastRate: 10}
+	status := &core.LoadTestStatus{Spec: spec}
+	mlg.On("Start", or.ctx, spec).Return(status, nil)
+	mlg.On("Stop", or.ctx).Return(status, nil)
+	mlg.On("Status", or.ctx).Return(status, nil)
+
+	result, err := or.StartLoadTest(or.ctx, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, status, result)
+	result, err = or.StopLoadTest(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, status, result)
+	result, err = or.GetLoadTestStatus(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, status, result)
+
+	mlg.AssertExpectations(t)
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type onlineMigrationRun struct {
+	running bool
+	err     string
+	done    chan struct{}
+}
+
+func (or *orchestrator) GetOnlineMigrations(ctx context.Context) ([]*core.OnlineMigration, error) {
+	migrations, err := or.database().GetOnlineMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	or.onlineMigrationMux.Lock()
+	defer or.onlineMigrationMux.Unlock()
+	for _, m := range migrations {
+		if run := or.onlineMigrationRuns[m.Name]; run != nil {
+			m.Running = run.running
+			m.Error = run.err
+		}
+	}
+	return migrations, nil
+}
+
+// RunOnlineMigration starts an online migration in the background. The new table is created and
+// backfilled in small steps while the node continues to run, and blockchain event ingestion and
+// aggregation are only paused for the final swap.
+func (or *orchestrator) RunOnlineMigration(ctx context.Context, name string) (*core.OnlineMigration, error) {
+	migrations, err := or.GetOnlineMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var migration *core.OnlineMigration
+	for _, m := range migrations {
+		if m.Name == name {
+			migration = m
+		}
+	}
+	if migration == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationNotFound, name)
+	}
+	if migration.Status == core.OnlineMigrationStatusComplete {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationBadStatus, name, migration.Status)
+	}
+
+	or.onlineMigrationMux.Lock()
+	defer or.onlineMigrationMux.Unlock()
+	if run := or.onlineMigrationRuns[name]; run != nil && run.running {
+		return nil, i18n.NewError(ctx, coremsgs.MsgOnlineMigrationRunning, name)
+	}
+	if or.onlineMigrationRuns == nil {
+		or.onlineMigrationRuns = make(map[string]*onlineMigrationRun)
+	}
+	run := &onlineMigrationRun{running: true, done: make(chan struct{})}
+	or.onlineMigrationRuns[name] = run
+	go or.onlineMigrationLoop(name, run)
+
+	migration.Running = true
+	migration.Error = ""
+	return migration, nil
+}
+
+func (or *orchestrator) onlineMigrationLoop(name string, run *onlineMigrationRun) {
+	defer close(run.done)
+	err := or.runOnlineMigration(or.ctx, name)
+	if err != nil {
+		log.L(or.ctx).Errorf("Online migration '%s' failed: %s", name, err)
+	}
+	or.onlineMigrationMux.Lock()
+	defer or.onlineMigrationMux.Unlock()
+	run.running = false
+	if err != nil {
+		run.err = err.Error()
+	}
+}
+
+func (or *orchestrator) runOnlineMigration(ctx context.Context, name string) error {
+	interval := config.GetDuration(coreconfig.OnlineMigrationsStepInterval)
+	for {
+		migration, err := or.database().RunOnlineMigrationStep(ctx, name)
+		if err != nil {
+			return err
+		}
+		if migration.Status == core.OnlineMigrationStatusComplete {
+			return nil // completed by another node
+		}
+		if migration.Status == core.OnlineMigrationStatusBackfilled {
+			break
+		}
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+		}
+	}
+
+	// The swap blocks every writer to the table, so event ingestion is paused rather than left waiting on the lock
+	log.L(ctx).Infof("Online migration '%s' pausing event ingestion to swap tables", name)
+	resume := or.events.PauseIngestion()
+	defer resume()
+	migration, err := or.database().SwapOnlineMigration(ctx, name)
+	if err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Online migration '%s' complete after copying %d rows", name, migration.Rows)
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRunOnlineMigration(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.OnlineMigrationsStepInterval, "1ms")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetOnlineMigrations", mock.Anything).Return([]*core.OnlineMigration{
+		{Name: "m1", Status: core.OnlineMigrationStatusPending},
+	}, nil)
+	or.mdi.On("RunOnlineMigrationStep", mock.Anything, "m1").Return(&core.OnlineMigration{Status: core.OnlineMigrationStatusBackfilling}, nil).Once()
+	or.mdi.On("RunOnlineMigrationStep", mock.Anything, "m1").Return(&core.OnlineMigration{Status: core.OnlineMigrationStatusBackfilled}, nil).Once()
+	resumed := false
+	or.mem.On("PauseIngestion").Return(func() { resumed = true })
+	or.mdi.On("SwapOnlineMigration", mock.Anything, "m1").Return(&core.OnlineMigration{Status: core.OnlineMigrationStatusComplete, Rows: 10}, nil)
+
+	migration, err := or.RunOnlineMigration(context.Background(), "m1")
+	assert.NoError(t, err)
+	assert.True(t, migration.Running)
+
+	run := or.onlineMigrationRuns["m1"]
+	<-run.done
+	assert.True(t, resumed)
+
+	migrations, err := or.GetOnlineMigrations(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, migrations[0].Running)
+	assert.Empty(t, migrations[0].Error)
+}
+
+func TestRunOnlineMigrationCompletedElsewhere(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("RunOnlineMigrationStep", mock.Anything, "m1").Return(&core.OnlineMigration{Status: core.OnlineMigrationStatusComplete}, nil)
+
+	err := or.runOnlineMigration(context.Background(), "m1")
+	assert.NoError(t, err)
+}
+
+func TestRunOnlineMigrationStepFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetOnlineMigrations", mock.Anything).Return([]*core.OnlineMigration{
+		{Name: "m1", Status: core.OnlineMigrationStatusBackfilling},
+	}, nil)
+	or.mdi.On("RunOnlineMigrationStep", mock.Anything, "m1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.RunOnlineMigration(context.Background(), "m1")
+	assert.NoError(t, err)
+	<-or.onlineMigrationRuns["m1"].done
+
+	migrations, err := or.GetOnlineMigrations(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, migrations[0].Running)
+	assert.Equal(t, "pop", migrations[0].Error)
+}
+
+func TestRunOnlineMigrationSwapFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("RunOnlineMigrationStep", mock.Anything, "m1").Return(&core.OnlineMigration{Status: core.OnlineMigrationStatusBackfilled}, nil)
+	or.mem.On("PauseIngestion").Return(func() {})
+	or.mdi.On("SwapOnlineMigration", mock.Anything, "m1").Return(nil, fmt.Errorf("pop"))
+
+	err := or.runOnlineMigration(context.Background(), "m1")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRunOnlineMigrationCancelled(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("RunOnlineMigrationStep", mock.Anything, "m1").Return(&core.OnlineMigration{Status: core.OnlineMigrationStatusBackfilling}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := or.runOnlineMigration(ctx, "m1")
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestRunOnlineMigrationAlreadyRunning(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetOnlineMigrations", mock.Anything).Return([]*core.OnlineMigration{
+		{Name: "m1", Status: core.OnlineMigrationStatusBackfilling},
+	}, nil)
+	or.onlineMigrationRuns = map[string]*onlineMigrationRun{"m1": {running: true}}
+
+	_, err := or.RunOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10517", err)
+}
+
+func TestRunOnlineMigrationComplete(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetOnlineMigrations", mock.Anything).Return([]*core.OnlineMigration{
+		{Name: "m1", Status: core.OnlineMigrationStatusComplete},
+	}, nil)
+
+	_, err := or.RunOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10518", err)
+}
+
+func TestRunOnlineMigrationNotFound(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetOnlineMigrations", mock.Anything).Return([]*core.OnlineMigration{}, nil)
+
+	_, err := or.RunOnlineMigration(context.Background(), "m1")
+	assert.Regexp(t, "FF10514", err)
+}
+
+func TestRunOnlineMigrationListFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetOnlineMigrations", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.RunOnlineMigration(context.Background(), "m1")
+	assert.EqualError(t, err, "pop")
+}
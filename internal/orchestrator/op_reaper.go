@@ -0,0 +1,169 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// reaperOpState is how far the reaper has escalated an operation it found to be stale
+type reaperOpState int
+
+const (
+	// reaperOpRequeried means the plugin of the operation has been re-queried, and an operation_stale event emitted
+	reaperOpRequeried reaperOpState = iota + 1
+	// reaperOpFailing means an update to fail the operation has been submitted, but not yet applied
+	reaperOpFailing
+	// reaperOpFailed means the update to fail the operation has been applied, so any scan that still finds it
+	// pending read it before the update was committed
+	reaperOpFailed
+)
+
+func (or *orchestrator) startOperationReaper() {
+	if !config.GetBool(coreconfig.OperationsReaperEnabled) || or.config.ReadOnly {
+		return
+	}
+	or.reaperStale = make(map[fftypes.UUID]reaperOpState)
+	or.reaperDone = make(chan struct{})
+	go or.reaperLoop()
+}
+
+func (or *orchestrator) reaperLoop() {
+	defer close(or.reaperDone)
+	interval := config.GetDuration(coreconfig.OperationsReaperInterval)
+	for {
+		select {
+		case <-time.After(interval):
+		case <-or.ctx.Done():
+			log.L(or.ctx).Debugf("Operation reaper exiting")
+			return
+		}
+		if err := or.reapStaleOperations(or.ctx); err != nil {
+			log.L(or.ctx).Errorf("Operation reaper scan failed: %s", err)
+		}
+	}
+}
+
+// reapStaleOperations escalates operations that have been pending without an update for too long.
+// The first time a stale operation is found its plugin is re-queried, which resolves the operation
+// if the plugin simply missed delivering an update, and an operation_stale event is emitted.
+// If it is still pending on a later scan, once past the failure threshold, it is marked failed.
+// An operation being failed is tracked until the update has been applied and a scan no longer finds it,
+// so that it is not picked up again as a newly stale operation while the update is queued.
+func (or *orchestrator) reapStaleOperations(ctx context.Context) error {
+	staleAfter := config.GetDuration(coreconfig.OperationsReaperStaleAfter)
+	failAfter := config.GetDuration(coreconfig.OperationsReaperFailAfter)
+	now := time.Now()
+	staleBefore := fftypes.FFTime(now.Add(-staleAfter))
+
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("status", core.OpStatusPending),
+		fb.Lt("updated", &staleBefore),
+	).Sort("updated").Limit(uint64(config.GetInt(coreconfig.OperationsReaperBatchSize)))
+	ops, _, err := or.database().GetOperations(ctx, or.namespace.Name, filter)
+	if err != nil {
+		return err
+	}
+
+	pending := make(map[fftypes.UUID]bool, len(ops))
+	for _, op := range ops {
+		pending[*op.ID] = true
+		lastUpdate := op.Updated
+		if lastUpdate == nil {
+			lastUpdate = op.Created
+		}
+		pendingFor := now.Sub(*lastUpdate.Time())
+		switch or.getReaperOpState(op.ID) {
+		case 0:
+			or.setReaperOpState(op.ID, reaperOpRequeried)
+			or.requeryStaleOperation(ctx, op, pendingFor)
+		case reaperOpRequeried:
+			if pendingFor > failAfter {
+				// The state is set first, as the update can be applied before SubmitOperationUpdate returns
+				or.setReaperOpState(op.ID, reaperOpFailing)
+				or.failStaleOperation(ctx, op, pendingFor)
+			}
+		}
+	}
+	// Forget operations that have been resolved since they were found to be stale
+	or.reaperMux.Lock()
+	defer or.reaperMux.Unlock()
+	for id, state := range or.reaperStale {
+		if !pending[id] && state != reaperOpFailing {
+			delete(or.reaperStale, id)
+		}
+	}
+	return nil
+}
+
+func (or *orchestrator) getReaperOpState(id *fftypes.UUID) reaperOpState {
+	or.reaperMux.Lock()
+	defer or.reaperMux.Unlock()
+	return or.reaperStale[*id]
+}
+
+func (or *orchestrator) setReaperOpState(id *fftypes.UUID, state reaperOpState) {
+	or.reaperMux.Lock()
+	defer or.reaperMux.Unlock()
+	or.reaperStale[*id] = state
+}
+
+func (or *orchestrator) requeryStaleOperation(ctx context.Context, op *core.Operation, pendingFor time.Duration) {
+	log.L(ctx).Warnf("Operation %s of type %s has been pending for %s - re-querying plugin '%s'", op.ID, op.Type, pendingFor.Round(time.Second), op.Plugin)
+	if op.IsBlockchainOperation() || op.IsTokenOperation() {
+		// The blockchain plugin feeds any change in status it finds back through the normal receipt processing
+		if _, err := or.blockchain().GetTransactionStatus(ctx, op); err != nil {
+			log.L(ctx).Warnf("Failed to re-query status of operation %s: %s", op.ID, err)
+		}
+	}
+	or.emitOperationAlert(ctx, core.EventTypeOperationStale, op)
+}
+
+func (or *orchestrator) failStaleOperation(ctx context.Context, op *core.Operation, pendingFor time.Duration) {
+	reason := fmt.Sprintf("Operation timed out after %s pending without an update from plugin '%s'", pendingFor.Round(time.Second), op.Plugin)
+	log.L(ctx).Errorf("Operation %s of type %s: %s", op.ID, op.Type, reason)
+	// The failure goes through the normal update path, so automatic retries and other side effects apply
+	or.operations.SubmitOperationUpdate(&core.OperationUpdateAsync{
+		OperationUpdate: core.OperationUpdate{
+			NamespacedOpID: or.namespace.Name + ":" + op.ID.String(),
+			Plugin:         op.Plugin,
+			Status:         core.OpStatusFailed,
+			ErrorMessage:   reason,
+		},
+		OnComplete: func() {
+			or.setReaperOpState(op.ID, reaperOpFailed)
+			or.emitOperationAlert(or.ctx, core.EventTypeOperationTimedOut, op)
+		},
+	})
+}
+
+func (or *orchestrator) emitOperationAlert(ctx context.Context, eventType core.EventType, op *core.Operation) {
+	event := core.NewEvent(eventType, or.namespace.Name, op.ID, op.Transaction, core.SystemTopicOperations)
+	if err := or.database().InsertEvent(ctx, event); err != nil {
+		log.L(ctx).Errorf("Failed to record %s event for operation %s: %s", eventType, op.ID, err)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestStaleOp(age time.Duration) *core.Operation {
+	updated := fftypes.FFTime(time.Now().Add(-age))
+	return &core.Operation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns",
+		Transaction: fftypes.NewUUID(),
+		Type:        core.OpTypeBlockchainInvoke,
+		Plugin:      "ethereum",
+		Status:      core.OpStatusPending,
+		Created:     &updated,
+		Updated:     &updated,
+	}
+}
+
+func TestReapStaleOperationsEscalation(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.OperationsReaperStaleAfter, "10m")
+	config.Set(coreconfig.OperationsReaperFailAfter, "1h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.reaperStale = make(map[fftypes.UUID]reaperOpState)
+
+	recent := newTestStaleOp(20 * time.Minute)
+	old := newTestStaleOp(2 * time.Hour)
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return([]*core.Operation{recent, old}, nil, nil)
+	or.mbi.On("GetTransactionStatus", mock.Anything, recent).Return(nil, nil).Once()
+	or.mbi.On("GetTransactionStatus", mock.Anything, old).Return(nil, fmt.Errorf("pop")).Once()
+	or.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeOperationStale && e.Topic == core.SystemTopicOperations
+	})).Return(nil).Twice()
+
+	// First scan re-queries both operations, and alerts
+	err := or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	assert.Len(t, or.reaperStale, 2)
+
+	// Second scan fails the one past the failure threshold
+	or.mom.On("SubmitOperationUpdate", mock.MatchedBy(func(update *core.OperationUpdateAsync) bool {
+		return update.NamespacedOpID == "ns:"+old.ID.String() &&
+			update.Plugin == "ethereum" &&
+			update.Status == core.OpStatusFailed &&
+			update.ErrorMessage != ""
+	})).Run(func(args mock.Arguments) {
+		args[0].(*core.OperationUpdateAsync).OnComplete()
+	})
+	or.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeOperationTimedOut && e.Reference.Equals(old.ID) && e.Correlator.Equals(old.Transaction)
+	})).Return(nil).Once()
+	err = or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	assert.Len(t, or.reaperStale, 2)
+	assert.Equal(t, reaperOpRequeried, or.reaperStale[*recent.ID])
+	assert.Equal(t, reaperOpFailed, or.reaperStale[*old.ID])
+}
+
+func TestReapStaleOperationsFailingUntilApplied(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.OperationsReaperFailAfter, "1h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	op := newTestStaleOp(2 * time.Hour)
+	or.reaperStale = map[fftypes.UUID]reaperOpState{*op.ID: reaperOpRequeried}
+
+	// The update to fail the operation is queued, but not yet applied
+	var update *core.OperationUpdateAsync
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return([]*core.Operation{op}, nil, nil).Times(3)
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return([]*core.Operation{}, nil, nil).Once()
+	or.mom.On("SubmitOperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		update = args[0].(*core.OperationUpdateAsync)
+	}).Once()
+	err := or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, reaperOpFailing, or.reaperStale[*op.ID])
+
+	// A scan before the update is applied neither re-queries nor fails the operation again
+	err = or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, reaperOpFailing, or.reaperStale[*op.ID])
+
+	// Nor does a scan that still finds it pending after the update is applied
+	or.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeOperationTimedOut && e.Reference.Equals(op.ID)
+	})).Return(nil).Once()
+	update.OnComplete()
+	err = or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, reaperOpFailed, or.reaperStale[*op.ID])
+
+	// The operation is forgotten once a scan no longer finds it
+	err = or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, or.reaperStale)
+}
+
+func TestReapStaleOperationsResolved(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	op := newTestStaleOp(time.Hour)
+	or.reaperStale = map[fftypes.UUID]reaperOpState{*op.ID: reaperOpRequeried}
+
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return([]*core.Operation{}, nil, nil)
+
+	err := or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, or.reaperStale)
+}
+
+func TestReapStaleOperationsQueryFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.reaperStale = make(map[fftypes.UUID]reaperOpState)
+
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := or.reapStaleOperations(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReapStaleOperationsFailAndAlertErrors(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	op := newTestStaleOp(2 * time.Hour)
+	op.Type = core.OpTypeDataExchangeSendBatch
+	op.Updated = nil
+	or.reaperStale = make(map[fftypes.UUID]reaperOpState)
+
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return([]*core.Operation{op}, nil, nil)
+	or.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	or.mom.On("SubmitOperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		args[0].(*core.OperationUpdateAsync).OnComplete()
+	})
+
+	err := or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	err = or.reapStaleOperations(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, reaperOpFailed, or.reaperStale[*op.ID])
+}
+
+func TestOperationReaperLoop(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.OperationsReaperInterval, "1ms")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	scanned := make(chan struct{})
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		select {
+		case <-scanned:
+		default:
+			close(scanned)
+		}
+	})
+
+	or.startOperationReaper()
+	assert.NotNil(t, or.reaperDone)
+	<-scanned
+	or.cancelCtx()
+	<-or.reaperDone
+}
+
+func TestOperationReaperDisabled(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.OperationsReaperEnabled, false)
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.startOperationReaper()
+	assert.Nil(t, or.reaperDone)
+}
+
+func TestOperationReaperReadOnly(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.ReadOnly = true
+
+	or.startOperationReaper()
+	assert.Nil(t, or.reaperDone)
+}
@@ -18,14 +18,19 @@ package orchestrator
 
 import (
 	"context"
+	"net/http"
 	"sync"
 
 	"github.com/hyperledger/firefly-common/pkg/auth"
+	"github.com/hyperledger/firefly-common/pkg/auth/basic"
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/archive"
+	"github.com/hyperledger/firefly/internal/archivestore"
 	"github.com/hyperledger/firefly/internal/assets"
+	"github.com/hyperledger/firefly/internal/audit"
 	"github.com/hyperledger/firefly/internal/batch"
 	"github.com/hyperledger/firefly/internal/broadcast"
 	"github.com/hyperledger/firefly/internal/cache"
@@ -33,15 +38,24 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/definitions"
+	"github.com/hyperledger/firefly/internal/eventbridge"
 	"github.com/hyperledger/firefly/internal/events"
+	"github.com/hyperledger/firefly/internal/extensions"
+	"github.com/hyperledger/firefly/internal/faults"
 	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/loadgen"
 	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/multiparty"
 	"github.com/hyperledger/firefly/internal/networkmap"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
+	"github.com/hyperledger/firefly/internal/ratelimit"
+	"github.com/hyperledger/firefly/internal/search"
 	"github.com/hyperledger/firefly/internal/shareddownload"
+	"github.com/hyperledger/firefly/internal/slo"
 	"github.com/hyperledger/firefly/internal/syncasync"
+	"github.com/hyperledger/firefly/internal/traffic"
+	"github.com/hyperledger/firefly/internal/triggers"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/internal/txwriter"
 	"github.com/hyperledger/firefly/pkg/blockchain"
@@ -50,8 +64,11 @@ import (
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 	eventsplugin "github.com/hyperledger/firefly/pkg/events"
 	idplugin "github.com/hyperledger/firefly/pkg/identity"
+	"github.com/hyperledger/firefly/pkg/policy"
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
+	"github.com/hyperledger/firefly/pkg/signing"
 	"github.com/hyperledger/firefly/pkg/tokens"
+	"github.com/hyperledger/firefly/pkg/zkproof"
 )
 
 // Orchestrator is the main interface behind the API, implementing the actions
@@ -73,20 +90,57 @@ type Orchestrator interface {
 	NetworkMap() networkmap.Manager
 	Operations() operations.Manager
 	Identity() identity.Manager
+	Archive() archive.Manager
 
 	// Status
 	GetStatus(ctx context.Context) (*core.NamespaceStatus, error)
 	GetMultipartyStatus(ctx context.Context) (*core.NamespaceMultipartyStatus, error)
+	GetBootstrapStatus(ctx context.Context) (*core.NamespaceBootstrapStatus, error)
+	GetHealth(ctx context.Context) (*core.NamespaceHealth, error)
+	GetSLOStatus(ctx context.Context) ([]*core.SLOStatus, error)
+	GetListenerReconciliation(ctx context.Context) (*core.ListenerReconciliationReport, error)
+	GetCatchUpStatus(ctx context.Context) (*core.CatchUpStatus, error)
+
+	// Quotas
+	CheckQuota(ctx context.Context, quotaType core.QuotaType, requested int64) error
+	GetQuotaStatus(ctx context.Context) (*core.NamespaceQuotaStatus, error)
+	SetQuotaLimits(ctx context.Context, limits *core.NamespaceQuotas) (*core.NamespaceQuotaStatus, error)
+
+	// Fault injection
+	GetFaults(ctx context.Context) (*core.NamespaceFaults, error)
+	SetFaults(ctx context.Context, rules *core.NamespaceFaults) (*core.NamespaceFaults, error)
+
+	// Load generation
+	StartLoadTest(ctx context.Context, spec *core.LoadTestSpec) (*core.LoadTestStatus, error)
+	StopLoadTest(ctx context.Context) (*core.LoadTestStatus, error)
+	GetLoadTestStatus(ctx context.Context) (*core.LoadTestStatus, error)
+	GetDefinitionRecording(ctx context.Context, filter ffapi.AndFilter) ([]*core.DefinitionReplayRecord, *ffapi.FilterResult, error)
+	ReplayDefinitions(ctx context.Context, input *core.DefinitionReplayInput) (*core.DefinitionReplayReport, error)
+
+	// Rate limits
+	CheckRateLimit(ctx context.Context, group core.RateLimitGroup, identity string) (*core.RateLimitStatus, error)
+
+	// Read-only replicas
+	CheckWritable(ctx context.Context) error
 
 	// Subscription management
 	GetSubscriptions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error)
 	GetSubscriptionByID(ctx context.Context, id string) (*core.Subscription, error)
 	GetSubscriptionByIDWithStatus(ctx context.Context, id string) (*core.SubscriptionWithStatus, error)
 	GetSubscriptionEventsHistorical(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter, startSequence int, endSequence int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)
+	GetSubscriptionOffset(ctx context.Context, id string) (*core.SubscriptionOffset, error)
+	SetSubscriptionOffset(ctx context.Context, id string, offset *core.SubscriptionOffset) (*core.SubscriptionOffset, error)
+	PollSubscriptionEvents(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)
+	AckSubscriptionEvents(ctx context.Context, id string, ack *core.SubscriptionEventsAck) (*core.SubscriptionOffset, error)
 	CreateSubscription(ctx context.Context, subDef *core.Subscription) (*core.Subscription, error)
 	CreateUpdateSubscription(ctx context.Context, subDef *core.Subscription) (*core.Subscription, error)
 	DeleteSubscription(ctx context.Context, id string) error
 
+	// Idempotency key management
+	GetIdempotencyKeys(ctx context.Context, filter ffapi.AndFilter) ([]*core.IdempotencyKeyRecord, *ffapi.FilterResult, error)
+	GetIdempotencyKey(ctx context.Context, key string) (*core.IdempotencyKeyRecord, error)
+	ExpireIdempotencyKey(ctx context.Context, key string) error
+
 	// Data Query
 	GetNamespace(ctx context.Context) *core.Namespace
 	GetTransactionByID(ctx context.Context, id string) (*core.Transaction, error)
@@ -102,26 +156,58 @@ type Orchestrator interface {
 	GetMessageEvents(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error)
 	GetMessageData(ctx context.Context, id string) (core.DataArray, error)
 	GetMessagesForData(ctx context.Context, dataID string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)
+	GetMessagesForDataHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)
+	GetMessagesForBlobHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)
 	GetBatchByID(ctx context.Context, id string) (*core.BatchPersisted, error)
+	GetBatchAcks(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.BatchAck, *ffapi.FilterResult, error)
 	GetBatches(ctx context.Context, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)
+	GetBatchesForDataHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)
+	GetBatchesForBlobHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)
+	VerifyBatchPin(ctx context.Context, id string) (*core.BatchPinVerification, error)
+	VerifyMessageBatchPin(ctx context.Context, id string) (*core.BatchPinVerification, error)
 	GetDataByID(ctx context.Context, id string) (*core.Data, error)
 	GetData(ctx context.Context, filter ffapi.AndFilter) (core.DataArray, *ffapi.FilterResult, error)
 	GetDataSubPaths(ctx context.Context, path string) ([]string, error)
 	GetDatatypeByID(ctx context.Context, id string) (*core.Datatype, error)
 	GetDatatypeByName(ctx context.Context, name, version string) (*core.Datatype, error)
 	GetDatatypes(ctx context.Context, filter ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error)
+	GetDisclosureByID(ctx context.Context, id string) (*core.Disclosure, error)
+	GetDisclosures(ctx context.Context, filter ffapi.AndFilter) ([]*core.Disclosure, *ffapi.FilterResult, error)
 	GetOperationByID(ctx context.Context, id string) (*core.Operation, error)
 	GetOperationByIDWithStatus(ctx context.Context, id string) (*core.OperationWithDetail, error)
+	GetOperationHistory(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.OperationHistoryEntry, *ffapi.FilterResult, error)
+	GetOperationApprovalByID(ctx context.Context, id string) (*core.OperationApproval, error)
+	GetOperationApprovals(ctx context.Context, filter ffapi.AndFilter) ([]*core.OperationApproval, *ffapi.FilterResult, error)
+	GetOperationFees(ctx context.Context, filter ffapi.AndFilter) ([]*core.OperationFee, *ffapi.FilterResult, error)
+	GetFeeSummary(ctx context.Context, filter ffapi.AndFilter) ([]*core.FeeSummary, error)
+	EstimateRetention(ctx context.Context) (*core.RetentionEstimate, error)
+	GetOnlineMigrations(ctx context.Context) ([]*core.OnlineMigration, error)
+	RunOnlineMigration(ctx context.Context, name string) (*core.OnlineMigration, error)
+	GetRebuildStatus(ctx context.Context) []*core.RebuildStatus
+	RebuildDerivedState(ctx context.Context, target core.RebuildTarget) (*core.RebuildStatus, error)
+	SearchMessages(ctx context.Context, query string, limit int) ([]*core.SearchResult, error)
+	QueryGraphQL(ctx context.Context, req *core.GraphQLRequest) *core.GraphQLResponse
+	AuditAPIRequest(ctx context.Context, record *core.AuditRecord) error
+	GetAuditRecords(ctx context.Context, filter ffapi.AndFilter) ([]*core.AuditRecord, *ffapi.FilterResult, error)
+	VerifyAuditLog(ctx context.Context) (*core.AuditVerification, error)
+	GetAuditAnchors(ctx context.Context, filter ffapi.AndFilter) ([]*core.AuditAnchor, *ffapi.FilterResult, error)
+	VerifyAuditAnchor(ctx context.Context, id string) (*core.AuditAnchorVerification, error)
+	GetAuditAnchorProof(ctx context.Context, id, eventID string) (*core.AuditAnchorProof, error)
 	GetOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)
 	GetEventByID(ctx context.Context, id string) (*core.Event, error)
 	GetEventByIDWithReference(ctx context.Context, id string) (*core.EnrichedEvent, error)
 	GetEvents(ctx context.Context, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error)
 	GetEventsWithReferences(ctx context.Context, filter ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)
+	GetActivity(ctx context.Context, categories []string, filter ffapi.AndFilter) ([]*core.ActivityItem, *ffapi.FilterResult, error)
+	GetTrafficMatrix(ctx context.Context, startTime, endTime *fftypes.FFTime) (*core.TrafficMatrix, error)
+	GetLedgerTransactions(ctx context.Context, filter ffapi.AndFilter) ([]*core.LedgerTransaction, *ffapi.FilterResult, error)
+	GetLedgerBalances(ctx context.Context, key string, filter ffapi.AndFilter) ([]*core.LedgerBalance, *ffapi.FilterResult, error)
 	GetBlockchainEventByID(ctx context.Context, id string) (*core.BlockchainEvent, error)
 	GetBlockchainEvents(ctx context.Context, filter ffapi.AndFilter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error)
 	GetPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.Pin, *ffapi.FilterResult, error)
 	GetNextPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.NextPin, *ffapi.FilterResult, error)
 	RewindPins(ctx context.Context, rewind *core.PinRewind) (*core.PinRewind, error)
+	ReleaseMessage(ctx context.Context, id string) (*core.Message, error)
 
 	// Charts
 	GetChartHistogram(ctx context.Context, startTime int64, endTime int64, buckets int64, tableName database.CollectionName) ([]*core.ChartHistogram, error)
@@ -131,9 +217,13 @@ type Orchestrator interface {
 
 	// Network Operations
 	SubmitNetworkAction(ctx context.Context, action *core.NetworkAction) error
+	GetScheduledNetworkActions(ctx context.Context) ([]*core.ScheduledNetworkAction, error)
 
 	// Authorizer
 	Authorize(ctx context.Context, authReq *fftypes.AuthReq) error
+
+	// Policy decision point
+	CheckPolicy(ctx context.Context, req *policy.Request) error
 }
 
 type BlockchainPlugin struct {
@@ -157,8 +247,9 @@ type SharedStoragePlugin struct {
 }
 
 type TokensPlugin struct {
-	Name   string
-	Plugin tokens.Plugin
+	Name    string
+	Plugin  tokens.Plugin
+	Startup StartupPolicy
 }
 
 type IdentityPlugin struct {
@@ -171,6 +262,21 @@ type AuthPlugin struct {
 	Plugin auth.Plugin
 }
 
+type SigningPlugin struct {
+	Name   string
+	Plugin signing.Plugin
+}
+
+type ZKProofPlugin struct {
+	Name   string
+	Plugin zkproof.Plugin
+}
+
+type PolicyPlugin struct {
+	Name   string
+	Plugin policy.Plugin
+}
+
 type Plugins struct {
 	Blockchain    BlockchainPlugin
 	Identity      IdentityPlugin
@@ -180,6 +286,9 @@ type Plugins struct {
 	Tokens        []TokensPlugin
 	Events        map[string]eventsplugin.Plugin
 	Auth          AuthPlugin
+	Signing       SigningPlugin
+	ZKProof       ZKProofPlugin
+	Policy        PolicyPlugin
 }
 
 type Config struct {
@@ -188,6 +297,13 @@ type Config struct {
 	Multiparty                  multiparty.Config
 	TokenBroadcastNames         map[string]string
 	MaxHistoricalEventScanLimit int
+	DefinitionApprovals         definitions.ApprovalPolicy
+	PayloadSigning              identity.PayloadSigningConfig
+	Quotas                      core.NamespaceQuotas
+	RateLimits                  map[core.RateLimitGroup]core.RateLimit
+	ReadOnly                    bool
+	Sandbox                     bool
+	RequiredConfirmations       int
 }
 
 type orchestrator struct {
@@ -196,6 +312,7 @@ type orchestrator struct {
 	started                 bool
 	startedBlockchainPlugin bool
 	startedLock             sync.Mutex
+	quotaLock               sync.Mutex
 	namespace               *core.Namespace
 	config                  Config
 	plugins                 *Plugins
@@ -214,11 +331,41 @@ type orchestrator struct {
 	assets                  assets.Manager
 	bc                      boundCallbacks
 	contracts               contracts.Manager
+	reconciler              contracts.Reconciler
 	metrics                 metrics.Manager
 	cacheManager            cache.Manager
 	operations              operations.Manager
 	txHelper                txcommon.Helper
 	txWriter                txwriter.Writer
+	archive                 archive.Manager
+	archiver                archivestore.Archiver
+	search                  search.Indexer
+	traffic                 traffic.Aggregator
+	eventBridge             eventbridge.Manager
+	triggers                triggers.Manager
+	extensions              extensions.Manager
+	slo                     slo.Monitor
+	audit                   audit.Logger
+	anchorer                audit.Anchorer
+	faults                  faults.Injector
+	loadgen                 loadgen.Generator
+	rateLimiter             *ratelimit.Limiter
+	bootstrapDone           chan struct{}
+	healthMux               sync.Mutex
+	healthProbes            []*dependencyProbe
+	healthDone              chan struct{}
+	reaperDone              chan struct{}
+	reaperMux               sync.Mutex
+	reaperStale             map[fftypes.UUID]reaperOpState
+	disclosureVerifierDone  chan struct{}
+	idempotencyExpiryDone   chan struct{}
+	retentionDone           chan struct{}
+	onlineMigrationMux      sync.Mutex
+	onlineMigrationRuns     map[string]*onlineMigrationRun
+	rebuildMux              sync.Mutex
+	rebuildRuns             map[core.RebuildTarget]*rebuildRun
+	dependencyMux           sync.Mutex
+	dependencies            []*core.NamespaceStatusDependency
 }
 
 func NewOrchestrator(ns *core.Namespace, config Config, plugins *Plugins, metrics metrics.Manager, cacheManager cache.Manager) Orchestrator {
@@ -228,6 +375,7 @@ func NewOrchestrator(ns *core.Namespace, config Config, plugins *Plugins, metric
 		plugins:      plugins,
 		metrics:      metrics,
 		cacheManager: cacheManager,
+		rateLimiter:  ratelimit.NewLimiter(config.RateLimits),
 	}
 	or.bc.o = or
 	return or
@@ -247,6 +395,9 @@ func (or *orchestrator) Init() (err error) {
 	if err == nil {
 		err = or.initMultiParty(or.ctx)
 	}
+	if err == nil {
+		err = or.loadQuotaLimits(or.ctx)
+	}
 	return err
 }
 
@@ -279,6 +430,21 @@ func (or *orchestrator) tokens() map[string]tokens.Plugin {
 	return result
 }
 
+func (or *orchestrator) registerOperationOutputSchemas(ctx context.Context) error {
+	plugins := []interface{}{or.blockchain(), or.dataexchange(), or.sharedstorage()}
+	for _, t := range or.plugins.Tokens {
+		plugins = append(plugins, t.Plugin)
+	}
+	for _, p := range plugins {
+		if provider, ok := p.(core.OperationOutputSchemaProvider); ok {
+			if err := or.operations.RegisterOutputSchemas(ctx, provider); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (or *orchestrator) Start() (err error) {
 	or.data.Start()
 	if or.config.Multiparty.Enabled {
@@ -289,6 +455,9 @@ func (or *orchestrator) Start() (err error) {
 		if err == nil {
 			err = or.sharedDownload.Start()
 		}
+		if err == nil {
+			err = or.messaging.Start()
+		}
 	}
 	if err == nil {
 		err = or.events.Start()
@@ -300,7 +469,7 @@ func (or *orchestrator) Start() (err error) {
 		or.txWriter.Start()
 	}
 	if err == nil {
-		err = or.assets.Start()
+		err = or.startTokenConnectors()
 	}
 
 	or.started = true
@@ -310,14 +479,125 @@ func (or *orchestrator) Start() (err error) {
 		if err != nil {
 			log.L(or.ctx).Errorf("Error checking node identity status: %s", err.Error())
 		}
+		if err == nil && or.config.Multiparty.Bootstrap.Enabled && !or.config.ReadOnly {
+			or.bootstrapDone = make(chan struct{})
+			go or.bootstrapLoop()
+		}
+	}
+	if err == nil {
+		or.startHealthLoop()
+		or.startOperationReaper()
+		or.startDisclosureVerifier()
+		or.startIdempotencyKeyExpiry()
+		or.startRetention()
+		or.archive.Start()
+		if or.search != nil {
+			or.search.Start()
+		}
+		if or.traffic != nil {
+			or.traffic.Start()
+		}
+		or.startEventBridge()
+		or.startTriggers()
+		if or.slo != nil {
+			or.slo.Start()
+		}
+		or.startAnchorer()
+		if or.reconciler != nil {
+			or.reconciler.Start()
+		}
 	}
 	return err
 }
 
+// startEventBridge starts bridging events into messages, unless the namespace is a read-only replica that never sends messages
+func (or *orchestrator) startEventBridge() {
+	if or.eventBridge == nil || or.config.ReadOnly {
+		return
+	}
+	or.eventBridge.Start()
+}
+
+// startTriggers starts invoking contracts and transferring tokens from messages, unless the namespace is a read-only replica
+func (or *orchestrator) startTriggers() {
+	if or.triggers == nil || or.config.ReadOnly {
+		return
+	}
+	or.triggers.Start()
+}
+
+// startAnchorer starts pinning audit anchors, unless the namespace is a read-only replica. Anchors that
+// were pinned by a writable node can still be verified on a replica.
+func (or *orchestrator) startAnchorer() {
+	if or.anchorer == nil || or.config.ReadOnly {
+		return
+	}
+	or.anchorer.Start()
+}
+
+func (or *orchestrator) bootstrapLoop() {
+	defer close(or.bootstrapDone)
+	if err := or.networkmap.Bootstrap(or.ctx, &or.config.Multiparty.Bootstrap.Retry); err != nil {
+		log.L(or.ctx).Warnf("Bootstrap exiting: %s", err)
+	}
+}
+
 func (or *orchestrator) WaitStop() {
 	if !or.started {
 		return
 	}
+	if or.bootstrapDone != nil {
+		<-or.bootstrapDone
+		or.bootstrapDone = nil
+	}
+	if or.healthDone != nil {
+		<-or.healthDone
+		or.healthDone = nil
+	}
+	if or.reaperDone != nil {
+		<-or.reaperDone
+		or.reaperDone = nil
+	}
+	if or.disclosureVerifierDone != nil {
+		<-or.disclosureVerifierDone
+		or.disclosureVerifierDone = nil
+	}
+	if or.idempotencyExpiryDone != nil {
+		<-or.idempotencyExpiryDone
+		or.idempotencyExpiryDone = nil
+	}
+	if or.retentionDone != nil {
+		<-or.retentionDone
+		or.retentionDone = nil
+	}
+	if or.search != nil {
+		or.search.WaitStop()
+	}
+	if or.traffic != nil {
+		or.traffic.WaitStop()
+	}
+	if or.eventBridge != nil {
+		or.eventBridge.WaitStop()
+	}
+	if or.triggers != nil {
+		or.triggers.WaitStop()
+	}
+	if or.slo != nil {
+		or.slo.WaitStop()
+	}
+	if or.anchorer != nil {
+		or.anchorer.WaitStop()
+	}
+	if or.reconciler != nil {
+		or.reconciler.WaitStop()
+	}
+	if or.loadgen != nil {
+		or.loadgen.WaitStop()
+	}
+	if or.extensions != nil {
+		or.extensions.Close(or.ctx)
+		or.extensions = nil
+	}
 	err := or.plugins.Blockchain.Plugin.StopNamespace(or.ctx, or.namespace.Name)
 	if err != nil {
 		log.L(or.ctx).Errorf("Error purging namespace '%s' from blockchain plugin '%s': %s", or.namespace.Name, or.plugins.Blockchain.Name, err.Error())
@@ -344,6 +624,10 @@ func (or *orchestrator) WaitStop() {
 		or.sharedDownload.WaitStop()
 		or.sharedDownload = nil
 	}
+	if or.messaging != nil {
+		or.messaging.WaitStop()
+		or.messaging = nil
+	}
 	if or.events != nil {
 		or.events.WaitStop()
 		or.events = nil
@@ -414,6 +698,29 @@ func (or *orchestrator) Identity() identity.Manager {
 	return or.identity
 }
 
+func (or *orchestrator) Archive() archive.Manager {
+	return or.archive
+}
+
+// CheckWritable returns an error if the namespace is a read-only replica, so no submissions are allowed
+func (or *orchestrator) CheckWritable(ctx context.Context) error {
+	if or.config.ReadOnly {
+		return i18n.NewError(ctx, coremsgs.MsgNamespaceReadOnly, or.namespace.Name)
+	}
+	return nil
+}
+
+// validateNewMessage runs on every new message sent from this node, whichever component sends it
+func (or *orchestrator) validateNewMessage(ctx context.Context, msg *core.Message, data core.DataArray) error {
+	if err := or.CheckWritable(ctx); err != nil {
+		return err
+	}
+	if or.extensions != nil {
+		return or.extensions.ValidateMessage(ctx, msg, data)
+	}
+	return nil
+}
+
 func (or *orchestrator) initHandlers(ctx context.Context) {
 	// Update all the handlers to point to this instance of the orchestrator
 	setHandlers(ctx, or.plugins, or.namespace, or.config.Multiparty.Node.Name, or, &or.bc)
@@ -491,7 +798,10 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 	}
 
 	if or.operations == nil {
-		if or.operations, err = operations.NewOperationsManager(ctx, or.namespace.Name, or.database(), or.txHelper, or.cacheManager); err != nil {
+		if or.operations, err = operations.NewOperationsManager(ctx, or.namespace.Name, or.database(), or.txHelper, or.metrics, or.cacheManager, or.config.ReadOnly); err != nil {
+			return err
+		}
+		if err = or.registerOperationOutputSchemas(ctx); err != nil {
 			return err
 		}
 	}
@@ -513,7 +823,7 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 	}
 
 	if or.identity == nil {
-		or.identity, err = identity.NewIdentityManager(ctx, or.namespace.Name, or.config.DefaultKey, or.database(), or.blockchain(), or.multiparty, or.cacheManager)
+		or.identity, err = identity.NewIdentityManager(ctx, or.namespace.Name, or.config.DefaultKey, or.database(), or.blockchain(), or.plugins.Signing.Plugin, or.multiparty, or.cacheManager, or.config.PayloadSigning)
 		if err != nil {
 			return err
 		}
@@ -535,7 +845,7 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 		}
 
 		if or.sharedDownload == nil {
-			or.sharedDownload, err = shareddownload.NewDownloadManager(ctx, or.namespace, or.database(), or.sharedstorage(), or.dataexchange(), or.operations, &or.bc)
+			or.sharedDownload, err = shareddownload.NewDownloadManager(ctx, or.namespace, or.database(), or.blockchain(), or.sharedstorage(), or.dataexchange(), or.operations, &or.bc)
 			if err != nil {
 				return err
 			}
@@ -549,6 +859,11 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 				return err
 			}
 		}
+		if or.reconciler == nil {
+			if or.reconciler, err = contracts.NewReconciler(ctx, or.namespace.Name, or.database(), or.blockchain()); err != nil {
+				return err
+			}
+		}
 	}
 
 	if or.assets == nil {
@@ -559,7 +874,7 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 	}
 
 	if or.defsender == nil {
-		or.defsender, or.defhandler, err = definitions.NewDefinitionSender(ctx, or.namespace, or.config.Multiparty.Enabled, or.database(), or.blockchain(), or.dataexchange(), or.broadcast, or.identity, or.data, or.assets, or.contracts, or.config.TokenBroadcastNames)
+		or.defsender, or.defhandler, err = definitions.NewDefinitionSender(ctx, or.namespace, or.config.Multiparty.Enabled, or.database(), or.blockchain(), or.dataexchange(), or.broadcast, or.identity, or.data, or.assets, or.contracts, or.config.TokenBroadcastNames, &or.config.DefinitionApprovals, or.multiparty, or.plugins.ZKProof.Plugin)
 		if err != nil {
 			return err
 		}
@@ -572,10 +887,72 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 		}
 	}
 
+	if or.archive == nil {
+		if or.archive, err = archive.NewArchiveManager(ctx, or.namespace.Name, or.database(), or.identity); err != nil {
+			return err
+		}
+	}
+
+	if or.archiver == nil {
+		if or.archiver, err = archivestore.NewArchiver(ctx, or.namespace.Name, or.database()); err != nil {
+			return err
+		}
+	}
+
+	if or.search == nil {
+		if or.search, err = search.NewIndexer(ctx, or.namespace.Name, or.database()); err != nil {
+			return err
+		}
+	}
+
+	if or.traffic == nil {
+		if or.traffic, err = traffic.NewAggregator(ctx, or.namespace.Name, or.database(), or.identity); err != nil {
+			return err
+		}
+	}
+
+	if or.eventBridge == nil {
+		if or.eventBridge, err = eventbridge.NewEventBridgeManager(ctx, or.namespace.Name, or.database(), or.broadcast, or.messaging); err != nil {
+			return err
+		}
+	}
+
+	if or.triggers == nil {
+		if or.triggers, err = triggers.NewTriggerManager(ctx, or.namespace.Name, or.database(), or.contracts, or.assets); err != nil {
+			return err
+		}
+	}
+
+	if or.slo == nil {
+		if or.slo, err = slo.NewMonitor(ctx, or.namespace.Name, or.database()); err != nil {
+			return err
+		}
+	}
+
+	if or.audit == nil {
+		if or.audit, err = audit.NewLogger(ctx, or.namespace.Name, or.database()); err != nil {
+			return err
+		}
+	}
+
+	if or.anchorer == nil {
+		if or.anchorer, err = audit.NewAnchorer(ctx, or.namespace.Name, or.database(), or.multiparty, or.identity); err != nil {
+			return err
+		}
+	}
+
+	if or.config.Sandbox && or.loadgen == nil {
+		or.loadgen = loadgen.NewGenerator(ctx, or.namespace.Name, or.broadcast, or.messaging, or.assets)
+	}
+
 	return nil
 }
 
 func (or *orchestrator) initComponents(ctx context.Context) (err error) {
+	if or.faults == nil {
+		or.initFaults(ctx)
+	}
+
 	// The blockchain plugin doesn't return a manager or struct that we can check for
 	// nil like all the other mangagers to see if it has been initialised before!
 	// So we have a boolean to check so that when the retry wrapper initialises these components
@@ -588,11 +965,20 @@ func (or *orchestrator) initComponents(ctx context.Context) (err error) {
 		or.startedBlockchainPlugin = true
 	}
 
+	if or.extensions == nil {
+		if or.extensions, err = extensions.NewExtensionManager(ctx, or.namespace.Name); err != nil {
+			return err
+		}
+	}
+
 	if or.data == nil {
 		or.data, err = data.NewDataManager(ctx, or.namespace, or.database(), or.dataexchange(), or.cacheManager)
 		if err != nil {
 			return err
 		}
+		if or.extensions != nil || or.config.ReadOnly {
+			or.data.SetMessageValidator(or.validateNewMessage)
+		}
 	}
 
 	if err := or.initManagers(ctx); err != nil {
@@ -604,6 +990,10 @@ func (or *orchestrator) initComponents(ctx context.Context) (err error) {
 		if err != nil {
 			return err
 		}
+		or.events.SetRequiredConfirmations(or.config.RequiredConfirmations)
+		if or.extensions != nil {
+			or.events.SetEventTransformer(or.extensions.TransformEvent)
+		}
 	}
 
 	or.syncasync.Init(or.events)
@@ -622,10 +1012,47 @@ func (or *orchestrator) SubmitNetworkAction(ctx context.Context, action *core.Ne
 	return or.multiparty.SubmitNetworkAction(ctx, key, action, false /* network actions do not support idempotency keys currently */)
 }
 
+func (or *orchestrator) GetScheduledNetworkActions(ctx context.Context) ([]*core.ScheduledNetworkAction, error) {
+	if or.multiparty == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	return or.multiparty.GetScheduledNetworkActions(), nil
+}
+
+// Authorize checks the request with the auth plugin of the namespace, if there is one. Where the context has a
+// holder from core.WithAuthPrincipal, the principal the plugin authenticated the caller as is recorded in it.
 func (or *orchestrator) Authorize(ctx context.Context, authReq *fftypes.AuthReq) error {
 	authReq.Namespace = or.namespace.Name
-	if or.plugins.Auth.Plugin != nil {
-		return or.plugins.Auth.Plugin.Authorize(ctx, authReq)
+	if or.plugins.Auth.Plugin == nil {
+		return nil
+	}
+	if err := or.plugins.Auth.Plugin.Authorize(ctx, authReq); err != nil {
+		return err
+	}
+	// The basic auth plugin only authorizes a request once it has checked the password of the user
+	if core.GetAuthPrincipal(ctx) == "" && or.plugins.Auth.Plugin.Name() == basic.Name() {
+		if user, _, ok := (&http.Request{Header: authReq.Header}).BasicAuth(); ok {
+			core.SetAuthPrincipal(ctx, user)
+		}
+	}
+	return nil
+}
+
+// CheckPolicy asks the policy plugin, and any policy extensions, of the namespace whether a mutating API call is allowed
+func (or *orchestrator) CheckPolicy(ctx context.Context, req *policy.Request) error {
+	req.Namespace = or.namespace.Name
+	if or.plugins.Policy.Plugin != nil {
+		decision, err := or.plugins.Policy.Plugin.Decide(ctx, req)
+		if err != nil {
+			return err
+		}
+		if !decision.Allow {
+			log.L(ctx).Infof("Policy denied %s %s: %s", req.Method, req.Path, decision.Reason)
+			return i18n.NewError(ctx, coremsgs.MsgPolicyDenied, decision.Reason)
+		}
+	}
+	if or.extensions != nil {
+		return or.extensions.CheckPolicy(ctx, req)
 	}
 	return nil
 }
@@ -645,3 +1072,12 @@ func (or *orchestrator) RewindPins(ctx context.Context, rewind *core.PinRewind)
 	or.events.QueueBatchRewind(rewind.Batch)
 	return rewind, nil
 }
+
+// ReleaseMessage delivers a message held by the quarantine policy to subscriptions
+func (or *orchestrator) ReleaseMessage(ctx context.Context, id string) (*core.Message, error) {
+	msgID, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return or.events.ReleaseMessage(ctx, msgID)
+}
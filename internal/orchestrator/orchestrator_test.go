@@ -20,14 +20,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/hyperledger/firefly-common/mocks/authmocks"
+	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/extensions"
 	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/mocks/archivemocks"
 	"github.com/hyperledger/firefly/mocks/assetmocks"
 	"github.com/hyperledger/firefly/mocks/batchmocks"
 	"github.com/hyperledger/firefly/mocks/blockchainmocks"
@@ -39,12 +44,14 @@ import (
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/definitionsmocks"
 	"github.com/hyperledger/firefly/mocks/eventmocks"
+	"github.com/hyperledger/firefly/mocks/extensionsmocks"
 	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
 	"github.com/hyperledger/firefly/mocks/identitymocks"
 	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/multipartymocks"
 	"github.com/hyperledger/firefly/mocks/networkmapmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
+	"github.com/hyperledger/firefly/mocks/policymocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/mocks/shareddownloadmocks"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
@@ -55,6 +62,7 @@ import (
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/events"
+	"github.com/hyperledger/firefly/pkg/policy"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -89,6 +97,7 @@ type testOrchestrator struct {
 	mmp *multipartymocks.Manager
 	mds *definitionsmocks.Sender
 	mtw *txwritermocks.Writer
+	mar *archivemocks.Manager
 }
 
 func (tor *testOrchestrator) cleanup(t *testing.T) {
@@ -115,6 +124,7 @@ func (tor *testOrchestrator) cleanup(t *testing.T) {
 	tor.mae.AssertExpectations(t)
 	tor.mdh.AssertExpectations(t)
 	tor.mmp.AssertExpectations(t)
+	tor.cancelCtx()
 }
 
 func newTestOrchestrator() *testOrchestrator {
@@ -150,6 +160,7 @@ func newTestOrchestrator() *testOrchestrator {
 		mmp: &multipartymocks.Manager{},
 		mds: &definitionsmocks.Sender{},
 		mtw: &txwritermocks.Writer{},
+		mar: &archivemocks.Manager{},
 	}
 	tor.orchestrator.multiparty = tor.mmp
 	tor.orchestrator.data = tor.mdm
@@ -169,6 +180,7 @@ func newTestOrchestrator() *testOrchestrator {
 	tor.orchestrator.txWriter = tor.mtw
 	tor.orchestrator.defhandler = tor.mdh
 	tor.orchestrator.defsender = tor.mds
+	tor.orchestrator.archive = tor.mar
 	tor.orchestrator.config.Multiparty.Enabled = true
 	tor.orchestrator.config.MaxHistoricalEventScanLimit = 1000
 	tor.orchestrator.plugins = &Plugins{
@@ -239,9 +251,11 @@ func TestInitOK(t *testing.T) {
 	or.mti.On("SetHandler", "ns", mock.Anything).Return(nil)
 	or.mti.On("SetOperationHandler", "ns", mock.Anything).Return()
 	or.mmp.On("ConfigureContract", mock.Anything, mock.Anything).Return(nil)
+	or.mdi.On("GetNamespaceQuotas", mock.Anything, "ns").Return(&core.NamespaceQuotas{Subscriptions: 5}, nil)
 	or.PreInit(or.ctx, or.cancelCtx)
 	err := or.Init()
 	assert.NoError(t, err)
+	assert.Equal(t, int64(5), or.getQuotaLimits().Subscriptions)
 
 	assert.Equal(t, or.mba, or.BatchManager())
 	assert.Equal(t, or.mbm, or.Broadcast())
@@ -255,6 +269,7 @@ func TestInitOK(t *testing.T) {
 	assert.Equal(t, or.mnm, or.NetworkMap())
 	assert.Equal(t, or.mmp, or.MultiParty())
 	assert.Equal(t, or.identity, or.Identity())
+	assert.Equal(t, or.mar, or.Archive())
 }
 
 func TestCacheInitFail(t *testing.T) {
@@ -336,6 +351,17 @@ func TestInitNetworkMapComponentFail(t *testing.T) {
 	assert.Regexp(t, "FF10128", err)
 }
 
+func TestInitArchiveComponentFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.plugins.Database.Plugin = nil
+	or.archive = nil
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	or.mmp.On("ConfigureContract", mock.Anything, mock.Anything).Return(nil)
+	err := or.initComponents(context.Background())
+	assert.Regexp(t, "FF10128", err)
+}
+
 func TestInitMultipartyComponentFail(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -408,6 +434,18 @@ func TestInitDataComponentFail(t *testing.T) {
 	assert.Regexp(t, "FF10128", err)
 }
 
+func TestInitExtensionsComponentFail(t *testing.T) {
+	coreconfig.Reset()
+	extensions.InitConfig()
+	config.Set("extensions", []fftypes.JSONObject{{"name": "ext1", "namespace": "ns"}})
+	defer coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	err := or.initComponents(context.Background())
+	assert.Regexp(t, "FF10138", err)
+}
+
 func TestInitIdentityComponentFail(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -452,6 +490,36 @@ func TestInitOperationsComponentFail(t *testing.T) {
 	assert.Regexp(t, "FF10128", err)
 }
 
+type schemaTokensPlugin struct {
+	*tokenmocks.Plugin
+}
+
+func (p *schemaTokensPlugin) OperationOutputSchemas() map[core.OpType]*fftypes.JSONAny {
+	return map[core.OpType]*fftypes.JSONAny{
+		core.OpTypeTokenTransfer: fftypes.JSONAnyPtr(`{"type": "object"}`),
+	}
+}
+
+func TestRegisterOperationOutputSchemas(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	tp := &schemaTokensPlugin{Plugin: or.mti}
+	or.plugins.Tokens[0].Plugin = tp
+	or.mom.On("RegisterOutputSchemas", mock.Anything, tp).Return(nil)
+	err := or.registerOperationOutputSchemas(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRegisterOperationOutputSchemasFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	tp := &schemaTokensPlugin{Plugin: or.mti}
+	or.plugins.Tokens[0].Plugin = tp
+	or.mom.On("RegisterOutputSchemas", mock.Anything, tp).Return(fmt.Errorf("pop"))
+	err := or.registerOperationOutputSchemas(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
 func TestStartBatchFail(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -462,6 +530,127 @@ func TestStartBatchFail(t *testing.T) {
 	assert.EqualError(t, err, "pop")
 }
 
+func TestStartStopBootstrap(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Multiparty.Bootstrap.Enabled = true
+	or.mdm.On("Start").Return(nil)
+	or.mba.On("Start").Return(nil)
+	or.mem.On("Start").Return(nil)
+	or.mbm.On("Start").Return(nil)
+	or.msd.On("Start").Return(nil)
+	or.mpm.On("Start").Return(nil)
+	or.mnm.On("CheckNodeIdentityStatus", or.ctx).Return(nil)
+	or.mnm.On("Bootstrap", or.ctx, &or.config.Multiparty.Bootstrap.Retry).Return(fmt.Errorf("context cancelled"))
+	or.mom.On("Start").Return(nil)
+	or.mtw.On("Start").Return()
+	or.mar.On("Start").Return()
+	or.mam.On("StartConnector", "token").Return(nil)
+	or.mba.On("WaitStop").Return(nil)
+	or.mbm.On("WaitStop").Return(nil)
+	or.mdm.On("WaitStop").Return(nil)
+	or.msd.On("WaitStop").Return(nil)
+	or.mpm.On("WaitStop").Return(nil)
+	or.mom.On("WaitStop").Return(nil)
+	or.mem.On("WaitStop").Return(nil)
+	or.mtw.On("Close").Return(nil)
+	or.mbi.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	or.mti.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	err := or.Start()
+	assert.NoError(t, err)
+	or.cancelCtx()
+	or.WaitStop()
+	assert.Nil(t, or.bootstrapDone)
+}
+
+func TestStartReadOnlySkipsBootstrap(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Multiparty.Bootstrap.Enabled = true
+	or.config.ReadOnly = true
+	or.mdm.On("Start").Return(nil)
+	or.mba.On("Start").Return(nil)
+	or.mem.On("Start").Return(nil)
+	or.mbm.On("Start").Return(nil)
+	or.msd.On("Start").Return(nil)
+	or.mpm.On("Start").Return(nil)
+	or.mnm.On("CheckNodeIdentityStatus", or.ctx).Return(nil)
+	or.mom.On("Start").Return(nil)
+	or.mtw.On("Start").Return()
+	or.mar.On("Start").Return()
+	or.mam.On("StartConnector", "token").Return(nil)
+	err := or.Start()
+	assert.NoError(t, err)
+	assert.Nil(t, or.bootstrapDone)
+	or.mnm.AssertNotCalled(t, "Bootstrap", mock.Anything, mock.Anything)
+}
+
+func TestCheckWritable(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	assert.NoError(t, or.CheckWritable(or.ctx))
+	or.config.ReadOnly = true
+	assert.Regexp(t, "FF10497.*ns", or.CheckWritable(or.ctx))
+}
+
+func TestValidateNewMessage(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msg := &core.Message{}
+	assert.NoError(t, or.validateNewMessage(or.ctx, msg, core.DataArray{}))
+
+	mex := &extensionsmocks.Manager{}
+	mex.On("ValidateMessage", mock.Anything, msg, core.DataArray{}).Return(fmt.Errorf("pop"))
+	or.extensions = mex
+	assert.Regexp(t, "pop", or.validateNewMessage(or.ctx, msg, core.DataArray{}))
+
+	or.config.ReadOnly = true
+	assert.Regexp(t, "FF10497.*ns", or.validateNewMessage(or.ctx, msg, core.DataArray{}))
+	mex.AssertExpectations(t)
+}
+
+type testBackgroundManager struct {
+	started bool
+}
+
+func (m *testBackgroundManager) Start() {
+	m.started = true
+}
+
+func (m *testBackgroundManager) WaitStop() {}
+
+func TestStartEventBridge(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.startEventBridge()
+
+	bridge := &testBackgroundManager{}
+	or.eventBridge = bridge
+	or.config.ReadOnly = true
+	or.startEventBridge()
+	assert.False(t, bridge.started)
+
+	or.config.ReadOnly = false
+	or.startEventBridge()
+	assert.True(t, bridge.started)
+}
+
+func TestStartTriggers(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.startTriggers()
+
+	triggers := &testBackgroundManager{}
+	or.triggers = triggers
+	or.config.ReadOnly = true
+	or.startTriggers()
+	assert.False(t, triggers.started)
+
+	or.config.ReadOnly = false
+	or.startTriggers()
+	assert.True(t, triggers.started)
+}
+
 func TestInitTXWriter(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -480,14 +669,17 @@ func TestStartStopOk(t *testing.T) {
 	or.mem.On("Start").Return(nil)
 	or.mbm.On("Start").Return(nil)
 	or.msd.On("Start").Return(nil)
+	or.mpm.On("Start").Return(nil)
 	or.mnm.On("CheckNodeIdentityStatus", or.ctx).Return(nil)
 	or.mom.On("Start").Return(nil)
 	or.mtw.On("Start").Return()
-	or.mam.On("Start").Return(nil)
+	or.mar.On("Start").Return()
+	or.mam.On("StartConnector", "token").Return(nil)
 	or.mba.On("WaitStop").Return(nil)
 	or.mbm.On("WaitStop").Return(nil)
 	or.mdm.On("WaitStop").Return(nil)
 	or.msd.On("WaitStop").Return(nil)
+	or.mpm.On("WaitStop").Return(nil)
 	or.mom.On("WaitStop").Return(nil)
 	or.mem.On("WaitStop").Return(nil)
 	or.mtw.On("Close").Return(nil)
@@ -495,6 +687,7 @@ func TestStartStopOk(t *testing.T) {
 	or.mti.On("StopNamespace", mock.Anything, "ns").Return(nil)
 	err := or.Start()
 	assert.NoError(t, err)
+	or.cancelCtx()
 	or.WaitStop()
 	or.WaitStop() // swallows dups
 
@@ -504,14 +697,17 @@ func TestStartStopOk(t *testing.T) {
 	or.mem.On("Start").Return(nil)
 	or.mbm.On("Start").Return(nil)
 	or.msd.On("Start").Return(nil)
+	or.mpm.On("Start").Return(nil)
 	or.mnm.On("CheckNodeIdentityStatus", or.ctx).Return(errors.New("benign error"))
 	or.mom.On("Start").Return(nil)
 	or.mtw.On("Start").Return()
-	or.mam.On("Start").Return(nil)
+	or.mar.On("Start").Return()
+	or.mam.On("StartConnector", "token").Return(nil)
 	or.mba.On("WaitStop").Return(nil)
 	or.mbm.On("WaitStop").Return(nil)
 	or.mdm.On("WaitStop").Return(nil)
 	or.msd.On("WaitStop").Return(nil)
+	or.mpm.On("WaitStop").Return(nil)
 	or.mom.On("WaitStop").Return(nil)
 	or.mem.On("WaitStop").Return(nil)
 	or.mtw.On("Close").Return(nil)
@@ -519,6 +715,7 @@ func TestStartStopOk(t *testing.T) {
 	or.mti.On("StopNamespace", mock.Anything, "ns").Return(fmt.Errorf("pop"))
 	err = or.Start()
 	assert.NoError(t, err)
+	or.cancelCtx()
 	or.WaitStop()
 	or.WaitStop() // swallows dups
 }
@@ -596,13 +793,70 @@ func TestNetworkActionNonMultiparty(t *testing.T) {
 	assert.Regexp(t, "FF10414", err)
 }
 
+func TestGetScheduledNetworkActions(t *testing.T) {
+	or := newTestOrchestrator()
+	actions := []*core.ScheduledNetworkAction{{NetworkAction: core.NetworkAction{Type: core.NetworkActionTerminate}}}
+	or.mmp.On("GetScheduledNetworkActions").Return(actions)
+	res, err := or.GetScheduledNetworkActions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, actions, res)
+}
+
+func TestGetScheduledNetworkActionsNonMultiparty(t *testing.T) {
+	or := newTestOrchestrator()
+	or.multiparty = nil
+	_, err := or.GetScheduledNetworkActions(context.Background())
+	assert.Regexp(t, "FF10414", err)
+}
+
 func TestAuthorize(t *testing.T) {
+	or := newTestOrchestrator()
+	auth := &authmocks.Plugin{}
+	auth.On("Authorize", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		core.SetAuthPrincipal(args[0].(context.Context), "alice")
+	})
+	or.plugins.Auth.Plugin = auth
+	ctx := core.WithAuthPrincipal(context.Background())
+	err := or.Authorize(ctx, &fftypes.AuthReq{})
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", core.GetAuthPrincipal(ctx))
+}
+
+func TestAuthorizeBasic(t *testing.T) {
 	or := newTestOrchestrator()
 	auth := &authmocks.Plugin{}
 	auth.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	auth.On("Name").Return("basic")
 	or.plugins.Auth.Plugin = auth
-	err := or.Authorize(context.Background(), &fftypes.AuthReq{})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth("bob", "pass")
+	ctx := core.WithAuthPrincipal(context.Background())
+	err := or.Authorize(ctx, &fftypes.AuthReq{Header: req.Header})
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", core.GetAuthPrincipal(ctx))
+}
+
+func TestAuthorizeOtherPluginNoPrincipal(t *testing.T) {
+	or := newTestOrchestrator()
+	auth := &authmocks.Plugin{}
+	auth.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	auth.On("Name").Return("custom")
+	or.plugins.Auth.Plugin = auth
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth("bob", "pass")
+	ctx := core.WithAuthPrincipal(context.Background())
+	err := or.Authorize(ctx, &fftypes.AuthReq{Header: req.Header})
 	assert.NoError(t, err)
+	assert.Empty(t, core.GetAuthPrincipal(ctx))
+}
+
+func TestAuthorizeFail(t *testing.T) {
+	or := newTestOrchestrator()
+	auth := &authmocks.Plugin{}
+	auth.On("Authorize", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	or.plugins.Auth.Plugin = auth
+	err := or.Authorize(context.Background(), &fftypes.AuthReq{})
+	assert.EqualError(t, err, "pop")
 }
 
 func TestAuthorizeNoPlugin(t *testing.T) {
@@ -611,6 +865,70 @@ func TestAuthorizeNoPlugin(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCheckPolicy(t *testing.T) {
+	or := newTestOrchestrator()
+	mpp := &policymocks.Plugin{}
+	mpp.On("Decide", mock.Anything, mock.MatchedBy(func(req *policy.Request) bool {
+		return req.Namespace == "ns" && req.Route == "postData"
+	})).Return(&policy.Decision{Allow: true}, nil)
+	or.plugins.Policy.Plugin = mpp
+	err := or.CheckPolicy(context.Background(), &policy.Request{Method: http.MethodPost, Route: "postData"})
+	assert.NoError(t, err)
+	mpp.AssertExpectations(t)
+}
+
+func TestCheckPolicyDenied(t *testing.T) {
+	or := newTestOrchestrator()
+	mpp := &policymocks.Plugin{}
+	mpp.On("Decide", mock.Anything, mock.Anything).Return(&policy.Decision{Allow: false, Reason: "not permitted"}, nil)
+	or.plugins.Policy.Plugin = mpp
+	err := or.CheckPolicy(context.Background(), &policy.Request{Method: http.MethodPost, Route: "postData"})
+	assert.Regexp(t, "FF10638.*not permitted", err)
+	mpp.AssertExpectations(t)
+}
+
+func TestCheckPolicyFail(t *testing.T) {
+	or := newTestOrchestrator()
+	mpp := &policymocks.Plugin{}
+	mpp.On("Decide", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	or.plugins.Policy.Plugin = mpp
+	err := or.CheckPolicy(context.Background(), &policy.Request{Method: http.MethodPost, Route: "postData"})
+	assert.Regexp(t, "pop", err)
+	mpp.AssertExpectations(t)
+}
+
+func TestCheckPolicyNoPlugin(t *testing.T) {
+	or := newTestOrchestrator()
+	err := or.CheckPolicy(context.Background(), &policy.Request{})
+	assert.NoError(t, err)
+}
+
+func TestCheckPolicyExtension(t *testing.T) {
+	or := newTestOrchestrator()
+	mpp := &policymocks.Plugin{}
+	mpp.On("Decide", mock.Anything, mock.Anything).Return(&policy.Decision{Allow: true}, nil)
+	or.plugins.Policy.Plugin = mpp
+	mex := &extensionsmocks.Manager{}
+	mex.On("CheckPolicy", mock.Anything, mock.MatchedBy(func(req *policy.Request) bool {
+		return req.Namespace == "ns" && req.Route == "postData"
+	})).Return(fmt.Errorf("pop"))
+	or.extensions = mex
+	err := or.CheckPolicy(context.Background(), &policy.Request{Method: http.MethodPost, Route: "postData"})
+	assert.Regexp(t, "pop", err)
+	mpp.AssertExpectations(t)
+	mex.AssertExpectations(t)
+}
+
+func TestCheckPolicyExtensionNoPlugin(t *testing.T) {
+	or := newTestOrchestrator()
+	mex := &extensionsmocks.Manager{}
+	mex.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+	or.extensions = mex
+	err := or.CheckPolicy(context.Background(), &policy.Request{Method: http.MethodPost, Route: "postData"})
+	assert.NoError(t, err)
+	mex.AssertExpectations(t)
+}
+
 func TestRewindPinsSeq(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -648,6 +966,25 @@ func TestRewindPinsNotFound(t *testing.T) {
 	assert.Regexp(t, "FF10109", err)
 }
 
+func TestReleaseMessage(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msgID := fftypes.NewUUID()
+
+	or.mem.On("ReleaseMessage", context.Background(), msgID).Return(&core.Message{}, nil)
+
+	_, err := or.ReleaseMessage(context.Background(), msgID.String())
+	assert.NoError(t, err)
+}
+
+func TestReleaseMessageBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.ReleaseMessage(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
 func TestRewindPinsBatch(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
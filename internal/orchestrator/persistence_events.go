@@ -58,9 +58,21 @@ func (or *orchestrator) UUIDCollectionNSEvent(resType database.UUIDCollectionNS,
 		or.events.DeletedSubscriptions() <- id
 	case eventType == core.ChangeEventTypeUpdated && resType == database.CollectionSubscriptions:
 		or.events.SubscriptionUpdates() <- id
+	case eventType != core.ChangeEventTypeDeleted && resType == database.CollectionOperations:
+		or.events.OperationUpdates() <- id
+	case resType == database.CollectionIdentities && or.identity != nil:
+		or.identity.ForgetMissingIdentities()
+	case resType == database.CollectionDataTypes && or.data != nil:
+		or.data.ForgetMissingDatatypes()
 	}
 }
 
 func (or *orchestrator) HashCollectionNSEvent(resType database.HashCollectionNS, eventType core.ChangeEventType, ns string, hash *fftypes.Bytes32) {
-	// do nothing
+	if ns != or.namespace.Name {
+		log.L(or.ctx).Debugf("Ignoring database event from different namespace '%s'", ns)
+		return
+	}
+	if resType == database.CollectionVerifiers && or.identity != nil {
+		or.identity.ForgetMissingIdentities()
+	}
 }
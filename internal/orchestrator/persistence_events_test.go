@@ -22,9 +22,12 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/mocks/batchmocks"
+	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/eventmocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMessageCreated(t *testing.T) {
@@ -93,6 +96,21 @@ func TestSubscriptionDeleted(t *testing.T) {
 	mem.AssertExpectations(t)
 }
 
+func TestOperationUpdated(t *testing.T) {
+	mem := &eventmocks.EventManager{}
+	o := &orchestrator{
+		namespace: &core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		events:    mem,
+	}
+	updates := make(chan *fftypes.UUID, 2)
+	mem.On("OperationUpdates").Return((chan<- *fftypes.UUID)(updates))
+	o.UUIDCollectionNSEvent(database.CollectionOperations, core.ChangeEventTypeCreated, "ns1", fftypes.NewUUID())
+	o.UUIDCollectionNSEvent(database.CollectionOperations, core.ChangeEventTypeUpdated, "ns1", fftypes.NewUUID())
+	o.UUIDCollectionNSEvent(database.CollectionOperations, core.ChangeEventTypeDeleted, "ns1", fftypes.NewUUID())
+	assert.Len(t, updates, 2)
+	mem.AssertExpectations(t)
+}
+
 func TestGroupCreatedNOOP(t *testing.T) {
 	mem := &eventmocks.EventManager{}
 	o := &orchestrator{
@@ -126,3 +144,37 @@ func TestUUIDCollectionWrongNS(t *testing.T) {
 	}
 	o.UUIDCollectionNSEvent(database.CollectionSubscriptions, core.ChangeEventTypeCreated, "ns2", fftypes.NewUUID())
 }
+
+func TestIdentityCreatedForgetsMissing(t *testing.T) {
+	mim := &identitymanagermocks.Manager{}
+	o := &orchestrator{
+		namespace: &core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		identity:  mim,
+	}
+	mim.On("ForgetMissingIdentities").Return().Twice()
+	o.UUIDCollectionNSEvent(database.CollectionIdentities, core.ChangeEventTypeCreated, "ns1", fftypes.NewUUID())
+	o.HashCollectionNSEvent(database.CollectionVerifiers, core.ChangeEventTypeCreated, "ns1", fftypes.NewRandB32())
+	mim.AssertExpectations(t)
+}
+
+func TestDatatypeCreatedForgetsMissing(t *testing.T) {
+	mdm := &datamocks.Manager{}
+	o := &orchestrator{
+		namespace: &core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		data:      mdm,
+	}
+	mdm.On("ForgetMissingDatatypes").Return()
+	o.UUIDCollectionNSEvent(database.CollectionDataTypes, core.ChangeEventTypeCreated, "ns1", fftypes.NewUUID())
+	mdm.AssertExpectations(t)
+}
+
+func TestHashCollectionWrongNS(t *testing.T) {
+	mim := &identitymanagermocks.Manager{}
+	o := &orchestrator{
+		ctx:       context.Background(),
+		namespace: &core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		identity:  mim,
+	}
+	o.HashCollectionNSEvent(database.CollectionVerifiers, core.ChangeEventTypeCreated, "ns2", fftypes.NewRandB32())
+	mim.AssertExpectations(t)
+}
@@ -0,0 +1,179 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// CheckQuota returns an error if adding the requested amount to the current usage would take the namespace over
+// its limit for the given quota type. A negative amount means the size of the request is not known, which is
+// rejected when there is a limit to check it against.
+// Usage is calculated from the database, so other than messages (where only those sent by local identities
+// count) it reflects all records in the namespace, including those received from other members of the network.
+func (or *orchestrator) CheckQuota(ctx context.Context, quotaType core.QuotaType, requested int64) error {
+	limit := or.getQuotaLimits().Get(quotaType)
+	if limit <= 0 {
+		return nil
+	}
+	if requested < 0 {
+		return i18n.NewError(ctx, coremsgs.MsgNamespaceQuotaSizeUnknown, or.namespace.Name, quotaType)
+	}
+	usage, err := or.getQuotaUsage(ctx, quotaType)
+	if err != nil {
+		return err
+	}
+	if usage+requested > limit {
+		return i18n.NewError(ctx, coremsgs.MsgNamespaceQuotaExceeded, or.namespace.Name, quotaType, limit)
+	}
+	return nil
+}
+
+func (or *orchestrator) GetQuotaStatus(ctx context.Context) (*core.NamespaceQuotaStatus, error) {
+	limits := or.getQuotaLimits()
+	usage := &core.NamespaceQuotas{}
+	var err error
+	if usage.MessagesPerDay, err = or.getQuotaUsage(ctx, core.QuotaTypeMessagesPerDay); err != nil {
+		return nil, err
+	}
+	if usage.BlobBytes, err = or.getQuotaUsage(ctx, core.QuotaTypeBlobBytes); err != nil {
+		return nil, err
+	}
+	if usage.ContractListeners, err = or.getQuotaUsage(ctx, core.QuotaTypeContractListeners); err != nil {
+		return nil, err
+	}
+	if usage.Subscriptions, err = or.getQuotaUsage(ctx, core.QuotaTypeSubscriptions); err != nil {
+		return nil, err
+	}
+	return &core.NamespaceQuotaStatus{
+		Limits: &limits,
+		Usage:  usage,
+	}, nil
+}
+
+// SetQuotaLimits replaces the quota limits of the namespace. The new limits are stored in the database, and
+// take precedence over the configured limits when the namespace is restarted or the config is reloaded.
+func (or *orchestrator) SetQuotaLimits(ctx context.Context, limits *core.NamespaceQuotas) (*core.NamespaceQuotaStatus, error) {
+	if limits.MessagesPerDay < 0 || limits.BlobBytes < 0 || limits.ContractListeners < 0 || limits.Subscriptions < 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceQuotaInvalid)
+	}
+	if err := or.database().UpsertNamespaceQuotas(ctx, or.namespace.Name, limits); err != nil {
+		return nil, err
+	}
+	or.quotaLock.Lock()
+	or.config.Quotas = *limits
+	or.quotaLock.Unlock()
+	return or.GetQuotaStatus(ctx)
+}
+
+// loadQuotaLimits replaces the configured quota limits with any stored by a previous call to SetQuotaLimits
+func (or *orchestrator) loadQuotaLimits(ctx context.Context) error {
+	limits, err := or.database().GetNamespaceQuotas(ctx, or.namespace.Name)
+	if err != nil || limits == nil {
+		return err
+	}
+	or.quotaLock.Lock()
+	or.config.Quotas = *limits
+	or.quotaLock.Unlock()
+	return nil
+}
+
+func (or *orchestrator) getQuotaLimits() core.NamespaceQuotas {
+	or.quotaLock.Lock()
+	defer or.quotaLock.Unlock()
+	return or.config.Quotas
+}
+
+func (or *orchestrator) getQuotaUsage(ctx context.Context, quotaType core.QuotaType) (int64, error) {
+	switch quotaType {
+	case core.QuotaTypeMessagesPerDay:
+		authors, err := or.getLocalAuthors(ctx)
+		if err != nil || len(authors) == 0 {
+			return 0, err
+		}
+		startOfDay := fftypes.FFTime(time.Now().UTC().Truncate(24 * time.Hour))
+		fb := database.MessageQueryFactory.NewFilter(ctx)
+		_, res, err := or.database().GetMessages(ctx, or.namespace.Name, fb.And(
+			fb.Gte("created", &startOfDay),
+			fb.In("author", authors),
+		).Limit(1).Count(true))
+		return totalCount(res), err
+	case core.QuotaTypeBlobBytes:
+		return or.database().GetBlobsTotalSize(ctx, or.namespace.Name)
+	case core.QuotaTypeContractListeners:
+		fb := database.ContractListenerQueryFactory.NewFilter(ctx)
+		_, res, err := or.database().GetContractListeners(ctx, or.namespace.Name, fb.And().Limit(1).Count(true))
+		return totalCount(res), err
+	case core.QuotaTypeSubscriptions:
+		fb := database.SubscriptionQueryFactory.NewFilter(ctx)
+		_, res, err := or.database().GetSubscriptions(ctx, or.namespace.Name, fb.And().Limit(1).Count(true))
+		return totalCount(res), err
+	default:
+		return 0, nil
+	}
+}
+
+// getLocalAuthors returns the DIDs of the root org of this node, and of all the identities beneath it, which are
+// the only identities that can send messages from this node
+func (or *orchestrator) getLocalAuthors(ctx context.Context) ([]driver.Value, error) {
+	if !or.config.Multiparty.Enabled {
+		return nil, nil
+	}
+	orgDID, err := or.identity.GetRootOrgDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	org, _, err := or.identity.CachedIdentityLookupNilOK(ctx, orgDID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		// The org is not registered yet, so cannot have any child identities
+		return []driver.Value{orgDID}, nil
+	}
+	authors := []driver.Value{org.DID}
+	parents := []driver.Value{org.ID}
+	for len(parents) > 0 {
+		fb := database.IdentityQueryFactory.NewFilter(ctx)
+		children, _, err := or.database().GetIdentities(ctx, or.namespace.Name, fb.In("parent", parents))
+		if err != nil {
+			return nil, err
+		}
+		parents = make([]driver.Value, 0, len(children))
+		for _, child := range children {
+			authors = append(authors, child.DID)
+			parents = append(parents, child.ID)
+		}
+	}
+	return authors, nil
+}
+
+func totalCount(res *ffapi.FilterResult) int64 {
+	if res == nil || res.TotalCount == nil {
+		return 0
+	}
+	return *res.TotalCount
+}
@@ -0,0 +1,229 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func countResult(count int64) *ffapi.FilterResult {
+	return &ffapi.FilterResult{TotalCount: &count}
+}
+
+func mockLocalAuthors(or *testOrchestrator) {
+	org := &core.Identity{
+		IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:org/org1"},
+	}
+	child := &core.Identity{
+		IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:custom1", Parent: org.ID},
+	}
+	or.mim.On("GetRootOrgDID", or.ctx).Return("did:firefly:org/org1", nil)
+	or.mim.On("CachedIdentityLookupNilOK", or.ctx, "did:firefly:org/org1").Return(org, false, nil)
+	or.mdi.On("GetIdentities", or.ctx, "ns", mock.Anything).Return([]*core.Identity{child}, nil, nil).Once()
+	or.mdi.On("GetIdentities", or.ctx, "ns", mock.Anything).Return([]*core.Identity{}, nil, nil).Once()
+}
+
+func TestCheckQuotaNoLimit(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	err := or.CheckQuota(or.ctx, core.QuotaTypeMessagesPerDay, 1)
+	assert.NoError(t, err)
+}
+
+func TestCheckQuotaMessages(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.MessagesPerDay = 10
+
+	mockLocalAuthors(or)
+	or.mdi.On("GetMessages", or.ctx, "ns", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		f, _ := filter.Finalize()
+		return strings.Contains(f.String(), `author IN ['did:firefly:org/org1','did:firefly:custom1']`)
+	})).Return([]*core.Message{}, countResult(9), nil).Once()
+	err := or.CheckQuota(or.ctx, core.QuotaTypeMessagesPerDay, 1)
+	assert.NoError(t, err)
+
+	mockLocalAuthors(or)
+	or.mdi.On("GetMessages", or.ctx, "ns", mock.Anything).Return([]*core.Message{}, countResult(10), nil).Once()
+	err = or.CheckQuota(or.ctx, core.QuotaTypeMessagesPerDay, 1)
+	assert.Regexp(t, "FF10494.*messages_per_day", err)
+}
+
+func TestCheckQuotaMessagesOrgNotRegistered(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.MessagesPerDay = 10
+
+	or.mim.On("GetRootOrgDID", or.ctx).Return("did:firefly:org/org1", nil)
+	or.mim.On("CachedIdentityLookupNilOK", or.ctx, "did:firefly:org/org1").Return(nil, false, nil)
+	or.mdi.On("GetMessages", or.ctx, "ns", mock.Anything).Return([]*core.Message{}, countResult(0), nil)
+	err := or.CheckQuota(or.ctx, core.QuotaTypeMessagesPerDay, 1)
+	assert.NoError(t, err)
+}
+
+func TestCheckQuotaMessagesNonMultiparty(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.MessagesPerDay = 10
+	or.config.Multiparty.Enabled = false
+
+	err := or.CheckQuota(or.ctx, core.QuotaTypeMessagesPerDay, 1)
+	assert.NoError(t, err)
+}
+
+func TestCheckQuotaMessagesRootOrgFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.MessagesPerDay = 10
+
+	or.mim.On("GetRootOrgDID", or.ctx).Return("", fmt.Errorf("pop"))
+	err := or.CheckQuota(or.ctx, core.QuotaTypeMessagesPerDay, 1)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckQuotaMessagesGetIdentitiesFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.MessagesPerDay = 10
+
+	org := &core.Identity{
+		IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:org/org1"},
+	}
+	or.mim.On("GetRootOrgDID", or.ctx).Return("did:firefly:org/org1", nil)
+	or.mim.On("CachedIdentityLookupNilOK", or.ctx, "did:firefly:org/org1").Return(org, false, nil)
+	or.mdi.On("GetIdentities", or.ctx, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	err := or.CheckQuota(or.ctx, core.QuotaTypeMessagesPerDay, 1)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCheckQuotaBlobBytes(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.BlobBytes = 1024
+
+	or.mdi.On("GetBlobsTotalSize", or.ctx, "ns").Return(int64(1000), nil)
+	err := or.CheckQuota(or.ctx, core.QuotaTypeBlobBytes, 24)
+	assert.NoError(t, err)
+
+	err = or.CheckQuota(or.ctx, core.QuotaTypeBlobBytes, 25)
+	assert.Regexp(t, "FF10494.*blob_bytes", err)
+}
+
+func TestCheckQuotaBlobBytesSizeUnknown(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.BlobBytes = 1024
+
+	err := or.CheckQuota(or.ctx, core.QuotaTypeBlobBytes, -1)
+	assert.Regexp(t, "FF10689.*blob_bytes", err)
+}
+
+func TestCheckQuotaLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.Subscriptions = 10
+
+	or.mdi.On("GetSubscriptions", or.ctx, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	err := or.CheckQuota(or.ctx, core.QuotaTypeSubscriptions, 1)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSetQuotaLimitsAndGetStatus(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	limits := &core.NamespaceQuotas{MessagesPerDay: 5, Subscriptions: 3}
+	or.mdi.On("UpsertNamespaceQuotas", or.ctx, "ns", limits).Return(nil)
+	mockLocalAuthors(or)
+	or.mdi.On("GetMessages", or.ctx, "ns", mock.Anything).Return([]*core.Message{}, countResult(1), nil)
+	or.mdi.On("GetBlobsTotalSize", or.ctx, "ns").Return(int64(100), nil)
+	or.mdi.On("GetContractListeners", or.ctx, "ns", mock.Anything).Return([]*core.ContractListener{}, countResult(2), nil)
+	or.mdi.On("GetSubscriptions", or.ctx, "ns", mock.Anything).Return([]*core.Subscription{}, countResult(3), nil)
+
+	status, err := or.SetQuotaLimits(or.ctx, limits)
+	assert.NoError(t, err)
+	assert.Equal(t, limits, status.Limits)
+	assert.Equal(t, &core.NamespaceQuotas{MessagesPerDay: 1, BlobBytes: 100, ContractListeners: 2, Subscriptions: 3}, status.Usage)
+
+	err = or.CheckQuota(or.ctx, core.QuotaTypeSubscriptions, 1)
+	assert.Regexp(t, "FF10494", err)
+}
+
+func TestSetQuotaLimitsStoreFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.Subscriptions = 10
+
+	or.mdi.On("UpsertNamespaceQuotas", or.ctx, "ns", mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := or.SetQuotaLimits(or.ctx, &core.NamespaceQuotas{Subscriptions: 3})
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, int64(10), or.getQuotaLimits().Subscriptions)
+}
+
+func TestLoadQuotaLimits(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Quotas.Subscriptions = 10
+
+	or.mdi.On("GetNamespaceQuotas", or.ctx, "ns").Return(nil, nil).Once()
+	err := or.loadQuotaLimits(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), or.getQuotaLimits().Subscriptions)
+
+	or.mdi.On("GetNamespaceQuotas", or.ctx, "ns").Return(&core.NamespaceQuotas{BlobBytes: 1024}, nil).Once()
+	err = or.loadQuotaLimits(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.NamespaceQuotas{BlobBytes: 1024}, or.getQuotaLimits())
+}
+
+func TestLoadQuotaLimitsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetNamespaceQuotas", or.ctx, "ns").Return(nil, fmt.Errorf("pop"))
+	err := or.loadQuotaLimits(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSetQuotaLimitsNegative(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.SetQuotaLimits(or.ctx, &core.NamespaceQuotas{BlobBytes: -1})
+	assert.Regexp(t, "FF10495", err)
+}
+
+func TestGetQuotaStatusFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	mockLocalAuthors(or)
+	or.mdi.On("GetMessages", or.ctx, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.GetQuotaStatus(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,44 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// CheckRateLimit uses one request of the allowance of an identity for a group of routes, returning an error
+// if none remains. The status is nil if the group has no rate limit in this namespace.
+func (or *orchestrator) CheckRateLimit(ctx context.Context, group core.RateLimitGroup, identity string) (*core.RateLimitStatus, error) {
+	if or.rateLimiter == nil {
+		return nil, nil
+	}
+	status, allowed := or.rateLimiter.Take(group, identity)
+	if status == nil {
+		return nil, nil
+	}
+	if or.metrics.IsMetricsEnabled() {
+		or.metrics.RateLimitChecked(or.namespace.Name, string(group), allowed)
+	}
+	if !allowed {
+		return status, i18n.NewError(ctx, coremsgs.MsgRateLimitExceeded, group, or.namespace.Name, status.RetryAfter)
+	}
+	return status, nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly/internal/ratelimit"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRateLimitNoLimits(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	status, err := or.CheckRateLimit(or.ctx, core.RateLimitGroupMessages, "alice")
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+}
+
+func TestCheckRateLimitGroupNotLimited(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.rateLimiter = ratelimit.NewLimiter(map[core.RateLimitGroup]core.RateLimit{
+		core.RateLimitGroupMessages: {RequestsPerSecond: 1},
+	})
+
+	status, err := or.CheckRateLimit(or.ctx, core.RateLimitGroupTokens, "alice")
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+}
+
+func TestCheckRateLimitExceeded(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.rateLimiter = ratelimit.NewLimiter(map[core.RateLimitGroup]core.RateLimit{
+		core.RateLimitGroupMessages: {RequestsPerSecond: 0.1, Burst: 1},
+	})
+	or.mmi.On("IsMetricsEnabled").Return(true)
+	or.mmi.On("RateLimitChecked", "ns", "messages", true).Once()
+	or.mmi.On("RateLimitChecked", "ns", "messages", false).Once()
+
+	status, err := or.CheckRateLimit(or.ctx, core.RateLimitGroupMessages, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, status.Limit)
+	assert.Equal(t, 0, status.Remaining)
+
+	status, err = or.CheckRateLimit(or.ctx, core.RateLimitGroupMessages, "alice")
+	assert.Regexp(t, "FF10545.*messages", err)
+	assert.Equal(t, int64(10), status.RetryAfter)
+}
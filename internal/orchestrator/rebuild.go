@@ -0,0 +1,98 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type rebuildRun struct {
+	status core.RebuildStatus
+	done   chan struct{}
+}
+
+// GetRebuildStatus returns the progress of the latest rebuild of each target on this node, since it started
+func (or *orchestrator) GetRebuildStatus(ctx context.Context) []*core.RebuildStatus {
+	or.rebuildMux.Lock()
+	defer or.rebuildMux.Unlock()
+	statuses := make([]*core.RebuildStatus, 0, len(or.rebuildRuns))
+	for _, run := range or.rebuildRuns {
+		status := run.status
+		statuses = append(statuses, &status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Target < statuses[j].Target })
+	return statuses
+}
+
+// RebuildDerivedState starts regenerating a set of derived records in the background, from the records they are
+// derived from. Blockchain event ingestion and aggregation are paused until the rebuild completes.
+func (or *orchestrator) RebuildDerivedState(ctx context.Context, target core.RebuildTarget) (*core.RebuildStatus, error) {
+	or.rebuildMux.Lock()
+	defer or.rebuildMux.Unlock()
+	if run := or.rebuildRuns[target]; run != nil && run.status.Running {
+		return nil, i18n.NewError(ctx, coremsgs.MsgRebuildRunning, target, or.namespace.Name)
+	}
+	if or.rebuildRuns == nil {
+		or.rebuildRuns = make(map[core.RebuildTarget]*rebuildRun)
+	}
+	run := &rebuildRun{
+		status: core.RebuildStatus{
+			Target:  target,
+			Running: true,
+			Started: fftypes.Now(),
+		},
+		done: make(chan struct{}),
+	}
+	or.rebuildRuns[target] = run
+	go or.rebuildLoop(run)
+
+	status := run.status
+	return &status, nil
+}
+
+func (or *orchestrator) rebuildLoop(run *rebuildRun) {
+	defer close(run.done)
+	status := &run.status
+	ctx := or.ctx
+	log.L(ctx).Infof("Rebuild of %s pausing event ingestion", status.Target)
+	resume := or.events.PauseIngestion()
+	err := or.events.RebuildDerivedState(ctx, status.Target, func(processed, repaired int64) {
+		or.rebuildMux.Lock()
+		defer or.rebuildMux.Unlock()
+		status.Processed += processed
+		status.Repaired += repaired
+	})
+	resume()
+
+	or.rebuildMux.Lock()
+	defer or.rebuildMux.Unlock()
+	status.Running = false
+	status.Completed = fftypes.Now()
+	if err != nil {
+		log.L(ctx).Errorf("Rebuild of %s failed: %s", status.Target, err)
+		status.Error = err.Error()
+	} else {
+		log.L(ctx).Infof("Rebuild of %s complete after reading %d records and repairing %d", status.Target, status.Processed, status.Repaired)
+	}
+}
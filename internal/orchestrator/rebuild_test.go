@@ -0,0 +1,101 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRebuildDerivedState(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	resumed := false
+	or.mem.On("PauseIngestion").Return(func() { resumed = true })
+	or.mem.On("RebuildDerivedState", mock.Anything, core.RebuildTargetTokenBalances, mock.Anything).
+		Run(func(args mock.Arguments) {
+			progress := args[2].(func(int64, int64))
+			progress(10, 0)
+			progress(5, 1)
+		}).
+		Return(nil)
+
+	status, err := or.RebuildDerivedState(context.Background(), core.RebuildTargetTokenBalances)
+	assert.NoError(t, err)
+	assert.True(t, status.Running)
+	assert.NotNil(t, status.Started)
+
+	<-or.rebuildRuns[core.RebuildTargetTokenBalances].done
+	assert.True(t, resumed)
+
+	statuses := or.GetRebuildStatus(context.Background())
+	assert.Len(t, statuses, 1)
+	assert.False(t, statuses[0].Running)
+	assert.Equal(t, int64(15), statuses[0].Processed)
+	assert.Equal(t, int64(1), statuses[0].Repaired)
+	assert.NotNil(t, statuses[0].Completed)
+	assert.Empty(t, statuses[0].Error)
+}
+
+func TestRebuildDerivedStateFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	resumed := false
+	or.mem.On("PauseIngestion").Return(func() { resumed = true })
+	or.mem.On("RebuildDerivedState", mock.Anything, core.RebuildTargetNextPins, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := or.RebuildDerivedState(context.Background(), core.RebuildTargetNextPins)
+	assert.NoError(t, err)
+	<-or.rebuildRuns[core.RebuildTargetNextPins].done
+	assert.True(t, resumed)
+
+	statuses := or.GetRebuildStatus(context.Background())
+	assert.Equal(t, "pop", statuses[0].Error)
+}
+
+func TestRebuildDerivedStateAlreadyRunning(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.rebuildRuns = map[core.RebuildTarget]*rebuildRun{
+		core.RebuildTargetMessageStates: {status: core.RebuildStatus{Running: true}},
+	}
+
+	_, err := or.RebuildDerivedState(context.Background(), core.RebuildTargetMessageStates)
+	assert.Regexp(t, "FF10546", err)
+}
+
+func TestGetRebuildStatusSorted(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.rebuildRuns = map[core.RebuildTarget]*rebuildRun{
+		core.RebuildTargetTokenBalances: {status: core.RebuildStatus{Target: core.RebuildTargetTokenBalances}},
+		core.RebuildTargetMessageStates: {status: core.RebuildStatus{Target: core.RebuildTargetMessageStates}},
+	}
+
+	statuses := or.GetRebuildStatus(context.Background())
+	assert.Equal(t, core.RebuildTargetMessageStates, statuses[0].Target)
+	assert.Equal(t, core.RebuildTargetTokenBalances, statuses[1].Target)
+}
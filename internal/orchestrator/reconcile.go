@@ -0,0 +1,33 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// GetListenerReconciliation returns the report of the contract listener reconciliation run when the namespace started
+func (or *orchestrator) GetListenerReconciliation(ctx context.Context) (*core.ListenerReconciliationReport, error) {
+	if or.reconciler == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgListenerReconcileNotEnabled)
+	}
+	return or.reconciler.Report(), nil
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/contractmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetListenerReconciliation(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mrc := &contractmocks.Reconciler{}
+	or.reconciler = mrc
+	mrc.On("Report").Return(&core.ListenerReconciliationReport{Namespace: "ns", Backfilled: 1})
+
+	report, err := or.GetListenerReconciliation(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Backfilled)
+	mrc.AssertExpectations(t)
+}
+
+func TestGetListenerReconciliationNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.GetListenerReconciliation(context.Background())
+	assert.Regexp(t, "FF10593", err)
+}
+
+func TestStartStopReconciler(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mrc := &contractmocks.Reconciler{}
+	or.reconciler = mrc
+	or.mdm.On("Start").Return(nil)
+	or.mba.On("Start").Return(nil)
+	or.mem.On("Start").Return(nil)
+	or.mbm.On("Start").Return(nil)
+	or.msd.On("Start").Return(nil)
+	or.mpm.On("Start").Return(nil)
+	or.mnm.On("CheckNodeIdentityStatus", or.ctx).Return(nil)
+	or.mom.On("Start").Return(nil)
+	or.mtw.On("Start").Return()
+	or.mar.On("Start").Return()
+	or.mam.On("StartConnector", "token").Return(nil)
+	mrc.On("Start").Return()
+	or.mba.On("WaitStop").Return(nil)
+	or.mbm.On("WaitStop").Return(nil)
+	or.mdm.On("WaitStop").Return(nil)
+	or.msd.On("WaitStop").Return(nil)
+	or.mpm.On("WaitStop").Return(nil)
+	or.mom.On("WaitStop").Return(nil)
+	or.mem.On("WaitStop").Return(nil)
+	or.mtw.On("Close").Return(nil)
+	mrc.On("WaitStop").Return()
+	or.mbi.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	or.mti.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	err := or.Start()
+	assert.NoError(t, err)
+	or.cancelCtx()
+	or.WaitStop()
+	mrc.AssertExpectations(t)
+}
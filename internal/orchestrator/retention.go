@@ -0,0 +1,231 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+var retentionMaxAgeKeys = []struct {
+	collection database.PrunableCollection
+	maxAge     config.RootKey
+}{
+	{database.PrunableEvents, coreconfig.RetentionEventsMaxAge},
+	{database.PrunableOperations, coreconfig.RetentionOperationsMaxAge},
+	{database.PrunablePins, coreconfig.RetentionPinsMaxAge},
+}
+
+func retentionConfigured() bool {
+	if config.GetBool(coreconfig.RetentionMessagesEnabled) {
+		return true
+	}
+	for _, c := range retentionMaxAgeKeys {
+		if config.GetDuration(c.maxAge) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (or *orchestrator) startRetention() {
+	if (!retentionConfigured() && or.archiver == nil) || or.config.ReadOnly {
+		return
+	}
+	or.retentionDone = make(chan struct{})
+	go or.retentionLoop()
+}
+
+func (or *orchestrator) retentionLoop() {
+	defer close(or.retentionDone)
+	interval := config.GetDuration(coreconfig.RetentionInterval)
+	for {
+		select {
+		case <-time.After(interval):
+		case <-or.ctx.Done():
+			log.L(or.ctx).Debugf("Retention exiting")
+			return
+		}
+		if err := or.pruneRecords(or.ctx); err != nil {
+			log.L(or.ctx).Errorf("Retention run failed: %s", err)
+		}
+	}
+}
+
+// retentionPolicies builds the policy for each collection with a max age configured. Events are only
+// eligible once they have been delivered to every durable subscription in the namespace.
+func (or *orchestrator) retentionPolicies(ctx context.Context) ([]*database.RetentionPolicy, []*fftypes.FFDuration, error) {
+	now := time.Now()
+	var policies []*database.RetentionPolicy
+	var maxAges []*fftypes.FFDuration
+	for _, c := range retentionMaxAgeKeys {
+		maxAge := config.GetDuration(c.maxAge)
+		if maxAge <= 0 {
+			continue
+		}
+		before := fftypes.FFTime(now.Add(-maxAge))
+		policy := &database.RetentionPolicy{Collection: c.collection, Before: &before}
+		if c.collection == database.PrunableEvents {
+			maxSequence, err := or.deliveredEventSequence(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			policy.MaxSequence = maxSequence
+		}
+		ffd := fftypes.FFDuration(maxAge)
+		policies = append(policies, policy)
+		maxAges = append(maxAges, &ffd)
+	}
+	return policies, maxAges, nil
+}
+
+// deliveredEventSequence returns the lowest event offset of the durable subscriptions in the namespace,
+// or nil if there are no subscriptions
+func (or *orchestrator) deliveredEventSequence(ctx context.Context) (*int64, error) {
+	subs, _, err := or.database().GetSubscriptions(ctx, or.namespace.Name, database.SubscriptionQueryFactory.NewFilter(ctx).And())
+	if err != nil {
+		return nil, err
+	}
+	var lowest *int64
+	for _, sub := range subs {
+		offset, err := or.database().GetOffset(ctx, core.OffsetTypeSubscription, sub.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		current := int64(0)
+		if offset != nil {
+			current = offset.Current
+		}
+		if lowest == nil || current < *lowest {
+			lowest = &current
+		}
+	}
+	return lowest, nil
+}
+
+// pruneRecords deletes the records that have aged out of each configured collection, in batches
+func (or *orchestrator) pruneRecords(ctx context.Context) error {
+	policies, _, err := or.retentionPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	if or.archiver != nil {
+		if err := or.archiveRecords(ctx, policies); err != nil {
+			return err
+		}
+	}
+	batchSize := config.GetInt(coreconfig.RetentionBatchSize)
+	for _, policy := range policies {
+		total := int64(0)
+		for {
+			deleted, err := or.database().PruneRecords(ctx, or.namespace.Name, policy, batchSize)
+			if err != nil {
+				return err
+			}
+			total += deleted
+			if deleted > 0 && or.metrics.IsMetricsEnabled() {
+				or.metrics.RecordsPruned(or.namespace.Name, string(policy.Collection), deleted)
+			}
+			if deleted < int64(batchSize) {
+				break
+			}
+		}
+		if total > 0 {
+			log.L(ctx).Infof("Pruned %d %s created before %s", total, policy.Collection, policy.Before.String())
+		}
+	}
+	if config.GetBool(coreconfig.RetentionMessagesEnabled) {
+		return or.pruneMessageData(ctx, batchSize)
+	}
+	return nil
+}
+
+// pruneMessageData enforces each of the message retention policies of the namespace
+func (or *orchestrator) pruneMessageData(ctx context.Context, batchSize int) error {
+	policies, _, err := or.data.GetMessageRetentionPolicies(ctx, database.MessageRetentionPolicyQueryFactory.NewFilter(ctx).And())
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		deleted, err := or.data.PruneMessageData(ctx, policy, batchSize)
+		if deleted > 0 {
+			if or.metrics.IsMetricsEnabled() {
+				or.metrics.RecordsPruned(or.namespace.Name, string(database.CollectionData), deleted)
+			}
+			log.L(ctx).Infof("Pruned %d data records of messages matching retention policy '%s'", deleted, policy.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveRecords exports each archived collection to the archive store, up to the cut-off of its
+// retention policy, and then limits the policy so that only archived records are pruned
+func (or *orchestrator) archiveRecords(ctx context.Context, policies []*database.RetentionPolicy) error {
+	for _, collection := range or.archiver.Collections() {
+		var policy *database.RetentionPolicy
+		for _, p := range policies {
+			if string(p.Collection) == string(collection) {
+				policy = p
+			}
+		}
+		before := fftypes.FFTime(time.Now().Add(-config.GetDuration(coreconfig.RetentionArchiveMinAge)))
+		if policy != nil {
+			before = *policy.Before
+		}
+		archivedTo, err := or.archiver.Archive(ctx, collection, &before)
+		if err != nil {
+			return err
+		}
+		if policy != nil && (policy.MaxSequence == nil || *policy.MaxSequence > archivedTo) {
+			policy.MaxSequence = &archivedTo
+		}
+	}
+	return nil
+}
+
+func (or *orchestrator) EstimateRetention(ctx context.Context) (*core.RetentionEstimate, error) {
+	policies, maxAges, err := or.retentionPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	estimate := &core.RetentionEstimate{
+		Collections: make([]*core.RetentionCollectionEstimate, len(policies)),
+	}
+	for i, policy := range policies {
+		count, err := or.database().CountPrunableRecords(ctx, or.namespace.Name, policy)
+		if err != nil {
+			return nil, err
+		}
+		estimate.Collections[i] = &core.RetentionCollectionEstimate{
+			Collection: string(policy.Collection),
+			MaxAge:     maxAges[i],
+			Before:     policy.Before,
+			Records:    count,
+		}
+	}
+	return estimate, nil
+}
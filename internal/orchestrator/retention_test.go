@@ -0,0 +1,299 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/archivestoremocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func policyFor(collection database.PrunableCollection) interface{} {
+	return mock.MatchedBy(func(p *database.RetentionPolicy) bool { return p.Collection == collection })
+}
+
+func TestPruneRecords(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionEventsMaxAge, "720h")
+	config.Set(coreconfig.RetentionPinsMaxAge, "720h")
+	config.Set(coreconfig.RetentionBatchSize, 10)
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub1 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	sub2 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return([]*core.Subscription{sub1, sub2}, nil, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub1.ID.String()).Return(&core.Offset{Current: 500}, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub2.ID.String()).Return(&core.Offset{Current: 200}, nil)
+	or.mdi.On("PruneRecords", mock.Anything, "ns", mock.MatchedBy(func(p *database.RetentionPolicy) bool {
+		return p.Collection == database.PrunableEvents && *p.MaxSequence == 200
+	}), 10).Return(int64(10), nil).Once()
+	or.mdi.On("PruneRecords", mock.Anything, "ns", policyFor(database.PrunableEvents), 10).Return(int64(3), nil).Once()
+	or.mdi.On("PruneRecords", mock.Anything, "ns", policyFor(database.PrunablePins), 10).Return(int64(0), nil).Once()
+	or.mmi.On("IsMetricsEnabled").Return(true)
+	or.mmi.On("RecordsPruned", "ns", "events", int64(10)).Once()
+	or.mmi.On("RecordsPruned", "ns", "events", int64(3)).Once()
+
+	err := or.pruneRecords(or.ctx)
+	assert.NoError(t, err)
+}
+
+func TestPruneRecordsFail(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionOperationsMaxAge, "24h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("PruneRecords", mock.Anything, "ns", policyFor(database.PrunableOperations), 1000).Return(int64(0), fmt.Errorf("pop"))
+
+	err := or.pruneRecords(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPruneRecordsMessageData(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionMessagesEnabled, true)
+	config.Set(coreconfig.RetentionBatchSize, 10)
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	policy1 := &core.MessageRetentionPolicy{Name: "heartbeats"}
+	policy2 := &core.MessageRetentionPolicy{Name: "pings"}
+	or.mdm.On("GetMessageRetentionPolicies", mock.Anything, mock.Anything).Return([]*core.MessageRetentionPolicy{policy1, policy2}, nil, nil)
+	or.mdm.On("PruneMessageData", mock.Anything, policy1, 10).Return(int64(5), nil)
+	or.mdm.On("PruneMessageData", mock.Anything, policy2, 10).Return(int64(0), nil)
+	or.mmi.On("IsMetricsEnabled").Return(true)
+	or.mmi.On("RecordsPruned", "ns", "data", int64(5)).Once()
+
+	err := or.pruneRecords(or.ctx)
+	assert.NoError(t, err)
+}
+
+func TestPruneRecordsMessageDataPoliciesFail(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionMessagesEnabled, true)
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdm.On("GetMessageRetentionPolicies", mock.Anything, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := or.pruneRecords(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPruneRecordsMessageDataFail(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionMessagesEnabled, true)
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	policy := &core.MessageRetentionPolicy{Name: "heartbeats"}
+	or.mdm.On("GetMessageRetentionPolicies", mock.Anything, mock.Anything).Return([]*core.MessageRetentionPolicy{policy}, nil, nil)
+	or.mdm.On("PruneMessageData", mock.Anything, policy, 1000).Return(int64(2), fmt.Errorf("pop"))
+	or.mmi.On("IsMetricsEnabled").Return(false)
+
+	err := or.pruneRecords(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPruneRecordsSubscriptionsFail(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionEventsMaxAge, "24h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := or.pruneRecords(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPruneRecordsOffsetFail(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionEventsMaxAge, "24h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub1 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return([]*core.Subscription{sub1}, nil, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub1.ID.String()).Return(nil, fmt.Errorf("pop"))
+
+	err := or.pruneRecords(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestEstimateRetention(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionEventsMaxAge, "24h")
+	config.Set(coreconfig.RetentionOperationsMaxAge, "48h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	// A subscription that has not yet been delivered anything holds back all events
+	sub1 := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}}
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return([]*core.Subscription{sub1}, nil, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub1.ID.String()).Return(nil, nil)
+	or.mdi.On("CountPrunableRecords", mock.Anything, "ns", mock.MatchedBy(func(p *database.RetentionPolicy) bool {
+		return p.Collection == database.PrunableEvents && *p.MaxSequence == 0
+	})).Return(int64(0), nil)
+	or.mdi.On("CountPrunableRecords", mock.Anything, "ns", policyFor(database.PrunableOperations)).Return(int64(42), nil)
+
+	estimate, err := or.EstimateRetention(or.ctx)
+	assert.NoError(t, err)
+	assert.Len(t, estimate.Collections, 2)
+	assert.Equal(t, "events", estimate.Collections[0].Collection)
+	assert.Equal(t, "24h0m0s", estimate.Collections[0].MaxAge.String())
+	assert.Equal(t, int64(0), estimate.Collections[0].Records)
+	assert.Equal(t, "operations", estimate.Collections[1].Collection)
+	assert.Equal(t, int64(42), estimate.Collections[1].Records)
+}
+
+func TestEstimateRetentionNoPolicy(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	estimate, err := or.EstimateRetention(or.ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, estimate.Collections)
+}
+
+func TestEstimateRetentionFail(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionPinsMaxAge, "24h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("CountPrunableRecords", mock.Anything, "ns", policyFor(database.PrunablePins)).Return(int64(0), fmt.Errorf("pop"))
+
+	_, err := or.EstimateRetention(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestEstimateRetentionSubscriptionsFail(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionEventsMaxAge, "24h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptions", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.EstimateRetention(or.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPruneRecordsArchivesFirst(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionEventsMaxAge, "720h")
+	config.Set(coreconfig.RetentionBatchSize, 10)
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mar := &archivestoremocks.Archiver{}
+	or.archiver = mar
+
+	mar.On("Collections").Return([]database.CollectionName{"messages", "events"})
+	mar.On("Archive", mock.Anything, database.CollectionName("messages"), mock.Anything).Return(int64(50), nil)
+	mar.On("Archive", mock.Anything, database.CollectionName("events"), mock.Anything).Return(int64(20), nil)
+	or.mdi.On("PruneRecords", mock.Anything, "ns", mock.MatchedBy(func(p *database.RetentionPolicy) bool {
+		return p.Collection == database.PrunableEvents && *p.MaxSequence == 20
+	}), 10).Return(int64(5), nil).Once()
+	or.mmi.On("IsMetricsEnabled").Return(false)
+
+	err := or.pruneRecords(or.ctx)
+	assert.NoError(t, err)
+	mar.AssertExpectations(t)
+}
+
+func TestPruneRecordsArchiveFail(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mar := &archivestoremocks.Archiver{}
+	or.archiver = mar
+
+	mar.On("Collections").Return([]database.CollectionName{"events"})
+	mar.On("Archive", mock.Anything, database.CollectionName("events"), mock.Anything).Return(int64(-1), fmt.Errorf("pop"))
+
+	err := or.pruneRecords(or.ctx)
+	assert.EqualError(t, err, "pop")
+	mar.AssertExpectations(t)
+}
+
+func TestRetentionLoop(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionPinsMaxAge, "24h")
+	config.Set(coreconfig.RetentionInterval, "1ms")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	pruned := make(chan struct{})
+	or.mdi.On("PruneRecords", mock.Anything, "ns", mock.Anything, 1000).Return(int64(0), fmt.Errorf("pop")).Once()
+	or.mdi.On("PruneRecords", mock.Anything, "ns", mock.Anything, 1000).Return(int64(0), nil).Run(func(args mock.Arguments) {
+		select {
+		case <-pruned:
+		default:
+			close(pruned)
+		}
+	})
+
+	or.startRetention()
+	assert.NotNil(t, or.retentionDone)
+	<-pruned
+	or.cancelCtx()
+	<-or.retentionDone
+}
+
+func TestRetentionDisabled(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.startRetention()
+	assert.Nil(t, or.retentionDone)
+}
+
+func TestRetentionReadOnly(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionPinsMaxAge, "24h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.ReadOnly = true
+
+	or.startRetention()
+	assert.Nil(t, or.retentionDone)
+}
+
+func TestRetentionMessagesEnabled(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.RetentionMessagesEnabled, true)
+	config.Set(coreconfig.RetentionInterval, "1h")
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.startRetention()
+	assert.NotNil(t, or.retentionDone)
+	or.cancelCtx()
+	<-or.retentionDone
+}
@@ -0,0 +1,69 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func (or *orchestrator) SearchMessages(ctx context.Context, query string, limit int) ([]*core.SearchResult, error) {
+	if or.search == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSearchNotEnabled)
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSearchQueryRequired)
+	}
+	hits, err := or.search.Search(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return []*core.SearchResult{}, nil
+	}
+
+	ids := make([]driver.Value, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.Message
+	}
+	fb := database.MessageQueryFactory.NewFilter(ctx)
+	msgs, _, err := or.database().GetMessages(ctx, or.namespace.Name, fb.In("id", ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[fftypes.UUID]*core.Message, len(msgs))
+	for _, msg := range msgs {
+		byID[*msg.Header.ID] = msg
+	}
+
+	results := make([]*core.SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		msg := byID[*hit.Message]
+		if msg == nil {
+			continue // pruned by the retention policy since it was indexed
+		}
+		results = append(results, &core.SearchResult{Score: hit.Score, Message: msg})
+	}
+	return results, nil
+}
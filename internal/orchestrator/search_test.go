@@ -0,0 +1,104 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/searchmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchMessages(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msi := &searchmocks.Indexer{}
+	or.search = msi
+
+	msg1, msg2 := fftypes.NewUUID(), fftypes.NewUUID()
+	msi.On("Search", mock.Anything, "widget", 10).Return([]*database.SearchHit{
+		{Message: msg1, Score: 2},
+		{Message: msg2, Score: 1},
+	}, nil)
+	// msg2 has been pruned since it was indexed
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{{Header: core.MessageHeader{ID: msg1}}}, nil, nil).Once()
+
+	results, err := or.SearchMessages(context.Background(), "widget", 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, msg1, results[0].Message.Header.ID)
+	assert.Equal(t, float64(2), results[0].Score)
+	msi.AssertExpectations(t)
+}
+
+func TestSearchMessagesNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.SearchMessages(context.Background(), "widget", 10)
+	assert.Regexp(t, "FF10520", err)
+}
+
+func TestSearchMessagesNoQuery(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.search = &searchmocks.Indexer{}
+
+	_, err := or.SearchMessages(context.Background(), " ", 10)
+	assert.Regexp(t, "FF10523", err)
+}
+
+func TestSearchMessagesSearchFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msi := &searchmocks.Indexer{}
+	or.search = msi
+	msi.On("Search", mock.Anything, "widget", 10).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.SearchMessages(context.Background(), "widget", 10)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSearchMessagesGetMessagesFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msi := &searchmocks.Indexer{}
+	or.search = msi
+	msi.On("Search", mock.Anything, "widget", 10).Return([]*database.SearchHit{{Message: fftypes.NewUUID()}}, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.SearchMessages(context.Background(), "widget", 10)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSearchMessagesNoHits(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msi := &searchmocks.Indexer{}
+	or.search = msi
+	msi.On("Search", mock.Anything, "widget", 10).Return([]*database.SearchHit{}, nil)
+
+	results, err := or.SearchMessages(context.Background(), "widget", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,49 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/slomocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSLOStatus(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	msm := &slomocks.Monitor{}
+	or.slo = msm
+	msm.On("Status").Return([]*core.SLOStatus{{Type: core.SLOTypeEventDeliveryLag, Breached: true}})
+
+	statuses, err := or.GetSLOStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Breached)
+	msm.AssertExpectations(t)
+}
+
+func TestGetSLOStatusNotConfigured(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	statuses, err := or.GetSLOStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, statuses)
+}
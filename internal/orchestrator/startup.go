@@ -0,0 +1,140 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// StartupPolicy is how the namespace waits for a plugin connector to start.
+// The zero value is a single attempt, that must succeed for the namespace to start.
+type StartupPolicy struct {
+	// Wait is how long to retry before giving up on the connector
+	Wait time.Duration
+	// Optional allows the namespace to start in a degraded mode, while the connector continues to start in the background
+	Optional bool
+	// Retry is the backoff between attempts
+	Retry retry.Retry
+}
+
+func (or *orchestrator) startTokenConnectors() error {
+	for _, t := range or.plugins.Tokens {
+		name := t.Name
+		if err := or.startDependency("tokens", name, t.Startup, func() error {
+			return or.assets.StartConnector(name)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startDependency waits for a plugin connector to start, according to its policy.
+// As the namespace retries the whole of its startup on failure, dependencies that have
+// already started (or are being retried in the background) are not started again.
+func (or *orchestrator) startDependency(depType, name string, policy StartupPolicy, start func() error) error {
+	or.dependencyMux.Lock()
+	var dep *core.NamespaceStatusDependency
+	for _, d := range or.dependencies {
+		if d.Type == depType && d.Name == name {
+			dep = d
+		}
+	}
+	if dep == nil {
+		dep = &core.NamespaceStatusDependency{
+			Type:     depType,
+			Name:     name,
+			Optional: policy.Optional,
+		}
+		or.dependencies = append(or.dependencies, dep)
+	}
+	if dep.Status == core.DependencyStartStatusStarted || dep.Status == core.DependencyStartStatusPending {
+		or.dependencyMux.Unlock()
+		return nil
+	}
+	dep.Status = core.DependencyStartStatusStarting
+	or.dependencyMux.Unlock()
+
+	attempt := func(_ int) (bool, error) {
+		err := start()
+		or.dependencyMux.Lock()
+		defer or.dependencyMux.Unlock()
+		dep.Attempts++
+		if err != nil {
+			dep.LastError = err.Error()
+			return true, err
+		}
+		dep.Status = core.DependencyStartStatusStarted
+		dep.LastError = ""
+		dep.Started = fftypes.Now()
+		return false, nil
+	}
+
+	logDescription := fmt.Sprintf("start %s plugin '%s'", depType, name)
+	_, err := attempt(1)
+	if err != nil && policy.Wait > 0 {
+		waitCtx, cancel := context.WithTimeout(or.ctx, policy.Wait)
+		err = policy.Retry.Do(waitCtx, logDescription, attempt)
+		cancel()
+	}
+	if err == nil {
+		return nil
+	}
+
+	or.dependencyMux.Lock()
+	lastError := dep.LastError
+	if policy.Optional {
+		dep.Status = core.DependencyStartStatusPending
+	}
+	or.dependencyMux.Unlock()
+
+	if !policy.Optional {
+		return i18n.NewError(or.ctx, coremsgs.MsgDependencyStartupFailed, depType, name, lastError)
+	}
+	log.L(or.ctx).Warnf("Namespace starting in degraded mode - %s plugin '%s' did not start: %s", depType, name, lastError)
+	go func() {
+		// Retry until we start, or the namespace stops
+		if err := policy.Retry.Do(or.ctx, logDescription, attempt); err == nil {
+			log.L(or.ctx).Infof("Started %s plugin '%s' - namespace no longer degraded", depType, name)
+		}
+	}()
+	return nil
+}
+
+// getDependencies returns a snapshot of the startup progress of each dependency, and
+// whether any optional dependency is still pending
+func (or *orchestrator) getDependencies() (deps []*core.NamespaceStatusDependency, degraded bool) {
+	or.dependencyMux.Lock()
+	defer or.dependencyMux.Unlock()
+	for _, d := range or.dependencies {
+		dep := *d
+		deps = append(deps, &dep)
+		if d.Status == core.DependencyStartStatusPending {
+			degraded = true
+		}
+	}
+	return deps, degraded
+}
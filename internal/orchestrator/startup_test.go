@@ -0,0 +1,150 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStartupRetry = retry.Retry{
+	InitialDelay: 1 * time.Millisecond,
+	MaximumDelay: 1 * time.Millisecond,
+}
+
+func TestStartTokenConnectors(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mam.On("StartConnector", "token").Return(nil)
+
+	err := or.startTokenConnectors()
+	assert.NoError(t, err)
+
+	deps, degraded := or.getDependencies()
+	assert.False(t, degraded)
+	assert.Len(t, deps, 1)
+	assert.Equal(t, "tokens", deps[0].Type)
+	assert.Equal(t, "token", deps[0].Name)
+	assert.Equal(t, core.DependencyStartStatusStarted, deps[0].Status)
+	assert.Equal(t, 1, deps[0].Attempts)
+	assert.NotNil(t, deps[0].Started)
+
+	// Not started again on a subsequent namespace start
+	err = or.startTokenConnectors()
+	assert.NoError(t, err)
+	or.mam.AssertNumberOfCalls(t, "StartConnector", 1)
+}
+
+func TestStartTokenConnectorsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mam.On("StartConnector", "token").Return(fmt.Errorf("pop"))
+
+	err := or.startTokenConnectors()
+	assert.Regexp(t, "FF10621.*token.*pop", err)
+
+	deps, degraded := or.getDependencies()
+	assert.False(t, degraded)
+	assert.Equal(t, core.DependencyStartStatusStarting, deps[0].Status)
+	assert.Equal(t, "pop", deps[0].LastError)
+
+	// Retried on a subsequent namespace start
+	err = or.startTokenConnectors()
+	assert.Regexp(t, "FF10621", err)
+	or.mam.AssertNumberOfCalls(t, "StartConnector", 2)
+}
+
+func TestStartDependencyRetryWithinWait(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	calls := 0
+	err := or.startDependency("tokens", "token", StartupPolicy{
+		Wait:  1 * time.Minute,
+		Retry: testStartupRetry,
+	}, func() error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("pop")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	deps, _ := or.getDependencies()
+	assert.Equal(t, core.DependencyStartStatusStarted, deps[0].Status)
+	assert.Equal(t, 3, deps[0].Attempts)
+	assert.Empty(t, deps[0].LastError)
+}
+
+func TestStartDependencyWaitTimeout(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	err := or.startDependency("tokens", "token", StartupPolicy{
+		Wait:  10 * time.Millisecond,
+		Retry: testStartupRetry,
+	}, func() error {
+		return fmt.Errorf("pop")
+	})
+	assert.Regexp(t, "FF10621.*pop", err)
+
+	deps, _ := or.getDependencies()
+	assert.Greater(t, deps[0].Attempts, 1)
+}
+
+func TestStartDependencyOptionalDegraded(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	var available atomic.Bool
+	err := or.startDependency("tokens", "token", StartupPolicy{
+		Optional: true,
+		Retry:    testStartupRetry,
+	}, func() error {
+		if !available.Load() {
+			return fmt.Errorf("pop")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	deps, degraded := or.getDependencies()
+	assert.True(t, degraded)
+	assert.True(t, deps[0].Optional)
+	assert.Equal(t, core.DependencyStartStatusPending, deps[0].Status)
+
+	// Not started again while pending in the background
+	err = or.startDependency("tokens", "token", StartupPolicy{Optional: true}, func() error {
+		panic("should not be called")
+	})
+	assert.NoError(t, err)
+
+	available.Store(true)
+	assert.Eventually(t, func() bool {
+		deps, degraded := or.getDependencies()
+		return !degraded && deps[0].Status == core.DependencyStartStatusStarted
+	}, 5*time.Second, 1*time.Millisecond)
+}
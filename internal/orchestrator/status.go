@@ -20,10 +20,13 @@ import (
 	"context"
 	"database/sql/driver"
 	"encoding/json"
+	"sort"
+	"strconv"
 
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/events/system"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 )
@@ -81,15 +84,62 @@ func (or *orchestrator) getPlugins() core.NamespaceStatusPlugins {
 	}
 }
 
+func newFeature(plugins []*core.NamespaceStatusPlugin, useName bool) core.NamespaceStatusFeature {
+	feature := core.NamespaceStatusFeature{
+		Enabled: len(plugins) > 0,
+	}
+	for _, plugin := range plugins {
+		if useName {
+			feature.Plugins = append(feature.Plugins, plugin.Name)
+		} else {
+			feature.Plugins = append(feature.Plugins, plugin.PluginType)
+		}
+	}
+	sort.Strings(feature.Plugins)
+	return feature
+}
+
+func (or *orchestrator) getFeatures(plugins core.NamespaceStatusPlugins) core.NamespaceStatusFeatures {
+	// The system transport is internal, so cannot be used by applications
+	transports := make([]*core.NamespaceStatusPlugin, 0, len(plugins.Events))
+	for _, plugin := range plugins.Events {
+		if plugin.PluginType != system.SystemEventsTransport {
+			transports = append(transports, plugin)
+		}
+	}
+
+	features := core.NamespaceStatusFeatures{
+		Multiparty: core.NamespaceStatusFeature{
+			Enabled: or.config.Multiparty.Enabled,
+		},
+		Tokens:        newFeature(plugins.Tokens, true),
+		Contracts:     newFeature(plugins.Blockchain, false),
+		DataExchange:  newFeature(plugins.DataExchange, false),
+		SharedStorage: newFeature(plugins.SharedStorage, false),
+		Transports:    newFeature(transports, false),
+	}
+	if or.config.Multiparty.Enabled && or.namespace.Contracts != nil && or.namespace.Contracts.Active != nil {
+		if version := or.namespace.Contracts.Active.Info.Version; version > 0 {
+			features.Multiparty.Version = strconv.Itoa(version)
+		}
+	}
+	return features
+}
+
 func (or *orchestrator) GetStatus(ctx context.Context) (status *core.NamespaceStatus, err error) {
 
+	plugins := or.getPlugins()
 	status = &core.NamespaceStatus{
 		Namespace: or.namespace,
-		Plugins:   or.getPlugins(),
+		Plugins:   plugins,
+		Features:  or.getFeatures(plugins),
 		Multiparty: core.NamespaceStatusMultiparty{
 			Enabled: or.config.Multiparty.Enabled,
 		},
+		ReadOnly:        or.config.ReadOnly,
+		CircuitBreakers: or.operations.GetCircuitBreakers(),
 	}
+	status.Dependencies, status.Degraded = or.getDependencies()
 
 	if or.config.Multiparty.Enabled {
 		status.Node = &core.NamespaceStatusNode{Name: or.config.Multiparty.Node.Name}
@@ -198,6 +248,8 @@ func (or *orchestrator) GetMultipartyStatus(ctx context.Context) (mpStatus *core
 			Status:             core.ContractListenerStatusUnknown,
 		}
 		mpStatus.Contracts.Terminated = or.namespace.Contracts.Terminated
+		mpStatus.Contracts.Migration = or.namespace.Contracts.Migration
+		mpStatus.Contracts.Scheduled = or.namespace.Contracts.Scheduled
 		log.L(ctx).Debugf("Looking up listener status with subscription ID: %s", mpStatus.Contracts.Active.Info.Subscription)
 		ok, _, listenerStatus, err := or.blockchain().GetContractListenerStatus(ctx, or.namespace.Name, mpStatus.Contracts.Active.Info.Subscription, false)
 		if !ok || err != nil {
@@ -253,3 +305,10 @@ func (or *orchestrator) GetMultipartyStatus(ctx context.Context) (mpStatus *core
 
 	return mpStatus, nil
 }
+
+func (or *orchestrator) GetBootstrapStatus(ctx context.Context) (*core.NamespaceBootstrapStatus, error) {
+	if !or.config.Multiparty.Enabled {
+		return &core.NamespaceBootstrapStatus{Enabled: false}, nil
+	}
+	return or.networkmap.GetBootstrapStatus(ctx), nil
+}
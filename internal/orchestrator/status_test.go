@@ -78,6 +78,7 @@ var (
 func TestGetStatusRegistered(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
 
 	config.Set(coreconfig.NamespacesDefault, "default")
 
@@ -139,6 +140,7 @@ func TestGetStatusRegistered(t *testing.T) {
 func TestGetStatusVerifierLookupFail(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
 
 	config.Set(coreconfig.NamespacesDefault, "default")
 
@@ -164,6 +166,7 @@ func TestGetStatusVerifierLookupFail(t *testing.T) {
 func TestGetStatusWrongNodeOwner(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
 
 	config.Set(coreconfig.NamespacesDefault, "default")
 
@@ -216,6 +219,7 @@ func TestGetStatusWrongNodeOwner(t *testing.T) {
 func TestGetStatusUnregistered(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
 
 	config.Set(coreconfig.NamespacesDefault, "default")
 
@@ -223,6 +227,7 @@ func TestGetStatusUnregistered(t *testing.T) {
 
 	or.config.Multiparty.Org.Name = "org1"
 	or.config.Multiparty.Node.Name = "node1"
+	or.config.ReadOnly = true
 
 	or.mem.On("GetPlugins").Return(mockEventPlugins)
 
@@ -236,12 +241,14 @@ func TestGetStatusUnregistered(t *testing.T) {
 
 	assert.Equal(t, "node1", status.Node.Name)
 	assert.False(t, status.Node.Registered)
+	assert.True(t, status.ReadOnly)
 
 }
 
 func TestGetStatusOrgOnlyRegistered(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
 
 	config.Set(coreconfig.NamespacesDefault, "default")
 
@@ -293,6 +300,7 @@ func TestGetStatusOrgOnlyRegistered(t *testing.T) {
 func TestGetStatusNodeError(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
 
 	config.Set(coreconfig.NamespacesDefault, "default")
 
@@ -945,3 +953,108 @@ func TestGetMultipartyErrorActiveListenerStatus(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 
 }
+
+func TestGetBootstrapStatus(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mnm.On("GetBootstrapStatus", or.ctx).Return(&core.NamespaceBootstrapStatus{Enabled: true})
+
+	status, err := or.GetBootstrapStatus(or.ctx)
+	assert.NoError(t, err)
+	assert.True(t, status.Enabled)
+}
+
+func TestGetBootstrapStatusNonMultiparty(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.config.Multiparty.Enabled = false
+
+	status, err := or.GetBootstrapStatus(or.ctx)
+	assert.NoError(t, err)
+	assert.False(t, status.Enabled)
+}
+
+func TestGetStatusFeatures(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
+
+	or.mim.On("GetRootOrg", or.ctx).Return(nil, nil)
+	or.mem.On("GetPlugins").Return([]*core.NamespaceStatusPlugin{
+		{PluginType: "websockets"},
+		{PluginType: "system"},
+		{PluginType: "poll"},
+	})
+
+	or.config.Multiparty.Enabled = true
+	or.namespace.Contracts = &core.MultipartyContracts{
+		Active: &core.MultipartyContract{
+			Info: core.MultipartyContractInfo{Version: 2},
+		},
+	}
+
+	status, err := or.GetStatus(or.ctx)
+	assert.NoError(t, err)
+
+	features := status.Features
+	assert.True(t, features.Multiparty.Enabled)
+	assert.Equal(t, "2", features.Multiparty.Version)
+	assert.True(t, features.Tokens.Enabled)
+	assert.Equal(t, []string{"token"}, features.Tokens.Plugins)
+	assert.True(t, features.Contracts.Enabled)
+	assert.Equal(t, []string{"mock-bi"}, features.Contracts.Plugins)
+	assert.True(t, features.DataExchange.Enabled)
+	assert.True(t, features.SharedStorage.Enabled)
+	assert.True(t, features.Transports.Enabled)
+	assert.Equal(t, []string{"poll", "websockets"}, features.Transports.Plugins)
+}
+
+func TestGetStatusFeaturesGatewayMode(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
+
+	or.mem.On("GetPlugins").Return(mockEventPlugins)
+
+	or.config.Multiparty.Enabled = false
+	or.plugins.DataExchange.Plugin = nil
+	or.plugins.Tokens = nil
+
+	status, err := or.GetStatus(or.ctx)
+	assert.NoError(t, err)
+
+	features := status.Features
+	assert.False(t, features.Multiparty.Enabled)
+	assert.Empty(t, features.Multiparty.Version)
+	assert.False(t, features.Tokens.Enabled)
+	assert.Empty(t, features.Tokens.Plugins)
+	assert.False(t, features.DataExchange.Enabled)
+	assert.True(t, features.Contracts.Enabled)
+}
+
+func TestGetStatusDegraded(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mom.On("GetCircuitBreakers").Return([]*core.CircuitBreakerStatus{})
+
+	or.mem.On("GetPlugins").Return(mockEventPlugins)
+
+	or.config.Multiparty.Enabled = false
+	or.dependencies = []*core.NamespaceStatusDependency{{
+		Type:      "tokens",
+		Name:      "token",
+		Status:    core.DependencyStartStatusPending,
+		Optional:  true,
+		Attempts:  3,
+		LastError: "pop",
+	}}
+
+	status, err := or.GetStatus(or.ctx)
+	assert.NoError(t, err)
+
+	assert.True(t, status.Degraded)
+	assert.Len(t, status.Dependencies, 1)
+	assert.Equal(t, "pop", status.Dependencies[0].LastError)
+	assert.NotSame(t, or.dependencies[0], status.Dependencies[0])
+}
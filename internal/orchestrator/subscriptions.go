@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/events/poll"
 	"github.com/hyperledger/firefly/internal/events/system"
 	"github.com/hyperledger/firefly/pkg/core"
 )
@@ -138,6 +139,98 @@ func (or *orchestrator) GetSubscriptionByIDWithStatus(ctx context.Context, id st
 	return subWithStatus, nil
 }
 
+func (or *orchestrator) GetSubscriptionOffset(ctx context.Context, id string) (*core.SubscriptionOffset, error) {
+	sub, err := or.getDurableSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := or.database().GetOffset(ctx, core.OffsetTypeSubscription, sub.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	subOffset := &core.SubscriptionOffset{
+		Subscription: sub.ID,
+	}
+	if offset != nil {
+		subOffset.Current = offset.Current
+	}
+	return subOffset, nil
+}
+
+func (or *orchestrator) SetSubscriptionOffset(ctx context.Context, id string, offset *core.SubscriptionOffset) (*core.SubscriptionOffset, error) {
+	sub, err := or.getDurableSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if offset.Current < 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidSubscriptionOffset, offset.Current)
+	}
+	if err := or.events.SetDurableSubscriptionOffset(ctx, sub, offset.Current); err != nil {
+		return nil, err
+	}
+	return &core.SubscriptionOffset{
+		Subscription: sub.ID,
+		Current:      offset.Current,
+	}, nil
+}
+
+func (or *orchestrator) PollSubscriptionEvents(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
+	requestedFiltering, err := filter.Finalize()
+	if err != nil {
+		return nil, nil, err
+	}
+	events, err := or.events.PollDurableSubscriptionEvents(ctx, subscription, requestedFiltering.Limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	count := int64(len(events))
+	return events, &ffapi.FilterResult{
+		TotalCount: &count,
+	}, nil
+}
+
+func (or *orchestrator) AckSubscriptionEvents(ctx context.Context, id string, ack *core.SubscriptionEventsAck) (*core.SubscriptionOffset, error) {
+	sub, err := or.getDurableSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Transport != poll.PollTransport {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSubscriptionNotPolled, sub.ID, sub.Transport)
+	}
+	offset, err := or.database().GetOffset(ctx, core.OffsetTypeSubscription, sub.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if offset != nil && ack.Sequence <= offset.Current {
+		// Already acknowledged - the offset never moves backwards on an ack
+		return &core.SubscriptionOffset{
+			Subscription: sub.ID,
+			Current:      offset.Current,
+		}, nil
+	}
+	if ack.Sequence < 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidSubscriptionOffset, ack.Sequence)
+	}
+	if err := or.events.SetDurableSubscriptionOffset(ctx, sub, ack.Sequence); err != nil {
+		return nil, err
+	}
+	return &core.SubscriptionOffset{
+		Subscription: sub.ID,
+		Current:      ack.Sequence,
+	}, nil
+}
+
+func (or *orchestrator) getDurableSubscription(ctx context.Context, id string) (*core.Subscription, error) {
+	sub, err := or.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return sub, nil
+}
+
 func (or *orchestrator) GetSubscriptionEventsHistorical(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter, startSequence int, endSequence int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
 	if startSequence != -1 && endSequence != -1 && endSequence-startSequence > config.GetInt(coreconfig.SubscriptionMaxHistoricalEventScanLength) {
 		return nil, nil, i18n.NewError(ctx, coremsgs.MsgMaxSubscriptionEventScanLimitBreached, startSequence, endSequence)
@@ -359,6 +359,205 @@ func TestGetSGetSubscriptionsByIDWithStatusUnknownSub(t *testing.T) {
 	assert.Nil(t, subWithStatus)
 }
 
+func TestGetSubscriptionOffset(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(&core.Offset{Current: 12345}, nil)
+	offset, err := or.GetSubscriptionOffset(or.ctx, sub.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, sub.ID, offset.Subscription)
+	assert.Equal(t, int64(12345), offset.Current)
+}
+
+func TestGetSubscriptionOffsetNotStored(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(nil, nil)
+	offset, err := or.GetSubscriptionOffset(or.ctx, sub.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), offset.Current)
+}
+
+func TestGetSubscriptionOffsetFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(nil, fmt.Errorf("pop"))
+	_, err := or.GetSubscriptionOffset(or.ctx, sub.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetSubscriptionOffsetBadUUID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.GetSubscriptionOffset(or.ctx, "! a UUID")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestSetSubscriptionOffset(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mem.On("SetDurableSubscriptionOffset", mock.Anything, sub, int64(12345)).Return(nil)
+	offset, err := or.SetSubscriptionOffset(or.ctx, sub.ID.String(), &core.SubscriptionOffset{Current: 12345})
+	assert.NoError(t, err)
+	assert.Equal(t, sub.ID, offset.Subscription)
+	assert.Equal(t, int64(12345), offset.Current)
+}
+
+func TestSetSubscriptionOffsetNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	_, err := or.SetSubscriptionOffset(or.ctx, fftypes.NewUUID().String(), &core.SubscriptionOffset{Current: 12345})
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestSetSubscriptionOffsetNegative(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	_, err := or.SetSubscriptionOffset(or.ctx, sub.ID.String(), &core.SubscriptionOffset{Current: -1})
+	assert.Regexp(t, "FF10618", err)
+}
+
+func TestSetSubscriptionOffsetFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mem.On("SetDurableSubscriptionOffset", mock.Anything, sub, int64(12345)).Return(fmt.Errorf("pop"))
+	_, err := or.SetSubscriptionOffset(or.ctx, sub.ID.String(), &core.SubscriptionOffset{Current: 12345})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPollSubscriptionEvents(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "poll"}
+	or.mem.On("PollDurableSubscriptionEvents", mock.Anything, sub, uint64(10)).Return([]*core.EnrichedEvent{{}, {}}, nil)
+	fb := database.EventQueryFactory.NewFilter(or.ctx)
+	events, fr, err := or.PollSubscriptionEvents(or.ctx, sub, fb.And().Limit(10))
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, int64(2), *fr.TotalCount)
+}
+
+func TestPollSubscriptionEventsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "poll"}
+	or.mem.On("PollDurableSubscriptionEvents", mock.Anything, sub, uint64(10)).Return(nil, fmt.Errorf("pop"))
+	fb := database.EventQueryFactory.NewFilter(or.ctx)
+	_, _, err := or.PollSubscriptionEvents(or.ctx, sub, fb.And().Limit(10))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPollSubscriptionEventsBadFilter(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "poll"}
+	fb := database.EventQueryFactory.NewFilter(or.ctx)
+	_, _, err := or.PollSubscriptionEvents(or.ctx, sub, fb.And(fb.Eq("wrong", "field")))
+	assert.Regexp(t, "FF00142", err)
+}
+
+func TestAckSubscriptionEvents(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "poll"}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(&core.Offset{Current: 10}, nil)
+	or.mem.On("SetDurableSubscriptionOffset", mock.Anything, sub, int64(20)).Return(nil)
+	offset, err := or.AckSubscriptionEvents(or.ctx, sub.ID.String(), &core.SubscriptionEventsAck{Sequence: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), offset.Current)
+}
+
+func TestAckSubscriptionEventsAlreadyAcked(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "poll"}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(&core.Offset{Current: 30}, nil)
+	offset, err := or.AckSubscriptionEvents(or.ctx, sub.ID.String(), &core.SubscriptionEventsAck{Sequence: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), offset.Current)
+}
+
+func TestAckSubscriptionEventsNegative(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "poll"}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(nil, nil)
+	_, err := or.AckSubscriptionEvents(or.ctx, sub.ID.String(), &core.SubscriptionEventsAck{Sequence: -5})
+	assert.Regexp(t, "FF10618", err)
+}
+
+func TestAckSubscriptionEventsNotPolled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "websockets"}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	_, err := or.AckSubscriptionEvents(or.ctx, sub.ID.String(), &core.SubscriptionEventsAck{Sequence: 20})
+	assert.Regexp(t, "FF10620", err)
+}
+
+func TestAckSubscriptionEventsNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	_, err := or.AckSubscriptionEvents(or.ctx, fftypes.NewUUID().String(), &core.SubscriptionEventsAck{Sequence: 20})
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestAckSubscriptionEventsGetOffsetFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "poll"}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(nil, fmt.Errorf("pop"))
+	_, err := or.AckSubscriptionEvents(or.ctx, sub.ID.String(), &core.SubscriptionEventsAck{Sequence: 20})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAckSubscriptionEventsSetFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Name: "sub1", Namespace: "ns"}, Transport: "poll"}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, sub.ID.String()).Return(nil, nil)
+	or.mem.On("SetDurableSubscriptionOffset", mock.Anything, sub, int64(20)).Return(fmt.Errorf("pop"))
+	_, err := or.AckSubscriptionEvents(or.ctx, sub.ID.String(), &core.SubscriptionEventsAck{Sequence: 20})
+	assert.EqualError(t, err, "pop")
+}
+
 func generateFakeEvents(eventCount int) ([]*core.Event, []*core.EnrichedEvent) {
 	baseEvents := []*core.Event{}
 	enrichedEvents := []*core.EnrichedEvent{}
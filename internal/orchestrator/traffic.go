@@ -0,0 +1,96 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/traffic"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// trafficPageSize is the number of traffic buckets read from the database at a time, when building a matrix
+const trafficPageSize = 1000
+
+// GetTrafficMatrix totals the traffic exchanged with each counterparty org over a time window, from the hourly
+// buckets maintained by the traffic aggregator. The window defaults to the configured period up to now, and its
+// start is rounded down to the bucket it falls in.
+func (or *orchestrator) GetTrafficMatrix(ctx context.Context, startTime, endTime *fftypes.FFTime) (*core.TrafficMatrix, error) {
+	if or.traffic == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgTrafficNotEnabled)
+	}
+	if endTime == nil {
+		endTime = fftypes.Now()
+	}
+	if startTime == nil {
+		start := fftypes.FFTime(endTime.Time().Add(-config.GetDuration(coreconfig.TrafficDefaultWindow)))
+		startTime = &start
+	}
+	if !startTime.Time().Before(*endTime.Time()) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgHistogramInvalidTimes)
+	}
+	localOrg, err := or.identity.GetRootOrgDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &core.TrafficMatrix{
+		Local:          localOrg,
+		StartTime:      traffic.Bucket(startTime),
+		EndTime:        endTime,
+		Counterparties: []*core.TrafficCounterparty{},
+	}
+	byCounterparty := make(map[string]*core.TrafficCounterparty)
+	fb := database.TrafficStatsQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Gte("bucket", matrix.StartTime),
+		fb.Lt("bucket", endTime),
+	).Sort("bucket")
+	for skip := uint64(0); ; skip += trafficPageSize {
+		stats, _, err := or.database().GetTrafficStats(ctx, or.namespace.Name, filter.Skip(skip).Limit(trafficPageSize))
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stats {
+			row := byCounterparty[s.Counterparty]
+			if row == nil {
+				row = &core.TrafficCounterparty{Counterparty: s.Counterparty}
+				byCounterparty[s.Counterparty] = row
+				matrix.Counterparties = append(matrix.Counterparties, row)
+			}
+			if s.Direction == core.TrafficDirectionSent {
+				row.Sent.Add(s)
+			} else {
+				row.Received.Add(s)
+			}
+		}
+		if len(stats) < trafficPageSize {
+			break
+		}
+	}
+	sort.Slice(matrix.Counterparties, func(i, j int) bool {
+		return matrix.Counterparties[i].Counterparty < matrix.Counterparties[j].Counterparty
+	})
+	return matrix, nil
+}
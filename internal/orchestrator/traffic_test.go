@@ -0,0 +1,134 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/trafficmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestTrafficStats(counterparty string, direction core.TrafficDirection, messages, messageBytes, transfers int64) *core.TrafficStats {
+	return &core.TrafficStats{
+		Namespace:    "ns",
+		Bucket:       fftypes.Now(),
+		Counterparty: counterparty,
+		Direction:    direction,
+		Messages:     messages,
+		MessageBytes: messageBytes,
+		Transfers:    transfers,
+	}
+}
+
+func TestGetTrafficMatrix(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.traffic = &trafficmocks.Aggregator{}
+
+	page1 := make([]*core.TrafficStats, trafficPageSize)
+	for i := range page1 {
+		page1[i] = newTestTrafficStats("did:firefly:org/org3", core.TrafficDirectionReceived, 1, 10, 0)
+	}
+	page2 := []*core.TrafficStats{
+		newTestTrafficStats("did:firefly:org/org2", core.TrafficDirectionSent, 2, 200, 1),
+		newTestTrafficStats("did:firefly:org/org2", core.TrafficDirectionSent, 1, 100, 0),
+		newTestTrafficStats("did:firefly:org/org2", core.TrafficDirectionReceived, 0, 0, 3),
+	}
+	or.mim.On("GetRootOrgDID", mock.Anything).Return("did:firefly:org/org1", nil)
+	or.mdi.On("GetTrafficStats", mock.Anything, "ns", mock.Anything).Return(page1, nil, nil).Once()
+	or.mdi.On("GetTrafficStats", mock.Anything, "ns", mock.Anything).Return(page2, nil, nil).Once()
+
+	startTime := fftypes.FFTime(time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC))
+	endTime := fftypes.FFTime(time.Date(2024, 3, 2, 10, 30, 0, 0, time.UTC))
+	matrix, err := or.GetTrafficMatrix(or.ctx, &startTime, &endTime)
+	assert.NoError(t, err)
+	assert.Equal(t, "did:firefly:org/org1", matrix.Local)
+	assert.Equal(t, "2024-03-01T10:00:00Z", matrix.StartTime.String())
+	assert.Equal(t, "2024-03-02T10:30:00Z", matrix.EndTime.String())
+	assert.Len(t, matrix.Counterparties, 2)
+
+	assert.Equal(t, "did:firefly:org/org2", matrix.Counterparties[0].Counterparty)
+	assert.Equal(t, core.TrafficTotals{Messages: 3, MessageBytes: 300, Transfers: 1}, matrix.Counterparties[0].Sent)
+	assert.Equal(t, core.TrafficTotals{Transfers: 3}, matrix.Counterparties[0].Received)
+
+	assert.Equal(t, "did:firefly:org/org3", matrix.Counterparties[1].Counterparty)
+	assert.Equal(t, core.TrafficTotals{}, matrix.Counterparties[1].Sent)
+	assert.Equal(t, core.TrafficTotals{Messages: trafficPageSize, MessageBytes: 10 * trafficPageSize}, matrix.Counterparties[1].Received)
+}
+
+func TestGetTrafficMatrixDefaultWindow(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.traffic = &trafficmocks.Aggregator{}
+
+	or.mim.On("GetRootOrgDID", mock.Anything).Return("did:firefly:org/org1", nil)
+	or.mdi.On("GetTrafficStats", mock.Anything, "ns", mock.Anything).Return([]*core.TrafficStats{}, nil, nil)
+
+	matrix, err := or.GetTrafficMatrix(or.ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, matrix.Counterparties)
+	assert.Equal(t, 24*time.Hour, matrix.EndTime.Time().Sub(*matrix.StartTime.Time()).Truncate(time.Hour))
+}
+
+func TestGetTrafficMatrixNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.GetTrafficMatrix(or.ctx, nil, nil)
+	assert.Regexp(t, "FF10644", err)
+}
+
+func TestGetTrafficMatrixBadWindow(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.traffic = &trafficmocks.Aggregator{}
+
+	endTime := fftypes.Now()
+	_, err := or.GetTrafficMatrix(or.ctx, endTime, endTime)
+	assert.Regexp(t, "FF10300", err)
+}
+
+func TestGetTrafficMatrixRootOrgFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.traffic = &trafficmocks.Aggregator{}
+
+	or.mim.On("GetRootOrgDID", mock.Anything).Return("", fmt.Errorf("pop"))
+
+	_, err := or.GetTrafficMatrix(or.ctx, nil, nil)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetTrafficMatrixQueryFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.traffic = &trafficmocks.Aggregator{}
+
+	or.mim.On("GetRootOrgDID", mock.Anything).Return("did:firefly:org/org1", nil)
+	or.mdi.On("GetTrafficStats", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.GetTrafficMatrix(or.ctx, nil, nil)
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/policy"
+)
+
+// OPA queries a policy decision from an Open Policy Agent server, using its data API. The request
+// context is passed as the input document, and the decision can be either a boolean, or an object
+// with an "allow" boolean and an optional "reason" string.
+type OPA struct {
+	ctx      context.Context
+	client   *resty.Client
+	decision string
+	failOpen bool
+}
+
+type dataRequest struct {
+	Input *policy.Request `json:"input"`
+}
+
+type dataResponse struct {
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+func (o *OPA) Name() string {
+	return "opa"
+}
+
+func (o *OPA) Init(ctx context.Context, config config.Section) (err error) {
+	o.ctx = log.WithLogField(ctx, "policy", "opa")
+
+	if config.GetString(ffresty.HTTPConfigURL) == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(ffresty.HTTPConfigURL), "opa")
+	}
+	o.client, err = ffresty.New(o.ctx, config)
+	if err != nil {
+		return err
+	}
+	o.decision = strings.Trim(config.GetString(OPAConfDecision), "/")
+	o.failOpen = config.GetBool(OPAConfFailOpen)
+	return nil
+}
+
+func (o *OPA) Decide(ctx context.Context, req *policy.Request) (*policy.Decision, error) {
+	decision, err := o.query(ctx, req)
+	if err != nil && o.failOpen {
+		log.L(ctx).Warnf("Allowing %s %s in namespace '%s' as the policy decision failed: %s", req.Method, req.Path, req.Namespace, err)
+		return &policy.Decision{Allow: true}, nil
+	}
+	return decision, err
+}
+
+func (o *OPA) query(ctx context.Context, req *policy.Request) (*policy.Decision, error) {
+	var data dataResponse
+	res, err := o.client.R().SetContext(ctx).
+		SetBody(&dataRequest{Input: req}).
+		SetResult(&data).
+		Post("/v1/data/" + o.decision)
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgPolicyRequestFailed)
+	}
+	if len(data.Result) == 0 {
+		// OPA returns no result when the decision is not defined by any loaded policy
+		return nil, i18n.NewError(ctx, coremsgs.MsgPolicyDecisionUndefined, o.decision)
+	}
+	var allow bool
+	if err := json.Unmarshal(data.Result, &allow); err == nil {
+		return &policy.Decision{Allow: allow}, nil
+	}
+	var decision policy.Decision
+	if err := json.Unmarshal(data.Result, &decision); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgPolicyRequestFailed, string(data.Result))
+	}
+	return &decision, nil
+}
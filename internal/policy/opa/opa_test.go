@@ -0,0 +1,151 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("policy_opa_unit_tests")
+
+func newTestOPA(t *testing.T, failOpen bool, handler http.HandlerFunc) *OPA {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	coreconfig.Reset()
+	o := &OPA{}
+	o.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	utConfig.Set(OPAConfFailOpen, failOpen)
+	err := o.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "opa", o.Name())
+	return o
+}
+
+func testRequest() *policy.Request {
+	return &policy.Request{
+		Namespace: "ns1",
+		Identity:  "alice",
+		Method:    http.MethodPost,
+		Route:     "postNewMessageBroadcast",
+		Path:      "/api/v1/namespaces/ns1/messages/broadcast",
+		Payload: &policy.PayloadSummary{
+			ContentType: "application/json",
+			Size:        42,
+			Fields:      map[string]interface{}{"header.tag": "trade"},
+		},
+	}
+}
+
+func TestDecideAllowBool(t *testing.T) {
+	o := newTestOPA(t, false, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/data/firefly/allow", req.URL.Path)
+		var body dataRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, "ns1", body.Input.Namespace)
+		assert.Equal(t, "alice", body.Input.Identity)
+		assert.Equal(t, "trade", body.Input.Payload.Fields["header.tag"])
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"result":true}`))
+	})
+
+	decision, err := o.Decide(context.Background(), testRequest())
+	assert.NoError(t, err)
+	assert.True(t, decision.Allow)
+}
+
+func TestDecideDenyObject(t *testing.T) {
+	o := newTestOPA(t, false, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"result":{"allow":false,"reason":"tag not permitted"}}`))
+	})
+
+	decision, err := o.Decide(context.Background(), testRequest())
+	assert.NoError(t, err)
+	assert.False(t, decision.Allow)
+	assert.Equal(t, "tag not permitted", decision.Reason)
+}
+
+func TestDecideBadResult(t *testing.T) {
+	o := newTestOPA(t, false, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"result":"yes"}`))
+	})
+
+	_, err := o.Decide(context.Background(), testRequest())
+	assert.Regexp(t, "FF10636", err)
+}
+
+func TestDecideUndefined(t *testing.T) {
+	o := newTestOPA(t, false, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	_, err := o.Decide(context.Background(), testRequest())
+	assert.Regexp(t, "FF10637.*firefly/allow", err)
+}
+
+func TestDecideFail(t *testing.T) {
+	o := newTestOPA(t, false, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := o.Decide(context.Background(), testRequest())
+	assert.Regexp(t, "FF10636", err)
+}
+
+func TestDecideFailOpen(t *testing.T) {
+	o := newTestOPA(t, true, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	decision, err := o.Decide(context.Background(), testRequest())
+	assert.NoError(t, err)
+	assert.True(t, decision.Allow)
+}
+
+func TestInitMissingURL(t *testing.T) {
+	coreconfig.Reset()
+	o := &OPA{}
+	o.InitConfig(utConfig)
+	err := o.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestInitBadTLS(t *testing.T) {
+	coreconfig.Reset()
+	o := &OPA{}
+	o.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, "https://opa.example.com")
+	tlsConf := utConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := o.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
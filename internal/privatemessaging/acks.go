@@ -0,0 +1,169 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (pm *privateMessaging) newBatchAckNotification(batchID *fftypes.UUID, state core.BatchAckState) *core.BatchAckNotification {
+	return &core.BatchAckNotification{
+		Namespace: pm.namespace.NetworkName,
+		Batch:     batchID,
+		State:     state,
+	}
+}
+
+func (pm *privateMessaging) recordBatchAck(ctx context.Context, batchID, nodeID *fftypes.UUID, state core.BatchAckState) error {
+	now := fftypes.Now()
+	return pm.database.UpsertBatchAck(ctx, &core.BatchAck{
+		Namespace: pm.namespace.Name,
+		Batch:     batchID,
+		Node:      nodeID,
+		State:     state,
+		Created:   now,
+		Updated:   now,
+	})
+}
+
+// batchSendUpdated records the transfer result for a batch sent to a recipient. If the recipient's FireFly core
+// returned a manifest (which has already been checked against ours) then we know it received the batch.
+func (pm *privateMessaging) batchSendUpdated(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
+	if update.Status != core.OpStatusSucceeded {
+		return nil
+	}
+	nodeID, _, batchID, err := retrieveBatchSendInputs(ctx, op)
+	if err != nil {
+		log.L(ctx).Warnf("Unable to record acknowledgement for operation '%s': %s", op.ID, err)
+		return nil
+	}
+	state := core.BatchAckStateTransferred
+	if update.DXManifest != "" {
+		state = core.BatchAckStateReceived
+	}
+	return pm.recordBatchAck(ctx, batchID, nodeID, state)
+}
+
+// SendBatchAck lets the sender of a private batch we received know how far we have got with processing it.
+// Acknowledgements are best-effort, and are only sent when enabled in the configuration.
+func (pm *privateMessaging) SendBatchAck(ctx context.Context, batchID *fftypes.UUID, state core.BatchAckState) error {
+	if !pm.sendAcks {
+		return nil
+	}
+	l := log.L(ctx)
+
+	batch, err := pm.database.GetBatchByID(ctx, pm.namespace.Name, batchID)
+	if err != nil {
+		return err
+	}
+	if batch == nil || batch.Type != core.BatchTypePrivate {
+		l.Debugf("No private batch '%s' to acknowledge", batchID)
+		return nil
+	}
+
+	localNode, err := pm.identity.GetLocalNode(ctx)
+	if err != nil {
+		return err
+	}
+	if batch.Node.Equals(localNode.ID) {
+		// We sent this batch ourselves
+		return nil
+	}
+	node, err := pm.identity.CachedIdentityLookupByID(ctx, batch.Node)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		l.Warnf("Unable to acknowledge batch '%s' as sending node '%s' could not be found", batchID, batch.Node)
+		return nil
+	}
+
+	op := core.NewOperation(
+		pm.exchange,
+		pm.namespace.Name,
+		batch.TX.ID,
+		core.OpTypeDataExchangeSendBatchAck)
+	addBatchAckInputs(op, node.ID, batch.ID, state)
+	if err = pm.operations.AddOrReuseOperation(ctx, op); err != nil {
+		return err
+	}
+
+	l.Debugf("Acknowledging batch '%s' to node '%s' state=%s", batchID, node.ID, state)
+	_, err = pm.operations.RunOperation(ctx, opSendBatchAck(op, node, pm.newBatchAckNotification(batch.ID, state)), false /* acks do not use idempotency keys */)
+	return err
+}
+
+// BatchAckReceived records an acknowledgement from the recipient of a private batch we sent. Only the states
+// a recipient can vouch for itself are accepted, and only from a member of the group the batch was sent to.
+func (pm *privateMessaging) BatchAckReceived(ctx context.Context, peerID string, ack *core.BatchAckNotification) error {
+	l := log.L(ctx)
+
+	if ack.Namespace != pm.namespace.NetworkName {
+		l.Debugf("Ignoring batch acknowledgement from different namespace '%s'", ack.Namespace)
+		return nil
+	}
+	if ack.Batch == nil || (ack.State != core.BatchAckStatePersisted && ack.State != core.BatchAckStateAggregated) {
+		l.Errorf("Invalid batch acknowledgement from peer '%s': batch=%s state=%s", peerID, ack.Batch, ack.State)
+		return nil
+	}
+
+	node, err := pm.identity.FindIdentityForVerifier(ctx, []core.IdentityType{core.IdentityTypeNode}, &core.VerifierRef{
+		Type:  core.VerifierTypeFFDXPeerID,
+		Value: peerID,
+	})
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		l.Errorf("Peer '%s' could not be resolved", peerID)
+		return nil
+	}
+
+	batch, err := pm.database.GetBatchByID(ctx, pm.namespace.Name, ack.Batch)
+	if err != nil {
+		return err
+	}
+	localNode, err := pm.identity.GetLocalNode(ctx)
+	if err != nil {
+		return err
+	}
+	if batch == nil || batch.Type != core.BatchTypePrivate || !batch.Node.Equals(localNode.ID) {
+		l.Warnf("Ignoring acknowledgement from peer '%s' for batch '%s', which was not sent by this node", peerID, ack.Batch)
+		return nil
+	}
+
+	group, err := pm.database.GetGroupByHash(ctx, pm.namespace.Name, batch.Group)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		l.Warnf("Ignoring acknowledgement for batch '%s' as group '%s' could not be found", ack.Batch, batch.Group)
+		return nil
+	}
+	for _, member := range group.Members {
+		if member.Node.Equals(node.ID) {
+			l.Infof("Batch '%s' acknowledged by node '%s' state=%s", ack.Batch, node.ID, ack.State)
+			return pm.recordBatchAck(ctx, ack.Batch, node.ID, ack.State)
+		}
+	}
+	l.Warnf("Ignoring acknowledgement for batch '%s' from node '%s', which is not a member of group '%s'", ack.Batch, node.ID, batch.Group)
+	return nil
+}
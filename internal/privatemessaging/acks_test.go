@@ -0,0 +1,436 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/operationmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestBatchSendOp() (*core.Operation, *fftypes.UUID, *fftypes.UUID) {
+	nodeID := fftypes.NewUUID()
+	batchID := fftypes.NewUUID()
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeDataExchangeSendBatch,
+	}
+	addBatchSendInputs(op, nodeID, fftypes.NewRandB32(), batchID)
+	return op, nodeID, batchID
+}
+
+func ackMatcher(batchID, nodeID *fftypes.UUID, state core.BatchAckState) interface{} {
+	return mock.MatchedBy(func(ack *core.BatchAck) bool {
+		return ack.Namespace == "ns1" &&
+			ack.Batch.Equals(batchID) &&
+			ack.Node.Equals(nodeID) &&
+			ack.State == state
+	})
+}
+
+func TestBatchSendUpdatedReceived(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op, nodeID, batchID := newTestBatchSendOp()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("UpsertBatchAck", context.Background(), ackMatcher(batchID, nodeID, core.BatchAckStateReceived)).Return(nil)
+
+	err := pm.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status:         core.OpStatusSucceeded,
+		VerifyManifest: true,
+		DXManifest:     `{"manifest":true}`,
+	})
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBatchSendUpdatedTransferred(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op, nodeID, batchID := newTestBatchSendOp()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("UpsertBatchAck", context.Background(), ackMatcher(batchID, nodeID, core.BatchAckStateTransferred)).Return(fmt.Errorf("pop"))
+
+	err := pm.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBatchSendUpdatedFailedOrBadInput(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op, _, _ := newTestBatchSendOp()
+	err := pm.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status: core.OpStatusFailed,
+	})
+	assert.NoError(t, err)
+
+	op.Input = fftypes.JSONObject{}
+	err = pm.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+	})
+	assert.NoError(t, err)
+}
+
+func TestSendBatchAckDisabled(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	err := pm.SendBatchAck(context.Background(), fftypes.NewUUID(), core.BatchAckStatePersisted)
+	assert.NoError(t, err)
+}
+
+func TestSendBatchAckOk(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+	pm.sendAcks = true
+
+	node := &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}}
+	bp := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{
+			ID:   fftypes.NewUUID(),
+			Type: core.BatchTypePrivate,
+			Node: node.ID,
+		},
+		TX: core.TransactionRef{ID: fftypes.NewUUID()},
+	}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mom := pm.operations.(*operationmocks.Manager)
+	mdi.On("GetBatchByID", context.Background(), "ns1", bp.ID).Return(bp, nil)
+	mim.On("GetLocalNode", context.Background()).Return(&core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}}, nil)
+	mim.On("CachedIdentityLookupByID", context.Background(), node.ID).Return(node, nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeDataExchangeSendBatchAck &&
+			op.Transaction.Equals(bp.TX.ID) &&
+			op.Input.GetString("state") == "aggregated"
+	})).Return(nil)
+	mom.On("RunOperation", context.Background(), mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(batchAckSendData)
+		return data.Node == node &&
+			data.Ack.Namespace == "ns1-remote" &&
+			data.Ack.Batch.Equals(bp.ID) &&
+			data.Ack.State == core.BatchAckStateAggregated
+	}), false).Return(nil, nil)
+
+	err := pm.SendBatchAck(context.Background(), bp.ID, core.BatchAckStateAggregated)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestSendBatchAckAddOpFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+	pm.sendAcks = true
+
+	node := &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}}
+	bp := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{
+			ID:   fftypes.NewUUID(),
+			Type: core.BatchTypePrivate,
+			Node: node.ID,
+		},
+	}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mom := pm.operations.(*operationmocks.Manager)
+	mdi.On("GetBatchByID", context.Background(), "ns1", bp.ID).Return(bp, nil)
+	mim.On("GetLocalNode", context.Background()).Return(&core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}}, nil)
+	mim.On("CachedIdentityLookupByID", context.Background(), node.ID).Return(node, nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := pm.SendBatchAck(context.Background(), bp.ID, core.BatchAckStatePersisted)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestSendBatchAckSkipped(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+	pm.sendAcks = true
+
+	localNode := &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}}
+	missingID := fftypes.NewUUID()
+	broadcast := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Type: core.BatchTypeBroadcast},
+	}
+	ours := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Type: core.BatchTypePrivate, Node: localNode.ID},
+	}
+	unknownNode := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Type: core.BatchTypePrivate, Node: fftypes.NewUUID()},
+	}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetBatchByID", context.Background(), "ns1", missingID).Return(nil, nil)
+	mdi.On("GetBatchByID", context.Background(), "ns1", broadcast.ID).Return(broadcast, nil)
+	mdi.On("GetBatchByID", context.Background(), "ns1", ours.ID).Return(ours, nil)
+	mdi.On("GetBatchByID", context.Background(), "ns1", unknownNode.ID).Return(unknownNode, nil)
+	mim.On("GetLocalNode", context.Background()).Return(localNode, nil)
+	mim.On("CachedIdentityLookupByID", context.Background(), unknownNode.Node).Return(nil, nil)
+
+	for _, batchID := range []*fftypes.UUID{missingID, broadcast.ID, ours.ID, unknownNode.ID} {
+		err := pm.SendBatchAck(context.Background(), batchID, core.BatchAckStatePersisted)
+		assert.NoError(t, err)
+	}
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestSendBatchAckLookupFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+	pm.sendAcks = true
+
+	bp := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Type: core.BatchTypePrivate, Node: fftypes.NewUUID()},
+	}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetBatchByID", context.Background(), "ns1", bp.ID).Return(nil, fmt.Errorf("pop")).Once()
+	err := pm.SendBatchAck(context.Background(), bp.ID, core.BatchAckStatePersisted)
+	assert.EqualError(t, err, "pop")
+
+	mdi.On("GetBatchByID", context.Background(), "ns1", bp.ID).Return(bp, nil)
+	mim.On("GetLocalNode", context.Background()).Return(nil, fmt.Errorf("pop")).Once()
+	err = pm.SendBatchAck(context.Background(), bp.ID, core.BatchAckStatePersisted)
+	assert.EqualError(t, err, "pop")
+
+	mim.On("GetLocalNode", context.Background()).Return(&core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}}, nil)
+	mim.On("CachedIdentityLookupByID", context.Background(), bp.Node).Return(nil, fmt.Errorf("pop"))
+	err = pm.SendBatchAck(context.Background(), bp.ID, core.BatchAckStatePersisted)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+type testAckReceived struct {
+	pm        *privateMessaging
+	node      *core.Identity
+	localNode *core.Identity
+	batch     *core.BatchPersisted
+	group     *core.Group
+	mdi       *databasemocks.Plugin
+	mim       *identitymanagermocks.Manager
+}
+
+func newTestAckReceived(t *testing.T) (*testAckReceived, func()) {
+	pm, cancel := newTestPrivateMessaging(t)
+	ta := &testAckReceived{
+		pm:        pm,
+		node:      &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}},
+		localNode: &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID()}},
+		mdi:       pm.database.(*databasemocks.Plugin),
+		mim:       pm.identity.(*identitymanagermocks.Manager),
+	}
+	ta.group = &core.Group{
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{
+				{Identity: "did:firefly:org/org1", Node: ta.localNode.ID},
+				{Identity: "did:firefly:org/org2", Node: ta.node.ID},
+			},
+		},
+		Hash: fftypes.NewRandB32(),
+	}
+	ta.batch = &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{
+			ID:    fftypes.NewUUID(),
+			Type:  core.BatchTypePrivate,
+			Node:  ta.localNode.ID,
+			Group: ta.group.Hash,
+		},
+	}
+	return ta, func() {
+		cancel()
+		ta.mdi.AssertExpectations(t)
+		ta.mim.AssertExpectations(t)
+	}
+}
+
+func (ta *testAckReceived) mockPeer(node *core.Identity, err error) {
+	ta.mim.On("FindIdentityForVerifier", context.Background(), []core.IdentityType{core.IdentityTypeNode}, &core.VerifierRef{
+		Type:  core.VerifierTypeFFDXPeerID,
+		Value: "peer2",
+	}).Return(node, err)
+}
+
+func (ta *testAckReceived) ack(state core.BatchAckState) *core.BatchAckNotification {
+	return &core.BatchAckNotification{
+		Namespace: "ns1-remote",
+		Batch:     ta.batch.ID,
+		State:     state,
+	}
+}
+
+func TestBatchAckReceivedOk(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.mockPeer(ta.node, nil)
+	ta.mdi.On("GetBatchByID", context.Background(), "ns1", ta.batch.ID).Return(ta.batch, nil)
+	ta.mim.On("GetLocalNode", context.Background()).Return(ta.localNode, nil)
+	ta.mdi.On("GetGroupByHash", context.Background(), "ns1", ta.group.Hash).Return(ta.group, nil)
+	ta.mdi.On("UpsertBatchAck", context.Background(), ackMatcher(ta.batch.ID, ta.node.ID, core.BatchAckStatePersisted)).Return(nil)
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStatePersisted))
+	assert.NoError(t, err)
+}
+
+func TestBatchAckReceivedInvalid(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", &core.BatchAckNotification{
+		Namespace: "other",
+		Batch:     ta.batch.ID,
+		State:     core.BatchAckStatePersisted,
+	})
+	assert.NoError(t, err)
+
+	// The recipient cannot vouch for the data exchange transfer
+	err = ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateTransferred))
+	assert.NoError(t, err)
+
+	err = ta.pm.BatchAckReceived(context.Background(), "peer2", &core.BatchAckNotification{
+		Namespace: "ns1-remote",
+		State:     core.BatchAckStatePersisted,
+	})
+	assert.NoError(t, err)
+}
+
+func TestBatchAckReceivedPeerUnknown(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.mockPeer(nil, nil)
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateAggregated))
+	assert.NoError(t, err)
+}
+
+func TestBatchAckReceivedPeerLookupFail(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.mockPeer(nil, fmt.Errorf("pop"))
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateAggregated))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBatchAckReceivedBatchLookupFail(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.mockPeer(ta.node, nil)
+	ta.mdi.On("GetBatchByID", context.Background(), "ns1", ta.batch.ID).Return(nil, fmt.Errorf("pop"))
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateAggregated))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBatchAckReceivedLocalNodeFail(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.mockPeer(ta.node, nil)
+	ta.mdi.On("GetBatchByID", context.Background(), "ns1", ta.batch.ID).Return(ta.batch, nil)
+	ta.mim.On("GetLocalNode", context.Background()).Return(nil, fmt.Errorf("pop"))
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateAggregated))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBatchAckReceivedNotOurBatch(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.batch.Node = ta.node.ID
+	ta.mockPeer(ta.node, nil)
+	ta.mdi.On("GetBatchByID", context.Background(), "ns1", ta.batch.ID).Return(ta.batch, nil)
+	ta.mim.On("GetLocalNode", context.Background()).Return(ta.localNode, nil)
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateAggregated))
+	assert.NoError(t, err)
+}
+
+func TestBatchAckReceivedGroupFail(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.mockPeer(ta.node, nil)
+	ta.mdi.On("GetBatchByID", context.Background(), "ns1", ta.batch.ID).Return(ta.batch, nil)
+	ta.mim.On("GetLocalNode", context.Background()).Return(ta.localNode, nil)
+	ta.mdi.On("GetGroupByHash", context.Background(), "ns1", ta.group.Hash).Return(nil, fmt.Errorf("pop"))
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateAggregated))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestBatchAckReceivedGroupNotFound(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.mockPeer(ta.node, nil)
+	ta.mdi.On("GetBatchByID", context.Background(), "ns1", ta.batch.ID).Return(ta.batch, nil)
+	ta.mim.On("GetLocalNode", context.Background()).Return(ta.localNode, nil)
+	ta.mdi.On("GetGroupByHash", context.Background(), "ns1", ta.group.Hash).Return(nil, nil)
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateAggregated))
+	assert.NoError(t, err)
+}
+
+func TestBatchAckReceivedNotMember(t *testing.T) {
+	ta, done := newTestAckReceived(t)
+	defer done()
+
+	ta.group.Members = ta.group.Members[0:1]
+	ta.mockPeer(ta.node, nil)
+	ta.mdi.On("GetBatchByID", context.Background(), "ns1", ta.batch.ID).Return(ta.batch, nil)
+	ta.mim.On("GetLocalNode", context.Background()).Return(ta.localNode, nil)
+	ta.mdi.On("GetGroupByHash", context.Background(), "ns1", ta.group.Hash).Return(ta.group, nil)
+
+	err := ta.pm.BatchAckReceived(context.Background(), "peer2", ta.ack(core.BatchAckStateAggregated))
+	assert.NoError(t, err)
+}
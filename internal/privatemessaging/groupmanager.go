@@ -37,6 +37,8 @@ type GroupManager interface {
 	GetGroups(ctx context.Context, filter ffapi.AndFilter) ([]*core.Group, *ffapi.FilterResult, error)
 	ResolveInitGroup(ctx context.Context, msg *core.Message, creator *core.Member) (*core.Group, error)
 	EnsureLocalGroup(ctx context.Context, group *core.Group, creator *core.Member) (ok bool, err error)
+	UpdateGroupMetadata(ctx context.Context, hash string, metadata *core.GroupMetadata) (*core.Message, error)
+	HandleGroupMetadataUpdate(ctx context.Context, msg *core.Message, data core.DataArray) (core.MessageAction, error)
 }
 
 type groupManager struct {
@@ -272,3 +274,122 @@ func (gm *groupManager) groupContains(ctx context.Context, group *core.Group, me
 	log.L(ctx).Errorf("Group '%s' does not contain member identity=%s node=%s", group.Hash, member.Identity, member.Node)
 	return false
 }
+
+// UpdateGroupMetadata sends a new display name and description for an existing group privately to all
+// members of the group. The update is only applied when the message is confirmed, so that all members
+// see the metadata change in the same order relative to the other messages in the group.
+func (gm *groupManager) UpdateGroupMetadata(ctx context.Context, hash string, metadata *core.GroupMetadata) (*core.Message, error) {
+	h, err := fftypes.ParseBytes32(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.Validate(ctx); err != nil {
+		return nil, err
+	}
+	group, _, err := gm.getGroupNodes(ctx, h, false)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Group = group.Hash
+
+	// Only members of the group can update it
+	signer := &core.SignerRef{}
+	if err := gm.identity.ResolveInputSigningIdentity(ctx, signer); err != nil {
+		return nil, err
+	}
+	localNode, err := gm.identity.GetLocalNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !gm.groupContains(ctx, group, &core.Member{Identity: signer.Author, Node: localNode.ID}) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgGroupMetadataNotMember, signer.Author, localNode.ID, group.Hash)
+	}
+
+	data := &core.Data{
+		Validator: core.ValidatorTypeSystemDefinition,
+		ID:        fftypes.NewUUID(),
+		Namespace: gm.namespace.Name,
+		Created:   fftypes.Now(),
+	}
+	b, err := json.Marshal(metadata)
+	if err == nil {
+		data.Value = fftypes.JSONAnyPtrBytes(b)
+		err = data.Seal(ctx, nil)
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+	}
+
+	msg := &core.Message{
+		State:          core.MessageStateReady,
+		LocalNamespace: gm.namespace.Name,
+		Header: core.MessageHeader{
+			Group:     group.Hash,
+			Namespace: gm.namespace.NetworkName,
+			Type:      core.MessageTypeGroupInit,
+			SignerRef: *signer,
+			Tag:       core.SystemTagUpdateGroup,
+			Topics:    fftypes.FFStringArray{group.Topic()},
+			TxType:    core.TransactionTypeBatchPin,
+		},
+		Data: core.DataRefs{
+			{ID: data.ID, Hash: data.Hash},
+		},
+	}
+	if err = msg.Seal(ctx); err != nil {
+		return nil, err
+	}
+	err = gm.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		if err := gm.database.UpsertData(ctx, data, database.UpsertOptimizationNew); err != nil {
+			return err
+		}
+		return gm.database.UpsertMessage(ctx, msg, database.UpsertOptimizationNew)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Sent metadata update for group %s in message %s", group.Hash, msg.Header.ID)
+	return msg, nil
+}
+
+// HandleGroupMetadataUpdate is called by the aggregator when a group metadata update message is ready for dispatch.
+// The sender has already been verified as a member of the group, as part of resolving the masked context.
+//
+// Errors are only returned for database issues. For validation issues, the message is rejected.
+func (gm *groupManager) HandleGroupMetadataUpdate(ctx context.Context, msg *core.Message, data core.DataArray) (core.MessageAction, error) {
+	if len(data) != 1 || data[0].Value == nil {
+		log.L(ctx).Warnf("Group %s metadata update in message %s invalid: expected exactly one data item", msg.Header.Group, msg.Header.ID)
+		return core.ActionReject, nil
+	}
+	var metadata core.GroupMetadata
+	if err := json.Unmarshal(data[0].Value.Bytes(), &metadata); err != nil {
+		log.L(ctx).Warnf("Group %s metadata update in message %s invalid: %s", msg.Header.Group, msg.Header.ID, err)
+		return core.ActionReject, nil
+	}
+	if err := metadata.Validate(ctx); err != nil {
+		log.L(ctx).Warnf("Group %s metadata update in message %s invalid: %s", msg.Header.Group, msg.Header.ID, err)
+		return core.ActionReject, nil
+	}
+	if !metadata.Group.Equals(msg.Header.Group) {
+		log.L(ctx).Warnf("Group %s metadata update in message %s invalid: mismatched hash '%s'", msg.Header.Group, msg.Header.ID, metadata.Group)
+		return core.ActionReject, nil
+	}
+
+	group, err := gm.database.GetGroupByHash(ctx, gm.namespace.Name, msg.Header.Group)
+	if err != nil {
+		return core.ActionRetry, err
+	}
+	if group == nil {
+		log.L(ctx).Warnf("Group %s metadata update in message %s invalid: group not found", msg.Header.Group, msg.Header.ID)
+		return core.ActionReject, nil
+	}
+	group.DisplayName = metadata.DisplayName
+	group.Description = metadata.Description
+	group.Updated = msg.Header.Created
+	if err := gm.database.UpsertGroup(ctx, group, database.UpsertOptimizationExisting); err != nil {
+		return core.ActionRetry, err
+	}
+	gm.groupCache.Delete(group.Hash.String())
+	log.L(ctx).Infof("Updated metadata for group %s from message %s", group.Hash, msg.Header.ID)
+	return core.ActionConfirm, nil
+}
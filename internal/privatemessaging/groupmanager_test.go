@@ -822,3 +822,320 @@ func TestEnsureLocalGroupWithoutCreator(t *testing.T) {
 
 	mdi.AssertExpectations(t)
 }
+
+func newTestMetadataGroup() (*core.Group, *core.Identity) {
+	node := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:   fftypes.NewUUID(),
+			Type: core.IdentityTypeNode,
+		},
+	}
+	group := &core.Group{
+		GroupIdentity: core.GroupIdentity{
+			Namespace: "ns1",
+			Members: core.Members{
+				&core.Member{Identity: "did:firefly:org/org1", Node: node.ID},
+			},
+		},
+	}
+	group.Seal()
+	return group, node
+}
+
+func TestUpdateGroupMetadataOk(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, node := newTestMetadataGroup()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+	mdi.On("UpsertData", pm.ctx, mock.Anything, database.UpsertOptimizationNew).Return(nil)
+	mdi.On("UpsertMessage", pm.ctx, mock.MatchedBy(func(msg *core.Message) bool {
+		return msg.Header.Type == core.MessageTypeGroupInit &&
+			msg.Header.Tag == core.SystemTagUpdateGroup &&
+			msg.Header.Group.Equals(group.Hash) &&
+			msg.Header.Topics[0] == group.Topic()
+	}), database.UpsertOptimizationNew).Return(nil)
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("ResolveInputSigningIdentity", pm.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		args[1].(*core.SignerRef).Author = "did:firefly:org/org1"
+	}).Return(nil)
+	mim.On("GetLocalNode", pm.ctx).Return(node, nil)
+
+	msg, err := pm.UpdateGroupMetadata(pm.ctx, group.Hash.String(), &core.GroupMetadata{
+		DisplayName: "My Group",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageStateReady, msg.State)
+	assert.Equal(t, "did:firefly:org/org1", msg.Header.Author)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestUpdateGroupMetadataBadHash(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	_, err := pm.UpdateGroupMetadata(pm.ctx, "!bad", &core.GroupMetadata{})
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestUpdateGroupMetadataBadMetadata(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	_, err := pm.UpdateGroupMetadata(pm.ctx, fftypes.NewRandB32().String(), &core.GroupMetadata{
+		DisplayName: string(make([]byte, 257)),
+	})
+	assert.Regexp(t, "FF00135", err)
+}
+
+func TestUpdateGroupMetadataGroupNotFound(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(nil, nil)
+
+	_, err := pm.UpdateGroupMetadata(pm.ctx, fftypes.NewRandB32().String(), &core.GroupMetadata{})
+	assert.Regexp(t, "FF10226", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUpdateGroupMetadataResolveSignerFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, node := newTestMetadataGroup()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("ResolveInputSigningIdentity", pm.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := pm.UpdateGroupMetadata(pm.ctx, group.Hash.String(), &core.GroupMetadata{})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestUpdateGroupMetadataLocalNodeFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, node := newTestMetadataGroup()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("ResolveInputSigningIdentity", pm.ctx, mock.Anything).Return(nil)
+	mim.On("GetLocalNode", pm.ctx).Return(nil, fmt.Errorf("pop"))
+
+	_, err := pm.UpdateGroupMetadata(pm.ctx, group.Hash.String(), &core.GroupMetadata{})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestUpdateGroupMetadataNotMember(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, node := newTestMetadataGroup()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("ResolveInputSigningIdentity", pm.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		args[1].(*core.SignerRef).Author = "did:firefly:org/org2"
+	}).Return(nil)
+	mim.On("GetLocalNode", pm.ctx).Return(node, nil)
+
+	_, err := pm.UpdateGroupMetadata(pm.ctx, group.Hash.String(), &core.GroupMetadata{})
+	assert.Regexp(t, "FF10616", err)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestUpdateGroupMetadataWriteFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, node := newTestMetadataGroup()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+	mdi.On("UpsertData", pm.ctx, mock.Anything, database.UpsertOptimizationNew).Return(fmt.Errorf("pop"))
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("ResolveInputSigningIdentity", pm.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		args[1].(*core.SignerRef).Author = "did:firefly:org/org1"
+	}).Return(nil)
+	mim.On("GetLocalNode", pm.ctx).Return(node, nil)
+
+	_, err := pm.UpdateGroupMetadata(pm.ctx, group.Hash.String(), &core.GroupMetadata{})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func newTestMetadataUpdate(group *core.Group, metadata *core.GroupMetadata) (*core.Message, core.DataArray) {
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:      fftypes.NewUUID(),
+			Type:    core.MessageTypeGroupInit,
+			Tag:     core.SystemTagUpdateGroup,
+			Group:   group.Hash,
+			Created: fftypes.Now(),
+		},
+	}
+	b, _ := json.Marshal(metadata)
+	return msg, core.DataArray{{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtrBytes(b)}}
+}
+
+func TestHandleGroupMetadataUpdateOk(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _ := newTestMetadataGroup()
+	msg, data := newTestMetadataUpdate(group, &core.GroupMetadata{
+		Group:       group.Hash,
+		DisplayName: "My Group",
+		Description: "Renamed",
+	})
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+	mdi.On("UpsertGroup", pm.ctx, mock.MatchedBy(func(g *core.Group) bool {
+		return g.DisplayName == "My Group" && g.Description == "Renamed" && g.Updated == msg.Header.Created
+	}), database.UpsertOptimizationExisting).Return(nil)
+
+	action, err := pm.HandleGroupMetadataUpdate(pm.ctx, msg, data)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionConfirm, action)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestHandleGroupMetadataUpdateMissingData(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _ := newTestMetadataGroup()
+	msg, _ := newTestMetadataUpdate(group, &core.GroupMetadata{})
+
+	action, err := pm.HandleGroupMetadataUpdate(pm.ctx, msg, core.DataArray{})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionReject, action)
+}
+
+func TestHandleGroupMetadataUpdateBadData(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _ := newTestMetadataGroup()
+	msg, _ := newTestMetadataUpdate(group, &core.GroupMetadata{})
+
+	action, err := pm.HandleGroupMetadataUpdate(pm.ctx, msg, core.DataArray{
+		{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr("!json")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionReject, action)
+}
+
+func TestHandleGroupMetadataUpdateBadValidation(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _ := newTestMetadataGroup()
+	msg, data := newTestMetadataUpdate(group, &core.GroupMetadata{
+		Group:       group.Hash,
+		DisplayName: string(make([]byte, 257)),
+	})
+
+	action, err := pm.HandleGroupMetadataUpdate(pm.ctx, msg, data)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionReject, action)
+}
+
+func TestHandleGroupMetadataUpdateMismatchedGroup(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _ := newTestMetadataGroup()
+	msg, data := newTestMetadataUpdate(group, &core.GroupMetadata{
+		Group: fftypes.NewRandB32(),
+	})
+
+	action, err := pm.HandleGroupMetadataUpdate(pm.ctx, msg, data)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionReject, action)
+}
+
+func TestHandleGroupMetadataUpdateGetGroupFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _ := newTestMetadataGroup()
+	msg, data := newTestMetadataUpdate(group, &core.GroupMetadata{Group: group.Hash})
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(nil, fmt.Errorf("pop"))
+
+	action, err := pm.HandleGroupMetadataUpdate(pm.ctx, msg, data)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.ActionRetry, action)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestHandleGroupMetadataUpdateGroupNotFound(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _ := newTestMetadataGroup()
+	msg, data := newTestMetadataUpdate(group, &core.GroupMetadata{Group: group.Hash})
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(nil, nil)
+
+	action, err := pm.HandleGroupMetadataUpdate(pm.ctx, msg, data)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionReject, action)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestHandleGroupMetadataUpdateUpsertFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _ := newTestMetadataGroup()
+	msg, data := newTestMetadataUpdate(group, &core.GroupMetadata{Group: group.Hash})
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+	mdi.On("UpsertGroup", pm.ctx, group, database.UpsertOptimizationExisting).Return(fmt.Errorf("pop"))
+
+	action, err := pm.HandleGroupMetadataUpdate(pm.ctx, msg, data)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.ActionRetry, action)
+
+	mdi.AssertExpectations(t)
+}
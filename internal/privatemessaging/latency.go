@@ -0,0 +1,126 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"context"
+	"database/sql/driver"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+type nodeLatency struct {
+	durations []time.Duration
+	failed    int
+	lastAck   *fftypes.FFTime
+}
+
+// GetGroupLatency measures how long the node of each member took to acknowledge the batches sent to it for
+// the group. The operation that sends a batch to a node is created when the batch is dispatched for pinning,
+// and succeeds when Data Exchange reports the transfer was received - so the time between the two includes
+// the infrastructure of the receiving node, and the network between the nodes.
+func (pm *privateMessaging) GetGroupLatency(ctx context.Context, hash string) ([]*core.MemberLatency, error) {
+	group, err := pm.GetGroupByID(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	localNode, err := pm.identity.GetLocalNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	since := fftypes.FFTime(time.Now().Add(-config.GetDuration(coreconfig.PrivateMessagingLatencyWindow)))
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("type", core.OpTypeDataExchangeSendBatch),
+		fb.In("status", []driver.Value{core.OpStatusSucceeded, core.OpStatusFailed}),
+		fb.Gt("updated", &since),
+	)
+	filter.Sort("-updated").Limit(uint64(config.GetInt(coreconfig.PrivateMessagingLatencySampleLimit)))
+	ops, _, err := pm.database.GetOperations(ctx, pm.namespace.Name, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byNode := make(map[string]*nodeLatency)
+	for _, op := range ops {
+		nodeID, groupHash, _, err := retrieveBatchSendInputs(ctx, op)
+		if err != nil || !groupHash.Equals(group.Hash) {
+			continue
+		}
+		nl := byNode[nodeID.String()]
+		if nl == nil {
+			nl = &nodeLatency{}
+			byNode[nodeID.String()] = nl
+		}
+		if op.Status == core.OpStatusFailed {
+			nl.failed++
+			continue
+		}
+		if op.Created == nil || op.Updated == nil {
+			continue
+		}
+		nl.durations = append(nl.durations, time.Time(*op.Updated).Sub(time.Time(*op.Created)))
+		if nl.lastAck == nil || time.Time(*op.Updated).After(time.Time(*nl.lastAck)) {
+			nl.lastAck = op.Updated
+		}
+	}
+
+	latencies := make([]*core.MemberLatency, len(group.Members))
+	for i, member := range group.Members {
+		ml := &core.MemberLatency{
+			Identity: member.Identity,
+			Node:     member.Node,
+			Local:    localNode != nil && member.Node.Equals(localNode.ID),
+		}
+		if nl := byNode[member.Node.String()]; nl != nil {
+			ml.Failed = nl.failed
+			ml.LastAck = nl.lastAck
+			summarizeLatency(ml, nl.durations)
+		}
+		latencies[i] = ml
+	}
+	return latencies, nil
+}
+
+func summarizeLatency(ml *core.MemberLatency, durations []time.Duration) {
+	ml.Batches = len(durations)
+	if len(durations) == 0 {
+		return
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	// Nearest-rank percentile
+	rank := (len(durations)*95 + 99) / 100
+	ml.Average = fftypes.FFDuration(total / time.Duration(len(durations)))
+	ml.P95 = fftypes.FFDuration(durations[rank-1])
+	ml.Max = fftypes.FFDuration(durations[len(durations)-1])
+}
@@ -0,0 +1,128 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSendBatchOp(node *fftypes.UUID, group *fftypes.Bytes32, status core.OpStatus, took time.Duration) *core.Operation {
+	op := &core.Operation{Type: core.OpTypeDataExchangeSendBatch, Status: status}
+	addBatchSendInputs(op, node, group, fftypes.NewUUID())
+	updated := fftypes.Now()
+	created := fftypes.FFTime(time.Time(*updated).Add(-took))
+	op.Created = &created
+	op.Updated = updated
+	return op
+}
+
+func TestGetGroupLatency(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	localNode := newTestNode("node1", newTestOrg("localorg"))
+	remoteNode := newTestNode("node2", newTestOrg("remoteorg"))
+	group := &core.Group{
+		Hash: fftypes.NewRandB32(),
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{
+				{Identity: "did:firefly:org/localorg", Node: localNode.ID},
+				{Identity: "did:firefly:org/remoteorg", Node: remoteNode.ID},
+			},
+		},
+	}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(localNode, nil)
+	mdi.On("GetOperations", pm.ctx, "ns1", mock.Anything).Return([]*core.Operation{
+		newTestSendBatchOp(remoteNode.ID, group.Hash, core.OpStatusSucceeded, 1*time.Second),
+		newTestSendBatchOp(remoteNode.ID, group.Hash, core.OpStatusSucceeded, 3*time.Second),
+		newTestSendBatchOp(remoteNode.ID, group.Hash, core.OpStatusFailed, 0),
+		newTestSendBatchOp(remoteNode.ID, fftypes.NewRandB32(), core.OpStatusSucceeded, 10*time.Second),
+		{Type: core.OpTypeDataExchangeSendBatch, Input: fftypes.JSONObject{}},
+	}, nil, nil)
+
+	latencies, err := pm.GetGroupLatency(pm.ctx, group.Hash.String())
+	assert.NoError(t, err)
+	assert.Len(t, latencies, 2)
+	assert.True(t, latencies[0].Local)
+	assert.Equal(t, 0, latencies[0].Batches)
+	assert.False(t, latencies[1].Local)
+	assert.Equal(t, 2, latencies[1].Batches)
+	assert.Equal(t, 1, latencies[1].Failed)
+	assert.Equal(t, fftypes.FFDuration(2*time.Second), latencies[1].Average)
+	assert.Equal(t, fftypes.FFDuration(3*time.Second), latencies[1].P95)
+	assert.Equal(t, fftypes.FFDuration(3*time.Second), latencies[1].Max)
+	assert.NotNil(t, latencies[1].LastAck)
+}
+
+func TestGetGroupLatencyBadHash(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	_, err := pm.GetGroupLatency(pm.ctx, "!bad")
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestGetGroupLatencyNotFound(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(nil, nil)
+
+	_, err := pm.GetGroupLatency(pm.ctx, fftypes.NewRandB32().String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetGroupLatencyLocalNodeFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(&core.Group{}, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(nil, fmt.Errorf("pop"))
+
+	_, err := pm.GetGroupLatency(pm.ctx, fftypes.NewRandB32().String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetGroupLatencyGetOperationsFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(&core.Group{}, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(newTestNode("node1", newTestOrg("localorg")), nil)
+	mdi.On("GetOperations", pm.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := pm.GetGroupLatency(pm.ctx, fftypes.NewRandB32().String())
+	assert.EqualError(t, err, "pop")
+}
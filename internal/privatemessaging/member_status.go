@@ -0,0 +1,133 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// GetGroupMemberStatus reports, for each member of a group, whether its identities are still registered and
+// when its node was last seen. A node is seen when we receive a private batch from it, or when data exchange
+// delivers a batch to it (or the node acknowledges one). The local node is never contacted, so only has
+// its identity status reported.
+func (pm *privateMessaging) GetGroupMemberStatus(ctx context.Context, hash string) ([]*core.MemberStatus, error) {
+	group, err := pm.GetGroupByID(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	localNode, err := pm.identity.GetLocalNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*core.MemberStatus, len(group.Members))
+	for i, member := range group.Members {
+		ms := &core.MemberStatus{
+			Identity: member.Identity,
+			Node:     member.Node,
+			Local:    localNode != nil && member.Node.Equals(localNode.ID),
+		}
+		if err := pm.resolveMemberIdentityStatus(ctx, member, ms); err != nil {
+			return nil, err
+		}
+		if !ms.Local {
+			if err := pm.resolveMemberLiveness(ctx, member, ms); err != nil {
+				return nil, err
+			}
+		}
+		statuses[i] = ms
+	}
+	return statuses, nil
+}
+
+func (pm *privateMessaging) resolveMemberIdentityStatus(ctx context.Context, member *core.Member, ms *core.MemberStatus) error {
+	ms.IdentityStatus = core.MemberIdentityStatusUnregistered
+	node, err := pm.identity.CachedIdentityLookupByID(ctx, member.Node)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return nil
+	}
+	ms.NodeName = node.Name
+	org, _, err := pm.identity.CachedIdentityLookupNilOK(ctx, member.Identity)
+	if err != nil {
+		return err
+	}
+	if org == nil {
+		return nil
+	}
+	valid, err := pm.identity.ValidateNodeOwner(ctx, node, org)
+	if err != nil {
+		return err
+	}
+	if valid {
+		ms.IdentityStatus = core.MemberIdentityStatusRegistered
+	} else {
+		ms.IdentityStatus = core.MemberIdentityStatusInvalid
+	}
+	return nil
+}
+
+func (pm *privateMessaging) resolveMemberLiveness(ctx context.Context, member *core.Member, ms *core.MemberStatus) error {
+	bfb := database.BatchQueryFactory.NewFilter(ctx)
+	batchFilter := bfb.And(
+		bfb.Eq("type", core.BatchTypePrivate),
+		bfb.Eq("node", member.Node),
+	)
+	batchFilter.Sort("-created").Limit(1)
+	batches, _, err := pm.database.GetBatches(ctx, pm.namespace.Name, batchFilter)
+	if err != nil {
+		return err
+	}
+	if len(batches) > 0 {
+		ms.LastBatchReceived = batches[0].Created
+	}
+
+	afb := database.BatchAckQueryFactory.NewFilter(ctx)
+	ackFilter := afb.And(
+		afb.Eq("node", member.Node),
+	)
+	ackFilter.Sort("-updated").Limit(1)
+	acks, _, err := pm.database.GetBatchAcks(ctx, pm.namespace.Name, ackFilter)
+	if err != nil {
+		return err
+	}
+	if len(acks) > 0 {
+		ms.LastDXContact = acks[0].Updated
+	}
+
+	ms.LastSeen = latestTime(ms.LastBatchReceived, ms.LastDXContact)
+	return nil
+}
+
+func latestTime(a, b *fftypes.FFTime) *fftypes.FFTime {
+	if a == nil || (b != nil && time.Time(*b).After(time.Time(*a))) {
+		return b
+	}
+	return a
+}
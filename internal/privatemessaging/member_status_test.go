@@ -0,0 +1,263 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetGroupMemberStatus(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	localOrg := newTestOrg("localorg")
+	localNode := newTestNode("node1", localOrg)
+	remoteOrg := newTestOrg("remoteorg")
+	remoteNode := newTestNode("node2", remoteOrg)
+	group := &core.Group{
+		Hash: fftypes.NewRandB32(),
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{
+				{Identity: localOrg.DID, Node: localNode.ID},
+				{Identity: remoteOrg.DID, Node: remoteNode.ID},
+			},
+		},
+	}
+	batchCreated := fftypes.FFTime(time.Now().Add(-48 * time.Hour))
+	ackUpdated := fftypes.FFTime(time.Now().Add(-24 * time.Hour))
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(localNode, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, localNode.ID).Return(localNode, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, localOrg.DID).Return(localOrg, false, nil)
+	mim.On("ValidateNodeOwner", pm.ctx, localNode, localOrg).Return(true, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, remoteNode.ID).Return(remoteNode, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, remoteOrg.DID).Return(remoteOrg, false, nil)
+	mim.On("ValidateNodeOwner", pm.ctx, remoteNode, remoteOrg).Return(false, nil)
+	mdi.On("GetBatches", pm.ctx, "ns1", mock.Anything).Return([]*core.BatchPersisted{
+		{BatchHeader: core.BatchHeader{Created: &batchCreated}},
+	}, nil, nil).Once()
+	mdi.On("GetBatchAcks", pm.ctx, "ns1", mock.Anything).Return([]*core.BatchAck{
+		{Updated: &ackUpdated},
+	}, nil, nil).Once()
+
+	statuses, err := pm.GetGroupMemberStatus(pm.ctx, group.Hash.String())
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Local)
+	assert.Equal(t, "node1", statuses[0].NodeName)
+	assert.Equal(t, core.MemberIdentityStatusRegistered, statuses[0].IdentityStatus)
+	assert.Nil(t, statuses[0].LastSeen)
+	assert.False(t, statuses[1].Local)
+	assert.Equal(t, core.MemberIdentityStatusInvalid, statuses[1].IdentityStatus)
+	assert.Equal(t, &batchCreated, statuses[1].LastBatchReceived)
+	assert.Equal(t, &ackUpdated, statuses[1].LastDXContact)
+	assert.Equal(t, &ackUpdated, statuses[1].LastSeen)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestGetGroupMemberStatusUnregistered(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	remoteOrg := newTestOrg("remoteorg")
+	remoteNode := newTestNode("node2", remoteOrg)
+	group := &core.Group{
+		Hash: fftypes.NewRandB32(),
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{
+				{Identity: remoteOrg.DID, Node: remoteNode.ID},
+				{Identity: "did:firefly:org/gone", Node: fftypes.NewUUID()},
+			},
+		},
+	}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", group.Hash).Return(group, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(newTestNode("node1", newTestOrg("localorg")), nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, remoteNode.ID).Return(remoteNode, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, remoteOrg.DID).Return(nil, false, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, group.Members[1].Node).Return(nil, nil)
+	mdi.On("GetBatches", pm.ctx, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+	mdi.On("GetBatchAcks", pm.ctx, "ns1", mock.Anything).Return([]*core.BatchAck{}, nil, nil)
+
+	statuses, err := pm.GetGroupMemberStatus(pm.ctx, group.Hash.String())
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, core.MemberIdentityStatusUnregistered, statuses[0].IdentityStatus)
+	assert.Equal(t, "node2", statuses[0].NodeName)
+	assert.Equal(t, core.MemberIdentityStatusUnregistered, statuses[1].IdentityStatus)
+	assert.Empty(t, statuses[1].NodeName)
+	assert.Nil(t, statuses[1].LastSeen)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestGetGroupMemberStatusBadHash(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	_, err := pm.GetGroupMemberStatus(pm.ctx, "!bad")
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestGetGroupMemberStatusNotFound(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(nil, nil)
+
+	_, err := pm.GetGroupMemberStatus(pm.ctx, fftypes.NewRandB32().String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetGroupMemberStatusLocalNodeFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(&core.Group{}, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(nil, fmt.Errorf("pop"))
+
+	_, err := pm.GetGroupMemberStatus(pm.ctx, fftypes.NewRandB32().String())
+	assert.EqualError(t, err, "pop")
+}
+
+func newTestMemberStatusGroup() (*core.Group, *core.Identity, *core.Identity) {
+	org := newTestOrg("remoteorg")
+	node := newTestNode("node2", org)
+	return &core.Group{
+		Hash: fftypes.NewRandB32(),
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{
+				{Identity: org.DID, Node: node.ID},
+			},
+		},
+	}, org, node
+}
+
+func TestGetGroupMemberStatusNodeLookupFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, _, node := newTestMemberStatusGroup()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(group, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(newTestNode("node1", newTestOrg("localorg")), nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := pm.GetGroupMemberStatus(pm.ctx, group.Hash.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetGroupMemberStatusOrgLookupFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, org, node := newTestMemberStatusGroup()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(group, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(newTestNode("node1", newTestOrg("localorg")), nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, org.DID).Return(nil, true, fmt.Errorf("pop"))
+
+	_, err := pm.GetGroupMemberStatus(pm.ctx, group.Hash.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetGroupMemberStatusValidateOwnerFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, org, node := newTestMemberStatusGroup()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(group, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(newTestNode("node1", newTestOrg("localorg")), nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, org.DID).Return(org, false, nil)
+	mim.On("ValidateNodeOwner", pm.ctx, node, org).Return(false, fmt.Errorf("pop"))
+
+	_, err := pm.GetGroupMemberStatus(pm.ctx, group.Hash.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetGroupMemberStatusGetBatchesFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, org, node := newTestMemberStatusGroup()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(group, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(newTestNode("node1", newTestOrg("localorg")), nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, org.DID).Return(org, false, nil)
+	mim.On("ValidateNodeOwner", pm.ctx, node, org).Return(true, nil)
+	mdi.On("GetBatches", pm.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := pm.GetGroupMemberStatus(pm.ctx, group.Hash.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetGroupMemberStatusGetBatchAcksFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	group, org, node := newTestMemberStatusGroup()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", mock.Anything).Return(group, nil)
+	mim.On("GetLocalNode", pm.ctx).Return(newTestNode("node1", newTestOrg("localorg")), nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, node.ID).Return(node, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, org.DID).Return(org, false, nil)
+	mim.On("ValidateNodeOwner", pm.ctx, node, org).Return(true, nil)
+	mdi.On("GetBatches", pm.ctx, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+	mdi.On("GetBatchAcks", pm.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := pm.GetGroupMemberStatus(pm.ctx, group.Hash.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestLatestTime(t *testing.T) {
+	earlier := fftypes.FFTime(time.Now().Add(-1 * time.Hour))
+	later := fftypes.Now()
+	assert.Nil(t, latestTime(nil, nil))
+	assert.Equal(t, &earlier, latestTime(&earlier, nil))
+	assert.Equal(t, later, latestTime(nil, later))
+	assert.Equal(t, later, latestTime(&earlier, later))
+	assert.Equal(t, later, latestTime(later, &earlier))
+}
@@ -37,6 +37,11 @@ type batchSendData struct {
 	Transport *core.TransportWrapper `json:"transport"`
 }
 
+type batchAckSendData struct {
+	Node *core.Identity             `json:"node"`
+	Ack  *core.BatchAckNotification `json:"ack"`
+}
+
 func addTransferBlobInputs(op *core.Operation, nodeID *fftypes.UUID, blobHash *fftypes.Bytes32, dataID *fftypes.UUID) {
 	op.Input = fftypes.JSONObject{
 		"node":    nodeID.String(),
@@ -80,6 +85,22 @@ func retrieveBatchSendInputs(ctx context.Context, op *core.Operation) (nodeID *f
 	return nodeID, groupHash, batchID, err
 }
 
+func addBatchAckInputs(op *core.Operation, nodeID *fftypes.UUID, batchID *fftypes.UUID, state core.BatchAckState) {
+	op.Input = fftypes.JSONObject{
+		"node":  nodeID.String(),
+		"batch": batchID.String(),
+		"state": state.String(),
+	}
+}
+
+func retrieveBatchAckInputs(ctx context.Context, op *core.Operation) (nodeID *fftypes.UUID, batchID *fftypes.UUID, state core.BatchAckState, err error) {
+	nodeID, err = fftypes.ParseUUID(ctx, op.Input.GetString("node"))
+	if err == nil {
+		batchID, err = fftypes.ParseUUID(ctx, op.Input.GetString("batch"))
+	}
+	return nodeID, batchID, core.BatchAckState(op.Input.GetString("state")), err
+}
+
 func (pm *privateMessaging) PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error) {
 	switch op.Type {
 	case core.OpTypeDataExchangeSendBlob:
@@ -133,6 +154,19 @@ func (pm *privateMessaging) PrepareOperation(ctx context.Context, op *core.Opera
 		pm.prepareBatchForNetworkTransport(ctx, transport)
 		return opSendBatch(op, node, transport), nil
 
+	case core.OpTypeDataExchangeSendBatchAck:
+		nodeID, batchID, state, err := retrieveBatchAckInputs(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		node, err := pm.identity.CachedIdentityLookupByID(ctx, nodeID)
+		if err != nil {
+			return nil, err
+		} else if node == nil {
+			return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+		}
+		return opSendBatchAck(op, node, pm.newBatchAckNotification(batchID, state)), nil
+
 	default:
 		return nil, i18n.NewError(ctx, coremsgs.MsgOperationNotSupported, op.Type)
 	}
@@ -159,12 +193,27 @@ func (pm *privateMessaging) RunOperation(ctx context.Context, op *core.PreparedO
 		}
 		return nil, core.OpPhaseInitializing, pm.exchange.SendMessage(ctx, op.NamespacedIDString(), data.Node.Profile, localNode.Profile, payload)
 
+	case batchAckSendData:
+		localNode, err := pm.identity.GetLocalNode(ctx)
+		if err != nil {
+			return nil, core.OpPhaseInitializing, err
+		}
+
+		payload, err := json.Marshal(&core.TransportWrapper{Ack: data.Ack})
+		if err != nil {
+			return nil, core.OpPhaseInitializing, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+		}
+		return nil, core.OpPhaseInitializing, pm.exchange.SendMessage(ctx, op.NamespacedIDString(), data.Node.Profile, localNode.Profile, payload)
+
 	default:
 		return nil, core.OpPhaseInitializing, i18n.NewError(ctx, coremsgs.MsgOperationDataIncorrect, op.Data)
 	}
 }
 
 func (pm *privateMessaging) OnOperationUpdate(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
+	if op.Type == core.OpTypeDataExchangeSendBatch {
+		return pm.batchSendUpdated(ctx, op, update)
+	}
 	return nil
 }
 
@@ -187,3 +236,13 @@ func opSendBatch(op *core.Operation, node *core.Identity, transport *core.Transp
 		Data:      batchSendData{Node: node, Transport: transport},
 	}
 }
+
+func opSendBatchAck(op *core.Operation, node *core.Identity, ack *core.BatchAckNotification) *core.PreparedOperation {
+	return &core.PreparedOperation{
+		ID:        op.ID,
+		Namespace: op.Namespace,
+		Plugin:    op.Plugin,
+		Type:      op.Type,
+		Data:      batchAckSendData{Node: node, Ack: ack},
+	}
+}
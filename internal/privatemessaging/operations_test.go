@@ -17,6 +17,7 @@ package privatemessaging
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -650,7 +651,129 @@ func TestRunOperationBlobSendNodeFail(t *testing.T) {
 func TestOperationUpdate(t *testing.T) {
 	pm, cancel := newTestPrivateMessaging(t)
 	defer cancel()
-	assert.NoError(t, pm.OnOperationUpdate(context.Background(), nil, nil))
+	assert.NoError(t, pm.OnOperationUpdate(context.Background(), &core.Operation{Type: core.OpTypeDataExchangeSendBlob}, &core.OperationUpdate{}))
+}
+
+func TestPrepareAndRunBatchAckSend(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op := &core.Operation{
+		Type:      core.OpTypeDataExchangeSendBatchAck,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	node := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID: fftypes.NewUUID(),
+		},
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{
+				"id": "peer1",
+			},
+		},
+	}
+	localNode := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID: fftypes.NewUUID(),
+		},
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{
+				"id": "local1",
+			},
+		},
+	}
+	batchID := fftypes.NewUUID()
+	addBatchAckInputs(op, node.ID, batchID, core.BatchAckStatePersisted)
+
+	mdx := pm.exchange.(*dataexchangemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", context.Background()).Return(localNode, nil)
+	mim.On("CachedIdentityLookupByID", context.Background(), node.ID).Return(node, nil)
+	mdx.On("SendMessage", context.Background(), "ns1:"+op.ID.String(), node.Profile, localNode.Profile, mock.MatchedBy(func(payload []byte) bool {
+		var tw core.TransportWrapper
+		err := json.Unmarshal(payload, &tw)
+		return err == nil && tw.Batch == nil &&
+			tw.Ack.Namespace == "ns1-remote" &&
+			tw.Ack.Batch.Equals(batchID) &&
+			tw.Ack.State == core.BatchAckStatePersisted
+	})).Return(nil)
+
+	po, err := pm.PrepareOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, node, po.Data.(batchAckSendData).Node)
+
+	_, phase, err := pm.RunOperation(context.Background(), po)
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.NoError(t, err)
+
+	mdx.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestPrepareOperationBatchAckSendBadInput(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op := &core.Operation{
+		Type:  core.OpTypeDataExchangeSendBatchAck,
+		Input: fftypes.JSONObject{"node": fftypes.NewUUID().String()},
+	}
+
+	_, err := pm.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestPrepareOperationBatchAckSendNodeFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	nodeID := fftypes.NewUUID()
+	op := &core.Operation{
+		Type: core.OpTypeDataExchangeSendBatchAck,
+	}
+	addBatchAckInputs(op, nodeID, fftypes.NewUUID(), core.BatchAckStateAggregated)
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", context.Background(), nodeID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := pm.PrepareOperation(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+
+	mim.AssertExpectations(t)
+}
+
+func TestPrepareOperationBatchAckSendNodeNotFound(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	nodeID := fftypes.NewUUID()
+	op := &core.Operation{
+		Type: core.OpTypeDataExchangeSendBatchAck,
+	}
+	addBatchAckInputs(op, nodeID, fftypes.NewUUID(), core.BatchAckStateAggregated)
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", context.Background(), nodeID).Return(nil, nil)
+
+	_, err := pm.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF10109", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestRunOperationBatchAckSendNodeFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", context.Background()).Return(nil, fmt.Errorf("pop"))
+
+	_, phase, err := pm.RunOperation(context.Background(), opSendBatchAck(&core.Operation{}, &core.Identity{}, &core.BatchAckNotification{}))
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.EqualError(t, err, "pop")
 }
 
 func TestRetrieveBSendBlobInputs(t *testing.T) {
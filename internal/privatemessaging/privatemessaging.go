@@ -19,6 +19,7 @@ package privatemessaging
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -35,6 +36,7 @@ import (
 	"github.com/hyperledger/firefly/internal/multiparty"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/internal/syncasync"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -51,6 +53,12 @@ type Manager interface {
 	NewMessage(msg *core.MessageInOut) syncasync.Sender
 	SendMessage(ctx context.Context, in *core.MessageInOut, waitConfirm bool) (out *core.Message, err error)
 	RequestReply(ctx context.Context, request *core.MessageInOut) (reply *core.MessageInOut, err error)
+	GetGroupLatency(ctx context.Context, hash string) ([]*core.MemberLatency, error)
+	GetGroupMemberStatus(ctx context.Context, hash string) ([]*core.MemberStatus, error)
+	SendBatchAck(ctx context.Context, batchID *fftypes.UUID, state core.BatchAckState) error
+	BatchAckReceived(ctx context.Context, peerID string, ack *core.BatchAckNotification) error
+	Start() error
+	WaitStop()
 
 	// From operations.OperationHandler
 	PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error)
@@ -74,6 +82,12 @@ type privateMessaging struct {
 	metrics               metrics.Manager
 	operations            operations.Manager
 	orgFirstNodes         map[string]*core.Identity
+	sendAcks              bool
+	queueEnabled          bool
+	queuePageSize         int
+	queuePollInterval     time.Duration
+	queueNotify           chan struct{}
+	queueDone             chan struct{}
 }
 
 type blobTransferTracker struct {
@@ -112,6 +126,11 @@ func NewPrivateMessaging(ctx context.Context, ns *core.Namespace, di database.Pl
 		metrics:               mm,
 		operations:            om,
 		orgFirstNodes:         make(map[string]*core.Identity),
+		sendAcks:              config.GetBool(coreconfig.PrivateMessagingAcksEnabled),
+		queueEnabled:          config.GetBool(coreconfig.PrivateMessagingQueueEnabled),
+		queuePageSize:         config.GetInt(coreconfig.PrivateMessagingQueuePageSize),
+		queuePollInterval:     config.GetDuration(coreconfig.PrivateMessagingQueuePollInterval),
+		queueNotify:           make(chan struct{}, 1),
 	}
 
 	groupCache, err := cacheManager.GetCache(
@@ -156,6 +175,7 @@ func NewPrivateMessaging(ctx context.Context, ns *core.Namespace, di database.Pl
 	om.RegisterHandler(ctx, pm, []core.OpType{
 		core.OpTypeDataExchangeSendBlob,
 		core.OpTypeDataExchangeSendBatch,
+		core.OpTypeDataExchangeSendBatchAck,
 	})
 
 	return pm, nil
@@ -189,6 +209,7 @@ func (pm *privateMessaging) prepareBatchForNetworkTransport(ctx context.Context,
 
 func (pm *privateMessaging) dispatchBatchCommon(ctx context.Context, payload *batch.DispatchPayload) error {
 	batch := payload.Batch.GenInflight(payload.Messages, payload.Data)
+	batch.Payload.Signature = payload.Signature
 	tw := &core.TransportWrapper{
 		Batch: batch,
 	}
@@ -263,7 +284,7 @@ func (pm *privateMessaging) submitBlobTransfersToDX(ctx context.Context, tracker
 	for _, tracker := range trackers {
 		go func(tracker *blobTransferTracker) {
 			defer wg.Done()
-			log.L(ctx).Debugf("Initiating DX transfer blob=%s data=%s operation=%s", tracker.blobHash, tracker.dataID, tracker.op.ID)
+			tracing.Logf(ctx, "Initiating DX transfer blob=%s data=%s operation=%s", tracker.blobHash, tracker.dataID, tracker.op.ID)
 			if _, err := pm.operations.RunOperation(ctx, tracker.op, false /* batch processing does not currently use idempotency keys */); err != nil {
 				log.L(ctx).Errorf("Failed to initiate DX transfer blob=%s data=%s operation=%s", tracker.blobHash, tracker.dataID, tracker.op.ID)
 				if firstError == nil {
@@ -277,7 +298,6 @@ func (pm *privateMessaging) submitBlobTransfersToDX(ctx context.Context, tracker
 }
 
 func (pm *privateMessaging) sendData(ctx context.Context, tw *core.TransportWrapper, nodes []*core.Identity) (err error) {
-	l := log.L(ctx)
 	batch := tw.Batch
 
 	// Lookup the local node
@@ -290,11 +310,11 @@ func (pm *privateMessaging) sendData(ctx context.Context, tw *core.TransportWrap
 	for i, node := range nodes {
 
 		if node.ID.Equals(localNode.ID) {
-			l.Debugf("Skipping send of batch for local node %s for group=%s node=%s (%d/%d)", batch.ID, batch.Group, node.ID, i+1, len(nodes))
+			tracing.Logf(ctx, "Skipping send of batch for local node %s for group=%s node=%s (%d/%d)", batch.ID, batch.Group, node.ID, i+1, len(nodes))
 			continue
 		}
 
-		l.Debugf("Sending batch %s to group=%s node=%s (%d/%d)", batch.ID, batch.Group, node.ID, i+1, len(nodes))
+		tracing.Logf(ctx, "Sending batch %s to group=%s node=%s (%d/%d)", batch.ID, batch.Group, node.ID, i+1, len(nodes))
 
 		var blobTrackers []*blobTransferTracker
 		var sendBatchOp *core.PreparedOperation
@@ -316,12 +336,22 @@ func (pm *privateMessaging) sendData(ctx context.Context, tw *core.TransportWrap
 				return err
 			}
 			sendBatchOp = opSendBatch(op, node, tw)
+			if pm.queueEnabled {
+				return pm.queueBatchSend(ctx, blobTrackers, sendBatchOp, batchSendPriority(batch))
+			}
 			return nil
 		})
 		if err != nil {
 			return err
 		}
 
+		if pm.queueEnabled {
+			// The queue loop takes it from here
+			tracing.Logf(ctx, "Queued DX transfer of batch %s to node=%s with %d blobs", batch.ID, node.ID, len(blobTrackers))
+			pm.notifyQueue()
+			continue
+		}
+
 		// Initiate transfer of any blobs first
 		if len(blobTrackers) > 0 {
 			if err = pm.submitBlobTransfersToDX(ctx, blobTrackers); err != nil {
@@ -333,6 +363,7 @@ func (pm *privateMessaging) sendData(ctx context.Context, tw *core.TransportWrap
 		if _, err = pm.operations.RunOperation(ctx, sendBatchOp, false /* batch processing does not currently use idempotency keys */); err != nil {
 			return err
 		}
+		tracing.Logf(ctx, "Initiated DX transfer of batch %s to node=%s operation=%s", batch.ID, node.ID, sendBatchOp.ID)
 	}
 
 	return nil
@@ -0,0 +1,183 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// The outbound queue persists DX sends in the database, rather than running them directly. A single loop
+// dispatches them in priority order, and holds its place in the queue while DX is unavailable - so an
+// outage cannot reorder sends, and anything not yet accepted by DX is picked up again after a restart.
+
+func (pm *privateMessaging) Start() error {
+	if pm.queueEnabled {
+		pm.queueDone = make(chan struct{})
+		go pm.queueLoop()
+	}
+	return nil
+}
+
+func (pm *privateMessaging) WaitStop() {
+	if pm.queueDone != nil {
+		<-pm.queueDone
+		pm.queueDone = nil
+	}
+}
+
+// batchSendPriority puts batches that set up a group ahead of other messages, and batches
+// that reference blobs behind them - in the same order as the blobs themselves
+func batchSendPriority(batch *core.Batch) core.DXSendPriority {
+	for _, msg := range batch.Payload.Messages {
+		if msg.Header.Type == core.MessageTypeGroupInit {
+			return core.DXSendPriorityDefinition
+		}
+	}
+	for _, d := range batch.Payload.Data {
+		if d.Blob != nil {
+			return core.DXSendPriorityBlob
+		}
+	}
+	return core.DXSendPriorityMessage
+}
+
+func (pm *privateMessaging) queueSend(ctx context.Context, op *core.PreparedOperation, priority core.DXSendPriority) error {
+	log.L(ctx).Debugf("Queuing %s operation %s with priority %d", op.Type, op.ID, priority)
+	return pm.database.InsertDXQueueEntry(ctx, &core.DXQueueEntry{
+		Namespace: pm.namespace.Name,
+		Operation: op.ID,
+		Priority:  priority,
+		Created:   fftypes.Now(),
+	})
+}
+
+// queueBatchSend queues the blob transfers ahead of the batch that references them
+func (pm *privateMessaging) queueBatchSend(ctx context.Context, blobTrackers []*blobTransferTracker, sendBatchOp *core.PreparedOperation, priority core.DXSendPriority) error {
+	for _, tracker := range blobTrackers {
+		if err := pm.queueSend(ctx, tracker.op, core.DXSendPriorityBlob); err != nil {
+			return err
+		}
+	}
+	return pm.queueSend(ctx, sendBatchOp, priority)
+}
+
+// notifyQueue wakes the queue loop, and must only be called once the entries have been committed
+func (pm *privateMessaging) notifyQueue() {
+	select {
+	case pm.queueNotify <- struct{}{}:
+	default:
+	}
+}
+
+// queueNotified consumes any pending notification from notifyQueue
+func (pm *privateMessaging) queueNotified() bool {
+	select {
+	case <-pm.queueNotify:
+		return true
+	default:
+		return false
+	}
+}
+
+func (pm *privateMessaging) queueLoop() {
+	defer close(pm.queueDone)
+	l := log.L(pm.ctx)
+	for {
+		var entries []*core.DXQueueEntry
+		err := pm.retry.Do(pm.ctx, "read dx queue", func(attempt int) (retry bool, err error) {
+			entries, err = pm.database.GetDXQueueEntries(pm.ctx, pm.namespace.Name, pm.queuePageSize)
+			return true, err
+		})
+		if err != nil {
+			l.Debugf("DX queue loop exiting: %s", err)
+			return
+		}
+
+		if len(entries) == 0 {
+			select {
+			case <-pm.queueNotify:
+			case <-time.After(pm.queuePollInterval):
+			case <-pm.ctx.Done():
+				l.Debugf("DX queue loop exiting")
+				return
+			}
+			continue
+		}
+
+		for _, entry := range entries {
+			if err := pm.dispatchQueuedSend(pm.ctx, entry); err != nil {
+				l.Debugf("DX queue loop exiting: %s", err)
+				return
+			}
+			if pm.queueNotified() {
+				// Re-read the queue, as anything new might be of a higher priority
+				break
+			}
+		}
+	}
+}
+
+// dispatchQueuedSend retries until the operation has been accepted by DX (or found not to need sending) and
+// its queue entry removed, so the loop only moves past an entry once it is done with
+func (pm *privateMessaging) dispatchQueuedSend(ctx context.Context, entry *core.DXQueueEntry) error {
+	dispatched := false
+	return pm.retry.Do(ctx, "dispatch queued send", func(attempt int) (retry bool, err error) {
+		if !dispatched {
+			if err = pm.runQueuedOperation(ctx, entry); err != nil {
+				return true, err
+			}
+			dispatched = true
+		}
+		return true, pm.database.DeleteDXQueueEntry(ctx, pm.namespace.Name, entry.Sequence)
+	})
+}
+
+func (pm *privateMessaging) runQueuedOperation(ctx context.Context, entry *core.DXQueueEntry) error {
+	op, err := pm.operations.GetOperationByIDCached(ctx, entry.Operation)
+	if err != nil {
+		return err
+	}
+	if op == nil || op.Status != core.OpStatusInitialized {
+		// Already accepted by DX, for example if we stopped before removing the entry
+		log.L(ctx).Debugf("Skipping queued operation %s that is no longer initialized", entry.Operation)
+		return nil
+	}
+
+	prepared, err := pm.operations.PrepareOperation(ctx, op)
+	if err != nil {
+		// The inputs of the operation cannot be resolved, so there is nothing to gain from holding up the queue
+		log.L(ctx).Errorf("Failed to prepare queued %s operation %s: %s", op.Type, op.ID, err)
+		pm.operations.SubmitOperationUpdate(&core.OperationUpdateAsync{
+			OperationUpdate: core.OperationUpdate{
+				NamespacedOpID: op.Namespace + ":" + op.ID.String(),
+				Plugin:         op.Plugin,
+				Status:         core.OpStatusFailed,
+				ErrorMessage:   err.Error(),
+			},
+		})
+		return nil
+	}
+
+	// Submitting idempotently leaves the operation initialized if DX does not accept it, so we can try again
+	_, err = pm.operations.RunOperation(ctx, prepared, true)
+	return err
+}
@@ -0,0 +1,360 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/batch"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/operationmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestQueuePrivateMessaging(t *testing.T) (*privateMessaging, func()) {
+	pm, cancel := newTestPrivateMessaging(t)
+	pm.queueEnabled = true
+	pm.queuePageSize = 50
+	pm.queuePollInterval = time.Minute
+	return pm, cancel
+}
+
+func newTestQueuedPayload(groupID *fftypes.Bytes32) *batch.DispatchPayload {
+	blob1 := fftypes.NewRandB32()
+	return &batch.DispatchPayload{
+		Batch: core.BatchPersisted{
+			BatchHeader: core.BatchHeader{
+				ID:        fftypes.NewUUID(),
+				Group:     groupID,
+				Namespace: "ns1",
+			},
+			TX: core.TransactionRef{
+				Type: core.TransactionTypeUnpinned,
+				ID:   fftypes.NewUUID(),
+			},
+		},
+		Data: core.DataArray{
+			{ID: fftypes.NewUUID(), Blob: &core.BlobRef{Hash: blob1}},
+		},
+	}
+}
+
+func mockQueuedDispatch(pm *privateMessaging) (*databasemocks.Plugin, *fftypes.Bytes32) {
+	localNode := newTestNode("node1", newTestOrg("localorg"))
+	remoteNode := newTestNode("node2", newTestOrg("remoteorg"))
+	groupID := fftypes.NewRandB32()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mom := pm.operations.(*operationmocks.Manager)
+	mim.On("GetLocalNode", pm.ctx).Return(localNode, nil)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", groupID).Return(&core.Group{
+		Hash: groupID,
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{
+				{Identity: "org1", Node: localNode.ID},
+				{Identity: "org2", Node: remoteNode.ID},
+			},
+		},
+	}, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, localNode.ID).Return(localNode, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, remoteNode.ID).Return(remoteNode, nil)
+	mdi.On("GetBlobs", pm.ctx, "ns1", mock.Anything).Return([]*core.Blob{{Hash: fftypes.NewRandB32(), PayloadRef: "/blob/1"}}, nil, nil)
+	mom.On("AddOrReuseOperation", pm.ctx, mock.Anything).Return(nil)
+	return mdi, groupID
+}
+
+func TestDispatchBatchQueued(t *testing.T) {
+	pm, cancel := newTestQueuePrivateMessaging(t)
+	defer cancel()
+
+	mdi, groupID := mockQueuedDispatch(pm)
+	var queued []*core.DXQueueEntry
+	mdi.On("InsertDXQueueEntry", pm.ctx, mock.Anything).Run(func(args mock.Arguments) {
+		queued = append(queued, args[1].(*core.DXQueueEntry))
+	}).Return(nil)
+
+	err := pm.dispatchUnpinnedBatch(pm.ctx, newTestQueuedPayload(groupID))
+	assert.NoError(t, err)
+
+	// The blob is queued ahead of the batch that references it, and nothing is sent directly
+	assert.Len(t, queued, 2)
+	assert.Equal(t, core.DXSendPriorityBlob, queued[0].Priority)
+	assert.Equal(t, core.DXSendPriorityBlob, queued[1].Priority)
+	assert.Equal(t, "ns1", queued[1].Namespace)
+	assert.True(t, pm.queueNotified())
+
+	mom := pm.operations.(*operationmocks.Manager)
+	mom.AssertNotCalled(t, "RunOperation", mock.Anything, mock.Anything, mock.Anything)
+	mdi.AssertExpectations(t)
+}
+
+func TestDispatchBatchQueueFail(t *testing.T) {
+	pm, cancel := newTestQueuePrivateMessaging(t)
+	defer cancel()
+
+	mdi, groupID := mockQueuedDispatch(pm)
+	mdi.On("InsertDXQueueEntry", pm.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := pm.dispatchUnpinnedBatch(pm.ctx, newTestQueuedPayload(groupID))
+	assert.Regexp(t, "pop", err)
+	assert.False(t, pm.queueNotified())
+}
+
+func TestBatchSendPriority(t *testing.T) {
+	batch := &core.Batch{
+		Payload: core.BatchPayload{
+			Messages: []*core.Message{{Header: core.MessageHeader{Type: core.MessageTypePrivate}}},
+			Data:     core.DataArray{{ID: fftypes.NewUUID()}},
+		},
+	}
+	assert.Equal(t, core.DXSendPriorityMessage, batchSendPriority(batch))
+
+	batch.Payload.Data = append(batch.Payload.Data, &core.Data{Blob: &core.BlobRef{Hash: fftypes.NewRandB32()}})
+	assert.Equal(t, core.DXSendPriorityBlob, batchSendPriority(batch))
+
+	batch.Payload.Messages = append(batch.Payload.Messages, &core.Message{Header: core.MessageHeader{Type: core.MessageTypeGroupInit}})
+	assert.Equal(t, core.DXSendPriorityDefinition, batchSendPriority(batch))
+}
+
+func TestStartQueueDisabled(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	err := pm.Start()
+	assert.NoError(t, err)
+	assert.Nil(t, pm.queueDone)
+	pm.WaitStop()
+}
+
+func newTestQueueEntry() (*core.DXQueueEntry, *core.Operation) {
+	op := &core.Operation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Type:      core.OpTypeDataExchangeSendBatch,
+		Plugin:    "utdx",
+		Status:    core.OpStatusInitialized,
+	}
+	return &core.DXQueueEntry{
+		Namespace: "ns1",
+		Operation: op.ID,
+		Priority:  core.DXSendPriorityMessage,
+		Sequence:  12345,
+	}, op
+}
+
+func TestQueueLoopDispatchesInOrder(t *testing.T) {
+	pm, cancel := newTestQueuePrivateMessaging(t)
+	defer cancel()
+
+	entry1, op1 := newTestQueueEntry()
+	entry2, op2 := newTestQueueEntry()
+	entry2.Sequence = 12346
+	prepared1 := &core.PreparedOperation{ID: op1.ID}
+	prepared2 := &core.PreparedOperation{ID: op2.ID}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mom := pm.operations.(*operationmocks.Manager)
+	mdi.On("GetDXQueueEntries", pm.ctx, "ns1", 50).Return([]*core.DXQueueEntry{entry1, entry2}, nil).Once()
+	mdi.On("GetDXQueueEntries", pm.ctx, "ns1", 50).Return([]*core.DXQueueEntry{}, nil).Run(func(args mock.Arguments) {
+		cancel()
+	})
+	mom.On("GetOperationByIDCached", pm.ctx, op1.ID).Return(op1, nil)
+	mom.On("GetOperationByIDCached", pm.ctx, op2.ID).Return(op2, nil)
+	mom.On("PrepareOperation", pm.ctx, op1).Return(prepared1, nil)
+	mom.On("PrepareOperation", pm.ctx, op2).Return(prepared2, nil)
+	var sent []*core.PreparedOperation
+	mom.On("RunOperation", pm.ctx, mock.Anything, true).Run(func(args mock.Arguments) {
+		sent = append(sent, args[1].(*core.PreparedOperation))
+	}).Return(nil, nil)
+	mdi.On("DeleteDXQueueEntry", pm.ctx, "ns1", int64(12345)).Return(nil)
+	mdi.On("DeleteDXQueueEntry", pm.ctx, "ns1", int64(12346)).Return(nil)
+
+	err := pm.Start()
+	assert.NoError(t, err)
+	pm.WaitStop()
+
+	assert.Equal(t, []*core.PreparedOperation{prepared1, prepared2}, sent)
+	mdi.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestQueueLoopRereadsOnNotify(t *testing.T) {
+	pm, cancel := newTestQueuePrivateMessaging(t)
+	defer cancel()
+
+	entry1, op1 := newTestQueueEntry()
+	entry2, _ := newTestQueueEntry()
+	op1.Status = core.OpStatusPending
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mom := pm.operations.(*operationmocks.Manager)
+	mdi.On("GetDXQueueEntries", pm.ctx, "ns1", 50).Return([]*core.DXQueueEntry{entry1, entry2}, nil).Once()
+	mdi.On("GetDXQueueEntries", pm.ctx, "ns1", 50).Return([]*core.DXQueueEntry{}, nil).Run(func(args mock.Arguments) {
+		cancel()
+	})
+	mom.On("GetOperationByIDCached", pm.ctx, op1.ID).Return(op1, nil)
+	mdi.On("DeleteDXQueueEntry", pm.ctx, "ns1", int64(12345)).Return(nil).Run(func(args mock.Arguments) {
+		pm.notifyQueue()
+	}).Once()
+
+	err := pm.Start()
+	assert.NoError(t, err)
+	pm.WaitStop()
+
+	mdi.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	mom.AssertNotCalled(t, "PrepareOperation", mock.Anything, mock.Anything)
+}
+
+func TestQueueLoopNotifiedWhenEmpty(t *testing.T) {
+	pm, cancel := newTestQueuePrivateMessaging(t)
+	defer cancel()
+	pm.notifyQueue()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetDXQueueEntries", pm.ctx, "ns1", 50).Return([]*core.DXQueueEntry{}, nil).Once()
+	mdi.On("GetDXQueueEntries", pm.ctx, "ns1", 50).Return([]*core.DXQueueEntry{}, nil).Run(func(args mock.Arguments) {
+		cancel()
+	})
+
+	err := pm.Start()
+	assert.NoError(t, err)
+	pm.WaitStop()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueLoopReadFail(t *testing.T) {
+	pm, cancel := newTestQueuePrivateMessaging(t)
+	defer cancel()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetDXQueueEntries", pm.ctx, "ns1", 50).Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		cancel()
+	})
+
+	err := pm.Start()
+	assert.NoError(t, err)
+	pm.WaitStop()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueLoopRunFailExits(t *testing.T) {
+	pm, cancel := newTestQueuePrivateMessaging(t)
+	defer cancel()
+
+	entry1, op1 := newTestQueueEntry()
+	prepared1 := &core.PreparedOperation{ID: op1.ID}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mom := pm.operations.(*operationmocks.Manager)
+	mdi.On("GetDXQueueEntries", pm.ctx, "ns1", 50).Return([]*core.DXQueueEntry{entry1}, nil)
+	mom.On("GetOperationByIDCached", pm.ctx, op1.ID).Return(op1, nil)
+	mom.On("PrepareOperation", pm.ctx, op1).Return(prepared1, nil)
+	mom.On("RunOperation", pm.ctx, prepared1, true).Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		cancel()
+	})
+
+	err := pm.Start()
+	assert.NoError(t, err)
+	pm.WaitStop()
+
+	mdi.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	mdi.AssertNotCalled(t, "DeleteDXQueueEntry", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDispatchQueuedSendRetryDelete(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	entry1, op1 := newTestQueueEntry()
+	prepared1 := &core.PreparedOperation{ID: op1.ID}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mom := pm.operations.(*operationmocks.Manager)
+	mom.On("GetOperationByIDCached", pm.ctx, op1.ID).Return(op1, nil).Once()
+	mom.On("PrepareOperation", pm.ctx, op1).Return(prepared1, nil).Once()
+	mom.On("RunOperation", pm.ctx, prepared1, true).Return(nil, nil).Once()
+	mdi.On("DeleteDXQueueEntry", pm.ctx, "ns1", int64(12345)).Return(fmt.Errorf("pop")).Once()
+	mdi.On("DeleteDXQueueEntry", pm.ctx, "ns1", int64(12345)).Return(nil).Once()
+
+	err := pm.dispatchQueuedSend(pm.ctx, entry1)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestRunQueuedOperationLookupFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	entry1, op1 := newTestQueueEntry()
+
+	mom := pm.operations.(*operationmocks.Manager)
+	mom.On("GetOperationByIDCached", pm.ctx, op1.ID).Return(nil, fmt.Errorf("pop"))
+
+	err := pm.runQueuedOperation(pm.ctx, entry1)
+	assert.Regexp(t, "pop", err)
+
+	mom.AssertExpectations(t)
+}
+
+func TestRunQueuedOperationNotFound(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	entry1, op1 := newTestQueueEntry()
+
+	mom := pm.operations.(*operationmocks.Manager)
+	mom.On("GetOperationByIDCached", pm.ctx, op1.ID).Return(nil, nil)
+
+	err := pm.runQueuedOperation(pm.ctx, entry1)
+	assert.NoError(t, err)
+
+	mom.AssertExpectations(t)
+}
+
+func TestRunQueuedOperationPrepareFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	entry1, op1 := newTestQueueEntry()
+
+	mom := pm.operations.(*operationmocks.Manager)
+	mom.On("GetOperationByIDCached", pm.ctx, op1.ID).Return(op1, nil)
+	mom.On("PrepareOperation", pm.ctx, op1).Return(nil, fmt.Errorf("pop"))
+	mom.On("SubmitOperationUpdate", mock.MatchedBy(func(update *core.OperationUpdateAsync) bool {
+		return update.NamespacedOpID == "ns1:"+op1.ID.String() &&
+			update.Status == core.OpStatusFailed &&
+			update.ErrorMessage == "pop"
+	})).Return()
+
+	err := pm.runQueuedOperation(pm.ctx, entry1)
+	assert.NoError(t, err)
+
+	mom.AssertExpectations(t)
+}
@@ -165,6 +165,8 @@ func (pm *privateMessaging) findOrGenerateGroup(ctx context.Context, in *core.Me
 	newCandidate := &core.Group{
 		GroupIdentity: *gi,
 		Created:       fftypes.Now(),
+		DisplayName:   in.Group.DisplayName,
+		Description:   in.Group.Description,
 	}
 	newCandidate.Seal()
 
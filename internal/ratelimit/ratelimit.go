@@ -0,0 +1,121 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// sweepInterval is how often buckets that have refilled are discarded, so the memory used
+// is bounded by the number of identities that have made requests recently
+const sweepInterval = time.Minute
+
+// Limiter is a token bucket for each identity and route group of a namespace. Each bucket holds up to
+// the burst size of the group, and refills at the requests per second of the group.
+type Limiter struct {
+	mux       sync.Mutex
+	limits    map[core.RateLimitGroup]core.RateLimit
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucketKey struct {
+	group    core.RateLimitGroup
+	identity string
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewLimiter returns nil if none of the groups are limited
+func NewLimiter(limits map[core.RateLimitGroup]core.RateLimit) *Limiter {
+	l := &Limiter{
+		limits:  make(map[core.RateLimitGroup]core.RateLimit),
+		buckets: make(map[bucketKey]*bucket),
+		now:     time.Now,
+	}
+	for group, limit := range limits {
+		if limit.RequestsPerSecond > 0 {
+			if limit.Burst <= 0 {
+				limit.Burst = int(math.Max(1, math.Ceil(limit.RequestsPerSecond)))
+			}
+			l.limits[group] = limit
+		}
+	}
+	if len(l.limits) == 0 {
+		return nil
+	}
+	l.lastSweep = l.now()
+	return l
+}
+
+// Take uses one request of the allowance of the identity, returning false if none remains.
+// The status is nil if the group is not limited.
+func (l *Limiter) Take(group core.RateLimitGroup, identity string) (status *core.RateLimitStatus, allowed bool) {
+	limit, ok := l.limits[group]
+	if !ok {
+		return nil, true
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	key := bucketKey{group: group, identity: identity}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.RequestsPerSecond)
+	b.updated = now
+	if b.tokens >= 1 {
+		b.tokens--
+		allowed = true
+	}
+
+	status = &core.RateLimitStatus{
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(b.tokens)),
+		Reset:     int64(math.Ceil((float64(limit.Burst) - b.tokens) / limit.RequestsPerSecond)),
+	}
+	if !allowed {
+		status.RetryAfter = int64(math.Ceil((1 - b.tokens) / limit.RequestsPerSecond))
+	}
+	return status, allowed
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		limit := l.limits[key.group]
+		if b.tokens+now.Sub(b.updated).Seconds()*limit.RequestsPerSecond >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
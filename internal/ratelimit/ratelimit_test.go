@@ -0,0 +1,98 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLimiter(limits map[core.RateLimitGroup]core.RateLimit) (*Limiter, *time.Time) {
+	now := time.Unix(1000000, 0)
+	l := NewLimiter(limits)
+	l.now = func() time.Time { return now }
+	l.lastSweep = now
+	return l, &now
+}
+
+func TestNewLimiterNoLimits(t *testing.T) {
+	assert.Nil(t, NewLimiter(nil))
+	assert.Nil(t, NewLimiter(map[core.RateLimitGroup]core.RateLimit{
+		core.RateLimitGroupMessages: {RequestsPerSecond: 0, Burst: 10},
+	}))
+}
+
+func TestTakeBurstAndRefill(t *testing.T) {
+	l, now := newTestLimiter(map[core.RateLimitGroup]core.RateLimit{
+		core.RateLimitGroupMessages: {RequestsPerSecond: 2, Burst: 3},
+	})
+
+	for i := 2; i >= 0; i-- {
+		status, allowed := l.Take(core.RateLimitGroupMessages, "alice")
+		assert.True(t, allowed)
+		assert.Equal(t, 3, status.Limit)
+		assert.Equal(t, i, status.Remaining)
+	}
+	status, allowed := l.Take(core.RateLimitGroupMessages, "alice")
+	assert.False(t, allowed)
+	assert.Equal(t, 0, status.Remaining)
+	assert.Equal(t, int64(1), status.RetryAfter)
+	assert.Equal(t, int64(2), status.Reset)
+
+	// Other identities have their own allowance
+	_, allowed = l.Take(core.RateLimitGroupMessages, "bob")
+	assert.True(t, allowed)
+
+	// Half a second refills one request
+	*now = now.Add(500 * time.Millisecond)
+	_, allowed = l.Take(core.RateLimitGroupMessages, "alice")
+	assert.True(t, allowed)
+	_, allowed = l.Take(core.RateLimitGroupMessages, "alice")
+	assert.False(t, allowed)
+}
+
+func TestTakeUnlimitedGroup(t *testing.T) {
+	l, _ := newTestLimiter(map[core.RateLimitGroup]core.RateLimit{
+		core.RateLimitGroupMessages: {RequestsPerSecond: 1},
+	})
+	status, allowed := l.Take(core.RateLimitGroupTokens, "alice")
+	assert.True(t, allowed)
+	assert.Nil(t, status)
+
+	// The burst defaults to one second of requests
+	status, _ = l.Take(core.RateLimitGroupMessages, "alice")
+	assert.Equal(t, 1, status.Limit)
+}
+
+func TestSweepDiscardsFullBuckets(t *testing.T) {
+	l, now := newTestLimiter(map[core.RateLimitGroup]core.RateLimit{
+		core.RateLimitGroupMessages: {RequestsPerSecond: 1, Burst: 100},
+	})
+	l.Take(core.RateLimitGroupMessages, "alice")
+	*now = now.Add(30 * time.Second)
+	l.Take(core.RateLimitGroupMessages, "bob")
+	assert.Len(t, l.buckets, 2)
+
+	// After a minute alice has refilled, but bob has not
+	*now = now.Add(31 * time.Second)
+	l.Take(core.RateLimitGroupMessages, "carol")
+	assert.Len(t, l.buckets, 2)
+	assert.NotContains(t, l.buckets, bucketKey{group: core.RateLimitGroupMessages, identity: "alice"})
+}
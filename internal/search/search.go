@@ -0,0 +1,263 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Indexer follows the message confirmed and rejected events of a namespace in sequence order, and writes a
+// document for each message to the search index, containing the tag, topics and JSON data values of the message.
+// The events are followed rather than the messages, as the event sequence is allocated under a namespace lock
+// and so always increases in commit order, whereas message rows can commit out of sequence order and would be
+// skipped. The sequence of the last indexed event is stored as an offset, so indexing resumes where it left off
+// after a restart.
+type Indexer interface {
+	Start()
+	WaitStop()
+	Search(ctx context.Context, query string, limit int) ([]*database.SearchHit, error)
+}
+
+type indexer struct {
+	ctx       context.Context
+	namespace string
+	database  database.Plugin
+	sink      Sink
+	interval  time.Duration
+	batchSize int
+	done      chan struct{}
+}
+
+// NewIndexer returns nil if search is not enabled
+func NewIndexer(ctx context.Context, ns string, di database.Plugin) (Indexer, error) {
+	if !config.GetBool(coreconfig.SearchEnabled) {
+		return nil, nil
+	}
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "SearchIndexer")
+	}
+	sink, err := NewSink(ctx, di)
+	if err != nil {
+		return nil, err
+	}
+	return &indexer{
+		ctx:       ctx,
+		namespace: ns,
+		database:  di,
+		sink:      sink,
+		interval:  config.GetDuration(coreconfig.SearchInterval),
+		batchSize: config.GetInt(coreconfig.SearchBatchSize),
+	}, nil
+}
+
+func (ix *indexer) Start() {
+	ix.done = make(chan struct{})
+	go ix.indexLoop()
+}
+
+func (ix *indexer) WaitStop() {
+	if ix.done != nil {
+		<-ix.done
+	}
+}
+
+func (ix *indexer) Search(ctx context.Context, query string, limit int) ([]*database.SearchHit, error) {
+	return ix.sink.Search(ctx, ix.namespace, query, limit)
+}
+
+func (ix *indexer) indexLoop() {
+	defer close(ix.done)
+	for {
+		indexed, err := ix.indexBatch(ix.ctx)
+		if err != nil {
+			log.L(ix.ctx).Errorf("Search indexing failed: %s", err)
+		}
+		if err != nil || indexed < ix.batchSize {
+			select {
+			case <-time.After(ix.interval):
+			case <-ix.ctx.Done():
+				log.L(ix.ctx).Debugf("Search indexer exiting")
+				return
+			}
+		} else if ix.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (ix *indexer) indexBatch(ctx context.Context) (indexed int, err error) {
+	offset, err := ix.database.GetOffset(ctx, core.OffsetTypeSearch, ix.namespace)
+	if err != nil {
+		return 0, err
+	}
+	if offset == nil {
+		offset = &core.Offset{Type: core.OffsetTypeSearch, Name: ix.namespace}
+	}
+
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	events, _, err := ix.database.GetEvents(ctx, ix.namespace, fb.And(
+		fb.Gt("sequence", offset.Current),
+		fb.In("type", []driver.Value{core.EventTypeMessageConfirmed, core.EventTypeMessageRejected}),
+	).Sort("sequence").Limit(uint64(ix.batchSize)))
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	msgs, err := ix.getMessages(ctx, events)
+	if err != nil {
+		return 0, err
+	}
+	values, err := ix.getDataValues(ctx, msgs)
+	if err != nil {
+		return 0, err
+	}
+	docs := make([]*core.SearchDocument, len(msgs))
+	for i, msg := range msgs {
+		docs[i] = buildDocument(ix.namespace, msg, values)
+	}
+	if len(docs) > 0 {
+		if err := ix.sink.Index(ctx, docs); err != nil {
+			return 0, err
+		}
+	}
+
+	offset.Current = events[len(events)-1].Sequence
+	if err := ix.database.UpsertOffset(ctx, offset, true); err != nil {
+		return 0, err
+	}
+	log.L(ctx).Debugf("Indexed %d messages for search, up to event sequence %d", len(msgs), offset.Current)
+	return len(events), nil
+}
+
+// getMessages reads the messages referred to by a batch of events in a single query, returned in event order.
+// Messages pruned by the retention policy since the event was written are skipped.
+func (ix *indexer) getMessages(ctx context.Context, events []*core.Event) ([]*core.Message, error) {
+	ids := make([]driver.Value, 0, len(events))
+	seen := make(map[fftypes.UUID]bool)
+	for _, event := range events {
+		if event.Reference != nil && !seen[*event.Reference] {
+			seen[*event.Reference] = true
+			ids = append(ids, event.Reference)
+		}
+	}
+	if len(ids) == 0 {
+		return []*core.Message{}, nil
+	}
+	fb := database.MessageQueryFactory.NewFilter(ctx)
+	found, _, err := ix.database.GetMessages(ctx, ix.namespace, fb.In("id", ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[fftypes.UUID]*core.Message, len(found))
+	for _, msg := range found {
+		byID[*msg.Header.ID] = msg
+	}
+	msgs := make([]*core.Message, 0, len(found))
+	for _, id := range ids {
+		if msg := byID[*id.(*fftypes.UUID)]; msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, nil
+}
+
+func (ix *indexer) getDataValues(ctx context.Context, msgs []*core.Message) (map[fftypes.UUID]*fftypes.JSONAny, error) {
+	values := make(map[fftypes.UUID]*fftypes.JSONAny)
+	var ids []driver.Value
+	for _, msg := range msgs {
+		for _, d := range msg.Data {
+			ids = append(ids, d.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return values, nil
+	}
+	fb := database.DataQueryFactory.NewFilter(ctx)
+	data, _, err := ix.database.GetData(ctx, ix.namespace, fb.In("id", ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range data {
+		values[*d.ID] = d.Value
+	}
+	return values, nil
+}
+
+func buildDocument(ns string, msg *core.Message, values map[fftypes.UUID]*fftypes.JSONAny) *core.SearchDocument {
+	terms := []string{}
+	if msg.Header.Tag != "" {
+		terms = append(terms, msg.Header.Tag)
+	}
+	terms = append(terms, msg.Header.Topics...)
+	for _, d := range msg.Data {
+		if value := values[*d.ID]; value != nil {
+			var parsed interface{}
+			decoder := json.NewDecoder(strings.NewReader(value.String()))
+			decoder.UseNumber()
+			if err := decoder.Decode(&parsed); err == nil {
+				terms = appendJSONValues(terms, parsed)
+			}
+		}
+	}
+	return &core.SearchDocument{
+		Namespace: ns,
+		Message:   msg.Header.ID,
+		Tag:       msg.Header.Tag,
+		Topics:    msg.Header.Topics,
+		Content:   strings.Join(terms, " "),
+		Created:   msg.Header.Created,
+	}
+}
+
+// appendJSONValues collects the string and number values from anywhere within a JSON document.
+// Keys are not indexed, as they are generally common to every message using the same datatype.
+func appendJSONValues(terms []string, v interface{}) []string {
+	switch vt := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(vt))
+		for k := range vt {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			terms = appendJSONValues(terms, vt[k])
+		}
+	case []interface{}:
+		for _, child := range vt {
+			terms = appendJSONValues(terms, child)
+		}
+	case string:
+		terms = append(terms, vt)
+	case json.Number:
+		terms = append(terms, vt.String())
+	}
+	return terms
+}
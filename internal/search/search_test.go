@@ -0,0 +1,239 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestIndexer(t *testing.T) (*indexer, *databasemocks.Plugin, func()) {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set(coreconfig.SearchEnabled, true)
+	config.Set(coreconfig.SearchBatchSize, 2)
+	config.Set(coreconfig.SearchInterval, "1ms")
+	mdi := &databasemocks.Plugin{}
+	ctx, cancel := context.WithCancel(context.Background())
+	ix, err := NewIndexer(ctx, "ns1", mdi)
+	assert.NoError(t, err)
+	return ix.(*indexer), mdi, func() {
+		cancel()
+		ix.WaitStop()
+		mdi.AssertExpectations(t)
+	}
+}
+
+func TestNewIndexerDisabled(t *testing.T) {
+	coreconfig.Reset()
+	ix, err := NewIndexer(context.Background(), "ns1", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ix)
+}
+
+func TestNewIndexerNilDatabase(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.SearchEnabled, true)
+	_, err := NewIndexer(context.Background(), "ns1", nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestNewIndexerBadType(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.SearchEnabled, true)
+	config.Set(coreconfig.SearchType, "wrong")
+	_, err := NewIndexer(context.Background(), "ns1", &databasemocks.Plugin{})
+	assert.Regexp(t, "FF10521", err)
+}
+
+func TestIndexLoop(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	data1 := fftypes.NewUUID()
+	msg1 := &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID(), Tag: "invoice", Topics: fftypes.FFStringArray{"orders"}},
+		Data:   core.DataRefs{{ID: data1}},
+	}
+	msg2 := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	events := []*core.Event{
+		{Sequence: 5, Type: core.EventTypeMessageConfirmed, Reference: msg1.Header.ID},
+		{Sequence: 7, Type: core.EventTypeMessageRejected, Reference: msg2.Header.ID},
+	}
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(events, nil, nil).Once()
+	// Returned out of order, but indexed in event order
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{msg2, msg1}, nil, nil).Once()
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{
+		{ID: data1, Value: fftypes.JSONAnyPtr(`{"customer":"Acme","lines":[{"sku":"widget-1","qty":10}],"paid":true}`)},
+	}, nil, nil)
+	mdi.On("InsertSearchDocuments", mock.Anything, mock.MatchedBy(func(docs []*core.SearchDocument) bool {
+		return len(docs) == 2 &&
+			docs[0].Content == "invoice orders Acme 10 widget-1" &&
+			docs[0].Tag == "invoice" &&
+			docs[1].Content == ""
+	})).Return(nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Current == 7
+	}), true).Return(nil)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(&core.Offset{Current: 7}, nil)
+	done := make(chan struct{})
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Run(func(args mock.Arguments) {
+		select {
+		case <-done:
+		default:
+			close(done)
+		}
+	})
+
+	ix.Start()
+	<-done
+}
+
+func TestIndexLoopError(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	done := make(chan struct{})
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		select {
+		case <-done:
+		default:
+			close(done)
+		}
+	})
+
+	ix.Start()
+	<-done
+}
+
+func TestIndexBatchGetEventsFail(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ix.indexBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestIndexBatchGetMessagesFail(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 1, Reference: fftypes.NewUUID()}}, nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ix.indexBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestIndexBatchPrunedMessages(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 1, Reference: fftypes.NewUUID()}, {Sequence: 2}}, nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Current == 2
+	}), true).Return(nil)
+
+	indexed, err := ix.indexBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, indexed)
+}
+
+func TestIndexBatchNoReferences(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 1}}, nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+	_, err := ix.indexBatch(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestIndexBatchGetDataFail(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, Data: core.DataRefs{{ID: fftypes.NewUUID()}}}
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 1, Reference: msg.Header.ID}}, nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ix.indexBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestIndexBatchIndexFail(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 1, Reference: msg.Header.ID}}, nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("InsertSearchDocuments", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := ix.indexBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestIndexBatchOffsetFail(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSearch, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 1, Reference: msg.Header.ID}}, nil, nil)
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	mdi.On("InsertSearchDocuments", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(fmt.Errorf("pop"))
+
+	_, err := ix.indexBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSearchDatabase(t *testing.T) {
+	ix, mdi, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	hits := []*database.SearchHit{{Message: fftypes.NewUUID(), Score: 1}}
+	mdi.On("SearchDocuments", mock.Anything, "ns1", "widget", 10).Return(hits, nil)
+
+	result, err := ix.Search(context.Background(), "widget", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, hits, result)
+}
@@ -0,0 +1,164 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const (
+	// SinkTypeDatabase indexes messages in the database. PostgreSQL matches and ranks them with a tsvector,
+	// while other databases fall back to a substring match
+	SinkTypeDatabase = "database"
+	// SinkTypeOpenSearch indexes messages in an external OpenSearch (or Elasticsearch) cluster
+	SinkTypeOpenSearch = "opensearch"
+)
+
+var openSearchConfig = config.RootSection("search.opensearch")
+
+func InitConfig() {
+	ffresty.InitConfig(openSearchConfig)
+}
+
+// Sink is the search index that message documents are written to, and queried from
+type Sink interface {
+	Index(ctx context.Context, docs []*core.SearchDocument) error
+	Search(ctx context.Context, ns, query string, limit int) ([]*database.SearchHit, error)
+}
+
+func NewSink(ctx context.Context, di database.Plugin) (Sink, error) {
+	sinkType := config.GetString(coreconfig.SearchType)
+	switch sinkType {
+	case SinkTypeDatabase:
+		return &dbSink{database: di}, nil
+	case SinkTypeOpenSearch:
+		client, err := ffresty.New(ctx, openSearchConfig)
+		if err != nil {
+			return nil, err
+		}
+		return &openSearchSink{client: client, index: config.GetString(coreconfig.SearchOpenSearchIndex)}, nil
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedSearchType, sinkType)
+	}
+}
+
+type dbSink struct {
+	database database.Plugin
+}
+
+func (ds *dbSink) Index(ctx context.Context, docs []*core.SearchDocument) error {
+	return ds.database.InsertSearchDocuments(ctx, docs)
+}
+
+func (ds *dbSink) Search(ctx context.Context, ns, query string, limit int) ([]*database.SearchHit, error) {
+	return ds.database.SearchDocuments(ctx, ns, query, limit)
+}
+
+type openSearchSink struct {
+	client *resty.Client
+	index  string
+}
+
+type openSearchBulkResponse struct {
+	Errors bool `json:"errors"`
+}
+
+type openSearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			Score  float64 `json:"_score"`
+			Source struct {
+				Message *fftypes.UUID `json:"message"`
+			} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// Index writes the documents with the bulk API. Each document is keyed by namespace and message ID,
+// so re-indexing a message after a restart overwrites the existing document.
+func (oss *openSearchSink) Index(ctx context.Context, docs []*core.SearchDocument) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, doc := range docs {
+		_ = enc.Encode(map[string]interface{}{
+			"index": map[string]string{
+				"_index": oss.index,
+				"_id":    doc.Namespace + ":" + doc.Message.String(),
+			},
+		})
+		_ = enc.Encode(doc)
+	}
+	var result openSearchBulkResponse
+	res, err := oss.client.R().SetContext(ctx).
+		SetHeader("Content-Type", "application/x-ndjson").
+		SetBody(buf.Bytes()).
+		SetResult(&result).
+		Post("/_bulk")
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSearchIndexErr)
+	}
+	if result.Errors {
+		return i18n.NewError(ctx, coremsgs.MsgSearchIndexErr, res.String())
+	}
+	return nil
+}
+
+func (oss *openSearchSink) Search(ctx context.Context, ns, query string, limit int) ([]*database.SearchHit, error) {
+	body := map[string]interface{}{
+		"size":    limit,
+		"_source": []string{"message"},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				// Relies on the keyword sub-field created by the default dynamic mapping
+				"filter": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{"namespace.keyword": ns}},
+				},
+				"must": []interface{}{
+					map[string]interface{}{"multi_match": map[string]interface{}{
+						"query":  query,
+						"fields": []string{"tag^2", "topics^2", "content"},
+					}},
+				},
+			},
+		},
+	}
+	var result openSearchResponse
+	res, err := oss.client.R().SetContext(ctx).
+		SetBody(body).
+		SetResult(&result).
+		Post("/" + oss.index + "/_search")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSearchIndexErr)
+	}
+	hits := make([]*database.SearchHit, len(result.Hits.Hits))
+	for i, h := range result.Hits.Hits {
+		hits[i] = &database.SearchHit{Message: h.Source.Message, Score: h.Score}
+	}
+	return hits, nil
+}
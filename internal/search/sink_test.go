@@ -0,0 +1,126 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOpenSearchSink(t *testing.T, handler http.HandlerFunc) (Sink, func()) {
+	server := httptest.NewServer(handler)
+	coreconfig.Reset()
+	InitConfig()
+	config.Set(coreconfig.SearchType, SinkTypeOpenSearch)
+	config.Set(coreconfig.SearchOpenSearchIndex, "ffidx")
+	openSearchConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	sink, err := NewSink(context.Background(), nil)
+	assert.NoError(t, err)
+	return sink, server.Close
+}
+
+func TestOpenSearchIndex(t *testing.T) {
+	msgID := fftypes.NewUUID()
+	sink, done := newTestOpenSearchSink(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_bulk", r.URL.Path)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		scanner := bufio.NewScanner(r.Body)
+		var lines []map[string]interface{}
+		for scanner.Scan() {
+			var line map[string]interface{}
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			lines = append(lines, line)
+		}
+		assert.Len(t, lines, 2)
+		assert.Equal(t, "ns1:"+msgID.String(), lines[0]["index"].(map[string]interface{})["_id"])
+		assert.Equal(t, "ffidx", lines[0]["index"].(map[string]interface{})["_index"])
+		assert.Equal(t, "invoice Acme", lines[1]["content"])
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errors":false}`))
+	})
+	defer done()
+
+	err := sink.Index(context.Background(), []*core.SearchDocument{
+		{Namespace: "ns1", Message: msgID, Content: "invoice Acme"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestOpenSearchIndexItemErrors(t *testing.T) {
+	sink, done := newTestOpenSearchSink(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errors":true}`))
+	})
+	defer done()
+
+	err := sink.Index(context.Background(), []*core.SearchDocument{
+		{Namespace: "ns1", Message: fftypes.NewUUID()},
+	})
+	assert.Regexp(t, "FF10522", err)
+}
+
+func TestOpenSearchIndexFail(t *testing.T) {
+	sink, done := newTestOpenSearchSink(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer done()
+
+	err := sink.Index(context.Background(), []*core.SearchDocument{
+		{Namespace: "ns1", Message: fftypes.NewUUID()},
+	})
+	assert.Regexp(t, "FF10522", err)
+}
+
+func TestOpenSearchSearch(t *testing.T) {
+	msgID := fftypes.NewUUID()
+	sink, done := newTestOpenSearchSink(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ffidx/_search", r.URL.Path)
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(5), body["size"])
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hits":{"hits":[{"_score":1.5,"_source":{"message":"` + msgID.String() + `"}}]}}`))
+	})
+	defer done()
+
+	hits, err := sink.Search(context.Background(), "ns1", "widget", 5)
+	assert.NoError(t, err)
+	assert.Len(t, hits, 1)
+	assert.Equal(t, *msgID, *hits[0].Message)
+	assert.Equal(t, 1.5, hits[0].Score)
+}
+
+func TestOpenSearchSearchFail(t *testing.T) {
+	sink, done := newTestOpenSearchSink(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	defer done()
+
+	_, err := sink.Search(context.Background(), "ns1", "widget", 5)
+	assert.Regexp(t, "FF10522", err)
+}
@@ -0,0 +1,113 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/awsauth"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+const (
+	awsService = "secretsmanager"
+	awsTarget  = "secretsmanager.GetSecretValue"
+)
+
+// awsProvider reads secrets from AWS Secrets Manager, calling the GetSecretValue API directly with a
+// Signature Version 4 signed request. References have the form secretId, or secretId#field to read a
+// field of a secret stored as JSON.
+type awsProvider struct {
+	client *resty.Client
+	signer *awsauth.Signer
+}
+
+type awsGetSecretValueRequest struct {
+	SecretID string `json:"SecretId"`
+}
+
+type awsGetSecretValueResponse struct {
+	SecretString string `json:"SecretString"`
+}
+
+func newAWSProvider(ctx context.Context) (Provider, error) {
+	region := awsauth.ConfigOrEnv(awsConfig.GetString(AWSConfRegion), "AWS_REGION")
+	if region == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, awsConfig.Resolve(AWSConfRegion), "secrets")
+	}
+	client, err := ffresty.New(ctx, awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := awsConfig.GetString(ffresty.HTTPConfigURL)
+	if endpoint == "" {
+		// The URL only needs to be set to use a VPC endpoint, or an emulator
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", awsService, region)
+		client.SetBaseURL(endpoint)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, i18n.MsgInvalidURL, endpoint)
+	}
+	return &awsProvider{
+		client: client,
+		signer: &awsauth.Signer{
+			Service:         awsService,
+			Host:            u.Host,
+			Region:          region,
+			AccessKeyID:     awsauth.ConfigOrEnv(awsConfig.GetString(AWSConfAccessKeyID), "AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: awsauth.ConfigOrEnv(awsConfig.GetString(AWSConfSecretAccessKey), "AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    awsauth.ConfigOrEnv(awsConfig.GetString(AWSConfSessionToken), "AWS_SESSION_TOKEN"),
+			Now:             time.Now,
+		},
+	}, nil
+}
+
+func (ap *awsProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	secretID, field := splitFieldRef(ref, "")
+	body, _ := json.Marshal(&awsGetSecretValueRequest{SecretID: secretID})
+	var secret awsGetSecretValueResponse
+	req := ap.client.R().SetContext(ctx).
+		SetBody(body).
+		SetResult(&secret)
+	for k, v := range ap.signer.SignedHeaders(awsTarget, body) {
+		req.SetHeader(k, v)
+	}
+	res, err := req.Post("/")
+	if err != nil || !res.IsSuccess() {
+		return "", ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSecretProviderRequestFailed)
+	}
+	if field == "" {
+		return secret.SecretString, nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(secret.SecretString), &fields); err != nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgSecretFieldNotFound, field)
+	}
+	value, ok := fields[field].(string)
+	if !ok {
+		return "", i18n.NewError(ctx, coremsgs.MsgSecretFieldNotFound, field)
+	}
+	return value, nil
+}
@@ -0,0 +1,122 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/awsauth"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAWSProvider(t *testing.T, handler http.HandlerFunc) *awsProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	coreconfig.Reset()
+	InitConfig()
+	awsConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	awsConfig.Set(AWSConfRegion, "us-east-1")
+	awsConfig.Set(AWSConfAccessKeyID, "AKIDEXAMPLE")
+	awsConfig.Set(AWSConfSecretAccessKey, "secret1")
+	awsConfig.Set(AWSConfSessionToken, "session1")
+	ap, err := newAWSProvider(context.Background())
+	assert.NoError(t, err)
+	return ap.(*awsProvider)
+}
+
+func TestAWSProviderResolve(t *testing.T) {
+	ap := newTestAWSProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "session1", r.Header.Get("X-Amz-Security-Token"))
+		auth := r.Header.Get("Authorization")
+		assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-east-1/secretsmanager/aws4_request, "))
+		assert.Contains(t, auth, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, ")
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var req awsGetSecretValueRequest
+		err = json.Unmarshal(b, &req)
+		assert.NoError(t, err)
+		secretString := "plain1"
+		if req.SecretID == "prod/json" {
+			secretString = `{"password":"pass1"}`
+		}
+		w.Header().Set("Content-Type", awsauth.ContentType)
+		_ = json.NewEncoder(w).Encode(&awsGetSecretValueResponse{SecretString: secretString})
+	})
+	ap.signer.Now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	value, err := ap.Resolve(context.Background(), "prod/plain")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain1", value)
+
+	value, err = ap.Resolve(context.Background(), "prod/json#password")
+	assert.NoError(t, err)
+	assert.Equal(t, "pass1", value)
+
+	_, err = ap.Resolve(context.Background(), "prod/json#missing")
+	assert.Regexp(t, "FF10571", err)
+
+	_, err = ap.Resolve(context.Background(), "prod/plain#password")
+	assert.Regexp(t, "FF10571", err)
+}
+
+func TestAWSProviderResolveFail(t *testing.T) {
+	ap := newTestAWSProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	_, err := ap.Resolve(context.Background(), "prod/plain")
+	assert.Regexp(t, "FF10570", err)
+}
+
+func TestNewAWSProviderDefaultEndpoint(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	awsConfig.Set(AWSConfRegion, "eu-west-2")
+	ap, err := newAWSProvider(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "secretsmanager.eu-west-2.amazonaws.com", ap.(*awsProvider).signer.Host)
+}
+
+func TestNewAWSProviderMissingRegion(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	t.Setenv("AWS_REGION", "")
+	_, err := newAWSProvider(context.Background())
+	assert.Regexp(t, "FF10138.*secrets.aws.region", err)
+}
+
+func TestNewAWSProviderBadTLS(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	awsConfig.Set(AWSConfRegion, "eu-west-2")
+	tlsConf := awsConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	_, err := newAWSProvider(context.Background())
+	assert.Regexp(t, "FF00153", err)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+)
+
+const (
+	// FileConfDirectory is the directory that file secret references are relative to
+	FileConfDirectory = "directory"
+	// VaultConfToken is the token used to authenticate to Vault
+	VaultConfToken = "token"
+	// VaultConfMount is the mount path of the KV version 2 secrets engine in Vault
+	VaultConfMount = "mount"
+	// AWSConfRegion is the AWS region of the Secrets Manager service
+	AWSConfRegion = "region"
+	// AWSConfAccessKeyID is the AWS access key ID, which defaults to the AWS_ACCESS_KEY_ID environment variable
+	AWSConfAccessKeyID = "accessKeyID"
+	// AWSConfSecretAccessKey is the AWS secret access key, which defaults to the AWS_SECRET_ACCESS_KEY environment variable
+	AWSConfSecretAccessKey = "secretAccessKey"
+	// AWSConfSessionToken is an optional AWS session token, which defaults to the AWS_SESSION_TOKEN environment variable
+	AWSConfSessionToken = "sessionToken"
+)
+
+var (
+	fileConfig  = config.RootSection("secrets.file")
+	vaultConfig = config.RootSection("secrets.vault")
+	awsConfig   = config.RootSection("secrets.aws")
+)
+
+func InitConfig() {
+	fileConfig.AddKnownKey(FileConfDirectory, "/run/secrets")
+
+	ffresty.InitConfig(vaultConfig)
+	vaultConfig.AddKnownKey(VaultConfToken)
+	vaultConfig.AddKnownKey(VaultConfMount, "secret")
+
+	ffresty.InitConfig(awsConfig)
+	awsConfig.AddKnownKey(AWSConfRegion)
+	awsConfig.AddKnownKey(AWSConfAccessKeyID)
+	awsConfig.AddKnownKey(AWSConfSecretAccessKey)
+	awsConfig.AddKnownKey(AWSConfSessionToken)
+}
@@ -0,0 +1,49 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// fileProvider reads secrets from files, such as those mounted by Docker or Kubernetes
+type fileProvider struct {
+	directory string
+}
+
+func newFileProvider(ctx context.Context) (Provider, error) {
+	return &fileProvider{directory: fileConfig.GetString(FileConfDirectory)}, nil
+}
+
+func (fp *fileProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	path := filepath.Join(fp.directory, ref)
+	if rel, err := filepath.Rel(fp.directory, path); err != nil || strings.HasPrefix(rel, "..") {
+		return "", i18n.NewError(ctx, coremsgs.MsgSecretFileOutsideDirectory, ref, fp.directory)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	// Files written by editors and most tooling end with a newline, which is never part of the secret
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileProviderResolve(t *testing.T) {
+	newTestSecretsDir(t, map[string]string{"secret1": "value1\r\n"})
+	fp, err := newFileProvider(context.Background())
+	assert.NoError(t, err)
+
+	value, err := fp.Resolve(context.Background(), "secret1")
+	assert.NoError(t, err)
+	assert.Equal(t, "value1", value)
+}
+
+func TestFileProviderOutsideDirectory(t *testing.T) {
+	newTestSecretsDir(t, nil)
+	fp, err := newFileProvider(context.Background())
+	assert.NoError(t, err)
+
+	_, err = fp.Resolve(context.Background(), "../../etc/passwd")
+	assert.Regexp(t, "FF10572", err)
+}
@@ -0,0 +1,151 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets resolves references to secrets held outside of the configuration file, so that connector
+// credentials, database DSNs and auth secrets do not have to be written into the file in plain text.
+//
+// A reference has the form ${provider:reference}, and can be all or part of any string in the configuration,
+// such as "postgres://firefly:${vault:firefly/db#password}@db:5432/firefly". The providers are:
+//
+//   - file - the contents of a file, relative to secrets.file.directory, such as ${file:db-password}
+//   - vault - a field of a secret in a HashiCorp Vault KV version 2 engine, such as ${vault:firefly/db#password}
+//   - aws - an AWS Secrets Manager secret, or a field of a JSON secret, such as ${aws:prod/firefly#password}
+package secrets
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/spf13/viper"
+)
+
+var referenceRegex = regexp.MustCompile(`\$\{(\w+):([^}]+)\}`)
+
+// Provider resolves a reference to the value of a secret
+type Provider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+var providerFactories = map[string]func(ctx context.Context) (Provider, error){
+	"file":  newFileProvider,
+	"vault": newVaultProvider,
+	"aws":   newAWSProvider,
+}
+
+type resolver struct {
+	ctx       context.Context
+	providers map[string]Provider
+}
+
+// ResolveConfig replaces every secret reference in the configuration that has been read with the value of
+// the secret. It is called each time the configuration is read, so rotated secrets are picked up on reload.
+// Providers are only created if a reference to them is found.
+func ResolveConfig(ctx context.Context) error {
+	r := &resolver{
+		ctx:       ctx,
+		providers: make(map[string]Provider),
+	}
+	for _, key := range viper.AllKeys() {
+		value, changed, err := r.resolveValue(key, viper.Get(key))
+		if err != nil {
+			return err
+		}
+		if changed {
+			log.L(ctx).Debugf("Resolved secret references in config key '%s'", key)
+			viper.Set(key, value)
+		}
+	}
+	return nil
+}
+
+func (r *resolver) resolveValue(key string, value interface{}) (interface{}, bool, error) {
+	switch v := value.(type) {
+	case string:
+		return r.resolveString(key, v)
+	case map[string]interface{}:
+		anyChanged := false
+		for mk, mv := range v {
+			resolved, changed, err := r.resolveValue(key, mv)
+			if err != nil {
+				return nil, false, err
+			}
+			if changed {
+				v[mk] = resolved
+				anyChanged = true
+			}
+		}
+		return v, anyChanged, nil
+	case []interface{}:
+		anyChanged := false
+		for i, av := range v {
+			resolved, changed, err := r.resolveValue(key, av)
+			if err != nil {
+				return nil, false, err
+			}
+			if changed {
+				v[i] = resolved
+				anyChanged = true
+			}
+		}
+		return v, anyChanged, nil
+	default:
+		return value, false, nil
+	}
+}
+
+func (r *resolver) resolveString(key, value string) (string, bool, error) {
+	if !strings.Contains(value, "${") {
+		return value, false, nil
+	}
+	var err error
+	resolved := referenceRegex.ReplaceAllStringFunc(value, func(match string) string {
+		if err != nil {
+			return match
+		}
+		parts := referenceRegex.FindStringSubmatch(match)
+		var secret string
+		secret, err = r.resolveReference(key, parts[1], parts[2])
+		return secret
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return resolved, resolved != value, nil
+}
+
+func (r *resolver) resolveReference(key, providerName, ref string) (string, error) {
+	provider, ok := r.providers[providerName]
+	if !ok {
+		factory, known := providerFactories[providerName]
+		if !known {
+			return "", i18n.NewError(r.ctx, coremsgs.MsgSecretProviderUnknown, providerName, key)
+		}
+		var err error
+		if provider, err = factory(r.ctx); err != nil {
+			return "", err
+		}
+		r.providers[providerName] = provider
+	}
+	secret, err := provider.Resolve(r.ctx, ref)
+	if err != nil {
+		return "", i18n.WrapError(r.ctx, err, coremsgs.MsgSecretResolveFailed, ref, providerName, key)
+	}
+	return secret, nil
+}
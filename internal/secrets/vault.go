@@ -0,0 +1,81 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// vaultProvider reads fields of secrets from a HashiCorp Vault KV version 2 secrets engine.
+// References have the form path#field, and the field defaults to "value".
+type vaultProvider struct {
+	client *resty.Client
+	token  string
+	mount  string
+}
+
+type vaultSecretResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+func newVaultProvider(ctx context.Context) (Provider, error) {
+	if vaultConfig.GetString(ffresty.HTTPConfigURL) == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, vaultConfig.Resolve(ffresty.HTTPConfigURL), "secrets")
+	}
+	client, err := ffresty.New(ctx, vaultConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &vaultProvider{
+		client: client,
+		token:  vaultConfig.GetString(VaultConfToken),
+		mount:  strings.Trim(vaultConfig.GetString(VaultConfMount), "/"),
+	}, nil
+}
+
+func splitFieldRef(ref, defaultField string) (path, field string) {
+	path, field, found := strings.Cut(ref, "#")
+	if !found {
+		field = defaultField
+	}
+	return path, field
+}
+
+func (vp *vaultProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field := splitFieldRef(ref, "value")
+	var secret vaultSecretResponse
+	res, err := vp.client.R().SetContext(ctx).
+		SetHeader("X-Vault-Token", vp.token).
+		SetResult(&secret).
+		Get("/v1/" + vp.mount + "/data/" + strings.Trim(path, "/"))
+	if err != nil || !res.IsSuccess() {
+		return "", ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSecretProviderRequestFailed)
+	}
+	value, ok := secret.Data.Data[field].(string)
+	if !ok {
+		return "", i18n.NewError(ctx, coremsgs.MsgSecretFieldNotFound, field)
+	}
+	return value, nil
+}
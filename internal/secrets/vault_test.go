@@ -0,0 +1,90 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestVaultProvider(t *testing.T, handler http.HandlerFunc) *vaultProvider {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	coreconfig.Reset()
+	InitConfig()
+	vaultConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	vaultConfig.Set(VaultConfToken, "token1")
+	vp, err := newVaultProvider(context.Background())
+	assert.NoError(t, err)
+	return vp.(*vaultProvider)
+}
+
+func TestVaultProviderResolve(t *testing.T) {
+	vp := newTestVaultProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/secret/data/firefly/db", r.URL.Path)
+		assert.Equal(t, "token1", r.Header.Get("X-Vault-Token"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(fftypes.JSONObject{
+			"data": fftypes.JSONObject{
+				"data": fftypes.JSONObject{
+					"password": "pass1",
+					"value":    "value1",
+				},
+			},
+		})
+	})
+
+	value, err := vp.Resolve(context.Background(), "firefly/db#password")
+	assert.NoError(t, err)
+	assert.Equal(t, "pass1", value)
+
+	value, err = vp.Resolve(context.Background(), "/firefly/db")
+	assert.NoError(t, err)
+	assert.Equal(t, "value1", value)
+
+	_, err = vp.Resolve(context.Background(), "firefly/db#missing")
+	assert.Regexp(t, "FF10571.*missing", err)
+}
+
+func TestVaultProviderResolveFail(t *testing.T) {
+	vp := newTestVaultProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, err := vp.Resolve(context.Background(), "firefly/db#password")
+	assert.Regexp(t, "FF10570", err)
+}
+
+func TestNewVaultProviderBadTLS(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	vaultConfig.Set(ffresty.HTTPConfigURL, "https://localhost:12345")
+	tlsConf := vaultConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	_, err := newVaultProvider(context.Background())
+	assert.Regexp(t, "FF00153", err)
+}
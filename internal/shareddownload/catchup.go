@@ -0,0 +1,334 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shareddownload
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// catchUp bulk downloads historical broadcast batches, while a node is catching up with the BatchPin
+// events of the network - such as a member that has just joined an existing network, and is processing
+// the chain from the start.
+//
+// While catch-up is active, the event manager stores each BatchPin event as normal but download of the
+// batch is deferred. Catch-up pages through the stored BatchPin events in sequence order, and downloads the
+// batches of each page in parallel - rather than creating an operation for every pin and dispatching them
+// one at a time to the download workers. Downloaded batches are stored and handed to the aggregator in pin
+// order, so the aggregator is not repeatedly rewound by batches arriving ahead of the pins before them.
+// Any batch that fails to download is handed back to the regular download operations for retry.
+//
+// The sequence of the last event processed is stored as an offset, so catch-up resumes where it left off after
+// a restart. Once no new BatchPin events have arrived within the idle timeout, catch-up completes and new
+// batches return to the regular download path.
+type catchUp struct {
+	ctx          context.Context
+	dm           *downloadManager
+	blockchain   blockchain.Plugin
+	workers      int
+	batchSize    int
+	pollInterval time.Duration
+	idleTimeout  time.Duration
+	done         chan struct{}
+	mux          sync.Mutex
+	status       core.CatchUpStatus
+}
+
+func newCatchUp(dm *downloadManager, bi blockchain.Plugin) *catchUp {
+	cu := &catchUp{
+		ctx:          log.WithLogField(dm.ctx, "role", "catchup"),
+		dm:           dm,
+		blockchain:   bi,
+		workers:      config.GetInt(coreconfig.DownloadCatchUpWorkers),
+		batchSize:    config.GetInt(coreconfig.DownloadCatchUpBatchSize),
+		pollInterval: config.GetDuration(coreconfig.DownloadCatchUpPollInterval),
+		idleTimeout:  config.GetDuration(coreconfig.DownloadCatchUpIdleTimeout),
+		status: core.CatchUpStatus{
+			// Active from construction, so no batch pins delivered during startup are missed
+			Active: true,
+		},
+	}
+	if cu.workers < 1 {
+		cu.workers = 1
+	}
+	return cu
+}
+
+func (cu *catchUp) start() {
+	cu.mux.Lock()
+	cu.status.Started = fftypes.Now()
+	cu.mux.Unlock()
+	cu.done = make(chan struct{})
+	go cu.catchUpLoop()
+}
+
+func (cu *catchUp) waitStop() {
+	if cu.done != nil {
+		<-cu.done
+	}
+}
+
+func (cu *catchUp) active() bool {
+	cu.mux.Lock()
+	defer cu.mux.Unlock()
+	return cu.status.Active
+}
+
+func (cu *catchUp) getStatus() *core.CatchUpStatus {
+	cu.mux.Lock()
+	defer cu.mux.Unlock()
+	status := cu.status
+	return &status
+}
+
+func (cu *catchUp) catchUpLoop() {
+	defer close(cu.done)
+	lastActivity := time.Now()
+	for {
+		processed, err := cu.processPage(cu.ctx)
+		if err != nil {
+			log.L(cu.ctx).Errorf("Catch-up failed to process BatchPin events: %s", err)
+		}
+		if processed > 0 {
+			lastActivity = time.Now()
+		}
+		if err == nil && processed == 0 && time.Since(lastActivity) >= cu.idleTimeout {
+			cu.complete()
+			return
+		}
+		if err != nil || processed < cu.batchSize {
+			select {
+			case <-time.After(cu.pollInterval):
+			case <-cu.ctx.Done():
+				log.L(cu.ctx).Debugf("Catch-up exiting")
+				return
+			}
+		} else if cu.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// complete switches batch downloads back to the regular download path, then drains any BatchPin events that were
+// stored with their download deferred before the switch
+func (cu *catchUp) complete() {
+	cu.mux.Lock()
+	cu.status.Active = false
+	cu.mux.Unlock()
+
+	// The check for catch-up happens inside the DB transaction that stores a BatchPin event, so wait for any
+	// transaction that saw catch-up active to commit before draining
+	wait := true
+	for {
+		if wait {
+			select {
+			case <-time.After(cu.pollInterval):
+			case <-cu.ctx.Done():
+				return
+			}
+		}
+		processed, err := cu.processPage(cu.ctx)
+		if err != nil {
+			log.L(cu.ctx).Errorf("Catch-up failed to process BatchPin events: %s", err)
+			wait = true
+			continue
+		}
+		if processed < cu.batchSize {
+			break
+		}
+		wait = false
+	}
+
+	cu.mux.Lock()
+	cu.status.Completed = fftypes.Now()
+	status := cu.status
+	cu.mux.Unlock()
+	log.L(cu.ctx).Infof("Catch-up completed: scanned=%d downloaded=%d skipped=%d requeued=%d", status.Scanned, status.Downloaded, status.Skipped, status.Requeued)
+}
+
+// processPage reads the next page of BatchPin events after the stored offset, downloads the batches for any that
+// are not already stored in parallel, then moves the offset on
+func (cu *catchUp) processPage(ctx context.Context) (processed int, err error) {
+	offset, err := cu.dm.database.GetOffset(ctx, core.OffsetTypeCatchUp, cu.dm.namespace.Name)
+	if err != nil {
+		return 0, err
+	}
+	if offset == nil {
+		offset = &core.Offset{Type: core.OffsetTypeCatchUp, Name: cu.dm.namespace.Name}
+	}
+
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	events, _, err := cu.dm.database.GetEvents(ctx, cu.dm.namespace.Name, fb.And(
+		fb.Gt("sequence", offset.Current),
+		fb.Eq("type", core.EventTypeBlockchainEventReceived),
+		fb.Eq("topic", core.SystemBatchPinTopic),
+	).Sort("sequence").Limit(uint64(cu.batchSize)))
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	work, skipped, err := cu.getWork(ctx, events)
+	if err != nil {
+		return 0, err
+	}
+	downloaded, requeued, err := cu.downloadAll(ctx, work)
+	if err != nil {
+		return 0, err
+	}
+
+	offset.Current = events[len(events)-1].Sequence
+	if err := cu.dm.database.UpsertOffset(ctx, offset, true); err != nil {
+		return 0, err
+	}
+
+	cu.mux.Lock()
+	cu.status.Offset = offset.Current
+	cu.status.Scanned += int64(len(events))
+	cu.status.Downloaded += int64(downloaded)
+	cu.status.Skipped += int64(skipped)
+	cu.status.Requeued += int64(requeued)
+	cu.mux.Unlock()
+	log.L(ctx).Infof("Catch-up processed %d BatchPin events up to sequence %d (downloaded=%d skipped=%d requeued=%d)", len(events), offset.Current, downloaded, skipped, requeued)
+	return len(events), nil
+}
+
+// getWork resolves the BatchPin events for a page, and returns the broadcast batches that need downloading
+func (cu *catchUp) getWork(ctx context.Context, events []*core.Event) (work []*blockchain.BatchPin, skipped int, err error) {
+	ids := make([]driver.Value, len(events))
+	for i, event := range events {
+		ids[i] = event.Reference
+	}
+	fb := database.BlockchainEventQueryFactory.NewFilter(ctx)
+	chainEvents, _, err := cu.dm.database.GetBlockchainEvents(ctx, cu.dm.namespace.Name, fb.In("id", ids))
+	if err != nil {
+		return nil, 0, err
+	}
+	skipped = len(events) - len(chainEvents)
+
+	for _, chainEvent := range chainEvents {
+		pin, _, err := cu.blockchain.ParseBatchPinEvent(ctx, chainEvent)
+		if err != nil {
+			// Network actions are delivered on the same topic
+			log.L(ctx).Debugf("Catch-up skipping blockchain event %s: %s", chainEvent.ID, err)
+			skipped++
+			continue
+		}
+		if pin.BatchPayloadRef == "" {
+			skipped++
+			continue
+		}
+		batch, err := cu.dm.database.GetBatchByID(ctx, cu.dm.namespace.Name, pin.BatchID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if batch != nil {
+			skipped++
+			continue
+		}
+		work = append(work, pin)
+	}
+	return work, skipped, nil
+}
+
+// catchUpResult is the outcome of downloading and verifying one batch of a page
+type catchUpResult struct {
+	batch *core.Batch
+	err   error
+}
+
+// downloadAll downloads and verifies the batches of a page in parallel, then hands each batch to the aggregator
+// strictly in pin order - as soon as the batches before it have been handed over. So a slow download only holds
+// back the batches pinned after it, rather than the whole page.
+//
+// The configured number of workers bounds the batches in the pipeline, including those that are verified and
+// waiting for an earlier batch, so memory use is bounded however far ahead the downloads get.
+//
+// Batches that fail to download are handed to the regular download operations, which retry with backoff.
+func (cu *catchUp) downloadAll(ctx context.Context, work []*blockchain.BatchPin) (downloaded, requeued int, err error) {
+	pipelineCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	results := make([]chan *catchUpResult, len(work))
+	for i := range work {
+		results[i] = make(chan *catchUpResult, 1)
+	}
+	slots := make(chan struct{}, cu.workers)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i, pin := range work {
+			select {
+			case slots <- struct{}{}:
+			case <-pipelineCtx.Done():
+				return
+			}
+			wg.Add(1)
+			go func(i int, pin *blockchain.BatchPin) {
+				defer wg.Done()
+				batch, err := cu.dm.verifyBatch(pipelineCtx, pin.BatchPayloadRef)
+				results[i] <- &catchUpResult{batch: batch, err: err}
+			}(i, pin)
+		}
+	}()
+
+	for i, pin := range work {
+		var result *catchUpResult
+		select {
+		case result = <-results[i]:
+		case <-pipelineCtx.Done():
+			return 0, 0, i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+		}
+		// The slot is only released once the batch leaves the pipeline
+		<-slots
+
+		if result.err != nil {
+			if ctx.Err() != nil {
+				// Not requeued, as the download failed because we are stopping
+				return 0, 0, i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+			}
+			log.L(ctx).Warnf("Catch-up failed to download batch %s from '%s', requeuing: %s", pin.BatchID, pin.BatchPayloadRef, result.err)
+			if err := cu.dm.initiateDownloadBatch(ctx, pin.TransactionID, pin.BatchPayloadRef, false); err != nil {
+				return 0, 0, err
+			}
+			requeued++
+			continue
+		}
+		if result.batch != nil {
+			if _, err := cu.dm.callbacks.SharedStorageBatchDeliver(result.batch); err != nil {
+				return 0, 0, err
+			}
+		}
+		downloaded++
+	}
+	return downloaded, requeued, nil
+}
@@ -0,0 +1,418 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shareddownload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/mocks/operationmocks"
+	"github.com/hyperledger/firefly/mocks/shareddownloadmocks"
+	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCatchUp(t *testing.T) (*downloadManager, *catchUp, *blockchainmocks.Plugin, func()) {
+	coreconfig.Reset()
+	config.Set(coreconfig.DownloadCatchUpEnabled, true)
+	config.Set(coreconfig.DownloadCatchUpBatchSize, 10)
+	config.Set(coreconfig.DownloadCatchUpPollInterval, "1ms")
+	config.Set(coreconfig.DownloadCatchUpIdleTimeout, "0s")
+
+	mdi := &databasemocks.Plugin{}
+	mbi := &blockchainmocks.Plugin{}
+	mss := &sharedstoragemocks.Plugin{}
+	mdx := &dataexchangemocks.Plugin{}
+	mci := &shareddownloadmocks.Callbacks{}
+	mom := &operationmocks.Manager{}
+	mom.On("RegisterHandler", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	pm, err := NewDownloadManager(ctx, ns, mdi, mbi, mss, mdx, mom, mci)
+	assert.NoError(t, err)
+	dm := pm.(*downloadManager)
+	assert.NotNil(t, dm.catchUp)
+
+	return dm, dm.catchUp, mbi, func() {
+		cancel()
+		mdi.AssertExpectations(t)
+		mbi.AssertExpectations(t)
+		mss.AssertExpectations(t)
+		mci.AssertExpectations(t)
+		mom.AssertExpectations(t)
+	}
+}
+
+func mockCatchUpEvents(dm *downloadManager, mbi *blockchainmocks.Plugin, pins ...*blockchain.BatchPin) {
+	mdi := dm.database.(*databasemocks.Plugin)
+	events := make([]*core.Event, len(pins))
+	chainEvents := make([]*core.BlockchainEvent, len(pins))
+	for i, pin := range pins {
+		chainEvents[i] = &core.BlockchainEvent{ID: fftypes.NewUUID(), Name: "BatchPin"}
+		events[i] = &core.Event{Sequence: int64(100 + i), Reference: chainEvents[i].ID}
+		if pin == nil {
+			mbi.On("ParseBatchPinEvent", mock.Anything, chainEvents[i]).Return(nil, nil, fmt.Errorf("network action")).Once()
+		} else {
+			mbi.On("ParseBatchPinEvent", mock.Anything, chainEvents[i]).Return(pin, &core.VerifierRef{}, nil).Once()
+		}
+	}
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(events, nil, nil).Once()
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(chainEvents, nil, nil).Once()
+}
+
+func TestCatchUpProcessPage(t *testing.T) {
+	dm, cu, mbi, done := newTestCatchUp(t)
+	defer done()
+
+	stored := &blockchain.BatchPin{BatchID: fftypes.NewUUID(), BatchPayloadRef: "ref1"}
+	private := &blockchain.BatchPin{BatchID: fftypes.NewUUID()}
+	ok := &blockchain.BatchPin{BatchID: fftypes.NewUUID(), BatchPayloadRef: "ref2"}
+	failed := &blockchain.BatchPin{BatchID: fftypes.NewUUID(), TransactionID: fftypes.NewUUID(), BatchPayloadRef: "ref3"}
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mockCatchUpEvents(dm, mbi, stored, private, nil, ok, failed)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", stored.BatchID).Return(&core.BatchPersisted{}, nil)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", ok.BatchID).Return(nil, nil)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", failed.BatchID).Return(nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Type == core.OffsetTypeCatchUp && o.Name == "ns1" && o.Current == 104
+	}), true).Return(nil)
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref2").Return(io.NopCloser(strings.NewReader("batch2")), nil)
+	mss.On("DownloadData", mock.Anything, "ref3").Return(nil, fmt.Errorf("pop"))
+	mss.On("Name").Return("utss")
+	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
+	batch := &core.Batch{BatchHeader: core.BatchHeader{ID: ok.BatchID}}
+	mci.On("SharedStorageBatchVerify", "ref2", mock.Anything).Return(batch, nil)
+	mci.On("SharedStorageBatchDeliver", batch).Return(ok.BatchID, nil)
+	mom := dm.operations.(*operationmocks.Manager)
+	mom.On("AddOrReuseOperation", mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeSharedStorageDownloadBatch && op.Transaction.Equals(failed.TransactionID)
+	}), mock.Anything).Return(nil)
+
+	processed, err := cu.processPage(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, processed)
+
+	status := cu.getStatus()
+	assert.True(t, status.Active)
+	assert.Equal(t, int64(104), status.Offset)
+	assert.Equal(t, int64(5), status.Scanned)
+	assert.Equal(t, int64(1), status.Downloaded)
+	assert.Equal(t, int64(3), status.Skipped)
+	assert.Equal(t, int64(1), status.Requeued)
+}
+
+func TestCatchUpProcessPageEmpty(t *testing.T) {
+	dm, cu, _, done := newTestCatchUp(t)
+	defer done()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(&core.Offset{Current: 12345}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+
+	processed, err := cu.processPage(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, processed)
+}
+
+func TestCatchUpProcessPageDeliversInPinOrder(t *testing.T) {
+	dm, cu, mbi, done := newTestCatchUp(t)
+	defer done()
+	cu.workers = 4
+
+	pins := make([]*blockchain.BatchPin, 4)
+	batches := make([]*core.Batch, 4)
+	for i := range pins {
+		pins[i] = &blockchain.BatchPin{BatchID: fftypes.NewUUID(), BatchPayloadRef: fmt.Sprintf("ref%d", i)}
+		batches[i] = &core.Batch{BatchHeader: core.BatchHeader{ID: pins[i].BatchID}}
+	}
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mockCatchUpEvents(dm, mbi, pins...)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, mock.Anything).Return(func(ctx context.Context, payloadRef string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(payloadRef)), nil
+	})
+
+	// The first batch only completes once all the others have downloaded
+	var others sync.WaitGroup
+	others.Add(len(pins) - 1)
+	var delivered []*fftypes.UUID
+	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
+	for i := range pins {
+		i := i
+		mci.On("SharedStorageBatchVerify", pins[i].BatchPayloadRef, mock.Anything).Return(func(payloadRef string, reader io.Reader) (*core.Batch, error) {
+			if i == 0 {
+				others.Wait()
+			} else {
+				others.Done()
+			}
+			return batches[i], nil
+		})
+		mci.On("SharedStorageBatchDeliver", batches[i]).Return(func(batch *core.Batch) (*fftypes.UUID, error) {
+			delivered = append(delivered, batch.ID)
+			return batch.ID, nil
+		})
+	}
+
+	processed, err := cu.processPage(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 4, processed)
+	assert.Equal(t, []*fftypes.UUID{pins[0].BatchID, pins[1].BatchID, pins[2].BatchID, pins[3].BatchID}, delivered)
+	assert.Equal(t, int64(4), cu.getStatus().Downloaded)
+}
+
+func TestCatchUpProcessPageInvalidBatchSkipped(t *testing.T) {
+	dm, cu, mbi, done := newTestCatchUp(t)
+	defer done()
+
+	pin := &blockchain.BatchPin{BatchID: fftypes.NewUUID(), BatchPayloadRef: "ref1"}
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mockCatchUpEvents(dm, mbi, pin)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", pin.BatchID).Return(nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(io.NopCloser(strings.NewReader("!json")), nil)
+	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
+	mci.On("SharedStorageBatchVerify", "ref1", mock.Anything).Return(nil, nil)
+
+	processed, err := cu.processPage(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, int64(0), cu.getStatus().Requeued)
+}
+
+func TestCatchUpProcessPageDeliverFail(t *testing.T) {
+	dm, cu, mbi, done := newTestCatchUp(t)
+	defer done()
+
+	pin1 := &blockchain.BatchPin{BatchID: fftypes.NewUUID(), BatchPayloadRef: "ref1"}
+	pin2 := &blockchain.BatchPin{BatchID: fftypes.NewUUID(), BatchPayloadRef: "ref2"}
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mockCatchUpEvents(dm, mbi, pin1, pin2)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, mock.Anything).Return(io.NopCloser(strings.NewReader("batch")), nil).Maybe()
+	batch := &core.Batch{}
+	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
+	mci.On("SharedStorageBatchVerify", mock.Anything, mock.Anything).Return(batch, nil).Maybe()
+	mci.On("SharedStorageBatchDeliver", batch).Return(nil, fmt.Errorf("pop")).Once()
+
+	_, err := cu.processPage(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCatchUpDownloadAllCancelled(t *testing.T) {
+	dm, cu, _, done := newTestCatchUp(t)
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		cancel()
+		<-args[0].(context.Context).Done()
+	})
+
+	_, _, err := cu.downloadAll(ctx, []*blockchain.BatchPin{{BatchPayloadRef: "ref1"}})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestCatchUpProcessPageGetOffsetFail(t *testing.T) {
+	dm, cu, _, done := newTestCatchUp(t)
+	defer done()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := cu.processPage(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCatchUpProcessPageGetBlockchainEventsFail(t *testing.T) {
+	dm, cu, _, done := newTestCatchUp(t)
+	defer done()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Reference: fftypes.NewUUID()}}, nil, nil)
+	mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := cu.processPage(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCatchUpProcessPageGetBatchFail(t *testing.T) {
+	dm, cu, mbi, done := newTestCatchUp(t)
+	defer done()
+
+	pin := &blockchain.BatchPin{BatchID: fftypes.NewUUID(), BatchPayloadRef: "ref1"}
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mockCatchUpEvents(dm, mbi, pin)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", pin.BatchID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := cu.processPage(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCatchUpProcessPageRequeueFail(t *testing.T) {
+	dm, cu, mbi, done := newTestCatchUp(t)
+	defer done()
+
+	pin := &blockchain.BatchPin{BatchID: fftypes.NewUUID(), BatchPayloadRef: "ref1"}
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mockCatchUpEvents(dm, mbi, pin)
+	mdi.On("GetBatchByID", mock.Anything, "ns1", pin.BatchID).Return(nil, nil)
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(nil, fmt.Errorf("pop"))
+	mss.On("Name").Return("utss")
+	mom := dm.operations.(*operationmocks.Manager)
+	mom.On("AddOrReuseOperation", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("requeue failed"))
+
+	_, err := cu.processPage(context.Background())
+	assert.EqualError(t, err, "requeue failed")
+}
+
+func TestCatchUpProcessPageUpsertOffsetFail(t *testing.T) {
+	dm, cu, mbi, done := newTestCatchUp(t)
+	defer done()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mockCatchUpEvents(dm, mbi, &blockchain.BatchPin{BatchID: fftypes.NewUUID()})
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(fmt.Errorf("pop"))
+
+	_, err := cu.processPage(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCatchUpDefersDownloadsUntilComplete(t *testing.T) {
+	dm, cu, _, done := newTestCatchUp(t)
+	defer done()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+
+	// Deferred while active - no operation is created
+	err := dm.InitiateDownloadBatch(context.Background(), fftypes.NewUUID(), "ref1", false)
+	assert.NoError(t, err)
+
+	cu.start()
+	cu.waitStop()
+
+	status, err := dm.GetCatchUpStatus(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, status.Active)
+	assert.NotNil(t, status.Started)
+	assert.NotNil(t, status.Completed)
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("Name").Return("utss")
+	mom := dm.operations.(*operationmocks.Manager)
+	mom.On("AddOrReuseOperation", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	err = dm.InitiateDownloadBatch(context.Background(), fftypes.NewUUID(), "ref2", false)
+	assert.NoError(t, err)
+}
+
+func TestCatchUpDrainRetries(t *testing.T) {
+	dm, cu, _, done := newTestCatchUp(t)
+	defer done()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+
+	cu.start()
+	cu.waitStop()
+
+	assert.NotNil(t, cu.getStatus().Completed)
+}
+
+func TestCatchUpExitOnClose(t *testing.T) {
+	dm, cu, _, done := newTestCatchUp(t)
+	cu.idleTimeout = 1 * time.Hour
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+
+	cu.start()
+	dm.WaitStop()
+	done()
+
+	status := cu.getStatus()
+	assert.True(t, status.Active)
+	assert.Nil(t, status.Completed)
+}
+
+func TestCatchUpExitDuringDrain(t *testing.T) {
+	dm, cu, _, done := newTestCatchUp(t)
+	defer done()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeCatchUp, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+	cu.pollInterval = 1 * time.Hour
+
+	go func() {
+		for cu.active() {
+			time.Sleep(time.Millisecond)
+		}
+		dm.cancelFunc()
+	}()
+	cu.start()
+	cu.waitStop()
+
+	assert.Nil(t, cu.getStatus().Completed)
+}
+
+func TestGetCatchUpStatusNotEnabled(t *testing.T) {
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	_, err := dm.GetCatchUpStatus(context.Background())
+	assert.Regexp(t, "FF10599", err)
+}
@@ -19,6 +19,7 @@ package shareddownload
 import (
 	"context"
 	"database/sql/driver"
+	"io"
 	"math"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
@@ -41,6 +43,7 @@ type Manager interface {
 
 	InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, payloadRef string, idempotentSubmit bool) error
 	InitiateDownloadBlob(ctx context.Context, tx *fftypes.UUID, dataID *fftypes.UUID, payloadRef string, idempotentSubmit bool) error
+	GetCatchUpStatus(ctx context.Context) (*core.CatchUpStatus, error)
 }
 
 // downloadManager operates a number of workers that can perform downloads/retries. Each download
@@ -61,6 +64,7 @@ type downloadManager struct {
 	workers                    []*downloadWorker
 	work                       chan *downloadWork
 	recoveryComplete           chan struct{}
+	catchUp                    *catchUp // only if enabled
 	broadcastBatchPayloadLimit int64
 	retryMaxAttempts           int
 	retryInitDelay             time.Duration
@@ -76,11 +80,11 @@ type downloadWork struct {
 }
 
 type Callbacks interface {
-	SharedStorageBatchDownloaded(payloadRef string, data []byte) (batchID *fftypes.UUID, err error)
+	SharedStorageBatchDownloaded(payloadRef string, reader io.Reader) (batchID *fftypes.UUID, err error)
 	SharedStorageBlobDownloaded(hash fftypes.Bytes32, size int64, payloadRef string, dataID *fftypes.UUID) error
 }
 
-func NewDownloadManager(ctx context.Context, ns *core.Namespace, di database.Plugin, ss sharedstorage.Plugin, dx dataexchange.Plugin, om operations.Manager, cb Callbacks) (Manager, error) {
+func NewDownloadManager(ctx context.Context, ns *core.Namespace, di database.Plugin, bi blockchain.Plugin, ss sharedstorage.Plugin, dx dataexchange.Plugin, om operations.Manager, cb Callbacks) (Manager, error) {
 	if di == nil || dx == nil || ss == nil || cb == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "DownloadManager")
 	}
@@ -111,6 +115,9 @@ func NewDownloadManager(ctx context.Context, ns *core.Namespace, di database.Plu
 		dm.retryMaxAttempts = 1
 	}
 	dm.work = make(chan *downloadWork, workQueueLength)
+	if config.GetBool(coreconfig.DownloadCatchUpEnabled) && bi != nil {
+		dm.catchUp = newCatchUp(dm, bi)
+	}
 
 	dm.operations.RegisterHandler(ctx, dm, []core.OpType{
 		core.OpTypeSharedStorageDownloadBatch,
@@ -127,6 +134,9 @@ func (dm *downloadManager) Start() error {
 	}
 	dm.recoveryComplete = make(chan struct{})
 	go dm.recoverDownloads(fftypes.Now())
+	if dm.catchUp != nil {
+		dm.catchUp.start()
+	}
 	return nil
 }
 
@@ -139,6 +149,16 @@ func (dm *downloadManager) WaitStop() {
 	for _, w := range dm.workers {
 		<-w.done
 	}
+	if dm.catchUp != nil {
+		dm.catchUp.waitStop()
+	}
+}
+
+func (dm *downloadManager) GetCatchUpStatus(ctx context.Context) (*core.CatchUpStatus, error) {
+	if dm.catchUp == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgCatchUpNotEnabled)
+	}
+	return dm.catchUp.getStatus(), nil
 }
 
 func (dm *downloadManager) calcDelay(attempts int) time.Duration {
@@ -226,6 +246,15 @@ func (dm *downloadManager) waitAndRetryDownload(work *downloadWork) {
 }
 
 func (dm *downloadManager) InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, payloadRef string, idempotentSubmit bool) error {
+	if dm.catchUp != nil && dm.catchUp.active() {
+		// The catch-up will download the batch in bulk, when it reaches the BatchPin event
+		log.L(ctx).Debugf("Deferring download of batch '%s' to catch-up", payloadRef)
+		return nil
+	}
+	return dm.initiateDownloadBatch(ctx, tx, payloadRef, idempotentSubmit)
+}
+
+func (dm *downloadManager) initiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, payloadRef string, idempotentSubmit bool) error {
 	op := core.NewOperation(dm.sharedstorage, dm.namespace.Name, tx, core.OpTypeSharedStorageDownloadBatch)
 	addDownloadBatchInputs(op, payloadRef)
 	return dm.createAndDispatchOp(ctx, op, opDownloadBatch(op, payloadRef), idempotentSubmit)
@@ -55,14 +55,14 @@ func newTestDownloadManager(t *testing.T) (*downloadManager, func()) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
-	pm, err := NewDownloadManager(ctx, ns, mdi, mss, mdx, mom, mci)
+	pm, err := NewDownloadManager(ctx, ns, mdi, nil, mss, mdx, mom, mci)
 	assert.NoError(t, err)
 
 	return pm.(*downloadManager), cancel
 }
 
 func TestNewDownloadManagerMissingDeps(t *testing.T) {
-	_, err := NewDownloadManager(context.Background(), &core.Namespace{}, nil, nil, nil, nil, nil)
+	_, err := NewDownloadManager(context.Background(), &core.Namespace{}, nil, nil, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -109,7 +109,7 @@ func TestDownloadBatchE2EOk(t *testing.T) {
 	})
 
 	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
-	mci.On("SharedStorageBatchDownloaded", "ref1", []byte("some batch data")).Return(batchID, nil)
+	mci.On("SharedStorageBatchDownloaded", "ref1", mock.Anything).Return(batchID, nil)
 
 	err := dm.InitiateDownloadBatch(dm.ctx, txID, "ref1", false)
 	assert.NoError(t, err)
@@ -298,7 +298,7 @@ func TestDownloadManagerStartupRecoveryCombinations(t *testing.T) {
 	mom.On("SubmitOperationUpdate", mock.Anything).Return(nil)
 
 	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
-	mci.On("SharedStorageBatchDownloaded", "ref2", []byte("some batch data")).Return(batchID, nil)
+	mci.On("SharedStorageBatchDownloaded", "ref2", mock.Anything).Return(batchID, nil)
 
 	err := dm.Start()
 	assert.NoError(t, err)
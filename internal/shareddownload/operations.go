@@ -111,30 +111,71 @@ func (dm *downloadManager) RunOperation(ctx context.Context, op *core.PreparedOp
 // on the messages included (just like the event driven when we receive data over DX).
 func (dm *downloadManager) downloadBatch(ctx context.Context, data downloadBatchData) (outputs fftypes.JSONObject, phase core.OpPhase, err error) {
 
-	// Download into memory for batches
-	reader, err := dm.sharedstorage.DownloadData(ctx, data.PayloadRef)
+	reader, err := dm.openBatch(ctx, data.PayloadRef)
 	if err != nil {
-		return nil, core.OpPhaseInitializing, i18n.WrapError(ctx, err, coremsgs.MsgDownloadSharedFailed, data.PayloadRef)
+		return nil, core.OpPhaseInitializing, err
 	}
 	defer reader.Close()
 
-	// Read from the stream up to the limit
-	maxReadLimit := dm.broadcastBatchPayloadLimit + 1024
-	limitedReader := io.LimitReader(reader, maxReadLimit)
-	batchBytes, err := io.ReadAll(limitedReader)
+	// Parse and store the batch
+	batchID, err := dm.callbacks.SharedStorageBatchDownloaded(data.PayloadRef, reader)
 	if err != nil {
-		return nil, core.OpPhasePending, i18n.WrapError(ctx, err, coremsgs.MsgDownloadSharedFailed, data.PayloadRef)
+		return nil, core.OpPhasePending, err
 	}
-	if len(batchBytes) == int(maxReadLimit) {
-		return nil, core.OpPhasePending, i18n.WrapError(ctx, err, coremsgs.MsgDownloadBatchMaxBytes, data.PayloadRef)
+	return getDownloadBatchOutputs(batchID), core.OpPhaseComplete, nil
+}
+
+// verifyBatch retrieves a serialized batch from public storage, and parses and verifies it without storing it.
+// A nil batch with no error is returned for a batch that can never be stored, as retrying will not fix it.
+func (dm *downloadManager) verifyBatch(ctx context.Context, payloadRef string) (*core.Batch, error) {
+	reader, err := dm.openBatch(ctx, payloadRef)
+	if err != nil {
+		return nil, err
 	}
+	defer reader.Close()
+	return dm.callbacks.SharedStorageBatchVerify(payloadRef, reader)
+}
 
-	// Parse and store the batch
-	batchID, err := dm.callbacks.SharedStorageBatchDownloaded(data.PayloadRef, batchBytes)
+// openBatch streams a serialized batch from public storage, to be parsed and verified as it is read.
+// Reading fails if the batch exceeds the limit.
+func (dm *downloadManager) openBatch(ctx context.Context, payloadRef string) (io.ReadCloser, error) {
+	reader, err := dm.sharedstorage.DownloadData(ctx, payloadRef)
 	if err != nil {
-		return nil, core.OpPhasePending, err
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDownloadSharedFailed, payloadRef)
+	}
+	return &batchLimitReader{
+		ctx:        ctx,
+		payloadRef: payloadRef,
+		r:          reader,
+		remaining:  dm.broadcastBatchPayloadLimit + 1024,
+	}, nil
+}
+
+// batchLimitReader fails the download of a batch that exceeds the maximum size, rather than truncating it
+type batchLimitReader struct {
+	ctx        context.Context
+	payloadRef string
+	r          io.ReadCloser
+	remaining  int64
+}
+
+func (lr *batchLimitReader) Close() error {
+	return lr.r.Close()
+}
+
+func (lr *batchLimitReader) Read(p []byte) (int, error) {
+	if lr.remaining <= 0 {
+		return 0, i18n.NewError(lr.ctx, coremsgs.MsgDownloadBatchMaxBytes, lr.payloadRef)
 	}
-	return getDownloadBatchOutputs(batchID), core.OpPhaseComplete, nil
+	if int64(len(p)) > lr.remaining {
+		p = p[:lr.remaining]
+	}
+	n, err := lr.r.Read(p)
+	lr.remaining -= int64(n)
+	if err != nil && err != io.EOF {
+		return n, i18n.WrapError(lr.ctx, err, coremsgs.MsgDownloadSharedFailed, lr.payloadRef)
+	}
+	return n, err
 }
 
 func (dm *downloadManager) downloadBlob(ctx context.Context, data downloadBlobData) (outputs fftypes.JSONObject, phase core.OpPhase, err error) {
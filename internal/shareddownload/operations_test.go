@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"strings"
 	"testing"
@@ -29,10 +30,17 @@ import (
 	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
 	"github.com/hyperledger/firefly/mocks/shareddownloadmocks"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
+	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+// readBatchCallback consumes the batch stream, like the real callback, returning any read error
+func readBatchCallback(payloadRef string, reader io.Reader) (*fftypes.UUID, error) {
+	_, err := io.ReadAll(reader)
+	return nil, err
+}
+
 func TestDownloadBatchDownloadDataFail(t *testing.T) {
 
 	dm, cancel := newTestDownloadManager(t)
@@ -59,10 +67,14 @@ func TestDownloadBatchDownloadDataReadFail(t *testing.T) {
 	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
 	mss.On("DownloadData", mock.Anything, "ref1").Return(reader, nil)
 
-	_, _, err := dm.downloadBatch(dm.ctx, downloadBatchData{
+	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
+	mci.On("SharedStorageBatchDownloaded", "ref1", mock.Anything).Return(readBatchCallback)
+
+	_, phase, err := dm.downloadBatch(dm.ctx, downloadBatchData{
 		PayloadRef: "ref1",
 	})
-	assert.Regexp(t, "FF10376", err)
+	assert.Regexp(t, "FF10376.*read failed", err)
+	assert.Equal(t, core.OpPhasePending, phase)
 
 	mss.AssertExpectations(t)
 }
@@ -78,10 +90,14 @@ func TestDownloadBatchDownloadDataReadMaxedOut(t *testing.T) {
 	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
 	mss.On("DownloadData", mock.Anything, "ref1").Return(reader, nil)
 
-	_, _, err := dm.downloadBatch(dm.ctx, downloadBatchData{
+	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
+	mci.On("SharedStorageBatchDownloaded", "ref1", mock.Anything).Return(readBatchCallback)
+
+	_, phase, err := dm.downloadBatch(dm.ctx, downloadBatchData{
 		PayloadRef: "ref1",
 	})
 	assert.Regexp(t, "FF10377", err)
+	assert.Equal(t, core.OpPhasePending, phase)
 
 	mss.AssertExpectations(t)
 }
@@ -97,7 +113,12 @@ func TestDownloadBatchDownloadCallbackFailed(t *testing.T) {
 	mss.On("DownloadData", mock.Anything, "ref1").Return(reader, nil)
 
 	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
-	mci.On("SharedStorageBatchDownloaded", "ref1", []byte("some batch data")).Return(nil, fmt.Errorf("pop"))
+	mci.On("SharedStorageBatchDownloaded", "ref1", mock.Anything).Return(func(payloadRef string, reader io.Reader) (*fftypes.UUID, error) {
+		b, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, "some batch data", string(b))
+		return nil, fmt.Errorf("pop")
+	})
 
 	_, _, err := dm.downloadBatch(dm.ctx, downloadBatchData{
 		PayloadRef: "ref1",
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
 )
 
@@ -61,6 +62,7 @@ func (i *IPFS) Init(ctx context.Context, config config.Section) (err error) {
 	if err != nil {
 		return err
 	}
+	tracing.InstrumentClient(i.apiClient, "ipfs")
 	gwConfig := config.SubSection(IPFSConfGatewaySubconf)
 	if gwConfig.GetString(ffresty.HTTPConfigURL) == "" {
 		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, gwConfig.Resolve(ffresty.HTTPConfigURL), "ipfs")
@@ -69,6 +71,7 @@ func (i *IPFS) Init(ctx context.Context, config config.Section) (err error) {
 	if err != nil {
 		return err
 	}
+	tracing.InstrumentClient(i.gwClient, "ipfs")
 	i.capabilities = &sharedstorage.Capabilities{}
 	return nil
 }
@@ -80,6 +83,17 @@ func (i *IPFS) Capabilities() *sharedstorage.Capabilities {
 	return i.capabilities
 }
 
+// CheckHealth confirms the IPFS API is reachable, by querying its version
+func (i *IPFS) CheckHealth(ctx context.Context) error {
+	res, err := i.apiClient.R().
+		SetContext(ctx).
+		Post("/api/v0/version")
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgIPFSRESTErr)
+	}
+	return nil
+}
+
 func (i *IPFS) UploadData(ctx context.Context, data io.Reader) (string, error) {
 	var ipfsResponse ipfsUploadResponse
 	res, err := i.apiClient.R().
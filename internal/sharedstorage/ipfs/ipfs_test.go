@@ -228,3 +228,47 @@ func TestIPFSDownloadError(t *testing.T) {
 	assert.Regexp(t, "FF10136", err)
 
 }
+
+func TestIPFSCheckHealth(t *testing.T) {
+	i := &IPFS{}
+
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+	defer httpmock.DeactivateAndReset()
+
+	resetConf()
+	utConfig.SubSection(IPFSConfAPISubconf).Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.SubSection(IPFSConfGatewaySubconf).Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.SubSection(IPFSConfAPISubconf).Set(ffresty.HTTPCustomClient, mockedClient)
+
+	err := i.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/api/v0/version",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{"Version": "0.20.0"}))
+
+	err = i.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestIPFSCheckHealthFail(t *testing.T) {
+	i := &IPFS{}
+
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+	defer httpmock.DeactivateAndReset()
+
+	resetConf()
+	utConfig.SubSection(IPFSConfAPISubconf).Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.SubSection(IPFSConfGatewaySubconf).Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.SubSection(IPFSConfAPISubconf).Set(ffresty.HTTPCustomClient, mockedClient)
+
+	err := i.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/api/v0/version",
+		httpmock.NewJsonResponderOrPanic(500, map[string]interface{}{"error": "pop"}))
+
+	err = i.CheckHealth(context.Background())
+	assert.Regexp(t, "FF10136", err)
+}
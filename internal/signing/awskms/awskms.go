@@ -0,0 +1,197 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/awsauth"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/signing"
+)
+
+const (
+	kmsService            = "kms"
+	kmsTargetSign         = "TrentService.Sign"
+	kmsTargetGetPublicKey = "TrentService.GetPublicKey"
+	kmsMessageTypeDigest  = "DIGEST"
+	kmsAlgorithmECDSA     = "ECDSA_SHA_256"
+	kmsAlgorithmRSA       = "RSASSA_PKCS1_V1_5_SHA_256"
+)
+
+// AWSKMS signs with asymmetric keys held in AWS Key Management Service, calling the KMS API directly
+// with Signature Version 4 signed requests. Key IDs can be a key ID, key ARN, alias name or alias ARN.
+type AWSKMS struct {
+	ctx          context.Context
+	capabilities *signing.Capabilities
+	client       *resty.Client
+	signer       *awsauth.Signer
+	keyMux       sync.Mutex
+	algorithms   map[string]string
+}
+
+type kmsGetPublicKeyRequest struct {
+	KeyID string `json:"KeyId"`
+}
+
+type kmsGetPublicKeyResponse struct {
+	KeySpec   string `json:"KeySpec"`
+	PublicKey []byte `json:"PublicKey"`
+}
+
+type kmsSignRequest struct {
+	KeyID            string `json:"KeyId"`
+	Message          []byte `json:"Message"`
+	MessageType      string `json:"MessageType"`
+	SigningAlgorithm string `json:"SigningAlgorithm"`
+}
+
+type kmsSignResponse struct {
+	Signature []byte `json:"Signature"`
+}
+
+func (k *AWSKMS) Name() string {
+	return "awskms"
+}
+
+func (k *AWSKMS) Init(ctx context.Context, config config.Section) (err error) {
+	k.ctx = log.WithLogField(ctx, "signing", "awskms")
+
+	region := awsauth.ConfigOrEnv(config.GetString(AWSKMSConfRegion), "AWS_REGION")
+	if region == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(AWSKMSConfRegion), "awskms")
+	}
+	k.client, err = ffresty.New(k.ctx, config)
+	if err != nil {
+		return err
+	}
+	endpoint := config.GetString(ffresty.HTTPConfigURL)
+	if endpoint == "" {
+		// The URL only needs to be set to use a VPC endpoint, or an emulator
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", kmsService, region)
+		k.client.SetBaseURL(endpoint)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return i18n.WrapError(ctx, err, i18n.MsgInvalidURL, endpoint)
+	}
+	k.signer = &awsauth.Signer{
+		Service:         kmsService,
+		Host:            u.Host,
+		Region:          region,
+		AccessKeyID:     awsauth.ConfigOrEnv(config.GetString(AWSKMSConfAccessKeyID), "AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: awsauth.ConfigOrEnv(config.GetString(AWSKMSConfSecretAccessKey), "AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    awsauth.ConfigOrEnv(config.GetString(AWSKMSConfSessionToken), "AWS_SESSION_TOKEN"),
+		Now:             time.Now,
+	}
+	k.algorithms = make(map[string]string)
+	k.capabilities = &signing.Capabilities{}
+	return nil
+}
+
+func (k *AWSKMS) Capabilities() *signing.Capabilities {
+	return k.capabilities
+}
+
+func (k *AWSKMS) invoke(ctx context.Context, target string, input, output interface{}) error {
+	body, _ := json.Marshal(input)
+	req := k.client.R().SetContext(ctx).
+		SetBody(body).
+		SetResult(output)
+	for name, value := range k.signer.SignedHeaders(target, body) {
+		req.SetHeader(name, value)
+	}
+	res, err := req.Post("/")
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSigningRequestFailed)
+	}
+	return nil
+}
+
+// signingAlgorithm returns the KMS signing algorithm that matches the way FireFly verifies signatures
+// for the key spec. Only keys that can sign a SHA-256 digest are supported. KMS signs the digest as-is for
+// ECDSA keys, so secp256k1 keys can be used to sign the Keccak-256 digest of EIP-712 typed data.
+func signingAlgorithm(keySpec string) string {
+	switch {
+	case keySpec == "ECC_NIST_P256", keySpec == "ECC_SECG_P256K1":
+		return kmsAlgorithmECDSA
+	case strings.HasPrefix(keySpec, "RSA_"):
+		return kmsAlgorithmRSA
+	default:
+		return ""
+	}
+}
+
+func (k *AWSKMS) getPublicKey(ctx context.Context, keyID string) (*kmsGetPublicKeyResponse, string, error) {
+	var res kmsGetPublicKeyResponse
+	if err := k.invoke(ctx, kmsTargetGetPublicKey, &kmsGetPublicKeyRequest{KeyID: keyID}, &res); err != nil {
+		return nil, "", err
+	}
+	algorithm := signingAlgorithm(res.KeySpec)
+	if algorithm == "" || len(res.PublicKey) == 0 {
+		return nil, "", i18n.NewError(ctx, coremsgs.MsgSigningPublicKeyInvalid, keyID)
+	}
+	k.keyMux.Lock()
+	k.algorithms[keyID] = algorithm
+	k.keyMux.Unlock()
+	return &res, algorithm, nil
+}
+
+func (k *AWSKMS) PublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	res, _, err := k.getPublicKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return res.PublicKey, nil
+}
+
+func (k *AWSKMS) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	k.keyMux.Lock()
+	algorithm := k.algorithms[keyID]
+	k.keyMux.Unlock()
+	if algorithm == "" {
+		var err error
+		if _, algorithm, err = k.getPublicKey(ctx, keyID); err != nil {
+			return nil, err
+		}
+	}
+	var res kmsSignResponse
+	err := k.invoke(ctx, kmsTargetSign, &kmsSignRequest{
+		KeyID:            keyID,
+		Message:          digest,
+		MessageType:      kmsMessageTypeDigest,
+		SigningAlgorithm: algorithm,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Signature) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSigningSignatureInvalid, keyID)
+	}
+	return res.Signature, nil
+}
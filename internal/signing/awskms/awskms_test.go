@@ -0,0 +1,181 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/awsauth"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("awskms_unit_tests")
+
+func newTestAWSKMS(t *testing.T, handler http.HandlerFunc) *AWSKMS {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	coreconfig.Reset()
+	k := &AWSKMS{}
+	k.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	utConfig.Set(AWSKMSConfRegion, "us-east-1")
+	utConfig.Set(AWSKMSConfAccessKeyID, "AKIDEXAMPLE")
+	utConfig.Set(AWSKMSConfSecretAccessKey, "secret1")
+	err := k.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	assert.NotNil(t, k.Capabilities())
+	assert.Equal(t, "awskms", k.Name())
+	return k
+}
+
+func newTestKMSHandler(t *testing.T, keySpec string, key *ecdsa.PrivateKey) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/kms/aws4_request, ")
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", awsauth.ContentType)
+		switch r.Header.Get("X-Amz-Target") {
+		case kmsTargetGetPublicKey:
+			var req kmsGetPublicKeyRequest
+			assert.NoError(t, json.Unmarshal(b, &req))
+			assert.Equal(t, "alias/org1", req.KeyID)
+			der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			assert.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(&kmsGetPublicKeyResponse{KeySpec: keySpec, PublicKey: der})
+		case kmsTargetSign:
+			var req kmsSignRequest
+			assert.NoError(t, json.Unmarshal(b, &req))
+			assert.Equal(t, kmsMessageTypeDigest, req.MessageType)
+			assert.Equal(t, kmsAlgorithmECDSA, req.SigningAlgorithm)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, req.Message)
+			assert.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(&kmsSignResponse{Signature: signature})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}
+}
+
+func TestSignECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	k := newTestAWSKMS(t, newTestKMSHandler(t, "ECC_NIST_P256", key))
+
+	digest := sha256.Sum256([]byte("payload"))
+	signature, err := k.Sign(context.Background(), "alias/org1", digest[:])
+	assert.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature))
+
+	der, err := k.PublicKey(context.Background(), "alias/org1")
+	assert.NoError(t, err)
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	assert.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(publicKey))
+}
+
+func TestSignUnsupportedKeySpec(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	k := newTestAWSKMS(t, newTestKMSHandler(t, "ECC_NIST_P384", key))
+
+	_, err = k.Sign(context.Background(), "alias/org1", make([]byte, 32))
+	assert.Regexp(t, "FF10582", err)
+}
+
+func TestSignFail(t *testing.T) {
+	k := newTestAWSKMS(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	k.algorithms["alias/org1"] = kmsAlgorithmECDSA
+
+	_, err := k.Sign(context.Background(), "alias/org1", make([]byte, 32))
+	assert.Regexp(t, "FF10581", err)
+}
+
+func TestSignEmptySignature(t *testing.T) {
+	k := newTestAWSKMS(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", awsauth.ContentType)
+		_ = json.NewEncoder(w).Encode(&kmsSignResponse{})
+	})
+	k.algorithms["alias/org1"] = kmsAlgorithmRSA
+
+	_, err := k.Sign(context.Background(), "alias/org1", make([]byte, 32))
+	assert.Regexp(t, "FF10583", err)
+}
+
+func TestPublicKeyFail(t *testing.T) {
+	k := newTestAWSKMS(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := k.PublicKey(context.Background(), "alias/org1")
+	assert.Regexp(t, "FF10581", err)
+}
+
+func TestSigningAlgorithm(t *testing.T) {
+	assert.Equal(t, kmsAlgorithmECDSA, signingAlgorithm("ECC_NIST_P256"))
+	assert.Equal(t, kmsAlgorithmRSA, signingAlgorithm("RSA_2048"))
+	assert.Equal(t, kmsAlgorithmECDSA, signingAlgorithm("ECC_SECG_P256K1"))
+	assert.Equal(t, "", signingAlgorithm("ECC_NIST_P384"))
+}
+
+func TestInitDefaultEndpoint(t *testing.T) {
+	coreconfig.Reset()
+	k := &AWSKMS{}
+	k.InitConfig(utConfig)
+	utConfig.Set(AWSKMSConfRegion, "eu-west-2")
+	err := k.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "kms.eu-west-2.amazonaws.com", k.signer.Host)
+}
+
+func TestInitMissingRegion(t *testing.T) {
+	coreconfig.Reset()
+	t.Setenv("AWS_REGION", "")
+	k := &AWSKMS{}
+	k.InitConfig(utConfig)
+	err := k.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*region", err)
+}
+
+func TestInitBadTLS(t *testing.T) {
+	coreconfig.Reset()
+	k := &AWSKMS{}
+	k.InitConfig(utConfig)
+	utConfig.Set(AWSKMSConfRegion, "eu-west-2")
+	tlsConf := utConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := k.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
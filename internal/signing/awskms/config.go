@@ -0,0 +1,41 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awskms
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+)
+
+const (
+	// AWSKMSConfRegion is the AWS region of the KMS service
+	AWSKMSConfRegion = "region"
+	// AWSKMSConfAccessKeyID is the AWS access key ID, which defaults to the AWS_ACCESS_KEY_ID environment variable
+	AWSKMSConfAccessKeyID = "accessKeyID"
+	// AWSKMSConfSecretAccessKey is the AWS secret access key, which defaults to the AWS_SECRET_ACCESS_KEY environment variable
+	AWSKMSConfSecretAccessKey = "secretAccessKey"
+	// AWSKMSConfSessionToken is an optional AWS session token, which defaults to the AWS_SESSION_TOKEN environment variable
+	AWSKMSConfSessionToken = "sessionToken"
+)
+
+func (k *AWSKMS) InitConfig(config config.Section) {
+	ffresty.InitConfig(config)
+	config.AddKnownKey(AWSKMSConfRegion)
+	config.AddKnownKey(AWSKMSConfAccessKeyID)
+	config.AddKnownKey(AWSKMSConfSecretAccessKey)
+	config.AddKnownKey(AWSKMSConfSessionToken)
+}
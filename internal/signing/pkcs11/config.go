@@ -0,0 +1,39 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs11
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+)
+
+const (
+	// PKCS11ConfLibrary is the path to the PKCS#11 module of the HSM vendor
+	PKCS11ConfLibrary = "library"
+	// PKCS11ConfSlot is the ID of the slot holding the token, if the token label is not set
+	PKCS11ConfSlot = "slot"
+	// PKCS11ConfTokenLabel is the label of the token holding the keys
+	PKCS11ConfTokenLabel = "tokenLabel"
+	// PKCS11ConfPIN is the user PIN used to log in to the token
+	PKCS11ConfPIN = "pin"
+)
+
+func (p *PKCS11) InitConfig(config config.Section) {
+	config.AddKnownKey(PKCS11ConfLibrary)
+	config.AddKnownKey(PKCS11ConfSlot, 0)
+	config.AddKnownKey(PKCS11ConfTokenLabel)
+	config.AddKnownKey(PKCS11ConfPIN)
+}
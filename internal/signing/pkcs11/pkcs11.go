@@ -0,0 +1,285 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs11
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"math/big"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/signing"
+	p11 "github.com/miekg/pkcs11"
+)
+
+// digestInfoSHA256 is the DER prefix of a PKCS #1 v1.5 DigestInfo for a SHA-256 digest. CKM_RSA_PKCS only
+// pads the input, so the DigestInfo is added before signing.
+var digestInfoSHA256 = []byte{0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20}
+
+var oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// module is the part of the PKCS#11 API used by the plugin
+type module interface {
+	Initialize() error
+	GetSlotList(tokenPresent bool) ([]uint, error)
+	GetTokenInfo(slotID uint) (p11.TokenInfo, error)
+	OpenSession(slotID uint, flags uint) (p11.SessionHandle, error)
+	Login(sh p11.SessionHandle, userType uint, pin string) error
+	FindObjectsInit(sh p11.SessionHandle, temp []*p11.Attribute) error
+	FindObjects(sh p11.SessionHandle, max int) ([]p11.ObjectHandle, bool, error)
+	FindObjectsFinal(sh p11.SessionHandle) error
+	GetAttributeValue(sh p11.SessionHandle, o p11.ObjectHandle, a []*p11.Attribute) ([]*p11.Attribute, error)
+	SignInit(sh p11.SessionHandle, m []*p11.Mechanism, o p11.ObjectHandle) error
+	Sign(sh p11.SessionHandle, message []byte) ([]byte, error)
+}
+
+// loadModule loads the PKCS#11 module of the HSM vendor into the process, returning nil if it cannot be loaded
+var loadModule = func(library string) module {
+	if ctx := p11.New(library); ctx != nil {
+		return ctx
+	}
+	return nil
+}
+
+// PKCS11 signs with keys held in a HSM, through the PKCS#11 module of the HSM vendor, which is loaded into
+// the FireFly process. Key IDs are the labels of the keys in the token. A single session is used for all
+// requests, as a PKCS#11 session can only run one operation at a time.
+type PKCS11 struct {
+	ctx          context.Context
+	capabilities *signing.Capabilities
+	module       module
+	session      p11.SessionHandle
+	sessionMux   sync.Mutex
+}
+
+func (p *PKCS11) Name() string {
+	return "pkcs11"
+}
+
+func (p *PKCS11) Init(ctx context.Context, config config.Section) (err error) {
+	p.ctx = log.WithLogField(ctx, "signing", "pkcs11")
+
+	library := config.GetString(PKCS11ConfLibrary)
+	if library == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(PKCS11ConfLibrary), "pkcs11")
+	}
+	if p.module = loadModule(library); p.module == nil {
+		return i18n.NewError(ctx, coremsgs.MsgPKCS11LoadFailed, library)
+	}
+	if err := p.module.Initialize(); err != nil && err != p11.Error(p11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		return i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	slot, err := p.findSlot(ctx, config.GetUint(PKCS11ConfSlot), config.GetString(PKCS11ConfTokenLabel))
+	if err != nil {
+		return err
+	}
+	if p.session, err = p.module.OpenSession(slot, p11.CKF_SERIAL_SESSION); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	if err := p.module.Login(p.session, p11.CKU_USER, config.GetString(PKCS11ConfPIN)); err != nil && err != p11.Error(p11.CKR_USER_ALREADY_LOGGED_IN) {
+		return i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	p.capabilities = &signing.Capabilities{}
+	return nil
+}
+
+func (p *PKCS11) Capabilities() *signing.Capabilities {
+	return p.capabilities
+}
+
+// findSlot returns the slot of the token with the label, or the configured slot if no label is set
+func (p *PKCS11) findSlot(ctx context.Context, slot uint, tokenLabel string) (uint, error) {
+	if tokenLabel == "" {
+		return slot, nil
+	}
+	slots, err := p.module.GetSlotList(true)
+	if err != nil {
+		return 0, i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	for _, s := range slots {
+		if info, err := p.module.GetTokenInfo(s); err == nil && info.Label == tokenLabel {
+			return s, nil
+		}
+	}
+	return 0, i18n.NewError(ctx, coremsgs.MsgPKCS11TokenNotFound, tokenLabel)
+}
+
+// findKey returns the handle of the key of the class with the label. The session lock must be held.
+func (p *PKCS11) findKey(ctx context.Context, class uint, keyID string) (p11.ObjectHandle, error) {
+	template := []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, class),
+		p11.NewAttribute(p11.CKA_LABEL, keyID),
+	}
+	if err := p.module.FindObjectsInit(p.session, template); err != nil {
+		return 0, i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	objects, _, err := p.module.FindObjects(p.session, 1)
+	_ = p.module.FindObjectsFinal(p.session)
+	if err != nil {
+		return 0, i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	if len(objects) == 0 {
+		return 0, i18n.NewError(ctx, coremsgs.MsgPKCS11KeyNotFound, keyID)
+	}
+	return objects[0], nil
+}
+
+// getAttributes reads attributes of an object, returning their values by type. The session lock must be held.
+func (p *PKCS11) getAttributes(ctx context.Context, object p11.ObjectHandle, types ...uint) (map[uint][]byte, error) {
+	template := make([]*p11.Attribute, len(types))
+	for i, t := range types {
+		template[i] = p11.NewAttribute(t, nil)
+	}
+	attrs, err := p.module.GetAttributeValue(p.session, object, template)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	values := make(map[uint][]byte, len(attrs))
+	for _, attr := range attrs {
+		values[attr.Type] = attr.Value
+	}
+	return values, nil
+}
+
+// getKeyType reads the CKA_KEY_TYPE of a key, which is a CK_ULONG in the byte order of the platform
+func (p *PKCS11) getKeyType(ctx context.Context, key p11.ObjectHandle) (uint, error) {
+	attrs, err := p.getAttributes(ctx, key, p11.CKA_KEY_TYPE)
+	if err != nil {
+		return 0, err
+	}
+	value := attrs[p11.CKA_KEY_TYPE]
+	switch len(value) {
+	case 8:
+		return uint(binary.NativeEndian.Uint64(value)), nil
+	case 4:
+		return uint(binary.NativeEndian.Uint32(value)), nil
+	default:
+		return 0, i18n.NewError(ctx, coremsgs.MsgSigningRequestFailed, "CKA_KEY_TYPE")
+	}
+}
+
+// PublicKey returns the public key with the label, as PKIX ASN.1 DER. Only EC and RSA keys are supported.
+func (p *PKCS11) PublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	p.sessionMux.Lock()
+	defer p.sessionMux.Unlock()
+
+	key, err := p.findKey(ctx, p11.CKO_PUBLIC_KEY, keyID)
+	if err != nil {
+		return nil, err
+	}
+	keyType, err := p.getKeyType(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	switch keyType {
+	case p11.CKK_EC:
+		attrs, err := p.getAttributes(ctx, key, p11.CKA_EC_PARAMS, p11.CKA_EC_POINT)
+		if err != nil {
+			return nil, err
+		}
+		return marshalECPublicKey(attrs[p11.CKA_EC_PARAMS], attrs[p11.CKA_EC_POINT])
+	case p11.CKK_RSA:
+		attrs, err := p.getAttributes(ctx, key, p11.CKA_MODULUS, p11.CKA_PUBLIC_EXPONENT)
+		if err != nil {
+			return nil, err
+		}
+		return x509.MarshalPKIXPublicKey(&rsa.PublicKey{
+			N: new(big.Int).SetBytes(attrs[p11.CKA_MODULUS]),
+			E: int(new(big.Int).SetBytes(attrs[p11.CKA_PUBLIC_EXPONENT]).Int64()),
+		})
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgSigningPublicKeyInvalid, keyID)
+	}
+}
+
+// marshalECPublicKey builds the PKIX public key from the curve parameters and point of an EC key. The point is
+// a DER encoded OCTET STRING, although some modules return the uncompressed point without the encoding.
+func marshalECPublicKey(params, point []byte) ([]byte, error) {
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err != nil || len(rest) > 0 || len(raw) == 0 || raw[0] != 0x04 {
+		raw = point
+	}
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: raw, BitLength: 8 * len(raw)},
+	})
+}
+
+// Sign signs the digest with the private key with the label. ECDSA signatures are returned by the module as
+// r and s concatenated, and are converted to ASN.1 DER to match the other signing plugins.
+func (p *PKCS11) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	p.sessionMux.Lock()
+	defer p.sessionMux.Unlock()
+
+	key, err := p.findKey(ctx, p11.CKO_PRIVATE_KEY, keyID)
+	if err != nil {
+		return nil, err
+	}
+	keyType, err := p.getKeyType(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	var mechanism uint
+	input := digest
+	switch keyType {
+	case p11.CKK_EC:
+		mechanism = p11.CKM_ECDSA
+	case p11.CKK_RSA:
+		mechanism = p11.CKM_RSA_PKCS
+		input = append(append([]byte{}, digestInfoSHA256...), digest...)
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgSigningPublicKeyInvalid, keyID)
+	}
+	if err := p.module.SignInit(p.session, []*p11.Mechanism{p11.NewMechanism(mechanism, nil)}, key); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	signature, err := p.module.Sign(p.session, input)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSigningRequestFailed, err)
+	}
+	if keyType == p11.CKK_EC {
+		if len(signature) == 0 || len(signature)%2 != 0 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgSigningSignatureInvalid, keyID)
+		}
+		half := len(signature) / 2
+		return asn1.Marshal(ecdsaSignature{
+			R: new(big.Int).SetBytes(signature[:half]),
+			S: new(big.Int).SetBytes(signature[half:]),
+		})
+	}
+	return signature, nil
+}
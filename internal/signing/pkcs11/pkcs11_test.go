@@ -0,0 +1,366 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkcs11
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	p11 "github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("pkcs11_unit_tests")
+
+var defaultLoadModule = loadModule
+
+var oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+
+// fakeObject is a key in the fake token, with its attributes and the Go key used to sign
+type fakeObject struct {
+	attrs  map[uint][]byte
+	signer crypto.Signer
+}
+
+// fakeModule is an in-memory PKCS#11 token
+type fakeModule struct {
+	initErr     error
+	loginErr    error
+	findErr     error
+	attrErr     error
+	signErr     error
+	badKeyType  bool
+	slots       map[uint]string
+	objects     map[p11.ObjectHandle]*fakeObject
+	findResults []p11.ObjectHandle
+	signing     p11.ObjectHandle
+	mechanism   uint
+	loggedIn    string
+}
+
+func newFakeModule() *fakeModule {
+	return &fakeModule{
+		slots:   map[uint]string{0: "other", 3: "firefly"},
+		objects: make(map[p11.ObjectHandle]*fakeObject),
+	}
+}
+
+func (m *fakeModule) addKey(label string, keyType uint, signer crypto.Signer, publicAttrs map[uint][]byte) {
+	classAttr := func(class uint) map[uint][]byte {
+		attrs := map[uint][]byte{
+			p11.CKA_CLASS:    p11.NewAttribute(p11.CKA_CLASS, class).Value,
+			p11.CKA_LABEL:    []byte(label),
+			p11.CKA_KEY_TYPE: p11.NewAttribute(p11.CKA_KEY_TYPE, keyType).Value,
+		}
+		return attrs
+	}
+	public := classAttr(p11.CKO_PUBLIC_KEY)
+	for t, v := range publicAttrs {
+		public[t] = v
+	}
+	m.objects[p11.ObjectHandle(len(m.objects)+1)] = &fakeObject{attrs: public}
+	m.objects[p11.ObjectHandle(len(m.objects)+1)] = &fakeObject{attrs: classAttr(p11.CKO_PRIVATE_KEY), signer: signer}
+}
+
+func (m *fakeModule) Initialize() error {
+	return m.initErr
+}
+
+func (m *fakeModule) GetSlotList(tokenPresent bool) ([]uint, error) {
+	return []uint{0, 3}, nil
+}
+
+func (m *fakeModule) GetTokenInfo(slotID uint) (p11.TokenInfo, error) {
+	return p11.TokenInfo{Label: m.slots[slotID]}, nil
+}
+
+func (m *fakeModule) OpenSession(slotID uint, flags uint) (p11.SessionHandle, error) {
+	return p11.SessionHandle(slotID), nil
+}
+
+func (m *fakeModule) Login(sh p11.SessionHandle, userType uint, pin string) error {
+	m.loggedIn = fmt.Sprintf("%d:%s", sh, pin)
+	return m.loginErr
+}
+
+func (m *fakeModule) FindObjectsInit(sh p11.SessionHandle, temp []*p11.Attribute) error {
+	m.findResults = nil
+	for handle, obj := range m.objects {
+		match := true
+		for _, attr := range temp {
+			if string(obj.attrs[attr.Type]) != string(attr.Value) {
+				match = false
+			}
+		}
+		if match {
+			m.findResults = append(m.findResults, handle)
+		}
+	}
+	return nil
+}
+
+func (m *fakeModule) FindObjects(sh p11.SessionHandle, max int) ([]p11.ObjectHandle, bool, error) {
+	if len(m.findResults) > max {
+		m.findResults = m.findResults[:max]
+	}
+	return m.findResults, false, m.findErr
+}
+
+func (m *fakeModule) FindObjectsFinal(sh p11.SessionHandle) error {
+	return nil
+}
+
+func (m *fakeModule) GetAttributeValue(sh p11.SessionHandle, o p11.ObjectHandle, a []*p11.Attribute) ([]*p11.Attribute, error) {
+	if m.attrErr != nil {
+		return nil, m.attrErr
+	}
+	results := make([]*p11.Attribute, len(a))
+	for i, attr := range a {
+		value := m.objects[o].attrs[attr.Type]
+		if attr.Type == p11.CKA_KEY_TYPE && m.badKeyType {
+			value = []byte{1}
+		}
+		results[i] = &p11.Attribute{Type: attr.Type, Value: value}
+	}
+	return results, nil
+}
+
+func (m *fakeModule) SignInit(sh p11.SessionHandle, mechanisms []*p11.Mechanism, o p11.ObjectHandle) error {
+	m.signing = o
+	m.mechanism = mechanisms[0].Mechanism
+	return nil
+}
+
+func (m *fakeModule) Sign(sh p11.SessionHandle, message []byte) ([]byte, error) {
+	if m.signErr != nil {
+		return nil, m.signErr
+	}
+	switch key := m.objects[m.signing].signer.(type) {
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, message)
+		if err != nil {
+			return nil, err
+		}
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+		return signature, nil
+	case *rsa.PrivateKey:
+		// CKM_RSA_PKCS pads the DigestInfo it is given, which matches signing the digest with the hash type
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, message[len(digestInfoSHA256):])
+	default:
+		return []byte{}, nil
+	}
+}
+
+func newTestPKCS11(t *testing.T, m *fakeModule) *PKCS11 {
+	coreconfig.Reset()
+	loadModule = func(library string) module {
+		assert.Equal(t, "/usr/lib/softhsm/libsofthsm2.so", library)
+		return m
+	}
+	t.Cleanup(func() { loadModule = defaultLoadModule })
+	p := &PKCS11{}
+	p.InitConfig(utConfig)
+	utConfig.Set(PKCS11ConfLibrary, "/usr/lib/softhsm/libsofthsm2.so")
+	utConfig.Set(PKCS11ConfTokenLabel, "firefly")
+	utConfig.Set(PKCS11ConfPIN, "1234")
+	err := p.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	assert.NotNil(t, p.Capabilities())
+	assert.Equal(t, "pkcs11", p.Name())
+	assert.Equal(t, "3:1234", m.loggedIn)
+	return p
+}
+
+func uncompressedPoint(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	publicKey, err := key.PublicKey.ECDH()
+	assert.NoError(t, err)
+	return publicKey.Bytes()
+}
+
+func addECKey(t *testing.T, m *fakeModule, label string) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	params, _ := asn1.Marshal(oidNamedCurveP256)
+	point, _ := asn1.Marshal(uncompressedPoint(t, key))
+	m.addKey(label, p11.CKK_EC, key, map[uint][]byte{
+		p11.CKA_EC_PARAMS: params,
+		p11.CKA_EC_POINT:  point,
+	})
+	return key
+}
+
+func TestSignECDSA(t *testing.T) {
+	m := newFakeModule()
+	key := addECKey(t, m, "org1")
+	p := newTestPKCS11(t, m)
+
+	digest := sha256.Sum256([]byte("payload"))
+	signature, err := p.Sign(context.Background(), "org1", digest[:])
+	assert.NoError(t, err)
+	assert.Equal(t, uint(p11.CKM_ECDSA), m.mechanism)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature))
+
+	der, err := p.PublicKey(context.Background(), "org1")
+	assert.NoError(t, err)
+	expected, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, der)
+}
+
+func TestPublicKeyECRawPoint(t *testing.T) {
+	m := newFakeModule()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	params, _ := asn1.Marshal(oidNamedCurveP256)
+	m.addKey("org1", p11.CKK_EC, key, map[uint][]byte{
+		p11.CKA_EC_PARAMS: params,
+		p11.CKA_EC_POINT:  uncompressedPoint(t, key),
+	})
+	p := newTestPKCS11(t, m)
+
+	der, err := p.PublicKey(context.Background(), "org1")
+	assert.NoError(t, err)
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	assert.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(parsed))
+}
+
+func TestSignRSA(t *testing.T) {
+	m := newFakeModule()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	m.addKey("org1", p11.CKK_RSA, key, map[uint][]byte{
+		p11.CKA_MODULUS:         key.N.Bytes(),
+		p11.CKA_PUBLIC_EXPONENT: big.NewInt(int64(key.E)).Bytes(),
+	})
+	p := newTestPKCS11(t, m)
+
+	digest := sha256.Sum256([]byte("payload"))
+	signature, err := p.Sign(context.Background(), "org1", digest[:])
+	assert.NoError(t, err)
+	assert.Equal(t, uint(p11.CKM_RSA_PKCS), m.mechanism)
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	der, err := p.PublicKey(context.Background(), "org1")
+	assert.NoError(t, err)
+	expected, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, der)
+}
+
+func TestUnsupportedKeyType(t *testing.T) {
+	m := newFakeModule()
+	m.addKey("org1", p11.CKK_AES, nil, nil)
+	p := newTestPKCS11(t, m)
+
+	_, err := p.PublicKey(context.Background(), "org1")
+	assert.Regexp(t, "FF10582", err)
+	_, err = p.Sign(context.Background(), "org1", make([]byte, 32))
+	assert.Regexp(t, "FF10582", err)
+}
+
+func TestKeyNotFound(t *testing.T) {
+	p := newTestPKCS11(t, newFakeModule())
+
+	_, err := p.PublicKey(context.Background(), "org1")
+	assert.Regexp(t, "FF10680.*org1", err)
+	_, err = p.Sign(context.Background(), "org1", make([]byte, 32))
+	assert.Regexp(t, "FF10680.*org1", err)
+}
+
+func TestModuleErrors(t *testing.T) {
+	m := newFakeModule()
+	addECKey(t, m, "org1")
+	p := newTestPKCS11(t, m)
+
+	m.badKeyType = true
+	_, err := p.PublicKey(context.Background(), "org1")
+	assert.Regexp(t, "FF10581", err)
+	m.badKeyType = false
+
+	m.signErr = p11.Error(p11.CKR_DEVICE_ERROR)
+	_, err = p.Sign(context.Background(), "org1", make([]byte, 32))
+	assert.Regexp(t, "FF10581.*CKR_DEVICE_ERROR", err)
+
+	m.attrErr = p11.Error(p11.CKR_DEVICE_ERROR)
+	_, err = p.PublicKey(context.Background(), "org1")
+	assert.Regexp(t, "FF10581", err)
+
+	m.findErr = p11.Error(p11.CKR_SESSION_HANDLE_INVALID)
+	_, err = p.Sign(context.Background(), "org1", make([]byte, 32))
+	assert.Regexp(t, "FF10581.*CKR_SESSION_HANDLE_INVALID", err)
+}
+
+func TestInitSlot(t *testing.T) {
+	coreconfig.Reset()
+	m := newFakeModule()
+	m.initErr = p11.Error(p11.CKR_CRYPTOKI_ALREADY_INITIALIZED)
+	m.loginErr = p11.Error(p11.CKR_USER_ALREADY_LOGGED_IN)
+	loadModule = func(library string) module { return m }
+	defer func() { loadModule = defaultLoadModule }()
+	p := &PKCS11{}
+	p.InitConfig(utConfig)
+	utConfig.Set(PKCS11ConfLibrary, "libhsm.so")
+	utConfig.Set(PKCS11ConfSlot, 5)
+	err := p.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "5:", m.loggedIn)
+}
+
+func TestInitFail(t *testing.T) {
+	coreconfig.Reset()
+	p := &PKCS11{}
+	p.InitConfig(utConfig)
+	err := p.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*library", err)
+
+	utConfig.Set(PKCS11ConfLibrary, "/not/a/library.so")
+	err = p.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10678.*/not/a/library.so", err)
+
+	m := newFakeModule()
+	loadModule = func(library string) module { return m }
+	defer func() { loadModule = defaultLoadModule }()
+
+	m.initErr = p11.Error(p11.CKR_GENERAL_ERROR)
+	err = p.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10581.*CKR_GENERAL_ERROR", err)
+	m.initErr = nil
+
+	utConfig.Set(PKCS11ConfTokenLabel, "missing")
+	err = p.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10679.*missing", err)
+	utConfig.Set(PKCS11ConfTokenLabel, "")
+
+	m.loginErr = p11.Error(p11.CKR_PIN_INCORRECT)
+	err = p.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10581.*CKR_PIN_INCORRECT", err)
+}
@@ -0,0 +1,52 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sifactory
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/signing/awskms"
+	"github.com/hyperledger/firefly/internal/signing/pkcs11"
+	"github.com/hyperledger/firefly/internal/signing/vault"
+	"github.com/hyperledger/firefly/pkg/signing"
+)
+
+var pluginsByName = map[string]func() signing.Plugin{
+	(*awskms.AWSKMS)(nil).Name(): func() signing.Plugin { return &awskms.AWSKMS{} },
+	(*pkcs11.PKCS11)(nil).Name(): func() signing.Plugin { return &pkcs11.PKCS11{} },
+	(*vault.Vault)(nil).Name():   func() signing.Plugin { return &vault.Vault{} },
+}
+
+func InitConfig(config config.ArraySection) {
+	config.AddKnownKey(coreconfig.PluginConfigName)
+	config.AddKnownKey(coreconfig.PluginConfigType)
+	for name, plugin := range pluginsByName {
+		plugin().InitConfig(config.SubSection(name))
+	}
+}
+
+func GetPlugin(ctx context.Context, pluginType string) (signing.Plugin, error) {
+	plugin, ok := pluginsByName[pluginType]
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnknownSigningPlugin, pluginType)
+	}
+	return plugin(), nil
+}
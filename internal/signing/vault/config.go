@@ -0,0 +1,35 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+)
+
+const (
+	// VaultConfToken is the token used to authenticate to Vault
+	VaultConfToken = "token"
+	// VaultConfMount is the mount path of the transit secrets engine in Vault
+	VaultConfMount = "mount"
+)
+
+func (v *Vault) InitConfig(config config.Section) {
+	ffresty.InitConfig(config)
+	config.AddKnownKey(VaultConfToken)
+	config.AddKnownKey(VaultConfMount, "transit")
+}
@@ -0,0 +1,181 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/signing"
+)
+
+const vaultKeyTypeEd25519 = "ed25519"
+
+// Vault signs with keys held in the transit secrets engine of HashiCorp Vault. Key IDs are the names
+// of transit keys, and the latest version of the key is used.
+type Vault struct {
+	ctx          context.Context
+	capabilities *signing.Capabilities
+	client       *resty.Client
+	token        string
+	mount        string
+	keyMux       sync.Mutex
+	keyTypes     map[string]string
+}
+
+type vaultKeyResponse struct {
+	Data struct {
+		Type          string `json:"type"`
+		LatestVersion int    `json:"latest_version"`
+		Keys          map[string]struct {
+			PublicKey string `json:"public_key"`
+		} `json:"keys"`
+	} `json:"data"`
+}
+
+type vaultSignRequest struct {
+	Input              string `json:"input"`
+	Prehashed          bool   `json:"prehashed,omitempty"`
+	SignatureAlgorithm string `json:"signature_algorithm,omitempty"`
+}
+
+type vaultSignResponse struct {
+	Data struct {
+		Signature string `json:"signature"`
+	} `json:"data"`
+}
+
+func (v *Vault) Name() string {
+	return "vault"
+}
+
+func (v *Vault) Init(ctx context.Context, config config.Section) (err error) {
+	v.ctx = log.WithLogField(ctx, "signing", "vault")
+
+	if config.GetString(ffresty.HTTPConfigURL) == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(ffresty.HTTPConfigURL), "vault")
+	}
+	v.client, err = ffresty.New(v.ctx, config)
+	if err != nil {
+		return err
+	}
+	v.token = config.GetString(VaultConfToken)
+	v.mount = strings.Trim(config.GetString(VaultConfMount), "/")
+	v.keyTypes = make(map[string]string)
+	v.capabilities = &signing.Capabilities{}
+	return nil
+}
+
+func (v *Vault) Capabilities() *signing.Capabilities {
+	return v.capabilities
+}
+
+func (v *Vault) getKey(ctx context.Context, keyID string) (*vaultKeyResponse, error) {
+	var key vaultKeyResponse
+	res, err := v.client.R().SetContext(ctx).
+		SetHeader("X-Vault-Token", v.token).
+		SetResult(&key).
+		Get("/v1/" + v.mount + "/keys/" + url.PathEscape(keyID))
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSigningRequestFailed)
+	}
+	v.keyMux.Lock()
+	v.keyTypes[keyID] = key.Data.Type
+	v.keyMux.Unlock()
+	return &key, nil
+}
+
+// PublicKey returns the public key of the latest version of the transit key. Vault returns
+// ECDSA and RSA public keys PEM encoded, and Ed25519 public keys as base64 encoded raw bytes.
+func (v *Vault) PublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	key, err := v.getKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	latest, ok := key.Data.Keys[strconv.Itoa(key.Data.LatestVersion)]
+	if !ok || latest.PublicKey == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSigningPublicKeyInvalid, keyID)
+	}
+	if key.Data.Type == vaultKeyTypeEd25519 {
+		raw, err := base64.StdEncoding.DecodeString(latest.PublicKey)
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			return nil, i18n.NewError(ctx, coremsgs.MsgSigningPublicKeyInvalid, keyID)
+		}
+		return x509.MarshalPKIXPublicKey(ed25519.PublicKey(raw))
+	}
+	block, _ := pem.Decode([]byte(latest.PublicKey))
+	if block == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSigningPublicKeyInvalid, keyID)
+	}
+	return block.Bytes, nil
+}
+
+// Sign signs the digest with the latest version of the transit key. Vault only supports signing a
+// prehashed input for ECDSA and RSA keys, so Ed25519 keys sign the digest as the message.
+func (v *Vault) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	v.keyMux.Lock()
+	keyType, ok := v.keyTypes[keyID]
+	v.keyMux.Unlock()
+	if !ok {
+		key, err := v.getKey(ctx, keyID)
+		if err != nil {
+			return nil, err
+		}
+		keyType = key.Data.Type
+	}
+
+	path := "/v1/" + v.mount + "/sign/" + url.PathEscape(keyID)
+	req := &vaultSignRequest{Input: base64.StdEncoding.EncodeToString(digest)}
+	if keyType != vaultKeyTypeEd25519 {
+		path += "/sha2-256"
+		req.Prehashed = true
+		req.SignatureAlgorithm = "pkcs1v15" // ignored for ECDSA keys
+	}
+	var signed vaultSignResponse
+	res, err := v.client.R().SetContext(ctx).
+		SetHeader("X-Vault-Token", v.token).
+		SetBody(req).
+		SetResult(&signed).
+		Post(path)
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSigningRequestFailed)
+	}
+
+	// Signatures have the form vault:v<version>:<base64 signature>
+	parts := strings.SplitN(signed.Data.Signature, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSigningSignatureInvalid, keyID)
+	}
+	signature, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSigningSignatureInvalid, keyID)
+	}
+	return signature, nil
+}
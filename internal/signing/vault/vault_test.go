@@ -0,0 +1,217 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("vault_unit_tests")
+
+func newTestVault(t *testing.T, handler http.HandlerFunc) *Vault {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	coreconfig.Reset()
+	v := &Vault{}
+	v.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	utConfig.Set(VaultConfToken, "token1")
+	err := v.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	assert.NotNil(t, v.Capabilities())
+	assert.Equal(t, "vault", v.Name())
+	return v
+}
+
+func writeKeyResponse(w http.ResponseWriter, keyType, publicKey string) {
+	var res vaultKeyResponse
+	res.Data.Type = keyType
+	res.Data.LatestVersion = 2
+	res.Data.Keys = map[string]struct {
+		PublicKey string `json:"public_key"`
+	}{
+		"2": {PublicKey: publicKey},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&res)
+}
+
+func writeSignResponse(w http.ResponseWriter, signature string) {
+	var res vaultSignResponse
+	res.Data.Signature = signature
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&res)
+}
+
+func TestSignECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NoError(t, err)
+
+	v := newTestVault(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token1", r.Header.Get("X-Vault-Token"))
+		switch r.URL.Path {
+		case "/v1/transit/keys/org1":
+			writeKeyResponse(w, "ecdsa-p256", string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+		case "/v1/transit/sign/org1/sha2-256":
+			var req vaultSignRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.True(t, req.Prehashed)
+			digest, err := base64.StdEncoding.DecodeString(req.Input)
+			assert.NoError(t, err)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, digest)
+			assert.NoError(t, err)
+			writeSignResponse(w, "vault:v2:"+base64.StdEncoding.EncodeToString(signature))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	digest := sha256.Sum256([]byte("payload"))
+	signature, err := v.Sign(context.Background(), "org1", digest[:])
+	assert.NoError(t, err)
+	assert.True(t, ecdsa.VerifyASN1(&key.PublicKey, digest[:], signature))
+
+	publicKey, err := v.PublicKey(context.Background(), "org1")
+	assert.NoError(t, err)
+	assert.Equal(t, der, publicKey)
+}
+
+func TestSignEd25519(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	v := newTestVault(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/transit/keys/org1":
+			writeKeyResponse(w, "ed25519", base64.StdEncoding.EncodeToString(publicKey))
+		case "/v1/transit/sign/org1":
+			var req vaultSignRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.False(t, req.Prehashed)
+			message, err := base64.StdEncoding.DecodeString(req.Input)
+			assert.NoError(t, err)
+			writeSignResponse(w, "vault:v2:"+base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, message)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	der, err := v.PublicKey(context.Background(), "org1")
+	assert.NoError(t, err)
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey, parsed)
+
+	digest := sha256.Sum256([]byte("payload"))
+	signature, err := v.Sign(context.Background(), "org1", digest[:])
+	assert.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, digest[:], signature))
+}
+
+func TestSignBadSignature(t *testing.T) {
+	v := newTestVault(t, func(w http.ResponseWriter, r *http.Request) {
+		writeSignResponse(w, "vault:v1:!!!")
+	})
+	v.keyTypes["org1"] = "rsa-2048"
+
+	_, err := v.Sign(context.Background(), "org1", make([]byte, 32))
+	assert.Regexp(t, "FF10583", err)
+
+	v = newTestVault(t, func(w http.ResponseWriter, r *http.Request) {
+		writeSignResponse(w, "wrong")
+	})
+	v.keyTypes["org1"] = "rsa-2048"
+
+	_, err = v.Sign(context.Background(), "org1", make([]byte, 32))
+	assert.Regexp(t, "FF10583", err)
+}
+
+func TestSignFail(t *testing.T) {
+	v := newTestVault(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, err := v.Sign(context.Background(), "org1", make([]byte, 32))
+	assert.Regexp(t, "FF10581", err)
+
+	v.keyTypes["org1"] = "ecdsa-p256"
+	_, err = v.Sign(context.Background(), "org1", make([]byte, 32))
+	assert.Regexp(t, "FF10581", err)
+}
+
+func TestPublicKeyInvalid(t *testing.T) {
+	for keyType, publicKey := range map[string]string{
+		"ecdsa-p256": "not pem",
+		"ed25519":    "AAAA",
+		"rsa-2048":   "",
+	} {
+		v := newTestVault(t, func(w http.ResponseWriter, r *http.Request) {
+			writeKeyResponse(w, keyType, publicKey)
+		})
+		_, err := v.PublicKey(context.Background(), "org1")
+		assert.Regexp(t, "FF10582", err)
+	}
+}
+
+func TestPublicKeyFail(t *testing.T) {
+	v := newTestVault(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := v.PublicKey(context.Background(), "org1")
+	assert.Regexp(t, "FF10581", err)
+}
+
+func TestInitMissingURL(t *testing.T) {
+	coreconfig.Reset()
+	v := &Vault{}
+	v.InitConfig(utConfig)
+	err := v.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestInitBadTLS(t *testing.T) {
+	coreconfig.Reset()
+	v := &Vault{}
+	v.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, "https://vault.example.com")
+	tlsConf := utConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := v.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
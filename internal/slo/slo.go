@@ -0,0 +1,281 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slo measures service level objectives of a namespace, such as how quickly messages are confirmed
+// and how far behind the durable subscriptions are. A system event is emitted each time an objective is
+// breached, or recovers, and can also be posted to a webhook for alerting.
+package slo
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+var notifyConfig = config.RootSection("slo.notify")
+
+func InitConfig() {
+	ffresty.InitConfig(notifyConfig)
+}
+
+// Monitor evaluates the configured objectives on an interval, and emits an slo_breached or slo_recovered
+// event when the state of an objective changes
+type Monitor interface {
+	Start()
+	WaitStop()
+	Status() []*core.SLOStatus
+}
+
+type objective struct {
+	status  core.SLOStatus
+	measure func(ctx context.Context) (value float64, samples int, err error)
+}
+
+type monitor struct {
+	ctx         context.Context
+	namespace   string
+	database    database.Plugin
+	notify      *resty.Client
+	interval    time.Duration
+	window      time.Duration
+	percentile  int
+	sampleLimit int
+	statusMux   sync.Mutex
+	objectives  []*objective
+	done        chan struct{}
+}
+
+// NewMonitor returns nil if no objectives have a threshold configured
+func NewMonitor(ctx context.Context, ns string, di database.Plugin) (Monitor, error) {
+	confirmationThreshold := config.GetDuration(coreconfig.SLOMessageConfirmationThreshold)
+	lagThreshold := config.GetInt(coreconfig.SLOEventDeliveryLagThreshold)
+	if confirmationThreshold <= 0 && lagThreshold <= 0 {
+		return nil, nil
+	}
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "SLOMonitor")
+	}
+	m := &monitor{
+		ctx:         ctx,
+		namespace:   ns,
+		database:    di,
+		interval:    config.GetDuration(coreconfig.SLOInterval),
+		window:      config.GetDuration(coreconfig.SLOWindow),
+		percentile:  config.GetInt(coreconfig.SLOMessageConfirmationPercentile),
+		sampleLimit: config.GetInt(coreconfig.SLOMessageConfirmationSampleLimit),
+	}
+	if notifyConfig.GetString(ffresty.HTTPConfigURL) != "" {
+		client, err := ffresty.New(ctx, notifyConfig)
+		if err != nil {
+			return nil, err
+		}
+		m.notify = client
+	}
+	if confirmationThreshold > 0 {
+		m.objectives = append(m.objectives, &objective{
+			status: core.SLOStatus{
+				ID:        fftypes.NewUUID(),
+				Type:      core.SLOTypeMessageConfirmation,
+				Threshold: confirmationThreshold.Seconds(),
+			},
+			measure: m.measureMessageConfirmation,
+		})
+	}
+	if lagThreshold > 0 {
+		m.objectives = append(m.objectives, &objective{
+			status: core.SLOStatus{
+				ID:        fftypes.NewUUID(),
+				Type:      core.SLOTypeEventDeliveryLag,
+				Threshold: float64(lagThreshold),
+			},
+			measure: m.measureEventDeliveryLag,
+		})
+	}
+	return m, nil
+}
+
+func (m *monitor) Start() {
+	m.done = make(chan struct{})
+	go m.monitorLoop()
+}
+
+func (m *monitor) WaitStop() {
+	if m.done != nil {
+		<-m.done
+	}
+}
+
+// Status returns a copy of the latest measurement of each objective
+func (m *monitor) Status() []*core.SLOStatus {
+	m.statusMux.Lock()
+	defer m.statusMux.Unlock()
+	statuses := make([]*core.SLOStatus, len(m.objectives))
+	for i, o := range m.objectives {
+		status := o.status
+		statuses[i] = &status
+	}
+	return statuses
+}
+
+func (m *monitor) monitorLoop() {
+	defer close(m.done)
+	for {
+		m.evaluate(m.ctx)
+		select {
+		case <-time.After(m.interval):
+		case <-m.ctx.Done():
+			log.L(m.ctx).Debugf("SLO monitor exiting")
+			return
+		}
+	}
+}
+
+func (m *monitor) evaluate(ctx context.Context) {
+	for _, o := range m.objectives {
+		value, samples, err := o.measure(ctx)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to measure SLO %s: %s", o.status.Type, err)
+			continue
+		}
+		breached := samples > 0 && value > o.status.Threshold
+
+		m.statusMux.Lock()
+		changed := breached != o.status.Breached
+		o.status.Value = value
+		o.status.Samples = samples
+		o.status.Breached = breached
+		o.status.Checked = fftypes.Now()
+		if changed {
+			o.status.Changed = o.status.Checked
+		}
+		status := o.status
+		m.statusMux.Unlock()
+
+		if changed {
+			eventType := core.EventTypeSLORecovered
+			if breached {
+				eventType = core.EventTypeSLOBreached
+				log.L(ctx).Warnf("SLO %s breached: value=%f threshold=%f", status.Type, status.Value, status.Threshold)
+			} else {
+				log.L(ctx).Infof("SLO %s recovered: value=%f threshold=%f", status.Type, status.Value, status.Threshold)
+			}
+			m.emit(ctx, eventType, &status)
+		}
+	}
+}
+
+func (m *monitor) emit(ctx context.Context, eventType core.EventType, status *core.SLOStatus) {
+	event := core.NewEvent(eventType, m.namespace, status.ID, nil, core.SystemTopicSLO)
+	if err := m.database.InsertEvent(ctx, event); err != nil {
+		log.L(ctx).Errorf("Failed to insert %s event: %s", eventType, err)
+	}
+	if m.notify != nil {
+		if err := m.postNotification(ctx, eventType, status); err != nil {
+			log.L(ctx).Errorf("%s", err)
+		}
+	}
+}
+
+func (m *monitor) postNotification(ctx context.Context, eventType core.EventType, status *core.SLOStatus) error {
+	res, err := m.notify.R().SetContext(ctx).
+		SetBody(&core.SLONotification{
+			Namespace: m.namespace,
+			Event:     eventType,
+			SLO:       status,
+		}).
+		Post("")
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgSLONotifyFailed)
+	}
+	return nil
+}
+
+// measureMessageConfirmation returns the configured percentile of the time taken to confirm the messages
+// confirmed within the window, in seconds
+func (m *monitor) measureMessageConfirmation(ctx context.Context) (float64, int, error) {
+	since := fftypes.FFTime(time.Now().Add(-m.window))
+	fb := database.MessageQueryFactory.NewFilter(ctx)
+	msgs, _, err := m.database.GetMessages(ctx, m.namespace,
+		fb.Gt("confirmed", &since).Sort("-confirmed").Limit(uint64(m.sampleLimit)))
+	if err != nil {
+		return 0, 0, err
+	}
+	durations := make([]float64, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Confirmed != nil && msg.Header.Created != nil {
+			durations = append(durations, time.Time(*msg.Confirmed).Sub(time.Time(*msg.Header.Created)).Seconds())
+		}
+	}
+	return percentile(durations, m.percentile), len(durations), nil
+}
+
+// measureEventDeliveryLag returns the number of events the slowest durable subscription is behind the latest event
+func (m *monitor) measureEventDeliveryLag(ctx context.Context) (float64, int, error) {
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	events, _, err := m.database.GetEvents(ctx, m.namespace, fb.And().Sort("-sequence").Limit(1))
+	if err != nil || len(events) == 0 {
+		return 0, 0, err
+	}
+	latest := events[0].Sequence
+
+	subs, _, err := m.database.GetSubscriptions(ctx, m.namespace, database.SubscriptionQueryFactory.NewFilter(ctx).And())
+	if err != nil {
+		return 0, 0, err
+	}
+	maxLag := int64(0)
+	for _, sub := range subs {
+		offset, err := m.database.GetOffset(ctx, core.OffsetTypeSubscription, sub.ID.String())
+		if err != nil {
+			return 0, 0, err
+		}
+		current := int64(0)
+		if offset != nil {
+			current = offset.Current
+		}
+		if lag := latest - current; lag > maxLag {
+			maxLag = lag
+		}
+	}
+	return float64(maxLag), len(subs), nil
+}
+
+// percentile uses the nearest-rank method, so the result is always one of the values
+func percentile(values []float64, p int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(values))))
+	if rank < 1 {
+		rank = 1
+	} else if rank > len(values) {
+		rank = len(values)
+	}
+	return values[rank-1]
+}
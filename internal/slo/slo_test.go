@@ -0,0 +1,229 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestMonitor(t *testing.T) (*monitor, *databasemocks.Plugin) {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set(coreconfig.SLOMessageConfirmationThreshold, "10s")
+	config.Set(coreconfig.SLOEventDeliveryLagThreshold, 100)
+	mdi := &databasemocks.Plugin{}
+	m, err := NewMonitor(context.Background(), "ns1", mdi)
+	assert.NoError(t, err)
+	t.Cleanup(func() { mdi.AssertExpectations(t) })
+	return m.(*monitor), mdi
+}
+
+func confirmedMessage(took time.Duration) *core.Message {
+	now := time.Now()
+	created := fftypes.FFTime(now.Add(-took))
+	confirmed := fftypes.FFTime(now)
+	return &core.Message{
+		Header:    core.MessageHeader{ID: fftypes.NewUUID(), Created: &created},
+		Confirmed: &confirmed,
+	}
+}
+
+func TestNewMonitorDisabled(t *testing.T) {
+	coreconfig.Reset()
+	m, err := NewMonitor(context.Background(), "ns1", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+}
+
+func TestNewMonitorNilDatabase(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.SLOEventDeliveryLagThreshold, 100)
+	_, err := NewMonitor(context.Background(), "ns1", nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestNewMonitorBadNotifyConfig(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set(coreconfig.SLOEventDeliveryLagThreshold, 100)
+	notifyConfig.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	tlsConf := notifyConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	_, err := NewMonitor(context.Background(), "ns1", &databasemocks.Plugin{})
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestMonitorLoop(t *testing.T) {
+	m, mdi := newTestMonitor(t)
+	m.objectives = m.objectives[1:]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.ctx = ctx
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		cancel()
+	})
+
+	m.Start()
+	m.WaitStop()
+}
+
+func TestEvaluateBreachedAndRecovered(t *testing.T) {
+	m, mdi := newTestMonitor(t)
+
+	var notifications []*core.SLONotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n core.SLONotification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+		notifications = append(notifications, &n)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	notifyConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	m.notify, _ = ffresty.New(context.Background(), notifyConfig)
+
+	subID := fftypes.NewUUID()
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{
+		confirmedMessage(1 * time.Second),
+		confirmedMessage(20 * time.Second),
+		{Header: core.MessageHeader{ID: fftypes.NewUUID()}},
+	}, nil, nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 500}}, nil, nil)
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{
+		{SubscriptionRef: core.SubscriptionRef{ID: subID}},
+	}, nil, nil)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subID.String()).Return(nil, nil).Once()
+	mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeSLOBreached && e.Topic == core.SystemTopicSLO
+	})).Return(nil).Twice()
+
+	m.evaluate(context.Background())
+
+	statuses := m.Status()
+	assert.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Breached)
+	assert.Equal(t, 2, statuses[0].Samples)
+	assert.Greater(t, statuses[0].Value, float64(10))
+	assert.True(t, statuses[1].Breached)
+	assert.Equal(t, float64(500), statuses[1].Value)
+	assert.NotNil(t, statuses[1].Changed)
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, "ns1", notifications[0].Namespace)
+	assert.Equal(t, core.EventTypeSLOBreached, notifications[0].Event)
+
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{
+		confirmedMessage(1 * time.Second),
+	}, nil, nil).Once()
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, subID.String()).Return(&core.Offset{Current: 450}, nil).Once()
+	mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeSLORecovered
+	})).Return(fmt.Errorf("pop")).Twice()
+
+	m.evaluate(context.Background())
+
+	statuses = m.Status()
+	assert.False(t, statuses[0].Breached)
+	assert.False(t, statuses[1].Breached)
+	assert.Equal(t, float64(50), statuses[1].Value)
+	assert.Len(t, notifications, 4)
+	assert.Equal(t, core.EventTypeSLORecovered, notifications[3].Event)
+}
+
+func TestEvaluateNotifyFail(t *testing.T) {
+	m, mdi := newTestMonitor(t)
+	m.objectives = m.objectives[:1]
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	notifyConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	m.notify, _ = ffresty.New(context.Background(), notifyConfig)
+
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{
+		confirmedMessage(20 * time.Second),
+	}, nil, nil)
+	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+
+	m.evaluate(context.Background())
+	assert.True(t, m.Status()[0].Breached)
+
+	err := m.postNotification(context.Background(), core.EventTypeSLOBreached, &m.objectives[0].status)
+	assert.Regexp(t, "FF10551", err)
+}
+
+func TestEvaluateNoSamples(t *testing.T) {
+	m, mdi := newTestMonitor(t)
+
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return([]*core.Message{}, nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil)
+
+	m.evaluate(context.Background())
+
+	for _, status := range m.Status() {
+		assert.False(t, status.Breached)
+		assert.Equal(t, 0, status.Samples)
+		assert.NotNil(t, status.Checked)
+		assert.Nil(t, status.Changed)
+	}
+}
+
+func TestEvaluateMeasureErrors(t *testing.T) {
+	m, mdi := newTestMonitor(t)
+
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 500}}, nil, nil)
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+
+	m.evaluate(context.Background())
+	for _, status := range m.Status() {
+		assert.Nil(t, status.Checked)
+	}
+
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return([]*core.Subscription{
+		{SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID()}},
+	}, nil, nil)
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeSubscription, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, _, err := m.measureEventDeliveryLag(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPercentile(t *testing.T) {
+	assert.Equal(t, float64(0), percentile(nil, 95))
+	values := []float64{5, 1, 4, 2, 3, 10, 9, 8, 7, 6}
+	assert.Equal(t, float64(10), percentile(values, 95))
+	assert.Equal(t, float64(5), percentile(values, 50))
+	assert.Equal(t, float64(1), percentile(values, 0))
+	assert.Equal(t, float64(10), percentile(values, 150))
+}
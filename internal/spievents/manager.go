@@ -19,19 +19,26 @@ package spievents
 import (
 	"context"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
 )
 
 type Manager interface {
 	Dispatch(changeEvent *core.ChangeEvent)
 	ServeHTTPWebSocketListener(res http.ResponseWriter, req *http.Request)
+	NotifyLifecycle(event *core.LifecycleEvent)
 	WaitStop()
 }
 
@@ -45,9 +52,16 @@ type adminEventManager struct {
 
 	queueLength         int
 	blockedWarnInterval time.Duration
+
+	historyMux   sync.Mutex
+	history      []*core.ChangeEvent
+	lastSequence int64
+	startTime    *fftypes.FFTime
+
+	webhook *lifecycleWebhook
 }
 
-func NewAdminEventManager(ctx context.Context) Manager {
+func NewAdminEventManager(ctx context.Context) (Manager, error) {
 	ae := &adminEventManager{
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  int(config.GetByteSize(coreconfig.SPIWebSocketReadBufferSize)),
@@ -60,14 +74,30 @@ func NewAdminEventManager(ctx context.Context) Manager {
 		activeWebsockets:    make(map[string]*webSocket),
 		queueLength:         config.GetInt(coreconfig.SPIWebSocketEventQueueLength),
 		blockedWarnInterval: config.GetDuration(coreconfig.SPIWebSocketBlockedWarnInterval),
+		history:             make([]*core.ChangeEvent, config.GetInt(coreconfig.SPIWebSocketHistoryLength)),
+		startTime:           fftypes.Now(),
 	}
 	ae.ctx, ae.cancelCtx = context.WithCancel(
 		log.WithLogField(ctx, "role", "change-event-manager"),
 	)
-	return ae
+	var err error
+	if ae.webhook, err = newLifecycleWebhook(ae.ctx); err != nil {
+		ae.cancelCtx()
+		return nil, err
+	}
+	return ae, nil
 }
 
+// ServeHTTPWebSocketListener accepts a listener connection. The listener can start immediately by passing the same
+// options as the start command as query parameters - collections, namespaces, types and resumeFrom - rather
+// than sending a start command after connecting.
 func (ae *adminEventManager) ServeHTTPWebSocketListener(res http.ResponseWriter, req *http.Request) {
+	start, err := startCommandFromQuery(req.Context(), req.URL.Query())
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	wsConn, err := ae.upgrader.Upgrade(res, req, nil)
 	if err != nil {
 		log.L(ae.ctx).Errorf("WebSocket upgrade failed: %s", err)
@@ -76,11 +106,51 @@ func (ae *adminEventManager) ServeHTTPWebSocketListener(res http.ResponseWriter,
 
 	ae.mux.Lock()
 	wc := newWebSocket(ae, wsConn)
+	if start != nil {
+		wc.handleStart(start)
+	}
 	ae.activeWebsockets[wc.connID] = wc
 	ae.makeDirtyReadList()
 	ae.mux.Unlock()
 }
 
+func startCommandFromQuery(ctx context.Context, query url.Values) (*core.WSChangeEventCommand, error) {
+	collections := queryList(query, "collections")
+	if len(collections) == 0 {
+		return nil, nil
+	}
+	start := &core.WSChangeEventCommand{
+		Type:        core.WSChangeEventCommandTypeStart,
+		Collections: collections,
+		Filter: core.ChangeEventFilter{
+			Namespaces: queryList(query, "namespaces"),
+		},
+	}
+	for _, t := range queryList(query, "types") {
+		start.Filter.Types = append(start.Filter.Types, core.ChangeEventType(t))
+	}
+	if resumeFrom := query.Get("resumeFrom"); resumeFrom != "" {
+		sequence, err := strconv.ParseInt(resumeFrom, 10, 64)
+		if err != nil || sequence < 0 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgInvalidChangeEventResumeFrom, resumeFrom)
+		}
+		start.ResumeFrom = &sequence
+	}
+	return start, nil
+}
+
+// queryList accepts both repeated and comma separated query parameters
+func queryList(query url.Values, key string) (values []string) {
+	for _, v := range query[key] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
 func (ae *adminEventManager) wsClosed(connID string) {
 	ae.mux.Lock()
 	delete(ae.activeWebsockets, connID)
@@ -101,6 +171,19 @@ func (ae *adminEventManager) WaitStop() {
 	for _, ws := range activeWebsockets {
 		ws.waitClose()
 	}
+	if ae.webhook != nil {
+		<-ae.webhook.done
+	}
+}
+
+// NotifyLifecycle posts the event to the SPI webhook, if one is configured. It never blocks.
+func (ae *adminEventManager) NotifyLifecycle(event *core.LifecycleEvent) {
+	if ae.webhook == nil {
+		return
+	}
+	event.ID = fftypes.NewUUID()
+	event.Timestamp = fftypes.Now()
+	ae.webhook.notify(event)
 }
 
 func (ae *adminEventManager) makeDirtyReadList() {
@@ -111,9 +194,49 @@ func (ae *adminEventManager) makeDirtyReadList() {
 }
 
 func (ae *adminEventManager) Dispatch(changeEvent *core.ChangeEvent) {
-	// We don't lock here, as this is a critical path function.
+	// This is a critical path function, so we only hold the history lock while we assign the sequence,
+	// record the event, and queue it to each listener without blocking. Holding it while we queue means
+	// each listener receives events in sequence order.
 	// We use a dirty copy of the connection list, updated on add/remove
+	ae.historyMux.Lock()
+	defer ae.historyMux.Unlock()
+	ae.lastSequence++
+	changeEvent.ChangeSequence = ae.lastSequence
+	if len(ae.history) > 0 {
+		ae.history[ae.lastSequence%int64(len(ae.history))] = changeEvent
+	}
 	for _, ws := range ae.dirtyReadList {
 		ws.dispatch(changeEvent)
 	}
 }
+
+// eventsSince returns the events in the history after the supplied sequence. If any of those events are no
+// longer held, or the node has restarted since the sequence was issued, a dropped event is returned first.
+func (ae *adminEventManager) eventsSince(resumeFrom int64) []*core.ChangeEvent {
+	ae.historyMux.Lock()
+	defer ae.historyMux.Unlock()
+	if resumeFrom > ae.lastSequence {
+		// We cannot know how many events were missed before the restart
+		return []*core.ChangeEvent{{
+			Type:         core.ChangeEventTypeDropped,
+			DroppedSince: ae.startTime,
+		}}
+	}
+	oldest := ae.lastSequence - int64(len(ae.history)) + 1
+	if oldest < 1 {
+		oldest = 1
+	}
+	var events []*core.ChangeEvent
+	from := resumeFrom + 1
+	if from < oldest {
+		events = append(events, &core.ChangeEvent{
+			Type:         core.ChangeEventTypeDropped,
+			DroppedCount: oldest - from,
+		})
+		from = oldest
+	}
+	for seq := from; seq <= ae.lastSequence; seq++ {
+		events = append(events, ae.history[seq%int64(len(ae.history))])
+	}
+	return events
+}
@@ -35,13 +35,20 @@ import (
 )
 
 func newTestSPIEventsManager(t *testing.T) (ae *adminEventManager, ws *webSocket, wsc wsclient.WSClient, cancel func()) {
+	return newTestSPIEventsManagerWithQuery(t, "")
+}
+
+func newTestSPIEventsManagerWithQuery(t *testing.T, query string) (ae *adminEventManager, ws *webSocket, wsc wsclient.WSClient, cancel func()) {
 
-	ae = NewAdminEventManager(context.Background()).(*adminEventManager)
+	coreconfig.Reset()
+	mgr, err := NewAdminEventManager(context.Background())
+	assert.NoError(t, err)
+	ae = mgr.(*adminEventManager)
 	svr := httptest.NewServer(http.HandlerFunc(ae.ServeHTTPWebSocketListener))
 
 	clientConfig := config.RootSection("ut.wsclient")
 	wsclient.InitConfig(clientConfig)
-	clientConfig.Set(ffresty.HTTPConfigURL, fmt.Sprintf("http://%s", svr.Listener.Addr()))
+	clientConfig.Set(ffresty.HTTPConfigURL, fmt.Sprintf("http://%s/%s", svr.Listener.Addr(), query))
 	wsConfig, err := wsclient.GenerateConfig(context.Background(), clientConfig)
 	assert.NoError(t, err)
 
@@ -136,7 +143,9 @@ func TestSPIEventsE2E(t *testing.T) {
 
 func TestBadUpgrade(t *testing.T) {
 
-	ae := NewAdminEventManager(context.Background()).(*adminEventManager)
+	mgr, err := NewAdminEventManager(context.Background())
+	assert.NoError(t, err)
+	ae := mgr.(*adminEventManager)
 	svr := httptest.NewServer(http.HandlerFunc(ae.ServeHTTPWebSocketListener))
 	defer svr.Close()
 
@@ -145,3 +154,99 @@ func TestBadUpgrade(t *testing.T) {
 	assert.True(t, res.StatusCode >= 300)
 
 }
+
+func TestSPIEventsConnectWithFilter(t *testing.T) {
+	ae, _, wsc, cancel := newTestSPIEventsManagerWithQuery(t, "?collections=collection1&namespaces=ns1,ns2&types=created")
+	defer cancel()
+
+	ae.Dispatch(&core.ChangeEvent{
+		Collection: "collection1",
+		Type:       core.ChangeEventTypeCreated,
+		Namespace:  "ns3",
+	})
+	ae.Dispatch(&core.ChangeEvent{
+		Collection: "collection1",
+		Type:       core.ChangeEventTypeUpdated,
+		Namespace:  "ns2",
+	})
+	match := &core.ChangeEvent{
+		Collection: "collection1",
+		Type:       core.ChangeEventTypeCreated,
+		Namespace:  "ns2",
+	}
+	ae.Dispatch(match)
+
+	event := unmarshalChangeEvent(t, <-wsc.Receive())
+	assert.Equal(t, match, event)
+	assert.Equal(t, int64(3), event.ChangeSequence)
+}
+
+func TestSPIEventsResume(t *testing.T) {
+	ae, _, wsc, cancel := newTestSPIEventsManager(t)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		ae.Dispatch(&core.ChangeEvent{
+			Collection: "collection1",
+			Type:       core.ChangeEventTypeCreated,
+			Namespace:  "ns1",
+		})
+	}
+
+	resumeFrom := int64(1)
+	wsc.Send(ae.ctx, toJSON(t, &core.WSChangeEventCommand{
+		Type:        core.WSChangeEventCommandTypeStart,
+		Collections: []string{"collection1"},
+		ResumeFrom:  &resumeFrom,
+	}))
+
+	assert.Equal(t, int64(2), unmarshalChangeEvent(t, <-wsc.Receive()).ChangeSequence)
+	assert.Equal(t, int64(3), unmarshalChangeEvent(t, <-wsc.Receive()).ChangeSequence)
+
+	ae.Dispatch(&core.ChangeEvent{
+		Collection: "collection1",
+		Type:       core.ChangeEventTypeCreated,
+		Namespace:  "ns1",
+	})
+	assert.Equal(t, int64(4), unmarshalChangeEvent(t, <-wsc.Receive()).ChangeSequence)
+}
+
+func TestConnectBadResumeFrom(t *testing.T) {
+
+	mgr, err := NewAdminEventManager(context.Background())
+	assert.NoError(t, err)
+	ae := mgr.(*adminEventManager)
+	svr := httptest.NewServer(http.HandlerFunc(ae.ServeHTTPWebSocketListener))
+	defer svr.Close()
+
+	res, err := http.Get(fmt.Sprintf("http://%s?collections=collection1&resumeFrom=wrong", svr.Listener.Addr()))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+}
+
+func TestEventsSince(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.SPIWebSocketHistoryLength, 2)
+	mgr, err := NewAdminEventManager(context.Background())
+	assert.NoError(t, err)
+	ae := mgr.(*adminEventManager)
+
+	for i := 0; i < 5; i++ {
+		ae.Dispatch(&core.ChangeEvent{Collection: "collection1"})
+	}
+
+	events := ae.eventsSince(1)
+	assert.Len(t, events, 3)
+	assert.Equal(t, core.ChangeEventTypeDropped, events[0].Type)
+	assert.Equal(t, int64(2), events[0].DroppedCount)
+	assert.Equal(t, int64(4), events[1].ChangeSequence)
+	assert.Equal(t, int64(5), events[2].ChangeSequence)
+
+	assert.Empty(t, ae.eventsSince(5))
+
+	events = ae.eventsSince(10)
+	assert.Len(t, events, 1)
+	assert.Equal(t, core.ChangeEventTypeDropped, events[0].Type)
+	assert.Equal(t, ae.startTime, events[0].DroppedSince)
+}
@@ -0,0 +1,123 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spievents
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const (
+	// WebhookConfEvents is the list of lifecycle event types posted to the webhook. All types are posted if empty
+	WebhookConfEvents = "events"
+	// WebhookConfQueueLength is the number of lifecycle events queued for the webhook, before events are dropped
+	WebhookConfQueueLength = "queueLength"
+)
+
+var webhookConfig = config.RootSection("spi.webhook")
+
+func InitConfig() {
+	ffresty.InitConfig(webhookConfig)
+	webhookConfig.AddKnownKey(WebhookConfEvents)
+	webhookConfig.AddKnownKey(WebhookConfQueueLength, 100)
+}
+
+// lifecycleWebhook posts lifecycle events in order from a background routine, as they are raised while the
+// namespace manager holds its locks
+type lifecycleWebhook struct {
+	ctx        context.Context
+	client     *resty.Client
+	eventTypes []string
+	events     chan *core.LifecycleEvent
+	done       chan struct{}
+}
+
+// newLifecycleWebhook returns nil if no webhook URL is configured
+func newLifecycleWebhook(ctx context.Context) (*lifecycleWebhook, error) {
+	if webhookConfig.GetString(ffresty.HTTPConfigURL) == "" {
+		return nil, nil
+	}
+	client, err := ffresty.New(ctx, webhookConfig)
+	if err != nil {
+		return nil, err
+	}
+	wh := &lifecycleWebhook{
+		ctx:        log.WithLogField(ctx, "spi", "webhook"),
+		client:     client,
+		eventTypes: webhookConfig.GetStringSlice(WebhookConfEvents),
+		events:     make(chan *core.LifecycleEvent, webhookConfig.GetInt(WebhookConfQueueLength)),
+		done:       make(chan struct{}),
+	}
+	go wh.postLoop()
+	return wh, nil
+}
+
+func (wh *lifecycleWebhook) eventMatches(event *core.LifecycleEvent) bool {
+	if len(wh.eventTypes) == 0 {
+		return true
+	}
+	for _, t := range wh.eventTypes {
+		if t == event.Type.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (wh *lifecycleWebhook) notify(event *core.LifecycleEvent) {
+	if !wh.eventMatches(event) {
+		return
+	}
+	select {
+	case wh.events <- event:
+	default:
+		log.L(wh.ctx).Warnf("Lifecycle webhook is blocked - dropped %s event %s", event.Type, event.ID)
+	}
+}
+
+func (wh *lifecycleWebhook) postLoop() {
+	l := log.L(wh.ctx)
+	defer close(wh.done)
+	for {
+		select {
+		case event := <-wh.events:
+			if err := wh.post(event); err != nil {
+				// Retry is handled by the HTTP client, so once that is exhausted we move on
+				l.Errorf("Failed to post %s event %s: %s", event.Type, event.ID, err)
+			}
+		case <-wh.ctx.Done():
+			l.Debugf("Webhook closing - context cancelled")
+			return
+		}
+	}
+}
+
+func (wh *lifecycleWebhook) post(event *core.LifecycleEvent) error {
+	res, err := wh.client.R().SetContext(wh.ctx).
+		SetBody(event).
+		Post("")
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(wh.ctx, res, err, coremsgs.MsgSPIWebhookFailed)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spievents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleWebhookPost(t *testing.T) {
+	received := make(chan *core.LifecycleEvent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event core.LifecycleEvent
+		err := json.NewDecoder(r.Body).Decode(&event)
+		assert.NoError(t, err)
+		received <- &event
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	coreconfig.Reset()
+	InitConfig()
+	webhookConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	webhookConfig.Set(WebhookConfEvents, []string{"namespace_started"})
+	mgr, err := NewAdminEventManager(context.Background())
+	assert.NoError(t, err)
+	defer mgr.WaitStop()
+
+	mgr.NotifyLifecycle(&core.LifecycleEvent{
+		Type:   core.LifecycleEventTypePluginStarted,
+		Plugin: "database0",
+	})
+	mgr.NotifyLifecycle(&core.LifecycleEvent{
+		Type:      core.LifecycleEventTypeNamespaceStarted,
+		Namespace: "ns1",
+	})
+
+	event := <-received
+	assert.Equal(t, core.LifecycleEventTypeNamespaceStarted, event.Type)
+	assert.Equal(t, "ns1", event.Namespace)
+	assert.NotNil(t, event.ID)
+	assert.NotNil(t, event.Timestamp)
+}
+
+func TestLifecycleWebhookPostFail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	coreconfig.Reset()
+	InitConfig()
+	webhookConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	wh, err := newLifecycleWebhook(context.Background())
+	assert.NoError(t, err)
+
+	err = wh.post(&core.LifecycleEvent{Type: core.LifecycleEventTypeConfigReloaded})
+	assert.Regexp(t, "FF10567", err)
+}
+
+func TestLifecycleWebhookBlocked(t *testing.T) {
+	wh := &lifecycleWebhook{
+		ctx:    context.Background(),
+		events: make(chan *core.LifecycleEvent, 1),
+	}
+	wh.notify(&core.LifecycleEvent{Type: core.LifecycleEventTypeConfigReloaded})
+	// Should not block, and will warn
+	wh.notify(&core.LifecycleEvent{Type: core.LifecycleEventTypeConfigReloaded})
+	assert.Len(t, wh.events, 1)
+}
+
+func TestLifecycleWebhookNotConfigured(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	mgr, err := NewAdminEventManager(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, mgr.(*adminEventManager).webhook)
+	mgr.NotifyLifecycle(&core.LifecycleEvent{Type: core.LifecycleEventTypeConfigReloaded})
+	mgr.WaitStop()
+}
+
+func TestLifecycleWebhookBadTLS(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	webhookConfig.Set(ffresty.HTTPConfigURL, "https://localhost:12345")
+	tlsConf := webhookConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	_, err := NewAdminEventManager(context.Background())
+	assert.Regexp(t, "FF00153", err)
+}
@@ -44,6 +44,8 @@ type webSocket struct {
 	closed       bool
 	blocked      *core.ChangeEvent
 	lastWarnTime *fftypes.FFTime
+	replay       chan int64
+	replayedTo   int64
 }
 
 func newWebSocket(ae *adminEventManager, wsConn *websocket.Conn) *webSocket {
@@ -57,6 +59,7 @@ func newWebSocket(ae *adminEventManager, wsConn *websocket.Conn) *webSocket {
 		cancelCtx:    cancelCtx,
 		connID:       connID,
 		events:       make(chan *core.ChangeEvent, ae.queueLength),
+		replay:       make(chan int64, 1),
 		senderDone:   make(chan struct{}),
 		receiverDone: make(chan struct{}),
 	}
@@ -130,11 +133,17 @@ func (wc *webSocket) sendLoop() {
 				l.Debugf("Notifying client it missed %d events since %s", blocked.DroppedCount, blocked.DroppedSince)
 				wc.writeObject(blocked)
 			}
+			if changeEvent.ChangeSequence > 0 && changeEvent.ChangeSequence <= wc.replayedTo {
+				// Already sent while replaying from the history
+				continue
+			}
 			if !wc.eventMatches(changeEvent) {
 				continue
 			}
 			l.Tracef("Sending: %+v", changeEvent)
 			wc.writeObject(changeEvent)
+		case resumeFrom := <-wc.replay:
+			wc.replayFrom(resumeFrom)
 		case <-wc.receiverDone:
 			l.Debugf("Sender closing - receiver completed")
 			return
@@ -145,6 +154,23 @@ func (wc *webSocket) sendLoop() {
 	}
 }
 
+// replayFrom sends the events in the manager's history after the sequence that match the filter. Events dispatched
+// while we replay are also in our queue, so the live loop skips those up to the last sequence we replayed.
+func (wc *webSocket) replayFrom(resumeFrom int64) {
+	events := wc.manager.eventsSince(resumeFrom)
+	log.L(wc.ctx).Debugf("Replaying %d events after sequence %d", len(events), resumeFrom)
+	for _, changeEvent := range events {
+		if changeEvent.Type == core.ChangeEventTypeDropped {
+			wc.writeObject(changeEvent)
+			continue
+		}
+		wc.replayedTo = changeEvent.ChangeSequence
+		if wc.eventMatches(changeEvent) {
+			wc.writeObject(changeEvent)
+		}
+	}
+}
+
 func (wc *webSocket) receiveLoop() {
 	l := log.L(wc.ctx)
 	defer close(wc.receiverDone)
@@ -191,6 +217,7 @@ func (wc *webSocket) dispatch(event *core.ChangeEvent) {
 		wc.mux.Unlock()
 		if wc.lastWarnTime == nil || time.Since(*wc.lastWarnTime.Time()) > wc.manager.blockedWarnInterval {
 			log.L(wc.ctx).Warnf("Change event listener is blocked an missing %d events (since %s)", blocked.DroppedCount, blocked.DroppedSince)
+			wc.lastWarnTime = fftypes.Now()
 		}
 	}
 }
@@ -200,6 +227,12 @@ func (wc *webSocket) handleStart(start *core.WSChangeEventCommand) {
 	wc.collections = start.Collections
 	wc.filter = start.Filter
 	wc.mux.Unlock()
+	if start.ResumeFrom != nil {
+		select {
+		case wc.replay <- *start.ResumeFrom:
+		case <-wc.ctx.Done():
+		}
+	}
 }
 
 func (wc *webSocket) close() {
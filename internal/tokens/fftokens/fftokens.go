@@ -34,6 +34,8 @@ import (
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ffi2abi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/credentials"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/tokens"
@@ -58,8 +60,11 @@ type FFTokens struct {
 	callbacks       callbacks
 	configuredName  string
 	client          *resty.Client
+	credentials     *credentials.REST
 	wsconn          map[string]wsclient.WSClient
 	wsConfig        *wsclient.WSConfig
+	stop            map[string]chan struct{}
+	closed          map[string]chan struct{}
 	retry           *retry.Retry
 	poolsToActivate map[string][]*core.TokenPool
 }
@@ -305,20 +310,26 @@ func (ft *FFTokens) Init(ctx context.Context, cancelCtx context.CancelFunc, name
 		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "url", "tokens.fftokens")
 	}
 
+	var restyConfig *ffresty.Config
 	ft.wsConfig, err = wsclient.GenerateConfig(ctx, config)
 	if err == nil {
-		ft.client, err = ffresty.New(ft.ctx, config)
+		restyConfig, err = ffresty.GenerateConfig(ft.ctx, config)
 	}
 
 	if err != nil {
 		return err
 	}
+	ft.client = ffresty.NewWithConfig(ft.ctx, *restyConfig)
+	ft.credentials = credentials.NewREST(ft.ctx, ft.client, restyConfig)
+	tracing.InstrumentClient(ft.client, "fftokens")
 
 	if ft.wsConfig.WSKeyPath == "" {
 		ft.wsConfig.WSKeyPath = "/api/ws"
 	}
 
 	ft.wsconn = make(map[string]wsclient.WSClient)
+	ft.stop = make(map[string]chan struct{})
+	ft.closed = make(map[string]chan struct{})
 
 	ft.retry = &retry.Retry{
 		InitialDelay: config.GetDuration(FFTEventRetryInitialDelay),
@@ -345,11 +356,7 @@ func (ft *FFTokens) sendWSStartMsg(ctx context.Context, w wsclient.WSClient, nam
 
 func (ft *FFTokens) StartNamespace(ctx context.Context, namespace string, activePools []*core.TokenPool) (err error) {
 	if ft.wsconn[namespace] == nil {
-		ft.wsconn[namespace], err = wsclient.New(ctx, ft.wsConfig, nil, func(ctx context.Context, w wsclient.WSClient) error {
-			// On connect send start namespace message
-			// Will occur on reconnect as well
-			return ft.sendWSStartMsg(ctx, w, namespace)
-		})
+		ft.wsconn[namespace], err = ft.newNamespaceWS(ctx, namespace)
 		if err != nil {
 			return err
 		}
@@ -367,17 +374,71 @@ func (ft *FFTokens) StartNamespace(ctx context.Context, namespace string, active
 		return err
 	}
 
-	go ft.eventLoop(namespace)
+	ft.startEventLoop(namespace)
 
 	return nil
 }
 
+func (ft *FFTokens) newNamespaceWS(ctx context.Context, namespace string) (wsclient.WSClient, error) {
+	return wsclient.New(ctx, ft.wsConfig, nil, func(ctx context.Context, w wsclient.WSClient) error {
+		// On connect send start namespace message
+		// Will occur on reconnect as well
+		return ft.sendWSStartMsg(ctx, w, namespace)
+	})
+}
+
+func (ft *FFTokens) startEventLoop(namespace string) {
+	ft.stop[namespace] = make(chan struct{})
+	ft.closed[namespace] = make(chan struct{})
+
+	go ft.eventLoop(namespace, ft.stop[namespace], ft.closed[namespace])
+}
+
+// RotateCredentials switches to the credentials in the updated config. REST requests in flight complete with
+// the previous credentials. The websocket of each namespace is replaced with one using the new credentials,
+// once the event in progress has been handled - events that are not yet acknowledged are redelivered.
+func (ft *FFTokens) RotateCredentials(ctx context.Context, config config.Section) error {
+	var restyConfig *ffresty.Config
+	wsConfig, err := wsclient.GenerateConfig(ctx, config)
+	if err == nil {
+		restyConfig, err = ffresty.GenerateConfig(ctx, config)
+	}
+	if err != nil {
+		return err
+	}
+	if wsConfig.WSKeyPath == "" {
+		wsConfig.WSKeyPath = "/api/ws"
+	}
+	ft.credentials.Update(restyConfig)
+	ft.wsConfig = wsConfig
+
+	for namespace := range ft.wsconn {
+		wsconn, err := ft.newNamespaceWS(ft.ctx, namespace)
+		if err != nil {
+			return err
+		}
+		if stop, ok := ft.stop[namespace]; ok {
+			close(stop)
+			<-ft.closed[namespace]
+		}
+		log.L(ctx).Infof("Reconnecting websocket for namespace '%s' with new credentials", namespace)
+		ft.wsconn[namespace] = wsconn
+		if err := wsconn.Connect(); err != nil {
+			return err
+		}
+		ft.startEventLoop(namespace)
+	}
+	return nil
+}
+
 func (ft *FFTokens) StopNamespace(ctx context.Context, namespace string) error {
 	wsconn, ok := ft.wsconn[namespace]
 	if ok {
 		wsconn.Close()
 	}
 	delete(ft.wsconn, namespace)
+	delete(ft.stop, namespace)
+	delete(ft.closed, namespace)
 
 	return nil
 }
@@ -736,9 +797,10 @@ func (ft *FFTokens) handleTokenPoolActivated(ctx context.Context, data fftypes.J
 	return nil
 }
 
-func (ft *FFTokens) eventLoop(namespace string) {
+func (ft *FFTokens) eventLoop(namespace string, stop, closed chan struct{}) {
 	wsconn := ft.wsconn[namespace]
 	defer wsconn.Close()
+	defer close(closed)
 	l := log.L(ft.ctx).WithField("role", "event-loop")
 	ctx := log.WithLogger(ft.ctx, l)
 	for {
@@ -746,6 +808,9 @@ func (ft *FFTokens) eventLoop(namespace string) {
 		case <-ctx.Done():
 			l.Debugf("Event loop exiting (context cancelled)")
 			return
+		case <-stop:
+			l.Debugf("Event loop exiting (websocket replaced)")
+			return
 		case msgBytes, ok := <-wsconn.Receive():
 			if !ok {
 				l.Debugf("Event loop exiting (receive channel closed). Terminating server!")
@@ -783,6 +848,42 @@ func wrapError(ctx context.Context, errRes *tokenError, res *resty.Response, err
 	return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgTokensRESTErr)
 }
 
+// CheckHealth confirms the token connector is reachable and ready to accept requests
+func (ft *FFTokens) CheckHealth(ctx context.Context) error {
+	var errRes tokenError
+	res, err := ft.client.R().SetContext(ctx).
+		SetError(&errRes).
+		Get("/api/v1/health/readiness")
+	if err != nil || !res.IsSuccess() {
+		return wrapError(ctx, &errRes, res, err)
+	}
+	return nil
+}
+
+// poolConfig returns the connector config for a pool, with the pool's firstEvent mapped
+// onto the "blockNumber" option so the connector indexes historical events from that block.
+// An explicit blockNumber in the pool config always takes precedence.
+func poolConfig(pool *core.TokenPool) fftypes.JSONObject {
+	var blockNumber string
+	switch pool.FirstEvent {
+	case "", core.TokenPoolFirstEventNewest:
+		return pool.Config
+	case core.TokenPoolFirstEventOldest:
+		blockNumber = "0"
+	default:
+		blockNumber = pool.FirstEvent
+	}
+	if _, ok := pool.Config["blockNumber"]; ok {
+		return pool.Config
+	}
+	config := fftypes.JSONObject{}
+	for k, v := range pool.Config {
+		config[k] = v
+	}
+	config["blockNumber"] = blockNumber
+	return config
+}
+
 func (ft *FFTokens) CreateTokenPool(ctx context.Context, nsOpID string, pool *core.TokenPool) (phase core.OpPhase, err error) {
 	tokenData := &tokenData{
 		TX:     pool.TX.ID,
@@ -797,7 +898,7 @@ func (ft *FFTokens) CreateTokenPool(ctx context.Context, nsOpID string, pool *co
 			RequestID: nsOpID,
 			Signer:    pool.Key,
 			Data:      string(data),
-			Config:    pool.Config,
+			Config:    poolConfig(pool),
 			Name:      pool.Name,
 			Symbol:    pool.Symbol,
 		}).
@@ -826,7 +927,7 @@ func (ft *FFTokens) EnsureTokenPoolActive(ctx context.Context, pool *core.TokenP
 			Namespace:   pool.Namespace,
 			PoolData:    packPoolData(pool.Namespace, pool.ID),
 			PoolLocator: pool.Locator,
-			Config:      pool.Config,
+			Config:      poolConfig(pool),
 		}).
 		SetError(&errRes).
 		Post("/api/v1/activatepool")
@@ -425,6 +425,65 @@ func TestActivateTokenPool(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestActivateTokenPoolFirstEvent(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	pool := &core.TokenPool{
+		Namespace:  "ns1",
+		Locator:    "N1",
+		FirstEvent: "oldest",
+		Config: map[string]interface{}{
+			"address": "0x12345",
+		},
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/activatepool", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			body := make(fftypes.JSONObject)
+			err := json.NewDecoder(req.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, fftypes.JSONObject{
+				"namespace":   "ns1",
+				"poolData":    "ns1",
+				"poolLocator": "N1",
+				"config": map[string]interface{}{
+					"address":     "0x12345",
+					"blockNumber": "0",
+				},
+			}, body)
+
+			res := &http.Response{
+				Body: io.NopCloser(bytes.NewReader([]byte(`{"id":"1"}`))),
+				Header: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+				StatusCode: 202,
+			}
+			return res, nil
+		})
+
+	phase, err := h.ActivateTokenPool(context.Background(), pool)
+	assert.Equal(t, core.OpPhasePending, phase)
+	assert.NoError(t, err)
+	// The stored pool config is not modified
+	assert.Equal(t, fftypes.JSONObject{"address": "0x12345"}, pool.Config)
+}
+
+func TestPoolConfigFirstEvent(t *testing.T) {
+	pool := &core.TokenPool{}
+	assert.Nil(t, poolConfig(pool))
+
+	pool.FirstEvent = "newest"
+	assert.Nil(t, poolConfig(pool))
+
+	pool.FirstEvent = "100"
+	assert.Equal(t, fftypes.JSONObject{"blockNumber": "100"}, poolConfig(pool))
+
+	pool.Config = fftypes.JSONObject{"blockNumber": "50"}
+	assert.Equal(t, fftypes.JSONObject{"blockNumber": "50"}, poolConfig(pool))
+}
+
 func TestActivateTokenPoolError(t *testing.T) {
 	h, _, _, httpURL, done := newTestFFTokens(t)
 	defer done()
@@ -1576,7 +1635,7 @@ func TestEventLoopReceiveClosed(t *testing.T) {
 	close(r)
 	wsm.On("Close").Return()
 	wsm.On("Receive").Return((<-chan []byte)(r))
-	h.eventLoop("ns1")
+	h.eventLoop("ns1", make(chan struct{}), make(chan struct{}))
 	assert.True(t, called)
 }
 
@@ -1594,7 +1653,7 @@ func TestEventLoopSendClosed(t *testing.T) {
 	wsm.On("Close").Return()
 	wsm.On("Receive").Return((<-chan []byte)(r))
 	wsm.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
-	h.eventLoop("ns1")
+	h.eventLoop("ns1", make(chan struct{}), make(chan struct{}))
 	assert.True(t, called)
 }
 
@@ -1622,7 +1681,7 @@ func TestEventLoopClosedContext(t *testing.T) {
 	r := make(chan []byte, 1)
 	wsm.On("Close").Return()
 	wsm.On("Receive").Return((<-chan []byte)(r))
-	h.eventLoop("ns1") // we're simply looking for it exiting
+	h.eventLoop("ns1", make(chan struct{}), make(chan struct{})) // we're simply looking for it exiting
 }
 
 func TestCallbacksWrongNamespace(t *testing.T) {
@@ -1912,3 +1971,74 @@ func TestConnectorName(t *testing.T) {
 	}
 	assert.Equal(t, h.ConnectorName(), "bob")
 }
+
+func TestCheckHealth(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/health/readiness", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{"status": "ok"}))
+
+	err := h.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestCheckHealthFail(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/health/readiness", httpURL),
+		httpmock.NewJsonResponderOrPanic(503, fftypes.JSONObject{"message": "not ready"}))
+
+	err := h.CheckHealth(context.Background())
+	assert.Regexp(t, "FF10274.*not ready", err)
+}
+
+func TestRotateCredentials(t *testing.T) {
+	h, toServer1, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	err := h.StartNamespace(context.Background(), "ns1", []*core.TokenPool{})
+	assert.NoError(t, err)
+	msg := <-toServer1
+	assert.Contains(t, msg, `"namespace":"ns1"`)
+
+	// The test server only accepts one connection, so the new websocket connects to a second one
+	toServer2, _, wsURL2, done2 := wsclient.NewTestWSServer(func(req *http.Request) {
+		assert.Equal(t, "key2", req.Header.Get("X-API-Key"))
+	})
+	defer done2()
+	u, _ := url.Parse(wsURL2)
+	u.Scheme = "http"
+	rotatedConfig := config.RootSection("fftokensrotated")
+	h.InitConfig(rotatedConfig)
+	rotatedConfig.AddKnownKey(ffresty.HTTPConfigURL, u.String())
+	rotatedConfig.AddKnownKey(ffresty.HTTPConfigHeaders, map[string]interface{}{"X-API-Key": "key2"})
+
+	err = h.RotateCredentials(context.Background(), rotatedConfig)
+	assert.NoError(t, err)
+	msg = <-toServer2
+	assert.Contains(t, msg, `"namespace":"ns1"`)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/health/readiness", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "key2", req.Header.Get("X-API-Key"))
+			return httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{"status": "ok"})(req)
+		})
+	err = h.CheckHealth(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestRotateCredentialsBadTLS(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	rotatedConfig := config.RootSection("fftokensrotatedtls")
+	h.InitConfig(rotatedConfig)
+	tlsConf := rotatedConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+
+	err := h.RotateCredentials(context.Background(), rotatedConfig)
+	assert.Regexp(t, "FF00153", err)
+}
@@ -35,6 +35,11 @@ func InitConfig(config config.ArraySection) {
 	config.AddKnownKey(coreconfig.PluginConfigName)
 	config.AddKnownKey(coreconfig.PluginConfigType)
 	config.AddKnownKey(coreconfig.PluginBroadcastName)
+	config.AddKnownKey(coreconfig.PluginStartupWait, "0")
+	config.AddKnownKey(coreconfig.PluginStartupOptional, false)
+	config.AddKnownKey(coreconfig.PluginStartupRetryInitDelay, "1s")
+	config.AddKnownKey(coreconfig.PluginStartupRetryMaxDelay, "30s")
+	config.AddKnownKey(coreconfig.PluginStartupRetryFactor, 2.0)
 	for name, plugin := range pluginsByName {
 		plugin().InitConfig(config.SubSection(name))
 	}
@@ -0,0 +1,65 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+// CorrelationIDHeader is the HTTP header used to pass a correlation ID into FireFly on API calls, and on
+// from FireFly to the connectors it calls
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// correlationIDMaxLength is the size of the column that stores the correlation ID on transactions and operations
+const correlationIDMaxLength = 64
+
+var correlationIDValidator = regexp.MustCompile(`^[a-zA-Z0-9_.:/=+-]+$`)
+
+type correlationIDKey struct{}
+
+// WithCorrelationID sets the correlation ID on the context, and adds it to the log fields of the context
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	ctx = log.WithLogField(ctx, "correlationid", correlationID)
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// GetCorrelationID returns the correlation ID set on the context with WithCorrelationID, or an empty string
+func GetCorrelationID(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
+
+// CorrelationMiddleware takes the correlation ID of each request from the X-Correlation-ID header, or generates
+// one if the header is not set or cannot be stored, and returns it on the response
+func CorrelationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		correlationID := req.Header.Get(CorrelationIDHeader)
+		if len(correlationID) > correlationIDMaxLength || !correlationIDValidator.MatchString(correlationID) {
+			if correlationID != "" {
+				log.L(req.Context()).Warnf("Ignoring invalid %s header", CorrelationIDHeader)
+			}
+			correlationID = fftypes.ShortID()
+		}
+		w.Header().Set(CorrelationIDHeader, correlationID)
+		next.ServeHTTP(w, req.WithContext(WithCorrelationID(req.Context(), correlationID)))
+	})
+}
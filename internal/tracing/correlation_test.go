@@ -0,0 +1,90 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationMiddlewareUsesHeader(t *testing.T) {
+	var correlationID string
+	handler := CorrelationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		correlationID = GetCorrelationID(req.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/messages/broadcast", nil)
+	req.Header.Set(CorrelationIDHeader, "esb-1234")
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, req)
+
+	assert.Equal(t, "esb-1234", correlationID)
+	assert.Equal(t, "esb-1234", res.Header().Get(CorrelationIDHeader))
+}
+
+func TestCorrelationMiddlewareGenerates(t *testing.T) {
+	var correlationIDs []string
+	handler := CorrelationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		correlationIDs = append(correlationIDs, GetCorrelationID(req.Context()))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/messages/broadcast", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/messages/broadcast", nil)
+	req.Header.Set(CorrelationIDHeader, strings.Repeat("a", correlationIDMaxLength+1))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/messages/broadcast", nil)
+	req.Header.Set(CorrelationIDHeader, "bad id")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Len(t, correlationIDs, 3)
+	for _, correlationID := range correlationIDs {
+		assert.NotEmpty(t, correlationID)
+		assert.LessOrEqual(t, len(correlationID), correlationIDMaxLength)
+		assert.NotContains(t, correlationID, " ")
+	}
+}
+
+func TestGetCorrelationIDUnset(t *testing.T) {
+	assert.Empty(t, GetCorrelationID(context.Background()))
+}
+
+func TestInstrumentClientCorrelationID(t *testing.T) {
+	newTestRecorder(t)
+
+	var correlationID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		correlationID = req.Header.Get(CorrelationIDHeader)
+	}))
+	defer server.Close()
+
+	client := resty.New().SetBaseURL(server.URL)
+	InstrumentClient(client, "ethereum")
+
+	ctx := WithCorrelationID(context.Background(), "esb-1234")
+	_, err := client.R().SetContext(ctx).Post("/")
+	assert.NoError(t, err)
+	assert.Equal(t, "esb-1234", correlationID)
+}
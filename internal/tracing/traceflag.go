@@ -0,0 +1,67 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type traceFlagKey struct{}
+
+// WithTraceFlag marks the context as processing a message or batch that was submitted with the trace flag, so
+// a single flow can be followed on a busy system without raising the global log level or sample ratio. The ID
+// is added to the log fields of the context under the kind.
+func WithTraceFlag(ctx context.Context, kind string, id *fftypes.UUID) context.Context {
+	ctx = log.WithLogField(ctx, kind, id.String())
+	return context.WithValue(ctx, traceFlagKey{}, true)
+}
+
+// HasTraceFlag returns true if the context was marked with WithTraceFlag
+func HasTraceFlag(ctx context.Context) bool {
+	flagged, _ := ctx.Value(traceFlagKey{}).(bool)
+	return flagged
+}
+
+// Logf logs at info level if the context has the trace flag, and at debug level otherwise
+func Logf(ctx context.Context, format string, args ...interface{}) {
+	if HasTraceFlag(ctx) {
+		log.L(ctx).WithField("trace", true).Infof(format, args...)
+	} else {
+		log.L(ctx).Debugf(format, args...)
+	}
+}
+
+// traceFlagSampler samples every span started with a context that has the trace flag, and defers to the
+// configured sampler for all other spans
+type traceFlagSampler struct {
+	sdktrace.Sampler
+}
+
+func (s traceFlagSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if HasTraceFlag(p.ParentContext) {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+		}
+	}
+	return s.Sampler.ShouldSample(p)
+}
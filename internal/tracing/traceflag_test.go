@@ -0,0 +1,57 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestTraceFlag(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, HasTraceFlag(ctx))
+	Logf(ctx, "not traced")
+
+	ctx = WithTraceFlag(ctx, "message", fftypes.NewUUID())
+	assert.True(t, HasTraceFlag(ctx))
+	Logf(ctx, "traced")
+}
+
+func TestTraceFlagSampler(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sr),
+		sdktrace.WithSampler(traceFlagSampler{sdktrace.NeverSample()}),
+	))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	_, span := StartSpan(context.Background(), "untraced")
+	span.End()
+	_, span = StartSpan(WithTraceFlag(context.Background(), "batch", fftypes.NewUUID()), "traced")
+	span.End()
+
+	ended := sr.Ended()
+	assert.Len(t, ended, 1)
+	assert.Equal(t, "traced", ended[0].Name())
+}
@@ -0,0 +1,300 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing creates OpenTelemetry spans around the processing stages of FireFly, and propagates
+// the trace context over HTTP to the connectors that FireFly calls.
+//
+// The stages of a message send run on different goroutines, and are not in the same call stack as the
+// REST request that sent it. So the span context of each message and batch is remembered for a while,
+// and the spans of later stages link back to the spans of the messages and batches they process.
+//
+// When tracing is disabled the global no-op tracer is used, so spans cost almost nothing.
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const tracerName = "github.com/hyperledger/firefly"
+
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+var providerMux sync.Mutex
+var provider *sdktrace.TracerProvider
+var remembered = newSpanStore(0)
+
+// Init installs a tracer provider that exports spans over OTLP/HTTP, if tracing is enabled.
+// It replaces any provider installed by a previous call.
+func Init(ctx context.Context) error {
+	Shutdown(ctx)
+	if !config.GetBool(coreconfig.TracingEnabled) {
+		return nil
+	}
+
+	var opts []otlptracehttp.Option
+	if endpoint := config.GetString(coreconfig.TracingEndpoint); endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	providerMux.Lock()
+	defer providerMux.Unlock()
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(traceFlagSampler{sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.GetFloat64(coreconfig.TracingSampleRatio)))}),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", config.GetString(coreconfig.TracingServiceName)),
+		)),
+	)
+	remembered.reset(config.GetInt(coreconfig.TracingLinkCacheSize))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	log.L(ctx).Infof("Tracing enabled, exporting to %s", config.GetString(coreconfig.TracingEndpoint))
+	return nil
+}
+
+// Shutdown flushes any spans that have not been exported, and returns to the no-op tracer
+func Shutdown(ctx context.Context) {
+	providerMux.Lock()
+	defer providerMux.Unlock()
+	if provider == nil {
+		return
+	}
+	if err := provider.Shutdown(ctx); err != nil {
+		log.L(ctx).Warnf("Failed to flush spans on shutdown: %s", err)
+	}
+	provider = nil
+	otel.SetTracerProvider(noop.NewTracerProvider())
+}
+
+// StartSpan starts a span as a child of any span in the context
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan records the error, if any, as the status of the span and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Remember keeps the span context of the context against an ID, so later spans can link to it with Links
+func Remember(ctx context.Context, id *fftypes.UUID) {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() && id != nil {
+		remembered.put(*id, sc)
+	}
+}
+
+// Links returns a link to each remembered span context of the IDs, ignoring those that are not remembered
+func Links(ids ...*fftypes.UUID) trace.SpanStartOption {
+	links := make([]trace.Link, 0, len(ids))
+	seen := make(map[fftypes.UUID]bool, len(ids))
+	for _, id := range ids {
+		if id == nil || seen[*id] {
+			continue
+		}
+		seen[*id] = true
+		if sc, ok := remembered.get(*id); ok {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+	return trace.WithLinks(links...)
+}
+
+// Middleware starts a server span for each request, continuing any trace propagated by the caller
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		name := req.URL.Path
+		if route := mux.CurrentRoute(req); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				name = template
+			}
+		}
+		ctx, span := StartSpan(ctx, fmt.Sprintf("%s %s", req.Method, name),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", req.Method),
+				attribute.String("http.route", name),
+			),
+		)
+		defer span.End()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, req.WithContext(ctx))
+		span.SetAttributes(attribute.Int("http.response.status_code", sw.status))
+		if sw.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(sw.status))
+		}
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Flush() {
+	if f, ok := sw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack is required for WebSocket upgrades
+func (sw *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := sw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	sw.status = http.StatusSwitchingProtocols
+	return h.Hijack()
+}
+
+type clientSpanKey struct{}
+
+type clientSpan struct {
+	parent context.Context
+	span   trace.Span
+}
+
+func getClientSpan(ctx context.Context) *clientSpan {
+	cs, _ := ctx.Value(clientSpanKey{}).(*clientSpan)
+	return cs
+}
+
+// InstrumentClient starts a client span for each request made with the context of the request, and
+// propagates the trace context and any correlation ID to the server in the request headers
+func InstrumentClient(client *resty.Client, plugin string) {
+	client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		parent := req.Context()
+		if cs := getClientSpan(parent); cs != nil {
+			// A retry of a request that failed - each attempt gets its own span
+			cs.span.End()
+			parent = cs.parent
+		}
+		ctx, span := StartSpan(parent, fmt.Sprintf("%s %s", plugin, req.Method),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("firefly.plugin", plugin),
+				attribute.String("http.request.method", req.Method),
+				attribute.String("url.full", req.URL),
+			),
+		)
+		propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+		if correlationID := GetCorrelationID(parent); correlationID != "" {
+			req.Header.Set(CorrelationIDHeader, correlationID)
+		}
+		req.SetContext(context.WithValue(ctx, clientSpanKey{}, &clientSpan{parent: parent, span: span}))
+		return nil
+	})
+	client.OnAfterResponse(func(_ *resty.Client, res *resty.Response) error {
+		if cs := getClientSpan(res.Request.Context()); cs != nil {
+			cs.span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode()))
+			if res.IsError() {
+				cs.span.SetStatus(codes.Error, res.Status())
+			}
+			cs.span.End()
+		}
+		return nil
+	})
+	client.OnError(func(req *resty.Request, err error) {
+		if cs := getClientSpan(req.Context()); cs != nil {
+			EndSpan(cs.span, err)
+		}
+	})
+}
+
+// spanStore holds the most recently remembered span contexts, discarding the oldest when it is full
+type spanStore struct {
+	mux      sync.Mutex
+	size     int
+	contexts map[fftypes.UUID]trace.SpanContext
+	order    []fftypes.UUID
+	next     int
+}
+
+func newSpanStore(size int) *spanStore {
+	ss := &spanStore{}
+	ss.reset(size)
+	return ss
+}
+
+func (ss *spanStore) reset(size int) {
+	ss.mux.Lock()
+	defer ss.mux.Unlock()
+	ss.size = size
+	ss.contexts = make(map[fftypes.UUID]trace.SpanContext, size)
+	ss.order = make([]fftypes.UUID, 0, size)
+	ss.next = 0
+}
+
+func (ss *spanStore) put(id fftypes.UUID, sc trace.SpanContext) {
+	ss.mux.Lock()
+	defer ss.mux.Unlock()
+	if ss.size <= 0 {
+		return
+	}
+	if _, exists := ss.contexts[id]; exists {
+		ss.contexts[id] = sc
+		return
+	}
+	if len(ss.order) < ss.size {
+		ss.order = append(ss.order, id)
+	} else {
+		delete(ss.contexts, ss.order[ss.next])
+		ss.order[ss.next] = id
+		ss.next = (ss.next + 1) % ss.size
+	}
+	ss.contexts[id] = sc
+}
+
+func (ss *spanStore) get(id fftypes.UUID) (trace.SpanContext, bool) {
+	ss.mux.Lock()
+	defer ss.mux.Unlock()
+	sc, ok := ss.contexts[id]
+	return sc, ok
+}
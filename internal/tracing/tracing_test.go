@@ -0,0 +1,195 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func newTestRecorder(t *testing.T) *tracetest.SpanRecorder {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	remembered.reset(10)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		remembered.reset(0)
+	})
+	return sr
+}
+
+func TestInitDisabled(t *testing.T) {
+	coreconfig.Reset()
+	err := Init(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, provider)
+}
+
+func TestInitEnabled(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.TracingEnabled, true)
+	config.Set(coreconfig.TracingEndpoint, "http://localhost:4318")
+	err := Init(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, provider)
+
+	Shutdown(context.Background())
+	assert.Nil(t, provider)
+	remembered.reset(0)
+}
+
+func TestInitBadEndpoint(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.TracingEnabled, true)
+	config.Set(coreconfig.TracingEndpoint, "::::")
+	err := Init(context.Background())
+	assert.Error(t, err)
+}
+
+func TestEndSpanError(t *testing.T) {
+	sr := newTestRecorder(t)
+
+	_, span := StartSpan(context.Background(), "test")
+	EndSpan(span, fmt.Errorf("pop"))
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "pop", spans[0].Status().Description)
+}
+
+func TestRememberLinks(t *testing.T) {
+	sr := newTestRecorder(t)
+
+	msgID := fftypes.NewUUID()
+	ctx, span := StartSpan(context.Background(), "message")
+	Remember(ctx, msgID)
+	span.End()
+
+	_, span = StartSpan(context.Background(), "batch", Links(msgID, msgID, fftypes.NewUUID(), nil))
+	span.End()
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 2)
+	assert.Len(t, spans[1].Links(), 1)
+	assert.Equal(t, spans[0].SpanContext().SpanID(), spans[1].Links()[0].SpanContext.SpanID())
+}
+
+func TestRememberNoSpan(t *testing.T) {
+	newTestRecorder(t)
+
+	msgID := fftypes.NewUUID()
+	Remember(context.Background(), msgID)
+	_, ok := remembered.get(*msgID)
+	assert.False(t, ok)
+}
+
+func TestSpanStoreEvictsOldest(t *testing.T) {
+	ss := newSpanStore(2)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{})
+	id1, id2, id3 := fftypes.NewUUID(), fftypes.NewUUID(), fftypes.NewUUID()
+	ss.put(*id1, sc)
+	ss.put(*id2, sc)
+	ss.put(*id2, sc)
+	ss.put(*id3, sc)
+
+	_, ok := ss.get(*id1)
+	assert.False(t, ok)
+	_, ok = ss.get(*id2)
+	assert.True(t, ok)
+	_, ok = ss.get(*id3)
+	assert.True(t, ok)
+}
+
+func TestMiddlewareContinuesTrace(t *testing.T) {
+	sr := newTestRecorder(t)
+
+	r := mux.NewRouter()
+	r.Use(Middleware)
+	r.HandleFunc("/api/v1/namespaces/{ns}/messages", func(w http.ResponseWriter, req *http.Request) {
+		assert.True(t, trace.SpanContextFromContext(req.Context()).IsValid())
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/ns1/messages", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "GET /api/v1/namespaces/{ns}/messages", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestInstrumentClientPropagates(t *testing.T) {
+	sr := newTestRecorder(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		traceparent = req.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := resty.New().SetBaseURL(server.URL)
+	InstrumentClient(client, "ffdx")
+
+	ctx, parent := StartSpan(context.Background(), "parent")
+	_, err := client.R().SetContext(ctx).Get("/api/v1/transfers")
+	assert.NoError(t, err)
+	parent.End()
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "ffdx GET", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, traceparent, spans[0].SpanContext().SpanID().String())
+}
+
+func TestInstrumentClientError(t *testing.T) {
+	sr := newTestRecorder(t)
+
+	client := resty.New().SetBaseURL("http://localhost:0")
+	InstrumentClient(client, "fftokens")
+
+	_, err := client.R().SetContext(context.Background()).Get("/api/v1/pools")
+	assert.Error(t, err)
+
+	spans := sr.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
@@ -0,0 +1,370 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"context"
+	"database/sql/driver"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Aggregator follows the confirmation events of a namespace in sequence order, and adds each confirmed message
+// and token transfer to the running totals of the counterparty org it was exchanged with, in hourly buckets.
+// The totals and the offset of the last event are written in the same database transaction, so each event is
+// counted exactly once across restarts.
+//
+// Messages are attributed to the org of their author when received, and to the orgs of the other members of
+// the group when sent privately. Broadcasts sent by the local org are not exchanged with any one counterparty,
+// so are not counted. Token transfers are attributed by resolving the from and to keys to their orgs, and are
+// only counted when one side is the local org and the other is a different org.
+type Aggregator interface {
+	Start()
+	WaitStop()
+}
+
+type aggregator struct {
+	ctx       context.Context
+	namespace string
+	database  database.Plugin
+	identity  identity.Manager
+	interval  time.Duration
+	batchSize int
+	done      chan struct{}
+}
+
+// NewAggregator returns nil if traffic totals are not enabled
+func NewAggregator(ctx context.Context, ns string, di database.Plugin, im identity.Manager) (Aggregator, error) {
+	if !config.GetBool(coreconfig.TrafficEnabled) {
+		return nil, nil
+	}
+	if di == nil || im == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "TrafficAggregator")
+	}
+	return &aggregator{
+		ctx:       ctx,
+		namespace: ns,
+		database:  di,
+		identity:  im,
+		interval:  config.GetDuration(coreconfig.TrafficInterval),
+		batchSize: config.GetInt(coreconfig.TrafficBatchSize),
+	}, nil
+}
+
+// Bucket returns the start of the hourly bucket a time falls in
+func Bucket(t *fftypes.FFTime) *fftypes.FFTime {
+	bucket := fftypes.FFTime(t.Time().UTC().Truncate(time.Hour))
+	return &bucket
+}
+
+func (ta *aggregator) Start() {
+	ta.done = make(chan struct{})
+	go ta.aggregateLoop()
+}
+
+func (ta *aggregator) WaitStop() {
+	if ta.done != nil {
+		<-ta.done
+	}
+}
+
+func (ta *aggregator) aggregateLoop() {
+	defer close(ta.done)
+	for {
+		processed, err := ta.aggregateBatch(ta.ctx)
+		if err != nil {
+			log.L(ta.ctx).Errorf("Traffic aggregation failed: %s", err)
+		}
+		if err != nil || processed < ta.batchSize {
+			select {
+			case <-time.After(ta.interval):
+			case <-ta.ctx.Done():
+				log.L(ta.ctx).Debugf("Traffic aggregator exiting")
+				return
+			}
+		} else if ta.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// batchTotals accumulates the stats of a batch of events, and caches the orgs resolved along the way
+type batchTotals struct {
+	localOrg string
+	stats    map[string]*core.TrafficStats
+	orgs     map[string]string
+}
+
+func (bt *batchTotals) add(ns string, created *fftypes.FFTime, counterparty string, direction core.TrafficDirection) *core.TrafficStats {
+	bucket := Bucket(created)
+	key := bucket.String() + "/" + counterparty + "/" + direction.String()
+	stats := bt.stats[key]
+	if stats == nil {
+		stats = &core.TrafficStats{
+			Namespace:    ns,
+			Bucket:       bucket,
+			Counterparty: counterparty,
+			Direction:    direction,
+		}
+		bt.stats[key] = stats
+	}
+	return stats
+}
+
+func (ta *aggregator) aggregateBatch(ctx context.Context) (processed int, err error) {
+	offset, err := ta.database.GetOffset(ctx, core.OffsetTypeTraffic, ta.namespace)
+	if err != nil {
+		return 0, err
+	}
+	if offset == nil {
+		offset = &core.Offset{Type: core.OffsetTypeTraffic, Name: ta.namespace}
+	}
+
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	events, _, err := ta.database.GetEvents(ctx, ta.namespace, fb.And(
+		fb.Gt("sequence", offset.Current),
+		fb.In("type", []driver.Value{core.EventTypeMessageConfirmed, core.EventTypeTransferConfirmed}),
+	).Sort("sequence").Limit(uint64(ta.batchSize)))
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	localOrg, err := ta.identity.GetRootOrgDID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	bt := &batchTotals{
+		localOrg: localOrg,
+		stats:    make(map[string]*core.TrafficStats),
+		orgs:     make(map[string]string),
+	}
+	for _, event := range events {
+		switch event.Type {
+		case core.EventTypeMessageConfirmed:
+			err = ta.addMessage(ctx, bt, event)
+		default:
+			err = ta.addTransfer(ctx, bt, event)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	stats := make([]*core.TrafficStats, 0, len(bt.stats))
+	for _, s := range bt.stats {
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Bucket.UnixNano() != stats[j].Bucket.UnixNano() {
+			return stats[i].Bucket.UnixNano() < stats[j].Bucket.UnixNano()
+		}
+		if stats[i].Counterparty != stats[j].Counterparty {
+			return stats[i].Counterparty < stats[j].Counterparty
+		}
+		return stats[i].Direction < stats[j].Direction
+	})
+	offset.Current = events[len(events)-1].Sequence
+	err = ta.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		if err := ta.database.AddTrafficStats(ctx, stats); err != nil {
+			return err
+		}
+		return ta.database.UpsertOffset(ctx, offset, true)
+	})
+	if err != nil {
+		return 0, err
+	}
+	log.L(ctx).Debugf("Totalled traffic for %d events, up to sequence %d", len(events), offset.Current)
+	return len(events), nil
+}
+
+func (ta *aggregator) addMessage(ctx context.Context, bt *batchTotals, event *core.Event) error {
+	msg, err := ta.database.GetMessageByID(ctx, ta.namespace, event.Reference)
+	if err != nil || msg == nil {
+		return err // messages pruned by a retention policy are skipped
+	}
+	author, err := ta.orgForDID(ctx, bt, msg.Header.Author)
+	if err != nil {
+		return err
+	}
+
+	var counterparties []string
+	var direction core.TrafficDirection
+	switch {
+	case author != bt.localOrg:
+		if author == "" {
+			return nil
+		}
+		counterparties = []string{author}
+		direction = core.TrafficDirectionReceived
+	case msg.Header.Group != nil:
+		if counterparties, err = ta.groupCounterparties(ctx, bt, msg.Header.Group); err != nil {
+			return err
+		}
+		direction = core.TrafficDirectionSent
+	default:
+		return nil // a broadcast sent by the local org
+	}
+	if len(counterparties) == 0 {
+		return nil
+	}
+
+	size, err := ta.messageBytes(ctx, msg)
+	if err != nil {
+		return err
+	}
+	for _, counterparty := range counterparties {
+		stats := bt.add(ta.namespace, event.Created, counterparty, direction)
+		stats.Messages++
+		stats.MessageBytes += size
+	}
+	return nil
+}
+
+func (ta *aggregator) groupCounterparties(ctx context.Context, bt *batchTotals, hash *fftypes.Bytes32) ([]string, error) {
+	group, err := ta.database.GetGroupByHash(ctx, ta.namespace, hash)
+	if err != nil || group == nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var counterparties []string
+	for _, member := range group.Members {
+		org, err := ta.orgForDID(ctx, bt, member.Identity)
+		if err != nil {
+			return nil, err
+		}
+		if org != "" && org != bt.localOrg && !seen[org] {
+			seen[org] = true
+			counterparties = append(counterparties, org)
+		}
+	}
+	return counterparties, nil
+}
+
+// messageBytes is the size of the data values of a message, plus the size of any blobs attached to the data
+func (ta *aggregator) messageBytes(ctx context.Context, msg *core.Message) (int64, error) {
+	if len(msg.Data) == 0 {
+		return 0, nil
+	}
+	ids := make([]driver.Value, len(msg.Data))
+	for i, d := range msg.Data {
+		ids[i] = d.ID
+	}
+	fb := database.DataQueryFactory.NewFilter(ctx)
+	data, _, err := ta.database.GetData(ctx, ta.namespace, fb.In("id", ids))
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, d := range data {
+		size += d.ValueSize
+		if d.Blob != nil {
+			size += d.Blob.Size
+		}
+	}
+	return size, nil
+}
+
+func (ta *aggregator) addTransfer(ctx context.Context, bt *batchTotals, event *core.Event) error {
+	transfer, err := ta.database.GetTokenTransferByID(ctx, ta.namespace, event.Reference)
+	if err != nil || transfer == nil {
+		return err
+	}
+	from, err := ta.orgForKey(ctx, bt, transfer.From)
+	if err != nil {
+		return err
+	}
+	to, err := ta.orgForKey(ctx, bt, transfer.To)
+	if err != nil {
+		return err
+	}
+	switch {
+	case from == bt.localOrg && to != "" && to != bt.localOrg:
+		bt.add(ta.namespace, event.Created, to, core.TrafficDirectionSent).Transfers++
+	case to == bt.localOrg && from != "" && from != bt.localOrg:
+		bt.add(ta.namespace, event.Created, from, core.TrafficDirectionReceived).Transfers++
+	}
+	return nil
+}
+
+// orgForDID returns the DID of the org an identity belongs to, or an empty string if it is not known
+func (ta *aggregator) orgForDID(ctx context.Context, bt *batchTotals, did string) (string, error) {
+	if did == "" {
+		return "", nil
+	}
+	if org, ok := bt.orgs[did]; ok {
+		return org, nil
+	}
+	id, _, err := ta.identity.CachedIdentityLookupNilOK(ctx, did)
+	if err != nil {
+		return "", err
+	}
+	org, err := ta.orgForIdentity(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	bt.orgs[did] = org
+	return org, nil
+}
+
+// orgForKey returns the DID of the org a signing key is registered to, or an empty string if it is not known
+func (ta *aggregator) orgForKey(ctx context.Context, bt *batchTotals, key string) (string, error) {
+	if key == "" {
+		return "", nil
+	}
+	if org, ok := bt.orgs[key]; ok {
+		return org, nil
+	}
+	fb := database.VerifierQueryFactory.NewFilter(ctx)
+	verifiers, _, err := ta.database.GetVerifiers(ctx, ta.namespace, fb.And(fb.Eq("value", key)).Limit(1))
+	if err != nil {
+		return "", err
+	}
+	var org string
+	if len(verifiers) > 0 {
+		id, err := ta.identity.CachedIdentityLookupByID(ctx, verifiers[0].Identity)
+		if err != nil {
+			return "", err
+		}
+		if org, err = ta.orgForIdentity(ctx, id); err != nil {
+			return "", err
+		}
+	}
+	bt.orgs[key] = org
+	return org, nil
+}
+
+func (ta *aggregator) orgForIdentity(ctx context.Context, id *core.Identity) (_ string, err error) {
+	for id != nil && id.Type != core.IdentityTypeOrg && id.Parent != nil {
+		if id, err = ta.identity.CachedIdentityLookupByID(ctx, id.Parent); err != nil {
+			return "", err
+		}
+	}
+	if id == nil || id.Type != core.IdentityTypeOrg {
+		return "", nil
+	}
+	return id.DID, nil
+}
@@ -0,0 +1,368 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testBucket = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	org1       = &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:org/org1", Type: core.IdentityTypeOrg}}
+	org2       = &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:org/org2", Type: core.IdentityTypeOrg}}
+	user2      = &core.Identity{IdentityBase: core.IdentityBase{ID: fftypes.NewUUID(), DID: "did:firefly:user2", Type: core.IdentityTypeCustom, Parent: org2.ID}}
+)
+
+func newTestAggregator(t *testing.T) (*aggregator, *databasemocks.Plugin, *identitymanagermocks.Manager, func()) {
+	coreconfig.Reset()
+	config.Set(coreconfig.TrafficEnabled, true)
+	config.Set(coreconfig.TrafficBatchSize, 10)
+	config.Set(coreconfig.TrafficInterval, "1ms")
+	mdi := &databasemocks.Plugin{}
+	mim := &identitymanagermocks.Manager{}
+	ctx, cancel := context.WithCancel(context.Background())
+	ta, err := NewAggregator(ctx, "ns1", mdi, mim)
+	assert.NoError(t, err)
+	return ta.(*aggregator), mdi, mim, func() {
+		cancel()
+		ta.WaitStop()
+		mdi.AssertExpectations(t)
+		mim.AssertExpectations(t)
+	}
+}
+
+func newTestEvent(seq int64, eventType core.EventType, ref *fftypes.UUID) *core.Event {
+	created := fftypes.FFTime(testBucket.Add(time.Duration(seq) * time.Minute))
+	return &core.Event{ID: fftypes.NewUUID(), Sequence: seq, Type: eventType, Reference: ref, Created: &created}
+}
+
+func mockRunAsGroup(mdi *databasemocks.Plugin) {
+	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
+	}
+}
+
+func TestNewAggregatorDisabled(t *testing.T) {
+	coreconfig.Reset()
+	ta, err := NewAggregator(context.Background(), "ns1", nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, ta)
+}
+
+func TestNewAggregatorNilDependency(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.TrafficEnabled, true)
+	_, err := NewAggregator(context.Background(), "ns1", nil, nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestBucket(t *testing.T) {
+	ts := fftypes.FFTime(testBucket.Add(59 * time.Minute))
+	assert.Equal(t, "2024-03-01T10:00:00Z", Bucket(&ts).String())
+}
+
+func TestAggregateLoopNoEvents(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	polled := make(chan struct{})
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeTraffic, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Run(func(args mock.Arguments) {
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	})
+	ta.Start()
+	<-polled
+	cleanup()
+}
+
+func TestAggregateLoopFail(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	polled := make(chan struct{})
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeTraffic, "ns1").Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	})
+	ta.Start()
+	<-polled
+	cleanup()
+}
+
+func TestAggregateBatch(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+
+	received := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), SignerRef: core.SignerRef{Author: user2.DID}}, Data: core.DataRefs{{ID: fftypes.NewUUID()}}}
+	group := &core.Group{GroupIdentity: core.GroupIdentity{Members: core.Members{{Identity: org1.DID}, {Identity: org2.DID}, {Identity: user2.DID}, {Identity: "did:firefly:unknown"}}}, Hash: fftypes.NewRandB32()}
+	sent := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), SignerRef: core.SignerRef{Author: org1.DID}, Group: group.Hash}, Data: core.DataRefs{{ID: fftypes.NewUUID()}}}
+	broadcast := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID(), SignerRef: core.SignerRef{Author: org1.DID}}}
+	transferOut := &core.TokenTransfer{LocalID: fftypes.NewUUID(), From: "0x1", To: "0x2"}
+	transferIn := &core.TokenTransfer{LocalID: fftypes.NewUUID(), From: "0x2", To: "0x1"}
+	mint := &core.TokenTransfer{LocalID: fftypes.NewUUID(), To: "0x1"}
+	pruned := fftypes.NewUUID()
+
+	events := []*core.Event{
+		newTestEvent(1, core.EventTypeMessageConfirmed, received.Header.ID),
+		newTestEvent(2, core.EventTypeMessageConfirmed, sent.Header.ID),
+		newTestEvent(3, core.EventTypeMessageConfirmed, broadcast.Header.ID),
+		newTestEvent(4, core.EventTypeTransferConfirmed, transferOut.LocalID),
+		newTestEvent(5, core.EventTypeTransferConfirmed, transferIn.LocalID),
+		newTestEvent(6, core.EventTypeTransferConfirmed, mint.LocalID),
+		newTestEvent(7, core.EventTypeMessageConfirmed, pruned),
+	}
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeTraffic, "ns1").Return(&core.Offset{Current: 0}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(events, nil, nil)
+	mim.On("GetRootOrgDID", mock.Anything).Return(org1.DID, nil)
+
+	mdi.On("GetMessageByID", mock.Anything, "ns1", received.Header.ID).Return(received, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", sent.Header.ID).Return(sent, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", broadcast.Header.ID).Return(broadcast, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", pruned).Return(nil, nil)
+	mim.On("CachedIdentityLookupNilOK", mock.Anything, user2.DID).Return(user2, false, nil).Once()
+	mim.On("CachedIdentityLookupNilOK", mock.Anything, org1.DID).Return(org1, false, nil).Once()
+	mim.On("CachedIdentityLookupNilOK", mock.Anything, org2.DID).Return(org2, false, nil).Once()
+	mim.On("CachedIdentityLookupNilOK", mock.Anything, "did:firefly:unknown").Return(nil, false, nil).Once()
+	mim.On("CachedIdentityLookupByID", mock.Anything, org2.ID).Return(org2, nil)
+	mdi.On("GetGroupByHash", mock.Anything, "ns1", group.Hash).Return(group, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{
+		{ValueSize: 100},
+		{ValueSize: 20, Blob: &core.BlobRef{Size: 1000}},
+	}, nil, nil)
+
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", transferOut.LocalID).Return(transferOut, nil)
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", transferIn.LocalID).Return(transferIn, nil)
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", mint.LocalID).Return(mint, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{{Identity: org1.ID}}, nil, nil).Once()
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{{Identity: user2.ID}}, nil, nil).Once()
+	mim.On("CachedIdentityLookupByID", mock.Anything, org1.ID).Return(org1, nil)
+	mim.On("CachedIdentityLookupByID", mock.Anything, user2.ID).Return(user2, nil)
+
+	mockRunAsGroup(mdi)
+	mdi.On("AddTrafficStats", mock.Anything, mock.MatchedBy(func(stats []*core.TrafficStats) bool {
+		return assert.Len(t, stats, 2) &&
+			assert.Equal(t, "2024-03-01T10:00:00Z", stats[0].Bucket.String()) &&
+			assert.Equal(t, org2.DID, stats[0].Counterparty) &&
+			assert.Equal(t, core.TrafficDirectionReceived, stats[0].Direction) &&
+			assert.Equal(t, int64(1), stats[0].Messages) &&
+			assert.Equal(t, int64(1120), stats[0].MessageBytes) &&
+			assert.Equal(t, int64(1), stats[0].Transfers) &&
+			assert.Equal(t, org2.DID, stats[1].Counterparty) &&
+			assert.Equal(t, core.TrafficDirectionSent, stats[1].Direction) &&
+			assert.Equal(t, int64(1), stats[1].Messages) &&
+			assert.Equal(t, int64(1120), stats[1].MessageBytes) &&
+			assert.Equal(t, int64(1), stats[1].Transfers)
+	})).Return(nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(offset *core.Offset) bool {
+		return offset.Current == 7
+	}), true).Return(nil)
+
+	processed, err := ta.aggregateBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 7, processed)
+}
+
+func TestAggregateBatchGetEventsFail(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeTraffic, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	_, err := ta.aggregateBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAggregateBatchRootOrgFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeTraffic, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID())}, nil, nil)
+	mim.On("GetRootOrgDID", mock.Anything).Return("", fmt.Errorf("pop"))
+	_, err := ta.aggregateBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAggregateBatchMessageFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeTraffic, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID())}, nil, nil)
+	mim.On("GetRootOrgDID", mock.Anything).Return(org1.DID, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	_, err := ta.aggregateBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAggregateBatchTransferFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeTraffic, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, core.EventTypeTransferConfirmed, fftypes.NewUUID())}, nil, nil)
+	mim.On("GetRootOrgDID", mock.Anything).Return(org1.DID, nil)
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	_, err := ta.aggregateBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAggregateBatchWriteFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeTraffic, "ns1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, core.EventTypeTransferConfirmed, fftypes.NewUUID())}, nil, nil)
+	mim.On("GetRootOrgDID", mock.Anything).Return(org1.DID, nil)
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+	mockRunAsGroup(mdi)
+	mdi.On("AddTrafficStats", mock.Anything, []*core.TrafficStats{}).Return(fmt.Errorf("pop"))
+	_, err := ta.aggregateBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAddMessageAuthorFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	msg := &core.Message{Header: core.MessageHeader{SignerRef: core.SignerRef{Author: user2.DID}}}
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(msg, nil)
+	mim.On("CachedIdentityLookupNilOK", mock.Anything, user2.DID).Return(nil, false, fmt.Errorf("pop"))
+	err := ta.addMessage(context.Background(), &batchTotals{orgs: map[string]string{}}, newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID()))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAddMessageParentFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	msg := &core.Message{Header: core.MessageHeader{SignerRef: core.SignerRef{Author: user2.DID}}}
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(msg, nil)
+	mim.On("CachedIdentityLookupNilOK", mock.Anything, user2.DID).Return(user2, false, nil)
+	mim.On("CachedIdentityLookupByID", mock.Anything, org2.ID).Return(nil, fmt.Errorf("pop"))
+	err := ta.addMessage(context.Background(), &batchTotals{orgs: map[string]string{}}, newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID()))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAddMessageUnknownAuthor(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	defer cleanup()
+	msg := &core.Message{Header: core.MessageHeader{SignerRef: core.SignerRef{Author: "did:firefly:unknown"}}}
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(msg, nil)
+	bt := &batchTotals{localOrg: org1.DID, stats: map[string]*core.TrafficStats{}, orgs: map[string]string{"did:firefly:unknown": ""}}
+	err := ta.addMessage(context.Background(), bt, newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID()))
+	assert.NoError(t, err)
+	assert.Empty(t, bt.stats)
+}
+
+func TestAddMessageGroupFail(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	defer cleanup()
+	msg := &core.Message{Header: core.MessageHeader{SignerRef: core.SignerRef{Author: org1.DID}, Group: fftypes.NewRandB32()}}
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(msg, nil)
+	mdi.On("GetGroupByHash", mock.Anything, "ns1", msg.Header.Group).Return(nil, fmt.Errorf("pop"))
+	bt := &batchTotals{localOrg: org1.DID, orgs: map[string]string{org1.DID: org1.DID}}
+	err := ta.addMessage(context.Background(), bt, newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID()))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAddMessageGroupMemberFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	group := &core.Group{GroupIdentity: core.GroupIdentity{Members: core.Members{{Identity: org2.DID}}}, Hash: fftypes.NewRandB32()}
+	msg := &core.Message{Header: core.MessageHeader{SignerRef: core.SignerRef{Author: org1.DID}, Group: group.Hash}}
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(msg, nil)
+	mdi.On("GetGroupByHash", mock.Anything, "ns1", group.Hash).Return(group, nil)
+	mim.On("CachedIdentityLookupNilOK", mock.Anything, org2.DID).Return(nil, false, fmt.Errorf("pop"))
+	bt := &batchTotals{localOrg: org1.DID, orgs: map[string]string{org1.DID: org1.DID}}
+	err := ta.addMessage(context.Background(), bt, newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID()))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAddMessageGroupNoCounterparties(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	defer cleanup()
+	msg := &core.Message{Header: core.MessageHeader{SignerRef: core.SignerRef{Author: org1.DID}, Group: fftypes.NewRandB32()}}
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(msg, nil)
+	mdi.On("GetGroupByHash", mock.Anything, "ns1", msg.Header.Group).Return(nil, nil)
+	bt := &batchTotals{localOrg: org1.DID, stats: map[string]*core.TrafficStats{}, orgs: map[string]string{org1.DID: org1.DID}}
+	err := ta.addMessage(context.Background(), bt, newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID()))
+	assert.NoError(t, err)
+	assert.Empty(t, bt.stats)
+}
+
+func TestAddMessageDataFail(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	defer cleanup()
+	msg := &core.Message{Header: core.MessageHeader{SignerRef: core.SignerRef{Author: org2.DID}}, Data: core.DataRefs{{ID: fftypes.NewUUID()}}}
+	mdi.On("GetMessageByID", mock.Anything, "ns1", mock.Anything).Return(msg, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	bt := &batchTotals{localOrg: org1.DID, orgs: map[string]string{org2.DID: org2.DID}}
+	err := ta.addMessage(context.Background(), bt, newTestEvent(1, core.EventTypeMessageConfirmed, fftypes.NewUUID()))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAddTransferFromFail(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", mock.Anything).Return(&core.TokenTransfer{From: "0x1", To: "0x2"}, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	err := ta.addTransfer(context.Background(), &batchTotals{orgs: map[string]string{}}, newTestEvent(1, core.EventTypeTransferConfirmed, fftypes.NewUUID()))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAddTransferToFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", mock.Anything).Return(&core.TokenTransfer{From: "0x1", To: "0x2"}, nil)
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{{Identity: org2.ID}}, nil, nil)
+	mim.On("CachedIdentityLookupByID", mock.Anything, org2.ID).Return(nil, fmt.Errorf("pop"))
+	bt := &batchTotals{orgs: map[string]string{"0x1": org1.DID}}
+	err := ta.addTransfer(context.Background(), bt, newTestEvent(1, core.EventTypeTransferConfirmed, fftypes.NewUUID()))
+	assert.EqualError(t, err, "pop")
+}
+
+func TestOrgForIdentityParentFail(t *testing.T) {
+	ta, mdi, mim, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{{Identity: user2.ID}}, nil, nil)
+	mim.On("CachedIdentityLookupByID", mock.Anything, user2.ID).Return(user2, nil)
+	mim.On("CachedIdentityLookupByID", mock.Anything, org2.ID).Return(nil, fmt.Errorf("pop"))
+	_, err := ta.orgForKey(context.Background(), &batchTotals{orgs: map[string]string{}}, "0x2")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestOrgForKeyUnknown(t *testing.T) {
+	ta, mdi, _, cleanup := newTestAggregator(t)
+	defer cleanup()
+	mdi.On("GetVerifiers", mock.Anything, "ns1", mock.Anything).Return([]*core.Verifier{}, nil, nil).Once()
+	bt := &batchTotals{orgs: map[string]string{}}
+	org, err := ta.orgForKey(context.Background(), bt, "0x3")
+	assert.NoError(t, err)
+	assert.Empty(t, org)
+	// Cached for the rest of the batch
+	org, err = ta.orgForKey(context.Background(), bt, "0x3")
+	assert.NoError(t, err)
+	assert.Empty(t, org)
+}
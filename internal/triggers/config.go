@@ -0,0 +1,59 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggers
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+)
+
+const (
+	// TriggerConfName is the name of the trigger, which must be unique within its namespace
+	TriggerConfName = "name"
+	// TriggerConfNamespace is the namespace the trigger follows confirmed messages in
+	TriggerConfNamespace = "namespace"
+	// TriggerConfTopic selects the confirmed messages on a topic
+	TriggerConfTopic = "topic"
+	// TriggerConfTag selects the confirmed messages with a tag
+	TriggerConfTag = "tag"
+	// TriggerConfDatatype selects the confirmed messages with data of a datatype, by name
+	TriggerConfDatatype = "datatype"
+	// TriggerConfAuthors limits the trigger to messages from these authors, by DID or signing key
+	TriggerConfAuthors = "authors"
+	// TriggerConfAction is the action performed for each message - invoke, mint, burn or transfer
+	TriggerConfAction = "action"
+	// TriggerConfTemplate is a Go template rendering the JSON request of the action from the message
+	TriggerConfTemplate = "template"
+	// TriggerConfBatchSize is the maximum number of events read in each poll
+	TriggerConfBatchSize = "batchSize"
+	// TriggerConfInterval is the time between polls for new events, once the trigger has caught up
+	TriggerConfInterval = "interval"
+)
+
+var triggersConfig = config.RootArray("messagetriggers")
+
+func InitConfig() {
+	triggersConfig.AddKnownKey(TriggerConfName)
+	triggersConfig.AddKnownKey(TriggerConfNamespace)
+	triggersConfig.AddKnownKey(TriggerConfTopic)
+	triggersConfig.AddKnownKey(TriggerConfTag)
+	triggersConfig.AddKnownKey(TriggerConfDatatype)
+	triggersConfig.AddKnownKey(TriggerConfAuthors)
+	triggersConfig.AddKnownKey(TriggerConfAction)
+	triggersConfig.AddKnownKey(TriggerConfTemplate)
+	triggersConfig.AddKnownKey(TriggerConfBatchSize, 50)
+	triggersConfig.AddKnownKey(TriggerConfInterval, "1s")
+}
@@ -0,0 +1,388 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package triggers performs a contract invoke or token operation for each confirmed message that matches
+// a rule, with the request rendered from the message and its data. This allows simple workflows, where
+// a message from one member drives an on-chain action, to run without an external orchestrator.
+package triggers
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/assets"
+	"github.com/hyperledger/firefly/internal/contracts"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const (
+	ActionInvoke   = "invoke"
+	ActionMint     = "mint"
+	ActionBurn     = "burn"
+	ActionTransfer = "transfer"
+)
+
+// Manager runs the message triggers configured for a namespace.
+//
+// Each trigger follows the message_confirmed events of the namespace in sequence order. For each message
+// that matches its topic, tag and datatype, the template is rendered with the message and its data, and
+// the result is decoded as the request of the action - a contract call request for invoke, or a token
+// transfer input for the token actions. The request is given an idempotency key derived from the trigger
+// name and the message, which is checked against existing transactions first, so a message triggers its
+// action at most once even if the trigger restarts before its offset is written.
+//
+// Requests that are rejected as invalid, such as those naming an unknown pool or a bad address, are logged
+// and skipped, so a single bad message does not block the trigger. A trigger can be limited to messages from
+// a list of authors, so other members of the network cannot drive actions signed by the local node.
+type Manager interface {
+	Start()
+	WaitStop()
+}
+
+type triggerManager struct {
+	triggers []*trigger
+}
+
+type trigger struct {
+	ctx       context.Context
+	name      string
+	namespace string
+	database  database.Plugin
+	contracts contracts.Manager
+	assets    assets.Manager
+	topic     string
+	tag       string
+	datatype  string
+	authors   map[string]bool
+	action    string
+	template  *template.Template
+	batchSize int
+	interval  time.Duration
+	done      chan struct{}
+}
+
+// triggerInput is the data a trigger template is executed against
+type triggerInput struct {
+	// Message is the confirmed message
+	Message *core.Message
+	// Data is the parsed JSON value of each data item of the message, in order
+	Data []interface{}
+	// Value is the parsed JSON value of the first data item of the message
+	Value interface{}
+}
+
+// NewTriggerManager returns nil if no message triggers are configured for the namespace
+func NewTriggerManager(ctx context.Context, ns string, di database.Plugin, cm contracts.Manager, am assets.Manager) (Manager, error) {
+	if di == nil || am == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "MessageTriggerManager")
+	}
+	tm := &triggerManager{}
+	names := make(map[string]bool)
+	for i := 0; i < triggersConfig.ArraySize(); i++ {
+		conf := triggersConfig.ArrayEntry(i)
+		if conf.GetString(TriggerConfNamespace) != ns {
+			continue
+		}
+		t, err := newTrigger(ctx, ns, conf, di, cm, am)
+		if err == nil && names[t.name] {
+			err = i18n.NewError(ctx, coremsgs.MsgDuplicateMessageTriggerName, t.name, ns)
+		}
+		if err != nil {
+			return nil, err
+		}
+		names[t.name] = true
+		tm.triggers = append(tm.triggers, t)
+	}
+	if len(tm.triggers) == 0 {
+		return nil, nil
+	}
+	return tm, nil
+}
+
+func newTrigger(ctx context.Context, ns string, conf config.Section, di database.Plugin, cm contracts.Manager, am assets.Manager) (*trigger, error) {
+	name := conf.GetString(TriggerConfName)
+	if name == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, conf.Resolve(TriggerConfName), "messagetriggers")
+	}
+	t := &trigger{
+		ctx:       log.WithLogField(ctx, "messagetrigger", name),
+		name:      name,
+		namespace: ns,
+		database:  di,
+		contracts: cm,
+		assets:    am,
+		topic:     conf.GetString(TriggerConfTopic),
+		tag:       conf.GetString(TriggerConfTag),
+		datatype:  conf.GetString(TriggerConfDatatype),
+		action:    conf.GetString(TriggerConfAction),
+		batchSize: conf.GetInt(TriggerConfBatchSize),
+		interval:  conf.GetDuration(TriggerConfInterval),
+	}
+	if t.topic == "" && t.tag == "" && t.datatype == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMessageTriggerNoSelector, name)
+	}
+	if authors := conf.GetStringSlice(TriggerConfAuthors); len(authors) > 0 {
+		t.authors = make(map[string]bool, len(authors))
+		for _, author := range authors {
+			t.authors[author] = true
+		}
+	} else {
+		log.L(ctx).Warnf("Message trigger '%s' has no authors configured, so messages from any member of the network will trigger its action", name)
+	}
+
+	switch t.action {
+	case ActionInvoke:
+		if cm == nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgMessageTriggerActionDisabled, name, t.action, ns)
+		}
+	case ActionMint, ActionBurn, ActionTransfer:
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedMessageTriggerAction, t.action, name)
+	}
+
+	tmpl := conf.GetString(TriggerConfTemplate)
+	if tmpl == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, conf.Resolve(TriggerConfTemplate), name)
+	}
+	var err error
+	t.template, err = template.New(name).Option("missingkey=zero").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			out, err := json.Marshal(v)
+			return string(out), err
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMessageTriggerInvalidTemplate, name, err)
+	}
+	return t, nil
+}
+
+func (tm *triggerManager) Start() {
+	for _, t := range tm.triggers {
+		t.done = make(chan struct{})
+		go t.triggerLoop()
+	}
+}
+
+func (tm *triggerManager) WaitStop() {
+	for _, t := range tm.triggers {
+		if t.done != nil {
+			<-t.done
+		}
+	}
+}
+
+func (t *trigger) triggerLoop() {
+	defer close(t.done)
+	for {
+		processed, err := t.triggerBatch(t.ctx)
+		if err != nil {
+			log.L(t.ctx).Errorf("Message trigger failed: %s", err)
+		}
+		if err != nil || processed < t.batchSize {
+			select {
+			case <-time.After(t.interval):
+			case <-t.ctx.Done():
+				log.L(t.ctx).Debugf("Message trigger exiting")
+				return
+			}
+		} else if t.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (t *trigger) triggerBatch(ctx context.Context) (processed int, err error) {
+	offsetName := fmt.Sprintf("%s:%s", t.namespace, t.name)
+	offset, err := t.database.GetOffset(ctx, core.OffsetTypeMessageTrigger, offsetName)
+	if err != nil {
+		return 0, err
+	}
+	if offset == nil {
+		offset = &core.Offset{Type: core.OffsetTypeMessageTrigger, Name: offsetName}
+	}
+
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	filters := []ffapi.Filter{
+		fb.Gt("sequence", offset.Current),
+		fb.Eq("type", core.EventTypeMessageConfirmed),
+	}
+	if t.topic != "" {
+		filters = append(filters, fb.Eq("topic", t.topic))
+	}
+	events, _, err := t.database.GetEvents(ctx, t.namespace, fb.And(filters...).Sort("sequence").Limit(uint64(t.batchSize)))
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if err := t.triggerEvent(ctx, event); err != nil {
+			return 0, err
+		}
+	}
+
+	offset.Current = events[len(events)-1].Sequence
+	if err = t.database.UpsertOffset(ctx, offset, true); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func (t *trigger) triggerEvent(ctx context.Context, event *core.Event) error {
+	msg, err := t.database.GetMessageByID(ctx, t.namespace, event.Reference)
+	if err != nil || msg == nil {
+		return err // messages pruned by a retention policy are skipped
+	}
+	if t.tag != "" && msg.Header.Tag != t.tag {
+		return nil
+	}
+	if t.authors != nil && !t.authors[msg.Header.Author] && !t.authors[msg.Header.Key] {
+		log.L(ctx).Warnf("Skipping message %s, as author '%s' (key '%s') is not allowed to trigger this action", msg.Header.ID, msg.Header.Author, msg.Header.Key)
+		return nil
+	}
+	input, err := t.loadInput(ctx, msg)
+	if err != nil || input == nil {
+		return err
+	}
+
+	idempotencyKey := core.IdempotencyKey(fmt.Sprintf("trigger:%s:%s", t.name, msg.Header.ID))
+	fb := database.TransactionQueryFactory.NewFilter(ctx)
+	existing, _, err := t.database.GetTransactions(ctx, t.namespace, fb.Eq("idempotencykey", string(idempotencyKey)))
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		log.L(ctx).Debugf("Message %s already triggered transaction %s", msg.Header.ID, existing[0].ID)
+		return nil
+	}
+
+	request, err := t.renderRequest(input)
+	if err != nil {
+		// The template cannot be rendered for this message, so retrying would block the trigger forever
+		log.L(ctx).Errorf("Skipping message %s, as the request template failed: %s", msg.Header.ID, err)
+		return nil
+	}
+	if err := t.performAction(ctx, request, idempotencyKey); err != nil {
+		if isRequestRejected(err) {
+			// The request is invalid for this message, so retrying would block the trigger forever
+			log.L(ctx).Errorf("Skipping message %s, as the %s request was rejected: %s", msg.Header.ID, t.action, err)
+			return nil
+		}
+		return err
+	}
+	log.L(ctx).Infof("Message %s triggered %s action", msg.Header.ID, t.action)
+	return nil
+}
+
+// loadInput returns nil if the trigger selects a datatype, and none of the data of the message is of that datatype
+func (t *trigger) loadInput(ctx context.Context, msg *core.Message) (*triggerInput, error) {
+	input := &triggerInput{Message: msg, Data: make([]interface{}, 0, len(msg.Data))}
+	matched := t.datatype == ""
+	if len(msg.Data) > 0 {
+		ids := make([]driver.Value, len(msg.Data))
+		for i, d := range msg.Data {
+			ids[i] = d.ID
+		}
+		fb := database.DataQueryFactory.NewFilter(ctx)
+		data, _, err := t.database.GetData(ctx, t.namespace, fb.In("id", ids))
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]*core.Data, len(data))
+		for _, d := range data {
+			byID[d.ID.String()] = d
+		}
+		for _, ref := range msg.Data {
+			var value interface{}
+			if d := byID[ref.ID.String()]; d != nil {
+				if d.Datatype != nil && d.Datatype.Name == t.datatype {
+					matched = true
+				}
+				if d.Value != nil {
+					_ = json.Unmarshal(d.Value.Bytes(), &value)
+				}
+			}
+			input.Data = append(input.Data, value)
+		}
+	}
+	if !matched {
+		return nil, nil
+	}
+	if len(input.Data) > 0 {
+		input.Value = input.Data[0]
+	}
+	return input, nil
+}
+
+func (t *trigger) renderRequest(input *triggerInput) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := t.template.Execute(buf, input); err != nil {
+		return nil, err
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("rendered request is not valid JSON: %s", buf.String())
+	}
+	return buf.Bytes(), nil
+}
+
+// isRequestRejected returns true for errors that report a problem with the request itself, rather than a
+// failure that might succeed on retry
+func isRequestRejected(err error) bool {
+	var ffErr i18n.FFError
+	return errors.As(err, &ffErr) && ffErr.HTTPStatus() >= http.StatusBadRequest && ffErr.HTTPStatus() < http.StatusInternalServerError
+}
+
+func (t *trigger) performAction(ctx context.Context, request []byte, idempotencyKey core.IdempotencyKey) (err error) {
+	if t.action == ActionInvoke {
+		var req core.ContractCallRequest
+		if err := json.Unmarshal(request, &req); err != nil {
+			log.L(ctx).Errorf("Skipping invalid contract call request: %s", err)
+			return nil
+		}
+		req.IdempotencyKey = idempotencyKey
+		_, err = t.contracts.InvokeContract(ctx, &req, false)
+		return err
+	}
+
+	var transfer core.TokenTransferInput
+	if err := json.Unmarshal(request, &transfer); err != nil {
+		log.L(ctx).Errorf("Skipping invalid token transfer request: %s", err)
+		return nil
+	}
+	transfer.IdempotencyKey = idempotencyKey
+	switch t.action {
+	case ActionMint:
+		_, err = t.assets.MintTokens(ctx, &transfer, false)
+	case ActionBurn:
+		_, err = t.assets.BurnTokens(ctx, &transfer, false)
+	default:
+		_, err = t.assets.TransferTokens(ctx, &transfer, false)
+	}
+	return err
+}
@@ -0,0 +1,425 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/mocks/assetmocks"
+	"github.com/hyperledger/firefly/mocks/contractmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testInvokeTemplate = `{
+	"interface": "{{ .Value.interface }}",
+	"location": {"address": "0x12345"},
+	"methodPath": "set",
+	"input": {"x": {{ json .Value.x }}, "author": "{{ .Message.Header.Author }}"}
+}`
+
+const testTransferTemplate = `{"pool": "pool1", "to": "{{ .Value.to }}", "amount": "{{ .Value.amount }}"}`
+
+func newTestTriggerConfig(triggers ...fftypes.JSONObject) {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set("messagetriggers", triggers)
+}
+
+func newTestTrigger(t *testing.T, conf fftypes.JSONObject) (*trigger, *databasemocks.Plugin, *contractmocks.Manager, *assetmocks.Manager, func()) {
+	conf["namespace"] = "ns1"
+	conf["interval"] = "1ms"
+	newTestTriggerConfig(conf)
+	mdi := &databasemocks.Plugin{}
+	mcm := &contractmocks.Manager{}
+	mam := &assetmocks.Manager{}
+	ctx, cancel := context.WithCancel(context.Background())
+	tm, err := NewTriggerManager(ctx, "ns1", mdi, mcm, mam)
+	assert.NoError(t, err)
+	return tm.(*triggerManager).triggers[0], mdi, mcm, mam, func() {
+		cancel()
+		tm.WaitStop()
+		mdi.AssertExpectations(t)
+		mcm.AssertExpectations(t)
+		mam.AssertExpectations(t)
+	}
+}
+
+func newTestMessage(tag string, data ...*core.Data) *core.Message {
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			Tag:       tag,
+			SignerRef: core.SignerRef{Author: "did:firefly:org/org1"},
+		},
+	}
+	for _, d := range data {
+		msg.Data = append(msg.Data, &core.DataRef{ID: d.ID})
+	}
+	return msg
+}
+
+func newTestData(datatype string, value string) *core.Data {
+	d := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(value)}
+	if datatype != "" {
+		d.Datatype = &core.DatatypeRef{Name: datatype, Version: "1"}
+	}
+	return d
+}
+
+func newTestEvent(seq int64, msg *core.Message) *core.Event {
+	return &core.Event{ID: fftypes.NewUUID(), Sequence: seq, Type: core.EventTypeMessageConfirmed, Reference: msg.Header.ID}
+}
+
+func TestNewTriggerManagerNoTriggers(t *testing.T) {
+	newTestTriggerConfig(fftypes.JSONObject{"name": "trigger1", "namespace": "ns2", "tag": "t1"})
+	tm, err := NewTriggerManager(context.Background(), "ns1", &databasemocks.Plugin{}, nil, &assetmocks.Manager{})
+	assert.NoError(t, err)
+	assert.Nil(t, tm)
+}
+
+func TestNewTriggerManagerNilDependency(t *testing.T) {
+	newTestTriggerConfig()
+	_, err := NewTriggerManager(context.Background(), "ns1", nil, nil, nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestNewTriggerManagerBadConfig(t *testing.T) {
+	for _, tc := range []struct {
+		conf     fftypes.JSONObject
+		expected string
+	}{
+		{fftypes.JSONObject{"tag": "t1"}, "FF10138"},
+		{fftypes.JSONObject{"name": "trigger1", "action": "invoke"}, "FF10655"},
+		{fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "wrong"}, "FF10656.*wrong.*trigger1"},
+		{fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint"}, "FF10138.*template"},
+		{fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint", "template": "{{ .Value"}, "FF10657"},
+	} {
+		tc.conf["namespace"] = "ns1"
+		newTestTriggerConfig(tc.conf)
+		_, err := NewTriggerManager(context.Background(), "ns1", &databasemocks.Plugin{}, &contractmocks.Manager{}, &assetmocks.Manager{})
+		assert.Regexp(t, tc.expected, err)
+	}
+}
+
+func TestNewTriggerManagerContractsDisabled(t *testing.T) {
+	newTestTriggerConfig(fftypes.JSONObject{"name": "trigger1", "namespace": "ns1", "tag": "t1", "action": "invoke", "template": "{}"})
+	_, err := NewTriggerManager(context.Background(), "ns1", &databasemocks.Plugin{}, nil, &assetmocks.Manager{})
+	assert.Regexp(t, "FF10658", err)
+}
+
+func TestNewTriggerManagerDuplicateName(t *testing.T) {
+	trigger := fftypes.JSONObject{"name": "trigger1", "namespace": "ns1", "tag": "t1", "action": "mint", "template": "{}"}
+	newTestTriggerConfig(trigger, trigger)
+	_, err := NewTriggerManager(context.Background(), "ns1", &databasemocks.Plugin{}, nil, &assetmocks.Manager{})
+	assert.Regexp(t, "FF10654", err)
+}
+
+func TestTriggerLoopNoEvents(t *testing.T) {
+	tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint", "template": "{}"})
+	polled := make(chan struct{})
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Run(func(args mock.Arguments) {
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	})
+	tm := &triggerManager{triggers: []*trigger{tr}}
+	tm.Start()
+	<-polled
+	cleanup()
+}
+
+func TestTriggerLoopFail(t *testing.T) {
+	tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint", "template": "{}"})
+	polled := make(chan struct{})
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	})
+	tm := &triggerManager{triggers: []*trigger{tr}}
+	tm.Start()
+	<-polled
+	cleanup()
+}
+
+func TestTriggerBatchInvoke(t *testing.T) {
+	tr, mdi, mcm, _, cleanup := newTestTrigger(t, fftypes.JSONObject{
+		"name":     "trigger1",
+		"topic":    "orders",
+		"tag":      "order",
+		"datatype": "order",
+		"action":   "invoke",
+		"template": testInvokeTemplate,
+	})
+	defer cleanup()
+
+	ifaceID := fftypes.NewUUID()
+	orderData := newTestData("order", fmt.Sprintf(`{"interface": "%s", "x": 42}`, ifaceID))
+	triggered := newTestMessage("order", orderData)
+	already := newTestMessage("order", newTestData("order", "{}"))
+	otherTag := newTestMessage("other")
+	otherDatatype := newTestMessage("order", newTestData("other", "{}"))
+	events := []*core.Event{
+		newTestEvent(11, triggered),
+		newTestEvent(12, already),
+		newTestEvent(13, otherTag),
+		newTestEvent(14, otherDatatype),
+		{Sequence: 15, Reference: fftypes.NewUUID()},
+	}
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(&core.Offset{Current: 10}, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(events, nil, nil)
+	for _, m := range []*core.Message{triggered, already, otherTag, otherDatatype} {
+		mdi.On("GetMessageByID", mock.Anything, "ns1", m.Header.ID).Return(m, nil)
+	}
+	mdi.On("GetMessageByID", mock.Anything, "ns1", events[4].Reference).Return(nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{orderData}, nil, nil).Once()
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{{ID: already.Data[0].ID, Datatype: &core.DatatypeRef{Name: "order"}}}, nil, nil).Once()
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{}, nil, nil).Once()
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{}, nil, nil).Once()
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{{ID: fftypes.NewUUID()}}, nil, nil).Once()
+	mcm.On("InvokeContract", mock.Anything, mock.MatchedBy(func(req *core.ContractCallRequest) bool {
+		return req.Interface.Equals(ifaceID) &&
+			req.MethodPath == "set" &&
+			req.Input["x"] == float64(42) &&
+			req.Input["author"] == "did:firefly:org/org1" &&
+			req.IdempotencyKey == core.IdempotencyKey("trigger:trigger1:"+triggered.Header.ID.String())
+	}), false).Return(nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.MatchedBy(func(o *core.Offset) bool {
+		return o.Current == 15
+	}), true).Return(nil)
+
+	processed, err := tr.triggerBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, processed)
+}
+
+func TestTriggerBatchTokenActions(t *testing.T) {
+	for _, action := range []string{ActionMint, ActionBurn, ActionTransfer} {
+		tr, mdi, _, mam, cleanup := newTestTrigger(t, fftypes.JSONObject{
+			"name":     "trigger1",
+			"tag":      "payment",
+			"action":   action,
+			"template": testTransferTemplate,
+		})
+
+		data := newTestData("", `{"to": "0xabcd", "amount": "10"}`)
+		msg := newTestMessage("payment", data)
+		mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+		mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+		mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+		mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{data}, nil, nil)
+		mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{}, nil, nil)
+		method := map[string]string{ActionMint: "MintTokens", ActionBurn: "BurnTokens", ActionTransfer: "TransferTokens"}[action]
+		mam.On(method, mock.Anything, mock.MatchedBy(func(transfer *core.TokenTransferInput) bool {
+			return transfer.Pool == "pool1" &&
+				transfer.To == "0xabcd" &&
+				transfer.Amount.String() == "10" &&
+				transfer.IdempotencyKey == core.IdempotencyKey("trigger:trigger1:"+msg.Header.ID.String())
+		}), false).Return(nil, nil)
+		mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+		processed, err := tr.triggerBatch(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		cleanup()
+	}
+}
+
+func TestTriggerBatchActionFail(t *testing.T) {
+	tr, mdi, _, mam, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "payment", "action": "mint", "template": "{}"})
+	defer cleanup()
+
+	msg := newTestMessage("payment")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{}, nil, nil)
+	mam.On("MintTokens", mock.Anything, mock.Anything, false).Return(nil, fmt.Errorf("pop"))
+
+	_, err := tr.triggerBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestTriggerBatchActionRejectedSkipped(t *testing.T) {
+	tr, mdi, _, mam, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "payment", "action": "mint", "template": "{}"})
+	defer cleanup()
+
+	msg := newTestMessage("payment")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{}, nil, nil)
+	mam.On("MintTokens", mock.Anything, mock.Anything, false).Return(nil, i18n.NewError(context.Background(), coremsgs.Msg404NotFound))
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+	processed, err := tr.triggerBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
+
+func TestTriggerBatchAuthorNotAllowed(t *testing.T) {
+	tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{
+		"name":     "trigger1",
+		"tag":      "payment",
+		"authors":  []string{"did:firefly:org/org2", "0x12345"},
+		"action":   "mint",
+		"template": "{}",
+	})
+	defer cleanup()
+
+	msg := newTestMessage("payment")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+	processed, err := tr.triggerBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
+
+func TestTriggerBatchAuthorKeyAllowed(t *testing.T) {
+	tr, mdi, _, mam, cleanup := newTestTrigger(t, fftypes.JSONObject{
+		"name":     "trigger1",
+		"tag":      "payment",
+		"authors":  []string{"did:firefly:org/org2", "0x12345"},
+		"action":   "mint",
+		"template": "{}",
+	})
+	defer cleanup()
+
+	msg := newTestMessage("payment")
+	msg.Header.Key = "0x12345"
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{}, nil, nil)
+	mam.On("MintTokens", mock.Anything, mock.Anything, false).Return(nil, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+	processed, err := tr.triggerBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+}
+
+func TestTriggerBatchBadRequestsSkipped(t *testing.T) {
+	for _, tc := range []struct {
+		action   string
+		template string
+	}{
+		{ActionMint, `not json`},
+		{ActionMint, `{{ .Value.missing.field }}`},
+		{ActionMint, `{"amount": true}`},
+		{ActionInvoke, `{"input": []}`},
+	} {
+		tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": tc.action, "template": tc.template})
+
+		data := newTestData("", `"a string"`)
+		msg := newTestMessage("t1", data)
+		mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+		mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+		mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+		mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{data}, nil, nil)
+		mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return([]*core.Transaction{}, nil, nil)
+		mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(nil)
+
+		processed, err := tr.triggerBatch(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, processed)
+		cleanup()
+	}
+}
+
+func TestTriggerBatchGetEventsFail(t *testing.T) {
+	tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint", "template": "{}"})
+	defer cleanup()
+
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := tr.triggerBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestTriggerBatchGetMessageFail(t *testing.T) {
+	tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint", "template": "{}"})
+	defer cleanup()
+
+	msg := newTestMessage("t1")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := tr.triggerBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestTriggerBatchGetDataFail(t *testing.T) {
+	tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint", "template": "{}"})
+	defer cleanup()
+
+	msg := newTestMessage("t1", newTestData("", "{}"))
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := tr.triggerBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestTriggerBatchGetTransactionsFail(t *testing.T) {
+	tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint", "template": "{}"})
+	defer cleanup()
+
+	msg := newTestMessage("t1")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+	mdi.On("GetTransactions", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := tr.triggerBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestTriggerBatchUpsertOffsetFail(t *testing.T) {
+	tr, mdi, _, _, cleanup := newTestTrigger(t, fftypes.JSONObject{"name": "trigger1", "tag": "t1", "action": "mint", "template": "{}"})
+	defer cleanup()
+
+	msg := newTestMessage("other")
+	mdi.On("GetOffset", mock.Anything, core.OffsetTypeMessageTrigger, "ns1:trigger1").Return(nil, nil)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{newTestEvent(1, msg)}, nil, nil)
+	mdi.On("GetMessageByID", mock.Anything, "ns1", msg.Header.ID).Return(msg, nil)
+	mdi.On("UpsertOffset", mock.Anything, mock.Anything, true).Return(fmt.Errorf("pop"))
+
+	_, err := tr.triggerBatch(context.Background())
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,206 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txcommon
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// AddSagaStep declares the next ordered step of a transaction. If a later step fails permanently,
+// the compensation (when provided) is submitted to undo this step once it has succeeded.
+func (t *transactionHelper) AddSagaStep(ctx context.Context, txID *fftypes.UUID, name string, opID *fftypes.UUID, compensation *core.SagaCompensation) error {
+	t.sagaMux.Lock()
+	defer t.sagaMux.Unlock()
+
+	tx, err := t.GetTransactionByIDCached(ctx, txID)
+	if err != nil {
+		return err
+	}
+	if tx == nil {
+		return i18n.NewError(ctx, coremsgs.MsgTransactionNotFound, txID)
+	}
+
+	saga := copySaga(tx.Saga)
+	if saga.State != core.SagaStateActive {
+		return i18n.NewError(ctx, coremsgs.MsgSagaNotActive, txID, saga.State)
+	}
+	saga.Steps = append(saga.Steps, &core.SagaStep{
+		Name:         name,
+		Operation:    opID,
+		Status:       core.OpStatusInitialized,
+		Compensation: compensation,
+	})
+	return t.persistSaga(ctx, tx, saga)
+}
+
+// SagaProgress is the work that results from applying the status of an operation to the saga of its transaction
+type SagaProgress struct {
+	// Next is the operation of the following step, to be run now that the step before it has succeeded
+	Next *fftypes.UUID
+	// Skipped are the operations of later steps that will never be run, because an earlier step failed permanently
+	Skipped []*fftypes.UUID
+	// Compensations are the operations to submit, in order, to undo the earlier steps that succeeded
+	Compensations []*core.Operation
+}
+
+// UpdateSagaOperation applies the status of a step (or compensation) operation to the saga of a
+// transaction. Steps run one at a time, so when a step succeeds the operation of the next step is
+// returned for the caller to run. When a step fails permanently, the compensation operations for all
+// earlier succeeded steps are built in reverse order and returned for the caller to submit.
+func (t *transactionHelper) UpdateSagaOperation(ctx context.Context, txID, opID *fftypes.UUID, status core.OpStatus, permanent bool) (*SagaProgress, error) {
+	t.sagaMux.Lock()
+	defer t.sagaMux.Unlock()
+
+	progress := &SagaProgress{}
+	tx, err := t.GetTransactionByIDCached(ctx, txID)
+	if err != nil || tx == nil || tx.Saga == nil {
+		return progress, err
+	}
+	saga := copySaga(tx.Saga)
+	step, idx, isCompensation := saga.FindStep(opID)
+	if step == nil || (status == core.OpStatusFailed && !permanent) {
+		// Not part of the saga, or a failure that will be retried automatically
+		return progress, nil
+	}
+	if !isCompensation && step.Status == status {
+		// Duplicate update for a step that has already been applied
+		return progress, nil
+	}
+
+	if isCompensation {
+		step.CompensationStatus = status
+		saga.State = compensationState(saga)
+	} else {
+		step.Status = status
+		switch {
+		case saga.State != core.SagaStateActive:
+			// Late update after compensation started - recorded on the step only
+		case status == core.OpStatusFailed:
+			saga.FailedStep = opID
+			progress.Skipped = skipRemainingSteps(saga, idx)
+			progress.Compensations = t.buildCompensations(tx, saga, idx)
+			saga.State = compensationState(saga)
+			log.L(ctx).Infof("Saga step %d of transaction %s failed permanently - skipping %d steps and submitting %d compensations", idx, txID, len(progress.Skipped), len(progress.Compensations))
+		case allStepsSucceeded(saga):
+			saga.State = core.SagaStateCompleted
+		case idx+1 < len(saga.Steps) && saga.Steps[idx+1].Status == core.OpStatusInitialized:
+			next := saga.Steps[idx+1]
+			next.Status = core.OpStatusPending
+			progress.Next = next.Operation
+		}
+	}
+
+	if err := t.persistSaga(ctx, tx, saga); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+func (t *transactionHelper) buildCompensations(tx *core.Transaction, saga *core.TransactionSaga, failedIdx int) []*core.Operation {
+	compensations := []*core.Operation{}
+	for i := failedIdx - 1; i >= 0; i-- {
+		step := saga.Steps[i]
+		if step.Status != core.OpStatusSucceeded || step.Compensation == nil {
+			continue
+		}
+		now := fftypes.Now()
+		op := &core.Operation{
+			ID:          fftypes.NewUUID(),
+			Namespace:   tx.Namespace,
+			Plugin:      step.Compensation.Plugin,
+			Transaction: tx.ID,
+			Type:        step.Compensation.Type,
+			Status:      core.OpStatusInitialized,
+			Input:       step.Compensation.Input,
+			Created:     now,
+			Updated:     now,
+		}
+		step.CompensationOperation = op.ID
+		step.CompensationStatus = core.OpStatusInitialized
+		compensations = append(compensations, op)
+	}
+	return compensations
+}
+
+func (t *transactionHelper) persistSaga(ctx context.Context, tx *core.Transaction, saga *core.TransactionSaga) error {
+	saga.Updated = fftypes.Now()
+	if err := t.database.UpdateTransactionSaga(ctx, t.namespace, tx.ID, saga); err != nil {
+		return err
+	}
+	// Replace rather than modify the cached transaction, as readers may hold a reference to it
+	updated := *tx
+	updated.Saga = saga
+	t.updateTransactionsCache(&updated)
+	return nil
+}
+
+func copySaga(saga *core.TransactionSaga) *core.TransactionSaga {
+	if saga == nil {
+		return &core.TransactionSaga{State: core.SagaStateActive}
+	}
+	c := *saga
+	c.Steps = make([]*core.SagaStep, len(saga.Steps))
+	for i, step := range saga.Steps {
+		stepCopy := *step
+		c.Steps[i] = &stepCopy
+	}
+	return &c
+}
+
+// skipRemainingSteps marks the steps after a permanently failed step, which have not been started, as failed
+func skipRemainingSteps(saga *core.TransactionSaga, failedIdx int) []*fftypes.UUID {
+	skipped := []*fftypes.UUID{}
+	for _, step := range saga.Steps[failedIdx+1:] {
+		if step.Status == core.OpStatusInitialized {
+			step.Status = core.OpStatusFailed
+			skipped = append(skipped, step.Operation)
+		}
+	}
+	return skipped
+}
+
+func allStepsSucceeded(saga *core.TransactionSaga) bool {
+	for _, step := range saga.Steps {
+		if step.Status != core.OpStatusSucceeded {
+			return false
+		}
+	}
+	return true
+}
+
+func compensationState(saga *core.TransactionSaga) core.SagaState {
+	state := core.SagaStateCompensated
+	for _, step := range saga.Steps {
+		if step.CompensationOperation == nil {
+			continue
+		}
+		switch step.CompensationStatus {
+		case core.OpStatusFailed:
+			return core.SagaStateCompensationFailed
+		case core.OpStatusSucceeded:
+		default:
+			state = core.SagaStateCompensating
+		}
+	}
+	return state
+}
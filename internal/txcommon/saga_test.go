@@ -0,0 +1,309 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSagaTransaction(txHelper *testTransactionHelper, saga *core.TransactionSaga) *core.Transaction {
+	tx := &core.Transaction{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Type:      core.TransactionTypeContractInvoke,
+		Saga:      saga,
+	}
+	txHelper.updateTransactionsCache(tx)
+	return tx
+}
+
+func TestAddSagaSteps(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	tx := newTestSagaTransaction(txHelper, nil)
+
+	op1 := fftypes.NewUUID()
+	op2 := fftypes.NewUUID()
+	compensation := &core.SagaCompensation{Type: core.OpTypeBlockchainInvoke, Plugin: "ethereum", Input: fftypes.JSONObject{"method": "undo"}}
+	txHelper.mdi.On("UpdateTransactionSaga", ctx, "ns1", tx.ID, mock.Anything).Return(nil)
+
+	err := txHelper.AddSagaStep(ctx, tx.ID, "step1", op1, compensation)
+	assert.NoError(t, err)
+	err = txHelper.AddSagaStep(ctx, tx.ID, "step2", op2, nil)
+	assert.NoError(t, err)
+
+	cached, err := txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, core.SagaStateActive, cached.Saga.State)
+	assert.Len(t, cached.Saga.Steps, 2)
+	assert.Equal(t, op1, cached.Saga.Steps[0].Operation)
+	assert.Equal(t, compensation, cached.Saga.Steps[0].Compensation)
+	assert.Equal(t, core.OpStatusInitialized, cached.Saga.Steps[1].Status)
+	assert.Nil(t, tx.Saga)
+}
+
+func TestAddSagaStepTxNotFound(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	txID := fftypes.NewUUID()
+
+	txHelper.mdi.On("GetTransactionByID", ctx, "ns1", txID).Return(nil, nil)
+
+	err := txHelper.AddSagaStep(ctx, txID, "step1", fftypes.NewUUID(), nil)
+	assert.Regexp(t, "FF10505", err)
+}
+
+func TestAddSagaStepTxLookupFail(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	txID := fftypes.NewUUID()
+
+	txHelper.mdi.On("GetTransactionByID", ctx, "ns1", txID).Return(nil, fmt.Errorf("pop"))
+
+	err := txHelper.AddSagaStep(ctx, txID, "step1", fftypes.NewUUID(), nil)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAddSagaStepNotActive(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	tx := newTestSagaTransaction(txHelper, &core.TransactionSaga{State: core.SagaStateCompensating})
+
+	err := txHelper.AddSagaStep(ctx, tx.ID, "step1", fftypes.NewUUID(), nil)
+	assert.Regexp(t, "FF10506", err)
+}
+
+func TestAddSagaStepPersistFail(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	tx := newTestSagaTransaction(txHelper, nil)
+
+	txHelper.mdi.On("UpdateTransactionSaga", ctx, "ns1", tx.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := txHelper.AddSagaStep(ctx, tx.ID, "step1", fftypes.NewUUID(), nil)
+	assert.EqualError(t, err, "pop")
+
+	cached, _ := txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Nil(t, cached.Saga)
+}
+
+func TestUpdateSagaOperationCompleted(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	op1 := fftypes.NewUUID()
+	op2 := fftypes.NewUUID()
+	tx := newTestSagaTransaction(txHelper, &core.TransactionSaga{
+		State: core.SagaStateActive,
+		Steps: []*core.SagaStep{
+			{Operation: op1, Status: core.OpStatusInitialized},
+			{Operation: op2, Status: core.OpStatusInitialized},
+		},
+	})
+
+	txHelper.mdi.On("UpdateTransactionSaga", ctx, "ns1", tx.ID, mock.Anything).Return(nil)
+
+	progress, err := txHelper.UpdateSagaOperation(ctx, tx.ID, op1, core.OpStatusSucceeded, true)
+	assert.NoError(t, err)
+	assert.Empty(t, progress.Compensations)
+	assert.Equal(t, op2, progress.Next)
+	cached, _ := txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Equal(t, core.SagaStateActive, cached.Saga.State)
+	assert.Equal(t, core.OpStatusPending, cached.Saga.Steps[1].Status)
+
+	// A duplicate update does not start the next step again
+	progress, err = txHelper.UpdateSagaOperation(ctx, tx.ID, op1, core.OpStatusSucceeded, true)
+	assert.NoError(t, err)
+	assert.Nil(t, progress.Next)
+
+	progress, err = txHelper.UpdateSagaOperation(ctx, tx.ID, op2, core.OpStatusSucceeded, true)
+	assert.NoError(t, err)
+	assert.Empty(t, progress.Compensations)
+	assert.Nil(t, progress.Next)
+	cached, _ = txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Equal(t, core.SagaStateCompleted, cached.Saga.State)
+	assert.NotNil(t, cached.Saga.Updated)
+}
+
+func TestUpdateSagaOperationCompensate(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	op1 := fftypes.NewUUID()
+	op2 := fftypes.NewUUID()
+	op3 := fftypes.NewUUID()
+	op4 := fftypes.NewUUID()
+	tx := newTestSagaTransaction(txHelper, &core.TransactionSaga{
+		State: core.SagaStateActive,
+		Steps: []*core.SagaStep{
+			{Operation: op1, Status: core.OpStatusSucceeded, Compensation: &core.SagaCompensation{Type: core.OpTypeBlockchainInvoke, Plugin: "ethereum", Input: fftypes.JSONObject{"step": "1"}}},
+			{Operation: op2, Status: core.OpStatusSucceeded, Compensation: &core.SagaCompensation{Type: core.OpTypeTokenTransfer, Plugin: "erc1155", Input: fftypes.JSONObject{"step": "2"}}},
+			{Operation: op3, Status: core.OpStatusPending},
+			{Operation: op4, Status: core.OpStatusInitialized},
+		},
+	})
+
+	txHelper.mdi.On("UpdateTransactionSaga", ctx, "ns1", tx.ID, mock.Anything).Return(nil)
+
+	// A failure that will be retried automatically has no effect
+	progress, err := txHelper.UpdateSagaOperation(ctx, tx.ID, op3, core.OpStatusFailed, false)
+	assert.NoError(t, err)
+	assert.Empty(t, progress.Compensations)
+	assert.Empty(t, progress.Skipped)
+
+	progress, err = txHelper.UpdateSagaOperation(ctx, tx.ID, op3, core.OpStatusFailed, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []*fftypes.UUID{op4}, progress.Skipped)
+	compensations := progress.Compensations
+	assert.Len(t, compensations, 2)
+	assert.Equal(t, core.OpTypeTokenTransfer, compensations[0].Type)
+	assert.Equal(t, "erc1155", compensations[0].Plugin)
+	assert.Equal(t, tx.ID, compensations[0].Transaction)
+	assert.Equal(t, core.OpStatusInitialized, compensations[0].Status)
+	assert.Equal(t, "2", compensations[0].Input.GetString("step"))
+	assert.Equal(t, core.OpTypeBlockchainInvoke, compensations[1].Type)
+
+	cached, _ := txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Equal(t, core.SagaStateCompensating, cached.Saga.State)
+	assert.Equal(t, op3, cached.Saga.FailedStep)
+	assert.Equal(t, compensations[1].ID, cached.Saga.Steps[0].CompensationOperation)
+	assert.Equal(t, compensations[0].ID, cached.Saga.Steps[1].CompensationOperation)
+	assert.Equal(t, core.OpStatusFailed, cached.Saga.Steps[3].Status)
+
+	// The failure of the skipped step is recorded without further effect
+	progress, err = txHelper.UpdateSagaOperation(ctx, tx.ID, op4, core.OpStatusFailed, true)
+	assert.NoError(t, err)
+	assert.Empty(t, progress.Compensations)
+
+	_, err = txHelper.UpdateSagaOperation(ctx, tx.ID, compensations[0].ID, core.OpStatusSucceeded, true)
+	assert.NoError(t, err)
+	cached, _ = txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Equal(t, core.SagaStateCompensating, cached.Saga.State)
+
+	_, err = txHelper.UpdateSagaOperation(ctx, tx.ID, compensations[1].ID, core.OpStatusSucceeded, true)
+	assert.NoError(t, err)
+	cached, _ = txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Equal(t, core.SagaStateCompensated, cached.Saga.State)
+}
+
+func TestUpdateSagaOperationCompensationFailed(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	op1 := fftypes.NewUUID()
+	comp1 := fftypes.NewUUID()
+	tx := newTestSagaTransaction(txHelper, &core.TransactionSaga{
+		State: core.SagaStateCompensating,
+		Steps: []*core.SagaStep{
+			{Operation: op1, Status: core.OpStatusSucceeded, CompensationOperation: comp1, CompensationStatus: core.OpStatusInitialized},
+			{Operation: fftypes.NewUUID(), Status: core.OpStatusFailed},
+		},
+	})
+
+	txHelper.mdi.On("UpdateTransactionSaga", ctx, "ns1", tx.ID, mock.Anything).Return(nil)
+
+	_, err := txHelper.UpdateSagaOperation(ctx, tx.ID, comp1, core.OpStatusFailed, true)
+	assert.NoError(t, err)
+	cached, _ := txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Equal(t, core.SagaStateCompensationFailed, cached.Saga.State)
+}
+
+func TestUpdateSagaOperationNoCompensations(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	op1 := fftypes.NewUUID()
+	tx := newTestSagaTransaction(txHelper, &core.TransactionSaga{
+		State: core.SagaStateActive,
+		Steps: []*core.SagaStep{
+			{Operation: op1, Status: core.OpStatusPending},
+		},
+	})
+
+	txHelper.mdi.On("UpdateTransactionSaga", ctx, "ns1", tx.ID, mock.Anything).Return(nil)
+
+	progress, err := txHelper.UpdateSagaOperation(ctx, tx.ID, op1, core.OpStatusFailed, true)
+	assert.NoError(t, err)
+	assert.Empty(t, progress.Compensations)
+	cached, _ := txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Equal(t, core.SagaStateCompensated, cached.Saga.State)
+}
+
+func TestUpdateSagaOperationLateUpdate(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	op1 := fftypes.NewUUID()
+	tx := newTestSagaTransaction(txHelper, &core.TransactionSaga{
+		State: core.SagaStateCompensated,
+		Steps: []*core.SagaStep{
+			{Operation: op1, Status: core.OpStatusPending},
+		},
+	})
+
+	txHelper.mdi.On("UpdateTransactionSaga", ctx, "ns1", tx.ID, mock.Anything).Return(nil)
+
+	_, err := txHelper.UpdateSagaOperation(ctx, tx.ID, op1, core.OpStatusSucceeded, true)
+	assert.NoError(t, err)
+	cached, _ := txHelper.GetTransactionByIDCached(ctx, tx.ID)
+	assert.Equal(t, core.SagaStateCompensated, cached.Saga.State)
+	assert.Equal(t, core.OpStatusSucceeded, cached.Saga.Steps[0].Status)
+}
+
+func TestUpdateSagaOperationNotInSaga(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	noSaga := newTestSagaTransaction(txHelper, nil)
+	withSaga := newTestSagaTransaction(txHelper, &core.TransactionSaga{State: core.SagaStateActive})
+
+	progress, err := txHelper.UpdateSagaOperation(ctx, noSaga.ID, fftypes.NewUUID(), core.OpStatusFailed, true)
+	assert.NoError(t, err)
+	assert.Nil(t, progress.Compensations)
+
+	progress, err = txHelper.UpdateSagaOperation(ctx, withSaga.ID, fftypes.NewUUID(), core.OpStatusFailed, true)
+	assert.NoError(t, err)
+	assert.Nil(t, progress.Compensations)
+}
+
+func TestUpdateSagaOperationPersistFail(t *testing.T) {
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+	op1 := fftypes.NewUUID()
+	tx := newTestSagaTransaction(txHelper, &core.TransactionSaga{
+		State: core.SagaStateActive,
+		Steps: []*core.SagaStep{{Operation: op1}},
+	})
+
+	txHelper.mdi.On("UpdateTransactionSaga", ctx, "ns1", tx.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := txHelper.UpdateSagaOperation(ctx, tx.ID, op1, core.OpStatusSucceeded, true)
+	assert.EqualError(t, err, "pop")
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"strings"
+	"sync"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
@@ -29,20 +30,24 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/database/sqlcommon"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 )
 
 type Helper interface {
-	SubmitNewTransaction(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey) (*fftypes.UUID, error)
+	SubmitNewTransaction(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey, labels ...string) (*fftypes.UUID, error)
 	SubmitNewTransactionBatch(ctx context.Context, namespace string, batch []*BatchedTransactionInsert) error
 	PersistTransaction(ctx context.Context, id *fftypes.UUID, txType core.TransactionType, blockchainTXID string) (valid bool, err error)
 	AddBlockchainTX(ctx context.Context, tx *core.Transaction, blockchainTXID string) error
 	InsertOrGetBlockchainEvent(ctx context.Context, event *core.BlockchainEvent) (existing *core.BlockchainEvent, err error)
 	InsertNewBlockchainEvents(ctx context.Context, events []*core.BlockchainEvent) (inserted []*core.BlockchainEvent, err error)
 	GetTransactionByIDCached(ctx context.Context, id *fftypes.UUID) (*core.Transaction, error)
+	EvictTransactionsCached(ids []*fftypes.UUID)
 	GetBlockchainEventByIDCached(ctx context.Context, id *fftypes.UUID) (*core.BlockchainEvent, error)
 	FindOperationInTransaction(ctx context.Context, tx *fftypes.UUID, opType core.OpType) (*core.Operation, error)
+	AddSagaStep(ctx context.Context, txID *fftypes.UUID, name string, opID *fftypes.UUID, compensation *core.SagaCompensation) error
+	UpdateSagaOperation(ctx context.Context, txID, opID *fftypes.UUID, status core.OpStatus, permanent bool) (*SagaProgress, error)
 }
 
 type transactionHelper struct {
@@ -51,6 +56,7 @@ type transactionHelper struct {
 	data                 data.Manager
 	transactionCache     cache.CInterface
 	blockchainEventCache cache.CInterface
+	sagaMux              sync.Mutex
 }
 
 type BatchedTransactionInsert struct {
@@ -64,6 +70,8 @@ type BatchedTransactionInsert struct {
 type TransactionInsertInput struct {
 	Type           core.TransactionType
 	IdempotencyKey core.IdempotencyKey
+	Labels         []string
+	CorrelationID  string
 }
 
 func NewTransactionHelper(ctx context.Context, ns string, di database.Plugin, dm data.Manager, cacheManager cache.Manager) (Helper, error) {
@@ -121,14 +129,25 @@ func (t *transactionHelper) GetTransactionByIDCached(ctx context.Context, id *ff
 	return tx, nil
 }
 
-// SubmitNewTransaction is called when there is a new transaction being submitted by the local node
-func (t *transactionHelper) SubmitNewTransaction(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey) (*fftypes.UUID, error) {
+// EvictTransactionsCached removes transactions that have been updated in the database outside of this helper,
+// such as by releasing their idempotency keys, so the next lookup reads them from the database
+func (t *transactionHelper) EvictTransactionsCached(ids []*fftypes.UUID) {
+	for _, id := range ids {
+		t.transactionCache.Delete(id.String())
+	}
+}
+
+// SubmitNewTransaction is called when there is a new transaction being submitted by the local node.
+// Any labels supplied by the submitter are persisted on the transaction, for use in queries and event filters.
+func (t *transactionHelper) SubmitNewTransaction(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey, labels ...string) (*fftypes.UUID, error) {
 
 	tx := &core.Transaction{
 		ID:             fftypes.NewUUID(),
 		Namespace:      t.namespace,
 		Type:           txType,
 		IdempotencyKey: idempotencyKey,
+		Labels:         labels,
+		CorrelationID:  tracing.GetCorrelationID(ctx),
 	}
 
 	// Note that InsertTransaction is responsible for idempotency key duplicate detection and helpful error creation.
@@ -161,6 +180,8 @@ func (t *transactionHelper) SubmitNewTransactionBatch(ctx context.Context, names
 			Namespace:      namespace,
 			Type:           t.Input.Type,
 			IdempotencyKey: t.Input.IdempotencyKey,
+			Labels:         t.Input.Labels,
+			CorrelationID:  t.Input.CorrelationID,
 		}
 		if t.Input.IdempotencyKey == "" {
 			plainTxInserts = append(plainTxInserts, t.Output.Transaction)
@@ -274,6 +295,7 @@ func (t *transactionHelper) PersistTransaction(ctx context.Context, id *fftypes.
 	}
 
 	t.updateTransactionsCache(tx)
+	cache.Changed(ctx, t.transactionCache, tx.ID.String())
 	return true, nil
 }
 
@@ -293,6 +315,7 @@ func (t *transactionHelper) AddBlockchainTX(ctx context.Context, tx *core.Transa
 	}
 
 	t.updateTransactionsCache(tx)
+	cache.Changed(ctx, t.transactionCache, tx.ID.String())
 	return nil
 }
 
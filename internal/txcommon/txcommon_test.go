@@ -28,6 +28,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/mocks/cachemocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
@@ -52,18 +53,17 @@ func (tth *testTransactionHelper) cleanup(t *testing.T) {
 func NewTestTransactionHelper() (*testTransactionHelper, cache.CInterface, cache.CInterface) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
-	t := transactionHelper{
-		namespace: "ns1",
-		database:  mdi,
-		data:      mdm,
+	tth := &testTransactionHelper{
+		mdi: mdi,
+		mdm: mdm,
 	}
+	t := &tth.transactionHelper
+	t.namespace = "ns1"
+	t.database = mdi
+	t.data = mdm
 	t.transactionCache = cache.NewUmanagedCache(context.Background(), config.GetByteSize(coreconfig.CacheTransactionSize), config.GetDuration(coreconfig.CacheTransactionTTL))
 	t.blockchainEventCache = cache.NewUmanagedCache(context.Background(), config.GetByteSize(coreconfig.CacheBlockchainEventLimit), config.GetDuration(coreconfig.CacheBlockchainEventTTL))
-	return &testTransactionHelper{
-		transactionHelper: t,
-		mdi:               mdi,
-		mdm:               mdm,
-	}, t.transactionCache, t.blockchainEventCache
+	return tth, t.transactionCache, t.blockchainEventCache
 }
 
 func TestSubmitNewTransactionOK(t *testing.T) {
@@ -96,6 +96,48 @@ func TestSubmitNewTransactionOK(t *testing.T) {
 
 }
 
+func TestSubmitNewTransactionWithLabels(t *testing.T) {
+
+	mdi := &databasemocks.Plugin{}
+	mdm := &datamocks.Manager{}
+	ctx := context.Background()
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	txHelper, _ := NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
+
+	mdi.On("InsertTransaction", ctx, mock.MatchedBy(func(transaction *core.Transaction) bool {
+		return transaction.Labels.String() == "payroll,quarter-end"
+	})).Return(nil)
+	mdi.On("InsertEvent", ctx, mock.Anything).Return(nil)
+
+	_, err := txHelper.SubmitNewTransaction(ctx, core.TransactionTypeTokenTransfer, "", "payroll", "quarter-end")
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+
+}
+
+func TestSubmitNewTransactionWithCorrelationID(t *testing.T) {
+
+	mdi := &databasemocks.Plugin{}
+	mdm := &datamocks.Manager{}
+	ctx := tracing.WithCorrelationID(context.Background(), "esb-1234")
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	txHelper, _ := NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
+
+	mdi.On("InsertTransaction", ctx, mock.MatchedBy(func(transaction *core.Transaction) bool {
+		return transaction.CorrelationID == "esb-1234"
+	})).Return(nil)
+	mdi.On("InsertEvent", ctx, mock.Anything).Return(nil)
+
+	_, err := txHelper.SubmitNewTransaction(ctx, core.TransactionTypeTokenTransfer, "")
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+
+}
+
 func TestSubmitNewTransactionFail(t *testing.T) {
 
 	mdi := &databasemocks.Plugin{}
@@ -490,6 +532,35 @@ func TestGetTransactionByIDCached(t *testing.T) {
 
 }
 
+func TestEvictTransactionsCached(t *testing.T) {
+
+	txHelper, _, _ := NewTestTransactionHelper()
+	defer txHelper.cleanup(t)
+	ctx := context.Background()
+
+	txid := fftypes.NewUUID()
+	txHelper.mdi.On("GetTransactionByID", ctx, "ns1", txid).Return(&core.Transaction{
+		ID:             txid,
+		Namespace:      "ns1",
+		IdempotencyKey: "idem1",
+	}, nil).Once()
+	txHelper.mdi.On("GetTransactionByID", ctx, "ns1", txid).Return(&core.Transaction{
+		ID:        txid,
+		Namespace: "ns1",
+	}, nil).Once()
+
+	tx, err := txHelper.GetTransactionByIDCached(ctx, txid)
+	assert.NoError(t, err)
+	assert.Equal(t, core.IdempotencyKey("idem1"), tx.IdempotencyKey)
+
+	// once evicted, the released key is read back from the database
+	txHelper.EvictTransactionsCached([]*fftypes.UUID{txid})
+	tx, err = txHelper.GetTransactionByIDCached(ctx, txid)
+	assert.NoError(t, err)
+	assert.Empty(t, tx.IdempotencyKey)
+
+}
+
 func TestGetTransactionByIDCachedFail(t *testing.T) {
 
 	txHelper, _, _ := NewTestTransactionHelper()
@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/internal/tracing"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -34,7 +35,7 @@ import (
 
 type Writer interface {
 	Start()
-	WriteTransactionAndOps(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey, operations ...*core.Operation) (*core.Transaction, error)
+	WriteTransactionAndOps(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey, labels []string, operations ...*core.Operation) (*core.Transaction, error)
 	Close()
 }
 
@@ -45,6 +46,8 @@ type Writer interface {
 type request struct {
 	txType         core.TransactionType
 	idempotencyKey core.IdempotencyKey
+	labels         []string
+	correlationID  string
 	operations     []*core.Operation
 	result         chan *result
 }
@@ -101,10 +104,12 @@ func NewTransactionWriter(ctx context.Context, ns string, di database.Plugin, tx
 	return tw
 }
 
-func (tw *txWriter) WriteTransactionAndOps(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey, operations ...*core.Operation) (*core.Transaction, error) {
+func (tw *txWriter) WriteTransactionAndOps(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey, labels []string, operations ...*core.Operation) (*core.Transaction, error) {
 	req := &request{
 		txType:         txType,
 		idempotencyKey: idempotencyKey,
+		labels:         labels,
+		correlationID:  tracing.GetCorrelationID(ctx),
 		operations:     operations,
 		result:         make(chan *result, 1), // allocate a slot for the result to avoid blocking
 	}
@@ -192,6 +197,8 @@ func (tw *txWriter) processBatch(ctx context.Context, batch *txWriterBatch) erro
 			Input: txcommon.TransactionInsertInput{
 				Type:           req.txType,
 				IdempotencyKey: req.idempotencyKey,
+				Labels:         req.labels,
+				CorrelationID:  req.correlationID,
 			},
 		}
 	}
@@ -211,6 +218,7 @@ func (tw *txWriter) processBatch(ctx context.Context, batch *txWriterBatch) erro
 			// Set the transaction ID on all ops, and add to list for insertion
 			for _, op := range req.operations {
 				op.Transaction = txn.ID
+				op.CorrelationID = req.correlationID
 				operations = append(operations, op)
 			}
 		}
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/stretchr/testify/assert"
@@ -49,14 +50,17 @@ func newTestTransactionWriter(t *testing.T, dbCaps *database.Capabilities, mods
 		mrag.Return(fn(ctx))
 	}).Maybe()
 	mdm := &datamocks.Manager{}
-	cm := cache.NewCacheManager(ctx)
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
+	cm, err := cache.NewCacheManager(ctx, mmi)
+	assert.NoError(t, err)
 	for _, mod := range mods {
 		mod()
 	}
 
 	txh, err := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cm)
 	assert.NoError(t, err)
-	ops, err := operations.NewOperationsManager(ctx, "ns1", mdi, txh, cm)
+	ops, err := operations.NewOperationsManager(ctx, "ns1", mdi, txh, mmi, cm, false)
 	assert.NoError(t, err)
 	txw := NewTransactionWriter(ctx, "ns1", mdi, txh, ops).(*txWriter)
 	return ctx, txw, func() {
@@ -78,7 +82,7 @@ func TestWriteNewTransactionClosed(t *testing.T) {
 	_, txw, done := newTestTransactionWriter(t, &database.Capabilities{Concurrency: true})
 	done()
 	// Write under background context, but the write context is closed
-	_, err := txw.WriteTransactionAndOps(context.Background(), core.TransactionTypeContractInvoke, "")
+	_, err := txw.WriteTransactionAndOps(context.Background(), core.TransactionTypeContractInvoke, "", nil)
 	assert.Regexp(t, "FF00154", err)
 }
 
@@ -88,7 +92,7 @@ func TestWriteNewTransactionInputContextClosed(t *testing.T) {
 	// Write under background context, but the write context is closed
 	cancelledCtx, cancelContext := context.WithCancel(context.Background())
 	cancelContext()
-	_, err := txw.WriteTransactionAndOps(cancelledCtx, core.TransactionTypeContractInvoke, "")
+	_, err := txw.WriteTransactionAndOps(cancelledCtx, core.TransactionTypeContractInvoke, "", nil)
 	assert.Regexp(t, "FF00154", err)
 }
 
@@ -101,7 +105,7 @@ func TestBatchOfOneSequentialSuccess(t *testing.T) {
 	inputOpID := fftypes.NewUUID()
 	mdi := txw.database.(*databasemocks.Plugin)
 	mdi.On("InsertTransactions", mock.Anything, mock.MatchedBy(func(txns []*core.Transaction) bool {
-		return len(txns) == 1
+		return len(txns) == 1 && txns[0].Labels.String() == "payroll"
 	})).Return(nil)
 	mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
 		return event.Type.Equals(core.EventTypeTransactionSubmitted)
@@ -110,7 +114,7 @@ func TestBatchOfOneSequentialSuccess(t *testing.T) {
 		return len(ops) == 1 && ops[0].ID.Equals(inputOpID)
 	})).Return(nil)
 
-	tx, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", &core.Operation{
+	tx, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", []string{"payroll"}, &core.Operation{
 		ID: inputOpID,
 	})
 	assert.NoError(t, err)
@@ -141,7 +145,7 @@ func TestBatchOfOneAsyncSuccess(t *testing.T) {
 	op := &core.Operation{
 		ID: inputOpID,
 	}
-	tx, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", op)
+	tx, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", nil, op)
 	assert.NoError(t, err)
 	assert.NotNil(t, tx)
 	assert.NotNil(t, tx.ID)                // generated for us
@@ -167,7 +171,7 @@ func TestBatchOfOneInsertOpFail(t *testing.T) {
 		return len(ops) == 1 && ops[0].ID.Equals(inputOpID)
 	})).Return(fmt.Errorf("pop"))
 
-	_, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", &core.Operation{
+	_, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", nil, &core.Operation{
 		ID: inputOpID,
 	})
 	assert.Regexp(t, "pop", err)
@@ -186,7 +190,7 @@ func TestInsertTxNonIdempotentFail(t *testing.T) {
 		return len(txns) == 1
 	})).Return(fmt.Errorf("pop"))
 
-	_, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", &core.Operation{
+	_, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", nil, &core.Operation{
 		ID: inputOpID,
 	})
 	assert.Regexp(t, "pop", err)
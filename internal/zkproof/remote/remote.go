@@ -0,0 +1,115 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/zkproof"
+)
+
+// Remote delegates proof generation and verification to a proof service over HTTP. The service is
+// responsible for the circuits, and for any proving and verification keys they need.
+type Remote struct {
+	ctx          context.Context
+	capabilities *zkproof.Capabilities
+	client       *resty.Client
+}
+
+type proveRequest struct {
+	Circuit       string           `json:"circuit"`
+	PublicInputs  *fftypes.JSONAny `json:"publicInputs,omitempty"`
+	PrivateInputs *fftypes.JSONAny `json:"privateInputs,omitempty"`
+}
+
+type proveResponse struct {
+	Proof *fftypes.JSONAny `json:"proof"`
+}
+
+type verifyRequest struct {
+	Circuit      string           `json:"circuit"`
+	PublicInputs *fftypes.JSONAny `json:"publicInputs,omitempty"`
+	Proof        *fftypes.JSONAny `json:"proof"`
+}
+
+type verifyResponse struct {
+	Valid bool `json:"valid"`
+}
+
+func (r *Remote) Name() string {
+	return "remote"
+}
+
+func (r *Remote) Init(ctx context.Context, config config.Section) (err error) {
+	r.ctx = log.WithLogField(ctx, "zkproof", "remote")
+
+	if config.GetString(ffresty.HTTPConfigURL) == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(ffresty.HTTPConfigURL), "remote")
+	}
+	r.client, err = ffresty.New(r.ctx, config)
+	if err != nil {
+		return err
+	}
+	r.capabilities = &zkproof.Capabilities{}
+	return nil
+}
+
+func (r *Remote) Capabilities() *zkproof.Capabilities {
+	return r.capabilities
+}
+
+func (r *Remote) GenerateProof(ctx context.Context, circuit string, publicInputs, privateInputs *fftypes.JSONAny) (*fftypes.JSONAny, error) {
+	var proved proveResponse
+	res, err := r.client.R().SetContext(ctx).
+		SetBody(&proveRequest{
+			Circuit:       circuit,
+			PublicInputs:  publicInputs,
+			PrivateInputs: privateInputs,
+		}).
+		SetResult(&proved).
+		Post("/prove")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgZKProofRequestFailed)
+	}
+	if proved.Proof.IsNil() {
+		return nil, i18n.NewError(ctx, coremsgs.MsgZKProofEmpty, circuit)
+	}
+	return proved.Proof, nil
+}
+
+func (r *Remote) VerifyProof(ctx context.Context, circuit string, publicInputs, proof *fftypes.JSONAny) (bool, error) {
+	var verified verifyResponse
+	res, err := r.client.R().SetContext(ctx).
+		SetBody(&verifyRequest{
+			Circuit:      circuit,
+			PublicInputs: publicInputs,
+			Proof:        proof,
+		}).
+		SetResult(&verified).
+		Post("/verify")
+	if err != nil || !res.IsSuccess() {
+		return false, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgZKProofRequestFailed)
+	}
+	return verified.Valid, nil
+}
@@ -0,0 +1,129 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("zkproof_remote_unit_tests")
+
+func newTestRemote(t *testing.T, handler http.HandlerFunc) *Remote {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	coreconfig.Reset()
+	r := &Remote{}
+	r.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, server.URL)
+	err := r.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	assert.NotNil(t, r.Capabilities())
+	assert.Equal(t, "remote", r.Name())
+	return r
+}
+
+func TestGenerateProof(t *testing.T) {
+	r := newTestRemote(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/prove", req.URL.Path)
+		var body proveRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, "range", body.Circuit)
+		assert.JSONEq(t, `{"limit":100}`, body.PublicInputs.String())
+		assert.JSONEq(t, `[{"amount":50}]`, body.PrivateInputs.String())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&proveResponse{Proof: fftypes.JSONAnyPtr(`{"pi_a":["1","2"]}`)})
+	})
+
+	proof, err := r.GenerateProof(context.Background(), "range", fftypes.JSONAnyPtr(`{"limit":100}`), fftypes.JSONAnyPtr(`[{"amount":50}]`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"pi_a":["1","2"]}`, proof.String())
+}
+
+func TestGenerateProofEmpty(t *testing.T) {
+	r := newTestRemote(t, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	_, err := r.GenerateProof(context.Background(), "range", nil, nil)
+	assert.Regexp(t, "FF10588", err)
+}
+
+func TestGenerateProofFail(t *testing.T) {
+	r := newTestRemote(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := r.GenerateProof(context.Background(), "range", nil, nil)
+	assert.Regexp(t, "FF10587", err)
+}
+
+func TestVerifyProof(t *testing.T) {
+	r := newTestRemote(t, func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/verify", req.URL.Path)
+		var body verifyRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, "range", body.Circuit)
+		assert.JSONEq(t, `{"pi_a":["1","2"]}`, body.Proof.String())
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&verifyResponse{Valid: true})
+	})
+
+	valid, err := r.VerifyProof(context.Background(), "range", fftypes.JSONAnyPtr(`{"limit":100}`), fftypes.JSONAnyPtr(`{"pi_a":["1","2"]}`))
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestVerifyProofFail(t *testing.T) {
+	r := newTestRemote(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	_, err := r.VerifyProof(context.Background(), "range", nil, fftypes.JSONAnyPtr(`{}`))
+	assert.Regexp(t, "FF10587", err)
+}
+
+func TestInitMissingURL(t *testing.T) {
+	coreconfig.Reset()
+	r := &Remote{}
+	r.InitConfig(utConfig)
+	err := r.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestInitBadTLS(t *testing.T) {
+	coreconfig.Reset()
+	r := &Remote{}
+	r.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, "https://prover.example.com")
+	tlsConf := utConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := r.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
@@ -663,17 +663,17 @@ func (_m *Manager) RunOperation(ctx context.Context, op *core.PreparedOperation)
 	return r0, r1, r2
 }
 
-// Start provides a mock function with given fields:
-func (_m *Manager) Start() error {
-	ret := _m.Called()
+// StartConnector provides a mock function with given fields: name
+func (_m *Manager) StartConnector(name string) error {
+	ret := _m.Called(name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Start")
+		panic("no return value specified for StartConnector")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -305,6 +305,36 @@ func (_m *Plugin) GetAndConvertDeprecatedContractConfig(ctx context.Context) (*f
 	return r0, r1, r2
 }
 
+// GetContractListenerCheckpoint provides a mock function with given fields: ctx, namespace, subID
+func (_m *Plugin) GetContractListenerCheckpoint(ctx context.Context, namespace string, subID string) (*blockchain.ListenerCheckpoint, error) {
+	ret := _m.Called(ctx, namespace, subID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractListenerCheckpoint")
+	}
+
+	var r0 *blockchain.ListenerCheckpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*blockchain.ListenerCheckpoint, error)); ok {
+		return rf(ctx, namespace, subID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *blockchain.ListenerCheckpoint); ok {
+		r0 = rf(ctx, namespace, subID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*blockchain.ListenerCheckpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, subID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetContractListenerStatus provides a mock function with given fields: ctx, namespace, subID, okNotFound
 func (_m *Plugin) GetContractListenerStatus(ctx context.Context, namespace string, subID string, okNotFound bool) (bool, interface{}, fftypes.FFEnum, error) {
 	ret := _m.Called(ctx, namespace, subID, okNotFound)
@@ -407,6 +437,34 @@ func (_m *Plugin) GetNetworkVersion(ctx context.Context, location *fftypes.JSONA
 	return r0, r1
 }
 
+// GetProtocolIDBlock provides a mock function with given fields: ctx, protocolID
+func (_m *Plugin) GetProtocolIDBlock(ctx context.Context, protocolID string) (int64, error) {
+	ret := _m.Called(ctx, protocolID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProtocolIDBlock")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, protocolID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, protocolID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, protocolID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionStatus provides a mock function with given fields: ctx, operation
 func (_m *Plugin) GetTransactionStatus(ctx context.Context, operation *core.Operation) (interface{}, error) {
 	ret := _m.Called(ctx, operation)
@@ -536,6 +594,45 @@ func (_m *Plugin) NormalizeContractLocation(ctx context.Context, ntype blockchai
 	return r0, r1
 }
 
+// ParseBatchPinEvent provides a mock function with given fields: ctx, event
+func (_m *Plugin) ParseBatchPinEvent(ctx context.Context, event *core.BlockchainEvent) (*blockchain.BatchPin, *core.VerifierRef, error) {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseBatchPinEvent")
+	}
+
+	var r0 *blockchain.BatchPin
+	var r1 *core.VerifierRef
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.BlockchainEvent) (*blockchain.BatchPin, *core.VerifierRef, error)); ok {
+		return rf(ctx, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.BlockchainEvent) *blockchain.BatchPin); ok {
+		r0 = rf(ctx, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*blockchain.BatchPin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.BlockchainEvent) *core.VerifierRef); ok {
+		r1 = rf(ctx, event)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*core.VerifierRef)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *core.BlockchainEvent) error); ok {
+		r2 = rf(ctx, event)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ParseInterface provides a mock function with given fields: ctx, method, errors
 func (_m *Plugin) ParseInterface(ctx context.Context, method *fftypes.FFIMethod, errors []*fftypes.FFIError) (interface{}, error) {
 	ret := _m.Called(ctx, method, errors)
@@ -601,6 +698,24 @@ func (_m *Plugin) RemoveFireflySubscription(ctx context.Context, subID string) {
 	_m.Called(ctx, subID)
 }
 
+// ResetContractListener provides a mock function with given fields: ctx, namespace, subID, fromBlock
+func (_m *Plugin) ResetContractListener(ctx context.Context, namespace string, subID string, fromBlock int64) error {
+	ret := _m.Called(ctx, namespace, subID, fromBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetContractListener")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) error); ok {
+		r0 = rf(ctx, namespace, subID, fromBlock)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResolveSigningKey provides a mock function with given fields: ctx, keyRef, intent
 func (_m *Plugin) ResolveSigningKey(ctx context.Context, keyRef string, intent blockchain.ResolveKeyIntent) (string, error) {
 	ret := _m.Called(ctx, keyRef, intent)
@@ -693,17 +808,17 @@ func (_m *Plugin) SubmitBatchPin(ctx context.Context, nsOpID string, networkName
 	return r0
 }
 
-// SubmitNetworkAction provides a mock function with given fields: ctx, nsOpID, signingKey, action, location
-func (_m *Plugin) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action fftypes.FFEnum, location *fftypes.JSONAny) error {
-	ret := _m.Called(ctx, nsOpID, signingKey, action, location)
+// SubmitNetworkAction provides a mock function with given fields: ctx, nsOpID, signingKey, action, payload, location
+func (_m *Plugin) SubmitNetworkAction(ctx context.Context, nsOpID string, signingKey string, action fftypes.FFEnum, payload string, location *fftypes.JSONAny) error {
+	ret := _m.Called(ctx, nsOpID, signingKey, action, payload, location)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SubmitNetworkAction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, fftypes.FFEnum, *fftypes.JSONAny) error); ok {
-		r0 = rf(ctx, nsOpID, signingKey, action, location)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, fftypes.FFEnum, string, *fftypes.JSONAny) error); ok {
+		r0 = rf(ctx, nsOpID, signingKey, action, payload, location)
 	} else {
 		r0 = ret.Error(0)
 	}
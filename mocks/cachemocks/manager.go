@@ -3,7 +3,12 @@
 package cachemocks
 
 import (
+	context "context"
+
 	cache "github.com/hyperledger/firefly/internal/cache"
+
+	core "github.com/hyperledger/firefly/pkg/core"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -42,6 +47,62 @@ func (_m *Manager) GetCache(cc *cache.CConfig) (cache.CInterface, error) {
 	return r0, r1
 }
 
+// GetCacheStatus provides a mock function with given fields:
+func (_m *Manager) GetCacheStatus() []*core.CacheStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCacheStatus")
+	}
+
+	var r0 []*core.CacheStatus
+	if rf, ok := ret.Get(0).(func() []*core.CacheStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.CacheStatus)
+		}
+	}
+
+	return r0
+}
+
+// InvalidateCache provides a mock function with given fields: ctx, fqName
+func (_m *Manager) InvalidateCache(ctx context.Context, fqName string) error {
+	ret := _m.Called(ctx, fqName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateCache")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, fqName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateCacheKey provides a mock function with given fields: ctx, fqName, key
+func (_m *Manager) InvalidateCacheKey(ctx context.Context, fqName string, key string) error {
+	ret := _m.Called(ctx, fqName, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateCacheKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, fqName, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ListCacheNames provides a mock function with given fields: namespace
 func (_m *Manager) ListCacheNames(namespace string) []string {
 	ret := _m.Called(namespace)
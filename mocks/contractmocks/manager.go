@@ -739,6 +739,36 @@ func (_m *Manager) InvokeContractAPI(ctx context.Context, apiName string, method
 	return r0, r1
 }
 
+// InvokeContractSequence provides a mock function with given fields: ctx, req
+func (_m *Manager) InvokeContractSequence(ctx context.Context, req *core.ContractInvokeSequenceRequest) (*core.Transaction, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvokeContractSequence")
+	}
+
+	var r0 *core.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractInvokeSequenceRequest) (*core.Transaction, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractInvokeSequenceRequest) *core.Transaction); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.ContractInvokeSequenceRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *Manager) Name() string {
 	ret := _m.Called()
@@ -787,6 +817,24 @@ func (_m *Manager) PrepareOperation(ctx context.Context, op *core.Operation) (*c
 	return r0, r1
 }
 
+// ReconcileContractAPIListeners provides a mock function with given fields: ctx, api
+func (_m *Manager) ReconcileContractAPIListeners(ctx context.Context, api *core.ContractAPI) error {
+	ret := _m.Called(ctx, api)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconcileContractAPIListeners")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractAPI) error); ok {
+		r0 = rf(ctx, api)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResolveContractAPI provides a mock function with given fields: ctx, httpServerURL, api
 func (_m *Manager) ResolveContractAPI(ctx context.Context, httpServerURL string, api *core.ContractAPI) error {
 	ret := _m.Called(ctx, httpServerURL, api)
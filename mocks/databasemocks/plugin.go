@@ -23,6 +23,24 @@ type Plugin struct {
 	mock.Mock
 }
 
+// AddTrafficStats provides a mock function with given fields: ctx, stats
+func (_m *Plugin) AddTrafficStats(ctx context.Context, stats []*core.TrafficStats) error {
+	ret := _m.Called(ctx, stats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTrafficStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.TrafficStats) error); ok {
+		r0 = rf(ctx, stats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Capabilities provides a mock function with given fields:
 func (_m *Plugin) Capabilities() *database.Capabilities {
 	ret := _m.Called()
@@ -43,6 +61,82 @@ func (_m *Plugin) Capabilities() *database.Capabilities {
 	return r0
 }
 
+// ClearIdempotencyKey provides a mock function with given fields: ctx, namespace, key
+func (_m *Plugin) ClearIdempotencyKey(ctx context.Context, namespace string, key core.IdempotencyKey) ([]*fftypes.UUID, error) {
+	ret := _m.Called(ctx, namespace, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearIdempotencyKey")
+	}
+
+	var r0 []*fftypes.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, core.IdempotencyKey) ([]*fftypes.UUID, error)); ok {
+		return rf(ctx, namespace, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, core.IdempotencyKey) []*fftypes.UUID); ok {
+		r0 = rf(ctx, namespace, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*fftypes.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, core.IdempotencyKey) error); ok {
+		r1 = rf(ctx, namespace, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountPrunableRecords provides a mock function with given fields: ctx, namespace, policy
+func (_m *Plugin) CountPrunableRecords(ctx context.Context, namespace string, policy *database.RetentionPolicy) (int64, error) {
+	ret := _m.Called(ctx, namespace, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPrunableRecords")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *database.RetentionPolicy) (int64, error)); ok {
+		return rf(ctx, namespace, policy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *database.RetentionPolicy) int64); ok {
+		r0 = rf(ctx, namespace, policy)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *database.RetentionPolicy) error); ok {
+		r1 = rf(ctx, namespace, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBatchPins provides a mock function with given fields: ctx, namespace, batchID
+func (_m *Plugin) DeleteBatchPins(ctx context.Context, namespace string, batchID *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBatchPins")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, batchID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteBlob provides a mock function with given fields: ctx, sequence
 func (_m *Plugin) DeleteBlob(ctx context.Context, sequence int64) error {
 	ret := _m.Called(ctx, sequence)
@@ -61,6 +155,42 @@ func (_m *Plugin) DeleteBlob(ctx context.Context, sequence int64) error {
 	return r0
 }
 
+// DeleteBlockchainEvent provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) DeleteBlockchainEvent(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBlockchainEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteConfirmationQueueEntry provides a mock function with given fields: ctx, namespace, sequence
+func (_m *Plugin) DeleteConfirmationQueueEntry(ctx context.Context, namespace string, sequence int64) error {
+	ret := _m.Called(ctx, namespace, sequence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConfirmationQueueEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, namespace, sequence)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteContractAPI provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) DeleteContractAPI(ctx context.Context, namespace string, id *fftypes.UUID) error {
 	ret := _m.Called(ctx, namespace, id)
@@ -97,6 +227,24 @@ func (_m *Plugin) DeleteContractListenerByID(ctx context.Context, namespace stri
 	return r0
 }
 
+// DeleteDXQueueEntry provides a mock function with given fields: ctx, namespace, sequence
+func (_m *Plugin) DeleteDXQueueEntry(ctx context.Context, namespace string, sequence int64) error {
+	ret := _m.Called(ctx, namespace, sequence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDXQueueEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, namespace, sequence)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteData provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) DeleteData(ctx context.Context, namespace string, id *fftypes.UUID) error {
 	ret := _m.Called(ctx, namespace, id)
@@ -133,6 +281,24 @@ func (_m *Plugin) DeleteFFI(ctx context.Context, namespace string, id *fftypes.U
 	return r0
 }
 
+// DeleteMessageRetentionPolicy provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) DeleteMessageRetentionPolicy(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessageRetentionPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteNonce provides a mock function with given fields: ctx, hash
 func (_m *Plugin) DeleteNonce(ctx context.Context, hash *fftypes.Bytes32) error {
 	ret := _m.Called(ctx, hash)
@@ -241,6 +407,24 @@ func (_m *Plugin) DeleteTokenPool(ctx context.Context, namespace string, id *fft
 	return r0
 }
 
+// DeleteTokenTransfer provides a mock function with given fields: ctx, namespace, localID
+func (_m *Plugin) DeleteTokenTransfer(ctx context.Context, namespace string, localID *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, localID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTokenTransfer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, localID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTokenTransfers provides a mock function with given fields: ctx, namespace, poolID
 func (_m *Plugin) DeleteTokenTransfers(ctx context.Context, namespace string, poolID *fftypes.UUID) error {
 	ret := _m.Called(ctx, namespace, poolID)
@@ -259,29 +443,29 @@ func (_m *Plugin) DeleteTokenTransfers(ctx context.Context, namespace string, po
 	return r0
 }
 
-// GetBatchByID provides a mock function with given fields: ctx, namespace, id
-func (_m *Plugin) GetBatchByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.BatchPersisted, error) {
-	ret := _m.Called(ctx, namespace, id)
+// ExpireIdempotencyKeys provides a mock function with given fields: ctx, namespace, createdBefore
+func (_m *Plugin) ExpireIdempotencyKeys(ctx context.Context, namespace string, createdBefore *fftypes.FFTime) ([]*fftypes.UUID, error) {
+	ret := _m.Called(ctx, namespace, createdBefore)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatchByID")
+		panic("no return value specified for ExpireIdempotencyKeys")
 	}
 
-	var r0 *core.BatchPersisted
+	var r0 []*fftypes.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.BatchPersisted, error)); ok {
-		return rf(ctx, namespace, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.FFTime) ([]*fftypes.UUID, error)); ok {
+		return rf(ctx, namespace, createdBefore)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.BatchPersisted); ok {
-		r0 = rf(ctx, namespace, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.FFTime) []*fftypes.UUID); ok {
+		r0 = rf(ctx, namespace, createdBefore)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.BatchPersisted)
+			r0 = ret.Get(0).([]*fftypes.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
-		r1 = rf(ctx, namespace, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.FFTime) error); ok {
+		r1 = rf(ctx, namespace, createdBefore)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -289,29 +473,29 @@ func (_m *Plugin) GetBatchByID(ctx context.Context, namespace string, id *fftype
 	return r0, r1
 }
 
-// GetBatchIDsForDataAttachments provides a mock function with given fields: ctx, namespace, dataIDs
-func (_m *Plugin) GetBatchIDsForDataAttachments(ctx context.Context, namespace string, dataIDs []*fftypes.UUID) ([]*fftypes.UUID, error) {
-	ret := _m.Called(ctx, namespace, dataIDs)
+// GetArchiveRows provides a mock function with given fields: ctx, namespace, collection, afterSequence, createdBefore, limit
+func (_m *Plugin) GetArchiveRows(ctx context.Context, namespace string, collection database.CollectionName, afterSequence int64, createdBefore *fftypes.FFTime, limit int) (*database.ArchiveRows, error) {
+	ret := _m.Called(ctx, namespace, collection, afterSequence, createdBefore, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatchIDsForDataAttachments")
+		panic("no return value specified for GetArchiveRows")
 	}
 
-	var r0 []*fftypes.UUID
+	var r0 *database.ArchiveRows
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, []*fftypes.UUID) ([]*fftypes.UUID, error)); ok {
-		return rf(ctx, namespace, dataIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, string, database.CollectionName, int64, *fftypes.FFTime, int) (*database.ArchiveRows, error)); ok {
+		return rf(ctx, namespace, collection, afterSequence, createdBefore, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, []*fftypes.UUID) []*fftypes.UUID); ok {
-		r0 = rf(ctx, namespace, dataIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, string, database.CollectionName, int64, *fftypes.FFTime, int) *database.ArchiveRows); ok {
+		r0 = rf(ctx, namespace, collection, afterSequence, createdBefore, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*fftypes.UUID)
+			r0 = ret.Get(0).(*database.ArchiveRows)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, []*fftypes.UUID) error); ok {
-		r1 = rf(ctx, namespace, dataIDs)
+	if rf, ok := ret.Get(1).(func(context.Context, string, database.CollectionName, int64, *fftypes.FFTime, int) error); ok {
+		r1 = rf(ctx, namespace, collection, afterSequence, createdBefore, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -319,29 +503,29 @@ func (_m *Plugin) GetBatchIDsForDataAttachments(ctx context.Context, namespace s
 	return r0, r1
 }
 
-// GetBatchIDsForMessages provides a mock function with given fields: ctx, namespace, msgIDs
-func (_m *Plugin) GetBatchIDsForMessages(ctx context.Context, namespace string, msgIDs []*fftypes.UUID) ([]*fftypes.UUID, error) {
-	ret := _m.Called(ctx, namespace, msgIDs)
+// GetAuditAnchorByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetAuditAnchorByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.AuditAnchor, error) {
+	ret := _m.Called(ctx, namespace, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatchIDsForMessages")
+		panic("no return value specified for GetAuditAnchorByID")
 	}
 
-	var r0 []*fftypes.UUID
+	var r0 *core.AuditAnchor
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, []*fftypes.UUID) ([]*fftypes.UUID, error)); ok {
-		return rf(ctx, namespace, msgIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.AuditAnchor, error)); ok {
+		return rf(ctx, namespace, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, []*fftypes.UUID) []*fftypes.UUID); ok {
-		r0 = rf(ctx, namespace, msgIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.AuditAnchor); ok {
+		r0 = rf(ctx, namespace, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*fftypes.UUID)
+			r0 = ret.Get(0).(*core.AuditAnchor)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, []*fftypes.UUID) error); ok {
-		r1 = rf(ctx, namespace, msgIDs)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -349,25 +533,25 @@ func (_m *Plugin) GetBatchIDsForMessages(ctx context.Context, namespace string,
 	return r0, r1
 }
 
-// GetBatches provides a mock function with given fields: ctx, namespace, filter
-func (_m *Plugin) GetBatches(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+// GetAuditAnchors provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetAuditAnchors(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.AuditAnchor, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatches")
+		panic("no return value specified for GetAuditAnchors")
 	}
 
-	var r0 []*core.BatchPersisted
+	var r0 []*core.AuditAnchor
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.AuditAnchor, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, namespace, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.BatchPersisted); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.AuditAnchor); ok {
 		r0 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.BatchPersisted)
+			r0 = ret.Get(0).([]*core.AuditAnchor)
 		}
 	}
 
@@ -388,25 +572,25 @@ func (_m *Plugin) GetBatches(ctx context.Context, namespace string, filter ffapi
 	return r0, r1, r2
 }
 
-// GetBlobs provides a mock function with given fields: ctx, namespace, filter
-func (_m *Plugin) GetBlobs(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Blob, *ffapi.FilterResult, error) {
+// GetAuditRecords provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetAuditRecords(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.AuditRecord, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBlobs")
+		panic("no return value specified for GetAuditRecords")
 	}
 
-	var r0 []*core.Blob
+	var r0 []*core.AuditRecord
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Blob, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.AuditRecord, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, namespace, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Blob); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.AuditRecord); ok {
 		r0 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Blob)
+			r0 = ret.Get(0).([]*core.AuditRecord)
 		}
 	}
 
@@ -427,24 +611,63 @@ func (_m *Plugin) GetBlobs(ctx context.Context, namespace string, filter ffapi.F
 	return r0, r1, r2
 }
 
-// GetBlockchainEventByID provides a mock function with given fields: ctx, namespace, id
-func (_m *Plugin) GetBlockchainEventByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.BlockchainEvent, error) {
+// GetBatchAcks provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetBatchAcks(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.BatchAck, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchAcks")
+	}
+
+	var r0 []*core.BatchAck
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.BatchAck, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.BatchAck); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.BatchAck)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBatchByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetBatchByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.BatchPersisted, error) {
 	ret := _m.Called(ctx, namespace, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBlockchainEventByID")
+		panic("no return value specified for GetBatchByID")
 	}
 
-	var r0 *core.BlockchainEvent
+	var r0 *core.BatchPersisted
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.BlockchainEvent, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.BatchPersisted, error)); ok {
 		return rf(ctx, namespace, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.BlockchainEvent); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.BatchPersisted); ok {
 		r0 = rf(ctx, namespace, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.BlockchainEvent)
+			r0 = ret.Get(0).(*core.BatchPersisted)
 		}
 	}
 
@@ -457,29 +680,29 @@ func (_m *Plugin) GetBlockchainEventByID(ctx context.Context, namespace string,
 	return r0, r1
 }
 
-// GetBlockchainEventByProtocolID provides a mock function with given fields: ctx, namespace, listener, protocolID
-func (_m *Plugin) GetBlockchainEventByProtocolID(ctx context.Context, namespace string, listener *fftypes.UUID, protocolID string) (*core.BlockchainEvent, error) {
-	ret := _m.Called(ctx, namespace, listener, protocolID)
+// GetBatchIDsForBlobHash provides a mock function with given fields: ctx, namespace, blobHash
+func (_m *Plugin) GetBatchIDsForBlobHash(ctx context.Context, namespace string, blobHash *fftypes.Bytes32) ([]*fftypes.UUID, error) {
+	ret := _m.Called(ctx, namespace, blobHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBlockchainEventByProtocolID")
+		panic("no return value specified for GetBatchIDsForBlobHash")
 	}
 
-	var r0 *core.BlockchainEvent
+	var r0 []*fftypes.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, string) (*core.BlockchainEvent, error)); ok {
-		return rf(ctx, namespace, listener, protocolID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32) ([]*fftypes.UUID, error)); ok {
+		return rf(ctx, namespace, blobHash)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, string) *core.BlockchainEvent); ok {
-		r0 = rf(ctx, namespace, listener, protocolID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32) []*fftypes.UUID); ok {
+		r0 = rf(ctx, namespace, blobHash)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.BlockchainEvent)
+			r0 = ret.Get(0).([]*fftypes.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID, string) error); ok {
-		r1 = rf(ctx, namespace, listener, protocolID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.Bytes32) error); ok {
+		r1 = rf(ctx, namespace, blobHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -487,12 +710,268 @@ func (_m *Plugin) GetBlockchainEventByProtocolID(ctx context.Context, namespace
 	return r0, r1
 }
 
-// GetBlockchainEvents provides a mock function with given fields: ctx, namespace, filter
-func (_m *Plugin) GetBlockchainEvents(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, namespace, filter)
+// GetBatchIDsForDataAttachments provides a mock function with given fields: ctx, namespace, dataIDs
+func (_m *Plugin) GetBatchIDsForDataAttachments(ctx context.Context, namespace string, dataIDs []*fftypes.UUID) ([]*fftypes.UUID, error) {
+	ret := _m.Called(ctx, namespace, dataIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBlockchainEvents")
+		panic("no return value specified for GetBatchIDsForDataAttachments")
+	}
+
+	var r0 []*fftypes.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*fftypes.UUID) ([]*fftypes.UUID, error)); ok {
+		return rf(ctx, namespace, dataIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*fftypes.UUID) []*fftypes.UUID); ok {
+		r0 = rf(ctx, namespace, dataIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*fftypes.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []*fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, dataIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBatchIDsForDataHash provides a mock function with given fields: ctx, namespace, dataHash
+func (_m *Plugin) GetBatchIDsForDataHash(ctx context.Context, namespace string, dataHash *fftypes.Bytes32) ([]*fftypes.UUID, error) {
+	ret := _m.Called(ctx, namespace, dataHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchIDsForDataHash")
+	}
+
+	var r0 []*fftypes.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32) ([]*fftypes.UUID, error)); ok {
+		return rf(ctx, namespace, dataHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32) []*fftypes.UUID); ok {
+		r0 = rf(ctx, namespace, dataHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*fftypes.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.Bytes32) error); ok {
+		r1 = rf(ctx, namespace, dataHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBatchIDsForMessages provides a mock function with given fields: ctx, namespace, msgIDs
+func (_m *Plugin) GetBatchIDsForMessages(ctx context.Context, namespace string, msgIDs []*fftypes.UUID) ([]*fftypes.UUID, error) {
+	ret := _m.Called(ctx, namespace, msgIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchIDsForMessages")
+	}
+
+	var r0 []*fftypes.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*fftypes.UUID) ([]*fftypes.UUID, error)); ok {
+		return rf(ctx, namespace, msgIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*fftypes.UUID) []*fftypes.UUID); ok {
+		r0 = rf(ctx, namespace, msgIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*fftypes.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []*fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, msgIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBatches provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetBatches(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatches")
+	}
+
+	var r0 []*core.BatchPersisted
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.BatchPersisted); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.BatchPersisted)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBlobs provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetBlobs(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Blob, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlobs")
+	}
+
+	var r0 []*core.Blob
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Blob, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Blob); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Blob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBlobsTotalSize provides a mock function with given fields: ctx, namespace
+func (_m *Plugin) GetBlobsTotalSize(ctx context.Context, namespace string) (int64, error) {
+	ret := _m.Called(ctx, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlobsTotalSize")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockchainEventByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetBlockchainEventByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.BlockchainEvent, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockchainEventByID")
+	}
+
+	var r0 *core.BlockchainEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.BlockchainEvent, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.BlockchainEvent); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BlockchainEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockchainEventByProtocolID provides a mock function with given fields: ctx, namespace, listener, protocolID
+func (_m *Plugin) GetBlockchainEventByProtocolID(ctx context.Context, namespace string, listener *fftypes.UUID, protocolID string) (*core.BlockchainEvent, error) {
+	ret := _m.Called(ctx, namespace, listener, protocolID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockchainEventByProtocolID")
+	}
+
+	var r0 *core.BlockchainEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, string) (*core.BlockchainEvent, error)); ok {
+		return rf(ctx, namespace, listener, protocolID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, string) *core.BlockchainEvent); ok {
+		r0 = rf(ctx, namespace, listener, protocolID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BlockchainEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID, string) error); ok {
+		r1 = rf(ctx, namespace, listener, protocolID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockchainEvents provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetBlockchainEvents(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockchainEvents")
 	}
 
 	var r0 []*core.BlockchainEvent
@@ -526,6 +1005,36 @@ func (_m *Plugin) GetBlockchainEvents(ctx context.Context, namespace string, fil
 	return r0, r1, r2
 }
 
+// GetBlockchainReorgByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetBlockchainReorgByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.BlockchainReorg, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockchainReorgByID")
+	}
+
+	var r0 *core.BlockchainReorg
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.BlockchainReorg, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.BlockchainReorg); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BlockchainReorg)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChartHistogram provides a mock function with given fields: ctx, namespace, intervals, collection
 func (_m *Plugin) GetChartHistogram(ctx context.Context, namespace string, intervals []core.ChartHistogramInterval, collection database.CollectionName) ([]*core.ChartHistogram, error) {
 	ret := _m.Called(ctx, namespace, intervals, collection)
@@ -556,6 +1065,36 @@ func (_m *Plugin) GetChartHistogram(ctx context.Context, namespace string, inter
 	return r0, r1
 }
 
+// GetConfirmationQueueEntries provides a mock function with given fields: ctx, namespace
+func (_m *Plugin) GetConfirmationQueueEntries(ctx context.Context, namespace string) ([]*core.ConfirmationQueueEntry, error) {
+	ret := _m.Called(ctx, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfirmationQueueEntries")
+	}
+
+	var r0 []*core.ConfirmationQueueEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.ConfirmationQueueEntry, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.ConfirmationQueueEntry); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ConfirmationQueueEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetContractAPIByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetContractAPIByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.ContractAPI, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -814,6 +1353,36 @@ func (_m *Plugin) GetContractListeners(ctx context.Context, namespace string, fi
 	return r0, r1, r2
 }
 
+// GetDXQueueEntries provides a mock function with given fields: ctx, namespace, limit
+func (_m *Plugin) GetDXQueueEntries(ctx context.Context, namespace string, limit int) ([]*core.DXQueueEntry, error) {
+	ret := _m.Called(ctx, namespace, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDXQueueEntries")
+	}
+
+	var r0 []*core.DXQueueEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*core.DXQueueEntry, error)); ok {
+		return rf(ctx, namespace, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*core.DXQueueEntry); ok {
+		r0 = rf(ctx, namespace, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.DXQueueEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, namespace, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetData provides a mock function with given fields: ctx, namespace, filter
 func (_m *Plugin) GetData(ctx context.Context, namespace string, filter ffapi.Filter) (core.DataArray, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
@@ -1051,6 +1620,75 @@ func (_m *Plugin) GetDatatypes(ctx context.Context, namespace string, filter ffa
 	return r0, r1, r2
 }
 
+// GetDisclosureByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetDisclosureByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Disclosure, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDisclosureByID")
+	}
+
+	var r0 *core.Disclosure
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.Disclosure, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.Disclosure); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Disclosure)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDisclosures provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetDisclosures(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Disclosure, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDisclosures")
+	}
+
+	var r0 []*core.Disclosure
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Disclosure, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Disclosure); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Disclosure)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetEventByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetEventByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Event, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -1649,38 +2287,137 @@ func (_m *Plugin) GetIdentityByName(ctx context.Context, iType fftypes.FFEnum, n
 	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, string, string) *core.Identity); ok {
 		r0 = rf(ctx, iType, namespace, name)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Identity)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, string, string) error); ok {
+		r1 = rf(ctx, iType, namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessageByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetMessageByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Message, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageByID")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.Message, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.Message); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessageIDs provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetMessageIDs(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.IDAndSequence, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageIDs")
+	}
+
+	var r0 []*core.IDAndSequence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.IDAndSequence, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.IDAndSequence); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.IDAndSequence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessageRetentionPolicies provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetMessageRetentionPolicies(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.MessageRetentionPolicy, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageRetentionPolicies")
+	}
+
+	var r0 []*core.MessageRetentionPolicy
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.MessageRetentionPolicy, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.MessageRetentionPolicy); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.MessageRetentionPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, string, string) error); ok {
-		r1 = rf(ctx, iType, namespace, name)
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
 	} else {
-		r1 = ret.Error(1)
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
 
-// GetMessageByID provides a mock function with given fields: ctx, namespace, id
-func (_m *Plugin) GetMessageByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Message, error) {
+// GetMessageRetentionPolicyByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetMessageRetentionPolicyByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.MessageRetentionPolicy, error) {
 	ret := _m.Called(ctx, namespace, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessageByID")
+		panic("no return value specified for GetMessageRetentionPolicyByID")
 	}
 
-	var r0 *core.Message
+	var r0 *core.MessageRetentionPolicy
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.Message, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.MessageRetentionPolicy, error)); ok {
 		return rf(ctx, namespace, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.Message); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.MessageRetentionPolicy); ok {
 		r0 = rf(ctx, namespace, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Message)
+			r0 = ret.Get(0).(*core.MessageRetentionPolicy)
 		}
 	}
 
@@ -1693,68 +2430,77 @@ func (_m *Plugin) GetMessageByID(ctx context.Context, namespace string, id *ffty
 	return r0, r1
 }
 
-// GetMessageIDs provides a mock function with given fields: ctx, namespace, filter
-func (_m *Plugin) GetMessageIDs(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.IDAndSequence, error) {
+// GetMessages provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetMessages(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Message, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessageIDs")
+		panic("no return value specified for GetMessages")
 	}
 
-	var r0 []*core.IDAndSequence
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.IDAndSequence, error)); ok {
+	var r0 []*core.Message
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, namespace, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.IDAndSequence); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Message); ok {
 		r0 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.IDAndSequence)
+			r0 = ret.Get(0).([]*core.Message)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
 		r1 = rf(ctx, namespace, filter)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetMessages provides a mock function with given fields: ctx, namespace, filter
-func (_m *Plugin) GetMessages(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Message, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, namespace, filter)
+// GetMessagesForBlobHash provides a mock function with given fields: ctx, namespace, blobHash, filter
+func (_m *Plugin) GetMessagesForBlobHash(ctx context.Context, namespace string, blobHash *fftypes.Bytes32, filter ffapi.Filter) ([]*core.Message, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, blobHash, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessages")
+		panic("no return value specified for GetMessagesForBlobHash")
 	}
 
 	var r0 []*core.Message
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32, ffapi.Filter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, blobHash, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Message); ok {
-		r0 = rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32, ffapi.Filter) []*core.Message); ok {
+		r0 = rf(ctx, namespace, blobHash, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*core.Message)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.Bytes32, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, blobHash, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
-		r2 = rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(2).(func(context.Context, string, *fftypes.Bytes32, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, blobHash, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1801,6 +2547,45 @@ func (_m *Plugin) GetMessagesForData(ctx context.Context, namespace string, data
 	return r0, r1, r2
 }
 
+// GetMessagesForDataHash provides a mock function with given fields: ctx, namespace, dataHash, filter
+func (_m *Plugin) GetMessagesForDataHash(ctx context.Context, namespace string, dataHash *fftypes.Bytes32, filter ffapi.Filter) ([]*core.Message, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, dataHash, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessagesForDataHash")
+	}
+
+	var r0 []*core.Message
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32, ffapi.Filter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, dataHash, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32, ffapi.Filter) []*core.Message); ok {
+		r0 = rf(ctx, namespace, dataHash, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.Bytes32, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, dataHash, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *fftypes.Bytes32, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, dataHash, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetNamespace provides a mock function with given fields: ctx, name
 func (_m *Plugin) GetNamespace(ctx context.Context, name string) (*core.Namespace, error) {
 	ret := _m.Called(ctx, name)
@@ -1831,6 +2616,36 @@ func (_m *Plugin) GetNamespace(ctx context.Context, name string) (*core.Namespac
 	return r0, r1
 }
 
+// GetNamespaceQuotas provides a mock function with given fields: ctx, namespace
+func (_m *Plugin) GetNamespaceQuotas(ctx context.Context, namespace string) (*core.NamespaceQuotas, error) {
+	ret := _m.Called(ctx, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespaceQuotas")
+	}
+
+	var r0 *core.NamespaceQuotas
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.NamespaceQuotas, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.NamespaceQuotas); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NamespaceQuotas)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNextPins provides a mock function with given fields: ctx, namespace, filter
 func (_m *Plugin) GetNextPins(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.NextPin, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
@@ -1944,24 +2759,192 @@ func (_m *Plugin) GetNonces(ctx context.Context, filter ffapi.Filter) ([]*core.N
 	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) ([]*core.Nonce, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) []*core.Nonce); ok {
-		r0 = rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) []*core.Nonce); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Nonce)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.Filter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOffset provides a mock function with given fields: ctx, t, name
+func (_m *Plugin) GetOffset(ctx context.Context, t fftypes.FFEnum, name string) (*core.Offset, error) {
+	ret := _m.Called(ctx, t, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOffset")
+	}
+
+	var r0 *core.Offset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, string) (*core.Offset, error)); ok {
+		return rf(ctx, t, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, string) *core.Offset); ok {
+		r0 = rf(ctx, t, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Offset)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, string) error); ok {
+		r1 = rf(ctx, t, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOffsets provides a mock function with given fields: ctx, filter
+func (_m *Plugin) GetOffsets(ctx context.Context, filter ffapi.Filter) ([]*core.Offset, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOffsets")
+	}
+
+	var r0 []*core.Offset
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) ([]*core.Offset, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) []*core.Offset); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Offset)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.Filter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOnlineMigrations provides a mock function with given fields: ctx
+func (_m *Plugin) GetOnlineMigrations(ctx context.Context) ([]*core.OnlineMigration, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOnlineMigrations")
+	}
+
+	var r0 []*core.OnlineMigration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*core.OnlineMigration, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.OnlineMigration); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.OnlineMigration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperationApprovalByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetOperationApprovalByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.OperationApproval, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationApprovalByID")
+	}
+
+	var r0 *core.OperationApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.OperationApproval, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.OperationApproval); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.OperationApproval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperationApprovals provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetOperationApprovals(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.OperationApproval, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationApprovals")
+	}
+
+	var r0 []*core.OperationApproval
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.OperationApproval, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.OperationApproval); ok {
+		r0 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Nonce)
+			r0 = ret.Get(0).([]*core.OperationApproval)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, ffapi.Filter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, ffapi.Filter) error); ok {
-		r2 = rf(ctx, filter)
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1969,29 +2952,29 @@ func (_m *Plugin) GetNonces(ctx context.Context, filter ffapi.Filter) ([]*core.N
 	return r0, r1, r2
 }
 
-// GetOffset provides a mock function with given fields: ctx, t, name
-func (_m *Plugin) GetOffset(ctx context.Context, t fftypes.FFEnum, name string) (*core.Offset, error) {
-	ret := _m.Called(ctx, t, name)
+// GetOperationByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetOperationByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Operation, error) {
+	ret := _m.Called(ctx, namespace, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOffset")
+		panic("no return value specified for GetOperationByID")
 	}
 
-	var r0 *core.Offset
+	var r0 *core.Operation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, string) (*core.Offset, error)); ok {
-		return rf(ctx, t, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.Operation, error)); ok {
+		return rf(ctx, namespace, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, string) *core.Offset); ok {
-		r0 = rf(ctx, t, name)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.Operation); ok {
+		r0 = rf(ctx, namespace, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Offset)
+			r0 = ret.Get(0).(*core.Operation)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, string) error); ok {
-		r1 = rf(ctx, t, name)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1999,38 +2982,68 @@ func (_m *Plugin) GetOffset(ctx context.Context, t fftypes.FFEnum, name string)
 	return r0, r1
 }
 
-// GetOffsets provides a mock function with given fields: ctx, filter
-func (_m *Plugin) GetOffsets(ctx context.Context, filter ffapi.Filter) ([]*core.Offset, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, filter)
+// GetOperationFeeSummary provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetOperationFeeSummary(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.FeeSummary, error) {
+	ret := _m.Called(ctx, namespace, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOffsets")
+		panic("no return value specified for GetOperationFeeSummary")
 	}
 
-	var r0 []*core.Offset
+	var r0 []*core.FeeSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.FeeSummary, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.FeeSummary); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.FeeSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) error); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperationFees provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetOperationFees(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.OperationFee, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationFees")
+	}
+
+	var r0 []*core.OperationFee
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) ([]*core.Offset, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.OperationFee, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) []*core.Offset); ok {
-		r0 = rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.OperationFee); ok {
+		r0 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Offset)
+			r0 = ret.Get(0).([]*core.OperationFee)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, ffapi.Filter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, ffapi.Filter) error); ok {
-		r2 = rf(ctx, filter)
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -2038,34 +3051,43 @@ func (_m *Plugin) GetOffsets(ctx context.Context, filter ffapi.Filter) ([]*core.
 	return r0, r1, r2
 }
 
-// GetOperationByID provides a mock function with given fields: ctx, namespace, id
-func (_m *Plugin) GetOperationByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Operation, error) {
-	ret := _m.Called(ctx, namespace, id)
+// GetOperationHistory provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetOperationHistory(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.OperationHistoryEntry, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOperationByID")
+		panic("no return value specified for GetOperationHistory")
 	}
 
-	var r0 *core.Operation
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.Operation, error)); ok {
-		return rf(ctx, namespace, id)
+	var r0 []*core.OperationHistoryEntry
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.OperationHistoryEntry, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.Operation); ok {
-		r0 = rf(ctx, namespace, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.OperationHistoryEntry); ok {
+		r0 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Operation)
+			r0 = ret.Get(0).([]*core.OperationHistoryEntry)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
-		r1 = rf(ctx, namespace, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // GetOperations provides a mock function with given fields: ctx, namespace, filter
@@ -2146,6 +3168,36 @@ func (_m *Plugin) GetPins(ctx context.Context, namespace string, filter ffapi.Fi
 	return r0, r1, r2
 }
 
+// GetSnapshotVerification provides a mock function with given fields: ctx, namespace
+func (_m *Plugin) GetSnapshotVerification(ctx context.Context, namespace string) (*core.SnapshotVerificationState, error) {
+	ret := _m.Called(ctx, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSnapshotVerification")
+	}
+
+	var r0 *core.SnapshotVerificationState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.SnapshotVerificationState, error)); ok {
+		return rf(ctx, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.SnapshotVerificationState); ok {
+		r0 = rf(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SnapshotVerificationState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSubscriptionByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetSubscriptionByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Subscription, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -2719,6 +3771,45 @@ func (_m *Plugin) GetTokenTransfers(ctx context.Context, namespace string, filte
 	return r0, r1, r2
 }
 
+// GetTrafficStats provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetTrafficStats(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.TrafficStats, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrafficStats")
+	}
+
+	var r0 []*core.TrafficStats
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.TrafficStats, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.TrafficStats); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.TrafficStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTransactionByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetTransactionByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Transaction, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -2910,6 +4001,42 @@ func (_m *Plugin) InitConfig(_a0 config.Section) {
 	_m.Called(_a0)
 }
 
+// InsertAuditAnchor provides a mock function with given fields: ctx, anchor
+func (_m *Plugin) InsertAuditAnchor(ctx context.Context, anchor *core.AuditAnchor) error {
+	ret := _m.Called(ctx, anchor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertAuditAnchor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AuditAnchor) error); ok {
+		r0 = rf(ctx, anchor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertAuditRecord provides a mock function with given fields: ctx, record
+func (_m *Plugin) InsertAuditRecord(ctx context.Context, record *core.AuditRecord) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertAuditRecord")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AuditRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertBlob provides a mock function with given fields: ctx, blob
 func (_m *Plugin) InsertBlob(ctx context.Context, blob *core.Blob) error {
 	ret := _m.Called(ctx, blob)
@@ -2971,17 +4098,107 @@ func (_m *Plugin) InsertBlockchainEvents(ctx context.Context, messages []*core.B
 	return r0
 }
 
-// InsertContractListener provides a mock function with given fields: ctx, sub
-func (_m *Plugin) InsertContractListener(ctx context.Context, sub *core.ContractListener) error {
-	ret := _m.Called(ctx, sub)
+// InsertBlockchainReorg provides a mock function with given fields: ctx, reorg
+func (_m *Plugin) InsertBlockchainReorg(ctx context.Context, reorg *core.BlockchainReorg) error {
+	ret := _m.Called(ctx, reorg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertBlockchainReorg")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.BlockchainReorg) error); ok {
+		r0 = rf(ctx, reorg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertConfirmationQueueEntry provides a mock function with given fields: ctx, entry
+func (_m *Plugin) InsertConfirmationQueueEntry(ctx context.Context, entry *core.ConfirmationQueueEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertConfirmationQueueEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ConfirmationQueueEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertContractListener provides a mock function with given fields: ctx, sub
+func (_m *Plugin) InsertContractListener(ctx context.Context, sub *core.ContractListener) error {
+	ret := _m.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertContractListener")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractListener) error); ok {
+		r0 = rf(ctx, sub)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertDXQueueEntry provides a mock function with given fields: ctx, entry
+func (_m *Plugin) InsertDXQueueEntry(ctx context.Context, entry *core.DXQueueEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertDXQueueEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DXQueueEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertDataArray provides a mock function with given fields: ctx, data
+func (_m *Plugin) InsertDataArray(ctx context.Context, data core.DataArray) error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertDataArray")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, core.DataArray) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertDisclosure provides a mock function with given fields: ctx, disclosure
+func (_m *Plugin) InsertDisclosure(ctx context.Context, disclosure *core.Disclosure) error {
+	ret := _m.Called(ctx, disclosure)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertContractListener")
+		panic("no return value specified for InsertDisclosure")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractListener) error); ok {
-		r0 = rf(ctx, sub)
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Disclosure) error); ok {
+		r0 = rf(ctx, disclosure)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2989,16 +4206,16 @@ func (_m *Plugin) InsertContractListener(ctx context.Context, sub *core.Contract
 	return r0
 }
 
-// InsertDataArray provides a mock function with given fields: ctx, data
-func (_m *Plugin) InsertDataArray(ctx context.Context, data core.DataArray) error {
+// InsertEvent provides a mock function with given fields: ctx, data
+func (_m *Plugin) InsertEvent(ctx context.Context, data *core.Event) error {
 	ret := _m.Called(ctx, data)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertDataArray")
+		panic("no return value specified for InsertEvent")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, core.DataArray) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Event) error); ok {
 		r0 = rf(ctx, data)
 	} else {
 		r0 = ret.Error(0)
@@ -3007,17 +4224,17 @@ func (_m *Plugin) InsertDataArray(ctx context.Context, data core.DataArray) erro
 	return r0
 }
 
-// InsertEvent provides a mock function with given fields: ctx, data
-func (_m *Plugin) InsertEvent(ctx context.Context, data *core.Event) error {
-	ret := _m.Called(ctx, data)
+// InsertMessageRetentionPolicy provides a mock function with given fields: ctx, policy
+func (_m *Plugin) InsertMessageRetentionPolicy(ctx context.Context, policy *core.MessageRetentionPolicy) error {
+	ret := _m.Called(ctx, policy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for InsertEvent")
+		panic("no return value specified for InsertMessageRetentionPolicy")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *core.Event) error); ok {
-		r0 = rf(ctx, data)
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageRetentionPolicy) error); ok {
+		r0 = rf(ctx, policy)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3111,6 +4328,42 @@ func (_m *Plugin) InsertOperation(ctx context.Context, operation *core.Operation
 	return r0
 }
 
+// InsertOperationApproval provides a mock function with given fields: ctx, approval
+func (_m *Plugin) InsertOperationApproval(ctx context.Context, approval *core.OperationApproval) error {
+	ret := _m.Called(ctx, approval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOperationApproval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.OperationApproval) error); ok {
+		r0 = rf(ctx, approval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertOperationHistory provides a mock function with given fields: ctx, entry
+func (_m *Plugin) InsertOperationHistory(ctx context.Context, entry *core.OperationHistoryEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOperationHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.OperationHistoryEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertOperations provides a mock function with given fields: ctx, ops, hooks
 func (_m *Plugin) InsertOperations(ctx context.Context, ops []*core.Operation, hooks ...database.PostCompletionHook) error {
 	_va := make([]interface{}, len(hooks))
@@ -3334,6 +4587,24 @@ func (_m *Plugin) InsertPins(ctx context.Context, pins []*core.Pin) error {
 	return r0
 }
 
+// InsertSearchDocuments provides a mock function with given fields: ctx, docs
+func (_m *Plugin) InsertSearchDocuments(ctx context.Context, docs []*core.SearchDocument) error {
+	ret := _m.Called(ctx, docs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSearchDocuments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.SearchDocument) error); ok {
+		r0 = rf(ctx, docs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertTransaction provides a mock function with given fields: ctx, txn
 func (_m *Plugin) InsertTransaction(ctx context.Context, txn *core.Transaction) error {
 	ret := _m.Called(ctx, txn)
@@ -3388,6 +4659,34 @@ func (_m *Plugin) Name() string {
 	return r0
 }
 
+// PruneRecords provides a mock function with given fields: ctx, namespace, policy, limit
+func (_m *Plugin) PruneRecords(ctx context.Context, namespace string, policy *database.RetentionPolicy, limit int) (int64, error) {
+	ret := _m.Called(ctx, namespace, policy, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneRecords")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *database.RetentionPolicy, int) (int64, error)); ok {
+		return rf(ctx, namespace, policy, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *database.RetentionPolicy, int) int64); ok {
+		r0 = rf(ctx, namespace, policy, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *database.RetentionPolicy, int) error); ok {
+		r1 = rf(ctx, namespace, policy, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReplaceMessage provides a mock function with given fields: ctx, message
 func (_m *Plugin) ReplaceMessage(ctx context.Context, message *core.Message) error {
 	ret := _m.Called(ctx, message)
@@ -3424,11 +4723,101 @@ func (_m *Plugin) RunAsGroup(ctx context.Context, fn func(context.Context) error
 	return r0
 }
 
+// RunOnlineMigrationStep provides a mock function with given fields: ctx, name
+func (_m *Plugin) RunOnlineMigrationStep(ctx context.Context, name string) (*core.OnlineMigration, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunOnlineMigrationStep")
+	}
+
+	var r0 *core.OnlineMigration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.OnlineMigration, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.OnlineMigration); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.OnlineMigration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchDocuments provides a mock function with given fields: ctx, namespace, query, limit
+func (_m *Plugin) SearchDocuments(ctx context.Context, namespace string, query string, limit int) ([]*database.SearchHit, error) {
+	ret := _m.Called(ctx, namespace, query, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchDocuments")
+	}
+
+	var r0 []*database.SearchHit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) ([]*database.SearchHit, error)); ok {
+		return rf(ctx, namespace, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []*database.SearchHit); ok {
+		r0 = rf(ctx, namespace, query, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*database.SearchHit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, namespace, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetHandler provides a mock function with given fields: namespace, handler
 func (_m *Plugin) SetHandler(namespace string, handler database.Callbacks) {
 	_m.Called(namespace, handler)
 }
 
+// SwapOnlineMigration provides a mock function with given fields: ctx, name
+func (_m *Plugin) SwapOnlineMigration(ctx context.Context, name string) (*core.OnlineMigration, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapOnlineMigration")
+	}
+
+	var r0 *core.OnlineMigration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.OnlineMigration, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.OnlineMigration); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.OnlineMigration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateBatch provides a mock function with given fields: ctx, namespace, id, update
 func (_m *Plugin) UpdateBatch(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error {
 	ret := _m.Called(ctx, namespace, id, update)
@@ -3483,6 +4872,24 @@ func (_m *Plugin) UpdateData(ctx context.Context, namespace string, id *fftypes.
 	return r0
 }
 
+// UpdateDisclosure provides a mock function with given fields: ctx, namespace, id, update
+func (_m *Plugin) UpdateDisclosure(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error {
+	ret := _m.Called(ctx, namespace, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDisclosure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, ffapi.Update) error); ok {
+		r0 = rf(ctx, namespace, id, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateMessage provides a mock function with given fields: ctx, namespace, id, update
 func (_m *Plugin) UpdateMessage(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error {
 	ret := _m.Called(ctx, namespace, id, update)
@@ -3501,6 +4908,24 @@ func (_m *Plugin) UpdateMessage(ctx context.Context, namespace string, id *fftyp
 	return r0
 }
 
+// UpdateMessageRetentionPolicy provides a mock function with given fields: ctx, namespace, id, update
+func (_m *Plugin) UpdateMessageRetentionPolicy(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error {
+	ret := _m.Called(ctx, namespace, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMessageRetentionPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, ffapi.Update) error); ok {
+		r0 = rf(ctx, namespace, id, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateMessages provides a mock function with given fields: ctx, namespace, filter, update
 func (_m *Plugin) UpdateMessages(ctx context.Context, namespace string, filter ffapi.Filter, update ffapi.Update) error {
 	ret := _m.Called(ctx, namespace, filter, update)
@@ -3601,6 +5026,34 @@ func (_m *Plugin) UpdateOperation(ctx context.Context, namespace string, id *fft
 	return r0, r1
 }
 
+// UpdateOperationApproval provides a mock function with given fields: ctx, namespace, id, filter, update
+func (_m *Plugin) UpdateOperationApproval(ctx context.Context, namespace string, id *fftypes.UUID, filter ffapi.Filter, update ffapi.Update) (bool, error) {
+	ret := _m.Called(ctx, namespace, id, filter, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOperationApproval")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, ffapi.Filter, ffapi.Update) (bool, error)); ok {
+		return rf(ctx, namespace, id, filter, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, ffapi.Filter, ffapi.Update) bool); ok {
+		r0 = rf(ctx, namespace, id, filter, update)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID, ffapi.Filter, ffapi.Update) error); ok {
+		r1 = rf(ctx, namespace, id, filter, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePins provides a mock function with given fields: ctx, namespace, filter, update
 func (_m *Plugin) UpdatePins(ctx context.Context, namespace string, filter ffapi.Filter, update ffapi.Update) error {
 	ret := _m.Called(ctx, namespace, filter, update)
@@ -3691,6 +5144,42 @@ func (_m *Plugin) UpdateTransaction(ctx context.Context, namespace string, id *f
 	return r0
 }
 
+// UpdateTransactionSaga provides a mock function with given fields: ctx, namespace, id, saga
+func (_m *Plugin) UpdateTransactionSaga(ctx context.Context, namespace string, id *fftypes.UUID, saga *core.TransactionSaga) error {
+	ret := _m.Called(ctx, namespace, id, saga)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransactionSaga")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, *core.TransactionSaga) error); ok {
+		r0 = rf(ctx, namespace, id, saga)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertBatchAck provides a mock function with given fields: ctx, ack
+func (_m *Plugin) UpsertBatchAck(ctx context.Context, ack *core.BatchAck) error {
+	ret := _m.Called(ctx, ack)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertBatchAck")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.BatchAck) error); ok {
+		r0 = rf(ctx, ack)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertContractAPI provides a mock function with given fields: ctx, api, optimization
 func (_m *Plugin) UpsertContractAPI(ctx context.Context, api *core.ContractAPI, optimization database.UpsertOptimization) error {
 	ret := _m.Called(ctx, api, optimization)
@@ -3914,6 +5403,24 @@ func (_m *Plugin) UpsertNamespace(ctx context.Context, data *core.Namespace, all
 	return r0
 }
 
+// UpsertNamespaceQuotas provides a mock function with given fields: ctx, namespace, limits
+func (_m *Plugin) UpsertNamespaceQuotas(ctx context.Context, namespace string, limits *core.NamespaceQuotas) error {
+	ret := _m.Called(ctx, namespace, limits)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertNamespaceQuotas")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.NamespaceQuotas) error); ok {
+		r0 = rf(ctx, namespace, limits)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertOffset provides a mock function with given fields: ctx, data, allowExisting
 func (_m *Plugin) UpsertOffset(ctx context.Context, data *core.Offset, allowExisting bool) error {
 	ret := _m.Called(ctx, data, allowExisting)
@@ -3932,6 +5439,24 @@ func (_m *Plugin) UpsertOffset(ctx context.Context, data *core.Offset, allowExis
 	return r0
 }
 
+// UpsertOperationFee provides a mock function with given fields: ctx, fee
+func (_m *Plugin) UpsertOperationFee(ctx context.Context, fee *core.OperationFee) error {
+	ret := _m.Called(ctx, fee)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertOperationFee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.OperationFee) error); ok {
+		r0 = rf(ctx, fee)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertPin provides a mock function with given fields: ctx, parked
 func (_m *Plugin) UpsertPin(ctx context.Context, parked *core.Pin) error {
 	ret := _m.Called(ctx, parked)
@@ -3950,6 +5475,24 @@ func (_m *Plugin) UpsertPin(ctx context.Context, parked *core.Pin) error {
 	return r0
 }
 
+// UpsertSnapshotVerification provides a mock function with given fields: ctx, namespace, state
+func (_m *Plugin) UpsertSnapshotVerification(ctx context.Context, namespace string, state *core.SnapshotVerificationState) error {
+	ret := _m.Called(ctx, namespace, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSnapshotVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SnapshotVerificationState) error); ok {
+		r0 = rf(ctx, namespace, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertSubscription provides a mock function with given fields: ctx, data, allowExisting
 func (_m *Plugin) UpsertSubscription(ctx context.Context, data *core.Subscription, allowExisting bool) error {
 	ret := _m.Called(ctx, data, allowExisting)
@@ -282,6 +282,24 @@ func (_m *Plugin) TransferBlob(ctx context.Context, nsOpID string, peer fftypes.
 	return r0
 }
 
+// UpdateNode provides a mock function with given fields: ctx, networkNamespace, nodeName, peer
+func (_m *Plugin) UpdateNode(ctx context.Context, networkNamespace string, nodeName string, peer fftypes.JSONObject) error {
+	ret := _m.Called(ctx, networkNamespace, nodeName, peer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, fftypes.JSONObject) error); ok {
+		r0 = rf(ctx, networkNamespace, nodeName, peer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UploadBlob provides a mock function with given fields: ctx, ns, id, content
 func (_m *Plugin) UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (string, *fftypes.Bytes32, int64, error) {
 	ret := _m.Called(ctx, ns, id, content)
@@ -58,6 +58,36 @@ func (_m *Manager) CheckDatatype(ctx context.Context, datatype *core.Datatype) e
 	return r0
 }
 
+// CreateMessageRetentionPolicy provides a mock function with given fields: ctx, policy
+func (_m *Manager) CreateMessageRetentionPolicy(ctx context.Context, policy *core.MessageRetentionPolicy) (*core.MessageRetentionPolicy, error) {
+	ret := _m.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMessageRetentionPolicy")
+	}
+
+	var r0 *core.MessageRetentionPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageRetentionPolicy) (*core.MessageRetentionPolicy, error)); ok {
+		return rf(ctx, policy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageRetentionPolicy) *core.MessageRetentionPolicy); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.MessageRetentionPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.MessageRetentionPolicy) error); ok {
+		r1 = rf(ctx, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteData provides a mock function with given fields: ctx, dataID
 func (_m *Manager) DeleteData(ctx context.Context, dataID string) error {
 	ret := _m.Called(ctx, dataID)
@@ -76,6 +106,24 @@ func (_m *Manager) DeleteData(ctx context.Context, dataID string) error {
 	return r0
 }
 
+// DeleteMessageRetentionPolicy provides a mock function with given fields: ctx, id
+func (_m *Manager) DeleteMessageRetentionPolicy(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessageRetentionPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DownloadBlob provides a mock function with given fields: ctx, dataID
 func (_m *Manager) DownloadBlob(ctx context.Context, dataID string) (*core.Blob, io.ReadCloser, error) {
 	ret := _m.Called(ctx, dataID)
@@ -115,6 +163,11 @@ func (_m *Manager) DownloadBlob(ctx context.Context, dataID string) (*core.Blob,
 	return r0, r1, r2
 }
 
+// ForgetMissingDatatypes provides a mock function with given fields:
+func (_m *Manager) ForgetMissingDatatypes() {
+	_m.Called()
+}
+
 // GetMessageDataCached provides a mock function with given fields: ctx, msg, options
 func (_m *Manager) GetMessageDataCached(ctx context.Context, msg *core.Message, options ...data.CacheReadOption) (core.DataArray, bool, error) {
 	_va := make([]interface{}, len(options))
@@ -159,6 +212,75 @@ func (_m *Manager) GetMessageDataCached(ctx context.Context, msg *core.Message,
 	return r0, r1, r2
 }
 
+// GetMessageRetentionPolicies provides a mock function with given fields: ctx, filter
+func (_m *Manager) GetMessageRetentionPolicies(ctx context.Context, filter ffapi.AndFilter) ([]*core.MessageRetentionPolicy, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageRetentionPolicies")
+	}
+
+	var r0 []*core.MessageRetentionPolicy
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.MessageRetentionPolicy, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.MessageRetentionPolicy); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.MessageRetentionPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMessageRetentionPolicyByID provides a mock function with given fields: ctx, id
+func (_m *Manager) GetMessageRetentionPolicyByID(ctx context.Context, id string) (*core.MessageRetentionPolicy, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageRetentionPolicyByID")
+	}
+
+	var r0 *core.MessageRetentionPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.MessageRetentionPolicy, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.MessageRetentionPolicy); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.MessageRetentionPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMessageWithDataCached provides a mock function with given fields: ctx, msgID, options
 func (_m *Manager) GetMessageWithDataCached(ctx context.Context, msgID *fftypes.UUID, options ...data.CacheReadOption) (*core.Message, core.DataArray, bool, error) {
 	_va := make([]interface{}, len(options))
@@ -242,6 +364,36 @@ func (_m *Manager) HydrateBatch(ctx context.Context, persistedBatch *core.BatchP
 	return r0, r1
 }
 
+// InferDatatype provides a mock function with given fields: ctx, input
+func (_m *Manager) InferDatatype(ctx context.Context, input *core.DatatypeInference) (*core.Datatype, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InferDatatype")
+	}
+
+	var r0 *core.Datatype
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DatatypeInference) (*core.Datatype, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DatatypeInference) *core.Datatype); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Datatype)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.DatatypeInference) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PeekMessageCache provides a mock function with given fields: ctx, id, options
 func (_m *Manager) PeekMessageCache(ctx context.Context, id *fftypes.UUID, options ...data.CacheReadOption) (*core.Message, core.DataArray) {
 	_va := make([]interface{}, len(options))
@@ -281,6 +433,34 @@ func (_m *Manager) PeekMessageCache(ctx context.Context, id *fftypes.UUID, optio
 	return r0, r1
 }
 
+// PruneMessageData provides a mock function with given fields: ctx, policy, limit
+func (_m *Manager) PruneMessageData(ctx context.Context, policy *core.MessageRetentionPolicy, limit int) (int64, error) {
+	ret := _m.Called(ctx, policy, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneMessageData")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageRetentionPolicy, int) (int64, error)); ok {
+		return rf(ctx, policy, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageRetentionPolicy, int) int64); ok {
+		r0 = rf(ctx, policy, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.MessageRetentionPolicy, int) error); ok {
+		r1 = rf(ctx, policy, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResolveInlineData provides a mock function with given fields: ctx, msg
 func (_m *Manager) ResolveInlineData(ctx context.Context, msg *data.NewMessage) error {
 	ret := _m.Called(ctx, msg)
@@ -299,6 +479,11 @@ func (_m *Manager) ResolveInlineData(ctx context.Context, msg *data.NewMessage)
 	return r0
 }
 
+// SetMessageValidator provides a mock function with given fields: validator
+func (_m *Manager) SetMessageValidator(validator data.MessageValidator) {
+	_m.Called(validator)
+}
+
 // Start provides a mock function with given fields:
 func (_m *Manager) Start() {
 	_m.Called()
@@ -314,6 +499,36 @@ func (_m *Manager) UpdateMessageIfCached(ctx context.Context, msg *core.Message)
 	_m.Called(ctx, msg)
 }
 
+// UpdateMessageRetentionPolicy provides a mock function with given fields: ctx, id, policy
+func (_m *Manager) UpdateMessageRetentionPolicy(ctx context.Context, id string, policy *core.MessageRetentionPolicy) (*core.MessageRetentionPolicy, error) {
+	ret := _m.Called(ctx, id, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMessageRetentionPolicy")
+	}
+
+	var r0 *core.MessageRetentionPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.MessageRetentionPolicy) (*core.MessageRetentionPolicy, error)); ok {
+		return rf(ctx, id, policy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.MessageRetentionPolicy) *core.MessageRetentionPolicy); ok {
+		r0 = rf(ctx, id, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.MessageRetentionPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.MessageRetentionPolicy) error); ok {
+		r1 = rf(ctx, id, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateMessageStateIfCached provides a mock function with given fields: ctx, id, state, confirmed, rejectReason
 func (_m *Manager) UpdateMessageStateIfCached(ctx context.Context, id *fftypes.UUID, state fftypes.FFEnum, confirmed *fftypes.FFTime, rejectReason string) {
 	_m.Called(ctx, id, state, confirmed, rejectReason)
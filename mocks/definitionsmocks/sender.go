@@ -17,6 +17,66 @@ type Sender struct {
 	mock.Mock
 }
 
+// AcknowledgeContractMigration provides a mock function with given fields: ctx, migrationID, waitConfirm
+func (_m *Sender) AcknowledgeContractMigration(ctx context.Context, migrationID string, waitConfirm bool) (*core.Message, error) {
+	ret := _m.Called(ctx, migrationID, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcknowledgeContractMigration")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*core.Message, error)); ok {
+		return rf(ctx, migrationID, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *core.Message); ok {
+		r0 = rf(ctx, migrationID, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, migrationID, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ApproveDefinition provides a mock function with given fields: ctx, msgID, waitConfirm
+func (_m *Sender) ApproveDefinition(ctx context.Context, msgID string, waitConfirm bool) (*core.Message, error) {
+	ret := _m.Called(ctx, msgID, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveDefinition")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*core.Message, error)); ok {
+		return rf(ctx, msgID, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *core.Message); ok {
+		r0 = rf(ctx, msgID, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, msgID, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ClaimIdentity provides a mock function with given fields: ctx, def, signingIdentity, parentSigner
 func (_m *Sender) ClaimIdentity(ctx context.Context, def *core.IdentityClaim, signingIdentity *core.SignerRef, parentSigner *core.SignerRef) error {
 	ret := _m.Called(ctx, def, signingIdentity, parentSigner)
@@ -53,6 +113,24 @@ func (_m *Sender) DefineContractAPI(ctx context.Context, httpServerURL string, a
 	return r0
 }
 
+// DefineCustom provides a mock function with given fields: ctx, tag, def, waitConfirm
+func (_m *Sender) DefineCustom(ctx context.Context, tag string, def core.Definition, waitConfirm bool) error {
+	ret := _m.Called(ctx, tag, def, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DefineCustom")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, core.Definition, bool) error); ok {
+		r0 = rf(ctx, tag, def, waitConfirm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DefineDatatype provides a mock function with given fields: ctx, datatype, waitConfirm
 func (_m *Sender) DefineDatatype(ctx context.Context, datatype *core.Datatype, waitConfirm bool) error {
 	ret := _m.Called(ctx, datatype, waitConfirm)
@@ -71,6 +149,36 @@ func (_m *Sender) DefineDatatype(ctx context.Context, datatype *core.Datatype, w
 	return r0
 }
 
+// DefineDisclosure provides a mock function with given fields: ctx, msgID, input, waitConfirm
+func (_m *Sender) DefineDisclosure(ctx context.Context, msgID string, input *core.DisclosureInput, waitConfirm bool) (*core.Disclosure, error) {
+	ret := _m.Called(ctx, msgID, input, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DefineDisclosure")
+	}
+
+	var r0 *core.Disclosure
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.DisclosureInput, bool) (*core.Disclosure, error)); ok {
+		return rf(ctx, msgID, input, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.DisclosureInput, bool) *core.Disclosure); ok {
+		r0 = rf(ctx, msgID, input, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Disclosure)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.DisclosureInput, bool) error); ok {
+		r1 = rf(ctx, msgID, input, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DefineFFI provides a mock function with given fields: ctx, ffi, waitConfirm
 func (_m *Sender) DefineFFI(ctx context.Context, ffi *fftypes.FFI, waitConfirm bool) error {
 	ret := _m.Called(ctx, ffi, waitConfirm)
@@ -107,6 +215,36 @@ func (_m *Sender) DefineTokenPool(ctx context.Context, pool *core.TokenPool, wai
 	return r0
 }
 
+// GetDefinitionApprovalStatus provides a mock function with given fields: ctx, msgID
+func (_m *Sender) GetDefinitionApprovalStatus(ctx context.Context, msgID string) (*core.DefinitionApprovalStatus, error) {
+	ret := _m.Called(ctx, msgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefinitionApprovalStatus")
+	}
+
+	var r0 *core.DefinitionApprovalStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.DefinitionApprovalStatus, error)); ok {
+		return rf(ctx, msgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.DefinitionApprovalStatus); ok {
+		r0 = rf(ctx, msgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.DefinitionApprovalStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, msgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *Sender) Name() string {
 	ret := _m.Called()
@@ -125,6 +263,36 @@ func (_m *Sender) Name() string {
 	return r0
 }
 
+// ProposeContractMigration provides a mock function with given fields: ctx, proposal, waitConfirm
+func (_m *Sender) ProposeContractMigration(ctx context.Context, proposal *core.ContractMigrationProposal, waitConfirm bool) (*core.ContractMigrationProposal, error) {
+	ret := _m.Called(ctx, proposal, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProposeContractMigration")
+	}
+
+	var r0 *core.ContractMigrationProposal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractMigrationProposal, bool) (*core.ContractMigrationProposal, error)); ok {
+		return rf(ctx, proposal, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractMigrationProposal, bool) *core.ContractMigrationProposal); ok {
+		r0 = rf(ctx, proposal, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractMigrationProposal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.ContractMigrationProposal, bool) error); ok {
+		r1 = rf(ctx, proposal, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PublishContractAPI provides a mock function with given fields: ctx, httpServerURL, name, networkName, waitConfirm
 func (_m *Sender) PublishContractAPI(ctx context.Context, httpServerURL string, name string, networkName string, waitConfirm bool) (*core.ContractAPI, error) {
 	ret := _m.Called(ctx, httpServerURL, name, networkName, waitConfirm)
@@ -11,8 +11,12 @@ import (
 
 	dataexchange "github.com/hyperledger/firefly/pkg/dataexchange"
 
+	events "github.com/hyperledger/firefly/internal/events"
+
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	pkgevents "github.com/hyperledger/firefly/pkg/events"
@@ -47,6 +51,26 @@ func (_m *EventManager) AddSystemEventListener(ns string, el system.EventListene
 	return r0
 }
 
+// AggregatorStatus provides a mock function with given fields:
+func (_m *EventManager) AggregatorStatus() *events.AggregatorStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AggregatorStatus")
+	}
+
+	var r0 *events.AggregatorStatus
+	if rf, ok := ret.Get(0).(func() *events.AggregatorStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*events.AggregatorStatus)
+		}
+	}
+
+	return r0
+}
+
 // BlockchainEventBatch provides a mock function with given fields: batch
 func (_m *EventManager) BlockchainEventBatch(batch []*blockchain.EventToDispatch) error {
 	ret := _m.Called(batch)
@@ -309,11 +333,129 @@ func (_m *EventManager) NewSubscriptions() chan<- *fftypes.UUID {
 	return r0
 }
 
+// OperationUpdates provides a mock function with given fields:
+func (_m *EventManager) OperationUpdates() chan<- *fftypes.UUID {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OperationUpdates")
+	}
+
+	var r0 chan<- *fftypes.UUID
+	if rf, ok := ret.Get(0).(func() chan<- *fftypes.UUID); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(chan<- *fftypes.UUID)
+		}
+	}
+
+	return r0
+}
+
+// PauseIngestion provides a mock function with given fields:
+func (_m *EventManager) PauseIngestion() func() {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseIngestion")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func() func()); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// PollDurableSubscriptionEvents provides a mock function with given fields: ctx, subDef, limit
+func (_m *EventManager) PollDurableSubscriptionEvents(ctx context.Context, subDef *core.Subscription, limit uint64) ([]*core.EnrichedEvent, error) {
+	ret := _m.Called(ctx, subDef, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PollDurableSubscriptionEvents")
+	}
+
+	var r0 []*core.EnrichedEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, uint64) ([]*core.EnrichedEvent, error)); ok {
+		return rf(ctx, subDef, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, uint64) []*core.EnrichedEvent); ok {
+		r0 = rf(ctx, subDef, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.EnrichedEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.Subscription, uint64) error); ok {
+		r1 = rf(ctx, subDef, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // QueueBatchRewind provides a mock function with given fields: batchID
 func (_m *EventManager) QueueBatchRewind(batchID *fftypes.UUID) {
 	_m.Called(batchID)
 }
 
+// RebuildDerivedState provides a mock function with given fields: ctx, target, progress
+func (_m *EventManager) RebuildDerivedState(ctx context.Context, target fftypes.FFEnum, progress func(int64, int64)) error {
+	ret := _m.Called(ctx, target, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebuildDerivedState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, func(int64, int64)) error); ok {
+		r0 = rf(ctx, target, progress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseMessage provides a mock function with given fields: ctx, msgID
+func (_m *EventManager) ReleaseMessage(ctx context.Context, msgID *fftypes.UUID) (*core.Message, error) {
+	ret := _m.Called(ctx, msgID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseMessage")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID) (*core.Message, error)); ok {
+		return rf(ctx, msgID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID) *core.Message); ok {
+		r0 = rf(ctx, msgID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, msgID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResolveTransportAndCapabilities provides a mock function with given fields: ctx, transportName
 func (_m *EventManager) ResolveTransportAndCapabilities(ctx context.Context, transportName string) (string, *pkgevents.Capabilities, error) {
 	ret := _m.Called(ctx, transportName)
@@ -351,9 +493,67 @@ func (_m *EventManager) ResolveTransportAndCapabilities(ctx context.Context, tra
 	return r0, r1, r2
 }
 
-// SharedStorageBatchDownloaded provides a mock function with given fields: ss, payloadRef, data
-func (_m *EventManager) SharedStorageBatchDownloaded(ss sharedstorage.Plugin, payloadRef string, data []byte) (*fftypes.UUID, error) {
-	ret := _m.Called(ss, payloadRef, data)
+// SetDurableSubscriptionOffset provides a mock function with given fields: ctx, subDef, offset
+func (_m *EventManager) SetDurableSubscriptionOffset(ctx context.Context, subDef *core.Subscription, offset int64) error {
+	ret := _m.Called(ctx, subDef, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDurableSubscriptionOffset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, int64) error); ok {
+		r0 = rf(ctx, subDef, offset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetEventTransformer provides a mock function with given fields: transformer
+func (_m *EventManager) SetEventTransformer(transformer events.EventTransformer) {
+	_m.Called(transformer)
+}
+
+// SetRequiredConfirmations provides a mock function with given fields: required
+func (_m *EventManager) SetRequiredConfirmations(required int) {
+	_m.Called(required)
+}
+
+// SharedStorageBatchDeliver provides a mock function with given fields: batch
+func (_m *EventManager) SharedStorageBatchDeliver(batch *core.Batch) (*fftypes.UUID, error) {
+	ret := _m.Called(batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SharedStorageBatchDeliver")
+	}
+
+	var r0 *fftypes.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*core.Batch) (*fftypes.UUID, error)); ok {
+		return rf(batch)
+	}
+	if rf, ok := ret.Get(0).(func(*core.Batch) *fftypes.UUID); ok {
+		r0 = rf(batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fftypes.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*core.Batch) error); ok {
+		r1 = rf(batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SharedStorageBatchDownloaded provides a mock function with given fields: ss, payloadRef, reader
+func (_m *EventManager) SharedStorageBatchDownloaded(ss sharedstorage.Plugin, payloadRef string, reader io.Reader) (*fftypes.UUID, error) {
+	ret := _m.Called(ss, payloadRef, reader)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SharedStorageBatchDownloaded")
@@ -361,19 +561,49 @@ func (_m *EventManager) SharedStorageBatchDownloaded(ss sharedstorage.Plugin, pa
 
 	var r0 *fftypes.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(sharedstorage.Plugin, string, []byte) (*fftypes.UUID, error)); ok {
-		return rf(ss, payloadRef, data)
+	if rf, ok := ret.Get(0).(func(sharedstorage.Plugin, string, io.Reader) (*fftypes.UUID, error)); ok {
+		return rf(ss, payloadRef, reader)
 	}
-	if rf, ok := ret.Get(0).(func(sharedstorage.Plugin, string, []byte) *fftypes.UUID); ok {
-		r0 = rf(ss, payloadRef, data)
+	if rf, ok := ret.Get(0).(func(sharedstorage.Plugin, string, io.Reader) *fftypes.UUID); ok {
+		r0 = rf(ss, payloadRef, reader)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*fftypes.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(sharedstorage.Plugin, string, []byte) error); ok {
-		r1 = rf(ss, payloadRef, data)
+	if rf, ok := ret.Get(1).(func(sharedstorage.Plugin, string, io.Reader) error); ok {
+		r1 = rf(ss, payloadRef, reader)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SharedStorageBatchVerify provides a mock function with given fields: ss, payloadRef, reader
+func (_m *EventManager) SharedStorageBatchVerify(ss sharedstorage.Plugin, payloadRef string, reader io.Reader) (*core.Batch, error) {
+	ret := _m.Called(ss, payloadRef, reader)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SharedStorageBatchVerify")
+	}
+
+	var r0 *core.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(sharedstorage.Plugin, string, io.Reader) (*core.Batch, error)); ok {
+		return rf(ss, payloadRef, reader)
+	}
+	if rf, ok := ret.Get(0).(func(sharedstorage.Plugin, string, io.Reader) *core.Batch); ok {
+		r0 = rf(ss, payloadRef, reader)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(sharedstorage.Plugin, string, io.Reader) error); ok {
+		r1 = rf(ss, payloadRef, reader)
 	} else {
 		r1 = ret.Error(1)
 	}
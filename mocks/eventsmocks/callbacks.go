@@ -6,6 +6,8 @@ import (
 	core "github.com/hyperledger/firefly/pkg/core"
 	events "github.com/hyperledger/firefly/pkg/events"
 
+	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -60,6 +62,29 @@ func (_m *Callbacks) RegisterConnection(connID string, matcher events.Subscripti
 	return r0
 }
 
+// UnwatchOperations provides a mock function with given fields: connID, operations, transactions
+func (_m *Callbacks) UnwatchOperations(connID string, operations []*fftypes.UUID, transactions []*fftypes.UUID) {
+	_m.Called(connID, operations, transactions)
+}
+
+// WatchOperations provides a mock function with given fields: connID, operations, transactions, watcher
+func (_m *Callbacks) WatchOperations(connID string, operations []*fftypes.UUID, transactions []*fftypes.UUID, watcher events.OperationWatcher) error {
+	ret := _m.Called(connID, operations, transactions, watcher)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchOperations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []*fftypes.UUID, []*fftypes.UUID, events.OperationWatcher) error); ok {
+		r0 = rf(connID, operations, transactions, watcher)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCallbacks creates a new instance of Callbacks. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCallbacks(t interface {
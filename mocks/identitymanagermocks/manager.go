@@ -153,6 +153,11 @@ func (_m *Manager) FindIdentityForVerifier(ctx context.Context, iTypes []fftypes
 	return r0, r1
 }
 
+// ForgetMissingIdentities provides a mock function with given fields:
+func (_m *Manager) ForgetMissingIdentities() {
+	_m.Called()
+}
+
 // GetLocalNode provides a mock function with given fields: ctx
 func (_m *Manager) GetLocalNode(ctx context.Context) (*core.Identity, error) {
 	ret := _m.Called(ctx)
@@ -269,6 +274,36 @@ func (_m *Manager) GetRootOrgDID(ctx context.Context) (string, error) {
 	return r0, r1
 }
 
+// IssueCredential provides a mock function with given fields: ctx, req
+func (_m *Manager) IssueCredential(ctx context.Context, req *core.CredentialIssueRequest) (*core.IssuedCredential, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueCredential")
+	}
+
+	var r0 *core.IssuedCredential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.CredentialIssueRequest) (*core.IssuedCredential, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.CredentialIssueRequest) *core.IssuedCredential); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.IssuedCredential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.CredentialIssueRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResolveIdentitySigner provides a mock function with given fields: ctx, _a1
 func (_m *Manager) ResolveIdentitySigner(ctx context.Context, _a1 *core.Identity) (*core.SignerRef, error) {
 	ret := _m.Called(ctx, _a1)
@@ -433,6 +468,66 @@ func (_m *Manager) ResolveQuerySigningKey(ctx context.Context, inputKey string,
 	return r0, r1
 }
 
+// SignPayload provides a mock function with given fields: ctx, hash
+func (_m *Manager) SignPayload(ctx context.Context, hash *fftypes.Bytes32) (*core.PayloadSignature, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignPayload")
+	}
+
+	var r0 *core.PayloadSignature
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.Bytes32) (*core.PayloadSignature, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.Bytes32) *core.PayloadSignature); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.PayloadSignature)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *fftypes.Bytes32) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignTypedData provides a mock function with given fields: ctx, req
+func (_m *Manager) SignTypedData(ctx context.Context, req *core.TypedDataSignRequest) (*core.TypedDataSignature, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignTypedData")
+	}
+
+	var r0 *core.TypedDataSignature
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.TypedDataSignRequest) (*core.TypedDataSignature, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.TypedDataSignRequest) *core.TypedDataSignature); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.TypedDataSignature)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.TypedDataSignRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateNodeOwner provides a mock function with given fields: ctx, node, _a2
 func (_m *Manager) ValidateNodeOwner(ctx context.Context, node *core.Identity, _a2 *core.Identity) (bool, error) {
 	ret := _m.Called(ctx, node, _a2)
@@ -498,6 +593,52 @@ func (_m *Manager) VerifyIdentityChain(ctx context.Context, _a1 *core.Identity)
 	return r0, r1, r2
 }
 
+// VerifyOrgPayloadSignature provides a mock function with given fields: ctx, hash, signature
+func (_m *Manager) VerifyOrgPayloadSignature(ctx context.Context, hash *fftypes.Bytes32, signature *core.PayloadSignature) error {
+	ret := _m.Called(ctx, hash, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyOrgPayloadSignature")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.Bytes32, *core.PayloadSignature) error); ok {
+		r0 = rf(ctx, hash, signature)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyPayloadSignature provides a mock function with given fields: ctx, author, manifest
+func (_m *Manager) VerifyPayloadSignature(ctx context.Context, author string, manifest *core.BatchManifest) (bool, error) {
+	ret := _m.Called(ctx, author, manifest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyPayloadSignature")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.BatchManifest) (bool, error)); ok {
+		return rf(ctx, author, manifest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.BatchManifest) bool); ok {
+		r0 = rf(ctx, author, manifest)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.BatchManifest) error); ok {
+		r1 = rf(ctx, author, manifest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
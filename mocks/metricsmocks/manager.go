@@ -23,6 +23,11 @@ func (_m *Manager) AddTime(id string) {
 	_m.Called(id)
 }
 
+// AggregatorPinsProcessed provides a mock function with given fields: namespace, count, lag
+func (_m *Manager) AggregatorPinsProcessed(namespace string, count int, lag int64) {
+	_m.Called(namespace, count, lag)
+}
+
 // BlockchainContractDeployment provides a mock function with given fields:
 func (_m *Manager) BlockchainContractDeployment() {
 	_m.Called()
@@ -33,6 +38,11 @@ func (_m *Manager) BlockchainEvent(location string, signature string) {
 	_m.Called(location, signature)
 }
 
+// BlockchainFee provides a mock function with given fields: namespace, key, gasUsed, fee
+func (_m *Manager) BlockchainFee(namespace string, key string, gasUsed float64, fee float64) {
+	_m.Called(namespace, key, gasUsed, fee)
+}
+
 // BlockchainQuery provides a mock function with given fields: location, methodName
 func (_m *Manager) BlockchainQuery(location string, methodName string) {
 	_m.Called(location, methodName)
@@ -43,6 +53,16 @@ func (_m *Manager) BlockchainTransaction(location string, methodName string) {
 	_m.Called(location, methodName)
 }
 
+// CacheLookup provides a mock function with given fields: namespace, cache, hit
+func (_m *Manager) CacheLookup(namespace string, cache string, hit bool) {
+	_m.Called(namespace, cache, hit)
+}
+
+// CircuitBreakerState provides a mock function with given fields: namespace, plugin, state
+func (_m *Manager) CircuitBreakerState(namespace string, plugin string, state fftypes.FFEnum) {
+	_m.Called(namespace, plugin, state)
+}
+
 // CountBatchPin provides a mock function with given fields: namespace
 func (_m *Manager) CountBatchPin(namespace string) {
 	_m.Called(namespace)
@@ -53,6 +73,21 @@ func (_m *Manager) DeleteTime(id string) {
 	_m.Called(id)
 }
 
+// DXTransferCompleted provides a mock function with given fields: nsOpID, transferType, status
+func (_m *Manager) DXTransferCompleted(nsOpID string, transferType string, status core.OpStatus) {
+	_m.Called(nsOpID, transferType, status)
+}
+
+// DXTransferSubmitted provides a mock function with given fields: nsOpID
+func (_m *Manager) DXTransferSubmitted(nsOpID string) {
+	_m.Called(nsOpID)
+}
+
+// EventDelivered provides a mock function with given fields: namespace, transport, elapsed
+func (_m *Manager) EventDelivered(namespace string, transport string, elapsed time.Duration) {
+	_m.Called(namespace, transport, elapsed)
+}
+
 // GetTime provides a mock function with given fields: id
 func (_m *Manager) GetTime(id string) time.Time {
 	ret := _m.Called(id)
@@ -109,6 +144,31 @@ func (_m *Manager) NodeIdentityDXCertMismatch(namespace string, mismatch metrics
 	_m.Called(namespace, mismatch)
 }
 
+// RateLimitChecked provides a mock function with given fields: namespace, group, allowed
+func (_m *Manager) RateLimitChecked(namespace string, group string, allowed bool) {
+	_m.Called(namespace, group, allowed)
+}
+
+// RecordsPruned provides a mock function with given fields: namespace, collection, count
+func (_m *Manager) RecordsPruned(namespace string, collection string, count int64) {
+	_m.Called(namespace, collection, count)
+}
+
+// SubscriptionClosed provides a mock function with given fields: namespace, subscription
+func (_m *Manager) SubscriptionClosed(namespace string, subscription string) {
+	_m.Called(namespace, subscription)
+}
+
+// SubscriptionInflight provides a mock function with given fields: namespace, subscription, count
+func (_m *Manager) SubscriptionInflight(namespace string, subscription string, count int) {
+	_m.Called(namespace, subscription, count)
+}
+
+// SubscriptionRedelivery provides a mock function with given fields: namespace, subscription
+func (_m *Manager) SubscriptionRedelivery(namespace string, subscription string) {
+	_m.Called(namespace, subscription)
+}
+
 // TransferConfirmed provides a mock function with given fields: transfer
 func (_m *Manager) TransferConfirmed(transfer *core.TokenTransfer) {
 	_m.Called(transfer)
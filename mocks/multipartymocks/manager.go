@@ -14,6 +14,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	multiparty "github.com/hyperledger/firefly/internal/multiparty"
+
+	time "time"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -21,6 +23,89 @@ type Manager struct {
 	mock.Mock
 }
 
+// ApplyScheduledNetworkActions provides a mock function with given fields: ctx, scheduled, event
+func (_m *Manager) ApplyScheduledNetworkActions(ctx context.Context, scheduled []*core.ScheduledNetworkAction, event *blockchain.Event) ([]*core.ScheduledNetworkAction, error) {
+	ret := _m.Called(ctx, scheduled, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyScheduledNetworkActions")
+	}
+
+	var r0 []*core.ScheduledNetworkAction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.ScheduledNetworkAction, *blockchain.Event) ([]*core.ScheduledNetworkAction, error)); ok {
+		return rf(ctx, scheduled, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.ScheduledNetworkAction, *blockchain.Event) []*core.ScheduledNetworkAction); ok {
+		r0 = rf(ctx, scheduled, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ScheduledNetworkAction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*core.ScheduledNetworkAction, *blockchain.Event) error); ok {
+		r1 = rf(ctx, scheduled, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckAuditAnchorPin provides a mock function with given fields: ctx, anchor, maxAttempts, retryDelay
+func (_m *Manager) CheckAuditAnchorPin(ctx context.Context, anchor *core.AuditAnchor, maxAttempts int, retryDelay time.Duration) (bool, bool, error) {
+	ret := _m.Called(ctx, anchor, maxAttempts, retryDelay)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAuditAnchorPin")
+	}
+
+	var r0 bool
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AuditAnchor, int, time.Duration) (bool, bool, error)); ok {
+		return rf(ctx, anchor, maxAttempts, retryDelay)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AuditAnchor, int, time.Duration) bool); ok {
+		r0 = rf(ctx, anchor, maxAttempts, retryDelay)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.AuditAnchor, int, time.Duration) bool); ok {
+		r1 = rf(ctx, anchor, maxAttempts, retryDelay)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *core.AuditAnchor, int, time.Duration) error); ok {
+		r2 = rf(ctx, anchor, maxAttempts, retryDelay)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CheckContractMigration provides a mock function with given fields: ctx, event
+func (_m *Manager) CheckContractMigration(ctx context.Context, event *blockchain.Event) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckContractMigration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *blockchain.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ConfigureContract provides a mock function with given fields: ctx
 func (_m *Manager) ConfigureContract(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -39,6 +124,26 @@ func (_m *Manager) ConfigureContract(ctx context.Context) error {
 	return r0
 }
 
+// GetContractMigration provides a mock function with given fields:
+func (_m *Manager) GetContractMigration() *core.ContractMigration {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractMigration")
+	}
+
+	var r0 *core.ContractMigration
+	if rf, ok := ret.Get(0).(func() *core.ContractMigration); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractMigration)
+		}
+	}
+
+	return r0
+}
+
 // GetNetworkVersion provides a mock function with given fields:
 func (_m *Manager) GetNetworkVersion() int {
 	ret := _m.Called()
@@ -57,6 +162,26 @@ func (_m *Manager) GetNetworkVersion() int {
 	return r0
 }
 
+// GetScheduledNetworkActions provides a mock function with given fields:
+func (_m *Manager) GetScheduledNetworkActions() []*core.ScheduledNetworkAction {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduledNetworkActions")
+	}
+
+	var r0 []*core.ScheduledNetworkAction
+	if rf, ok := ret.Get(0).(func() []*core.ScheduledNetworkAction); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ScheduledNetworkAction)
+		}
+	}
+
+	return r0
+}
+
 // LocalNode provides a mock function with given fields:
 func (_m *Manager) LocalNode() multiparty.LocalNode {
 	ret := _m.Called()
@@ -93,6 +218,24 @@ func (_m *Manager) Name() string {
 	return r0
 }
 
+// PersistScheduledNetworkActions provides a mock function with given fields: ctx, scheduled
+func (_m *Manager) PersistScheduledNetworkActions(ctx context.Context, scheduled []*core.ScheduledNetworkAction) error {
+	ret := _m.Called(ctx, scheduled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PersistScheduledNetworkActions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.ScheduledNetworkAction) error); ok {
+		r0 = rf(ctx, scheduled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PrepareOperation provides a mock function with given fields: ctx, op
 func (_m *Manager) PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error) {
 	ret := _m.Called(ctx, op)
@@ -178,6 +321,77 @@ func (_m *Manager) RunOperation(ctx context.Context, op *core.PreparedOperation)
 	return r0, r1, r2
 }
 
+// ScheduleNetworkAction provides a mock function with given fields: ctx, scheduled, action, event
+func (_m *Manager) ScheduleNetworkAction(ctx context.Context, scheduled []*core.ScheduledNetworkAction, action *core.ScheduledNetworkAction, event *blockchain.Event) ([]*core.ScheduledNetworkAction, bool) {
+	ret := _m.Called(ctx, scheduled, action, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleNetworkAction")
+	}
+
+	var r0 []*core.ScheduledNetworkAction
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.ScheduledNetworkAction, *core.ScheduledNetworkAction, *blockchain.Event) ([]*core.ScheduledNetworkAction, bool)); ok {
+		return rf(ctx, scheduled, action, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.ScheduledNetworkAction, *core.ScheduledNetworkAction, *blockchain.Event) []*core.ScheduledNetworkAction); ok {
+		r0 = rf(ctx, scheduled, action, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ScheduledNetworkAction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*core.ScheduledNetworkAction, *core.ScheduledNetworkAction, *blockchain.Event) bool); ok {
+		r1 = rf(ctx, scheduled, action, event)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// SetContractMigration provides a mock function with given fields: ctx, migration
+func (_m *Manager) SetContractMigration(ctx context.Context, migration *core.ContractMigration) error {
+	ret := _m.Called(ctx, migration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetContractMigration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractMigration) error); ok {
+		r0 = rf(ctx, migration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetScheduledNetworkActions provides a mock function with given fields: scheduled
+func (_m *Manager) SetScheduledNetworkActions(scheduled []*core.ScheduledNetworkAction) {
+	_m.Called(scheduled)
+}
+
+// SubmitAuditAnchor provides a mock function with given fields: ctx, signingKey, anchor
+func (_m *Manager) SubmitAuditAnchor(ctx context.Context, signingKey string, anchor *core.AuditAnchor) error {
+	ret := _m.Called(ctx, signingKey, anchor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitAuditAnchor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.AuditAnchor) error); ok {
+		r0 = rf(ctx, signingKey, anchor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SubmitBatchPin provides a mock function with given fields: ctx, batch, contexts, payloadRef, idempotentSubmit
 func (_m *Manager) SubmitBatchPin(ctx context.Context, batch *core.BatchPersisted, contexts []*fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error {
 	ret := _m.Called(ctx, batch, contexts, payloadRef, idempotentSubmit)
@@ -232,6 +446,24 @@ func (_m *Manager) TerminateContract(ctx context.Context, location *fftypes.JSON
 	return r0
 }
 
+// ValidateContractMigration provides a mock function with given fields: ctx, location, version
+func (_m *Manager) ValidateContractMigration(ctx context.Context, location *fftypes.JSONAny, version int) error {
+	ret := _m.Called(ctx, location, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateContractMigration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.JSONAny, int) error); ok {
+		r0 = rf(ctx, location, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
@@ -38,6 +38,86 @@ func (_m *Manager) Authorize(ctx context.Context, authReq *fftypes.AuthReq) erro
 	return r0
 }
 
+// CloneNamespace provides a mock function with given fields: ctx, source, clone
+func (_m *Manager) CloneNamespace(ctx context.Context, source string, clone *core.NamespaceClone) (*core.NamespaceCloneResult, error) {
+	ret := _m.Called(ctx, source, clone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloneNamespace")
+	}
+
+	var r0 *core.NamespaceCloneResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.NamespaceClone) (*core.NamespaceCloneResult, error)); ok {
+		return rf(ctx, source, clone)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.NamespaceClone) *core.NamespaceCloneResult); ok {
+		r0 = rf(ctx, source, clone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NamespaceCloneResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.NamespaceClone) error); ok {
+		r1 = rf(ctx, source, clone)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDiagnosticsBundle provides a mock function with given fields: ctx
+func (_m *Manager) GetDiagnosticsBundle(ctx context.Context) ([]byte, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDiagnosticsBundle")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]byte, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []byte); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCaches provides a mock function with given fields: ctx
+func (_m *Manager) GetCaches(ctx context.Context) []*core.CacheStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCaches")
+	}
+
+	var r0 []*core.CacheStatus
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.CacheStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.CacheStatus)
+		}
+	}
+
+	return r0
+}
+
 // GetNamespaces provides a mock function with given fields: ctx, includeInitializing
 func (_m *Manager) GetNamespaces(ctx context.Context, includeInitializing bool) ([]*core.NamespaceWithInitStatus, error) {
 	ret := _m.Called(ctx, includeInitializing)
@@ -116,6 +196,42 @@ func (_m *Manager) Init(ctx context.Context, cancelCtx context.CancelFunc, reset
 	return r0
 }
 
+// InvalidateCache provides a mock function with given fields: ctx, name
+func (_m *Manager) InvalidateCache(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateCache")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvalidateCacheKey provides a mock function with given fields: ctx, name, key
+func (_m *Manager) InvalidateCacheKey(ctx context.Context, name string, key string) error {
+	ret := _m.Called(ctx, name, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvalidateCacheKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MustOrchestrator provides a mock function with given fields: ns
 func (_m *Manager) MustOrchestrator(ns string) orchestrator.Orchestrator {
 	ret := _m.Called(ns)
@@ -166,6 +282,36 @@ func (_m *Manager) Orchestrator(ctx context.Context, ns string, includeInitializ
 	return r0, r1
 }
 
+// PreviewConfigReload provides a mock function with given fields: ctx
+func (_m *Manager) PreviewConfigReload(ctx context.Context) (*core.ConfigReloadPreview, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewConfigReload")
+	}
+
+	var r0 *core.ConfigReloadPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.ConfigReloadPreview, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.ConfigReloadPreview); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ConfigReloadPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Reset provides a mock function with given fields: ctx
 func (_m *Manager) Reset(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -240,6 +386,36 @@ func (_m *Manager) Start() error {
 	return r0
 }
 
+// UpdateNamespaceConfig provides a mock function with given fields: ctx, name, update
+func (_m *Manager) UpdateNamespaceConfig(ctx context.Context, name string, update *core.NamespaceConfigUpdate) (*core.NamespaceConfigUpdateResult, error) {
+	ret := _m.Called(ctx, name, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNamespaceConfig")
+	}
+
+	var r0 *core.NamespaceConfigUpdateResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.NamespaceConfigUpdate) (*core.NamespaceConfigUpdateResult, error)); ok {
+		return rf(ctx, name, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.NamespaceConfigUpdate) *core.NamespaceConfigUpdateResult); ok {
+		r0 = rf(ctx, name, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NamespaceConfigUpdateResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.NamespaceConfigUpdate) error); ok {
+		r1 = rf(ctx, name, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WaitStop provides a mock function with given fields:
 func (_m *Manager) WaitStop() {
 	_m.Called()
@@ -11,6 +11,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	networkmap "github.com/hyperledger/firefly/internal/networkmap"
+
+	retry "github.com/hyperledger/firefly-common/pkg/retry"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -18,6 +20,24 @@ type Manager struct {
 	mock.Mock
 }
 
+// Bootstrap provides a mock function with given fields: ctx, _a1
+func (_m *Manager) Bootstrap(ctx context.Context, _a1 *retry.Retry) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bootstrap")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *retry.Retry) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckNodeIdentityStatus provides a mock function with given fields: ctx
 func (_m *Manager) CheckNodeIdentityStatus(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -36,6 +56,26 @@ func (_m *Manager) CheckNodeIdentityStatus(ctx context.Context) error {
 	return r0
 }
 
+// GetBootstrapStatus provides a mock function with given fields: ctx
+func (_m *Manager) GetBootstrapStatus(ctx context.Context) *core.NamespaceBootstrapStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBootstrapStatus")
+	}
+
+	var r0 *core.NamespaceBootstrapStatus
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceBootstrapStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NamespaceBootstrapStatus)
+		}
+	}
+
+	return r0
+}
+
 // GetDIDDocForIndentityByDID provides a mock function with given fields: ctx, did
 func (_m *Manager) GetDIDDocForIndentityByDID(ctx context.Context, did string) (*networkmap.DIDDocument, error) {
 	ret := _m.Called(ctx, did)
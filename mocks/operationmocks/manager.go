@@ -8,6 +8,7 @@ import (
 	core "github.com/hyperledger/firefly/pkg/core"
 	database "github.com/hyperledger/firefly/pkg/database"
 
+	ffapi "github.com/hyperledger/firefly-common/pkg/ffapi"
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
 
 	mock "github.com/stretchr/testify/mock"
@@ -45,6 +46,36 @@ func (_m *Manager) AddOrReuseOperation(ctx context.Context, op *core.Operation,
 	return r0
 }
 
+// ApproveOperation provides a mock function with given fields: ctx, approvalID, approver
+func (_m *Manager) ApproveOperation(ctx context.Context, approvalID *fftypes.UUID, approver string) (*core.OperationApproval, error) {
+	ret := _m.Called(ctx, approvalID, approver)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveOperation")
+	}
+
+	var r0 *core.OperationApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, string) (*core.OperationApproval, error)); ok {
+		return rf(ctx, approvalID, approver)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, string) *core.OperationApproval); ok {
+		r0 = rf(ctx, approvalID, approver)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.OperationApproval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *fftypes.UUID, string) error); ok {
+		r1 = rf(ctx, approvalID, approver)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BulkInsertOperations provides a mock function with given fields: ctx, ops
 func (_m *Manager) BulkInsertOperations(ctx context.Context, ops ...*core.Operation) error {
 	_va := make([]interface{}, len(ops))
@@ -70,6 +101,56 @@ func (_m *Manager) BulkInsertOperations(ctx context.Context, ops ...*core.Operat
 	return r0
 }
 
+// BulkRetryOperations provides a mock function with given fields: ctx, filter, req
+func (_m *Manager) BulkRetryOperations(ctx context.Context, filter ffapi.AndFilter, req *core.BulkOperationRetry) (*core.BulkOperationRetryResult, error) {
+	ret := _m.Called(ctx, filter, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkRetryOperations")
+	}
+
+	var r0 *core.BulkOperationRetryResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, *core.BulkOperationRetry) (*core.BulkOperationRetryResult, error)); ok {
+		return rf(ctx, filter, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, *core.BulkOperationRetry) *core.BulkOperationRetryResult); ok {
+		r0 = rf(ctx, filter, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BulkOperationRetryResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter, *core.BulkOperationRetry) error); ok {
+		r1 = rf(ctx, filter, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCircuitBreakers provides a mock function with given fields:
+func (_m *Manager) GetCircuitBreakers() []*core.CircuitBreakerStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCircuitBreakers")
+	}
+
+	var r0 []*core.CircuitBreakerStatus
+	if rf, ok := ret.Get(0).(func() []*core.CircuitBreakerStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.CircuitBreakerStatus)
+		}
+	}
+
+	return r0
+}
+
 // GetOperationByIDCached provides a mock function with given fields: ctx, opID
 func (_m *Manager) GetOperationByIDCached(ctx context.Context, opID *fftypes.UUID) (*core.Operation, error) {
 	ret := _m.Called(ctx, opID)
@@ -100,6 +181,26 @@ func (_m *Manager) GetOperationByIDCached(ctx context.Context, opID *fftypes.UUI
 	return r0, r1
 }
 
+// GetOutputSchemas provides a mock function with given fields:
+func (_m *Manager) GetOutputSchemas() []*core.OperationOutputSchema {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutputSchemas")
+	}
+
+	var r0 []*core.OperationOutputSchema
+	if rf, ok := ret.Get(0).(func() []*core.OperationOutputSchema); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.OperationOutputSchema)
+		}
+	}
+
+	return r0
+}
+
 // PrepareOperation provides a mock function with given fields: ctx, op
 func (_m *Manager) PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error) {
 	ret := _m.Called(ctx, op)
@@ -135,6 +236,24 @@ func (_m *Manager) RegisterHandler(ctx context.Context, handler operations.Opera
 	_m.Called(ctx, handler, ops)
 }
 
+// RegisterOutputSchemas provides a mock function with given fields: ctx, plugin
+func (_m *Manager) RegisterOutputSchemas(ctx context.Context, plugin core.OperationOutputSchemaProvider) error {
+	ret := _m.Called(ctx, plugin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterOutputSchemas")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, core.OperationOutputSchemaProvider) error); ok {
+		r0 = rf(ctx, plugin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResolveOperationByID provides a mock function with given fields: ctx, opID, op
 func (_m *Manager) ResolveOperationByID(ctx context.Context, opID *fftypes.UUID, op *core.OperationUpdateDTO) error {
 	ret := _m.Called(ctx, opID, op)
@@ -36,7 +36,11 @@ import (
 
 	operations "github.com/hyperledger/firefly/internal/operations"
 
+	policy "github.com/hyperledger/firefly/pkg/policy"
+
 	privatemessaging "github.com/hyperledger/firefly/internal/privatemessaging"
+
+	archive "github.com/hyperledger/firefly/internal/archive"
 )
 
 // Orchestrator is an autogenerated mock type for the Orchestrator type
@@ -44,6 +48,56 @@ type Orchestrator struct {
 	mock.Mock
 }
 
+// AckSubscriptionEvents provides a mock function with given fields: ctx, id, ack
+func (_m *Orchestrator) AckSubscriptionEvents(ctx context.Context, id string, ack *core.SubscriptionEventsAck) (*core.SubscriptionOffset, error) {
+	ret := _m.Called(ctx, id, ack)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AckSubscriptionEvents")
+	}
+
+	var r0 *core.SubscriptionOffset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SubscriptionEventsAck) (*core.SubscriptionOffset, error)); ok {
+		return rf(ctx, id, ack)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SubscriptionEventsAck) *core.SubscriptionOffset); ok {
+		r0 = rf(ctx, id, ack)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SubscriptionOffset)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.SubscriptionEventsAck) error); ok {
+		r1 = rf(ctx, id, ack)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Archive provides a mock function with given fields:
+func (_m *Orchestrator) Archive() archive.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 archive.Manager
+	if rf, ok := ret.Get(0).(func() archive.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(archive.Manager)
+		}
+	}
+
+	return r0
+}
+
 // Assets provides a mock function with given fields:
 func (_m *Orchestrator) Assets() assets.Manager {
 	ret := _m.Called()
@@ -64,6 +118,24 @@ func (_m *Orchestrator) Assets() assets.Manager {
 	return r0
 }
 
+// AuditAPIRequest provides a mock function with given fields: ctx, record
+func (_m *Orchestrator) AuditAPIRequest(ctx context.Context, record *core.AuditRecord) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditAPIRequest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AuditRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Authorize provides a mock function with given fields: ctx, authReq
 func (_m *Orchestrator) Authorize(ctx context.Context, authReq *fftypes.AuthReq) error {
 	ret := _m.Called(ctx, authReq)
@@ -122,6 +194,90 @@ func (_m *Orchestrator) Broadcast() broadcast.Manager {
 	return r0
 }
 
+// CheckPolicy provides a mock function with given fields: ctx, req
+func (_m *Orchestrator) CheckPolicy(ctx context.Context, req *policy.Request) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckPolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *policy.Request) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckQuota provides a mock function with given fields: ctx, quotaType, requested
+func (_m *Orchestrator) CheckQuota(ctx context.Context, quotaType fftypes.FFEnum, requested int64) error {
+	ret := _m.Called(ctx, quotaType, requested)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckQuota")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, int64) error); ok {
+		r0 = rf(ctx, quotaType, requested)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckRateLimit provides a mock function with given fields: ctx, group, identity
+func (_m *Orchestrator) CheckRateLimit(ctx context.Context, group fftypes.FFEnum, identity string) (*core.RateLimitStatus, error) {
+	ret := _m.Called(ctx, group, identity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckRateLimit")
+	}
+
+	var r0 *core.RateLimitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, string) (*core.RateLimitStatus, error)); ok {
+		return rf(ctx, group, identity)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, string) *core.RateLimitStatus); ok {
+		r0 = rf(ctx, group, identity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.RateLimitStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, string) error); ok {
+		r1 = rf(ctx, group, identity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckWritable provides a mock function with given fields: ctx
+func (_m *Orchestrator) CheckWritable(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckWritable")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Contracts provides a mock function with given fields:
 func (_m *Orchestrator) Contracts() contracts.Manager {
 	ret := _m.Called()
@@ -260,6 +416,36 @@ func (_m *Orchestrator) DeleteSubscription(ctx context.Context, id string) error
 	return r0
 }
 
+// EstimateRetention provides a mock function with given fields: ctx
+func (_m *Orchestrator) EstimateRetention(ctx context.Context) (*core.RetentionEstimate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateRetention")
+	}
+
+	var r0 *core.RetentionEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.RetentionEstimate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.RetentionEstimate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.RetentionEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Events provides a mock function with given fields:
 func (_m *Orchestrator) Events() events.EventManager {
 	ret := _m.Called()
@@ -280,68 +466,56 @@ func (_m *Orchestrator) Events() events.EventManager {
 	return r0
 }
 
-// GetBatchByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetBatchByID(ctx context.Context, id string) (*core.BatchPersisted, error) {
-	ret := _m.Called(ctx, id)
+// ExpireIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *Orchestrator) ExpireIdempotencyKey(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatchByID")
-	}
-
-	var r0 *core.BatchPersisted
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.BatchPersisted, error)); ok {
-		return rf(ctx, id)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.BatchPersisted); ok {
-		r0 = rf(ctx, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.BatchPersisted)
-		}
+		panic("no return value specified for ExpireIdempotencyKey")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetBatches provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetBatches(ctx context.Context, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, filter)
+// GetActivity provides a mock function with given fields: ctx, categories, filter
+func (_m *Orchestrator) GetActivity(ctx context.Context, categories []string, filter ffapi.AndFilter) ([]*core.ActivityItem, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, categories, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBatches")
+		panic("no return value specified for GetActivity")
 	}
 
-	var r0 []*core.BatchPersisted
+	var r0 []*core.ActivityItem
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, ffapi.AndFilter) ([]*core.ActivityItem, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, categories, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.BatchPersisted); ok {
-		r0 = rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, []string, ffapi.AndFilter) []*core.ActivityItem); ok {
+		r0 = rf(ctx, categories, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.BatchPersisted)
+			r0 = ret.Get(0).([]*core.ActivityItem)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, []string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, categories, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
-		r2 = rf(ctx, filter)
+	if rf, ok := ret.Get(2).(func(context.Context, []string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, categories, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -349,29 +523,29 @@ func (_m *Orchestrator) GetBatches(ctx context.Context, filter ffapi.AndFilter)
 	return r0, r1, r2
 }
 
-// GetBlockchainEventByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetBlockchainEventByID(ctx context.Context, id string) (*core.BlockchainEvent, error) {
-	ret := _m.Called(ctx, id)
+// GetAuditAnchorProof provides a mock function with given fields: ctx, id, eventID
+func (_m *Orchestrator) GetAuditAnchorProof(ctx context.Context, id string, eventID string) (*core.AuditAnchorProof, error) {
+	ret := _m.Called(ctx, id, eventID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBlockchainEventByID")
+		panic("no return value specified for GetAuditAnchorProof")
 	}
 
-	var r0 *core.BlockchainEvent
+	var r0 *core.AuditAnchorProof
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.BlockchainEvent, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.AuditAnchorProof, error)); ok {
+		return rf(ctx, id, eventID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.BlockchainEvent); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.AuditAnchorProof); ok {
+		r0 = rf(ctx, id, eventID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.BlockchainEvent)
+			r0 = ret.Get(0).(*core.AuditAnchorProof)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, eventID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -379,25 +553,25 @@ func (_m *Orchestrator) GetBlockchainEventByID(ctx context.Context, id string) (
 	return r0, r1
 }
 
-// GetBlockchainEvents provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetBlockchainEvents(ctx context.Context, filter ffapi.AndFilter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
+// GetAuditAnchors provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetAuditAnchors(ctx context.Context, filter ffapi.AndFilter) ([]*core.AuditAnchor, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBlockchainEvents")
+		panic("no return value specified for GetAuditAnchors")
 	}
 
-	var r0 []*core.BlockchainEvent
+	var r0 []*core.AuditAnchor
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.AuditAnchor, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.BlockchainEvent); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.AuditAnchor); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.BlockchainEvent)
+			r0 = ret.Get(0).([]*core.AuditAnchor)
 		}
 	}
 
@@ -418,68 +592,77 @@ func (_m *Orchestrator) GetBlockchainEvents(ctx context.Context, filter ffapi.An
 	return r0, r1, r2
 }
 
-// GetChartHistogram provides a mock function with given fields: ctx, startTime, endTime, buckets, tableName
-func (_m *Orchestrator) GetChartHistogram(ctx context.Context, startTime int64, endTime int64, buckets int64, tableName database.CollectionName) ([]*core.ChartHistogram, error) {
-	ret := _m.Called(ctx, startTime, endTime, buckets, tableName)
+// GetAuditRecords provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetAuditRecords(ctx context.Context, filter ffapi.AndFilter) ([]*core.AuditRecord, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChartHistogram")
+		panic("no return value specified for GetAuditRecords")
 	}
 
-	var r0 []*core.ChartHistogram
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, database.CollectionName) ([]*core.ChartHistogram, error)); ok {
-		return rf(ctx, startTime, endTime, buckets, tableName)
+	var r0 []*core.AuditRecord
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.AuditRecord, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, database.CollectionName) []*core.ChartHistogram); ok {
-		r0 = rf(ctx, startTime, endTime, buckets, tableName)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.AuditRecord); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.ChartHistogram)
+			r0 = ret.Get(0).([]*core.AuditRecord)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64, database.CollectionName) error); ok {
-		r1 = rf(ctx, startTime, endTime, buckets, tableName)
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetData provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetData(ctx context.Context, filter ffapi.AndFilter) (core.DataArray, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, filter)
+// GetBatchAcks provides a mock function with given fields: ctx, id, filter
+func (_m *Orchestrator) GetBatchAcks(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.BatchAck, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, id, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetData")
+		panic("no return value specified for GetBatchAcks")
 	}
 
-	var r0 core.DataArray
+	var r0 []*core.BatchAck
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) (core.DataArray, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.BatchAck, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, id, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) core.DataArray); ok {
-		r0 = rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.BatchAck); ok {
+		r0 = rf(ctx, id, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(core.DataArray)
+			r0 = ret.Get(0).([]*core.BatchAck)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, id, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
-		r2 = rf(ctx, filter)
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, id, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -487,24 +670,24 @@ func (_m *Orchestrator) GetData(ctx context.Context, filter ffapi.AndFilter) (co
 	return r0, r1, r2
 }
 
-// GetDataByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetDataByID(ctx context.Context, id string) (*core.Data, error) {
+// GetBatchByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetBatchByID(ctx context.Context, id string) (*core.BatchPersisted, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDataByID")
+		panic("no return value specified for GetBatchByID")
 	}
 
-	var r0 *core.Data
+	var r0 *core.BatchPersisted
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Data, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.BatchPersisted, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Data); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.BatchPersisted); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Data)
+			r0 = ret.Get(0).(*core.BatchPersisted)
 		}
 	}
 
@@ -517,89 +700,146 @@ func (_m *Orchestrator) GetDataByID(ctx context.Context, id string) (*core.Data,
 	return r0, r1
 }
 
-// GetDataSubPaths provides a mock function with given fields: ctx, path
-func (_m *Orchestrator) GetDataSubPaths(ctx context.Context, path string) ([]string, error) {
-	ret := _m.Called(ctx, path)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetDataSubPaths")
+// GetBatches provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetBatches(ctx context.Context, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatches")
 	}
 
-	var r0 []string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
-		return rf(ctx, path)
+	var r0 []*core.BatchPersisted
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, path)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.BatchPersisted); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*core.BatchPersisted)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, path)
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetDatatypeByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetDatatypeByID(ctx context.Context, id string) (*core.Datatype, error) {
-	ret := _m.Called(ctx, id)
+// GetBatchesForBlobHash provides a mock function with given fields: ctx, hash, filter
+func (_m *Orchestrator) GetBatchesForBlobHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, hash, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDatatypeByID")
+		panic("no return value specified for GetBatchesForBlobHash")
 	}
 
-	var r0 *core.Datatype
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Datatype, error)); ok {
-		return rf(ctx, id)
+	var r0 []*core.BatchPersisted
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, hash, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Datatype); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.BatchPersisted); ok {
+		r0 = rf(ctx, hash, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Datatype)
+			r0 = ret.Get(0).([]*core.BatchPersisted)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, hash, filter)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, hash, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetDatatypeByName provides a mock function with given fields: ctx, name, version
-func (_m *Orchestrator) GetDatatypeByName(ctx context.Context, name string, version string) (*core.Datatype, error) {
-	ret := _m.Called(ctx, name, version)
+// GetBatchesForDataHash provides a mock function with given fields: ctx, hash, filter
+func (_m *Orchestrator) GetBatchesForDataHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, hash, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDatatypeByName")
+		panic("no return value specified for GetBatchesForDataHash")
 	}
 
-	var r0 *core.Datatype
+	var r0 []*core.BatchPersisted
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, hash, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.BatchPersisted); ok {
+		r0 = rf(ctx, hash, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.BatchPersisted)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, hash, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, hash, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetBlockchainEventByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetBlockchainEventByID(ctx context.Context, id string) (*core.BlockchainEvent, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockchainEventByID")
+	}
+
+	var r0 *core.BlockchainEvent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.Datatype, error)); ok {
-		return rf(ctx, name, version)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.BlockchainEvent, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.Datatype); ok {
-		r0 = rf(ctx, name, version)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.BlockchainEvent); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Datatype)
+			r0 = ret.Get(0).(*core.BlockchainEvent)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, name, version)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -607,25 +847,25 @@ func (_m *Orchestrator) GetDatatypeByName(ctx context.Context, name string, vers
 	return r0, r1
 }
 
-// GetDatatypes provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetDatatypes(ctx context.Context, filter ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error) {
+// GetBlockchainEvents provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetBlockchainEvents(ctx context.Context, filter ffapi.AndFilter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDatatypes")
+		panic("no return value specified for GetBlockchainEvents")
 	}
 
-	var r0 []*core.Datatype
+	var r0 []*core.BlockchainEvent
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Datatype); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.BlockchainEvent); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Datatype)
+			r0 = ret.Get(0).([]*core.BlockchainEvent)
 		}
 	}
 
@@ -646,29 +886,29 @@ func (_m *Orchestrator) GetDatatypes(ctx context.Context, filter ffapi.AndFilter
 	return r0, r1, r2
 }
 
-// GetEventByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetEventByID(ctx context.Context, id string) (*core.Event, error) {
-	ret := _m.Called(ctx, id)
+// GetBootstrapStatus provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetBootstrapStatus(ctx context.Context) (*core.NamespaceBootstrapStatus, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventByID")
+		panic("no return value specified for GetBootstrapStatus")
 	}
 
-	var r0 *core.Event
+	var r0 *core.NamespaceBootstrapStatus
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Event, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceBootstrapStatus, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Event); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceBootstrapStatus); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Event)
+			r0 = ret.Get(0).(*core.NamespaceBootstrapStatus)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -676,29 +916,29 @@ func (_m *Orchestrator) GetEventByID(ctx context.Context, id string) (*core.Even
 	return r0, r1
 }
 
-// GetEventByIDWithReference provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetEventByIDWithReference(ctx context.Context, id string) (*core.EnrichedEvent, error) {
-	ret := _m.Called(ctx, id)
+// GetCatchUpStatus provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetCatchUpStatus(ctx context.Context) (*core.CatchUpStatus, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventByIDWithReference")
+		panic("no return value specified for GetCatchUpStatus")
 	}
 
-	var r0 *core.EnrichedEvent
+	var r0 *core.CatchUpStatus
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.EnrichedEvent, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.CatchUpStatus, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.EnrichedEvent); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) *core.CatchUpStatus); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.EnrichedEvent)
+			r0 = ret.Get(0).(*core.CatchUpStatus)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -706,64 +946,55 @@ func (_m *Orchestrator) GetEventByIDWithReference(ctx context.Context, id string
 	return r0, r1
 }
 
-// GetEvents provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetEvents(ctx context.Context, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, filter)
+// GetChartHistogram provides a mock function with given fields: ctx, startTime, endTime, buckets, tableName
+func (_m *Orchestrator) GetChartHistogram(ctx context.Context, startTime int64, endTime int64, buckets int64, tableName database.CollectionName) ([]*core.ChartHistogram, error) {
+	ret := _m.Called(ctx, startTime, endTime, buckets, tableName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEvents")
+		panic("no return value specified for GetChartHistogram")
 	}
 
-	var r0 []*core.Event
-	var r1 *ffapi.FilterResult
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, filter)
+	var r0 []*core.ChartHistogram
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, database.CollectionName) ([]*core.ChartHistogram, error)); ok {
+		return rf(ctx, startTime, endTime, buckets, tableName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Event); ok {
-		r0 = rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, int64, database.CollectionName) []*core.ChartHistogram); ok {
+		r0 = rf(ctx, startTime, endTime, buckets, tableName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Event)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, filter)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ffapi.FilterResult)
+			r0 = ret.Get(0).([]*core.ChartHistogram)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
-		r2 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, int64, database.CollectionName) error); ok {
+		r1 = rf(ctx, startTime, endTime, buckets, tableName)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetEventsWithReferences provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetEventsWithReferences(ctx context.Context, filter ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
+// GetData provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetData(ctx context.Context, filter ffapi.AndFilter) (core.DataArray, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEventsWithReferences")
+		panic("no return value specified for GetData")
 	}
 
-	var r0 []*core.EnrichedEvent
+	var r0 core.DataArray
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) (core.DataArray, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.EnrichedEvent); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) core.DataArray); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.EnrichedEvent)
+			r0 = ret.Get(0).(core.DataArray)
 		}
 	}
 
@@ -784,24 +1015,24 @@ func (_m *Orchestrator) GetEventsWithReferences(ctx context.Context, filter ffap
 	return r0, r1, r2
 }
 
-// GetMessageByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetMessageByID(ctx context.Context, id string) (*core.Message, error) {
+// GetDataByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetDataByID(ctx context.Context, id string) (*core.Data, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessageByID")
+		panic("no return value specified for GetDataByID")
 	}
 
-	var r0 *core.Message
+	var r0 *core.Data
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Message, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Data, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Message); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Data); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Message)
+			r0 = ret.Get(0).(*core.Data)
 		}
 	}
 
@@ -814,29 +1045,29 @@ func (_m *Orchestrator) GetMessageByID(ctx context.Context, id string) (*core.Me
 	return r0, r1
 }
 
-// GetMessageByIDWithData provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetMessageByIDWithData(ctx context.Context, id string) (*core.MessageInOut, error) {
-	ret := _m.Called(ctx, id)
+// GetDataSubPaths provides a mock function with given fields: ctx, path
+func (_m *Orchestrator) GetDataSubPaths(ctx context.Context, path string) ([]string, error) {
+	ret := _m.Called(ctx, path)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessageByIDWithData")
+		panic("no return value specified for GetDataSubPaths")
 	}
 
-	var r0 *core.MessageInOut
+	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.MessageInOut, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, path)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.MessageInOut); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, path)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.MessageInOut)
+			r0 = ret.Get(0).([]string)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+		r1 = rf(ctx, path)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -844,24 +1075,24 @@ func (_m *Orchestrator) GetMessageByIDWithData(ctx context.Context, id string) (
 	return r0, r1
 }
 
-// GetMessageData provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetMessageData(ctx context.Context, id string) (core.DataArray, error) {
+// GetDatatypeByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetDatatypeByID(ctx context.Context, id string) (*core.Datatype, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessageData")
+		panic("no return value specified for GetDatatypeByID")
 	}
 
-	var r0 core.DataArray
+	var r0 *core.Datatype
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (core.DataArray, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Datatype, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) core.DataArray); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Datatype); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(core.DataArray)
+			r0 = ret.Get(0).(*core.Datatype)
 		}
 	}
 
@@ -874,68 +1105,29 @@ func (_m *Orchestrator) GetMessageData(ctx context.Context, id string) (core.Dat
 	return r0, r1
 }
 
-// GetMessageEvents provides a mock function with given fields: ctx, id, filter
-func (_m *Orchestrator) GetMessageEvents(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, id, filter)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetMessageEvents")
-	}
-
-	var r0 []*core.Event
-	var r1 *ffapi.FilterResult
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, id, filter)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.Event); ok {
-		r0 = rf(ctx, id, filter)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Event)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, id, filter)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ffapi.FilterResult)
-		}
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
-		r2 = rf(ctx, id, filter)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetMessageTransaction provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetMessageTransaction(ctx context.Context, id string) (*core.Transaction, error) {
-	ret := _m.Called(ctx, id)
+// GetDatatypeByName provides a mock function with given fields: ctx, name, version
+func (_m *Orchestrator) GetDatatypeByName(ctx context.Context, name string, version string) (*core.Datatype, error) {
+	ret := _m.Called(ctx, name, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessageTransaction")
+		panic("no return value specified for GetDatatypeByName")
 	}
 
-	var r0 *core.Transaction
+	var r0 *core.Datatype
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Transaction, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.Datatype, error)); ok {
+		return rf(ctx, name, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Transaction); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.Datatype); ok {
+		r0 = rf(ctx, name, version)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Transaction)
+			r0 = ret.Get(0).(*core.Datatype)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -943,25 +1135,25 @@ func (_m *Orchestrator) GetMessageTransaction(ctx context.Context, id string) (*
 	return r0, r1
 }
 
-// GetMessages provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetMessages(ctx context.Context, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+// GetDatatypes provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetDatatypes(ctx context.Context, filter ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessages")
+		panic("no return value specified for GetDatatypes")
 	}
 
-	var r0 []*core.Message
+	var r0 []*core.Datatype
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Datatype, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Message); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Datatype); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Message)
+			r0 = ret.Get(0).([]*core.Datatype)
 		}
 	}
 
@@ -982,64 +1174,25 @@ func (_m *Orchestrator) GetMessages(ctx context.Context, filter ffapi.AndFilter)
 	return r0, r1, r2
 }
 
-// GetMessagesForData provides a mock function with given fields: ctx, dataID, filter
-func (_m *Orchestrator) GetMessagesForData(ctx context.Context, dataID string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, dataID, filter)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetMessagesForData")
-	}
-
-	var r0 []*core.Message
-	var r1 *ffapi.FilterResult
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, dataID, filter)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.Message); ok {
-		r0 = rf(ctx, dataID, filter)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Message)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, dataID, filter)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ffapi.FilterResult)
-		}
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
-		r2 = rf(ctx, dataID, filter)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetMessagesWithData provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetMessagesWithData(ctx context.Context, filter ffapi.AndFilter) ([]*core.MessageInOut, *ffapi.FilterResult, error) {
+// GetDefinitionRecording provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetDefinitionRecording(ctx context.Context, filter ffapi.AndFilter) ([]*core.DefinitionReplayRecord, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessagesWithData")
+		panic("no return value specified for GetDefinitionRecording")
 	}
 
-	var r0 []*core.MessageInOut
+	var r0 []*core.DefinitionReplayRecord
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.MessageInOut, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.DefinitionReplayRecord, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.MessageInOut); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.DefinitionReplayRecord); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.MessageInOut)
+			r0 = ret.Get(0).([]*core.DefinitionReplayRecord)
 		}
 	}
 
@@ -1060,29 +1213,29 @@ func (_m *Orchestrator) GetMessagesWithData(ctx context.Context, filter ffapi.An
 	return r0, r1, r2
 }
 
-// GetMultipartyStatus provides a mock function with given fields: ctx
-func (_m *Orchestrator) GetMultipartyStatus(ctx context.Context) (*core.NamespaceMultipartyStatus, error) {
-	ret := _m.Called(ctx)
+// GetDisclosureByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetDisclosureByID(ctx context.Context, id string) (*core.Disclosure, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMultipartyStatus")
+		panic("no return value specified for GetDisclosureByID")
 	}
 
-	var r0 *core.NamespaceMultipartyStatus
+	var r0 *core.Disclosure
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceMultipartyStatus, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Disclosure, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceMultipartyStatus); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Disclosure); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.NamespaceMultipartyStatus)
+			r0 = ret.Get(0).(*core.Disclosure)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1090,45 +1243,25 @@ func (_m *Orchestrator) GetMultipartyStatus(ctx context.Context) (*core.Namespac
 	return r0, r1
 }
 
-// GetNamespace provides a mock function with given fields: ctx
-func (_m *Orchestrator) GetNamespace(ctx context.Context) *core.Namespace {
-	ret := _m.Called(ctx)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetNamespace")
-	}
-
-	var r0 *core.Namespace
-	if rf, ok := ret.Get(0).(func(context.Context) *core.Namespace); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Namespace)
-		}
-	}
-
-	return r0
-}
-
-// GetNextPins provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetNextPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.NextPin, *ffapi.FilterResult, error) {
+// GetDisclosures provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetDisclosures(ctx context.Context, filter ffapi.AndFilter) ([]*core.Disclosure, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNextPins")
+		panic("no return value specified for GetDisclosures")
 	}
 
-	var r0 []*core.NextPin
+	var r0 []*core.Disclosure
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.NextPin, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Disclosure, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.NextPin); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Disclosure); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.NextPin)
+			r0 = ret.Get(0).([]*core.Disclosure)
 		}
 	}
 
@@ -1149,24 +1282,24 @@ func (_m *Orchestrator) GetNextPins(ctx context.Context, filter ffapi.AndFilter)
 	return r0, r1, r2
 }
 
-// GetOperationByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetOperationByID(ctx context.Context, id string) (*core.Operation, error) {
+// GetEventByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetEventByID(ctx context.Context, id string) (*core.Event, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOperationByID")
+		panic("no return value specified for GetEventByID")
 	}
 
-	var r0 *core.Operation
+	var r0 *core.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Operation, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Event, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Operation); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Event); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Operation)
+			r0 = ret.Get(0).(*core.Event)
 		}
 	}
 
@@ -1179,24 +1312,24 @@ func (_m *Orchestrator) GetOperationByID(ctx context.Context, id string) (*core.
 	return r0, r1
 }
 
-// GetOperationByIDWithStatus provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetOperationByIDWithStatus(ctx context.Context, id string) (*core.OperationWithDetail, error) {
+// GetEventByIDWithReference provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetEventByIDWithReference(ctx context.Context, id string) (*core.EnrichedEvent, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOperationByIDWithStatus")
+		panic("no return value specified for GetEventByIDWithReference")
 	}
 
-	var r0 *core.OperationWithDetail
+	var r0 *core.EnrichedEvent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.OperationWithDetail, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.EnrichedEvent, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.OperationWithDetail); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.EnrichedEvent); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.OperationWithDetail)
+			r0 = ret.Get(0).(*core.EnrichedEvent)
 		}
 	}
 
@@ -1209,25 +1342,25 @@ func (_m *Orchestrator) GetOperationByIDWithStatus(ctx context.Context, id strin
 	return r0, r1
 }
 
-// GetOperations provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
+// GetEvents provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetEvents(ctx context.Context, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOperations")
+		panic("no return value specified for GetEvents")
 	}
 
-	var r0 []*core.Operation
+	var r0 []*core.Event
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Operation); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Event); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Operation)
+			r0 = ret.Get(0).([]*core.Event)
 		}
 	}
 
@@ -1248,25 +1381,25 @@ func (_m *Orchestrator) GetOperations(ctx context.Context, filter ffapi.AndFilte
 	return r0, r1, r2
 }
 
-// GetPins provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.Pin, *ffapi.FilterResult, error) {
+// GetEventsWithReferences provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetEventsWithReferences(ctx context.Context, filter ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPins")
+		panic("no return value specified for GetEventsWithReferences")
 	}
 
-	var r0 []*core.Pin
+	var r0 []*core.EnrichedEvent
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Pin, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Pin); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.EnrichedEvent); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Pin)
+			r0 = ret.Get(0).([]*core.EnrichedEvent)
 		}
 	}
 
@@ -1287,24 +1420,24 @@ func (_m *Orchestrator) GetPins(ctx context.Context, filter ffapi.AndFilter) ([]
 	return r0, r1, r2
 }
 
-// GetStatus provides a mock function with given fields: ctx
-func (_m *Orchestrator) GetStatus(ctx context.Context) (*core.NamespaceStatus, error) {
+// GetFaults provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetFaults(ctx context.Context) (*core.NamespaceFaults, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStatus")
+		panic("no return value specified for GetFaults")
 	}
 
-	var r0 *core.NamespaceStatus
+	var r0 *core.NamespaceFaults
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceStatus, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceFaults, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceStatus); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceFaults); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.NamespaceStatus)
+			r0 = ret.Get(0).(*core.NamespaceFaults)
 		}
 	}
 
@@ -1317,29 +1450,29 @@ func (_m *Orchestrator) GetStatus(ctx context.Context) (*core.NamespaceStatus, e
 	return r0, r1
 }
 
-// GetSubscriptionByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetSubscriptionByID(ctx context.Context, id string) (*core.Subscription, error) {
-	ret := _m.Called(ctx, id)
+// GetFeeSummary provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetFeeSummary(ctx context.Context, filter ffapi.AndFilter) ([]*core.FeeSummary, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSubscriptionByID")
+		panic("no return value specified for GetFeeSummary")
 	}
 
-	var r0 *core.Subscription
+	var r0 []*core.FeeSummary
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Subscription, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.FeeSummary, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Subscription); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.FeeSummary); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Subscription)
+			r0 = ret.Get(0).([]*core.FeeSummary)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1347,29 +1480,59 @@ func (_m *Orchestrator) GetSubscriptionByID(ctx context.Context, id string) (*co
 	return r0, r1
 }
 
-// GetSubscriptionByIDWithStatus provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetSubscriptionByIDWithStatus(ctx context.Context, id string) (*core.SubscriptionWithStatus, error) {
-	ret := _m.Called(ctx, id)
+// GetHealth provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetHealth(ctx context.Context) (*core.NamespaceHealth, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSubscriptionByIDWithStatus")
+		panic("no return value specified for GetHealth")
 	}
 
-	var r0 *core.SubscriptionWithStatus
+	var r0 *core.NamespaceHealth
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.SubscriptionWithStatus, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceHealth, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.SubscriptionWithStatus); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceHealth); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.SubscriptionWithStatus)
+			r0 = ret.Get(0).(*core.NamespaceHealth)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *Orchestrator) GetIdempotencyKey(ctx context.Context, key string) (*core.IdempotencyKeyRecord, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 *core.IdempotencyKeyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.IdempotencyKeyRecord, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.IdempotencyKeyRecord); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.IdempotencyKeyRecord)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+		r1 = rf(ctx, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1377,38 +1540,38 @@ func (_m *Orchestrator) GetSubscriptionByIDWithStatus(ctx context.Context, id st
 	return r0, r1
 }
 
-// GetSubscriptionEventsHistorical provides a mock function with given fields: ctx, subscription, filter, startSequence, endSequence
-func (_m *Orchestrator) GetSubscriptionEventsHistorical(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter, startSequence int, endSequence int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, subscription, filter, startSequence, endSequence)
+// GetIdempotencyKeys provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetIdempotencyKeys(ctx context.Context, filter ffapi.AndFilter) ([]*core.IdempotencyKeyRecord, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSubscriptionEventsHistorical")
+		panic("no return value specified for GetIdempotencyKeys")
 	}
 
-	var r0 []*core.EnrichedEvent
+	var r0 []*core.IdempotencyKeyRecord
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, ffapi.AndFilter, int, int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, subscription, filter, startSequence, endSequence)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.IdempotencyKeyRecord, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, ffapi.AndFilter, int, int) []*core.EnrichedEvent); ok {
-		r0 = rf(ctx, subscription, filter, startSequence, endSequence)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.IdempotencyKeyRecord); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.EnrichedEvent)
+			r0 = ret.Get(0).([]*core.IdempotencyKeyRecord)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *core.Subscription, ffapi.AndFilter, int, int) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, subscription, filter, startSequence, endSequence)
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, *core.Subscription, ffapi.AndFilter, int, int) error); ok {
-		r2 = rf(ctx, subscription, filter, startSequence, endSequence)
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1416,25 +1579,64 @@ func (_m *Orchestrator) GetSubscriptionEventsHistorical(ctx context.Context, sub
 	return r0, r1, r2
 }
 
-// GetSubscriptions provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetSubscriptions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error) {
+// GetLedgerBalances provides a mock function with given fields: ctx, key, filter
+func (_m *Orchestrator) GetLedgerBalances(ctx context.Context, key string, filter ffapi.AndFilter) ([]*core.LedgerBalance, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, key, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLedgerBalances")
+	}
+
+	var r0 []*core.LedgerBalance
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.LedgerBalance, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, key, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.LedgerBalance); ok {
+		r0 = rf(ctx, key, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.LedgerBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, key, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, key, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetLedgerTransactions provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetLedgerTransactions(ctx context.Context, filter ffapi.AndFilter) ([]*core.LedgerTransaction, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSubscriptions")
+		panic("no return value specified for GetLedgerTransactions")
 	}
 
-	var r0 []*core.Subscription
+	var r0 []*core.LedgerTransaction
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.LedgerTransaction, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Subscription); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.LedgerTransaction); ok {
 		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Subscription)
+			r0 = ret.Get(0).([]*core.LedgerTransaction)
 		}
 	}
 
@@ -1455,63 +1657,84 @@ func (_m *Orchestrator) GetSubscriptions(ctx context.Context, filter ffapi.AndFi
 	return r0, r1, r2
 }
 
-// GetTransactionBlockchainEvents provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetTransactionBlockchainEvents(ctx context.Context, id string) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, id)
+// GetListenerReconciliation provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetListenerReconciliation(ctx context.Context) (*core.ListenerReconciliationReport, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTransactionBlockchainEvents")
+		panic("no return value specified for GetListenerReconciliation")
 	}
 
-	var r0 []*core.BlockchainEvent
-	var r1 *ffapi.FilterResult
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.BlockchainEvent, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, id)
+	var r0 *core.ListenerReconciliationReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.ListenerReconciliationReport, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.BlockchainEvent); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context) *core.ListenerReconciliationReport); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.BlockchainEvent)
+			r0 = ret.Get(0).(*core.ListenerReconciliationReport)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ffapi.FilterResult)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoadTestStatus provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetLoadTestStatus(ctx context.Context) (*core.LoadTestStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoadTestStatus")
+	}
+
+	var r0 *core.LoadTestStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.LoadTestStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.LoadTestStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.LoadTestStatus)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetTransactionByID provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetTransactionByID(ctx context.Context, id string) (*core.Transaction, error) {
+// GetMessageByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetMessageByID(ctx context.Context, id string) (*core.Message, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTransactionByID")
+		panic("no return value specified for GetMessageByID")
 	}
 
-	var r0 *core.Transaction
+	var r0 *core.Message
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Transaction, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Message, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Transaction); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Message); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Transaction)
+			r0 = ret.Get(0).(*core.Message)
 		}
 	}
 
@@ -1524,63 +1747,54 @@ func (_m *Orchestrator) GetTransactionByID(ctx context.Context, id string) (*cor
 	return r0, r1
 }
 
-// GetTransactionOperations provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetTransactionOperations(ctx context.Context, id string) ([]*core.Operation, *ffapi.FilterResult, error) {
+// GetMessageByIDWithData provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetMessageByIDWithData(ctx context.Context, id string) (*core.MessageInOut, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTransactionOperations")
+		panic("no return value specified for GetMessageByIDWithData")
 	}
 
-	var r0 []*core.Operation
-	var r1 *ffapi.FilterResult
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.Operation, *ffapi.FilterResult, error)); ok {
+	var r0 *core.MessageInOut
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.MessageInOut, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.Operation); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.MessageInOut); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Operation)
+			r0 = ret.Get(0).(*core.MessageInOut)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) *ffapi.FilterResult); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = rf(ctx, id)
 	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*ffapi.FilterResult)
-		}
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, id)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetTransactionStatus provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) GetTransactionStatus(ctx context.Context, id string) (*core.TransactionStatus, error) {
+// GetMessageData provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetMessageData(ctx context.Context, id string) (core.DataArray, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTransactionStatus")
+		panic("no return value specified for GetMessageData")
 	}
 
-	var r0 *core.TransactionStatus
+	var r0 core.DataArray
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.TransactionStatus, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (core.DataArray, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *core.TransactionStatus); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) core.DataArray); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.TransactionStatus)
+			r0 = ret.Get(0).(core.DataArray)
 		}
 	}
 
@@ -1593,38 +1807,38 @@ func (_m *Orchestrator) GetTransactionStatus(ctx context.Context, id string) (*c
 	return r0, r1
 }
 
-// GetTransactions provides a mock function with given fields: ctx, filter
-func (_m *Orchestrator) GetTransactions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Transaction, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, filter)
+// GetMessageEvents provides a mock function with given fields: ctx, id, filter
+func (_m *Orchestrator) GetMessageEvents(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, id, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTransactions")
+		panic("no return value specified for GetMessageEvents")
 	}
 
-	var r0 []*core.Transaction
+	var r0 []*core.Event
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Transaction, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, id, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Transaction); ok {
-		r0 = rf(ctx, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.Event); ok {
+		r0 = rf(ctx, id, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Transaction)
+			r0 = ret.Get(0).([]*core.Event)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, id, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
-		r2 = rf(ctx, filter)
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, id, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1632,24 +1846,1168 @@ func (_m *Orchestrator) GetTransactions(ctx context.Context, filter ffapi.AndFil
 	return r0, r1, r2
 }
 
-// Identity provides a mock function with given fields:
-func (_m *Orchestrator) Identity() identity.Manager {
-	ret := _m.Called()
+// GetMessageTransaction provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetMessageTransaction(ctx context.Context, id string) (*core.Transaction, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Identity")
+		panic("no return value specified for GetMessageTransaction")
 	}
 
-	var r0 identity.Manager
-	if rf, ok := ret.Get(0).(func() identity.Manager); ok {
-		r0 = rf()
+	var r0 *core.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Transaction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Transaction); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(identity.Manager)
+			r0 = ret.Get(0).(*core.Transaction)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMessages provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetMessages(ctx context.Context, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessages")
+	}
+
+	var r0 []*core.Message
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Message); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMessagesForBlobHash provides a mock function with given fields: ctx, hash, filter
+func (_m *Orchestrator) GetMessagesForBlobHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, hash, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessagesForBlobHash")
+	}
+
+	var r0 []*core.Message
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, hash, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.Message); ok {
+		r0 = rf(ctx, hash, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, hash, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, hash, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMessagesForData provides a mock function with given fields: ctx, dataID, filter
+func (_m *Orchestrator) GetMessagesForData(ctx context.Context, dataID string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, dataID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessagesForData")
+	}
+
+	var r0 []*core.Message
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, dataID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.Message); ok {
+		r0 = rf(ctx, dataID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, dataID, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, dataID, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMessagesForDataHash provides a mock function with given fields: ctx, hash, filter
+func (_m *Orchestrator) GetMessagesForDataHash(ctx context.Context, hash string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, hash, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessagesForDataHash")
+	}
+
+	var r0 []*core.Message
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, hash, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.Message); ok {
+		r0 = rf(ctx, hash, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, hash, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, hash, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMessagesWithData provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetMessagesWithData(ctx context.Context, filter ffapi.AndFilter) ([]*core.MessageInOut, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessagesWithData")
+	}
+
+	var r0 []*core.MessageInOut
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.MessageInOut, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.MessageInOut); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.MessageInOut)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetMultipartyStatus provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetMultipartyStatus(ctx context.Context) (*core.NamespaceMultipartyStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMultipartyStatus")
+	}
+
+	var r0 *core.NamespaceMultipartyStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceMultipartyStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceMultipartyStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NamespaceMultipartyStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNamespace provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetNamespace(ctx context.Context) *core.Namespace {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespace")
+	}
+
+	var r0 *core.Namespace
+	if rf, ok := ret.Get(0).(func(context.Context) *core.Namespace); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Namespace)
+		}
+	}
+
+	return r0
+}
+
+// GetNextPins provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetNextPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.NextPin, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextPins")
+	}
+
+	var r0 []*core.NextPin
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.NextPin, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.NextPin); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.NextPin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOnlineMigrations provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetOnlineMigrations(ctx context.Context) ([]*core.OnlineMigration, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOnlineMigrations")
+	}
+
+	var r0 []*core.OnlineMigration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*core.OnlineMigration, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.OnlineMigration); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.OnlineMigration)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperationApprovalByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetOperationApprovalByID(ctx context.Context, id string) (*core.OperationApproval, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationApprovalByID")
+	}
+
+	var r0 *core.OperationApproval
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.OperationApproval, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.OperationApproval); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.OperationApproval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperationApprovals provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetOperationApprovals(ctx context.Context, filter ffapi.AndFilter) ([]*core.OperationApproval, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationApprovals")
+	}
+
+	var r0 []*core.OperationApproval
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.OperationApproval, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.OperationApproval); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.OperationApproval)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOperationByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetOperationByID(ctx context.Context, id string) (*core.Operation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationByID")
+	}
+
+	var r0 *core.Operation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Operation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Operation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Operation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperationByIDWithStatus provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetOperationByIDWithStatus(ctx context.Context, id string) (*core.OperationWithDetail, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationByIDWithStatus")
+	}
+
+	var r0 *core.OperationWithDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.OperationWithDetail, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.OperationWithDetail); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.OperationWithDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperationFees provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetOperationFees(ctx context.Context, filter ffapi.AndFilter) ([]*core.OperationFee, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationFees")
+	}
+
+	var r0 []*core.OperationFee
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.OperationFee, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.OperationFee); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.OperationFee)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOperationHistory provides a mock function with given fields: ctx, id, filter
+func (_m *Orchestrator) GetOperationHistory(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.OperationHistoryEntry, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, id, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationHistory")
+	}
+
+	var r0 []*core.OperationHistoryEntry
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.OperationHistoryEntry, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, id, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.OperationHistoryEntry); ok {
+		r0 = rf(ctx, id, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.OperationHistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, id, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, id, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOperations provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperations")
+	}
+
+	var r0 []*core.Operation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Operation); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Operation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPins provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.Pin, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPins")
+	}
+
+	var r0 []*core.Pin
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Pin, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Pin); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Pin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetQuotaStatus provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetQuotaStatus(ctx context.Context) (*core.NamespaceQuotaStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuotaStatus")
+	}
+
+	var r0 *core.NamespaceQuotaStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceQuotaStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceQuotaStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NamespaceQuotaStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRebuildStatus provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetRebuildStatus(ctx context.Context) []*core.RebuildStatus {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRebuildStatus")
+	}
+
+	var r0 []*core.RebuildStatus
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.RebuildStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.RebuildStatus)
+		}
+	}
+
+	return r0
+}
+
+// GetSLOStatus provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetSLOStatus(ctx context.Context) ([]*core.SLOStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSLOStatus")
+	}
+
+	var r0 []*core.SLOStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*core.SLOStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.SLOStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.SLOStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScheduledNetworkActions provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetScheduledNetworkActions(ctx context.Context) ([]*core.ScheduledNetworkAction, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduledNetworkActions")
+	}
+
+	var r0 []*core.ScheduledNetworkAction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*core.ScheduledNetworkAction, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.ScheduledNetworkAction); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ScheduledNetworkAction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStatus provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetStatus(ctx context.Context) (*core.NamespaceStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatus")
+	}
+
+	var r0 *core.NamespaceStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NamespaceStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptionByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetSubscriptionByID(ctx context.Context, id string) (*core.Subscription, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionByID")
+	}
+
+	var r0 *core.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Subscription, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Subscription); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptionByIDWithStatus provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetSubscriptionByIDWithStatus(ctx context.Context, id string) (*core.SubscriptionWithStatus, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionByIDWithStatus")
+	}
+
+	var r0 *core.SubscriptionWithStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.SubscriptionWithStatus, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.SubscriptionWithStatus); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SubscriptionWithStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptionEventsHistorical provides a mock function with given fields: ctx, subscription, filter, startSequence, endSequence
+func (_m *Orchestrator) GetSubscriptionEventsHistorical(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter, startSequence int, endSequence int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, subscription, filter, startSequence, endSequence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionEventsHistorical")
+	}
+
+	var r0 []*core.EnrichedEvent
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, ffapi.AndFilter, int, int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, subscription, filter, startSequence, endSequence)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, ffapi.AndFilter, int, int) []*core.EnrichedEvent); ok {
+		r0 = rf(ctx, subscription, filter, startSequence, endSequence)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.EnrichedEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.Subscription, ffapi.AndFilter, int, int) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, subscription, filter, startSequence, endSequence)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *core.Subscription, ffapi.AndFilter, int, int) error); ok {
+		r2 = rf(ctx, subscription, filter, startSequence, endSequence)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSubscriptionOffset provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetSubscriptionOffset(ctx context.Context, id string) (*core.SubscriptionOffset, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionOffset")
+	}
+
+	var r0 *core.SubscriptionOffset
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.SubscriptionOffset, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.SubscriptionOffset); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SubscriptionOffset)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptions provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetSubscriptions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptions")
+	}
+
+	var r0 []*core.Subscription
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Subscription); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTrafficMatrix provides a mock function with given fields: ctx, startTime, endTime
+func (_m *Orchestrator) GetTrafficMatrix(ctx context.Context, startTime *fftypes.FFTime, endTime *fftypes.FFTime) (*core.TrafficMatrix, error) {
+	ret := _m.Called(ctx, startTime, endTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrafficMatrix")
+	}
+
+	var r0 *core.TrafficMatrix
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.FFTime, *fftypes.FFTime) (*core.TrafficMatrix, error)); ok {
+		return rf(ctx, startTime, endTime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.FFTime, *fftypes.FFTime) *core.TrafficMatrix); ok {
+		r0 = rf(ctx, startTime, endTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.TrafficMatrix)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *fftypes.FFTime, *fftypes.FFTime) error); ok {
+		r1 = rf(ctx, startTime, endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionBlockchainEvents provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetTransactionBlockchainEvents(ctx context.Context, id string) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionBlockchainEvents")
+	}
+
+	var r0 []*core.BlockchainEvent
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.BlockchainEvent, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.BlockchainEvent); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.BlockchainEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTransactionByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetTransactionByID(ctx context.Context, id string) (*core.Transaction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByID")
+	}
+
+	var r0 *core.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Transaction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Transaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionOperations provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetTransactionOperations(ctx context.Context, id string) ([]*core.Operation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionOperations")
+	}
+
+	var r0 []*core.Operation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.Operation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.Operation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Operation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTransactionStatus provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetTransactionStatus(ctx context.Context, id string) (*core.TransactionStatus, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionStatus")
+	}
+
+	var r0 *core.TransactionStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.TransactionStatus, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.TransactionStatus); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.TransactionStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactions provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetTransactions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Transaction, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactions")
+	}
+
+	var r0 []*core.Transaction
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Transaction, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Transaction); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Identity provides a mock function with given fields:
+func (_m *Orchestrator) Identity() identity.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Identity")
+	}
+
+	var r0 identity.Manager
+	if rf, ok := ret.Get(0).(func() identity.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(identity.Manager)
+		}
+	}
+
+	return r0
 }
 
 // Init provides a mock function with given fields:
@@ -1660,124 +3018,423 @@ func (_m *Orchestrator) Init() error {
 		panic("no return value specified for Init")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func() error); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MultiParty provides a mock function with given fields:
+func (_m *Orchestrator) MultiParty() multiparty.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MultiParty")
+	}
+
+	var r0 multiparty.Manager
+	if rf, ok := ret.Get(0).(func() multiparty.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(multiparty.Manager)
+		}
+	}
+
+	return r0
+}
+
+// NetworkMap provides a mock function with given fields:
+func (_m *Orchestrator) NetworkMap() networkmap.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NetworkMap")
+	}
+
+	var r0 networkmap.Manager
+	if rf, ok := ret.Get(0).(func() networkmap.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(networkmap.Manager)
+		}
+	}
+
+	return r0
+}
+
+// Operations provides a mock function with given fields:
+func (_m *Orchestrator) Operations() operations.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Operations")
+	}
+
+	var r0 operations.Manager
+	if rf, ok := ret.Get(0).(func() operations.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(operations.Manager)
+		}
+	}
+
+	return r0
+}
+
+// PollSubscriptionEvents provides a mock function with given fields: ctx, subscription, filter
+func (_m *Orchestrator) PollSubscriptionEvents(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, subscription, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PollSubscriptionEvents")
+	}
+
+	var r0 []*core.EnrichedEvent
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, ffapi.AndFilter) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, subscription, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, ffapi.AndFilter) []*core.EnrichedEvent); ok {
+		r0 = rf(ctx, subscription, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.EnrichedEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.Subscription, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, subscription, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *core.Subscription, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, subscription, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PreInit provides a mock function with given fields: ctx, cancelCtx
+func (_m *Orchestrator) PreInit(ctx context.Context, cancelCtx context.CancelFunc) {
+	_m.Called(ctx, cancelCtx)
+}
+
+// PrivateMessaging provides a mock function with given fields:
+func (_m *Orchestrator) PrivateMessaging() privatemessaging.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PrivateMessaging")
+	}
+
+	var r0 privatemessaging.Manager
+	if rf, ok := ret.Get(0).(func() privatemessaging.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(privatemessaging.Manager)
+		}
+	}
+
+	return r0
+}
+
+// QueryGraphQL provides a mock function with given fields: ctx, req
+func (_m *Orchestrator) QueryGraphQL(ctx context.Context, req *core.GraphQLRequest) *core.GraphQLResponse {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryGraphQL")
+	}
+
+	var r0 *core.GraphQLResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *core.GraphQLRequest) *core.GraphQLResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.GraphQLResponse)
+		}
+	}
+
+	return r0
+}
+
+// RebuildDerivedState provides a mock function with given fields: ctx, target
+func (_m *Orchestrator) RebuildDerivedState(ctx context.Context, target fftypes.FFEnum) (*core.RebuildStatus, error) {
+	ret := _m.Called(ctx, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebuildDerivedState")
+	}
+
+	var r0 *core.RebuildStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum) (*core.RebuildStatus, error)); ok {
+		return rf(ctx, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum) *core.RebuildStatus); ok {
+		r0 = rf(ctx, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.RebuildStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum) error); ok {
+		r1 = rf(ctx, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseMessage provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) ReleaseMessage(ctx context.Context, id string) (*core.Message, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseMessage")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Message, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Message); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplayDefinitions provides a mock function with given fields: ctx, input
+func (_m *Orchestrator) ReplayDefinitions(ctx context.Context, input *core.DefinitionReplayInput) (*core.DefinitionReplayReport, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDefinitions")
+	}
+
+	var r0 *core.DefinitionReplayReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DefinitionReplayInput) (*core.DefinitionReplayReport, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DefinitionReplayInput) *core.DefinitionReplayReport); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.DefinitionReplayReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.DefinitionReplayInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestReply provides a mock function with given fields: ctx, msg
+func (_m *Orchestrator) RequestReply(ctx context.Context, msg *core.MessageInOut) (*core.MessageInOut, error) {
+	ret := _m.Called(ctx, msg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestReply")
+	}
+
+	var r0 *core.MessageInOut
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageInOut) (*core.MessageInOut, error)); ok {
+		return rf(ctx, msg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageInOut) *core.MessageInOut); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.MessageInOut)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.MessageInOut) error); ok {
+		r1 = rf(ctx, msg)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// MultiParty provides a mock function with given fields:
-func (_m *Orchestrator) MultiParty() multiparty.Manager {
-	ret := _m.Called()
+// RewindPins provides a mock function with given fields: ctx, rewind
+func (_m *Orchestrator) RewindPins(ctx context.Context, rewind *core.PinRewind) (*core.PinRewind, error) {
+	ret := _m.Called(ctx, rewind)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MultiParty")
+		panic("no return value specified for RewindPins")
 	}
 
-	var r0 multiparty.Manager
-	if rf, ok := ret.Get(0).(func() multiparty.Manager); ok {
-		r0 = rf()
+	var r0 *core.PinRewind
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.PinRewind) (*core.PinRewind, error)); ok {
+		return rf(ctx, rewind)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.PinRewind) *core.PinRewind); ok {
+		r0 = rf(ctx, rewind)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(multiparty.Manager)
+			r0 = ret.Get(0).(*core.PinRewind)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *core.PinRewind) error); ok {
+		r1 = rf(ctx, rewind)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// NetworkMap provides a mock function with given fields:
-func (_m *Orchestrator) NetworkMap() networkmap.Manager {
-	ret := _m.Called()
+// RunOnlineMigration provides a mock function with given fields: ctx, name
+func (_m *Orchestrator) RunOnlineMigration(ctx context.Context, name string) (*core.OnlineMigration, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NetworkMap")
+		panic("no return value specified for RunOnlineMigration")
 	}
 
-	var r0 networkmap.Manager
-	if rf, ok := ret.Get(0).(func() networkmap.Manager); ok {
-		r0 = rf()
+	var r0 *core.OnlineMigration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.OnlineMigration, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.OnlineMigration); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(networkmap.Manager)
+			r0 = ret.Get(0).(*core.OnlineMigration)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Operations provides a mock function with given fields:
-func (_m *Orchestrator) Operations() operations.Manager {
-	ret := _m.Called()
+// SearchMessages provides a mock function with given fields: ctx, query, limit
+func (_m *Orchestrator) SearchMessages(ctx context.Context, query string, limit int) ([]*core.SearchResult, error) {
+	ret := _m.Called(ctx, query, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Operations")
+		panic("no return value specified for SearchMessages")
 	}
 
-	var r0 operations.Manager
-	if rf, ok := ret.Get(0).(func() operations.Manager); ok {
-		r0 = rf()
+	var r0 []*core.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*core.SearchResult, error)); ok {
+		return rf(ctx, query, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*core.SearchResult); ok {
+		r0 = rf(ctx, query, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(operations.Manager)
+			r0 = ret.Get(0).([]*core.SearchResult)
 		}
 	}
 
-	return r0
-}
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, query, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
 
-// PreInit provides a mock function with given fields: ctx, cancelCtx
-func (_m *Orchestrator) PreInit(ctx context.Context, cancelCtx context.CancelFunc) {
-	_m.Called(ctx, cancelCtx)
+	return r0, r1
 }
 
-// PrivateMessaging provides a mock function with given fields:
-func (_m *Orchestrator) PrivateMessaging() privatemessaging.Manager {
-	ret := _m.Called()
+// SetFaults provides a mock function with given fields: ctx, rules
+func (_m *Orchestrator) SetFaults(ctx context.Context, rules *core.NamespaceFaults) (*core.NamespaceFaults, error) {
+	ret := _m.Called(ctx, rules)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PrivateMessaging")
+		panic("no return value specified for SetFaults")
 	}
 
-	var r0 privatemessaging.Manager
-	if rf, ok := ret.Get(0).(func() privatemessaging.Manager); ok {
-		r0 = rf()
+	var r0 *core.NamespaceFaults
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.NamespaceFaults) (*core.NamespaceFaults, error)); ok {
+		return rf(ctx, rules)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.NamespaceFaults) *core.NamespaceFaults); ok {
+		r0 = rf(ctx, rules)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(privatemessaging.Manager)
+			r0 = ret.Get(0).(*core.NamespaceFaults)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *core.NamespaceFaults) error); ok {
+		r1 = rf(ctx, rules)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// RequestReply provides a mock function with given fields: ctx, msg
-func (_m *Orchestrator) RequestReply(ctx context.Context, msg *core.MessageInOut) (*core.MessageInOut, error) {
-	ret := _m.Called(ctx, msg)
+// SetQuotaLimits provides a mock function with given fields: ctx, limits
+func (_m *Orchestrator) SetQuotaLimits(ctx context.Context, limits *core.NamespaceQuotas) (*core.NamespaceQuotaStatus, error) {
+	ret := _m.Called(ctx, limits)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RequestReply")
+		panic("no return value specified for SetQuotaLimits")
 	}
 
-	var r0 *core.MessageInOut
+	var r0 *core.NamespaceQuotaStatus
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageInOut) (*core.MessageInOut, error)); ok {
-		return rf(ctx, msg)
+	if rf, ok := ret.Get(0).(func(context.Context, *core.NamespaceQuotas) (*core.NamespaceQuotaStatus, error)); ok {
+		return rf(ctx, limits)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageInOut) *core.MessageInOut); ok {
-		r0 = rf(ctx, msg)
+	if rf, ok := ret.Get(0).(func(context.Context, *core.NamespaceQuotas) *core.NamespaceQuotaStatus); ok {
+		r0 = rf(ctx, limits)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.MessageInOut)
+			r0 = ret.Get(0).(*core.NamespaceQuotaStatus)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *core.MessageInOut) error); ok {
-		r1 = rf(ctx, msg)
+	if rf, ok := ret.Get(1).(func(context.Context, *core.NamespaceQuotas) error); ok {
+		r1 = rf(ctx, limits)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1785,29 +3442,29 @@ func (_m *Orchestrator) RequestReply(ctx context.Context, msg *core.MessageInOut
 	return r0, r1
 }
 
-// RewindPins provides a mock function with given fields: ctx, rewind
-func (_m *Orchestrator) RewindPins(ctx context.Context, rewind *core.PinRewind) (*core.PinRewind, error) {
-	ret := _m.Called(ctx, rewind)
+// SetSubscriptionOffset provides a mock function with given fields: ctx, id, offset
+func (_m *Orchestrator) SetSubscriptionOffset(ctx context.Context, id string, offset *core.SubscriptionOffset) (*core.SubscriptionOffset, error) {
+	ret := _m.Called(ctx, id, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RewindPins")
+		panic("no return value specified for SetSubscriptionOffset")
 	}
 
-	var r0 *core.PinRewind
+	var r0 *core.SubscriptionOffset
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *core.PinRewind) (*core.PinRewind, error)); ok {
-		return rf(ctx, rewind)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SubscriptionOffset) (*core.SubscriptionOffset, error)); ok {
+		return rf(ctx, id, offset)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *core.PinRewind) *core.PinRewind); ok {
-		r0 = rf(ctx, rewind)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SubscriptionOffset) *core.SubscriptionOffset); ok {
+		r0 = rf(ctx, id, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.PinRewind)
+			r0 = ret.Get(0).(*core.SubscriptionOffset)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *core.PinRewind) error); ok {
-		r1 = rf(ctx, rewind)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.SubscriptionOffset) error); ok {
+		r1 = rf(ctx, id, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1833,6 +3490,66 @@ func (_m *Orchestrator) Start() error {
 	return r0
 }
 
+// StartLoadTest provides a mock function with given fields: ctx, spec
+func (_m *Orchestrator) StartLoadTest(ctx context.Context, spec *core.LoadTestSpec) (*core.LoadTestStatus, error) {
+	ret := _m.Called(ctx, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartLoadTest")
+	}
+
+	var r0 *core.LoadTestStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.LoadTestSpec) (*core.LoadTestStatus, error)); ok {
+		return rf(ctx, spec)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.LoadTestSpec) *core.LoadTestStatus); ok {
+		r0 = rf(ctx, spec)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.LoadTestStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.LoadTestSpec) error); ok {
+		r1 = rf(ctx, spec)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StopLoadTest provides a mock function with given fields: ctx
+func (_m *Orchestrator) StopLoadTest(ctx context.Context) (*core.LoadTestStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopLoadTest")
+	}
+
+	var r0 *core.LoadTestStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.LoadTestStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.LoadTestStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.LoadTestStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitNetworkAction provides a mock function with given fields: ctx, action
 func (_m *Orchestrator) SubmitNetworkAction(ctx context.Context, action *core.NetworkAction) error {
 	ret := _m.Called(ctx, action)
@@ -1851,6 +3568,126 @@ func (_m *Orchestrator) SubmitNetworkAction(ctx context.Context, action *core.Ne
 	return r0
 }
 
+// VerifyAuditAnchor provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) VerifyAuditAnchor(ctx context.Context, id string) (*core.AuditAnchorVerification, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAuditAnchor")
+	}
+
+	var r0 *core.AuditAnchorVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.AuditAnchorVerification, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.AuditAnchorVerification); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.AuditAnchorVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyAuditLog provides a mock function with given fields: ctx
+func (_m *Orchestrator) VerifyAuditLog(ctx context.Context) (*core.AuditVerification, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyAuditLog")
+	}
+
+	var r0 *core.AuditVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.AuditVerification, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.AuditVerification); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.AuditVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyBatchPin provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) VerifyBatchPin(ctx context.Context, id string) (*core.BatchPinVerification, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyBatchPin")
+	}
+
+	var r0 *core.BatchPinVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.BatchPinVerification, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.BatchPinVerification); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BatchPinVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMessageBatchPin provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) VerifyMessageBatchPin(ctx context.Context, id string) (*core.BatchPinVerification, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMessageBatchPin")
+	}
+
+	var r0 *core.BatchPinVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.BatchPinVerification, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.BatchPinVerification); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BatchPinVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WaitStop provides a mock function with given fields:
 func (_m *Orchestrator) WaitStop() {
 	_m.Called()
@@ -20,6 +20,24 @@ type Manager struct {
 	mock.Mock
 }
 
+// BatchAckReceived provides a mock function with given fields: ctx, peerID, ack
+func (_m *Manager) BatchAckReceived(ctx context.Context, peerID string, ack *core.BatchAckNotification) error {
+	ret := _m.Called(ctx, peerID, ack)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchAckReceived")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.BatchAckNotification) error); ok {
+		r0 = rf(ctx, peerID, ack)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EnsureLocalGroup provides a mock function with given fields: ctx, group, creator
 func (_m *Manager) EnsureLocalGroup(ctx context.Context, group *core.Group, creator *core.Member) (bool, error) {
 	ret := _m.Called(ctx, group, creator)
@@ -78,6 +96,66 @@ func (_m *Manager) GetGroupByID(ctx context.Context, id string) (*core.Group, er
 	return r0, r1
 }
 
+// GetGroupLatency provides a mock function with given fields: ctx, hash
+func (_m *Manager) GetGroupLatency(ctx context.Context, hash string) ([]*core.MemberLatency, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupLatency")
+	}
+
+	var r0 []*core.MemberLatency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.MemberLatency, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.MemberLatency); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.MemberLatency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGroupMemberStatus provides a mock function with given fields: ctx, hash
+func (_m *Manager) GetGroupMemberStatus(ctx context.Context, hash string) ([]*core.MemberStatus, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroupMemberStatus")
+	}
+
+	var r0 []*core.MemberStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.MemberStatus, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.MemberStatus); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.MemberStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetGroups provides a mock function with given fields: ctx, filter
 func (_m *Manager) GetGroups(ctx context.Context, filter ffapi.AndFilter) ([]*core.Group, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
@@ -117,6 +195,34 @@ func (_m *Manager) GetGroups(ctx context.Context, filter ffapi.AndFilter) ([]*co
 	return r0, r1, r2
 }
 
+// HandleGroupMetadataUpdate provides a mock function with given fields: ctx, msg, data
+func (_m *Manager) HandleGroupMetadataUpdate(ctx context.Context, msg *core.Message, data core.DataArray) (core.MessageAction, error) {
+	ret := _m.Called(ctx, msg, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleGroupMetadataUpdate")
+	}
+
+	var r0 core.MessageAction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Message, core.DataArray) (core.MessageAction, error)); ok {
+		return rf(ctx, msg, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Message, core.DataArray) core.MessageAction); ok {
+		r0 = rf(ctx, msg, data)
+	} else {
+		r0 = ret.Get(0).(core.MessageAction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.Message, core.DataArray) error); ok {
+		r1 = rf(ctx, msg, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *Manager) Name() string {
 	ret := _m.Called()
@@ -282,6 +388,24 @@ func (_m *Manager) RunOperation(ctx context.Context, op *core.PreparedOperation)
 	return r0, r1, r2
 }
 
+// SendBatchAck provides a mock function with given fields: ctx, batchID, state
+func (_m *Manager) SendBatchAck(ctx context.Context, batchID *fftypes.UUID, state fftypes.FFEnum) error {
+	ret := _m.Called(ctx, batchID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendBatchAck")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, fftypes.FFEnum) error); ok {
+		r0 = rf(ctx, batchID, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendMessage provides a mock function with given fields: ctx, in, waitConfirm
 func (_m *Manager) SendMessage(ctx context.Context, in *core.MessageInOut, waitConfirm bool) (*core.Message, error) {
 	ret := _m.Called(ctx, in, waitConfirm)
@@ -312,6 +436,59 @@ func (_m *Manager) SendMessage(ctx context.Context, in *core.MessageInOut, waitC
 	return r0, r1
 }
 
+// Start provides a mock function with given fields:
+func (_m *Manager) Start() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateGroupMetadata provides a mock function with given fields: ctx, hash, metadata
+func (_m *Manager) UpdateGroupMetadata(ctx context.Context, hash string, metadata *core.GroupMetadata) (*core.Message, error) {
+	ret := _m.Called(ctx, hash, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGroupMetadata")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.GroupMetadata) (*core.Message, error)); ok {
+		return rf(ctx, hash, metadata)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.GroupMetadata) *core.Message); ok {
+		r0 = rf(ctx, hash, metadata)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.GroupMetadata) error); ok {
+		r1 = rf(ctx, hash, metadata)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaitStop provides a mock function with given fields:
+func (_m *Manager) WaitStop() {
+	_m.Called()
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
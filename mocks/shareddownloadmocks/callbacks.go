@@ -3,7 +3,12 @@
 package shareddownloadmocks
 
 import (
+	core "github.com/hyperledger/firefly/pkg/core"
+
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -12,9 +17,39 @@ type Callbacks struct {
 	mock.Mock
 }
 
-// SharedStorageBatchDownloaded provides a mock function with given fields: payloadRef, data
-func (_m *Callbacks) SharedStorageBatchDownloaded(payloadRef string, data []byte) (*fftypes.UUID, error) {
-	ret := _m.Called(payloadRef, data)
+// SharedStorageBatchDeliver provides a mock function with given fields: batch
+func (_m *Callbacks) SharedStorageBatchDeliver(batch *core.Batch) (*fftypes.UUID, error) {
+	ret := _m.Called(batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SharedStorageBatchDeliver")
+	}
+
+	var r0 *fftypes.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*core.Batch) (*fftypes.UUID, error)); ok {
+		return rf(batch)
+	}
+	if rf, ok := ret.Get(0).(func(*core.Batch) *fftypes.UUID); ok {
+		r0 = rf(batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fftypes.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*core.Batch) error); ok {
+		r1 = rf(batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SharedStorageBatchDownloaded provides a mock function with given fields: payloadRef, reader
+func (_m *Callbacks) SharedStorageBatchDownloaded(payloadRef string, reader io.Reader) (*fftypes.UUID, error) {
+	ret := _m.Called(payloadRef, reader)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SharedStorageBatchDownloaded")
@@ -22,19 +57,49 @@ func (_m *Callbacks) SharedStorageBatchDownloaded(payloadRef string, data []byte
 
 	var r0 *fftypes.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, []byte) (*fftypes.UUID, error)); ok {
-		return rf(payloadRef, data)
+	if rf, ok := ret.Get(0).(func(string, io.Reader) (*fftypes.UUID, error)); ok {
+		return rf(payloadRef, reader)
 	}
-	if rf, ok := ret.Get(0).(func(string, []byte) *fftypes.UUID); ok {
-		r0 = rf(payloadRef, data)
+	if rf, ok := ret.Get(0).(func(string, io.Reader) *fftypes.UUID); ok {
+		r0 = rf(payloadRef, reader)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*fftypes.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
-		r1 = rf(payloadRef, data)
+	if rf, ok := ret.Get(1).(func(string, io.Reader) error); ok {
+		r1 = rf(payloadRef, reader)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SharedStorageBatchVerify provides a mock function with given fields: payloadRef, reader
+func (_m *Callbacks) SharedStorageBatchVerify(payloadRef string, reader io.Reader) (*core.Batch, error) {
+	ret := _m.Called(payloadRef, reader)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SharedStorageBatchVerify")
+	}
+
+	var r0 *core.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, io.Reader) (*core.Batch, error)); ok {
+		return rf(payloadRef, reader)
+	}
+	if rf, ok := ret.Get(0).(func(string, io.Reader) *core.Batch); ok {
+		r0 = rf(payloadRef, reader)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, io.Reader) error); ok {
+		r1 = rf(payloadRef, reader)
 	} else {
 		r1 = ret.Error(1)
 	}
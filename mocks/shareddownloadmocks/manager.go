@@ -5,7 +5,10 @@ package shareddownloadmocks
 import (
 	context "context"
 
+	core "github.com/hyperledger/firefly/pkg/core"
+
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +17,36 @@ type Manager struct {
 	mock.Mock
 }
 
+// GetCatchUpStatus provides a mock function with given fields: ctx
+func (_m *Manager) GetCatchUpStatus(ctx context.Context) (*core.CatchUpStatus, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCatchUpStatus")
+	}
+
+	var r0 *core.CatchUpStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.CatchUpStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.CatchUpStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.CatchUpStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InitiateDownloadBatch provides a mock function with given fields: ctx, tx, payloadRef, idempotentSubmit
 func (_m *Manager) InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, payloadRef string, idempotentSubmit bool) error {
 	ret := _m.Called(ctx, tx, payloadRef, idempotentSubmit)
@@ -20,6 +20,11 @@ func (_m *Manager) Dispatch(changeEvent *core.ChangeEvent) {
 	_m.Called(changeEvent)
 }
 
+// NotifyLifecycle provides a mock function with given fields: event
+func (_m *Manager) NotifyLifecycle(event *core.LifecycleEvent) {
+	_m.Called(event)
+}
+
 // ServeHTTPWebSocketListener provides a mock function with given fields: res, req
 func (_m *Manager) ServeHTTPWebSocketListener(res http.ResponseWriter, req *http.Request) {
 	_m.Called(res, req)
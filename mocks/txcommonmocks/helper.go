@@ -36,6 +36,29 @@ func (_m *Helper) AddBlockchainTX(ctx context.Context, tx *core.Transaction, blo
 	return r0
 }
 
+// AddSagaStep provides a mock function with given fields: ctx, txID, name, opID, compensation
+func (_m *Helper) AddSagaStep(ctx context.Context, txID *fftypes.UUID, name string, opID *fftypes.UUID, compensation *core.SagaCompensation) error {
+	ret := _m.Called(ctx, txID, name, opID, compensation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSagaStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, string, *fftypes.UUID, *core.SagaCompensation) error); ok {
+		r0 = rf(ctx, txID, name, opID, compensation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvictTransactionsCached provides a mock function with given fields: ids
+func (_m *Helper) EvictTransactionsCached(ids []*fftypes.UUID) {
+	_m.Called(ids)
+}
+
 // FindOperationInTransaction provides a mock function with given fields: ctx, tx, opType
 func (_m *Helper) FindOperationInTransaction(ctx context.Context, tx *fftypes.UUID, opType fftypes.FFEnum) (*core.Operation, error) {
 	ret := _m.Called(ctx, tx, opType)
@@ -214,9 +237,16 @@ func (_m *Helper) PersistTransaction(ctx context.Context, id *fftypes.UUID, txTy
 	return r0, r1
 }
 
-// SubmitNewTransaction provides a mock function with given fields: ctx, txType, idempotencyKey
-func (_m *Helper) SubmitNewTransaction(ctx context.Context, txType fftypes.FFEnum, idempotencyKey core.IdempotencyKey) (*fftypes.UUID, error) {
-	ret := _m.Called(ctx, txType, idempotencyKey)
+// SubmitNewTransaction provides a mock function with given fields: ctx, txType, idempotencyKey, labels
+func (_m *Helper) SubmitNewTransaction(ctx context.Context, txType fftypes.FFEnum, idempotencyKey core.IdempotencyKey, labels ...string) (*fftypes.UUID, error) {
+	_va := make([]interface{}, len(labels))
+	for _i := range labels {
+		_va[_i] = labels[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, txType, idempotencyKey)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SubmitNewTransaction")
@@ -224,19 +254,19 @@ func (_m *Helper) SubmitNewTransaction(ctx context.Context, txType fftypes.FFEnu
 
 	var r0 *fftypes.UUID
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey) (*fftypes.UUID, error)); ok {
-		return rf(ctx, txType, idempotencyKey)
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, ...string) (*fftypes.UUID, error)); ok {
+		return rf(ctx, txType, idempotencyKey, labels...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey) *fftypes.UUID); ok {
-		r0 = rf(ctx, txType, idempotencyKey)
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, ...string) *fftypes.UUID); ok {
+		r0 = rf(ctx, txType, idempotencyKey, labels...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*fftypes.UUID)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey) error); ok {
-		r1 = rf(ctx, txType, idempotencyKey)
+	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, ...string) error); ok {
+		r1 = rf(ctx, txType, idempotencyKey, labels...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -262,6 +292,36 @@ func (_m *Helper) SubmitNewTransactionBatch(ctx context.Context, namespace strin
 	return r0
 }
 
+// UpdateSagaOperation provides a mock function with given fields: ctx, txID, opID, status, permanent
+func (_m *Helper) UpdateSagaOperation(ctx context.Context, txID *fftypes.UUID, opID *fftypes.UUID, status fftypes.FFEnum, permanent bool) (*txcommon.SagaProgress, error) {
+	ret := _m.Called(ctx, txID, opID, status, permanent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSagaOperation")
+	}
+
+	var r0 *txcommon.SagaProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, *fftypes.UUID, fftypes.FFEnum, bool) (*txcommon.SagaProgress, error)); ok {
+		return rf(ctx, txID, opID, status, permanent)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, *fftypes.UUID, fftypes.FFEnum, bool) *txcommon.SagaProgress); ok {
+		r0 = rf(ctx, txID, opID, status, permanent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*txcommon.SagaProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *fftypes.UUID, *fftypes.UUID, fftypes.FFEnum, bool) error); ok {
+		r1 = rf(ctx, txID, opID, status, permanent)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewHelper creates a new instance of Helper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHelper(t interface {
@@ -26,14 +26,14 @@ func (_m *Writer) Start() {
 	_m.Called()
 }
 
-// WriteTransactionAndOps provides a mock function with given fields: ctx, txType, idempotencyKey, operations
-func (_m *Writer) WriteTransactionAndOps(ctx context.Context, txType fftypes.FFEnum, idempotencyKey core.IdempotencyKey, operations ...*core.Operation) (*core.Transaction, error) {
+// WriteTransactionAndOps provides a mock function with given fields: ctx, txType, idempotencyKey, labels, operations
+func (_m *Writer) WriteTransactionAndOps(ctx context.Context, txType fftypes.FFEnum, idempotencyKey core.IdempotencyKey, labels []string, operations ...*core.Operation) (*core.Transaction, error) {
 	_va := make([]interface{}, len(operations))
 	for _i := range operations {
 		_va[_i] = operations[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, txType, idempotencyKey)
+	_ca = append(_ca, ctx, txType, idempotencyKey, labels)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -43,19 +43,19 @@ func (_m *Writer) WriteTransactionAndOps(ctx context.Context, txType fftypes.FFE
 
 	var r0 *core.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, ...*core.Operation) (*core.Transaction, error)); ok {
-		return rf(ctx, txType, idempotencyKey, operations...)
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, []string, ...*core.Operation) (*core.Transaction, error)); ok {
+		return rf(ctx, txType, idempotencyKey, labels, operations...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, ...*core.Operation) *core.Transaction); ok {
-		r0 = rf(ctx, txType, idempotencyKey, operations...)
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, []string, ...*core.Operation) *core.Transaction); ok {
+		r0 = rf(ctx, txType, idempotencyKey, labels, operations...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*core.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, ...*core.Operation) error); ok {
-		r1 = rf(ctx, txType, idempotencyKey, operations...)
+	if rf, ok := ret.Get(1).(func(context.Context, fftypes.FFEnum, core.IdempotencyKey, []string, ...*core.Operation) error); ok {
+		r1 = rf(ctx, txType, idempotencyKey, labels, operations...)
 	} else {
 		r1 = ret.Error(1)
 	}
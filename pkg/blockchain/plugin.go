@@ -77,8 +77,12 @@ type Plugin interface {
 	// SubmitBatchPin sequences a batch of message globally to all viewers of a given ledger
 	SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, signingKey string, batch *BatchPin, location *fftypes.JSONAny) error
 
-	// SubmitNetworkAction writes a special "BatchPin" event which signals the plugin to take an action
-	SubmitNetworkAction(ctx context.Context, nsOpID, signingKey string, action core.NetworkActionType, location *fftypes.JSONAny) error
+	// ParseBatchPinEvent extracts the batch pin, and the key that signed it, from a BatchPin event recorded in the database
+	ParseBatchPinEvent(ctx context.Context, event *core.BlockchainEvent) (*BatchPin, *core.VerifierRef, error)
+
+	// SubmitNetworkAction writes a special "BatchPin" event which signals the plugin to take an action.
+	// The payload is written alongside the action, and is delivered back in the NetworkActionEvent.
+	SubmitNetworkAction(ctx context.Context, nsOpID, signingKey string, action core.NetworkActionType, payload string, location *fftypes.JSONAny) error
 
 	// DeployContract submits a new transaction to deploy a new instance of a smart contract
 	DeployContract(ctx context.Context, nsOpID, signingKey string, definition, contract *fftypes.JSONAny, input []interface{}, options map[string]interface{}) (submissionRejected bool, err error)
@@ -105,6 +109,15 @@ type Plugin interface {
 	// GetContractListenerStatus gets the status of a contract listener from the backend connector. Returns false if not found
 	GetContractListenerStatus(ctx context.Context, namespace, subID string, okNotFound bool) (bool, interface{}, core.ContractListenerStatus, error)
 
+	// GetContractListenerCheckpoint gets the block up to which the connector has delivered events for a listener. Returns nil if not found
+	GetContractListenerCheckpoint(ctx context.Context, namespace, subID string) (*ListenerCheckpoint, error)
+
+	// ResetContractListener rewinds a listener, so the connector re-delivers all events from the given block onwards
+	ResetContractListener(ctx context.Context, namespace, subID string, fromBlock int64) error
+
+	// GetProtocolIDBlock returns the block number encoded in the protocol ID of an event emitted by this plugin
+	GetProtocolIDBlock(ctx context.Context, protocolID string) (int64, error)
+
 	// GetFFIParamValidator returns a blockchain-plugin-specific validator for FFIParams and their JSON Schema
 	GetFFIParamValidator(ctx context.Context) (fftypes.FFIParamValidator, error)
 
@@ -157,6 +170,7 @@ const (
 	EventTypeBatchPinComplete EventType = iota
 	EventTypeNetworkAction
 	EventTypeForListener
+	EventTypeReorg
 )
 
 // BatchPinComplete notifies on the arrival of a sequenced batch of messages, which might have been
@@ -170,6 +184,7 @@ type BatchPinCompleteEvent struct {
 // BlockchainNetworkAction notifies on the arrival of a network operator action
 type NetworkActionEvent struct {
 	Action     string
+	Payload    string
 	Location   *fftypes.JSONAny
 	Event      *Event
 	SigningKey *core.VerifierRef
@@ -182,12 +197,30 @@ type EventForListener struct {
 	ListenerID string
 }
 
+// ReorgEvent notifies that blocks have been removed from the chain by a re-organization.
+// Every event from the first removed block onwards must be reverted, and the events of the
+// replacing blocks are then delivered as normal after the re-org event.
+type ReorgEvent struct {
+	// Source indicates where the event originated (ie plugin name)
+	Source string
+
+	// BlockNumber is the first block that was removed from the chain
+	BlockNumber int64
+
+	// BlockHash is the hash of the removed block, if known
+	BlockHash string
+
+	// FromProtocolID is the lowest ProtocolID any event in the removed blocks can have
+	FromProtocolID string
+}
+
 // EventToDispatch is a wrapper around the other event types, to allow them to be dispatched as a group
 type EventToDispatch struct {
 	Type             EventType
 	BatchPinComplete *BatchPinCompleteEvent
 	NetworkAction    *NetworkActionEvent
 	ForListener      *EventForListener
+	Reorg            *ReorgEvent
 }
 
 // Callbacks is the interface provided to the blockchain plugin, to allow it to pass events back to firefly.
@@ -209,6 +242,14 @@ type Callbacks interface {
 // Capabilities the supported featureset of the blockchain
 // interface implemented by the plugin, with the specified config
 type Capabilities struct {
+	// ListenerReset is true if the connector can rewind a listener to re-deliver events from an earlier block
+	ListenerReset bool
+}
+
+// ListenerCheckpoint is the position a connector has reached in delivering the events of a listener
+type ListenerCheckpoint struct {
+	Block   int64
+	Catchup bool
 }
 
 // MultipartyContract represents the location and configuration of a FireFly multiparty contract for batch pinning of messages
@@ -0,0 +1,82 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// ActivityCategory groups the event types shown on the activity timeline of a namespace
+type ActivityCategory = fftypes.FFEnum
+
+var (
+	// ActivityCategoryMessage is a message that has been confirmed or rejected
+	ActivityCategoryMessage = fftypes.FFEnumValue("activitycategory", "message")
+	// ActivityCategoryTokenTransfer is a token transfer that has been confirmed, or submitted by this node and failed
+	ActivityCategoryTokenTransfer = fftypes.FFEnumValue("activitycategory", "tokentransfer")
+	// ActivityCategoryContractInvoke is a smart contract invocation submitted by this node that has succeeded or failed
+	ActivityCategoryContractInvoke = fftypes.FFEnumValue("activitycategory", "contractinvoke")
+	// ActivityCategoryIdentity is an identity that has been registered or updated
+	ActivityCategoryIdentity = fftypes.FFEnumValue("activitycategory", "identity")
+	// ActivityCategoryNetwork is a definition broadcast to the network, or a change to the blockchain itself
+	ActivityCategoryNetwork = fftypes.FFEnumValue("activitycategory", "network")
+)
+
+// ActivityEventTypes are the event types shown on the activity timeline, and the category of each
+var ActivityEventTypes = map[EventType]ActivityCategory{
+	EventTypeMessageConfirmed:            ActivityCategoryMessage,
+	EventTypeMessageRejected:             ActivityCategoryMessage,
+	EventTypeTransferConfirmed:           ActivityCategoryTokenTransfer,
+	EventTypeTransferOpFailed:            ActivityCategoryTokenTransfer,
+	EventTypeBlockchainInvokeOpSucceeded: ActivityCategoryContractInvoke,
+	EventTypeBlockchainInvokeOpFailed:    ActivityCategoryContractInvoke,
+	EventTypeIdentityConfirmed:           ActivityCategoryIdentity,
+	EventTypeIdentityUpdated:             ActivityCategoryIdentity,
+	EventTypeDatatypeConfirmed:           ActivityCategoryNetwork,
+	EventTypePoolConfirmed:               ActivityCategoryNetwork,
+	EventTypeContractInterfaceConfirmed:  ActivityCategoryNetwork,
+	EventTypeContractAPIConfirmed:        ActivityCategoryNetwork,
+	EventTypeBlockchainReorg:             ActivityCategoryNetwork,
+}
+
+// ActivityItem is an entry on the activity timeline of a namespace, built from an event and the object it refers to
+type ActivityItem struct {
+	ID          *fftypes.UUID    `ffstruct:"ActivityItem" json:"id"`
+	Sequence    int64            `ffstruct:"ActivityItem" json:"sequence"`
+	Category    ActivityCategory `ffstruct:"ActivityItem" json:"category" ffenum:"activitycategory"`
+	Type        EventType        `ffstruct:"ActivityItem" json:"type" ffenum:"eventtype"`
+	Reference   *fftypes.UUID    `ffstruct:"ActivityItem" json:"reference"`
+	Transaction *fftypes.UUID    `ffstruct:"ActivityItem" json:"tx,omitempty"`
+	Topic       string           `ffstruct:"ActivityItem" json:"topic,omitempty"`
+	Created     *fftypes.FFTime  `ffstruct:"ActivityItem" json:"created"`
+	Summary     *ActivitySummary `ffstruct:"ActivityItem" json:"summary"`
+}
+
+// ActivitySummary holds the headline details of the object an activity item refers to. Only the fields
+// relevant to the category of the item are set.
+type ActivitySummary struct {
+	Author   string                `ffstruct:"ActivitySummary" json:"author,omitempty"`
+	Tag      string                `ffstruct:"ActivitySummary" json:"tag,omitempty"`
+	Topics   fftypes.FFStringArray `ffstruct:"ActivitySummary" json:"topics,omitempty"`
+	State    string                `ffstruct:"ActivitySummary" json:"state,omitempty"`
+	Name     string                `ffstruct:"ActivitySummary" json:"name,omitempty"`
+	Pool     *fftypes.UUID         `ffstruct:"ActivitySummary" json:"pool,omitempty"`
+	From     string                `ffstruct:"ActivitySummary" json:"from,omitempty"`
+	To       string                `ffstruct:"ActivitySummary" json:"to,omitempty"`
+	Amount   *fftypes.FFBigInt     `ffstruct:"ActivitySummary" json:"amount,omitempty"`
+	Method   string                `ffstruct:"ActivitySummary" json:"method,omitempty"`
+	Location *fftypes.JSONAny      `ffstruct:"ActivitySummary" json:"location,omitempty"`
+	Error    string                `ffstruct:"ActivitySummary" json:"error,omitempty"`
+}
@@ -0,0 +1,45 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "net/http"
+
+// ErrorCode describes an error code the API can return, so clients can handle errors programmatically
+// rather than by matching the text of the message
+type ErrorCode struct {
+	Code      string `ffstruct:"ErrorCode" json:"code"`
+	Template  string `ffstruct:"ErrorCode" json:"template"`
+	Status    int    `ffstruct:"ErrorCode" json:"status"`
+	Retryable bool   `ffstruct:"ErrorCode" json:"retryable"`
+}
+
+// APIError is the body of an error response from the API
+type APIError struct {
+	Error         string   `ffstruct:"APIError" json:"error"`
+	Code          string   `ffstruct:"APIError" json:"code,omitempty"`
+	Params        []string `ffstruct:"APIError" json:"params,omitempty"`
+	Retryable     bool     `ffstruct:"APIError" json:"retryable"`
+	CorrelationID string   `ffstruct:"APIError" json:"correlationId,omitempty"`
+}
+
+// StatusRetryable returns true if a request that failed with the given HTTP status might succeed if it is
+// submitted again unchanged. Server side failures are assumed transient, as are timeouts and rate limits.
+func StatusRetryable(status int) bool {
+	return status >= http.StatusInternalServerError ||
+		status == http.StatusRequestTimeout ||
+		status == http.StatusTooManyRequests
+}
@@ -0,0 +1,33 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusRetryable(t *testing.T) {
+	assert.True(t, StatusRetryable(500))
+	assert.True(t, StatusRetryable(503))
+	assert.True(t, StatusRetryable(408))
+	assert.True(t, StatusRetryable(429))
+	assert.False(t, StatusRetryable(400))
+	assert.False(t, StatusRetryable(404))
+	assert.False(t, StatusRetryable(409))
+}
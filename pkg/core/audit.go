@@ -0,0 +1,60 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// AuditRecord is an entry in the audit log of the API calls that change the state of a namespace.
+// Each record includes the hash of the record before it, so any change to the log can be detected.
+type AuditRecord struct {
+	Sequence       int64            `ffstruct:"AuditRecord" json:"sequence"`
+	ID             *fftypes.UUID    `ffstruct:"AuditRecord" json:"id"`
+	Namespace      string           `ffstruct:"AuditRecord" json:"namespace"`
+	User           string           `ffstruct:"AuditRecord" json:"user,omitempty"`
+	Source         string           `ffstruct:"AuditRecord" json:"source,omitempty"`
+	Method         string           `ffstruct:"AuditRecord" json:"method"`
+	Path           string           `ffstruct:"AuditRecord" json:"path"`
+	Route          string           `ffstruct:"AuditRecord" json:"route"`
+	IdempotencyKey IdempotencyKey   `ffstruct:"AuditRecord" json:"idempotencyKey,omitempty"`
+	Status         int              `ffstruct:"AuditRecord" json:"status"`
+	Latency        int64            `ffstruct:"AuditRecord" json:"latencyMs"`
+	Created        *fftypes.FFTime  `ffstruct:"AuditRecord" json:"created"`
+	PreviousHash   *fftypes.Bytes32 `ffstruct:"AuditRecord" json:"previousHash,omitempty"`
+	Hash           *fftypes.Bytes32 `ffstruct:"AuditRecord" json:"hash"`
+}
+
+// AuditVerification is the result of checking the hash chain of the audit log of a namespace
+type AuditVerification struct {
+	Records      int64         `ffstruct:"AuditVerification" json:"records"`
+	Valid        bool          `ffstruct:"AuditVerification" json:"valid"`
+	FirstInvalid *fftypes.UUID `ffstruct:"AuditVerification" json:"firstInvalid,omitempty"`
+}
+
+// CalcHash returns the hash of the content of the record, including the hash of the previous record
+func (r *AuditRecord) CalcHash() *fftypes.Bytes32 {
+	content := *r
+	content.Sequence = 0 // assigned by the database on insert
+	content.Hash = nil
+	b, _ := json.Marshal(&content)
+	var hash fftypes.Bytes32 = sha256.Sum256(b)
+	return &hash
+}
@@ -0,0 +1,155 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// Domain separation prefixes, so a leaf can never be passed off as an interior node of the tree (or vice versa)
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+// AuditAnchor is a periodic commitment to the history of a namespace. The root is the Merkle root over the
+// confirmed messages and operation outcomes recorded in a range of events, and is pinned to the blockchain
+// so that any later change to the local history can be proved.
+type AuditAnchor struct {
+	ID         *fftypes.UUID    `ffstruct:"AuditAnchor" json:"id"`
+	Namespace  string           `ffstruct:"AuditAnchor" json:"namespace"`
+	FirstEvent int64            `ffstruct:"AuditAnchor" json:"firstEvent"`
+	LastEvent  int64            `ffstruct:"AuditAnchor" json:"lastEvent"`
+	Leaves     int64            `ffstruct:"AuditAnchor" json:"leaves"`
+	Root       *fftypes.Bytes32 `ffstruct:"AuditAnchor" json:"root"`
+	TX         TransactionRef   `ffstruct:"AuditAnchor" json:"tx"`
+	Created    *fftypes.FFTime  `ffstruct:"AuditAnchor" json:"created"`
+}
+
+// AuditAnchorLeaf is the content of a single leaf of the Merkle tree of an anchor, built from an event and
+// the message or operation it refers to
+type AuditAnchorLeaf struct {
+	Sequence    int64            `ffstruct:"AuditAnchorLeaf" json:"sequence"`
+	Event       *fftypes.UUID    `ffstruct:"AuditAnchorLeaf" json:"event"`
+	Type        EventType        `ffstruct:"AuditAnchorLeaf" json:"type"`
+	Reference   *fftypes.UUID    `ffstruct:"AuditAnchorLeaf" json:"reference"`
+	Transaction *fftypes.UUID    `ffstruct:"AuditAnchorLeaf" json:"tx,omitempty"`
+	MessageHash *fftypes.Bytes32 `ffstruct:"AuditAnchorLeaf" json:"messageHash,omitempty"`
+	OpStatus    OpStatus         `ffstruct:"AuditAnchorLeaf" json:"opStatus,omitempty"`
+	OpError     string           `ffstruct:"AuditAnchorLeaf" json:"opError,omitempty"`
+}
+
+// AuditAnchorVerification is the result of rebuilding the Merkle tree of an anchor from the local history,
+// and comparing it to the root that was recorded and pinned
+type AuditAnchorVerification struct {
+	Anchor          *AuditAnchor     `ffstruct:"AuditAnchorVerification" json:"anchor"`
+	Leaves          int64            `ffstruct:"AuditAnchorVerification" json:"leaves"`
+	Root            *fftypes.Bytes32 `ffstruct:"AuditAnchorVerification" json:"root"`
+	BlockchainEvent *fftypes.UUID    `ffstruct:"AuditAnchorVerification" json:"blockchainEvent,omitempty"`
+	PinnedRoot      *fftypes.Bytes32 `ffstruct:"AuditAnchorVerification" json:"pinnedRoot,omitempty"`
+	Valid           bool             `ffstruct:"AuditAnchorVerification" json:"valid"`
+}
+
+// AuditAnchorProofStep is one sibling on the path from a leaf to the root of the Merkle tree
+type AuditAnchorProofStep struct {
+	Hash *fftypes.Bytes32 `ffstruct:"AuditAnchorProofStep" json:"hash"`
+	Left bool             `ffstruct:"AuditAnchorProofStep" json:"left"`
+}
+
+// AuditAnchorProof proves that a single event is included in the Merkle root of an anchor, without needing
+// the rest of the history of the namespace
+type AuditAnchorProof struct {
+	Anchor   *AuditAnchor            `ffstruct:"AuditAnchorProof" json:"anchor"`
+	Leaf     *AuditAnchorLeaf        `ffstruct:"AuditAnchorProof" json:"leaf"`
+	LeafHash *fftypes.Bytes32        `ffstruct:"AuditAnchorProof" json:"leafHash"`
+	Index    int64                   `ffstruct:"AuditAnchorProof" json:"index"`
+	Path     []*AuditAnchorProofStep `ffstruct:"AuditAnchorProof" json:"path"`
+	Valid    bool                    `ffstruct:"AuditAnchorProof" json:"valid"`
+}
+
+// Hash returns the hash of the leaf, as included in the Merkle tree
+func (l *AuditAnchorLeaf) Hash() *fftypes.Bytes32 {
+	b, _ := json.Marshal(l)
+	var hash fftypes.Bytes32 = sha256.Sum256(append([]byte{merkleLeafPrefix}, b...))
+	return &hash
+}
+
+func merkleNodeHash(left, right *fftypes.Bytes32) *fftypes.Bytes32 {
+	b := make([]byte, 0, 1+2*len(left))
+	b = append(b, merkleNodePrefix)
+	b = append(b, left[:]...)
+	b = append(b, right[:]...)
+	var hash fftypes.Bytes32 = sha256.Sum256(b)
+	return &hash
+}
+
+func merkleNextLevel(level []*fftypes.Bytes32) []*fftypes.Bytes32 {
+	next := make([]*fftypes.Bytes32, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 < len(level) {
+			next = append(next, merkleNodeHash(level[i], level[i+1]))
+		} else {
+			next = append(next, level[i])
+		}
+	}
+	return next
+}
+
+// MerkleRoot returns the root of the Merkle tree over the supplied leaf hashes. A node without a sibling
+// is promoted unchanged to the next level of the tree.
+func MerkleRoot(leaves []*fftypes.Bytes32) *fftypes.Bytes32 {
+	if len(leaves) == 0 {
+		return nil
+	}
+	level := leaves
+	for len(level) > 1 {
+		level = merkleNextLevel(level)
+	}
+	return level[0]
+}
+
+// MerklePath returns the siblings needed to recompute the root of the Merkle tree from the leaf at the given index
+func MerklePath(leaves []*fftypes.Bytes32, index int) []*AuditAnchorProofStep {
+	path := []*AuditAnchorProofStep{}
+	level := leaves
+	for len(level) > 1 {
+		if index%2 == 1 {
+			path = append(path, &AuditAnchorProofStep{Hash: level[index-1], Left: true})
+		} else if index+1 < len(level) {
+			path = append(path, &AuditAnchorProofStep{Hash: level[index+1]})
+		}
+		level = merkleNextLevel(level)
+		index /= 2
+	}
+	return path
+}
+
+// MerklePathRoot returns the root reached by applying a path to a leaf hash
+func MerklePathRoot(leaf *fftypes.Bytes32, path []*AuditAnchorProofStep) *fftypes.Bytes32 {
+	hash := leaf
+	for _, step := range path {
+		if step.Left {
+			hash = merkleNodeHash(step.Hash, hash)
+		} else {
+			hash = merkleNodeHash(hash, step.Hash)
+		}
+	}
+	return hash
+}
@@ -0,0 +1,84 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAnchorLeaves(n int) []*fftypes.Bytes32 {
+	leaves := make([]*fftypes.Bytes32, n)
+	for i := range leaves {
+		leaf := &AuditAnchorLeaf{
+			Sequence:  int64(i + 1),
+			Event:     fftypes.NewUUID(),
+			Type:      EventTypeMessageConfirmed,
+			Reference: fftypes.NewUUID(),
+		}
+		leaves[i] = leaf.Hash()
+	}
+	return leaves
+}
+
+func TestMerkleRootEmpty(t *testing.T) {
+	assert.Nil(t, MerkleRoot(nil))
+}
+
+func TestMerkleRootSingleLeaf(t *testing.T) {
+	leaves := testAnchorLeaves(1)
+	assert.Equal(t, leaves[0], MerkleRoot(leaves))
+	assert.Empty(t, MerklePath(leaves, 0))
+}
+
+func TestMerkleRootOddLeafPromoted(t *testing.T) {
+	leaves := testAnchorLeaves(3)
+	expected := merkleNodeHash(merkleNodeHash(leaves[0], leaves[1]), leaves[2])
+	assert.Equal(t, *expected, *MerkleRoot(leaves))
+}
+
+func TestMerklePathAllLeaves(t *testing.T) {
+	for _, n := range []int{2, 5, 8, 13} {
+		leaves := testAnchorLeaves(n)
+		root := MerkleRoot(leaves)
+		for i := range leaves {
+			path := MerklePath(leaves, i)
+			assert.Equal(t, *root, *MerklePathRoot(leaves[i], path), "leaves=%d index=%d", n, i)
+		}
+	}
+}
+
+func TestMerklePathWrongLeaf(t *testing.T) {
+	leaves := testAnchorLeaves(4)
+	path := MerklePath(leaves, 1)
+	assert.NotEqual(t, *MerkleRoot(leaves), *MerklePathRoot(leaves[2], path))
+}
+
+func TestAuditAnchorLeafHashChangesWithContent(t *testing.T) {
+	leaf := &AuditAnchorLeaf{
+		Sequence:    1,
+		Event:       fftypes.NewUUID(),
+		Type:        EventTypeMessageConfirmed,
+		Reference:   fftypes.NewUUID(),
+		MessageHash: fftypes.NewRandB32(),
+	}
+	hash := leaf.Hash()
+	leaf.MessageHash = fftypes.NewRandB32()
+	assert.NotEqual(t, *hash, *leaf.Hash())
+}
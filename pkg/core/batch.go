@@ -46,6 +46,7 @@ type BatchHeader struct {
 	Node      *fftypes.UUID    `ffstruct:"BatchHeader" json:"node,omitempty"`
 	Group     *fftypes.Bytes32 `ffstruct:"BatchHeader" json:"group,omitempty"`
 	Created   *fftypes.FFTime  `ffstruct:"BatchHeader" json:"created"`
+	Trace     bool             `ffstruct:"BatchHeader" json:"trace,omitempty"`
 	SignerRef
 }
 
@@ -64,8 +65,9 @@ type BatchManifest struct {
 	ID      *fftypes.UUID  `json:"id"`
 	TX      TransactionRef `json:"tx"`
 	SignerRef
-	Messages []*MessageManifestEntry `json:"messages"`
-	Data     DataRefs                `json:"data"`
+	Messages  []*MessageManifestEntry `json:"messages"`
+	Data      DataRefs                `json:"data"`
+	Signature *PayloadSignature       `json:"signature,omitempty"`
 }
 
 // Batch is the full payload object used in-flight.
@@ -82,6 +84,7 @@ type BatchPersisted struct {
 	Manifest  *fftypes.JSONAny `ffstruct:"Batch" json:"manifest"`
 	TX        TransactionRef   `ffstruct:"Batch" json:"tx"`
 	Confirmed *fftypes.FFTime  `ffstruct:"Batch" json:"confirmed"`
+	Sequence  int64            `json:"-"` // Local database sequence, used to page through batches in a stable order
 }
 
 // BatchPayload contains the full JSON of the messages and data, but
@@ -93,9 +96,10 @@ type BatchPersisted struct {
 // calculating the hash).
 // - See Message.BatchMessage() and Data.BatchData()
 type BatchPayload struct {
-	TX       TransactionRef `ffstruct:"BatchPayload" json:"tx"`
-	Messages []*Message     `ffstruct:"BatchPayload" json:"messages"`
-	Data     DataArray      `ffstruct:"BatchPayload" json:"data"`
+	TX        TransactionRef    `ffstruct:"BatchPayload" json:"tx"`
+	Messages  []*Message        `ffstruct:"BatchPayload" json:"messages"`
+	Data      DataArray         `ffstruct:"BatchPayload" json:"data"`
+	Signature *PayloadSignature `ffstruct:"BatchPayload" json:"signature,omitempty"`
 }
 
 func (bm *BatchManifest) String() string {
@@ -103,6 +107,13 @@ func (bm *BatchManifest) String() string {
 	return string(b)
 }
 
+// PayloadHash is the hash of the manifest without any signature, which is what a payload signature is made over
+func (bm *BatchManifest) PayloadHash() *fftypes.Bytes32 {
+	unsigned := *bm
+	unsigned.Signature = nil
+	return fftypes.HashString(unsigned.String())
+}
+
 func (ma *BatchPayload) Hash() *fftypes.Bytes32 {
 	b, _ := json.Marshal(&ma)
 	var b32 fftypes.Bytes32 = sha256.Sum256(b)
@@ -111,11 +122,12 @@ func (ma *BatchPayload) Hash() *fftypes.Bytes32 {
 
 func (ma *BatchPayload) Manifest(id *fftypes.UUID) *BatchManifest {
 	tm := &BatchManifest{
-		Version:  ManifestVersion1,
-		ID:       id,
-		TX:       ma.TX,
-		Messages: make([]*MessageManifestEntry, 0, len(ma.Messages)),
-		Data:     make(DataRefs, 0, len(ma.Data)),
+		Version:   ManifestVersion1,
+		ID:        id,
+		TX:        ma.TX,
+		Messages:  make([]*MessageManifestEntry, 0, len(ma.Messages)),
+		Data:      make(DataRefs, 0, len(ma.Data)),
+		Signature: ma.Signature,
 	}
 	for _, m := range ma.Messages {
 		if m != nil && m.Header.ID != nil {
@@ -0,0 +1,66 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// BatchAckState is how far a recipient is known to have got with a private batch sent to it.
+// The states are ordered, and an acknowledgement only ever moves forwards through them.
+type BatchAckState = fftypes.FFEnum
+
+var (
+	// BatchAckStateTransferred the data exchange reported the batch was delivered to the recipient's data exchange
+	BatchAckStateTransferred = fftypes.FFEnumValue("batchackstate", "transferred")
+	// BatchAckStateReceived the recipient's FireFly core returned a matching manifest for the batch
+	BatchAckStateReceived = fftypes.FFEnumValue("batchackstate", "received")
+	// BatchAckStatePersisted the recipient's FireFly core acknowledged it had stored the batch
+	BatchAckStatePersisted = fftypes.FFEnumValue("batchackstate", "persisted")
+	// BatchAckStateAggregated the recipient's FireFly core acknowledged every message in the batch had been aggregated
+	BatchAckStateAggregated = fftypes.FFEnumValue("batchackstate", "aggregated")
+)
+
+var batchAckStateOrder = map[BatchAckState]int{
+	BatchAckStateTransferred: 1,
+	BatchAckStateReceived:    2,
+	BatchAckStatePersisted:   3,
+	BatchAckStateAggregated:  4,
+}
+
+// BatchAckStateAdvances returns true if moving an acknowledgement from one state to another goes forwards
+func BatchAckStateAdvances(from, to BatchAckState) bool {
+	return batchAckStateOrder[to] > batchAckStateOrder[from]
+}
+
+// BatchAck records the furthest state a recipient node is known to have reached with a private batch
+// sent from this node
+type BatchAck struct {
+	Namespace string          `ffstruct:"BatchAck" json:"namespace"`
+	Batch     *fftypes.UUID   `ffstruct:"BatchAck" json:"batch"`
+	Node      *fftypes.UUID   `ffstruct:"BatchAck" json:"node"`
+	State     BatchAckState   `ffstruct:"BatchAck" json:"state" ffenum:"batchackstate"`
+	Created   *fftypes.FFTime `ffstruct:"BatchAck" json:"created"`
+	Updated   *fftypes.FFTime `ffstruct:"BatchAck" json:"updated"`
+}
+
+// BatchAckNotification is sent by the recipient of a private batch back to its sender, over data exchange
+type BatchAckNotification struct {
+	Namespace string        `json:"namespace"`
+	Batch     *fftypes.UUID `json:"batch"`
+	State     BatchAckState `json:"state"`
+}
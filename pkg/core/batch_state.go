@@ -42,6 +42,9 @@ type BatchState struct {
 
 	// ConfirmedDIDClaims are DID claims locked in within this batch
 	ConfirmedDIDClaims []string
+
+	// ApprovedDefinitions are definition messages that received an approval within this batch
+	ApprovedDefinitions []*fftypes.UUID
 }
 
 func (bs *BatchState) AddPreFinalize(action func(ctx context.Context) error) {
@@ -60,6 +63,10 @@ func (bs *BatchState) AddConfirmedDIDClaim(did string) {
 	bs.ConfirmedDIDClaims = append(bs.ConfirmedDIDClaims, did)
 }
 
+func (bs *BatchState) AddApprovedDefinition(msgID *fftypes.UUID) {
+	bs.ApprovedDefinitions = append(bs.ApprovedDefinitions, msgID)
+}
+
 func (bs *BatchState) RunPreFinalize(ctx context.Context) error {
 	for _, action := range bs.PreFinalize {
 		if err := action(ctx); err != nil {
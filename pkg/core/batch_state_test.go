@@ -63,6 +63,10 @@ func TestBatchStateIdentities(t *testing.T) {
 	did := "did:firefly:id1"
 	bs.AddConfirmedDIDClaim(did)
 
+	defID := fftypes.NewUUID()
+	bs.AddApprovedDefinition(defID)
+
 	assert.Equal(t, msg, bs.PendingConfirms[*id])
 	assert.Equal(t, bs.ConfirmedDIDClaims[0], did)
+	assert.Equal(t, defID, bs.ApprovedDefinitions[0])
 }
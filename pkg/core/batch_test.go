@@ -65,3 +65,35 @@ func TestSQLSerializedManifest(t *testing.T) {
 	assert.NotEqual(t, batch.Payload.Hash().String(), hex.EncodeToString(mfHash[:]))
 
 }
+
+func TestManifestPayloadHash(t *testing.T) {
+
+	batch := &Batch{
+		BatchHeader: BatchHeader{
+			ID: fftypes.NewUUID(),
+		},
+		Payload: BatchPayload{
+			TX: TransactionRef{
+				ID: fftypes.NewUUID(),
+			},
+			Messages: []*Message{
+				{Header: MessageHeader{ID: fftypes.NewUUID()}},
+			},
+		},
+	}
+	unsigned := batch.Payload.Manifest(batch.ID)
+	assert.Nil(t, unsigned.Signature)
+	assert.Equal(t, fftypes.HashString(unsigned.String()), unsigned.PayloadHash())
+
+	batch.Payload.Signature = &PayloadSignature{
+		Algorithm: PayloadSignatureAlgorithmEd25519,
+		PublicKey: "pubkey",
+		Signature: "sig",
+	}
+	signed := batch.Payload.Manifest(batch.ID)
+	assert.Equal(t, batch.Payload.Signature, signed.Signature)
+	assert.Equal(t, unsigned.PayloadHash(), signed.PayloadHash())
+	assert.NotEqual(t, fftypes.HashString(unsigned.String()), fftypes.HashString(signed.String()))
+	assert.NotNil(t, signed.Signature)
+
+}
@@ -0,0 +1,44 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// BatchPinVerification is the result of checking a confirmed batch against the local copies of its messages and data,
+// the BatchPin event that sequenced it on the blockchain, and (for broadcast batches) the payload in shared storage
+type BatchPinVerification struct {
+	Batch            *fftypes.UUID    `ffstruct:"BatchPinVerification" json:"batch"`
+	Message          *fftypes.UUID    `ffstruct:"BatchPinVerification" json:"message,omitempty"`
+	Type             BatchType        `ffstruct:"BatchPinVerification" json:"type" ffenum:"batchtype"`
+	Hash             *fftypes.Bytes32 `ffstruct:"BatchPinVerification" json:"hash"`
+	ManifestHash     *fftypes.Bytes32 `ffstruct:"BatchPinVerification" json:"manifestHash,omitempty"`
+	ManifestMatch    bool             `ffstruct:"BatchPinVerification" json:"manifestMatch"`
+	BlockchainEvent  *fftypes.UUID    `ffstruct:"BatchPinVerification" json:"blockchainEvent,omitempty"`
+	BlockchainTXID   string           `ffstruct:"BatchPinVerification" json:"blockchainTxId,omitempty"`
+	BlockNumber      int64            `ffstruct:"BatchPinVerification" json:"blockNumber,omitempty"`
+	PinnedHash       *fftypes.Bytes32 `ffstruct:"BatchPinVerification" json:"pinnedHash,omitempty"`
+	PinnedHashMatch  bool             `ffstruct:"BatchPinVerification" json:"pinnedHashMatch"`
+	Signer           string           `ffstruct:"BatchPinVerification" json:"signer,omitempty"`
+	SignerMatch      bool             `ffstruct:"BatchPinVerification" json:"signerMatch"`
+	PayloadRef       string           `ffstruct:"BatchPinVerification" json:"payloadRef,omitempty"`
+	PayloadHash      *fftypes.Bytes32 `ffstruct:"BatchPinVerification" json:"payloadHash,omitempty"`
+	PayloadHashMatch *bool            `ffstruct:"BatchPinVerification" json:"payloadHashMatch,omitempty"`
+	Verified         bool             `ffstruct:"BatchPinVerification" json:"verified"`
+	Errors           []string         `ffstruct:"BatchPinVerification" json:"errors,omitempty"`
+}
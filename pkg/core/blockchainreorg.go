@@ -0,0 +1,69 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+)
+
+// BlockchainReorg records that a blockchain plugin reported blocks as removed from the chain,
+// and the state that was derived from those blocks and has been reverted as a result.
+// Events from the blocks that replaced them are processed as normal after the re-org.
+type BlockchainReorg struct {
+	ID          *fftypes.UUID            `ffstruct:"BlockchainReorg" json:"id"`
+	Namespace   string                   `ffstruct:"BlockchainReorg" json:"namespace"`
+	Source      string                   `ffstruct:"BlockchainReorg" json:"source"`
+	BlockNumber int64                    `ffstruct:"BlockchainReorg" json:"blockNumber"`
+	BlockHash   string                   `ffstruct:"BlockchainReorg" json:"blockHash,omitempty"`
+	Reverted    *BlockchainReorgReverted `ffstruct:"BlockchainReorg" json:"reverted"`
+	Created     *fftypes.FFTime          `ffstruct:"BlockchainReorg" json:"created"`
+}
+
+// BlockchainReorgReverted lists everything that was affected by a re-org.
+// Messages that were already confirmed are moved back to pending, so applications that have
+// processed their message_confirmed events must compensate until they are confirmed again.
+type BlockchainReorgReverted struct {
+	BlockchainEvents  []*fftypes.UUID `ffstruct:"BlockchainReorgReverted" json:"blockchainEvents,omitempty"`
+	TokenTransfers    []*fftypes.UUID `ffstruct:"BlockchainReorgReverted" json:"tokenTransfers,omitempty"`
+	Batches           []*fftypes.UUID `ffstruct:"BlockchainReorgReverted" json:"batches,omitempty"`
+	ConfirmedMessages []*fftypes.UUID `ffstruct:"BlockchainReorgReverted" json:"confirmedMessages,omitempty"`
+}
+
+// Scan implements sql.Scanner
+func (r *BlockchainReorgReverted) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), &r)
+	case []byte:
+		return json.Unmarshal(src, &r)
+	default:
+		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, r)
+	}
+}
+
+// Value implements sql.Valuer
+func (r BlockchainReorgReverted) Value() (driver.Value, error) {
+	bytes, _ := json.Marshal(r)
+	return bytes, nil
+}
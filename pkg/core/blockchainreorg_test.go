@@ -0,0 +1,57 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockchainReorgRevertedDatabaseSerialization(t *testing.T) {
+	reverted1 := &BlockchainReorgReverted{
+		BlockchainEvents: []*fftypes.UUID{fftypes.NewUUID()},
+		Batches:          []*fftypes.UUID{fftypes.NewUUID()},
+	}
+
+	// Verify it serializes as bytes to the database
+	b1, err := reverted1.Value()
+	assert.NoError(t, err)
+
+	// Verify it restores ok
+	reverted2 := &BlockchainReorgReverted{}
+	err = reverted2.Scan(b1)
+	assert.NoError(t, err)
+	assert.Equal(t, reverted1, reverted2)
+
+	// Verify it restores from a string
+	reverted3 := &BlockchainReorgReverted{}
+	err = reverted3.Scan(string(b1.([]byte)))
+	assert.NoError(t, err)
+	assert.Equal(t, reverted1, reverted3)
+
+	// Verify nil is left empty
+	reverted4 := &BlockchainReorgReverted{}
+	err = reverted4.Scan(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, reverted4.BlockchainEvents)
+
+	// Verify a bad type is rejected
+	err = reverted4.Scan(12345)
+	assert.Regexp(t, "FF00105", err)
+}
@@ -0,0 +1,38 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// CacheStatus is the configuration of a cache, and how it has been used since it was created or last invalidated
+type CacheStatus struct {
+	Name           string             `ffstruct:"CacheStatus" json:"name"`
+	Namespace      string             `ffstruct:"CacheStatus" json:"namespace"`
+	MaxSize        int64              `ffstruct:"CacheStatus" json:"maxSize"`
+	ConfiguredSize int64              `ffstruct:"CacheStatus" json:"configuredSize"`
+	TTL            fftypes.FFDuration `ffstruct:"CacheStatus" json:"ttl"`
+	Hits           int64              `ffstruct:"CacheStatus" json:"hits"`
+	Misses         int64              `ffstruct:"CacheStatus" json:"misses"`
+	HitRatio       float64            `ffstruct:"CacheStatus" json:"hitRatio"`
+	Sets           int64              `ffstruct:"CacheStatus" json:"sets"`
+	Deletes        int64              `ffstruct:"CacheStatus" json:"deletes"`
+	Created        *fftypes.FFTime    `ffstruct:"CacheStatus" json:"created,omitempty"`
+	Invalidated    *fftypes.FFTime    `ffstruct:"CacheStatus" json:"invalidated,omitempty"`
+	Resized        *fftypes.FFTime    `ffstruct:"CacheStatus" json:"resized,omitempty"`
+}
@@ -0,0 +1,33 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// CatchUpStatus reports the progress of the bulk download of historical broadcast batches
+type CatchUpStatus struct {
+	Active     bool            `ffstruct:"CatchUpStatus" json:"active"`
+	Started    *fftypes.FFTime `ffstruct:"CatchUpStatus" json:"started,omitempty"`
+	Completed  *fftypes.FFTime `ffstruct:"CatchUpStatus" json:"completed,omitempty"`
+	Offset     int64           `ffstruct:"CatchUpStatus" json:"offset"`
+	Scanned    int64           `ffstruct:"CatchUpStatus" json:"scanned"`
+	Downloaded int64           `ffstruct:"CatchUpStatus" json:"downloaded"`
+	Skipped    int64           `ffstruct:"CatchUpStatus" json:"skipped"`
+	Requeued   int64           `ffstruct:"CatchUpStatus" json:"requeued"`
+}
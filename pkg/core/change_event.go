@@ -41,6 +41,9 @@ type WSChangeEventCommand struct {
 	Type        WSChangeEventCommandType `json:"type" ffenum:"changeevent_cmd_type"`
 	Collections []string                 `json:"collections"`
 	Filter      ChangeEventFilter        `json:"filter"`
+	// ResumeFrom is the changeSequence of the last event received on a previous connection. The recent events after it
+	// that match the filter are replayed before live events are delivered
+	ResumeFrom *int64 `json:"resumeFrom,omitempty"`
 }
 
 type ChangeEventFilter struct {
@@ -66,4 +69,7 @@ type ChangeEvent struct {
 	DroppedSince *fftypes.FFTime `json:"droppedSince,omitempty"`
 	// DroppedCount only for ChangeEventTypeDropped. How many events dropped
 	DroppedCount int64 `json:"droppedCount,omitempty"`
+	// ChangeSequence is assigned as the event is dispatched, and can be passed as resumeFrom when reconnecting.
+	// It is local to the running node and restarts from 1 when the node restarts
+	ChangeSequence int64 `json:"changeSequence,omitempty"`
 }
@@ -0,0 +1,40 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// CircuitBreakerState is the state of the circuit breaker protecting calls to a plugin
+type CircuitBreakerState = fftypes.FFEnum
+
+var (
+	// CircuitBreakerStateClosed calls flow to the plugin as normal
+	CircuitBreakerStateClosed = fftypes.FFEnumValue("circuitbreakerstate", "closed")
+	// CircuitBreakerStateOpen calls are rejected without reaching the plugin, until the cooldown expires
+	CircuitBreakerStateOpen = fftypes.FFEnumValue("circuitbreakerstate", "open")
+	// CircuitBreakerStateHalfOpen a single trial call is allowed through, to decide whether to close the breaker again
+	CircuitBreakerStateHalfOpen = fftypes.FFEnumValue("circuitbreakerstate", "half_open")
+)
+
+// CircuitBreakerStatus is the current state of the circuit breaker for a single plugin
+type CircuitBreakerStatus struct {
+	Plugin     string              `ffstruct:"CircuitBreakerStatus" json:"plugin"`
+	State      CircuitBreakerState `ffstruct:"CircuitBreakerStatus" json:"state" ffenum:"circuitbreakerstate"`
+	Failures   int                 `ffstruct:"CircuitBreakerStatus" json:"failures"`
+	Opened     *fftypes.FFTime     `ffstruct:"CircuitBreakerStatus" json:"opened,omitempty"`
+	RetryAfter *fftypes.FFTime     `ffstruct:"CircuitBreakerStatus" json:"retryAfter,omitempty"`
+}
@@ -0,0 +1,40 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// ConfirmationQueueEntryType identifies the callback a held blockchain event is replayed through once confirmed
+type ConfirmationQueueEntryType string
+
+const (
+	// ConfirmationQueueEntryTypeBlockchainEvent is an event from the blockchain plugin - a batch pin, network action or contract event
+	ConfirmationQueueEntryTypeBlockchainEvent ConfirmationQueueEntryType = "blockchain_event"
+	// ConfirmationQueueEntryTypeTokenTransfer is a transfer, mint or burn from a token connector
+	ConfirmationQueueEntryTypeTokenTransfer ConfirmationQueueEntryType = "token_transfer"
+)
+
+// ConfirmationQueueEntry is a blockchain event held until it is buried by the namespace's required number of confirmations
+type ConfirmationQueueEntry struct {
+	Namespace   string                     `json:"namespace"`
+	BlockNumber int64                      `json:"blockNumber"`
+	BlockHash   string                     `json:"blockHash,omitempty"`
+	Type        ConfirmationQueueEntryType `json:"type"`
+	Payload     *fftypes.JSONAny           `json:"payload"`
+	Created     *fftypes.FFTime            `json:"created"`
+	Sequence    int64                      `json:"-"` // Local database sequence, which orders entries as they were received
+}
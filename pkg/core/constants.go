@@ -27,6 +27,16 @@ const (
 	SystemTopicDefinitions = "ff_definition"
 	// SystemBatchPinTopic is the FireFly event topic for events from the FireFly batch pin listener
 	SystemBatchPinTopic = "ff_batch_pin"
+	// SystemTopicDefinitionApprovals is the FireFly event topic for approvals of definitions that require governance approval
+	SystemTopicDefinitionApprovals = "ff_definition_approval"
+	// SystemTopicHealth is the FireFly event topic for changes in the health of the plugin dependencies of a namespace
+	SystemTopicHealth = "ff_health"
+	// SystemTopicOperations is the FireFly event topic for alerts about operations that are stuck in pending
+	SystemTopicOperations = "ff_operations"
+	// SystemTopicAuditAnchors is the FireFly event topic for alerts about audit anchors that could not be pinned to the blockchain
+	SystemTopicAuditAnchors = "ff_audit_anchor"
+	// SystemTopicSLO is the FireFly event topic for service level objectives of a namespace being breached or recovering
+	SystemTopicSLO = "ff_slo"
 )
 
 const (
@@ -38,6 +48,8 @@ const (
 	DeprecatedSystemTagDefineNode = "ff_define_node"
 	// SystemTagDefineGroup is the tag for messages that send the definition of a group, to all parties in that group
 	SystemTagDefineGroup = "ff_define_group"
+	// SystemTagUpdateGroup is the tag for messages that send updated metadata of a group, such as its display name, to all parties in that group
+	SystemTagUpdateGroup = "ff_update_group"
 	// SystemTagDefinePool is the tag for messages that broadcast data definitions
 	SystemTagDefinePool = "ff_define_pool"
 	// SystemTagDefineFFI is the tag for messages that broadcast contract FFIs
@@ -53,6 +65,14 @@ const (
 	SystemTagIdentityUpdate = "ff_identity_update"
 	// SystemTagGapFill is the tag for messages that provide a nonce gap fill for a message that failed to send
 	SystemTagGapFill = "ff_gap_fill"
+	// SystemTagApproveDefinition is the tag for messages that broadcast an approval of a pending definition
+	SystemTagApproveDefinition = "ff_approve_definition"
+	// SystemTagProposeContractMigration is the tag for messages that broadcast a proposal to migrate to a new FireFly contract
+	SystemTagProposeContractMigration = "ff_propose_contract_migration"
+	// SystemTagAckContractMigration is the tag for messages that broadcast a member acknowledgement of a contract migration
+	SystemTagAckContractMigration = "ff_ack_contract_migration"
+	// SystemTagDefineDisclosure is the tag for messages that broadcast a zero-knowledge proof about the private data of a message
+	SystemTagDefineDisclosure = "ff_define_disclosure"
 )
 
 const (
@@ -0,0 +1,92 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// ContractMigrationState is the progress of a coordinated migration to a new FireFly contract
+type ContractMigrationState = fftypes.FFEnum
+
+var (
+	// ContractMigrationStateProposed the migration has been proposed, and is waiting for acknowledgements from all members
+	ContractMigrationStateProposed = fftypes.FFEnumValue("contractmigrationstate", "proposed")
+	// ContractMigrationStateReady all members have acknowledged, and the switch will happen at the activation block
+	ContractMigrationStateReady = fftypes.FFEnumValue("contractmigrationstate", "ready")
+	// ContractMigrationStateComplete the batch pin target has been switched to the new contract
+	ContractMigrationStateComplete = fftypes.FFEnumValue("contractmigrationstate", "complete")
+	// ContractMigrationStateRolledBack the switch failed on this node, so it remained on the current contract
+	ContractMigrationStateRolledBack = fftypes.FFEnumValue("contractmigrationstate", "rolled_back")
+)
+
+// ContractMigrationProposal is broadcast by a member to propose migrating the network to the next FireFly contract.
+// Every member must have the new contract configured as the next entry in its multiparty contract list.
+type ContractMigrationProposal struct {
+	ID              *fftypes.UUID    `ffstruct:"ContractMigrationProposal" json:"id,omitempty" ffexcludeinput:"true"`
+	Location        *fftypes.JSONAny `ffstruct:"ContractMigrationProposal" json:"location"`
+	Version         int              `ffstruct:"ContractMigrationProposal" json:"version,omitempty"`
+	ActivationBlock uint64           `ffstruct:"ContractMigrationProposal" json:"activationBlock"`
+	Message         *fftypes.UUID    `ffstruct:"ContractMigrationProposal" json:"message,omitempty" ffexcludeinput:"true"`
+}
+
+func (cmp *ContractMigrationProposal) Topic() string {
+	return SystemTopicDefinitions
+}
+
+func (cmp *ContractMigrationProposal) SetBroadcastMessage(msgID *fftypes.UUID) {
+	cmp.Message = msgID
+}
+
+// ContractMigrationAck is broadcast by a member to confirm it is ready to switch to the proposed contract
+type ContractMigrationAck struct {
+	Migration *fftypes.UUID    `ffstruct:"ContractMigrationAck" json:"migration"`
+	Location  *fftypes.JSONAny `ffstruct:"ContractMigrationAck" json:"location"`
+}
+
+func (cma *ContractMigrationAck) Topic() string {
+	return SystemTopicDefinitions
+}
+
+func (cma *ContractMigrationAck) SetBroadcastMessage(msgID *fftypes.UUID) {
+	// The acknowledgement is correlated to the migration, not to its own broadcast message
+}
+
+// ContractMigration is the state of a coordinated migration to a new FireFly contract, as recorded by this node
+type ContractMigration struct {
+	ContractMigrationProposal
+	Proposer         string                 `ffstruct:"ContractMigration" json:"proposer"`
+	State            ContractMigrationState `ffstruct:"ContractMigration" json:"state" ffenum:"contractmigrationstate"`
+	Members          []string               `ffstruct:"ContractMigration" json:"members"`
+	Acknowledgements []string               `ffstruct:"ContractMigration" json:"acknowledgements"`
+	Error            string                 `ffstruct:"ContractMigration" json:"error,omitempty"`
+	Created          *fftypes.FFTime        `ffstruct:"ContractMigration" json:"created"`
+	Updated          *fftypes.FFTime        `ffstruct:"ContractMigration" json:"updated,omitempty"`
+}
+
+// InProgress is true if the migration has not yet completed or been rolled back
+func (cm *ContractMigration) InProgress() bool {
+	return cm != nil && (cm.State == ContractMigrationStateProposed || cm.State == ContractMigrationStateReady)
+}
+
+// IsAcknowledged is true if the given member has acknowledged the migration
+func (cm *ContractMigration) IsAcknowledged(did string) bool {
+	for _, ack := range cm.Acknowledgements {
+		if ack == did {
+			return true
+		}
+	}
+	return false
+}
@@ -18,8 +18,12 @@ package core
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
 )
 
 type ContractCallType = fftypes.FFEnum
@@ -43,6 +47,21 @@ type ContractCallRequest struct {
 	Options        map[string]interface{} `ffstruct:"ContractCallRequest" json:"options"`
 	Message        *MessageInOut          `ffstruct:"ContractCallRequest" json:"message,omitempty" ffexcludeinput:"postContractQuery,postContractAPIQuery"`
 	IdempotencyKey IdempotencyKey         `ffstruct:"ContractCallRequest" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
+	TxLabels       fftypes.FFStringArray  `ffstruct:"ContractCallRequest" json:"txlabels,omitempty" ffexcludeinput:"postContractQuery,postContractAPIQuery" ffexcludeoutput:"true"`
+}
+
+// ContractInvokeStep is one step of an ordered sequence of contract invocations, with the invocation that undoes it
+type ContractInvokeStep struct {
+	Name         string               `ffstruct:"ContractInvokeStep" json:"name,omitempty"`
+	Invoke       *ContractCallRequest `ffstruct:"ContractInvokeStep" json:"invoke"`
+	Compensation *ContractCallRequest `ffstruct:"ContractInvokeStep" json:"compensation,omitempty"`
+}
+
+// ContractInvokeSequenceRequest submits contract invocations as the ordered saga steps of a single transaction
+type ContractInvokeSequenceRequest struct {
+	Steps          []*ContractInvokeStep `ffstruct:"ContractInvokeSequenceRequest" json:"steps"`
+	IdempotencyKey IdempotencyKey        `ffstruct:"ContractInvokeSequenceRequest" json:"idempotencyKey,omitempty"`
+	TxLabels       fftypes.FFStringArray `ffstruct:"ContractInvokeSequenceRequest" json:"txlabels,omitempty"`
 }
 
 type ContractDeployRequest struct {
@@ -61,17 +80,29 @@ type ContractURLs struct {
 }
 
 type ContractAPI struct {
-	ID          *fftypes.UUID         `ffstruct:"ContractAPI" json:"id,omitempty" ffexcludeinput:"true"`
-	Namespace   string                `ffstruct:"ContractAPI" json:"namespace,omitempty" ffexcludeinput:"true"`
-	Interface   *fftypes.FFIReference `ffstruct:"ContractAPI" json:"interface"`
-	Location    *fftypes.JSONAny      `ffstruct:"ContractAPI" json:"location,omitempty"`
-	Name        string                `ffstruct:"ContractAPI" json:"name"`
-	NetworkName string                `ffstruct:"ContractAPI" json:"networkName,omitempty"`
-	Message     *fftypes.UUID         `ffstruct:"ContractAPI" json:"message,omitempty" ffexcludeinput:"true"`
-	URLs        ContractURLs          `ffstruct:"ContractAPI" json:"urls" ffexcludeinput:"true"`
-	Published   bool                  `ffstruct:"ContractAPI" json:"published" ffexcludeinput:"true"`
+	ID          *fftypes.UUID                `ffstruct:"ContractAPI" json:"id,omitempty" ffexcludeinput:"true"`
+	Namespace   string                       `ffstruct:"ContractAPI" json:"namespace,omitempty" ffexcludeinput:"true"`
+	Interface   *fftypes.FFIReference        `ffstruct:"ContractAPI" json:"interface"`
+	Location    *fftypes.JSONAny             `ffstruct:"ContractAPI" json:"location,omitempty"`
+	Name        string                       `ffstruct:"ContractAPI" json:"name"`
+	NetworkName string                       `ffstruct:"ContractAPI" json:"networkName,omitempty"`
+	Message     *fftypes.UUID                `ffstruct:"ContractAPI" json:"message,omitempty" ffexcludeinput:"true"`
+	URLs        ContractURLs                 `ffstruct:"ContractAPI" json:"urls" ffexcludeinput:"true"`
+	Published   bool                         `ffstruct:"ContractAPI" json:"published" ffexcludeinput:"true"`
+	Listeners   ContractAPIListenerTemplates `ffstruct:"ContractAPI" json:"listeners,omitempty"`
 }
 
+// ContractAPIListenerTemplate declares a contract listener that is created automatically
+// for an event of the API's interface, whenever the API is defined with a location
+type ContractAPIListenerTemplate struct {
+	Name      string                   `ffstruct:"ContractAPIListenerTemplate" json:"name,omitempty"`
+	EventPath string                   `ffstruct:"ContractAPIListenerTemplate" json:"eventPath"`
+	Topic     string                   `ffstruct:"ContractAPIListenerTemplate" json:"topic,omitempty"`
+	Options   *ContractListenerOptions `ffstruct:"ContractAPIListenerTemplate" json:"options,omitempty"`
+}
+
+type ContractAPIListenerTemplates []*ContractAPIListenerTemplate
+
 func (c *ContractAPI) Validate(ctx context.Context) (err error) {
 	if err = fftypes.ValidateFFNameField(ctx, c.Namespace, "namespace"); err != nil {
 		return err
@@ -84,9 +115,38 @@ func (c *ContractAPI) Validate(ctx context.Context) (err error) {
 			return err
 		}
 	}
+	for _, l := range c.Listeners {
+		if l == nil || l.EventPath == "" {
+			return i18n.NewError(ctx, i18n.MsgMissingRequiredField, "listeners.eventPath")
+		}
+		if err = fftypes.ValidateFFNameField(ctx, c.ListenerName(l), "listeners.name"); err != nil {
+			return err
+		}
+		if err = fftypes.ValidateFFNameField(ctx, c.ListenerTopic(l), "listeners.topic"); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// ListenerName returns the name of the contract listener created from a template,
+// which defaults to the API name combined with the event path
+func (c *ContractAPI) ListenerName(l *ContractAPIListenerTemplate) string {
+	if l.Name != "" {
+		return l.Name
+	}
+	return fmt.Sprintf("%s-%s", c.Name, l.EventPath)
+}
+
+// ListenerTopic returns the topic of the contract listener created from a template,
+// which defaults to the API name
+func (c *ContractAPI) ListenerTopic(l *ContractAPIListenerTemplate) string {
+	if l.Topic != "" {
+		return l.Topic
+	}
+	return c.Name
+}
+
 func (c *ContractAPI) Topic() string {
 	return fftypes.TypeNamespaceNameTopicHash("contractapi", c.Namespace, c.NetworkName)
 }
@@ -101,3 +161,26 @@ func (c *ContractAPI) LocationAndLedgerEquals(a *ContractAPI) bool {
 	}
 	return c.Location.Hash().Equals(a.Location.Hash())
 }
+
+// Scan implements sql.Scanner
+func (lt *ContractAPIListenerTemplates) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		lt = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), &lt)
+	case []byte:
+		return json.Unmarshal(src, &lt)
+	default:
+		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, lt)
+	}
+}
+
+func (lt ContractAPIListenerTemplates) Value() (driver.Value, error) {
+	if lt == nil {
+		return nil, nil
+	}
+	bytes, _ := json.Marshal(lt)
+	return bytes, nil
+}
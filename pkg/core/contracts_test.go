@@ -57,6 +57,66 @@ func TestValidateInvalidContractAPI(t *testing.T) {
 	assert.Regexp(t, "FF00140", err)
 }
 
+func TestValidateContractAPIListeners(t *testing.T) {
+	api := &ContractAPI{
+		Namespace: "ns1",
+		Name:      "banana",
+		Listeners: ContractAPIListenerTemplates{
+			{EventPath: "Changed"},
+			{EventPath: "Removed", Name: "removals", Topic: "banana-removals"},
+		},
+	}
+	err := api.Validate(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "banana-Changed", api.ListenerName(api.Listeners[0]))
+	assert.Equal(t, "banana", api.ListenerTopic(api.Listeners[0]))
+	assert.Equal(t, "removals", api.ListenerName(api.Listeners[1]))
+	assert.Equal(t, "banana-removals", api.ListenerTopic(api.Listeners[1]))
+}
+
+func TestValidateContractAPIListenersInvalid(t *testing.T) {
+	api := &ContractAPI{
+		Namespace: "ns1",
+		Name:      "banana",
+		Listeners: ContractAPIListenerTemplates{{}},
+	}
+	err := api.Validate(context.Background())
+	assert.Regexp(t, "listeners.eventPath", err)
+
+	api.Listeners = ContractAPIListenerTemplates{{EventPath: "Changed", Name: "(%&@!^%^)"}}
+	err = api.Validate(context.Background())
+	assert.Regexp(t, "FF00140", err)
+
+	api.Listeners = ContractAPIListenerTemplates{{EventPath: "Changed", Topic: "(%&@!^%^)"}}
+	err = api.Validate(context.Background())
+	assert.Regexp(t, "FF00140", err)
+}
+
+func TestContractAPIListenerTemplatesScanValue(t *testing.T) {
+	templates := ContractAPIListenerTemplates{}
+	err := templates.Scan(`[{"eventPath":"Changed","options":{"firstEvent":"oldest"}}]`)
+	assert.NoError(t, err)
+	assert.Equal(t, "oldest", templates[0].Options.FirstEvent)
+
+	err = templates.Scan([]byte(`[{"eventPath":"Changed","topic":"t1"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", templates[0].Topic)
+
+	v, err := templates.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"eventPath":"Changed","topic":"t1"}]`, string(v.([]byte)))
+
+	err = templates.Scan(nil)
+	assert.NoError(t, err)
+
+	err = templates.Scan(12345)
+	assert.Regexp(t, "FF00105", err)
+
+	v, err = ContractAPIListenerTemplates(nil).Value()
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
 func TestContractAPITopic(t *testing.T) {
 	api := &ContractAPI{
 		Namespace:   "ns1",
@@ -0,0 +1,30 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+)
+
+// CredentialRotator is implemented by plugins that can switch to new credentials for their connector while
+// running, without interrupting operations that are in flight. The config is the updated plugin config
+// section, in which only the credentials differ from the config the plugin was initialized with.
+type CredentialRotator interface {
+	RotateCredentials(ctx context.Context, config config.Section) error
+}
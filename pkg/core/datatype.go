@@ -47,6 +47,13 @@ type Datatype struct {
 	Value     *fftypes.JSONAny `ffstruct:"Datatype" json:"value,omitempty"`
 }
 
+// DatatypeInference is the input for inferring a JSON schema datatype from sample payloads
+type DatatypeInference struct {
+	Name    string             `ffstruct:"DatatypeInference" json:"name"`
+	Version string             `ffstruct:"DatatypeInference" json:"version"`
+	Samples []*fftypes.JSONAny `ffstruct:"DatatypeInference" json:"samples"`
+}
+
 func (dt *Datatype) Validate(ctx context.Context, existing bool) (err error) {
 	if dt.Validator != ValidatorTypeJSON {
 		return i18n.NewError(ctx, i18n.MsgUnknownFieldValue, "validator", dt.Validator)
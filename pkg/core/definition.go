@@ -16,7 +16,14 @@
 
 package core
 
-import "github.com/hyperledger/firefly-common/pkg/fftypes"
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// CustomDefinitionTagPrefix is the tag prefix that must be used by all custom definition types
+const CustomDefinitionTagPrefix = "ff_define_"
 
 // Definition is implemented by all objects that can be broadcast as system definitions to the network
 type Definition interface {
@@ -29,3 +36,14 @@ type Definition interface {
 type DefinitionPublish struct {
 	NetworkName string `ffstruct:"DefinitionPublish" json:"networkName,omitempty"`
 }
+
+// CustomDefinitionHandler is implemented by extensions that process their own definition types, broadcast
+// with a custom ff_define_ tag. The handler is invoked in-line by the aggregator with the same batch state as
+// the built-in definitions, so it must be deterministic - the result must depend only on the message, its data,
+// and the state of the local database - so that every member of the network reaches the same outcome.
+type CustomDefinitionHandler interface {
+	// HandleDefinition validates and stores the definition, returning ActionConfirm or ActionReject, or
+	// ActionRetry on a transient error. Non-idempotent work (such as inserting events) should be deferred
+	// with state.AddFinalize.
+	HandleDefinition(ctx context.Context, state *BatchState, msg *Message, data DataArray, tx *fftypes.UUID) (MessageAction, error)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// DefinitionApprovalState is the governance approval state of a definition
+type DefinitionApprovalState = fftypes.FFEnum
+
+var (
+	// DefinitionApprovalStatePending the definition is held until enough approvals have been received
+	DefinitionApprovalStatePending = fftypes.FFEnumValue("definitionapprovalstate", "pending_approval")
+	// DefinitionApprovalStateApproved the definition received the required approvals and has been processed
+	DefinitionApprovalStateApproved = fftypes.FFEnumValue("definitionapprovalstate", "approved")
+	// DefinitionApprovalStateRejected the definition was rejected when it was processed
+	DefinitionApprovalStateRejected = fftypes.FFEnumValue("definitionapprovalstate", "rejected")
+)
+
+// DefinitionApproval is broadcast by a member to approve a pending definition. The hash binds the approval to the
+// exact content of the definition message, and the approval is signed by the member as part of the batch pin.
+type DefinitionApproval struct {
+	Message *fftypes.UUID    `ffstruct:"DefinitionApproval" json:"message"`
+	Hash    *fftypes.Bytes32 `ffstruct:"DefinitionApproval" json:"hash"`
+}
+
+func (da *DefinitionApproval) Topic() string {
+	return SystemTopicDefinitionApprovals
+}
+
+func (da *DefinitionApproval) SetBroadcastMessage(msgID *fftypes.UUID) {
+	// The approval is correlated to the definition message, not to its own broadcast message
+}
+
+// DefinitionApprovalStatus is the approval progress of a definition that requires governance approval
+type DefinitionApprovalStatus struct {
+	Message   *fftypes.UUID           `ffstruct:"DefinitionApprovalStatus" json:"message"`
+	Tag       string                  `ffstruct:"DefinitionApprovalStatus" json:"tag"`
+	State     DefinitionApprovalState `ffstruct:"DefinitionApprovalStatus" json:"state" ffenum:"definitionapprovalstate"`
+	Required  int                     `ffstruct:"DefinitionApprovalStatus" json:"required"`
+	Approvers []string                `ffstruct:"DefinitionApprovalStatus" json:"approvers"`
+}
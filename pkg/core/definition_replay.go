@@ -0,0 +1,54 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// DefinitionReplayRecord is a definition broadcast, with the outcome recorded when it was processed
+type DefinitionReplayRecord struct {
+	Message *Message     `ffstruct:"DefinitionReplayRecord" json:"message"`
+	Data    DataArray    `ffstruct:"DefinitionReplayRecord" json:"data"`
+	Outcome MessageState `ffstruct:"DefinitionReplayRecord" json:"outcome" ffenum:"messagestate"`
+}
+
+// DefinitionReplayInput is a recorded sequence of definition broadcasts, to replay in order
+type DefinitionReplayInput struct {
+	Records []*DefinitionReplayRecord `ffstruct:"DefinitionReplayInput" json:"records"`
+}
+
+// DefinitionReplayResult is the outcome of replaying a single definition broadcast
+type DefinitionReplayResult struct {
+	Message  *fftypes.UUID `ffstruct:"DefinitionReplayResult" json:"message"`
+	Tag      string        `ffstruct:"DefinitionReplayResult" json:"tag"`
+	Recorded MessageState  `ffstruct:"DefinitionReplayResult" json:"recorded" ffenum:"messagestate"`
+	Replayed MessageState  `ffstruct:"DefinitionReplayResult" json:"replayed,omitempty" ffenum:"messagestate"`
+	Diverged bool          `ffstruct:"DefinitionReplayResult" json:"diverged"`
+	Skipped  bool          `ffstruct:"DefinitionReplayResult" json:"skipped,omitempty"`
+	Error    string        `ffstruct:"DefinitionReplayResult" json:"error,omitempty"`
+}
+
+// DefinitionReplayReport compares the outcome of replaying a sequence of definition broadcasts with the recorded outcomes
+type DefinitionReplayReport struct {
+	Total    int                       `ffstruct:"DefinitionReplayReport" json:"total"`
+	Replayed int                       `ffstruct:"DefinitionReplayReport" json:"replayed"`
+	Matched  int                       `ffstruct:"DefinitionReplayReport" json:"matched"`
+	Diverged int                       `ffstruct:"DefinitionReplayReport" json:"diverged"`
+	Skipped  int                       `ffstruct:"DefinitionReplayReport" json:"skipped"`
+	Results  []*DefinitionReplayResult `ffstruct:"DefinitionReplayReport" json:"results"`
+}
@@ -0,0 +1,66 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// DisclosureStatus is whether this node has verified the proof of a disclosure. It is local to each node, as
+// members confirm the disclosure definition the same way regardless of the status they reach.
+type DisclosureStatus = fftypes.FFEnum
+
+var (
+	// DisclosureStatusPending the disclosure is confirmed, and has not yet been verified by this node
+	DisclosureStatusPending = fftypes.FFEnumValue("disclosurestatus", "pending")
+	// DisclosureStatusVerified the proof was verified by the ZK proof plugin of this node
+	DisclosureStatusVerified = fftypes.FFEnumValue("disclosurestatus", "verified")
+	// DisclosureStatusUnverified no ZK proof plugin is configured on this node, so the proof was recorded without verification
+	DisclosureStatusUnverified = fftypes.FFEnumValue("disclosurestatus", "unverified")
+	// DisclosureStatusInvalid the proof was rejected by the ZK proof plugin of this node, or the message hash does not match its copy
+	DisclosureStatusInvalid = fftypes.FFEnumValue("disclosurestatus", "invalid")
+)
+
+// DisclosureInput is a request to prove a statement about the private data of a message
+type DisclosureInput struct {
+	Circuit      string           `ffstruct:"DisclosureInput" json:"circuit"`
+	PublicInputs *fftypes.JSONAny `ffstruct:"DisclosureInput" json:"publicInputs,omitempty"`
+}
+
+// Disclosure is a zero-knowledge proof of a statement about the private data of a message, such as "amount < limit".
+// It is broadcast to the whole network, so members that do not hold the data can verify the statement.
+type Disclosure struct {
+	ID                *fftypes.UUID    `ffstruct:"Disclosure" json:"id"`
+	Namespace         string           `ffstruct:"Disclosure" json:"namespace,omitempty"`
+	Message           *fftypes.UUID    `ffstruct:"Disclosure" json:"message"`
+	MessageHash       *fftypes.Bytes32 `ffstruct:"Disclosure" json:"messageHash"`
+	Circuit           string           `ffstruct:"Disclosure" json:"circuit"`
+	PublicInputs      *fftypes.JSONAny `ffstruct:"Disclosure" json:"publicInputs,omitempty"`
+	Proof             *fftypes.JSONAny `ffstruct:"Disclosure" json:"proof"`
+	Author            string           `ffstruct:"Disclosure" json:"author,omitempty"`
+	Status            DisclosureStatus `ffstruct:"Disclosure" json:"status,omitempty" ffenum:"disclosurestatus"`
+	DefinitionMessage *fftypes.UUID    `ffstruct:"Disclosure" json:"definitionMessage,omitempty"`
+	Created           *fftypes.FFTime  `ffstruct:"Disclosure" json:"created,omitempty"`
+}
+
+func (d *Disclosure) Topic() string {
+	return SystemTopicDefinitions
+}
+
+func (d *Disclosure) SetBroadcastMessage(msgID *fftypes.UUID) {
+	d.DefinitionMessage = msgID
+}
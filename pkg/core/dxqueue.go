@@ -0,0 +1,40 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// DXSendPriority orders the outbound data exchange queue - lower values are sent first
+type DXSendPriority int
+
+const (
+	// DXSendPriorityDefinition is used for batches that establish state other messages depend on, such as group init
+	DXSendPriorityDefinition DXSendPriority = 0
+	// DXSendPriorityMessage is used for batches of messages that carry no blobs
+	DXSendPriorityMessage DXSendPriority = 1
+	// DXSendPriorityBlob is used for blob transfers, and the batches that reference them
+	DXSendPriorityBlob DXSendPriority = 2
+)
+
+// DXQueueEntry is a persisted outbound data exchange send, pending dispatch of its operation
+type DXQueueEntry struct {
+	Namespace string          `json:"namespace"`
+	Operation *fftypes.UUID   `json:"operation"`
+	Priority  DXSendPriority  `json:"priority"`
+	Created   *fftypes.FFTime `json:"created"`
+	Sequence  int64           `json:"-"` // Local database sequence, which orders entries of the same priority
+}
@@ -61,6 +61,25 @@ var (
 	EventTypeBlockchainContractDeployOpSucceeded = fftypes.FFEnumValue("eventtype", "blockchain_contract_deploy_op_succeeded")
 	// EventTypeBlockchainContractDeployOpFailed occurs when a contract deployment request has failed
 	EventTypeBlockchainContractDeployOpFailed = fftypes.FFEnumValue("eventtype", "blockchain_contract_deploy_op_failed")
+	// EventTypeDependencyDegraded occurs when a health probe finds a plugin dependency of the namespace is slow or unavailable
+	EventTypeDependencyDegraded = fftypes.FFEnumValue("eventtype", "dependency_degraded")
+	// EventTypeDependencyRecovered occurs when a health probe finds a previously degraded plugin dependency is healthy again
+	EventTypeDependencyRecovered = fftypes.FFEnumValue("eventtype", "dependency_recovered")
+	// EventTypeOperationStale occurs when an operation has been pending for longer than expected, and its plugin has been re-queried
+	EventTypeOperationStale = fftypes.FFEnumValue("eventtype", "operation_stale")
+	// EventTypeOperationTimedOut occurs when a stale operation is still unresolved after re-querying its plugin, and has been marked failed
+	EventTypeOperationTimedOut = fftypes.FFEnumValue("eventtype", "operation_timed_out")
+	// EventTypeSLOBreached occurs when a measurement of a service level objective of the namespace exceeds its threshold
+	EventTypeSLOBreached = fftypes.FFEnumValue("eventtype", "slo_breached")
+	// EventTypeSLORecovered occurs when a previously breached service level objective is back within its threshold
+	EventTypeSLORecovered = fftypes.FFEnumValue("eventtype", "slo_recovered")
+	// EventTypeDisclosureConfirmed occurs when a zero-knowledge proof about the private data of a message has been broadcast, and verified if this node has a ZK proof plugin
+	EventTypeDisclosureConfirmed = fftypes.FFEnumValue("eventtype", "disclosure_confirmed")
+	// EventTypeAuditAnchorFailed occurs when the pin of an audit anchor has failed on every attempt, and anchoring of the namespace has stopped
+	EventTypeAuditAnchorFailed = fftypes.FFEnumValue("eventtype", "audit_anchor_failed")
+	// EventTypeBlockchainReorg occurs when blocks are removed from the chain, and the state derived from them has been reverted.
+	// Messages that were already confirmed are moved back to pending, and confirmed again when their pins are re-delivered
+	EventTypeBlockchainReorg = fftypes.FFEnumValue("eventtype", "blockchain_reorg")
 )
 
 // Event is an activity in the system, delivered reliably to applications, that indicates something has happened in the network
@@ -80,6 +99,7 @@ type Event struct {
 type EnrichedEvent struct {
 	Event
 	BlockchainEvent   *BlockchainEvent `ffstruct:"EnrichedEvent" json:"blockchainEvent,omitempty"`
+	BlockchainReorg   *BlockchainReorg `ffstruct:"EnrichedEvent" json:"blockchainReorg,omitempty"`
 	ContractAPI       *ContractAPI     `ffstruct:"EnrichedEvent" json:"contractAPI,omitempty"`
 	ContractInterface *fftypes.FFI     `ffstruct:"EnrichedEvent" json:"contractInterface,omitempty"`
 	Datatype          *Datatype        `ffstruct:"EnrichedEvent" json:"datatype,omitempty"`
@@ -90,6 +110,7 @@ type EnrichedEvent struct {
 	TokenTransfer     *TokenTransfer   `ffstruct:"EnrichedEvent" json:"tokenTransfer,omitempty"`
 	Transaction       *Transaction     `ffstruct:"EnrichedEvent" json:"transaction,omitempty"`
 	Operation         *Operation       `ffstruct:"EnrichedEvent" json:"operation,omitempty"`
+	CorrelationID     string           `ffstruct:"EnrichedEvent" json:"correlationId,omitempty"`
 }
 
 // EventDelivery adds the referred object to an event, as well as details of the subscription that caused the event to
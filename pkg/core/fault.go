@@ -0,0 +1,60 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// FaultPlugin is a type of plugin that faults can be injected into
+type FaultPlugin = fftypes.FFEnum
+
+var (
+	// FaultPluginDatabase is the database plugin of the namespace
+	FaultPluginDatabase = fftypes.FFEnumValue("faultplugin", "database")
+	// FaultPluginBlockchain is the blockchain plugin of the namespace
+	FaultPluginBlockchain = fftypes.FFEnumValue("faultplugin", "blockchain")
+	// FaultPluginDataExchange is the data exchange plugin of the namespace
+	FaultPluginDataExchange = fftypes.FFEnumValue("faultplugin", "dataexchange")
+)
+
+// FaultAction is the fault injected into a plugin call
+type FaultAction = fftypes.FFEnum
+
+var (
+	// FaultActionDelay holds the call for the delay of the rule before making it
+	FaultActionDelay = fftypes.FFEnumValue("faultaction", "delay")
+	// FaultActionFail returns an error without making the call
+	FaultActionFail = fftypes.FFEnumValue("faultaction", "fail")
+	// FaultActionDuplicate makes the call twice, returning the result of the second call
+	FaultActionDuplicate = fftypes.FFEnumValue("faultaction", "duplicate")
+)
+
+// FaultRule injects a fault into a proportion of the calls to a plugin method
+type FaultRule struct {
+	Plugin      FaultPlugin         `ffstruct:"FaultRule" json:"plugin" ffenum:"faultplugin"`
+	Method      string              `ffstruct:"FaultRule" json:"method,omitempty"`
+	Action      FaultAction         `ffstruct:"FaultRule" json:"action" ffenum:"faultaction"`
+	Probability float64             `ffstruct:"FaultRule" json:"probability"`
+	Delay       *fftypes.FFDuration `ffstruct:"FaultRule" json:"delay,omitempty"`
+	Injected    int64               `ffstruct:"FaultRule" json:"injected" ffexcludeinput:"true"`
+}
+
+// NamespaceFaults is the set of fault injection rules of a namespace
+type NamespaceFaults struct {
+	Rules []*FaultRule `ffstruct:"NamespaceFaults" json:"rules"`
+}
@@ -0,0 +1,38 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// GraphQLRequest is a GraphQL query, in the standard JSON request format
+type GraphQLRequest struct {
+	Query         string             `ffstruct:"GraphQLRequest" json:"query"`
+	OperationName string             `ffstruct:"GraphQLRequest" json:"operationName,omitempty"`
+	Variables     fftypes.JSONObject `ffstruct:"GraphQLRequest" json:"variables,omitempty"`
+}
+
+// GraphQLError is an error resolving a GraphQL query, with the path of the field that failed if any
+type GraphQLError struct {
+	Message string        `ffstruct:"GraphQLError" json:"message"`
+	Path    []interface{} `ffstruct:"GraphQLError" json:"path,omitempty"`
+}
+
+// GraphQLResponse is the result of a GraphQL query, in the standard JSON response format
+type GraphQLResponse struct {
+	Data   *fftypes.JSONAny `ffstruct:"GraphQLResponse" json:"data,omitempty"`
+	Errors []*GraphQLError  `ffstruct:"GraphQLResponse" json:"errors,omitempty"`
+}
@@ -39,6 +39,64 @@ type Group struct {
 	Message        *fftypes.UUID    `ffstruct:"Group" json:"message,omitempty"`
 	Hash           *fftypes.Bytes32 `ffstruct:"Group" json:"hash,omitempty"`
 	Created        *fftypes.FFTime  `ffstruct:"Group" json:"created,omitempty"`
+	DisplayName    string           `ffstruct:"Group" json:"displayName,omitempty"`
+	Description    string           `ffstruct:"Group" json:"description,omitempty"`
+	Updated        *fftypes.FFTime  `ffstruct:"Group" json:"updated,omitempty"`
+}
+
+// GroupMetadata is the human-readable metadata of a group. Unlike the name and members, it is not
+// part of the group hash, so it can be changed after the group is created by sending it privately
+// to the members of the group.
+type GroupMetadata struct {
+	Group       *fftypes.Bytes32 `ffstruct:"GroupMetadata" json:"group,omitempty" ffexcludeinput:"true"`
+	DisplayName string           `ffstruct:"GroupMetadata" json:"displayName"`
+	Description string           `ffstruct:"GroupMetadata" json:"description,omitempty"`
+}
+
+func (gm *GroupMetadata) Validate(ctx context.Context) (err error) {
+	if err = fftypes.ValidateLength(ctx, gm.DisplayName, "displayName", 256); err != nil {
+		return err
+	}
+	return fftypes.ValidateLength(ctx, gm.Description, "description", 4096)
+}
+
+// MemberLatency is the time taken for the node of a group member to acknowledge receipt of the private
+// batches sent to it, measured from when each batch was dispatched for pinning
+type MemberLatency struct {
+	Identity string             `ffstruct:"MemberLatency" json:"identity"`
+	Node     *fftypes.UUID      `ffstruct:"MemberLatency" json:"node,omitempty"`
+	Local    bool               `ffstruct:"MemberLatency" json:"local"`
+	Batches  int                `ffstruct:"MemberLatency" json:"batches"`
+	Failed   int                `ffstruct:"MemberLatency" json:"failed"`
+	Average  fftypes.FFDuration `ffstruct:"MemberLatency" json:"average"`
+	P95      fftypes.FFDuration `ffstruct:"MemberLatency" json:"p95"`
+	Max      fftypes.FFDuration `ffstruct:"MemberLatency" json:"max"`
+	LastAck  *fftypes.FFTime    `ffstruct:"MemberLatency" json:"lastAck,omitempty"`
+}
+
+// MemberIdentityStatus is whether the identities of a group member can still be resolved by this node
+type MemberIdentityStatus = fftypes.FFEnum
+
+var (
+	// MemberIdentityStatusRegistered the org and node of the member are both registered, and the node is owned by the org
+	MemberIdentityStatusRegistered = fftypes.FFEnumValue("memberidentitystatus", "registered")
+	// MemberIdentityStatusUnregistered the org or the node of the member could not be found
+	MemberIdentityStatusUnregistered = fftypes.FFEnumValue("memberidentitystatus", "unregistered")
+	// MemberIdentityStatusInvalid the node of the member is not owned by the org of the member
+	MemberIdentityStatusInvalid = fftypes.FFEnumValue("memberidentitystatus", "invalid")
+)
+
+// MemberStatus combines the membership of a group with the health of the node of each member, as seen
+// from the local node. It allows a sender to find members whose nodes have been offline for some time.
+type MemberStatus struct {
+	Identity          string               `ffstruct:"MemberStatus" json:"identity"`
+	Node              *fftypes.UUID        `ffstruct:"MemberStatus" json:"node,omitempty"`
+	NodeName          string               `ffstruct:"MemberStatus" json:"nodeName,omitempty"`
+	Local             bool                 `ffstruct:"MemberStatus" json:"local"`
+	IdentityStatus    MemberIdentityStatus `ffstruct:"MemberStatus" json:"identityStatus" ffenum:"memberidentitystatus"`
+	LastBatchReceived *fftypes.FFTime      `ffstruct:"MemberStatus" json:"lastBatchReceived,omitempty"`
+	LastDXContact     *fftypes.FFTime      `ffstruct:"MemberStatus" json:"lastDXContact,omitempty"`
+	LastSeen          *fftypes.FFTime      `ffstruct:"MemberStatus" json:"lastSeen,omitempty"`
 }
 
 type Members []*Member
@@ -104,6 +162,10 @@ func (group *Group) Validate(ctx context.Context, existing bool) (err error) {
 		}
 		dupCheck[key] = true
 	}
+	metadata := &GroupMetadata{DisplayName: group.DisplayName, Description: group.Description}
+	if err = metadata.Validate(ctx); err != nil {
+		return err
+	}
 	if existing {
 		hash := group.GroupIdentity.Hash()
 		if !group.Hash.Equals(hash) {
@@ -97,6 +97,16 @@ func TestGroupValidation(t *testing.T) {
 	}
 	assert.Regexp(t, "FF00135.*identity", group.Validate(context.Background(), false))
 
+	group = &Group{
+		GroupIdentity: GroupIdentity{
+			Name:      "ok",
+			Namespace: "ok",
+			Members:   Members{{Identity: "0x12345", Node: fftypes.NewUUID()}},
+		},
+		DisplayName: string(make([]byte, 257)),
+	}
+	assert.Regexp(t, "FF00135.*displayName", group.Validate(context.Background(), false))
+
 	group = &Group{
 		GroupIdentity: GroupIdentity{
 			Name:      "ok",
@@ -174,3 +184,19 @@ func TestGroupSealSorting(t *testing.T) {
 	assert.Equal(t, *group1.Hash, *group2.Hash)
 
 }
+
+func TestGroupMetadataValidation(t *testing.T) {
+
+	metadata := &GroupMetadata{
+		DisplayName: "My Group",
+		Description: "A group for testing",
+	}
+	assert.NoError(t, metadata.Validate(context.Background()))
+
+	metadata.DisplayName = string(make([]byte, 257))
+	assert.Regexp(t, "FF00135.*displayName", metadata.Validate(context.Background()))
+
+	metadata.DisplayName = "My Group"
+	metadata.Description = string(make([]byte, 4097))
+	assert.Regexp(t, "FF00135.*description", metadata.Validate(context.Background()))
+}
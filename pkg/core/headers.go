@@ -19,4 +19,5 @@ package core
 const (
 	HTTPHeadersBlobHashSHA256 = "x-ff-blob-hash-sha256"
 	HTTPHeadersBlobSize       = "x-ff-blob-size"
+	HTTPHeadersNextCursor     = "x-ff-next-cursor"
 )
@@ -0,0 +1,61 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// HealthStatus is the result of probing a dependency of the namespace
+type HealthStatus = fftypes.FFEnum
+
+var (
+	// HealthStatusUnknown the dependency has not been probed, or the plugin does not support probing
+	HealthStatusUnknown = fftypes.FFEnumValue("healthstatus", "unknown")
+	// HealthStatusHealthy the dependency responded within the latency threshold
+	HealthStatusHealthy = fftypes.FFEnumValue("healthstatus", "healthy")
+	// HealthStatusDegraded the dependency responded, but slower than the latency threshold
+	HealthStatusDegraded = fftypes.FFEnumValue("healthstatus", "degraded")
+	// HealthStatusUnhealthy the dependency failed to respond
+	HealthStatusUnhealthy = fftypes.FFEnumValue("healthstatus", "unhealthy")
+)
+
+// HealthProber is implemented by plugins that can check connectivity to the backing service they depend on
+type HealthProber interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// DependencyHealth is the latest probe result for a single plugin the namespace depends on
+type DependencyHealth struct {
+	ID         *fftypes.UUID      `ffstruct:"DependencyHealth" json:"id"`
+	Name       string             `ffstruct:"DependencyHealth" json:"name"`
+	Type       string             `ffstruct:"DependencyHealth" json:"type"`
+	PluginType string             `ffstruct:"DependencyHealth" json:"pluginType"`
+	Status     HealthStatus       `ffstruct:"DependencyHealth" json:"status" ffenum:"healthstatus"`
+	Latency    fftypes.FFDuration `ffstruct:"DependencyHealth" json:"latency,omitempty"`
+	Error      string             `ffstruct:"DependencyHealth" json:"error,omitempty"`
+	Checked    *fftypes.FFTime    `ffstruct:"DependencyHealth" json:"checked,omitempty"`
+	Changed    *fftypes.FFTime    `ffstruct:"DependencyHealth" json:"changed,omitempty"`
+}
+
+// NamespaceHealth is the overall health of a namespace, with the detail for each dependency
+type NamespaceHealth struct {
+	Status       HealthStatus        `ffstruct:"NamespaceHealth" json:"status" ffenum:"healthstatus"`
+	Dependencies []*DependencyHealth `ffstruct:"NamespaceHealth" json:"dependencies"`
+}
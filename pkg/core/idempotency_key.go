@@ -20,6 +20,7 @@ import (
 	"context"
 	"database/sql/driver"
 
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 )
 
@@ -48,3 +49,14 @@ func (ik *IdempotencyKey) Scan(src interface{}) error {
 		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, ik)
 	}
 }
+
+// IdempotencyKeyRecord describes an idempotency key that is reserved in a namespace, along with
+// the transaction it was submitted with, and the operations of that transaction
+type IdempotencyKeyRecord struct {
+	Key         IdempotencyKey  `ffstruct:"IdempotencyKeyRecord" json:"key"`
+	Namespace   string          `ffstruct:"IdempotencyKeyRecord" json:"namespace"`
+	Transaction *fftypes.UUID   `ffstruct:"IdempotencyKeyRecord" json:"transaction"`
+	Type        TransactionType `ffstruct:"IdempotencyKeyRecord" json:"type"`
+	Created     *fftypes.FFTime `ffstruct:"IdempotencyKeyRecord" json:"created"`
+	Operations  []*fftypes.UUID `ffstruct:"IdempotencyKeyRecord" json:"operations"`
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// LedgerOperationType is the kind of change a ledger operation makes, in the standardized account/operation
+// model used to present the blockchain data of a namespace
+type LedgerOperationType = fftypes.FFEnum
+
+var (
+	// LedgerOperationTypePin is a context pinned to the chain for a batch of messages, signed by the account
+	LedgerOperationTypePin = fftypes.FFEnumValue("ledgeroptype", "pin")
+	// LedgerOperationTypeMint credits newly minted tokens to the account
+	LedgerOperationTypeMint = fftypes.FFEnumValue("ledgeroptype", "mint")
+	// LedgerOperationTypeBurn debits burned tokens from the account
+	LedgerOperationTypeBurn = fftypes.FFEnumValue("ledgeroptype", "burn")
+	// LedgerOperationTypeTransfer is one side of a token transfer - a debit of the sender, or a credit of the recipient
+	LedgerOperationTypeTransfer = fftypes.FFEnumValue("ledgeroptype", "transfer")
+	// LedgerOperationTypeEvent is any other blockchain event, such as an event from a contract listener
+	LedgerOperationTypeEvent = fftypes.FFEnumValue("ledgeroptype", "event")
+)
+
+// LedgerTransaction presents a blockchain event, and everything FireFly recorded from it, as a transaction
+// made up of operations on accounts
+type LedgerTransaction struct {
+	ID         *fftypes.UUID      `ffstruct:"LedgerTransaction" json:"id"`
+	Block      *LedgerBlock       `ffstruct:"LedgerTransaction" json:"block,omitempty"`
+	Hash       string             `ffstruct:"LedgerTransaction" json:"hash,omitempty"`
+	Source     string             `ffstruct:"LedgerTransaction" json:"source,omitempty"`
+	ProtocolID string             `ffstruct:"LedgerTransaction" json:"protocolId,omitempty"`
+	TX         *fftypes.UUID      `ffstruct:"LedgerTransaction" json:"tx,omitempty"`
+	Timestamp  *fftypes.FFTime    `ffstruct:"LedgerTransaction" json:"timestamp,omitempty"`
+	Operations []*LedgerOperation `ffstruct:"LedgerTransaction" json:"operations"`
+}
+
+// LedgerBlock identifies the block a ledger transaction was included in
+type LedgerBlock struct {
+	Index int64 `ffstruct:"LedgerBlock" json:"index"`
+}
+
+// LedgerOperation is a single change within a ledger transaction
+type LedgerOperation struct {
+	Index             int                 `ffstruct:"LedgerOperation" json:"index"`
+	RelatedOperations []int               `ffstruct:"LedgerOperation" json:"relatedOperations,omitempty"`
+	Type              LedgerOperationType `ffstruct:"LedgerOperation" json:"type" ffenum:"ledgeroptype"`
+	Account           *LedgerAccount      `ffstruct:"LedgerOperation" json:"account,omitempty"`
+	Amount            *LedgerAmount       `ffstruct:"LedgerOperation" json:"amount,omitempty"`
+	Metadata          fftypes.JSONObject  `ffstruct:"LedgerOperation" json:"metadata,omitempty"`
+}
+
+// LedgerAccount identifies the account an operation applies to
+type LedgerAccount struct {
+	Address string `ffstruct:"LedgerAccount" json:"address"`
+}
+
+// LedgerAmount is a signed amount of a currency, in the smallest unit of that currency
+type LedgerAmount struct {
+	Value    string          `ffstruct:"LedgerAmount" json:"value"`
+	Currency *LedgerCurrency `ffstruct:"LedgerAmount" json:"currency"`
+}
+
+// LedgerCurrency identifies the tokens an amount is made up of
+type LedgerCurrency struct {
+	Symbol     string        `ffstruct:"LedgerCurrency" json:"symbol,omitempty"`
+	Decimals   int           `ffstruct:"LedgerCurrency" json:"decimals"`
+	Pool       *fftypes.UUID `ffstruct:"LedgerCurrency" json:"pool"`
+	TokenIndex string        `ffstruct:"LedgerCurrency" json:"tokenIndex,omitempty"`
+}
+
+// LedgerBalance is the balance an account holds of a currency
+type LedgerBalance struct {
+	Account *LedgerAccount  `ffstruct:"LedgerBalance" json:"account"`
+	Amount  *LedgerAmount   `ffstruct:"LedgerBalance" json:"amount"`
+	Updated *fftypes.FFTime `ffstruct:"LedgerBalance" json:"updated,omitempty"`
+}
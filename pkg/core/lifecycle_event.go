@@ -0,0 +1,52 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// LifecycleEventType is a change in the state of the node, that is posted to the SPI webhook
+type LifecycleEventType = fftypes.FFEnum
+
+var (
+	// LifecycleEventTypeNamespaceStarted a namespace has initialized and started
+	LifecycleEventTypeNamespaceStarted = fftypes.FFEnumValue("lifecycleeventtype", "namespace_started")
+	// LifecycleEventTypeNamespaceStartFailed a namespace failed to start, and will be retried
+	LifecycleEventTypeNamespaceStartFailed = fftypes.FFEnumValue("lifecycleeventtype", "namespace_start_failed")
+	// LifecycleEventTypeNamespaceStopped a namespace has stopped, due to a configuration change or the node shutting down
+	LifecycleEventTypeNamespaceStopped = fftypes.FFEnumValue("lifecycleeventtype", "namespace_stopped")
+	// LifecycleEventTypePluginStarted a plugin has initialized, and connected to its runtime where it has one
+	LifecycleEventTypePluginStarted = fftypes.FFEnumValue("lifecycleeventtype", "plugin_started")
+	// LifecycleEventTypePluginStopped a plugin has been stopped after a configuration change
+	LifecycleEventTypePluginStopped = fftypes.FFEnumValue("lifecycleeventtype", "plugin_stopped")
+	// LifecycleEventTypePluginCredentialsRotated a running plugin has switched to new credentials after a configuration change
+	LifecycleEventTypePluginCredentialsRotated = fftypes.FFEnumValue("lifecycleeventtype", "plugin_credentials_rotated")
+	// LifecycleEventTypeConfigReloaded a changed configuration has been applied
+	LifecycleEventTypeConfigReloaded = fftypes.FFEnumValue("lifecycleeventtype", "config_reloaded")
+	// LifecycleEventTypeConfigReloadFailed a changed configuration could not be applied
+	LifecycleEventTypeConfigReloadFailed = fftypes.FFEnumValue("lifecycleeventtype", "config_reload_failed")
+)
+
+// LifecycleEvent is posted to the SPI webhook when the state of the node changes, so orchestration platforms
+// can react without holding open an SPI websocket
+type LifecycleEvent struct {
+	ID        *fftypes.UUID      `json:"id"`
+	Type      LifecycleEventType `json:"type" ffenum:"lifecycleeventtype"`
+	Namespace string             `json:"namespace,omitempty"`
+	Plugin    string             `json:"plugin,omitempty"`
+	Error     string             `json:"error,omitempty"`
+	Timestamp *fftypes.FFTime    `json:"timestamp"`
+}
@@ -0,0 +1,62 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// ListenerReconciliationStatus is the outcome of comparing a contract listener with its connector checkpoint
+type ListenerReconciliationStatus = fftypes.FFEnum
+
+var (
+	// ListenerReconciliationStatusInSync the connector has not passed the block of the latest event recorded locally
+	ListenerReconciliationStatusInSync = fftypes.FFEnumValue("listenerreconciliationstatus", "in_sync")
+	// ListenerReconciliationStatusSkipped no events are recorded locally for the listener, so there is nothing to compare against
+	ListenerReconciliationStatusSkipped = fftypes.FFEnumValue("listenerreconciliationstatus", "skipped")
+	// ListenerReconciliationStatusBackfilling the listener has been rewound, and the connector is re-delivering events
+	ListenerReconciliationStatusBackfilling = fftypes.FFEnumValue("listenerreconciliationstatus", "backfilling")
+	// ListenerReconciliationStatusBackfilled the connector has re-delivered all events up to its original checkpoint
+	ListenerReconciliationStatusBackfilled = fftypes.FFEnumValue("listenerreconciliationstatus", "backfilled")
+	// ListenerReconciliationStatusFailed the listener could not be checked or rewound, or did not catch up in time
+	ListenerReconciliationStatusFailed = fftypes.FFEnumValue("listenerreconciliationstatus", "failed")
+)
+
+// ListenerReconciliation is the result of reconciling a single contract listener
+type ListenerReconciliation struct {
+	Listener       *fftypes.UUID                `ffstruct:"ListenerReconciliation" json:"listener"`
+	Name           string                       `ffstruct:"ListenerReconciliation" json:"name,omitempty"`
+	BackendID      string                       `ffstruct:"ListenerReconciliation" json:"backendId"`
+	LocalBlock     int64                        `ffstruct:"ListenerReconciliation" json:"localBlock"`
+	ConnectorBlock int64                        `ffstruct:"ListenerReconciliation" json:"connectorBlock"`
+	FromBlock      int64                        `ffstruct:"ListenerReconciliation" json:"fromBlock,omitempty"`
+	Truncated      bool                         `ffstruct:"ListenerReconciliation" json:"truncated,omitempty"`
+	Status         ListenerReconciliationStatus `ffstruct:"ListenerReconciliation" json:"status" ffenum:"listenerreconciliationstatus"`
+	Error          string                       `ffstruct:"ListenerReconciliation" json:"error,omitempty"`
+}
+
+// ListenerReconciliationReport is the result of reconciling all the contract listeners of a namespace on startup
+type ListenerReconciliationReport struct {
+	Namespace  string                    `ffstruct:"ListenerReconciliationReport" json:"namespace"`
+	Started    *fftypes.FFTime           `ffstruct:"ListenerReconciliationReport" json:"started,omitempty"`
+	Completed  *fftypes.FFTime           `ffstruct:"ListenerReconciliationReport" json:"completed,omitempty"`
+	InSync     int                       `ffstruct:"ListenerReconciliationReport" json:"inSync"`
+	Skipped    int                       `ffstruct:"ListenerReconciliationReport" json:"skipped"`
+	Backfilled int                       `ffstruct:"ListenerReconciliationReport" json:"backfilled"`
+	Failed     int                       `ffstruct:"ListenerReconciliationReport" json:"failed"`
+	Listeners  []*ListenerReconciliation `ffstruct:"ListenerReconciliationReport" json:"listeners"`
+}
@@ -0,0 +1,71 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// LoadTestType is a type of synthetic request sent by a load test
+type LoadTestType = fftypes.FFEnum
+
+var (
+	// LoadTestTypeBroadcast sends broadcast messages
+	LoadTestTypeBroadcast = fftypes.FFEnumValue("loadtesttype", "broadcast")
+	// LoadTestTypePrivate sends private messages to a group
+	LoadTestTypePrivate = fftypes.FFEnumValue("loadtesttype", "private")
+	// LoadTestTypeTransfer sends token transfers
+	LoadTestTypeTransfer = fftypes.FFEnumValue("loadtesttype", "transfer")
+)
+
+// LoadTestSpec is the rate and shape of the synthetic requests sent by a load test
+type LoadTestSpec struct {
+	Duration       *fftypes.FFDuration `ffstruct:"LoadTestSpec" json:"duration"`
+	Concurrency    int                 `ffstruct:"LoadTestSpec" json:"concurrency,omitempty"`
+	PayloadSize    int                 `ffstruct:"LoadTestSpec" json:"payloadSize,omitempty"`
+	BroadcastRate  float64             `ffstruct:"LoadTestSpec" json:"broadcastRate,omitempty"`
+	PrivateRate    float64             `ffstruct:"LoadTestSpec" json:"privateRate,omitempty"`
+	PrivateMembers []string            `ffstruct:"LoadTestSpec" json:"privateMembers,omitempty"`
+	TransferRate   float64             `ffstruct:"LoadTestSpec" json:"transferRate,omitempty"`
+	TransferPool   string              `ffstruct:"LoadTestSpec" json:"transferPool,omitempty"`
+	TransferTo     string              `ffstruct:"LoadTestSpec" json:"transferTo,omitempty"`
+	TransferAmount *fftypes.FFBigInt   `ffstruct:"LoadTestSpec" json:"transferAmount,omitempty"`
+}
+
+// LoadTestResult is the outcome of the requests of one type sent by a load test
+type LoadTestResult struct {
+	Type       LoadTestType        `ffstruct:"LoadTestResult" json:"type" ffenum:"loadtesttype"`
+	Sent       int64               `ffstruct:"LoadTestResult" json:"sent"`
+	Confirmed  int64               `ffstruct:"LoadTestResult" json:"confirmed"`
+	Failed     int64               `ffstruct:"LoadTestResult" json:"failed"`
+	Skipped    int64               `ffstruct:"LoadTestResult" json:"skipped"`
+	LatencyP50 *fftypes.FFDuration `ffstruct:"LoadTestResult" json:"latencyP50,omitempty"`
+	LatencyP90 *fftypes.FFDuration `ffstruct:"LoadTestResult" json:"latencyP90,omitempty"`
+	LatencyP99 *fftypes.FFDuration `ffstruct:"LoadTestResult" json:"latencyP99,omitempty"`
+	LatencyMax *fftypes.FFDuration `ffstruct:"LoadTestResult" json:"latencyMax,omitempty"`
+	LastError  string              `ffstruct:"LoadTestResult" json:"lastError,omitempty"`
+}
+
+// LoadTestStatus is the progress of the latest load test of a namespace
+type LoadTestStatus struct {
+	ID      *fftypes.UUID     `ffstruct:"LoadTestStatus" json:"id"`
+	Spec    *LoadTestSpec     `ffstruct:"LoadTestStatus" json:"spec"`
+	Running bool              `ffstruct:"LoadTestStatus" json:"running"`
+	Started *fftypes.FFTime   `ffstruct:"LoadTestStatus" json:"started"`
+	Ended   *fftypes.FFTime   `ffstruct:"LoadTestStatus" json:"ended,omitempty"`
+	Results []*LoadTestResult `ffstruct:"LoadTestStatus" json:"results"`
+}
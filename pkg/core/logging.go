@@ -0,0 +1,67 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// LogSubsystem is a group of components whose log level can be changed independently at runtime
+type LogSubsystem = fftypes.FFEnum
+
+var (
+	// LogSubsystemBatch the batch manager and its batch processors
+	LogSubsystemBatch = fftypes.FFEnumValue("logsubsystem", "batch")
+	// LogSubsystemEvents the event manager, event pollers and subscription dispatchers
+	LogSubsystemEvents = fftypes.FFEnumValue("logsubsystem", "events")
+	// LogSubsystemAggregator the aggregator that sequences pins into confirmed messages
+	LogSubsystemAggregator = fftypes.FFEnumValue("logsubsystem", "aggregator")
+	// LogSubsystemPlugins the blockchain, tokens, data exchange, shared storage and auth plugins
+	LogSubsystemPlugins = fftypes.FFEnumValue("logsubsystem", "plugins")
+)
+
+// LogSubsystems is every subsystem that can have its own log level
+var LogSubsystems = []LogSubsystem{
+	LogSubsystemBatch,
+	LogSubsystemEvents,
+	LogSubsystemAggregator,
+	LogSubsystemPlugins,
+}
+
+// LogLevels is the log level of the node, and of each subsystem that has been set to a different level
+type LogLevels struct {
+	Level      string                  `ffstruct:"LogLevels" json:"level,omitempty"`
+	Subsystems map[LogSubsystem]string `ffstruct:"LogLevels" json:"subsystems,omitempty"`
+}
+
+// LogCaptureRequest starts a temporary capture of all log output into an in-memory ring buffer
+type LogCaptureRequest struct {
+	Level    string              `ffstruct:"LogCaptureRequest" json:"level,omitempty"`
+	Duration *fftypes.FFDuration `ffstruct:"LogCaptureRequest" json:"duration,omitempty"`
+	MaxLines int                 `ffstruct:"LogCaptureRequest" json:"maxLines,omitempty"`
+}
+
+// LogCaptureStatus is the state of the latest log capture
+type LogCaptureStatus struct {
+	Active   bool            `ffstruct:"LogCaptureStatus" json:"active"`
+	Level    string          `ffstruct:"LogCaptureStatus" json:"level,omitempty"`
+	Started  *fftypes.FFTime `ffstruct:"LogCaptureStatus" json:"started,omitempty"`
+	Expires  *fftypes.FFTime `ffstruct:"LogCaptureStatus" json:"expires,omitempty"`
+	MaxLines int             `ffstruct:"LogCaptureStatus" json:"maxLines,omitempty"`
+	Lines    int             `ffstruct:"LogCaptureStatus" json:"lines"`
+	Dropped  int64           `ffstruct:"LogCaptureStatus" json:"dropped"`
+}
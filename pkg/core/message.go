@@ -70,6 +70,8 @@ var (
 	MessageStateRejected = fftypes.FFEnumValue("messagestate", "rejected")
 	// MessageStateCancelled is a message that was cancelled without being sent
 	MessageStateCancelled = fftypes.FFEnumValue("messagestate", "cancelled")
+	// MessageStateQuarantined is a message that has completed confirmation, but is held by the quarantine policy until released
+	MessageStateQuarantined = fftypes.FFEnumValue("messagestate", "quarantined")
 )
 
 // MessageHeader contains all fields that contribute to the hash
@@ -87,6 +89,8 @@ type MessageHeader struct {
 	Tag       string                `ffstruct:"MessageHeader" json:"tag,omitempty"`
 	DataHash  *fftypes.Bytes32      `ffstruct:"MessageHeader" json:"datahash,omitempty" ffexcludeinput:"true"`
 	TxParent  *TransactionRef       `ffstruct:"MessageHeader" json:"txparent,omitempty" ffexcludeinput:"true"`
+	// ContextQualifier is mixed into the pin context of each topic, so it is never written to the chain in cleartext
+	ContextQualifier string `ffstruct:"MessageHeader" json:"contextqualifier,omitempty"`
 }
 
 // Message is the envelope by which coordinated data exchange can happen between parties in the network
@@ -104,13 +108,15 @@ type Message struct {
 	Data           DataRefs              `ffstruct:"Message" json:"data" ffexcludeinput:"true"`
 	Pins           fftypes.FFStringArray `ffstruct:"Message" json:"pins,omitempty" ffexcludeinput:"true"`
 	IdempotencyKey IdempotencyKey        `ffstruct:"Message" json:"idempotencyKey,omitempty"`
+	TxLabels       fftypes.FFStringArray `ffstruct:"Message" json:"txlabels,omitempty"`
+	Trace          bool                  `ffstruct:"Message" json:"trace,omitempty"`
 	Sequence       int64                 `ffstruct:"Message" json:"-"` // Local database sequence used internally for batch assembly
 }
 
 // BatchMessage is the fields in a message record that are assured to be consistent on all parties.
 // This is what is transferred and hashed in a batch payload between nodes.
 //
-// Fields such as the idempotencyKey do NOT transfer, as these are meant for local processing of messages before being sent.
+// Fields such as the idempotencyKey and txlabels do NOT transfer, as these are meant for local processing of messages before being sent.
 //
 // Fields such as the state/confirmed do NOT transfer, as these are calculated individually by each member.
 func (m *Message) BatchMessage() *Message {
@@ -121,6 +127,8 @@ func (m *Message) BatchMessage() *Message {
 		TransactionID: m.TransactionID,
 		// The pins are immutable once assigned by the sender, which happens before the batch is sealed
 		Pins: m.Pins,
+		// The trace flag is not in the header, so a traced message has the same hash as an untraced one
+		Trace: m.Trace,
 	}
 }
 
@@ -134,8 +142,10 @@ type MessageInOut struct {
 
 // InputGroup declares a group in-line for automatic resolution, without having to define a group up-front
 type InputGroup struct {
-	Name    string        `ffstruct:"InputGroup" json:"name,omitempty"`
-	Members []MemberInput `ffstruct:"InputGroup" json:"members"`
+	Name        string        `ffstruct:"InputGroup" json:"name,omitempty"`
+	Members     []MemberInput `ffstruct:"InputGroup" json:"members"`
+	DisplayName string        `ffstruct:"InputGroup" json:"displayName,omitempty"`
+	Description string        `ffstruct:"InputGroup" json:"description,omitempty"`
 }
 
 // InlineData is an array of data references or values
@@ -164,6 +174,16 @@ func (h *MessageHeader) Hash() *fftypes.Bytes32 {
 	return &b32
 }
 
+// ContextTopic returns the string hashed in place of the topic, when calculating the pin context for one of
+// the topics of the message. Messages with a context qualifier are ordered independently of messages on the
+// same topic with a different qualifier, or without one. Names cannot contain a '/', so the result is unique.
+func (h *MessageHeader) ContextTopic(topic string) string {
+	if h.ContextQualifier == "" {
+		return topic
+	}
+	return topic + "/" + h.ContextQualifier
+}
+
 func (m *MessageInOut) SetInlineData(data []*Data) {
 	m.InlineData = make(InlineData, len(data))
 	for i, d := range data {
@@ -250,6 +270,14 @@ func (m *Message) VerifyFields(ctx context.Context) error {
 			return err
 		}
 	}
+	if m.Header.ContextQualifier != "" {
+		if err := fftypes.ValidateFFNameField(ctx, m.Header.ContextQualifier, "header.contextqualifier"); err != nil {
+			return err
+		}
+	}
+	if err := ValidateTransactionLabels(ctx, "txlabels", m.TxLabels); err != nil {
+		return err
+	}
 	return m.DupDataCheck(ctx)
 }
 
@@ -0,0 +1,79 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// MessageRetentionPolicy deletes the data of confirmed messages on a topic, or with a tag, once the messages
+// are older than the max age. The messages themselves are kept, so the hashes needed to verify the batches
+// they were sent in remain available. Messages that do not match any policy keep their data indefinitely.
+type MessageRetentionPolicy struct {
+	ID         *fftypes.UUID       `ffstruct:"MessageRetentionPolicy" json:"id" ffexcludeinput:"true"`
+	Namespace  string              `ffstruct:"MessageRetentionPolicy" json:"namespace" ffexcludeinput:"true"`
+	Name       string              `ffstruct:"MessageRetentionPolicy" json:"name"`
+	Topic      string              `ffstruct:"MessageRetentionPolicy" json:"topic,omitempty"`
+	Tag        string              `ffstruct:"MessageRetentionPolicy" json:"tag,omitempty"`
+	MaxAge     *fftypes.FFDuration `ffstruct:"MessageRetentionPolicy" json:"maxAge"`
+	Checkpoint int64               `ffstruct:"MessageRetentionPolicy" json:"checkpoint" ffexcludeinput:"true"`
+	Created    *fftypes.FFTime     `ffstruct:"MessageRetentionPolicy" json:"created" ffexcludeinput:"true"`
+	Updated    *fftypes.FFTime     `ffstruct:"MessageRetentionPolicy" json:"updated" ffexcludeinput:"true"`
+}
+
+func (mrp *MessageRetentionPolicy) Validate(ctx context.Context) error {
+	if err := fftypes.ValidateFFNameFieldNoUUID(ctx, mrp.Name, "name"); err != nil {
+		return err
+	}
+	if mrp.Topic == "" && mrp.Tag == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMessageRetentionPolicyNoScope)
+	}
+	if mrp.Topic != "" {
+		if err := fftypes.ValidateFFNameField(ctx, mrp.Topic, "topic"); err != nil {
+			return err
+		}
+	}
+	if mrp.Tag != "" {
+		if err := fftypes.ValidateFFNameField(ctx, mrp.Tag, "tag"); err != nil {
+			return err
+		}
+	}
+	if mrp.MaxAge == nil || *mrp.MaxAge <= 0 {
+		return i18n.NewError(ctx, coremsgs.MsgMessageRetentionPolicyMaxAge)
+	}
+	return nil
+}
+
+// Matches is true if the message is on the topic, and has the tag, of the policy
+func (mrp *MessageRetentionPolicy) Matches(msg *Message) bool {
+	if mrp.Tag != "" && msg.Header.Tag != mrp.Tag {
+		return false
+	}
+	if mrp.Topic == "" {
+		return true
+	}
+	for _, topic := range msg.Header.Topics {
+		if topic == mrp.Topic {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageRetentionPolicyValidate(t *testing.T) {
+	ctx := context.Background()
+	maxAge := fftypes.FFDuration(24 * time.Hour)
+	zero := fftypes.FFDuration(0)
+
+	mrp := &MessageRetentionPolicy{Name: "heartbeats", Topic: "heartbeat", MaxAge: &maxAge}
+	assert.NoError(t, mrp.Validate(ctx))
+
+	mrp = &MessageRetentionPolicy{Name: "pings", Tag: "ping", MaxAge: &maxAge}
+	assert.NoError(t, mrp.Validate(ctx))
+
+	mrp = &MessageRetentionPolicy{Name: "!bad", Topic: "heartbeat", MaxAge: &maxAge}
+	assert.Regexp(t, "FF00140.*name", mrp.Validate(ctx))
+
+	mrp = &MessageRetentionPolicy{Name: "heartbeats", MaxAge: &maxAge}
+	assert.Regexp(t, "FF10641", mrp.Validate(ctx))
+
+	mrp = &MessageRetentionPolicy{Name: "heartbeats", Topic: "!bad", MaxAge: &maxAge}
+	assert.Regexp(t, "FF00140.*topic", mrp.Validate(ctx))
+
+	mrp = &MessageRetentionPolicy{Name: "heartbeats", Tag: "!bad", MaxAge: &maxAge}
+	assert.Regexp(t, "FF00140.*tag", mrp.Validate(ctx))
+
+	mrp = &MessageRetentionPolicy{Name: "heartbeats", Topic: "heartbeat"}
+	assert.Regexp(t, "FF10642", mrp.Validate(ctx))
+
+	mrp = &MessageRetentionPolicy{Name: "heartbeats", Topic: "heartbeat", MaxAge: &zero}
+	assert.Regexp(t, "FF10642", mrp.Validate(ctx))
+}
+
+func TestMessageRetentionPolicyMatches(t *testing.T) {
+	msg := &Message{
+		Header: MessageHeader{
+			Topics: fftypes.FFStringArray{"topic1", "heartbeat"},
+			Tag:    "ping",
+		},
+	}
+	assert.True(t, (&MessageRetentionPolicy{Topic: "heartbeat"}).Matches(msg))
+	assert.True(t, (&MessageRetentionPolicy{Tag: "ping"}).Matches(msg))
+	assert.True(t, (&MessageRetentionPolicy{Topic: "topic1", Tag: "ping"}).Matches(msg))
+	assert.False(t, (&MessageRetentionPolicy{Topic: "heart"}).Matches(msg))
+	assert.False(t, (&MessageRetentionPolicy{Topic: "heartbeat", Tag: "pong"}).Matches(msg))
+}
@@ -111,6 +111,38 @@ func TestVerifyBadTagString(t *testing.T) {
 	assert.Regexp(t, `FF00140.*header.tag`, err)
 }
 
+func TestVerifyBadContextQualifier(t *testing.T) {
+	msg := Message{
+		Header: MessageHeader{
+			TxType:           TransactionTypeBatchPin,
+			Topics:           fftypes.FFStringArray{"topic1"},
+			ContextQualifier: "app/1",
+		},
+	}
+	err := msg.Verify(context.Background())
+	assert.Regexp(t, `FF00140.*header.contextqualifier`, err)
+}
+
+func TestVerifyBadTxLabels(t *testing.T) {
+	msg := Message{
+		Header: MessageHeader{
+			TxType: TransactionTypeBatchPin,
+			Topics: fftypes.FFStringArray{"topic1"},
+		},
+		TxLabels: fftypes.FFStringArray{"payroll", "payroll"},
+	}
+	err := msg.Verify(context.Background())
+	assert.Regexp(t, `FF00133.*txlabels`, err)
+}
+
+func TestContextTopic(t *testing.T) {
+	header := &MessageHeader{}
+	assert.Equal(t, "topic1", header.ContextTopic("topic1"))
+
+	header.ContextQualifier = "app1"
+	assert.Equal(t, "topic1/app1", header.ContextTopic("topic1"))
+}
+
 func TestSealNilDataID(t *testing.T) {
 	msg := Message{
 		Header: MessageHeader{
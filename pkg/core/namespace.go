@@ -43,14 +43,84 @@ type NamespaceWithInitStatus struct {
 	InitializationError string `ffstruct:"NamespaceWithInitStatus" json:"initializationError,omitempty"`
 }
 
+// NamespaceConfigUpdate is a runtime update to the configuration of a single namespace, and the plugins it uses
+type NamespaceConfigUpdate struct {
+	Namespace fftypes.JSONObject `ffstruct:"NamespaceConfigUpdate" json:"namespace,omitempty"`
+	Plugins   fftypes.JSONObject `ffstruct:"NamespaceConfigUpdate" json:"plugins,omitempty"`
+}
+
+// NamespaceConfigUpdateResult describes the outcome of applying a NamespaceConfigUpdate
+type NamespaceConfigUpdateResult struct {
+	Namespace string   `ffstruct:"NamespaceConfigUpdateResult" json:"namespace"`
+	Restarted bool     `ffstruct:"NamespaceConfigUpdateResult" json:"restarted"`
+	Changes   []string `ffstruct:"NamespaceConfigUpdateResult" json:"changes,omitempty"`
+}
+
+// ConfigReloadPreview describes what reloading the configuration file would change, without applying it
+type ConfigReloadPreview struct {
+	File       string                 `ffstruct:"ConfigReloadPreview" json:"file"`
+	Namespaces []*ConfigReloadChange  `ffstruct:"ConfigReloadPreview" json:"namespaces"`
+	Plugins    []*ConfigReloadChange  `ffstruct:"ConfigReloadPreview" json:"plugins"`
+	Settings   []*ConfigSettingChange `ffstruct:"ConfigReloadPreview" json:"settings"`
+}
+
+type ConfigReloadAction = fftypes.FFEnum
+
+var (
+	// ConfigReloadActionAdded is a namespace or plugin that would be started for the first time
+	ConfigReloadActionAdded = fftypes.FFEnumValue("configreloadaction", "added")
+	// ConfigReloadActionRemoved is a namespace or plugin that would be stopped
+	ConfigReloadActionRemoved = fftypes.FFEnumValue("configreloadaction", "removed")
+	// ConfigReloadActionRestarted is a namespace or plugin that would be stopped, and started with the new configuration
+	ConfigReloadActionRestarted = fftypes.FFEnumValue("configreloadaction", "restarted")
+	// ConfigReloadActionRotated is a plugin that would have its credentials rotated, without a restart
+	ConfigReloadActionRotated = fftypes.FFEnumValue("configreloadaction", "rotated")
+)
+
+// ConfigReloadChange is a namespace or plugin that would be affected by a configuration reload
+type ConfigReloadChange struct {
+	Name    string             `ffstruct:"ConfigReloadChange" json:"name"`
+	Type    string             `ffstruct:"ConfigReloadChange" json:"type,omitempty"`
+	Action  ConfigReloadAction `ffstruct:"ConfigReloadChange" json:"action" ffenum:"configreloadaction"`
+	Changes []string           `ffstruct:"ConfigReloadChange" json:"changes,omitempty"`
+}
+
+// ConfigSettingChange is a configuration setting that differs in the configuration file, with secret values redacted
+type ConfigSettingChange struct {
+	Key string      `ffstruct:"ConfigSettingChange" json:"key"`
+	Old interface{} `ffstruct:"ConfigSettingChange" json:"old,omitempty"`
+	New interface{} `ffstruct:"ConfigSettingChange" json:"new,omitempty"`
+}
+
+// NamespaceClone is a request to provision a new namespace with the same plugin wiring as an existing
+// template namespace, optionally copying selected local definitions from the template
+type NamespaceClone struct {
+	Name          string `ffstruct:"NamespaceClone" json:"name"`
+	Description   string `ffstruct:"NamespaceClone" json:"description,omitempty"`
+	Datatypes     bool   `ffstruct:"NamespaceClone" json:"datatypes,omitempty"`
+	ContractAPIs  bool   `ffstruct:"NamespaceClone" json:"contractAPIs,omitempty"`
+	Subscriptions bool   `ffstruct:"NamespaceClone" json:"subscriptions,omitempty"`
+}
+
+// NamespaceCloneResult describes the namespace created by a NamespaceClone
+type NamespaceCloneResult struct {
+	Namespace string         `ffstruct:"NamespaceCloneResult" json:"namespace"`
+	Source    string         `ffstruct:"NamespaceCloneResult" json:"source"`
+	Copied    map[string]int `ffstruct:"NamespaceCloneResult" json:"copied"`
+}
+
 // MultipartyContracts represent the currently active and any terminated FireFly multiparty contract(s)
 type MultipartyContracts struct {
-	Active     *MultipartyContract   `ffstruct:"MultipartyContracts" json:"active"`
-	Terminated []*MultipartyContract `ffstruct:"MultipartyContracts" json:"terminated,omitempty"`
+	Active     *MultipartyContract       `ffstruct:"MultipartyContracts" json:"active"`
+	Terminated []*MultipartyContract     `ffstruct:"MultipartyContracts" json:"terminated,omitempty"`
+	Migration  *ContractMigration        `ffstruct:"MultipartyContracts" json:"migration,omitempty"`
+	Scheduled  []*ScheduledNetworkAction `ffstruct:"MultipartyContracts" json:"scheduled,omitempty"`
 }
 type MultipartyContractsWithActiveStatus struct {
 	Active     *MultipartyContractWithStatus `ffstruct:"MultipartyContracts" json:"active"`
 	Terminated []*MultipartyContract         `ffstruct:"MultipartyContracts" json:"terminated,omitempty"`
+	Migration  *ContractMigration            `ffstruct:"MultipartyContracts" json:"migration,omitempty"`
+	Scheduled  []*ScheduledNetworkAction     `ffstruct:"MultipartyContracts" json:"scheduled,omitempty"`
 }
 
 // MultipartyContract represents identifying details about a FireFly multiparty contract, as read from the config file
@@ -92,8 +162,35 @@ var (
 	NetworkActionTerminate = fftypes.FFEnumValue("networkactiontype", "terminate")
 )
 
+// NetworkActionScheduledTerminate is the action written to the blockchain for a terminate with an effective block or time.
+// It is a distinct action, rather than a terminate with a schedule in the payload, so that members on versions that do not
+// support scheduling ignore it as unrecognized, instead of terminating immediately. It cannot be submitted directly.
+const NetworkActionScheduledTerminate NetworkActionType = "scheduled_terminate"
+
 type NetworkAction struct {
 	Type NetworkActionType `ffstruct:"NetworkAction" json:"type" ffenum:"networkactiontype"`
+	NetworkActionSchedule
+}
+
+// NetworkActionSchedule is the effective point of a network action that is broadcast in advance of being applied.
+// It is carried on the blockchain with the action, so that every member applies the action at the same point.
+type NetworkActionSchedule struct {
+	EffectiveBlock uint64          `ffstruct:"NetworkAction" json:"effectiveBlock,omitempty"`
+	EffectiveTime  *fftypes.FFTime `ffstruct:"NetworkAction" json:"effectiveTime,omitempty"`
+}
+
+// IsScheduled is true if the action has an effective block or time, rather than being applied as soon as it is received
+func (nas *NetworkActionSchedule) IsScheduled() bool {
+	return nas.EffectiveBlock > 0 || nas.EffectiveTime != nil
+}
+
+// ScheduledNetworkAction is a network action that has been received from the blockchain, and is waiting for its effective point
+type ScheduledNetworkAction struct {
+	NetworkAction
+	Author   string           `ffstruct:"ScheduledNetworkAction" json:"author"`
+	Location *fftypes.JSONAny `ffstruct:"ScheduledNetworkAction" json:"location"`
+	Event    string           `ffstruct:"ScheduledNetworkAction" json:"event"`
+	Created  *fftypes.FFTime  `ffstruct:"ScheduledNetworkAction" json:"created"`
 }
 
 // Scan implements sql.Scanner
@@ -0,0 +1,113 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+)
+
+// NamespaceArchiveVersion is the version of the archive format written by this node
+const NamespaceArchiveVersion = 1
+
+// NamespaceExportOptions control what is included in a namespace archive
+type NamespaceExportOptions struct {
+	IncludeMessages bool
+	IncludeBatches  bool
+}
+
+// NamespaceArchive is a portable export of the definitions, identities and (optionally) messages of a namespace,
+// which can be imported into a namespace on another node
+type NamespaceArchive struct {
+	Version      int               `ffstruct:"NamespaceArchive" json:"version"`
+	Namespace    string            `ffstruct:"NamespaceArchive" json:"namespace"`
+	Created      *fftypes.FFTime   `ffstruct:"NamespaceArchive" json:"created"`
+	Identities   []*Identity       `ffstruct:"NamespaceArchive" json:"identities"`
+	Verifiers    []*Verifier       `ffstruct:"NamespaceArchive" json:"verifiers"`
+	Groups       []*Group          `ffstruct:"NamespaceArchive" json:"groups"`
+	Datatypes    []*Datatype       `ffstruct:"NamespaceArchive" json:"datatypes"`
+	FFIs         []*fftypes.FFI    `ffstruct:"NamespaceArchive" json:"interfaces"`
+	ContractAPIs []*ContractAPI    `ffstruct:"NamespaceArchive" json:"apis"`
+	TokenPools   []*TokenPool      `ffstruct:"NamespaceArchive" json:"tokenPools"`
+	Messages     []*Message        `ffstruct:"NamespaceArchive" json:"messages,omitempty"`
+	Data         DataArray         `ffstruct:"NamespaceArchive" json:"data,omitempty"`
+	Batches      []*BatchPersisted `ffstruct:"NamespaceArchive" json:"batches,omitempty"`
+}
+
+// Hash is the hash of the JSON serialization of the archive
+func (na *NamespaceArchive) Hash() *fftypes.Bytes32 {
+	b, _ := json.Marshal(na)
+	return fftypes.HashString(string(b))
+}
+
+// NamespaceImportResult is the number of records imported from an archive, for each type
+type NamespaceImportResult struct {
+	Namespace string         `ffstruct:"NamespaceImportResult" json:"namespace"`
+	Imported  map[string]int `ffstruct:"NamespaceImportResult" json:"imported"`
+}
+
+// NamespaceSnapshot is an archive of the confirmed state of a namespace, signed with the payload signing key of the
+// org. A new node of the same org can import it to bootstrap, rather than processing the history of the network.
+type NamespaceSnapshot struct {
+	Hash      *fftypes.Bytes32  `ffstruct:"NamespaceSnapshot" json:"hash"`
+	Signature *PayloadSignature `ffstruct:"NamespaceSnapshot" json:"signature"`
+	Archive   *NamespaceArchive `ffstruct:"NamespaceSnapshot" json:"archive"`
+}
+
+// SnapshotVerification is the progress of checking the batches of an imported snapshot against the pins
+// received from the blockchain
+type SnapshotVerification struct {
+	Hash       *fftypes.Bytes32 `ffstruct:"SnapshotVerification" json:"hash"`
+	Imported   *fftypes.FFTime  `ffstruct:"SnapshotVerification" json:"imported"`
+	Completed  *fftypes.FFTime  `ffstruct:"SnapshotVerification" json:"completed,omitempty"`
+	Batches    int              `ffstruct:"SnapshotVerification" json:"batches"`
+	Verified   int              `ffstruct:"SnapshotVerification" json:"verified"`
+	Pending    int              `ffstruct:"SnapshotVerification" json:"pending"`
+	Mismatched []*fftypes.UUID  `ffstruct:"SnapshotVerification" json:"mismatched,omitempty"`
+}
+
+// SnapshotVerificationState is the persisted state of verifying the snapshot most recently imported into a
+// namespace, so verification resumes after a restart. Expected holds the batch hash recomputed from the
+// imported content, for each batch still waiting for its pin.
+type SnapshotVerificationState struct {
+	SnapshotVerification
+	Expected map[string]*fftypes.Bytes32 `json:"expected,omitempty"`
+}
+
+// Scan implements sql.Scanner
+func (s *SnapshotVerificationState) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), &s)
+	case []byte:
+		return json.Unmarshal(src, &s)
+	default:
+		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, s)
+	}
+}
+
+// Value implements sql.Valuer
+func (s SnapshotVerificationState) Value() (driver.Value, error) {
+	bytes, _ := json.Marshal(s)
+	return bytes, nil
+}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotVerificationStateDatabaseSerialization(t *testing.T) {
+	state1 := &SnapshotVerificationState{
+		SnapshotVerification: SnapshotVerification{
+			Hash:       fftypes.NewRandB32(),
+			Imported:   fftypes.Now(),
+			Batches:    2,
+			Pending:    1,
+			Mismatched: []*fftypes.UUID{fftypes.NewUUID()},
+		},
+		Expected: map[string]*fftypes.Bytes32{
+			fftypes.NewUUID().String(): fftypes.NewRandB32(),
+		},
+	}
+
+	// Verify it serializes as bytes to the database
+	b1, err := state1.Value()
+	assert.NoError(t, err)
+
+	// Verify it restores ok
+	state2 := &SnapshotVerificationState{}
+	err = state2.Scan(b1)
+	assert.NoError(t, err)
+	assert.Equal(t, state1.Hash, state2.Hash)
+	assert.Equal(t, state1.Mismatched, state2.Mismatched)
+	assert.Equal(t, state1.Expected, state2.Expected)
+
+	// Verify it restores from a string
+	state3 := &SnapshotVerificationState{}
+	err = state3.Scan(string(b1.([]byte)))
+	assert.NoError(t, err)
+	assert.Equal(t, state1.Expected, state3.Expected)
+
+	// Verify nil is left empty
+	state4 := &SnapshotVerificationState{}
+	err = state4.Scan(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, state4.Expected)
+
+	// Verify a bad type is rejected
+	err = state4.Scan(12345)
+	assert.Regexp(t, "FF00105", err)
+}
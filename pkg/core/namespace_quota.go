@@ -0,0 +1,90 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+)
+
+// QuotaType is a resource in a namespace that can be limited
+type QuotaType = fftypes.FFEnum
+
+var (
+	// QuotaTypeMessagesPerDay is the number of messages sent by local identities in the namespace in the current day (UTC)
+	QuotaTypeMessagesPerDay = fftypes.FFEnumValue("quotatype", "messages_per_day")
+	// QuotaTypeBlobBytes is the total size of blobs stored in the namespace
+	QuotaTypeBlobBytes = fftypes.FFEnumValue("quotatype", "blob_bytes")
+	// QuotaTypeContractListeners is the number of contract listeners in the namespace
+	QuotaTypeContractListeners = fftypes.FFEnumValue("quotatype", "contract_listeners")
+	// QuotaTypeSubscriptions is the number of subscriptions in the namespace
+	QuotaTypeSubscriptions = fftypes.FFEnumValue("quotatype", "subscriptions")
+)
+
+// NamespaceQuotas is a value for each quota type of a namespace - used both for limits (where zero means no limit) and usage
+type NamespaceQuotas struct {
+	MessagesPerDay    int64 `ffstruct:"NamespaceQuotas" json:"messagesPerDay"`
+	BlobBytes         int64 `ffstruct:"NamespaceQuotas" json:"blobBytes"`
+	ContractListeners int64 `ffstruct:"NamespaceQuotas" json:"contractListeners"`
+	Subscriptions     int64 `ffstruct:"NamespaceQuotas" json:"subscriptions"`
+}
+
+// Get returns the value for a quota type
+func (q *NamespaceQuotas) Get(quotaType QuotaType) int64 {
+	switch quotaType {
+	case QuotaTypeMessagesPerDay:
+		return q.MessagesPerDay
+	case QuotaTypeBlobBytes:
+		return q.BlobBytes
+	case QuotaTypeContractListeners:
+		return q.ContractListeners
+	case QuotaTypeSubscriptions:
+		return q.Subscriptions
+	default:
+		return 0
+	}
+}
+
+// Scan implements sql.Scanner
+func (q *NamespaceQuotas) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), &q)
+	case []byte:
+		return json.Unmarshal(src, &q)
+	default:
+		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, q)
+	}
+}
+
+// Value implements sql.Valuer
+func (q NamespaceQuotas) Value() (driver.Value, error) {
+	bytes, _ := json.Marshal(q)
+	return bytes, nil
+}
+
+// NamespaceQuotaStatus is the current limits and usage of the quotas of a namespace
+type NamespaceQuotaStatus struct {
+	Limits *NamespaceQuotas `ffstruct:"NamespaceQuotaStatus" json:"limits"`
+	Usage  *NamespaceQuotas `ffstruct:"NamespaceQuotaStatus" json:"usage"`
+}
@@ -0,0 +1,70 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceQuotasGet(t *testing.T) {
+	q := &NamespaceQuotas{
+		MessagesPerDay:    1,
+		BlobBytes:         2,
+		ContractListeners: 3,
+		Subscriptions:     4,
+	}
+	assert.Equal(t, int64(1), q.Get(QuotaTypeMessagesPerDay))
+	assert.Equal(t, int64(2), q.Get(QuotaTypeBlobBytes))
+	assert.Equal(t, int64(3), q.Get(QuotaTypeContractListeners))
+	assert.Equal(t, int64(4), q.Get(QuotaTypeSubscriptions))
+	assert.Equal(t, int64(0), q.Get("unknown"))
+}
+
+func TestNamespaceQuotasDatabaseSerialization(t *testing.T) {
+	q1 := &NamespaceQuotas{
+		MessagesPerDay: 10,
+		BlobBytes:      1024,
+	}
+
+	// Verify it serializes as bytes to the database
+	b1, err := q1.Value()
+	assert.NoError(t, err)
+
+	// Verify it restores ok
+	q2 := &NamespaceQuotas{}
+	err = q2.Scan(b1)
+	assert.NoError(t, err)
+	assert.Equal(t, q1, q2)
+
+	// Verify it restores from a string
+	q3 := &NamespaceQuotas{}
+	err = q3.Scan(string(b1.([]byte)))
+	assert.NoError(t, err)
+	assert.Equal(t, q1, q3)
+
+	// Verify nil is left empty
+	q4 := &NamespaceQuotas{}
+	err = q4.Scan(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &NamespaceQuotas{}, q4)
+
+	// Verify a bad type is rejected
+	err = q4.Scan(12345)
+	assert.Regexp(t, "FF00105", err)
+}
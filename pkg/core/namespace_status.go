@@ -20,11 +20,16 @@ import "github.com/hyperledger/firefly-common/pkg/fftypes"
 
 // NamespaceStatus is a set of information that represents the configuration and status of a given namespace
 type NamespaceStatus struct {
-	Namespace  *Namespace                `ffstruct:"NamespaceStatus" json:"namespace"`
-	Node       *NamespaceStatusNode      `ffstruct:"NamespaceStatus" json:"node,omitempty"`
-	Org        *NamespaceStatusOrg       `ffstruct:"NamespaceStatus" json:"org,omitempty"`
-	Plugins    NamespaceStatusPlugins    `ffstruct:"NamespaceStatus" json:"plugins"`
-	Multiparty NamespaceStatusMultiparty `ffstruct:"NamespaceStatus" json:"multiparty"`
+	Namespace       *Namespace                   `ffstruct:"NamespaceStatus" json:"namespace"`
+	Node            *NamespaceStatusNode         `ffstruct:"NamespaceStatus" json:"node,omitempty"`
+	Org             *NamespaceStatusOrg          `ffstruct:"NamespaceStatus" json:"org,omitempty"`
+	Plugins         NamespaceStatusPlugins       `ffstruct:"NamespaceStatus" json:"plugins"`
+	Multiparty      NamespaceStatusMultiparty    `ffstruct:"NamespaceStatus" json:"multiparty"`
+	ReadOnly        bool                         `ffstruct:"NamespaceStatus" json:"readOnly,omitempty"`
+	CircuitBreakers []*CircuitBreakerStatus      `ffstruct:"NamespaceStatus" json:"circuitBreakers,omitempty"`
+	Features        NamespaceStatusFeatures      `ffstruct:"NamespaceStatus" json:"features"`
+	Degraded        bool                         `ffstruct:"NamespaceStatus" json:"degraded,omitempty"`
+	Dependencies    []*NamespaceStatusDependency `ffstruct:"NamespaceStatus" json:"dependencies,omitempty"`
 }
 
 type NamespaceRegistrationStatus = fftypes.FFEnum
@@ -40,6 +45,28 @@ var (
 	NamespaceRegistrationStatusUnknown = fftypes.FFEnumValue("namespaceregistrationstatus", "unknown")
 )
 
+type DependencyStartStatus = fftypes.FFEnum
+
+var (
+	// DependencyStartStatusStarting is a dependency the namespace is waiting to start
+	DependencyStartStatusStarting = fftypes.FFEnumValue("dependencystartstatus", "starting")
+	// DependencyStartStatusPending is an optional dependency that did not start in time, and is still being retried in the background
+	DependencyStartStatusPending = fftypes.FFEnumValue("dependencystartstatus", "pending")
+	// DependencyStartStatusStarted is a dependency that has started
+	DependencyStartStatusStarted = fftypes.FFEnumValue("dependencystartstatus", "started")
+)
+
+// NamespaceStatusDependency is the startup progress of a plugin connector the namespace depends on
+type NamespaceStatusDependency struct {
+	Type      string                `ffstruct:"NamespaceStatusDependency" json:"type"`
+	Name      string                `ffstruct:"NamespaceStatusDependency" json:"name"`
+	Status    DependencyStartStatus `ffstruct:"NamespaceStatusDependency" json:"status" ffenum:"dependencystartstatus"`
+	Optional  bool                  `ffstruct:"NamespaceStatusDependency" json:"optional"`
+	Attempts  int                   `ffstruct:"NamespaceStatusDependency" json:"attempts"`
+	LastError string                `ffstruct:"NamespaceStatusDependency" json:"lastError,omitempty"`
+	Started   *fftypes.FFTime       `ffstruct:"NamespaceStatusDependency" json:"started,omitempty"`
+}
+
 // NamespaceStatusNode is the information about the local node, returned in the namespace status
 type NamespaceStatusNode struct {
 	Name       string        `ffstruct:"NamespaceStatusNode" json:"name"`
@@ -73,6 +100,24 @@ type NamespaceStatusPlugin struct {
 	PluginType string `ffstruct:"NamespaceStatusPlugin" json:"pluginType"`
 }
 
+// NamespaceStatusFeatures reports which optional features are available in the namespace, so that
+// applications can adapt to the configuration rather than probing endpoints
+type NamespaceStatusFeatures struct {
+	Multiparty    NamespaceStatusFeature `ffstruct:"NamespaceStatusFeatures" json:"multiparty"`
+	Tokens        NamespaceStatusFeature `ffstruct:"NamespaceStatusFeatures" json:"tokens"`
+	Contracts     NamespaceStatusFeature `ffstruct:"NamespaceStatusFeatures" json:"contracts"`
+	DataExchange  NamespaceStatusFeature `ffstruct:"NamespaceStatusFeatures" json:"dataExchange"`
+	SharedStorage NamespaceStatusFeature `ffstruct:"NamespaceStatusFeatures" json:"sharedStorage"`
+	Transports    NamespaceStatusFeature `ffstruct:"NamespaceStatusFeatures" json:"transports"`
+}
+
+// NamespaceStatusFeature is whether an optional feature is enabled, and what provides it
+type NamespaceStatusFeature struct {
+	Enabled bool     `ffstruct:"NamespaceStatusFeature" json:"enabled"`
+	Plugins []string `ffstruct:"NamespaceStatusFeature" json:"plugins,omitempty"`
+	Version string   `ffstruct:"NamespaceStatusFeature" json:"version,omitempty"`
+}
+
 // NamespaceStatusMultiparty is information about multiparty mode and any associated multiparty contracts
 type NamespaceStatusMultiparty struct {
 	Enabled   bool                 `ffstruct:"NamespaceStatusMultiparty" json:"enabled"`
@@ -95,3 +140,40 @@ type NamespaceMultipartyStatus struct {
 	Node      NamespaceMultipartyStatusNode        `ffstruct:"NamespaceMultipartyStatus" json:"node"`
 	Contracts *MultipartyContractsWithActiveStatus `ffstruct:"NamespaceMultipartyStatus" json:"contracts,omitempty"`
 }
+
+type BootstrapStepStatus = fftypes.FFEnum
+
+var (
+	// step has not yet been attempted
+	BootstrapStepStatusPending = fftypes.FFEnumValue("bootstrapstepstatus", "pending")
+	// step is currently being attempted
+	BootstrapStepStatusRunning = fftypes.FFEnumValue("bootstrapstepstatus", "running")
+	// step failed, and will be retried after a backoff delay
+	BootstrapStepStatusRetrying = fftypes.FFEnumValue("bootstrapstepstatus", "retrying")
+	// step completed successfully
+	BootstrapStepStatusComplete = fftypes.FFEnumValue("bootstrapstepstatus", "complete")
+)
+
+const (
+	// BootstrapStepOrg registers the root org identity for the namespace
+	BootstrapStepOrg = "org"
+	// BootstrapStepNode registers the local node identity, as a child of the root org
+	BootstrapStepNode = "node"
+)
+
+// NamespaceBootstrapStatus is the progress of automatic org and node registration for a namespace
+type NamespaceBootstrapStatus struct {
+	Enabled   bool                      `ffstruct:"NamespaceBootstrapStatus" json:"enabled"`
+	Started   *fftypes.FFTime           `ffstruct:"NamespaceBootstrapStatus" json:"started,omitempty"`
+	Completed *fftypes.FFTime           `ffstruct:"NamespaceBootstrapStatus" json:"completed,omitempty"`
+	Steps     []*NamespaceBootstrapStep `ffstruct:"NamespaceBootstrapStatus" json:"steps,omitempty"`
+}
+
+// NamespaceBootstrapStep is the progress of an individual step in the bootstrap sequence
+type NamespaceBootstrapStep struct {
+	Name      string              `ffstruct:"NamespaceBootstrapStep" json:"name"`
+	Status    BootstrapStepStatus `ffstruct:"NamespaceBootstrapStep" json:"status"`
+	Attempts  int                 `ffstruct:"NamespaceBootstrapStep" json:"attempts"`
+	LastError string              `ffstruct:"NamespaceBootstrapStep" json:"lastError,omitempty"`
+	Updated   *fftypes.FFTime     `ffstruct:"NamespaceBootstrapStep" json:"updated,omitempty"`
+}
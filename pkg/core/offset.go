@@ -25,8 +25,22 @@ var (
 	OffsetTypeBatch = fftypes.FFEnumValue("offsettype", "batch")
 	// OffsetTypeAggregator is an offset stored by the aggregator on the events table
 	OffsetTypeAggregator = fftypes.FFEnumValue("offsettype", "aggregator")
+	// OffsetTypeAggregatorCheckpoint is the sequence on the pins table below which the aggregator has dispatched every pin
+	OffsetTypeAggregatorCheckpoint = fftypes.FFEnumValue("offsettype", "aggregatorcheckpoint")
 	// OffsetTypeSubscription is an offeset stored by a dispatcher on the events table
 	OffsetTypeSubscription = fftypes.FFEnumValue("offsettype", "subscription")
+	// OffsetTypeArchive is an offset stored by the retention archiver on the archived collection
+	OffsetTypeArchive = fftypes.FFEnumValue("offsettype", "archive")
+	// OffsetTypeSearch is an offset stored by the search indexer on the events table
+	OffsetTypeSearch = fftypes.FFEnumValue("offsettype", "search")
+	// OffsetTypeCatchUp is an offset stored by the historical catch-up on the events table
+	OffsetTypeCatchUp = fftypes.FFEnumValue("offsettype", "catchup")
+	// OffsetTypeTraffic is an offset stored by the traffic aggregator on the events table
+	OffsetTypeTraffic = fftypes.FFEnumValue("offsettype", "traffic")
+	// OffsetTypeEventBridge is an offset stored by an event bridge on the events table
+	OffsetTypeEventBridge = fftypes.FFEnumValue("offsettype", "eventbridge")
+	// OffsetTypeMessageTrigger is an offset stored by a message trigger on the events table
+	OffsetTypeMessageTrigger = fftypes.FFEnumValue("offsettype", "messagetrigger")
 )
 
 // Offset is a simple stored data structure that records a sequence position within another collection
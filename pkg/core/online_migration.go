@@ -0,0 +1,47 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+type OnlineMigrationStatus = fftypes.FFEnum
+
+var (
+	// OnlineMigrationStatusPending the new table has not been created yet
+	OnlineMigrationStatusPending = fftypes.FFEnumValue("onlinemigrationstatus", "pending")
+	// OnlineMigrationStatusBackfilling the new table exists, and existing rows are being copied into it
+	OnlineMigrationStatusBackfilling = fftypes.FFEnumValue("onlinemigrationstatus", "backfilling")
+	// OnlineMigrationStatusBackfilled all rows that existed when the last batch was copied are in the new table
+	OnlineMigrationStatusBackfilled = fftypes.FFEnumValue("onlinemigrationstatus", "backfilled")
+	// OnlineMigrationStatusComplete the new table has been swapped into place
+	OnlineMigrationStatusComplete = fftypes.FFEnumValue("onlinemigrationstatus", "complete")
+)
+
+// OnlineMigration is the progress of a schema change that is applied while the node is running, by
+// creating a new copy of a table, backfilling it, and then swapping it into place
+type OnlineMigration struct {
+	Name     string                `ffstruct:"OnlineMigration" json:"name"`
+	Table    string                `ffstruct:"OnlineMigration" json:"table"`
+	Status   OnlineMigrationStatus `ffstruct:"OnlineMigration" json:"status" ffenum:"onlinemigrationstatus"`
+	Sequence int64                 `ffstruct:"OnlineMigration" json:"sequence"`
+	Rows     int64                 `ffstruct:"OnlineMigration" json:"rows"`
+	Updated  *fftypes.FFTime       `ffstruct:"OnlineMigration" json:"updated,omitempty"`
+	Running  bool                  `ffstruct:"OnlineMigration" json:"running"`
+	Error    string                `ffstruct:"OnlineMigration" json:"error,omitempty"`
+}
@@ -18,6 +18,8 @@ package core
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -35,6 +37,8 @@ var (
 	OpTypeBlockchainPinBatch = fftypes.FFEnumValue("optype", "blockchain_pin_batch")
 	// OpTypeBlockchainNetworkAction is an administrative action on a multiparty blockchain network
 	OpTypeBlockchainNetworkAction = fftypes.FFEnumValue("optype", "blockchain_network_action")
+	// OpTypeBlockchainPinAuditAnchor is a blockchain transaction to pin the Merkle root of an audit anchor
+	OpTypeBlockchainPinAuditAnchor = fftypes.FFEnumValue("optype", "blockchain_pin_audit_anchor")
 	// OpTypeBlockchainContractDeploy is a smart contract deploy
 	OpTypeBlockchainContractDeploy = fftypes.FFEnumValue("optype", "blockchain_deploy")
 	// OpTypeBlockchainInvoke is a smart contract invoke
@@ -53,6 +57,8 @@ var (
 	OpTypeDataExchangeSendBatch = fftypes.FFEnumValue("optype", "dataexchange_send_batch")
 	// OpTypeDataExchangeSendBlob is a private send of a blob
 	OpTypeDataExchangeSendBlob = fftypes.FFEnumValue("optype", "dataexchange_send_blob")
+	// OpTypeDataExchangeSendBatchAck is a private send of an acknowledgement for a received batch, back to its sender
+	OpTypeDataExchangeSendBatchAck = fftypes.FFEnumValue("optype", "dataexchange_send_batch_ack")
 	// OpTypeTokenCreatePool is a token pool creation
 	OpTypeTokenCreatePool = fftypes.FFEnumValue("optype", "token_create_pool")
 	// OpTypeTokenActivatePool is a token pool activation
@@ -67,6 +73,7 @@ func (op *Operation) IsBlockchainOperation() bool {
 	return op.Type == OpTypeBlockchainInvoke ||
 		op.Type == OpTypeBlockchainNetworkAction ||
 		op.Type == OpTypeBlockchainPinBatch ||
+		op.Type == OpTypeBlockchainPinAuditAnchor ||
 		op.Type == OpTypeBlockchainContractDeploy
 }
 
@@ -76,11 +83,12 @@ func (op *Operation) IsTokenOperation() bool {
 
 func (op *Operation) DeepCopy() *Operation {
 	cop := &Operation{
-		Namespace: op.Namespace,
-		Type:      op.Type,
-		Status:    op.Status,
-		Plugin:    op.Plugin,
-		Error:     op.Error,
+		Namespace:     op.Namespace,
+		Type:          op.Type,
+		Status:        op.Status,
+		Plugin:        op.Plugin,
+		Error:         op.Error,
+		CorrelationID: op.CorrelationID,
 	}
 	if op.ID != nil {
 		idCopy := *op.ID
@@ -108,6 +116,10 @@ func (op *Operation) DeepCopy() *Operation {
 	if op.Output != nil {
 		cop.Output = deepCopyMap(op.Output)
 	}
+	if op.Progress != nil {
+		progressCopy := *op.Progress
+		cop.Progress = &progressCopy
+	}
 	return cop
 }
 
@@ -184,18 +196,99 @@ func NewOperation(plugin Named, namespace string, tx *fftypes.UUID, opType OpTyp
 
 // Operation is a description of an action performed as part of a transaction submitted by this node
 type Operation struct {
-	ID          *fftypes.UUID      `ffstruct:"Operation" json:"id" ffexcludeinput:"true"`
-	Namespace   string             `ffstruct:"Operation" json:"namespace" ffexcludeinput:"true"`
-	Transaction *fftypes.UUID      `ffstruct:"Operation" json:"tx" ffexcludeinput:"true"`
-	Type        OpType             `ffstruct:"Operation" json:"type" ffenum:"optype" ffexcludeinput:"true"`
-	Status      OpStatus           `ffstruct:"Operation" json:"status"`
-	Plugin      string             `ffstruct:"Operation" json:"plugin" ffexcludeinput:"true"`
-	Input       fftypes.JSONObject `ffstruct:"Operation" json:"input,omitempty" ffexcludeinput:"true"`
-	Output      fftypes.JSONObject `ffstruct:"Operation" json:"output,omitempty"`
-	Error       string             `ffstruct:"Operation" json:"error,omitempty"`
-	Created     *fftypes.FFTime    `ffstruct:"Operation" json:"created,omitempty" ffexcludeinput:"true"`
-	Updated     *fftypes.FFTime    `ffstruct:"Operation" json:"updated,omitempty" ffexcludeinput:"true"`
-	Retry       *fftypes.UUID      `ffstruct:"Operation" json:"retry,omitempty" ffexcludeinput:"true"`
+	ID            *fftypes.UUID      `ffstruct:"Operation" json:"id" ffexcludeinput:"true"`
+	Namespace     string             `ffstruct:"Operation" json:"namespace" ffexcludeinput:"true"`
+	Transaction   *fftypes.UUID      `ffstruct:"Operation" json:"tx" ffexcludeinput:"true"`
+	Type          OpType             `ffstruct:"Operation" json:"type" ffenum:"optype" ffexcludeinput:"true"`
+	Status        OpStatus           `ffstruct:"Operation" json:"status"`
+	Plugin        string             `ffstruct:"Operation" json:"plugin" ffexcludeinput:"true"`
+	Input         fftypes.JSONObject `ffstruct:"Operation" json:"input,omitempty" ffexcludeinput:"true"`
+	Output        fftypes.JSONObject `ffstruct:"Operation" json:"output,omitempty"`
+	Error         string             `ffstruct:"Operation" json:"error,omitempty"`
+	Created       *fftypes.FFTime    `ffstruct:"Operation" json:"created,omitempty" ffexcludeinput:"true"`
+	Updated       *fftypes.FFTime    `ffstruct:"Operation" json:"updated,omitempty" ffexcludeinput:"true"`
+	Retry         *fftypes.UUID      `ffstruct:"Operation" json:"retry,omitempty" ffexcludeinput:"true"`
+	Progress      *OperationProgress `ffstruct:"Operation" json:"progress,omitempty" ffexcludeinput:"true"`
+	CorrelationID string             `ffstruct:"Operation" json:"correlationId,omitempty" ffexcludeinput:"true"`
+}
+
+// OperationProgress is the last reported progress of a long running transfer, such as a large blob sent over data exchange
+type OperationProgress struct {
+	BytesSent  int64 `ffstruct:"OperationProgress" json:"bytesSent"`
+	BytesTotal int64 `ffstruct:"OperationProgress" json:"bytesTotal"`
+}
+
+// Scan implements sql.Scanner
+func (p *OperationProgress) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), &p)
+	case []byte:
+		return json.Unmarshal(src, &p)
+	default:
+		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, p)
+	}
+}
+
+// Value implements sql.Valuer
+func (p OperationProgress) Value() (driver.Value, error) {
+	bytes, _ := json.Marshal(p)
+	return bytes, nil
+}
+
+// OperationHistoryEntry records a single status update applied to an operation, so the full timeline
+// of an operation is retained even though the status/error/output fields on the operation are overwritten
+type OperationHistoryEntry struct {
+	ID             *fftypes.UUID      `ffstruct:"OperationHistoryEntry" json:"id"`
+	Namespace      string             `ffstruct:"OperationHistoryEntry" json:"namespace"`
+	Operation      *fftypes.UUID      `ffstruct:"OperationHistoryEntry" json:"operation"`
+	Transaction    *fftypes.UUID      `ffstruct:"OperationHistoryEntry" json:"tx,omitempty"`
+	Status         OpStatus           `ffstruct:"OperationHistoryEntry" json:"status"`
+	Error          string             `ffstruct:"OperationHistoryEntry" json:"error,omitempty"`
+	Plugin         string             `ffstruct:"OperationHistoryEntry" json:"plugin,omitempty"`
+	BlockchainTXID string             `ffstruct:"OperationHistoryEntry" json:"blockchainId,omitempty"`
+	Receipt        fftypes.JSONObject `ffstruct:"OperationHistoryEntry" json:"receipt,omitempty"`
+	Created        *fftypes.FFTime    `ffstruct:"OperationHistoryEntry" json:"created"`
+	Sequence       int64              `json:"-"`
+}
+
+// BulkOperationRetry is the input to a bulk retry of the failed operations matching a filter
+type BulkOperationRetry struct {
+	DryRun      bool `ffstruct:"BulkOperationRetry" json:"dryRun,omitempty"`
+	Concurrency int  `ffstruct:"BulkOperationRetry" json:"concurrency,omitempty"`
+}
+
+// BulkOperationRetryItem is the outcome of a bulk retry for a single failed operation
+type BulkOperationRetryItem struct {
+	Operation *fftypes.UUID `ffstruct:"BulkOperationRetryItem" json:"operation"`
+	Type      OpType        `ffstruct:"BulkOperationRetryItem" json:"type"`
+	Retry     *fftypes.UUID `ffstruct:"BulkOperationRetryItem" json:"retry,omitempty"`
+	Error     string        `ffstruct:"BulkOperationRetryItem" json:"error,omitempty"`
+}
+
+// BulkOperationRetryResult summarizes a bulk retry, or previews it for a dry run
+type BulkOperationRetryResult struct {
+	DryRun     bool                      `ffstruct:"BulkOperationRetryResult" json:"dryRun"`
+	Matched    int                       `ffstruct:"BulkOperationRetryResult" json:"matched"`
+	Retried    int                       `ffstruct:"BulkOperationRetryResult" json:"retried"`
+	Failed     int                       `ffstruct:"BulkOperationRetryResult" json:"failed"`
+	Operations []*BulkOperationRetryItem `ffstruct:"BulkOperationRetryResult" json:"operations"`
+}
+
+// OperationOutputSchema is the JSON schema a plugin declares for the output it reports on one type of operation
+type OperationOutputSchema struct {
+	Plugin string           `ffstruct:"OperationOutputSchema" json:"plugin"`
+	Type   OpType           `ffstruct:"OperationOutputSchema" json:"type" ffenum:"optype"`
+	Schema *fftypes.JSONAny `ffstruct:"OperationOutputSchema" json:"schema"`
+}
+
+// OperationOutputSchemaProvider can be implemented by a plugin to declare the structure of the outputs it
+// reports for each type of operation. Outputs that do not match are rejected, so clients can rely on them.
+type OperationOutputSchemaProvider interface {
+	Named
+	OperationOutputSchemas() map[OpType]*fftypes.JSONAny
 }
 
 // OperationUpdateDTO is the subset of fields on an operation that are mutable, via the SPI
@@ -254,6 +347,7 @@ type OperationCallbacks interface {
 // Output can be used to add opaque protocol-specific JSON from the plugin (protocol transaction ID etc.)
 // Note this is an optional hook information, and stored separately to the confirmation of the actual event that was being submitted/sequenced.
 // Only the party submitting the transaction will see this data.
+// Progress can be reported with an empty Status, to record how much of a transfer has completed without changing the status.
 type OperationUpdate struct {
 	Plugin         string
 	NamespacedOpID string
@@ -264,6 +358,7 @@ type OperationUpdate struct {
 	VerifyManifest bool
 	DXManifest     string
 	DXHash         string
+	Progress       *OperationProgress
 }
 
 type OperationUpdateAsync struct {
@@ -0,0 +1,59 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// OperationApprovalStatus is the state of a request for approval of an operation
+type OperationApprovalStatus = fftypes.FFEnum
+
+var (
+	// OperationApprovalStatusPending the operation is waiting for more approvals before it is submitted
+	OperationApprovalStatusPending = fftypes.FFEnumValue("opapprovalstatus", "pending")
+	// OperationApprovalStatusApproved the required number of approvals was given, and the operation was submitted
+	OperationApprovalStatusApproved = fftypes.FFEnumValue("opapprovalstatus", "approved")
+	// OperationApprovalStatusExpired the approvals were not given in time, and the operation was failed
+	OperationApprovalStatusExpired = fftypes.FFEnumValue("opapprovalstatus", "expired")
+)
+
+// OperationApproval holds an operation back from submission to its connector, until the number
+// of local approvers required by the approval policy for its type have approved it
+type OperationApproval struct {
+	ID          *fftypes.UUID           `ffstruct:"OperationApproval" json:"id"`
+	Namespace   string                  `ffstruct:"OperationApproval" json:"namespace"`
+	Operation   *fftypes.UUID           `ffstruct:"OperationApproval" json:"operation"`
+	Transaction *fftypes.UUID           `ffstruct:"OperationApproval" json:"tx,omitempty"`
+	Type        OpType                  `ffstruct:"OperationApproval" json:"type" ffenum:"optype"`
+	Status      OperationApprovalStatus `ffstruct:"OperationApproval" json:"status" ffenum:"opapprovalstatus"`
+	Required    int                     `ffstruct:"OperationApproval" json:"required"`
+	Approvers   fftypes.FFStringArray   `ffstruct:"OperationApproval" json:"approvers"`
+	Created     *fftypes.FFTime         `ffstruct:"OperationApproval" json:"created"`
+	Updated     *fftypes.FFTime         `ffstruct:"OperationApproval" json:"updated"`
+	Expires     *fftypes.FFTime         `ffstruct:"OperationApproval" json:"expires"`
+}
+
+// HasApproved is true if the approver has already approved the operation
+func (oa *OperationApproval) HasApproved(approver string) bool {
+	for _, a := range oa.Approvers {
+		if a == approver {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// OperationFee records the gas used, and the fee paid, by the blockchain transaction of an operation,
+// as reported in the receipt from the blockchain connector
+type OperationFee struct {
+	Operation      *fftypes.UUID     `ffstruct:"OperationFee" json:"operation"`
+	Namespace      string            `ffstruct:"OperationFee" json:"namespace"`
+	Transaction    *fftypes.UUID     `ffstruct:"OperationFee" json:"tx,omitempty"`
+	Type           OpType            `ffstruct:"OperationFee" json:"type"`
+	Status         OpStatus          `ffstruct:"OperationFee" json:"status"`
+	Plugin         string            `ffstruct:"OperationFee" json:"plugin"`
+	Key            string            `ffstruct:"OperationFee" json:"key,omitempty"`
+	BlockchainTXID string            `ffstruct:"OperationFee" json:"blockchainId,omitempty"`
+	GasUsed        *fftypes.FFBigInt `ffstruct:"OperationFee" json:"gasUsed"`
+	GasPrice       *fftypes.FFBigInt `ffstruct:"OperationFee" json:"gasPrice,omitempty"`
+	Fee            *fftypes.FFBigInt `ffstruct:"OperationFee" json:"fee"`
+	Created        *fftypes.FFTime   `ffstruct:"OperationFee" json:"created"`
+}
+
+// FeeSummary is the total of the fees paid by a signing key in a namespace, on a single UTC day
+type FeeSummary struct {
+	Day        string            `ffstruct:"FeeSummary" json:"day"`
+	Key        string            `ffstruct:"FeeSummary" json:"key"`
+	Operations int64             `ffstruct:"FeeSummary" json:"operations"`
+	GasUsed    *fftypes.FFBigInt `ffstruct:"FeeSummary" json:"gasUsed"`
+	Fee        *fftypes.FFBigInt `ffstruct:"FeeSummary" json:"fee"`
+}
@@ -80,6 +80,10 @@ func TestOperationTypes(t *testing.T) {
 	assert.True(t, op.IsBlockchainOperation())
 	assert.False(t, op.IsTokenOperation())
 
+	op.Type = OpTypeBlockchainPinAuditAnchor
+	assert.True(t, op.IsBlockchainOperation())
+	assert.False(t, op.IsTokenOperation())
+
 	// Token operation types
 	op.Type = OpTypeTokenActivatePool
 	assert.True(t, op.IsTokenOperation())
@@ -100,18 +104,20 @@ func TestOperationTypes(t *testing.T) {
 
 func TestOperationDeepCopy(t *testing.T) {
 	op := &Operation{
-		ID:          fftypes.NewUUID(),
-		Namespace:   "ns1",
-		Transaction: fftypes.NewUUID(),
-		Type:        OpTypeBlockchainInvoke,
-		Status:      OpStatusInitialized,
-		Plugin:      "fake",
-		Input:       fftypes.JSONObject{"key": "value"},
-		Output:      fftypes.JSONObject{"result": "success"},
-		Error:       "error message",
-		Created:     fftypes.Now(),
-		Updated:     fftypes.Now(),
-		Retry:       fftypes.NewUUID(),
+		ID:            fftypes.NewUUID(),
+		Namespace:     "ns1",
+		Transaction:   fftypes.NewUUID(),
+		Type:          OpTypeBlockchainInvoke,
+		Status:        OpStatusInitialized,
+		Plugin:        "fake",
+		Input:         fftypes.JSONObject{"key": "value"},
+		Output:        fftypes.JSONObject{"result": "success"},
+		Error:         "error message",
+		Created:       fftypes.Now(),
+		Updated:       fftypes.Now(),
+		Retry:         fftypes.NewUUID(),
+		Progress:      &OperationProgress{BytesSent: 10, BytesTotal: 100},
+		CorrelationID: "esb-1234",
 	}
 
 	copyOp := op.DeepCopy()
@@ -130,6 +136,8 @@ func TestOperationDeepCopy(t *testing.T) {
 	assert.Equal(t, op.Created, copyOp.Created)
 	assert.Equal(t, op.Updated, copyOp.Updated)
 	assert.Equal(t, op.Retry, copyOp.Retry)
+	assert.Equal(t, op.Progress, copyOp.Progress)
+	assert.Equal(t, op.CorrelationID, copyOp.CorrelationID)
 
 	// Modify the original and ensure the copy is not modified
 	*op.ID = *fftypes.NewUUID()
@@ -146,6 +154,7 @@ func TestOperationDeepCopy(t *testing.T) {
 	assert.NotSame(t, copyOp.Retry, op.Retry)
 	assert.NotSame(t, copyOp.Input, op.Input)
 	assert.NotSame(t, copyOp.Output, op.Output)
+	assert.NotSame(t, copyOp.Progress, op.Progress)
 
 	// showcasing that the shallow copy is a shallow copy and the copied object value changed as well the pointer has the same address as the original
 	assert.Equal(t, shallowCopy.ID, op.ID)
@@ -153,8 +162,35 @@ func TestOperationDeepCopy(t *testing.T) {
 
 	// Ensure no new fields are added to the Operation struct
 	// If a new field is added, this test will fail and the DeepCopy function should be updated
-	assert.Equal(t, 12, reflect.TypeOf(Operation{}).NumField())
+	assert.Equal(t, 14, reflect.TypeOf(Operation{}).NumField())
+}
+
+func TestOperationProgressScan(t *testing.T) {
+	progress := &OperationProgress{}
+	err := progress.Scan([]byte(`{"bytesSent":10,"bytesTotal":100}`))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), progress.BytesSent)
+	assert.Equal(t, int64(100), progress.BytesTotal)
+
+	progress = &OperationProgress{}
+	err = progress.Scan(`{"bytesSent":20}`)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), progress.BytesSent)
+
+	err = progress.Scan(nil)
+	assert.NoError(t, err)
+
+	err = progress.Scan(false)
+	assert.Regexp(t, "FF00105", err)
 }
+
+func TestOperationProgressValue(t *testing.T) {
+	progress := &OperationProgress{BytesSent: 10, BytesTotal: 100}
+	b, err := progress.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"bytesSent":10,"bytesTotal":100}`, string(b.([]byte)))
+}
+
 func TestParseNamespacedOpID(t *testing.T) {
 
 	ctx := context.Background()
@@ -0,0 +1,44 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// PayloadSignatureAlgorithm is the algorithm used to sign the payload hash of a batch
+type PayloadSignatureAlgorithm = fftypes.FFEnum
+
+var (
+	// PayloadSignatureAlgorithmEd25519 is a pure Ed25519 signature over the payload hash
+	PayloadSignatureAlgorithmEd25519 = fftypes.FFEnumValue("payloadsignaturealgorithm", "ed25519")
+	// PayloadSignatureAlgorithmECDSASHA256 is an ASN.1 encoded ECDSA signature over the (SHA-256) payload hash
+	PayloadSignatureAlgorithmECDSASHA256 = fftypes.FFEnumValue("payloadsignaturealgorithm", "ecdsa_sha256")
+	// PayloadSignatureAlgorithmRSASHA256 is an RSA PKCS #1 v1.5 signature over the (SHA-256) payload hash
+	PayloadSignatureAlgorithmRSASHA256 = fftypes.FFEnumValue("payloadsignaturealgorithm", "rsa_sha256")
+)
+
+// IdentityProfilePayloadSigningKeys is the field in the profile of an org, containing the public keys its
+// members can use to sign batch payloads. Each entry is a base64 encoded PKIX (DER) public key.
+const IdentityProfilePayloadSigningKeys = "payloadSigningKeys"
+
+// PayloadSignature is a signature over the payload hash of a batch (the hash of the manifest without the
+// signature), made with an org-level key rather than a blockchain key. It lets recipients verify who authored
+// the payload, even for unpinned batches.
+type PayloadSignature struct {
+	Algorithm PayloadSignatureAlgorithm `json:"algorithm"`
+	PublicKey string                    `json:"publicKey"` // base64 encoded PKIX (DER) public key
+	Signature string                    `json:"signature"` // base64 encoded
+}
@@ -0,0 +1,82 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// APIPermission is the permission an API route requires within a namespace, which auth plugins
+// that support role-based authorization check against the roles of the caller
+type APIPermission = fftypes.FFEnum
+
+var (
+	// APIPermissionRead is required to query the namespace
+	APIPermissionRead = fftypes.FFEnumValue("apipermission", "read")
+	// APIPermissionSend is required to submit messages, transactions and data to the namespace
+	APIPermissionSend = fftypes.FFEnumValue("apipermission", "send")
+	// APIPermissionAdmin is required to manage the identities and network configuration of the namespace
+	APIPermissionAdmin = fftypes.FFEnumValue("apipermission", "admin")
+	// APIPermissionSPI is required to call the SPI of the namespace
+	APIPermissionSPI = fftypes.FFEnumValue("apipermission", "spi")
+)
+
+type apiPermissionKey struct{}
+
+// WithAPIPermission records on the context the permission required by the API route being called
+func WithAPIPermission(ctx context.Context, permission APIPermission) context.Context {
+	return context.WithValue(ctx, apiPermissionKey{}, permission)
+}
+
+// GetAPIPermission returns the permission recorded by WithAPIPermission. Where none has been recorded,
+// reads require the read permission and any other method requires the send permission.
+func GetAPIPermission(ctx context.Context, method string) APIPermission {
+	if permission, ok := ctx.Value(apiPermissionKey{}).(APIPermission); ok {
+		return permission
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return APIPermissionRead
+	}
+	return APIPermissionSend
+}
+
+type authPrincipalKey struct{}
+
+// WithAuthPrincipal adds to the context a holder for the principal that the auth plugin of the namespace
+// authenticates the caller as, so it can be read after the request has been authorized
+func WithAuthPrincipal(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authPrincipalKey{}, new(string))
+}
+
+// SetAuthPrincipal is called by auth plugins to record the principal they authenticated the caller as
+func SetAuthPrincipal(ctx context.Context, principal string) {
+	if holder, ok := ctx.Value(authPrincipalKey{}).(*string); ok {
+		*holder = principal
+	}
+}
+
+// GetAuthPrincipal returns the principal recorded by SetAuthPrincipal, or an empty string where the caller
+// has not been authenticated by an auth plugin
+func GetAuthPrincipal(ctx context.Context) string {
+	if holder, ok := ctx.Value(authPrincipalKey{}).(*string); ok {
+		return *holder
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAPIPermission(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, APIPermissionRead, GetAPIPermission(ctx, "GET"))
+	assert.Equal(t, APIPermissionSend, GetAPIPermission(ctx, "POST"))
+	assert.Equal(t, APIPermissionAdmin, GetAPIPermission(WithAPIPermission(ctx, APIPermissionAdmin), "GET"))
+}
+
+func TestAuthPrincipal(t *testing.T) {
+	ctx := context.Background()
+	SetAuthPrincipal(ctx, "ignored")
+	assert.Empty(t, GetAuthPrincipal(ctx))
+
+	ctx = WithAuthPrincipal(ctx)
+	assert.Empty(t, GetAuthPrincipal(ctx))
+	SetAuthPrincipal(WithAPIPermission(ctx, APIPermissionAdmin), "alice") // a derived context shares the holder
+	assert.Equal(t, "alice", GetAuthPrincipal(ctx))
+}
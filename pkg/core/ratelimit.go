@@ -0,0 +1,57 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// RateLimitGroup is a group of API routes that share a rate limit
+type RateLimitGroup = fftypes.FFEnum
+
+var (
+	// RateLimitGroupMessages is the routes under /messages
+	RateLimitGroupMessages = fftypes.FFEnumValue("ratelimitgroup", "messages")
+	// RateLimitGroupContracts is the routes under /contracts
+	RateLimitGroupContracts = fftypes.FFEnumValue("ratelimitgroup", "contracts")
+	// RateLimitGroupTokens is the routes under /tokens
+	RateLimitGroupTokens = fftypes.FFEnumValue("ratelimitgroup", "tokens")
+	// RateLimitGroupOther is every other route of the namespace
+	RateLimitGroupOther = fftypes.FFEnumValue("ratelimitgroup", "other")
+)
+
+// RateLimitGroups is every route group that can be configured with a rate limit
+var RateLimitGroups = []RateLimitGroup{
+	RateLimitGroupMessages,
+	RateLimitGroupContracts,
+	RateLimitGroupTokens,
+	RateLimitGroupOther,
+}
+
+// RateLimit is the rate at which each identity can call a group of routes in a namespace
+type RateLimit struct {
+	RequestsPerSecond float64 // zero means no limit
+	Burst             int     // the number of requests that can be made at once, after a period of inactivity
+}
+
+// RateLimitStatus is the state of the rate limit of an identity, returned in the RateLimit headers of responses
+type RateLimitStatus struct {
+	Limit      int   // the maximum number of requests that can be made at once
+	Remaining  int   // the number of requests that can be made now
+	Reset      int64 // the number of seconds until Remaining returns to Limit
+	RetryAfter int64 // when no requests remain, the number of seconds until the next request can be made
+}
@@ -0,0 +1,44 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// RebuildTarget is a set of derived records that can be regenerated from the records they are derived from
+type RebuildTarget = fftypes.FFEnum
+
+var (
+	// RebuildTargetNextPins the next expected pin of each member of each private context, derived from the pins of confirmed private messages
+	RebuildTargetNextPins = fftypes.FFEnumValue("rebuildtarget", "nextpins")
+	// RebuildTargetMessageStates the state of each message, derived from the dispatch status of the pins of its batch
+	RebuildTargetMessageStates = fftypes.FFEnumValue("rebuildtarget", "messagestates")
+	// RebuildTargetTokenBalances the balance of each account of each token pool, derived from the token transfers of the pool
+	RebuildTargetTokenBalances = fftypes.FFEnumValue("rebuildtarget", "tokenbalances")
+)
+
+// RebuildStatus is the progress of the latest rebuild of a set of derived records on this node
+type RebuildStatus struct {
+	Target    RebuildTarget   `ffstruct:"RebuildStatus" json:"target" ffenum:"rebuildtarget"`
+	Running   bool            `ffstruct:"RebuildStatus" json:"running"`
+	Processed int64           `ffstruct:"RebuildStatus" json:"processed"`
+	Repaired  int64           `ffstruct:"RebuildStatus" json:"repaired"`
+	Started   *fftypes.FFTime `ffstruct:"RebuildStatus" json:"started,omitempty"`
+	Completed *fftypes.FFTime `ffstruct:"RebuildStatus" json:"completed,omitempty"`
+	Error     string          `ffstruct:"RebuildStatus" json:"error,omitempty"`
+}
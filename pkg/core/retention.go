@@ -0,0 +1,34 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// RetentionEstimate is the result of a dry run of the data retention policy of a namespace
+type RetentionEstimate struct {
+	Collections []*RetentionCollectionEstimate `ffstruct:"RetentionEstimate" json:"collections"`
+}
+
+// RetentionCollectionEstimate is the number of records in a collection that are currently old enough to be pruned
+type RetentionCollectionEstimate struct {
+	Collection string              `ffstruct:"RetentionCollectionEstimate" json:"collection"`
+	MaxAge     *fftypes.FFDuration `ffstruct:"RetentionCollectionEstimate" json:"maxAge"`
+	Before     *fftypes.FFTime     `ffstruct:"RetentionCollectionEstimate" json:"before"`
+	Records    int64               `ffstruct:"RetentionCollectionEstimate" json:"records"`
+}
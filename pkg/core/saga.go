@@ -0,0 +1,100 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+)
+
+// SagaState is the composite state of a transaction declared as a sequence of compensable steps
+type SagaState = fftypes.FFEnum
+
+var (
+	// SagaStateActive steps are still being submitted or are in progress
+	SagaStateActive = fftypes.FFEnumValue("sagastate", "active")
+	// SagaStateCompleted every step succeeded
+	SagaStateCompleted = fftypes.FFEnumValue("sagastate", "completed")
+	// SagaStateCompensating a step failed permanently, and compensations for the earlier steps have been submitted
+	SagaStateCompensating = fftypes.FFEnumValue("sagastate", "compensating")
+	// SagaStateCompensated every compensation succeeded
+	SagaStateCompensated = fftypes.FFEnumValue("sagastate", "compensated")
+	// SagaStateCompensationFailed at least one compensation failed, and manual intervention is required
+	SagaStateCompensationFailed = fftypes.FFEnumValue("sagastate", "compensation_failed")
+)
+
+// SagaCompensation describes the operation to submit to undo a step, if a later step fails permanently
+type SagaCompensation struct {
+	Type   OpType             `ffstruct:"SagaCompensation" json:"type" ffenum:"optype"`
+	Plugin string             `ffstruct:"SagaCompensation" json:"plugin"`
+	Input  fftypes.JSONObject `ffstruct:"SagaCompensation" json:"input,omitempty"`
+}
+
+// SagaStep is a single ordered step in a transaction, with the compensation that undoes it
+type SagaStep struct {
+	Name                  string            `ffstruct:"SagaStep" json:"name,omitempty"`
+	Operation             *fftypes.UUID     `ffstruct:"SagaStep" json:"operation"`
+	Status                OpStatus          `ffstruct:"SagaStep" json:"status"`
+	Compensation          *SagaCompensation `ffstruct:"SagaStep" json:"compensation,omitempty"`
+	CompensationOperation *fftypes.UUID     `ffstruct:"SagaStep" json:"compensationOperation,omitempty"`
+	CompensationStatus    OpStatus          `ffstruct:"SagaStep" json:"compensationStatus,omitempty"`
+}
+
+// TransactionSaga is the ordered set of steps declared on a transaction, and its composite state
+type TransactionSaga struct {
+	State      SagaState       `ffstruct:"TransactionSaga" json:"state" ffenum:"sagastate"`
+	Steps      []*SagaStep     `ffstruct:"TransactionSaga" json:"steps"`
+	FailedStep *fftypes.UUID   `ffstruct:"TransactionSaga" json:"failedStep,omitempty"`
+	Updated    *fftypes.FFTime `ffstruct:"TransactionSaga" json:"updated,omitempty"`
+}
+
+// FindStep returns the step whose forward or compensation operation has the given ID
+func (s *TransactionSaga) FindStep(opID *fftypes.UUID) (step *SagaStep, idx int, compensation bool) {
+	for i, step := range s.Steps {
+		if step.Operation.Equals(opID) {
+			return step, i, false
+		}
+		if step.CompensationOperation.Equals(opID) {
+			return step, i, true
+		}
+	}
+	return nil, -1, false
+}
+
+// Scan implements sql.Scanner
+func (s *TransactionSaga) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), &s)
+	case []byte:
+		return json.Unmarshal(src, &s)
+	default:
+		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, s)
+	}
+}
+
+// Value implements sql.Valuer
+func (s TransactionSaga) Value() (driver.Value, error) {
+	bytes, _ := json.Marshal(s)
+	return bytes, nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionSagaFindStep(t *testing.T) {
+	op1 := fftypes.NewUUID()
+	op2 := fftypes.NewUUID()
+	comp1 := fftypes.NewUUID()
+	saga := &TransactionSaga{
+		Steps: []*SagaStep{
+			{Operation: op1, CompensationOperation: comp1},
+			{Operation: op2},
+		},
+	}
+
+	step, idx, compensation := saga.FindStep(op2)
+	assert.Equal(t, saga.Steps[1], step)
+	assert.Equal(t, 1, idx)
+	assert.False(t, compensation)
+
+	step, idx, compensation = saga.FindStep(comp1)
+	assert.Equal(t, saga.Steps[0], step)
+	assert.Equal(t, 0, idx)
+	assert.True(t, compensation)
+
+	step, idx, _ = saga.FindStep(fftypes.NewUUID())
+	assert.Nil(t, step)
+	assert.Equal(t, -1, idx)
+}
+
+func TestTransactionSagaScan(t *testing.T) {
+	saga := &TransactionSaga{}
+	err := saga.Scan([]byte(`{"state":"active","steps":[{"name":"step1"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, SagaStateActive, saga.State)
+	assert.Equal(t, "step1", saga.Steps[0].Name)
+}
+
+func TestTransactionSagaScanNil(t *testing.T) {
+	saga := &TransactionSaga{}
+	err := saga.Scan(nil)
+	assert.NoError(t, err)
+}
+
+func TestTransactionSagaScanString(t *testing.T) {
+	saga := &TransactionSaga{}
+	err := saga.Scan(`{"state":"compensating"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, SagaStateCompensating, saga.State)
+}
+
+func TestTransactionSagaScanError(t *testing.T) {
+	saga := &TransactionSaga{}
+	err := saga.Scan(false)
+	assert.Regexp(t, "FF00105", err)
+}
+
+func TestTransactionSagaValue(t *testing.T) {
+	saga := &TransactionSaga{State: SagaStateCompleted}
+	b, err := saga.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"state":"completed","steps":null}`, string(b.([]byte)))
+}
@@ -0,0 +1,36 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// SearchDocument is the text indexed for the full-text search of a message, built from its
+// tag, its topics and the values within its JSON data
+type SearchDocument struct {
+	Namespace string                `json:"namespace"`
+	Message   *fftypes.UUID         `json:"message"`
+	Tag       string                `json:"tag,omitempty"`
+	Topics    fftypes.FFStringArray `json:"topics,omitempty"`
+	Content   string                `json:"content"`
+	Created   *fftypes.FFTime       `json:"created"`
+}
+
+// SearchResult is a message that matched a full-text search, with its relevance score
+type SearchResult struct {
+	Score   float64  `ffstruct:"SearchResult" json:"score"`
+	Message *Message `ffstruct:"SearchResult" json:"message"`
+}
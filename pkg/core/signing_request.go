@@ -0,0 +1,50 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// TypedDataSignRequest is a request to sign EIP-712 typed data with a secp256k1 key held in the KMS
+// of the signing plugin of the namespace
+type TypedDataSignRequest struct {
+	KeyID     string           `ffstruct:"TypedDataSignRequest" json:"keyId"`
+	TypedData *fftypes.JSONAny `ffstruct:"TypedDataSignRequest" json:"typedData"`
+}
+
+// TypedDataSignature is an EIP-712 signature, in the form accepted by ecrecover
+type TypedDataSignature struct {
+	KeyID     string `ffstruct:"TypedDataSignature" json:"keyId"`
+	Address   string `ffstruct:"TypedDataSignature" json:"address"`
+	Hash      string `ffstruct:"TypedDataSignature" json:"hash"`
+	Signature string `ffstruct:"TypedDataSignature" json:"signature"`
+}
+
+// CredentialIssueRequest is a request to issue a verifiable credential, signed with the payload signing
+// key of the org
+type CredentialIssueRequest struct {
+	Type              []string           `ffstruct:"CredentialIssueRequest" json:"type,omitempty"`
+	CredentialSubject fftypes.JSONObject `ffstruct:"CredentialIssueRequest" json:"credentialSubject"`
+	ExpirationDate    *fftypes.FFTime    `ffstruct:"CredentialIssueRequest" json:"expirationDate,omitempty"`
+}
+
+// IssuedCredential is a verifiable credential, in the JSON form of the W3C data model and as a signed JWT
+type IssuedCredential struct {
+	ID         string             `ffstruct:"IssuedCredential" json:"id"`
+	Issuer     string             `ffstruct:"IssuedCredential" json:"issuer"`
+	Credential fftypes.JSONObject `ffstruct:"IssuedCredential" json:"credential"`
+	JWT        string             `ffstruct:"IssuedCredential" json:"jwt"`
+}
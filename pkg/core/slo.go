@@ -0,0 +1,50 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// SLOType is a service level objective that FireFly measures for itself
+type SLOType = fftypes.FFEnum
+
+var (
+	// SLOTypeMessageConfirmation the time from a message being created, to it being confirmed, at the configured percentile
+	SLOTypeMessageConfirmation = fftypes.FFEnumValue("slotype", "message_confirmation")
+	// SLOTypeEventDeliveryLag the number of events the slowest durable subscription is behind the latest event
+	SLOTypeEventDeliveryLag = fftypes.FFEnumValue("slotype", "event_delivery_lag")
+)
+
+// SLOStatus is the latest measurement of a service level objective, against its threshold
+type SLOStatus struct {
+	ID        *fftypes.UUID   `ffstruct:"SLOStatus" json:"id"`
+	Type      SLOType         `ffstruct:"SLOStatus" json:"type" ffenum:"slotype"`
+	Threshold float64         `ffstruct:"SLOStatus" json:"threshold"`
+	Value     float64         `ffstruct:"SLOStatus" json:"value"`
+	Samples   int             `ffstruct:"SLOStatus" json:"samples"`
+	Breached  bool            `ffstruct:"SLOStatus" json:"breached"`
+	Checked   *fftypes.FFTime `ffstruct:"SLOStatus" json:"checked,omitempty"`
+	Changed   *fftypes.FFTime `ffstruct:"SLOStatus" json:"changed,omitempty"`
+}
+
+// SLONotification is posted to the SLO notification webhook when a service level objective is breached or recovers
+type SLONotification struct {
+	Namespace string     `json:"namespace"`
+	Event     EventType  `json:"event"`
+	SLO       *SLOStatus `json:"slo"`
+}
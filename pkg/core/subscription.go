@@ -52,7 +52,8 @@ func NewSubscriptionFilterFromQuery(query url.Values) SubscriptionFilter {
 			Listener: query.Get("filter.blockchain.listener"),
 		},
 		Transaction: TransactionFilter{
-			Type: query.Get("filter.transaction.type"),
+			Type:   query.Get("filter.transaction.type"),
+			Labels: query.Get("filter.transaction.labels"),
 		},
 		Topic:            query.Get("filter.topic"),
 		DeprecatedTag:    query.Get("filter.tag"),
@@ -69,7 +70,8 @@ type MessageFilter struct {
 }
 
 type TransactionFilter struct {
-	Type string `ffstruct:"SubscriptionTransactionFilter" json:"type,omitempty"`
+	Type   string `ffstruct:"SubscriptionTransactionFilter" json:"type,omitempty"`
+	Labels string `ffstruct:"SubscriptionTransactionFilter" json:"labels,omitempty"`
 }
 
 type BlockchainEventFilter struct {
@@ -134,6 +136,18 @@ type SubscriptionStatus struct {
 	CurrentOffset int64 `ffstruct:"SubscriptionStatus" json:"currentOffset,omitempty" ffexcludeinout:"true"`
 }
 
+// SubscriptionOffset is the committed position of a durable subscription in the event stream,
+// readable and writable out-of-band by consumers that poll for events rather than connecting
+type SubscriptionOffset struct {
+	Subscription *fftypes.UUID `ffstruct:"SubscriptionOffset" json:"subscription" ffexcludeinput:"true"`
+	Current      int64         `ffstruct:"SubscriptionOffset" json:"current"`
+}
+
+// SubscriptionEventsAck acknowledges the events polled from a subscription using the poll transport
+type SubscriptionEventsAck struct {
+	Sequence int64 `ffstruct:"SubscriptionEventsAck" json:"sequence"`
+}
+
 func (so *SubscriptionOptions) UnmarshalJSON(b []byte) error {
 	so.additionalOptions = fftypes.JSONObject{}
 	err := json.Unmarshal(b, &so.additionalOptions)
@@ -126,7 +126,7 @@ func TestSubscriptionUnMarshalFail(t *testing.T) {
 }
 
 func TestNewSubscriptionFilterFromQuery(t *testing.T) {
-	query, _ := url.ParseQuery("filter.events=message_confirmed&filter.topic=topic1&filter.message.author=did:firefly:org/author1&filter.blockchain.name=flapflip&filter.transaction.type=test&filter.group=deprecated")
+	query, _ := url.ParseQuery("filter.events=message_confirmed&filter.topic=topic1&filter.message.author=did:firefly:org/author1&filter.blockchain.name=flapflip&filter.transaction.type=test&filter.transaction.labels=payroll&filter.group=deprecated")
 	expectedFilter := SubscriptionFilter{
 		Events: "message_confirmed",
 		Topic:  "topic1",
@@ -137,7 +137,8 @@ func TestNewSubscriptionFilterFromQuery(t *testing.T) {
 			Name: "flapflip",
 		},
 		Transaction: TransactionFilter{
-			Type: "test",
+			Type:   "test",
+			Labels: "payroll",
 		},
 		DeprecatedGroup: "deprecated",
 	}
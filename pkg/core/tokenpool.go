@@ -18,8 +18,11 @@ package core
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
 )
 
 type TokenType = fftypes.FFEnum
@@ -36,6 +39,13 @@ var (
 	TokenInterfaceFormatFFI = fftypes.FFEnumValue("tokeninterfaceformat", "ffi")
 )
 
+const (
+	// TokenPoolFirstEventOldest indexes the full history of the token contract
+	TokenPoolFirstEventOldest = "oldest"
+	// TokenPoolFirstEventNewest indexes only events after the pool is activated
+	TokenPoolFirstEventNewest = "newest"
+)
+
 type TokenPoolInput struct {
 	TokenPool
 	IdempotencyKey IdempotencyKey `ffstruct:"TokenPoolInput" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
@@ -63,7 +73,9 @@ type TokenPool struct {
 	InterfaceFormat TokenInterfaceFormat  `ffstruct:"TokenPool" json:"interfaceFormat,omitempty" ffenum:"tokeninterfaceformat" ffexcludeinput:"true"`
 	Methods         *fftypes.JSONAny      `ffstruct:"TokenPool" json:"methods,omitempty" ffexcludeinput:"true"`
 	Published       bool                  `ffstruct:"TokenPool" json:"published" ffexcludeinput:"true"`
+	FirstEvent      string                `ffstruct:"TokenPool" json:"firstEvent,omitempty"`
 	PluginData      string                `ffstruct:"TokenPool" json:"-" ffexcludeinput:"true"` // reserved for internal plugin use (not returned on API)
+	Sequence        int64                 `json:"-"`                                            // Local database sequence, used to page through pools in a stable order
 }
 
 type TokenPoolDefinition struct {
@@ -79,6 +91,13 @@ func (t *TokenPool) Validate(ctx context.Context) (err error) {
 			return err
 		}
 	}
+	switch t.FirstEvent {
+	case "", TokenPoolFirstEventNewest, TokenPoolFirstEventOldest:
+	default:
+		if _, err := strconv.ParseUint(t.FirstEvent, 10, 64); err != nil {
+			return i18n.NewError(ctx, coremsgs.MsgInvalidTokenPoolFirstEvent, t.FirstEvent)
+		}
+	}
 	return nil
 }
 
@@ -40,6 +40,22 @@ func TestTokenPoolValidation(t *testing.T) {
 	err = pool.Validate(context.Background())
 	assert.Regexp(t, "FF00140.*'networkName'", err)
 
+	pool = &TokenPool{
+		Namespace:  "ok",
+		Name:       "ok",
+		FirstEvent: "-1",
+	}
+	err = pool.Validate(context.Background())
+	assert.Regexp(t, "FF10611", err)
+
+	pool = &TokenPool{
+		Namespace:  "ok",
+		Name:       "ok",
+		FirstEvent: "12345",
+	}
+	err = pool.Validate(context.Background())
+	assert.NoError(t, err)
+
 	pool = &TokenPool{
 		Namespace: "ok",
 		Name:      "ok",
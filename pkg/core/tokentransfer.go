@@ -45,11 +45,13 @@ type TokenTransfer struct {
 	TX              TransactionRef     `ffstruct:"TokenTransfer" json:"tx" ffexcludeinput:"true"`
 	BlockchainEvent *fftypes.UUID      `ffstruct:"TokenTransfer" json:"blockchainEvent,omitempty" ffexcludeinput:"true"`
 	Config          fftypes.JSONObject `ffstruct:"TokenTransfer" json:"config,omitempty" ffexcludeoutput:"true"` // for REST calls only (not stored)
+	Sequence        int64              `json:"-"`                                                                // Local database sequence, used to page through transfers in a stable order
 }
 
 type TokenTransferInput struct {
 	TokenTransfer
-	Message        *MessageInOut  `ffstruct:"TokenTransferInput" json:"message,omitempty"`
-	Pool           string         `ffstruct:"TokenTransferInput" json:"pool,omitempty"`
-	IdempotencyKey IdempotencyKey `ffstruct:"TokenTransferInput" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
+	Message        *MessageInOut         `ffstruct:"TokenTransferInput" json:"message,omitempty"`
+	Pool           string                `ffstruct:"TokenTransferInput" json:"pool,omitempty"`
+	IdempotencyKey IdempotencyKey        `ffstruct:"TokenTransferInput" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
+	TxLabels       fftypes.FFStringArray `ffstruct:"TokenTransferInput" json:"txlabels,omitempty" ffexcludeoutput:"true"`
 }
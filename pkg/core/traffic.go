@@ -0,0 +1,71 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// TrafficDirection is whether traffic was sent to, or received from, a counterparty
+type TrafficDirection = fftypes.FFEnum
+
+var (
+	// TrafficDirectionSent is traffic from the local org to the counterparty
+	TrafficDirectionSent = fftypes.FFEnumValue("trafficdirection", "sent")
+	// TrafficDirectionReceived is traffic from the counterparty to the local org
+	TrafficDirectionReceived = fftypes.FFEnumValue("trafficdirection", "received")
+)
+
+// TrafficStats are the running totals of the traffic exchanged with one counterparty org, in one direction,
+// within an hourly bucket. They are added to as messages and token transfers are confirmed.
+type TrafficStats struct {
+	Namespace    string           `json:"namespace"`
+	Bucket       *fftypes.FFTime  `json:"bucket"`
+	Counterparty string           `json:"counterparty"`
+	Direction    TrafficDirection `json:"direction" ffenum:"trafficdirection"`
+	Messages     int64            `json:"messages"`
+	MessageBytes int64            `json:"messageBytes"`
+	Transfers    int64            `json:"transfers"`
+	Updated      *fftypes.FFTime  `json:"updated,omitempty"`
+}
+
+// TrafficTotals are the messages and token transfers exchanged in one direction over a time window
+type TrafficTotals struct {
+	Messages     int64 `ffstruct:"TrafficTotals" json:"messages"`
+	MessageBytes int64 `ffstruct:"TrafficTotals" json:"messageBytes"`
+	Transfers    int64 `ffstruct:"TrafficTotals" json:"transfers"`
+}
+
+// TrafficCounterparty is a row of the traffic matrix, for one counterparty org
+type TrafficCounterparty struct {
+	Counterparty string        `ffstruct:"TrafficCounterparty" json:"counterparty"`
+	Sent         TrafficTotals `ffstruct:"TrafficCounterparty" json:"sent"`
+	Received     TrafficTotals `ffstruct:"TrafficCounterparty" json:"received"`
+}
+
+// TrafficMatrix is the traffic exchanged between the local org and each counterparty org over a time window
+type TrafficMatrix struct {
+	Local          string                 `ffstruct:"TrafficMatrix" json:"local"`
+	StartTime      *fftypes.FFTime        `ffstruct:"TrafficMatrix" json:"startTime"`
+	EndTime        *fftypes.FFTime        `ffstruct:"TrafficMatrix" json:"endTime"`
+	Counterparties []*TrafficCounterparty `ffstruct:"TrafficMatrix" json:"counterparties"`
+}
+
+// Add includes the totals of one bucket of stats
+func (ts *TrafficTotals) Add(stats *TrafficStats) {
+	ts.Messages += stats.Messages
+	ts.MessageBytes += stats.MessageBytes
+	ts.Transfers += stats.Transfers
+}
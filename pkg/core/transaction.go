@@ -16,7 +16,11 @@
 
 package core
 
-import "github.com/hyperledger/firefly-common/pkg/fftypes"
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
 
 type TransactionType = fftypes.FFEnum
 
@@ -69,6 +73,9 @@ type Transaction struct {
 	Created        *fftypes.FFTime       `ffstruct:"Transaction" json:"created"`
 	IdempotencyKey IdempotencyKey        `ffstruct:"Transaction" json:"idempotencyKey,omitempty"`
 	BlockchainIDs  fftypes.FFStringArray `ffstruct:"Transaction" json:"blockchainIds,omitempty"`
+	Labels         fftypes.FFStringArray `ffstruct:"Transaction" json:"labels,omitempty"`
+	CorrelationID  string                `ffstruct:"Transaction" json:"correlationId,omitempty"`
+	Saga           *TransactionSaga      `ffstruct:"Transaction" json:"saga,omitempty"`
 }
 
 type TransactionStatusType string
@@ -101,6 +108,12 @@ func (tx *Transaction) Size() int64 {
 	return transactionBaseSizeEstimate // currently a static size assessment for caching
 }
 
+// ValidateTransactionLabels checks the labels a caller has asked to be attached to a new transaction.
+// Labels follow the same rules as topics, so they can be used safely in query filters.
+func ValidateTransactionLabels(ctx context.Context, fieldName string, labels fftypes.FFStringArray) error {
+	return labels.Validate(ctx, fieldName, true, fftypes.FFStringNameItemsMax)
+}
+
 func IsPinned(t TransactionType) bool {
 	return t.Equals(TransactionTypeBatchPin) || t.Equals(TransactionTypeContractInvokePin)
 }
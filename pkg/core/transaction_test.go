@@ -17,8 +17,11 @@
 package core
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,3 +33,13 @@ func TestIsPinned(t *testing.T) {
 	assert.True(t, IsPinned(TransactionTypeBatchPin))
 	assert.False(t, IsPinned(TransactionTypeUnpinned))
 }
+
+func TestValidateTransactionLabels(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, ValidateTransactionLabels(ctx, "labels", nil))
+	assert.NoError(t, ValidateTransactionLabels(ctx, "labels", fftypes.FFStringArray{"payroll", "quarter-end"}))
+	err := ValidateTransactionLabels(ctx, "labels", fftypes.FFStringArray{"payroll", "payroll"})
+	assert.Regexp(t, "FF00133", err)
+	err = ValidateTransactionLabels(ctx, "labels", fftypes.FFStringArray{"!bad"})
+	assert.Regexp(t, "FF00140", err)
+}
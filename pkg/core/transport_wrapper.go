@@ -27,6 +27,19 @@ var (
 
 // TransportWrapper wraps paylaods over data exchange transfers, for easy deserialization at target
 type TransportWrapper struct {
-	Group *Group `json:"group,omitempty"`
-	Batch *Batch `json:"batch,omitempty"`
+	Group *Group                `json:"group,omitempty"`
+	Batch *Batch                `json:"batch,omitempty"`
+	Ack   *BatchAckNotification `json:"ack,omitempty"`
+}
+
+// Namespace returns the network namespace of the batch, or acknowledgement, being carried
+func (tw *TransportWrapper) Namespace() string {
+	switch {
+	case tw.Batch != nil:
+		return tw.Batch.Namespace
+	case tw.Ack != nil:
+		return tw.Ack.Namespace
+	default:
+		return ""
+	}
 }
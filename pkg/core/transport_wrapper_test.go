@@ -53,3 +53,9 @@ func TestBatchManifest(t *testing.T) {
 	assert.Equal(t, tw.Batch.Payload.Data[1].Hash.String(), tm.Data[1].Hash.String())
 
 }
+
+func TestTransportWrapperNamespace(t *testing.T) {
+	assert.Equal(t, "ns1", (&TransportWrapper{Batch: &Batch{BatchHeader: BatchHeader{Namespace: "ns1"}}}).Namespace())
+	assert.Equal(t, "ns2", (&TransportWrapper{Ack: &BatchAckNotification{Namespace: "ns2"}}).Namespace())
+	assert.Equal(t, "", (&TransportWrapper{}).Namespace())
+}
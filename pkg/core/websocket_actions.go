@@ -26,12 +26,19 @@ var (
 	WSClientActionStart = fftypes.FFEnumValue("wstype", "start")
 	// WSClientActionAck acknowledges an event that was delivered, allowing further messages to be sent
 	WSClientActionAck = fftypes.FFEnumValue("wstype", "ack")
+	// WSClientActionWatch is a request to the server to push every change to specific operations, or to the operations of specific transactions
+	WSClientActionWatch = fftypes.FFEnumValue("wstype", "watch")
+	// WSClientActionUnwatch stops the updates requested by an earlier watch
+	WSClientActionUnwatch = fftypes.FFEnumValue("wstype", "unwatch")
 
 	// WSProtocolErrorEventType is a special event "type" field for server to send the client, if it performs a ProtocolError
 	WSProtocolErrorEventType = fftypes.FFEnumValue("wstype", "protocol_error")
 
 	// WSEventBatchType is the type set when the message contains an array of events
 	WSEventBatchType = fftypes.FFEnumValue("wstype", "event_batch")
+
+	// WSOperationUpdateType is the type set when the message contains the latest state of a watched operation
+	WSOperationUpdateType = fftypes.FFEnumValue("wstype", "operation_update")
 )
 
 // WSActionBase is the base fields of all client actions sent on the websocket
@@ -59,6 +66,16 @@ type WSAck struct {
 	Subscription *SubscriptionRef `ffstruct:"WSAck" json:"subscription,omitempty"`
 }
 
+// WSWatch starts (or stops) pushing the state of operations on this socket, each time they are created or updated.
+// Operations can be watched individually, or for all the operations of a transaction.
+type WSWatch struct {
+	WSActionBase
+
+	Namespace    string          `ffstruct:"WSWatch" json:"namespace"`
+	Operations   []*fftypes.UUID `ffstruct:"WSWatch" json:"operations,omitempty"`
+	Transactions []*fftypes.UUID `ffstruct:"WSWatch" json:"transactions,omitempty"`
+}
+
 // WSError is sent to the client by the server in the case of a protocol error
 type WSError struct {
 	Type  WSClientPayloadType `ffstruct:"WSAck" json:"type" ffenum:"wstype"`
@@ -73,3 +90,10 @@ type WSEventBatch struct {
 	Subscription SubscriptionRef     `ffstruct:"WSEventBatch" json:"subscription"`
 	Events       []*EventDelivery    `ffstruct:"WSEventBatch" json:"events"`
 }
+
+// WSOperationUpdate is sent to the client with the latest state of a watched operation, including the status
+// and any output (such as a receipt) reported by the connector
+type WSOperationUpdate struct {
+	Type      WSClientPayloadType `ffstruct:"WSOperationUpdate" json:"type" ffenum:"wstype"`
+	Operation *Operation          `ffstruct:"WSOperationUpdate" json:"operation"`
+}
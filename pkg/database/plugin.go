@@ -106,11 +106,23 @@ type iMessageCollection interface {
 	// GetMessagesForData - List messages where there is a data reference to the specified ID
 	GetMessagesForData(ctx context.Context, namespace string, dataID *fftypes.UUID, filter ffapi.Filter) (message []*core.Message, res *ffapi.FilterResult, err error)
 
+	// GetMessagesForDataHash - List messages where there is a data reference with the specified hash
+	GetMessagesForDataHash(ctx context.Context, namespace string, dataHash *fftypes.Bytes32, filter ffapi.Filter) (message []*core.Message, res *ffapi.FilterResult, err error)
+
+	// GetMessagesForBlobHash - List messages where there is a data reference to data with a blob of the specified hash
+	GetMessagesForBlobHash(ctx context.Context, namespace string, blobHash *fftypes.Bytes32, filter ffapi.Filter) (message []*core.Message, res *ffapi.FilterResult, err error)
+
 	// GetBatchIDsForMessages - an optimized query to retrieve any non-null batch IDs for a list of message IDs
 	GetBatchIDsForMessages(ctx context.Context, namespace string, msgIDs []*fftypes.UUID) (batchIDs []*fftypes.UUID, err error)
 
 	// GetBatchIDsForDataAttachments - an optimized query to retrieve any non-null batch IDs for a list of data IDs that might be attached to messages in batches
 	GetBatchIDsForDataAttachments(ctx context.Context, namespace string, dataIDs []*fftypes.UUID) (batchIDs []*fftypes.UUID, err error)
+
+	// GetBatchIDsForDataHash - an optimized query to retrieve any non-null batch IDs for messages with a data reference of the specified hash
+	GetBatchIDsForDataHash(ctx context.Context, namespace string, dataHash *fftypes.Bytes32) (batchIDs []*fftypes.UUID, err error)
+
+	// GetBatchIDsForBlobHash - an optimized query to retrieve any non-null batch IDs for messages with a data reference to a blob of the specified hash
+	GetBatchIDsForBlobHash(ctx context.Context, namespace string, blobHash *fftypes.Bytes32) (batchIDs []*fftypes.UUID, err error)
 }
 
 type iDataCollection interface {
@@ -156,6 +168,14 @@ type iBatchCollection interface {
 	GetBatches(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.BatchPersisted, res *ffapi.FilterResult, err error)
 }
 
+type iBatchAckCollection interface {
+	// UpsertBatchAck - Record the state a recipient has reached with a batch, unless it is already known to have got further
+	UpsertBatchAck(ctx context.Context, ack *core.BatchAck) (err error)
+
+	// GetBatchAcks - Get the acknowledgements recorded for batches
+	GetBatchAcks(ctx context.Context, namespace string, filter ffapi.Filter) (acks []*core.BatchAck, res *ffapi.FilterResult, err error)
+}
+
 type iTransactionCollection interface {
 	// InsertTransaction - Insert a new transaction
 	InsertTransaction(ctx context.Context, txn *core.Transaction) (err error)
@@ -166,6 +186,15 @@ type iTransactionCollection interface {
 	// UpdateTransaction - Update transaction
 	UpdateTransaction(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) (err error)
 
+	// UpdateTransactionSaga - Replace the saga steps and state recorded on a transaction
+	UpdateTransactionSaga(ctx context.Context, namespace string, id *fftypes.UUID, saga *core.TransactionSaga) (err error)
+
+	// ClearIdempotencyKey - Release an idempotency key from the transaction and messages it was used on, so it can be submitted again
+	ClearIdempotencyKey(ctx context.Context, namespace string, key core.IdempotencyKey) (cleared []*fftypes.UUID, err error)
+
+	// ExpireIdempotencyKeys - Release all idempotency keys used on transactions and messages created before the supplied time
+	ExpireIdempotencyKeys(ctx context.Context, namespace string, createdBefore *fftypes.FFTime) (cleared []*fftypes.UUID, err error)
+
 	// GetTransactionByID - Get a transaction by ID
 	GetTransactionByID(ctx context.Context, namespace string, id *fftypes.UUID) (txn *core.Transaction, err error)
 
@@ -216,6 +245,9 @@ type iPinCollection interface {
 
 	// UpdatePins - Updates pins
 	UpdatePins(ctx context.Context, namespace string, filter ffapi.Filter, update ffapi.Update) (err error)
+
+	// DeleteBatchPins - Remove all the pins of a batch, such as when the pinning transaction is lost in a re-org
+	DeleteBatchPins(ctx context.Context, namespace string, batchID *fftypes.UUID) (err error)
 }
 
 type iOperationCollection interface {
@@ -235,6 +267,129 @@ type iOperationCollection interface {
 	GetOperations(ctx context.Context, namespace string, filter ffapi.Filter) (operation []*core.Operation, res *ffapi.FilterResult, err error)
 }
 
+type iOperationHistoryCollection interface {
+	// InsertOperationHistory - Record a status update applied to an operation
+	InsertOperationHistory(ctx context.Context, entry *core.OperationHistoryEntry) (err error)
+
+	// GetOperationHistory - Get the status updates applied to operations
+	GetOperationHistory(ctx context.Context, namespace string, filter ffapi.Filter) (entries []*core.OperationHistoryEntry, res *ffapi.FilterResult, err error)
+}
+
+type iOperationFeeCollection interface {
+	// UpsertOperationFee - Record the fee paid for an operation, replacing any previous record for the same operation
+	UpsertOperationFee(ctx context.Context, fee *core.OperationFee) (err error)
+
+	// GetOperationFees - Get the fees recorded for operations
+	GetOperationFees(ctx context.Context, namespace string, filter ffapi.Filter) (fees []*core.OperationFee, res *ffapi.FilterResult, err error)
+
+	// GetOperationFeeSummary - Total the fees matching the filter by signing key and UTC day
+	GetOperationFeeSummary(ctx context.Context, namespace string, filter ffapi.Filter) (summaries []*core.FeeSummary, err error)
+}
+
+type iOperationApprovalCollection interface {
+	// InsertOperationApproval - Record a request for approval of an operation
+	InsertOperationApproval(ctx context.Context, approval *core.OperationApproval) (err error)
+
+	// UpdateOperationApproval - Update an operation approval, if it matches the filter
+	UpdateOperationApproval(ctx context.Context, namespace string, id *fftypes.UUID, filter ffapi.Filter, update ffapi.Update) (updated bool, err error)
+
+	// GetOperationApprovalByID - Get an operation approval by ID
+	GetOperationApprovalByID(ctx context.Context, namespace string, id *fftypes.UUID) (approval *core.OperationApproval, err error)
+
+	// GetOperationApprovals - Get operation approvals
+	GetOperationApprovals(ctx context.Context, namespace string, filter ffapi.Filter) (approvals []*core.OperationApproval, res *ffapi.FilterResult, err error)
+}
+
+type iMessageRetentionPolicyCollection interface {
+	// InsertMessageRetentionPolicy - Create a message retention policy
+	InsertMessageRetentionPolicy(ctx context.Context, policy *core.MessageRetentionPolicy) (err error)
+
+	// UpdateMessageRetentionPolicy - Update a message retention policy
+	UpdateMessageRetentionPolicy(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) (err error)
+
+	// GetMessageRetentionPolicyByID - Get a message retention policy by ID
+	GetMessageRetentionPolicyByID(ctx context.Context, namespace string, id *fftypes.UUID) (policy *core.MessageRetentionPolicy, err error)
+
+	// GetMessageRetentionPolicies - Get message retention policies
+	GetMessageRetentionPolicies(ctx context.Context, namespace string, filter ffapi.Filter) (policies []*core.MessageRetentionPolicy, res *ffapi.FilterResult, err error)
+
+	// DeleteMessageRetentionPolicy - Delete a message retention policy
+	DeleteMessageRetentionPolicy(ctx context.Context, namespace string, id *fftypes.UUID) (err error)
+}
+
+type iRetentionCollection interface {
+	// CountPrunableRecords - Count the records that would be deleted by a retention policy
+	CountPrunableRecords(ctx context.Context, namespace string, policy *RetentionPolicy) (count int64, err error)
+
+	// PruneRecords - Delete up to limit records matching a retention policy, oldest first, returning the number deleted
+	PruneRecords(ctx context.Context, namespace string, policy *RetentionPolicy, limit int) (deleted int64, err error)
+}
+
+type iArchiveCollection interface {
+	// GetArchiveRows - Read the raw rows of a collection in sequence order, for export to an archive
+	GetArchiveRows(ctx context.Context, namespace string, collection CollectionName, afterSequence int64, createdBefore *fftypes.FFTime, limit int) (rows *ArchiveRows, err error)
+}
+
+type iOnlineMigrationCollection interface {
+	// GetOnlineMigrations - List the online migrations defined for the database, with their progress
+	GetOnlineMigrations(ctx context.Context) (migrations []*core.OnlineMigration, err error)
+
+	// RunOnlineMigrationStep - Create the new table of an online migration, or copy the next batch of rows or changed rows into it
+	RunOnlineMigrationStep(ctx context.Context, name string) (migration *core.OnlineMigration, err error)
+
+	// SwapOnlineMigration - Copy any remaining rows and changes, and swap the new table into place. Writers to the table are blocked until it completes
+	SwapOnlineMigration(ctx context.Context, name string) (migration *core.OnlineMigration, err error)
+}
+
+type iSearchCollection interface {
+	// InsertSearchDocuments - Index messages for full-text search. Messages that are already indexed are skipped
+	InsertSearchDocuments(ctx context.Context, docs []*core.SearchDocument) (err error)
+
+	// SearchDocuments - Find the indexed messages that match a full-text query, most relevant first
+	SearchDocuments(ctx context.Context, namespace, query string, limit int) (hits []*SearchHit, err error)
+}
+
+type iTrafficStatsCollection interface {
+	// AddTrafficStats - Add to the running totals of traffic for each bucket, counterparty and direction
+	AddTrafficStats(ctx context.Context, stats []*core.TrafficStats) (err error)
+
+	// GetTrafficStats - Get the running totals of traffic
+	GetTrafficStats(ctx context.Context, namespace string, filter ffapi.Filter) (stats []*core.TrafficStats, res *ffapi.FilterResult, err error)
+}
+
+type iAuditCollection interface {
+	// InsertAuditRecord - Append a record to the audit log
+	InsertAuditRecord(ctx context.Context, record *core.AuditRecord) (err error)
+
+	// GetAuditRecords - Get records from the audit log
+	GetAuditRecords(ctx context.Context, namespace string, filter ffapi.Filter) (records []*core.AuditRecord, res *ffapi.FilterResult, err error)
+}
+
+type iAuditAnchorCollection interface {
+	// InsertAuditAnchor - Record a Merkle commitment over a range of the history of a namespace
+	InsertAuditAnchor(ctx context.Context, anchor *core.AuditAnchor) (err error)
+
+	// GetAuditAnchorByID - Get an audit anchor by ID
+	GetAuditAnchorByID(ctx context.Context, namespace string, id *fftypes.UUID) (anchor *core.AuditAnchor, err error)
+
+	// GetAuditAnchors - Get audit anchors
+	GetAuditAnchors(ctx context.Context, namespace string, filter ffapi.Filter) (anchors []*core.AuditAnchor, res *ffapi.FilterResult, err error)
+}
+
+type iDisclosureCollection interface {
+	// InsertDisclosure - Record a zero-knowledge proof about the private data of a message
+	InsertDisclosure(ctx context.Context, disclosure *core.Disclosure) (err error)
+
+	// UpdateDisclosure - Update the local verification status of a disclosure
+	UpdateDisclosure(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) (err error)
+
+	// GetDisclosureByID - Get a disclosure by ID
+	GetDisclosureByID(ctx context.Context, namespace string, id *fftypes.UUID) (disclosure *core.Disclosure, err error)
+
+	// GetDisclosures - Get disclosures
+	GetDisclosures(ctx context.Context, namespace string, filter ffapi.Filter) (disclosures []*core.Disclosure, res *ffapi.FilterResult, err error)
+}
+
 type iSubscriptionCollection interface {
 	// UpsertSubscription - Upsert a subscription
 	UpsertSubscription(ctx context.Context, data *core.Subscription, allowExisting bool) (err error)
@@ -346,6 +501,44 @@ type iNextPinCollection interface {
 	UpdateNextPin(ctx context.Context, namespace string, sequence int64, update ffapi.Update) (err error)
 }
 
+type iDXQueueCollection interface {
+	// InsertDXQueueEntry - Queue an outbound data exchange send for dispatch
+	InsertDXQueueEntry(ctx context.Context, entry *core.DXQueueEntry) (err error)
+
+	// GetDXQueueEntries - Get the next queued sends, highest priority first, then in the order they were queued
+	GetDXQueueEntries(ctx context.Context, namespace string, limit int) (entries []*core.DXQueueEntry, err error)
+
+	// DeleteDXQueueEntry - Remove a queued send once it has been dispatched
+	DeleteDXQueueEntry(ctx context.Context, namespace string, sequence int64) (err error)
+}
+
+type iConfirmationQueueCollection interface {
+	// InsertConfirmationQueueEntry - Hold a blockchain event until it has the required number of confirmations
+	InsertConfirmationQueueEntry(ctx context.Context, entry *core.ConfirmationQueueEntry) (err error)
+
+	// GetConfirmationQueueEntries - Get all the events held for confirmation, in the order they were received
+	GetConfirmationQueueEntries(ctx context.Context, namespace string) (entries []*core.ConfirmationQueueEntry, err error)
+
+	// DeleteConfirmationQueueEntry - Remove a held event once it is confirmed, or rolled back by a re-org
+	DeleteConfirmationQueueEntry(ctx context.Context, namespace string, sequence int64) (err error)
+}
+
+type iSnapshotVerificationCollection interface {
+	// UpsertSnapshotVerification - Store the verification state of the snapshot last imported into a namespace
+	UpsertSnapshotVerification(ctx context.Context, namespace string, state *core.SnapshotVerificationState) (err error)
+
+	// GetSnapshotVerification - Get the verification state of the snapshot last imported into a namespace, or nil if none
+	GetSnapshotVerification(ctx context.Context, namespace string) (state *core.SnapshotVerificationState, err error)
+}
+
+type iNamespaceQuotasCollection interface {
+	// UpsertNamespaceQuotas - Store the quota limits set for a namespace, replacing any previous limits
+	UpsertNamespaceQuotas(ctx context.Context, namespace string, limits *core.NamespaceQuotas) (err error)
+
+	// GetNamespaceQuotas - Get the quota limits stored for a namespace, or nil if none have been set
+	GetNamespaceQuotas(ctx context.Context, namespace string) (limits *core.NamespaceQuotas, err error)
+}
+
 type iBlobCollection interface {
 	// InsertBlob - insert a blob
 	InsertBlob(ctx context.Context, blob *core.Blob) (err error)
@@ -356,6 +549,9 @@ type iBlobCollection interface {
 	// GetBlobs - get blobs
 	GetBlobs(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.Blob, res *ffapi.FilterResult, err error)
 
+	// GetBlobsTotalSize - get the total size in bytes of all the blobs in a namespace
+	GetBlobsTotalSize(ctx context.Context, namespace string) (total int64, err error)
+
 	// DeleteBlob - delete a blob, using its local database ID
 	DeleteBlob(ctx context.Context, sequence int64) (err error)
 }
@@ -420,6 +616,9 @@ type iTokenTransferCollection interface {
 
 	// DeleteTokenTransfers - Delete token transfers from a particular pool
 	DeleteTokenTransfers(ctx context.Context, namespace string, poolID *fftypes.UUID) error
+
+	// DeleteTokenTransfer - Delete a single token transfer, such as when its blockchain event is lost in a re-org
+	DeleteTokenTransfer(ctx context.Context, namespace string, localID *fftypes.UUID) error
 }
 
 type iTokenApprovalCollection interface {
@@ -563,6 +762,17 @@ type iBlockchainEventCollection interface {
 
 	// GetBlockchainEvents - get blockchain events
 	GetBlockchainEvents(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.BlockchainEvent, *ffapi.FilterResult, error)
+
+	// DeleteBlockchainEvent - delete a blockchain event that was removed from the chain by a re-org
+	DeleteBlockchainEvent(ctx context.Context, namespace string, id *fftypes.UUID) error
+}
+
+type iBlockchainReorgCollection interface {
+	// InsertBlockchainReorg - Record a chain re-organization, and the state that was reverted because of it
+	InsertBlockchainReorg(ctx context.Context, reorg *core.BlockchainReorg) (err error)
+
+	// GetBlockchainReorgByID - Get a blockchain re-org record by ID
+	GetBlockchainReorgByID(ctx context.Context, namespace string, id *fftypes.UUID) (reorg *core.BlockchainReorg, err error)
 }
 
 // PersistenceInterface are the operations that must be implemented by a database interface plugin.
@@ -609,11 +819,24 @@ type PersistenceInterface interface {
 	iMessageCollection
 	iDataCollection
 	iBatchCollection
+	iBatchAckCollection
 	iTransactionCollection
 	iDatatypeCollection
 	iOffsetCollection
 	iPinCollection
 	iOperationCollection
+	iOperationHistoryCollection
+	iOperationFeeCollection
+	iOperationApprovalCollection
+	iMessageRetentionPolicyCollection
+	iRetentionCollection
+	iArchiveCollection
+	iOnlineMigrationCollection
+	iSearchCollection
+	iTrafficStatsCollection
+	iAuditCollection
+	iAuditAnchorCollection
+	iDisclosureCollection
 	iSubscriptionCollection
 	iEventCollection
 	iIdentitiesCollection
@@ -621,6 +844,10 @@ type PersistenceInterface interface {
 	iGroupCollection
 	iNonceCollection
 	iNextPinCollection
+	iDXQueueCollection
+	iConfirmationQueueCollection
+	iSnapshotVerificationCollection
+	iNamespaceQuotasCollection
 	iBlobCollection
 	iTokenPoolCollection
 	iTokenBalanceCollection
@@ -633,6 +860,7 @@ type PersistenceInterface interface {
 	iContractAPICollection
 	iContractListenerCollection
 	iBlockchainEventCollection
+	iBlockchainReorgCollection
 	iChartCollection
 }
 
@@ -706,6 +934,38 @@ const (
 	CollectionTokenBalances OtherCollection = "tokenbalances"
 )
 
+// PrunableCollection is a collection that old records can be deleted from by a retention policy
+type PrunableCollection CollectionName
+
+const (
+	PrunableEvents     PrunableCollection = PrunableCollection(CollectionEvents)
+	PrunableOperations PrunableCollection = PrunableCollection(CollectionOperations)
+	PrunablePins       PrunableCollection = PrunableCollection(CollectionPins)
+)
+
+// RetentionPolicy selects the records of a collection that are old enough to be deleted.
+// Only events, operations that have succeeded (or failed and been retried)
+// and pins that have been dispatched are eligible.
+type RetentionPolicy struct {
+	Collection PrunableCollection
+	Before     *fftypes.FFTime
+	// MaxSequence if set, records with a higher sequence are kept regardless of age
+	MaxSequence *int64
+}
+
+// ArchiveRows is a set of raw database rows read for export to an archive, with every value rendered as a string
+type ArchiveRows struct {
+	Columns   []string
+	Rows      [][]string
+	Sequences []int64
+}
+
+// SearchHit is a message matching a full-text search query, with its relevance score
+type SearchHit struct {
+	Message *fftypes.UUID
+	Score   float64
+}
+
 // PostCompletionHook is a closure/function that will be called after a successful insertion.
 // This includes where the insert is nested in a RunAsGroup, and the database is transactional.
 // These hooks are useful when triggering code that relies on the inserted database object being available.
@@ -743,28 +1003,30 @@ type Capabilities struct {
 
 // MessageQueryFactory filter fields for messages
 var MessageQueryFactory = &ffapi.QueryFields{
-	"id":             &ffapi.UUIDField{},
-	"cid":            &ffapi.UUIDField{},
-	"type":           &ffapi.StringField{},
-	"author":         &ffapi.StringField{},
-	"key":            &ffapi.StringField{},
-	"topics":         &ffapi.FFStringArrayField{},
-	"tag":            &ffapi.StringField{},
-	"group":          &ffapi.Bytes32Field{},
-	"created":        &ffapi.TimeField{},
-	"datahash":       &ffapi.Bytes32Field{},
-	"idempotencykey": &ffapi.StringField{},
-	"hash":           &ffapi.Bytes32Field{},
-	"pins":           &ffapi.FFStringArrayField{},
-	"state":          &ffapi.StringField{},
-	"confirmed":      &ffapi.TimeField{},
-	"rejectreason":   &ffapi.StringField{},
-	"sequence":       &ffapi.Int64Field{},
-	"txtype":         &ffapi.StringField{},
-	"batch":          &ffapi.UUIDField{},
-	"txid":           &ffapi.UUIDField{},
-	"txparent.type":  &ffapi.StringField{},
-	"txparent.id":    &ffapi.UUIDField{},
+	"id":               &ffapi.UUIDField{},
+	"cid":              &ffapi.UUIDField{},
+	"type":             &ffapi.StringField{},
+	"author":           &ffapi.StringField{},
+	"key":              &ffapi.StringField{},
+	"topics":           &ffapi.FFStringArrayField{},
+	"tag":              &ffapi.StringField{},
+	"contextqualifier": &ffapi.StringField{},
+	"group":            &ffapi.Bytes32Field{},
+	"created":          &ffapi.TimeField{},
+	"datahash":         &ffapi.Bytes32Field{},
+	"idempotencykey":   &ffapi.StringField{},
+	"hash":             &ffapi.Bytes32Field{},
+	"pins":             &ffapi.FFStringArrayField{},
+	"state":            &ffapi.StringField{},
+	"confirmed":        &ffapi.TimeField{},
+	"rejectreason":     &ffapi.StringField{},
+	"sequence":         &ffapi.Int64Field{},
+	"txtype":           &ffapi.StringField{},
+	"batch":            &ffapi.UUIDField{},
+	"txid":             &ffapi.UUIDField{},
+	"txparent.type":    &ffapi.StringField{},
+	"txparent.id":      &ffapi.UUIDField{},
+	"txlabels":         &ffapi.FFStringArrayField{},
 }
 
 // BatchQueryFactory filter fields for batches
@@ -781,6 +1043,16 @@ var BatchQueryFactory = &ffapi.QueryFields{
 	"tx.type":    &ffapi.StringField{},
 	"tx.id":      &ffapi.UUIDField{},
 	"node":       &ffapi.UUIDField{},
+	"sequence":   &ffapi.Int64Field{},
+}
+
+// BatchAckQueryFactory filter fields for batch acknowledgements
+var BatchAckQueryFactory = &ffapi.QueryFields{
+	"batch":   &ffapi.UUIDField{},
+	"node":    &ffapi.UUIDField{},
+	"state":   &ffapi.StringField{},
+	"created": &ffapi.TimeField{},
+	"updated": &ffapi.TimeField{},
 }
 
 // TransactionQueryFactory filter fields for transactions
@@ -790,6 +1062,8 @@ var TransactionQueryFactory = &ffapi.QueryFields{
 	"created":        &ffapi.TimeField{},
 	"idempotencykey": &ffapi.StringField{},
 	"blockchainids":  &ffapi.FFStringArrayField{},
+	"labels":         &ffapi.FFStringArrayField{},
+	"correlationid":  &ffapi.StringField{},
 }
 
 // DataQueryFactory filter fields for data
@@ -828,17 +1102,110 @@ var OffsetQueryFactory = &ffapi.QueryFields{
 
 // OperationQueryFactory filter fields for data operations
 var OperationQueryFactory = &ffapi.QueryFields{
-	"id":      &ffapi.UUIDField{},
-	"tx":      &ffapi.UUIDField{},
-	"type":    &ffapi.StringField{},
-	"status":  &ffapi.StringField{},
-	"error":   &ffapi.StringField{},
-	"plugin":  &ffapi.StringField{},
-	"input":   &ffapi.JSONField{},
-	"output":  &ffapi.JSONField{},
-	"created": &ffapi.TimeField{},
-	"updated": &ffapi.TimeField{},
-	"retry":   &ffapi.UUIDField{},
+	"id":            &ffapi.UUIDField{},
+	"tx":            &ffapi.UUIDField{},
+	"type":          &ffapi.StringField{},
+	"status":        &ffapi.StringField{},
+	"error":         &ffapi.StringField{},
+	"plugin":        &ffapi.StringField{},
+	"input":         &ffapi.JSONField{},
+	"output":        &ffapi.JSONField{},
+	"created":       &ffapi.TimeField{},
+	"updated":       &ffapi.TimeField{},
+	"retry":         &ffapi.UUIDField{},
+	"progress":      &ffapi.JSONField{},
+	"correlationid": &ffapi.StringField{},
+}
+
+// OperationHistoryQueryFactory filter fields for operation history entries
+var OperationHistoryQueryFactory = &ffapi.QueryFields{
+	"id":           &ffapi.UUIDField{},
+	"operation":    &ffapi.UUIDField{},
+	"tx":           &ffapi.UUIDField{},
+	"status":       &ffapi.StringField{},
+	"error":        &ffapi.StringField{},
+	"plugin":       &ffapi.StringField{},
+	"blockchainid": &ffapi.StringField{},
+	"receipt":      &ffapi.JSONField{},
+	"created":      &ffapi.TimeField{},
+}
+
+// OperationFeeQueryFactory filter fields for operation fees
+var OperationFeeQueryFactory = &ffapi.QueryFields{
+	"operation":    &ffapi.UUIDField{},
+	"tx":           &ffapi.UUIDField{},
+	"type":         &ffapi.StringField{},
+	"status":       &ffapi.StringField{},
+	"plugin":       &ffapi.StringField{},
+	"key":          &ffapi.StringField{},
+	"blockchainid": &ffapi.StringField{},
+	"created":      &ffapi.TimeField{},
+}
+
+// OperationApprovalQueryFactory filter fields for operation approvals
+var OperationApprovalQueryFactory = &ffapi.QueryFields{
+	"id":        &ffapi.UUIDField{},
+	"operation": &ffapi.UUIDField{},
+	"tx":        &ffapi.UUIDField{},
+	"type":      &ffapi.StringField{},
+	"status":    &ffapi.StringField{},
+	"required":  &ffapi.Int64Field{},
+	"approvers": &ffapi.FFStringArrayField{},
+	"created":   &ffapi.TimeField{},
+	"updated":   &ffapi.TimeField{},
+	"expires":   &ffapi.TimeField{},
+}
+
+// MessageRetentionPolicyQueryFactory filter fields for message retention policies
+var MessageRetentionPolicyQueryFactory = &ffapi.QueryFields{
+	"id":         &ffapi.UUIDField{},
+	"name":       &ffapi.StringField{},
+	"topic":      &ffapi.StringField{},
+	"tag":        &ffapi.StringField{},
+	"maxage":     &ffapi.StringField{},
+	"checkpoint": &ffapi.Int64Field{},
+	"created":    &ffapi.TimeField{},
+	"updated":    &ffapi.TimeField{},
+}
+
+// AuditQueryFactory filter fields for the audit log
+var AuditQueryFactory = &ffapi.QueryFields{
+	"sequence":       &ffapi.Int64Field{},
+	"id":             &ffapi.UUIDField{},
+	"user":           &ffapi.StringField{},
+	"source":         &ffapi.StringField{},
+	"method":         &ffapi.StringField{},
+	"path":           &ffapi.StringField{},
+	"route":          &ffapi.StringField{},
+	"idempotencykey": &ffapi.StringField{},
+	"status":         &ffapi.Int64Field{},
+	"latency":        &ffapi.Int64Field{},
+	"created":        &ffapi.TimeField{},
+	"hash":           &ffapi.Bytes32Field{},
+}
+
+// AuditAnchorQueryFactory filter fields for audit anchors
+var AuditAnchorQueryFactory = &ffapi.QueryFields{
+	"id":         &ffapi.UUIDField{},
+	"firstevent": &ffapi.Int64Field{},
+	"lastevent":  &ffapi.Int64Field{},
+	"leaves":     &ffapi.Int64Field{},
+	"root":       &ffapi.Bytes32Field{},
+	"tx.type":    &ffapi.StringField{},
+	"tx.id":      &ffapi.UUIDField{},
+	"created":    &ffapi.TimeField{},
+}
+
+// DisclosureQueryFactory filter fields for disclosures
+var DisclosureQueryFactory = &ffapi.QueryFields{
+	"id":                &ffapi.UUIDField{},
+	"message":           &ffapi.UUIDField{},
+	"messagehash":       &ffapi.Bytes32Field{},
+	"circuit":           &ffapi.StringField{},
+	"author":            &ffapi.StringField{},
+	"status":            &ffapi.StringField{},
+	"definitionmessage": &ffapi.UUIDField{},
+	"created":           &ffapi.TimeField{},
 }
 
 // SubscriptionQueryFactory filter fields for data subscriptions
@@ -907,6 +1274,8 @@ var GroupQueryFactory = &ffapi.QueryFields{
 	"description": &ffapi.StringField{},
 	"ledger":      &ffapi.UUIDField{},
 	"created":     &ffapi.TimeField{},
+	"displayname": &ffapi.StringField{},
+	"updated":     &ffapi.TimeField{},
 }
 
 // NonceQueryFactory filter fields for nonces
@@ -951,6 +1320,7 @@ var TokenPoolQueryFactory = &ffapi.QueryFields{
 	"interface":       &ffapi.UUIDField{},
 	"interfaceformat": &ffapi.StringField{},
 	"published":       &ffapi.BoolField{},
+	"sequence":        &ffapi.Int64Field{},
 }
 
 // TokenBalanceQueryFactory filter fields for token balances
@@ -995,6 +1365,7 @@ var TokenTransferQueryFactory = &ffapi.QueryFields{
 	"tx.id":           &ffapi.UUIDField{},
 	"blockchainevent": &ffapi.UUIDField{},
 	"type":            &ffapi.StringField{},
+	"sequence":        &ffapi.Int64Field{},
 }
 
 var TokenApprovalQueryFactory = &ffapi.QueryFields{
@@ -1087,3 +1458,11 @@ var ContractAPIQueryFactory = &ffapi.QueryFields{
 	"interface":   &ffapi.UUIDField{},
 	"published":   &ffapi.BoolField{},
 }
+
+// TrafficStatsQueryFactory filter fields for traffic stats
+var TrafficStatsQueryFactory = &ffapi.QueryFields{
+	"bucket":       &ffapi.TimeField{},
+	"counterparty": &ffapi.StringField{},
+	"direction":    &ffapi.StringField{},
+	"updated":      &ffapi.TimeField{},
+}
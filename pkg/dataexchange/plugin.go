@@ -85,6 +85,10 @@ type Plugin interface {
 	// This may be information loaded from the database at init, or received in flight while running
 	AddNode(ctx context.Context, networkNamespace, nodeName string, peer fftypes.JSONObject) (err error)
 
+	// UpdateNode refreshes the details of a node previously added, after its identity profile has changed.
+	// Any stale details held for the same peer are replaced in every namespace
+	UpdateNode(ctx context.Context, networkNamespace, nodeName string, peer fftypes.JSONObject) (err error)
+
 	// UploadBlob streams a blob to storage, and returns the hash to confirm the hash calculated in Core matches the hash calculated in the plugin
 	UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (payloadRef string, hash *fftypes.Bytes32, size int64, err error)
 
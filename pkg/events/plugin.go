@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/pkg/core"
 )
 
@@ -62,6 +63,10 @@ type Plugin interface {
 
 type SubscriptionMatcher func(core.SubscriptionRef) bool
 
+// OperationWatcher is passed the latest state of a watched operation, each time it changes.
+// Returning an error stops all the watches of the connection.
+type OperationWatcher func(op *core.Operation) error
+
 type Callbacks interface {
 
 	// RegisterConnection can be fired as often as required.
@@ -74,6 +79,14 @@ type Callbacks interface {
 	// EphemeralSubscription creates an ephemeral (non-durable) subscription, and associates it with a connection
 	EphemeralSubscription(connID, namespace string, filter *core.SubscriptionFilter, options *core.SubscriptionOptions) error
 
+	// WatchOperations adds operations, and transactions whose operations should all be watched, to the set watched
+	// by a connection. The current state of each operation is passed to the watcher straight away, then again every
+	// time the operation is updated, until the connection closes.
+	WatchOperations(connID string, operations, transactions []*fftypes.UUID, watcher OperationWatcher) error
+
+	// UnwatchOperations removes operations and transactions from the set watched by a connection
+	UnwatchOperations(connID string, operations, transactions []*fftypes.UUID)
+
 	// ConnectionClosed is a notification that a connection has closed, and all dispatchers should be re-allocated.
 	// Note the plugin must not crash if it receives PublishEvent calls on the connID after the ConnectionClosed event is fired
 	ConnectionClosed(connID string)
@@ -0,0 +1,66 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Plugin is the interface implemented by each policy plugin.
+//
+// Policy plugins are a policy decision point, consulted by the namespace before it accepts any
+// mutating API call. This allows enterprises to centralize authorization and data-governance rules
+// in an external engine, rather than configuring them in each FireFly node.
+type Plugin interface {
+	core.Named
+
+	// InitConfig initializes the set of configuration options that are valid, with defaults. Called on all plugins.
+	InitConfig(config config.Section)
+
+	// Init initializes the plugin, with configuration
+	Init(ctx context.Context, config config.Section) error
+
+	// Decide evaluates the policy for a request, returning whether it is allowed.
+	// An error means no decision could be reached.
+	Decide(ctx context.Context, req *Request) (*Decision, error)
+}
+
+// Request is the context of an API call that a policy decision is made on
+type Request struct {
+	Namespace string          `json:"namespace"`
+	Identity  string          `json:"identity,omitempty"` // the principal authenticated by the auth plugin, if any
+	Method    string          `json:"method"`
+	Route     string          `json:"route"`
+	Path      string          `json:"path"`
+	Payload   *PayloadSummary `json:"payload,omitempty"`
+}
+
+// PayloadSummary describes the body of a request, without passing the full (potentially large) data to the policy engine
+type PayloadSummary struct {
+	ContentType string                 `json:"contentType,omitempty"`
+	Size        int64                  `json:"size"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Decision is the outcome of evaluating the policy for a request
+type Decision struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,56 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Plugin is the interface implemented by each signing plugin.
+//
+// Signing plugins delegate signing to an external key management system (KMS), such as a cloud KMS or
+// a HSM, so the private keys used by FireFly for its own signatures (such as the payload signatures on
+// batches, EIP-712 typed data signatures, and issued verifiable credentials) are never held by FireFly,
+// or in the configuration of its connectors.
+type Plugin interface {
+	core.Named
+
+	// InitConfig initializes the set of configuration options that are valid, with defaults. Called on all plugins.
+	InitConfig(config config.Section)
+
+	// Init initializes the plugin, with configuration
+	Init(ctx context.Context, config config.Section) error
+
+	// Capabilities returns capabilities - not called until after Init
+	Capabilities() *Capabilities
+
+	// PublicKey returns the public key of a key in the KMS, as PKIX ASN.1 DER
+	PublicKey(ctx context.Context, keyID string) ([]byte, error)
+
+	// Sign signs a SHA-256 digest with a key in the KMS. ECDSA keys sign the digest as-is, so secp256k1 keys can
+	// also sign the Keccak-256 digest of EIP-712 typed data.
+	// ECDSA signatures are ASN.1 DER encoded, RSA signatures are PKCS #1 v1.5, and Ed25519 keys sign the digest as the message.
+	Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+}
+
+// Capabilities the supported featureset of the signing
+// interface implemented by the plugin, with the specified config
+type Capabilities struct {
+}
@@ -0,0 +1,55 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zkproof
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Plugin is the interface implemented by each zero-knowledge proof plugin.
+//
+// ZK proof plugins connect FireFly to a proof service, which generates proofs of statements about the
+// private data of a message (such as "amount < limit") without revealing the data. The proofs are broadcast
+// to the whole network, so members that do not hold the data can verify the statement.
+type Plugin interface {
+	core.Named
+
+	// InitConfig initializes the set of configuration options that are valid, with defaults. Called on all plugins.
+	InitConfig(config config.Section)
+
+	// Init initializes the plugin, with configuration
+	Init(ctx context.Context, config config.Section) error
+
+	// Capabilities returns capabilities - not called until after Init
+	Capabilities() *Capabilities
+
+	// GenerateProof proves the statement of a circuit, over the private inputs and the public inputs.
+	// The private inputs are passed only to the proof service, and are not part of the proof.
+	GenerateProof(ctx context.Context, circuit string, publicInputs, privateInputs *fftypes.JSONAny) (proof *fftypes.JSONAny, err error)
+
+	// VerifyProof checks a proof of the statement of a circuit, against the public inputs
+	VerifyProof(ctx context.Context, circuit string, publicInputs, proof *fftypes.JSONAny) (valid bool, err error)
+}
+
+// Capabilities the supported featureset of the ZK proof
+// interface implemented by the plugin, with the specified config
+type Capabilities struct {
+}